@@ -2,11 +2,24 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"os/exec"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/kaeawc/auto-worktree/internal/cmd"
+	"github.com/kaeawc/auto-worktree/internal/git"
+	"github.com/kaeawc/auto-worktree/internal/interrupt"
+	"github.com/kaeawc/auto-worktree/internal/logging"
+	"github.com/kaeawc/auto-worktree/internal/offline"
 	"github.com/kaeawc/auto-worktree/internal/perf"
+	"github.com/kaeawc/auto-worktree/internal/plugin"
+	"github.com/kaeawc/auto-worktree/internal/providers"
+	"github.com/kaeawc/auto-worktree/internal/ui"
 )
 
 const version = "0.1.0-dev"
@@ -21,14 +34,82 @@ func main() {
 
 	perf.Mark("process-start")
 
+	args, flags := extractGlobalFlags(os.Args[1:])
+	os.Args = append(os.Args[:1], args...)
+
+	cmd.ConfigureLogging(flags.verbose, flags.debug)
+	defer logging.Close()
+
+	if flags.offline {
+		offline.Enable("--offline")
+	}
+
+	// status --porcelain runs from shell prompts, so it skips the rest of startup
+	if len(args) >= 2 && args[0] == "status" && args[1] == "--porcelain" {
+		if err := cmd.RunStatus(args[1:]); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1) //nolint:gocritic // exitAfterDefer: intentional - error path exits immediately
+		}
+
+		return
+	}
+
+	// Ctrl-C stops the commands in flight so the operation can undo what it
+	// started, instead of killing auto-worktree halfway; a second one quits
+	stopInterrupts := interrupt.Watch()
+	defer stopInterrupts()
+
+	cmd.ApplyCommandLimits()
+	cmd.ApplyStoredCredentials()
+	cmd.ApplyRedaction()
+	cmd.ApplyProviderTransports()
+
+	cmd.EnableEventLog()
+
+	// Piped or redirected output gets plain lines instead of full-screen UI
+	ui.SetPlain(flags.plain || !ui.IsTerminal(os.Stdout))
+
+	cmd.Version = version
+
+	// Pick the repository to work in: --repo, or a choice when run outside of one
+	var repoErr error
+
+	switch {
+	case flags.host != "" && flags.repo != "":
+		repoErr = errors.New("--repo cannot be combined with --host; give the remote directory as --host <host>:<dir>")
+	case flags.host != "":
+		repoErr = cmd.UseHost(flags.host)
+	case flags.repo != "":
+		repoErr = cmd.UseRepository(flags.repo)
+	case commandNeedsRepository(os.Args[1:]):
+		repoErr = cmd.EnsureRepository()
+	}
+
+	if repoErr != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", repoErr)
+		os.Exit(1) //nolint:gocritic // exitAfterDefer: intentional - nothing has run yet
+	}
+
+	cmd.ApplyConfiguredTheme()
+	cmd.ApplyConfiguredKeybindings()
+
 	// Determine if we need startup cleanup based on command
 	// Skip cleanup for simple commands that don't interact with worktrees
 	needsCleanup := true
 
 	if len(os.Args) >= 2 {
 		switch os.Args[1] {
-		case "version", "--version", "-v", "help", "--help", "-h", "doctor", "health-check", "health", "repair", "monitor": //nolint:goconst
+		case "version", "--version", "-v", "help", "--help", "-h", "doctor", "health-check", "health", "repair", "monitor", "overview", "tour", "freeze", "thaw", "analytics", "state", "check", "update", "setup", "repos", "stats", "auth", "shell-init", "status", "plugins", "exec", "test", "checkpoints", "sessions", "maintain", "tidy", "cherry": //nolint:goconst
 			needsCleanup = false
+		case "resume":
+			// resume --all is not tied to the current repository
+			needsCleanup = commandNeedsRepository(os.Args[1:])
+		case "cleanup", "prune":
+			// Nothing may prompt or print before a --json report
+			needsCleanup = !slices.Contains(os.Args[2:], "--json")
+		default:
+			// Command plugins look after their own worktrees
+			needsCleanup = !isPluginCommand(os.Args[1])
 		}
 	}
 
@@ -37,7 +118,7 @@ func main() {
 		endCleanup := perf.StartSpanWithParent("startup-cleanup", "main")
 
 		if err := cmd.RunStartupCleanup(); err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: startup cleanup encountered an error: %v\n", err)
+			logging.Warn("startup cleanup encountered an error", "err", err)
 			// Don't exit on cleanup errors, continue to menu/command
 		}
 
@@ -49,7 +130,10 @@ func main() {
 	if len(os.Args) < 2 {
 		endMenu := perf.StartSpanWithParent("interactive-menu", "main")
 
-		if err := cmd.RunInteractiveMenu(); err != nil {
+		err := cmd.RunInteractiveMenu()
+		exitIfInterrupted()
+
+		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1) //nolint:gocritic // exitAfterDefer: intentional - error path exits immediately
 		}
@@ -61,14 +145,27 @@ func main() {
 
 	endCommand := perf.StartSpanWithParent("run-command", "main")
 
-	if err := runCommand(os.Args[1]); err != nil {
+	err := runCommand(os.Args[1])
+	exitIfInterrupted()
+
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1) //nolint:gocritic // exitAfterDefer: intentional - error path exits immediately
 	}
 
+	cmd.RecordUsage(os.Args[1])
+
 	endCommand()
 }
 
+// exitIfInterrupted exits quietly after Ctrl-C: the command has undone what
+// it could, and its errors are only those of the commands it stopped
+func exitIfInterrupted() {
+	if interrupt.Interrupted() {
+		os.Exit(interrupt.ExitCode)
+	}
+}
+
 func runCommand(command string) error {
 	switch command {
 	case "version", "--version", "-v":
@@ -80,13 +177,13 @@ func runCommand(command string) error {
 		return nil
 
 	case "list", "ls":
-		return cmd.RunList()
+		return runListCommand()
 
 	case "new", "create":
 		return cmd.RunNew(false)
 
 	case "resume":
-		return cmd.RunResume()
+		return runResumeCommand()
 
 	case "issue":
 		return runIssueCommand()
@@ -95,7 +192,7 @@ func runCommand(command string) error {
 		return runPRCommand()
 
 	case "cleanup":
-		return cmd.RunCleanup()
+		return runCleanupCommand()
 
 	case "settings":
 		return runSettingsCommand()
@@ -104,15 +201,127 @@ func runCommand(command string) error {
 		return runRemoveCommand()
 
 	case "prune":
-		return cmd.RunPrune()
+		return runPruneCommand()
+
+	case "overview":
+		return cmd.RunOverview()
+
+	case "tour":
+		return cmd.RunTour()
+
+	case "history":
+		return runHistoryCommand()
+
+	case "stats":
+		opts, err := parseStatsArgs(os.Args[2:])
+		if err != nil {
+			return err
+		}
+
+		return cmd.RunStats(opts)
+
+	case "redo":
+		return cmd.RunRedo()
+
+	case "dashboard", "dash":
+		return cmd.RunDashboard(parseIntervalFlag(os.Args[2:], cmd.DefaultDashboardInterval))
+
+	case "describe":
+		return runDescribeCommand()
+
+	case "feedback":
+		return cmd.RunFeedback()
+
+	case "ci":
+		return cmd.RunCIFix()
+
+	case "exec":
+		return runExecCommand()
+
+	case "test":
+		return runTestCommand()
+
+	case "pick":
+		return runPickCommand()
+
+	case "compare":
+		return runCompareCommand()
+
+	case "checkpoints":
+		return runCheckpointsCommand()
+
+	case "sessions":
+		return runSessionsCommand()
+
+	case "tidy":
+		return runTidyCommand()
+
+	case "cherry":
+		return runCherryCommand()
+
+	case "conflicts":
+		branch := ""
+		if len(os.Args) > 2 {
+			branch = os.Args[2]
+		}
+
+		return cmd.RunConflicts(branch)
+
+	case "analytics":
+		return runAnalyticsCommand()
+
+	case "state":
+		return runStateCommand()
+
+	case "update":
+		return runUpdateCommand()
+
+	case "setup":
+		return cmd.RunSetup()
+
+	case "repos":
+		return runReposCommand()
+
+	case "status":
+		return cmd.RunStatus(os.Args[2:])
+
+	case "shell-init":
+		shell := ""
+		if len(os.Args) > 2 {
+			shell = os.Args[2]
+		}
+
+		return cmd.RunShellInit(shell)
+
+	case "auth":
+		return runAuthCommand()
+
+	case "plugins":
+		return cmd.RunPlugins()
+
+	case "check":
+		return runCheckCommand()
+
+	case "freeze":
+		return cmd.RunFreeze(strings.Join(os.Args[2:], " "))
+
+	case "thaw":
+		return cmd.RunThaw()
 
 	case "doctor":
 		return runDoctorCommand()
 
+	case "maintain":
+		return runMaintainCommand()
+
 	case "health-check", "health", "repair", "monitor": //nolint:goconst
 		return runHealthCommand(command)
 
 	default:
+		if path, err := plugin.FindCommand(command); err == nil {
+			return runPluginCommand(path, os.Args[2:])
+		}
+
 		fmt.Fprintf(os.Stderr, "Unknown command: %s\n\n", command)
 		showHelp()
 		os.Exit(1)
@@ -122,21 +331,999 @@ func runCommand(command string) error {
 }
 
 func runIssueCommand() error {
-	issueID := ""
+	issueID, filters, err := parseIssueArgs(os.Args[2:])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n\n", err)
+		fmt.Fprintf(os.Stderr, "Usage: auto-worktree issue [id] [--label <name>] [--assignee me|unassigned|<user>]\n")
+		fmt.Fprintf(os.Stderr, "                            [--milestone <name>|current] [--search <text>] [--sort recent|priority] [--limit <n>]\n")
+		os.Exit(1)
+	}
+
+	return cmd.RunIssueWithFilters(issueID, filters)
+}
+
+func runResumeCommand() error {
+	for _, arg := range os.Args[2:] {
+		switch arg {
+		case "--all", "-a":
+			return cmd.RunResumeAll()
+		default:
+			return fmt.Errorf("unknown flag for resume: %s", arg)
+		}
+	}
+
+	return cmd.RunResume()
+}
+
+// commandNeedsRepository reports whether the command works on a repository,
+// so running it outside of one should offer to pick one
+func commandNeedsRepository(args []string) bool {
+	if len(args) == 0 {
+		return true
+	}
+
+	switch args[0] {
+	case "version", "--version", "-v", "help", "--help", "-h", "update", "repos", "analytics", "state", "tour", "auth", "shell-init", "plugins", "sessions":
+		return false
+	case "resume":
+		// resume --all restores sessions in every repository
+		return len(args) < 2 || (args[1] != "--all" && args[1] != "-a")
+	default:
+		// A command plugin may be run anywhere; it gets the repository if there is one
+		return !isPluginCommand(args[0])
+	}
+}
+
+// isPluginCommand reports whether command runs an auto-worktree-<command>
+// plugin rather than a built-in command
+func isPluginCommand(command string) bool {
+	_, err := plugin.FindCommand(command)
+	return err == nil
+}
+
+// runPluginCommand runs a command plugin and exits with its exit code
+func runPluginCommand(path string, args []string) error {
+	err := cmd.RunPluginCommand(path, args)
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		os.Exit(exitErr.ExitCode())
+	}
+
+	return err
+}
+
+// globalFlags are the flags accepted by every command
+type globalFlags struct {
+	plain   bool
+	verbose bool
+	debug   bool
+	// offline skips provider, AI and download calls
+	offline bool
+	// repo is the registered repository to run in instead of the current directory
+	repo string
+	// host is the SSH destination ("host" or "host:dir") to run git, tmux and AI tools on
+	host string
+}
+
+// extractGlobalFlags removes the global flags from the arguments.
+// Arguments after "--" are left alone so they can be passed through to other tools.
+func extractGlobalFlags(args []string) ([]string, globalFlags) {
+	rest := make([]string, 0, len(args))
+	flags := globalFlags{}
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+
+		switch {
+		case arg == "--":
+			return append(rest, args[i:]...), flags
+		case arg == "--plain":
+			flags.plain = true
+		case arg == "--verbose":
+			flags.verbose = true
+		case arg == "--debug":
+			flags.debug = true
+		case arg == "--offline":
+			flags.offline = true
+		case arg == "--repo" && i+1 < len(args):
+			i++
+			flags.repo = args[i]
+		case strings.HasPrefix(arg, "--repo="):
+			flags.repo = strings.TrimPrefix(arg, "--repo=")
+		case arg == "--host" && i+1 < len(args):
+			i++
+			flags.host = args[i]
+		case strings.HasPrefix(arg, "--host="):
+			flags.host = strings.TrimPrefix(arg, "--host=")
+		default:
+			rest = append(rest, arg)
+		}
+	}
+
+	return rest, flags
+}
+
+// parseIssueArgs parses the issue ID and selector filter flags
+func parseIssueArgs(args []string) (string, providers.ListIssuesOptions, error) {
+	var issueID string
+
+	var filters providers.ListIssuesOptions
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+
+		if !strings.HasPrefix(arg, "-") {
+			if issueID != "" {
+				return "", filters, fmt.Errorf("unexpected argument: %s", arg)
+			}
+
+			issueID = arg
+
+			continue
+		}
+
+		if i+1 >= len(args) {
+			return "", filters, fmt.Errorf("flag %s requires a value", arg)
+		}
+
+		i++
+		value := args[i]
+
+		switch arg {
+		case "--label", "-l":
+			for _, label := range strings.Split(value, ",") {
+				if label = strings.TrimSpace(label); label != "" {
+					filters.Labels = append(filters.Labels, label)
+				}
+			}
+		case "--assignee", "-a":
+			filters.Assignee = value
+		case "--milestone", "-m":
+			filters.Milestone = value
+		case "--search", "-s":
+			filters.Search = value
+		case "--sort":
+			if value != providers.SortRecent && value != providers.SortPriority {
+				return "", filters, fmt.Errorf("invalid sort: %s (must be %s or %s)", value, providers.SortRecent, providers.SortPriority)
+			}
+
+			filters.Sort = value
+		case "--limit", "-n":
+			limit, err := strconv.Atoi(value)
+			if err != nil || limit <= 0 {
+				return "", filters, fmt.Errorf("invalid limit: %s", value)
+			}
+
+			filters.Limit = limit
+		default:
+			return "", filters, fmt.Errorf("unknown flag: %s", arg)
+		}
+	}
+
+	return issueID, filters, nil
+}
+
+func runDescribeCommand() error {
+	opts, err := parseDescribeArgs(os.Args[2:])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n\n", err)
+		fmt.Fprintf(os.Stderr, "Usage: auto-worktree describe [--commit | --pr] [--apply]\n")
+		os.Exit(1)
+	}
+
+	return cmd.RunDescribe(opts)
+}
+
+// runCheckCommand runs hygiene assertions and exits 0 when they pass,
+// 1 when any fail, and 2 on usage or runtime errors
+func runCheckCommand() error {
+	opts, err := parseCheckArgs(os.Args[2:])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n\n", err)
+		fmt.Fprintf(os.Stderr, "Usage: auto-worktree check [--max-age <days>] [--no-unpushed-merged] "+
+			"[--no-failed-sessions] [--max-disk <GB>] [--json]\n")
+		os.Exit(2)
+	}
+
+	err = cmd.RunCheck(opts)
+
+	switch {
+	case errors.Is(err, cmd.ErrChecksFailed):
+		os.Exit(1)
+	case err != nil:
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(2)
+	}
+
+	return nil
+}
+
+func runExecCommand() error {
+	opts, err := parseExecArgs(os.Args[2:])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n\n", err)
+		fmt.Fprintf(os.Stderr, "Usage: auto-worktree exec [--all | <branch>...] [--parallel] -- <command> [args...]\n")
+		os.Exit(2)
+	}
+
+	err = cmd.RunExec(opts)
+
+	var exitErr *exec.ExitError
+
+	switch {
+	case errors.Is(err, cmd.ErrExecFailed) && errors.As(err, &exitErr):
+		// Run in one worktree: exit as the command did
+		os.Exit(exitErr.ExitCode())
+	case errors.Is(err, cmd.ErrExecFailed):
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	return err
+}
+
+// parseExecArgs parses the worktree selection before "--" and the command after it
+func parseExecArgs(args []string) (cmd.ExecOptions, error) {
+	var opts cmd.ExecOptions
+
+	for i, arg := range args {
+		switch {
+		case arg == "--":
+			opts.Command = args[i+1:]
+			if len(opts.Command) == 0 {
+				return opts, fmt.Errorf("no command after --")
+			}
+
+			if opts.All && len(opts.Branches) > 0 {
+				return opts, fmt.Errorf("--all runs in every worktree; don't also name branches")
+			}
+
+			return opts, nil
+		case arg == "--all" || arg == "-a":
+			opts.All = true
+		case arg == "--parallel" || arg == "-p":
+			opts.Parallel = true
+		case strings.HasPrefix(arg, "-"):
+			return opts, fmt.Errorf("unknown flag for exec: %s", arg)
+		default:
+			opts.Branches = append(opts.Branches, arg)
+		}
+	}
+
+	return opts, fmt.Errorf("separate the command with --, e.g. exec --all -- git fetch")
+}
+
+func runTestCommand() error {
+	opts, err := parseTestArgs(os.Args[2:])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n\n", err)
+		fmt.Fprintf(os.Stderr, "Usage: auto-worktree test [--all | <branch>...]\n")
+		os.Exit(2)
+	}
+
+	err = cmd.RunTest(opts)
+
+	var exitErr *exec.ExitError
+
+	switch {
+	case errors.Is(err, cmd.ErrExecFailed) && errors.As(err, &exitErr):
+		os.Exit(exitErr.ExitCode())
+	case errors.Is(err, cmd.ErrExecFailed):
+		os.Exit(1)
+	}
+
+	return err
+}
+
+// parseTestArgs parses the worktrees to test
+func parseTestArgs(args []string) (cmd.TestOptions, error) {
+	var opts cmd.TestOptions
+
+	for _, arg := range args {
+		switch {
+		case arg == "--all" || arg == "-a":
+			opts.All = true
+		case strings.HasPrefix(arg, "-"):
+			return opts, fmt.Errorf("unknown flag for test: %s", arg)
+		default:
+			opts.Branches = append(opts.Branches, arg)
+		}
+	}
+
+	if opts.All && len(opts.Branches) > 0 {
+		return opts, fmt.Errorf("--all tests every worktree; don't also name branches")
+	}
+
+	return opts, nil
+}
+
+func runPickCommand() error {
+	opts, err := parsePickArgs(os.Args[2:])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n\n", err)
+		fmt.Fprintf(os.Stderr, "Usage: auto-worktree pick <branch> [--no-pr] [--yes] [--force]\n")
+		os.Exit(2)
+	}
+
+	return cmd.RunPick(opts)
+}
+
+// parsePickArgs parses the winning branch and pick's flags
+func parsePickArgs(args []string) (cmd.PickOptions, error) {
+	var opts cmd.PickOptions
+
+	for _, arg := range args {
+		switch {
+		case arg == "--no-pr":
+			opts.NoPR = true
+		case arg == "--yes" || arg == "-y":
+			opts.Yes = true
+		case arg == "--force":
+			opts.Force = true
+		case strings.HasPrefix(arg, "-"):
+			return opts, fmt.Errorf("unknown flag for pick: %s", arg)
+		case opts.Branch != "":
+			return opts, fmt.Errorf("pick takes one branch")
+		default:
+			opts.Branch = arg
+		}
+	}
+
+	if opts.Branch == "" {
+		return opts, fmt.Errorf("name the branch to keep")
+	}
+
+	return opts, nil
+}
+
+func runCompareCommand() error {
+	opts, err := parseCompareArgs(os.Args[2:])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n\n", err)
+		fmt.Fprintf(os.Stderr, "Usage: auto-worktree compare <branch-a> <branch-b> [--ai]\n")
+		os.Exit(2)
+	}
+
+	return cmd.RunCompare(opts)
+}
+
+// parseCompareArgs parses the two branches to compare and --ai
+func parseCompareArgs(args []string) (cmd.CompareOptions, error) {
+	var (
+		opts     cmd.CompareOptions
+		branches []string
+	)
+
+	for _, arg := range args {
+		switch {
+		case arg == "--ai":
+			opts.AI = true
+		case strings.HasPrefix(arg, "-"):
+			return opts, fmt.Errorf("unknown flag for compare: %s", arg)
+		default:
+			branches = append(branches, arg)
+		}
+	}
+
+	if len(branches) != 2 {
+		return opts, fmt.Errorf("compare takes two branches")
+	}
+
+	opts.BranchA, opts.BranchB = branches[0], branches[1]
+
+	return opts, nil
+}
+
+func runSessionsCommand() error {
+	if len(os.Args) < 3 {
+		return cmd.RunSessions()
+	}
+
+	if os.Args[2] != "prune" {
+		fmt.Fprintf(os.Stderr, "Error: unknown sessions subcommand: %s\n\n", os.Args[2])
+		fmt.Fprintf(os.Stderr, "Usage: auto-worktree sessions [prune [--ended] [--dry-run]]\n")
+		os.Exit(2)
+	}
+
+	opts, err := parseSessionsPruneArgs(os.Args[3:])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n\n", err)
+		fmt.Fprintf(os.Stderr, "Usage: auto-worktree sessions prune [--ended] [--dry-run]\n")
+		os.Exit(2)
+	}
+
+	return cmd.RunSessionsPrune(opts)
+}
+
+func runCleanupCommand() error {
+	opts, err := parseCleanupArgs(os.Args[2:])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n\n", err)
+		fmt.Fprintf(os.Stderr, "Usage: auto-worktree cleanup [--json [--yes]]\n")
+		os.Exit(2)
+	}
+
+	return cmd.RunCleanup(opts)
+}
+
+// parseCleanupArgs parses --json and --yes for the cleanup command
+func parseCleanupArgs(args []string) (cmd.CleanupOptions, error) {
+	var opts cmd.CleanupOptions
+
+	for _, arg := range args {
+		switch arg {
+		case "--json":
+			opts.JSON = true
+		case "--yes", "-y":
+			opts.Yes = true
+		default:
+			return opts, fmt.Errorf("unknown argument for cleanup: %s", arg)
+		}
+	}
+
+	if opts.Yes && !opts.JSON {
+		return opts, fmt.Errorf("--yes only applies with --json; without it cleanup asks")
+	}
+
+	return opts, nil
+}
+
+func runPruneCommand() error {
+	opts, err := parsePruneArgs(os.Args[2:])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n\n", err)
+		fmt.Fprintf(os.Stderr, "Usage: auto-worktree prune [--expire <time>] [--json]\n")
+		os.Exit(2)
+	}
+
+	return cmd.RunPrune(opts)
+}
+
+// parsePruneArgs parses --json and --expire for the prune command
+func parsePruneArgs(args []string) (cmd.PruneOptions, error) {
+	var opts cmd.PruneOptions
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--json":
+			opts.JSON = true
+		case "--expire":
+			if i+1 >= len(args) || args[i+1] == "" {
+				return opts, fmt.Errorf("--expire needs a time, e.g. 2.weeks.ago")
+			}
+
+			opts.Expire = args[i+1]
+			i++
+		default:
+			return opts, fmt.Errorf("unknown argument for prune: %s", args[i])
+		}
+	}
+
+	return opts, nil
+}
+
+// parseSessionsPruneArgs parses the flags for sessions prune
+func parseSessionsPruneArgs(args []string) (cmd.SessionsPruneOptions, error) {
+	var opts cmd.SessionsPruneOptions
+
+	for _, arg := range args {
+		switch arg {
+		case "--ended":
+			opts.Ended = true
+		case "--dry-run", "-n":
+			opts.DryRun = true
+		default:
+			return opts, fmt.Errorf("unknown argument for sessions prune: %s", arg)
+		}
+	}
+
+	return opts, nil
+}
+
+func runCheckpointsCommand() error {
+	opts, err := parseCheckpointsArgs(os.Args[2:])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n\n", err)
+		fmt.Fprintf(os.Stderr, "Usage: auto-worktree checkpoints [<branch>] [--restore <n>]\n")
+		os.Exit(2)
+	}
+
+	return cmd.RunCheckpoints(opts)
+}
+
+func runTidyCommand() error {
+	args := os.Args[2:]
+	if len(args) > 1 || (len(args) == 1 && strings.HasPrefix(args[0], "-")) {
+		fmt.Fprintf(os.Stderr, "Usage: auto-worktree tidy [<branch>]\n")
+		os.Exit(2)
+	}
+
+	branch := ""
+	if len(args) == 1 {
+		branch = args[0]
+	}
+
+	return cmd.RunTidy(branch)
+}
+
+func runCherryCommand() error {
+	opts, err := parseCherryArgs(os.Args[2:])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n\n", err)
+		fmt.Fprintf(os.Stderr, "Usage: auto-worktree cherry <from-branch> [--commits a..b | --paths p1 p2]\n")
+		os.Exit(2)
+	}
+
+	return cmd.RunCherry(opts)
+}
+
+func runListCommand() error {
+	opts, err := parseListArgs(os.Args[2:])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n\n", err)
+		fmt.Fprintf(os.Stderr, "Usage: auto-worktree list [--sort age|branch|status|size] [--columns path,branch,status] [--compact] [--watch [seconds]]\n")
+		os.Exit(2)
+	}
+
+	return cmd.RunList(opts)
+}
+
+// parseListArgs parses --sort, --columns, --compact and --watch, with an
+// optional number of seconds, for the list command
+func parseListArgs(args []string) (cmd.ListOptions, error) {
+	var opts cmd.ListOptions
+
+	for i := 0; i < len(args); i++ {
+		switch arg := args[i]; arg {
+		case "--sort":
+			if i+1 >= len(args) {
+				return opts, fmt.Errorf("--sort needs one of: %s", strings.Join(git.ValidListSorts, ", "))
+			}
+
+			opts.Sort = args[i+1]
+			i++
+
+			if !slices.Contains(git.ValidListSorts, opts.Sort) {
+				return opts, fmt.Errorf("invalid sort: %s (must be one of: %s)", opts.Sort, strings.Join(git.ValidListSorts, ", "))
+			}
+		case "--columns":
+			if i+1 >= len(args) {
+				return opts, fmt.Errorf("--columns needs a comma-separated list of: %s", strings.Join(git.ValidListColumns, ", "))
+			}
+
+			columns, err := git.ParseListColumns(args[i+1])
+			if err != nil {
+				return opts, err
+			}
+
+			if len(columns) == 0 {
+				return opts, fmt.Errorf("--columns needs at least one column")
+			}
+
+			opts.Columns = columns
+			i++
+		case "--compact":
+			opts.Compact = true
+		case "--watch":
+			opts.Watch = cmd.DefaultListWatchInterval
+
+			if i+1 < len(args) && !strings.HasPrefix(args[i+1], "-") {
+				seconds, err := strconv.Atoi(args[i+1])
+				if err != nil || seconds < 1 {
+					return opts, fmt.Errorf("--watch takes a positive number of seconds, not %s", args[i+1])
+				}
+
+				opts.Watch = time.Duration(seconds) * time.Second
+				i++
+			}
+		default:
+			return opts, fmt.Errorf("unknown argument for list: %s", arg)
+		}
+	}
+
+	return opts, nil
+}
+
+// parseCherryArgs parses the branch to copy from and either --commits or
+// --paths, which takes every argument after it
+func parseCherryArgs(args []string) (cmd.CherryOptions, error) {
+	var opts cmd.CherryOptions
+
+	for i := 0; i < len(args); i++ {
+		switch arg := args[i]; {
+		case arg == "--commits":
+			if i+1 >= len(args) {
+				return opts, fmt.Errorf("--commits needs a commit or a range like a..b")
+			}
+
+			opts.Commits = args[i+1]
+			i++
+		case arg == "--paths":
+			opts.Paths = append(opts.Paths, args[i+1:]...)
+			if len(opts.Paths) == 0 {
+				return opts, fmt.Errorf("--paths needs at least one path")
+			}
+
+			i = len(args)
+		case strings.HasPrefix(arg, "-"):
+			return opts, fmt.Errorf("unknown flag for cherry: %s", arg)
+		case opts.From != "":
+			return opts, fmt.Errorf("cherry takes one branch to copy from")
+		default:
+			opts.From = arg
+		}
+	}
+
+	if opts.From == "" {
+		return opts, fmt.Errorf("cherry needs a branch to copy from")
+	}
+
+	if opts.Commits != "" && len(opts.Paths) > 0 {
+		return opts, fmt.Errorf("--commits and --paths cannot be combined")
+	}
+
+	return opts, nil
+}
+
+// parseCheckpointsArgs parses the branch and --restore for the checkpoints
+// command, and --watch, which sessions start it with in the background
+func parseCheckpointsArgs(args []string) (cmd.CheckpointsOptions, error) {
+	var opts cmd.CheckpointsOptions
+
+	for i := 0; i < len(args); i++ {
+		switch arg := args[i]; {
+		case arg == "--restore":
+			if i+1 >= len(args) {
+				return opts, fmt.Errorf("--restore needs a checkpoint number")
+			}
+
+			n, err := strconv.Atoi(args[i+1])
+			if err != nil || n < 1 {
+				return opts, fmt.Errorf("invalid --restore: %s", args[i+1])
+			}
+
+			opts.Restore = n
+			i++
+		case arg == "--watch":
+			if i+1 >= len(args) {
+				return opts, fmt.Errorf("--watch needs a session name")
+			}
+
+			opts.Watch = args[i+1]
+			i++
+		case strings.HasPrefix(arg, "-"):
+			return opts, fmt.Errorf("unknown flag for checkpoints: %s", arg)
+		case opts.Branch != "":
+			return opts, fmt.Errorf("checkpoints takes one branch")
+		default:
+			opts.Branch = arg
+		}
+	}
+
+	return opts, nil
+}
+
+// parseCheckArgs parses the assertion flags for the check command
+func parseCheckArgs(args []string) (cmd.CheckOptions, error) {
+	var opts cmd.CheckOptions
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--max-age":
+			if i+1 >= len(args) {
+				return opts, fmt.Errorf("--max-age needs a number of days")
+			}
+
+			days, err := strconv.Atoi(args[i+1])
+			if err != nil || days <= 0 {
+				return opts, fmt.Errorf("invalid --max-age: %s", args[i+1])
+			}
+
+			opts.MaxAgeDays = days
+			i++
+		case "--max-disk":
+			if i+1 >= len(args) {
+				return opts, fmt.Errorf("--max-disk needs a size in GB")
+			}
+
+			gb, err := strconv.ParseFloat(args[i+1], 64)
+			if err != nil || gb <= 0 {
+				return opts, fmt.Errorf("invalid --max-disk: %s", args[i+1])
+			}
+
+			opts.MaxDiskGB = gb
+			i++
+		case "--no-unpushed-merged":
+			opts.NoUnpushedMerged = true
+		case "--no-failed-sessions":
+			opts.NoFailedSessions = true
+		case "--json":
+			opts.JSON = true
+		default:
+			return opts, fmt.Errorf("unknown flag: %s", args[i])
+		}
+	}
+
+	return opts, nil
+}
+
+// parseDescribeArgs parses the describe mode and --apply flags
+func parseDescribeArgs(args []string) (cmd.DescribeOptions, error) {
+	var opts cmd.DescribeOptions
+
+	for _, arg := range args {
+		switch arg {
+		case "--commit", "-c":
+			if opts.Mode == cmd.DescribePR {
+				return opts, fmt.Errorf("--commit and --pr are mutually exclusive")
+			}
+
+			opts.Mode = cmd.DescribeCommit
+		case "--pr", "-p":
+			if opts.Mode == cmd.DescribeCommit {
+				return opts, fmt.Errorf("--commit and --pr are mutually exclusive")
+			}
+
+			opts.Mode = cmd.DescribePR
+		case "--apply":
+			opts.Apply = true
+		default:
+			return opts, fmt.Errorf("unknown flag: %s", arg)
+		}
+	}
+
+	return opts, nil
+}
+
+func runAnalyticsCommand() error {
+	subcommand := "show"
 	if len(os.Args) > 2 {
-		issueID = os.Args[2]
+		subcommand = os.Args[2]
 	}
 
-	return cmd.RunIssue(issueID)
+	switch subcommand {
+	case "show":
+		return cmd.RunAnalyticsShow()
+	case "enable":
+		return cmd.RunAnalyticsEnable()
+	case "disable":
+		return cmd.RunAnalyticsDisable()
+	case "reset":
+		return cmd.RunAnalyticsReset()
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown analytics subcommand: %s\n", subcommand)
+		fmt.Fprintf(os.Stderr, "Usage: auto-worktree analytics [show|enable|disable|reset]\n")
+		os.Exit(1)
+
+		return nil
+	}
+}
+
+func runReposCommand() error {
+	args := os.Args[2:]
+
+	switch {
+	case len(args) == 0:
+		return cmd.RunRepos()
+	case args[0] == "forget" && len(args) == 2:
+		return cmd.RunReposForget(args[1])
+	default:
+		fmt.Fprintf(os.Stderr, "Usage: auto-worktree repos [forget <name>]\n")
+		os.Exit(1)
+
+		return nil
+	}
+}
+
+func runAuthCommand() error {
+	args := os.Args[2:]
+
+	switch {
+	case len(args) == 0:
+		return cmd.RunAuth()
+	case args[0] == "remove" && len(args) == 2:
+		return cmd.RunAuthRemove(args[1])
+	case len(args) == 1:
+		return cmd.RunAuthSet(args[0])
+	default:
+		fmt.Fprintf(os.Stderr, "Usage: auto-worktree auth [<name> | remove <name>]\n")
+		os.Exit(1)
+
+		return nil
+	}
+}
+
+func runStateCommand() error {
+	args := os.Args[2:]
+
+	subcommand := "info"
+	if len(args) > 0 {
+		subcommand = args[0]
+	}
+
+	switch {
+	case subcommand == "info":
+		return cmd.RunStateInfo()
+	case subcommand == "dump" && len(args) == 2:
+		return cmd.RunStateDump(args[1])
+	case subcommand == "get" && len(args) == 3:
+		return cmd.RunStateGet(args[1], args[2])
+	default:
+		fmt.Fprintf(os.Stderr, "Usage: auto-worktree state [info | dump <bucket> | get <bucket> <key>]\n")
+		os.Exit(1)
+
+		return nil
+	}
+}
+
+func runUpdateCommand() error {
+	var checkOnly, yes bool
+
+	for _, arg := range os.Args[2:] {
+		switch arg {
+		case "--check":
+			checkOnly = true
+		case "--yes", "-y":
+			yes = true
+		default:
+			fmt.Fprintf(os.Stderr, "Usage: auto-worktree update [--check] [--yes]\n")
+			os.Exit(1)
+		}
+	}
+
+	err := cmd.RunUpdate(checkOnly, yes)
+	if errors.Is(err, cmd.ErrUpdateCanceled) {
+		fmt.Println("Update canceled")
+		return nil
+	}
+
+	return err
+}
+
+// parseIntervalFlag returns the --interval/-i value in seconds, or fallback if absent or invalid
+func parseIntervalFlag(args []string, fallback time.Duration) time.Duration {
+	for i, arg := range args {
+		if (arg == "--interval" || arg == "-i") && i+1 < len(args) {
+			if seconds, err := strconv.Atoi(args[i+1]); err == nil && seconds > 0 {
+				return time.Duration(seconds) * time.Second
+			}
+		}
+	}
+
+	return fallback
+}
+
+func runHistoryCommand() error {
+	if len(os.Args) < 3 {
+		return cmd.RunHistory()
+	}
+
+	if os.Args[2] == "events" {
+		opts, err := parseHistoryEventsArgs(os.Args[3:])
+		if err != nil {
+			return err
+		}
+
+		return cmd.RunHistoryEvents(opts)
+	}
+
+	if os.Args[2] != "run" || len(os.Args) < 4 {
+		fmt.Fprintf(os.Stderr, "Usage: auto-worktree history [run <n> | events [flags]]\n")
+		os.Exit(1)
+	}
+
+	return cmd.RunHistoryRun(os.Args[3])
+}
+
+// parseStatsArgs parses the stats flags
+func parseStatsArgs(args []string) (cmd.StatsOptions, error) {
+	var opts cmd.StatsOptions
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--weeks", "-w":
+			if i+1 >= len(args) {
+				return opts, fmt.Errorf("--weeks needs a number of weeks")
+			}
+
+			weeks, err := strconv.Atoi(args[i+1])
+			if err != nil || weeks <= 0 {
+				return opts, fmt.Errorf("invalid --weeks: %s", args[i+1])
+			}
+
+			opts.Weeks = weeks
+			i++
+		case "--all", "-a":
+			opts.AllRepos = true
+		case "--json":
+			opts.JSON = true
+		default:
+			return opts, fmt.Errorf("unknown flag: %s", args[i])
+		}
+	}
+
+	return opts, nil
+}
+
+// parseHistoryEventsArgs parses the filters of history events
+func parseHistoryEventsArgs(args []string) (cmd.EventsOptions, error) {
+	var opts cmd.EventsOptions
+
+	for i := 0; i < len(args); i++ {
+		value := ""
+		if i+1 < len(args) {
+			value = args[i+1]
+		}
+
+		switch args[i] {
+		case "--action":
+			if value == "" {
+				return opts, fmt.Errorf("--action needs an action, e.g. remove")
+			}
+
+			opts.Action = value
+			i++
+		case "--since":
+			since, err := cmd.ParseSince(value)
+			if err != nil {
+				return opts, err
+			}
+
+			opts.Since = since
+			i++
+		case "--limit", "-n":
+			n, err := strconv.Atoi(value)
+			if err != nil || n <= 0 {
+				return opts, fmt.Errorf("invalid --limit: %s", value)
+			}
+
+			opts.Limit = n
+			i++
+		case "--all", "-a":
+			opts.AllRepos = true
+		default:
+			if strings.HasPrefix(args[i], "-") || opts.Match != "" {
+				return opts, fmt.Errorf("unknown argument: %s", args[i])
+			}
+
+			opts.Match = args[i]
+		}
+	}
+
+	return opts, nil
 }
 
 func runPRCommand() error {
-	prNum := ""
-	if len(os.Args) > 2 {
-		prNum = os.Args[2]
+	prNum, opts, err := parsePRArgs(os.Args[2:])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n\n", err)
+		fmt.Fprintf(os.Stderr, "Usage: auto-worktree pr [num] [--read-only]\n")
+		os.Exit(1)
+	}
+
+	return cmd.RunPRWithOptions(prNum, opts)
+}
+
+// parsePRArgs parses the arguments of 'auto-worktree pr'
+func parsePRArgs(args []string) (string, cmd.PROptions, error) {
+	var (
+		prNum string
+		opts  cmd.PROptions
+	)
+
+	for _, arg := range args {
+		switch {
+		case arg == "--read-only":
+			opts.ReadOnly = true
+		case strings.HasPrefix(arg, "-") || prNum != "":
+			return "", opts, fmt.Errorf("unexpected argument: %s", arg)
+		default:
+			prNum = arg
+		}
 	}
 
-	return cmd.RunPR(prNum)
+	return prNum, opts, nil
 }
 
 func runRemoveCommand() error {
@@ -152,7 +1339,7 @@ func runRemoveCommand() error {
 func runHealthCommand(command string) error {
 	switch command {
 	case "health-check", "health": //nolint:goconst
-		return cmd.RunHealthCheck()
+		return runHealthCheckCommand()
 	case "repair":
 		return cmd.RunRepair()
 	case "monitor":
@@ -162,30 +1349,132 @@ func runHealthCommand(command string) error {
 	}
 }
 
+// runHealthCheckCommand exits 0 when healthy, 1 when issues at or above
+// --severity were found, and 2 on usage or runtime errors
+func runHealthCheckCommand() error {
+	opts, err := parseHealthCheckArgs(os.Args[2:])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n\n", err)
+		fmt.Fprintf(os.Stderr, "Usage: auto-worktree health-check [--all] [--severity warning|error|critical] [--json]\n")
+		os.Exit(2)
+	}
+
+	err = cmd.RunHealthCheck(opts)
+
+	switch {
+	case errors.Is(err, cmd.ErrHealthCheckFailed):
+		os.Exit(1)
+	case err != nil:
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(2)
+	}
+
+	return nil
+}
+
+// parseHealthCheckArgs parses --all, --severity and --json
+func parseHealthCheckArgs(args []string) (cmd.HealthCheckOptions, error) {
+	var opts cmd.HealthCheckOptions
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--all", "-a":
+			opts.All = true
+		case "--severity", "-s":
+			if i+1 >= len(args) {
+				return opts, fmt.Errorf("--severity needs warning, error or critical")
+			}
+
+			severity, err := git.ParseHealthCheckSeverity(args[i+1])
+			if err != nil {
+				return opts, err
+			}
+
+			opts.Severity = severity
+			i++
+		case "--json":
+			opts.JSON = true
+		default:
+			return opts, fmt.Errorf("unknown flag: %s", args[i])
+		}
+	}
+
+	return opts, nil
+}
+
+// runMaintainCommand exits 0 when every maintenance step succeeded, 1 when
+// one failed, and 2 on usage or runtime errors
+func runMaintainCommand() error {
+	opts, err := parseMaintainArgs(os.Args[2:])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n\n", err)
+		fmt.Fprintf(os.Stderr, "Usage: auto-worktree maintain [--dry-run] [--quiet]\n")
+		os.Exit(2)
+	}
+
+	err = cmd.RunMaintain(opts)
+
+	switch {
+	case errors.Is(err, cmd.ErrMaintenanceFailed):
+		os.Exit(1)
+	case err != nil:
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(2)
+	}
+
+	return nil
+}
+
+// parseMaintainArgs parses --dry-run and --quiet
+func parseMaintainArgs(args []string) (cmd.MaintainOptions, error) {
+	var opts cmd.MaintainOptions
+
+	for _, arg := range args {
+		switch arg {
+		case "--dry-run", "-n":
+			opts.DryRun = true
+		case "--quiet", "-q":
+			opts.Quiet = true
+		default:
+			return opts, fmt.Errorf("unknown flag: %s", arg)
+		}
+	}
+
+	return opts, nil
+}
+
 func runDoctorCommand() error {
-	checkLocks := false
-	removeLocks := false
+	var opts cmd.DoctorOptions
 
 	// Parse flags
 	for i := 2; i < len(os.Args); i++ {
 		switch os.Args[i] {
 		case "--check-locks":
-			checkLocks = true
+			opts.CheckLocks = true
 		case "--remove-locks":
-			removeLocks = true
+			opts.RemoveLocks = true
+		case "--repair-base":
+			opts.RepairBase = true
+		case "--yes", "-y":
+			opts.Yes = true
 		default:
 			fmt.Fprintf(os.Stderr, "Unknown flag: %s\n\n", os.Args[i])
-			fmt.Fprintf(os.Stderr, "Usage: auto-worktree doctor [--check-locks] [--remove-locks]\n")
+			fmt.Fprintf(os.Stderr, "Usage: auto-worktree doctor [--check-locks] [--remove-locks] [--repair-base [--yes]]\n")
 			os.Exit(1)
 		}
 	}
 
 	// Default to checking locks if no flags provided
-	if !checkLocks && !removeLocks {
-		checkLocks = true
+	if !opts.CheckLocks && !opts.RemoveLocks {
+		opts.CheckLocks = true
+	}
+
+	err := cmd.RunDoctor(opts)
+	if errors.Is(err, cmd.ErrDoctorFailed) {
+		os.Exit(1)
 	}
 
-	return cmd.RunDoctor(checkLocks, removeLocks)
+	return err
 }
 
 func runSettingsCommand() error {
@@ -260,22 +1549,119 @@ USAGE:
 COMMANDS:
     (no command)          Show interactive menu
     new [branch]          Create new worktree
-    resume                Resume last worktree
+    resume [--all]        Resume last worktree, or restore every session lost to a reboot
+    sessions [prune [--ended] [--dry-run]]
+                          Manage AI sessions, or forget those whose worktree was removed (--ended:
+                          or whose tmux session ended; 'aw monitor' prunes removed worktrees too)
     issue [id]            Work on an issue (GitHub, GitLab, JIRA, or Linear)
     create                Create a new issue and start working on it
-    pr [num]              Review a pull request
-    list, ls              List all worktrees with status
-    cleanup               Interactive cleanup of merged/stale worktrees
+    pr [num] [--read-only]
+                          Review a pull request (--read-only: detached checkout with
+                          no branch, never pushed from and cleaned up after a day)
+    list, ls [--sort age|branch|status|size] [--columns path,branch,...] [--compact]
+             [--watch [seconds]]
+                          List all worktrees with status, fitted to the terminal
+                          (--compact: one unpadded line per worktree;
+                          --watch: refresh in place, every 5s by default)
+    conflicts [branch]    Show which worktree branches would conflict with the default branch
+    cleanup [--json [--yes]]
+                          Interactive cleanup of merged/stale worktrees (--json: report
+                          what would be removed without prompting; --yes: remove it)
     settings              Configure per-repository settings
+    setup                 Guided setup: provider, sign-in checks, AI tool, worktree location, cleanup
+    repos [forget <name>] List every repository auto-worktree has been used in, with worktrees and sessions
+    auth [<name> | remove <name>]
+                          List stored tokens, or store one (github, gitlab, jira, linear, anthropic,
+                          openai, gemini) in the OS keychain instead of git config or shell profiles
+    status [--porcelain]  Show the current worktree's branch, issue, PR and session; --porcelain
+                          prints one line for a shell prompt or tmux status bar
+    shell-init <shell>    Print shell functions (bash, zsh, fish) that leave your shell in the
+                          worktree when a command runs without tmux
+    plugins               List command plugins (auto-worktree-<name> on PATH, run as
+                          'auto-worktree <name>') and provider plugins in ~/.auto-worktree/plugins
     remove <path>         Remove a worktree
-    prune                 Prune orphaned worktrees
-    doctor                Run repository diagnostics
-    health-check          Check worktree health (use --all for all worktrees)
+    prune [--expire <time>] [--json]
+                          Prune the records of missing worktrees, listing them first; --expire
+                          keeps those younger than a git date such as 2.weeks.ago (default:
+                          gc.worktreePruneExpire if set); --json: report what was pruned
+    history [run <n>]     List recent issue/PR invocations, or repeat one
+    history events [filter] [--action <a>] [--since 7d] [--limit n] [--all]
+                          Show the log of worktree, branch and session operations (who, when, from where)
+    stats [--weeks n] [--all] [--json]
+                          Worktrees created per week, average lifetime, merge rate, cleanups and AI tool usage
+    redo                  Repeat the most recent issue/PR invocation
+    dashboard, dash       Full-screen dashboard of every worktree with quick actions
+    describe              Write a commit message or PR description from the diff with AI
+    feedback              Send unresolved PR review comments to the worktree's AI session
+    ci                    Send the logs of failing PR checks to the worktree's AI session to fix
+    exec [--all | <branch>...] [--parallel] -- <command>
+                          Run a command in the current, named or all worktrees (--parallel prefixes
+                          each line with the branch; exits non-zero if it fails anywhere)
+    test [--all | <branch>...]
+                          Run auto-worktree.test-command in the current, named or all worktrees at
+                          once; list and monitor show each one's result in a TEST column
+    pick <branch> [--no-pr] [--yes] [--force]
+                          Keep this attempt at an issue: open its PR and remove the other attempts'
+                          worktrees, branches and sessions (--force also removes attempts with
+                          uncommitted changes or unpushed commits)
+    compare <branch-a> <branch-b> [--ai]
+                          Show two worktrees' changes, commits, files and test results side by side
+                          (--ai asks the AI tool which approach looks better)
+    checkpoints [<branch>] [--restore <n>]
+                          List the snapshots taken while the worktree's AI session ran
+                          (auto-worktree.checkpoint-interval), or put its files back to one
+    tidy [<branch>]       Squash, fix up, reword or drop the worktree's unpushed commits in a
+                          list before pushing (a = fix up all into the first)
+    cherry <from-branch> [--commits a..b | --paths p1 p2]
+                          Copy another worktree's commits (default: all the current one lacks)
+                          or its files as they are on disk into the current worktree
+    analytics [show|enable|disable|reset]
+                          Local-only feature usage counts (opt-in, never sent anywhere)
+    check                 Assert worktree hygiene for cron or git hooks (exit 1 on failure)
+    maintain [--dry-run] [--quiet]
+                          Housekeeping for cron or systemd timers: remove stale locks, clean
+                          up per cleanup-policy, prune, drop old session metadata, refresh
+                          the status cache (exit 1 if a step failed)
+    state [info | dump <bucket> | get <bucket> <key>]
+                          Inspect the local state database (sessions, history, analytics)
+    freeze [reason]       Pause cleanup, prune, remove and background actions for this repo
+    thaw                  Resume automatic actions after a freeze
+    overview              Show a project-health summary (branches, PRs, issues, hygiene)
+    doctor                Check tools, sign-ins, config, the worktree base and lock files
+                          (exit 1 on problems; --repair-base adopts or deletes stray entries)
+    health-check [--all] [--severity <level>] [--json]
+                          Check worktree health; with --severity, show only issues that
+                          severe (warning, error, critical) and exit 1 if there are any
     repair                Repair worktree issues (use --all for all worktrees)
     monitor               Monitor worktree health continuously
+    tour                  Guided walkthrough of the core loop in a sandbox repo
+    update [--check]      Install the latest release after verifying its checksum
     version               Show version information
     help                  Show this help message
 
+GLOBAL FLAGS:
+    --plain               Line-based prompts and no colors or full-screen UI
+                          (automatic when stdout is not a terminal)
+    --verbose             Log every git/gh command run and how long it took
+    --debug               Also log command output and other debugging details
+    --offline             Skip issue/PR status, AI selection, fetches and downloads
+                          (automatic once GitHub, GitLab, JIRA or Linear is unreachable)
+    --repo <name>         Run in a registered repository instead of the current directory
+                          (see 'auto-worktree repos')
+    --host <host>[:<dir>] Run git, tmux and AI tools on another machine over SSH, in <dir>
+                          there (default: the remote home directory)
+
+NEW FLAGS:
+    --existing <branch>   Create the worktree for an existing branch
+    --scope <dir>         Start the session in a monorepo package and tell the AI tool about it
+    --sparse              With --scope, check out only that package (git sparse-checkout)
+    --prefix <prefix>     Prefix for a generated branch name instead of the configured one (e.g. feat/)
+    --no-session          Only create the worktree; with shell-init, the shell moves into it
+
+RESUME FLAGS:
+    --all, -a             Recreate the session (resuming the AI tool) of every worktree
+                          that had one, in all repositories, e.g. after a reboot
+
 DOCTOR FLAGS:
     --check-locks         Check for stale Git lock files (default)
     --remove-locks        Remove stale lock files (use with --check-locks)
@@ -287,31 +1673,120 @@ REPAIR FLAGS:
     --all, -a             Repair all worktrees (default: current worktree)
     --yes, -y             Skip confirmation for unsafe operations
 
+ISSUE FLAGS:
+    --label, -l <name>    Only list issues with this label (repeatable, comma-separated)
+    --assignee, -a <who>  Filter by assignee: me, unassigned, or a username
+    --milestone, -m <m>   Only list issues in this milestone (sprint for JIRA, cycle for Linear);
+                          current for the active one
+    --search, -s <text>   Search issue titles and descriptions
+    --sort <order>        recent (default) or priority: the most urgent first, using the tracker's
+                          priority or labels like P1 and "priority: high"
+    --limit, -n <n>       Issues per page in the selector (default: 20)
+
+DESCRIBE FLAGS:
+    --commit, -c          Describe the staged changes as a commit message (default if anything is staged)
+    --pr, -p              Describe the branch as a PR title and body
+    --apply               Commit with the message, or update the branch's open PR (pushing
+                          and opening one, with the pr-* reviewers, labels and draft settings,
+                          if there is none)
+
+CHECK FLAGS (default: --max-age 30 --no-unpushed-merged --no-failed-sessions):
+    --max-age <days>      Fail if a worktree has had no commits for longer than this
+    --no-unpushed-merged  Fail if a merged branch still has unpushed commits
+    --no-failed-sessions  Fail if any session is in the failed state
+    --max-disk <GB>       Fail if all worktrees together use more disk than this
+    --json                Print results as JSON
+
+UPDATE FLAGS:
+    --check               Only report whether a newer release is available
+    --yes, -y             Install without asking for confirmation
+
 MONITOR FLAGS:
-    --interval, -i <sec>  Check interval in seconds (default: 60)
+    --interval, -i <sec>  Check interval in seconds (default: 60; dashboard default: 30)
 
 EXAMPLES:
     # Show interactive menu
     auto-worktree
 
+    # New to auto-worktree? Take the guided tour
+    auto-worktree tour
+
     # Create a new worktree
     auto-worktree new feature/new-feature
 
+    # Work on one package of a monorepo, checking out only that package
+    auto-worktree new feature/api-auth --scope packages/api --sparse
+
+    # Create a worktree in a repository on a remote workstation
+    auto-worktree --host dev-box:~/src/app new feature/login
+
     # Work on a GitHub issue
     auto-worktree issue 42
 
+    # Pick from my open bugs
+    auto-worktree issue --assignee me --label bug
+
     # Review a pull request
     auto-worktree pr 123
 
+    # Take a quick look at a pull request without creating a branch
+    auto-worktree pr 123 --read-only
+
+    # Re-review the same PR after it has been updated
+    auto-worktree redo
+
     # List all worktrees
     auto-worktree list
 
+    # Only what fits a narrow terminal, ready-to-clean worktrees first
+    auto-worktree list --sort status --columns path,branch,status
+
+    # A live table for a tmux pane, refreshed every 10 seconds
+    auto-worktree list --watch 10 --compact
+
+    # Script-friendly listing without colors or prompts
+    auto-worktree list --plain < /dev/null | grep stale
+
+    # Commit staged changes with an AI-written message
+    auto-worktree describe --apply
+
+    # Run the tests on every agent branch at once
+    auto-worktree exec --all --parallel -- go test ./...
+
+    # Test every attempt at an issue, then compare the TEST column
+    git config auto-worktree.test-command "make test"
+    auto-worktree test --all && auto-worktree list
+    auto-worktree compare work/123-fix-login work/123-fix-login-2
+    auto-worktree pick work/123-fix-login-2
+
+    # Snapshot agent sessions every 10 minutes, then undo a bad turn
+    git config auto-worktree.checkpoint-interval 10
+    auto-worktree checkpoints work/123-fix-login --restore 2
+
+    # Fold an agent's WIP commits into one before pushing
+    auto-worktree tidy work/123-fix-login
+
+    # Take the part another attempt got right
+    auto-worktree cherry work/123-fix-login-2 --paths src/auth
+
+    # Enforce hygiene from a pre-push hook
+    auto-worktree check --max-age 14 --no-unpushed-merged
+
+    # Nightly housekeeping from cron, mailing only when something changed
+    0 3 * * * cd ~/src/my-repo && auto-worktree maintain --quiet
+
+    # Pause automatic actions during a history rewrite
+    auto-worktree freeze "rewriting history"
+
     # Resume last worktree
     auto-worktree resume
 
     # Interactive cleanup
     auto-worktree cleanup
 
+    # Remove merged worktrees from a script and log what was freed
+    auto-worktree cleanup --json --yes | jq '.freedBytes'
+
     # Configure settings
     auto-worktree settings
 
@@ -321,18 +1796,27 @@ EXAMPLES:
     # Clean up orphaned worktrees
     auto-worktree prune
 
+    # Weekly review of branches, PRs, and assigned issues
+    auto-worktree overview
+
     # Check for stale lock files
     auto-worktree doctor --check-locks
 
     # Remove stale lock files
     auto-worktree doctor --check-locks --remove-locks
 
+    # Adopt lost worktrees and delete leftovers in the worktree base
+    auto-worktree doctor --repair-base
+
     # Check health of current worktree
     auto-worktree health-check
 
     # Check health of all worktrees
     auto-worktree health-check --all
 
+    # Alert from cron when any worktree has an error or worse
+    auto-worktree health-check --all --severity error --json
+
     # Repair issues in current worktree
     auto-worktree repair
 
@@ -340,6 +1824,7 @@ EXAMPLES:
     auto-worktree repair --all --yes
 
     # Monitor all worktrees every 30 seconds
+    # (also stops sessions idle past auto-worktree.session-idle-timeout)
     auto-worktree monitor --interval 30
 
 For more information, visit: https://github.com/kaeawc/auto-worktree
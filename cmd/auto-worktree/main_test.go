@@ -4,8 +4,13 @@ import (
 	"context"
 	"os"
 	"os/exec"
+	"reflect"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/kaeawc/auto-worktree/internal/cmd"
+	"github.com/kaeawc/auto-worktree/internal/git"
 )
 
 func TestMain(t *testing.T) {
@@ -48,3 +53,404 @@ func TestVersionCommand(t *testing.T) {
 		t.Errorf("Expected version output, got: %s", outputStr)
 	}
 }
+
+func TestParseIssueArgs(t *testing.T) {
+	issueID, filters, err := parseIssueArgs([]string{
+		"--label", "bug,ui", "-l", "p1", "--assignee", "me", "--milestone", "v2", "--search", "crash", "--limit", "50",
+		"--sort", "priority",
+	})
+	if err != nil {
+		t.Fatalf("parseIssueArgs() error = %v", err)
+	}
+
+	if issueID != "" {
+		t.Errorf("issueID = %q, want empty", issueID)
+	}
+
+	if strings.Join(filters.Labels, ",") != "bug,ui,p1" {
+		t.Errorf("Labels = %v, want [bug ui p1]", filters.Labels)
+	}
+
+	if filters.Assignee != "me" || filters.Milestone != "v2" || filters.Search != "crash" || filters.Limit != 50 ||
+		filters.Sort != "priority" {
+		t.Errorf("unexpected filters: %+v", filters)
+	}
+
+	issueID, _, err = parseIssueArgs([]string{"42"})
+	if err != nil || issueID != "42" {
+		t.Errorf("parseIssueArgs([42]) = %q, %v", issueID, err)
+	}
+
+	for _, args := range [][]string{{"--limit", "0"}, {"--label"}, {"--bogus", "x"}, {"1", "2"}, {"--sort", "oldest"}} {
+		if _, _, err := parseIssueArgs(args); err == nil {
+			t.Errorf("parseIssueArgs(%v) expected error", args)
+		}
+	}
+}
+
+func TestParseDescribeArgs(t *testing.T) {
+	opts, err := parseDescribeArgs([]string{"--pr", "--apply"})
+	if err != nil || opts.Mode != cmd.DescribePR || !opts.Apply {
+		t.Errorf("parseDescribeArgs(--pr --apply) = %+v, %v", opts, err)
+	}
+
+	opts, err = parseDescribeArgs(nil)
+	if err != nil || opts.Mode != cmd.DescribeAuto || opts.Apply {
+		t.Errorf("parseDescribeArgs() = %+v, %v", opts, err)
+	}
+
+	for _, args := range [][]string{{"--commit", "--pr"}, {"--bogus"}} {
+		if _, err := parseDescribeArgs(args); err == nil {
+			t.Errorf("parseDescribeArgs(%v) expected error", args)
+		}
+	}
+}
+
+func TestParseIntervalFlag(t *testing.T) {
+	fallback := 30 * time.Second
+
+	tests := []struct {
+		args []string
+		want time.Duration
+	}{
+		{nil, fallback},
+		{[]string{"--interval", "5"}, 5 * time.Second},
+		{[]string{"-i", "90"}, 90 * time.Second},
+		{[]string{"--interval", "0"}, fallback},
+		{[]string{"--interval", "soon"}, fallback},
+		{[]string{"--interval"}, fallback},
+	}
+
+	for _, tt := range tests {
+		if got := parseIntervalFlag(tt.args, fallback); got != tt.want {
+			t.Errorf("parseIntervalFlag(%v) = %v, want %v", tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestParseCheckArgs(t *testing.T) {
+	opts, err := parseCheckArgs([]string{"--max-age", "14", "--no-failed-sessions", "--max-disk", "2.5", "--json"})
+	if err != nil {
+		t.Fatalf("parseCheckArgs() error = %v", err)
+	}
+
+	want := cmd.CheckOptions{MaxAgeDays: 14, NoFailedSessions: true, MaxDiskGB: 2.5, JSON: true}
+	if opts != want {
+		t.Errorf("parseCheckArgs() = %+v, want %+v", opts, want)
+	}
+
+	for _, args := range [][]string{{"--max-age"}, {"--max-age", "0"}, {"--max-disk", "lots"}, {"--stale"}} {
+		if _, err := parseCheckArgs(args); err == nil {
+			t.Errorf("parseCheckArgs(%v) should fail", args)
+		}
+	}
+}
+
+func TestParseHealthCheckArgs(t *testing.T) {
+	opts, err := parseHealthCheckArgs([]string{"--all", "--severity", "error", "--json"})
+	if err != nil {
+		t.Fatalf("parseHealthCheckArgs() error = %v", err)
+	}
+
+	want := cmd.HealthCheckOptions{All: true, Severity: git.SeverityError, JSON: true}
+	if opts != want {
+		t.Errorf("parseHealthCheckArgs() = %+v, want %+v", opts, want)
+	}
+
+	for _, args := range [][]string{{"--severity"}, {"--severity", "fatal"}, {"--quiet"}} {
+		if _, err := parseHealthCheckArgs(args); err == nil {
+			t.Errorf("parseHealthCheckArgs(%v) should fail", args)
+		}
+	}
+}
+
+func TestParseMaintainArgs(t *testing.T) {
+	opts, err := parseMaintainArgs([]string{"-n", "--quiet"})
+	if err != nil {
+		t.Fatalf("parseMaintainArgs() error = %v", err)
+	}
+
+	if want := (cmd.MaintainOptions{DryRun: true, Quiet: true}); opts != want {
+		t.Errorf("parseMaintainArgs() = %+v, want %+v", opts, want)
+	}
+
+	if _, err := parseMaintainArgs([]string{"--yes"}); err == nil {
+		t.Error("parseMaintainArgs(--yes) should fail")
+	}
+}
+
+func TestParseExecArgs(t *testing.T) {
+	opts, err := parseExecArgs([]string{"work/a", "work/b", "-p", "--", "go", "test", "--run", "X"})
+	if err != nil {
+		t.Fatalf("parseExecArgs() error = %v", err)
+	}
+
+	want := cmd.ExecOptions{Branches: []string{"work/a", "work/b"}, Parallel: true, Command: []string{"go", "test", "--run", "X"}}
+	if !reflect.DeepEqual(opts, want) {
+		t.Errorf("parseExecArgs() = %+v, want %+v", opts, want)
+	}
+
+	for _, args := range [][]string{{"--all", "git", "fetch"}, {"--all", "--"}, {"--all", "work/a", "--", "ls"}, {"--quiet", "--", "ls"}} {
+		if _, err := parseExecArgs(args); err == nil {
+			t.Errorf("parseExecArgs(%v) should fail", args)
+		}
+	}
+}
+
+func TestParseTestArgs(t *testing.T) {
+	opts, err := parseTestArgs([]string{"work/a", "work/b"})
+	if err != nil || !reflect.DeepEqual(opts, cmd.TestOptions{Branches: []string{"work/a", "work/b"}}) {
+		t.Errorf("parseTestArgs(work/a work/b) = %+v, %v", opts, err)
+	}
+
+	opts, err = parseTestArgs([]string{"--all"})
+	if err != nil || !opts.All {
+		t.Errorf("parseTestArgs(--all) = %+v, %v", opts, err)
+	}
+
+	for _, args := range [][]string{{"--all", "work/a"}, {"--parallel"}} {
+		if _, err := parseTestArgs(args); err == nil {
+			t.Errorf("parseTestArgs(%v) should fail", args)
+		}
+	}
+}
+
+func TestParsePickArgs(t *testing.T) {
+	opts, err := parsePickArgs([]string{"work/123-fix-2", "--no-pr", "-y", "--force"})
+	if want := (cmd.PickOptions{Branch: "work/123-fix-2", NoPR: true, Yes: true, Force: true}); err != nil || opts != want {
+		t.Errorf("parsePickArgs() = %+v, %v; want %+v", opts, err, want)
+	}
+
+	for _, args := range [][]string{nil, {"a", "b"}, {"a", "--all"}} {
+		if _, err := parsePickArgs(args); err == nil {
+			t.Errorf("parsePickArgs(%v) should fail", args)
+		}
+	}
+}
+
+func TestParseCompareArgs(t *testing.T) {
+	opts, err := parseCompareArgs([]string{"work/a", "--ai", "work/b"})
+	if want := (cmd.CompareOptions{BranchA: "work/a", BranchB: "work/b", AI: true}); err != nil || opts != want {
+		t.Errorf("parseCompareArgs() = %+v, %v; want %+v", opts, err, want)
+	}
+
+	for _, args := range [][]string{{"work/a"}, {"a", "b", "c"}, {"a", "b", "--stat"}} {
+		if _, err := parseCompareArgs(args); err == nil {
+			t.Errorf("parseCompareArgs(%v) should fail", args)
+		}
+	}
+}
+
+func TestParseCheckpointsArgs(t *testing.T) {
+	opts, err := parseCheckpointsArgs([]string{"work/a", "--restore", "2"})
+	if want := (cmd.CheckpointsOptions{Branch: "work/a", Restore: 2}); err != nil || opts != want {
+		t.Errorf("parseCheckpointsArgs() = %+v, %v; want %+v", opts, err, want)
+	}
+
+	opts, err = parseCheckpointsArgs([]string{"--watch", "auto-worktree-work-a"})
+	if want := (cmd.CheckpointsOptions{Watch: "auto-worktree-work-a"}); err != nil || opts != want {
+		t.Errorf("parseCheckpointsArgs() = %+v, %v; want %+v", opts, err, want)
+	}
+
+	for _, args := range [][]string{{"--restore"}, {"--restore", "0"}, {"a", "b"}, {"--all"}} {
+		if _, err := parseCheckpointsArgs(args); err == nil {
+			t.Errorf("parseCheckpointsArgs(%v) should fail", args)
+		}
+	}
+}
+
+func TestParseCleanupArgs(t *testing.T) {
+	opts, err := parseCleanupArgs([]string{"--json", "-y"})
+	if want := (cmd.CleanupOptions{JSON: true, Yes: true}); err != nil || opts != want {
+		t.Errorf("parseCleanupArgs() = %+v, %v; want %+v", opts, err, want)
+	}
+
+	for _, args := range [][]string{{"--yes"}, {"--all"}} {
+		if _, err := parseCleanupArgs(args); err == nil {
+			t.Errorf("parseCleanupArgs(%v) should fail", args)
+		}
+	}
+}
+
+func TestParseListArgs(t *testing.T) {
+	opts, err := parseListArgs([]string{"--sort", "status", "--columns", "path, branch,status"})
+	if err != nil || opts.Sort != "status" || strings.Join(opts.Columns, ",") != "path,branch,status" {
+		t.Errorf("parseListArgs() = %+v, %v", opts, err)
+	}
+
+	opts, err = parseListArgs(nil)
+	if err != nil || opts.Sort != "" || opts.Columns != nil || opts.Compact {
+		t.Errorf("parseListArgs(nil) = %+v, %v", opts, err)
+	}
+
+	if opts, err := parseListArgs([]string{"--compact"}); err != nil || !opts.Compact {
+		t.Errorf("parseListArgs(--compact) = %+v, %v", opts, err)
+	}
+
+	if opts, err := parseListArgs([]string{"--watch", "--compact"}); err != nil || opts.Watch != cmd.DefaultListWatchInterval || !opts.Compact {
+		t.Errorf("parseListArgs(--watch --compact) = %+v, %v", opts, err)
+	}
+
+	if opts, err := parseListArgs([]string{"--watch", "10"}); err != nil || opts.Watch != 10*time.Second {
+		t.Errorf("parseListArgs(--watch 10) = %+v, %v", opts, err)
+	}
+
+	for _, args := range [][]string{{"--sort"}, {"--sort", "color"}, {"--columns"}, {"--columns", "path,color"}, {"--columns", ","}, {"--watch", "0"}, {"--watch", "soon"}, {"stale"}} {
+		if _, err := parseListArgs(args); err == nil {
+			t.Errorf("parseListArgs(%v) should fail", args)
+		}
+	}
+}
+
+func TestParseCherryArgs(t *testing.T) {
+	opts, err := parseCherryArgs([]string{"work/a", "--commits", "abc..def"})
+	if err != nil || opts.From != "work/a" || opts.Commits != "abc..def" || len(opts.Paths) != 0 {
+		t.Errorf("parseCherryArgs() = %+v, %v", opts, err)
+	}
+
+	opts, err = parseCherryArgs([]string{"work/a", "--paths", "src/auth", "go.mod"})
+	if err != nil || opts.From != "work/a" || strings.Join(opts.Paths, " ") != "src/auth go.mod" {
+		t.Errorf("parseCherryArgs() = %+v, %v", opts, err)
+	}
+
+	for _, args := range [][]string{{}, {"--paths", "a"}, {"work/a", "--paths"}, {"work/a", "--commits"}, {"work/a", "--commits", "x", "--paths", "a"}, {"a", "b"}} {
+		if _, err := parseCherryArgs(args); err == nil {
+			t.Errorf("parseCherryArgs(%v) should fail", args)
+		}
+	}
+}
+
+func TestParseSessionsPruneArgs(t *testing.T) {
+	opts, err := parseSessionsPruneArgs([]string{"--ended", "-n"})
+	if want := (cmd.SessionsPruneOptions{Ended: true, DryRun: true}); err != nil || opts != want {
+		t.Errorf("parseSessionsPruneArgs() = %+v, %v; want %+v", opts, err, want)
+	}
+
+	if _, err := parseSessionsPruneArgs([]string{"--all"}); err == nil {
+		t.Error("parseSessionsPruneArgs(--all) should fail")
+	}
+}
+
+func TestParsePruneArgs(t *testing.T) {
+	opts, err := parsePruneArgs([]string{"--expire", "2.weeks.ago", "--json"})
+	if want := (cmd.PruneOptions{JSON: true, Expire: "2.weeks.ago"}); err != nil || opts != want {
+		t.Errorf("parsePruneArgs() = %+v, %v; want %+v", opts, err, want)
+	}
+
+	for _, args := range [][]string{{"--expire"}, {"--dry-run"}} {
+		if _, err := parsePruneArgs(args); err == nil {
+			t.Errorf("parsePruneArgs(%v) should fail", args)
+		}
+	}
+}
+
+func TestParseStatsArgs(t *testing.T) {
+	opts, err := parseStatsArgs([]string{"--weeks", "4", "--all", "--json"})
+	if err != nil {
+		t.Fatalf("parseStatsArgs() error = %v", err)
+	}
+
+	if want := (cmd.StatsOptions{Weeks: 4, AllRepos: true, JSON: true}); opts != want {
+		t.Errorf("parseStatsArgs() = %+v, want %+v", opts, want)
+	}
+
+	for _, args := range [][]string{{"--weeks"}, {"--weeks", "0"}, {"--verbose"}} {
+		if _, err := parseStatsArgs(args); err == nil {
+			t.Errorf("parseStatsArgs(%v) should fail", args)
+		}
+	}
+}
+
+func TestParseHistoryEventsArgs(t *testing.T) {
+	opts, err := parseHistoryEventsArgs([]string{"feature-x", "--action", "remove", "--since", "2d", "-n", "5", "--all"})
+	if err != nil {
+		t.Fatalf("parseHistoryEventsArgs() error = %v", err)
+	}
+
+	want := cmd.EventsOptions{Match: "feature-x", Action: "remove", Since: 48 * time.Hour, Limit: 5, AllRepos: true}
+	if opts != want {
+		t.Errorf("parseHistoryEventsArgs() = %+v, want %+v", opts, want)
+	}
+
+	for _, args := range [][]string{{"--action"}, {"--since", "later"}, {"--limit", "0"}, {"a", "b"}, {"--json"}} {
+		if _, err := parseHistoryEventsArgs(args); err == nil {
+			t.Errorf("parseHistoryEventsArgs(%v) should fail", args)
+		}
+	}
+}
+
+func TestExtractGlobalFlags(t *testing.T) {
+	args, flags := extractGlobalFlags([]string{"--verbose", "list", "--plain"})
+	if len(args) != 1 || args[0] != "list" {
+		t.Errorf("extractGlobalFlags() args = %v, want [list]", args)
+	}
+
+	if !flags.plain || !flags.verbose || flags.debug {
+		t.Errorf("extractGlobalFlags() flags = %+v, want plain and verbose", flags)
+	}
+
+	args, flags = extractGlobalFlags([]string{"exec", "--", "echo", "--plain"})
+	if flags.plain || len(args) != 4 {
+		t.Errorf("extractGlobalFlags() = %v, %+v; want --plain after -- left alone", args, flags)
+	}
+
+	args, flags = extractGlobalFlags([]string{"--repo", "api", "list", "--repo=web"})
+	if flags.repo != "web" || len(args) != 1 || args[0] != "list" {
+		t.Errorf("extractGlobalFlags() = %v, %+v; want [list] with repo web", args, flags)
+	}
+	args, flags = extractGlobalFlags([]string{"--offline", "list"})
+	if len(args) != 1 || args[0] != "list" || !flags.offline {
+		t.Errorf("extractGlobalFlags() = %v, %+v; want [list] with offline", args, flags)
+	}
+
+	args, flags = extractGlobalFlags([]string{"--host", "dev-box:~/src/app", "new", "feature/x"})
+	if flags.host != "dev-box:~/src/app" || len(args) != 2 || args[0] != "new" {
+		t.Errorf("extractGlobalFlags() = %v, %+v; want [new feature/x] with host dev-box:~/src/app", args, flags)
+	}
+}
+
+func TestPluginCommand(t *testing.T) {
+	if os.Getenv("GO_TEST_PROCESS") == "1" {
+		os.Args = []string{"auto-worktree", "hello", "world"}
+		main()
+		return
+	}
+
+	dir := t.TempDir()
+	script := "#!/bin/sh\necho \"hello $1\"\nexit 3\n"
+	if err := os.WriteFile(dir+"/auto-worktree-hello", []byte(script), 0o755); err != nil { //nolint:gosec // test plugin must be executable
+		t.Fatal(err)
+	}
+
+	cmd := exec.CommandContext(context.Background(), os.Args[0], "-test.run=TestPluginCommand")
+	cmd.Env = append(os.Environ(), "GO_TEST_PROCESS=1", "PATH="+dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	cmd.Dir = dir
+	output, err := cmd.CombinedOutput()
+
+	exitErr, ok := err.(*exec.ExitError) //nolint:errorlint // CombinedOutput returns it unwrapped
+	if !ok || exitErr.ExitCode() != 3 {
+		t.Fatalf("expected the plugin's exit code 3, got %v: %s", err, output)
+	}
+
+	if !strings.Contains(string(output), "hello world") {
+		t.Errorf("expected plugin output, got: %s", output)
+	}
+}
+
+func TestParsePRArgs(t *testing.T) {
+	prNum, opts, err := parsePRArgs([]string{"123", "--read-only"})
+	if err != nil || prNum != "123" || !opts.ReadOnly {
+		t.Errorf("parsePRArgs([123 --read-only]) = %q, %+v, %v", prNum, opts, err)
+	}
+
+	prNum, opts, err = parsePRArgs(nil)
+	if err != nil || prNum != "" || opts.ReadOnly {
+		t.Errorf("parsePRArgs() = %q, %+v, %v", prNum, opts, err)
+	}
+
+	for _, args := range [][]string{{"1", "2"}, {"--bogus"}} {
+		if _, _, err := parsePRArgs(args); err == nil {
+			t.Errorf("parsePRArgs(%v) expected error", args)
+		}
+	}
+}
@@ -3,7 +3,6 @@ package ai
 
 import (
 	"bufio"
-	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -11,8 +10,14 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/kaeawc/auto-worktree/internal/git"
+	"github.com/kaeawc/auto-worktree/internal/interrupt"
+	"github.com/kaeawc/auto-worktree/internal/limits"
+	"github.com/kaeawc/auto-worktree/internal/offline"
+	"github.com/kaeawc/auto-worktree/internal/redact"
+	"github.com/kaeawc/auto-worktree/internal/remote"
 )
 
 // AI tool config keys
@@ -29,6 +34,10 @@ type Tool struct {
 	ConfigKey     string   // Config value (e.g., "claude")
 	Command       []string // Command to start fresh session
 	ResumeCommand []string // Command to resume existing session
+	// SessionIDFlag starts a conversation with an ID chosen by the caller, when the tool supports it
+	SessionIDFlag string
+	// ResumeIDCommand resumes a specific conversation when the ID is appended
+	ResumeIDCommand []string
 }
 
 // InstallInstructions contains installation information for an AI tool
@@ -86,19 +95,22 @@ func (r *Resolver) getTool(name string) *Tool {
 	case toolClaude:
 		if commandExists(toolClaude) {
 			return &Tool{
-				Name:          "Claude Code",
-				ConfigKey:     toolClaude,
-				Command:       []string{toolClaude, "--dangerously-skip-permissions"},
-				ResumeCommand: []string{toolClaude, "--dangerously-skip-permissions", "--continue"},
+				Name:            "Claude Code",
+				ConfigKey:       toolClaude,
+				Command:         []string{toolClaude, "--dangerously-skip-permissions"},
+				ResumeCommand:   []string{toolClaude, "--dangerously-skip-permissions", "--continue"},
+				SessionIDFlag:   "--session-id",
+				ResumeIDCommand: []string{toolClaude, "--dangerously-skip-permissions", "--resume"},
 			}
 		}
 	case toolCodex:
 		if commandExists(toolCodex) {
 			return &Tool{
-				Name:          "Codex",
-				ConfigKey:     toolCodex,
-				Command:       []string{toolCodex, "--yolo"},
-				ResumeCommand: []string{toolCodex, "resume", "--last"},
+				Name:            "Codex",
+				ConfigKey:       toolCodex,
+				Command:         []string{toolCodex, "--yolo"},
+				ResumeCommand:   []string{toolCodex, "resume", "--last"},
+				ResumeIDCommand: []string{toolCodex, "resume"},
 			}
 		}
 	case toolGemini:
@@ -138,7 +150,7 @@ func (r *Resolver) ListAvailable() []Tool {
 }
 
 // CommandWithContext returns the command to run with an initial context/prompt.
-// The context is passed as a positional argument to the AI tool.
+// The context is passed, with secrets redacted, as a positional argument to the AI tool.
 func (t *Tool) CommandWithContext(context string) []string {
 	if context == "" {
 		return t.Command
@@ -148,7 +160,7 @@ func (t *Tool) CommandWithContext(context string) []string {
 	cmd := make([]string, len(t.Command), len(t.Command)+1)
 	copy(cmd, t.Command)
 
-	return append(cmd, context)
+	return append(cmd, redact.String(context))
 }
 
 // ResumeCommandWithContext returns the resume command with optional context.
@@ -160,7 +172,52 @@ func (t *Tool) ResumeCommandWithContext(context string) []string {
 	cmd := make([]string, len(t.ResumeCommand), len(t.ResumeCommand)+1)
 	copy(cmd, t.ResumeCommand)
 
-	return append(cmd, context)
+	return append(cmd, redact.String(context))
+}
+
+// StartCommandWithID is CommandWithContext for a new conversation with the given
+// ID, so it can be resumed by ID later. Tools that cannot be given an ID start
+// as usual.
+func (t *Tool) StartCommandWithID(conversationID, context string) []string {
+	if t.SessionIDFlag == "" || conversationID == "" {
+		return t.CommandWithContext(context)
+	}
+
+	cmd := make([]string, 0, len(t.Command)+3)
+	cmd = append(append(cmd, t.Command...), t.SessionIDFlag, conversationID)
+
+	if context != "" {
+		cmd = append(cmd, redact.String(context))
+	}
+
+	return cmd
+}
+
+// ResumeConversationCommand returns the command that continues the conversation
+// with the given ID, or nil when the tool cannot resume by ID
+func (t *Tool) ResumeConversationCommand(conversationID, context string) []string {
+	if len(t.ResumeIDCommand) == 0 || conversationID == "" {
+		return nil
+	}
+
+	cmd := make([]string, 0, len(t.ResumeIDCommand)+2)
+	cmd = append(append(cmd, t.ResumeIDCommand...), conversationID)
+
+	if context != "" {
+		cmd = append(cmd, redact.String(context))
+	}
+
+	return cmd
+}
+
+// LatestConversationID finds the most recent conversation the tool recorded for
+// worktreePath, for tools that choose their own IDs. It returns "" when there is none.
+func LatestConversationID(toolKey, worktreePath string) string {
+	if toolKey != toolCodex {
+		return ""
+	}
+
+	return latestCodexSessionID(worktreePath)
 }
 
 // HasExistingSession checks if there's an existing AI session in the given directory
@@ -189,6 +246,7 @@ func HasExistingSession(worktreePath string) bool {
 type codexSessionMeta struct {
 	Type    string `json:"type"`
 	Payload struct {
+		ID  string `json:"id"`
 		Cwd string `json:"cwd"`
 	} `json:"payload"`
 }
@@ -236,10 +294,49 @@ func getCodexSessionsDir() string {
 	return filepath.Join(codexHome, "sessions")
 }
 
+// latestCodexSessionID returns the ID of the newest Codex session started in worktreePath
+func latestCodexSessionID(worktreePath string) string {
+	sessionsDir := getCodexSessionsDir()
+	if sessionsDir == "" {
+		return ""
+	}
+
+	var (
+		latestID   string
+		latestTime time.Time
+	)
+
+	_ = filepath.WalkDir(sessionsDir, func(path string, entry os.DirEntry, walkErr error) error { //nolint:errcheck // best-effort scan
+		if walkErr != nil || entry.IsDir() || filepath.Ext(entry.Name()) != ".jsonl" {
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil || !info.ModTime().After(latestTime) {
+			return nil
+		}
+
+		if id, ok := codexSessionID(path, worktreePath); ok && id != "" {
+			latestID, latestTime = id, info.ModTime()
+		}
+
+		return nil
+	})
+
+	return latestID
+}
+
 func checkCodexSessionFile(path, worktreePath string) bool {
+	_, ok := codexSessionID(path, worktreePath)
+	return ok
+}
+
+// codexSessionID reports whether the Codex session file was started in
+// worktreePath, and the session's ID when the file records one
+func codexSessionID(path, worktreePath string) (string, bool) {
 	file, err := os.Open(path) //nolint:gosec // path comes from filepath.WalkDir
 	if err != nil {
-		return false
+		return "", false
 	}
 
 	defer file.Close() //nolint:errcheck // read-only file, error on close is not actionable
@@ -268,11 +365,11 @@ func checkCodexSessionFile(path, worktreePath string) bool {
 		}
 
 		if meta.Type == "session_meta" && meta.Payload.Cwd == worktreePath {
-			return true
+			return meta.Payload.ID, true
 		}
 	}
 
-	return false
+	return "", false
 }
 
 // GetInstallInstructions returns installation instructions for all supported AI tools
@@ -314,20 +411,26 @@ func GetInstallInstructions() []InstallInstructions {
 // This is used for non-interactive tasks like auto-selecting issues/PRs.
 // Returns the raw output from the AI tool.
 func (t *Tool) ExecutePrompt(prompt string) (string, error) {
+	if offline.Enabled() {
+		return "", offline.Skipped(t.Name)
+	}
+
 	// Build tool-specific command for one-shot prompt execution
-	ctx := context.Background()
+	ctx, cancel := limits.Context(interrupt.Context(), limits.ToolAI)
+	defer cancel()
+
 	var cmd *exec.Cmd
 
 	switch t.ConfigKey {
 	case toolClaude:
 		// Claude uses --print flag for non-interactive output
-		cmd = exec.CommandContext(ctx, toolClaude, "--print")
+		cmd = remote.Command(ctx, "", nil, toolClaude, "--print")
 	case toolGemini:
 		// Gemini uses --yolo flag to auto-approve actions
-		cmd = exec.CommandContext(ctx, toolGemini, "--yolo")
+		cmd = remote.Command(ctx, "", nil, toolGemini, "--yolo")
 	case toolCodex:
 		// Codex needs testing - using similar pattern to gemini
-		cmd = exec.CommandContext(ctx, toolCodex, "--yolo")
+		cmd = remote.Command(ctx, "", nil, toolCodex, "--yolo")
 	case toolJules:
 		// Jules doesn't support stdin piping for one-shot prompts
 		return "", fmt.Errorf("jules does not support one-shot prompt execution")
@@ -335,8 +438,8 @@ func (t *Tool) ExecutePrompt(prompt string) (string, error) {
 		return "", fmt.Errorf("unsupported AI tool for prompt execution: %s", t.ConfigKey)
 	}
 
-	// Set up stdin with the prompt
-	cmd.Stdin = strings.NewReader(prompt)
+	// Set up stdin with the prompt, minus any secrets in the diffs or logs it quotes
+	cmd.Stdin = strings.NewReader(redact.String(prompt))
 
 	// Capture both stdout and stderr
 	var stdout, stderr strings.Builder
@@ -344,7 +447,7 @@ func (t *Tool) ExecutePrompt(prompt string) (string, error) {
 	cmd.Stderr = &stderr
 
 	// Execute the command
-	err := cmd.Run()
+	err := limits.Wrap(ctx, limits.ToolAI, cmd.Run())
 	if err != nil {
 		// Include stderr in error message for debugging
 		stderrStr := stderr.String()
@@ -450,8 +553,7 @@ func isLinearID(s string) bool {
 	return isNumeric(suffix)
 }
 
-// commandExists checks if a command is available in PATH
+// commandExists checks if a command is available in PATH (on the --host machine when set)
 func commandExists(cmd string) bool {
-	_, err := exec.LookPath(cmd)
-	return err == nil
+	return remote.LookPath(cmd) == nil
 }
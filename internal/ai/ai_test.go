@@ -5,7 +5,9 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
+	"time"
 )
 
 func TestToolCommandWithContext(t *testing.T) {
@@ -181,6 +183,74 @@ func TestHasExistingSessionCodex(t *testing.T) {
 	}
 }
 
+func TestLatestConversationIDCodex(t *testing.T) {
+	tempDir := t.TempDir()
+	codexHome := filepath.Join(tempDir, "codex-home")
+	sessionDir := filepath.Join(codexHome, "sessions", "2026", "01", "01")
+	if err := os.MkdirAll(sessionDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	worktreePath := filepath.Join(tempDir, "worktree")
+	cwdJSON, err := json.Marshal(worktreePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	for i, id := range []string{"older", "newer"} {
+		sessionFile := filepath.Join(sessionDir, "rollout-"+id+".jsonl")
+		sessionLine := `{"type":"session_meta","payload":{"id":"` + id + `","cwd":` + string(cwdJSON) + `}}` + "\n"
+		if err := os.WriteFile(sessionFile, []byte(sessionLine), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		modTime := now.Add(time.Duration(i-2) * time.Hour)
+		if err := os.Chtimes(sessionFile, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Setenv("CODEX_HOME", codexHome)
+
+	if got := LatestConversationID(toolCodex, worktreePath); got != "newer" {
+		t.Errorf("LatestConversationID() = %q, want %q", got, "newer")
+	}
+
+	if got := LatestConversationID(toolCodex, filepath.Join(tempDir, "other")); got != "" {
+		t.Errorf("LatestConversationID() for another worktree = %q, want empty", got)
+	}
+}
+
+func TestToolConversationCommands(t *testing.T) {
+	claude := &Tool{
+		Command:         []string{"claude", "--dangerously-skip-permissions"},
+		SessionIDFlag:   "--session-id",
+		ResumeIDCommand: []string{"claude", "--dangerously-skip-permissions", "--resume"},
+	}
+
+	got := claude.StartCommandWithID("abc", "fix it")
+	want := []string{"claude", "--dangerously-skip-permissions", "--session-id", "abc", "fix it"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("StartCommandWithID() = %v, want %v", got, want)
+	}
+
+	got = claude.ResumeConversationCommand("abc", "")
+	want = []string{"claude", "--dangerously-skip-permissions", "--resume", "abc"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ResumeConversationCommand() = %v, want %v", got, want)
+	}
+
+	jules := &Tool{Command: []string{"jules"}, ResumeCommand: []string{"jules"}}
+	if got := jules.StartCommandWithID("abc", ""); !reflect.DeepEqual(got, []string{"jules"}) {
+		t.Errorf("StartCommandWithID() without ID support = %v, want the plain command", got)
+	}
+
+	if got := jules.ResumeConversationCommand("abc", ""); got != nil {
+		t.Errorf("ResumeConversationCommand() without ID support = %v, want nil", got)
+	}
+}
+
 func TestGetInstallInstructions(t *testing.T) {
 	instructions := GetInstallInstructions()
 
@@ -0,0 +1,280 @@
+package ai
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Usage is the tokens a conversation used and what they cost
+type Usage struct {
+	// Model is the model the conversation last used
+	Model string `json:"model,omitempty"`
+	// InputTokens excludes input read from the prompt cache
+	InputTokens      int64 `json:"inputTokens"`
+	OutputTokens     int64 `json:"outputTokens"`
+	CacheReadTokens  int64 `json:"cacheReadTokens,omitempty"`
+	CacheWriteTokens int64 `json:"cacheWriteTokens,omitempty"`
+	// CostUSD is the cost the tool reported or, when it reports none, an
+	// estimate from the model's list prices; zero for unknown models
+	CostUSD float64 `json:"costUsd,omitempty"`
+	// Estimated is set when CostUSD comes from list prices
+	Estimated bool      `json:"estimated,omitempty"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// Tokens returns the total tokens used
+func (u *Usage) Tokens() int64 {
+	return u.InputTokens + u.OutputTokens + u.CacheReadTokens + u.CacheWriteTokens
+}
+
+// Add adds other's tokens and cost to u, keeping the later update time
+func (u *Usage) Add(other *Usage) {
+	u.InputTokens += other.InputTokens
+	u.OutputTokens += other.OutputTokens
+	u.CacheReadTokens += other.CacheReadTokens
+	u.CacheWriteTokens += other.CacheWriteTokens
+	u.CostUSD += other.CostUSD
+	u.Estimated = u.Estimated || other.Estimated
+
+	if other.UpdatedAt.After(u.UpdatedAt) {
+		u.UpdatedAt = other.UpdatedAt
+	}
+}
+
+// modelPrice is a model's list price in USD per million tokens
+type modelPrice struct {
+	prefix                               string
+	input, output, cacheRead, cacheWrite float64
+}
+
+// modelPrices are checked in order, so more specific prefixes come first
+var modelPrices = []modelPrice{
+	{prefix: "claude-opus-4-5", input: 5, output: 25, cacheRead: 0.5, cacheWrite: 6.25},
+	{prefix: "claude-opus", input: 15, output: 75, cacheRead: 1.5, cacheWrite: 18.75},
+	{prefix: "claude-sonnet", input: 3, output: 15, cacheRead: 0.3, cacheWrite: 3.75},
+	{prefix: "claude-3-5-sonnet", input: 3, output: 15, cacheRead: 0.3, cacheWrite: 3.75},
+	{prefix: "claude-3-7-sonnet", input: 3, output: 15, cacheRead: 0.3, cacheWrite: 3.75},
+	{prefix: "claude-haiku-4", input: 1, output: 5, cacheRead: 0.1, cacheWrite: 1.25},
+	{prefix: "claude-3-5-haiku", input: 0.8, output: 4, cacheRead: 0.08, cacheWrite: 1},
+	{prefix: "gpt-5", input: 1.25, output: 10, cacheRead: 0.125},
+}
+
+// estimateCost prices tokens at the model's list price, or 0 when the model is unknown
+func estimateCost(model string, input, output, cacheRead, cacheWrite int64) float64 {
+	for _, p := range modelPrices {
+		if strings.HasPrefix(model, p.prefix) {
+			return (float64(input)*p.input + float64(output)*p.output +
+				float64(cacheRead)*p.cacheRead + float64(cacheWrite)*p.cacheWrite) / 1e6
+		}
+	}
+
+	return 0
+}
+
+// ConversationUsage reads the usage of a conversation from the transcript the
+// tool keeps: Claude Code's project transcripts and Codex's session rollouts.
+// It returns nil when the tool does not record usage or the transcript is gone.
+func ConversationUsage(toolKey, conversationID string) *Usage {
+	if conversationID == "" {
+		return nil
+	}
+
+	switch toolKey {
+	case toolClaude:
+		return claudeUsage(conversationID)
+	case toolCodex:
+		return codexUsage(conversationID)
+	}
+
+	return nil
+}
+
+// getClaudeProjectsDir returns where Claude Code keeps its transcripts
+func getClaudeProjectsDir() string {
+	configDir := os.Getenv("CLAUDE_CONFIG_DIR")
+
+	if configDir == "" {
+		homeDir := os.Getenv("HOME")
+		if homeDir == "" {
+			return ""
+		}
+
+		configDir = filepath.Join(homeDir, ".claude")
+	}
+
+	return filepath.Join(configDir, "projects")
+}
+
+// claudeTranscriptLine is the part of a Claude Code transcript line with usage
+type claudeTranscriptLine struct {
+	Type    string  `json:"type"`
+	CostUSD float64 `json:"costUSD"`
+	Message struct {
+		ID    string `json:"id"`
+		Model string `json:"model"`
+		Usage *struct {
+			InputTokens              int64 `json:"input_tokens"`
+			OutputTokens             int64 `json:"output_tokens"`
+			CacheCreationInputTokens int64 `json:"cache_creation_input_tokens"`
+			CacheReadInputTokens     int64 `json:"cache_read_input_tokens"`
+		} `json:"usage"`
+	} `json:"message"`
+}
+
+// claudeUsage sums the usage of the assistant messages in a Claude Code
+// transcript. The transcript is <projects>/<encoded cwd>/<id>.jsonl, and the
+// ID is a UUID, so the project directory need not be worked out.
+func claudeUsage(conversationID string) *Usage {
+	projectsDir := getClaudeProjectsDir()
+	if projectsDir == "" {
+		return nil
+	}
+
+	matches, err := filepath.Glob(filepath.Join(projectsDir, "*", conversationID+".jsonl"))
+	if err != nil || len(matches) == 0 {
+		return nil
+	}
+
+	usage := &Usage{}
+
+	// A message is written once per content block, each line repeating its usage
+	seen := map[string]bool{}
+
+	err = scanJSONLines(matches[0], func(data []byte) {
+		var line claudeTranscriptLine
+		if json.Unmarshal(data, &line) != nil || line.Type != "assistant" || line.Message.Usage == nil {
+			return
+		}
+
+		if line.Message.ID != "" {
+			if seen[line.Message.ID] {
+				return
+			}
+
+			seen[line.Message.ID] = true
+		}
+
+		u := line.Message.Usage
+		usage.InputTokens += u.InputTokens
+		usage.OutputTokens += u.OutputTokens
+		usage.CacheReadTokens += u.CacheReadInputTokens
+		usage.CacheWriteTokens += u.CacheCreationInputTokens
+
+		if line.CostUSD > 0 {
+			usage.CostUSD += line.CostUSD
+		} else if cost := estimateCost(line.Message.Model, u.InputTokens, u.OutputTokens,
+			u.CacheReadInputTokens, u.CacheCreationInputTokens); cost > 0 {
+			usage.CostUSD += cost
+			usage.Estimated = true
+		}
+
+		if line.Message.Model != "" && !strings.HasPrefix(line.Message.Model, "<") {
+			usage.Model = line.Message.Model
+		}
+	})
+	if err != nil {
+		return nil
+	}
+
+	usage.UpdatedAt = time.Now()
+
+	return usage
+}
+
+// codexRolloutLine is the part of a Codex rollout line with the model or token counts
+type codexRolloutLine struct {
+	Type    string `json:"type"`
+	Payload struct {
+		Type  string `json:"type"`
+		Model string `json:"model"`
+		Info  *struct {
+			TotalTokenUsage struct {
+				InputTokens       int64 `json:"input_tokens"`
+				CachedInputTokens int64 `json:"cached_input_tokens"`
+				OutputTokens      int64 `json:"output_tokens"`
+			} `json:"total_token_usage"`
+		} `json:"info"`
+	} `json:"payload"`
+}
+
+// codexUsage reads the running token total last recorded in a Codex rollout.
+// Rollouts are named rollout-<time>-<id>.jsonl.
+func codexUsage(conversationID string) *Usage {
+	sessionsDir := getCodexSessionsDir()
+	if sessionsDir == "" {
+		return nil
+	}
+
+	var rollout string
+
+	_ = filepath.WalkDir(sessionsDir, func(path string, entry os.DirEntry, walkErr error) error { //nolint:errcheck // best-effort scan
+		if walkErr == nil && !entry.IsDir() && strings.HasSuffix(entry.Name(), conversationID+".jsonl") {
+			rollout = path
+			return filepath.SkipAll
+		}
+
+		return nil
+	})
+
+	if rollout == "" {
+		return nil
+	}
+
+	usage := &Usage{}
+
+	err := scanJSONLines(rollout, func(data []byte) {
+		var line codexRolloutLine
+		if json.Unmarshal(data, &line) != nil {
+			return
+		}
+
+		switch {
+		case line.Type == "turn_context" && line.Payload.Model != "":
+			usage.Model = line.Payload.Model
+		case line.Type == "event_msg" && line.Payload.Type == "token_count" && line.Payload.Info != nil:
+			total := line.Payload.Info.TotalTokenUsage
+			usage.InputTokens = total.InputTokens - total.CachedInputTokens
+			usage.CacheReadTokens = total.CachedInputTokens
+			usage.OutputTokens = total.OutputTokens
+		}
+	})
+	if err != nil {
+		return nil
+	}
+
+	if usage.CostUSD = estimateCost(usage.Model, usage.InputTokens, usage.OutputTokens, usage.CacheReadTokens, 0); usage.CostUSD > 0 {
+		usage.Estimated = true
+	}
+
+	usage.UpdatedAt = time.Now()
+
+	return usage
+}
+
+// scanJSONLines calls fn with each line of a JSON lines file
+func scanJSONLines(path string, fn func([]byte)) error {
+	file, err := os.Open(path) //nolint:gosec // path is a transcript found by the caller
+	if err != nil {
+		return err
+	}
+
+	defer file.Close() //nolint:errcheck // read-only file, error on close is not actionable
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+
+	for scanner.Scan() {
+		fn(scanner.Bytes())
+	}
+
+	if err := scanner.Err(); err != nil && !errors.Is(err, bufio.ErrTooLong) {
+		return err
+	}
+
+	return nil
+}
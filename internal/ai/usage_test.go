@@ -0,0 +1,84 @@
+package ai
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestConversationUsageClaude(t *testing.T) {
+	configDir := t.TempDir()
+	projectDir := filepath.Join(configDir, "projects", "-tmp-worktree")
+	if err := os.MkdirAll(projectDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	// msg_1 is written once per content block, repeating its usage
+	transcript := `{"type":"user","message":{"role":"user","content":"hi"}}
+{"type":"assistant","message":{"id":"msg_1","model":"claude-sonnet-4-5-20250929","usage":{"input_tokens":1000,"output_tokens":500,"cache_creation_input_tokens":2000,"cache_read_input_tokens":10000}}}
+{"type":"assistant","message":{"id":"msg_1","model":"claude-sonnet-4-5-20250929","usage":{"input_tokens":1000,"output_tokens":500,"cache_creation_input_tokens":2000,"cache_read_input_tokens":10000}}}
+{"type":"assistant","message":{"id":"msg_2","model":"claude-sonnet-4-5-20250929","usage":{"input_tokens":100,"output_tokens":50}}}
+`
+	if err := os.WriteFile(filepath.Join(projectDir, "abc-123.jsonl"), []byte(transcript), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("CLAUDE_CONFIG_DIR", configDir)
+
+	usage := ConversationUsage(toolClaude, "abc-123")
+	if usage == nil {
+		t.Fatal("ConversationUsage() = nil")
+	}
+
+	if usage.InputTokens != 1100 || usage.OutputTokens != 550 || usage.CacheReadTokens != 10000 || usage.CacheWriteTokens != 2000 {
+		t.Errorf("ConversationUsage() = %+v", usage)
+	}
+
+	// 1100*3 + 550*15 + 10000*0.3 + 2000*3.75 per million
+	if want := 0.02205; math.Abs(usage.CostUSD-want) > 1e-9 || !usage.Estimated {
+		t.Errorf("CostUSD = %v (estimated %v), want %v estimated", usage.CostUSD, usage.Estimated, want)
+	}
+
+	if ConversationUsage(toolClaude, "missing") != nil {
+		t.Error("ConversationUsage() of a missing transcript should be nil")
+	}
+}
+
+func TestConversationUsageCodex(t *testing.T) {
+	codexHome := t.TempDir()
+	sessionDir := filepath.Join(codexHome, "sessions", "2026", "01", "01")
+	if err := os.MkdirAll(sessionDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	// token_count events carry a running total, so the last one counts
+	rollout := `{"type":"session_meta","payload":{"id":"sess-1","cwd":"/tmp/worktree"}}
+{"type":"turn_context","payload":{"model":"gpt-5-codex"}}
+{"type":"event_msg","payload":{"type":"token_count","info":{"total_token_usage":{"input_tokens":5000,"cached_input_tokens":1000,"output_tokens":300}}}}
+{"type":"event_msg","payload":{"type":"token_count","info":null}}
+{"type":"event_msg","payload":{"type":"token_count","info":{"total_token_usage":{"input_tokens":9000,"cached_input_tokens":4000,"output_tokens":700}}}}
+`
+	if err := os.WriteFile(filepath.Join(sessionDir, "rollout-2026-01-01T10-00-00-sess-1.jsonl"), []byte(rollout), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("CODEX_HOME", codexHome)
+
+	usage := ConversationUsage(toolCodex, "sess-1")
+	if usage == nil {
+		t.Fatal("ConversationUsage() = nil")
+	}
+
+	if usage.InputTokens != 5000 || usage.CacheReadTokens != 4000 || usage.OutputTokens != 700 || usage.Model != "gpt-5-codex" {
+		t.Errorf("ConversationUsage() = %+v", usage)
+	}
+
+	if !usage.Estimated || usage.CostUSD <= 0 {
+		t.Errorf("CostUSD = %v (estimated %v), want an estimate", usage.CostUSD, usage.Estimated)
+	}
+
+	if ConversationUsage(toolGemini, "sess-1") != nil {
+		t.Error("ConversationUsage() for a tool without transcripts should be nil")
+	}
+}
@@ -0,0 +1,112 @@
+// Package analytics keeps opt-in, local-only counts of which features are used.
+// Nothing recorded here ever leaves the machine: there is no network code in this package.
+package analytics
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/kaeawc/auto-worktree/internal/state"
+)
+
+// Usage is the anonymous usage count for a single feature (e.g. "issue", "menu:cleanup")
+type Usage struct {
+	Feature   string    `json:"feature"`
+	Count     int       `json:"count"`
+	FirstUsed time.Time `json:"firstUsed"`
+	LastUsed  time.Time `json:"lastUsed"`
+}
+
+// Store reads and writes usage counts in the state database
+type Store struct {
+	db *state.Store
+	mu sync.Mutex
+}
+
+// NewStore creates an analytics store backed by the state database at path
+func NewStore(path string) *Store {
+	return &Store{db: state.NewStore(path)}
+}
+
+// Record increments the usage count for a feature
+func (s *Store) Record(feature string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if feature == "" {
+		return fmt.Errorf("analytics feature name is empty")
+	}
+
+	err := s.db.Update(func(tx *state.Tx) error {
+		now := time.Now()
+		entry := Usage{Feature: feature, FirstUsed: now}
+
+		if data := tx.GetRaw(state.BucketAnalytics, feature); data != nil {
+			if err := json.Unmarshal(data, &entry); err != nil {
+				return fmt.Errorf("failed to parse analytics: %w", err)
+			}
+		}
+
+		entry.Count++
+		entry.LastUsed = now
+
+		data, err := json.Marshal(entry)
+		if err != nil {
+			return fmt.Errorf("failed to marshal analytics: %w", err)
+		}
+
+		return tx.PutRaw(state.BucketAnalytics, feature, data)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record analytics: %w", err)
+	}
+
+	return nil
+}
+
+// Summary returns all recorded features, most used first
+func (s *Store) Summary() ([]Usage, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var result []Usage
+
+	err := s.db.ForEach(state.BucketAnalytics, func(_ string, value []byte) error {
+		var u Usage
+		if err := json.Unmarshal(value, &u); err != nil {
+			return fmt.Errorf("failed to parse analytics: %w", err)
+		}
+
+		result = append(result, u)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+
+		return result[i].Feature < result[j].Feature
+	})
+
+	return result, nil
+}
+
+// Reset deletes all recorded usage
+func (s *Store) Reset() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.db.Clear(state.BucketAnalytics); err != nil {
+		return fmt.Errorf("failed to reset analytics: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package analytics
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestStoreRecordAndSummary(t *testing.T) {
+	store := NewStore(filepath.Join(t.TempDir(), "state.db"))
+
+	summary, err := store.Summary()
+	if err != nil || len(summary) != 0 {
+		t.Fatalf("Summary() on missing file = %v, %v; want empty", summary, err)
+	}
+
+	for _, feature := range []string{"issue", "list", "issue", "menu:cleanup", "issue", "list"} {
+		if err := store.Record(feature); err != nil {
+			t.Fatalf("Record(%q) error = %v", feature, err)
+		}
+	}
+
+	summary, err = store.Summary()
+	if err != nil {
+		t.Fatalf("Summary() error = %v", err)
+	}
+
+	want := []struct {
+		feature string
+		count   int
+	}{{"issue", 3}, {"list", 2}, {"menu:cleanup", 1}}
+
+	if len(summary) != len(want) {
+		t.Fatalf("Summary() returned %d features, want %d", len(summary), len(want))
+	}
+
+	for i, w := range want {
+		if summary[i].Feature != w.feature || summary[i].Count != w.count {
+			t.Errorf("summary[%d] = %s x%d, want %s x%d", i, summary[i].Feature, summary[i].Count, w.feature, w.count)
+		}
+
+		if summary[i].FirstUsed.IsZero() || summary[i].LastUsed.Before(summary[i].FirstUsed) {
+			t.Errorf("summary[%d] has invalid timestamps: %+v", i, summary[i])
+		}
+	}
+
+	if err := store.Record(""); err == nil {
+		t.Error("Record(\"\") expected error")
+	}
+}
+
+func TestStoreReset(t *testing.T) {
+	store := NewStore(filepath.Join(t.TempDir(), "state.db"))
+
+	if err := store.Reset(); err != nil {
+		t.Fatalf("Reset() on missing file error = %v", err)
+	}
+
+	if err := store.Record("list"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := store.Reset(); err != nil {
+		t.Fatalf("Reset() error = %v", err)
+	}
+
+	if summary, err := store.Summary(); err != nil || len(summary) != 0 {
+		t.Errorf("Summary() after Reset() = %v, %v; want empty", summary, err)
+	}
+}
@@ -0,0 +1,181 @@
+// Package check evaluates composable repository-hygiene assertions
+// (stale worktrees, unpushed merged branches, failed sessions, disk usage)
+// so they can be enforced from cron jobs or git hooks.
+package check
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/kaeawc/auto-worktree/internal/git"
+	"github.com/kaeawc/auto-worktree/internal/session"
+)
+
+// Status is the outcome of a single assertion
+type Status string
+
+// Assertion outcomes
+const (
+	StatusPass Status = "pass"
+	StatusFail Status = "fail"
+)
+
+// Snapshot is the repository state the assertions are evaluated against
+type Snapshot struct {
+	Worktrees []*git.Worktree
+	Sessions  []*session.Metadata
+	// DiskBytes is the total size of all worktrees, or -1 if it was not measured
+	DiskBytes int64
+	Now       time.Time
+}
+
+// Result is the outcome of one assertion
+type Result struct {
+	Name    string   `json:"name"`
+	Status  Status   `json:"status"`
+	Message string   `json:"message"`
+	Details []string `json:"details,omitempty"`
+}
+
+// Report is the outcome of every assertion in a run
+type Report struct {
+	Passed  bool     `json:"passed"`
+	Results []Result `json:"results"`
+}
+
+// Assertion checks one hygiene rule against a snapshot
+type Assertion interface {
+	Name() string
+	Check(s *Snapshot) Result
+}
+
+// Run evaluates every assertion; the report passes only if all of them pass
+func Run(s *Snapshot, assertions []Assertion) Report {
+	report := Report{Passed: true, Results: make([]Result, 0, len(assertions))}
+
+	for _, a := range assertions {
+		result := a.Check(s)
+		result.Name = a.Name()
+
+		if result.Status != StatusPass {
+			report.Passed = false
+		}
+
+		report.Results = append(report.Results, result)
+	}
+
+	return report
+}
+
+// result builds a pass result when there are no offenders, a fail result otherwise
+func result(offenders []string, passMessage, failFormat string) Result {
+	if len(offenders) == 0 {
+		return Result{Status: StatusPass, Message: passMessage}
+	}
+
+	return Result{Status: StatusFail, Message: fmt.Sprintf(failFormat, len(offenders)), Details: offenders}
+}
+
+// maxAge fails when a worktree has had no commits for longer than the limit
+type maxAge struct {
+	days int
+}
+
+// MaxAge asserts that no worktree is older than days since its last commit
+func MaxAge(days int) Assertion {
+	return maxAge{days: days}
+}
+
+func (a maxAge) Name() string {
+	return fmt.Sprintf("max-age %dd", a.days)
+}
+
+func (a maxAge) Check(s *Snapshot) Result {
+	limit := time.Duration(a.days) * 24 * time.Hour
+
+	var offenders []string
+
+	for _, wt := range s.Worktrees {
+		if age := s.Now.Sub(wt.LastCommitTime); !wt.LastCommitTime.IsZero() && age > limit {
+			offenders = append(offenders, fmt.Sprintf("%s (%dd)", filepath.Base(wt.Path), int(age.Hours()/24)))
+		}
+	}
+
+	return result(offenders, "no stale worktrees", "%d worktree(s) older than the limit")
+}
+
+// noUnpushedMerged fails when a merged branch still has commits that were never pushed
+type noUnpushedMerged struct{}
+
+// NoUnpushedMerged asserts that no merged branch has unpushed commits, which would be lost on cleanup
+func NoUnpushedMerged() Assertion {
+	return noUnpushedMerged{}
+}
+
+func (noUnpushedMerged) Name() string {
+	return "no-unpushed-merged"
+}
+
+func (noUnpushedMerged) Check(s *Snapshot) Result {
+	var offenders []string
+
+	for _, wt := range s.Worktrees {
+		if wt.IsBranchMerged && !wt.HasNoChanges && wt.UnpushedCount > 0 {
+			offenders = append(offenders, fmt.Sprintf("%s (%d unpushed)", wt.Branch, wt.UnpushedCount))
+		}
+	}
+
+	return result(offenders, "no merged branches with unpushed commits", "%d merged branch(es) with unpushed commits")
+}
+
+// noFailedSessions fails when any session is marked failed
+type noFailedSessions struct{}
+
+// NoFailedSessions asserts that no session is in the failed state
+func NoFailedSessions() Assertion {
+	return noFailedSessions{}
+}
+
+func (noFailedSessions) Name() string {
+	return "no-failed-sessions"
+}
+
+func (noFailedSessions) Check(s *Snapshot) Result {
+	var offenders []string
+
+	for _, m := range s.Sessions {
+		if m.Status == session.StatusFailed {
+			offenders = append(offenders, fmt.Sprintf("%s (%s)", m.SessionName, m.BranchName))
+		}
+	}
+
+	return result(offenders, "no failed sessions", "%d failed session(s)")
+}
+
+// maxDisk fails when the worktrees use more disk than the limit
+type maxDisk struct {
+	gigabytes float64
+}
+
+// MaxDisk asserts that all worktrees together use at most gigabytes of disk
+func MaxDisk(gigabytes float64) Assertion {
+	return maxDisk{gigabytes: gigabytes}
+}
+
+func (a maxDisk) Name() string {
+	return fmt.Sprintf("max-disk %gGB", a.gigabytes)
+}
+
+func (a maxDisk) Check(s *Snapshot) Result {
+	if s.DiskBytes < 0 {
+		return Result{Status: StatusFail, Message: "disk usage could not be measured"}
+	}
+
+	used := float64(s.DiskBytes) / (1 << 30)
+	if used > a.gigabytes {
+		return Result{Status: StatusFail, Message: fmt.Sprintf("worktrees use %.2fGB", used)}
+	}
+
+	return Result{Status: StatusPass, Message: fmt.Sprintf("worktrees use %.2fGB", used)}
+}
@@ -0,0 +1,84 @@
+package check
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kaeawc/auto-worktree/internal/git"
+	"github.com/kaeawc/auto-worktree/internal/session"
+)
+
+func TestRun(t *testing.T) {
+	now := time.Now()
+
+	snapshot := &Snapshot{
+		Now: now,
+		Worktrees: []*git.Worktree{
+			{Path: "/wt/fresh", Branch: "fresh", LastCommitTime: now.Add(-24 * time.Hour)},
+			{Path: "/wt/old", Branch: "old", LastCommitTime: now.Add(-40 * 24 * time.Hour)},
+			{Path: "/wt/merged", Branch: "merged", LastCommitTime: now, IsBranchMerged: true, UnpushedCount: 2},
+			{Path: "/wt/new", Branch: "new", LastCommitTime: now, IsBranchMerged: true, HasNoChanges: true, UnpushedCount: 1},
+		},
+		Sessions: []*session.Metadata{
+			{SessionName: "auto-worktree-fresh", Status: session.StatusRunning},
+			{SessionName: "auto-worktree-old", BranchName: "old", Status: session.StatusFailed},
+		},
+		DiskBytes: 3 << 30,
+	}
+
+	report := Run(snapshot, []Assertion{MaxAge(30), NoUnpushedMerged(), NoFailedSessions(), MaxDisk(5), MaxDisk(2)})
+
+	if report.Passed {
+		t.Fatal("report should fail")
+	}
+
+	want := []struct {
+		name    string
+		status  Status
+		details int
+	}{
+		{"max-age 30d", StatusFail, 1},
+		{"no-unpushed-merged", StatusFail, 1},
+		{"no-failed-sessions", StatusFail, 1},
+		{"max-disk 5GB", StatusPass, 0},
+		{"max-disk 2GB", StatusFail, 0},
+	}
+
+	for i, w := range want {
+		got := report.Results[i]
+		if got.Name != w.name || got.Status != w.status || len(got.Details) != w.details {
+			t.Errorf("result %d = %+v, want name %q status %s with %d details", i, got, w.name, w.status, w.details)
+		}
+	}
+
+	if report := Run(&Snapshot{Now: now, DiskBytes: -1}, []Assertion{MaxAge(30), NoFailedSessions()}); !report.Passed {
+		t.Errorf("empty repository should pass, got %+v", report)
+	}
+}
+
+func TestDirSize(t *testing.T) {
+	dir := t.TempDir()
+
+	if err := os.MkdirAll(filepath.Join(dir, "sub"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "a"), make([]byte, 100), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "sub", "b"), make([]byte, 50), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	size, err := DirSize(dir)
+	if err != nil {
+		t.Fatalf("DirSize() error = %v", err)
+	}
+
+	if size != 150 {
+		t.Errorf("DirSize() = %d, want 150", size)
+	}
+}
@@ -0,0 +1,36 @@
+package check
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+)
+
+// DirSize returns the total size of the regular files under path, without following symlinks
+func DirSize(path string) (int64, error) {
+	var total int64
+
+	err := filepath.WalkDir(path, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		total += info.Size()
+
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to measure %s: %w", path, err)
+	}
+
+	return total, nil
+}
@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kaeawc/auto-worktree/internal/ai"
+	"github.com/kaeawc/auto-worktree/internal/git"
+	"github.com/kaeawc/auto-worktree/internal/ui"
+)
+
+// minFileDiffShare is the least diff of one file worth sending; when the limit
+// can't give every file this much, the largest files are left out instead
+const minFileDiffShare = 512
+
+// confirmAIContext shows how much is about to be sent to the AI tool and, when
+// auto-worktree.ai-confirm-context is set, asks first. It returns false if the
+// user declines.
+func confirmAIContext(cfg *git.Config, tool *ai.Tool, what, prompt string) (bool, error) {
+	fmt.Printf("Sending %s (%d bytes) to %s\n", what, len(prompt), tool.Name)
+
+	if !cfg.GetAIConfirmContext() {
+		return true, nil
+	}
+
+	result, err := ui.Run(ui.NewConfirmModel(fmt.Sprintf("Send %d bytes of %s to %s?", len(prompt), what, tool.Name)))
+	if err != nil {
+		return false, fmt.Errorf("error getting confirmation: %w", err)
+	}
+
+	confirmed, ok := result.(ui.ConfirmModel)
+	if !ok {
+		return false, fmt.Errorf("unexpected model type")
+	}
+
+	return confirmed.GetChoice(), nil
+}
+
+// truncateDiff cuts a diff to about limit bytes, noting what was truncated.
+// The limit is shared between files so one large file, such as a lockfile,
+// can't crowd out the rest: small files are kept whole, large ones are cut at
+// a line boundary, and when there are too many files to show each usefully the
+// largest are listed by name only.
+func truncateDiff(diff string, limit int) string {
+	if len(diff) <= limit {
+		return diff
+	}
+
+	files := splitDiffFiles(diff)
+	shares := diffShares(files, limit)
+
+	var sb strings.Builder
+
+	var omitted []string
+
+	for i, file := range files {
+		switch share := shares[i]; {
+		case share >= len(file):
+			sb.WriteString(file)
+		case share == 0:
+			omitted = append(omitted, diffFileName(file))
+		default:
+			cut := file[:share]
+			if end := strings.LastIndexByte(cut, '\n'); end > 0 {
+				cut = cut[:end+1]
+			}
+
+			sb.WriteString(cut)
+
+			if !strings.HasSuffix(cut, "\n") {
+				sb.WriteString("\n")
+			}
+
+			fmt.Fprintf(&sb, "... (%d more bytes of %s truncated)\n", len(file)-len(cut), diffFileName(file))
+		}
+	}
+
+	if len(omitted) > 0 {
+		fmt.Fprintf(&sb, "... (diff truncated; %d more files not shown: %s)\n", len(omitted), strings.Join(omitted, ", "))
+	}
+
+	return sb.String()
+}
+
+// splitDiffFiles splits a unified diff into one section per file
+func splitDiffFiles(diff string) []string {
+	var files []string
+
+	for {
+		next := strings.Index(diff[1:], "\ndiff --git ")
+		if next < 0 {
+			return append(files, diff)
+		}
+
+		files = append(files, diff[:next+2])
+		diff = diff[next+2:]
+	}
+}
+
+// diffShares divides limit between files: the smallest are given what they
+// need first, and what they don't use goes to the larger ones
+func diffShares(files []string, limit int) []int {
+	order := make([]int, len(files))
+	for i := range order {
+		order[i] = i
+	}
+
+	sort.SliceStable(order, func(a, b int) bool { return len(files[order[a]]) < len(files[order[b]]) })
+
+	// Leave out the largest files until the rest can each get a useful share
+	kept := len(order)
+	for kept > 1 && limit/kept < minFileDiffShare {
+		kept--
+	}
+
+	shares := make([]int, len(files))
+	remaining := limit
+
+	for k, i := range order[:kept] {
+		shares[i] = min(len(files[i]), remaining/(kept-k))
+		remaining -= shares[i]
+	}
+
+	return shares
+}
+
+// diffFileName returns the path a diff section is for
+func diffFileName(file string) string {
+	header, _, _ := strings.Cut(file, "\n")
+	if !strings.HasPrefix(header, "diff --git ") {
+		return "the diff"
+	}
+
+	if i := strings.LastIndex(header, " b/"); i >= 0 {
+		return header[i+3:]
+	}
+
+	return strings.TrimPrefix(header, "diff --git ")
+}
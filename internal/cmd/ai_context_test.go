@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// fileDiff builds the diff of one file with n changed lines
+func fileDiff(path string, n int) string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "diff --git a/%s b/%s\n--- a/%s\n+++ b/%s\n@@ -1,%d +1,%d @@\n", path, path, path, path, n, n)
+
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&sb, "+line %d of %s\n", i, path)
+	}
+
+	return sb.String()
+}
+
+func TestTruncateDiffSharesLimitBetweenFiles(t *testing.T) {
+	small := fileDiff("main.go", 5)
+	lockfile := fileDiff("package-lock.json", 2000)
+
+	got := truncateDiff(small+lockfile, 2000)
+
+	if !strings.Contains(got, small) {
+		t.Errorf("small file was not kept whole:\n%s", got)
+	}
+
+	if !strings.Contains(got, "more bytes of package-lock.json truncated") {
+		t.Errorf("large file was not truncated by name:\n%s", got)
+	}
+
+	if len(got) > 2200 {
+		t.Errorf("len(truncateDiff()) = %d, want about 2000", len(got))
+	}
+
+	// Cuts fall on line boundaries
+	for _, line := range strings.Split(strings.TrimSuffix(got, "\n"), "\n") {
+		if strings.HasPrefix(line, "+line") && !strings.HasSuffix(line, "package-lock.json") && !strings.HasSuffix(line, "main.go") {
+			t.Errorf("partial line %q", line)
+		}
+	}
+}
+
+func TestTruncateDiffOmitsLargestFiles(t *testing.T) {
+	var diff strings.Builder
+
+	for i := 0; i < 10; i++ {
+		diff.WriteString(fileDiff(fmt.Sprintf("file%d.go", i), 10+i))
+	}
+
+	got := truncateDiff(diff.String(), 2*minFileDiffShare)
+
+	if !strings.Contains(got, "8 more files not shown") || !strings.Contains(got, "file9.go") {
+		t.Errorf("truncateDiff() did not list the omitted files:\n%s", got)
+	}
+
+	if !strings.Contains(got, "diff --git a/file0.go") || !strings.Contains(got, "diff --git a/file1.go") {
+		t.Errorf("truncateDiff() did not keep the smallest files:\n%s", got)
+	}
+}
@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kaeawc/auto-worktree/internal/git"
+	"github.com/kaeawc/auto-worktree/internal/logging"
+	"github.com/kaeawc/auto-worktree/internal/state"
+)
+
+// aiRankCallsKey is the state.BucketCache key holding when the AI was asked
+// for rankings in the last hour
+const aiRankCallsKey = "ai-rank-calls"
+
+// errAIRankLimit is returned when the hourly limit on AI rankings is reached
+var errAIRankLimit = errors.New("AI ranking limit reached")
+
+// aiRanking is the AI's choice of a repository's issues or PRs, best first
+type aiRanking struct {
+	IDs      []string  `json:"ids"`
+	RankedAt time.Time `json:"rankedAt"`
+}
+
+// aiRankingKey is the state.BucketCache key for the ranking of kind ("issues", "prs") in a repository
+func aiRankingKey(kind, repoPath string) string {
+	return "ai-rank:" + kind + ":" + repoPath
+}
+
+// Note says how old the ranking is, e.g. "ranked by AI 12m ago"
+func (r *aiRanking) Note(now time.Time) string {
+	age := now.Sub(r.RankedAt)
+	if age < time.Minute {
+		return "ranked by AI just now"
+	}
+
+	return "ranked by AI " + formatAge(age) + " ago"
+}
+
+// aiRanker caches AI rankings for a while and limits how often the AI is
+// asked for one, so opening a menu repeatedly doesn't run up cost and wait
+type aiRanker struct {
+	// store is nil when the state database can't be opened: then every
+	// ranking is asked for, as before rankings were cached
+	store    *state.Store
+	ttl      time.Duration
+	maxCalls int
+	now      func() time.Time
+}
+
+// newAIRanker creates a ranker with the configured cache TTL and hourly limit
+func newAIRanker(config *git.Config) *aiRanker {
+	ranker := &aiRanker{ttl: config.GetAIRankCacheTTL(), maxCalls: config.GetAIRankMaxCalls(), now: time.Now}
+
+	if store, err := openStateStore(); err == nil {
+		ranker.store = store
+	} else {
+		logging.Debug("AI rankings won't be cached", "err", err)
+	}
+
+	return ranker
+}
+
+// Rank returns the ranking cached under key while it is younger than the TTL,
+// or asks rank for a new one. Past the hourly limit it returns the cached
+// ranking, however old (nil if there is none), with errAIRankLimit.
+func (r *aiRanker) Rank(key string, rank func() ([]string, error)) (*aiRanking, error) {
+	if r.store == nil {
+		ids, err := rank()
+		if err != nil {
+			return nil, err
+		}
+
+		return &aiRanking{IDs: ids, RankedAt: r.now()}, nil
+	}
+
+	now := r.now()
+
+	var cached *aiRanking
+
+	var ranking aiRanking
+	if err := r.store.Get(state.BucketCache, key, &ranking); err == nil {
+		cached = &ranking
+	} else if !errors.Is(err, state.ErrNotFound) {
+		logging.Debug("failed to read cached AI ranking", "err", err)
+	}
+
+	if cached != nil && now.Sub(cached.RankedAt) < r.ttl {
+		return cached, nil
+	}
+
+	calls := r.recentCalls(now)
+	if len(calls) >= r.maxCalls {
+		return cached, fmt.Errorf("%w: %d in the last hour (%s is %d)", errAIRankLimit, len(calls), git.ConfigAIRankMaxCalls, r.maxCalls)
+	}
+
+	// The call costs the same whether or not it succeeds
+	if err := r.store.Put(state.BucketCache, aiRankCallsKey, append(calls, now)); err != nil {
+		logging.Debug("failed to record AI ranking call", "err", err)
+	}
+
+	ids, err := rank()
+	if err != nil {
+		return nil, err
+	}
+
+	fresh := &aiRanking{IDs: ids, RankedAt: now}
+
+	if len(ids) > 0 {
+		if err := r.store.Put(state.BucketCache, key, fresh); err != nil {
+			logging.Debug("failed to cache AI ranking", "err", err)
+		}
+	}
+
+	return fresh, nil
+}
+
+// recentCalls returns when rankings were asked for within the hour before now
+func (r *aiRanker) recentCalls(now time.Time) []time.Time {
+	var calls []time.Time
+	if err := r.store.Get(state.BucketCache, aiRankCallsKey, &calls); err != nil && !errors.Is(err, state.ErrNotFound) {
+		logging.Debug("failed to read AI ranking calls", "err", err)
+	}
+
+	recent := calls[:0]
+
+	for _, call := range calls {
+		if now.Sub(call) < time.Hour {
+			recent = append(recent, call)
+		}
+	}
+
+	return recent
+}
+
+// rankedOrder returns the items with the given IDs, in the order of ids;
+// IDs that match no item, such as a closed issue, are skipped
+func rankedOrder[T any](items []T, ids []string, id func(T) string) []T {
+	selected := make([]T, 0, len(ids))
+
+	for _, want := range ids {
+		for _, item := range items {
+			if id(item) == want {
+				selected = append(selected, item)
+				break
+			}
+		}
+	}
+
+	return selected
+}
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"errors"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/kaeawc/auto-worktree/internal/state"
+)
+
+func TestAIRankerRank(t *testing.T) {
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	ranker := &aiRanker{
+		store:    state.NewStore(filepath.Join(t.TempDir(), "state.db")),
+		ttl:      10 * time.Minute,
+		maxCalls: 2,
+		now:      func() time.Time { return now },
+	}
+
+	calls := 0
+	rank := func() ([]string, error) {
+		calls++
+		return []string{"42", "7"}, nil
+	}
+
+	key := aiRankingKey("prs", "/src/app")
+
+	ranking, err := ranker.Rank(key, rank)
+	if err != nil || calls != 1 || !slices.Equal(ranking.IDs, []string{"42", "7"}) {
+		t.Fatalf("first Rank() = %+v, %v after %d calls", ranking, err, calls)
+	}
+
+	// Within the TTL the cached ranking is reused
+	now = now.Add(5 * time.Minute)
+	if ranking, err = ranker.Rank(key, rank); err != nil || calls != 1 || !ranking.RankedAt.Equal(now.Add(-5*time.Minute)) {
+		t.Fatalf("cached Rank() = %+v, %v after %d calls", ranking, err, calls)
+	}
+
+	if got := ranking.Note(now); got != "ranked by AI 5m ago" {
+		t.Errorf("Note() = %q", got)
+	}
+
+	// Once it expires the AI is asked again, up to the hourly limit
+	now = now.Add(15 * time.Minute)
+	if _, err = ranker.Rank(key, rank); err != nil || calls != 2 {
+		t.Fatalf("expired Rank() error = %v after %d calls", err, calls)
+	}
+
+	now = now.Add(20 * time.Minute)
+	ranking, err = ranker.Rank(key, rank)
+	if !errors.Is(err, errAIRankLimit) || calls != 2 || ranking == nil {
+		t.Fatalf("limited Rank() = %+v, %v after %d calls; want the stale ranking and errAIRankLimit", ranking, err, calls)
+	}
+
+	// An hour after the first call, one call is available again
+	now = now.Add(25 * time.Minute)
+	if _, err = ranker.Rank(key, rank); err != nil || calls != 3 {
+		t.Fatalf("Rank() an hour later error = %v after %d calls", err, calls)
+	}
+}
+
+func TestAIRankerRankFailure(t *testing.T) {
+	ranker := &aiRanker{
+		store:    state.NewStore(filepath.Join(t.TempDir(), "state.db")),
+		ttl:      time.Hour,
+		maxCalls: 10,
+		now:      time.Now,
+	}
+
+	failure := errors.New("tool crashed")
+	if _, err := ranker.Rank("k", func() ([]string, error) { return nil, failure }); !errors.Is(err, failure) {
+		t.Fatalf("Rank() error = %v, want %v", err, failure)
+	}
+
+	// Failed and empty rankings aren't cached
+	calls := 0
+	empty := func() ([]string, error) { calls++; return nil, nil }
+
+	for range 2 {
+		if _, err := ranker.Rank("k", empty); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if calls != 2 {
+		t.Errorf("rank called %d times, want 2", calls)
+	}
+}
+
+func TestRankedOrder(t *testing.T) {
+	got := rankedOrder([]int{1, 2, 3, 4}, []string{"3", "9", "1"}, func(n int) string { return string(rune('0' + n)) })
+	if !slices.Equal(got, []int{3, 1}) {
+		t.Errorf("rankedOrder() = %v, want [3 1]", got)
+	}
+}
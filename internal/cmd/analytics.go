@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/kaeawc/auto-worktree/internal/analytics"
+	"github.com/kaeawc/auto-worktree/internal/git"
+	"github.com/kaeawc/auto-worktree/internal/state"
+	"github.com/kaeawc/auto-worktree/internal/ui"
+)
+
+// openAnalyticsStore opens the analytics in the default state database
+func openAnalyticsStore() (*analytics.Store, error) {
+	path, err := state.DefaultPath()
+	if err != nil {
+		return nil, err
+	}
+
+	return analytics.NewStore(path), nil
+}
+
+// RecordUsage counts one use of a feature when analytics are enabled.
+// Only the feature name is stored (no arguments, paths, or repository names),
+// and recording is best-effort so it never interrupts a command.
+func RecordUsage(feature string) {
+	if !git.NewConfig("").GetAnalyticsEnabled() {
+		return
+	}
+
+	store, err := openAnalyticsStore()
+	if err != nil {
+		return
+	}
+
+	_ = store.Record(feature) //nolint:errcheck // best-effort analytics
+}
+
+// RunAnalyticsShow prints the recorded feature usage, most used first
+func RunAnalyticsShow() error {
+	store, err := openAnalyticsStore()
+	if err != nil {
+		return err
+	}
+
+	usage, err := store.Summary()
+	if err != nil {
+		return err
+	}
+
+	if !git.NewConfig("").GetAnalyticsEnabled() {
+		fmt.Println(ui.SubtleStyle.Render("Analytics are off. Enable with: auto-worktree analytics enable"))
+		fmt.Println()
+	}
+
+	if len(usage) == 0 {
+		fmt.Println("No usage recorded yet")
+		return nil
+	}
+
+	total := 0
+	for _, u := range usage {
+		total += u.Count
+	}
+
+	fmt.Printf("  %-24s %8s %7s  %s\n", "FEATURE", "COUNT", "SHARE", "LAST USED")
+
+	for _, u := range usage {
+		share := float64(u.Count) * 100 / float64(total)
+		fmt.Printf("  %-24s %8d %6.1f%%  %s\n", u.Feature, u.Count, share, u.LastUsed.Format("2006-01-02"))
+	}
+
+	fmt.Printf("\nTotal: %d use(s) across %d feature(s)\n", total, len(usage))
+	fmt.Println(ui.SubtleStyle.Render("Stored locally only; nothing is ever sent anywhere."))
+
+	return nil
+}
+
+// RunAnalyticsEnable turns on local-only analytics for the current user
+func RunAnalyticsEnable() error {
+	if err := git.NewConfig("").SetBool(git.ConfigAnalytics, true, git.ConfigScopeGlobal); err != nil {
+		return fmt.Errorf("failed to enable analytics: %w", err)
+	}
+
+	fmt.Printf("%s Local analytics enabled. Usage counts are stored only on this machine.\n", ui.SuccessStyle.Render("✓"))
+
+	return nil
+}
+
+// RunAnalyticsDisable turns off analytics; existing counts are kept until reset
+func RunAnalyticsDisable() error {
+	if err := git.NewConfig("").SetBool(git.ConfigAnalytics, false, git.ConfigScopeGlobal); err != nil {
+		return fmt.Errorf("failed to disable analytics: %w", err)
+	}
+
+	fmt.Printf("%s Local analytics disabled. Run 'auto-worktree analytics reset' to delete recorded counts.\n",
+		ui.SuccessStyle.Render("✓"))
+
+	return nil
+}
+
+// RunAnalyticsReset deletes all recorded usage counts
+func RunAnalyticsReset() error {
+	store, err := openAnalyticsStore()
+	if err != nil {
+		return err
+	}
+
+	if err := store.Reset(); err != nil {
+		return err
+	}
+
+	fmt.Printf("%s Analytics data deleted\n", ui.SuccessStyle.Render("✓"))
+
+	return nil
+}
@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/kaeawc/auto-worktree/internal/logging"
+	"github.com/kaeawc/auto-worktree/internal/secrets"
+	"github.com/kaeawc/auto-worktree/internal/ui"
+)
+
+// ApplyStoredCredentials exports tokens stored with 'auto-worktree auth' into
+// the environment, unless the variable is already set
+func ApplyStoredCredentials() {
+	store, err := secrets.DefaultStore()
+	if err != nil {
+		logging.Debug("credential store unavailable", "err", err)
+		return
+	}
+
+	applied, err := store.ApplyToEnv()
+	if err != nil {
+		logging.Warn("failed to load stored credentials", "err", err)
+		return
+	}
+
+	if len(applied) > 0 {
+		logging.Debug("loaded stored credentials", "credentials", applied)
+	}
+}
+
+// RunAuth lists the credentials that can be stored and where each one comes from
+func RunAuth() error {
+	store, err := secrets.DefaultStore()
+	if err != nil {
+		return err
+	}
+
+	stored, err := store.List()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("  %-10s %-18s %-10s %s\n", "NAME", "VARIABLE", "SOURCE", "DESCRIPTION")
+
+	for _, c := range secrets.Credentials {
+		source, style := "-", ui.SubtleStyle
+
+		switch backend, ok := stored[c.Name]; {
+		case ok:
+			source, style = backend, ui.SuccessStyle
+		case os.Getenv(c.Env) != "":
+			source, style = "env", ui.InfoStyle
+		}
+
+		fmt.Printf("  %-10s %-18s %s %s\n", c.Name, c.Env, style.Render(fmt.Sprintf("%-10s", source)), ui.SubtleStyle.Render(c.Description))
+	}
+
+	fmt.Println()
+
+	if keychain := secrets.SystemKeychain(); keychain != nil {
+		fmt.Printf("Tokens are stored in the %s.\n", keychain.Name())
+	} else {
+		fmt.Println(ui.WarningStyle.Render("⚠ " + secrets.FileBackendWarning + "."))
+	}
+
+	fmt.Println(ui.SubtleStyle.Render("Store one with: auto-worktree auth <name>    Remove it with: auto-worktree auth remove <name>"))
+
+	return nil
+}
+
+// RunAuthSet prompts for a token and stores it under name
+func RunAuthSet(name string) error {
+	credential, err := secrets.Lookup(name)
+	if err != nil {
+		return err
+	}
+
+	store, err := secrets.DefaultStore()
+	if err != nil {
+		return err
+	}
+
+	result, err := ui.Run(ui.NewInput(fmt.Sprintf("Enter your %s", credential.Description), credential.Env).Masked())
+	if err != nil {
+		return fmt.Errorf("error getting token input: %w", err)
+	}
+
+	input, ok := result.(ui.InputModel)
+	if !ok {
+		return fmt.Errorf("unexpected model type")
+	}
+
+	if input.Err() != nil {
+		return input.Err()
+	}
+
+	token := strings.TrimSpace(input.Value())
+	if token == "" {
+		return fmt.Errorf("no token entered")
+	}
+
+	backend, err := store.Set(credential.Name, token)
+	if err != nil {
+		return err
+	}
+
+	where := "in ~/.auto-worktree"
+	if backend == secrets.BackendKeychain {
+		where = "in the " + secrets.SystemKeychain().Name()
+	}
+
+	fmt.Printf("%s Stored %s token %s\n", ui.SuccessStyle.Render("✓"), credential.Name, where)
+
+	if backend == secrets.BackendFile {
+		fmt.Println(ui.WarningStyle.Render("⚠ " + secrets.FileBackendWarning))
+	}
+	fmt.Printf("  auto-worktree sets %s from it when the variable is not already set\n", credential.Env)
+
+	return nil
+}
+
+// RunAuthRemove deletes the token stored under name
+func RunAuthRemove(name string) error {
+	credential, err := secrets.Lookup(name)
+	if err != nil {
+		return err
+	}
+
+	store, err := secrets.DefaultStore()
+	if err != nil {
+		return err
+	}
+
+	if err := store.Delete(credential.Name); err != nil {
+		return err
+	}
+
+	fmt.Printf("%s Removed stored %s token\n", ui.SuccessStyle.Render("✓"), credential.Name)
+
+	return nil
+}
@@ -0,0 +1,192 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/kaeawc/auto-worktree/internal/git"
+	"github.com/kaeawc/auto-worktree/internal/session"
+	"github.com/kaeawc/auto-worktree/internal/ui"
+)
+
+// Branch drift fix choices
+const (
+	driftRelink  = "relink"
+	driftRestore = "restore"
+	driftSkip    = "skip"
+)
+
+// branchDriftCategory is the health check category for branch drift
+const branchDriftCategory = "branch-drift"
+
+// branchDrift is a managed worktree whose checked-out branch no longer matches
+// the branch recorded in its session metadata (e.g. after a manual `git switch`)
+type branchDrift struct {
+	WorktreePath   string
+	OriginalBranch string
+	CurrentBranch  string
+	Metadata       *session.Metadata
+}
+
+// findBranchDrift compares each worktree's current branch with its session metadata.
+// Detached worktrees are skipped since there is no branch to relink to.
+func findBranchDrift(worktrees []*git.Worktree, metadata []*session.Metadata) []branchDrift {
+	byPath := make(map[string]*session.Metadata, len(metadata))
+	for _, m := range metadata {
+		byPath[m.WorktreePath] = m
+	}
+
+	var drifts []branchDrift
+
+	for _, wt := range worktrees {
+		if wt.IsDetached || wt.Branch == "" {
+			continue
+		}
+
+		m, ok := byPath[wt.Path]
+		if !ok || m.BranchName == "" || m.BranchName == wt.Branch {
+			continue
+		}
+
+		drifts = append(drifts, branchDrift{
+			WorktreePath:   wt.Path,
+			OriginalBranch: m.BranchName,
+			CurrentBranch:  wt.Branch,
+			Metadata:       m,
+		})
+	}
+
+	return drifts
+}
+
+// detectBranchDrift loads worktrees and session metadata and returns any drift (best-effort)
+func detectBranchDrift(repo *git.Repository, sessionMgr *session.SessionManager) []branchDrift {
+	worktrees, err := repo.ListWorktrees()
+	if err != nil {
+		return nil
+	}
+
+	metadata, err := sessionMgr.LoadAllSessionMetadata()
+	if err != nil {
+		return nil
+	}
+
+	return findBranchDrift(worktrees, metadata)
+}
+
+// addBranchDriftIssues reports branch drift as warnings on the matching health check results
+func addBranchDriftIssues(results []*git.HealthCheckResult, drifts []branchDrift) {
+	for _, drift := range drifts {
+		for _, result := range results {
+			if result.WorktreePath != drift.WorktreePath {
+				continue
+			}
+
+			result.Issues = append(result.Issues, git.HealthCheckIssue{
+				Severity: git.SeverityWarning,
+				Category: branchDriftCategory,
+				Description: fmt.Sprintf("Branch changed from %s to %s outside auto-worktree",
+					drift.OriginalBranch, drift.CurrentBranch),
+				RepairHint: "Run 'auto-worktree list' to relink the metadata or restore the original branch",
+			})
+		}
+	}
+}
+
+// relinkedMetadata returns a copy of the metadata pointing at newBranch.
+// The session is renamed to match the new branch unless it is still running,
+// since a live tmux session can't be found under a different name.
+func relinkedMetadata(m *session.Metadata, newBranch string, sessionRunning bool) *session.Metadata {
+	relinked := *m
+	relinked.BranchName = newBranch
+
+	if !sessionRunning {
+		relinked.SessionName = session.GenerateSessionName(newBranch)
+	}
+
+	return &relinked
+}
+
+// interactiveTerminal reports whether list may prompt: stdin and stdout are
+// terminals and plain output is off, so scripts and pipes are never asked
+func interactiveTerminal() bool {
+	return !ui.IsPlain() && ui.IsTerminal(os.Stdin) && ui.IsTerminal(os.Stdout)
+}
+
+// promptForBranchDrift shows drifted worktrees and offers to relink or restore
+// each one; when interactive is false it only notes them
+func promptForBranchDrift(repo *git.Repository, sessionMgr *session.SessionManager, drifts []branchDrift, interactive bool) error {
+	fmt.Println()
+	fmt.Println(ui.WarningStyle.Render("Worktrees whose branch was changed manually:"))
+	fmt.Println()
+
+	for _, drift := range drifts {
+		fmt.Printf("  • %s: %s → %s\n", drift.WorktreePath, drift.OriginalBranch, drift.CurrentBranch)
+	}
+
+	if !interactive {
+		fmt.Println(ui.SubtleStyle.Render("Run 'auto-worktree list' in a terminal to relink or restore them."))
+		return nil
+	}
+
+	for _, drift := range drifts {
+		items := []ui.MenuItem{
+			ui.NewMenuItem("Relink to "+drift.CurrentBranch, "Update session metadata to follow the new branch", driftRelink),
+			ui.NewMenuItem("Restore "+drift.OriginalBranch, "Switch the worktree back to its original branch", driftRestore),
+			ui.NewMenuItem("Skip", "Leave this worktree as it is", driftSkip),
+		}
+
+		m, err := ui.Run(ui.NewMenu(fmt.Sprintf("Fix branch drift in %s", drift.WorktreePath), items))
+		if err != nil {
+			return fmt.Errorf("error running branch drift prompt: %w", err)
+		}
+
+		menuModel, ok := m.(ui.MenuModel)
+		if !ok {
+			return fmt.Errorf("unexpected model type")
+		}
+
+		switch menuModel.Choice() {
+		case driftRelink:
+			if err := relinkBranchDrift(sessionMgr, drift); err != nil {
+				fmt.Printf("  %s %v\n", ui.ErrorStyle.Render("✗"), err)
+				continue
+			}
+
+			fmt.Printf("  %s Metadata now follows %s\n", ui.SuccessStyle.Render("✓"), drift.CurrentBranch)
+		case driftRestore:
+			if err := repo.SwitchBranch(drift.WorktreePath, drift.OriginalBranch); err != nil {
+				fmt.Printf("  %s %v\n", ui.ErrorStyle.Render("✗"), err)
+				continue
+			}
+
+			fmt.Printf("  %s Restored %s\n", ui.SuccessStyle.Render("✓"), drift.OriginalBranch)
+		case "":
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// relinkBranchDrift rewrites a worktree's session metadata for its current branch
+func relinkBranchDrift(sessionMgr *session.SessionManager, drift branchDrift) error {
+	running, err := sessionMgr.HasSession(drift.Metadata.SessionName)
+	if err != nil {
+		running = false
+	}
+
+	relinked := relinkedMetadata(drift.Metadata, drift.CurrentBranch, running)
+
+	if err := sessionMgr.SaveSessionMetadata(relinked); err != nil {
+		return fmt.Errorf("failed to save session metadata: %w", err)
+	}
+
+	if relinked.SessionName != drift.Metadata.SessionName {
+		if err := sessionMgr.DeleteSessionMetadata(drift.Metadata.SessionName); err != nil {
+			return fmt.Errorf("failed to remove old session metadata: %w", err)
+		}
+	}
+
+	return nil
+}
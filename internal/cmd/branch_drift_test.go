@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/kaeawc/auto-worktree/internal/git"
+	"github.com/kaeawc/auto-worktree/internal/session"
+	"github.com/kaeawc/auto-worktree/internal/ui"
+)
+
+func TestFindBranchDrift(t *testing.T) {
+	worktrees := []*git.Worktree{
+		{Path: "/wt/a", Branch: "work/1-a"},
+		{Path: "/wt/b", Branch: "hotfix"},
+		{Path: "/wt/c", IsDetached: true},
+		{Path: "/wt/d", Branch: "work/4-d"},
+	}
+
+	metadata := []*session.Metadata{
+		{WorktreePath: "/wt/a", BranchName: "work/1-a"},
+		{WorktreePath: "/wt/b", BranchName: "work/2-b", SessionName: "auto-worktree-2-b"},
+		{WorktreePath: "/wt/c", BranchName: "work/3-c"},
+	}
+
+	drifts := findBranchDrift(worktrees, metadata)
+	if len(drifts) != 1 {
+		t.Fatalf("findBranchDrift() returned %d drifts, want 1: %+v", len(drifts), drifts)
+	}
+
+	drift := drifts[0]
+	if drift.WorktreePath != "/wt/b" || drift.OriginalBranch != "work/2-b" || drift.CurrentBranch != "hotfix" {
+		t.Errorf("findBranchDrift() = %+v", drift)
+	}
+}
+
+func TestRelinkedMetadata(t *testing.T) {
+	original := &session.Metadata{SessionName: "auto-worktree-2-b", BranchName: "work/2-b", WorktreePath: "/wt/b"}
+
+	relinked := relinkedMetadata(original, "work/5-e", false)
+	if relinked.BranchName != "work/5-e" || relinked.SessionName != "auto-worktree-5-e" {
+		t.Errorf("relinkedMetadata(stopped) = %+v", relinked)
+	}
+
+	if original.BranchName != "work/2-b" {
+		t.Errorf("relinkedMetadata() modified the original metadata")
+	}
+
+	relinked = relinkedMetadata(original, "work/5-e", true)
+	if relinked.SessionName != "auto-worktree-2-b" {
+		t.Errorf("relinkedMetadata(running) renamed the live session to %q", relinked.SessionName)
+	}
+}
+
+func TestAddBranchDriftIssues(t *testing.T) {
+	results := []*git.HealthCheckResult{{WorktreePath: "/wt/a", Healthy: true}, {WorktreePath: "/wt/b", Healthy: true}}
+
+	addBranchDriftIssues(results, []branchDrift{{WorktreePath: "/wt/b", OriginalBranch: "work/2-b", CurrentBranch: "hotfix"}})
+
+	if len(results[0].Issues) != 0 {
+		t.Errorf("unexpected issues on /wt/a: %+v", results[0].Issues)
+	}
+
+	if len(results[1].Issues) != 1 || results[1].Issues[0].Category != branchDriftCategory {
+		t.Errorf("expected one branch drift issue on /wt/b, got %+v", results[1].Issues)
+	}
+}
+
+func TestPromptForBranchDriftWithoutTerminalOnlyNotes(t *testing.T) {
+	drifts := []branchDrift{{WorktreePath: "/wt/feature", OriginalBranch: "feature", CurrentBranch: "other",
+		Metadata: &session.Metadata{SessionName: "feature", BranchName: "feature"}}}
+
+	// Nothing is prompted for, so neither the repository nor the sessions are touched
+	if err := promptForBranchDrift(nil, nil, drifts, false); err != nil {
+		t.Errorf("promptForBranchDrift() without a terminal = %v, want nil", err)
+	}
+}
+
+func TestInteractiveTerminalIsOffInPlainMode(t *testing.T) {
+	ui.SetPlain(true)
+	t.Cleanup(func() { ui.SetPlain(false) })
+
+	if interactiveTerminal() {
+		t.Error("interactiveTerminal() = true in plain mode, want false")
+	}
+}
@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/kaeawc/auto-worktree/internal/ai"
+	"github.com/kaeawc/auto-worktree/internal/git"
+	"github.com/kaeawc/auto-worktree/internal/logging"
+	"github.com/kaeawc/auto-worktree/internal/ui"
+)
+
+// branchSuggestionCount is how many branch names the AI tool is asked to propose
+const branchSuggestionCount = 3
+
+// randomBranchChoice is the menu action for falling back to a random branch name
+const randomBranchChoice = "__random__"
+
+// suggestBranchNameWithAI asks for a short task description and lets the user pick
+// one of the AI tool's branch name suggestions.
+// Returns "" when AI suggestions are disabled, unavailable, or the user wants a random name.
+func suggestBranchNameWithAI(repo *git.Repository) (string, error) {
+	if !repo.Config.GetAIBranchNames() {
+		return "", nil
+	}
+
+	tool, err := ai.NewResolver(repo.Config).Resolve()
+	if err != nil || tool.ConfigKey == "jules" {
+		return "", nil
+	}
+
+	m, err := ui.Run(ui.NewInput("Describe the task:", "short description, or leave empty for a random name"))
+	if err != nil {
+		return "", fmt.Errorf("failed to get input: %w", err)
+	}
+
+	descModel, ok := m.(ui.InputModel)
+	if !ok {
+		return "", fmt.Errorf("unexpected model type")
+	}
+
+	if descModel.Err() != nil {
+		return "", descModel.Err()
+	}
+
+	description := strings.TrimSpace(descModel.Value())
+	if description == "" {
+		return "", nil
+	}
+
+	fmt.Printf("Asking %s for branch names...\n", tool.Name)
+
+	output, err := tool.ExecutePrompt(buildBranchNamePrompt(description, branchSuggestionCount))
+	if err != nil {
+		logging.Warn("AI branch naming failed", "err", err)
+		fmt.Fprintf(os.Stderr, "Falling back to a random branch name\n")
+
+		return "", nil
+	}
+
+	suggestions := parseBranchSuggestions(output, branchSuggestionCount, repo.BranchExists)
+	if len(suggestions) == 0 {
+		logging.Warn("AI returned no usable branch names, falling back to a random name")
+		return "", nil
+	}
+
+	items := make([]ui.MenuItem, 0, len(suggestions)+1)
+	for _, name := range suggestions {
+		items = append(items, ui.NewMenuItem(name, "", name))
+	}
+
+	items = append(items, ui.NewMenuItem("Random name", "Generate a random branch name instead", randomBranchChoice))
+
+	m, err = ui.Run(ui.NewMenu("Select a branch name", items))
+	if err != nil {
+		return "", fmt.Errorf("failed to run branch name picker: %w", err)
+	}
+
+	menuModel, ok := m.(ui.MenuModel)
+	if !ok {
+		return "", fmt.Errorf("unexpected model type")
+	}
+
+	choice := menuModel.Choice()
+	if choice == "" {
+		return "", fmt.Errorf("canceled")
+	}
+
+	if choice == randomBranchChoice {
+		return "", nil
+	}
+
+	return choice, nil
+}
+
+// buildBranchNamePrompt builds the prompt asking for concise branch names for a task
+func buildBranchNamePrompt(description string, count int) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("Suggest %d concise git branch names for the following task:\n\n", count))
+	sb.WriteString(description)
+	sb.WriteString("\n\nUse lowercase words separated by hyphens, with an optional prefix such as 'feature/' or 'fix/'.\n")
+	sb.WriteString("Keep each name under 40 characters.\n")
+	sb.WriteString("Return ONLY the branch names, one per line, nothing else.")
+
+	return sb.String()
+}
+
+// listMarkerPattern matches bullet and numbered list prefixes ("- ", "1. ", "2) ")
+var listMarkerPattern = regexp.MustCompile(`^(?:[-*•]|\d+[.)])\s+`)
+
+// parseBranchSuggestions extracts sanitized, unique branch names from AI output,
+// skipping list markers, blank lines, and branches that already exist.
+func parseBranchSuggestions(output string, limit int, exists func(string) bool) []string {
+	var names []string
+
+	seen := make(map[string]bool)
+
+	for _, line := range strings.Split(output, "\n") {
+		line = listMarkerPattern.ReplaceAllString(strings.TrimSpace(line), "")
+		line = strings.Trim(line, "`'\" ")
+
+		if line == "" || strings.HasSuffix(line, ":") || strings.Contains(line, " ") {
+			continue
+		}
+
+		name := sanitizeSuggestedBranch(line)
+		if name == "" || seen[name] || (exists != nil && exists(name)) {
+			continue
+		}
+
+		seen[name] = true
+		names = append(names, name)
+
+		if len(names) >= limit {
+			break
+		}
+	}
+
+	return names
+}
+
+// sanitizeSuggestedBranch sanitizes each path segment of a branch name,
+// keeping prefixes like "feature/" intact
+func sanitizeSuggestedBranch(name string) string {
+	var segments []string
+
+	for _, segment := range strings.Split(name, "/") {
+		if segment = git.SanitizeBranchName(segment); segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
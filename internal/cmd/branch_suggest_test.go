@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseBranchSuggestions(t *testing.T) {
+	exists := func(name string) bool { return name == "fix/taken" }
+
+	tests := []struct {
+		name   string
+		output string
+		limit  int
+		want   []string
+	}{
+		{
+			name:   "plain lines",
+			output: "feature/add-dark-mode\nfix/login-spaces\nchore/bump-deps\n",
+			limit:  3,
+			want:   []string{"feature/add-dark-mode", "fix/login-spaces", "chore/bump-deps"},
+		},
+		{
+			name:   "list markers, backticks and preamble",
+			output: "Here are some names:\n1. `feature/Dark_Mode`\n2) fix/login\n- 404-page\n",
+			limit:  3,
+			want:   []string{"feature/dark-mode", "fix/login", "404-page"},
+		},
+		{
+			name:   "skips duplicates, existing branches and sentences",
+			output: "fix/taken\nfix/login\nfix/login\nThis is a sentence\nfeature/x\nfeature/y",
+			limit:  2,
+			want:   []string{"fix/login", "feature/x"},
+		},
+		{
+			name:   "nothing usable",
+			output: "I can't help with that.",
+			limit:  3,
+			want:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseBranchSuggestions(tt.output, tt.limit, exists)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseBranchSuggestions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildBranchNamePrompt(t *testing.T) {
+	prompt := buildBranchNamePrompt("add dark mode", 3)
+	if !strings.Contains(prompt, "add dark mode") || !strings.Contains(prompt, "Suggest 3") {
+		t.Errorf("unexpected prompt:\n%s", prompt)
+	}
+}
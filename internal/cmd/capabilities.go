@@ -0,0 +1,222 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+
+	"github.com/kaeawc/auto-worktree/internal/ai"
+	"github.com/kaeawc/auto-worktree/internal/git"
+	"github.com/kaeawc/auto-worktree/internal/interrupt"
+	"github.com/kaeawc/auto-worktree/internal/logging"
+	"github.com/kaeawc/auto-worktree/internal/remote"
+	"github.com/kaeawc/auto-worktree/internal/sandbox"
+	"github.com/kaeawc/auto-worktree/internal/session"
+	"github.com/kaeawc/auto-worktree/internal/ui"
+)
+
+// lookPath finds an executable on PATH; replaced in tests
+var lookPath = exec.LookPath
+
+// providerCLIs maps each provider to the CLI it shells out to
+var providerCLIs = map[string]string{
+	providerGitHub: "gh",
+	providerGitLab: "glab",
+	providerJira:   "jira",
+	providerLinear: "linear",
+}
+
+// Capabilities is the set of optional tools available to this invocation.
+// Flows consult it to downgrade features instead of failing or prompting to install mid-task.
+type Capabilities struct {
+	// Tmux is true when background sessions are possible
+	Tmux bool
+	// AITools lists the installed AI assistants by display name
+	AITools []string
+	// IssueProvider is the configured or detected issue tracker, or "" if none is usable
+	IssueProvider string
+	// CodeHost is the provider hosting pull requests, or "" if its CLI is missing
+	CodeHost string
+}
+
+// Degradation describes a feature running in a reduced mode because a tool is missing
+type Degradation struct {
+	Feature  string
+	Fallback string
+	Fix      string
+}
+
+var (
+	capabilitiesOnce   sync.Once
+	cachedCapabilities Capabilities
+)
+
+// currentCapabilities detects the available tools once per invocation
+func currentCapabilities(cfg *git.Config) Capabilities {
+	capabilitiesOnce.Do(func() {
+		cachedCapabilities = detectCapabilities(cfg)
+	})
+
+	return cachedCapabilities
+}
+
+// detectCapabilities checks PATH for every optional tool. It never runs the tools
+// themselves, so detection is fast and does not touch the network.
+func detectCapabilities(cfg *git.Config) Capabilities {
+	caps := Capabilities{}
+
+	// Sessions run where the repository is; provider CLIs always run here
+	if remote.Enabled() {
+		caps.Tmux = remote.LookPath("tmux") == nil
+	} else if _, err := lookPath("tmux"); err == nil {
+		caps.Tmux = true
+	}
+
+	for _, tool := range ai.NewResolver(cfg).ListAvailable() {
+		caps.AITools = append(caps.AITools, tool.Name)
+	}
+
+	caps.IssueProvider = cfg.GetIssueProvider()
+	if caps.IssueProvider == "" {
+		// Mirror autoDetectProvider's order
+		for _, candidate := range []string{providerGitHub, providerGitLab, providerJira} {
+			if hasProviderCLI(candidate) {
+				caps.IssueProvider = candidate
+				break
+			}
+		}
+	} else if !hasProviderCLI(caps.IssueProvider) {
+		caps.IssueProvider = ""
+	}
+
+	if codeHost := resolveCodeHostType(cfg); hasProviderCLI(codeHost) {
+		caps.CodeHost = codeHost
+	}
+
+	return caps
+}
+
+// hasProviderCLI reports whether the CLI for a provider is on PATH
+func hasProviderCLI(provider string) bool {
+	cli, ok := providerCLIs[provider]
+	if !ok {
+		return false
+	}
+
+	_, err := lookPath(cli)
+
+	return err == nil
+}
+
+// HasIssueWorkflows reports whether issue-based flows (issue, create) can run
+func (c Capabilities) HasIssueWorkflows() bool {
+	return c.IssueProvider != ""
+}
+
+// HasPRWorkflows reports whether pull request flows (pr, PR status, CI state) can run
+func (c Capabilities) HasPRWorkflows() bool {
+	return c.CodeHost != ""
+}
+
+// Degradations lists every feature running in a reduced mode, with how to restore it
+func (c Capabilities) Degradations(cfg *git.Config) []Degradation {
+	var result []Degradation
+
+	if !c.Tmux {
+		_, installCmd := getTmuxInstallInstructions()
+		result = append(result, Degradation{
+			Feature:  "Background sessions",
+			Fallback: "the AI tool runs in the foreground of this terminal",
+			Fix:      installCmd,
+		})
+	}
+
+	if len(c.AITools) == 0 {
+		result = append(result, Degradation{
+			Feature:  "AI assistant",
+			Fallback: "worktrees open with a plain shell; AI drafting and describe are unavailable",
+			Fix:      "install claude, codex, gemini, or jules",
+		})
+	}
+
+	if !c.HasIssueWorkflows() {
+		provider := cfg.GetIssueProvider()
+		if provider == "" {
+			provider = providerGitHub
+		}
+
+		result = append(result, Degradation{
+			Feature:  "Issue workflows",
+			Fallback: "plain branch workflows only (New Worktree)",
+			Fix:      "install the " + providerCLIs[provider] + " CLI",
+		})
+	}
+
+	if !c.HasPRWorkflows() {
+		codeHost := resolveCodeHostType(cfg)
+		result = append(result, Degradation{
+			Feature:  "Pull request workflows",
+			Fallback: "merge status from git only; no PR review, PR status, or CI state",
+			Fix:      "install the " + providerCLIs[codeHost] + " CLI",
+		})
+	}
+
+	return result
+}
+
+// startForegroundSession is the fallback when tmux is missing: it runs the AI tool
+// directly in this terminal from the worktree (or its scope), or moves the shell there
+// (see 'auto-worktree shell-init') or prints how to start working there.
+func startForegroundSession(config *git.Config, worktreePath, scope, aiContext string) error {
+	fmt.Println(ui.SubtleStyle.Render("\ntmux not found: running in the foreground (see 'auto-worktree doctor')"))
+
+	workDir := scopeWorkDir(worktreePath, scope)
+
+	aiCommand, _, err := resolveAICommand(config, aiContext, nil, workDir)
+	if err != nil {
+		logging.Warn("continuing without an AI tool", "err", err)
+	}
+
+	if len(aiCommand) == 0 {
+		showWorktreeDirectory("To start working", workDir)
+
+		return nil
+	}
+
+	// With the shell integration, the shell is left in the worktree once the tool exits
+	changeDirectoryOnExit(workDir)
+
+	if runtime := config.GetSandbox(); runtime != git.SandboxOff {
+		name := session.GenerateSessionName(filepath.Base(worktreePath))
+
+		wrapped, containerID, err := sandboxSession(config, runtime, name, worktreePath, workDir, aiCommand)
+		if err != nil {
+			return err
+		}
+
+		// Without a session to come back to, the container lasts as long as the tool
+		defer func() {
+			if err := sandbox.Remove(context.WithoutCancel(interrupt.Context()), runtime, containerID); err != nil {
+				logging.Warn("failed to remove sandbox container", "err", err)
+			}
+		}()
+
+		aiCommand = wrapped
+	} else {
+		aiCommand, _ = limitedCommand(session.GenerateSessionName(filepath.Base(worktreePath)), sessionLimits(config), aiCommand)
+	}
+
+	cmd := remote.InteractiveCommand(interrupt.Context(), workDir, aiCommand[0], aiCommand[1:]...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	if err := interrupt.Foreground(cmd.Run); err != nil {
+		return fmt.Errorf("AI tool exited with error: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kaeawc/auto-worktree/internal/git"
+)
+
+// withPath replaces lookPath so only the given executables are found
+func withPath(t *testing.T, installed ...string) {
+	t.Helper()
+
+	original := lookPath
+	t.Cleanup(func() { lookPath = original })
+
+	lookPath = func(name string) (string, error) {
+		for _, bin := range installed {
+			if bin == name {
+				return "/usr/bin/" + name, nil
+			}
+		}
+
+		return "", errors.New("not found")
+	}
+}
+
+func TestDetectCapabilities(t *testing.T) {
+	tests := []struct {
+		name          string
+		installed     []string
+		issueProvider string
+		wantTmux      bool
+		wantIssue     string
+		wantCodeHost  string
+	}{
+		{
+			name:         "everything installed",
+			installed:    []string{"tmux", "gh"},
+			wantTmux:     true,
+			wantIssue:    providerGitHub,
+			wantCodeHost: providerGitHub,
+		},
+		{
+			name:      "auto-detects gitlab",
+			installed: []string{"glab"},
+			wantIssue: providerGitLab,
+		},
+		{
+			name: "nothing installed",
+		},
+		{
+			name:          "configured provider without its CLI",
+			installed:     []string{"gh"},
+			issueProvider: providerJira,
+			wantIssue:     "",
+			wantCodeHost:  providerGitHub,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withPath(t, tt.installed...)
+
+			executor := git.NewFakeGitExecutor()
+			if tt.issueProvider != "" {
+				executor.SetResponse("config --local --get "+git.ConfigIssueProvider, tt.issueProvider)
+			}
+
+			caps := detectCapabilities(git.NewConfigWithExecutor("/repo", executor))
+
+			if caps.Tmux != tt.wantTmux {
+				t.Errorf("Tmux = %v, want %v", caps.Tmux, tt.wantTmux)
+			}
+
+			if caps.IssueProvider != tt.wantIssue {
+				t.Errorf("IssueProvider = %q, want %q", caps.IssueProvider, tt.wantIssue)
+			}
+
+			if caps.CodeHost != tt.wantCodeHost {
+				t.Errorf("CodeHost = %q, want %q", caps.CodeHost, tt.wantCodeHost)
+			}
+		})
+	}
+}
+
+func TestCapabilitiesDegradations(t *testing.T) {
+	cfg := git.NewConfigWithExecutor("/repo", git.NewFakeGitExecutor())
+
+	full := Capabilities{Tmux: true, AITools: []string{"Claude Code"}, IssueProvider: providerGitHub, CodeHost: providerGitHub}
+	if got := full.Degradations(cfg); len(got) != 0 {
+		t.Errorf("Degradations() with every tool = %v, want none", got)
+	}
+
+	got := Capabilities{}.Degradations(cfg)
+
+	features := make(map[string]Degradation)
+	for _, d := range got {
+		features[d.Feature] = d
+	}
+
+	for _, feature := range []string{"Background sessions", "AI assistant", "Issue workflows", "Pull request workflows"} {
+		if _, ok := features[feature]; !ok {
+			t.Errorf("Degradations() missing %q", feature)
+		}
+	}
+
+	if fix := features["Issue workflows"].Fix; fix != "install the gh CLI" {
+		t.Errorf("Issue workflows fix = %q, want the gh CLI", fix)
+	}
+}
+
+func TestBuildMenuItems(t *testing.T) {
+	values := func(caps Capabilities) map[string]bool {
+		result := make(map[string]bool)
+		for _, item := range buildMenuItems(caps) {
+			result[item.Action()] = true
+		}
+
+		return result
+	}
+
+	full := values(Capabilities{Tmux: true, IssueProvider: providerGitHub, CodeHost: providerGitHub})
+	for _, value := range []string{"new", "issue", "create", "pr", "feedback", "ci", "sessions", "cleanup"} {
+		if !full[value] {
+			t.Errorf("menu with every tool is missing %q", value)
+		}
+	}
+
+	minimal := values(Capabilities{})
+	for _, value := range []string{"issue", "create", "pr", "feedback", "ci", "sessions"} {
+		if minimal[value] {
+			t.Errorf("menu without tools should not offer %q", value)
+		}
+	}
+
+	if !minimal["new"] || !minimal["list"] {
+		t.Error("plain branch workflows should always be offered")
+	}
+}
@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kaeawc/auto-worktree/internal/check"
+	"github.com/kaeawc/auto-worktree/internal/git"
+	"github.com/kaeawc/auto-worktree/internal/session"
+	"github.com/kaeawc/auto-worktree/internal/ui"
+)
+
+// DefaultCheckMaxAgeDays is the stale-worktree limit used when no assertions are selected
+const DefaultCheckMaxAgeDays = 30
+
+// ErrChecksFailed is returned by RunCheck when at least one assertion fails
+var ErrChecksFailed = errors.New("hygiene checks failed")
+
+// CheckOptions selects the assertions run by `auto-worktree check`.
+// When none are selected, the default set is used (max age, unpushed merged, failed sessions).
+type CheckOptions struct {
+	MaxAgeDays       int
+	NoUnpushedMerged bool
+	NoFailedSessions bool
+	MaxDiskGB        float64
+	JSON             bool
+}
+
+// assertions returns the selected assertions, or the default set if none were selected
+func (o CheckOptions) assertions() []check.Assertion {
+	var result []check.Assertion
+
+	if o.MaxAgeDays > 0 {
+		result = append(result, check.MaxAge(o.MaxAgeDays))
+	}
+
+	if o.NoUnpushedMerged {
+		result = append(result, check.NoUnpushedMerged())
+	}
+
+	if o.NoFailedSessions {
+		result = append(result, check.NoFailedSessions())
+	}
+
+	if o.MaxDiskGB > 0 {
+		result = append(result, check.MaxDisk(o.MaxDiskGB))
+	}
+
+	if len(result) == 0 {
+		result = []check.Assertion{check.MaxAge(DefaultCheckMaxAgeDays), check.NoUnpushedMerged(), check.NoFailedSessions()}
+	}
+
+	return result
+}
+
+// RunCheck evaluates repository hygiene assertions and prints the results.
+// It returns ErrChecksFailed if any assertion fails, so callers can map it to an exit code.
+func RunCheck(opts CheckOptions) error {
+	repo, err := git.NewRepository()
+	if err != nil {
+		return fmt.Errorf("error: %w", err)
+	}
+
+	worktrees, err := repo.ListWorktreesWithMergeStatusExcludingMain()
+	if err != nil {
+		return fmt.Errorf("error listing worktrees: %w", err)
+	}
+
+	sessions, err := session.NewManager().LoadAllSessionMetadata()
+	if err != nil {
+		return fmt.Errorf("failed to load session metadata: %w", err)
+	}
+
+	snapshot := &check.Snapshot{Worktrees: worktrees, Sessions: sessions, DiskBytes: -1, Now: time.Now()}
+
+	if opts.MaxDiskGB > 0 {
+		snapshot.DiskBytes = measureWorktreeDisk(worktrees)
+	}
+
+	report := check.Run(snapshot, opts.assertions())
+
+	if opts.JSON {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal check report: %w", err)
+		}
+
+		fmt.Println(string(data))
+	} else {
+		printCheckReport(report)
+	}
+
+	if !report.Passed {
+		return ErrChecksFailed
+	}
+
+	return nil
+}
+
+// measureWorktreeDisk sums the size of every worktree, or returns -1 if any can't be measured
+func measureWorktreeDisk(worktrees []*git.Worktree) int64 {
+	var total int64
+
+	for _, wt := range worktrees {
+		size, err := check.DirSize(wt.Path)
+		if err != nil {
+			return -1
+		}
+
+		total += size
+	}
+
+	return total
+}
+
+// printCheckReport prints one line per assertion with the offending worktrees or sessions
+func printCheckReport(report check.Report) {
+	for _, r := range report.Results {
+		mark := ui.SuccessStyle.Render("✓")
+		if r.Status != check.StatusPass {
+			mark = ui.ErrorStyle.Render("✗")
+		}
+
+		fmt.Printf("%s %-22s %s\n", mark, r.Name, r.Message)
+
+		for _, detail := range r.Details {
+			fmt.Printf("    • %s\n", detail)
+		}
+	}
+
+	fmt.Println()
+
+	if report.Passed {
+		fmt.Println(ui.SuccessStyle.Render("All checks passed"))
+	} else {
+		fmt.Println(ui.ErrorStyle.Render("Some checks failed"))
+	}
+}
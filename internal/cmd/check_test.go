@@ -0,0 +1,24 @@
+package cmd
+
+import "testing"
+
+func TestCheckOptionsAssertions(t *testing.T) {
+	names := func(opts CheckOptions) []string {
+		var result []string
+		for _, a := range opts.assertions() {
+			result = append(result, a.Name())
+		}
+
+		return result
+	}
+
+	defaults := names(CheckOptions{})
+	if len(defaults) != 3 || defaults[0] != "max-age 30d" {
+		t.Errorf("default assertions = %v, want max-age, unpushed merged, failed sessions", defaults)
+	}
+
+	selected := names(CheckOptions{NoFailedSessions: true, MaxDiskGB: 10})
+	if len(selected) != 2 || selected[0] != "no-failed-sessions" || selected[1] != "max-disk 10GB" {
+		t.Errorf("selected assertions = %v, want only the chosen ones", selected)
+	}
+}
@@ -0,0 +1,192 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/kaeawc/auto-worktree/internal/git"
+	"github.com/kaeawc/auto-worktree/internal/logging"
+	"github.com/kaeawc/auto-worktree/internal/remote"
+	"github.com/kaeawc/auto-worktree/internal/session"
+	"github.com/kaeawc/auto-worktree/internal/ui"
+)
+
+// CheckpointsOptions configures `auto-worktree checkpoints`
+type CheckpointsOptions struct {
+	// Branch is the worktree whose checkpoints to show; empty means the
+	// current worktree or one picked from the list
+	Branch string
+	// Restore is the checkpoint to restore, counting from 1 for the newest
+	Restore int
+	// Watch is the session to checkpoint the current worktree for until it ends
+	Watch string
+}
+
+// RunCheckpoints lists a worktree's checkpoints, restores one, or with Watch
+// keeps taking them while an AI session runs
+func RunCheckpoints(opts CheckpointsOptions) error {
+	repo, err := git.NewRepository()
+	if err != nil {
+		return fmt.Errorf("error: %w", err)
+	}
+
+	if opts.Watch != "" {
+		return watchCheckpoints(repo, opts.Watch)
+	}
+
+	wt, err := branchWorktree(repo, opts.Branch, "Select a worktree to show checkpoints for")
+	if err != nil || wt == nil {
+		return err
+	}
+
+	if wt.Branch == "" {
+		return fmt.Errorf("%s has no branch checked out, so it has no checkpoints", wt.Path)
+	}
+
+	checkpoints, err := repo.ListCheckpoints(wt.Branch)
+	if err != nil {
+		return err
+	}
+
+	if opts.Restore > 0 {
+		return restoreCheckpoint(repo, wt, checkpoints, opts.Restore)
+	}
+
+	if len(checkpoints) == 0 {
+		fmt.Printf("No checkpoints for %s\n", wt.Branch)
+
+		if repo.Config.GetCheckpointInterval() == 0 {
+			fmt.Println(ui.SubtleStyle.Render(fmt.Sprintf("Checkpoints are taken while a session runs once %s is set, e.g.: git config %s 10",
+				git.ConfigCheckpointInterval, git.ConfigCheckpointInterval)))
+		}
+
+		return nil
+	}
+
+	fmt.Println(ui.TitleStyle.Render(fmt.Sprintf("Checkpoints of %s:", wt.Branch)))
+
+	now := time.Now()
+
+	for i, checkpoint := range checkpoints {
+		fmt.Printf("  %2d  %s  %s\n", i+1, checkpoint.Commit[:7],
+			ui.SubtleStyle.Render(fmt.Sprintf("%s (%s ago)", checkpoint.CreatedAt.Format("2006-01-02 15:04"), formatAge(now.Sub(checkpoint.CreatedAt)))))
+	}
+
+	fmt.Println()
+	fmt.Println(ui.SubtleStyle.Render("See what a checkpoint holds with: git diff <commit>"))
+	fmt.Println(ui.SubtleStyle.Render(fmt.Sprintf("Restore one with: auto-worktree checkpoints %s --restore <n>", wt.Branch)))
+
+	return nil
+}
+
+// branchWorktree returns the worktree for branch, or the current one when
+// branch is empty, asking with title outside a worktree
+func branchWorktree(repo *git.Repository, branch, title string) (*git.Worktree, error) {
+	if branch == "" {
+		wt, _, err := targetWorktree(repo, session.NewManager(), title)
+		return wt, err
+	}
+
+	worktrees, err := repo.ListWorktrees()
+	if err != nil {
+		return nil, fmt.Errorf("error listing worktrees: %w", err)
+	}
+
+	matched := filterWorktreesByBranch(worktrees, branch)
+	if len(matched) == 0 {
+		return nil, fmt.Errorf("no worktree for branch %s", branch)
+	}
+
+	return matched[0], nil
+}
+
+// restoreCheckpoint puts the worktree's files back to checkpoint n, first
+// checkpointing them as they are now so the restore can be undone
+func restoreCheckpoint(repo *git.Repository, wt *git.Worktree, checkpoints []git.Checkpoint, n int) error {
+	if n > len(checkpoints) {
+		return fmt.Errorf("%s has %d checkpoint(s), not %d", wt.Branch, len(checkpoints), n)
+	}
+
+	checkpoint := checkpoints[n-1]
+
+	current, err := repo.CreateCheckpoint(wt.Path, wt.Branch, time.Now())
+	if err != nil {
+		return fmt.Errorf("could not checkpoint the current files before restoring: %w", err)
+	}
+
+	if err := repo.RestoreCheckpoint(wt.Path, checkpoint); err != nil {
+		return err
+	}
+
+	fmt.Println(ui.SuccessStyle.Render(fmt.Sprintf("✓ Restored %s to its checkpoint from %s", filepath.Base(wt.Path), checkpoint.CreatedAt.Format("2006-01-02 15:04"))))
+
+	if current != nil {
+		fmt.Println(ui.SubtleStyle.Render(fmt.Sprintf("The files as they were are checkpoint 1 (%s)", current.Commit[:7])))
+	}
+
+	return nil
+}
+
+// watchCheckpoints checkpoints the current worktree every checkpoint interval
+// while the session runs, skipping intervals where nothing changed
+func watchCheckpoints(repo *git.Repository, sessionName string) error {
+	interval := repo.Config.GetCheckpointInterval()
+	if interval == 0 || repo.InvokedFromWorktree == "" {
+		return nil
+	}
+
+	sessionMgr := session.NewManager()
+
+	for {
+		time.Sleep(interval)
+
+		if running, err := sessionMgr.HasSession(sessionName); err != nil || !running {
+			return nil
+		}
+
+		worktrees, err := repo.ListWorktrees()
+		if err != nil {
+			logging.Warn("failed to list worktrees for checkpoint", "err", err)
+			continue
+		}
+
+		for _, wt := range worktrees {
+			if filepath.Clean(wt.Path) != filepath.Clean(repo.InvokedFromWorktree) || wt.Branch == "" {
+				continue
+			}
+
+			if _, err := repo.CreateCheckpoint(wt.Path, wt.Branch, time.Now()); err != nil {
+				logging.Warn("failed to checkpoint worktree", "path", wt.Path, "err", err)
+			}
+		}
+	}
+}
+
+// startCheckpointWatcher runs 'auto-worktree checkpoints --watch' in the
+// background for the new session, so it outlives this command
+func startCheckpointWatcher(sessionName, worktreePath string) {
+	// The watcher would run here rather than on the --host machine
+	if remote.Enabled() {
+		return
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		return
+	}
+
+	watcher := exec.Command(exe, "checkpoints", "--watch", sessionName) //nolint:gosec // our own binary
+	watcher.Dir = worktreePath
+
+	if err := watcher.Start(); err != nil {
+		logging.Debug("failed to start checkpoint watcher", "err", err)
+		return
+	}
+
+	if err := watcher.Process.Release(); err != nil {
+		logging.Debug("failed to release checkpoint watcher", "err", err)
+	}
+}
@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/kaeawc/auto-worktree/internal/git"
+	"github.com/kaeawc/auto-worktree/internal/session"
+	"github.com/kaeawc/auto-worktree/internal/ui"
+)
+
+// CherryOptions configures `auto-worktree cherry`
+type CherryOptions struct {
+	// From is the branch to copy from
+	From string
+	// Commits is a range like a..b or a single commit to cherry-pick; empty
+	// picks every commit of From that the current worktree lacks
+	Commits string
+	// Paths copies these files or directories as they are in From's worktree
+	// instead of cherry-picking commits
+	Paths []string
+}
+
+// RunCherry copies another worktree's commits or files into the current
+// worktree, for taking the part one parallel attempt got right
+func RunCherry(opts CherryOptions) error {
+	repo, err := git.NewRepository()
+	if err != nil {
+		return fmt.Errorf("error: %w", err)
+	}
+
+	wt, _, err := targetWorktree(repo, session.NewManager(), "Select a worktree to copy into")
+	if err != nil || wt == nil {
+		return err
+	}
+
+	if wt.Branch == opts.From {
+		return fmt.Errorf("%s is the current worktree's branch; run cherry from the worktree to copy into", opts.From)
+	}
+
+	if len(opts.Paths) > 0 {
+		return cherryPaths(repo, wt, opts.From, opts.Paths)
+	}
+
+	return cherryCommits(repo, wt, opts.From, opts.Commits)
+}
+
+// cherryCommits cherry-picks commits of from into wt
+func cherryCommits(repo *git.Repository, wt *git.Worktree, from, commits string) error {
+	dirty, err := repo.HasUncommittedChanges(wt.Path)
+	if err != nil {
+		return fmt.Errorf("error checking %s for changes: %w", wt.Path, err)
+	}
+
+	if dirty {
+		return fmt.Errorf("%s has uncommitted changes; commit or stash them before cherry-picking", wt.Branch)
+	}
+
+	hashes, err := repo.CherryCommits(wt.Path, from, commits)
+	if err != nil {
+		return err
+	}
+
+	if len(hashes) == 0 {
+		fmt.Printf("Nothing to copy: %s has no commits that %s lacks\n", from, wt.Branch)
+		return nil
+	}
+
+	if err := repo.CherryPick(wt.Path, hashes); err != nil {
+		return err
+	}
+
+	fmt.Println(ui.SuccessStyle.Render(fmt.Sprintf("✓ Copied %d commit(s) from %s into %s", len(hashes), from, wt.Branch)))
+
+	return nil
+}
+
+// cherryPaths copies paths from the worktree of from into wt, refusing to
+// overwrite uncommitted changes there
+func cherryPaths(repo *git.Repository, wt *git.Worktree, from string, paths []string) error {
+	worktrees, err := repo.ListWorktrees()
+	if err != nil {
+		return fmt.Errorf("error listing worktrees: %w", err)
+	}
+
+	matched := filterWorktreesByBranch(worktrees, from)
+	if len(matched) == 0 {
+		return fmt.Errorf("no worktree for branch %s to copy files from", from)
+	}
+
+	source := matched[0]
+
+	for _, path := range paths {
+		if filepath.IsAbs(path) || strings.HasPrefix(filepath.Clean(path), "..") {
+			return fmt.Errorf("%s must be relative to the worktree root", path)
+		}
+	}
+
+	changed, err := repo.ChangedPaths(wt.Path, paths)
+	if err != nil {
+		return err
+	}
+
+	if len(changed) > 0 {
+		return fmt.Errorf("%s has uncommitted changes in %s; commit or stash them first", wt.Branch, strings.Join(changed, ", "))
+	}
+
+	copied, err := repo.CopyPaths(source.Path, wt.Path, paths)
+	if err != nil {
+		return err
+	}
+
+	if len(copied) == 0 {
+		fmt.Printf("Nothing to copy: %s already matches %s\n", strings.Join(paths, " "), from)
+		return nil
+	}
+
+	fmt.Println(ui.SuccessStyle.Render(fmt.Sprintf("✓ Copied %d file(s) from %s into %s:", len(copied), from, wt.Branch)))
+
+	for _, file := range copied {
+		fmt.Printf("  %s\n", file)
+	}
+
+	fmt.Println(ui.SubtleStyle.Render("The changes are uncommitted; review them with: git diff"))
+
+	return nil
+}
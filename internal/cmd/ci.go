@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kaeawc/auto-worktree/internal/git"
+	"github.com/kaeawc/auto-worktree/internal/github"
+	"github.com/kaeawc/auto-worktree/internal/logging"
+	"github.com/kaeawc/auto-worktree/internal/session"
+)
+
+// maxCILogLines caps the log lines kept for each failing check
+const maxCILogLines = 80
+
+// ciFailure is a failing check and the part of its log that explains it
+type ciFailure struct {
+	Name string
+	URL  string
+	// Log is the summarized log; empty when it could not be fetched
+	Log string
+}
+
+// RunCIFix fetches the logs of the failing checks on a worktree's pull request
+// and hands them to its AI session to fix, like RunFeedback does with review comments
+func RunCIFix() error {
+	repo, err := git.NewRepository()
+	if err != nil {
+		return fmt.Errorf("error: %w", err)
+	}
+
+	sessionMgr := session.NewManager()
+
+	wt, hasSession, err := targetWorktree(repo, sessionMgr, "Select a worktree to send CI failures to")
+	if err != nil || wt == nil {
+		return err
+	}
+
+	client, err := github.NewClient(repo.RootPath)
+	if err != nil {
+		return fmt.Errorf("CI logs need the gh CLI: %w", err)
+	}
+
+	found, err := client.FindPRForBranch(wt.Branch)
+	if err != nil {
+		return err
+	}
+
+	if found == nil {
+		return fmt.Errorf("no open pull request for %s", wt.Branch)
+	}
+
+	pr, err := client.GetPR(found.Number)
+	if err != nil {
+		return err
+	}
+
+	failing := pr.FailingChecks()
+	if len(failing) == 0 {
+		fmt.Printf("No failing checks on PR #%d (CI is %s)\n", pr.Number, pr.CIState())
+		return nil
+	}
+
+	fmt.Printf("Fetching logs for %d failing check(s) on PR #%d...\n", len(failing), pr.Number)
+
+	failures := make([]ciFailure, len(failing))
+	for i := range failing {
+		failures[i] = fetchCIFailure(client, &failing[i])
+	}
+
+	return resumeWorktree(repo, sessionMgr, wt, hasSession, formatCIFailures(pr.Number, failures))
+}
+
+// fetchCIFailure reads the failed steps' log of a GitHub Actions check; other
+// checks (and logs that cannot be read) are reported by name and link only
+func fetchCIFailure(client *github.Client, check *github.StatusCheck) ciFailure {
+	failure := ciFailure{Name: check.Name, URL: check.DetailsURL}
+	if check.WorkflowName != "" {
+		failure.Name = check.WorkflowName + " / " + check.Name
+	}
+
+	jobID, ok := check.JobID()
+	if !ok {
+		return failure
+	}
+
+	log, err := client.GetFailedJobLog(jobID)
+	if err != nil {
+		logging.Warn("failed to fetch CI log", "check", check.Name, "err", err)
+		return failure
+	}
+
+	failure.Log = github.SummarizeLog(log, maxCILogLines)
+
+	return failure
+}
+
+// formatCIFailures renders failing checks as instructions for the AI tool
+func formatCIFailures(prNumber int, failures []ciFailure) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "These CI checks failed on PR #%d for this branch. "+
+		"Find the cause of each failure, fix it, and run the failing step locally to confirm:\n", prNumber)
+
+	for _, f := range failures {
+		fmt.Fprintf(&b, "\n### %s\n", f.Name)
+
+		if f.Log == "" {
+			fmt.Fprintf(&b, "No log available; see %s\n", f.URL)
+			continue
+		}
+
+		fmt.Fprintf(&b, "```\n%s\n```\n", f.Log)
+	}
+
+	return b.String()
+}
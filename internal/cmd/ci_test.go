@@ -0,0 +1,23 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFormatCIFailures(t *testing.T) {
+	got := formatCIFailures(12, []ciFailure{
+		{Name: "CI / test", Log: "--- FAIL: TestParse"},
+		{Name: "external", URL: "https://ci.example.com/build/9"},
+	})
+
+	for _, want := range []string{
+		"failed on PR #12",
+		"### CI / test\n```\n--- FAIL: TestParse\n```\n",
+		"### external\nNo log available; see https://ci.example.com/build/9\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("formatCIFailures() = %q, want it to contain %q", got, want)
+		}
+	}
+}
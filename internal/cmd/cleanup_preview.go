@@ -0,0 +1,169 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/kaeawc/auto-worktree/internal/check"
+	"github.com/kaeawc/auto-worktree/internal/git"
+	"github.com/kaeawc/auto-worktree/internal/github"
+	"github.com/kaeawc/auto-worktree/internal/gitlab"
+	"github.com/kaeawc/auto-worktree/internal/logging"
+	"github.com/kaeawc/auto-worktree/internal/ui"
+)
+
+// prLookup finds the pull request (or merge request) of a branch on the
+// repository's code host. The client is created on first use and reused, and
+// the lookup is best-effort: without a working CLI every branch is unknown.
+type prLookup struct {
+	repo *git.Repository
+	once sync.Once
+	find func(branch string) (*branchPR, error)
+}
+
+// branchPR is the latest pull request (or merge request) of a branch
+type branchPR struct {
+	// Ref is how the code host refers to it, e.g. "#12" or "!12"
+	Ref string
+	// State is "open", "closed" or "merged"
+	State string
+	Title string
+}
+
+// newPRLookup creates a lookup for the repository's code host
+func newPRLookup(repo *git.Repository) *prLookup {
+	return &prLookup{repo: repo}
+}
+
+// describe summarizes the branch's latest pull request, e.g. "#12 merged: Add retry",
+// "none" when it has none, or "" when the code host cannot be asked
+func (l *prLookup) describe(branch string) string {
+	pr, ok := l.latest(branch)
+	switch {
+	case !ok:
+		return ""
+	case pr == nil:
+		return "none"
+	default:
+		return fmt.Sprintf("%s %s: %s", pr.Ref, pr.State, pr.Title)
+	}
+}
+
+// latest returns the branch's latest pull request, or nil if it has none; ok
+// is false when the code host cannot be asked
+func (l *prLookup) latest(branch string) (pr *branchPR, ok bool) {
+	if l == nil || branch == "" {
+		return nil, false
+	}
+
+	l.once.Do(l.init)
+
+	if l.find == nil {
+		return nil, false
+	}
+
+	pr, err := l.find(branch)
+	if err != nil {
+		logging.Debug("pull request lookup failed", "branch", branch, "err", err)
+		return nil, false
+	}
+
+	return pr, true
+}
+
+func (l *prLookup) init() {
+	switch resolveCodeHostType(l.repo.Config) {
+	case providerGitLab:
+		client, err := gitlab.NewClient(l.repo.RootPath)
+		if err != nil {
+			return
+		}
+
+		l.find = func(branch string) (*branchPR, error) {
+			mr, err := client.LatestMRForBranch(branch)
+			if err != nil || mr == nil {
+				return nil, err
+			}
+
+			state := mr.State
+			if state == "opened" {
+				state = "open"
+			}
+
+			return &branchPR{Ref: fmt.Sprintf("!%d", mr.IID), State: state, Title: mr.Title}, nil
+		}
+	case providerGitHub:
+		client, err := github.NewClient(l.repo.RootPath)
+		if err != nil {
+			return
+		}
+
+		l.find = func(branch string) (*branchPR, error) {
+			pr, err := client.LatestPRForBranch(branch)
+			if err != nil || pr == nil {
+				return nil, err
+			}
+
+			return &branchPR{Ref: fmt.Sprintf("#%d", pr.Number), State: strings.ToLower(pr.State), Title: pr.Title}, nil
+		}
+	}
+}
+
+// cleanupPreview gathers what a cleanup prompt shows about a worktree's work
+func cleanupPreview(repo *git.Repository, wt *git.Worktree, prs *prLookup) ui.CleanupPreview {
+	preview := repo.PreviewWorktree(wt)
+
+	return ui.CleanupPreview{
+		LastCommit:  preview.LastCommit,
+		DiffStat:    preview.DiffStat(),
+		PullRequest: prs.describe(wt.Branch),
+	}
+}
+
+// printCleanupCandidate lists a worktree with its cleanup reason and preview
+func printCleanupCandidate(repo *git.Repository, wt *git.Worktree, prs *prLookup) {
+	fmt.Printf("  • %s (%s) - %s\n", filepath.Base(wt.Path), wt.Branch, wt.CleanupReason())
+
+	for _, line := range cleanupPreview(repo, wt, prs).Lines() {
+		fmt.Println(ui.SubtleStyle.Render("      " + line))
+	}
+}
+
+// cleanupSavings is what removing worktrees along with their branches frees
+type cleanupSavings struct {
+	Worktrees int
+	Branches  int
+	Bytes     int64
+}
+
+// measureCleanupSavings adds up the disk space and branches of the worktrees;
+// a worktree that can't be measured counts as empty
+func measureCleanupSavings(worktrees []*git.Worktree) cleanupSavings {
+	savings := cleanupSavings{Worktrees: len(worktrees)}
+
+	for _, wt := range worktrees {
+		if size, err := check.DirSize(wt.Path); err == nil {
+			savings.Bytes += size
+		}
+
+		if wt.Branch != "" {
+			savings.Branches++
+		}
+	}
+
+	return savings
+}
+
+// add adds other to the savings
+func (s *cleanupSavings) add(other cleanupSavings) {
+	s.Worktrees += other.Worktrees
+	s.Branches += other.Branches
+	s.Bytes += other.Bytes
+}
+
+// String summarizes the savings, e.g. "3 worktree(s) (1.2G) and 3 branch(es)"
+func (s cleanupSavings) String() string {
+	return fmt.Sprintf("%d worktree(s) (%s) and %d branch(es)", s.Worktrees, formatDiskSize(s.Bytes), s.Branches)
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kaeawc/auto-worktree/internal/git"
+)
+
+func TestPRLookupDescribe(t *testing.T) {
+	calls := 0
+	lookup := &prLookup{find: func(branch string) (*branchPR, error) {
+		calls++
+		switch branch {
+		case "work/broken":
+			return nil, errors.New("gh failed")
+		case "work/new":
+			return nil, nil
+		}
+
+		return &branchPR{Ref: "#12", State: "merged", Title: "Add retry"}, nil
+	}}
+	lookup.once.Do(func() {})
+
+	if got := lookup.describe("work/retry"); got != "#12 merged: Add retry" {
+		t.Errorf("describe() = %q", got)
+	}
+
+	if got := lookup.describe("work/broken"); got != "" {
+		t.Errorf("describe() after a failed lookup = %q, want empty", got)
+	}
+
+	if got := lookup.describe("work/new"); got != "none" {
+		t.Errorf("describe() without a PR = %q, want none", got)
+	}
+
+	if got := lookup.describe(""); got != "" || calls != 3 {
+		t.Errorf("describe(\"\") = %q after %d lookups; want empty without a lookup", got, calls)
+	}
+
+	var none *prLookup
+	if got := none.describe("work/retry"); got != "" {
+		t.Errorf("nil lookup describe() = %q, want empty", got)
+	}
+}
+
+func TestMeasureCleanupSavings(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "main.go"), make([]byte, 2048), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	worktrees := []*git.Worktree{
+		{Path: dir, Branch: "work/retry"},
+		{Path: filepath.Join(t.TempDir(), "missing"), Branch: "work/gone"},
+		{Path: t.TempDir()},
+	}
+
+	savings := measureCleanupSavings(worktrees)
+	if want := (cleanupSavings{Worktrees: 3, Branches: 2, Bytes: 2048}); savings != want {
+		t.Fatalf("measureCleanupSavings() = %+v, want %+v", savings, want)
+	}
+
+	savings.add(cleanupSavings{Worktrees: 1, Branches: 1, Bytes: 1024})
+	if got, want := savings.String(), "4 worktree(s) (3.0K) and 3 branch(es)"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
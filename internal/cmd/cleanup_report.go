@@ -0,0 +1,201 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/kaeawc/auto-worktree/internal/check"
+	"github.com/kaeawc/auto-worktree/internal/git"
+)
+
+// CleanupOptions configures `auto-worktree cleanup`
+type CleanupOptions struct {
+	// JSON prints a report of what was removed instead of prompting; without
+	// Yes nothing is removed and the report says what would be
+	JSON bool
+	// Yes removes the merged worktrees without unpushed commits, as confirming
+	// the interactive cleanup would
+	Yes bool
+}
+
+// PruneOptions configures `auto-worktree prune`
+type PruneOptions struct {
+	// JSON prints a report of the pruned worktree records
+	JSON bool
+	// Expire only prunes records older than this git date, e.g. "2.weeks.ago";
+	// without it gc.worktreePruneExpire applies, if set
+	Expire string
+}
+
+// cleanupReport is the --json output of cleanup and prune, for automation to
+// log or alert on what maintenance did
+type cleanupReport struct {
+	Command string `json:"command"`
+	// DryRun is set when nothing was removed and Removed is what would be
+	DryRun          bool            `json:"dryRun"`
+	Removed         []cleanupRecord `json:"removed"`
+	Kept            []cleanupRecord `json:"kept"`
+	DeletedBranches []string        `json:"deletedBranches"`
+	FreedBytes      int64           `json:"freedBytes"`
+	Errors          []string        `json:"errors"`
+}
+
+// cleanupRecord is one worktree in a cleanup report
+type cleanupRecord struct {
+	Path   string `json:"path"`
+	Branch string `json:"branch,omitempty"`
+	// Reason is why the worktree was removed, or kept
+	Reason string `json:"reason"`
+	Bytes  int64  `json:"bytes"`
+}
+
+// newCleanupReport creates an empty report; the lists marshal as [] rather
+// than null so consumers can iterate without checks
+func newCleanupReport(command string, dryRun bool) *cleanupReport {
+	return &cleanupReport{
+		Command:         command,
+		DryRun:          dryRun,
+		Removed:         []cleanupRecord{},
+		Kept:            []cleanupRecord{},
+		DeletedBranches: []string{},
+		Errors:          []string{},
+	}
+}
+
+// remove removes a worktree, and its branch if asked, and records it. In a dry
+// run it only records what would be removed.
+func (r *cleanupReport) remove(repo *git.Repository, wt *git.Worktree, deleteBranch bool) {
+	// Measured first, since the directory is gone afterwards
+	size, err := check.DirSize(wt.Path)
+	if err != nil {
+		size = 0
+	}
+
+	record := cleanupRecord{Path: wt.Path, Branch: wt.Branch, Reason: wt.CleanupReason(), Bytes: size}
+
+	if r.DryRun {
+		r.Removed = append(r.Removed, record)
+		r.FreedBytes += size
+
+		return
+	}
+
+	branchErr, err := removeWorktreeAndBranch(repo, wt, deleteBranch)
+	if err != nil {
+		r.Errors = append(r.Errors, fmt.Sprintf("%s: %v", wt.Path, err))
+		return
+	}
+
+	r.Removed = append(r.Removed, record)
+	r.FreedBytes += size
+
+	switch {
+	case branchErr != nil:
+		r.Errors = append(r.Errors, fmt.Sprintf("failed to delete branch %s: %v", wt.Branch, branchErr))
+	case deleteBranch && wt.Branch != "":
+		r.DeletedBranches = append(r.DeletedBranches, wt.Branch)
+	}
+}
+
+// keep records a worktree left alone and why
+func (r *cleanupReport) keep(wt *git.Worktree, reason string) {
+	r.Kept = append(r.Kept, cleanupRecord{Path: wt.Path, Branch: wt.Branch, Reason: reason})
+}
+
+// print writes the report as JSON, returning an error when anything failed so
+// the exit status tells automation too
+func (r *cleanupReport) print() error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s report: %w", r.Command, err)
+	}
+
+	fmt.Println(string(data))
+
+	if len(r.Errors) > 0 {
+		return errors.New(r.Command + " had failures; see the errors in the report")
+	}
+
+	return nil
+}
+
+// cleanupJSON runs cleanup without prompts and prints its report
+func cleanupJSON(repo *git.Repository, candidates []*git.Worktree, yes bool) error {
+	return runCleanupReport(repo, candidates, yes).print()
+}
+
+// runCleanupReport decides without prompts what the interactive cleanup would
+// offer: merged worktrees are removed with their branches unless they have
+// unpushed commits, and stale ones are left for a person to review. Without
+// yes it is a dry run.
+func runCleanupReport(repo *git.Repository, candidates []*git.Worktree, yes bool) *cleanupReport {
+	report := newCleanupReport("cleanup", !yes)
+	merged, stale := categorizeWorktrees(candidates)
+
+	for _, wt := range merged {
+		if wt.UnpushedCount > 0 {
+			report.keep(wt, fmt.Sprintf("%d unpushed commit(s)", wt.UnpushedCount))
+			continue
+		}
+
+		report.remove(repo, wt, true)
+	}
+
+	for _, wt := range stale {
+		report.keep(wt, wt.CleanupReason()+"; run 'auto-worktree cleanup' to review")
+	}
+
+	return report
+}
+
+// pruneJSON prunes the records of worktrees whose directory is gone and
+// reports them; their directories are already gone, so nothing is freed
+func pruneJSON(repo *git.Repository, expire string) error {
+	report := newCleanupReport("prune", false)
+
+	entries, err := repo.PrunableWorktrees(expire)
+	if err != nil {
+		report.Errors = append(report.Errors, err.Error())
+		return report.print()
+	}
+
+	branches := map[string]string{}
+	if worktrees, err := repo.ListWorktrees(); err == nil {
+		for _, wt := range worktrees {
+			branches[wt.Path] = wt.Branch
+		}
+	}
+
+	for _, entry := range entries {
+		path := entry.Path
+		if path == "" {
+			path = pruneEntryName(entry)
+		}
+
+		report.Removed = append(report.Removed, cleanupRecord{Path: path, Branch: branches[entry.Path], Reason: entry.Reason})
+	}
+
+	if err := repo.PruneWorktreesExpire(expire); err != nil {
+		report.Removed = []cleanupRecord{}
+		report.Errors = append(report.Errors, err.Error())
+	}
+
+	return report.print()
+}
+
+// pruneEntryName is an entry's directory relative to the git directory, e.g. "worktrees/feature"
+func pruneEntryName(entry git.PruneEntry) string {
+	return "worktrees/" + entry.Name
+}
+
+// describePruneEntry says what an entry was for and why it is pruned, e.g.
+// "worktrees/feature (/src/feature): gitdir file points to non-existent location"
+func describePruneEntry(entry git.PruneEntry) string {
+	name := pruneEntryName(entry)
+	if entry.Path != "" {
+		name += " (" + entry.Path + ")"
+	}
+
+	return name + ": " + entry.Reason
+}
@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kaeawc/auto-worktree/internal/git"
+)
+
+func TestCleanupJSONDryRun(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "file"), make([]byte, 100), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	old := time.Now().Add(-30 * 24 * time.Hour)
+	merged := &git.Worktree{Path: dir, Branch: "done", IsBranchMerged: true, LastCommitTime: time.Now()}
+	unpushed := &git.Worktree{Path: "/wt/unpushed", Branch: "wip", IsBranchMerged: true, UnpushedCount: 2, LastCommitTime: time.Now()}
+	stale := &git.Worktree{Path: "/wt/stale", Branch: "old", LastCommitTime: old}
+
+	report := runCleanupReport(nil, []*git.Worktree{merged, unpushed, stale}, false)
+
+	if len(report.Removed) != 1 || report.Removed[0].Path != dir || report.FreedBytes != 100 {
+		t.Errorf("Removed = %+v, FreedBytes = %d; want %s with 100 bytes", report.Removed, report.FreedBytes, dir)
+	}
+
+	if !report.DryRun {
+		t.Error("without --yes the report should be a dry run")
+	}
+
+	if len(report.Kept) != 2 {
+		t.Errorf("Kept = %+v, want the unpushed and stale worktrees", report.Kept)
+	}
+
+	if len(report.DeletedBranches) != 0 {
+		t.Errorf("a dry run should delete no branches, got %v", report.DeletedBranches)
+	}
+}
+
+func TestCleanupReportMarshalsEmptyLists(t *testing.T) {
+	data, err := json.Marshal(newCleanupReport("prune", false))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"command":"prune","dryRun":false,"removed":[],"kept":[],"deletedBranches":[],"freedBytes":0,"errors":[]}`
+	if string(data) != want {
+		t.Errorf("report = %s, want %s", data, want)
+	}
+}
@@ -3,6 +3,7 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
@@ -18,12 +19,19 @@ import (
 
 	"github.com/kaeawc/auto-worktree/internal/ai"
 	"github.com/kaeawc/auto-worktree/internal/environment"
+	"github.com/kaeawc/auto-worktree/internal/events"
 	"github.com/kaeawc/auto-worktree/internal/git"
 	"github.com/kaeawc/auto-worktree/internal/github"
 	"github.com/kaeawc/auto-worktree/internal/hooks"
+	"github.com/kaeawc/auto-worktree/internal/interrupt"
+	"github.com/kaeawc/auto-worktree/internal/jira"
+	"github.com/kaeawc/auto-worktree/internal/limits"
+	"github.com/kaeawc/auto-worktree/internal/logging"
+	"github.com/kaeawc/auto-worktree/internal/offline"
 	"github.com/kaeawc/auto-worktree/internal/perf"
 	"github.com/kaeawc/auto-worktree/internal/provider"
 	"github.com/kaeawc/auto-worktree/internal/providers"
+	"github.com/kaeawc/auto-worktree/internal/remote"
 	"github.com/kaeawc/auto-worktree/internal/session"
 	"github.com/kaeawc/auto-worktree/internal/terminal"
 	"github.com/kaeawc/auto-worktree/internal/ui"
@@ -45,6 +53,8 @@ const (
 // The menu loops after each operation, allowing multiple tasks in one session.
 // Press Escape/Ctrl-C to exit the menu completely.
 func RunInteractiveMenu() error {
+	maybeRunFirstRunSetup()
+
 	for {
 		shouldExit, err := showInteractiveMenu()
 		if err != nil {
@@ -59,37 +69,39 @@ func RunInteractiveMenu() error {
 // showInteractiveMenu displays the menu and handles one selection.
 // Returns (shouldExit, error) where shouldExit indicates if user wants to exit menu.
 func showInteractiveMenu() (bool, error) {
-	if err := RunList(); err != nil {
+	if err := RunList(ListOptions{}); err != nil {
 		return false, err
 	}
 	fmt.Println()
 
+	repo, repoErr := git.NewRepository()
+
 	endMenuItems := perf.StartSpan("menu-items-create")
-	items := []ui.MenuItem{
-		ui.NewMenuItem("New Worktree", "Create a new worktree with a new branch", "new"),
-		ui.NewMenuItem("Resume Worktree", "Resume working on the last worktree", "resume"),
-		ui.NewMenuItem("Work on Issue", "Create worktree for a GitHub/GitLab/JIRA issue", "issue"),
-		ui.NewMenuItem("Create Issue", "Create a new issue and start working on it", "create"),
-		ui.NewMenuItem("Review PR", "Review a pull request in a new worktree", "pr"),
-		ui.NewMenuItem("List Worktrees", "Show all existing worktrees", "list"),
-		ui.NewMenuItem("View Tmux Sessions", "Manage active tmux sessions for worktrees", "sessions"),
-		ui.NewMenuItem("Cleanup Worktrees", "Interactive cleanup of merged/stale worktrees", "cleanup"),
-		ui.NewMenuItem("Settings", "Configure per-repository settings", "settings"),
+	caps := Capabilities{Tmux: true, IssueProvider: providerGitHub, CodeHost: providerGitHub}
+	if repoErr == nil {
+		caps = currentCapabilities(repo.Config)
 	}
+
+	items := buildMenuItems(caps)
 	endMenuItems()
 
 	endMenuCreate := perf.StartSpan("menu-model-create")
-	menu := ui.NewMenu("auto-worktree", items)
-	endMenuCreate()
+	menuTitle := "auto-worktree"
+	if repoErr == nil && repo.IsFrozen() {
+		menuTitle += " ❄ frozen"
+	}
+
+	if notice := updateNotice(); notice != "" {
+		menuTitle += " ⬆ " + notice
+	}
 
-	endProgramCreate := perf.StartSpan("tea-program-create")
-	p := tea.NewProgram(menu, tea.WithAltScreen())
-	endProgramCreate()
+	menu := ui.NewMenu(menuTitle, items)
+	endMenuCreate()
 
 	perf.Mark("menu-ready-to-render")
 
 	endProgramRun := perf.StartSpan("tea-program-run")
-	m, err := p.Run()
+	m, err := ui.Run(menu, tea.WithAltScreen())
 	endProgramRun()
 
 	if err != nil {
@@ -113,9 +125,49 @@ func showInteractiveMenu() (bool, error) {
 	return false, err
 }
 
+// buildMenuItems returns the main menu, leaving out flows whose tools are missing
+func buildMenuItems(caps Capabilities) []ui.MenuItem {
+	items := []ui.MenuItem{
+		ui.NewMenuItem("New Worktree", "Create a new worktree with a new branch", "new"),
+		ui.NewMenuItem("Resume Worktree", "Resume working on the last worktree", "resume"),
+	}
+
+	if caps.HasIssueWorkflows() {
+		items = append(items,
+			ui.NewMenuItem("Work on Issue", "Create worktree for a GitHub/GitLab/JIRA issue", "issue"),
+			ui.NewMenuItem("Create Issue", "Create a new issue and start working on it", "create"))
+	}
+
+	if caps.HasPRWorkflows() {
+		items = append(items,
+			ui.NewMenuItem("Review PR", "Review a pull request in a new worktree", "pr"),
+			ui.NewMenuItem("Address Review Feedback", "Send unresolved review comments on a worktree's PR to its AI session", "feedback"))
+
+		if caps.CodeHost == providerGitHub {
+			items = append(items, ui.NewMenuItem("Fix CI Failures", "Send failing check logs on a worktree's PR to its AI session", "ci"))
+		}
+	}
+
+	items = append(items,
+		ui.NewMenuItem("List Worktrees", "Show all existing worktrees", "list"),
+		ui.NewMenuItem("Dashboard", "Live view of every worktree with quick actions", "dashboard"),
+		ui.NewMenuItem("All Repositories", "Worktrees, sessions and cleanup across every repository you use", "repos"))
+
+	if caps.Tmux {
+		items = append(items, ui.NewMenuItem("View Tmux Sessions", "Manage active tmux sessions for worktrees", "sessions"))
+	}
+
+	return append(items,
+		ui.NewMenuItem("Cleanup Worktrees", "Interactive cleanup of merged/stale worktrees", "cleanup"),
+		ui.NewMenuItem("Project Overview", "Summarize branches, PRs, issues, and worktree hygiene", "overview"),
+		ui.NewMenuItem("Settings", "Configure per-repository settings", "settings"))
+}
+
 func routeMenuChoice(choice string, _ bool) error {
 	var err error
 
+	RecordUsage("menu:" + choice)
+
 	switch choice {
 	case "new":
 		err = RunNew(true)
@@ -127,12 +179,22 @@ func routeMenuChoice(choice string, _ bool) error {
 		err = RunCreate()
 	case "pr":
 		err = RunPR("")
+	case "feedback":
+		err = RunFeedback()
+	case "ci":
+		err = RunCIFix()
 	case "list":
-		err = RunList()
+		err = RunList(ListOptions{})
+	case "dashboard":
+		err = RunDashboard(DefaultDashboardInterval)
+	case "repos":
+		err = RunAllRepositories()
 	case "sessions":
 		err = RunSessions()
 	case "cleanup":
-		err = RunCleanup()
+		err = RunCleanup(CleanupOptions{})
+	case "overview":
+		err = RunOverview()
 	case "settings":
 		err = RunSettings()
 	default:
@@ -145,14 +207,26 @@ func routeMenuChoice(choice string, _ bool) error {
 }
 
 // RunList lists all worktrees.
-func RunList() error {
+func RunList(opts ListOptions) error {
 	repo, err := git.NewRepository()
 	if err != nil {
 		return fmt.Errorf("error: %w", err)
 	}
 
+	opts, err = resolveListOptions(opts, repo.Config)
+	if err != nil {
+		return err
+	}
+
 	// Get provider for issue/PR status enrichment (provider is optional, errors ignored)
-	prov, _ := GetProviderForRepository(repo) //nolint:errcheck
+	var prov providers.Provider
+	if !offline.Enabled() {
+		prov, _ = GetProviderForRepository(repo) //nolint:errcheck
+	}
+
+	if opts.Watch > 0 {
+		return watchList(repo, prov, opts)
+	}
 
 	// Use ListWorktreesWithAllStatusExcludingMain to get all status information,
 	// excluding the main repository root
@@ -166,29 +240,95 @@ func RunList() error {
 		return nil
 	}
 
+	listed := printList(repo, worktrees, opts)
+
+	// A frozen repository is mid-operation; don't offer to change anything
+	if listed.frozen {
+		return nil
+	}
+
+	// Offer to fix worktrees whose branch was switched manually
+	if drifts := findBranchDrift(worktrees, listed.sessions); len(drifts) > 0 {
+		if err := promptForBranchDrift(repo, session.NewManager(), drifts, interactiveTerminal()); err != nil {
+			return err
+		}
+	}
+
+	// Show cleanup prompt if there are candidates
+	if len(listed.cleanup) > 0 {
+		if err := promptForCleanup(repo, listed.cleanup); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// listOutcome is what printList found out while printing, for the prompts after it
+type listOutcome struct {
+	// cleanup are the worktrees ready to be cleaned up
+	cleanup  []*git.Worktree
+	sessions []*session.Metadata
+	frozen   bool
+}
+
+// printList prints the repository header and the worktree table
+func printList(repo *git.Repository, worktrees []*git.Worktree, opts ListOptions) listOutcome {
 	// Load session metadata to show tmux status
 	sessionMgr := session.NewManager()
 	sessionMetadataMap := make(map[string]*session.Metadata)
 
-	if allMetadata, err := sessionMgr.LoadAllSessionMetadata(); err == nil {
+	allMetadata, err := sessionMgr.LoadAllSessionMetadata()
+	if err == nil {
 		for _, metadata := range allMetadata {
 			sessionMetadataMap[metadata.WorktreePath] = metadata
 		}
 	}
 
+	// Branches that would conflict with the default branch (cached by commit)
+	var conflicts map[string][]string
+
+	checker := newConflictChecker(repo)
+	if checker != nil {
+		conflicts = checker.CheckAll(worktrees)
+	}
+
+	// Last results of 'auto-worktree test'
+	testResults := loadTestResults()
+
 	// Get current working directory for active worktree indicator (errors ignored)
 	currentWtPath, _ := os.Getwd() //nolint:errcheck
 
 	fmt.Printf("Repository: %s\n", repo.SourceFolder)
-	fmt.Printf("Worktree base: %s\n\n", repo.WorktreeBase)
-	fmt.Printf("  %-45s %-20s %-12s %-20s %-10s %s\n", "PATH", "BRANCH", "AGE", "STATUS", "SESSION", "UNPUSHED")
-	fmt.Println(strings.Repeat("-", 135))
+	fmt.Printf("Worktree base: %s\n", repo.WorktreeBase)
+
+	frozen := repo.FrozenState()
+	if frozen != nil {
+		fmt.Println(frozenBanner(frozen))
+	}
+
+	if offline.Enabled() {
+		fmt.Println(ui.WarningStyle.Render(fmt.Sprintf("⚠ Offline (%s): issue and PR status not shown", offline.Reason())))
+	}
+
+	// Narrow terminals get fewer columns; compact lines are just cut
+	width := ui.TerminalWidth(os.Stdout)
+	columns, pathWidth := opts.Columns, repo.Config.GetListPathWidth()
+
+	var hidden []string
+	if !opts.Compact {
+		columns, pathWidth, hidden = fitListColumns(columns, pathWidth, width)
+	}
 
 	// Collect cleanup candidates for later prompt
 	var cleanupWorktrees []*git.Worktree
 
+	rows := make([]*listEntry, 0, len(worktrees))
+
+	// Worktrees for the same issue are shown together and numbered
+	attempts := listAttempts(worktrees, sessionMetadataMap)
+
 	for _, wt := range worktrees {
-		path := wt.Path
 		branch := wt.Branch
 
 		if branch == "" {
@@ -207,11 +347,6 @@ func RunList() error {
 			unpushed = ui.SuccessStyle.Render("up to date")
 		}
 
-		// Truncate path if too long
-		if len(path) > 43 {
-			path = "..." + path[len(path)-40:]
-		}
-
 		// Active worktree indicator
 		activeIndicator := "  "
 		if wt.Path == currentWtPath {
@@ -220,6 +355,9 @@ func RunList() error {
 
 		// Get status indicator
 		status := getStatusIndicator(wt)
+		if _, ok := conflicts[wt.Path]; ok {
+			status += " " + ui.WarningStyle.Render(conflictIndicator)
+		}
 
 		// Get session status
 		sessionStatus := "-"
@@ -227,7 +365,29 @@ func RunList() error {
 			sessionStatus = getSessionStatusIndicator(metadata)
 		}
 
-		fmt.Printf("%s%-45s %-20s %-12s %-20s %-10s %s\n", activeIndicator, path, branch, age, status, sessionStatus, unpushed)
+		path := ui.ShortenPath(wt.Path, pathWidth-2)
+
+		attempt := attempts[wt.Path]
+		if attempt > 0 {
+			label := formatAttempt(attempt)
+			path = ui.ShortenPath(wt.Path, pathWidth-3-len(label)) + " " + ui.SubtleStyle.Render(label)
+		}
+
+		rows = append(rows, &listEntry{
+			wt:        wt,
+			indicator: activeIndicator,
+			issue:     listIssueOf(wt, sessionMetadataMap[wt.Path]),
+			attempt:   attempt,
+			cells: map[string]string{
+				"path":     path,
+				"branch":   branch,
+				"age":      age,
+				"status":   status,
+				"session":  sessionStatus,
+				"test":     formatTestResult(testResults[wt.Path], wt.HEAD),
+				"unpushed": unpushed,
+			},
+		})
 
 		// Collect cleanup candidates
 		if wt.ShouldCleanup() {
@@ -235,16 +395,79 @@ func RunList() error {
 		}
 	}
 
+	if needsListSize(opts) {
+		measureListSize(rows)
+	}
+
+	sortListEntries(rows, opts.Sort)
+	rows = groupListEntries(rows)
+
+	fmt.Println()
+
+	if opts.Compact {
+		printListCompact(rows, columns, width)
+	} else {
+		printListTable(rows, columns, pathWidth, width)
+	}
+
+	if len(hidden) > 0 {
+		fmt.Println(ui.SubtleStyle.Render(fmt.Sprintf("Hidden to fit the terminal: %s (see --columns or --compact)", strings.Join(hidden, ", "))))
+	}
+
 	fmt.Printf("\nTotal: %d worktree(s)\n", len(worktrees))
 
-	// Show cleanup prompt if there are candidates
-	if len(cleanupWorktrees) > 0 {
-		if err := promptForCleanup(repo, cleanupWorktrees); err != nil {
-			return err
+	if len(conflicts) > 0 {
+		fmt.Println(ui.WarningStyle.Render(fmt.Sprintf("%s %d worktree(s) would conflict with %s; run 'auto-worktree conflicts' for details",
+			conflictIndicator, len(conflicts), checker.target)))
+	}
+
+	if hint := lfsListHint(repo, worktrees); hint != "" {
+		fmt.Println(hint)
+	}
+
+	return listOutcome{cleanup: cleanupWorktrees, sessions: allMetadata, frozen: frozen != nil}
+}
+
+// lfsListHint warns when LFS-tracked files in any worktree are still pointer files
+func lfsListHint(repo *git.Repository, worktrees []*git.Worktree) string {
+	if !repo.UsesLFS(repo.RootPath) {
+		return ""
+	}
+
+	if !repo.HasLFSInstalled() {
+		return ui.WarningStyle.Render("⚠ This repository uses Git LFS but git-lfs is not installed; large files are pointer files")
+	}
+
+	var incomplete []string
+
+	for _, wt := range worktrees {
+		if missing, err := repo.MissingLFSObjects(wt.Path); err == nil && len(missing) > 0 {
+			incomplete = append(incomplete, filepath.Base(wt.Path))
 		}
 	}
 
-	return nil
+	if len(incomplete) == 0 {
+		return ""
+	}
+
+	return ui.WarningStyle.Render(fmt.Sprintf("⚠ LFS files not downloaded in: %s", strings.Join(incomplete, ", "))) +
+		"\n" + ui.SubtleStyle.Render("  Run 'auto-worktree repair --all' to download them")
+}
+
+// withoutProtected drops worktrees on protected branches, which are never cleaned up
+func withoutProtected(worktrees []*git.Worktree) []*git.Worktree {
+	var result []*git.Worktree
+
+	for _, wt := range worktrees {
+		if wt.IsProtected {
+			fmt.Println(ui.SubtleStyle.Render(fmt.Sprintf("Skipping %s: %s is a protected branch", filepath.Base(wt.Path), wt.Branch)))
+			continue
+		}
+
+		result = append(result, wt)
+	}
+
+	return result
 }
 
 // getStatusIndicator returns a styled status string for the worktree
@@ -323,22 +546,25 @@ func getSessionStatusIndicator(metadata *session.Metadata) string {
 
 // promptForCleanup shows an interactive prompt for cleaning up worktrees
 func promptForCleanup(repo *git.Repository, worktrees []*git.Worktree) error {
+	worktrees = withoutProtected(worktrees)
+	if len(worktrees) == 0 {
+		return nil
+	}
+
 	fmt.Println()
 	fmt.Println(ui.MergedStyle.Render("Worktrees that can be cleaned up:"))
 	fmt.Println()
 
 	// Display cleanup candidates
+	prs := newPRLookup(repo)
 	for _, wt := range worktrees {
-		basename := filepath.Base(wt.Path)
-		reason := wt.CleanupReason()
-		fmt.Printf("  • %s (%s) - %s\n", basename, wt.Branch, reason)
+		printCleanupCandidate(repo, wt, prs)
 	}
 
 	fmt.Println()
 
 	// Show confirmation prompt using bubbletea
-	p := tea.NewProgram(ui.NewCleanupConfirmation(len(worktrees), 0))
-	model, err := p.Run()
+	model, err := ui.Run(ui.NewCleanupConfirmation(len(worktrees), 0))
 	if err != nil {
 		return fmt.Errorf("error running cleanup prompt: %w", err)
 	}
@@ -354,21 +580,28 @@ func promptForCleanup(repo *git.Repository, worktrees []*git.Worktree) error {
 		basename := filepath.Base(wt.Path)
 		fmt.Printf("Removing %s...\n", basename)
 
-		// Remove worktree
-		if err := repo.RemoveWorktree(wt.Path); err != nil {
-			fmt.Printf("  %s Failed to remove: %v\n", ui.ErrorStyle.Render("✗"), err)
-			continue
-		}
-		fmt.Printf("  %s Worktree removed\n", ui.SuccessStyle.Render("✓"))
-
-		// Delete branch if it exists
-		if wt.Branch != "" {
-			if err := repo.DeleteBranch(wt.Branch); err != nil {
-				// Branch deletion failure is not critical
-				fmt.Printf("  %s Failed to delete branch: %v\n", ui.WarningStyle.Render("!"), err)
-			} else {
-				fmt.Printf("  %s Branch deleted\n", ui.SuccessStyle.Render("✓"))
+		// Remove the worktree and its branch under one hold of the lock
+		err := repo.WithOperationLock("removing a worktree", func(locked *git.Repository) error {
+			if err := locked.RemoveWorktree(wt.Path); err != nil {
+				return err
+			}
+			recordCleanup(locked, wt)
+			fmt.Printf("  %s Worktree removed\n", ui.SuccessStyle.Render("✓"))
+
+			// Delete branch if it exists
+			if wt.Branch != "" {
+				if err := locked.DeleteBranch(wt.Branch); err != nil {
+					// Branch deletion failure is not critical
+					fmt.Printf("  %s Failed to delete branch: %v\n", ui.WarningStyle.Render("!"), err)
+				} else {
+					fmt.Printf("  %s Branch deleted\n", ui.SuccessStyle.Render("✓"))
+				}
 			}
+
+			return nil
+		})
+		if err != nil {
+			fmt.Printf("  %s Failed to remove: %v\n", ui.ErrorStyle.Render("✗"), err)
 		}
 	}
 
@@ -382,79 +615,116 @@ func RunNew(skipList bool) error {
 		return fmt.Errorf("error: %w", err)
 	}
 
+	if repo.InvokedFromWorktree != "" {
+		fmt.Println(ui.SubtleStyle.Render(fmt.Sprintf(
+			"Running from worktree %s; the new worktree is created from %s",
+			repo.InvokedFromWorktree, repo.RootPath)))
+		fmt.Println()
+	}
+
 	if !skipList {
-		if err := RunList(); err != nil {
+		if err := RunList(ListOptions{}); err != nil {
 			return err
 		}
 		fmt.Println()
 	}
 
-	branchName, useExisting, err := getBranchInput(repo)
+	opts, err := parseNewArgs(os.Args[min(2, len(os.Args)):])
+	if err != nil {
+		return err
+	}
+
+	branchName, useExisting, err := getBranchInput(repo, opts)
 	if err != nil {
 		return err
 	}
 
-	// Sanitize branch name
-	sanitizedName := git.SanitizeBranchName(branchName)
+	if useExisting && git.IsProtectedBranch(branchName, repo.ProtectedBranchPatterns()) {
+		return fmt.Errorf("%s is a protected branch; create a new branch from it instead (see %s)",
+			branchName, git.ConfigProtectedBranches)
+	}
 
 	// Check if worktree already exists for this branch
 	if err := checkExistingWorktree(repo, branchName); err != nil {
 		return err
 	}
 
-	// Construct worktree path
-	worktreePath := filepath.Join(repo.WorktreeBase, sanitizedName)
-
-	if err := createWorktree(repo, worktreePath, branchName, useExisting); err != nil {
+	base, err := worktreeBase(repo, branchName, useExisting)
+	if err != nil {
 		return err
 	}
 
-	fmt.Printf("✓ Worktree created at: %s\n", worktreePath)
-	terminal.SetTitle(branchName)
+	// Construct worktree path
+	worktreePath := repo.WorktreePath(branchName)
+	config := git.NewConfig(repo.RootPath)
+	useTmux := !opts.NoSession && currentCapabilities(config).Tmux
 
-	// Create tmux session with metadata
-	sessionMgr := session.NewManager()
-	if !sessionMgr.IsAvailable() {
-		if err := handleMissingTmux(); err != nil {
-			return err
-		}
-		// Retry after installation
-		sessionMgr = session.NewManager()
-		if !sessionMgr.IsAvailable() {
-			return fmt.Errorf("tmux is still not available after installation attempt")
+	// Pick the AI tool before the progress view, since picking it can ask
+	var tool *ai.Tool
+	if useTmux {
+		if tool, err = resolveAITool(config); err != nil {
+			logging.Warn("continuing without an AI tool", "err", err)
 		}
 	}
 
-	sessionName := session.GenerateSessionName(branchName)
-	exists, err := sessionMgr.HasSession(sessionName)
-	if err != nil {
-		return fmt.Errorf("failed to check session existence: %w", err)
+	steps := append(worktreeSteps(base, false), stepInstall)
+	if opts.Scope != "" {
+		steps = append(steps, stepScope)
 	}
 
-	if !exists {
-		fmt.Println("\nSetting up tmux session...")
-		config := git.NewConfig(repo.RootPath)
+	if useTmux {
+		steps = append(steps, stepSession)
+	}
 
-		// Resolve AI command (no context for new worktree without issue)
-		aiCommand, err := resolveAICommand(config, "", false, worktreePath)
-		if err != nil {
-			fmt.Printf("⚠ Warning: %v\n", err)
-			// Continue without AI
-		}
+	pipeline := newCreatePipeline(repo, worktreePath, branchName)
+	pipeline.start(steps...)
+	defer pipeline.stop()
 
-		err = createSessionWithAICommand(sessionMgr, config, sessionName, branchName, worktreePath, aiCommand)
-		if err != nil {
-			return fmt.Errorf("failed to create tmux session: %w", err)
+	if err := pipeline.addWorktree(base); err != nil {
+		return err
+	}
+
+	if err := pipeline.install(); err != nil {
+		return err
+	}
+
+	scope := ""
+	if opts.Scope != "" {
+		_ = pipeline.step(stepScope, func() error { //nolint:errcheck // a scope that can't be applied is a warning
+			scope = applyScope(repo, config, worktreePath, opts)
+			return nil
+		})
+	}
+
+	if !useTmux {
+		pipeline.stop()
+		terminal.SetTitle(branchName)
+
+		if opts.NoSession {
+			showWorktreeDirectory("To start working", scopeWorkDir(worktreePath, scope))
+			return nil
 		}
-		fmt.Printf("✓ Tmux session created: %s\n", sessionName)
+
+		return startForegroundSession(config, worktreePath, scope, scopeContext(scope))
+	}
+
+	// Create tmux session with metadata; the AI tool gets only the package scope, if any
+	sessionMgr := session.NewManager()
+	sessionName := session.GenerateSessionName(branchName)
+
+	if _, err := pipeline.createSession(sessionMgr, config, sessionName, scope, tool, scopeContext(scope)); err != nil {
+		return err
 	}
 
+	pipeline.stop()
+	terminal.SetTitle(branchName)
+
 	// Attach to the session
 	fmt.Printf("\nAttaching to session: %s\n", sessionName)
 	if err := sessionMgr.AttachToSession(sessionName); err != nil {
 		fmt.Printf("⚠ Failed to attach to session: %v\n", err)
 		fmt.Printf("You can attach manually with:\n")
-		fmt.Printf("  tmux attach-session -t %s\n", sessionName)
+		fmt.Printf("  %s\n", remote.AttachCommand("tmux attach-session -t "+sessionName))
 		fmt.Printf("Or use:\n")
 		fmt.Printf("  auto-worktree resume\n")
 		return nil
@@ -463,26 +733,15 @@ func RunNew(skipList bool) error {
 	return nil
 }
 
-func getBranchInput(repo *git.Repository) (branchName string, useExisting bool, err error) {
-	if len(os.Args) > 2 {
+func getBranchInput(repo *git.Repository, opts newOptions) (branchName string, useExisting bool, err error) {
+	if opts.Branch != "" {
 		// Command line argument provided
-		arg := os.Args[2]
-		if arg == "--existing" {
-			if len(os.Args) < 4 {
-				return "", false, fmt.Errorf("branch name required after --existing")
-			}
-
-			return os.Args[3], true, nil
-		}
-
-		return arg, false, nil
+		return opts.Branch, opts.Existing, nil
 	}
 
 	// Interactive mode
 	input := ui.NewInput("Enter branch name:", "feature/my-feature or leave empty for random name")
-	p := tea.NewProgram(input)
-
-	m, err := p.Run()
+	m, err := ui.Run(input)
 	if err != nil {
 		return "", false, fmt.Errorf("failed to get input: %w", err)
 	}
@@ -497,9 +756,22 @@ func getBranchInput(repo *git.Repository) (branchName string, useExisting bool,
 	}
 
 	branchName = finalModel.Value()
+	if branchName == "" {
+		// Ask the AI tool for suggestions, if enabled
+		branchName, err = suggestBranchNameWithAI(repo)
+		if err != nil {
+			return "", false, err
+		}
+	}
+
 	if branchName == "" {
 		// Generate random branch name
-		branchName, err = repo.GenerateUniqueBranchName(100)
+		format := repo.Config.GetBranchNameFormat()
+		if opts.Prefix != "" {
+			format.Prefix = repo.Config.ExpandBranchPrefix(opts.Prefix)
+		}
+
+		branchName, err = repo.GenerateUniqueBranchNameWithFormat(format, 100)
 		if err != nil {
 			return "", false, fmt.Errorf("failed to generate random branch name: %w", err)
 		}
@@ -522,85 +794,69 @@ func checkExistingWorktree(repo *git.Repository, branchName string) error {
 	return nil
 }
 
-func createWorktree(repo *git.Repository, worktreePath, branchName string, useExisting bool) error {
+// worktreeBase checks the branch can get a worktree and returns the branch
+// a new one starts from, or "" when useExisting. An existing branch that is
+// only on origin starts from origin/<branch>, which the new branch tracks.
+func worktreeBase(repo *git.Repository, branchName string, useExisting bool) (string, error) {
 	if useExisting {
 		// Check if branch exists
-		if !repo.BranchExists(branchName) {
-			return fmt.Errorf("branch %s does not exist", branchName)
-		}
-
-		fmt.Printf("Creating worktree for existing branch: %s\n", branchName)
-
-		if err := repo.CreateWorktree(worktreePath, branchName); err != nil {
-			return err
-		}
-	} else {
-		// Check if branch already exists
 		if repo.BranchExists(branchName) {
-			return fmt.Errorf("branch %s already exists. Use --existing flag to create worktree for it", branchName)
+			return "", nil
 		}
 
-		// Get default branch as base
-		defaultBranch, err := repo.GetDefaultBranch()
-		if err != nil {
-			return fmt.Errorf("error getting default branch: %w", err)
+		if repo.RemoteBranchExists(branchName) {
+			return "origin/" + branchName, nil
 		}
 
-		fmt.Printf("Creating worktree with new branch: %s (from %s)\n", branchName, defaultBranch)
+		return "", fmt.Errorf("branch %s does not exist locally or on origin", branchName)
+	}
 
-		if err := repo.CreateWorktreeWithNewBranch(worktreePath, branchName, defaultBranch); err != nil {
-			return err
-		}
+	// Check if branch already exists
+	if repo.BranchExists(branchName) {
+		return "", fmt.Errorf("branch %s already exists. Use --existing flag to create worktree for it", branchName)
 	}
 
-	// Setup environment after worktree creation
-	setupEnvironment(repo, worktreePath)
+	if repo.RemoteBranchExists(branchName) {
+		return "", fmt.Errorf("branch %s already exists on origin. Use --existing flag to check it out", branchName)
+	}
 
-	return nil
+	// Start from the configured base branch, or the default branch
+	base, err := repo.BaseBranchFor(branchName)
+	if err != nil {
+		return "", fmt.Errorf("error getting base branch: %w", err)
+	}
+
+	return base, nil
 }
 
-// setupEnvironment runs environment setup for a worktree
-func setupEnvironment(repo *git.Repository, worktreePath string) {
+// setupEnvironment installs a worktree's dependencies, printing progress as
+// it goes. A failed install is returned as an error, so the caller can offer
+// to roll back.
+func setupEnvironment(repo *git.Repository, worktreePath string) error {
 	config := git.NewConfig(repo.RootPath)
 
-	// Get configuration
-	autoInstall := config.GetAutoInstall()
-	packageManager := config.GetPackageManager()
-
-	// Skip if auto-install is disabled
-	if !autoInstall {
-		return
-	}
-
-	// Run setup with spinner
-	spinnerModel := ui.NewSpinnerModel("Detecting project type...")
-	p := tea.NewProgram(spinnerModel)
-
-	// Run setup in background
-	go func() {
-		opts := &environment.SetupOptions{
-			AutoInstall:              autoInstall,
-			ConfiguredPackageManager: packageManager,
-			OnProgress: func(message string) {
-				p.Send(ui.SpinnerUpdateMsg{Message: message})
-			},
-			OnWarning: func(message string) {
-				// Warnings will be shown after spinner completes
-				fmt.Fprintf(os.Stderr, "\nWarning: %s\n", message)
-			},
-		}
+	var installErr error
 
-		// Run setup
-		err := environment.Setup(worktreePath, opts)
+	opts := &environment.SetupOptions{
+		AutoInstall:              config.GetAutoInstall(),
+		ConfiguredPackageManager: config.GetPackageManager(),
+		OnProgress: func(message string) {
+			fmt.Println(message)
+		},
+		OnWarning: func(message string) {
+			logging.Debug(message)
 
-		// Signal completion
-		p.Send(ui.SpinnerDoneMsg{Err: err})
-	}()
+			if installErr == nil {
+				installErr = errors.New(strings.TrimPrefix(message, "Warning: "))
+			}
+		},
+	}
 
-	// Run spinner
-	if _, err := p.Run(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error running spinner: %v\n", err)
+	if err := environment.Setup(worktreePath, opts); err != nil {
+		return err
 	}
+
+	return installErr
 }
 
 // RunResume resumes a worktree by listing available sessions and worktrees.
@@ -613,20 +869,32 @@ func RunResume() error {
 
 	sessionMgr := session.NewManager()
 
+	selectedWorktree, hasSession, err := selectWorktree(repo, sessionMgr, "Select a worktree to resume")
+	if err != nil || selectedWorktree == nil {
+		return err
+	}
+
+	return resumeWorktree(repo, sessionMgr, selectedWorktree, hasSession, "")
+}
+
+// selectWorktree lets the user pick a worktree, listing those with a running
+// session first. It returns nil if the user cancels, and whether the chosen
+// worktree has a session.
+func selectWorktree(repo *git.Repository, sessionMgr *session.SessionManager, title string) (*git.Worktree, bool, error) {
 	// Get all worktrees, excluding the main repository root
 	worktrees, err := repo.ListWorktreesWithMergeStatusExcludingMain()
 	if err != nil {
-		return fmt.Errorf("error listing worktrees: %w", err)
+		return nil, false, fmt.Errorf("error listing worktrees: %w", err)
 	}
 
 	if len(worktrees) == 0 {
-		return fmt.Errorf("no worktrees found")
+		return nil, false, fmt.Errorf("no worktrees found")
 	}
 
 	// Get all active sessions
 	allSessions, err := sessionMgr.ListSessions()
 	if err != nil {
-		return fmt.Errorf("error listing sessions: %w", err)
+		return nil, false, fmt.Errorf("error listing sessions: %w", err)
 	}
 
 	// Filter for auto-worktree sessions
@@ -668,52 +936,66 @@ func RunResume() error {
 	items = append(items, itemsWithoutSessions...)
 
 	if len(items) == 0 {
-		return fmt.Errorf("no worktrees found")
+		return nil, false, fmt.Errorf("no worktrees found")
 	}
 
 	// Show selection UI
-	filterList := ui.NewFilterList("Select a worktree to resume", items)
-	p := tea.NewProgram(filterList, tea.WithAltScreen())
-
-	m, err := p.Run()
+	filterList := ui.NewFilterList(title, items)
+	m, err := ui.Run(filterList, tea.WithAltScreen())
 	if err != nil {
-		return fmt.Errorf("failed to run selection: %w", err)
+		return nil, false, fmt.Errorf("failed to run selection: %w", err)
 	}
 
 	finalModel, ok := m.(ui.FilterListModel)
 	if !ok {
-		return fmt.Errorf("unexpected model type")
+		return nil, false, fmt.Errorf("unexpected model type")
 	}
 
 	if finalModel.Err() != nil {
-		return finalModel.Err()
+		return nil, false, finalModel.Err()
 	}
 
 	choice := finalModel.Choice()
 	if choice == nil {
-		return nil // User canceled
+		return nil, false, nil // User canceled
 	}
 
 	selectedWorktree := worktreeMap[choice.Number()]
 	if selectedWorktree == nil {
-		return fmt.Errorf("selected worktree not found")
+		return nil, false, fmt.Errorf("selected worktree not found")
 	}
-	terminal.SetTitle(formatResumeTitleForTerminal(selectedWorktree))
+
+	return selectedWorktree, sessionMap[session.GenerateSessionName(selectedWorktree.Branch)], nil
+}
+
+// resumeWorktree attaches to a worktree's session, creating one (resuming the AI tool) if needed.
+// A non-empty resumeContext is typed into a running session, or given to the AI tool of a new one.
+func resumeWorktree(repo *git.Repository, sessionMgr *session.SessionManager, wt *git.Worktree, hasSession bool,
+	resumeContext string) error {
+	terminal.SetTitle(formatResumeTitleForTerminal(wt))
 
 	// Run post-worktree hooks before resuming
-	if err := runPostWorktreeHooks(selectedWorktree.Path, repo.RootPath); err != nil {
+	if err := runPostWorktreeHooks(wt.Path, repo.RootPath); err != nil {
 		fmt.Printf("⚠ Hook execution warning: %v\n", err)
 		// Non-fatal: continue with resume
 	}
 
 	// Try to attach to session if available
-	sessionName := session.GenerateSessionName(selectedWorktree.Branch)
-	if sessionMap[sessionName] && sessionMgr.IsAvailable() {
+	sessionName := session.GenerateSessionName(wt.Branch)
+	if hasSession && sessionMgr.IsAvailable() {
+		if resumeContext != "" {
+			if err := sessionMgr.SendText(sessionName, resumeContext); err != nil {
+				return fmt.Errorf("failed to send context to session: %w", err)
+			}
+
+			fmt.Printf("✓ Sent to session: %s\n", sessionName)
+		}
+
 		fmt.Printf("Attaching to session: %s\n", sessionName)
 		if err := sessionMgr.AttachToSession(sessionName); err != nil {
 			fmt.Printf("⚠ Failed to attach to session: %v\n", err)
 			fmt.Printf("To resume manually:\n")
-			fmt.Printf("  cd %s\n", selectedWorktree.Path)
+			fmt.Printf("  cd %s\n", wt.Path)
 			return nil
 		}
 		return nil
@@ -724,14 +1006,17 @@ func RunResume() error {
 		fmt.Println("\nNo existing session found. Creating new session...")
 		config := git.NewConfig(repo.RootPath)
 
-		// Resolve AI command with resume flag (no new context, just resume)
-		aiCommand, err := resolveAICommand(config, "", true, selectedWorktree.Path)
+		// Start in the package the session was scoped to, if any
+		scope := savedScope(sessionMgr, sessionName)
+
+		// Resolve AI command with resume flag
+		aiCommand, conversation, err := resolveAICommand(config, resumeContext, savedConversation(sessionMgr, sessionName), scopeWorkDir(wt.Path, scope))
 		if err != nil {
-			fmt.Printf("⚠ Warning: %v\n", err)
+			logging.Warn("continuing without an AI tool", "err", err)
 			// Continue without AI
 		}
 
-		err = createSessionWithAICommand(sessionMgr, config, sessionName, selectedWorktree.Branch, selectedWorktree.Path, aiCommand)
+		err = createSessionWithAICommand(sessionMgr, config, sessionName, wt.Branch, wt.Path, scope, aiCommand, conversation)
 		if err != nil {
 			return fmt.Errorf("failed to create tmux session: %w", err)
 		}
@@ -742,17 +1027,16 @@ func RunResume() error {
 		if err := sessionMgr.AttachToSession(sessionName); err != nil {
 			fmt.Printf("⚠ Failed to attach to session: %v\n", err)
 			fmt.Printf("To attach manually:\n")
-			fmt.Printf("  tmux attach-session -t %s\n", sessionName)
+			fmt.Printf("  %s\n", remote.AttachCommand("tmux attach-session -t "+sessionName))
 			return nil
 		}
 		return nil
 	}
 
 	// Fallback: show path (no tmux available)
-	fmt.Printf("Worktree: %s\n", selectedWorktree.Branch)
-	fmt.Printf("Path: %s\n", selectedWorktree.Path)
-	fmt.Printf("\nTo resume working:\n")
-	fmt.Printf("  cd %s\n", selectedWorktree.Path)
+	fmt.Printf("Worktree: %s\n", wt.Branch)
+	fmt.Printf("Path: %s\n", wt.Path)
+	showWorktreeDirectory("To resume working", wt.Path)
 
 	return nil
 }
@@ -762,6 +1046,12 @@ func RunResume() error {
 // If issueID is provided, directly creates worktree for that issue.
 // Supports GitHub, GitLab, JIRA, and Linear.
 func RunIssue(issueID string) error {
+	return RunIssueWithFilters(issueID, providers.ListIssuesOptions{})
+}
+
+// RunIssueWithFilters works on an issue like RunIssue, narrowing the interactive
+// selector with the given filters. Unset filters fall back to git config.
+func RunIssueWithFilters(issueID string, filters providers.ListIssuesOptions) error {
 	// 1. Initialize repository
 	repo, err := git.NewRepository()
 	if err != nil {
@@ -775,24 +1065,83 @@ func RunIssue(issueID string) error {
 	}
 
 	// 3. Use unified provider-agnostic workflow
-	return runIssueWithProvider(issueID, repo, provider)
+	filters = applyIssueFilterDefaults(filters, repo.Config)
+
+	return runIssueWithProvider(issueID, repo, provider, filters)
 }
 
-// runIssueWithProvider handles issue workflow for any provider.
-// This is a unified handler that works with GitHub, GitLab, JIRA, Linear, etc.
-func runIssueWithProvider(issueID string, repo *git.Repository, provider providers.Provider) error {
-	ctx := context.Background()
+// applyIssueFilterDefaults fills unset issue filters from git config and
+// normalizes the assignee shorthand ("me", "unassigned").
+func applyIssueFilterDefaults(filters providers.ListIssuesOptions, cfg *git.Config) providers.ListIssuesOptions {
+	if len(filters.Labels) == 0 {
+		filters.Labels = cfg.GetIssueFilterLabels()
+	}
 
-	// 1. Display provider info
-	fmt.Printf("Provider: %s\n\n", provider.Name())
+	if filters.Assignee == "" {
+		filters.Assignee = cfg.GetIssueFilterAssignee()
+	}
 
-	// 2. Get issue (interactive or direct)
-	var issue *providers.Issue
-	var err error
+	if filters.Milestone == "" {
+		filters.Milestone = cfg.GetIssueFilterMilestone()
+	}
 
-	if issueID == "" {
-		// Interactive mode: select from list
-		issue, err = selectIssueInteractiveGeneric(ctx, provider)
+	if filters.Sort == "" {
+		filters.Sort = cfg.GetIssueSort()
+	}
+
+	if filters.Limit <= 0 {
+		filters.Limit = cfg.GetIssueListLimit()
+	}
+
+	filters.Assignee = normalizeAssigneeFilter(filters.Assignee)
+
+	return filters
+}
+
+// normalizeAssigneeFilter maps user-facing assignee values to provider filter values
+func normalizeAssigneeFilter(assignee string) string {
+	switch strings.ToLower(strings.TrimSpace(assignee)) {
+	case "":
+		return ""
+	case "me", "@me", "self":
+		return providers.AssigneeSelf
+	case "unassigned", "none":
+		return providers.AssigneeNone
+	default:
+		return strings.TrimSpace(assignee)
+	}
+}
+
+// issueBranchName names an issue's branch: a prefix chosen by its labels or type
+// (auto-worktree.issue-branch-prefixes, default work/), the issue ID and its title.
+// A work/ branch started before the prefixes were configured keeps being used.
+func issueBranchName(repo *git.Repository, provider providers.Provider, issue *providers.Issue) string {
+	name := fmt.Sprintf("%s-%s", provider.GetBranchNameSuffix(issue), provider.SanitizeBranchName(issue.Title))
+
+	prefix := git.BranchPrefixForIssue(repo.Config.GetIssueBranchPrefixes(), issue.Labels, issue.Type)
+	if prefix != "work/" && repo.BranchExists("work/"+name) {
+		return "work/" + name
+	}
+
+	return prefix + name
+}
+
+// runIssueWithProvider handles issue workflow for any provider.
+// This is a unified handler that works with GitHub, GitLab, JIRA, Linear, etc.
+func runIssueWithProvider(issueID string, repo *git.Repository, provider providers.Provider,
+	filters providers.ListIssuesOptions) error {
+	ctx := interrupt.Context()
+
+	// 1. Display provider info
+	fmt.Printf("Provider: %s\n\n", provider.Name())
+
+	// 2. Get issue (interactive or direct)
+	var issue *providers.Issue
+	var err error
+
+	if issueID == "" {
+		// Interactive mode: select from list
+		issue, err = selectIssueInteractiveGeneric(ctx, provider, filters)
 		if err != nil {
 			return err
 		}
@@ -814,10 +1163,10 @@ func runIssueWithProvider(issueID string, repo *git.Repository, provider provide
 		return fmt.Errorf("issue %s is already closed", issue.ID)
 	}
 
+	recordHistory(repo, issue.Title, "issue", issue.ID)
+
 	// 4. Generate branch name
-	suffix := provider.GetBranchNameSuffix(issue)
-	sanitized := provider.SanitizeBranchName(issue.Title)
-	branchName := fmt.Sprintf("work/%s-%s", suffix, sanitized)
+	branchName := issueBranchName(repo, provider, issue)
 
 	// 5. Check if worktree already exists
 	existingWt, err := repo.GetWorktreeForBranch(branchName)
@@ -832,8 +1181,7 @@ func runIssueWithProvider(issueID string, repo *git.Repository, provider provide
 		terminal.SetTitle(formatIssueTitleForTerminal(issue))
 
 		confirmModel := ui.NewConfirmModel(resumePrompt)
-		p := tea.NewProgram(confirmModel)
-		result, err := p.Run()
+		result, err := ui.Run(confirmModel)
 		if err != nil {
 			return fmt.Errorf("error getting resume confirmation: %w", err)
 		}
@@ -874,21 +1222,30 @@ func runIssueWithProvider(issueID string, repo *git.Repository, provider provide
 			issueContext := buildIssueContext(issue, provider.Name())
 			resumeContext := fmt.Sprintf("%s\n\n%s", issueContext, resumePrompt)
 
-			aiCommand, err := resolveAICommand(config, resumeContext, true, existingWt.Path)
+			// Lead with what happened on the issue and its PR while the session was away
+			previous, _ := sessionMgr.LoadSessionMetadata(sessionName) //nolint:errcheck // no metadata means nothing to compare
+			if changes := issueChangesSince(ctx, provider, issue, existingWt.Branch, previous); changes != "" {
+				fmt.Println("✓ Added what changed on the issue since the last session")
+				resumeContext = changes + "\n" + resumeContext
+			}
+
+			aiCommand, conversation, err := resolveAICommand(config, resumeContext, savedConversation(sessionMgr, sessionName), existingWt.Path)
 			if err != nil {
-				fmt.Printf("⚠ Warning: %v\n", err)
+				logging.Warn("continuing without an AI tool", "err", err)
 			}
 
-			if err := createSessionWithAICommand(sessionMgr, config, sessionName, existingWt.Branch, existingWt.Path, aiCommand); err != nil {
+			if err := createSessionWithAICommand(sessionMgr, config, sessionName, existingWt.Branch, existingWt.Path, "", aiCommand, conversation); err != nil {
 				return fmt.Errorf("failed to create tmux session: %w", err)
 			}
+
+			recordIssueSnapshot(sessionMgr, sessionName, provider, issue)
 			fmt.Printf("✓ Tmux session created: %s\n", sessionName)
 
 			fmt.Printf("\nAttaching to session: %s\n", sessionName)
 			if err := sessionMgr.AttachToSession(sessionName); err != nil {
 				fmt.Printf("⚠ Failed to attach to session: %v\n", err)
 				fmt.Printf("To attach manually:\n")
-				fmt.Printf("  tmux attach-session -t %s\n", sessionName)
+				fmt.Printf("  %s\n", remote.AttachCommand("tmux attach-session -t "+sessionName))
 			}
 			return nil
 		}
@@ -900,110 +1257,169 @@ func runIssueWithProvider(issueID string, repo *git.Repository, provider provide
 		return nil
 	}
 
-	// 6. Create worktree
-	worktreePath := filepath.Join(repo.WorktreeBase, git.SanitizeBranchName(branchName))
+	// 6. Create worktree, from the base branch unless the branch exists locally or on origin
+	worktreePath := repo.WorktreePath(branchName)
+	config := git.NewConfig(repo.RootPath)
+	useTmux := currentCapabilities(config).Tmux
 
-	// Check if branch exists
-	if repo.BranchExists(branchName) {
-		fmt.Printf("Creating worktree for existing branch: %s\n", branchName)
-		if err := repo.CreateWorktree(worktreePath, branchName); err != nil {
-			return fmt.Errorf("failed to create worktree: %w", err)
-		}
-	} else {
-		defaultBranch, err := repo.GetDefaultBranch()
-		if err != nil {
-			return fmt.Errorf("error getting default branch: %w", err)
+	base, err := worktreeBase(repo, branchName, repo.BranchExists(branchName) || repo.RemoteBranchExists(branchName))
+	if err != nil {
+		return err
+	}
+
+	// Pick the AI tool before the progress view, since picking it can ask
+	var tool *ai.Tool
+	if useTmux {
+		if tool, err = resolveAITool(config); err != nil {
+			logging.Warn("continuing without an AI tool", "err", err)
 		}
+	}
+
+	steps := append(worktreeSteps(base, false), stepInstall, stepHooks)
+	if useTmux {
+		steps = append(steps, stepSession)
+	}
 
-		fmt.Printf("Creating worktree for issue %s: %s\n", issue.ID, issue.Title)
-		fmt.Printf("Branch: %s (from %s)\n", branchName, defaultBranch)
+	fmt.Printf("Issue %s: %s\n", issue.ID, issue.Title)
 
-		if err := repo.CreateWorktreeWithNewBranch(worktreePath, branchName, defaultBranch); err != nil {
-			return fmt.Errorf("failed to create worktree: %w", err)
-		}
+	pipeline := newCreatePipeline(repo, worktreePath, branchName)
+	pipeline.start(steps...)
+	defer pipeline.stop()
+
+	if err := pipeline.addWorktree(base); err != nil {
+		return err
 	}
 
 	// 7. Setup environment after worktree creation
-	setupEnvironment(repo, worktreePath)
+	if err := pipeline.install(); err != nil {
+		return err
+	}
 
-	// 8. Display success message
-	fmt.Printf("\n✓ Worktree created at: %s\n", worktreePath)
-	terminal.SetTitle(formatIssueTitleForTerminal(issue))
+	// Optionally claim the issue on the tracker
+	if config.GetIssueSelfAssign() {
+		claimIssue(ctx, provider, issue, branchName)
+	}
 
-	// 9. Run post-worktree hooks
-	if err := runPostWorktreeHooks(worktreePath, repo.RootPath); err != nil {
-		return fmt.Errorf("hook execution failed: %w", err)
+	// Move the issue's card on its project board, e.g. to In Progress
+	if tracker, ok := provider.(providers.BoardTracker); ok {
+		startIssueOnBoard(ctx, tracker, issue)
 	}
 
-	// 10. Create tmux session with AI tool
-	sessionMgr := session.NewManager()
-	if !sessionMgr.IsAvailable() {
-		if err := handleMissingTmux(); err != nil {
-			return err
-		}
-		// Retry after installation
-		sessionMgr = session.NewManager()
-		if !sessionMgr.IsAvailable() {
-			return fmt.Errorf("tmux is still not available after installation attempt")
-		}
+	// 8. Run post-worktree hooks
+	if err := pipeline.runHooks(); err != nil {
+		return err
 	}
 
-	sessionName := session.GenerateSessionName(branchName)
-	exists, err := sessionMgr.HasSession(sessionName)
-	if err != nil {
-		return fmt.Errorf("failed to check session existence: %w", err)
+	// 9. Create tmux session with AI tool
+	issueContext := buildIssueContext(issue, provider.Name())
+
+	if !useTmux {
+		pipeline.stop()
+		terminal.SetTitle(formatIssueTitleForTerminal(issue))
+
+		return startForegroundSession(config, worktreePath, "", issueContext)
 	}
 
-	if !exists {
-		fmt.Println("\nSetting up tmux session...")
-		config := git.NewConfig(repo.RootPath)
+	sessionMgr := session.NewManager()
+	sessionName := session.GenerateSessionName(branchName)
 
-		// Build issue context for AI tool
-		issueContext := buildIssueContext(issue, provider.Name())
+	created, err := pipeline.createSession(sessionMgr, config, sessionName, "", tool, issueContext)
+	if err != nil {
+		return err
+	}
 
-		// Resolve AI command with issue context
-		aiCommand, err := resolveAICommand(config, issueContext, false, worktreePath)
-		if err != nil {
-			fmt.Printf("⚠ Warning: %v\n", err)
-			// Continue without AI
-		}
+	pipeline.stop()
+	terminal.SetTitle(formatIssueTitleForTerminal(issue))
 
-		err = createSessionWithAICommand(sessionMgr, config, sessionName, branchName, worktreePath, aiCommand)
-		if err != nil {
-			return fmt.Errorf("failed to create tmux session: %w", err)
-		}
-		fmt.Printf("✓ Tmux session created: %s\n", sessionName)
+	if created {
+		recordIssueSnapshot(sessionMgr, sessionName, provider, issue)
 	}
 
 	fmt.Printf("\nTo start working, attach to the session:\n")
-	fmt.Printf("  tmux attach-session -t %s\n", sessionName)
+	fmt.Printf("  %s\n", remote.AttachCommand("tmux attach-session -t "+sessionName))
 	fmt.Printf("\nOr use auto-worktree resume to attach\n")
 
 	return nil
 }
 
-// selectIssueInteractiveGeneric shows an interactive issue selector for any provider
-func selectIssueInteractiveGeneric(ctx context.Context, provider providers.Provider) (*providers.Issue, error) {
+// claimIssue assigns the issue to the current user and posts a "started work" comment.
+// Failures are reported as warnings since the worktree has already been created.
+func claimIssue(ctx context.Context, provider providers.Provider, issue *providers.Issue, branchName string) {
+	if err := provider.AddAssignee(ctx, issue.ID, providers.AssigneeSelf); err != nil {
+		fmt.Printf("⚠ Could not assign issue %s: %v\n", issue.ID, err)
+	} else {
+		fmt.Printf("✓ Assigned issue %s to you\n", issue.ID)
+	}
+
+	comment := fmt.Sprintf("Started work in branch `%s`", branchName)
+	if err := provider.AddComment(ctx, issue.ID, comment); err != nil {
+		fmt.Printf("⚠ Could not comment on issue %s: %v\n", issue.ID, err)
+	} else {
+		fmt.Printf("✓ Commented on issue %s\n", issue.ID)
+	}
+}
+
+// startIssueOnBoard moves the issue to its board's in-progress column. Like
+// claimIssue, failures are only warnings.
+func startIssueOnBoard(ctx context.Context, tracker providers.BoardTracker, issue *providers.Issue) {
+	column, err := tracker.StartWork(ctx, issue.ID)
+
+	switch {
+	case err != nil:
+		fmt.Printf("⚠ Could not move issue %s on the project board: %v\n", issue.ID, err)
+	case column != "":
+		fmt.Printf("✓ Moved issue %s to %s\n", issue.ID, column)
+	}
+}
+
+// selectIssueInteractiveGeneric shows an interactive issue selector for any provider.
+// When a full page of issues is shown, the user can load the next page from the list.
+func selectIssueInteractiveGeneric(ctx context.Context, provider providers.Provider,
+	filters providers.ListIssuesOptions) (*providers.Issue, error) {
+	pageSize := filters.EffectiveLimit()
+
+	for {
+		issue, loadMore, err := selectIssueFromPage(ctx, provider, filters)
+		if err != nil || !loadMore {
+			return issue, err
+		}
+
+		filters.Limit = filters.EffectiveLimit() + pageSize
+	}
+}
+
+// selectIssueFromPage fetches issues matching the filters and shows the selector.
+// Returns loadMore=true if the user asked for more issues instead of choosing one.
+func selectIssueFromPage(ctx context.Context, provider providers.Provider,
+	filters providers.ListIssuesOptions) (*providers.Issue, bool, error) {
 	// Fetch open issues
-	issues, err := provider.ListIssues(ctx, 20)
+	issues, err := provider.ListIssues(ctx, filters)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list issues: %w", err)
+		return nil, false, fmt.Errorf("failed to list issues: %w", err)
 	}
 
 	if len(issues) == 0 {
-		return nil, fmt.Errorf("no open issues found")
+		if filters.IsFiltered() {
+			return nil, false, fmt.Errorf("no open issues match the current filters")
+		}
+
+		return nil, false, fmt.Errorf("no open issues found")
 	}
 
+	// A full page suggests there are more issues to fetch
+	hasMore := len(issues) >= filters.EffectiveLimit()
+
+	providers.SortIssues(issues, filters.Sort)
+
 	// Check if AI auto-select is enabled
 	repo, err := git.NewRepository()
 	if err == nil {
 		issueAutoselect, err := repo.Config.GetBool(git.ConfigIssueAutoselect, git.ConfigScopeAuto)
 		if err == nil && issueAutoselect {
-			fmt.Println("Using AI to prioritize issues...")
-			issues = aiSelectIssues(repo, issues, provider.ProviderType())
-			if len(issues) > 0 {
-				fmt.Printf("Showing top %d AI-prioritized issues\n", len(issues))
-			}
+			var note string
+
+			issues, note = rankIssues(repo, issues, provider.ProviderType())
+			fmt.Println(note)
 		}
 	}
 
@@ -1017,34 +1433,41 @@ func selectIssueInteractiveGeneric(ctx context.Context, provider providers.Provi
 
 	// Create and run the filterable list UI
 	model := ui.NewFilterList("Select an issue", items)
-	p := tea.NewProgram(model, tea.WithAltScreen())
-	finalModel, err := p.Run()
+	if hasMore {
+		model = model.WithLoadMore()
+	}
+
+	finalModel, err := ui.Run(model, tea.WithAltScreen())
 	if err != nil {
-		return nil, fmt.Errorf("failed to run issue selector: %w", err)
+		return nil, false, fmt.Errorf("failed to run issue selector: %w", err)
 	}
 
 	// Get the selected item
 	m, ok := finalModel.(ui.FilterListModel)
 	if !ok {
-		return nil, fmt.Errorf("unexpected model type")
+		return nil, false, fmt.Errorf("unexpected model type")
+	}
+
+	if m.LoadMore() {
+		return nil, true, nil
 	}
 
 	if m.Err() != nil {
-		return nil, m.Err()
+		return nil, false, m.Err()
 	}
 
 	choice := m.Choice()
 	if choice == nil {
-		return nil, fmt.Errorf("no issue selected")
+		return nil, false, fmt.Errorf("no issue selected")
 	}
 
 	// Look up the original issue by ID
 	idx, ok := issueMap[choice.ID()]
 	if !ok {
-		return nil, fmt.Errorf("selected issue not found")
+		return nil, false, fmt.Errorf("selected issue not found")
 	}
 
-	return &issues[idx], nil
+	return &issues[idx], false, nil
 }
 
 // RunCreate creates a new issue using any configured provider.
@@ -1064,10 +1487,31 @@ func RunCreate() error {
 
 	fmt.Printf("Provider: %s\n\n", provider.Name())
 
-	// 3. Get issue title (interactive)
-	titleInput := ui.NewInput("Issue Title", "Enter a title for the issue")
-	p := tea.NewProgram(titleInput)
-	result, err := p.Run()
+	// 3. Pick an issue template, if the repository has any
+	tmpl, err := selectIssueTemplate(repo, provider.ProviderType())
+	if err != nil {
+		return err
+	}
+
+	templateTitle, templateBody := "", ""
+	if tmpl != nil {
+		templateTitle, templateBody = tmpl.Title, tmpl.Body
+	}
+
+	// 4. Optionally expand a one-line summary into a full draft with the AI tool
+	draft, err := offerAIIssueDraft(repo, templateBody)
+	if err != nil {
+		return err
+	}
+
+	initialTitle, initialBody := templateTitle, templateBody
+	if draft != nil {
+		initialTitle, initialBody = draft.Title, draft.Body
+	}
+
+	// 5. Get issue title (interactive)
+	titleInput := ui.NewInput("Issue Title", "Enter a title for the issue").WithValue(initialTitle)
+	result, err := ui.Run(titleInput)
 	if err != nil {
 		return fmt.Errorf("error getting title input: %w", err)
 	}
@@ -1080,15 +1524,14 @@ func RunCreate() error {
 		return fmt.Errorf("canceled")
 	}
 
-	title := titleModel.Value()
-	if title == "" {
+	title := strings.TrimSpace(titleModel.Value())
+	if title == "" || title == strings.TrimSpace(templateTitle) {
 		return fmt.Errorf("issue title cannot be empty")
 	}
 
-	// 4. Get issue body (interactive, optional)
-	bodyInput := ui.NewTextArea("Issue Description (optional)", "Describe the issue...")
-	p = tea.NewProgram(bodyInput)
-	result, err = p.Run()
+	// 6. Get issue body (interactive, optional); templates and drafts are edited in place
+	bodyInput := ui.NewTextArea("Issue Description (optional)", "Describe the issue...").WithValue(initialBody)
+	result, err = ui.Run(bodyInput)
 	if err != nil {
 		return fmt.Errorf("error getting body input: %w", err)
 	}
@@ -1103,11 +1546,10 @@ func RunCreate() error {
 
 	body := bodyModel.Value()
 
-	// 5. Confirm before creating
+	// 7. Confirm before creating
 	confirmMsg := fmt.Sprintf("Create issue: %s?", title)
 	confirmModel := ui.NewConfirmModel(confirmMsg)
-	p = tea.NewProgram(confirmModel)
-	result, err = p.Run()
+	result, err = ui.Run(confirmModel)
 	if err != nil {
 		return fmt.Errorf("error getting confirmation: %w", err)
 	}
@@ -1121,24 +1563,25 @@ func RunCreate() error {
 		return nil
 	}
 
-	// 6. Create the issue using the provider
+	// 8. Create the issue using the provider, then apply template labels/assignees
 	fmt.Println("\nCreating issue...")
-	ctx := context.Background()
+	ctx := interrupt.Context()
 	issue, err := provider.CreateIssue(ctx, title, body)
 	if err != nil {
 		return fmt.Errorf("failed to create issue: %w", err)
 	}
 
-	// 7. Display success message
+	applyTemplateMetadata(ctx, provider, issue, tmpl)
+
+	// 9. Display success message
 	fmt.Printf("\n✓ Issue created successfully!\n")
 	fmt.Printf("\nIssue %s: %s\n", issue.ID, issue.Title)
 	fmt.Printf("URL: %s\n", issue.URL)
 
-	// 8. Offer to create worktree for the new issue
+	// 10. Offer to create worktree for the new issue
 	wtConfirmMsg := fmt.Sprintf("Create a worktree for issue %s?", issue.ID)
 	wtConfirmModel := ui.NewConfirmModel(wtConfirmMsg)
-	p = tea.NewProgram(wtConfirmModel)
-	result, err = p.Run()
+	result, err = ui.Run(wtConfirmModel)
 	if err != nil {
 		return fmt.Errorf("error getting worktree confirmation: %w", err)
 	}
@@ -1151,86 +1594,106 @@ func RunCreate() error {
 		return nil
 	}
 
-	// 9. Create worktree for the new issue
-	suffix := provider.GetBranchNameSuffix(issue)
-	sanitized := provider.SanitizeBranchName(issue.Title)
-	branchName := fmt.Sprintf("work/%s-%s", suffix, sanitized)
-	worktreePath := filepath.Join(repo.WorktreeBase, git.SanitizeBranchName(branchName))
+	// 11. Create worktree for the new issue
+	branchName := issueBranchName(repo, provider, issue)
+	worktreePath := repo.WorktreePath(branchName)
 
-	defaultBranch, err := repo.GetDefaultBranch()
+	config := git.NewConfig(repo.RootPath)
+	useTmux := currentCapabilities(config).Tmux
+
+	baseBranch, err := repo.BaseBranchFor(branchName)
 	if err != nil {
-		return fmt.Errorf("error getting default branch: %w", err)
+		return fmt.Errorf("error getting base branch: %w", err)
 	}
 
-	fmt.Printf("\nCreating worktree for issue %s...\n", issue.ID)
-	fmt.Printf("Branch: %s (from %s)\n", branchName, defaultBranch)
+	// Pick the AI tool before the progress view, since picking it can ask
+	var tool *ai.Tool
+	if useTmux {
+		if tool, err = resolveAITool(config); err != nil {
+			logging.Warn("continuing without an AI tool", "err", err)
+		}
+	}
 
-	if err := repo.CreateWorktreeWithNewBranch(worktreePath, branchName, defaultBranch); err != nil {
-		return fmt.Errorf("failed to create worktree: %w", err)
+	steps := append(worktreeSteps(baseBranch, false), stepInstall)
+	if useTmux {
+		steps = append(steps, stepSession)
 	}
 
-	// Setup environment after worktree creation
-	setupEnvironment(repo, worktreePath)
+	fmt.Println()
+
+	pipeline := newCreatePipeline(repo, worktreePath, branchName)
+	pipeline.start(steps...)
+	defer pipeline.stop()
+
+	if err := pipeline.addWorktree(baseBranch); err != nil {
+		return err
+	}
 
-	fmt.Printf("\n✓ Worktree created at: %s\n", worktreePath)
+	// Setup environment after worktree creation
+	if err := pipeline.install(); err != nil {
+		return err
+	}
 
 	// Create tmux session with AI tool
-	sessionMgr := session.NewManager()
-	if !sessionMgr.IsAvailable() {
-		if err := handleMissingTmux(); err != nil {
-			return err
-		}
-		// Retry after installation
-		sessionMgr = session.NewManager()
-		if !sessionMgr.IsAvailable() {
-			return fmt.Errorf("tmux is still not available after installation attempt")
-		}
+	issueContext := buildIssueContext(issue, provider.Name())
+
+	if !useTmux {
+		pipeline.stop()
+		return startForegroundSession(config, worktreePath, "", issueContext)
 	}
 
+	sessionMgr := session.NewManager()
 	sessionName := session.GenerateSessionName(branchName)
-	exists, err := sessionMgr.HasSession(sessionName)
+
+	created, err := pipeline.createSession(sessionMgr, config, sessionName, "", tool, issueContext)
 	if err != nil {
-		return fmt.Errorf("failed to check session existence: %w", err)
+		return err
 	}
 
-	if !exists {
-		fmt.Println("\nSetting up tmux session...")
-		config := git.NewConfig(repo.RootPath)
-
-		// Build issue context for AI tool
-		issueContext := buildIssueContext(issue, provider.Name())
-
-		// Resolve AI command with issue context
-		aiCommand, err := resolveAICommand(config, issueContext, false, worktreePath)
-		if err != nil {
-			fmt.Printf("⚠ Warning: %v\n", err)
-			// Continue without AI
-		}
+	pipeline.stop()
 
-		err = createSessionWithAICommand(sessionMgr, config, sessionName, branchName, worktreePath, aiCommand)
-		if err != nil {
-			return fmt.Errorf("failed to create tmux session: %w", err)
-		}
-		fmt.Printf("✓ Tmux session created: %s\n", sessionName)
+	if created {
+		recordIssueSnapshot(sessionMgr, sessionName, provider, issue)
 	}
 
 	fmt.Printf("\nTo start working, attach to the session:\n")
-	fmt.Printf("  tmux attach-session -t %s\n", sessionName)
+	fmt.Printf("  %s\n", remote.AttachCommand("tmux attach-session -t "+sessionName))
 	fmt.Printf("\nOr use auto-worktree resume to attach\n")
 
 	return nil
 }
 
+// PROptions control how RunPRWithOptions checks out a pull request
+type PROptions struct {
+	// ReadOnly checks out the PR's head detached, with no local branch, in a
+	// review-only worktree that cleanup removes after a day and that isn't pushed from
+	ReadOnly bool
+}
+
 // RunPR reviews a pull request.
 // If prID is empty, shows interactive PR selector.
 // If prID is numeric, directly creates worktree for that PR.
 func RunPR(prID string) error {
+	return RunPRWithOptions(prID, PROptions{})
+}
+
+// RunPRWithOptions reviews a pull request like RunPR, as opts says
+func RunPRWithOptions(prID string, opts PROptions) error {
 	// 1. Initialize repository
 	repo, err := git.NewRepository()
 	if err != nil {
 		return fmt.Errorf("error: %w", err)
 	}
 
+	// Review where the code is hosted, whichever system tracks the issues
+	switch codeHost := resolveCodeHostType(repo.Config); codeHost {
+	case providerGitHub:
+	case providerGitLab:
+		return runMergeRequestReview(repo, prID, opts)
+	default:
+		return fmt.Errorf("PR review is not supported for code host %s", codeHost)
+	}
+
 	// 2. Check gh CLI availability
 	executor := github.NewGitHubExecutor()
 	if !github.IsInstalled(executor) {
@@ -1281,9 +1744,11 @@ func RunPR(prID string) error {
 		return fmt.Errorf("PR #%d is already merged", prNum)
 	}
 	if pr.State == "CLOSED" {
-		fmt.Printf("Warning: PR #%d is closed but not merged\n", prNum)
+		logging.Warn(fmt.Sprintf("PR #%d is closed but not merged", prNum))
 	}
 
+	recordHistory(repo, pr.Title, "pr", strconv.Itoa(pr.Number))
+
 	// 7. Display PR metadata
 	fmt.Printf("\n━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
 	fmt.Printf("PR #%d: %s\n", pr.Number, pr.Title)
@@ -1312,7 +1777,7 @@ func RunPR(prID string) error {
 	// 9. Check for merge conflicts
 	hasConflicts, err := client.HasMergeConflicts(prNum)
 	if err != nil {
-		fmt.Printf("Warning: Could not check merge conflicts: %v\n", err)
+		logging.Warn("could not check merge conflicts", "err", err)
 	} else if hasConflicts {
 		fmt.Printf("\n⚠️  Warning: This PR has merge conflicts with %s\n", pr.BaseRefName)
 	}
@@ -1332,92 +1797,142 @@ func RunPR(prID string) error {
 	if shouldGenerateAIReview(repo) {
 		fmt.Println("Generating AI review summary...")
 		if err := generateAIReviewSummary(client, pr, repo); err != nil {
-			fmt.Printf("Warning: Could not generate AI review: %v\n\n", err)
+			logging.Warn("could not generate AI review", "err", err)
 		}
 	}
 
-	// 12. Generate branch name: pr/<number>-<sanitized-title>
-	branchName := pr.BranchName()
+	// 12. Check out the PR on pr/<number>-<sanitized-title>, or review-only
+	prContext := buildPRContextFromGitHub(pr)
+
+	return startPRReview(repo, prReview{
+		Label:   fmt.Sprintf("PR #%d", pr.Number),
+		Title:   pr.Title,
+		URL:     pr.URL,
+		Branch:  pr.BranchName(),
+		Head:    pr.Head(),
+		Context: prContext,
+	}, opts)
+}
+
+// prReview is a pull or merge request to check out in a worktree for review
+type prReview struct {
+	// Label names it in output, e.g. "PR #12" or "MR !12"
+	Label string
+	Title string
+	URL   string
+	// Branch is the local branch it is checked out on; review-only worktrees
+	// have none, and the name only picks their path and session
+	Branch string
+	Head   git.PullRequestHead
+	// Context is the prompt the AI tool starts with
+	Context string
+}
+
+// startPRReview creates the worktree for a pull or merge request, checked out
+// on a new branch unless it exists locally, or detached when review-only, and
+// starts the AI tool in it. An existing worktree for it is offered instead.
+func startPRReview(repo *git.Repository, review prReview, opts PROptions) error {
+	branchName := review.Branch
+	if opts.ReadOnly {
+		branchName = "review/" + branchName
+	}
+
+	var (
+		existingWt *git.Worktree
+		err        error
+	)
+
+	if opts.ReadOnly {
+		existingWt, err = repo.GetReviewWorktree(review.Head.Number)
+	} else {
+		existingWt, err = repo.GetWorktreeForBranch(branchName)
+	}
 
-	// 13. Check if worktree already exists
-	existingWt, err := repo.GetWorktreeForBranch(branchName)
 	if err != nil {
 		return fmt.Errorf("error checking for existing worktree: %w", err)
 	}
 
 	if existingWt != nil {
 		// Offer to resume existing worktree
-		return offerResumePRWorktree(existingWt, pr)
+		return offerResumePRWorktree(existingWt, review.Label)
 	}
 
-	// 14. Create worktree
-	worktreePath := filepath.Join(repo.WorktreeBase, git.SanitizeBranchName(branchName))
+	worktreePath := repo.WorktreePath(branchName)
+	config := git.NewConfig(repo.RootPath)
+	useTmux := currentCapabilities(config).Tmux
 
-	// Check if branch exists locally
-	if repo.BranchExists(branchName) {
-		fmt.Printf("Creating worktree for existing branch: %s\n", branchName)
-		if err := repo.CreateWorktree(worktreePath, branchName); err != nil {
-			return fmt.Errorf("failed to create worktree: %w", err)
+	base := ""
+	if !opts.ReadOnly {
+		base, err = worktreeBase(repo, branchName, repo.BranchExists(branchName) || repo.RemoteBranchExists(branchName))
+		if err != nil {
+			return err
 		}
-	} else {
-		// Fetch the PR branch from the remote
-		fmt.Printf("Creating worktree for PR #%d: %s\n", pr.Number, pr.Title)
-		fmt.Printf("Branch: %s (tracking %s)\n", branchName, pr.HeadRefName)
+	}
 
-		// Create worktree and checkout the PR
-		if err := checkoutPRInWorktree(repo, worktreePath, branchName, pr); err != nil {
-			return fmt.Errorf("failed to checkout PR: %w", err)
+	// Pick the AI tool before the progress view, since picking it can ask
+	var tool *ai.Tool
+	if useTmux {
+		if tool, err = resolveAITool(config); err != nil {
+			logging.Warn("continuing without an AI tool", "err", err)
 		}
 	}
 
-	// 15. Display success message
-	fmt.Printf("\n✓ Worktree created at: %s\n", worktreePath)
-	fmt.Printf("\nPR #%d: %s\n", pr.Number, pr.Title)
-	fmt.Printf("URL: %s\n", pr.URL)
-	terminal.SetTitle(formatPRTitleForTerminal(pr))
+	steps := worktreeSteps(base, base != "")
+	if useTmux {
+		steps = append(steps, stepSession)
+	}
 
-	// 16. Create tmux session with AI tool for PR review
-	sessionMgr := session.NewManager()
-	if !sessionMgr.IsAvailable() {
-		if err := handleMissingTmux(); err != nil {
+	fmt.Printf("\n%s: %s\n", review.Label, review.Title)
+	fmt.Printf("URL: %s\n", review.URL)
+
+	pipeline := newCreatePipeline(repo, worktreePath, branchName)
+	pipeline.start(steps...)
+	defer pipeline.stop()
+
+	switch {
+	case opts.ReadOnly:
+		if err := pipeline.addReviewWorktree(review.Head); err != nil {
+			return err
+		}
+	default:
+		if err := pipeline.addWorktree(base); err != nil {
 			return err
 		}
-		// Retry after installation
-		sessionMgr = session.NewManager()
-		if !sessionMgr.IsAvailable() {
-			return fmt.Errorf("tmux is still not available after installation attempt")
+
+		if base != "" {
+			if err := checkoutPRInWorktree(pipeline, review); err != nil {
+				return err
+			}
 		}
 	}
 
-	sessionName := session.GenerateSessionName(branchName)
-	exists, err := sessionMgr.HasSession(sessionName)
-	if err != nil {
-		return fmt.Errorf("failed to check session existence: %w", err)
+	// Create tmux session with AI tool for the review
+	prContext := review.Context
+	if opts.ReadOnly {
+		prContext += "\nThis is a read-only checkout for review: don't commit or push changes."
 	}
 
-	if !exists {
-		fmt.Println("\nSetting up tmux session...")
-		config := git.NewConfig(repo.RootPath)
+	terminalTitle := formatTerminalTitle(review.Label, strings.TrimSpace(review.Title))
 
-		// Build PR context for AI tool
-		prContext := buildPRContextFromGitHub(pr)
+	if !useTmux {
+		pipeline.stop()
+		terminal.SetTitle(terminalTitle)
 
-		// Resolve AI command with PR context
-		aiCommand, err := resolveAICommand(config, prContext, false, worktreePath)
-		if err != nil {
-			fmt.Printf("⚠ Warning: %v\n", err)
-			// Continue without AI
-		}
+		return startForegroundSession(config, worktreePath, "", prContext)
+	}
 
-		err = createSessionWithAICommand(sessionMgr, config, sessionName, branchName, worktreePath, aiCommand)
-		if err != nil {
-			return fmt.Errorf("failed to create tmux session: %w", err)
-		}
-		fmt.Printf("✓ Tmux session created: %s\n", sessionName)
+	sessionMgr := session.NewManager()
+	sessionName := session.GenerateSessionName(branchName)
+
+	if _, err := pipeline.createSession(sessionMgr, config, sessionName, "", tool, prContext); err != nil {
+		return err
 	}
 
+	pipeline.stop()
+	terminal.SetTitle(terminalTitle)
+
 	fmt.Printf("\nTo start working, attach to the session:\n")
-	fmt.Printf("  tmux attach-session -t %s\n", sessionName)
+	fmt.Printf("  %s\n", remote.AttachCommand("tmux attach-session -t "+sessionName))
 	fmt.Printf("\nOr use auto-worktree resume to attach\n")
 
 	return nil
@@ -1446,6 +1961,13 @@ func RunStartupCleanup() error {
 		return fmt.Errorf("error: %w", err)
 	}
 
+	rememberRepository(repo.RootPath)
+
+	// Automatic cleanup is paused while the repository is frozen
+	if repo.IsFrozen() {
+		return nil
+	}
+
 	// Check for stale lock files first, as they could interfere with cleanup
 	lockFiles, lockErr := git.DetectLockFiles(repo.RootPath)
 	if lockErr == nil {
@@ -1458,6 +1980,11 @@ func RunStartupCleanup() error {
 		}
 	}
 
+	policy := repo.Config.GetCleanupPolicy()
+	if policy == git.CleanupPolicyOff {
+		return nil
+	}
+
 	// Get startup cleanup candidates
 	endCandidates := perf.StartSpan("cleanup-get-candidates")
 	candidates, err := repo.GetStartupCleanupCandidates()
@@ -1492,60 +2019,171 @@ func RunStartupCleanup() error {
 	// Process merged worktrees (interactive with skip option)
 	if len(candidates.Merged) > 0 {
 		fmt.Printf("Found %d merged worktree(s) ready for cleanup:\n\n", len(candidates.Merged))
-		processStartupMergedWorktrees(repo, candidates.Merged)
+
+		if policy == git.CleanupPolicyAuto {
+			autoCleanupMergedWorktrees(repo, candidates.Merged)
+		} else {
+			processStartupMergedWorktrees(repo, candidates.Merged)
+		}
 	}
 
 	return nil
 }
 
-// processStartupMergedWorktrees handles interactive cleanup of merged worktrees at startup
-func processStartupMergedWorktrees(repo *git.Repository, merged []*git.Worktree) {
+// autoCleanupMergedWorktrees removes merged worktrees and their branches without asking,
+// falling back to a prompt for any worktree that still has unpushed commits
+func autoCleanupMergedWorktrees(repo *git.Repository, merged []*git.Worktree) {
+	prs := newPRLookup(repo)
+
+	var freed cleanupSavings
+
 	for _, wt := range merged {
-		if err := interactiveCleanup(repo, wt); err != nil {
-			fmt.Printf("  Error: %v\n", err)
+		if wt.UnpushedCount > 0 {
+			if err := interactiveCleanup(repo, wt, prs); err != nil {
+				fmt.Printf("  Error: %v\n", err)
+			}
+
+			continue
 		}
-	}
-}
 
-// RunCleanup performs interactive cleanup.
-func RunCleanup() error {
-	repo, err := git.NewRepository()
-	if err != nil {
-		return fmt.Errorf("error: %w", err)
-	}
+		// Measured first, since the directory is gone afterwards
+		savings := measureCleanupSavings([]*git.Worktree{wt})
 
-	// Get cleanup candidates (merged first, then stale)
-	candidates, err := repo.GetCleanupCandidates()
-	if err != nil {
-		return fmt.Errorf("error finding cleanup candidates: %w", err)
-	}
+		if err := cleanupWorktree(repo, wt, true); err != nil {
+			fmt.Printf("  Error cleaning up %s: %v\n", wt.Path, err)
+			continue
+		}
 
-	if len(candidates) == 0 {
-		fmt.Println("No worktrees found that need cleanup.")
-		return nil
+		freed.add(savings)
+		fmt.Printf("  ✓ Removed %s (%s)\n", wt.Path, wt.CleanupReason())
 	}
 
-	// Separate merged and stale
-	merged, stale := categorizeWorktrees(candidates)
-
-	// Process merged worktrees (automatic with confirmation)
-	if err := processMergedWorktrees(repo, merged, stale); err != nil {
-		return err
+	if freed.Worktrees > 0 {
+		fmt.Printf("  Freed %s\n", freed)
 	}
 
-	// Process stale worktrees (interactive)
-	processStaleWorktrees(repo, stale)
-
-	fmt.Println("\nCleanup complete!")
-	return nil
+	fmt.Println()
 }
 
-// categorizeWorktrees separates worktrees into merged and stale categories
-func categorizeWorktrees(candidates []*git.Worktree) ([]*git.Worktree, []*git.Worktree) {
-	var merged, stale []*git.Worktree
-	for _, wt := range candidates {
-		if wt.IsMerged() {
-			merged = append(merged, wt)
+// processStartupMergedWorktrees lists the merged worktrees found at startup
+// with what removing them frees, and removes them with their branches after a
+// single confirmation. Worktrees with unpushed commits are still asked about
+// one at a time.
+func processStartupMergedWorktrees(repo *git.Repository, merged []*git.Worktree) {
+	prs := newPRLookup(repo)
+
+	var ready, unpushed []*git.Worktree
+
+	for _, wt := range merged {
+		if wt.UnpushedCount > 0 {
+			unpushed = append(unpushed, wt)
+		} else {
+			ready = append(ready, wt)
+		}
+	}
+
+	if len(ready) > 0 {
+		for _, wt := range ready {
+			printCleanupCandidate(repo, wt, prs)
+		}
+
+		fmt.Println()
+
+		savings := measureCleanupSavings(ready)
+
+		if confirmStartupCleanup(savings, len(unpushed)) {
+			for _, wt := range ready {
+				if err := cleanupWorktree(repo, wt, true); err != nil {
+					fmt.Printf("  Error cleaning up %s: %v\n", wt.Path, err)
+					continue
+				}
+
+				fmt.Printf("  ✓ Removed %s (%s)\n", wt.Path, wt.CleanupReason())
+			}
+		} else {
+			fmt.Println("  Skipped")
+		}
+	}
+
+	for _, wt := range unpushed {
+		if err := interactiveCleanup(repo, wt, prs); err != nil {
+			fmt.Printf("  Error: %v\n", err)
+		}
+	}
+}
+
+// confirmStartupCleanup asks once whether to remove the merged worktrees and
+// branches counted in savings
+func confirmStartupCleanup(savings cleanupSavings, unpushed int) bool {
+	confirmation := ui.NewCleanupConfirmation(savings.Worktrees, 0)
+	confirmation.Summary = "Removing them frees " + savings.String()
+
+	if unpushed > 0 {
+		confirmation.Summary += fmt.Sprintf("\n%d worktree(s) with unpushed commits will be asked about one at a time", unpushed)
+	}
+
+	m, err := ui.Run(confirmation)
+	if err != nil {
+		fmt.Printf("Error showing confirmation: %v\n", err)
+		return false
+	}
+
+	finalModel, ok := m.(ui.CleanupConfirmationModel)
+
+	return ok && finalModel.WasConfirmed()
+}
+
+// RunCleanup performs interactive cleanup, or reports it as JSON.
+func RunCleanup(opts CleanupOptions) error {
+	repo, err := git.NewRepository()
+	if err != nil {
+		return fmt.Errorf("error: %w", err)
+	}
+
+	// A JSON report without --yes removes nothing, so it may still run
+	if !opts.JSON || opts.Yes {
+		if err := errIfFrozen(repo); err != nil {
+			return err
+		}
+	}
+
+	// Get cleanup candidates (merged first, then stale)
+	candidates, err := repo.GetCleanupCandidates()
+	if err != nil {
+		return fmt.Errorf("error finding cleanup candidates: %w", err)
+	}
+
+	if opts.JSON {
+		return cleanupJSON(repo, candidates, opts.Yes)
+	}
+
+	if len(candidates) == 0 {
+		fmt.Println("No worktrees found that need cleanup.")
+		return nil
+	}
+
+	// Separate merged and stale
+	merged, stale := categorizeWorktrees(candidates)
+
+	// Process merged worktrees (automatic with confirmation)
+	if err := processMergedWorktrees(repo, merged, stale); err != nil {
+		return err
+	}
+
+	// Process stale worktrees (interactive)
+	processStaleWorktrees(repo, stale)
+
+	fmt.Println("\nCleanup complete!")
+	return nil
+}
+
+// categorizeWorktrees separates worktrees into merged and stale categories;
+// expired review-only worktrees are removed along with the merged ones
+func categorizeWorktrees(candidates []*git.Worktree) ([]*git.Worktree, []*git.Worktree) {
+	var merged, stale []*git.Worktree
+	for _, wt := range candidates {
+		if wt.IsMerged() || wt.IsExpiredReview() {
+			merged = append(merged, wt)
 		} else if wt.IsStale() {
 			stale = append(stale, wt)
 		}
@@ -1559,6 +2197,15 @@ func processMergedWorktrees(repo *git.Repository, merged, stale []*git.Worktree)
 		return nil
 	}
 
+	fmt.Println("Merged worktrees:")
+
+	prs := newPRLookup(repo)
+	for _, wt := range merged {
+		printCleanupCandidate(repo, wt, prs)
+	}
+
+	fmt.Println()
+
 	// Show confirmation prompt
 	if !confirmCleanup(len(merged), len(stale)) {
 		return nil
@@ -1580,9 +2227,7 @@ func processMergedWorktrees(repo *git.Repository, merged, stale []*git.Worktree)
 // confirmCleanup shows confirmation dialog and returns user's choice
 func confirmCleanup(mergedCount, staleCount int) bool {
 	confirmation := ui.NewCleanupConfirmation(mergedCount, staleCount)
-	p := tea.NewProgram(confirmation)
-
-	m, err := p.Run()
+	m, err := ui.Run(confirmation)
 	if err != nil {
 		fmt.Printf("Error showing confirmation: %v\n", err)
 		return false
@@ -1608,19 +2253,21 @@ func processStaleWorktrees(repo *git.Repository, stale []*git.Worktree) {
 	}
 
 	fmt.Printf("\nInteractive cleanup for %d stale worktree(s)...\n\n", len(stale))
+
+	prs := newPRLookup(repo)
 	for _, wt := range stale {
-		if err := interactiveCleanup(repo, wt); err != nil {
+		if err := interactiveCleanup(repo, wt, prs); err != nil {
 			fmt.Printf("  Error: %v\n", err)
 		}
 	}
 }
 
-// interactiveCleanup prompts the user to clean up a worktree
-func interactiveCleanup(repo *git.Repository, wt *git.Worktree) error {
+// interactiveCleanup prompts the user to clean up a worktree, showing its last
+// commit, changes and pull request
+func interactiveCleanup(repo *git.Repository, wt *git.Worktree, prs *prLookup) error {
 	prompt := ui.NewCleanupPrompt(wt.Path, wt.Branch, wt.CleanupReason(), wt.UnpushedCount, true)
-	p := tea.NewProgram(prompt)
-
-	m, err := p.Run()
+	prompt.Preview = cleanupPreview(repo, wt, prs)
+	m, err := ui.Run(prompt)
 	if err != nil {
 		return fmt.Errorf("error showing prompt: %w", err)
 	}
@@ -1655,22 +2302,67 @@ func interactiveCleanup(repo *git.Repository, wt *git.Worktree) error {
 
 // cleanupWorktree removes a worktree and optionally deletes its branch
 func cleanupWorktree(repo *git.Repository, wt *git.Worktree, deleteBranch bool) error {
-	// Remove the worktree
-	if err := repo.RemoveWorktree(wt.Path); err != nil {
-		return fmt.Errorf("failed to remove worktree: %w", err)
+	branchErr, err := removeWorktreeAndBranch(repo, wt, deleteBranch)
+	if err != nil {
+		return err
 	}
 
-	// Delete the branch if requested
-	if deleteBranch && wt.Branch != "" {
-		if err := repo.DeleteBranch(wt.Branch); err != nil {
-			// Don't fail the cleanup if branch deletion fails
-			fmt.Printf("  Warning: failed to delete branch %s: %v\n", wt.Branch, err)
-		}
+	// Don't fail the cleanup if branch deletion fails
+	if branchErr != nil {
+		fmt.Printf("  Warning: failed to delete branch %s: %v\n", wt.Branch, branchErr)
 	}
 
 	return nil
 }
 
+// removeWorktreeAndBranch removes a worktree and optionally deletes its
+// branch, returning the branch deletion error separately since the worktree
+// is gone either way
+func removeWorktreeAndBranch(repo *git.Repository, wt *git.Worktree, deleteBranch bool) (branchErr, err error) {
+	// Stop any sandbox container using the worktree first
+	removeSandboxes(wt.Path)
+
+	// Remove the worktree, record why and delete the branch under one hold of the lock
+	err = repo.WithOperationLock("removing a worktree", func(locked *git.Repository) error {
+		if err := locked.RemoveWorktree(wt.Path); err != nil {
+			return fmt.Errorf("failed to remove worktree: %w", err)
+		}
+
+		recordCleanup(locked, wt)
+
+		// Delete the branch if requested
+		if deleteBranch && wt.Branch != "" {
+			branchErr = locked.DeleteBranch(wt.Branch)
+		}
+
+		return nil
+	})
+
+	return branchErr, err
+}
+
+// recordCleanup logs why cleanup removed a worktree, for 'auto-worktree stats'
+func recordCleanup(repo *git.Repository, wt *git.Worktree) {
+	recordCleanupReason(repo, wt, wt.CleanupReason())
+}
+
+// recordCleanupReason logs a worktree removed for reason, whose first word is
+// the category 'auto-worktree stats' counts it under
+func recordCleanupReason(repo *git.Repository, wt *git.Worktree, reason string) {
+	if reason == "" {
+		reason = "manual"
+	}
+
+	events.Record(events.Event{
+		Action:   events.ActionCleanup,
+		RepoPath: repo.RootPath,
+		Path:     wt.Path,
+		Branch:   wt.Branch,
+		Detail:   reason,
+		Merged:   wt.IsMerged(),
+	})
+}
+
 const (
 	scopeLocal  = "local"
 	scopeGlobal = "global"
@@ -1691,9 +2383,7 @@ func RunSettings() error {
 		settings := loadCurrentSettings(cfg)
 
 		menu := ui.NewSettingsMenuModel(settings)
-		p := tea.NewProgram(menu, tea.WithAltScreen())
-
-		model, err := p.Run()
+		model, err := ui.Run(menu, tea.WithAltScreen())
 		if err != nil {
 			return fmt.Errorf("failed to run settings menu: %w", err)
 		}
@@ -1745,6 +2435,14 @@ func loadCurrentSettings(cfg *git.Config) []ui.SettingItem {
 			git.ValidIssueProviders,
 			cfg.GetWithDefault(git.ConfigIssueProvider, "", git.ConfigScopeAuto),
 		),
+		ui.NewSettingItem(
+			git.ConfigCodeHost,
+			"Code Host",
+			"Where pull requests live, if different from the issue provider",
+			"select",
+			git.ValidCodeHosts,
+			cfg.GetCodeHost(),
+		),
 		ui.NewSettingItem(
 			git.ConfigAITool,
 			"AI Tool",
@@ -1753,6 +2451,110 @@ func loadCurrentSettings(cfg *git.Config) []ui.SettingItem {
 			git.ValidAITools,
 			cfg.GetWithDefault(git.ConfigAITool, "", git.ConfigScopeAuto),
 		),
+		ui.NewSettingItem(
+			git.ConfigSandbox,
+			"Sandbox",
+			"Run AI sessions in a container with only the worktree mounted: off, docker, or podman",
+			"select",
+			git.ValidSandboxes,
+			cfg.GetSandbox(),
+		),
+		ui.NewSettingItem(
+			git.ConfigSandboxImage,
+			"Sandbox Image",
+			"Container image for sandboxed sessions; it must include the AI tool and git",
+			"string",
+			nil,
+			cfg.GetSandboxImage(),
+		),
+		ui.NewSettingItem(
+			git.ConfigSessionCPUs,
+			"Session CPU Limit",
+			"CPUs each AI session may use, e.g. 2 (needs a sandbox or systemd-run)",
+			"string",
+			nil,
+			cfg.GetSessionCPUs(),
+		),
+		ui.NewSettingItem(
+			git.ConfigSessionMemory,
+			"Session Memory Limit",
+			"Memory each AI session may use, e.g. 4g (needs a sandbox or systemd-run)",
+			"string",
+			nil,
+			cfg.GetSessionMemory(),
+		),
+		ui.NewSettingItem(
+			git.ConfigSessionIdleTimeout,
+			"Session Idle Timeout",
+			"Hours a session may be idle before 'aw monitor' saves its transcript and stops it (empty: never)",
+			"string",
+			nil,
+			cfg.GetWithDefault(git.ConfigSessionIdleTimeout, "", git.ConfigScopeAuto),
+		),
+		ui.NewSettingItem(
+			git.ConfigCheckpointInterval,
+			"Checkpoint Interval",
+			"Minutes between checkpoints of a worktree while its AI session runs (empty: off)",
+			"string",
+			nil,
+			cfg.GetWithDefault(git.ConfigCheckpointInterval, "", git.ConfigScopeAuto),
+		),
+		ui.NewSettingItem(
+			git.ConfigTheme,
+			"Theme",
+			"Color theme (NO_COLOR always wins)",
+			"select",
+			git.ValidThemes,
+			cfg.GetTheme(),
+		),
+		ui.NewSettingItem(
+			git.ConfigThemeColors,
+			"Theme Colors",
+			"Per-element overrides, e.g. success=#00aa00,subtle=245",
+			"string",
+			nil,
+			cfg.GetThemeColors(),
+		),
+		ui.NewSettingItem(
+			git.ConfigKeybindings,
+			"Keybindings",
+			"List/menu key remaps, e.g. up=k|up,down=j|down,cancel=esc",
+			"string",
+			nil,
+			cfg.GetKeybindings(),
+		),
+		ui.NewSettingItem(
+			git.ConfigListPathWidth,
+			"List Path Width",
+			fmt.Sprintf("Width of the PATH column of list; longer paths are shortened in the middle (default: %d)", git.DefaultListPathWidth),
+			"string",
+			nil,
+			fmt.Sprintf("%d", cfg.GetListPathWidth()),
+		),
+		ui.NewSettingItem(
+			git.ConfigListSort,
+			"List Sort",
+			"Default order of list: age (oldest first), branch, status or size (largest first); unset keeps git's order",
+			"select",
+			git.ValidListSorts,
+			cfg.GetListSort(),
+		),
+		ui.NewSettingItem(
+			git.ConfigListColumns,
+			"List Columns",
+			"Comma-separated columns list shows, from: "+strings.Join(git.ValidListColumns, ","),
+			"string",
+			nil,
+			strings.Join(cfg.GetListColumns(), ","),
+		),
+		ui.NewSettingItem(
+			git.ConfigListCompact,
+			"List Compact",
+			"Print list as one unpadded line per worktree instead of a table",
+			"bool",
+			nil,
+			fmt.Sprintf("%t", cfg.GetListCompact()),
+		),
 		ui.NewSettingItem(
 			git.ConfigIssueAutoselect,
 			"Issue Autoselect",
@@ -1769,6 +2571,350 @@ func loadCurrentSettings(cfg *git.Config) []ui.SettingItem {
 			nil,
 			fmt.Sprintf("%t", cfg.GetPRAutoselect()),
 		),
+		ui.NewSettingItem(
+			git.ConfigAIRankCacheTTL,
+			"AI Ranking Cache",
+			"Minutes to reuse an AI ranking of issues or PRs before asking again (empty: 30, 0: always ask)",
+			"string",
+			nil,
+			cfg.GetWithDefault(git.ConfigAIRankCacheTTL, "", git.ConfigScopeAuto),
+		),
+		ui.NewSettingItem(
+			git.ConfigAIRankMaxCalls,
+			"AI Ranking Limit",
+			"AI rankings of issues or PRs allowed per hour (empty: 10)",
+			"string",
+			nil,
+			cfg.GetWithDefault(git.ConfigAIRankMaxCalls, "", git.ConfigScopeAuto),
+		),
+		ui.NewSettingItem(
+			git.ConfigIssueRankingTemplate,
+			"Issue Ranking Prompt",
+			"Prompt template file for the AI ranking of issues (empty: built-in criteria)",
+			"string",
+			nil,
+			cfg.GetWithDefault(git.ConfigIssueRankingTemplate, "", git.ConfigScopeAuto),
+		),
+		ui.NewSettingItem(
+			git.ConfigPRRankingTemplate,
+			"PR Ranking Prompt",
+			"Prompt template file for the AI ranking of PRs (empty: built-in criteria)",
+			"string",
+			nil,
+			cfg.GetWithDefault(git.ConfigPRRankingTemplate, "", git.ConfigScopeAuto),
+		),
+		ui.NewSettingItem(
+			git.ConfigAIBranchNames,
+			"AI Branch Names",
+			"Suggest branch names with the AI tool when none is entered",
+			"bool",
+			nil,
+			fmt.Sprintf("%t", cfg.GetAIBranchNames()),
+		),
+		ui.NewSettingItem(
+			git.ConfigAnalytics,
+			"Local Analytics",
+			"Count feature usage in ~/.auto-worktree/state.db (never sent anywhere)",
+			"bool",
+			nil,
+			fmt.Sprintf("%t", cfg.GetAnalyticsEnabled()),
+		),
+		ui.NewSettingItem(
+			git.ConfigLogFile,
+			"Log File",
+			"Also write debug logs to ~/.local/state/auto-worktree/log",
+			"bool",
+			nil,
+			fmt.Sprintf("%t", cfg.GetLogFileEnabled()),
+		),
+		ui.NewSettingItem(
+			git.ConfigAIConfirmContext,
+			"Confirm AI Context",
+			"Ask before sending a diff to the AI tool for describe (its size is always shown)",
+			"bool",
+			nil,
+			fmt.Sprintf("%t", cfg.GetAIConfirmContext()),
+		),
+		ui.NewSettingItem(
+			git.ConfigAIDiffLimit,
+			"AI Diff Limit",
+			fmt.Sprintf("Bytes of diff sent to the AI tool, shared between files (default: %d for describe, %d for review summaries)", maxDescribeDiffBytes, maxReviewDiffBytes),
+			"string",
+			nil,
+			cfg.GetWithDefault(git.ConfigAIDiffLimit, "", git.ConfigScopeAuto),
+		),
+		ui.NewSettingItem(
+			git.ConfigRedactPatterns,
+			"Redaction Patterns",
+			"Extra regular expressions (space-separated) for secrets to hide from logs, transcripts, hooks and AI tools",
+			"string",
+			nil,
+			cfg.GetWithDefault(git.ConfigRedactPatterns, "", git.ConfigScopeAuto),
+		),
+		ui.NewSettingItem(
+			git.ConfigCommandTimeouts,
+			"Command Timeouts",
+			"Per-tool limits for git, gh, glab, jira, linear and ai, e.g. gh=30s,git=2m (0: no limit)",
+			"string",
+			nil,
+			cfg.GetWithDefault(git.ConfigCommandTimeouts, "", git.ConfigScopeAuto),
+		),
+		ui.NewSettingItem(
+			git.ConfigMaxParallel,
+			"Max Parallel Commands",
+			fmt.Sprintf("External commands run at once while listing and checking worktrees (default: %d)", limits.DefaultParallelism),
+			"string",
+			nil,
+			fmt.Sprintf("%d", cfg.GetMaxParallel()),
+		),
+		ui.NewSettingItem(
+			git.ConfigGitHubTransport,
+			"GitHub Transport",
+			"cli runs gh; api calls GitHub with GITHUB_TOKEN (or gh's token), for machines without gh",
+			"select",
+			git.ValidGitHubTransports,
+			cfg.GetGitHubTransport(),
+		),
+		ui.NewSettingItem(
+			git.ConfigGitHubProject,
+			"GitHub Project",
+			"List issues from this project board: its number, or owner/number (gh needs the project scope)",
+			"string",
+			nil,
+			cfg.GetGitHubProject(),
+		),
+		ui.NewSettingItem(
+			git.ConfigGitHubProjectField,
+			"GitHub Project Field",
+			"Single-select project field whose options are the board's columns",
+			"string",
+			nil,
+			cfg.GetGitHubProjectField(),
+		),
+		ui.NewSettingItem(
+			git.ConfigGitHubProjectReady,
+			"GitHub Project Ready Column",
+			"Only list issues in this column, e.g. Ready (default: every column)",
+			"string",
+			nil,
+			cfg.GetGitHubProjectReady(),
+		),
+		ui.NewSettingItem(
+			git.ConfigGitHubProjectInProgress,
+			"GitHub Project In Progress Column",
+			"Column an issue moves to when its worktree is created, or none",
+			"string",
+			nil,
+			cfg.GetWithDefault(git.ConfigGitHubProjectInProgress, "In Progress", git.ConfigScopeAuto),
+		),
+		ui.NewSettingItem(
+			git.ConfigGitLabTransport,
+			"GitLab Transport",
+			"cli runs glab; api calls GitLab with GITLAB_TOKEN or CI_JOB_TOKEN, for machines without glab",
+			"select",
+			git.ValidGitLabTransports,
+			cfg.GetGitLabTransport(),
+		),
+		ui.NewSettingItem(
+			git.ConfigWorktreeBase,
+			"Worktree Base",
+			"Directory holding per-repository worktree folders, or a template like {repo_parent}/{repo}-worktrees/{branch} (default: ~/worktrees)",
+			"string",
+			nil,
+			cfg.GetWorktreeBase(),
+		),
+		ui.NewSettingItem(
+			git.ConfigCleanupPolicy,
+			"Cleanup Policy",
+			"Merged worktrees at startup: prompt, auto (remove without asking), or off",
+			"select",
+			git.ValidCleanupPolicies,
+			cfg.GetCleanupPolicy(),
+		),
+		ui.NewSettingItem(
+			git.ConfigProtectedBranches,
+			"Protected Branches",
+			"Branch patterns never checked out with 'new --existing' or cleaned up (default: main,master,release/*)",
+			"string",
+			nil,
+			cfg.GetWithDefault(git.ConfigProtectedBranches, git.DefaultProtectedBranches, git.ConfigScopeAuto),
+		),
+		ui.NewSettingItem(
+			git.ConfigBranchNamePrefix,
+			"Branch Name Prefix",
+			"Prefix of generated branch names, e.g. feat/ or user/{user}/ (default: work/)",
+			"string",
+			nil,
+			cfg.GetWithDefault(git.ConfigBranchNamePrefix, git.DefaultBranchNameFormat().Prefix, git.ConfigScopeAuto),
+		),
+		ui.NewSettingItem(
+			git.ConfigBranchNameWords,
+			"Branch Name Words",
+			"Number of random words in generated branch names (default: 3)",
+			"string",
+			nil,
+			cfg.GetWithDefault(git.ConfigBranchNameWords, "", git.ConfigScopeAuto),
+		),
+		ui.NewSettingItem(
+			git.ConfigBranchNameSeparator,
+			"Branch Name Separator",
+			"Separator between the words of generated branch names (default: -)",
+			"string",
+			nil,
+			cfg.GetWithDefault(git.ConfigBranchNameSeparator, "", git.ConfigScopeAuto),
+		),
+		ui.NewSettingItem(
+			git.ConfigBranchNameDictionary,
+			"Branch Name Dictionary",
+			"File of words, one per line, for generated branch names (default: colors, adjectives and animals)",
+			"string",
+			nil,
+			cfg.GetWithDefault(git.ConfigBranchNameDictionary, "", git.ConfigScopeAuto),
+		),
+		ui.NewSettingItem(
+			git.ConfigBaseBranch,
+			"Base Branch",
+			"Branch new branches start from, by prefix, e.g. develop,hotfix/=main (default: the default branch)",
+			"string",
+			nil,
+			cfg.GetWithDefault(git.ConfigBaseBranch, "", git.ConfigScopeAuto),
+		),
+		ui.NewSettingItem(
+			git.ConfigIssueBranchPrefixes,
+			"Issue Branch Prefixes",
+			"Branch prefixes by issue label or type, e.g. bug=fix/,enhancement=feat/ (default: work/ for all)",
+			"string",
+			nil,
+			cfg.GetWithDefault(git.ConfigIssueBranchPrefixes, "", git.ConfigScopeAuto),
+		),
+		ui.NewSettingItem(
+			git.ConfigSubmoduleInit,
+			"Submodule Init",
+			"Run 'git submodule update --init --recursive' in new worktrees",
+			"bool",
+			nil,
+			fmt.Sprintf("%t", cfg.GetSubmoduleInit()),
+		),
+		ui.NewSettingItem(
+			git.ConfigSubmoduleShallow,
+			"Shallow Submodules",
+			"Clone submodules with --depth 1 in new worktrees",
+			"bool",
+			nil,
+			fmt.Sprintf("%t", cfg.GetSubmoduleShallow()),
+		),
+		ui.NewSettingItem(
+			git.ConfigLFSPull,
+			"LFS Pull",
+			"Run 'git lfs pull' in new worktrees when the repository uses Git LFS",
+			"bool",
+			nil,
+			fmt.Sprintf("%t", cfg.GetLFSPull()),
+		),
+		ui.NewSettingItem(
+			git.ConfigScopeSparseCheckout,
+			"Scope Sparse-Checkout",
+			"Check out only the package given with 'new --scope' (git sparse-checkout, cone mode)",
+			"bool",
+			nil,
+			fmt.Sprintf("%t", cfg.GetScopeSparseCheckout()),
+		),
+		ui.NewSettingItem(
+			git.ConfigUpdateCheck,
+			"Update Check",
+			"Show a notice in the menu when a new release is available (checked daily)",
+			"bool",
+			nil,
+			fmt.Sprintf("%t", cfg.GetUpdateCheckEnabled()),
+		),
+		ui.NewSettingItem(
+			git.ConfigIssueSelfAssign,
+			"Issue Self-Assign",
+			"Assign the issue to me and comment when starting work",
+			"bool",
+			nil,
+			fmt.Sprintf("%t", cfg.GetIssueSelfAssign()),
+		),
+		ui.NewSettingItem(
+			git.ConfigIssueFilterLabels,
+			"Issue Filter Labels",
+			"Comma-separated labels the issue selector filters by",
+			"string",
+			nil,
+			strings.Join(cfg.GetIssueFilterLabels(), ","),
+		),
+		ui.NewSettingItem(
+			git.ConfigIssueFilterAssignee,
+			"Issue Filter Assignee",
+			"Only list issues assigned to: me, unassigned, or a username",
+			"string",
+			nil,
+			cfg.GetIssueFilterAssignee(),
+		),
+		ui.NewSettingItem(
+			git.ConfigIssueFilterMilestone,
+			"Issue Filter Milestone",
+			"Only list issues in this milestone, sprint or cycle; current for the active one",
+			"string",
+			nil,
+			cfg.GetIssueFilterMilestone(),
+		),
+		ui.NewSettingItem(
+			git.ConfigIssueSort,
+			"Issue Sort",
+			"Order of the issue selector: recent, or priority for the most urgent first",
+			"select",
+			git.ValidIssueSorts,
+			cfg.GetIssueSort(),
+		),
+		ui.NewSettingItem(
+			git.ConfigPRReviewers,
+			"PR Reviewers",
+			"Comma-separated users or org/team asked to review PRs opened by describe --pr --apply",
+			"string",
+			nil,
+			strings.Join(cfg.GetPRReviewers(), ","),
+		),
+		ui.NewSettingItem(
+			git.ConfigPRLabels,
+			"PR Labels",
+			"Comma-separated labels added to PRs opened by describe --pr --apply",
+			"string",
+			nil,
+			strings.Join(cfg.GetPRLabels(), ","),
+		),
+		ui.NewSettingItem(
+			git.ConfigPRDraft,
+			"PR Draft",
+			"Open PRs as drafts",
+			"bool",
+			nil,
+			fmt.Sprintf("%t", cfg.GetPRDraft()),
+		),
+		ui.NewSettingItem(
+			git.ConfigPRCodeOwners,
+			"PR CODEOWNERS Reviewers",
+			"Also ask the CODEOWNERS of the changed files to review new PRs",
+			"bool",
+			nil,
+			fmt.Sprintf("%t", cfg.GetPRCodeOwners()),
+		),
+		ui.NewSettingItem(
+			git.ConfigIssueListLimit,
+			"Issue List Limit",
+			"How many issues the issue selector loads per page",
+			"string",
+			nil,
+			fmt.Sprintf("%d", cfg.GetIssueListLimit()),
+		),
+		ui.NewSettingItem(
+			git.ConfigTestCommand,
+			"Test Command",
+			"Shell command 'auto-worktree test' runs in each worktree (e.g. go test ./...)",
+			"string",
+			nil,
+			cfg.GetTestCommand(),
+		),
 		ui.NewSettingItem(
 			git.ConfigRunHooks,
 			"Run Hooks",
@@ -1801,6 +2947,14 @@ func loadCurrentSettings(cfg *git.Config) []ui.SettingItem {
 			nil,
 			cfg.GetWithDefault(git.ConfigJiraProject, "", git.ConfigScopeAuto),
 		),
+		ui.NewSettingItem(
+			git.ConfigJiraEmail,
+			"JIRA Email",
+			"Login for a JIRA Cloud API token in JIRA_API_TOKEN (unset for a personal access token)",
+			"string",
+			nil,
+			cfg.GetJiraEmail(),
+		),
 		ui.NewSettingItem(
 			git.ConfigGitLabServer,
 			"GitLab Server",
@@ -1825,6 +2979,22 @@ func loadCurrentSettings(cfg *git.Config) []ui.SettingItem {
 			nil,
 			cfg.GetWithDefault(git.ConfigLinearTeam, "", git.ConfigScopeAuto),
 		),
+		ui.NewSettingItem(
+			git.ConfigLinearProject,
+			"Linear Project",
+			"Only list issues in this project (needs LINEAR_API_KEY)",
+			"string",
+			nil,
+			cfg.GetWithDefault(git.ConfigLinearProject, "", git.ConfigScopeAuto),
+		),
+		ui.NewSettingItem(
+			git.ConfigLinearCycle,
+			"Linear Cycle",
+			"Only list issues in this cycle: current, a number or a name (needs LINEAR_API_KEY)",
+			"string",
+			nil,
+			cfg.GetWithDefault(git.ConfigLinearCycle, "", git.ConfigScopeAuto),
+		),
 		ui.NewSettingItem(
 			git.ConfigCustomHooks,
 			"Custom Hooks",
@@ -1879,8 +3049,7 @@ func editSetting(cfg *git.Config, key string, settings []ui.SettingItem) error {
 
 	// Show editor
 	editor := ui.NewSettingEditor(setting)
-	p := tea.NewProgram(editor, tea.WithAltScreen())
-	model, err := p.Run()
+	model, err := ui.Run(editor, tea.WithAltScreen())
 	if err != nil {
 		return fmt.Errorf("failed to run editor: %w", err)
 	}
@@ -1910,8 +3079,7 @@ func editSetting(cfg *git.Config, key string, settings []ui.SettingItem) error {
 
 	// Ask for scope
 	scopeSelector := ui.NewScopeSelector()
-	p = tea.NewProgram(scopeSelector, tea.WithAltScreen())
-	model, err = p.Run()
+	model, err = ui.Run(scopeSelector, tea.WithAltScreen())
 	if err != nil {
 		return fmt.Errorf("failed to run scope selector: %w", err)
 	}
@@ -1961,20 +3129,28 @@ func setupJIRAInteractive(cfg *git.Config, scope git.ConfigScope) error {
 	fmt.Println("================")
 	fmt.Println()
 
-	// Check if jira CLI is installed
-	if !isJiraCLIAvailable() {
-		fmt.Println("The 'jira' CLI tool is required. Install with:")
+	// An API token needs no CLI; otherwise check if jira CLI is installed
+	useToken := jira.TokenFromEnv() != ""
+
+	switch {
+	case useToken:
+		fmt.Println("✓ JIRA_API_TOKEN is set; auto-worktree will use the JIRA REST API")
+	case isJiraCLIAvailable():
+		fmt.Println("✓ jira CLI is installed")
+	default:
+		fmt.Println("Either set an API token, or install the 'jira' CLI tool.")
 		fmt.Println()
+		fmt.Println("  API token: auto-worktree auth jira, or export JIRA_API_TOKEN=...")
+		fmt.Println("             (create one at https://id.atlassian.com/manage-profile/security/api-tokens)")
 		fmt.Println("  macOS:     brew install ankitpokhrel/jira-cli/jira-cli")
 		fmt.Println("  Linux:     See https://github.com/ankitpokhrel/jira-cli#installation")
 		fmt.Println("  Docker:    docker pull ghcr.io/ankitpokhrel/jira-cli:latest")
 		fmt.Println()
-		fmt.Println("After installation, run: jira init")
+		fmt.Println("After installing the CLI, run: jira init")
 		fmt.Println()
 		return nil
 	}
 
-	fmt.Println("✓ jira CLI is installed")
 	fmt.Println()
 
 	// Ask for JIRA server URL
@@ -2007,6 +3183,24 @@ func setupJIRAInteractive(cfg *git.Config, scope git.ConfigScope) error {
 		}
 	}
 
+	if useToken {
+		fmt.Println()
+		fmt.Print("Enter the email you sign in to JIRA Cloud with (leave empty for a Server personal access token): ")
+
+		var email string
+		if _, err := fmt.Scanln(&email); err != nil && email != "" {
+			return fmt.Errorf("failed to read JIRA email: %w", err)
+		}
+
+		if email != "" {
+			if err := cfg.Set(git.ConfigJiraEmail, email, scope); err != nil {
+				fmt.Printf("Error saving JIRA email: %v\n", err)
+			} else {
+				fmt.Println("✓ JIRA email saved")
+			}
+		}
+	}
+
 	fmt.Println()
 	fmt.Println("JIRA setup complete! You can now use 'aw issue' to work with JIRA issues.")
 	fmt.Println()
@@ -2044,6 +3238,66 @@ func showAllSettings(cfg *git.Config) error {
 		git.ConfigIssueTemplatesDisabled,
 		git.ConfigIssueTemplatesNoPrompt,
 		git.ConfigIssueTemplatesDetected,
+		git.ConfigIssueSelfAssign,
+		git.ConfigIssueFilterLabels,
+		git.ConfigIssueFilterAssignee,
+		git.ConfigIssueListLimit,
+		git.ConfigCodeHost,
+		git.ConfigAIBranchNames,
+		git.ConfigAnalytics,
+		git.ConfigTheme,
+		git.ConfigThemeColors,
+		git.ConfigKeybindings,
+		git.ConfigLogFile,
+		git.ConfigUpdateCheck,
+		git.ConfigWorktreeBase,
+		git.ConfigCleanupPolicy,
+		git.ConfigSubmoduleInit,
+		git.ConfigSubmoduleShallow,
+		git.ConfigLFSPull,
+		git.ConfigScopeSparseCheckout,
+		git.ConfigSandbox,
+		git.ConfigSandboxImage,
+		git.ConfigSessionCPUs,
+		git.ConfigSessionMemory,
+		git.ConfigSessionIdleTimeout,
+		git.ConfigProtectedBranches,
+		git.ConfigBranchNamePrefix,
+		git.ConfigBranchNameWords,
+		git.ConfigBranchNameSeparator,
+		git.ConfigBranchNameDictionary,
+		git.ConfigIssueBranchPrefixes,
+		git.ConfigCommandTimeouts,
+		git.ConfigMaxParallel,
+		git.ConfigGitHubTransport,
+		git.ConfigGitLabTransport,
+		git.ConfigJiraEmail,
+		git.ConfigLinearProject,
+		git.ConfigLinearCycle,
+		git.ConfigRedactPatterns,
+		git.ConfigAIConfirmContext,
+		git.ConfigAIDiffLimit,
+		git.ConfigGitHubProject,
+		git.ConfigGitHubProjectField,
+		git.ConfigGitHubProjectReady,
+		git.ConfigGitHubProjectInProgress,
+		git.ConfigIssueFilterMilestone,
+		git.ConfigIssueSort,
+		git.ConfigPRReviewers,
+		git.ConfigPRLabels,
+		git.ConfigPRDraft,
+		git.ConfigPRCodeOwners,
+		git.ConfigTestCommand,
+		git.ConfigCheckpointInterval,
+		git.ConfigListPathWidth,
+		git.ConfigListSort,
+		git.ConfigListColumns,
+		git.ConfigListCompact,
+		git.ConfigBaseBranch,
+		git.ConfigAIRankCacheTTL,
+		git.ConfigAIRankMaxCalls,
+		git.ConfigIssueRankingTemplate,
+		git.ConfigPRRankingTemplate,
 	}
 
 	for _, key := range allKeys {
@@ -2056,16 +3310,14 @@ func showAllSettings(cfg *git.Config) error {
 	}
 
 	viewer := ui.NewSettingsViewer(localValues, globalValues)
-	p := tea.NewProgram(viewer, tea.WithAltScreen())
-	_, err := p.Run()
+	_, err := ui.Run(viewer, tea.WithAltScreen())
 	return err
 }
 
 func resetSettings(cfg *git.Config) error {
 	// Confirm reset
 	confirm := ui.NewConfirmModel("Are you sure you want to reset ALL settings to defaults?\nThis will clear all auto-worktree configuration.")
-	p := tea.NewProgram(confirm, tea.WithAltScreen())
-	model, err := p.Run()
+	model, err := ui.Run(confirm, tea.WithAltScreen())
 	if err != nil {
 		return fmt.Errorf("failed to run confirmation: %w", err)
 	}
@@ -2081,8 +3333,7 @@ func resetSettings(cfg *git.Config) error {
 
 	// Ask for scope
 	scopeSelector := ui.NewScopeSelector()
-	p = tea.NewProgram(scopeSelector, tea.WithAltScreen())
-	model, err = p.Run()
+	model, err = ui.Run(scopeSelector, tea.WithAltScreen())
 	if err != nil {
 		return fmt.Errorf("failed to run scope selector: %w", err)
 	}
@@ -2151,6 +3402,66 @@ func RunSettingsSet(key, value, scope string) error {
 		git.ConfigIssueTemplatesDisabled,
 		git.ConfigIssueTemplatesNoPrompt,
 		git.ConfigIssueTemplatesDetected,
+		git.ConfigIssueSelfAssign,
+		git.ConfigIssueFilterLabels,
+		git.ConfigIssueFilterAssignee,
+		git.ConfigIssueListLimit,
+		git.ConfigCodeHost,
+		git.ConfigAIBranchNames,
+		git.ConfigAnalytics,
+		git.ConfigTheme,
+		git.ConfigThemeColors,
+		git.ConfigKeybindings,
+		git.ConfigLogFile,
+		git.ConfigUpdateCheck,
+		git.ConfigWorktreeBase,
+		git.ConfigCleanupPolicy,
+		git.ConfigSubmoduleInit,
+		git.ConfigSubmoduleShallow,
+		git.ConfigLFSPull,
+		git.ConfigScopeSparseCheckout,
+		git.ConfigSandbox,
+		git.ConfigSandboxImage,
+		git.ConfigSessionCPUs,
+		git.ConfigSessionMemory,
+		git.ConfigSessionIdleTimeout,
+		git.ConfigProtectedBranches,
+		git.ConfigBranchNamePrefix,
+		git.ConfigBranchNameWords,
+		git.ConfigBranchNameSeparator,
+		git.ConfigBranchNameDictionary,
+		git.ConfigIssueBranchPrefixes,
+		git.ConfigCommandTimeouts,
+		git.ConfigMaxParallel,
+		git.ConfigGitHubTransport,
+		git.ConfigGitLabTransport,
+		git.ConfigJiraEmail,
+		git.ConfigLinearProject,
+		git.ConfigLinearCycle,
+		git.ConfigRedactPatterns,
+		git.ConfigAIConfirmContext,
+		git.ConfigAIDiffLimit,
+		git.ConfigGitHubProject,
+		git.ConfigGitHubProjectField,
+		git.ConfigGitHubProjectReady,
+		git.ConfigGitHubProjectInProgress,
+		git.ConfigIssueFilterMilestone,
+		git.ConfigIssueSort,
+		git.ConfigPRReviewers,
+		git.ConfigPRLabels,
+		git.ConfigPRDraft,
+		git.ConfigPRCodeOwners,
+		git.ConfigTestCommand,
+		git.ConfigCheckpointInterval,
+		git.ConfigListPathWidth,
+		git.ConfigListSort,
+		git.ConfigListColumns,
+		git.ConfigListCompact,
+		git.ConfigBaseBranch,
+		git.ConfigAIRankCacheTTL,
+		git.ConfigAIRankMaxCalls,
+		git.ConfigIssueRankingTemplate,
+		git.ConfigPRRankingTemplate,
 	}
 
 	isValidKey := false
@@ -2250,6 +3561,66 @@ func RunSettingsList() error {
 		git.ConfigIssueTemplatesDisabled,
 		git.ConfigIssueTemplatesNoPrompt,
 		git.ConfigIssueTemplatesDetected,
+		git.ConfigIssueSelfAssign,
+		git.ConfigIssueFilterLabels,
+		git.ConfigIssueFilterAssignee,
+		git.ConfigIssueListLimit,
+		git.ConfigCodeHost,
+		git.ConfigAIBranchNames,
+		git.ConfigAnalytics,
+		git.ConfigTheme,
+		git.ConfigThemeColors,
+		git.ConfigKeybindings,
+		git.ConfigLogFile,
+		git.ConfigUpdateCheck,
+		git.ConfigWorktreeBase,
+		git.ConfigCleanupPolicy,
+		git.ConfigSubmoduleInit,
+		git.ConfigSubmoduleShallow,
+		git.ConfigLFSPull,
+		git.ConfigScopeSparseCheckout,
+		git.ConfigSandbox,
+		git.ConfigSandboxImage,
+		git.ConfigSessionCPUs,
+		git.ConfigSessionMemory,
+		git.ConfigSessionIdleTimeout,
+		git.ConfigProtectedBranches,
+		git.ConfigBranchNamePrefix,
+		git.ConfigBranchNameWords,
+		git.ConfigBranchNameSeparator,
+		git.ConfigBranchNameDictionary,
+		git.ConfigIssueBranchPrefixes,
+		git.ConfigCommandTimeouts,
+		git.ConfigMaxParallel,
+		git.ConfigGitHubTransport,
+		git.ConfigGitLabTransport,
+		git.ConfigJiraEmail,
+		git.ConfigLinearProject,
+		git.ConfigLinearCycle,
+		git.ConfigRedactPatterns,
+		git.ConfigAIConfirmContext,
+		git.ConfigAIDiffLimit,
+		git.ConfigGitHubProject,
+		git.ConfigGitHubProjectField,
+		git.ConfigGitHubProjectReady,
+		git.ConfigGitHubProjectInProgress,
+		git.ConfigIssueFilterMilestone,
+		git.ConfigIssueSort,
+		git.ConfigPRReviewers,
+		git.ConfigPRLabels,
+		git.ConfigPRDraft,
+		git.ConfigPRCodeOwners,
+		git.ConfigTestCommand,
+		git.ConfigCheckpointInterval,
+		git.ConfigListPathWidth,
+		git.ConfigListSort,
+		git.ConfigListColumns,
+		git.ConfigListCompact,
+		git.ConfigBaseBranch,
+		git.ConfigAIRankCacheTTL,
+		git.ConfigAIRankMaxCalls,
+		git.ConfigIssueRankingTemplate,
+		git.ConfigPRRankingTemplate,
 	}
 
 	fmt.Println(ui.TitleStyle.Render("Configuration Settings"))
@@ -2338,6 +3709,10 @@ func RunRemove(path string) error {
 		return fmt.Errorf("error: %w", err)
 	}
 
+	if err := errIfFrozen(repo); err != nil {
+		return err
+	}
+
 	// Expand ~ to home directory
 	if strings.HasPrefix(path, "~") {
 		homeDir, homeErr := os.UserHomeDir()
@@ -2356,6 +3731,8 @@ func RunRemove(path string) error {
 
 	fmt.Printf("Removing worktree: %s\n", path)
 
+	removeSandboxes(path)
+
 	err = repo.RemoveWorktree(path)
 	if err != nil {
 		return fmt.Errorf("error removing worktree: %w", err)
@@ -2366,27 +3743,70 @@ func RunRemove(path string) error {
 	return nil
 }
 
-// RunPrune prunes orphaned worktrees.
-func RunPrune() error {
+// RunPrune prunes the records of worktrees whose directory is gone, listing them first.
+func RunPrune(opts PruneOptions) error {
 	repo, err := git.NewRepository()
 	if err != nil {
 		return fmt.Errorf("error: %w", err)
 	}
 
-	fmt.Println("Pruning orphaned worktrees...")
+	if err := errIfFrozen(repo); err != nil {
+		return err
+	}
+
+	expire := opts.Expire
+	if expire == "" {
+		expire = repo.WorktreePruneExpire()
+	}
+
+	if opts.JSON {
+		return pruneJSON(repo, expire)
+	}
 
-	err = repo.PruneWorktrees()
+	entries, err := repo.PrunableWorktrees(expire)
 	if err != nil {
+		return err
+	}
+
+	if len(entries) == 0 {
+		fmt.Println("No worktree records to prune.")
+		return nil
+	}
+
+	older := ""
+	if expire != "" {
+		older = " older than " + expire
+	}
+
+	fmt.Printf("Pruning %d worktree record(s)%s:\n", len(entries), older)
+
+	for _, entry := range entries {
+		fmt.Printf("  • %s\n", describePruneEntry(entry))
+	}
+
+	if err := repo.PruneWorktreesExpire(expire); err != nil {
 		return fmt.Errorf("error pruning worktrees: %w", err)
 	}
 
-	fmt.Println("✓ Pruned orphaned worktrees")
+	fmt.Printf("✓ Pruned %d worktree record(s)\n", len(entries))
 
 	return nil
 }
 
+// DoctorOptions configures `auto-worktree doctor`
+type DoctorOptions struct {
+	// CheckLocks looks for git lock files
+	CheckLocks bool
+	// RemoveLocks removes the stale lock files found
+	RemoveLocks bool
+	// RepairBase adopts, or deletes, the problem entries of the worktree base
+	RepairBase bool
+	// Yes deletes unregistered directories in the worktree base without asking
+	Yes bool
+}
+
 // RunDoctor performs diagnostic checks on the repository.
-func RunDoctor(checkLocks bool, removeLocks bool) error {
+func RunDoctor(opts DoctorOptions) error {
 	repo, err := git.NewRepository()
 	if err != nil {
 		return fmt.Errorf("error: %w", err)
@@ -2395,8 +3815,25 @@ func RunDoctor(checkLocks bool, removeLocks bool) error {
 	fmt.Println("Running repository diagnostics...")
 	fmt.Println()
 
+	failed := printDoctorChecks("Checking tools and configuration", runDoctorChecks(repo))
+
+	issues, err := repo.ScanWorktreeBase()
+	if err != nil {
+		return fmt.Errorf("error checking the worktree base: %w", err)
+	}
+
+	if printDoctorChecks("Checking the worktree base "+repo.WorktreeBase, baseIssueChecks(repo.WorktreeBase, issues)) {
+		failed = true
+	}
+
+	if opts.RepairBase && len(issues) > 0 {
+		fmt.Println("🔧 Repairing the worktree base...")
+		repairWorktreeBase(repo, issues, opts.Yes)
+		fmt.Println()
+	}
+
 	// Check for lock files
-	if checkLocks {
+	if opts.CheckLocks {
 		fmt.Println("🔍 Checking for Git lock files...")
 
 		lockFiles, err := git.DetectLockFiles(repo.RootPath)
@@ -2431,7 +3868,7 @@ func RunDoctor(checkLocks bool, removeLocks bool) error {
 					fmt.Printf("  • %s\n", lf.String())
 				}
 
-				if removeLocks {
+				if opts.RemoveLocks {
 					fmt.Println("\nRemoving stale lock files...")
 					removedCount := 0
 					for _, lf := range staleLocks {
@@ -2453,11 +3890,10 @@ func RunDoctor(checkLocks bool, removeLocks bool) error {
 		}
 	}
 
-	// Add other diagnostic checks here in the future
-	// - Check for orphaned worktrees
-	// - Check for corrupted refs
-	// - Check for large objects
-	// etc.
+	if failed {
+		fmt.Println(ui.ErrorStyle.Render("✗ Diagnostics found problems"))
+		return ErrDoctorFailed
+	}
 
 	fmt.Println("✓ Diagnostics complete")
 
@@ -2513,9 +3949,7 @@ func selectAIToolInteractive(tools []ai.Tool) (*ai.Tool, error) {
 	items[len(tools)] = ui.NewMenuItem("Skip", "Don't start any AI tool", aiToolSkip)
 
 	menu := ui.NewMenu("Select an AI coding assistant", items)
-	p := tea.NewProgram(menu)
-
-	m, err := p.Run()
+	m, err := ui.Run(menu)
 	if err != nil {
 		return nil, fmt.Errorf("failed to run menu: %w", err)
 	}
@@ -2587,16 +4021,6 @@ func formatIssueTitleForTerminal(issue *providers.Issue) string {
 	return formatTerminalTitle(prefix, title)
 }
 
-func formatPRTitleForTerminal(pr *github.PullRequest) string {
-	if pr == nil {
-		return ""
-	}
-
-	title := strings.TrimSpace(pr.Title)
-	prefix := fmt.Sprintf("PR #%d", pr.Number)
-	return formatTerminalTitle(prefix, title)
-}
-
 func formatIssuePrefix(id string) string {
 	if id == "" {
 		return "Issue"
@@ -2647,7 +4071,28 @@ func formatTerminalTitle(prefix, title string) string {
 // resolveAICommand determines the AI tool to use and returns the command.
 // It handles user selection if multiple tools are available.
 // Returns nil if AI is disabled or no tools are available.
-func resolveAICommand(config *git.Config, context string, isResume bool, worktreePath string) ([]string, error) {
+// resume is the conversation to continue, or nil to start a new one.
+func resolveAICommand(config *git.Config, context string, resume *aiConversation, worktreePath string) ([]string, aiConversation, error) {
+	tool, err := resolveAITool(config)
+	if err != nil || tool == nil {
+		return nil, aiConversation{}, err
+	}
+
+	if resume != nil {
+		cmd, conversation := resumeCommand(tool, resume, worktreePath, context)
+		return cmd, conversation, nil
+	}
+
+	fmt.Printf("Starting %s...\n", tool.Name)
+
+	cmd, conversation := startCommand(tool, context)
+
+	return cmd, conversation, nil
+}
+
+// resolveAITool picks the AI tool to start, asking when several are installed
+// and none is configured. It returns nil when AI is disabled or none is found.
+func resolveAITool(config *git.Config) (*ai.Tool, error) {
 	resolver := ai.NewResolver(config)
 
 	// Check if AI is explicitly disabled
@@ -2666,48 +4111,30 @@ func resolveAICommand(config *git.Config, context string, isResume bool, worktre
 
 	// Try to resolve the configured/preferred AI tool
 	tool, err := resolver.Resolve()
-	if err != nil {
-		// No tool configured but multiple are available - prompt user to select
-		if len(availableTools) > 1 {
-			selectedTool, selErr := selectAIToolInteractive(availableTools)
-			if selErr != nil {
-				return nil, fmt.Errorf("failed to select AI tool: %w", selErr)
-			}
+	if err == nil {
+		return tool, nil
+	}
 
-			if selectedTool == nil {
-				return nil, nil // User chose to skip
-			}
+	// No tool configured but multiple are available - prompt user to select
+	if len(availableTools) == 1 {
+		return &availableTools[0], nil
+	}
 
-			tool = selectedTool
+	selectedTool, selErr := selectAIToolInteractive(availableTools)
+	if selErr != nil {
+		return nil, fmt.Errorf("failed to select AI tool: %w", selErr)
+	}
 
-			// Save user's choice for future sessions
-			if saveErr := saveAIToolChoice(config, tool.Name); saveErr != nil {
-				fmt.Printf("⚠ Warning: Failed to save AI tool preference: %v\n", saveErr)
-			}
-		} else if len(availableTools) == 1 {
-			tool = &availableTools[0]
-		} else {
-			return nil, nil // No tools available
-		}
+	if selectedTool == nil {
+		return nil, nil // User chose to skip
 	}
 
-	// Determine which command to use (resume vs fresh)
-	var cmd []string
-	if isResume {
-		if ai.HasExistingSession(worktreePath) {
-			cmd = tool.ResumeCommandWithContext(context)
-			fmt.Printf("Resuming %s session...\n", tool.Name)
-		} else {
-			fmt.Println("No conversation found to continue.")
-			fmt.Println("Starting fresh session in worktree...")
-			cmd = tool.CommandWithContext(context)
-		}
-	} else {
-		cmd = tool.CommandWithContext(context)
-		fmt.Printf("Starting %s...\n", tool.Name)
+	// Save user's choice for future sessions
+	if saveErr := saveAIToolChoice(config, selectedTool.Name); saveErr != nil {
+		logging.Warn("failed to save AI tool preference", "err", saveErr)
 	}
 
-	return cmd, nil
+	return selectedTool, nil
 }
 
 // showAIInstallInstructions displays installation instructions for AI tools
@@ -2731,12 +4158,28 @@ func showAIInstallInstructions() {
 func createSessionWithAICommand(
 	sessionMgr session.Manager,
 	config *git.Config,
-	sessionName, branchName, worktreePath string,
+	sessionName, branchName, worktreePath, scope string,
 	aiCommand []string,
+	conversation aiConversation,
 ) error {
+	workDir := scopeWorkDir(worktreePath, scope)
+
 	// Determine the command to run in the session
 	var command []string
-	if len(aiCommand) > 0 {
+
+	var containerID string
+
+	sandboxRuntime := config.GetSandbox()
+
+	if sandboxRuntime != git.SandboxOff {
+		// The session execs into a container, so attaching to it lands inside the sandbox
+		var err error
+
+		command, containerID, err = sandboxSession(config, sandboxRuntime, sessionName, worktreePath, workDir, aiCommand)
+		if err != nil {
+			return err
+		}
+	} else if len(aiCommand) > 0 {
 		command = aiCommand
 	} else {
 		// Fall back to shell if no AI command
@@ -2744,11 +4187,26 @@ func createSessionWithAICommand(
 		command = session.GetShellCommand(configuredShell)
 	}
 
+	// Containers enforce limits themselves; host sessions get a systemd scope
+	limits := sessionLimits(config)
+
+	var unit string
+	if containerID == "" {
+		command, unit = limitedCommand(sessionName, limits, command)
+	}
+
+	// Usage of the conversations the session ran before, read before the new one starts
+	usage := previousUsage(sessionMgr, sessionName)
+
 	// Create the actual tmux session
-	if err := sessionMgr.CreateSession(sessionName, worktreePath, command); err != nil {
+	if err := sessionMgr.CreateSession(sessionName, workDir, command); err != nil {
 		return fmt.Errorf("failed to create session: %w", err)
 	}
 
+	if config.GetCheckpointInterval() > 0 {
+		startCheckpointWatcher(sessionName, worktreePath)
+	}
+
 	// Create session metadata
 	now := time.Now()
 	metadata := &session.Metadata{
@@ -2757,6 +4215,12 @@ func createSessionWithAICommand(
 		SessionType:    string(sessionMgr.SessionType()),
 		WorktreePath:   worktreePath,
 		BranchName:     branchName,
+		Scope:          scope,
+		ContainerID:    containerID,
+		Resources:      sessionResources(limits, containerID, unit),
+		AITool:         conversation.Tool,
+		ConversationID: conversation.ID,
+		Usage:          usage,
 		CreatedAt:      now,
 		LastAccessedAt: now,
 		Status:         session.StatusRunning,
@@ -2767,9 +4231,13 @@ func createSessionWithAICommand(
 		},
 	}
 
+	if containerID != "" {
+		metadata.ContainerRuntime = sandboxRuntime
+	}
+
 	// Save metadata
 	if err := sessionMgr.SaveSessionMetadata(metadata); err != nil {
-		fmt.Printf("⚠ Warning: Failed to save session metadata: %v\n", err)
+		logging.Warn("failed to save session metadata", "err", err)
 		// Don't fail the session creation if metadata save fails
 	}
 
@@ -2782,11 +4250,11 @@ func createSessionWithAICommand(
 			}
 
 			if err := session.InstallDependencies(metadata, progressFn); err != nil {
-				fmt.Printf("⚠ Warning: Failed to install dependencies: %v\n", err)
+				logging.Warn("failed to install dependencies", "err", err)
 			} else {
 				// Re-save metadata with updated dependency info
 				if err := sessionMgr.SaveSessionMetadata(metadata); err != nil {
-					fmt.Printf("⚠ Warning: Failed to save updated metadata: %v\n", err)
+					logging.Warn("failed to save updated metadata", "err", err)
 				}
 			}
 		}
@@ -2811,14 +4279,11 @@ func selectPRInteractive(client *github.Client, repo *git.Repository) (int, erro
 	// Check if PR auto-selection is enabled
 	prAutoselect, err := repo.Config.GetBool(git.ConfigPRAutoselect, git.ConfigScopeAuto)
 	if err == nil && prAutoselect {
-		// Apply AI-powered selection
-		fmt.Println("Using AI to prioritize pull requests...")
-		currentUser := getCurrentGitHubUser()
-		prs = aiSelectPRs(repo, prs, currentUser)
+		// Apply AI-powered selection, or the local ranking without it
+		var note string
 
-		if len(prs) > 0 {
-			fmt.Printf("Showing top %d AI-prioritized PRs\n", len(prs))
-		}
+		prs, note = rankPRs(repo, prs)
+		fmt.Println(note)
 	}
 
 	// Convert to filterable list items
@@ -2848,9 +4313,7 @@ func selectPRInteractive(client *github.Client, repo *git.Repository) (int, erro
 
 	// Show filterable list
 	filterList := ui.NewFilterList("Select a pull request to review", items)
-	p := tea.NewProgram(filterList, tea.WithAltScreen())
-
-	m, err := p.Run()
+	m, err := ui.Run(filterList, tea.WithAltScreen())
 	if err != nil {
 		return 0, fmt.Errorf("failed to run PR selector: %w", err)
 	}
@@ -2879,11 +4342,17 @@ func parsePRNumber(s string) (int, error) {
 	return strconv.Atoi(s)
 }
 
-// offerResumePRWorktree displays information about an existing worktree for a PR
-func offerResumePRWorktree(wt *git.Worktree, pr *github.PullRequest) error {
-	fmt.Printf("Worktree already exists for PR #%d\n", pr.Number)
+// offerResumePRWorktree displays information about an existing worktree for
+// the PR or MR named label
+func offerResumePRWorktree(wt *git.Worktree, label string) error {
+	fmt.Printf("Worktree already exists for %s\n", label)
 	fmt.Printf("Path: %s\n", wt.Path)
-	fmt.Printf("Branch: %s\n", wt.Branch)
+
+	if wt.ReviewOnly != nil {
+		fmt.Printf("Branch: none (review-only, detached at %s)\n", wt.HEAD[:min(7, len(wt.HEAD))])
+	} else {
+		fmt.Printf("Branch: %s\n", wt.Branch)
+	}
 	fmt.Printf("\nTo resume reviewing:\n")
 	fmt.Printf("  auto-worktree resume\n")
 	return nil
@@ -2891,7 +4360,7 @@ func offerResumePRWorktree(wt *git.Worktree, pr *github.PullRequest) error {
 
 // getCurrentGitHubUser gets the current GitHub user's login
 func getCurrentGitHubUser() string {
-	ctx := context.Background()
+	ctx := interrupt.Context()
 	cmd := exec.CommandContext(ctx, "gh", "api", "user", "--jq", ".login")
 	output, err := cmd.Output()
 	if err != nil {
@@ -2900,242 +4369,134 @@ func getCurrentGitHubUser() string {
 	return strings.TrimSpace(string(output))
 }
 
-// aiSelectIssues uses AI to select and prioritize issues.
-// Returns a filtered and reordered list of issues, or the original list if AI selection fails.
-func aiSelectIssues(repo *git.Repository, issues []providers.Issue, providerType string) []providers.Issue {
+// aiSelectIssues uses AI to select and prioritize issues, reusing a recent
+// ranking. Returns the selected issues in order with the ranking they follow,
+// or the original list and nil if AI selection is unavailable or fails.
+func aiSelectIssues(repo *git.Repository, issues []providers.Issue, providerType string) ([]providers.Issue, *aiRanking) {
+	if offline.Enabled() {
+		return issues, nil
+	}
+
 	// Resolve AI tool
 	resolver := ai.NewResolver(repo.Config)
 	tool, err := resolver.Resolve()
 	if err != nil {
 		// AI tool not available or disabled, return original list
-		return issues
+		return issues, nil
 	}
 
-	// Build the prompt with issue data
-	prompt := buildIssueSelectionPrompt(issues, providerType, repo)
+	ranking, err := newAIRanker(repo.Config).Rank(aiRankingKey("issues:"+providerType, repo.RootPath), func() ([]string, error) {
+		fmt.Println("Using AI to prioritize issues...")
 
-	// Execute AI prompt
-	output, err := tool.ExecutePrompt(prompt)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Warning: AI selection failed: %v\n", err)
-		fmt.Fprintf(os.Stderr, "Falling back to showing all issues\n")
+		output, err := tool.ExecutePrompt(buildIssueSelectionPrompt(issues, providerType, repo))
+		if err != nil {
+			return nil, err
+		}
+
+		// Parse IDs from AI output based on provider type
+		if providerType == "linear" {
+			return ai.ParseLinearIDs(output, 5), nil
+		}
+
+		return ai.ParseNumericIDs(output, 5), nil
+	})
+
+	switch {
+	case errors.Is(err, errAIRankLimit):
+		fmt.Fprintf(os.Stderr, "Skipped AI prioritization: %v\n", err)
+	case err != nil:
+		logging.Warn("AI selection failed, showing all issues", "err", err)
 
 		// Disable auto-select on failure
 		if setErr := repo.Config.SetBool(git.ConfigIssueAutoselect, false, git.ConfigScopeLocal); setErr != nil {
-			fmt.Fprintf(os.Stderr, "Warning: Failed to disable auto-select: %v\n", setErr)
+			logging.Warn("failed to disable auto-select", "err", setErr)
 		} else {
 			fmt.Fprintf(os.Stderr, "AI auto-select has been disabled. Re-enable in settings if needed.\n")
 		}
 
-		return issues
+		return issues, nil
 	}
 
-	// Parse IDs from AI output based on provider type
-	var selectedIDs []string
-	if providerType == "linear" {
-		selectedIDs = ai.ParseLinearIDs(output, 5)
-	} else {
-		selectedIDs = ai.ParseNumericIDs(output, 5)
+	if ranking == nil {
+		return issues, nil
 	}
 
-	if len(selectedIDs) == 0 {
-		fmt.Fprintf(os.Stderr, "Warning: AI returned no valid issue IDs\n")
-		return issues
+	if len(ranking.IDs) == 0 {
+		logging.Warn("AI returned no valid issue IDs")
+		return issues, nil
 	}
 
 	// Reorder issues based on AI selection
-	selected := make([]providers.Issue, 0, len(selectedIDs))
-	for _, id := range selectedIDs {
-		for _, issue := range issues {
-			if issue.ID == id {
-				selected = append(selected, issue)
-				break
-			}
-		}
-	}
-
+	selected := rankedOrder(issues, ranking.IDs, func(issue providers.Issue) string { return issue.ID })
 	if len(selected) == 0 {
 		// No matches found, return original list
-		return issues
+		return issues, nil
 	}
 
-	return selected
+	return selected, ranking
 }
 
-// buildIssueSelectionPrompt creates a prompt for AI to select issues.
-func buildIssueSelectionPrompt(issues []providers.Issue, providerType string, repo *git.Repository) string {
-	var sb strings.Builder
-
-	sb.WriteString("Analyze the following issues and select the top 5 issues that would be best to work on next. Consider:\n")
-	sb.WriteString("- Priority labels (high priority, urgent, etc.)\n")
-	sb.WriteString("- Issue type (bug fixes are often higher priority than features)\n")
-	sb.WriteString("- Labels like 'good first issue' or 'help wanted'\n")
-	sb.WriteString("- Issue complexity and impact\n\n")
-
-	// Add repository context if available
-	repoPath := repo.RootPath
-	if repoPath != "" {
-		sb.WriteString(fmt.Sprintf("Repository: %s\n\n", repoPath))
-	}
-
-	if providerType == "linear" {
-		sb.WriteString("Return ONLY the top 5 issue IDs in priority order (one per line), formatted as issue IDs (e.g., 'TEAM-42').\n\n")
-	} else {
-		sb.WriteString("Return ONLY the top 5 issue numbers in priority order (one per line), formatted as just the numbers (e.g., '42').\n\n")
-	}
-
-	sb.WriteString("Issues:\n")
-	for _, issue := range issues {
-		sb.WriteString(fmt.Sprintf("#%s | %s", issue.ID, issue.Title))
-		if len(issue.Labels) > 0 {
-			sb.WriteString(" [")
-			sb.WriteString(strings.Join(issue.Labels, ", "))
-			sb.WriteString("]")
-		}
-		sb.WriteString("\n")
+// aiSelectPRs uses AI to select and prioritize pull requests, reusing a recent
+// ranking. Returns the selected PRs in order with the ranking they follow, or
+// the original list and nil if AI selection is unavailable or fails.
+func aiSelectPRs(repo *git.Repository, prs []github.PullRequest) ([]github.PullRequest, *aiRanking) {
+	if offline.Enabled() {
+		return prs, nil
 	}
 
-	sb.WriteString("\nReturn only the issue IDs/numbers, one per line, nothing else.")
-
-	return sb.String()
-}
-
-// aiSelectPRs uses AI to select and prioritize pull requests.
-// Returns a filtered and reordered list of PRs, or the original list if AI selection fails.
-func aiSelectPRs(repo *git.Repository, prs []github.PullRequest, currentUser string) []github.PullRequest {
 	// Resolve AI tool
 	resolver := ai.NewResolver(repo.Config)
 	tool, err := resolver.Resolve()
 	if err != nil {
 		// AI tool not available or disabled, return original list
-		return prs
+		return prs, nil
 	}
 
-	// Build the prompt with PR data
-	prompt := buildPRSelectionPrompt(prs, currentUser, repo)
+	ranking, err := newAIRanker(repo.Config).Rank(aiRankingKey("prs", repo.RootPath), func() ([]string, error) {
+		fmt.Println("Using AI to prioritize pull requests...")
 
-	// Execute AI prompt
-	output, err := tool.ExecutePrompt(prompt)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Warning: AI selection failed: %v\n", err)
-		fmt.Fprintf(os.Stderr, "Falling back to showing all PRs\n")
+		output, err := tool.ExecutePrompt(buildPRSelectionPrompt(prs, getCurrentGitHubUser(), repo))
+		if err != nil {
+			return nil, err
+		}
+
+		// Parse PR numbers from AI output
+		return ai.ParseNumericIDs(output, 5), nil
+	})
+
+	switch {
+	case errors.Is(err, errAIRankLimit):
+		fmt.Fprintf(os.Stderr, "Skipped AI prioritization: %v\n", err)
+	case err != nil:
+		logging.Warn("AI selection failed, showing all PRs", "err", err)
 
 		// Disable auto-select on failure
 		if setErr := repo.Config.SetBool(git.ConfigPRAutoselect, false, git.ConfigScopeLocal); setErr != nil {
-			fmt.Fprintf(os.Stderr, "Warning: Failed to disable auto-select: %v\n", setErr)
+			logging.Warn("failed to disable auto-select", "err", setErr)
 		} else {
 			fmt.Fprintf(os.Stderr, "AI auto-select has been disabled. Re-enable in settings if needed.\n")
 		}
 
-		return prs
+		return prs, nil
 	}
 
-	// Parse PR numbers from AI output
-	selectedNumbers := ai.ParseNumericIDs(output, 5)
-
-	if len(selectedNumbers) == 0 {
-		fmt.Fprintf(os.Stderr, "Warning: AI returned no valid PR numbers\n")
-		return prs
+	if ranking == nil {
+		return prs, nil
 	}
 
-	// Reorder PRs based on AI selection
-	selected := make([]github.PullRequest, 0, len(selectedNumbers))
-	for _, numStr := range selectedNumbers {
-		for _, pr := range prs {
-			if fmt.Sprintf("%d", pr.Number) == numStr {
-				selected = append(selected, pr)
-				break
-			}
-		}
+	if len(ranking.IDs) == 0 {
+		logging.Warn("AI returned no valid PR numbers")
+		return prs, nil
 	}
 
+	// Reorder PRs based on AI selection
+	selected := rankedOrder(prs, ranking.IDs, func(pr github.PullRequest) string { return strconv.Itoa(pr.Number) })
 	if len(selected) == 0 {
 		// No matches found, return original list
-		return prs
-	}
-
-	return selected
-}
-
-// buildPRSelectionPrompt creates a prompt for AI to select PRs.
-func buildPRSelectionPrompt(prs []github.PullRequest, currentUser string, repo *git.Repository) string {
-	var sb strings.Builder
-
-	sb.WriteString("Analyze the following GitHub Pull Requests and select the top 5 PRs that would be best to review next. ")
-	sb.WriteString("Consider the following criteria in priority order:\n\n")
-	sb.WriteString(fmt.Sprintf("1. PRs where the current user (%s) was requested as a reviewer (highest priority)\n", currentUser))
-	sb.WriteString("2. PRs with no reviews yet (need attention)\n")
-	sb.WriteString("3. Smaller PRs with fewer changes (easier to review, faster feedback)\n")
-	sb.WriteString("4. PRs with 100% passing checks (✓ status) - prefer these over failing (✗) or pending (○)\n")
-	sb.WriteString("5. Author reputation: prefer maintainers/core contributors over occasional contributors\n\n")
-
-	// Add repository context if available
-	repoPath := repo.RootPath
-	if repoPath != "" {
-		sb.WriteString(fmt.Sprintf("Repository: %s\n", repoPath))
-	}
-	sb.WriteString(fmt.Sprintf("Current user: %s\n\n", currentUser))
-
-	sb.WriteString("Return ONLY the top 5 PR numbers in priority order (one per line), formatted as just the numbers (e.g., '42').\n\n")
-
-	sb.WriteString("Pull Requests:\n")
-	for _, pr := range prs {
-		// Format: #123 | Title [labels] | +50/-20 | ✓✗○
-		sb.WriteString(fmt.Sprintf("#%d | %s", pr.Number, pr.Title))
-
-		if len(pr.Labels) > 0 {
-			labels := make([]string, len(pr.Labels))
-			for i, label := range pr.Labels {
-				labels[i] = label.Name
-			}
-			sb.WriteString(" [")
-			sb.WriteString(strings.Join(labels, ", "))
-			sb.WriteString("]")
-		}
-
-		sb.WriteString(fmt.Sprintf(" | +%d/-%d", pr.Additions, pr.Deletions))
-
-		// Add review request info
-		if len(pr.ReviewRequests) > 0 {
-			reviewers := make([]string, len(pr.ReviewRequests))
-			for i, req := range pr.ReviewRequests {
-				reviewers[i] = req.Login
-			}
-			sb.WriteString(" | Reviewers: ")
-			sb.WriteString(strings.Join(reviewers, ", "))
-		}
-
-		// Add CI status
-		if len(pr.StatusCheckRollup) > 0 {
-			sb.WriteString(" | CI: ")
-			passing := 0
-			failing := 0
-			pending := 0
-			for _, check := range pr.StatusCheckRollup {
-				switch check.Status {
-				case "SUCCESS", "COMPLETED":
-					passing++
-				case "FAILURE", "ERROR":
-					failing++
-				default:
-					pending++
-				}
-			}
-			sb.WriteString(fmt.Sprintf("✓%d", passing))
-			if failing > 0 {
-				sb.WriteString(fmt.Sprintf(" ✗%d", failing))
-			}
-			if pending > 0 {
-				sb.WriteString(fmt.Sprintf(" ○%d", pending))
-			}
-		}
-
-		sb.WriteString("\n")
+		return prs, nil
 	}
 
-	sb.WriteString("\nReturn only the 5 PR numbers, one per line, nothing else.")
-
-	return sb.String()
+	return selected, ranking
 }
 
 // shouldGenerateAIReview checks if AI review should be generated
@@ -3147,6 +4508,10 @@ func shouldGenerateAIReview(repo *git.Repository) bool {
 	return aiTool != "" && aiTool != aiToolSkip
 }
 
+// maxReviewDiffBytes caps the PR diff in a review summary prompt unless
+// auto-worktree.ai-diff-limit says otherwise
+const maxReviewDiffBytes = 10_000
+
 // generateAIReviewSummary generates an AI-powered review summary
 func generateAIReviewSummary(client *github.Client, pr *github.PullRequest, repo *git.Repository) error {
 	// Get configured AI tool
@@ -3161,10 +4526,7 @@ func generateAIReviewSummary(client *github.Client, pr *github.PullRequest, repo
 		return fmt.Errorf("failed to fetch PR diff: %w", err)
 	}
 
-	// Truncate diff if too long (limit to first 10000 chars)
-	if len(diff) > 10000 {
-		diff = diff[:10000] + "\n... (diff truncated)"
-	}
+	diff = truncateDiff(diff, repo.Config.GetAIDiffLimit(maxReviewDiffBytes))
 
 	// Format prompt for AI
 	prompt := formatAIReviewPrompt(pr, diff)
@@ -3239,92 +4601,8 @@ func isPacmanBasedLinux() bool {
 	return err == nil
 }
 
-// tryInstallTmux attempts to install tmux using OS-specific package manager
-func tryInstallTmux() bool {
-	fmt.Println("\n⚠ Attempting to install tmux...")
-
-	var cmd *exec.Cmd
-
-	switch runtime.GOOS {
-	case "darwin":
-		// Check if Homebrew is installed
-		_, err := exec.LookPath("brew")
-		if err != nil {
-			fmt.Println("❌ Homebrew not found. Please install Homebrew from https://brew.sh")
-			return false
-		}
-		cmd = exec.CommandContext(context.Background(), "brew", "install", "tmux")
-
-	case "linux":
-		if isAptBasedLinux() {
-			cmd = exec.CommandContext(context.Background(), "sudo", "apt", "update")
-			if err := cmd.Run(); err != nil {
-				fmt.Printf("❌ Failed to update package manager: %v\n", err)
-				return false
-			}
-			cmd = exec.CommandContext(context.Background(), "sudo", "apt", "install", "-y", "tmux")
-		} else if isRpmBasedLinux() {
-			// Try dnf first (newer), then yum
-			_, err := exec.LookPath("dnf")
-			if err == nil {
-				cmd = exec.CommandContext(context.Background(), "sudo", "dnf", "install", "-y", "tmux")
-			} else {
-				cmd = exec.CommandContext(context.Background(), "sudo", "yum", "install", "-y", "tmux")
-			}
-		} else if isPacmanBasedLinux() {
-			cmd = exec.CommandContext(context.Background(), "sudo", "pacman", "-S", "--noconfirm", "tmux")
-		} else {
-			fmt.Println("❌ No supported package manager found")
-			return false
-		}
-
-	default:
-		fmt.Printf("❌ Automatic installation not supported on %s\n", runtime.GOOS)
-		return false
-	}
-
-	if err := cmd.Run(); err != nil {
-		fmt.Printf("❌ Failed to install tmux: %v\n", err)
-		return false
-	}
-
-	fmt.Println("✓ tmux installed successfully!")
-	return true
-}
-
 // startAISessionGitLab starts an AI tool in a background tmux session for GitLab
 
-// handleMissingTmux displays installation instructions and offers to install
-func handleMissingTmux() error {
-	osName, installCmd := getTmuxInstallInstructions()
-
-	fmt.Printf("\n❌ tmux is not installed\n\n")
-	fmt.Printf("Platform: %s\n", osName)
-	fmt.Printf("Installation command:\n  %s\n\n", installCmd)
-
-	// Ask if user wants to attempt auto-installation
-	fmt.Println("Would you like to attempt automatic installation?")
-	confirmModel := ui.NewConfirmModel("Install tmux now?")
-	p := tea.NewProgram(confirmModel)
-	result, err := p.Run()
-	if err != nil {
-		return fmt.Errorf("tmux is required - please install it manually")
-	}
-
-	confirmed, ok := result.(*ui.ConfirmModel)
-	if !ok || !confirmed.GetChoice() {
-		return fmt.Errorf("tmux is required - please install it manually")
-	}
-
-	// Attempt installation
-	if tryInstallTmux() {
-		fmt.Println("Please try the operation again.")
-		return nil
-	}
-
-	return fmt.Errorf("tmux installation failed - please install manually")
-}
-
 // formatAIReviewPrompt formats a prompt for AI review
 func formatAIReviewPrompt(pr *github.PullRequest, diff string) string {
 	return fmt.Sprintf(`Please review this pull request:
@@ -3350,35 +4628,42 @@ Please provide:
 `, pr.Title, pr.Author.Login, pr.Body, pr.ChangedFiles, pr.Additions, pr.Deletions, diff)
 }
 
-// checkoutPRInWorktree creates a worktree and checks out the PR branch
-func checkoutPRInWorktree(repo *git.Repository, worktreePath, branchName string, pr *github.PullRequest) error {
-	// Use gh pr checkout to fetch and checkout the PR
-	// This will create a local branch tracking the PR's head branch
-	executor := git.NewGitExecutor()
+// checkoutPRInWorktree checks out the PR or MR in a worktree just added on a
+// new branch. A worktree whose checkout failed isn't the PR, so it is rolled
+// back without asking.
+func checkoutPRInWorktree(p *createPipeline, review prReview) error {
+	head := review.Head
+
+	var pushRemote string
 
-	// First, create the worktree directory with a temporary branch
-	defaultBranch, err := repo.GetDefaultBranch()
+	err := p.step(stepCheckoutPR, func() error {
+		var err error
+		pushRemote, err = p.repo.CheckoutPullRequest(p.path, p.branch, head)
+
+		return err
+	})
 	if err != nil {
-		return fmt.Errorf("error getting default branch: %w", err)
-	}
+		if stopErr := p.check(); stopErr != nil {
+			return stopErr
+		}
 
-	// Create worktree with new branch
-	if err := repo.CreateWorktreeWithNewBranch(worktreePath, branchName, defaultBranch); err != nil {
-		return fmt.Errorf("failed to create worktree: %w", err)
+		// If checkout fails, clean up the worktree and its branch
+		p.rollback()
+
+		return fmt.Errorf("failed to checkout %s: %w", review.Label, err)
 	}
 
-	// Now checkout the PR in that worktree using gh pr checkout
-	checkoutCmd := fmt.Sprintf("cd %s && gh pr checkout %d -b %s", worktreePath, pr.Number, branchName)
-	if _, err := executor.Execute(checkoutCmd); err != nil {
-		// If checkout fails, try to clean up the worktree
-		if removeErr := repo.RemoveWorktree(worktreePath); removeErr != nil {
-			// Log the error but don't fail - we're already in an error state
-			fmt.Printf("Warning: Could not clean up worktree: %v\n", removeErr)
-		}
-		return fmt.Errorf("failed to checkout PR #%d: %w", pr.Number, err)
+	switch {
+	case pushRemote != "":
+		fmt.Printf("Push changes back to %s with: git push %s HEAD:%s\n", review.Label, pushRemote, head.Branch)
+	case head.IsFork() && head.ForkName == "":
+		fmt.Printf("%s %s is from a fork, so changes can't be pushed back to it\n", ui.WarningStyle.Render("⚠"), review.Label)
+	case head.IsFork():
+		fmt.Printf("%s The PR's author doesn't let maintainers push to %s/%s, so changes can't be pushed back to it\n",
+			ui.WarningStyle.Render("⚠"), head.ForkOwner, head.ForkName)
 	}
 
-	return nil
+	return p.check()
 }
 
 // RunSessions displays and manages active tmux sessions
@@ -3410,14 +4695,12 @@ func RunSessions() error {
 	// Convert metadata to UI items
 	items := make([]ui.SessionListItem, len(validSessions))
 	for i, metadata := range validSessions {
-		items[i] = ui.NewSessionListItem(metadata)
+		items[i] = ui.NewSessionListItem(metadata).WithUsage(sessionUsage(metadata))
 	}
 
 	// Show the sessions list
 	list := ui.NewSessionList("Active Tmux Sessions", items)
-	p := tea.NewProgram(list, tea.WithAltScreen())
-
-	m, err := p.Run()
+	m, err := ui.Run(list, tea.WithAltScreen())
 	if err != nil {
 		return fmt.Errorf("failed to run sessions UI: %w", err)
 	}
@@ -3446,8 +4729,30 @@ func RunSessions() error {
 	return nil
 }
 
+// ErrHealthCheckFailed is returned by RunHealthCheck when an issue at or above
+// the requested severity was found, so cron or CI can alert on it
+var ErrHealthCheckFailed = errors.New("health check found issues")
+
+// HealthCheckOptions configures `auto-worktree health-check`
+type HealthCheckOptions struct {
+	// All checks every worktree rather than the current one
+	All bool
+	// Severity shows only issues this severe or worse and fails when there
+	// are any; SeverityOK shows everything and never fails
+	Severity git.HealthCheckSeverity
+	// JSON prints the results for machines instead of people
+	JSON bool
+}
+
+// healthCheckReport is the --json output of health-check
+type healthCheckReport struct {
+	Severity git.HealthCheckSeverity  `json:"severity"`
+	Passed   bool                     `json:"passed"`
+	Results  []*git.HealthCheckResult `json:"results"`
+}
+
 // RunHealthCheck performs a health check on worktrees
-func RunHealthCheck() error {
+func RunHealthCheck(opts HealthCheckOptions) error {
 	span := perf.StartSpan("health-check-command")
 	defer span()
 
@@ -3456,19 +4761,13 @@ func RunHealthCheck() error {
 		return fmt.Errorf("failed to initialize repository: %w", err)
 	}
 
-	// Parse flags
-	checkAll := false
-	for _, arg := range os.Args[2:] {
-		if arg == "--all" || arg == "-a" {
-			checkAll = true
-		}
-	}
-
 	var results []*git.HealthCheckResult
 
-	if checkAll {
+	if opts.All {
 		// Check all worktrees
-		fmt.Println("🔍 Running health check on all worktrees...")
+		if !opts.JSON {
+			fmt.Println("🔍 Running health check on all worktrees...")
+		}
 		results, err = repo.PerformHealthCheckAll()
 		if err != nil {
 			return fmt.Errorf("health check failed: %w", err)
@@ -3480,7 +4779,9 @@ func RunHealthCheck() error {
 			return fmt.Errorf("failed to get current directory: %w", err)
 		}
 
-		fmt.Printf("🔍 Running health check on current worktree: %s\n", cwd)
+		if !opts.JSON {
+			fmt.Printf("🔍 Running health check on current worktree: %s\n", cwd)
+		}
 		result, err := repo.PerformHealthCheck(cwd)
 		if err != nil {
 			return fmt.Errorf("health check failed: %w", err)
@@ -3488,15 +4789,65 @@ func RunHealthCheck() error {
 		results = []*git.HealthCheckResult{result}
 	}
 
-	// Display results
-	fmt.Println()
-	displayHealthCheckResults(results)
+	// Branch drift needs session metadata, so it is checked here rather than in the git package
+	addBranchDriftIssues(results, detectBranchDrift(repo, session.NewManager()))
+
+	results = filterHealthResults(results, opts.Severity)
+	failed := healthCheckFailed(results, opts.Severity)
+
+	if opts.JSON {
+		data, err := json.MarshalIndent(healthCheckReport{Severity: opts.Severity, Passed: !failed, Results: results}, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal health check report: %w", err)
+		}
+
+		fmt.Println(string(data))
+	} else {
+		// Display results
+		fmt.Println()
+		displayHealthCheckResults(results, opts.Severity)
+	}
+
+	if failed {
+		return ErrHealthCheckFailed
+	}
 
 	return nil
 }
 
-// displayHealthCheckResults prints health check results in a readable format
-func displayHealthCheckResults(results []*git.HealthCheckResult) {
+// filterHealthResults copies the results without the issues less severe than
+// minSeverity, keeping every worktree so it still reports whether it is healthy
+func filterHealthResults(results []*git.HealthCheckResult, minSeverity git.HealthCheckSeverity) []*git.HealthCheckResult {
+	filtered := make([]*git.HealthCheckResult, 0, len(results))
+
+	for _, result := range results {
+		copied := *result
+		copied.Issues = result.IssuesAtLeast(minSeverity)
+		filtered = append(filtered, &copied)
+	}
+
+	return filtered
+}
+
+// healthCheckFailed reports whether any issue reaches minSeverity; without a
+// severity the health check only informs
+func healthCheckFailed(results []*git.HealthCheckResult, minSeverity git.HealthCheckSeverity) bool {
+	if minSeverity == git.SeverityOK {
+		return false
+	}
+
+	for _, result := range results {
+		if len(result.IssuesAtLeast(minSeverity)) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// displayHealthCheckResults prints health check results in a readable format;
+// with a minimum severity, worktrees without issues that severe are left out
+func displayHealthCheckResults(results []*git.HealthCheckResult, minSeverity git.HealthCheckSeverity) {
 	totalIssues := 0
 	healthyCount := 0
 	unhealthyCount := 0
@@ -3518,6 +4869,10 @@ func displayHealthCheckResults(results []*git.HealthCheckResult) {
 			}
 		}
 
+		if minSeverity > git.SeverityOK && len(result.Issues) == 0 {
+			continue
+		}
+
 		// Display worktree header
 		fmt.Printf("\n📁 Worktree: %s\n", result.WorktreePath)
 
@@ -3571,7 +4926,11 @@ func displayHealthCheckResults(results []*git.HealthCheckResult) {
 	fmt.Printf("  Total worktrees checked: %d\n", len(results))
 	fmt.Printf("  Healthy: %d\n", healthyCount)
 	fmt.Printf("  Unhealthy: %d\n", unhealthyCount)
-	fmt.Printf("  Total issues: %d\n", totalIssues)
+	if minSeverity > git.SeverityOK {
+		fmt.Printf("  Issues at or above %s: %d\n", minSeverity, totalIssues)
+	} else {
+		fmt.Printf("  Total issues: %d\n", totalIssues)
+	}
 	if repairableIssues > 0 {
 		fmt.Printf("  Repairable issues: %d\n", repairableIssues)
 		fmt.Println("\n💡 Run 'auto-worktree repair' to fix repairable issues automatically")
@@ -3732,11 +5091,34 @@ func RunMonitor() error {
 		}
 	}
 
+	// Stop sessions left idle too long, on the same schedule as the health checks
+	if timeout := repo.Config.GetSessionIdleTimeout(); timeout > 0 {
+		defer watchIdleSessions(repo, timeout, interval)()
+	}
+
+	// Forget sessions of worktrees removed outside auto-worktree, on the same schedule
+	defer watchOrphanedSessions(repo, interval)()
+
 	// Create and run the monitor UI
 	monitor := ui.NewMonitor(repo, interval)
-	p := tea.NewProgram(monitor, tea.WithAltScreen())
+	monitor.SetTestStatus(func() map[string]string {
+		tests := map[string]string{}
+
+		worktrees, err := repo.ListWorktrees()
+		if err != nil {
+			return tests
+		}
+
+		results := loadTestResults()
+		for _, wt := range worktrees {
+			if r, ok := results[wt.Path]; ok {
+				tests[wt.Path] = formatTestResult(r, wt.HEAD)
+			}
+		}
 
-	if _, err := p.Run(); err != nil {
+		return tests
+	})
+	if _, err := ui.Run(monitor, tea.WithAltScreen()); err != nil {
 		return fmt.Errorf("failed to run monitor: %w", err)
 	}
 
@@ -0,0 +1,337 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/kaeawc/auto-worktree/internal/ai"
+	"github.com/kaeawc/auto-worktree/internal/git"
+	"github.com/kaeawc/auto-worktree/internal/ui"
+)
+
+const (
+	// compareLabelWidth and compareColumnWidth lay out the comparison: a
+	// label, then a column for each branch
+	compareLabelWidth  = 10
+	compareColumnWidth = 50
+	// maxCompareRows caps the commits and files listed for each branch
+	maxCompareRows = 15
+)
+
+// CompareOptions configures `auto-worktree compare`
+type CompareOptions struct {
+	BranchA string
+	BranchB string
+	// AI asks the AI tool which approach looks better
+	AI bool
+}
+
+// attempt is one side of a comparison: a worktree's work since the default branch
+type attempt struct {
+	wt *git.Worktree
+	// commits are one-line summaries, newest first
+	commits []string
+	files   []git.FileStat
+	test    *testResult
+}
+
+// RunCompare shows two worktrees' work side by side, such as two attempts at
+// the same issue: their changes, commits, changed files and last test results
+func RunCompare(opts CompareOptions) error {
+	if opts.BranchA == opts.BranchB {
+		return fmt.Errorf("compare needs two different branches")
+	}
+
+	repo, err := git.NewRepository()
+	if err != nil {
+		return fmt.Errorf("error: %w", err)
+	}
+
+	worktrees, err := repo.ListWorktrees()
+	if err != nil {
+		return fmt.Errorf("error listing worktrees: %w", err)
+	}
+
+	target, err := repo.ConflictTarget()
+	if err != nil {
+		return fmt.Errorf("could not determine the default branch to compare against: %w", err)
+	}
+
+	tests := loadTestResults()
+
+	a, err := loadAttempt(repo, worktrees, opts.BranchA, target, tests)
+	if err != nil {
+		return err
+	}
+
+	b, err := loadAttempt(repo, worktrees, opts.BranchB, target, tests)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(ui.SubtleStyle.Render(fmt.Sprintf("Changes since %s", strings.TrimPrefix(target, "origin/"))))
+	fmt.Println()
+	fmt.Print(renderComparison(a, b))
+
+	if !opts.AI {
+		return nil
+	}
+
+	return compareWithAI(repo, a, b, target)
+}
+
+// loadAttempt reads the work on branch's worktree since target
+func loadAttempt(repo *git.Repository, worktrees []*git.Worktree, branch, target string, tests map[string]*testResult) (*attempt, error) {
+	matched := filterWorktreesByBranch(worktrees, branch)
+	if len(matched) == 0 {
+		return nil, fmt.Errorf("no worktree for branch %s", branch)
+	}
+
+	wt := matched[0]
+
+	log, err := repo.BranchLog(wt.Path, target)
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := repo.BranchFileStats(wt.Path, target)
+	if err != nil {
+		return nil, err
+	}
+
+	var commits []string
+
+	for _, line := range strings.Split(log, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			commits = append(commits, line)
+		}
+	}
+
+	return &attempt{wt: wt, commits: commits, files: files, test: tests[wt.Path]}, nil
+}
+
+// changeSummary describes an attempt's size, e.g. "3 commits, 4 files, +120 -30"
+func (a *attempt) changeSummary() string {
+	insertions, deletions := 0, 0
+
+	for _, f := range a.files {
+		insertions += f.Insertions
+		deletions += f.Deletions
+	}
+
+	return fmt.Sprintf("%d commits, %d files, +%d -%d", len(a.commits), len(a.files), insertions, deletions)
+}
+
+// fileLines lists the attempt's changed files with their line counts
+func (a *attempt) fileLines() []string {
+	lines := make([]string, len(a.files))
+
+	for i, f := range a.files {
+		counts := fmt.Sprintf("+%d -%d", f.Insertions, f.Deletions)
+		if f.Binary {
+			counts = "binary"
+		}
+
+		path := fitColumn(f.Path, compareColumnWidth-len(counts)-3)
+		lines[i] = path + " " + ui.SubtleStyle.Render(counts)
+	}
+
+	return lines
+}
+
+// renderComparison lays out two attempts in columns
+func renderComparison(a, b *attempt) string {
+	var sb strings.Builder
+
+	row := func(label, left, right string) {
+		line := padVisible(ui.SubtleStyle.Render(label), compareLabelWidth) + padVisible(left, compareColumnWidth) + right
+		sb.WriteString(strings.TrimRight(line, " "))
+		sb.WriteString("\n")
+	}
+
+	rows := func(label string, left, right []string) {
+		for i := 0; i < max(len(left), len(right), 1); i++ {
+			if i == 0 {
+				row(label, listRow(left, i), listRow(right, i))
+			} else {
+				row("", listRow(left, i), listRow(right, i))
+			}
+		}
+	}
+
+	row("", ui.TitleStyle.Render(fitColumn(a.wt.Branch, compareColumnWidth-2)), ui.TitleStyle.Render(b.wt.Branch))
+	row("Tests", formatTestResult(a.test, a.wt.HEAD), formatTestResult(b.test, b.wt.HEAD))
+	row("Changes", a.changeSummary(), b.changeSummary())
+	sb.WriteString("\n")
+
+	commits := func(at *attempt) []string {
+		lines := make([]string, len(at.commits))
+		for i, c := range at.commits {
+			lines[i] = fitColumn(c, compareColumnWidth-2)
+		}
+
+		return lines
+	}
+
+	rows("Commits", capRows(commits(a)), capRows(commits(b)))
+	sb.WriteString("\n")
+	rows("Files", capRows(a.fileLines()), capRows(b.fileLines()))
+
+	if shared := sharedFiles(a, b); len(shared) > 0 {
+		sb.WriteString("\n")
+		sb.WriteString(ui.InfoStyle.Render(fmt.Sprintf("Both change %d file(s): %s", len(shared), strings.Join(capList(shared, 5), ", "))))
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+// listRow returns line i of a column, "-" for an empty column and "" past its end
+func listRow(lines []string, i int) string {
+	switch {
+	case len(lines) == 0 && i == 0:
+		return "-"
+	case i < len(lines):
+		return lines[i]
+	default:
+		return ""
+	}
+}
+
+// capRows keeps the first maxCompareRows lines, noting how many were left out
+func capRows(lines []string) []string {
+	if len(lines) <= maxCompareRows {
+		return lines
+	}
+
+	return append(lines[:maxCompareRows:maxCompareRows], ui.SubtleStyle.Render(fmt.Sprintf("… and %d more", len(lines)-maxCompareRows)))
+}
+
+// capList keeps the first n items, noting how many were left out
+func capList(items []string, n int) []string {
+	if len(items) <= n {
+		return items
+	}
+
+	return append(items[:n:n], fmt.Sprintf("and %d more", len(items)-n))
+}
+
+// sharedFiles returns the paths both attempts change, sorted
+func sharedFiles(a, b *attempt) []string {
+	inA := make(map[string]bool, len(a.files))
+	for _, f := range a.files {
+		inA[f.Path] = true
+	}
+
+	var shared []string
+
+	for _, f := range b.files {
+		if inA[f.Path] {
+			shared = append(shared, f.Path)
+		}
+	}
+
+	sort.Strings(shared)
+
+	return shared
+}
+
+// fitColumn shortens s to at most width runes, marking the cut with an ellipsis
+func fitColumn(s string, width int) string {
+	runes := []rune(s)
+	if len(runes) <= width {
+		return s
+	}
+
+	return string(runes[:width-1]) + "…"
+}
+
+// padVisible pads s with spaces to width terminal columns, ignoring its styling
+func padVisible(s string, width int) string {
+	if w := lipgloss.Width(s); w < width {
+		return s + strings.Repeat(" ", width-w)
+	}
+
+	return s + " "
+}
+
+// compareWithAI asks the AI tool which of the two attempts looks better
+func compareWithAI(repo *git.Repository, a, b *attempt, target string) error {
+	tool, err := ai.NewResolver(repo.Config).Resolve()
+	if err != nil {
+		return fmt.Errorf("compare --ai needs an AI tool: %w", err)
+	}
+
+	diffA, err := repo.BranchDiff(a.wt.Path, target)
+	if err != nil {
+		return err
+	}
+
+	diffB, err := repo.BranchDiff(b.wt.Path, target)
+	if err != nil {
+		return err
+	}
+
+	// The diff budget is shared by both branches
+	limit := repo.Config.GetAIDiffLimit(maxDescribeDiffBytes) / 2
+	prompt := buildComparePrompt(a, b, truncateDiff(diffA, limit), truncateDiff(diffB, limit))
+
+	if ok, err := confirmAIContext(repo.Config, tool, "both branch diffs", prompt); err != nil || !ok {
+		return err
+	}
+
+	fmt.Printf("\nAsking %s to compare the approaches...\n\n", tool.Name)
+
+	output, err := tool.ExecutePrompt(prompt)
+	if err != nil {
+		return fmt.Errorf("failed to compare with AI: %w", err)
+	}
+
+	fmt.Println(strings.TrimSpace(output))
+
+	return nil
+}
+
+// buildComparePrompt builds the prompt asking which of two attempts looks better
+func buildComparePrompt(a, b *attempt, diffA, diffB string) string {
+	var sb strings.Builder
+
+	sb.WriteString("Two branches attempt the same change. Compare their approaches: correctness, ")
+	sb.WriteString("simplicity, test coverage and risk. Say which one looks better to keep and why, ")
+	sb.WriteString("in a few short paragraphs.\n\n")
+
+	for _, side := range []struct {
+		at   *attempt
+		diff string
+	}{{a, diffA}, {b, diffB}} {
+		sb.WriteString(fmt.Sprintf("Branch: %s\n", side.at.wt.Branch))
+
+		if side.at.test != nil {
+			result := "passed"
+			if !side.at.test.Passed {
+				result = "failed"
+			}
+
+			if side.at.test.Commit != side.at.wt.HEAD {
+				result += " (on an earlier commit)"
+			}
+
+			sb.WriteString(fmt.Sprintf("Tests: %s\n", result))
+		}
+
+		if len(side.at.commits) > 0 {
+			sb.WriteString("Commits:\n")
+			sb.WriteString(strings.Join(side.at.commits, "\n"))
+			sb.WriteString("\n")
+		}
+
+		sb.WriteString("Diff:\n")
+		sb.WriteString(side.diff)
+		sb.WriteString("\n\n")
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/kaeawc/auto-worktree/internal/git"
+)
+
+func TestRenderComparison(t *testing.T) {
+	a := &attempt{
+		wt:      &git.Worktree{Branch: "work/7-retry", HEAD: "aaa"},
+		commits: []string{"a1 Retry the sync job", "a0 Add backoff"},
+		files:   []git.FileStat{{Path: "sync/job.go", Insertions: 30, Deletions: 4}, {Path: "sync/job_test.go", Insertions: 50}},
+		test:    &testResult{Commit: "aaa", Passed: true},
+	}
+	b := &attempt{
+		wt:    &git.Worktree{Branch: "work/7-queue", HEAD: "bbb"},
+		files: []git.FileStat{{Path: "sync/job.go", Insertions: 8, Deletions: 1}, {Path: "logo.png", Binary: true}},
+	}
+
+	out := renderComparison(a, b)
+
+	for _, want := range []string{
+		"work/7-retry", "work/7-queue",
+		"2 commits, 2 files, +80 -4", "0 commits, 2 files, +8 -1",
+		"a1 Retry the sync job", "logo.png binary",
+		"Both change 1 file(s): sync/job.go",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("renderComparison() missing %q:\n%s", want, out)
+		}
+	}
+
+	// The columns line up: the right-hand branch starts at the same offset on every row
+	lines := strings.Split(out, "\n")
+	if col := strings.Index(lines[0], "work/7-queue"); col != compareLabelWidth+compareColumnWidth {
+		t.Errorf("right column starts at %d, want %d:\n%s", col, compareLabelWidth+compareColumnWidth, out)
+	}
+}
+
+func TestCapRows(t *testing.T) {
+	lines := make([]string, maxCompareRows+3)
+	for i := range lines {
+		lines[i] = fmt.Sprintf("line %d", i)
+	}
+
+	capped := capRows(lines)
+	if len(capped) != maxCompareRows+1 || !strings.Contains(capped[maxCompareRows], "and 3 more") {
+		t.Errorf("capRows() = %v", capped)
+	}
+
+	if got := capRows(lines[:2]); len(got) != 2 {
+		t.Errorf("capRows() of a short list = %v", got)
+	}
+}
+
+func TestBuildComparePrompt(t *testing.T) {
+	a := &attempt{wt: &git.Worktree{Branch: "work/7-retry", HEAD: "new"}, commits: []string{"a1 Retry"}, test: &testResult{Commit: "old", Passed: true}}
+	b := &attempt{wt: &git.Worktree{Branch: "work/7-queue"}}
+
+	prompt := buildComparePrompt(a, b, "diff-a", "diff-b")
+
+	for _, want := range []string{"Branch: work/7-retry", "Tests: passed (on an earlier commit)", "a1 Retry", "diff-a", "Branch: work/7-queue", "diff-b"} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("prompt missing %q:\n%s", want, prompt)
+		}
+	}
+}
@@ -0,0 +1,196 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/kaeawc/auto-worktree/internal/git"
+	"github.com/kaeawc/auto-worktree/internal/limits"
+	"github.com/kaeawc/auto-worktree/internal/logging"
+	"github.com/kaeawc/auto-worktree/internal/state"
+	"github.com/kaeawc/auto-worktree/internal/ui"
+)
+
+// conflictIndicator marks worktrees whose branch would conflict with the default branch
+const conflictIndicator = "⚡"
+
+// cachedConflicts is a merge-tree result; the key names both commits, so it never goes stale
+type cachedConflicts struct {
+	Conflicts bool     `json:"conflicts"`
+	Files     []string `json:"files,omitempty"`
+}
+
+// conflictCacheKey is the state.BucketCache key for merging head into target
+func conflictCacheKey(head, targetSHA string) string {
+	return "conflicts:" + head + ":" + targetSHA
+}
+
+// conflictChecker finds which worktrees would conflict with the default branch,
+// caching each result by the pair of commits involved
+type conflictChecker struct {
+	repo      *git.Repository
+	store     *state.Store
+	target    string
+	targetSHA string
+}
+
+// newConflictChecker resolves the default branch to check against; it returns
+// nil when there is nothing to check against
+func newConflictChecker(repo *git.Repository) *conflictChecker {
+	target, err := repo.ConflictTarget()
+	if err != nil {
+		return nil
+	}
+
+	targetSHA, err := repo.ResolveCommit(target)
+	if err != nil {
+		return nil
+	}
+
+	checker := &conflictChecker{repo: repo, target: target, targetSHA: targetSHA}
+	if store, err := openStateStore(); err == nil {
+		checker.store = store
+	}
+
+	return checker
+}
+
+// Check returns the files wt's branch would conflict on, or nil if it merges cleanly
+func (c *conflictChecker) Check(wt *git.Worktree) ([]string, error) {
+	if wt.HEAD == "" || wt.HEAD == c.targetSHA {
+		return nil, nil
+	}
+
+	key := conflictCacheKey(wt.HEAD, c.targetSHA)
+
+	if c.store != nil {
+		var cached cachedConflicts
+		if err := c.store.Get(state.BucketCache, key, &cached); err == nil {
+			if !cached.Conflicts {
+				return nil, nil
+			}
+
+			return append([]string{}, cached.Files...), nil
+		} else if !errors.Is(err, state.ErrNotFound) {
+			logging.Debug("failed to read conflict cache", "err", err)
+		}
+	}
+
+	files, err := c.repo.MergeConflicts(c.targetSHA, wt.HEAD)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.store != nil {
+		if err := c.store.Put(state.BucketCache, key, cachedConflicts{Conflicts: files != nil, Files: files}); err != nil {
+			logging.Debug("failed to cache conflict check", "err", err)
+		}
+	}
+
+	return files, nil
+}
+
+// CheckAll checks every worktree in parallel and returns the conflicted files
+// by worktree path, for the worktrees that conflict
+func (c *conflictChecker) CheckAll(worktrees []*git.Worktree) map[string][]string {
+	var (
+		mu        sync.Mutex
+		conflicts = make(map[string][]string)
+	)
+
+	limits.ForEach(worktrees, func(wt *git.Worktree) {
+		if wt.IsDetached || wt.Branch == "" {
+			return
+		}
+
+		files, err := c.Check(wt)
+		if err != nil {
+			logging.Debug("conflict check failed", "branch", wt.Branch, "err", err)
+			return
+		}
+
+		if files != nil {
+			mu.Lock()
+			conflicts[wt.Path] = files
+			mu.Unlock()
+		}
+	})
+
+	return conflicts
+}
+
+// RunConflicts shows which worktree branches would conflict with the default
+// branch, and on which files. With a branch name, only that worktree is checked.
+func RunConflicts(branch string) error {
+	repo, err := git.NewRepository()
+	if err != nil {
+		return fmt.Errorf("error: %w", err)
+	}
+
+	worktrees, err := repo.ListWorktrees()
+	if err != nil {
+		return fmt.Errorf("error listing worktrees: %w", err)
+	}
+
+	worktrees = repo.FilterOutMainBranch(worktrees)
+
+	if branch != "" {
+		worktrees = filterWorktreesByBranch(worktrees, branch)
+		if len(worktrees) == 0 {
+			return fmt.Errorf("no worktree for branch %s", branch)
+		}
+	}
+
+	checker := newConflictChecker(repo)
+	if checker == nil {
+		return fmt.Errorf("could not determine the default branch to check against")
+	}
+
+	fmt.Printf("Checking worktrees against %s...\n\n", checker.target)
+
+	conflicting := 0
+
+	for _, wt := range worktrees {
+		if wt.IsDetached || wt.Branch == "" {
+			continue
+		}
+
+		files, err := checker.Check(wt)
+
+		switch {
+		case err != nil:
+			fmt.Println(ui.WarningStyle.Render(fmt.Sprintf("⚠ %s: %v", wt.Branch, err)))
+		case files == nil:
+			fmt.Println(ui.SuccessStyle.Render("✓ " + wt.Branch + " merges cleanly"))
+		default:
+			conflicting++
+
+			fmt.Println(ui.WarningStyle.Render(fmt.Sprintf("%s %s conflicts with %s", conflictIndicator, wt.Branch, checker.target)))
+
+			for _, f := range files {
+				fmt.Printf("    %s\n", f)
+			}
+		}
+	}
+
+	if conflicting > 0 {
+		fmt.Printf("\n%d worktree(s) would conflict; rebase or merge %s into them before opening a pull request\n",
+			conflicting, checker.target)
+	}
+
+	return nil
+}
+
+// filterWorktreesByBranch returns the worktrees on branch
+func filterWorktreesByBranch(worktrees []*git.Worktree, branch string) []*git.Worktree {
+	var matched []*git.Worktree
+
+	for _, wt := range worktrees {
+		if wt.Branch == branch {
+			matched = append(matched, wt)
+		}
+	}
+
+	return matched
+}
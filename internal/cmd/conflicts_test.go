@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kaeawc/auto-worktree/internal/git"
+	"github.com/kaeawc/auto-worktree/internal/state"
+)
+
+func TestConflictCheckerUsesCache(t *testing.T) {
+	executor := git.NewFakeGitExecutor()
+
+	repo, err := git.NewRepositoryFromPathWithDeps("/fake/repo", executor, git.NewFakeFileSystem())
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+
+	store := state.NewStore(filepath.Join(t.TempDir(), "state.db"))
+	checker := &conflictChecker{repo: repo, store: store, target: "origin/main", targetSHA: "bbb"}
+
+	if err := store.Put(state.BucketCache, conflictCacheKey("aaa", "bbb"),
+		cachedConflicts{Conflicts: true, Files: []string{"main.go"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	conflicts := checker.CheckAll([]*git.Worktree{
+		{Path: "/wt/cached", Branch: "cached", HEAD: "aaa"},
+		{Path: "/wt/fresh", Branch: "fresh", HEAD: "ccc"},
+		{Path: "/wt/detached", HEAD: "ddd", IsDetached: true},
+	})
+
+	if want := map[string][]string{"/wt/cached": {"main.go"}}; !reflect.DeepEqual(conflicts, want) {
+		t.Errorf("CheckAll() = %v, want %v", conflicts, want)
+	}
+
+	var merges []string
+
+	for _, args := range executor.Commands {
+		if cmd := strings.Join(args, " "); strings.Contains(cmd, "merge-tree") {
+			merges = append(merges, cmd)
+		}
+	}
+
+	if len(merges) != 1 || !strings.HasSuffix(merges[0], "bbb ccc") {
+		t.Errorf("expected one merge-tree run for the uncached branch, got %v", merges)
+	}
+
+	// The fresh result was cached as clean
+	var cached cachedConflicts
+	if err := store.Get(state.BucketCache, conflictCacheKey("ccc", "bbb"), &cached); err != nil || cached.Conflicts {
+		t.Errorf("expected a cached clean result, got %+v, %v", cached, err)
+	}
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/kaeawc/auto-worktree/internal/ai"
+	"github.com/kaeawc/auto-worktree/internal/session"
+)
+
+// aiConversation identifies the AI tool conversation a session runs, so
+// resuming the worktree continues that conversation and not another one
+type aiConversation struct {
+	// Tool is the ai-tool config key, e.g. "claude"
+	Tool string
+	// ID is the tool's conversation ID; empty when the tool does not expose one
+	ID string
+}
+
+// savedConversation returns the conversation recorded for a session, empty
+// when there is none, for passing to resolveAICommand as the one to resume
+func savedConversation(sessionMgr session.Manager, sessionName string) *aiConversation {
+	conversation := &aiConversation{}
+
+	metadata, err := sessionMgr.LoadSessionMetadata(sessionName)
+	if err == nil && metadata != nil {
+		conversation.Tool, conversation.ID = metadata.AITool, metadata.ConversationID
+	}
+
+	return conversation
+}
+
+// resumeCommand continues the previous conversation by ID when it was held with
+// the same tool, or the tool's latest conversation in the worktree. Sessions
+// recorded before conversation IDs were kept fall back to the tool's own
+// "continue" command when the worktree has AI session files.
+func resumeCommand(tool *ai.Tool, previous *aiConversation, worktreePath, context string) ([]string, aiConversation) {
+	conversation := aiConversation{Tool: tool.ConfigKey}
+	if previous.Tool == tool.ConfigKey {
+		conversation.ID = previous.ID
+	}
+
+	if conversation.ID == "" {
+		conversation.ID = ai.LatestConversationID(tool.ConfigKey, worktreePath)
+	}
+
+	if cmd := tool.ResumeConversationCommand(conversation.ID, context); cmd != nil {
+		fmt.Printf("Resuming %s conversation %s...\n", tool.Name, conversation.ID)
+		return cmd, conversation
+	}
+
+	if ai.HasExistingSession(worktreePath) {
+		fmt.Printf("Resuming %s session...\n", tool.Name)
+		return tool.ResumeCommandWithContext(context), conversation
+	}
+
+	fmt.Println("No conversation found to continue.")
+	fmt.Println("Starting fresh session in worktree...")
+
+	return startCommand(tool, context)
+}
+
+// startCommand starts a new conversation, choosing its ID up front when the tool accepts one
+func startCommand(tool *ai.Tool, context string) ([]string, aiConversation) {
+	conversation := aiConversation{Tool: tool.ConfigKey}
+	if tool.SessionIDFlag != "" {
+		conversation.ID = generateUUID()
+	}
+
+	return tool.StartCommandWithID(conversation.ID, context), conversation
+}
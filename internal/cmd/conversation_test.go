@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kaeawc/auto-worktree/internal/ai"
+)
+
+func TestResumeCommand(t *testing.T) {
+	claude := &ai.Tool{
+		Name:            "Claude Code",
+		ConfigKey:       "claude",
+		Command:         []string{"claude"},
+		ResumeCommand:   []string{"claude", "--continue"},
+		SessionIDFlag:   "--session-id",
+		ResumeIDCommand: []string{"claude", "--resume"},
+	}
+	worktree := t.TempDir()
+
+	cmd, conversation := resumeCommand(claude, &aiConversation{Tool: "claude", ID: "abc"}, worktree, "")
+	if want := []string{"claude", "--resume", "abc"}; !reflect.DeepEqual(cmd, want) {
+		t.Errorf("resumeCommand() = %v, want %v", cmd, want)
+	}
+
+	if conversation != (aiConversation{Tool: "claude", ID: "abc"}) {
+		t.Errorf("resumeCommand() conversation = %+v, want the saved one", conversation)
+	}
+
+	// A conversation held with another tool cannot be resumed, so a new one starts
+	cmd, conversation = resumeCommand(claude, &aiConversation{Tool: "codex", ID: "xyz"}, worktree, "")
+	if len(cmd) != 3 || cmd[1] != "--session-id" || cmd[2] != conversation.ID || conversation.ID == "xyz" {
+		t.Errorf("resumeCommand() = %v (%+v), want a new conversation with a fresh ID", cmd, conversation)
+	}
+}
@@ -0,0 +1,364 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/kaeawc/auto-worktree/internal/ai"
+	"github.com/kaeawc/auto-worktree/internal/git"
+	"github.com/kaeawc/auto-worktree/internal/interrupt"
+	"github.com/kaeawc/auto-worktree/internal/logging"
+	"github.com/kaeawc/auto-worktree/internal/session"
+	"github.com/kaeawc/auto-worktree/internal/ui"
+)
+
+// Choices when a step after `git worktree add` fails
+const (
+	createRollBack = "rollback"
+	createKeep     = "keep"
+)
+
+// Steps of creating a worktree, as the progress view lists them
+const (
+	stepCreateBranch = "Create branch"
+	stepAddWorktree  = "Add worktree"
+	stepCheckoutPR   = "Check out the PR"
+	stepInstall      = "Install dependencies"
+	stepScope        = "Scope to package"
+	stepHooks        = "Run hooks"
+	stepSession      = "Create tmux session"
+)
+
+// createPipeline creates a worktree as a series of steps: creating the
+// branch, git worktree add, environment setup, hooks and the session, shown
+// in a progress view. Each step that changes something records the action
+// that undoes it, so when a later step fails the user can roll back, undoing
+// them newest first, or keep the worktree as it is instead of leaving a
+// half-configured one for cleanup to puzzle over.
+type createPipeline struct {
+	repo   *git.Repository
+	path   string
+	branch string
+	// undo are the compensating actions of the steps done so far
+	undo     []createUndo
+	progress *ui.StepProgress
+}
+
+// createUndo is the compensating action of one step
+type createUndo struct {
+	// what says what is undone, e.g. "remove the worktree"
+	what string
+	run  func(repo *git.Repository) error
+}
+
+// newCreatePipeline starts creating the worktree for branch at worktreePath
+func newCreatePipeline(repo *git.Repository, worktreePath, branch string) *createPipeline {
+	return &createPipeline{repo: repo, path: worktreePath, branch: branch}
+}
+
+// worktreeSteps are the first steps: creating the branch when it starts
+// from base, adding the worktree and, if checkout is set, checking out a PR
+func worktreeSteps(base string, checkout bool) []string {
+	var steps []string
+	if base != "" {
+		steps = append(steps, stepCreateBranch)
+	}
+
+	steps = append(steps, stepAddWorktree)
+	if checkout {
+		steps = append(steps, stepCheckoutPR)
+	}
+
+	return steps
+}
+
+// start shows the progress view of steps, titled with the branch and path.
+// Callers defer stop.
+func (p *createPipeline) start(steps ...string) {
+	p.progress = ui.NewStepProgress(fmt.Sprintf("Creating %s at %s", p.branch, p.path), steps...)
+	p.progress.Start()
+}
+
+// stop ends the progress view, leaving it on screen; prompts and sessions
+// that take over the terminal come after it
+func (p *createPipeline) stop() {
+	if p.progress != nil {
+		p.progress.Stop()
+	}
+}
+
+// step runs one step, showing it running and then done or failed
+func (p *createPipeline) step(name string, run func() error) error {
+	p.progress.Begin(name)
+	err := run()
+	p.progress.Finish(name, err)
+
+	return err
+}
+
+// onUndo records how to undo a step that has just succeeded
+func (p *createPipeline) onUndo(what string, run func(repo *git.Repository) error) {
+	p.undo = append(p.undo, createUndo{what: what, run: run})
+}
+
+// addWorktree creates the branch from base, unless base is empty because it
+// exists, and then the worktree. A branch started from its namesake on origin
+// tracks it.
+func (p *createPipeline) addWorktree(base string) error {
+	if base != "" {
+		create := func() error { return p.repo.CreateBranch(p.branch, base) }
+		if base == "origin/"+p.branch {
+			create = func() error { return p.repo.CreateTrackingBranch(p.branch) }
+		}
+
+		if err := p.step(stepCreateBranch, create); err != nil {
+			return err
+		}
+
+		p.onUndo("delete branch "+p.branch, func(repo *git.Repository) error { return repo.DeleteBranch(p.branch) })
+	}
+
+	if err := p.step(stepAddWorktree, func() error { return p.repo.CreateWorktree(p.path, p.branch) }); err != nil {
+		return p.addFailed(err)
+	}
+
+	p.addedWorktree()
+
+	return p.check()
+}
+
+// addReviewWorktree fetches the pull request's head and adds a
+// review-only worktree at it, detached, with no branch to delete on rollback
+func (p *createPipeline) addReviewWorktree(head git.PullRequestHead) error {
+	err := p.step(stepAddWorktree, func() error {
+		commit, err := p.repo.FetchPullRequestHead(head)
+		if err != nil {
+			return err
+		}
+
+		return p.repo.CreateReviewWorktree(p.path, commit, head.Number)
+	})
+	if err != nil {
+		return p.addFailed(err)
+	}
+
+	p.addedWorktree()
+
+	return p.check()
+}
+
+// addedWorktree records that the worktree was added: undoing it removes it
+func (p *createPipeline) addedWorktree() {
+	p.onUndo("remove the worktree", func(repo *git.Repository) error { return repo.RemoveWorktree(p.path) })
+}
+
+// addFailed handles `git worktree add` failing. git removes what it created
+// unless the failure came from a post-checkout hook, after the worktree was
+// made; that is handled as a later step failing. Otherwise the branch made
+// for the worktree is deleted again. Nothing is treated as added unless git
+// lists a worktree at the path, which callers checked didn't exist beforehand.
+func (p *createPipeline) addFailed(err error) error {
+	if p.worktreeListed() {
+		p.addedWorktree()
+		return p.fail(stepAddWorktree, err)
+	}
+
+	if stopErr := p.check(); stopErr != nil {
+		return stopErr
+	}
+
+	p.rollback()
+
+	return err
+}
+
+// worktreeListed reports whether git lists a worktree at the path
+func (p *createPipeline) worktreeListed() bool {
+	worktrees, err := p.repo.ForCleanup().ListWorktrees()
+	if err != nil {
+		return false
+	}
+
+	return slices.ContainsFunc(worktrees, func(wt *git.Worktree) bool { return samePath(wt.Path, p.path) })
+}
+
+// install sets up the environment, unless auto-install is off
+func (p *createPipeline) install() error {
+	if !git.NewConfig(p.repo.RootPath).GetAutoInstall() {
+		p.progress.Skip(stepInstall, "auto-install is off")
+		return nil
+	}
+
+	if err := p.step(stepInstall, func() error { return setupEnvironment(p.repo, p.path) }); err != nil {
+		return p.fail(stepInstall, err)
+	}
+
+	return p.check()
+}
+
+// runHooks runs the post-worktree hooks
+func (p *createPipeline) runHooks() error {
+	if err := p.step(stepHooks, func() error { return runPostWorktreeHooks(p.path, p.repo.RootPath) }); err != nil {
+		return p.fail(stepHooks, err)
+	}
+
+	return p.check()
+}
+
+// createSession creates the tmux session, unless it is already running,
+// starting tool with aiContext in it; tool is picked before the progress
+// view starts, since picking it can ask
+func (p *createPipeline) createSession(sessionMgr session.Manager, config *git.Config, sessionName, scope string,
+	tool *ai.Tool, aiContext string) (bool, error) {
+	exists, err := sessionMgr.HasSession(sessionName)
+	if err != nil {
+		return false, fmt.Errorf("failed to check session existence: %w", err)
+	}
+
+	if exists {
+		p.progress.Skip(stepSession, sessionName+" is already running")
+		return false, nil
+	}
+
+	err = p.step(stepSession, func() error {
+		var aiCommand []string
+
+		var conversation aiConversation
+
+		if tool != nil {
+			aiCommand, conversation = startCommand(tool, aiContext)
+		}
+
+		return createSessionWithAICommand(sessionMgr, config, sessionName, p.branch, p.path, scope, aiCommand, conversation)
+	})
+	if err != nil {
+		return false, p.fail(stepSession, err)
+	}
+
+	return true, nil
+}
+
+// check stops the pipeline, rolling back, when Ctrl-C was pressed during the
+// last step
+func (p *createPipeline) check() error {
+	if !interrupt.Interrupted() {
+		return nil
+	}
+
+	p.rollback()
+
+	return interrupt.ErrInterrupted
+}
+
+// fail handles a step failing after the worktree was added. After Ctrl-C it
+// rolls back; otherwise the user chooses to roll back or keep the worktree,
+// which is also what happens without an answer.
+func (p *createPipeline) fail(step string, err error) error {
+	if stopErr := p.check(); stopErr != nil {
+		return stopErr
+	}
+
+	p.stop()
+
+	step = strings.ToLower(step[:1]) + step[1:]
+
+	if p.chooseRollback() {
+		p.rollback()
+		return fmt.Errorf("%s failed, so the worktree was rolled back: %w", step, err)
+	}
+
+	fmt.Printf("Kept %s; remove it later with 'auto-worktree remove'\n", p.path)
+
+	return fmt.Errorf("%s failed: %w", step, err)
+}
+
+// chooseRollback asks whether to roll back or keep the worktree
+func (p *createPipeline) chooseRollback() bool {
+	undo := make([]string, 0, len(p.undo))
+	for i := len(p.undo) - 1; i >= 0; i-- {
+		undo = append(undo, p.undo[i].what)
+	}
+
+	items := []ui.MenuItem{
+		ui.NewMenuItem("Keep as is", "Leave the worktree to finish setting up by hand", createKeep),
+		ui.NewMenuItem("Roll back", joinUndo(undo), createRollBack),
+	}
+
+	m, err := ui.Run(ui.NewMenu("Keep the partly created worktree?", items))
+	if err != nil {
+		logging.Debug("no answer to the rollback prompt; keeping the worktree", "err", err)
+		return false
+	}
+
+	menu, ok := m.(ui.MenuModel)
+
+	return ok && menu.Choice() == createRollBack
+}
+
+// rollback runs the compensating actions newest first. They run even after
+// Ctrl-C; one that fails is reported and the rest still run.
+func (p *createPipeline) rollback() {
+	if len(p.undo) == 0 {
+		return
+	}
+
+	p.stop()
+
+	repo := p.repo.ForCleanup()
+	failed := false
+
+	for i := len(p.undo) - 1; i >= 0; i-- {
+		if err := p.undo[i].run(repo); err != nil {
+			logging.Warn("rollback step failed", "step", p.undo[i].what, "err", err)
+			fmt.Printf("%s Could not %s: %v\n", ui.WarningStyle.Render("⚠"), p.undo[i].what, err)
+
+			failed = true
+		}
+	}
+
+	p.undo = nil
+
+	if failed {
+		fmt.Printf("⚠ The partly created worktree may remain at %s; remove it with 'auto-worktree remove'\n", p.path)
+		return
+	}
+
+	fmt.Printf("✓ Rolled back the partly created worktree %s\n", p.path)
+}
+
+// joinUndo describes the rollback, e.g. "Remove the worktree and delete branch x"
+func joinUndo(undo []string) string {
+	desc := ""
+
+	for i, what := range undo {
+		switch {
+		case i == 0:
+			desc = what
+		case i == len(undo)-1:
+			desc += " and " + what
+		default:
+			desc += ", " + what
+		}
+	}
+
+	if desc == "" {
+		return ""
+	}
+
+	return strings.ToUpper(desc[:1]) + desc[1:]
+}
+
+// samePath reports whether two paths name the same directory, resolving
+// symlinks such as macOS's /tmp
+func samePath(a, b string) bool {
+	if filepath.Clean(a) == filepath.Clean(b) {
+		return true
+	}
+
+	resolvedA, errA := filepath.EvalSymlinks(a)
+	resolvedB, errB := filepath.EvalSymlinks(b)
+
+	return errA == nil && errB == nil && resolvedA == resolvedB
+}
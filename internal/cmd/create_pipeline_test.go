@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/kaeawc/auto-worktree/internal/git"
+	"github.com/kaeawc/auto-worktree/internal/ui"
+)
+
+func TestCreatePipelineRollback(t *testing.T) {
+	newRepo := func() (*git.Repository, *git.FakeGitExecutor) {
+		executor := git.NewFakeGitExecutor()
+		executor.SetResponse("worktree list --porcelain",
+			"worktree /fake/repo\nHEAD abc\nbranch refs/heads/main\n\nworktree /wt/feat\nHEAD abc\nbranch refs/heads/feat\n")
+
+		repo, err := git.NewRepositoryFromPathWithDeps("/fake/repo", executor, git.NewFakeFileSystem())
+		if err != nil {
+			t.Fatalf("Failed to create repository: %v", err)
+		}
+
+		return repo, executor
+	}
+
+	ran := func(executor *git.FakeGitExecutor, command string) bool {
+		return slices.ContainsFunc(executor.Commands, func(args []string) bool {
+			return strings.HasSuffix(strings.Join(args, " "), command)
+		})
+	}
+
+	indexOf := func(executor *git.FakeGitExecutor, command string) int {
+		return slices.IndexFunc(executor.Commands, func(args []string) bool {
+			return strings.HasSuffix(strings.Join(args, " "), command)
+		})
+	}
+
+	newPipeline := func(repo *git.Repository, worktreePath string) *createPipeline {
+		p := newCreatePipeline(repo, worktreePath, "feat")
+		p.progress = ui.NewStepProgress("Creating feat", worktreeSteps("main", false)...)
+
+		return p
+	}
+
+	repo, executor := newRepo()
+	p := newPipeline(repo, "/wt/feat")
+
+	if err := p.addWorktree("main"); err != nil {
+		t.Fatalf("addWorktree() error = %v", err)
+	}
+
+	if !ran(executor, "branch feat main") || !ran(executor, "worktree add /wt/feat feat") {
+		t.Errorf("expected the branch created, then the worktree added, ran %v", executor.Commands)
+	}
+
+	p.rollback()
+
+	remove, deleteBranch := indexOf(executor, "worktree remove --force /wt/feat"), indexOf(executor, "branch -D feat")
+	if remove < 0 || deleteBranch < 0 || remove > deleteBranch {
+		t.Errorf("expected the worktree removed, then its new branch deleted, ran %v", executor.Commands)
+	}
+
+	repo, executor = newRepo()
+	p = newPipeline(repo, "/wt/feat")
+
+	if err := p.addWorktree(""); err != nil {
+		t.Fatalf("addWorktree() error = %v", err)
+	}
+
+	p.rollback()
+
+	if !ran(executor, "worktree remove --force /wt/feat") || ran(executor, "branch -D feat") {
+		t.Errorf("expected an existing branch kept, ran %v", executor.Commands)
+	}
+
+	// A branch only on origin is created tracking it
+	repo, executor = newRepo()
+	p = newPipeline(repo, "/wt/feat")
+
+	if err := p.addWorktree("origin/feat"); err != nil {
+		t.Fatalf("addWorktree() error = %v", err)
+	}
+
+	if !ran(executor, "branch --track feat origin/feat") {
+		t.Errorf("expected a branch tracking origin/feat, ran %v", executor.Commands)
+	}
+
+	// A worktree git doesn't list at the path, e.g. when add failed, is left
+	// alone; only the branch made for it is deleted
+	repo, executor = newRepo()
+	p = newPipeline(repo, "/wt/other")
+	p.onUndo("delete branch feat", func(repo *git.Repository) error { return repo.DeleteBranch("feat") })
+
+	addErr := errors.New("fatal: could not create work tree dir")
+
+	if err := p.addFailed(addErr); !errors.Is(err, addErr) {
+		t.Errorf("addFailed() = %v, want %v", err, addErr)
+	}
+
+	if ran(executor, "worktree remove --force /wt/other") || !ran(executor, "branch -D feat") {
+		t.Errorf("expected only the branch deleted, ran %v", executor.Commands)
+	}
+}
+
+func TestWorktreeBaseRemoteBranch(t *testing.T) {
+	executor := git.NewFakeGitExecutor()
+	executor.SetResponse("symbolic-ref refs/remotes/origin/HEAD", "refs/remotes/origin/main")
+	executor.SetError("show-ref --verify --quiet refs/heads/feat", errors.New("exit status 1"))
+	executor.SetError("show-ref --verify --quiet refs/heads/gone", errors.New("exit status 1"))
+	executor.SetError("show-ref --verify --quiet refs/remotes/origin/gone", errors.New("exit status 1"))
+
+	repo, err := git.NewRepositoryFromPathWithDeps("/fake/repo", executor, git.NewFakeFileSystem())
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+
+	repo.Config = git.NewConfigWithExecutor("/fake/repo", executor)
+
+	if base, err := worktreeBase(repo, "feat", true); err != nil || base != "origin/feat" {
+		t.Errorf("worktreeBase(existing feat) = %q, %v, want origin/feat for a branch only on origin", base, err)
+	}
+
+	if _, err := worktreeBase(repo, "feat", false); err == nil || !strings.Contains(err.Error(), "--existing") {
+		t.Errorf("worktreeBase(new feat) error = %v, want a hint to use --existing", err)
+	}
+
+	if _, err := worktreeBase(repo, "gone", true); err == nil {
+		t.Error("worktreeBase(existing gone) should fail for a branch that is nowhere")
+	}
+
+	if base, err := worktreeBase(repo, "gone", false); err != nil || base != "main" {
+		t.Errorf("worktreeBase(new gone) = %q, %v, want main", base, err)
+	}
+}
+
+func TestJoinUndo(t *testing.T) {
+	tests := []struct {
+		undo []string
+		want string
+	}{
+		{nil, ""},
+		{[]string{"remove the worktree"}, "Remove the worktree"},
+		{[]string{"remove the worktree", "delete branch feat"}, "Remove the worktree and delete branch feat"},
+		{[]string{"stop the session", "remove the worktree", "delete branch feat"}, "Stop the session, remove the worktree and delete branch feat"},
+	}
+
+	for _, tt := range tests {
+		if got := joinUndo(tt.undo); got != tt.want {
+			t.Errorf("joinUndo(%q) = %q, want %q", tt.undo, got, tt.want)
+		}
+	}
+}
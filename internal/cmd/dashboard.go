@@ -0,0 +1,183 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kaeawc/auto-worktree/internal/git"
+	"github.com/kaeawc/auto-worktree/internal/github"
+	"github.com/kaeawc/auto-worktree/internal/providers"
+	"github.com/kaeawc/auto-worktree/internal/session"
+	"github.com/kaeawc/auto-worktree/internal/ui"
+)
+
+// DefaultDashboardInterval is how often the dashboard refreshes unless --interval is given
+const DefaultDashboardInterval = 30 * time.Second
+
+// dashboardPRLimit caps how many open PRs are fetched to match worktree branches
+const dashboardPRLimit = 100
+
+// RunDashboard shows a full-screen view of every worktree with actions on the selected row.
+// Attaching leaves the dashboard; cleanup and opening a PR return to it.
+func RunDashboard(interval time.Duration) error {
+	repo, err := git.NewRepository()
+	if err != nil {
+		return fmt.Errorf("error: %w", err)
+	}
+
+	// Provider and GitHub client are optional; the dashboard degrades to git-only data
+	prov, _ := GetProviderForRepository(repo) //nolint:errcheck
+
+	var client *github.Client
+	if resolveCodeHostType(repo.Config) == providerGitHub {
+		client, _ = github.NewClient(repo.RootPath) //nolint:errcheck
+	}
+
+	sessionMgr := session.NewManager()
+
+	for {
+		dashboard := ui.NewDashboard(repo, func() ([]ui.DashboardRow, error) {
+			return loadDashboardRows(repo, prov, client, sessionMgr)
+		}, interval)
+
+		if _, err := ui.Run(dashboard, tea.WithAltScreen()); err != nil {
+			return fmt.Errorf("failed to run dashboard: %w", err)
+		}
+
+		row := dashboard.Selected()
+		if dashboard.Action() == "" || row == nil {
+			return nil
+		}
+
+		switch dashboard.Action() {
+		case ui.DashboardActionAttach:
+			wt, err := findWorktreeByPath(repo, row.Path)
+			if err != nil {
+				return err
+			}
+
+			hasSession, _ := sessionMgr.HasSession(session.GenerateSessionName(wt.Branch)) //nolint:errcheck
+
+			return resumeWorktree(repo, sessionMgr, wt, hasSession, "")
+
+		case ui.DashboardActionCleanup:
+			wt, err := findWorktreeByPath(repo, row.Path)
+			if err != nil {
+				return err
+			}
+
+			if err := promptForCleanup(repo, []*git.Worktree{wt}); err != nil {
+				return err
+			}
+
+		case ui.DashboardActionOpenPR:
+			if client == nil {
+				fmt.Println(ui.WarningStyle.Render("Opening PRs needs the gh CLI and a GitHub repository"))
+				continue
+			}
+
+			if err := client.OpenPRInBrowser(row.PRNumber); err != nil {
+				fmt.Printf("⚠ %v\n", err)
+			}
+		}
+	}
+}
+
+// loadDashboardRows gathers branch, issue/PR, CI, session, and change state for every worktree
+func loadDashboardRows(repo *git.Repository, prov providers.Provider, client *github.Client,
+	sessionMgr *session.SessionManager) ([]ui.DashboardRow, error) {
+	worktrees, err := repo.ListWorktreesWithAllStatusExcludingMain(prov)
+	if err != nil {
+		return nil, fmt.Errorf("error listing worktrees: %w", err)
+	}
+
+	metadataByPath := make(map[string]*session.Metadata)
+	if allMetadata, err := sessionMgr.LoadAllSessionMetadata(); err == nil {
+		for _, metadata := range allMetadata {
+			metadataByPath[metadata.WorktreePath] = metadata
+		}
+	}
+
+	prsByBranch := make(map[string]github.PullRequest)
+	if client != nil {
+		if prs, err := client.ListOpenPRs(dashboardPRLimit); err == nil {
+			for _, pr := range prs {
+				prsByBranch[pr.HeadRefName] = pr
+			}
+		}
+	}
+
+	rows := make([]ui.DashboardRow, 0, len(worktrees))
+
+	for _, wt := range worktrees {
+		row := ui.DashboardRow{
+			Path:         wt.Path,
+			Branch:       wt.Branch,
+			IssueState:   getStatusIndicator(wt),
+			CIState:      github.CIStateNone,
+			SessionState: "-",
+			Unpushed:     wt.UnpushedCount,
+		}
+
+		if wt.IsDetached && len(wt.HEAD) >= 7 {
+			row.Branch = fmt.Sprintf("(detached @ %s)", wt.HEAD[:7])
+		}
+
+		if pr, ok := prsByBranch[wt.Branch]; ok {
+			row.PRNumber = pr.Number
+			row.CIState = pr.CIState()
+		}
+
+		row.Column = dashboardColumn(wt, row.PRNumber)
+
+		if metadata, ok := metadataByPath[wt.Path]; ok {
+			row.SessionState = getSessionStatusIndicator(metadata)
+		}
+
+		if dirty, err := repo.HasUncommittedChanges(wt.Path); err == nil {
+			row.Dirty = dirty
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// dashboardColumn places a worktree on the dashboard board: finished work is ready for
+// cleanup, an open PR needs review, old untouched work is stale, and the rest is in progress.
+// A fresh branch with no commits also looks "merged" to git, so it stays in progress,
+// as do protected branches, which are never cleaned up.
+func dashboardColumn(wt *git.Worktree, prNumber int) string {
+	switch {
+	case wt.IsProtected:
+		return ui.ColumnInProgress
+	case wt.IssueStatus != nil && (wt.IssueStatus.IsCompleted || wt.IssueStatus.IsClosed),
+		wt.IsBranchMerged && !wt.HasNoChanges:
+		return ui.ColumnCleanup
+	case prNumber > 0:
+		return ui.ColumnNeedsReview
+	case wt.IsStale():
+		return ui.ColumnStale
+	default:
+		return ui.ColumnInProgress
+	}
+}
+
+// findWorktreeByPath returns the worktree checked out at path
+func findWorktreeByPath(repo *git.Repository, path string) (*git.Worktree, error) {
+	worktrees, err := repo.ListWorktreesWithMergeStatusExcludingMain()
+	if err != nil {
+		return nil, fmt.Errorf("error listing worktrees: %w", err)
+	}
+
+	for _, wt := range worktrees {
+		if wt.Path == path {
+			return wt, nil
+		}
+	}
+
+	return nil, fmt.Errorf("worktree not found: %s", path)
+}
@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kaeawc/auto-worktree/internal/git"
+	"github.com/kaeawc/auto-worktree/internal/ui"
+)
+
+func TestDashboardColumn(t *testing.T) {
+	recent := time.Now()
+	old := time.Now().Add(-10 * 24 * time.Hour)
+
+	tests := []struct {
+		name     string
+		wt       *git.Worktree
+		prNumber int
+		want     string
+	}{
+		{"active work", &git.Worktree{LastCommitTime: recent}, 0, ui.ColumnInProgress},
+		{"fresh branch with no commits", &git.Worktree{LastCommitTime: recent, IsBranchMerged: true, HasNoChanges: true}, 0, ui.ColumnInProgress},
+		{"open PR", &git.Worktree{LastCommitTime: recent}, 12, ui.ColumnNeedsReview},
+		{"merged branch", &git.Worktree{LastCommitTime: recent, IsBranchMerged: true}, 0, ui.ColumnCleanup},
+		{"closed issue with open PR", &git.Worktree{LastCommitTime: recent, IssueStatus: &git.IssueStatus{IsClosed: true}}, 12, ui.ColumnCleanup},
+		{"old untouched work", &git.Worktree{LastCommitTime: old}, 0, ui.ColumnStale},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := dashboardColumn(tt.wt, tt.prNumber); got != tt.want {
+				t.Errorf("dashboardColumn() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
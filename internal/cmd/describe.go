@@ -0,0 +1,245 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/kaeawc/auto-worktree/internal/ai"
+	"github.com/kaeawc/auto-worktree/internal/git"
+	"github.com/kaeawc/auto-worktree/internal/github"
+)
+
+// Describe modes
+const (
+	DescribeAuto   = ""
+	DescribeCommit = "commit"
+	DescribePR     = "pr"
+)
+
+// maxDescribeDiffBytes caps how much diff is sent to the AI tool unless
+// auto-worktree.ai-diff-limit says otherwise
+const maxDescribeDiffBytes = 100_000
+
+// DescribeOptions configures `auto-worktree describe`
+type DescribeOptions struct {
+	// Mode is DescribeCommit, DescribePR, or DescribeAuto (commit if changes are staged, PR otherwise)
+	Mode string
+	// Apply commits with the generated message, or updates the open PR (opening one if there is none)
+	Apply bool
+}
+
+// RunDescribe generates a commit message or PR title/body from the current worktree's diff
+func RunDescribe(opts DescribeOptions) error {
+	repo, err := git.NewRepository()
+	if err != nil {
+		return fmt.Errorf("error: %w", err)
+	}
+
+	worktreePath := repo.RootPath
+	if repo.InvokedFromWorktree != "" {
+		worktreePath = repo.InvokedFromWorktree
+	}
+
+	tool, err := ai.NewResolver(repo.Config).Resolve()
+	if err != nil {
+		return fmt.Errorf("describe needs an AI tool: %w", err)
+	}
+
+	mode := opts.Mode
+	staged := ""
+
+	if mode != DescribePR {
+		staged, err = repo.StagedDiff(worktreePath)
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case strings.TrimSpace(staged) != "":
+			mode = DescribeCommit
+		case mode == DescribeCommit:
+			return fmt.Errorf("no staged changes to describe (stage changes with git add, or use --pr)")
+		default:
+			mode = DescribePR
+		}
+	}
+
+	if mode == DescribeCommit {
+		return describeCommit(repo, tool, worktreePath, staged, opts.Apply)
+	}
+
+	return describePR(repo, tool, worktreePath, opts.Apply)
+}
+
+// describeCommit generates a commit message for the staged diff and optionally commits
+func describeCommit(repo *git.Repository, tool *ai.Tool, worktreePath, diff string, apply bool) error {
+	prompt := buildCommitMessagePrompt(truncateDiff(diff, repo.Config.GetAIDiffLimit(maxDescribeDiffBytes)))
+
+	if ok, err := confirmAIContext(repo.Config, tool, "the staged diff", prompt); err != nil || !ok {
+		return err
+	}
+
+	fmt.Printf("Generating commit message with %s...\n\n", tool.Name)
+
+	output, err := tool.ExecutePrompt(prompt)
+	if err != nil {
+		return fmt.Errorf("failed to generate commit message: %w", err)
+	}
+
+	message := strings.TrimSpace(stripCodeFence(strings.TrimSpace(output)))
+	if message == "" {
+		return fmt.Errorf("AI tool returned an empty commit message")
+	}
+
+	fmt.Println(message)
+
+	if !apply {
+		return nil
+	}
+
+	if err := repo.Commit(worktreePath, message); err != nil {
+		return err
+	}
+
+	fmt.Printf("\n✓ Committed staged changes\n")
+
+	return nil
+}
+
+// describePR generates a PR title and body for the branch and optionally updates the open PR
+func describePR(repo *git.Repository, tool *ai.Tool, worktreePath string, apply bool) error {
+	branch, err := git.GetCurrentBranchInWorktree(worktreePath)
+	if err != nil || branch == "" {
+		return fmt.Errorf("describe --pr needs a branch checked out in %s", worktreePath)
+	}
+
+	baseBranch, err := repo.GetDefaultBranch()
+	if err != nil {
+		return fmt.Errorf("error getting default branch: %w", err)
+	}
+
+	draft, err := generatePRDraft(repo, tool, worktreePath, branch, baseBranch)
+	if err != nil || draft.Title == "" {
+		return err
+	}
+
+	fmt.Printf("Title: %s\n\n%s\n", draft.Title, draft.Body)
+
+	if !apply {
+		return nil
+	}
+
+	return applyPRDescription(repo, worktreePath, branch, baseBranch, draft)
+}
+
+// generatePRDraft asks the AI tool for a PR title and body for the branch's
+// changes since baseBranch; the draft is empty if the user declines to send them
+func generatePRDraft(repo *git.Repository, tool *ai.Tool, worktreePath, branch, baseBranch string) (titledDraft, error) {
+	diff, err := repo.BranchDiff(worktreePath, baseBranch)
+	if err != nil {
+		return titledDraft{}, err
+	}
+
+	if strings.TrimSpace(diff) == "" {
+		return titledDraft{}, fmt.Errorf("no changes on %s since %s", branch, baseBranch)
+	}
+
+	commits, err := repo.BranchLog(worktreePath, baseBranch)
+	if err != nil {
+		return titledDraft{}, err
+	}
+
+	prompt := buildPRDescriptionPrompt(branch, commits, truncateDiff(diff, repo.Config.GetAIDiffLimit(maxDescribeDiffBytes)))
+
+	if ok, err := confirmAIContext(repo.Config, tool, "the branch diff", prompt); err != nil || !ok {
+		return titledDraft{}, err
+	}
+
+	fmt.Printf("Generating PR description with %s...\n\n", tool.Name)
+
+	output, err := tool.ExecutePrompt(prompt)
+	if err != nil {
+		return titledDraft{}, fmt.Errorf("failed to generate PR description: %w", err)
+	}
+
+	draft := parseTitledDraft(output)
+	if draft.Title == "" {
+		return titledDraft{}, fmt.Errorf("AI tool did not return a PR title")
+	}
+
+	return draft, nil
+}
+
+// applyPRDescription updates the open GitHub PR for branch with the generated
+// title and body, or pushes the branch and opens one when there is none
+func applyPRDescription(repo *git.Repository, worktreePath, branch, baseBranch string, draft titledDraft) error {
+	if codeHost := resolveCodeHostType(repo.Config); codeHost != providerGitHub {
+		return fmt.Errorf("updating PRs is only supported for GitHub (code host: %s)", codeHost)
+	}
+
+	client, err := github.NewClient(repo.RootPath)
+	if err != nil {
+		if errors.Is(err, github.ErrGHNotAuthenticated) {
+			return fmt.Errorf("gh CLI is not authenticated. Run: gh auth login")
+		}
+
+		return fmt.Errorf("failed to initialize GitHub client: %w", err)
+	}
+
+	pr, err := client.FindPRForBranch(branch)
+	if err != nil {
+		return err
+	}
+
+	if pr == nil {
+		opts := pullRequestOptions(repo, worktreePath, branch, baseBranch, draft)
+		return openPullRequest(repo, newGitHubProviderFromClient(client), worktreePath, opts)
+	}
+
+	if err := client.EditPR(pr.Number, draft.Title, draft.Body); err != nil {
+		return err
+	}
+
+	fmt.Printf("\n✓ Updated PR #%d: %s\n", pr.Number, pr.URL)
+
+	return nil
+}
+
+// buildCommitMessagePrompt builds the prompt asking for a commit message for a staged diff
+func buildCommitMessagePrompt(diff string) string {
+	var sb strings.Builder
+
+	sb.WriteString("Write a git commit message for the following staged diff.\n")
+	sb.WriteString("Use an imperative subject line under 72 characters, a blank line, ")
+	sb.WriteString("then a short body explaining what changed and why (omit the body for trivial changes).\n")
+	sb.WriteString("Return ONLY the commit message, nothing else.\n\n")
+	sb.WriteString("Diff:\n")
+	sb.WriteString(diff)
+
+	return sb.String()
+}
+
+// buildPRDescriptionPrompt builds the prompt asking for a PR title and body for a branch
+func buildPRDescriptionPrompt(branch, commits, diff string) string {
+	var sb strings.Builder
+
+	sb.WriteString("Write a pull request title and description for the following changes.\n")
+	sb.WriteString(fmt.Sprintf("Branch: %s\n\n", branch))
+
+	if strings.TrimSpace(commits) != "" {
+		sb.WriteString("Commits:\n")
+		sb.WriteString(commits)
+		sb.WriteString("\n\n")
+	}
+
+	sb.WriteString("The body should be markdown with a ## Summary section and a ## Testing section.\n")
+	sb.WriteString("Respond in exactly this format, with nothing before or after:\n")
+	sb.WriteString("TITLE: <concise PR title>\n")
+	sb.WriteString("BODY:\n")
+	sb.WriteString("<markdown body>\n\n")
+	sb.WriteString("Diff:\n")
+	sb.WriteString(diff)
+
+	return sb.String()
+}
@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTruncateDiff(t *testing.T) {
+	if got := truncateDiff("short", 10); got != "short" {
+		t.Errorf("truncateDiff(short) = %q", got)
+	}
+
+	got := truncateDiff(strings.Repeat("x", 20), 10)
+	if !strings.HasPrefix(got, strings.Repeat("x", 10)+"\n") || !strings.Contains(got, "truncated") {
+		t.Errorf("truncateDiff(long) = %q", got)
+	}
+}
+
+func TestBuildPRDescriptionPrompt(t *testing.T) {
+	prompt := buildPRDescriptionPrompt("work/42-fix", "abc123 Fix login", "diff --git a/x b/x")
+
+	for _, want := range []string{"work/42-fix", "abc123 Fix login", "diff --git a/x b/x", "TITLE:"} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("prompt missing %q:\n%s", want, prompt)
+		}
+	}
+
+	if strings.Contains(buildPRDescriptionPrompt("b", "", "d"), "Commits:") {
+		t.Error("prompt should omit the commit list when there are no commits")
+	}
+}
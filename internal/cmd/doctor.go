@@ -0,0 +1,317 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/kaeawc/auto-worktree/internal/ai"
+	"github.com/kaeawc/auto-worktree/internal/git"
+	"github.com/kaeawc/auto-worktree/internal/logging"
+	"github.com/kaeawc/auto-worktree/internal/ui"
+	"github.com/kaeawc/auto-worktree/internal/update"
+)
+
+// MinGitVersion is the oldest git with every worktree command auto-worktree uses (`worktree repair`)
+const MinGitVersion = "2.30.0"
+
+// ErrDoctorFailed is returned by RunDoctor when at least one check fails, so CI can fail the job
+var ErrDoctorFailed = errors.New("doctor found problems")
+
+// doctorStatus is the outcome of one doctor check
+type doctorStatus int
+
+const (
+	doctorOK doctorStatus = iota
+	// doctorWarn marks an optional tool or setting that reduces functionality
+	doctorWarn
+	// doctorFail marks a problem that breaks a configured workflow
+	doctorFail
+)
+
+// doctorCheck is one line of doctor output with an actionable fix when it did not pass
+type doctorCheck struct {
+	Name   string
+	Status doctorStatus
+	Detail string
+	Fix    string
+}
+
+// toolVersion runs a tool's version command; replaced in tests
+var toolVersion = func(name string, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, name, args...)
+	start := time.Now()
+	output, err := cmd.Output()
+	logging.Command(cmd, start, output, err)
+
+	return strings.TrimSpace(string(output)), err
+}
+
+// providerAuth verifies a provider CLI is installed and signed in; replaced in tests
+var providerAuth = checkProviderAuth
+
+// runDoctorChecks runs every tool and configuration check for the repository
+func runDoctorChecks(repo *git.Repository) []doctorCheck {
+	cfg := repo.Config
+	origin, _ := cfg.Get("remote.origin.url", git.ConfigScopeLocal) //nolint:errcheck // a missing remote just disables detection
+	hooksPath, _ := cfg.Get("core.hooksPath", git.ConfigScopeAuto)  //nolint:errcheck // unset is the common case
+
+	checks := []doctorCheck{checkGitVersion(), checkTmux()}
+	checks = append(checks, checkProviderCLIs(cfg, origin)...)
+	checks = append(checks,
+		checkAITools(cfg.GetAITool(), ai.NewResolver(cfg).ListAvailable()),
+		checkWorktreeBase(repo.WorktreeBase),
+		checkHooksPath(hooksPath, repo.RootPath),
+	)
+
+	return checks
+}
+
+// checkGitVersion fails when git is older than MinGitVersion
+func checkGitVersion() doctorCheck {
+	output, err := toolVersion("git", "--version")
+	if err != nil {
+		return doctorCheck{Name: "git", Status: doctorFail, Detail: "not found", Fix: "install git " + MinGitVersion + " or newer"}
+	}
+
+	version := parseToolVersion(output, "git version ")
+	if update.IsNewer(MinGitVersion, version) {
+		return doctorCheck{
+			Name:   "git",
+			Status: doctorFail,
+			Detail: fmt.Sprintf("%s is older than %s", version, MinGitVersion),
+			Fix:    "upgrade git to " + MinGitVersion + " or newer",
+		}
+	}
+
+	return doctorCheck{Name: "git", Status: doctorOK, Detail: version}
+}
+
+// checkTmux warns when tmux is missing; sessions then run in the foreground
+func checkTmux() doctorCheck {
+	output, err := toolVersion("tmux", "-V")
+	if err != nil {
+		_, installCmd := getTmuxInstallInstructions()
+		return doctorCheck{
+			Name:   "tmux",
+			Status: doctorWarn,
+			Detail: "not found; AI tools run in the foreground",
+			Fix:    strings.ReplaceAll(installCmd, "\n", " "),
+		}
+	}
+
+	return doctorCheck{Name: "tmux", Status: doctorOK, Detail: parseToolVersion(output, "tmux ")}
+}
+
+// parseToolVersion strips prefix from a version line and drops trailing build details
+func parseToolVersion(output, prefix string) string {
+	line, _, _ := strings.Cut(output, "\n")
+	version := strings.TrimPrefix(strings.TrimSpace(line), prefix)
+
+	if fields := strings.Fields(version); len(fields) > 0 {
+		return fields[0]
+	}
+
+	return version
+}
+
+// requiredProviders returns the providers this repository's workflows depend on:
+// the configured issue provider and code host, or the host detected from origin
+func requiredProviders(cfg *git.Config, origin string) map[string]bool {
+	required := map[string]bool{}
+
+	if issueProvider := cfg.GetIssueProvider(); issueProvider != "" {
+		required[issueProvider] = true
+	}
+
+	codeHost := cfg.GetCodeHost()
+	if codeHost == "" && len(required) == 0 {
+		codeHost = detectProviderFromURL(origin)
+	}
+
+	if codeHost != "" {
+		required[codeHost] = true
+	}
+
+	return required
+}
+
+// checkProviderCLIs verifies each provider CLI. Missing or signed-out CLIs fail only
+// when the repository uses that provider; others are reported for information.
+func checkProviderCLIs(cfg *git.Config, origin string) []doctorCheck {
+	required := requiredProviders(cfg, origin)
+
+	var checks []doctorCheck
+
+	for _, provider := range []string{providerGitHub, providerGitLab, providerJira, providerLinear} {
+		cli := providerCLIs[provider]
+
+		if !required[provider] && !hasProviderCLI(provider) {
+			checks = append(checks, doctorCheck{Name: cli, Status: doctorOK, Detail: "not installed (not used here)"})
+			continue
+		}
+
+		err := providerAuth(provider)
+		if err == nil {
+			checks = append(checks, doctorCheck{Name: cli, Status: doctorOK, Detail: "installed and signed in"})
+			continue
+		}
+
+		status := doctorWarn
+		if required[provider] {
+			status = doctorFail
+		}
+
+		detail, fix, _ := strings.Cut(strings.TrimSpace(err.Error()), "\n")
+		checks = append(checks, doctorCheck{Name: cli, Status: status, Detail: detail, Fix: strings.TrimSpace(fix)})
+	}
+
+	return checks
+}
+
+// checkAITools fails when the configured AI tool is missing and warns when none is installed
+func checkAITools(configured string, available []ai.Tool) doctorCheck {
+	names := make([]string, 0, len(available))
+	for _, tool := range available {
+		names = append(names, tool.Name)
+	}
+
+	const fix = "install claude, codex, gemini, or jules"
+
+	if configured != "" && configured != aiToolSkip {
+		for _, tool := range available {
+			if tool.ConfigKey == configured {
+				return doctorCheck{Name: "AI tool", Status: doctorOK, Detail: tool.Name + " (configured)"}
+			}
+		}
+
+		return doctorCheck{
+			Name:   "AI tool",
+			Status: doctorFail,
+			Detail: fmt.Sprintf("configured tool %q is not installed", configured),
+			Fix:    "install it or run: auto-worktree settings set ai-tool <tool>",
+		}
+	}
+
+	if len(names) == 0 {
+		return doctorCheck{Name: "AI tool", Status: doctorWarn, Detail: "none installed; worktrees open with a plain shell", Fix: fix}
+	}
+
+	return doctorCheck{Name: "AI tool", Status: doctorOK, Detail: strings.Join(names, ", ")}
+}
+
+// checkWorktreeBase fails when new worktrees could not be created under base
+func checkWorktreeBase(base string) doctorCheck {
+	// The base is created on demand, so check the nearest existing ancestor
+	dir := base
+	for {
+		info, err := os.Stat(dir)
+		if err == nil {
+			if !info.IsDir() {
+				return doctorCheck{
+					Name:   "Worktree base",
+					Status: doctorFail,
+					Detail: dir + " is not a directory",
+					Fix:    "move it aside or set auto-worktree.worktree-base",
+				}
+			}
+
+			break
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+
+		dir = parent
+	}
+
+	probe, err := os.CreateTemp(dir, ".auto-worktree-doctor-*")
+	if err != nil {
+		return doctorCheck{
+			Name:   "Worktree base",
+			Status: doctorFail,
+			Detail: fmt.Sprintf("%s is not writable", dir),
+			Fix:    "fix its permissions or set auto-worktree.worktree-base to a writable directory",
+		}
+	}
+
+	_ = probe.Close()           //nolint:errcheck // empty probe file
+	_ = os.Remove(probe.Name()) //nolint:errcheck // best effort
+
+	return doctorCheck{Name: "Worktree base", Status: doctorOK, Detail: base}
+}
+
+// checkHooksPath fails when core.hooksPath points somewhere hooks cannot run from
+func checkHooksPath(hooksPath, rootPath string) doctorCheck {
+	if hooksPath == "" {
+		return doctorCheck{Name: "core.hooksPath", Status: doctorOK, Detail: "not set"}
+	}
+
+	resolved := hooksPath
+	if !filepath.IsAbs(resolved) {
+		resolved = filepath.Join(rootPath, resolved)
+	}
+
+	const fix = "create the directory or run: git config --unset core.hooksPath"
+
+	info, err := os.Stat(resolved)
+
+	switch {
+	case err != nil:
+		return doctorCheck{
+			Name:   "core.hooksPath",
+			Status: doctorFail,
+			Detail: fmt.Sprintf("%s does not exist, so no hooks run", hooksPath),
+			Fix:    fix,
+		}
+	case !info.IsDir():
+		return doctorCheck{Name: "core.hooksPath", Status: doctorFail, Detail: hooksPath + " is not a directory", Fix: fix}
+	default:
+		return doctorCheck{Name: "core.hooksPath", Status: doctorOK, Detail: hooksPath}
+	}
+}
+
+// printDoctorChecks prints a section of checks with their fixes and reports whether any failed
+func printDoctorChecks(title string, checks []doctorCheck) (failed bool) {
+	fmt.Printf("🔍 %s...\n", title)
+
+	for _, c := range checks {
+		mark := ui.SuccessStyle.Render("✓")
+
+		switch c.Status {
+		case doctorWarn:
+			mark = ui.WarningStyle.Render("⚠")
+		case doctorFail:
+			mark = ui.ErrorStyle.Render("✗")
+			failed = true
+		}
+
+		fmt.Printf("  %s %-16s %s\n", mark, c.Name, c.Detail)
+
+		if c.Status == doctorOK || c.Fix == "" {
+			continue
+		}
+
+		prefix := "Fix: "
+		for _, line := range strings.Split(c.Fix, "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				fmt.Printf("    %s\n", ui.SubtleStyle.Render(prefix+line))
+				prefix = "     "
+			}
+		}
+	}
+
+	fmt.Println()
+
+	return failed
+}
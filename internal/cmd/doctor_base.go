@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/kaeawc/auto-worktree/internal/git"
+	"github.com/kaeawc/auto-worktree/internal/ui"
+)
+
+// baseIssueChecks turns worktree base issues into doctor checks. Entries that
+// cannot be read or changed fail, since removing or updating the worktree
+// would; the rest are clutter and warn.
+func baseIssueChecks(base string, issues []git.BaseIssue) []doctorCheck {
+	if len(issues) == 0 {
+		return []doctorCheck{{Name: "Worktree base", Status: doctorOK, Detail: "every entry is a registered worktree"}}
+	}
+
+	checks := make([]doctorCheck, 0, len(issues))
+
+	for _, issue := range issues {
+		name, err := filepath.Rel(base, issue.Path)
+		if err != nil || name == "." {
+			name = issue.Path
+		}
+
+		check := doctorCheck{
+			Name:   name,
+			Status: doctorWarn,
+			Detail: fmt.Sprintf("%s: %s", issue.Kind, issue.Detail),
+		}
+
+		switch {
+		case issue.Kind == git.BaseIssuePermission:
+			check.Status = doctorFail
+			check.Fix = "chmod -R u+rwX " + issue.Path
+		case issue.Kind == git.BaseIssueCaseConflict:
+			check.Fix = "rename or remove one of them; they are the same directory on case-insensitive filesystems"
+		case issue.Adoptable:
+			check.Fix = "register it again with: auto-worktree doctor --repair-base"
+		case issue.Kind == git.BaseIssueUnregistered:
+			check.Fix = "delete it with: auto-worktree doctor --repair-base (asks first)"
+		default:
+			check.Fix = "delete it with: auto-worktree doctor --repair-base"
+		}
+
+		checks = append(checks, check)
+	}
+
+	return checks
+}
+
+// repairWorktreeBase adopts worktrees git lost track of and deletes leftover
+// trash and broken symlinks. Unregistered directories may hold work, so they
+// are only deleted after confirmation or with yes. Case conflicts and
+// permissions need a person to decide and are left alone.
+func repairWorktreeBase(repo *git.Repository, issues []git.BaseIssue, yes bool) {
+	var confirm []git.BaseIssue
+
+	for _, issue := range issues {
+		switch {
+		case issue.Adoptable:
+			reportBaseRepair(repo.AdoptWorktree(issue.Path), "Adopted "+issue.Path)
+		case issue.Kind == git.BaseIssueUnregistered:
+			confirm = append(confirm, issue)
+		case issue.Deletable():
+			reportBaseRepair(git.RemoveBaseEntry(issue.Path), "Removed "+issue.Path)
+		}
+	}
+
+	if len(confirm) == 0 {
+		return
+	}
+
+	if !yes {
+		fmt.Printf("\n⚠️  Not worktrees, so they may hold work (%d):\n", len(confirm))
+
+		for _, issue := range confirm {
+			fmt.Printf("   - %s\n", issue.Path)
+		}
+
+		fmt.Print("\nDelete them? (y/N): ")
+
+		var response string
+		_, _ = fmt.Scanln(&response) //nolint:errcheck
+
+		if strings.ToLower(strings.TrimSpace(response)) != "y" {
+			fmt.Println(ui.SubtleStyle.Render("Kept them"))
+			return
+		}
+	}
+
+	for _, issue := range confirm {
+		reportBaseRepair(git.RemoveBaseEntry(issue.Path), "Removed "+issue.Path)
+	}
+}
+
+// reportBaseRepair prints the outcome of one worktree base repair
+func reportBaseRepair(err error, done string) {
+	if err != nil {
+		fmt.Printf("  %s %v\n", ui.ErrorStyle.Render("✗"), err)
+		return
+	}
+
+	fmt.Printf("  %s %s\n", ui.SuccessStyle.Render("✓"), done)
+}
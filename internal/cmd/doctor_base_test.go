@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kaeawc/auto-worktree/internal/git"
+)
+
+func TestBaseIssueChecks(t *testing.T) {
+	if checks := baseIssueChecks("/wt", nil); len(checks) != 1 || checks[0].Status != doctorOK {
+		t.Errorf("baseIssueChecks(none) = %+v, want one ok check", checks)
+	}
+
+	checks := baseIssueChecks("/wt", []git.BaseIssue{
+		{Kind: git.BaseIssueUnregistered, Path: "/wt/lost", Detail: "lost", Adoptable: true},
+		{Kind: git.BaseIssueUnregistered, Path: "/wt/stray", Detail: "stray"},
+		{Kind: git.BaseIssuePermission, Path: "/wt/locked", Detail: "cannot be read"},
+		{Kind: git.BaseIssueCaseConflict, Path: "/wt/Feature", Detail: "differs from feature only in case"},
+	})
+
+	if len(checks) != 4 {
+		t.Fatalf("baseIssueChecks() = %+v, want 4 checks", checks)
+	}
+
+	if checks[0].Name != "lost" || checks[0].Status != doctorWarn || !strings.Contains(checks[0].Fix, "register") {
+		t.Errorf("adoptable check = %+v", checks[0])
+	}
+
+	if !strings.Contains(checks[1].Fix, "asks first") {
+		t.Errorf("unregistered check = %+v, want a fix that asks before deleting", checks[1])
+	}
+
+	if checks[2].Status != doctorFail {
+		t.Errorf("permission check = %+v, want fail", checks[2])
+	}
+
+	if strings.Contains(checks[3].Fix, "--repair-base") {
+		t.Errorf("case conflict check = %+v, want a manual fix", checks[3])
+	}
+}
@@ -0,0 +1,185 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kaeawc/auto-worktree/internal/ai"
+	"github.com/kaeawc/auto-worktree/internal/git"
+)
+
+// withToolVersions replaces toolVersion with canned output; tools missing from versions fail
+func withToolVersions(t *testing.T, versions map[string]string) {
+	t.Helper()
+
+	original := toolVersion
+	t.Cleanup(func() { toolVersion = original })
+
+	toolVersion = func(name string, _ ...string) (string, error) {
+		if v, ok := versions[name]; ok {
+			return v, nil
+		}
+
+		return "", errors.New("not found")
+	}
+}
+
+func TestParseToolVersion(t *testing.T) {
+	tests := []struct {
+		output, prefix, want string
+	}{
+		{"git version 2.39.3 (Apple Git-146)", "git version ", "2.39.3"},
+		{"git version 2.41.0.windows.1", "git version ", "2.41.0.windows.1"},
+		{"tmux 3.3a", "tmux ", "3.3a"},
+	}
+
+	for _, tt := range tests {
+		if got := parseToolVersion(tt.output, tt.prefix); got != tt.want {
+			t.Errorf("parseToolVersion(%q) = %q, want %q", tt.output, got, tt.want)
+		}
+	}
+}
+
+func TestCheckGitVersion(t *testing.T) {
+	withToolVersions(t, map[string]string{"git": "git version 2.45.1"})
+
+	if c := checkGitVersion(); c.Status != doctorOK || c.Detail != "2.45.1" {
+		t.Errorf("checkGitVersion() = %+v, want ok 2.45.1", c)
+	}
+
+	withToolVersions(t, map[string]string{"git": "git version 2.25.1"})
+
+	if c := checkGitVersion(); c.Status != doctorFail || c.Fix == "" {
+		t.Errorf("checkGitVersion() for an old git = %+v, want fail with a fix", c)
+	}
+
+	withToolVersions(t, nil)
+
+	if c := checkGitVersion(); c.Status != doctorFail {
+		t.Errorf("checkGitVersion() without git = %+v, want fail", c)
+	}
+}
+
+func TestCheckTmux(t *testing.T) {
+	withToolVersions(t, nil)
+
+	if c := checkTmux(); c.Status != doctorWarn || c.Fix == "" {
+		t.Errorf("checkTmux() without tmux = %+v, want warn with a fix", c)
+	}
+}
+
+func TestCheckProviderCLIs(t *testing.T) {
+	withPath(t, "gh", "jira")
+
+	original := providerAuth
+	t.Cleanup(func() { providerAuth = original })
+
+	providerAuth = func(provider string) error {
+		if provider == providerGitHub {
+			return nil
+		}
+
+		return errors.New(provider + " CLI is not authenticated.\n\nRun: login")
+	}
+
+	fake := git.NewFakeGitExecutor()
+	fake.SetResponse("config --local --get "+git.ConfigIssueProvider, providerGitLab)
+	cfg := git.NewConfigWithExecutor("/repo", fake)
+
+	got := map[string]doctorCheck{}
+	for _, c := range checkProviderCLIs(cfg, "git@github.com:a/b.git") {
+		got[c.Name] = c
+	}
+
+	want := map[string]doctorStatus{
+		"gh":     doctorOK,   // installed and signed in
+		"glab":   doctorFail, // configured issue provider
+		"jira":   doctorWarn, // installed but unused
+		"linear": doctorOK,   // not installed, not used
+	}
+
+	for name, status := range want {
+		if got[name].Status != status {
+			t.Errorf("%s status = %v, want %v (%+v)", name, got[name].Status, status, got[name])
+		}
+	}
+
+	if got["glab"].Fix != "Run: login" {
+		t.Errorf("glab fix = %q, want the login instruction", got["glab"].Fix)
+	}
+}
+
+func TestRequiredProvidersFromOrigin(t *testing.T) {
+	cfg := git.NewConfigWithExecutor("/repo", git.NewFakeGitExecutor())
+
+	if got := requiredProviders(cfg, "https://gitlab.com/g/p.git"); len(got) != 1 || !got[providerGitLab] {
+		t.Errorf("requiredProviders() = %v, want only gitlab", got)
+	}
+
+	if got := requiredProviders(cfg, ""); len(got) != 0 {
+		t.Errorf("requiredProviders() without origin = %v, want none", got)
+	}
+}
+
+func TestCheckAITools(t *testing.T) {
+	claude := ai.Tool{Name: "Claude Code", ConfigKey: "claude"}
+
+	if c := checkAITools("codex", []ai.Tool{claude}); c.Status != doctorFail {
+		t.Errorf("missing configured tool = %+v, want fail", c)
+	}
+
+	if c := checkAITools("claude", []ai.Tool{claude}); c.Status != doctorOK {
+		t.Errorf("installed configured tool = %+v, want ok", c)
+	}
+
+	if c := checkAITools("", nil); c.Status != doctorWarn {
+		t.Errorf("no tools = %+v, want warn", c)
+	}
+
+	if c := checkAITools(aiToolSkip, nil); c.Status != doctorWarn {
+		t.Errorf("skip with no tools = %+v, want warn", c)
+	}
+}
+
+func TestCheckWorktreeBase(t *testing.T) {
+	dir := t.TempDir()
+
+	if c := checkWorktreeBase(filepath.Join(dir, "worktrees", "repo")); c.Status != doctorOK {
+		t.Errorf("checkWorktreeBase() for a creatable path = %+v, want ok", c)
+	}
+
+	file := filepath.Join(dir, "file")
+	if err := os.WriteFile(file, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if c := checkWorktreeBase(filepath.Join(file, "repo")); c.Status != doctorFail {
+		t.Errorf("checkWorktreeBase() under a file = %+v, want fail", c)
+	}
+}
+
+func TestCheckHooksPath(t *testing.T) {
+	root := t.TempDir()
+
+	if err := os.Mkdir(filepath.Join(root, ".githooks"), 0o750); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		hooksPath string
+		want      doctorStatus
+	}{
+		{"", doctorOK},
+		{".githooks", doctorOK},
+		{".husky/_", doctorFail},
+		{filepath.Join(root, "missing"), doctorFail},
+	}
+
+	for _, tt := range tests {
+		if c := checkHooksPath(tt.hooksPath, root); c.Status != tt.want {
+			t.Errorf("checkHooksPath(%q) = %+v, want status %v", tt.hooksPath, c, tt.want)
+		}
+	}
+}
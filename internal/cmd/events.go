@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kaeawc/auto-worktree/internal/events"
+	"github.com/kaeawc/auto-worktree/internal/git"
+	"github.com/kaeawc/auto-worktree/internal/state"
+	"github.com/kaeawc/auto-worktree/internal/ui"
+)
+
+// defaultEventsLimit is how many events `auto-worktree history events` shows without --limit
+const defaultEventsLimit = 50
+
+// EventsOptions filters `auto-worktree history events`
+type EventsOptions struct {
+	Action string
+	// Match is a substring of the worktree path, branch or session name
+	Match string
+	Since time.Duration
+	Limit int
+	// AllRepos includes events from every repository and sessions not tied to one
+	AllRepos bool
+}
+
+// EnableEventLog records worktree, branch and session operations in the state database
+func EnableEventLog() {
+	path, err := state.DefaultPath()
+	if err != nil {
+		return
+	}
+
+	events.Enable(path)
+}
+
+// ParseSince parses a --since age such as 30m, 12h or 7d
+func ParseSince(value string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n <= 0 {
+			return 0, fmt.Errorf("invalid --since: %s (use e.g. 30m, 12h or 7d)", value)
+		}
+
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid --since: %s (use e.g. 30m, 12h or 7d)", value)
+	}
+
+	return d, nil
+}
+
+// RunHistoryEvents prints the event log, oldest first
+func RunHistoryEvents(opts EventsOptions) error {
+	if opts.Action != "" && !slices.Contains(events.Actions, opts.Action) {
+		return fmt.Errorf("unknown action %q (one of: %s)", opts.Action, strings.Join(events.Actions, ", "))
+	}
+
+	filter := events.Filter{Action: opts.Action, Match: opts.Match, Limit: opts.Limit}
+	if filter.Limit <= 0 {
+		filter.Limit = defaultEventsLimit
+	}
+
+	if opts.Since > 0 {
+		filter.Since = time.Now().Add(-opts.Since)
+	}
+
+	if !opts.AllRepos {
+		repo, err := git.NewRepository()
+		if err != nil {
+			return fmt.Errorf("error: %w (use --all outside a repository)", err)
+		}
+
+		filter.RepoPath = repo.RootPath
+		filter.Dirs = []string{repo.RootPath, repo.WorktreeBase}
+	}
+
+	path, err := state.DefaultPath()
+	if err != nil {
+		return err
+	}
+
+	list, err := events.NewStore(path).List(filter)
+	if err != nil {
+		return err
+	}
+
+	if len(list) == 0 {
+		fmt.Println("No matching events. Creating, removing and repairing worktrees and starting sessions are recorded here.")
+		return nil
+	}
+
+	for _, e := range list {
+		fmt.Println(formatEvent(e))
+	}
+
+	if len(list) == filter.Limit {
+		fmt.Println()
+		fmt.Println(ui.SubtleStyle.Render(fmt.Sprintf("Showing the latest %d; use --limit for more.", filter.Limit)))
+	}
+
+	return nil
+}
+
+// formatEvent renders an event as a line for the time, action and target, and one for who did it
+func formatEvent(e events.Event) string {
+	var target []string
+
+	for _, part := range []string{e.Branch, e.Session, e.Path, e.Detail} {
+		if part != "" {
+			target = append(target, part)
+		}
+	}
+
+	line := fmt.Sprintf("%s  %-13s %s", e.Time.Local().Format("2006-01-02 15:04:05"),
+		ui.BoldStyle.Render(e.Action), strings.Join(target, "  "))
+
+	if e.Error != "" {
+		line += "  " + ui.WarningStyle.Render("⚠ failed: "+e.Error)
+	}
+
+	who := fmt.Sprintf("by %s: %s", e.Actor, e.Command)
+	if e.Source != "" {
+		who += " (" + e.Source + ")"
+	}
+
+	return line + "\n                     " + ui.SubtleStyle.Render(who)
+}
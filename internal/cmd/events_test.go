@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kaeawc/auto-worktree/internal/events"
+)
+
+func TestParseSince(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"7d", 7 * 24 * time.Hour, false},
+		{"12h", 12 * time.Hour, false},
+		{"30m", 30 * time.Minute, false},
+		{"0d", 0, true},
+		{"-1h", 0, true},
+		{"soon", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseSince(tt.value)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseSince(%q) = %v, %v; want %v, error %v", tt.value, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestFormatEvent(t *testing.T) {
+	got := formatEvent(events.Event{
+		Time:    time.Date(2024, 5, 1, 12, 0, 0, 0, time.Local),
+		Action:  events.ActionRemove,
+		Path:    "/wt/feature",
+		Error:   "locked",
+		Actor:   "sam",
+		Command: "auto-worktree cleanup",
+		Source:  "cmd.cleanupWorktree < cmd.RunCleanup",
+	})
+
+	for _, want := range []string{"2024-05-01 12:00:00", "remove", "/wt/feature", "failed: locked", "by sam: auto-worktree cleanup",
+		"(cmd.cleanupWorktree < cmd.RunCleanup)"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("formatEvent() = %q, missing %q", got, want)
+		}
+	}
+}
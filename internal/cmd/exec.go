@@ -0,0 +1,270 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sync"
+	"time"
+
+	"github.com/kaeawc/auto-worktree/internal/git"
+	"github.com/kaeawc/auto-worktree/internal/interrupt"
+	"github.com/kaeawc/auto-worktree/internal/limits"
+	"github.com/kaeawc/auto-worktree/internal/session"
+	"github.com/kaeawc/auto-worktree/internal/ui"
+)
+
+// ErrExecFailed is returned by RunExec when the command fails in at least one worktree
+var ErrExecFailed = errors.New("command failed")
+
+// ExecOptions configures `auto-worktree exec`
+type ExecOptions struct {
+	// All runs the command in every worktree except the main one
+	All bool
+	// Branches are the worktrees to run in; with neither, the current worktree
+	// or one picked from the list
+	Branches []string
+	// Parallel runs in the worktrees at once, at most auto-worktree.max-parallel,
+	// prefixing each output line with the branch
+	Parallel bool
+	// Command is the program and its arguments
+	Command []string
+}
+
+// execResult is how the command went in one worktree
+type execResult struct {
+	wt       *git.Worktree
+	err      error
+	duration time.Duration
+}
+
+// RunExec runs a command in the selected worktrees and summarizes how it went
+// in each. It returns ErrExecFailed if the command failed anywhere.
+func RunExec(opts ExecOptions) error {
+	if len(opts.Command) == 0 {
+		return fmt.Errorf("no command given")
+	}
+
+	repo, err := git.NewRepository()
+	if err != nil {
+		return fmt.Errorf("error: %w", err)
+	}
+
+	worktrees, err := execWorktrees(repo, opts)
+	if err != nil || len(worktrees) == 0 {
+		return err
+	}
+
+	var results []execResult
+
+	if opts.Parallel && len(worktrees) > 1 {
+		results = execParallel(worktrees, opts.Command)
+	} else {
+		results = execSequential(worktrees, opts.Command)
+	}
+
+	if len(results) < 2 {
+		if len(results) == 1 && results[0].err != nil {
+			return fmt.Errorf("%w: %w", ErrExecFailed, results[0].err)
+		}
+
+		return nil
+	}
+
+	return summarizeExec(results)
+}
+
+// execWorktrees resolves the worktrees the command runs in
+func execWorktrees(repo *git.Repository, opts ExecOptions) ([]*git.Worktree, error) {
+	if !opts.All && len(opts.Branches) == 0 {
+		wt, _, err := targetWorktree(repo, session.NewManager(), "Select a worktree to run the command in")
+		if err != nil || wt == nil {
+			return nil, err
+		}
+
+		return []*git.Worktree{wt}, nil
+	}
+
+	worktrees, err := repo.ListWorktrees()
+	if err != nil {
+		return nil, fmt.Errorf("error listing worktrees: %w", err)
+	}
+
+	if opts.All {
+		worktrees = repo.FilterOutMainBranch(worktrees)
+		if len(worktrees) == 0 {
+			fmt.Println("No worktrees to run in")
+		}
+
+		return worktrees, nil
+	}
+
+	var selected []*git.Worktree
+
+	for _, branch := range opts.Branches {
+		matched := filterWorktreesByBranch(worktrees, branch)
+		if len(matched) == 0 {
+			return nil, fmt.Errorf("no worktree for branch %s", branch)
+		}
+
+		selected = append(selected, matched...)
+	}
+
+	return selected, nil
+}
+
+// execName names a worktree in exec output: its branch, or its directory when detached
+func execName(wt *git.Worktree) string {
+	if wt.Branch != "" {
+		return wt.Branch
+	}
+
+	return wt.Path
+}
+
+// execCommand builds the command for one worktree
+func execCommand(wt *git.Worktree, command []string) *exec.Cmd {
+	c := exec.Command(command[0], command[1:]...) //nolint:gosec // the user's own command
+	c.Dir = wt.Path
+
+	return c
+}
+
+// execSequential runs the command in one worktree after another, attached to
+// the terminal, under a heading for each when there are several
+func execSequential(worktrees []*git.Worktree, command []string) []execResult {
+	results := make([]execResult, 0, len(worktrees))
+
+	for i, wt := range worktrees {
+		if len(worktrees) > 1 {
+			if i > 0 {
+				fmt.Println()
+			}
+
+			fmt.Println(ui.TitleStyle.Render(fmt.Sprintf("==> %s", execName(wt))) + " " + ui.SubtleStyle.Render(wt.Path))
+		}
+
+		c := execCommand(wt, command)
+		c.Stdin = os.Stdin
+		c.Stdout = os.Stdout
+		c.Stderr = os.Stderr
+
+		start := time.Now()
+		err := c.Run()
+		results = append(results, execResult{wt: wt, err: err, duration: time.Since(start)})
+
+		// Ctrl-C stops the command and the worktrees still to go
+		if interrupt.Interrupted() {
+			break
+		}
+	}
+
+	return results
+}
+
+// execParallel runs the command in the worktrees at once, prefixing each line
+// of their output with the branch
+func execParallel(worktrees []*git.Worktree, command []string) []execResult {
+	width := 0
+	for _, wt := range worktrees {
+		width = max(width, len(execName(wt)))
+	}
+
+	results := make([]execResult, len(worktrees))
+	pending := make([]*execResult, len(worktrees))
+
+	for i, wt := range worktrees {
+		results[i].wt = wt
+		pending[i] = &results[i]
+	}
+
+	var mu sync.Mutex
+
+	limits.ForEach(pending, func(r *execResult) {
+		prefix := ui.SubtleStyle.Render(fmt.Sprintf("%-*s │", width, execName(r.wt))) + " "
+		out := &prefixWriter{mu: &mu, out: os.Stdout, prefix: prefix}
+
+		c := execCommand(r.wt, command)
+		c.Stdout = out
+		c.Stderr = out
+
+		start := time.Now()
+		r.err = c.Run()
+		r.duration = time.Since(start)
+
+		out.Flush()
+	})
+
+	return results
+}
+
+// summarizeExec prints how the command went in each worktree
+func summarizeExec(results []execResult) error {
+	fmt.Println()
+
+	failed := 0
+
+	for _, r := range results {
+		took := r.duration.Round(100 * time.Millisecond)
+
+		if r.err != nil {
+			failed++
+
+			fmt.Println(ui.WarningStyle.Render(fmt.Sprintf("⚠ %s: %v (%s)", execName(r.wt), r.err, took)))
+
+			continue
+		}
+
+		fmt.Println(ui.SuccessStyle.Render(fmt.Sprintf("✓ %s", execName(r.wt))) + " " + ui.SubtleStyle.Render(took.String()))
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%w in %d of %d worktrees", ErrExecFailed, failed, len(results))
+	}
+
+	return nil
+}
+
+// prefixWriter writes each complete line with a prefix, holding back a
+// partial line until it is finished or flushed; writers sharing mu never
+// interleave within a line
+type prefixWriter struct {
+	mu     *sync.Mutex
+	out    io.Writer
+	prefix string
+	buf    []byte
+}
+
+func (w *prefixWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+
+		w.writeLine(w.buf[:i])
+		w.buf = w.buf[i+1:]
+	}
+
+	return len(p), nil
+}
+
+// Flush writes the partial line left over, if any
+func (w *prefixWriter) Flush() {
+	if len(w.buf) > 0 {
+		w.writeLine(w.buf)
+		w.buf = nil
+	}
+}
+
+func (w *prefixWriter) writeLine(line []byte) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	fmt.Fprintf(w.out, "%s%s\n", w.prefix, bytes.TrimSuffix(line, []byte("\r")))
+}
@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+)
+
+func TestPrefixWriter(t *testing.T) {
+	var (
+		out bytes.Buffer
+		mu  sync.Mutex
+	)
+
+	w := &prefixWriter{mu: &mu, out: &out, prefix: "work/a | "}
+
+	_, _ = w.Write([]byte("ok  pkg/one\nFAIL pkg/"))
+	_, _ = w.Write([]byte("two\r\nexit status"))
+
+	if got := out.String(); got != "work/a | ok  pkg/one\nwork/a | FAIL pkg/two\n" {
+		t.Errorf("before Flush, output = %q; a partial line should be held back", got)
+	}
+
+	w.Flush()
+
+	if got := out.String(); got != "work/a | ok  pkg/one\nwork/a | FAIL pkg/two\nwork/a | exit status\n" {
+		t.Errorf("after Flush, output = %q", got)
+	}
+}
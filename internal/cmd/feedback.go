@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/kaeawc/auto-worktree/internal/git"
+	"github.com/kaeawc/auto-worktree/internal/interrupt"
+	"github.com/kaeawc/auto-worktree/internal/providers"
+	"github.com/kaeawc/auto-worktree/internal/session"
+)
+
+// RunFeedback sends the unresolved review comments on a worktree's pull request
+// to its AI session: typed into the running session, or as the resume context
+// of a new one. It uses the worktree it is run from, or asks which one.
+func RunFeedback() error {
+	repo, err := git.NewRepository()
+	if err != nil {
+		return fmt.Errorf("error: %w", err)
+	}
+
+	sessionMgr := session.NewManager()
+
+	wt, hasSession, err := targetWorktree(repo, sessionMgr, "Select a worktree to send review feedback to")
+	if err != nil || wt == nil {
+		return err
+	}
+
+	provider, err := GetProviderForRepository(repo)
+	if err != nil {
+		return err
+	}
+
+	reader, ok := provider.(providers.DiscussionReader)
+	if !ok {
+		return fmt.Errorf("%s does not support reading review comments", provider.Name())
+	}
+
+	fmt.Printf("Fetching review comments for %s...\n", wt.Branch)
+
+	threads, err := reader.UnresolvedReviewThreads(interrupt.Context(), wt.Branch)
+	if err != nil {
+		return fmt.Errorf("failed to fetch review comments: %w", err)
+	}
+
+	if len(threads) == 0 {
+		fmt.Printf("No unresolved review comments on the pull request for %s\n", wt.Branch)
+		return nil
+	}
+
+	fmt.Printf("✓ Found %d unresolved review thread(s)\n", len(threads))
+
+	return resumeWorktree(repo, sessionMgr, wt, hasSession, formatReviewFeedback(threads))
+}
+
+// targetWorktree returns the worktree the command was run from, or lets the user pick one,
+// and whether it has a session
+func targetWorktree(repo *git.Repository, sessionMgr *session.SessionManager, title string) (*git.Worktree, bool, error) {
+	if repo.InvokedFromWorktree == "" {
+		return selectWorktree(repo, sessionMgr, title)
+	}
+
+	worktrees, err := repo.ListWorktrees()
+	if err != nil {
+		return nil, false, fmt.Errorf("error listing worktrees: %w", err)
+	}
+
+	for _, wt := range worktrees {
+		if filepath.Clean(wt.Path) == filepath.Clean(repo.InvokedFromWorktree) {
+			hasSession, _ := sessionMgr.HasSession(session.GenerateSessionName(wt.Branch)) //nolint:errcheck
+
+			return wt, hasSession, nil
+		}
+	}
+
+	return nil, false, fmt.Errorf("worktree %s not found", repo.InvokedFromWorktree)
+}
+
+// formatReviewFeedback renders unresolved review threads as instructions for the AI tool
+func formatReviewFeedback(threads []providers.ReviewThread) string {
+	var b strings.Builder
+
+	b.WriteString("Reviewers left these unresolved comments on the pull request for this branch. " +
+		"Address each one, or explain why no change is needed:\n")
+
+	for i, t := range threads {
+		b.WriteString("\n")
+
+		switch {
+		case t.Path != "" && t.Line > 0:
+			fmt.Fprintf(&b, "%d. %s:%d\n", i+1, t.Path, t.Line)
+		case t.Path != "":
+			fmt.Fprintf(&b, "%d. %s (outdated)\n", i+1, t.Path)
+		default:
+			fmt.Fprintf(&b, "%d. General comment\n", i+1)
+		}
+
+		writeComments(&b, t.Comments)
+	}
+
+	return b.String()
+}
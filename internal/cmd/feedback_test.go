@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kaeawc/auto-worktree/internal/providers"
+)
+
+func TestFormatReviewFeedback(t *testing.T) {
+	at := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	got := formatReviewFeedback([]providers.ReviewThread{
+		{Path: "internal/cmd/feedback.go", Line: 42, Comments: []providers.Comment{
+			{Author: "alice", Body: "This can return nil", CreatedAt: at},
+			{Author: "bob", Body: "Agreed", CreatedAt: at},
+		}},
+		{Path: "README.md", Comments: []providers.Comment{{Author: "carol", Body: "Typo", CreatedAt: at}}},
+		{Comments: []providers.Comment{{Author: "dave", Body: "Needs a test", CreatedAt: at}}},
+	})
+
+	for _, want := range []string{
+		"1. internal/cmd/feedback.go:42\n- @alice, 2024-05-01: This can return nil\n- @bob, 2024-05-01: Agreed\n",
+		"2. README.md (outdated)\n- @carol, 2024-05-01: Typo\n",
+		"3. General comment\n- @dave, 2024-05-01: Needs a test\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("formatReviewFeedback() = %q, want it to contain %q", got, want)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kaeawc/auto-worktree/internal/git"
+	"github.com/kaeawc/auto-worktree/internal/ui"
+)
+
+// RunFreeze pauses automatic actions (startup cleanup and background work) for the repository
+func RunFreeze(reason string) error {
+	repo, err := git.NewRepository()
+	if err != nil {
+		return fmt.Errorf("error: %w", err)
+	}
+
+	if state := repo.FrozenState(); state != nil {
+		fmt.Println(frozenBanner(state))
+		fmt.Println("Run 'auto-worktree thaw' to resume automatic actions.")
+
+		return nil
+	}
+
+	state, err := repo.Freeze(strings.TrimSpace(reason))
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s %s\n", ui.SuccessStyle.Render("✓"), frozenBanner(state))
+	fmt.Println("Cleanup, prune, remove and background actions are paused until you run 'auto-worktree thaw'.")
+
+	return nil
+}
+
+// RunThaw resumes automatic actions for the repository
+func RunThaw() error {
+	repo, err := git.NewRepository()
+	if err != nil {
+		return fmt.Errorf("error: %w", err)
+	}
+
+	thawed, err := repo.Thaw()
+	if err != nil {
+		return err
+	}
+
+	if !thawed {
+		fmt.Println("Repository is not frozen.")
+		return nil
+	}
+
+	fmt.Printf("%s Repository thawed; automatic actions resumed\n", ui.SuccessStyle.Render("✓"))
+
+	return nil
+}
+
+// frozenBanner describes the freeze state for list and menu headers
+func frozenBanner(state *git.FreezeState) string {
+	text := "❄ Repository frozen"
+	if !state.FrozenAt.IsZero() {
+		text += " since " + state.FrozenAt.Format("2006-01-02 15:04")
+	}
+
+	if state.Reason != "" {
+		text += ": " + state.Reason
+	}
+
+	return ui.WarningStyle.Render(text)
+}
+
+// errIfFrozen refuses a destructive command while the repository is frozen,
+// so nothing is removed from under an operation the freeze is protecting
+func errIfFrozen(repo *git.Repository) error {
+	state := repo.FrozenState()
+	if state == nil {
+		return nil
+	}
+
+	reason := ""
+	if state.Reason != "" {
+		reason = " (" + state.Reason + ")"
+	}
+
+	return fmt.Errorf("repository is frozen%s; run 'auto-worktree thaw' first", reason)
+}
@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kaeawc/auto-worktree/internal/git"
+)
+
+func TestErrIfFrozen(t *testing.T) {
+	executor := git.NewFakeGitExecutor()
+	executor.SetResponse("rev-parse --show-toplevel", "/fake/repo")
+
+	repo, err := git.NewRepositoryFromPathWithDeps("/fake/repo", executor, git.NewFakeFileSystem())
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+
+	if err := errIfFrozen(repo); err != nil {
+		t.Errorf("errIfFrozen() on a thawed repository = %v, want nil", err)
+	}
+
+	if _, err := repo.Freeze("history rewrite"); err != nil {
+		t.Fatal(err)
+	}
+
+	err = errIfFrozen(repo)
+	if err == nil || !strings.Contains(err.Error(), "history rewrite") || !strings.Contains(err.Error(), "thaw") {
+		t.Errorf("errIfFrozen() on a frozen repository = %v, want the reason and how to thaw", err)
+	}
+}
@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/kaeawc/auto-worktree/internal/git"
+	"github.com/kaeawc/auto-worktree/internal/github"
+	"github.com/kaeawc/auto-worktree/internal/providers"
+)
+
+// githubProjectShim is the GitHub provider for a repository whose issues are
+// planned on a project board (auto-worktree.github-project): issues are listed
+// from the board, optionally from one column, and move to the in-progress
+// column when their worktree is created
+type githubProjectShim struct {
+	*githubProviderShim
+	board github.ProjectBoard
+	// ready is the column issues are listed from, or "" for the whole board
+	ready string
+	// inProgress is the column issues move to, or "" to leave them
+	inProgress string
+}
+
+// newGitHubProjectShim wraps the GitHub provider to use the project board
+func newGitHubProjectShim(shim *githubProviderShim, cfg *git.Config, project string) (providers.Provider, error) {
+	owner, number, err := git.ParseGitHubProject(project)
+	if err != nil {
+		return nil, err
+	}
+
+	if owner == "" {
+		owner = shim.client.Owner
+	}
+
+	return &githubProjectShim{
+		githubProviderShim: shim,
+		board:              github.ProjectBoard{Owner: owner, Number: number, Field: cfg.GetGitHubProjectField()},
+		ready:              cfg.GetGitHubProjectReady(),
+		inProgress:         cfg.GetGitHubProjectInProgress(),
+	}, nil
+}
+
+// ListIssues lists the repository's open issues on the board, in board order.
+// The board has no server-side filters, so the others are applied here.
+func (g *githubProjectShim) ListIssues(_ context.Context, opts providers.ListIssuesOptions) ([]providers.Issue, error) {
+	milestone, err := g.milestone(opts.Milestone)
+	if err != nil {
+		return nil, err
+	}
+
+	opts.Milestone = milestone
+
+	limit := opts.EffectiveLimit()
+	if opts.IsFiltered() {
+		// Filtering may drop issues, so read the whole board
+		limit = 0
+	}
+
+	issues, viewer, err := g.client.ListProjectIssues(g.board, g.ready, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	assignee := opts.Assignee
+	opts.Assignee = ""
+
+	result := make([]providers.Issue, 0, len(issues))
+
+	for i := range issues {
+		if !projectIssueAssigned(issues[i].Assignees, assignee, viewer) {
+			continue
+		}
+
+		result = append(result, projectProviderIssue(&issues[i]))
+	}
+
+	return providers.FilterIssues(result, opts), nil
+}
+
+// projectProviderIssue converts an issue on a board to the provider format
+func projectProviderIssue(issue *github.ProjectIssue) providers.Issue {
+	labels := make([]string, len(issue.Labels))
+	for i, label := range issue.Labels {
+		labels[i] = label.Name
+	}
+
+	result := providers.Issue{
+		ID:        strconv.Itoa(issue.Number),
+		Number:    issue.Number,
+		Title:     issue.Title,
+		Body:      issue.Body,
+		URL:       issue.URL,
+		State:     issue.State,
+		Labels:    labels,
+		Milestone: issue.Milestone,
+		Priority:  providers.PriorityFromLabels(labels),
+	}
+
+	if len(issue.Assignees) > 0 {
+		result.Assignee = issue.Assignees[0]
+	}
+
+	return result
+}
+
+// projectIssueAssigned reports whether an issue's assignees match an assignee
+// filter; viewer is the signed-in user's login, for providers.AssigneeSelf
+func projectIssueAssigned(assignees []string, want, viewer string) bool {
+	switch want {
+	case "":
+		return true
+	case providers.AssigneeNone:
+		return len(assignees) == 0
+	case providers.AssigneeSelf:
+		want = viewer
+	}
+
+	for _, assignee := range assignees {
+		if strings.EqualFold(assignee, want) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// StartWork moves the issue's card to the in-progress column, adding it to
+// the board if it isn't there
+func (g *githubProjectShim) StartWork(_ context.Context, id string) (string, error) {
+	if g.inProgress == "" {
+		return "", nil
+	}
+
+	number, err := strconv.Atoi(id)
+	if err != nil {
+		return "", fmt.Errorf("invalid issue number: %s", id)
+	}
+
+	if err := g.client.MoveProjectIssue(g.board, number, g.inProgress); err != nil {
+		return "", err
+	}
+
+	return g.inProgress, nil
+}
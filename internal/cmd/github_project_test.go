@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/kaeawc/auto-worktree/internal/providers"
+)
+
+func TestProjectIssueAssigned(t *testing.T) {
+	tests := []struct {
+		name      string
+		assignees []string
+		want      string
+		expected  bool
+	}{
+		{"no filter", []string{"bob"}, "", true},
+		{"unassigned", nil, providers.AssigneeNone, true},
+		{"assigned but want unassigned", []string{"bob"}, providers.AssigneeNone, false},
+		{"assigned to me", []string{"bob", "Alice"}, providers.AssigneeSelf, true},
+		{"assigned to someone else", []string{"bob"}, providers.AssigneeSelf, false},
+		{"assigned to a login", []string{"bob"}, "BOB", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := projectIssueAssigned(tt.assignees, tt.want, "alice"); got != tt.expected {
+				t.Errorf("projectIssueAssigned(%v, %q) = %v, want %v", tt.assignees, tt.want, got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/kaeawc/auto-worktree/internal/git"
+)
+
+func TestFilterHealthResults(t *testing.T) {
+	results := []*git.HealthCheckResult{
+		{WorktreePath: "/wt/a", Healthy: true, Issues: []git.HealthCheckIssue{{Severity: git.SeverityWarning}}},
+		{WorktreePath: "/wt/b", Issues: []git.HealthCheckIssue{{Severity: git.SeverityWarning}, {Severity: git.SeverityCritical}}},
+	}
+
+	if healthCheckFailed(results, git.SeverityOK) {
+		t.Error("healthCheckFailed() without a severity should never fail")
+	}
+
+	filtered := filterHealthResults(results, git.SeverityError)
+	if len(filtered) != 2 || len(filtered[0].Issues) != 0 || len(filtered[1].Issues) != 1 {
+		t.Errorf("filterHealthResults(Error) = %+v, want only b's critical issue", filtered)
+	}
+
+	if len(results[1].Issues) != 2 {
+		t.Error("filterHealthResults() should not change the results it filters")
+	}
+
+	if !healthCheckFailed(results, git.SeverityCritical) || healthCheckFailed(results[:1], git.SeverityError) {
+		t.Error("healthCheckFailed() should fail only on issues at or above the severity")
+	}
+}
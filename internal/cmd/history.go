@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/kaeawc/auto-worktree/internal/git"
+	"github.com/kaeawc/auto-worktree/internal/history"
+	"github.com/kaeawc/auto-worktree/internal/state"
+	"github.com/kaeawc/auto-worktree/internal/ui"
+)
+
+// historyListLimit is how many past invocations `auto-worktree history` shows
+const historyListLimit = 20
+
+// openHistoryStore opens the history in the default state database
+func openHistoryStore() (*history.Store, error) {
+	path, err := state.DefaultPath()
+	if err != nil {
+		return nil, err
+	}
+
+	return history.NewStore(path), nil
+}
+
+// recordHistory saves the resolved form of an invocation so it can be repeated.
+// History is best-effort: failures never interrupt the command being recorded.
+func recordHistory(repo *git.Repository, summary string, args ...string) {
+	store, err := openHistoryStore()
+	if err != nil {
+		return
+	}
+
+	_ = store.Record(history.Entry{ //nolint:errcheck // best-effort history
+		Args:     args,
+		RepoPath: repo.RootPath,
+		Summary:  summary,
+	})
+}
+
+// loadRepoHistory returns the history entries for the current repository, most recent first
+func loadRepoHistory() ([]history.Entry, error) {
+	repo, err := git.NewRepository()
+	if err != nil {
+		return nil, fmt.Errorf("error: %w", err)
+	}
+
+	store, err := openHistoryStore()
+	if err != nil {
+		return nil, err
+	}
+
+	return store.ForRepo(repo.RootPath)
+}
+
+// RunHistory lists recent invocations in the current repository
+func RunHistory() error {
+	entries, err := loadRepoHistory()
+	if err != nil {
+		return err
+	}
+
+	if len(entries) == 0 {
+		fmt.Println("No history yet. Commands like 'auto-worktree issue' and 'auto-worktree pr' are recorded here.")
+		return nil
+	}
+
+	fmt.Println(ui.TitleStyle.Render("Recent invocations"))
+	fmt.Println()
+
+	for i, e := range entries {
+		if i >= historyListLimit {
+			break
+		}
+
+		line := fmt.Sprintf("%3d  %s", i+1, ui.BoldStyle.Render(e.Command()))
+		if e.Summary != "" {
+			line += "  " + ui.SubtleStyle.Render(e.Summary)
+		}
+
+		fmt.Println(line)
+	}
+
+	fmt.Println()
+	fmt.Println(ui.SubtleStyle.Render("Repeat one with 'auto-worktree history run <n>', or the latest with 'auto-worktree redo'."))
+	fmt.Println(ui.SubtleStyle.Render("See what created or removed worktrees with 'auto-worktree history events'."))
+
+	return nil
+}
+
+// RunHistoryRun repeats the nth most recent invocation (1-based)
+func RunHistoryRun(n string) error {
+	index, err := strconv.Atoi(n)
+	if err != nil || index < 1 {
+		return fmt.Errorf("invalid history number: %s", n)
+	}
+
+	entries, err := loadRepoHistory()
+	if err != nil {
+		return err
+	}
+
+	if index > len(entries) {
+		return fmt.Errorf("no history entry %d (have %d)", index, len(entries))
+	}
+
+	return replayHistoryEntry(entries[index-1])
+}
+
+// RunRedo repeats the most recent invocation in the current repository
+func RunRedo() error {
+	entries, err := loadRepoHistory()
+	if err != nil {
+		return err
+	}
+
+	if len(entries) == 0 {
+		return fmt.Errorf("nothing to redo: no history for this repository")
+	}
+
+	return replayHistoryEntry(entries[0])
+}
+
+// replayHistoryEntry runs a recorded invocation again
+func replayHistoryEntry(e history.Entry) error {
+	fmt.Println(ui.SubtleStyle.Render("$ " + e.Command()))
+	fmt.Println()
+
+	if len(e.Args) != 2 {
+		return fmt.Errorf("cannot repeat %q", e.Command())
+	}
+
+	switch e.Args[0] {
+	case "issue":
+		return RunIssue(e.Args[1])
+	case "pr":
+		return RunPR(e.Args[1])
+	default:
+		return fmt.Errorf("cannot repeat %q", e.Command())
+	}
+}
@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"github.com/kaeawc/auto-worktree/internal/interrupt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/kaeawc/auto-worktree/internal/git"
+	"github.com/kaeawc/auto-worktree/internal/logging"
+	"github.com/kaeawc/auto-worktree/internal/sandbox"
+	"github.com/kaeawc/auto-worktree/internal/session"
+)
+
+// idleSessions returns the sessions that are still running but have had no
+// activity for longer than timeout. Sessions whose activity cannot be read
+// (typically because they no longer exist) are skipped.
+func idleSessions(sessions []*session.Metadata, lastActivity func(string) (time.Time, error),
+	timeout time.Duration, now time.Time) []*session.Metadata {
+	var idle []*session.Metadata
+
+	for _, m := range sessions {
+		if m.Status == session.StatusPaused || m.Status == session.StatusFailed {
+			continue
+		}
+
+		at, err := lastActivity(m.SessionName)
+		if err != nil {
+			continue
+		}
+
+		if now.Sub(at) > timeout {
+			idle = append(idle, m)
+		}
+	}
+
+	return idle
+}
+
+// underWorktreeBase reports whether path is a worktree inside base
+func underWorktreeBase(base, path string) bool {
+	rel, err := filepath.Rel(base, path)
+	return err == nil && rel != "." && !strings.HasPrefix(rel, "..")
+}
+
+// sessionsUnder returns the sessions whose worktree is inside base, so a
+// repository's monitor leaves other repositories' sessions to their own settings
+func sessionsUnder(sessions []*session.Metadata, base string) []*session.Metadata {
+	var under []*session.Metadata
+
+	for _, m := range sessions {
+		if m.WorktreePath != "" && underWorktreeBase(base, m.WorktreePath) {
+			under = append(under, m)
+		}
+	}
+
+	return under
+}
+
+// stopIdleSessions saves a transcript of every session of repo idle longer than
+// timeout, then kills it (and removes its sandbox container) and marks it paused,
+// so 'auto-worktree resume' starts it again. Nothing is stopped while repo is
+// frozen. It returns the stopped session names.
+func stopIdleSessions(sessionMgr *session.SessionManager, repo *git.Repository, timeout time.Duration) []string {
+	if repo.IsFrozen() {
+		logging.Debug("repository is frozen; skipped the idle check")
+		return nil
+	}
+
+	all, err := sessionMgr.LoadAllSessionMetadata()
+	if err != nil {
+		logging.Warn("failed to load sessions for the idle check", "err", err)
+		return nil
+	}
+
+	var stopped []string
+
+	for _, m := range idleSessions(sessionsUnder(all, repo.WorktreeBase), sessionMgr.LastActivity, timeout, time.Now()) {
+		path, err := sessionMgr.SaveTranscript(m.SessionName)
+		if err != nil {
+			// Without a transcript the agent's output would be lost, so leave it running
+			logging.Warn("not stopping idle session", "session", m.SessionName, "err", err)
+			continue
+		}
+
+		if err := sessionMgr.KillSession(m.SessionName); err != nil {
+			logging.Warn("failed to stop idle session", "session", m.SessionName, "err", err)
+			continue
+		}
+
+		if m.ContainerID != "" {
+			if err := sandbox.Remove(interrupt.Context(), m.ContainerRuntime, m.ContainerID); err != nil {
+				logging.Warn("failed to remove idle session's container", "session", m.SessionName, "err", err)
+			}
+
+			m.ContainerID, m.ContainerRuntime = "", ""
+		}
+
+		m.Status = session.StatusPaused
+		m.TranscriptPath = path
+
+		if err := sessionMgr.SaveSessionMetadata(m); err != nil {
+			logging.Warn("failed to mark idle session paused", "session", m.SessionName, "err", err)
+		}
+
+		logging.Info("stopped idle session", "session", m.SessionName, "transcript", path)
+		stopped = append(stopped, m.SessionName)
+	}
+
+	return stopped
+}
+
+// watchIdleSessions stops repo's idle sessions now and then every interval, until the returned function is called
+func watchIdleSessions(repo *git.Repository, timeout, interval time.Duration) func() {
+	sessionMgr := session.NewManager()
+	done := make(chan struct{})
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			stopIdleSessions(sessionMgr, repo, timeout)
+
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	return func() { close(done) }
+}
@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/kaeawc/auto-worktree/internal/session"
+)
+
+func TestIdleSessions(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	activity := map[string]time.Time{
+		"busy":   now.Add(-10 * time.Minute),
+		"idle":   now.Add(-5 * time.Hour),
+		"paused": now.Add(-9 * time.Hour),
+	}
+
+	lastActivity := func(name string) (time.Time, error) {
+		at, ok := activity[name]
+		if !ok {
+			return time.Time{}, fmt.Errorf("no session %s", name)
+		}
+
+		return at, nil
+	}
+
+	sessions := []*session.Metadata{
+		{SessionName: "busy", Status: session.StatusRunning},
+		{SessionName: "idle", Status: session.StatusNeedsAttention},
+		{SessionName: "paused", Status: session.StatusPaused},
+		{SessionName: "gone", Status: session.StatusRunning},
+	}
+
+	got := idleSessions(sessions, lastActivity, 4*time.Hour, now)
+	if len(got) != 1 || got[0].SessionName != "idle" {
+		t.Errorf("idleSessions() = %v, want only the idle session", got)
+	}
+}
+
+func TestSessionsUnder(t *testing.T) {
+	sessions := []*session.Metadata{
+		{SessionName: "mine", WorktreePath: "/home/me/worktrees/app/feature"},
+		{SessionName: "other", WorktreePath: "/home/me/worktrees/api/feature"},
+		{SessionName: "sibling-prefix", WorktreePath: "/home/me/worktrees/app-old/feature"},
+		{SessionName: "no-worktree"},
+	}
+
+	got := sessionsUnder(sessions, "/home/me/worktrees/app")
+	if len(got) != 1 || got[0].SessionName != "mine" {
+		t.Errorf("sessionsUnder() = %v, want only the session under the base", got)
+	}
+}
@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/kaeawc/auto-worktree/internal/ai"
+	"github.com/kaeawc/auto-worktree/internal/git"
+	"github.com/kaeawc/auto-worktree/internal/logging"
+	"github.com/kaeawc/auto-worktree/internal/ui"
+)
+
+// titledDraft is an AI-generated title and body (for an issue or pull request), shown to the user for editing
+type titledDraft struct {
+	Title string
+	Body  string
+}
+
+// offerAIIssueDraft asks whether to draft the issue with the configured AI tool and,
+// if so, expands a one-line summary into a structured title and body.
+// Returns nil when no capable AI tool is available, the user declines, or drafting fails.
+func offerAIIssueDraft(repo *git.Repository, templateBody string) (*titledDraft, error) {
+	tool, err := ai.NewResolver(repo.Config).Resolve()
+	if err != nil || tool.ConfigKey == "jules" {
+		// No AI tool, or one that can't run one-shot prompts
+		return nil, nil
+	}
+
+	result, err := ui.Run(ui.NewConfirmModel(fmt.Sprintf("Draft this issue with %s?", tool.Name)))
+	if err != nil {
+		return nil, fmt.Errorf("error getting confirmation: %w", err)
+	}
+
+	confirmed, ok := result.(ui.ConfirmModel)
+	if !ok {
+		return nil, fmt.Errorf("unexpected model type")
+	}
+
+	if !confirmed.GetChoice() {
+		return nil, nil
+	}
+
+	result, err = ui.Run(ui.NewInput("Describe the problem", "One line, e.g. 'login fails when password has a space'"))
+	if err != nil {
+		return nil, fmt.Errorf("error getting description input: %w", err)
+	}
+
+	summaryModel, ok := result.(ui.InputModel)
+	if !ok {
+		return nil, fmt.Errorf("unexpected model type")
+	}
+
+	if summaryModel.Err() != nil {
+		return nil, fmt.Errorf("canceled")
+	}
+
+	summary := strings.TrimSpace(summaryModel.Value())
+	if summary == "" {
+		return nil, nil
+	}
+
+	fmt.Printf("Drafting issue with %s...\n", tool.Name)
+
+	output, err := tool.ExecutePrompt(buildIssueDraftPrompt(summary, templateBody))
+	if err != nil {
+		logging.Warn("AI drafting failed", "err", err)
+		fmt.Fprintf(os.Stderr, "Continuing without a draft\n")
+
+		return nil, nil
+	}
+
+	draft := parseTitledDraft(output)
+	if draft.Title == "" {
+		logging.Warn("AI draft had no title, continuing without a draft")
+		return nil, nil
+	}
+
+	return &draft, nil
+}
+
+// buildIssueDraftPrompt builds the prompt that expands a one-line summary into an issue.
+// When a template body is given, the AI is asked to follow its structure instead.
+func buildIssueDraftPrompt(summary, templateBody string) string {
+	var sb strings.Builder
+
+	sb.WriteString("Write a well-structured issue for a software project from the following one-line description.\n\n")
+	sb.WriteString(fmt.Sprintf("Description: %s\n\n", summary))
+
+	if strings.TrimSpace(templateBody) != "" {
+		sb.WriteString("Fill in this issue template, keeping its headings:\n\n")
+		sb.WriteString(templateBody)
+		sb.WriteString("\n\n")
+	} else {
+		sb.WriteString("The body should be markdown with these sections:\n")
+		sb.WriteString("## Description\n")
+		sb.WriteString("## Steps to Reproduce (omit for feature requests)\n")
+		sb.WriteString("## Acceptance Criteria (as a checklist)\n\n")
+	}
+
+	sb.WriteString("Do not invent details that are not implied by the description; use placeholders instead.\n")
+	sb.WriteString("Respond in exactly this format, with nothing before or after:\n")
+	sb.WriteString("TITLE: <concise issue title>\n")
+	sb.WriteString("BODY:\n")
+	sb.WriteString("<markdown body>\n")
+
+	return sb.String()
+}
+
+// parseTitledDraft extracts the title and body from AI output in the
+// "TITLE: ... / BODY: ..." format requested by the drafting prompts.
+// Without a BODY marker, everything after the title line becomes the body.
+func parseTitledDraft(output string) titledDraft {
+	output = strings.TrimSpace(strings.ReplaceAll(output, "\r\n", "\n"))
+	output = stripCodeFence(output)
+
+	var draft titledDraft
+
+	lines := strings.Split(output, "\n")
+	titleLine := -1
+
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if value, ok := cutPrefixFold(trimmed, "TITLE:"); ok {
+			draft.Title = strings.Trim(strings.TrimSpace(value), `"`)
+			titleLine = i
+
+			break
+		}
+	}
+
+	if titleLine == -1 {
+		return draft
+	}
+
+	rest := lines[titleLine+1:]
+	for i, line := range rest {
+		if value, ok := cutPrefixFold(strings.TrimSpace(line), "BODY:"); ok {
+			rest = append([]string{value}, rest[i+1:]...)
+			break
+		}
+	}
+
+	draft.Body = strings.TrimSpace(strings.Join(rest, "\n"))
+
+	return draft
+}
+
+// stripCodeFence removes a single markdown code fence wrapping the whole output
+func stripCodeFence(output string) string {
+	if !strings.HasPrefix(output, "```") || !strings.HasSuffix(output, "```") || len(output) < 6 {
+		return output
+	}
+
+	inner := strings.TrimSuffix(output, "```")
+	if newline := strings.IndexByte(inner, '\n'); newline != -1 {
+		inner = inner[newline+1:]
+	} else {
+		return output
+	}
+
+	return strings.TrimSpace(inner)
+}
+
+// cutPrefixFold is strings.CutPrefix with a case-insensitive prefix match
+func cutPrefixFold(s, prefix string) (string, bool) {
+	if len(s) < len(prefix) || !strings.EqualFold(s[:len(prefix)], prefix) {
+		return s, false
+	}
+
+	return s[len(prefix):], true
+}
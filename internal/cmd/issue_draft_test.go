@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseTitledDraft(t *testing.T) {
+	tests := []struct {
+		name      string
+		output    string
+		wantTitle string
+		wantBody  string
+	}{
+		{
+			name:      "title and body markers",
+			output:    "TITLE: Login fails with spaces in password\nBODY:\n## Description\nUsers cannot log in.\n",
+			wantTitle: "Login fails with spaces in password",
+			wantBody:  "## Description\nUsers cannot log in.",
+		},
+		{
+			name:      "preamble and lowercase markers",
+			output:    "Here is your issue:\n\ntitle: \"Add dark mode\"\nbody: ## Description\nSupport a dark theme.",
+			wantTitle: "Add dark mode",
+			wantBody:  "## Description\nSupport a dark theme.",
+		},
+		{
+			name:      "no body marker",
+			output:    "TITLE: Crash on startup\n\n## Steps to Reproduce\n1. Run the app",
+			wantTitle: "Crash on startup",
+			wantBody:  "## Steps to Reproduce\n1. Run the app",
+		},
+		{
+			name:      "wrapped in a code fence",
+			output:    "```markdown\nTITLE: Fix typo\nBODY:\n- [ ] Typo fixed\n```",
+			wantTitle: "Fix typo",
+			wantBody:  "- [ ] Typo fixed",
+		},
+		{
+			name:   "no title",
+			output: "I couldn't draft an issue.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			draft := parseTitledDraft(tt.output)
+			if draft.Title != tt.wantTitle {
+				t.Errorf("Title = %q, want %q", draft.Title, tt.wantTitle)
+			}
+
+			if draft.Body != tt.wantBody {
+				t.Errorf("Body = %q, want %q", draft.Body, tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestBuildIssueDraftPrompt(t *testing.T) {
+	prompt := buildIssueDraftPrompt("login fails", "")
+	if !strings.Contains(prompt, "login fails") || !strings.Contains(prompt, "Acceptance Criteria") {
+		t.Errorf("prompt missing summary or default sections:\n%s", prompt)
+	}
+
+	prompt = buildIssueDraftPrompt("login fails", "## What happened?")
+	if !strings.Contains(prompt, "## What happened?") || strings.Contains(prompt, "Steps to Reproduce") {
+		t.Errorf("prompt should follow the template instead of default sections:\n%s", prompt)
+	}
+}
@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kaeawc/auto-worktree/internal/logging"
+	"github.com/kaeawc/auto-worktree/internal/offline"
+	"github.com/kaeawc/auto-worktree/internal/providers"
+	"github.com/kaeawc/auto-worktree/internal/session"
+)
+
+// maxRefreshCommentLength caps each comment quoted in the resume context
+const maxRefreshCommentLength = 1500
+
+// issueChangesSince reports what happened on an issue, and on its branch's pull
+// request, since the session last started: an edited description, new comments
+// and new review feedback. It returns "" when nothing changed or the session
+// has no record of the issue.
+func issueChangesSince(ctx context.Context, provider providers.Provider, issue *providers.Issue,
+	branchName string, previous *session.Metadata) string {
+	if previous == nil || offline.Enabled() {
+		return ""
+	}
+
+	since := previous.CreatedAt
+	bodyChanged := false
+
+	if snapshot := previous.Issue; snapshot != nil && snapshot.ID == issue.ID {
+		since = snapshot.SyncedAt
+		bodyChanged = strings.TrimSpace(snapshot.Body) != strings.TrimSpace(issue.Body)
+	}
+
+	if since.IsZero() {
+		return ""
+	}
+
+	var comments, feedback []providers.Comment
+
+	if reader, ok := provider.(providers.DiscussionReader); ok {
+		var err error
+
+		if comments, err = reader.IssueComments(ctx, issue.ID); err != nil {
+			logging.Warn("failed to fetch issue comments", "issue", issue.ID, "err", err)
+		}
+
+		if feedback, err = reader.PullRequestFeedback(ctx, branchName); err != nil {
+			logging.Warn("failed to fetch pull request feedback", "branch", branchName, "err", err)
+		}
+	}
+
+	return formatIssueChanges(bodyChanged, commentsAfter(comments, since), commentsAfter(feedback, since))
+}
+
+// commentsAfter returns the comments posted after t
+func commentsAfter(comments []providers.Comment, t time.Time) []providers.Comment {
+	var recent []providers.Comment
+
+	for _, c := range comments {
+		if c.CreatedAt.After(t) {
+			recent = append(recent, c)
+		}
+	}
+
+	return recent
+}
+
+// formatIssueChanges renders the "what changed" section of the resume context
+func formatIssueChanges(bodyChanged bool, comments, feedback []providers.Comment) string {
+	if !bodyChanged && len(comments) == 0 && len(feedback) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+
+	b.WriteString("## What changed since you last worked on this\n")
+
+	if bodyChanged {
+		b.WriteString("\nThe issue description was edited; the current description is below.\n")
+	}
+
+	if len(comments) > 0 {
+		b.WriteString("\nNew comments on the issue:\n")
+		writeComments(&b, comments)
+	}
+
+	if len(feedback) > 0 {
+		b.WriteString("\nNew review feedback on the pull request (check whether it is already addressed before changing code):\n")
+		writeComments(&b, feedback)
+	}
+
+	return b.String()
+}
+
+func writeComments(b *strings.Builder, comments []providers.Comment) {
+	for _, c := range comments {
+		who := "@" + c.Author
+		if c.ReviewState != "" && c.ReviewState != "COMMENTED" {
+			who += " (" + strings.ToLower(strings.ReplaceAll(c.ReviewState, "_", " ")) + ")"
+		}
+
+		body := strings.TrimSpace(c.Body)
+		if len(body) > maxRefreshCommentLength {
+			body = strings.ToValidUTF8(body[:maxRefreshCommentLength], "") + "…"
+		}
+
+		fmt.Fprintf(b, "- %s, %s: %s\n", who, c.CreatedAt.Format("2006-01-02"), body)
+	}
+}
+
+// recordIssueSnapshot saves what the session's AI tool was just told about the
+// issue, so the next resume can report only what changed after this
+func recordIssueSnapshot(sessionMgr session.Manager, sessionName string, provider providers.Provider, issue *providers.Issue) {
+	metadata, err := sessionMgr.LoadSessionMetadata(sessionName)
+	if err != nil || metadata == nil {
+		return
+	}
+
+	metadata.Issue = &session.IssueSnapshot{
+		Provider: provider.ProviderType(),
+		ID:       issue.ID,
+		Title:    issue.Title,
+		Body:     issue.Body,
+		SyncedAt: time.Now(),
+	}
+
+	if err := sessionMgr.SaveSessionMetadata(metadata); err != nil {
+		logging.Warn("failed to save issue snapshot", "session", sessionName, "err", err)
+	}
+}
@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kaeawc/auto-worktree/internal/providers"
+	"github.com/kaeawc/auto-worktree/internal/providers/stubs"
+	"github.com/kaeawc/auto-worktree/internal/session"
+)
+
+// discussionStub adds canned comments and PR feedback to the stub provider
+type discussionStub struct {
+	*stubs.StubProvider
+	comments, feedback []providers.Comment
+	threads            []providers.ReviewThread
+}
+
+func (d *discussionStub) IssueComments(_ context.Context, _ string) ([]providers.Comment, error) {
+	return d.comments, nil
+}
+
+func (d *discussionStub) PullRequestFeedback(_ context.Context, _ string) ([]providers.Comment, error) {
+	return d.feedback, nil
+}
+
+func (d *discussionStub) UnresolvedReviewThreads(_ context.Context, _ string) ([]providers.ReviewThread, error) {
+	return d.threads, nil
+}
+
+func TestIssueChangesSince(t *testing.T) {
+	synced := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	provider := &discussionStub{
+		StubProvider: stubs.NewStubProvider("GitHub", "github"),
+		comments: []providers.Comment{
+			{Author: "old", Body: "already seen", CreatedAt: synced.Add(-time.Hour)},
+			{Author: "alice", Body: "Please also handle the empty case", CreatedAt: synced.Add(time.Hour)},
+		},
+		feedback: []providers.Comment{
+			{Author: "bob", Body: "Rename this helper", CreatedAt: synced.Add(2 * time.Hour), ReviewState: "CHANGES_REQUESTED"},
+		},
+	}
+
+	issue := &providers.Issue{ID: "42", Body: "Updated description"}
+	previous := &session.Metadata{
+		CreatedAt: synced.Add(-24 * time.Hour),
+		Issue:     &session.IssueSnapshot{ID: "42", Body: "Original description", SyncedAt: synced},
+	}
+
+	got := issueChangesSince(context.Background(), provider, issue, "work/42-x", previous)
+
+	for _, want := range []string{
+		"What changed since you last worked on this",
+		"description was edited",
+		"@alice, 2024-05-01: Please also handle the empty case",
+		"@bob (changes requested), 2024-05-01: Rename this helper",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("issueChangesSince() = %q, want it to contain %q", got, want)
+		}
+	}
+
+	if strings.Contains(got, "already seen") {
+		t.Errorf("issueChangesSince() = %q, want only comments after the last sync", got)
+	}
+
+	// Nothing new since the last sync
+	previous.Issue = &session.IssueSnapshot{ID: "42", Body: "Updated description", SyncedAt: synced.Add(3 * time.Hour)}
+	if got := issueChangesSince(context.Background(), provider, issue, "work/42-x", previous); got != "" {
+		t.Errorf("issueChangesSince() with nothing new = %q, want empty", got)
+	}
+
+	if got := issueChangesSince(context.Background(), provider, issue, "work/42-x", nil); got != "" {
+		t.Errorf("issueChangesSince() without a previous session = %q, want empty", got)
+	}
+}
@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/kaeawc/auto-worktree/internal/git"
+	"github.com/kaeawc/auto-worktree/internal/providers"
+	"github.com/kaeawc/auto-worktree/internal/templates"
+	"github.com/kaeawc/auto-worktree/internal/ui"
+)
+
+// noTemplateChoice is the menu action for creating an issue without a template
+const noTemplateChoice = "__none__"
+
+// selectIssueTemplate discovers the repository's issue templates and lets the user pick one.
+// Returns nil when templates are disabled, none exist, or the user chooses a blank issue.
+func selectIssueTemplate(repo *git.Repository, providerType string) (*templates.Template, error) {
+	cfg := git.NewConfig(repo.RootPath)
+
+	if cfg.GetBoolWithDefault(git.ConfigIssueTemplatesDisabled, false, git.ConfigScopeAuto) {
+		return nil, nil
+	}
+
+	customDir := cfg.GetWithDefault(git.ConfigIssueTemplatesDir, "", git.ConfigScopeAuto)
+
+	found, err := templates.Discover(repo.RootPath, customDir, providerType)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(found) == 0 {
+		return nil, nil
+	}
+
+	// Remember that this repository has templates (best-effort)
+	_ = cfg.Set(git.ConfigIssueTemplatesDetected, "true", git.ConfigScopeLocal) //nolint:errcheck
+
+	if cfg.GetBoolWithDefault(git.ConfigIssueTemplatesNoPrompt, false, git.ConfigScopeAuto) {
+		return nil, nil
+	}
+
+	items := make([]ui.MenuItem, 0, len(found)+1)
+	for i, tmpl := range found {
+		items = append(items, ui.NewMenuItem(tmpl.Name, tmpl.About, strconv.Itoa(i)))
+	}
+
+	items = append(items, ui.NewMenuItem("Blank issue", "Start without a template", noTemplateChoice))
+
+	m, err := ui.Run(ui.NewMenu("Select an issue template", items))
+	if err != nil {
+		return nil, fmt.Errorf("failed to run template picker: %w", err)
+	}
+
+	finalModel, ok := m.(ui.MenuModel)
+	if !ok {
+		return nil, fmt.Errorf("unexpected model type")
+	}
+
+	choice := finalModel.Choice()
+	if choice == "" {
+		return nil, fmt.Errorf("canceled")
+	}
+
+	if choice == noTemplateChoice {
+		return nil, nil
+	}
+
+	index, err := strconv.Atoi(choice)
+	if err != nil || index < 0 || index >= len(found) {
+		return nil, fmt.Errorf("invalid template selection")
+	}
+
+	return &found[index], nil
+}
+
+// applyTemplateMetadata applies a template's front-matter labels and assignees to a new issue.
+// Failures are reported as warnings since the issue already exists.
+func applyTemplateMetadata(ctx context.Context, provider providers.Provider, issue *providers.Issue, tmpl *templates.Template) {
+	if tmpl == nil {
+		return
+	}
+
+	if len(tmpl.Labels) > 0 {
+		if err := provider.AddLabels(ctx, issue.ID, tmpl.Labels); err != nil {
+			fmt.Printf("⚠ Could not add labels to issue %s: %v\n", issue.ID, err)
+		} else {
+			issue.Labels = append(issue.Labels, tmpl.Labels...)
+		}
+	}
+
+	for _, assignee := range tmpl.Assignees {
+		if err := provider.AddAssignee(ctx, issue.ID, assignee); err != nil {
+			fmt.Printf("⚠ Could not assign %s to issue %s: %v\n", assignee, issue.ID, err)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kaeawc/auto-worktree/internal/providers"
+	"github.com/kaeawc/auto-worktree/internal/providers/stubs"
+	"github.com/kaeawc/auto-worktree/internal/templates"
+)
+
+func TestApplyTemplateMetadata(t *testing.T) {
+	stub := stubs.NewStubProvider("GitHub", "github")
+	stub.AddIssue(&providers.Issue{ID: "7", Title: "[BUG] crash"})
+	issue := &providers.Issue{ID: "7", Title: "[BUG] crash"}
+
+	tmpl := &templates.Template{Labels: []string{"bug", "triage"}, Assignees: []string{"octocat"}}
+
+	applyTemplateMetadata(context.Background(), stub, issue, tmpl)
+
+	if got := stub.Issues["7"].Labels; len(got) != 2 || got[0] != "bug" || got[1] != "triage" {
+		t.Errorf("issue labels = %v, want [bug triage]", got)
+	}
+
+	if len(issue.Labels) != 2 {
+		t.Errorf("returned issue labels = %v, want template labels", issue.Labels)
+	}
+
+	if got := stub.Issues["7"].Assignee; got != "octocat" {
+		t.Errorf("issue assignee = %q, want octocat", got)
+	}
+}
+
+func TestApplyTemplateMetadataErrorsAreNonFatal(t *testing.T) {
+	stub := stubs.NewStubProvider("GitHub", "github")
+	issue := &providers.Issue{ID: "8"}
+	stub.AddIssue(issue)
+	stub.SetError("AddLabels", errors.New("label does not exist"))
+
+	applyTemplateMetadata(context.Background(), stub, issue, &templates.Template{Labels: []string{"nope"}})
+	applyTemplateMetadata(context.Background(), stub, issue, nil)
+
+	if stub.GetCallCount("AddLabels") != 1 {
+		t.Errorf("AddLabels called %d times, want 1", stub.GetCallCount("AddLabels"))
+	}
+}
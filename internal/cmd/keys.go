@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"github.com/kaeawc/auto-worktree/internal/git"
+	"github.com/kaeawc/auto-worktree/internal/logging"
+	"github.com/kaeawc/auto-worktree/internal/ui"
+)
+
+// ApplyConfiguredKeybindings applies the configured key remaps to lists and menus.
+// Invalid settings keep the default bindings with a warning.
+func ApplyConfiguredKeybindings() {
+	keys, err := resolveKeyMap(git.NewConfig(""))
+	if err != nil {
+		logging.Warn("ignoring "+git.ConfigKeybindings, "err", err)
+	}
+
+	ui.ApplyKeyMap(keys)
+}
+
+// resolveKeyMap builds the key map from configuration, falling back to the defaults on error
+func resolveKeyMap(cfg *git.Config) (ui.KeyMap, error) {
+	keys, err := ui.DefaultKeyMap().WithOverrides(cfg.GetKeybindings())
+	if err != nil {
+		return ui.DefaultKeyMap(), err
+	}
+
+	return keys, nil
+}
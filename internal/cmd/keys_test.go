@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/kaeawc/auto-worktree/internal/git"
+)
+
+func TestResolveKeyMap(t *testing.T) {
+	executor := git.NewFakeGitExecutor()
+	executor.SetResponse("config --local --get "+git.ConfigKeybindings, "select=l|enter")
+	cfg := git.NewConfigWithExecutor("/repo", executor)
+
+	keys, err := resolveKeyMap(cfg)
+	if err != nil {
+		t.Fatalf("resolveKeyMap() error = %v", err)
+	}
+
+	if got := keys.Select.Keys(); len(got) != 2 || got[0] != "l" {
+		t.Errorf("Select keys = %v, want [l enter]", got)
+	}
+
+	executor.SetResponse("config --local --get "+git.ConfigKeybindings, "jump=g")
+
+	keys, err = resolveKeyMap(cfg)
+	if err == nil {
+		t.Error("resolveKeyMap() should reject unknown actions")
+	}
+
+	if got := keys.Select.Keys(); len(got) != 1 || got[0] != "enter" {
+		t.Errorf("Select keys after error = %v, want the default [enter]", got)
+	}
+}
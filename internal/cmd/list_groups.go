@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/kaeawc/auto-worktree/internal/git"
+	"github.com/kaeawc/auto-worktree/internal/session"
+	"github.com/kaeawc/auto-worktree/internal/ui"
+)
+
+// listIssue is the issue a worktree of list is for
+type listIssue struct {
+	// ID is the issue's number or key, e.g. "42" or "PROJ-42"
+	ID    string
+	Title string
+}
+
+// listIssueOf finds the issue a worktree is for: the one its session was
+// started with, from the session metadata, or else the one its branch names.
+// It returns nil for worktrees of no issue and those of pull requests.
+func listIssueOf(wt *git.Worktree, metadata *session.Metadata) *listIssue {
+	if metadata != nil && metadata.Issue != nil && metadata.Issue.ID != "" {
+		return &listIssue{ID: strings.TrimPrefix(metadata.Issue.ID, "#"), Title: metadata.Issue.Title}
+	}
+
+	status := wt.IssueStatus
+	if status == nil || status.ID == "" || strings.HasSuffix(status.Provider, "-pr") || strings.HasSuffix(status.Provider, "-mr") {
+		return nil
+	}
+
+	return &listIssue{ID: strings.TrimPrefix(status.ID, "#"), Title: status.Title}
+}
+
+// String names the issue as list shows it, e.g. "#42 Fix login crash"
+func (i *listIssue) String() string {
+	name := i.ID
+	if _, err := strconv.Atoi(i.ID); err == nil {
+		name = "#" + name
+	}
+
+	if i.Title == "" {
+		return name
+	}
+
+	return name + " " + i.Title
+}
+
+// listAttempts numbers the worktrees that share an issue with another, such
+// as parallel attempts or stacked branches, in the order their sessions were
+// started; worktrees alone on their issue aren't numbered
+func listAttempts(worktrees []*git.Worktree, sessions map[string]*session.Metadata) map[string]int {
+	byIssue := map[string][]*git.Worktree{}
+
+	for _, wt := range worktrees {
+		if issue := listIssueOf(wt, sessions[wt.Path]); issue != nil {
+			byIssue[issue.ID] = append(byIssue[issue.ID], wt)
+		}
+	}
+
+	attempts := map[string]int{}
+
+	for _, group := range byIssue {
+		if len(group) < 2 {
+			continue
+		}
+
+		// Worktrees without a session keep git's order, after those with one
+		sort.SliceStable(group, func(i, j int) bool {
+			a, b := sessions[group[i].Path], sessions[group[j].Path]
+			return a != nil && (b == nil || a.CreatedAt.Before(b.CreatedAt))
+		})
+
+		for i, wt := range group {
+			attempts[wt.Path] = i + 1
+		}
+	}
+
+	return attempts
+}
+
+// formatAttempt labels a worktree's attempt at its issue, e.g. "attempt 2"
+func formatAttempt(attempt int) string {
+	return fmt.Sprintf("attempt %d", attempt)
+}
+
+// groupListEntries moves the rows of each issue with several worktrees up to
+// the first of them, in attempt order, and gives that row a header naming
+// the issue; other rows keep their order
+func groupListEntries(rows []*listEntry) []*listEntry {
+	members := map[string][]*listEntry{}
+
+	for _, row := range rows {
+		if row.attempt > 0 {
+			members[row.issue.ID] = append(members[row.issue.ID], row)
+		}
+	}
+
+	grouped := make([]*listEntry, 0, len(rows))
+
+	for _, row := range rows {
+		if row.attempt == 0 {
+			grouped = append(grouped, row)
+			continue
+		}
+
+		group, ok := members[row.issue.ID]
+		if !ok {
+			continue
+		}
+
+		delete(members, row.issue.ID)
+		sort.SliceStable(group, func(i, j int) bool { return group[i].attempt < group[j].attempt })
+
+		issue := group[0].issue
+		for _, member := range group {
+			if member.issue.Title != "" {
+				issue = member.issue
+				break
+			}
+		}
+
+		group[0].header = fmt.Sprintf("%s (%d attempts)", issue, len(group))
+		grouped = append(grouped, group...)
+	}
+
+	return grouped
+}
+
+// printListGroupHeader prints the header row of a group of worktrees, if row starts one
+func printListGroupHeader(row *listEntry, width int) {
+	if row.header != "" {
+		fmt.Println(fitListLine(ui.SubtleStyle.Render("  ▸ "+row.header), width))
+	}
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kaeawc/auto-worktree/internal/git"
+	"github.com/kaeawc/auto-worktree/internal/provider"
+	"github.com/kaeawc/auto-worktree/internal/session"
+)
+
+func TestListIssueOf(t *testing.T) {
+	wt := &git.Worktree{IssueStatus: &git.IssueStatus{Provider: provider.ProviderTypeGitHubIssue, ID: "42"}}
+
+	if got := listIssueOf(wt, nil); got == nil || got.String() != "#42" {
+		t.Errorf("listIssueOf() from the branch = %v, want #42", got)
+	}
+
+	metadata := &session.Metadata{Issue: &session.IssueSnapshot{Provider: "jira", ID: "PROJ-7", Title: "Fix login"}}
+	if got := listIssueOf(wt, metadata); got == nil || got.String() != "PROJ-7 Fix login" {
+		t.Errorf("listIssueOf() from the session = %v, want PROJ-7 Fix login", got)
+	}
+
+	pr := &git.Worktree{IssueStatus: &git.IssueStatus{Provider: provider.ProviderTypeGitHubPR, ID: "12"}}
+	if got := listIssueOf(pr, nil); got != nil {
+		t.Errorf("listIssueOf() of a PR worktree = %v, want nil", got)
+	}
+}
+
+func TestGroupListEntries(t *testing.T) {
+	now := time.Now()
+	issue := func(id string) *git.IssueStatus {
+		return &git.IssueStatus{Provider: provider.ProviderTypeGitHubIssue, ID: id}
+	}
+
+	worktrees := []*git.Worktree{
+		{Path: "/wt/a", Branch: "a", IssueStatus: issue("42")},
+		{Path: "/wt/b", Branch: "b"},
+		{Path: "/wt/c", Branch: "c", IssueStatus: issue("7")},
+		{Path: "/wt/d", Branch: "d", IssueStatus: issue("42")},
+		{Path: "/wt/e", Branch: "e"},
+	}
+
+	sessions := map[string]*session.Metadata{
+		"/wt/a": {CreatedAt: now, Issue: &session.IssueSnapshot{ID: "42", Title: "Fix login"}},
+		"/wt/d": {CreatedAt: now.Add(-time.Hour)},
+	}
+
+	attempts := listAttempts(worktrees, sessions)
+	if attempts["/wt/d"] != 1 || attempts["/wt/a"] != 2 || attempts["/wt/c"] != 0 {
+		t.Fatalf("listAttempts() = %v, want d first, a second and c unnumbered", attempts)
+	}
+
+	rows := make([]*listEntry, len(worktrees))
+	for i, wt := range worktrees {
+		rows[i] = &listEntry{wt: wt, issue: listIssueOf(wt, sessions[wt.Path]), attempt: attempts[wt.Path]}
+	}
+
+	got := ""
+	for _, row := range groupListEntries(rows) {
+		got += row.wt.Branch
+	}
+
+	if got != "dabce" {
+		t.Errorf("groupListEntries() order = %s, want dabce", got)
+	}
+
+	if want := "#42 Fix login (2 attempts)"; rows[3].header != want {
+		t.Errorf("group header = %q, want %q", rows[3].header, want)
+	}
+
+	if rows[0].header != "" || rows[2].header != "" {
+		t.Error("only the first row of a group should have a header")
+	}
+}
@@ -0,0 +1,301 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/kaeawc/auto-worktree/internal/check"
+	"github.com/kaeawc/auto-worktree/internal/git"
+)
+
+// ListOptions configures `auto-worktree list`; empty fields fall back to the
+// list-sort and list-columns settings
+type ListOptions struct {
+	// Sort orders worktrees by age, branch, status or size
+	Sort string
+	// Columns are the columns to show, in order
+	Columns []string
+	// Compact prints one unpadded line per worktree instead of a table
+	Compact bool
+	// Watch reprints the list this often until interrupted; 0 lists once
+	Watch time.Duration
+}
+
+// listColumnWidths are the widths of the columns of list; path is configured
+// and the last column shown is never padded
+var listColumnWidths = map[string]int{
+	"branch":   20,
+	"age":      12,
+	"status":   20,
+	"session":  10,
+	"test":     12,
+	"unpushed": 12,
+	"size":     9,
+}
+
+// listEntry is one worktree of list with its rendered cells
+type listEntry struct {
+	wt *git.Worktree
+	// indicator marks the worktree list was run from
+	indicator string
+	size      int64
+	cells     map[string]string
+	// issue is the issue the worktree is for, and attempt its number among
+	// the worktrees of that issue (0 when it is the only one)
+	issue   *listIssue
+	attempt int
+	// header names the issue above the first row of its group
+	header string
+}
+
+// resolveListOptions fills unset options from config and checks them
+func resolveListOptions(opts ListOptions, cfg *git.Config) (ListOptions, error) {
+	if opts.Sort == "" {
+		opts.Sort = cfg.GetListSort()
+	}
+
+	if opts.Sort != "" {
+		if err := cfg.Validate(git.ConfigListSort, opts.Sort); err != nil {
+			return opts, err
+		}
+	}
+
+	if len(opts.Columns) == 0 {
+		opts.Columns = cfg.GetListColumns()
+	}
+
+	opts.Compact = opts.Compact || cfg.GetListCompact()
+
+	if err := cfg.Validate(git.ConfigListColumns, strings.Join(opts.Columns, ",")); err != nil {
+		return opts, err
+	}
+
+	return opts, nil
+}
+
+// listHideOrder is the order list hides columns in when the table is wider
+// than the terminal; path and status are always kept
+var listHideOrder = []string{"size", "test", "session", "age", "unpushed", "branch"}
+
+// fitListColumns makes the table fit a terminal width columns wide: the path
+// column is narrowed down to its minimum, then columns are hidden. It returns
+// the columns to show, the path width and the hidden columns. A width of 0
+// (output not to a terminal) leaves the table as it is.
+func fitListColumns(columns []string, pathWidth, width int) ([]string, int, []string) {
+	fits := func() bool { return 2+listTableWidth(columns, pathWidth) <= width }
+
+	if width <= 0 || fits() {
+		return columns, pathWidth, nil
+	}
+
+	configured := pathWidth
+
+	var hidden []string
+
+	if slices.Contains(columns, "path") {
+		pathWidth = max(width-2-listTableWidth(columns, 0), git.MinListPathWidth)
+	}
+
+	for _, column := range listHideOrder {
+		if fits() {
+			break
+		}
+
+		if i := slices.Index(columns, column); i >= 0 {
+			columns = slices.Delete(slices.Clone(columns), i, i+1)
+			hidden = append(hidden, column)
+		}
+	}
+
+	// Hiding a wide column can leave room to give the path back
+	if slices.Contains(columns, "path") {
+		pathWidth = min(max(width-2-listTableWidth(columns, 0), pathWidth), configured)
+	}
+
+	return columns, pathWidth, hidden
+}
+
+// needsListSize reports whether list has to measure worktrees on disk, which
+// is slow enough to skip unless asked for
+func needsListSize(opts ListOptions) bool {
+	return opts.Sort == "size" || slices.Contains(opts.Columns, "size")
+}
+
+// measureListSize fills in each row's size and size cell
+func measureListSize(rows []*listEntry) {
+	for _, row := range rows {
+		size, err := check.DirSize(row.wt.Path)
+		if err != nil {
+			row.size = -1
+			row.cells["size"] = "-"
+
+			continue
+		}
+
+		row.size = size
+		row.cells["size"] = formatDiskSize(size)
+	}
+}
+
+// sortListEntries orders rows by age (oldest first), branch, status (ready to
+// clean up first) or size (largest first); ties and an empty sortBy keep git's order
+func sortListEntries(rows []*listEntry, sortBy string) {
+	var less func(a, b *listEntry) bool
+
+	switch sortBy {
+	case "age":
+		less = func(a, b *listEntry) bool { return a.wt.LastCommitTime.Before(b.wt.LastCommitTime) }
+	case "branch":
+		less = func(a, b *listEntry) bool { return a.wt.Branch < b.wt.Branch }
+	case "status":
+		less = func(a, b *listEntry) bool { return listStatusRank(a.wt) < listStatusRank(b.wt) }
+	case "size":
+		less = func(a, b *listEntry) bool { return a.size > b.size }
+	default:
+		return
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool { return less(rows[i], rows[j]) })
+}
+
+// listStatusRank groups worktrees by the status list shows for them, with
+// those ready to clean up first: merged, closed, no changes, stale, then active.
+// Cases follow getStatusIndicator so a worktree ranks by the status it shows.
+func listStatusRank(wt *git.Worktree) int {
+	switch {
+	case wt.IssueStatus != nil && wt.IssueStatus.IsCompleted:
+		return 0
+	case wt.IssueStatus != nil && wt.IssueStatus.IsClosed:
+		return 1
+	case wt.HasNoChanges && wt.UnpushedCount == 0:
+		return 2
+	case wt.IsBranchMerged:
+		return 0
+	case wt.IsStale():
+		return 3
+	default:
+		return 4
+	}
+}
+
+// printListTable prints the header and one line per row with the chosen
+// columns, cutting lines that would wrap in a terminal width columns wide
+func printListTable(rows []*listEntry, columns []string, pathWidth, width int) {
+	header := make([]string, len(columns))
+	for i, column := range columns {
+		header[i] = strings.ToUpper(column)
+	}
+
+	fmt.Println(fitListLine("  "+joinListCells(header, columns, pathWidth), width))
+	rule := 2 + listTableWidth(columns, pathWidth)
+	if width > 0 {
+		rule = min(rule, width)
+	}
+
+	fmt.Println(strings.Repeat("-", rule))
+
+	for _, row := range rows {
+		printListGroupHeader(row, width)
+
+		cells := make([]string, len(columns))
+		for i, column := range columns {
+			cells[i] = row.cells[column]
+		}
+
+		fmt.Println(fitListLine(row.indicator+joinListCells(cells, columns, pathWidth), width))
+	}
+}
+
+// printListCompact prints one line per worktree with the chosen columns that
+// have something to show, unpadded and without a header
+func printListCompact(rows []*listEntry, columns []string, width int) {
+	for _, row := range rows {
+		printListGroupHeader(row, width)
+
+		var cells []string
+
+		for _, column := range columns {
+			if cell := row.cells[column]; cell != "" && cell != "-" {
+				cells = append(cells, cell)
+			}
+		}
+
+		fmt.Println(fitListLine(row.indicator+strings.Join(cells, "  "), width))
+	}
+}
+
+// fitListLine cuts a styled line to a terminal width columns wide, so a long
+// branch or status doesn't wrap onto the next line; 0 leaves it whole
+func fitListLine(line string, width int) string {
+	if width <= 0 || lipgloss.Width(line) <= width {
+		return line
+	}
+
+	return lipgloss.NewStyle().MaxWidth(width-1).Render(line) + "…"
+}
+
+// joinListCells pads each cell to its column's width, measuring styled text by
+// what it shows
+func joinListCells(cells, columns []string, pathWidth int) string {
+	var b strings.Builder
+
+	for i, cell := range cells {
+		if i == len(cells)-1 {
+			b.WriteString(cell)
+			break
+		}
+
+		b.WriteString(cell)
+
+		if gap := listColumnWidth(columns[i], pathWidth) - lipgloss.Width(cell); gap > 0 {
+			b.WriteString(strings.Repeat(" ", gap))
+		}
+
+		b.WriteString(" ")
+	}
+
+	return b.String()
+}
+
+// listTableWidth returns how wide the chosen columns are, counting the last
+// column at its nominal width
+func listTableWidth(columns []string, pathWidth int) int {
+	width := 0
+
+	for _, column := range columns {
+		width += listColumnWidth(column, pathWidth) + 1
+	}
+
+	return max(width-1, 0)
+}
+
+// listColumnWidth returns the width of one column
+func listColumnWidth(column string, pathWidth int) int {
+	if column == "path" {
+		return pathWidth
+	}
+
+	return listColumnWidths[column]
+}
+
+// formatDiskSize renders a size in bytes as e.g. "1.2G" or "340M"
+func formatDiskSize(bytes int64) string {
+	const unit = 1024
+
+	if bytes < unit {
+		return fmt.Sprintf("%dB", bytes)
+	}
+
+	div, exp := int64(unit), 0
+	for n := bytes / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.1f%c", float64(bytes)/float64(div), "KMGTPE"[exp])
+}
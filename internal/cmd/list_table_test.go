@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kaeawc/auto-worktree/internal/git"
+)
+
+func TestSortListEntries(t *testing.T) {
+	now := time.Now()
+	entries := func() []*listEntry {
+		return []*listEntry{
+			{wt: &git.Worktree{Branch: "c", LastCommitTime: now.Add(-time.Hour)}, size: 30},
+			{wt: &git.Worktree{Branch: "a", LastCommitTime: now.Add(-10 * 24 * time.Hour)}, size: 10},
+			{wt: &git.Worktree{Branch: "b", LastCommitTime: now, IsBranchMerged: true}, size: 20},
+		}
+	}
+
+	tests := []struct {
+		sortBy string
+		want   string
+	}{
+		{"", "cab"},
+		{"age", "acb"},
+		{"branch", "abc"},
+		{"status", "bac"},
+		{"size", "cba"},
+	}
+
+	for _, tt := range tests {
+		rows := entries()
+		sortListEntries(rows, tt.sortBy)
+
+		got := ""
+		for _, row := range rows {
+			got += row.wt.Branch
+		}
+
+		if got != tt.want {
+			t.Errorf("sortListEntries(%q) = %s, want %s", tt.sortBy, got, tt.want)
+		}
+	}
+}
+
+func TestListStatusRankIssueStatus(t *testing.T) {
+	closed := &git.Worktree{IssueStatus: &git.IssueStatus{IsClosed: true}}
+	completed := &git.Worktree{IssueStatus: &git.IssueStatus{IsCompleted: true}}
+
+	if listStatusRank(completed) >= listStatusRank(closed) {
+		t.Error("completed worktrees should sort before closed ones")
+	}
+}
+
+func TestJoinListCells(t *testing.T) {
+	got := joinListCells([]string{"a/path", "main", "[merged]"}, []string{"path", "branch", "status"}, 8)
+	if want := "a/path   main                 [merged]"; got != want {
+		t.Errorf("joinListCells() = %q, want %q", got, want)
+	}
+
+	if got := listTableWidth([]string{"path", "branch"}, 8); got != 29 {
+		t.Errorf("listTableWidth() = %d, want 29", got)
+	}
+}
+
+func TestFormatDiskSize(t *testing.T) {
+	tests := map[int64]string{
+		512:               "512B",
+		2048:              "2.0K",
+		340 * 1024 * 1024: "340.0M",
+		1288490189:        "1.2G",
+	}
+
+	for bytes, want := range tests {
+		if got := formatDiskSize(bytes); got != want {
+			t.Errorf("formatDiskSize(%d) = %s, want %s", bytes, got, want)
+		}
+	}
+}
+
+func TestFitListColumns(t *testing.T) {
+	all := git.DefaultListColumns
+
+	tests := []struct {
+		name          string
+		width         int
+		wantColumns   string
+		wantPathWidth int
+		wantHidden    string
+	}{
+		{"unknown width", 0, "path,branch,age,status,session,test,unpushed", 45, ""},
+		{"wide", 200, "path,branch,age,status,session,test,unpushed", 45, ""},
+		{"narrower path", 130, "path,branch,age,status,session,test,unpushed", 36, ""},
+		{"100 columns", 100, "path,branch,age,status,session,unpushed", 19, "test"},
+		{"very narrow", 40, "path,status", 17, "test,session,age,unpushed,branch"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			columns, pathWidth, hidden := fitListColumns(all, 45, tt.width)
+
+			if got := strings.Join(columns, ","); got != tt.wantColumns {
+				t.Errorf("columns = %s, want %s", got, tt.wantColumns)
+			}
+
+			if pathWidth != tt.wantPathWidth {
+				t.Errorf("path width = %d, want %d", pathWidth, tt.wantPathWidth)
+			}
+
+			if got := strings.Join(hidden, ","); got != tt.wantHidden {
+				t.Errorf("hidden = %s, want %s", got, tt.wantHidden)
+			}
+
+			if tt.width > 0 && 2+listTableWidth(columns, pathWidth) > tt.width {
+				t.Errorf("table is %d wide, more than %d", 2+listTableWidth(columns, pathWidth), tt.width)
+			}
+		})
+	}
+}
+
+func TestFitListLine(t *testing.T) {
+	if got := fitListLine("short", 10); got != "short" {
+		t.Errorf("fitListLine() = %q, want it whole", got)
+	}
+
+	if got := fitListLine("a line that is too long", 10); got != "a line th…" {
+		t.Errorf("fitListLine() = %q, want %q", got, "a line th…")
+	}
+
+	if got := fitListLine("a line that is too long", 0); got != "a line that is too long" {
+		t.Errorf("fitListLine() = %q, want it whole when the width is unknown", got)
+	}
+}
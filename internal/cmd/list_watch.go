@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/muesli/termenv"
+
+	"github.com/kaeawc/auto-worktree/internal/git"
+	"github.com/kaeawc/auto-worktree/internal/interrupt"
+	"github.com/kaeawc/auto-worktree/internal/providers"
+	"github.com/kaeawc/auto-worktree/internal/ui"
+)
+
+// DefaultListWatchInterval is how often list --watch refreshes unless a number of seconds is given
+const DefaultListWatchInterval = 5 * time.Second
+
+// watchList reprints the list every opts.Watch until interrupted, as a live
+// table for a tmux pane. Git status is read each time, conflicts come from
+// their per-commit cache, and issue and PR status is fetched at most every
+// statusCacheTTL, as for status, so providers aren't asked every few seconds.
+func watchList(repo *git.Repository, prov providers.Provider, opts ListOptions) error {
+	ctx := interrupt.Context()
+
+	out := termenv.NewOutput(os.Stdout)
+	redraw := ui.IsTerminal(os.Stdout)
+	issues := newIssueStatusCache(prov)
+
+	for {
+		worktrees, err := issues.list(repo, time.Now())
+		if err != nil {
+			return fmt.Errorf("error listing worktrees: %w", err)
+		}
+
+		if redraw {
+			out.ClearScreen()
+		} else {
+			fmt.Println()
+		}
+
+		if len(worktrees) == 0 {
+			fmt.Println("No worktrees found")
+		} else {
+			printList(repo, worktrees, opts)
+		}
+
+		fmt.Println()
+		fmt.Println(ui.SubtleStyle.Render(fmt.Sprintf("Every %s, updated %s (Ctrl+C to stop)", opts.Watch, time.Now().Format("15:04:05"))))
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(opts.Watch):
+		}
+	}
+}
+
+// issueStatusCache lists worktrees, asking the provider for their issue and PR
+// status only when the last answer is older than statusCacheTTL
+type issueStatusCache struct {
+	prov      providers.Provider
+	fetchedAt time.Time
+	// statuses are keyed by worktree path and branch
+	statuses map[string]*git.IssueStatus
+}
+
+// newIssueStatusCache creates an empty cache for prov, which may be nil
+func newIssueStatusCache(prov providers.Provider) *issueStatusCache {
+	return &issueStatusCache{prov: prov}
+}
+
+// list lists the worktrees other than the main one with all their status
+func (c *issueStatusCache) list(repo *git.Repository, now time.Time) ([]*git.Worktree, error) {
+	fetch := c.prov
+	if c.statuses != nil && now.Sub(c.fetchedAt) < statusCacheTTL {
+		fetch = nil
+	}
+
+	worktrees, err := repo.ListWorktreesWithAllStatusExcludingMain(fetch)
+	if err != nil {
+		return nil, err
+	}
+
+	if fetch != nil {
+		c.store(worktrees, now)
+	} else {
+		c.apply(worktrees)
+	}
+
+	return worktrees, nil
+}
+
+// store remembers the status each worktree was listed with
+func (c *issueStatusCache) store(worktrees []*git.Worktree, now time.Time) {
+	c.statuses = make(map[string]*git.IssueStatus, len(worktrees))
+	c.fetchedAt = now
+
+	for _, wt := range worktrees {
+		c.statuses[issueStatusKey(wt)] = wt.IssueStatus
+	}
+}
+
+// apply gives worktrees listed without a provider their remembered status
+func (c *issueStatusCache) apply(worktrees []*git.Worktree) {
+	for _, wt := range worktrees {
+		if status, ok := c.statuses[issueStatusKey(wt)]; ok {
+			wt.IssueStatus = status
+		}
+	}
+}
+
+// issueStatusKey identifies a worktree's issue status; switching the branch
+// checked out in it forgets the status
+func issueStatusKey(wt *git.Worktree) string {
+	return wt.Path + "\x00" + wt.Branch
+}
@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kaeawc/auto-worktree/internal/git"
+)
+
+func TestIssueStatusCacheApply(t *testing.T) {
+	cache := newIssueStatusCache(nil)
+	merged := &git.IssueStatus{IsCompleted: true}
+
+	cache.store([]*git.Worktree{{Path: "/wt/a", Branch: "a", IssueStatus: merged}}, time.Now())
+
+	same := &git.Worktree{Path: "/wt/a", Branch: "a"}
+	switched := &git.Worktree{Path: "/wt/a", Branch: "b"}
+	cache.apply([]*git.Worktree{same, switched})
+
+	if same.IssueStatus != merged {
+		t.Error("a worktree listed again should keep its issue status")
+	}
+
+	if switched.IssueStatus != nil {
+		t.Error("a worktree with another branch checked out should lose its issue status")
+	}
+}
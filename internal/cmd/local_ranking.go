@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kaeawc/auto-worktree/internal/git"
+	"github.com/kaeawc/auto-worktree/internal/github"
+	"github.com/kaeawc/auto-worktree/internal/providers"
+)
+
+// priorityScores weigh an issue's or PR's priority in the local ranking;
+// one without a priority sits between medium and low
+var priorityScores = map[int]int{
+	providers.PriorityUrgent: 40,
+	providers.PriorityHigh:   30,
+	providers.PriorityMedium: 20,
+	providers.PriorityNone:   10,
+	providers.PriorityLow:    5,
+}
+
+// issueTimeLayouts are the creation time formats of the issue providers
+var issueTimeLayouts = []string{time.RFC3339, "2006-01-02T15:04:05.000-0700", "2006-01-02"}
+
+// rankIssues orders issues for autoselect: by the AI tool when it can, and
+// otherwise by scoreIssue, so the list is still in a useful order. It returns
+// the issues with a line saying how they were ranked.
+func rankIssues(repo *git.Repository, issues []providers.Issue, providerType string) ([]providers.Issue, string) {
+	if selected, ranking := aiSelectIssues(repo, issues, providerType); ranking != nil {
+		return selected, fmt.Sprintf("Showing top %d AI-prioritized issues (%s)", len(selected), ranking.Note(time.Now()))
+	}
+
+	return localRankIssues(issues), "Issues ranked by priority, labels and age (AI ranking unavailable)"
+}
+
+// rankPRs is rankIssues for pull requests, scored by scorePR
+func rankPRs(repo *git.Repository, prs []github.PullRequest) ([]github.PullRequest, string) {
+	if selected, ranking := aiSelectPRs(repo, prs); ranking != nil {
+		return selected, fmt.Sprintf("Showing top %d AI-prioritized PRs (%s)", len(selected), ranking.Note(time.Now()))
+	}
+
+	return localRankPRs(prs, getCurrentGitHubUser()),
+		"PRs ranked by review requests, priority, size, checks and age (AI ranking unavailable)"
+}
+
+// localRankIssues orders issues by scoreIssue, then oldest first; issues
+// whose age is unknown keep their order among equals
+func localRankIssues(issues []providers.Issue) []providers.Issue {
+	ranked := slices.Clone(issues)
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if si, sj := scoreIssue(ranked[i]), scoreIssue(ranked[j]); si != sj {
+			return si > sj
+		}
+
+		ti, okI := parseIssueTime(ranked[i].CreatedAt)
+		tj, okJ := parseIssueTime(ranked[j].CreatedAt)
+
+		return okI && okJ && ti.Before(tj)
+	})
+
+	return ranked
+}
+
+// scoreIssue scores an issue by its priority, and by labels marking bugs
+// and security problems (first) or issues that can't be worked on (last)
+func scoreIssue(issue providers.Issue) int {
+	score := priorityScores[issue.Priority]
+
+	if strings.EqualFold(issue.Type, "bug") {
+		score += 8
+	}
+
+	for _, label := range issue.Labels {
+		score += labelScore(label)
+	}
+
+	return score
+}
+
+// labelScore is what a label adds to an issue's or PR's score
+func labelScore(label string) int {
+	name := strings.ToLower(label)
+
+	switch {
+	case strings.Contains(name, "security"):
+		return 15
+	case strings.Contains(name, "bug"):
+		return 8
+	case strings.Contains(name, "good first issue"), strings.Contains(name, "help wanted"):
+		return 3
+	case strings.Contains(name, "blocked"), strings.Contains(name, "wontfix"), strings.Contains(name, "on hold"):
+		return -20
+	default:
+		return 0
+	}
+}
+
+// parseIssueTime parses an issue's creation time in any provider's format
+func parseIssueTime(value string) (time.Time, bool) {
+	for _, layout := range issueTimeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// localRankPRs orders pull requests by scorePR, then oldest (lowest number) first
+func localRankPRs(prs []github.PullRequest, currentUser string) []github.PullRequest {
+	ranked := slices.Clone(prs)
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if si, sj := scorePR(ranked[i], currentUser), scorePR(ranked[j], currentUser); si != sj {
+			return si > sj
+		}
+
+		return ranked[i].Number < ranked[j].Number
+	})
+
+	return ranked
+}
+
+// scorePR scores a pull request to review: one waiting on currentUser's
+// review comes first, then by priority labels, smaller changes and passing
+// checks; drafts come last
+func scorePR(pr github.PullRequest, currentUser string) int {
+	labels := make([]string, len(pr.Labels))
+	for i, label := range pr.Labels {
+		labels[i] = label.Name
+	}
+
+	score := priorityScores[providers.PriorityFromLabels(labels)]
+
+	for _, label := range labels {
+		score += labelScore(label)
+	}
+
+	if currentUser != "" && slices.ContainsFunc(pr.ReviewRequests, func(r github.ReviewRequest) bool {
+		return strings.EqualFold(r.Login, currentUser)
+	}) {
+		score += 50
+	}
+
+	switch changed := pr.Additions + pr.Deletions; {
+	case changed < 50:
+		score += 15
+	case changed < 200:
+		score += 10
+	case changed < 500:
+		score += 5
+	}
+
+	switch passing, failing, pending := countChecks(pr.StatusCheckRollup); {
+	case failing > 0:
+		score -= 10
+	case passing > 0 && pending == 0:
+		score += 10
+	}
+
+	if pr.IsDraft {
+		score -= 30
+	}
+
+	return score
+}
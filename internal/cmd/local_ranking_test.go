@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/kaeawc/auto-worktree/internal/github"
+	"github.com/kaeawc/auto-worktree/internal/providers"
+)
+
+func TestLocalRankIssues(t *testing.T) {
+	issues := []providers.Issue{
+		{ID: "1", Title: "Newer feature", CreatedAt: "2026-03-01T00:00:00Z"},
+		{ID: "2", Title: "Blocked", Labels: []string{"priority: high", "blocked"}},
+		{ID: "3", Title: "Older feature", CreatedAt: "2026-01-01T00:00:00Z"},
+		{ID: "4", Title: "Urgent", Priority: providers.PriorityUrgent},
+		{ID: "5", Title: "Crash", Labels: []string{"bug"}},
+		{ID: "6", Title: "Someday", Priority: providers.PriorityLow},
+	}
+
+	// Priority first, then bug labels, then oldest first; blocked issues sink
+	// below even low priority ones
+	want := []string{"4", "5", "3", "1", "6", "2"}
+
+	got := localRankIssues(issues)
+	for i, issue := range got {
+		if issue.ID != want[i] {
+			t.Fatalf("localRankIssues() order = %v, want %v", issueIDs(got), want)
+		}
+	}
+
+	if issues[0].ID != "1" {
+		t.Error("localRankIssues() reordered its argument")
+	}
+}
+
+func TestLocalRankPRs(t *testing.T) {
+	passing := []github.StatusCheck{{Status: "SUCCESS"}}
+	failing := []github.StatusCheck{{Status: "SUCCESS"}, {Status: "FAILURE"}}
+
+	prs := []github.PullRequest{
+		{Number: 10, Additions: 1000, StatusCheckRollup: passing},
+		{Number: 11, Additions: 10, StatusCheckRollup: passing, IsDraft: true},
+		{Number: 12, Additions: 900, ReviewRequests: []github.ReviewRequest{{Login: "Alice"}}},
+		{Number: 13, Additions: 10, StatusCheckRollup: passing},
+		{Number: 14, Additions: 10, StatusCheckRollup: failing},
+		{Number: 9, Additions: 10, StatusCheckRollup: failing},
+	}
+
+	// Review requested from alice, then small and green, then large and green,
+	// then failing checks (oldest first); drafts last
+	want := []int{12, 13, 10, 9, 14, 11}
+
+	got := localRankPRs(prs, "alice")
+	for i, pr := range got {
+		if pr.Number != want[i] {
+			numbers := make([]int, len(got))
+			for j, p := range got {
+				numbers[j] = p.Number
+			}
+
+			t.Fatalf("localRankPRs() order = %v, want %v", numbers, want)
+		}
+	}
+}
+
+func issueIDs(issues []providers.Issue) []string {
+	ids := make([]string, len(issues))
+	for i, issue := range issues {
+		ids[i] = issue.ID
+	}
+
+	return ids
+}
@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"log/slog"
+	"os"
+
+	"github.com/kaeawc/auto-worktree/internal/git"
+	"github.com/kaeawc/auto-worktree/internal/github"
+	"github.com/kaeawc/auto-worktree/internal/gitlab"
+	"github.com/kaeawc/auto-worktree/internal/limits"
+	"github.com/kaeawc/auto-worktree/internal/logging"
+	"github.com/kaeawc/auto-worktree/internal/redact"
+)
+
+// ConfigureLogging sets how much is logged to stderr and opens the log file
+// when auto-worktree.log-file is enabled. debug implies verbose.
+func ConfigureLogging(verbose, debug bool) {
+	switch {
+	case debug:
+		logging.SetLevel(slog.LevelDebug)
+	case verbose:
+		logging.SetLevel(slog.LevelInfo)
+	}
+
+	if git.NewConfig("").GetLogFileEnabled() {
+		if err := logging.OpenFile(logging.DefaultLogPath()); err != nil {
+			logging.Warn("logging to stderr only", "err", err)
+		}
+	}
+
+	logging.Debug("starting", "args", os.Args[1:])
+}
+
+// ApplyCommandLimits applies the configured timeouts and parallelism to external commands
+func ApplyCommandLimits() {
+	cfg := git.NewConfig("")
+	limits.Configure(cfg.GetCommandTimeouts(), cfg.GetMaxParallel())
+}
+
+// ApplyRedaction sets up scrubbing of secrets, including the configured patterns
+// and the values of secret variables such as GITHUB_TOKEN, from logs,
+// transcripts, hook output and AI context. Call it after ApplyStoredCredentials.
+func ApplyRedaction() {
+	redact.Configure(git.NewConfig("").GetRedactPatterns())
+}
+
+// ApplyProviderTransports applies how providers are reached (CLI or API)
+func ApplyProviderTransports() {
+	cfg := git.NewConfig("")
+	github.SetTransport(cfg.GetGitHubTransport())
+	gitlab.SetTransport(cfg.GetGitLabTransport())
+}
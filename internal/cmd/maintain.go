@@ -0,0 +1,328 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kaeawc/auto-worktree/internal/git"
+	"github.com/kaeawc/auto-worktree/internal/offline"
+	"github.com/kaeawc/auto-worktree/internal/session"
+	"github.com/kaeawc/auto-worktree/internal/ui"
+)
+
+// ErrMaintenanceFailed is returned by RunMaintain when a step failed, so cron
+// or a systemd timer reports it
+var ErrMaintenanceFailed = errors.New("maintenance had failures")
+
+// MaintainOptions configures `auto-worktree maintain`
+type MaintainOptions struct {
+	// DryRun reports what each step would do without changing anything
+	DryRun bool
+	// Quiet prints nothing unless something was removed or a step failed, so
+	// cron only mails when there is news
+	Quiet bool
+}
+
+// maintainStep is one line of the maintenance report
+type maintainStep struct {
+	Name    string
+	Summary string
+	Details []string
+	// Changed is set when the step removed something
+	Changed bool
+	// Skipped says why the step did not run
+	Skipped string
+	Err     error
+}
+
+// cleanupDecision is what maintenance does with one cleanup candidate
+type cleanupDecision struct {
+	wt           *git.Worktree
+	deleteBranch bool
+	// keep says why the worktree is left for a person, or is empty to remove it
+	keep string
+}
+
+// RunMaintain runs every housekeeping step without prompts: stale lock
+// removal, cleanup under the cleanup policy, worktree pruning, session
+// metadata GC and a status cache refresh, then prints a summary
+func RunMaintain(opts MaintainOptions) error {
+	repo, err := git.NewRepository()
+	if err != nil {
+		return fmt.Errorf("error: %w", err)
+	}
+
+	if repo.IsFrozen() {
+		if !opts.Quiet {
+			fmt.Println(ui.WarningStyle.Render("⚠ Repository is frozen; skipped maintenance (run 'auto-worktree thaw' to resume)"))
+		}
+
+		return nil
+	}
+
+	steps := []maintainStep{
+		maintainLocks(repo, opts.DryRun),
+		maintainCleanup(repo, opts.DryRun),
+		maintainPrune(repo, opts.DryRun),
+		maintainSessions(repo, opts.DryRun),
+		maintainStatusCache(repo, opts.DryRun),
+	}
+
+	failed := false
+	changed := false
+
+	for _, step := range steps {
+		failed = failed || step.Err != nil
+		changed = changed || step.Changed
+	}
+
+	if opts.Quiet && !failed && !changed {
+		return nil
+	}
+
+	printMaintainReport(repo.RootPath, steps, opts.DryRun)
+
+	if failed {
+		return ErrMaintenanceFailed
+	}
+
+	return nil
+}
+
+// maintainLocks removes lock files left by git processes that are gone
+func maintainLocks(repo *git.Repository, dryRun bool) maintainStep {
+	step := maintainStep{Name: "Stale locks"}
+
+	lockFiles, err := git.DetectLockFiles(repo.RootPath)
+	if err != nil {
+		step.Err = fmt.Errorf("error detecting lock files: %w", err)
+		return step
+	}
+
+	var errs []error
+
+	for _, lf := range git.GetStaleLockFiles(lockFiles) {
+		if !dryRun {
+			if err := git.RemoveLockFile(lf); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+		}
+
+		step.Details = append(step.Details, lf.Path)
+	}
+
+	step.Err = errors.Join(errs...)
+	step.Changed = len(step.Details) > 0
+	step.Summary = fmt.Sprintf("%s %d stale lock file(s)", maintainVerb(dryRun), len(step.Details))
+
+	return step
+}
+
+// planMaintainCleanup decides what maintenance does with each candidate under
+// policy. Orphaned worktrees are always removed, as at startup. Merged ones are
+// only removed under the auto policy, and never with unpushed commits, since
+// nobody is there to confirm.
+func planMaintainCleanup(candidates *git.StartupCleanupCandidates, policy string) []cleanupDecision {
+	decisions := make([]cleanupDecision, 0, len(candidates.Orphaned)+len(candidates.Merged))
+
+	for _, wt := range candidates.Orphaned {
+		decisions = append(decisions, cleanupDecision{wt: wt})
+	}
+
+	for _, wt := range candidates.Merged {
+		decision := cleanupDecision{wt: wt, deleteBranch: true}
+
+		switch {
+		case policy != git.CleanupPolicyAuto:
+			decision.keep = fmt.Sprintf("cleanup-policy is %s; run 'auto-worktree cleanup'", policy)
+		case wt.UnpushedCount > 0:
+			decision.keep = fmt.Sprintf("%d unpushed commit(s)", wt.UnpushedCount)
+		}
+
+		decisions = append(decisions, decision)
+	}
+
+	return decisions
+}
+
+// maintainCleanup removes the worktrees the cleanup policy allows without asking
+func maintainCleanup(repo *git.Repository, dryRun bool) maintainStep {
+	step := maintainStep{Name: "Cleanup"}
+
+	policy := repo.Config.GetCleanupPolicy()
+	if policy == git.CleanupPolicyOff {
+		step.Skipped = "cleanup-policy is off"
+		return step
+	}
+
+	candidates, err := repo.GetStartupCleanupCandidates()
+	if err != nil {
+		step.Err = fmt.Errorf("error finding cleanup candidates: %w", err)
+		return step
+	}
+
+	var (
+		errs    []error
+		removed int
+		kept    int
+	)
+
+	for _, d := range planMaintainCleanup(candidates, policy) {
+		if d.keep != "" {
+			kept++
+			step.Details = append(step.Details, fmt.Sprintf("kept %s (%s)", d.wt.Path, d.keep))
+
+			continue
+		}
+
+		if !dryRun {
+			if err := cleanupWorktree(repo, d.wt, d.deleteBranch); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", d.wt.Path, err))
+				continue
+			}
+		}
+
+		removed++
+		step.Details = append(step.Details, fmt.Sprintf("%s %s (%s)", maintainVerb(dryRun), d.wt.Path, d.wt.CleanupReason()))
+	}
+
+	step.Err = errors.Join(errs...)
+	step.Changed = removed > 0
+	step.Summary = fmt.Sprintf("%s %d worktree(s), kept %d for review", maintainVerb(dryRun), removed, kept)
+
+	return step
+}
+
+// maintainPrune drops git's records of worktrees whose directory is gone,
+// keeping them for gc.worktreePruneExpire when that is set
+func maintainPrune(repo *git.Repository, dryRun bool) maintainStep {
+	step := maintainStep{Name: "Prune"}
+	expire := repo.WorktreePruneExpire()
+
+	entries, err := repo.PrunableWorktrees(expire)
+	if err != nil {
+		step.Err = err
+		return step
+	}
+
+	for _, entry := range entries {
+		step.Details = append(step.Details, describePruneEntry(entry))
+	}
+
+	step.Changed = len(step.Details) > 0
+	step.Summary = fmt.Sprintf("%s %d missing worktree record(s)", maintainVerb(dryRun), len(step.Details))
+
+	if !dryRun && step.Changed {
+		step.Err = repo.PruneWorktreesExpire(expire)
+	}
+
+	return step
+}
+
+// maintainSessions removes the metadata of sessions whose worktree is gone
+func maintainSessions(repo *git.Repository, dryRun bool) maintainStep {
+	step := maintainStep{Name: "Sessions", Details: collectOrphanedSessions(session.NewManager(), repo.WorktreeBase, dryRun)}
+	step.Changed = len(step.Details) > 0
+	step.Summary = fmt.Sprintf("%s the metadata of %d session(s) of removed worktrees", maintainVerb(dryRun), len(step.Details))
+
+	return step
+}
+
+// maintainStatusCache refreshes every worktree's cached issue and pull
+// request, so prompts show them without asking the code host
+func maintainStatusCache(repo *git.Repository, dryRun bool) maintainStep {
+	step := maintainStep{Name: "Status cache"}
+
+	if offline.Enabled() {
+		step.Skipped = "offline (" + offline.Reason() + ")"
+		return step
+	}
+
+	worktrees, err := repo.ListWorktrees()
+	if err != nil {
+		step.Err = fmt.Errorf("error listing worktrees: %w", err)
+		return step
+	}
+
+	store, err := openStateStore()
+	if err != nil {
+		step.Err = err
+		return step
+	}
+
+	prs := newPRLookup(repo)
+	now := time.Now()
+	refreshed := 0
+
+	var errs []error
+
+	for _, wt := range worktrees {
+		if wt.Branch == "" || wt.IsOrphaned() {
+			continue
+		}
+
+		if !dryRun {
+			if _, err := cacheWorktreeStatus(store, repo, prs, wt.Path, wt.Branch, now); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+		}
+
+		refreshed++
+	}
+
+	step.Err = errors.Join(errs...)
+
+	verb := "Refreshed"
+	if dryRun {
+		verb = "Would refresh"
+	}
+
+	step.Summary = fmt.Sprintf("%s the status of %d worktree(s)", verb, refreshed)
+
+	return step
+}
+
+// maintainVerb is how the report says something was removed
+func maintainVerb(dryRun bool) string {
+	if dryRun {
+		return "Would remove"
+	}
+
+	return "Removed"
+}
+
+// printMaintainReport prints one line per step with what it did
+func printMaintainReport(root string, steps []maintainStep, dryRun bool) {
+	title := "Maintenance of " + root
+	if dryRun {
+		title += " (dry run)"
+	}
+
+	fmt.Println(ui.TitleStyle.Render(title))
+
+	for _, step := range steps {
+		mark := ui.SuccessStyle.Render("✓")
+		summary := step.Summary
+
+		switch {
+		case step.Err != nil:
+			mark = ui.ErrorStyle.Render("✗")
+		case step.Skipped != "":
+			mark = ui.SubtleStyle.Render("-")
+			summary = "Skipped: " + step.Skipped
+		}
+
+		fmt.Printf("  %s %-14s %s\n", mark, step.Name, summary)
+
+		for _, detail := range step.Details {
+			fmt.Printf("      • %s\n", detail)
+		}
+
+		if step.Err != nil {
+			fmt.Printf("      %s\n", ui.ErrorStyle.Render(step.Err.Error()))
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/kaeawc/auto-worktree/internal/git"
+)
+
+func TestPlanMaintainCleanup(t *testing.T) {
+	candidates := &git.StartupCleanupCandidates{
+		Orphaned: []*git.Worktree{{Path: "/wt/gone", Branch: "gone"}},
+		Merged: []*git.Worktree{
+			{Path: "/wt/done", Branch: "done"},
+			{Path: "/wt/unpushed", Branch: "unpushed", UnpushedCount: 2},
+		},
+	}
+
+	kept := func(decisions []cleanupDecision) map[string]bool {
+		got := map[string]bool{}
+		for _, d := range decisions {
+			got[d.wt.Branch] = d.keep != ""
+		}
+
+		return got
+	}
+
+	auto := planMaintainCleanup(candidates, git.CleanupPolicyAuto)
+	if got := kept(auto); got["gone"] || got["done"] || !got["unpushed"] {
+		t.Errorf("planMaintainCleanup(auto) kept = %v, want only the unpushed worktree kept", got)
+	}
+
+	if auto[0].deleteBranch || !auto[1].deleteBranch {
+		t.Error("only merged worktrees should have their branch deleted")
+	}
+
+	if got := kept(planMaintainCleanup(candidates, git.CleanupPolicyPrompt)); got["gone"] || !got["done"] || !got["unpushed"] {
+		t.Errorf("planMaintainCleanup(prompt) kept = %v, want every merged worktree kept", got)
+	}
+}
@@ -0,0 +1,240 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kaeawc/auto-worktree/internal/git"
+	"github.com/kaeawc/auto-worktree/internal/github"
+	"github.com/kaeawc/auto-worktree/internal/providers"
+	"github.com/kaeawc/auto-worktree/internal/ui"
+)
+
+const (
+	// overviewStaleIssueAge is how long an assigned issue can go without updates before it is flagged
+	overviewStaleIssueAge = 14 * 24 * time.Hour
+	// overviewFetchLimit caps how many PRs/issues are fetched for the overview
+	overviewFetchLimit = 100
+	// overviewRecentBranchAge is how recently a merged branch must have been
+	// committed to for it to count as active work
+	overviewRecentBranchAge = 14 * 24 * time.Hour
+)
+
+// worktreeHygiene summarizes the cleanup state of a set of worktrees
+type worktreeHygiene struct {
+	Total    int
+	Merged   int
+	Stale    int
+	Orphaned int
+	Unpushed int
+	Detached int
+}
+
+// RunOverview prints a project-health summary combining git and tracker data
+func RunOverview() error {
+	repo, err := git.NewRepository()
+	if err != nil {
+		return fmt.Errorf("error: %w", err)
+	}
+
+	prov, _ := GetProviderForRepository(repo) //nolint:errcheck
+
+	worktrees, err := repo.ListWorktreesWithAllStatusExcludingMain(prov)
+	if err != nil {
+		return fmt.Errorf("error listing worktrees: %w", err)
+	}
+
+	fmt.Println(ui.TitleStyle.Render(fmt.Sprintf("Project overview: %s", repo.SourceFolder)))
+	fmt.Println()
+
+	showWorktreeHygiene(summarizeWorktreeHygiene(worktrees))
+	showBranchesWithoutWorktrees(repo)
+
+	// Pull requests and issues may be in different systems (e.g. GitHub and JIRA)
+	githubPRs := prov != nil && providers.CodeHostType(prov) == providerGitHub
+	githubIssues := prov != nil && prov.ProviderType() == providerGitHub
+
+	if !githubPRs && !githubIssues {
+		fmt.Println(ui.SubtleStyle.Render("Tracker data is only available for GitHub repositories"))
+		return nil
+	}
+
+	client, err := github.NewClient(repo.RootPath)
+	if err != nil {
+		fmt.Println(ui.WarningStyle.Render(fmt.Sprintf("%s Could not load GitHub data: %v", iconWarning, err)))
+		return nil
+	}
+
+	if githubPRs {
+		showPRsWithoutReviewers(client)
+	}
+
+	if githubIssues {
+		showStaleAssignedIssues(client)
+	}
+
+	return nil
+}
+
+// summarizeWorktreeHygiene counts worktrees by cleanup-relevant state
+func summarizeWorktreeHygiene(worktrees []*git.Worktree) worktreeHygiene {
+	h := worktreeHygiene{Total: len(worktrees)}
+
+	for _, wt := range worktrees {
+		switch {
+		case wt.IsOrphaned():
+			h.Orphaned++
+		case wt.IsMerged():
+			h.Merged++
+		case wt.IsStale():
+			h.Stale++
+		}
+
+		if wt.UnpushedCount > 0 {
+			h.Unpushed++
+		}
+
+		if wt.IsDetached {
+			h.Detached++
+		}
+	}
+
+	return h
+}
+
+// findBranchesWithoutWorktrees returns local branches that are not checked out in any worktree
+func findBranchesWithoutWorktrees(branches []string, worktrees []*git.Worktree, defaultBranch string) []string {
+	checkedOut := make(map[string]bool, len(worktrees))
+	for _, wt := range worktrees {
+		if wt.Branch != "" {
+			checkedOut[wt.Branch] = true
+		}
+	}
+
+	var result []string
+
+	for _, branch := range branches {
+		if branch == defaultBranch || checkedOut[branch] {
+			continue
+		}
+
+		result = append(result, branch)
+	}
+
+	return result
+}
+
+// showWorktreeHygiene prints worktree hygiene statistics
+func showWorktreeHygiene(h worktreeHygiene) {
+	fmt.Println(ui.BoldStyle.Render("Worktree hygiene"))
+	fmt.Printf("  Total:     %d\n", h.Total)
+	fmt.Printf("  Merged:    %s\n", ui.MergedStyle.Render(fmt.Sprintf("%d", h.Merged)))
+	fmt.Printf("  Stale:     %s\n", ui.WarningStyle.Render(fmt.Sprintf("%d", h.Stale)))
+	fmt.Printf("  Orphaned:  %s\n", ui.ErrorStyle.Render(fmt.Sprintf("%d", h.Orphaned)))
+	fmt.Printf("  Unpushed:  %d\n", h.Unpushed)
+	fmt.Printf("  Detached:  %d\n", h.Detached)
+
+	if cleanable := h.Merged + h.Stale + h.Orphaned; cleanable > 0 {
+		fmt.Println(ui.SubtleStyle.Render(fmt.Sprintf("  %d worktree(s) can be cleaned up: auto-worktree cleanup", cleanable)))
+	}
+
+	fmt.Println()
+}
+
+// showBranchesWithoutWorktrees prints local branches with active work, unmerged
+// commits or recent ones, that have no worktree
+func showBranchesWithoutWorktrees(repo *git.Repository) {
+	fmt.Println(ui.BoldStyle.Render("Active branches without worktrees"))
+
+	defaultBranch, _ := repo.GetDefaultBranch() //nolint:errcheck
+
+	branches, err := repo.ListActiveBranches(defaultBranch, time.Now().Add(-overviewRecentBranchAge))
+	if err != nil {
+		fmt.Println(ui.WarningStyle.Render(fmt.Sprintf("  %s %v", iconWarning, err)))
+		fmt.Println()
+
+		return
+	}
+
+	// Include the main worktree so its checked-out branch is not reported
+	worktrees, err := repo.ListWorktrees()
+	if err != nil {
+		fmt.Println(ui.WarningStyle.Render(fmt.Sprintf("  %s %v", iconWarning, err)))
+		fmt.Println()
+
+		return
+	}
+
+	orphanBranches := findBranchesWithoutWorktrees(branches, worktrees, defaultBranch)
+	if len(orphanBranches) == 0 {
+		fmt.Println(ui.SuccessStyle.Render("  None"))
+	}
+
+	for _, branch := range orphanBranches {
+		fmt.Printf("  %s\n", branch)
+	}
+
+	fmt.Println()
+}
+
+// showPRsWithoutReviewers prints open, non-draft PRs that have no reviewers requested
+func showPRsWithoutReviewers(client *github.Client) {
+	fmt.Println(ui.BoldStyle.Render("Open PRs without reviewers"))
+
+	prs, err := client.ListOpenPRs(overviewFetchLimit)
+	if err != nil {
+		fmt.Println(ui.WarningStyle.Render(fmt.Sprintf("  %s %v", iconWarning, err)))
+		fmt.Println()
+
+		return
+	}
+
+	count := 0
+
+	for i := range prs {
+		if !prs[i].NeedsReviewers() {
+			continue
+		}
+
+		fmt.Printf("  #%d %s %s\n", prs[i].Number, prs[i].Title, ui.SubtleStyle.Render("@"+prs[i].Author.Login))
+		count++
+	}
+
+	if count == 0 {
+		fmt.Println(ui.SuccessStyle.Render("  None"))
+	}
+
+	fmt.Println()
+}
+
+// showStaleAssignedIssues prints open issues assigned to the current user that have gone quiet
+func showStaleAssignedIssues(client *github.Client) {
+	fmt.Println(ui.BoldStyle.Render("Stale issues assigned to me"))
+
+	issues, err := client.ListAssignedIssues(overviewFetchLimit)
+	if err != nil {
+		fmt.Println(ui.WarningStyle.Render(fmt.Sprintf("  %s %v", iconWarning, err)))
+		fmt.Println()
+
+		return
+	}
+
+	count := 0
+
+	for i := range issues {
+		updated := issues[i].LastUpdated()
+		if updated.IsZero() || time.Since(updated) < overviewStaleIssueAge {
+			continue
+		}
+
+		fmt.Printf("  #%d %s %s\n", issues[i].Number, issues[i].Title,
+			ui.SubtleStyle.Render(fmt.Sprintf("(updated %s ago)", formatAge(time.Since(updated)))))
+		count++
+	}
+
+	if count == 0 {
+		fmt.Println(ui.SuccessStyle.Render("  None"))
+	}
+
+	fmt.Println()
+}
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/kaeawc/auto-worktree/internal/git"
+)
+
+func TestSummarizeWorktreeHygiene(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+
+	worktrees := []*git.Worktree{
+		{Path: dir, Branch: "merged", LastCommitTime: now, IsBranchMerged: true},
+		{Path: dir, Branch: "stale", LastCommitTime: now.Add(-10 * 24 * time.Hour), UnpushedCount: 2},
+		{Path: filepath.Join(dir, "missing"), Branch: "gone", LastCommitTime: now},
+		{Path: dir, LastCommitTime: now, IsDetached: true},
+		{Path: dir, Branch: "active", LastCommitTime: now, UnpushedCount: 1},
+	}
+
+	got := summarizeWorktreeHygiene(worktrees)
+	want := worktreeHygiene{Total: 5, Merged: 1, Stale: 1, Orphaned: 1, Unpushed: 2, Detached: 1}
+
+	if got != want {
+		t.Errorf("summarizeWorktreeHygiene() = %+v, want %+v", got, want)
+	}
+}
+
+func TestFindBranchesWithoutWorktrees(t *testing.T) {
+	branches := []string{"main", "feature/a", "feature/b", "work/42-fix"}
+	worktrees := []*git.Worktree{
+		{Branch: "main"},
+		{Branch: "feature/a"},
+		{IsDetached: true},
+	}
+
+	got := findBranchesWithoutWorktrees(branches, worktrees, "main")
+	want := []string{"feature/b", "work/42-fix"}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("findBranchesWithoutWorktrees() = %v, want %v", got, want)
+	}
+}
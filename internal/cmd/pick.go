@@ -0,0 +1,330 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/kaeawc/auto-worktree/internal/ai"
+	"github.com/kaeawc/auto-worktree/internal/events"
+	"github.com/kaeawc/auto-worktree/internal/git"
+	"github.com/kaeawc/auto-worktree/internal/github"
+	"github.com/kaeawc/auto-worktree/internal/session"
+	"github.com/kaeawc/auto-worktree/internal/ui"
+)
+
+// PickOptions configures `auto-worktree pick`
+type PickOptions struct {
+	// Branch is the winning attempt
+	Branch string
+	// NoPR leaves the winner's pull request to be opened later
+	NoPR bool
+	// Yes removes the other attempts without asking
+	Yes bool
+	// Force also removes attempts with uncommitted changes or unpushed commits
+	Force bool
+}
+
+// RunPick marks one of several attempts at an issue as the winner, opens its
+// pull request, and offers to remove the other attempts' worktrees, branches
+// and sessions
+func RunPick(opts PickOptions) error {
+	repo, err := git.NewRepository()
+	if err != nil {
+		return fmt.Errorf("error: %w", err)
+	}
+
+	if err := errIfFrozen(repo); err != nil {
+		return err
+	}
+
+	worktrees, err := repo.ListWorktrees()
+	if err != nil {
+		return fmt.Errorf("error listing worktrees: %w", err)
+	}
+
+	matched := filterWorktreesByBranch(worktrees, opts.Branch)
+	if len(matched) == 0 {
+		return fmt.Errorf("no worktree for branch %s", opts.Branch)
+	}
+
+	winner := matched[0]
+
+	issue, siblings := attemptSiblings(repo.Config, repo.FilterOutMainBranch(worktrees), winner)
+
+	events.Record(events.Event{
+		Action:   events.ActionPick,
+		RepoPath: repo.RootPath,
+		Path:     winner.Path,
+		Branch:   winner.Branch,
+		Detail:   issue,
+	})
+
+	if issue == "" {
+		fmt.Println(ui.SuccessStyle.Render(fmt.Sprintf("✓ Picked %s", winner.Branch)))
+	} else {
+		fmt.Println(ui.SuccessStyle.Render(fmt.Sprintf("✓ Picked %s for %s", winner.Branch, issue)))
+	}
+
+	if !opts.NoPR {
+		if err := pickPullRequest(repo, winner); err != nil {
+			fmt.Println(ui.WarningStyle.Render(fmt.Sprintf("⚠ Could not open a PR: %v", err)))
+		}
+	}
+
+	switch {
+	case issue == "":
+		fmt.Println(ui.SubtleStyle.Render(fmt.Sprintf("%s isn't named for an issue, so it has no other attempts to clean up", winner.Branch)))
+		return nil
+	case len(siblings) == 0:
+		fmt.Println(ui.SubtleStyle.Render(fmt.Sprintf("No other attempts at %s", issue)))
+		return nil
+	}
+
+	return discardAttempts(repo, winner, issue, siblings, opts)
+}
+
+// attemptSiblings returns the issue winner's branch is named for and the other
+// worktrees whose branches are named for it too; protected branches are never
+// counted as attempts
+func attemptSiblings(cfg *git.Config, worktrees []*git.Worktree, winner *git.Worktree) (string, []*git.Worktree) {
+	issue := branchIssue(cfg, winner.Branch)
+	if issue == "" {
+		return "", nil
+	}
+
+	var siblings []*git.Worktree
+
+	for _, wt := range worktrees {
+		if wt.Path == winner.Path || wt.Branch == "" || wt.IsProtected {
+			continue
+		}
+
+		if branchIssue(cfg, wt.Branch) == issue {
+			siblings = append(siblings, wt)
+		}
+	}
+
+	return issue, siblings
+}
+
+// pickPullRequest opens the winner's pull request unless one is already open,
+// described by the AI tool when there is one, otherwise from its commits
+func pickPullRequest(repo *git.Repository, wt *git.Worktree) error {
+	if codeHost := resolveCodeHostType(repo.Config); codeHost != providerGitHub {
+		return fmt.Errorf("opening PRs is only supported for GitHub (code host: %s)", codeHost)
+	}
+
+	client, err := github.NewClient(repo.RootPath)
+	if err != nil {
+		return fmt.Errorf("failed to initialize GitHub client: %w", err)
+	}
+
+	pr, err := client.FindPRForBranch(wt.Branch)
+	if err != nil {
+		return err
+	}
+
+	if pr != nil {
+		fmt.Printf("✓ PR #%d is already open: %s\n", pr.Number, pr.URL)
+		return nil
+	}
+
+	baseBranch, err := repo.GetDefaultBranch()
+	if err != nil {
+		return fmt.Errorf("error getting default branch: %w", err)
+	}
+
+	var draft titledDraft
+
+	if tool, err := ai.NewResolver(repo.Config).Resolve(); err == nil && tool.ConfigKey != "jules" {
+		if draft, err = generatePRDraft(repo, tool, wt.Path, wt.Branch, baseBranch); err != nil {
+			return err
+		}
+	}
+
+	if draft.Title == "" {
+		commits, err := repo.BranchLog(wt.Path, baseBranch)
+		if err != nil {
+			return err
+		}
+
+		if draft = commitsPRDraft(commits); draft.Title == "" {
+			return fmt.Errorf("no commits on %s since %s", wt.Branch, baseBranch)
+		}
+	}
+
+	return openPullRequest(repo, newGitHubProviderFromClient(client), wt.Path, pullRequestOptions(repo, wt.Path, wt.Branch, baseBranch, draft))
+}
+
+// commitsPRDraft titles a pull request after the first of its commits, given
+// newest first as `git log --oneline` lists them, and lists them all in the body
+func commitsPRDraft(commits string) titledDraft {
+	var subjects []string
+
+	for _, line := range strings.Split(strings.TrimSpace(commits), "\n") {
+		if _, subject, ok := strings.Cut(strings.TrimSpace(line), " "); ok {
+			subjects = append(subjects, subject)
+		}
+	}
+
+	if len(subjects) == 0 {
+		return titledDraft{}
+	}
+
+	draft := titledDraft{Title: subjects[len(subjects)-1]}
+
+	if len(subjects) > 1 {
+		var body strings.Builder
+		for i := len(subjects) - 1; i >= 0; i-- {
+			body.WriteString("- " + subjects[i] + "\n")
+		}
+
+		draft.Body = strings.TrimSuffix(body.String(), "\n")
+	}
+
+	return draft
+}
+
+// discardAttempts shows the other attempts at issue and, once confirmed,
+// stops their sessions and removes their worktrees and branches. Attempts with
+// work that would be lost are kept unless opts.Force is set.
+func discardAttempts(repo *git.Repository, winner *git.Worktree, issue string, siblings []*git.Worktree, opts PickOptions) error {
+	sessionMgr := session.NewManager()
+
+	sessions := map[string]*session.Metadata{}
+	if all, err := sessionMgr.LoadAllSessionMetadata(); err == nil {
+		for _, m := range all {
+			sessions[m.WorktreePath] = m
+		}
+	}
+
+	tests := loadTestResults()
+
+	fmt.Println()
+	fmt.Println(ui.TitleStyle.Render(fmt.Sprintf("Other attempts at %s:", issue)))
+
+	var discard, kept []*git.Worktree
+
+	for _, wt := range siblings {
+		unsaved := attemptUnsavedWork(repo, wt)
+
+		line := fmt.Sprintf("  • %s  %s  tests %s", wt.Branch, ui.SubtleStyle.Render(wt.Path), formatTestResult(tests[wt.Path], wt.HEAD))
+		if _, ok := sessions[wt.Path]; ok {
+			line += ui.SubtleStyle.Render("  (has a session)")
+		}
+
+		if unsaved != "" {
+			line += "  " + ui.WarningStyle.Render("⚠ "+unsaved)
+		}
+
+		fmt.Println(line)
+
+		if unsaved != "" && !opts.Force {
+			kept = append(kept, wt)
+		} else {
+			discard = append(discard, wt)
+		}
+	}
+
+	fmt.Println()
+
+	if len(kept) > 0 {
+		fmt.Println(ui.WarningStyle.Render(fmt.Sprintf("⚠ Keeping %d attempt(s) with uncommitted or unpushed work; pick --force removes them too", len(kept))))
+	}
+
+	if len(discard) == 0 {
+		return nil
+	}
+
+	if !opts.Yes {
+		result, err := ui.Run(ui.NewConfirmModel(fmt.Sprintf("Remove these %d worktree(s) with their branches and sessions?", len(discard))))
+		if err != nil {
+			return fmt.Errorf("error getting confirmation: %w", err)
+		}
+
+		confirmed, ok := result.(ui.ConfirmModel)
+		if !ok || !confirmed.GetChoice() {
+			return nil
+		}
+	}
+
+	removed := 0
+
+	for _, wt := range discard {
+		fmt.Printf("Removing %s...\n", filepath.Base(wt.Path))
+
+		stopAttemptSession(sessionMgr, wt, sessions[wt.Path])
+		removeSandboxes(wt.Path)
+
+		err := repo.WithOperationLock("removing a worktree", func(locked *git.Repository) error {
+			if err := locked.RemoveWorktree(wt.Path); err != nil {
+				return err
+			}
+
+			recordCleanupReason(locked, wt, "superseded by "+winner.Branch)
+
+			if err := locked.DeleteBranch(wt.Branch); err != nil {
+				fmt.Printf("  %s Failed to delete branch: %v\n", ui.WarningStyle.Render("!"), err)
+			}
+
+			return nil
+		})
+		if err != nil {
+			fmt.Printf("  %s Failed to remove: %v\n", ui.ErrorStyle.Render("✗"), err)
+			continue
+		}
+
+		removed++
+	}
+
+	fmt.Println(ui.SuccessStyle.Render(fmt.Sprintf("✓ Removed %d other attempt(s) at %s", removed, issue)))
+
+	return nil
+}
+
+// attemptUnsavedWork describes the work removing the attempt would lose: its
+// uncommitted changes and unpushed commits, or "" when there is none
+func attemptUnsavedWork(repo *git.Repository, wt *git.Worktree) string {
+	dirty, err := repo.HasUncommittedChanges(wt.Path)
+	if err != nil {
+		// Unknown is treated as dirty, so it isn't removed unasked
+		dirty = true
+	}
+
+	return unsavedWork(dirty, wt.UnpushedCount)
+}
+
+// unsavedWork describes uncommitted changes and unpushed commits, or "" when there are none
+func unsavedWork(dirty bool, unpushed int) string {
+	var parts []string
+	if dirty {
+		parts = append(parts, "uncommitted changes")
+	}
+
+	if unpushed > 0 {
+		parts = append(parts, fmt.Sprintf("%d unpushed commit(s)", unpushed))
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// stopAttemptSession kills the worktree's session if it is running and forgets its metadata
+func stopAttemptSession(sessionMgr *session.SessionManager, wt *git.Worktree, metadata *session.Metadata) {
+	name := session.GenerateSessionName(wt.Branch)
+	if metadata != nil {
+		name = metadata.SessionName
+	}
+
+	if running, err := sessionMgr.HasSession(name); err == nil && running {
+		if err := sessionMgr.KillSession(name); err != nil {
+			fmt.Printf("  %s Failed to stop session %s: %v\n", ui.WarningStyle.Render("!"), name, err)
+		}
+	}
+
+	if metadata != nil {
+		if err := sessionMgr.DeleteSessionMetadata(name); err != nil {
+			fmt.Printf("  %s Failed to remove session metadata: %v\n", ui.WarningStyle.Render("!"), err)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/kaeawc/auto-worktree/internal/git"
+)
+
+func TestAttemptSiblings(t *testing.T) {
+	cfg := git.NewConfigWithExecutor("/fake/repo", git.NewFakeGitExecutor())
+
+	winner := &git.Worktree{Path: "/wt/fix-2", Branch: "work/123-fix-login-2"}
+	worktrees := []*git.Worktree{
+		{Path: "/wt/fix", Branch: "work/123-fix-login"},
+		winner,
+		{Path: "/wt/other", Branch: "work/45-other"},
+		{Path: "/wt/fix-3", Branch: "work/123-retry", IsProtected: true},
+		{Path: "/wt/detached"},
+	}
+
+	issue, siblings := attemptSiblings(cfg, worktrees, winner)
+	if issue != "#123" {
+		t.Errorf("issue = %q, want #123", issue)
+	}
+
+	if len(siblings) != 1 || siblings[0].Path != "/wt/fix" {
+		t.Errorf("siblings = %v, want only /wt/fix", siblings)
+	}
+
+	if issue, siblings := attemptSiblings(cfg, worktrees, &git.Worktree{Path: "/wt/x", Branch: "spike"}); issue != "" || siblings != nil {
+		t.Errorf("attemptSiblings(spike) = %q, %v; want no issue", issue, siblings)
+	}
+}
+
+func TestUnsavedWork(t *testing.T) {
+	tests := []struct {
+		dirty    bool
+		unpushed int
+		want     string
+	}{
+		{false, 0, ""},
+		{true, 0, "uncommitted changes"},
+		{false, 2, "2 unpushed commit(s)"},
+		{true, 1, "uncommitted changes, 1 unpushed commit(s)"},
+	}
+
+	for _, tt := range tests {
+		if got := unsavedWork(tt.dirty, tt.unpushed); got != tt.want {
+			t.Errorf("unsavedWork(%v, %d) = %q, want %q", tt.dirty, tt.unpushed, got, tt.want)
+		}
+	}
+}
+
+func TestCommitsPRDraft(t *testing.T) {
+	draft := commitsPRDraft("c3c3c3c Handle empty passwords\nb2b2b2b Add tests\na1a1a1a Fix login with spaces\n")
+	if draft.Title != "Fix login with spaces" {
+		t.Errorf("Title = %q, want the first commit", draft.Title)
+	}
+
+	if want := "- Fix login with spaces\n- Add tests\n- Handle empty passwords"; draft.Body != want {
+		t.Errorf("Body = %q, want %q", draft.Body, want)
+	}
+
+	if draft := commitsPRDraft("a1a1a1a Fix login\n"); draft.Title != "Fix login" || draft.Body != "" {
+		t.Errorf("single commit draft = %+v", draft)
+	}
+
+	if draft := commitsPRDraft(""); draft.Title != "" {
+		t.Errorf("no commits draft = %+v, want empty", draft)
+	}
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/kaeawc/auto-worktree/internal/git"
+	"github.com/kaeawc/auto-worktree/internal/interrupt"
+	"github.com/kaeawc/auto-worktree/internal/plugin"
+	"github.com/kaeawc/auto-worktree/internal/ui"
+)
+
+// Environment variables passed to command plugins
+const (
+	pluginBinEnv          = "AUTO_WORKTREE_BIN"
+	pluginRepoEnv         = "AUTO_WORKTREE_REPO"
+	pluginWorktreeBaseEnv = "AUTO_WORKTREE_WORKTREE_BASE"
+)
+
+// RunPlugins lists the command plugins on PATH and the provider plugins in
+// ~/.auto-worktree/plugins
+func RunPlugins() error {
+	fmt.Println(ui.TitleStyle.Render("Command plugins"))
+
+	commands := plugin.Commands()
+	if len(commands) == 0 {
+		fmt.Println(ui.SubtleStyle.Render("  None. Put an executable named " + plugin.CommandPrefix + "<name> on PATH."))
+	}
+
+	for _, name := range commands {
+		path, _ := plugin.FindCommand(name)
+		fmt.Printf("  %-20s %s\n", name, ui.SubtleStyle.Render(path))
+	}
+
+	dir, err := plugin.Dir()
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+	fmt.Println(ui.TitleStyle.Render("Provider plugins"))
+
+	manifests, err := plugin.LoadManifests(dir)
+	if err != nil {
+		return err
+	}
+
+	if len(manifests) == 0 {
+		fmt.Println(ui.SubtleStyle.Render("  None. Add a <name>.json manifest to " + dir + "."))
+	}
+
+	for i := range manifests {
+		m := &manifests[i]
+
+		status := ui.SuccessStyle.Render("✓")
+		if _, err := m.Executable(); err != nil {
+			status = ui.WarningStyle.Render("⚠ " + err.Error())
+		}
+
+		fmt.Printf("  %s %-20s %s\n", status, git.IssueProviderPluginPrefix+m.Name, m.Description)
+	}
+
+	return nil
+}
+
+// RunPluginCommand runs a command plugin with the user's terminal, telling it
+// where the auto-worktree binary and the current repository are. A plugin
+// that exits non-zero returns an *exec.ExitError.
+func RunPluginCommand(path string, args []string) error {
+	command := exec.CommandContext(context.Background(), path, args...) //nolint:gosec // the plugin the user ran
+	command.Stdin = os.Stdin
+	command.Stdout = os.Stdout
+	command.Stderr = os.Stderr
+	command.Env = append(os.Environ(), pluginEnv()...)
+
+	return interrupt.Foreground(command.Run)
+}
+
+// pluginEnv returns what a command plugin is told about auto-worktree; outside
+// a repository the repository variables are left unset
+func pluginEnv() []string {
+	var env []string
+
+	if exe, err := os.Executable(); err == nil {
+		env = append(env, pluginBinEnv+"="+exe)
+	}
+
+	if repo, err := git.NewRepository(); err == nil {
+		env = append(env, pluginRepoEnv+"="+repo.RootPath, pluginWorktreeBaseEnv+"="+repo.WorktreeBase)
+	}
+
+	return env
+}
@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kaeawc/auto-worktree/internal/git"
+	"github.com/kaeawc/auto-worktree/internal/interrupt"
+	"github.com/kaeawc/auto-worktree/internal/logging"
+	"github.com/kaeawc/auto-worktree/internal/providers"
+)
+
+// maxCodeOwnerReviewers caps the reviewers suggested from CODEOWNERS, so that a
+// catch-all pattern doesn't request a review from every team in the file
+const maxCodeOwnerReviewers = 3
+
+// pullRequestOptions builds the pull request for branch: the generated title and
+// body, the configured reviewers, labels and draft status, and the CODEOWNERS
+// of the files it changes unless auto-worktree.pr-codeowners is false
+func pullRequestOptions(repo *git.Repository, worktreePath, branch, baseBranch string, draft titledDraft) providers.CreatePullRequestOptions {
+	cfg := repo.Config
+
+	opts := providers.CreatePullRequestOptions{
+		Title:      draft.Title,
+		Body:       draft.Body,
+		BaseBranch: baseBranch,
+		HeadBranch: branch,
+		Reviewers:  cfg.GetPRReviewers(),
+		Labels:     cfg.GetPRLabels(),
+		Draft:      cfg.GetPRDraft(),
+	}
+
+	if cfg.GetPRCodeOwners() {
+		opts.Reviewers = mergeReviewers(opts.Reviewers, codeOwnerReviewers(repo, worktreePath, baseBranch))
+	}
+
+	return opts
+}
+
+// codeOwnerReviewers suggests reviewers from the worktree's CODEOWNERS for the
+// files changed since baseBranch; without CODEOWNERS there are none
+func codeOwnerReviewers(repo *git.Repository, worktreePath, baseBranch string) []string {
+	owners, err := git.LoadCodeOwners(worktreePath)
+	if err != nil {
+		logging.Warn("failed to read CODEOWNERS", "err", err)
+		return nil
+	}
+
+	if owners == nil {
+		return nil
+	}
+
+	files, err := repo.BranchChangedFiles(worktreePath, baseBranch)
+	if err != nil {
+		logging.Warn("failed to list changed files for CODEOWNERS", "err", err)
+		return nil
+	}
+
+	suggested := owners.SuggestReviewers(files)
+
+	return suggested[:min(len(suggested), maxCodeOwnerReviewers)]
+}
+
+// mergeReviewers appends the suggested reviewers that aren't already listed
+func mergeReviewers(reviewers, suggested []string) []string {
+	for _, s := range suggested {
+		listed := false
+
+		for _, r := range reviewers {
+			if strings.EqualFold(r, s) {
+				listed = true
+				break
+			}
+		}
+
+		if !listed {
+			reviewers = append(reviewers, s)
+		}
+	}
+
+	return reviewers
+}
+
+// openPullRequest pushes the branch and opens a pull request for it
+func openPullRequest(repo *git.Repository, provider providers.Provider, worktreePath string, opts providers.CreatePullRequestOptions) error {
+	fmt.Printf("\nPushing %s...\n", opts.HeadBranch)
+
+	if err := repo.PushBranch(worktreePath, opts.HeadBranch); err != nil {
+		return err
+	}
+
+	pr, err := provider.CreatePullRequest(interrupt.Context(), opts)
+	if err != nil {
+		return err
+	}
+
+	kind := "PR"
+	if opts.Draft {
+		kind = "draft PR"
+	}
+
+	fmt.Printf("\n✓ Opened %s #%d: %s\n", kind, pr.Number, pr.URL)
+
+	if len(pr.ReviewersRequested) > 0 {
+		fmt.Printf("  Reviewers: %s\n", strings.Join(pr.ReviewersRequested, ", "))
+	}
+
+	if len(pr.Labels) > 0 {
+		fmt.Printf("  Labels: %s\n", strings.Join(pr.Labels, ", "))
+	}
+
+	return nil
+}
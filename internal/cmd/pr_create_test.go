@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kaeawc/auto-worktree/internal/git"
+	"github.com/kaeawc/auto-worktree/internal/providers"
+	"github.com/kaeawc/auto-worktree/internal/providers/stubs"
+)
+
+func TestPullRequestOptions(t *testing.T) {
+	worktree := t.TempDir()
+	if err := os.WriteFile(filepath.Join(worktree, "CODEOWNERS"),
+		[]byte("* @acme/core\n/docs/ @writer\n/api/ @alice @bob\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	executor := git.NewFakeGitExecutor()
+	executor.SetResponse("config --local --get "+git.ConfigPRReviewers, "@alice, carol")
+	executor.SetResponse("config --local --get "+git.ConfigPRLabels, "agent, needs review")
+	executor.SetResponse("config --local --get --bool "+git.ConfigPRDraft, "true")
+	executor.SetResponse("config --local --get --bool "+git.ConfigPRCodeOwners, "true")
+	executor.SetResponse("diff --name-only main...HEAD", "api/handler.go\ndocs/guide.md\napi/routes.go\n")
+
+	repo, err := git.NewRepositoryFromPathWithDeps("/fake/repo", executor, git.NewFakeFileSystem())
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+
+	repo.Config = git.NewConfigWithExecutor("/fake/repo", executor)
+
+	opts := pullRequestOptions(repo, worktree, "work/api", "main", titledDraft{Title: "Add API", Body: "Details"})
+
+	if opts.Title != "Add API" || opts.HeadBranch != "work/api" || opts.BaseBranch != "main" || !opts.Draft {
+		t.Errorf("pullRequestOptions() = %+v", opts)
+	}
+
+	// Configured reviewers come first; CODEOWNERS add those not already listed,
+	// most files owned first, up to maxCodeOwnerReviewers
+	if want := []string{"alice", "carol", "bob", "writer"}; !reflect.DeepEqual(opts.Reviewers, want) {
+		t.Errorf("Reviewers = %v, want %v", opts.Reviewers, want)
+	}
+
+	if want := []string{"agent", "needs review"}; !reflect.DeepEqual(opts.Labels, want) {
+		t.Errorf("Labels = %v, want %v", opts.Labels, want)
+	}
+
+	executor.SetResponse("config --local --get --bool "+git.ConfigPRCodeOwners, "false")
+
+	opts = pullRequestOptions(repo, worktree, "work/api", "main", titledDraft{Title: "Add API"})
+	if want := []string{"alice", "carol"}; !reflect.DeepEqual(opts.Reviewers, want) {
+		t.Errorf("Reviewers with pr-codeowners false = %v, want %v", opts.Reviewers, want)
+	}
+}
+
+func TestOpenPullRequest(t *testing.T) {
+	executor := git.NewFakeGitExecutor()
+
+	repo, err := git.NewRepositoryFromPathWithDeps("/fake/repo", executor, git.NewFakeFileSystem())
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+
+	stub := stubs.NewStubProvider("GitHub", "github")
+
+	opts := providers.CreatePullRequestOptions{
+		Title: "Add API", BaseBranch: "main", HeadBranch: "work/api", Reviewers: []string{"alice"},
+	}
+
+	if err := openPullRequest(repo, stub, "/wt/api", opts); err != nil {
+		t.Fatalf("openPullRequest() error = %v", err)
+	}
+
+	if got := strings.Join(executor.GetLastCommand(), " "); got != "[in:/wt/api] push -u origin work/api" {
+		t.Errorf("last git command = %q, want the branch pushed", got)
+	}
+
+	if len(stub.Calls) != 1 || stub.Calls[0].Method != "CreatePullRequest" {
+		t.Fatalf("calls = %+v, want one CreatePullRequest", stub.Calls)
+	}
+
+	pr := stub.PullRequests["1"]
+	if pr == nil || !reflect.DeepEqual(pr.ReviewersRequested, []string{"alice"}) {
+		t.Errorf("created PR = %+v", pr)
+	}
+}
@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kaeawc/auto-worktree/internal/git"
+	"github.com/kaeawc/auto-worktree/internal/gitlab"
+	"github.com/kaeawc/auto-worktree/internal/logging"
+	"github.com/kaeawc/auto-worktree/internal/ui"
+)
+
+// runMergeRequestReview reviews a GitLab merge request like RunPRWithOptions
+// does a GitHub pull request: mrID is its IID ("12" or "!12"), or empty to
+// pick one from the open merge requests
+func runMergeRequestReview(repo *git.Repository, mrID string, opts PROptions) error {
+	if err := checkProviderAuth(providerGitLab); err != nil {
+		return err
+	}
+
+	client, err := gitlab.NewClient(repo.RootPath)
+	if err != nil {
+		return handleGitLabClientError(err)
+	}
+
+	var iid int
+	if mrID == "" {
+		iid, err = selectMRInteractive(client, repo)
+		if err != nil {
+			return err
+		}
+	} else {
+		iid, err = parsePRNumber(strings.TrimPrefix(mrID, "!"))
+		if err != nil {
+			return fmt.Errorf("invalid MR number: %s", mrID)
+		}
+	}
+
+	mr, err := client.GetMR(iid)
+	if err != nil {
+		return fmt.Errorf("failed to fetch MR !%d: %w", iid, err)
+	}
+
+	switch mr.State {
+	case "merged":
+		return fmt.Errorf("MR !%d is already merged", iid)
+	case "closed":
+		logging.Warn(fmt.Sprintf("MR !%d is closed but not merged", iid))
+	}
+
+	recordHistory(repo, mr.Title, "pr", strconv.Itoa(mr.IID))
+
+	fmt.Printf("\n━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
+	fmt.Printf("MR !%d: %s\n", mr.IID, mr.Title)
+	fmt.Printf("Author: @%s\n", mr.Author.Username)
+	fmt.Printf("Target: %s ← Source: %s\n", mr.TargetBranch, mr.SourceBranch)
+
+	if mr.WorkInProgress {
+		fmt.Printf("Status: DRAFT\n")
+	}
+
+	if len(mr.Labels) > 0 {
+		fmt.Printf("Labels: %s\n", strings.Join(mr.Labels, ", "))
+	}
+
+	if mr.MergeStatus == "cannot_be_merged" {
+		fmt.Printf("\n⚠️  Warning: This MR has merge conflicts with %s\n", mr.TargetBranch)
+	}
+
+	fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n\n")
+
+	return startPRReview(repo, prReview{
+		Label:   fmt.Sprintf("MR !%d", mr.IID),
+		Title:   mr.Title,
+		URL:     mr.WebURL,
+		Branch:  mr.BranchName(),
+		Head:    mr.Head(),
+		Context: buildMRContextFromGitLab(mr),
+	}, opts)
+}
+
+// buildMRContextFromGitLab creates a context prompt for an AI tool from GitLab MR details
+func buildMRContextFromGitLab(mr *gitlab.MergeRequest) string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "I'm reviewing GitLab merge request !%d.\n", mr.IID)
+	fmt.Fprintf(&sb, "Title: %s\n", mr.Title)
+	fmt.Fprintf(&sb, "Branch: %s -> %s\n", mr.SourceBranch, mr.TargetBranch)
+
+	if mr.Description != "" {
+		fmt.Fprintf(&sb, "\n%s\n", mr.Description)
+	}
+
+	sb.WriteString("\nPlease review this merge request.")
+
+	return sb.String()
+}
+
+// selectMRInteractive lets the user pick one of the open merge requests and returns its IID
+func selectMRInteractive(client *gitlab.Client, repo *git.Repository) (int, error) {
+	fmt.Println("Fetching merge requests...")
+
+	mrs, err := client.ListOpenMRs(100)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch MRs: %w", err)
+	}
+
+	if len(mrs) == 0 {
+		return 0, fmt.Errorf("no open merge requests found")
+	}
+
+	items := make([]ui.FilterableListItem, len(mrs))
+	for i, mr := range mrs {
+		wt, err := repo.GetWorktreeForBranch(mr.BranchName())
+		if err != nil {
+			wt = nil
+		}
+
+		items[i] = ui.NewFilterableListItem(mr.IID, mr.Title, mr.Labels, wt != nil)
+	}
+
+	m, err := ui.Run(ui.NewFilterList("Select a merge request to review", items), tea.WithAltScreen())
+	if err != nil {
+		return 0, fmt.Errorf("failed to run MR selector: %w", err)
+	}
+
+	finalModel, ok := m.(ui.FilterListModel)
+	if !ok {
+		return 0, fmt.Errorf("unexpected model type")
+	}
+
+	if finalModel.Err() != nil {
+		return 0, finalModel.Err()
+	}
+
+	choice := finalModel.Choice()
+	if choice == nil {
+		return 0, fmt.Errorf("no MR selected")
+	}
+
+	return choice.Number(), nil
+}
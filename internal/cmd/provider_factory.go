@@ -5,12 +5,16 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"github.com/kaeawc/auto-worktree/internal/interrupt"
+	"strings"
+	"time"
 
 	"github.com/kaeawc/auto-worktree/internal/git"
 	"github.com/kaeawc/auto-worktree/internal/github"
 	"github.com/kaeawc/auto-worktree/internal/gitlab"
 	"github.com/kaeawc/auto-worktree/internal/jira"
 	"github.com/kaeawc/auto-worktree/internal/linear"
+	"github.com/kaeawc/auto-worktree/internal/plugin"
 	"github.com/kaeawc/auto-worktree/internal/providers"
 	"github.com/kaeawc/auto-worktree/internal/providers/stubs"
 )
@@ -23,8 +27,59 @@ const (
 )
 
 // GetProviderForRepository returns the appropriate provider for the given repository
-// based on configuration or auto-detection
+// based on configuration or auto-detection.
+// When a code host is configured separately from the issue provider (e.g. JIRA issues
+// with GitHub pull requests), issue operations go to the issue provider and pull
+// request operations go to the code host.
 func GetProviderForRepository(repo *git.Repository) (providers.Provider, error) {
+	issueProvider, err := GetIssueProviderForRepository(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	codeHost := git.NewConfig(repo.RootPath).GetCodeHost()
+	if codeHost == "" || codeHost == issueProvider.ProviderType() {
+		return issueProvider, nil
+	}
+
+	codeHostProvider, err := newCodeHostProvider(repo, codeHost)
+	if err != nil {
+		return nil, err
+	}
+
+	return providers.NewSplitProvider(issueProvider, codeHostProvider), nil
+}
+
+// resolveCodeHostType returns the provider type hosting pull requests for the repository:
+// the configured code host, else the issue provider if it hosts code, else GitHub
+func resolveCodeHostType(cfg *git.Config) string {
+	if codeHost := cfg.GetCodeHost(); codeHost != "" {
+		return codeHost
+	}
+
+	if issueProvider := cfg.GetIssueProvider(); issueProvider == providerGitLab {
+		return providerGitLab
+	}
+
+	return providerGitHub
+}
+
+// newCodeHostProvider creates the provider used for pull/merge requests
+func newCodeHostProvider(repo *git.Repository, codeHost string) (providers.Provider, error) {
+	switch codeHost {
+	case providerGitHub:
+		return newGitHubProvider(repo)
+	case providerGitLab:
+		return newGitLabProvider(repo)
+	default:
+		return nil, fmt.Errorf("unsupported code host: %s (must be one of: %s)",
+			codeHost, strings.Join(git.ValidCodeHosts, ", "))
+	}
+}
+
+// GetIssueProviderForRepository returns the issue tracking provider for the repository
+// based on the issue-provider configuration or auto-detection
+func GetIssueProviderForRepository(repo *git.Repository) (providers.Provider, error) {
 	cfg := git.NewConfig(repo.RootPath)
 
 	providerType := cfg.GetIssueProvider()
@@ -42,21 +97,82 @@ func GetProviderForRepository(repo *git.Repository) (providers.Provider, error)
 		// Try to auto-detect from the repo
 		return autoDetectProvider(repo)
 	default:
+		if name, ok := git.PluginProviderName(providerType); ok {
+			return newPluginProvider(repo, name)
+		}
+
 		return nil, fmt.Errorf("unknown provider type: %s", providerType)
 	}
 }
 
-// newGitHubProvider creates a GitHub provider
-func newGitHubProvider(repo *git.Repository) (providers.Provider, error) {
-	executor := github.NewGitHubExecutor()
-	installInfo := GitHubInstallInfo()
+// newPluginProvider creates the provider for a plugin from ~/.auto-worktree/plugins
+func newPluginProvider(repo *git.Repository, name string) (providers.Provider, error) {
+	manifest, err := plugin.FindProvider(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := manifest.Executable(); err != nil {
+		return nil, err
+	}
+
+	return plugin.NewProvider(*manifest, repo.RootPath), nil
+}
+
+// checkProviderAuth returns nil when the provider's CLI is installed and signed in,
+// or an error with install or login instructions otherwise
+func checkProviderAuth(provider string) error {
+	var (
+		installInfo   *ProviderInstallInfo
+		installed     bool
+		authenticated func() error
+	)
+
+	switch provider {
+	case providerGitHub:
+		executor := github.NewGitHubExecutor()
+		installInfo, installed = GitHubInstallInfo(), github.IsInstalled(executor)
+		authenticated = func() error { return github.IsAuthenticated(executor) }
+	case providerGitLab:
+		executor := gitlab.NewGitLabExecutor()
+		installInfo, installed = GitLabInstallInfo(), gitlab.IsInstalled(executor)
+		authenticated = func() error { return gitlab.IsAuthenticated(executor) }
+	case providerJira:
+		if jiraAPIToken() != "" {
+			// An API token needs no CLI; newJIRAProvider reports a bad token on first use
+			return nil
+		}
+
+		installInfo, installed = JIRAInstallInfo(), jira.IsInstalled(interrupt.Context())
+		authenticated = func() error { return jira.IsConfigured(interrupt.Context()) }
+	case providerLinear:
+		if linear.APIKeyFromEnv() != "" {
+			// The API needs no CLI; newLinearProvider checks the key
+			return nil
+		}
 
-	if !github.IsInstalled(executor) {
-		return nil, errors.New(installInfo.FormatNotInstalledError())
+		executor := linear.NewExecutor()
+		installInfo, installed = LinearInstallInfo(), linear.IsInstalled(executor)
+		authenticated = func() error { return linear.IsAuthenticated(executor) }
+	default:
+		return fmt.Errorf("unknown provider type: %s", provider)
+	}
+
+	if !installed {
+		return errors.New(installInfo.FormatNotInstalledError())
 	}
 
-	if err := github.IsAuthenticated(executor); err != nil {
-		return nil, errors.New(installInfo.FormatNotAuthenticatedError())
+	if err := authenticated(); err != nil {
+		return errors.New(installInfo.FormatNotAuthenticatedError())
+	}
+
+	return nil
+}
+
+// newGitHubProvider creates a GitHub provider
+func newGitHubProvider(repo *git.Repository) (providers.Provider, error) {
+	if err := checkProviderAuth(providerGitHub); err != nil {
+		return nil, err
 	}
 
 	client, err := github.NewClient(repo.RootPath)
@@ -64,7 +180,13 @@ func newGitHubProvider(repo *git.Repository) (providers.Provider, error) {
 		return nil, handleGitHubClientError(err)
 	}
 
-	return newGitHubProviderFromClient(client), nil
+	shim := newGitHubProviderFromClient(client)
+
+	if project := repo.Config.GetGitHubProject(); project != "" {
+		return newGitHubProjectShim(shim.(*githubProviderShim), repo.Config, project)
+	}
+
+	return shim, nil
 }
 
 // handleGitHubClientError converts GitHub client errors to user-friendly messages
@@ -93,8 +215,18 @@ type githubProviderShim struct {
 	client *github.Client
 }
 
-func (g *githubProviderShim) ListIssues(_ context.Context, limit int) ([]providers.Issue, error) {
-	issues, err := g.client.ListOpenIssues(limit)
+func (g *githubProviderShim) ListIssues(_ context.Context, opts providers.ListIssuesOptions) ([]providers.Issue, error) {
+	milestone, err := g.milestone(opts.Milestone)
+	if err != nil {
+		return nil, err
+	}
+
+	issues, err := g.client.ListFilteredIssues(opts.EffectiveLimit(), github.IssueFilter{
+		Labels:    opts.Labels,
+		Assignee:  opts.Assignee,
+		Milestone: milestone,
+		Search:    opts.Search,
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -108,19 +240,29 @@ func (g *githubProviderShim) ListIssues(_ context.Context, limit int) ([]provide
 		}
 
 		result = append(result, providers.Issue{
-			ID:     fmt.Sprintf("%d", issues[i].Number),
-			Number: issues[i].Number,
-			Title:  issues[i].Title,
-			Body:   issues[i].Body,
-			URL:    issues[i].URL,
-			State:  issues[i].State,
-			Labels: labelNames,
+			ID:       fmt.Sprintf("%d", issues[i].Number),
+			Number:   issues[i].Number,
+			Title:    issues[i].Title,
+			Body:     issues[i].Body,
+			URL:      issues[i].URL,
+			State:    issues[i].State,
+			Labels:   labelNames,
+			Priority: providers.PriorityFromLabels(labelNames),
 		})
 	}
 
 	return result, nil
 }
 
+// milestone resolves providers.MilestoneCurrent to the open milestone due soonest
+func (g *githubProviderShim) milestone(milestone string) (string, error) {
+	if milestone != providers.MilestoneCurrent {
+		return milestone, nil
+	}
+
+	return g.client.CurrentMilestone()
+}
+
 func (g *githubProviderShim) GetIssue(_ context.Context, id string) (*providers.Issue, error) {
 	// Parse issue number from ID
 	var issueNum int
@@ -138,13 +280,14 @@ func (g *githubProviderShim) GetIssue(_ context.Context, id string) (*providers.
 	}
 
 	return &providers.Issue{
-		ID:     fmt.Sprintf("%d", issue.Number),
-		Number: issue.Number,
-		Title:  issue.Title,
-		Body:   issue.Body,
-		URL:    issue.URL,
-		State:  issue.State,
-		Labels: labelNames,
+		ID:       fmt.Sprintf("%d", issue.Number),
+		Number:   issue.Number,
+		Title:    issue.Title,
+		Body:     issue.Body,
+		URL:      issue.URL,
+		State:    issue.State,
+		Labels:   labelNames,
+		Priority: providers.PriorityFromLabels(labelNames),
 	}, nil
 }
 
@@ -181,8 +324,58 @@ func (g *githubProviderShim) CreateIssue(_ context.Context, title, body string)
 	}, nil
 }
 
-func (g *githubProviderShim) CreatePullRequest(_ context.Context, _, _, _, _ string) (*providers.PullRequest, error) {
-	return nil, errors.New("not implemented")
+func (g *githubProviderShim) CreatePullRequest(_ context.Context, opts providers.CreatePullRequestOptions) (*providers.PullRequest, error) {
+	pr, err := g.client.CreatePR(github.CreatePROptions{
+		Title:     opts.Title,
+		Body:      opts.Body,
+		Base:      opts.BaseBranch,
+		Head:      opts.HeadBranch,
+		Reviewers: opts.Reviewers,
+		Labels:    opts.Labels,
+		Draft:     opts.Draft,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &providers.PullRequest{
+		ID:         fmt.Sprintf("%d", pr.Number),
+		Number:     pr.Number,
+		Title:      pr.Title,
+		Body:       pr.Body,
+		URL:        pr.URL,
+		State:      pr.State,
+		HeadBranch: pr.HeadRefName,
+		BaseBranch: pr.BaseRefName,
+		Labels:     opts.Labels,
+	}
+
+	for _, request := range pr.ReviewRequests {
+		result.ReviewersRequested = append(result.ReviewersRequested, request.Login)
+	}
+
+	return result, nil
+}
+
+func (g *githubProviderShim) AddAssignee(_ context.Context, id, assignee string) error {
+	var issueNum int
+	_, _ = fmt.Sscanf(id, "%d", &issueNum) //nolint:gosec,errcheck
+
+	return g.client.AddAssignee(issueNum, assignee)
+}
+
+func (g *githubProviderShim) AddComment(_ context.Context, id, body string) error {
+	var issueNum int
+	_, _ = fmt.Sscanf(id, "%d", &issueNum) //nolint:gosec,errcheck
+
+	return g.client.AddComment(issueNum, body)
+}
+
+func (g *githubProviderShim) AddLabels(_ context.Context, id string, labels []string) error {
+	var issueNum int
+	_, _ = fmt.Sscanf(id, "%d", &issueNum) //nolint:gosec,errcheck
+
+	return g.client.AddLabels(issueNum, labels)
 }
 
 func (g *githubProviderShim) GetBranchNameSuffix(issue *providers.Issue) string {
@@ -202,17 +395,90 @@ func (g *githubProviderShim) ProviderType() string {
 	return "github"
 }
 
-// newGitLabProvider creates a GitLab provider
-func newGitLabProvider(repo *git.Repository) (providers.Provider, error) {
-	executor := gitlab.NewGitLabExecutor()
-	installInfo := GitLabInstallInfo()
+func (g *githubProviderShim) IssueComments(_ context.Context, id string) ([]providers.Comment, error) {
+	var issueNum int
+	_, _ = fmt.Sscanf(id, "%d", &issueNum) //nolint:gosec,errcheck
+
+	comments, err := g.client.GetIssueComments(issueNum)
+	if err != nil {
+		return nil, err
+	}
+
+	return githubComments(comments), nil
+}
+
+func (g *githubProviderShim) PullRequestFeedback(_ context.Context, branch string) ([]providers.Comment, error) {
+	pr, err := g.client.FindPRForBranch(branch)
+	if err != nil || pr == nil {
+		return nil, err
+	}
+
+	feedback, err := g.client.GetPRFeedback(pr.Number)
+	if err != nil {
+		return nil, err
+	}
+
+	return githubComments(feedback), nil
+}
+
+func (g *githubProviderShim) UnresolvedReviewThreads(_ context.Context, branch string) ([]providers.ReviewThread, error) {
+	pr, err := g.client.FindPRForBranch(branch)
+	if err != nil || pr == nil {
+		return nil, err
+	}
+
+	threads, err := g.client.GetUnresolvedReviewThreads(pr.Number)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]providers.ReviewThread, len(threads))
+	for i := range threads {
+		result[i] = providers.ReviewThread{
+			Path:     threads[i].Path,
+			Line:     threads[i].Line,
+			Comments: githubComments(threads[i].Comments.Nodes),
+		}
+	}
 
-	if !gitlab.IsInstalled(executor) {
-		return nil, errors.New(installInfo.FormatNotInstalledError())
+	return result, nil
+}
+
+// githubComments converts gh comments and reviews, skipping empty approvals
+func githubComments(comments []github.Comment) []providers.Comment {
+	result := make([]providers.Comment, 0, len(comments))
+
+	for i := range comments {
+		c := &comments[i]
+		if strings.TrimSpace(c.Body) == "" && c.State != "CHANGES_REQUESTED" {
+			continue
+		}
+
+		result = append(result, providers.Comment{
+			Author:      c.Author.Login,
+			Body:        c.Body,
+			CreatedAt:   parseCommentTime(c.Time()),
+			ReviewState: c.State,
+		})
 	}
 
-	if err := gitlab.IsAuthenticated(executor); err != nil {
-		return nil, errors.New(installInfo.FormatNotAuthenticatedError())
+	return result
+}
+
+// parseCommentTime parses an RFC 3339 timestamp, returning the zero time when it is missing
+func parseCommentTime(s string) time.Time {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}
+	}
+
+	return t
+}
+
+// newGitLabProvider creates a GitLab provider
+func newGitLabProvider(repo *git.Repository) (providers.Provider, error) {
+	if err := checkProviderAuth(providerGitLab); err != nil {
+		return nil, err
 	}
 
 	client, err := gitlab.NewClient(repo.RootPath)
@@ -248,8 +514,23 @@ type gitlabProviderShim struct {
 	client *gitlab.Client
 }
 
-func (g *gitlabProviderShim) ListIssues(_ context.Context, limit int) ([]providers.Issue, error) {
-	issues, err := g.client.ListOpenIssues(limit)
+func (g *gitlabProviderShim) ListIssues(_ context.Context, opts providers.ListIssuesOptions) ([]providers.Issue, error) {
+	milestone := opts.Milestone
+	if milestone == providers.MilestoneCurrent {
+		current, err := g.client.CurrentMilestone(time.Now().Format(time.DateOnly))
+		if err != nil {
+			return nil, err
+		}
+
+		milestone = current
+	}
+
+	issues, err := g.client.ListFilteredIssues(opts.EffectiveLimit(), gitlab.IssueFilter{
+		Labels:    opts.Labels,
+		Assignee:  opts.Assignee,
+		Milestone: milestone,
+		Search:    opts.Search,
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -257,15 +538,22 @@ func (g *gitlabProviderShim) ListIssues(_ context.Context, limit int) ([]provide
 	result := make([]providers.Issue, 0, len(issues))
 
 	for i := range issues {
-		result = append(result, providers.Issue{
-			ID:     fmt.Sprintf("%d", issues[i].IID),
-			Number: issues[i].IID,
-			Title:  issues[i].Title,
-			Body:   issues[i].Description,
-			URL:    issues[i].WebURL,
-			State:  issues[i].State,
-			Labels: issues[i].Labels,
-		})
+		issue := providers.Issue{
+			ID:       fmt.Sprintf("%d", issues[i].IID),
+			Number:   issues[i].IID,
+			Title:    issues[i].Title,
+			Body:     issues[i].Description,
+			URL:      issues[i].WebURL,
+			State:    issues[i].State,
+			Labels:   issues[i].Labels,
+			Priority: providers.PriorityFromLabels(issues[i].Labels),
+		}
+
+		if issues[i].Milestone != nil {
+			issue.Milestone = issues[i].Milestone.Title
+		}
+
+		result = append(result, issue)
 	}
 
 	return result, nil
@@ -281,13 +569,14 @@ func (g *gitlabProviderShim) GetIssue(_ context.Context, id string) (*providers.
 	}
 
 	return &providers.Issue{
-		ID:     fmt.Sprintf("%d", issue.IID),
-		Number: issue.IID,
-		Title:  issue.Title,
-		Body:   issue.Description,
-		URL:    issue.WebURL,
-		State:  issue.State,
-		Labels: issue.Labels,
+		ID:       fmt.Sprintf("%d", issue.IID),
+		Number:   issue.IID,
+		Title:    issue.Title,
+		Body:     issue.Description,
+		URL:      issue.WebURL,
+		State:    issue.State,
+		Labels:   issue.Labels,
+		Priority: providers.PriorityFromLabels(issue.Labels),
 	}, nil
 }
 
@@ -324,8 +613,53 @@ func (g *gitlabProviderShim) CreateIssue(_ context.Context, title, body string)
 	}, nil
 }
 
-func (g *gitlabProviderShim) CreatePullRequest(_ context.Context, _, _, _, _ string) (*providers.PullRequest, error) {
-	return nil, errors.New("not implemented")
+func (g *gitlabProviderShim) CreatePullRequest(_ context.Context, opts providers.CreatePullRequestOptions) (*providers.PullRequest, error) {
+	mr, err := g.client.CreateMR(gitlab.CreateMROptions{
+		Title:        opts.Title,
+		Description:  opts.Body,
+		SourceBranch: opts.HeadBranch,
+		TargetBranch: opts.BaseBranch,
+		Reviewers:    opts.Reviewers,
+		Labels:       opts.Labels,
+		Draft:        opts.Draft,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &providers.PullRequest{
+		ID:                 fmt.Sprintf("%d", mr.IID),
+		Number:             mr.IID,
+		Title:              mr.Title,
+		Body:               mr.Description,
+		URL:                mr.WebURL,
+		State:              mr.State,
+		HeadBranch:         mr.SourceBranch,
+		BaseBranch:         mr.TargetBranch,
+		Labels:             mr.Labels,
+		ReviewersRequested: opts.Reviewers,
+	}, nil
+}
+
+func (g *gitlabProviderShim) AddAssignee(_ context.Context, id, assignee string) error {
+	var issueID int
+	_, _ = fmt.Sscanf(id, "%d", &issueID) //nolint:gosec,errcheck
+
+	return g.client.AddAssignee(issueID, assignee)
+}
+
+func (g *gitlabProviderShim) AddComment(_ context.Context, id, body string) error {
+	var issueID int
+	_, _ = fmt.Sscanf(id, "%d", &issueID) //nolint:gosec,errcheck
+
+	return g.client.AddComment(issueID, body)
+}
+
+func (g *gitlabProviderShim) AddLabels(_ context.Context, id string, labels []string) error {
+	var issueID int
+	_, _ = fmt.Sscanf(id, "%d", &issueID) //nolint:gosec,errcheck
+
+	return g.client.AddLabels(issueID, labels)
 }
 
 func (g *gitlabProviderShim) GetBranchNameSuffix(issue *providers.Issue) string {
@@ -344,16 +678,47 @@ func (g *gitlabProviderShim) ProviderType() string {
 	return "gitlab"
 }
 
-// newJIRAProvider creates a JIRA provider
-func newJIRAProvider() (providers.Provider, error) {
-	installInfo := JIRAInstallInfo()
+func (g *gitlabProviderShim) IssueComments(_ context.Context, id string) ([]providers.Comment, error) {
+	var issueID int
+	_, _ = fmt.Sscanf(id, "%d", &issueID) //nolint:gosec,errcheck
+
+	notes, err := g.client.GetIssueNotes(issueID)
+	if err != nil {
+		return nil, err
+	}
+
+	return gitlabComments(notes), nil
+}
+
+func (g *gitlabProviderShim) PullRequestFeedback(_ context.Context, branch string) ([]providers.Comment, error) {
+	mr, err := g.client.FindMRForBranch(branch)
+	if err != nil || mr == nil {
+		return nil, err
+	}
+
+	notes, err := g.client.GetMRNotes(mr.IID)
+	if err != nil {
+		return nil, err
+	}
+
+	return gitlabComments(notes), nil
+}
 
-	if !jira.IsInstalled() {
-		return nil, errors.New(installInfo.FormatNotInstalledError())
+// gitlabComments converts glab notes
+func gitlabComments(notes []gitlab.Note) []providers.Comment {
+	result := make([]providers.Comment, len(notes))
+
+	for i, n := range notes {
+		result[i] = providers.Comment{Author: n.Author.Username, Body: n.Body, CreatedAt: parseCommentTime(n.CreatedAt)}
 	}
 
-	if err := jira.IsConfigured(); err != nil {
-		return nil, errors.New(installInfo.FormatNotAuthenticatedError())
+	return result
+}
+
+// newJIRAProvider creates a JIRA provider
+func newJIRAProvider() (providers.Provider, error) {
+	if err := checkProviderAuth(providerJira); err != nil {
+		return nil, err
 	}
 
 	// Get repository for configuration
@@ -368,8 +733,14 @@ func newJIRAProvider() (providers.Provider, error) {
 	server := cfg.GetJiraServer()
 	project := cfg.GetJiraProject()
 
-	// Create provider
-	provider, err := jira.NewProvider(server, project)
+	// Create provider: the REST API when there is a token, otherwise jira-cli
+	var provider *jira.Provider
+	if token := jiraAPIToken(); token != "" {
+		provider, err = jira.NewAPIProvider(server, project, cfg.GetJiraEmail(), token)
+	} else {
+		provider, err = jira.NewProvider(server, project)
+	}
+
 	if err != nil {
 		return nil, fmt.Errorf("failed to create JIRA provider: %w", err)
 	}
@@ -377,6 +748,11 @@ func newJIRAProvider() (providers.Provider, error) {
 	return provider, nil
 }
 
+// jiraAPIToken returns the token for the JIRA REST API, or "" to use jira-cli
+func jiraAPIToken() string {
+	return jira.TokenFromEnv()
+}
+
 // autoDetectProvider attempts to detect the provider based on repository type
 func autoDetectProvider(repo *git.Repository) (providers.Provider, error) {
 	// Try GitHub first (most common)
@@ -396,7 +772,7 @@ func autoDetectProvider(repo *git.Repository) (providers.Provider, error) {
 	}
 
 	// Try JIRA
-	if jira.IsInstalled() {
+	if jiraAPIToken() != "" || jira.IsInstalled(interrupt.Context()) {
 		if provider, err := newJIRAProvider(); err == nil {
 			return provider, nil
 		}
@@ -407,20 +783,24 @@ func autoDetectProvider(repo *git.Repository) (providers.Provider, error) {
 
 // newLinearProvider creates a Linear provider
 func newLinearProvider(repo *git.Repository) (providers.Provider, error) {
-	executor := linear.NewExecutor()
-	installInfo := LinearInstallInfo()
-
-	if !linear.IsInstalled(executor) {
-		return nil, errors.New(installInfo.FormatNotInstalledError())
-	}
-
-	if err := linear.IsAuthenticated(executor); err != nil {
-		return nil, errors.New(installInfo.FormatNotAuthenticatedError())
+	if err := checkProviderAuth(providerLinear); err != nil {
+		return nil, err
 	}
 
 	cfg := git.NewConfig(repo.RootPath)
 
-	client, err := linear.NewClientWithExecutor(repo.RootPath, cfg, executor)
+	var (
+		client *linear.Client
+		err    error
+	)
+
+	// The API when there is a key, otherwise the linear CLI
+	if key := linear.APIKeyFromEnv(); key != "" {
+		client, err = linear.NewAPIClient(cfg, key)
+	} else {
+		client, err = linear.NewClientWithExecutor(repo.RootPath, cfg, linear.NewExecutor())
+	}
+
 	if err != nil {
 		return nil, handleLinearClientError(err)
 	}
@@ -457,36 +837,41 @@ type linearProviderShim struct {
 	client *linear.Client
 }
 
-func (l *linearProviderShim) ListIssues(_ context.Context, limit int) ([]providers.Issue, error) {
-	issues, err := l.client.ListOpenIssues(limit)
+// ListIssues lists open Linear issues. The API filters by label, assignee,
+// cycle (the milestone) and text; the linear CLI has no filter flags, so with
+// it filters other than the limit are applied after fetching.
+func (l *linearProviderShim) ListIssues(_ context.Context, opts providers.ListIssuesOptions) ([]providers.Issue, error) {
+	filter := linear.IssueFilter{Labels: opts.Labels, Cycle: opts.Milestone, Search: opts.Search}
+
+	switch opts.Assignee {
+	case providers.AssigneeSelf:
+		filter.Assignee = "@me"
+	case providers.AssigneeNone:
+		filter.Assignee = "none"
+	default:
+		filter.Assignee = opts.Assignee
+	}
+
+	issues, err := l.client.ListFilteredIssues(opts.EffectiveLimit(), filter)
 	if err != nil {
 		return nil, err
 	}
 
 	result := make([]providers.Issue, 0, len(issues))
-
 	for i := range issues {
-		result = append(result, providers.Issue{
-			ID:     issues[i].Identifier,
-			Number: issues[i].Number,
-			Title:  issues[i].Title,
-			Body:   issues[i].Description,
-			URL:    issues[i].URL,
-			State:  issues[i].State.Type,
-			Labels: extractLinearLabels(issues[i].Labels),
-		})
+		result = append(result, *linearIssue(&issues[i]))
 	}
 
-	return result, nil
-}
-
-func (l *linearProviderShim) GetIssue(_ context.Context, id string) (*providers.Issue, error) {
-	issue, err := l.client.GetIssue(id)
-	if err != nil {
-		return nil, err
+	if l.client.FiltersServerSide() {
+		return result, nil
 	}
 
-	return &providers.Issue{
+	return providers.FilterIssues(result, opts), nil
+}
+
+// linearIssue converts a Linear issue to the provider format
+func linearIssue(issue *linear.Issue) *providers.Issue {
+	result := &providers.Issue{
 		ID:     issue.Identifier,
 		Number: issue.Number,
 		Title:  issue.Title,
@@ -494,7 +879,24 @@ func (l *linearProviderShim) GetIssue(_ context.Context, id string) (*providers.
 		URL:    issue.URL,
 		State:  issue.State.Type,
 		Labels: extractLinearLabels(issue.Labels),
-	}, nil
+		// Linear's priorities are numbered like providers' Priority constants
+		Priority: int(issue.Priority),
+	}
+
+	if issue.Assignee != nil {
+		result.Assignee = issue.Assignee.DisplayName
+	}
+
+	return result
+}
+
+func (l *linearProviderShim) GetIssue(_ context.Context, id string) (*providers.Issue, error) {
+	issue, err := l.client.GetIssue(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return linearIssue(issue), nil
 }
 
 func (l *linearProviderShim) IsIssueClosed(_ context.Context, id string) (bool, error) {
@@ -521,14 +923,31 @@ func (l *linearProviderShim) IsPullRequestMerged(_ context.Context, _ string) (b
 	return false, errors.New("linear does not have pull requests")
 }
 
-func (l *linearProviderShim) CreateIssue(_ context.Context, _, _ string) (*providers.Issue, error) {
-	return nil, errors.New("creating issues via CLI not yet implemented for Linear")
+func (l *linearProviderShim) CreateIssue(_ context.Context, title, body string) (*providers.Issue, error) {
+	issue, err := l.client.CreateIssue(title, body)
+	if err != nil {
+		return nil, err
+	}
+
+	return linearIssue(issue), nil
 }
 
-func (l *linearProviderShim) CreatePullRequest(_ context.Context, _, _, _, _ string) (*providers.PullRequest, error) {
+func (l *linearProviderShim) CreatePullRequest(_ context.Context, _ providers.CreatePullRequestOptions) (*providers.PullRequest, error) {
 	return nil, errors.New("linear does not have pull requests")
 }
 
+func (l *linearProviderShim) AddAssignee(_ context.Context, _, _ string) error {
+	return errors.New("assigning issues via CLI not yet implemented for Linear")
+}
+
+func (l *linearProviderShim) AddComment(_ context.Context, _, _ string) error {
+	return errors.New("commenting on issues via CLI not yet implemented for Linear")
+}
+
+func (l *linearProviderShim) AddLabels(_ context.Context, _ string, _ []string) error {
+	return errors.New("labeling issues via CLI not yet implemented for Linear")
+}
+
 func (l *linearProviderShim) GetBranchNameSuffix(issue *providers.Issue) string {
 	// Linear issues use identifier like "ENG-123"
 	return issue.ID
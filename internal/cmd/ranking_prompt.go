@@ -0,0 +1,204 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kaeawc/auto-worktree/internal/git"
+	"github.com/kaeawc/auto-worktree/internal/github"
+	"github.com/kaeawc/auto-worktree/internal/logging"
+	"github.com/kaeawc/auto-worktree/internal/providers"
+)
+
+// Placeholders of the AI ranking prompt templates. {issues} and {prs} are the
+// lists to rank and {format} says how to answer; a template that leaves
+// either out gets it appended, so the answer can always be read.
+const (
+	rankingRepoPlaceholder     = "{repo}"
+	rankingProviderPlaceholder = "{provider}"
+	rankingUserPlaceholder     = "{user}"
+	rankingIssuesPlaceholder   = "{issues}"
+	rankingPRsPlaceholder      = "{prs}"
+	rankingFormatPlaceholder   = "{format}"
+)
+
+// defaultIssueRankingTemplate is the issue ranking prompt when
+// auto-worktree.issue-ranking-template is not set
+const defaultIssueRankingTemplate = `Analyze the following issues and select the top 5 issues that would be best to work on next. Consider:
+- Priority labels (high priority, urgent, etc.)
+- Issue type (bug fixes are often higher priority than features)
+- Labels like 'good first issue' or 'help wanted'
+- Issue complexity and impact
+
+Repository: {repo}
+
+{format}
+
+Issues:
+{issues}
+Return only the issue IDs/numbers, one per line, nothing else.`
+
+// defaultPRRankingTemplate is the PR ranking prompt when
+// auto-worktree.pr-ranking-template is not set
+const defaultPRRankingTemplate = `Analyze the following GitHub Pull Requests and select the top 5 PRs that would be best to review next. Consider the following criteria in priority order:
+
+1. PRs where the current user ({user}) was requested as a reviewer (highest priority)
+2. PRs with no reviews yet (need attention)
+3. Smaller PRs with fewer changes (easier to review, faster feedback)
+4. PRs with 100% passing checks (✓ status) - prefer these over failing (✗) or pending (○)
+5. Author reputation: prefer maintainers/core contributors over occasional contributors
+
+Repository: {repo}
+Current user: {user}
+
+{format}
+
+Pull Requests:
+{prs}
+Return only the 5 PR numbers, one per line, nothing else.`
+
+// buildIssueSelectionPrompt creates a prompt for AI to select issues.
+func buildIssueSelectionPrompt(issues []providers.Issue, providerType string, repo *git.Repository) string {
+	format := "Return ONLY the top 5 issue numbers in priority order (one per line), formatted as just the numbers (e.g., '42')."
+	if providerType == "linear" {
+		format = "Return ONLY the top 5 issue IDs in priority order (one per line), formatted as issue IDs (e.g., 'TEAM-42')."
+	}
+
+	var list strings.Builder
+
+	for _, issue := range issues {
+		list.WriteString(fmt.Sprintf("#%s | %s", issue.ID, issue.Title))
+
+		if len(issue.Labels) > 0 {
+			list.WriteString(" [" + strings.Join(issue.Labels, ", ") + "]")
+		}
+
+		list.WriteString("\n")
+	}
+
+	template := rankingTemplate(repo, git.ConfigIssueRankingTemplate, defaultIssueRankingTemplate)
+
+	return fillRankingTemplate(template, rankingIssuesPlaceholder, list.String(), format,
+		rankingRepoPlaceholder, repo.RootPath,
+		rankingProviderPlaceholder, providerType,
+	)
+}
+
+// buildPRSelectionPrompt creates a prompt for AI to select PRs.
+func buildPRSelectionPrompt(prs []github.PullRequest, currentUser string, repo *git.Repository) string {
+	format := "Return ONLY the top 5 PR numbers in priority order (one per line), formatted as just the numbers (e.g., '42')."
+
+	var list strings.Builder
+
+	for _, pr := range prs {
+		// Format: #123 | Title [labels] | +50/-20 | Reviewers: a, b | CI: ✓3 ✗1 ○2
+		list.WriteString(fmt.Sprintf("#%d | %s", pr.Number, pr.Title))
+
+		if len(pr.Labels) > 0 {
+			labels := make([]string, len(pr.Labels))
+			for i, label := range pr.Labels {
+				labels[i] = label.Name
+			}
+
+			list.WriteString(" [" + strings.Join(labels, ", ") + "]")
+		}
+
+		list.WriteString(fmt.Sprintf(" | +%d/-%d", pr.Additions, pr.Deletions))
+
+		if len(pr.ReviewRequests) > 0 {
+			reviewers := make([]string, len(pr.ReviewRequests))
+			for i, req := range pr.ReviewRequests {
+				reviewers[i] = req.Login
+			}
+
+			list.WriteString(" | Reviewers: " + strings.Join(reviewers, ", "))
+		}
+
+		if len(pr.StatusCheckRollup) > 0 {
+			list.WriteString(" | CI: " + formatCheckCounts(pr.StatusCheckRollup))
+		}
+
+		list.WriteString("\n")
+	}
+
+	template := rankingTemplate(repo, git.ConfigPRRankingTemplate, defaultPRRankingTemplate)
+
+	return fillRankingTemplate(template, rankingPRsPlaceholder, list.String(), format,
+		rankingRepoPlaceholder, repo.RootPath,
+		rankingUserPlaceholder, currentUser,
+	)
+}
+
+// countChecks counts the passing, failing and pending CI checks
+func countChecks(checks []github.StatusCheck) (passing, failing, pending int) {
+	for _, check := range checks {
+		switch check.Status {
+		case "SUCCESS", "COMPLETED":
+			passing++
+		case "FAILURE", "ERROR":
+			failing++
+		default:
+			pending++
+		}
+	}
+
+	return passing, failing, pending
+}
+
+// formatCheckCounts summarizes CI checks as e.g. "✓3 ✗1 ○2"
+func formatCheckCounts(checks []github.StatusCheck) string {
+	passing, failing, pending := countChecks(checks)
+
+	counts := fmt.Sprintf("✓%d", passing)
+	if failing > 0 {
+		counts += fmt.Sprintf(" ✗%d", failing)
+	}
+
+	if pending > 0 {
+		counts += fmt.Sprintf(" ○%d", pending)
+	}
+
+	return counts
+}
+
+// rankingTemplate reads the prompt template file configured under key, or
+// returns the built-in one when none is set or the file can't be read
+func rankingTemplate(repo *git.Repository, key, builtin string) string {
+	path := repo.Config.GetWithDefault(key, "", git.ConfigScopeAuto)
+	if path == "" {
+		return builtin
+	}
+
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(repo.RootPath, path)
+	}
+
+	data, err := os.ReadFile(path) //nolint:gosec // the path is the user's own configuration
+	if err != nil {
+		logging.Warn("failed to read ranking prompt template, using the built-in one", "key", key, "err", err)
+		fmt.Fprintf(os.Stderr, "Could not read %s (%v); ranking with the built-in criteria.\n", key, err)
+
+		return builtin
+	}
+
+	return string(data)
+}
+
+// fillRankingTemplate replaces the placeholders of a ranking prompt template
+// with their values, given as placeholder, value pairs after the list to rank
+// and the answer format, which are appended when the template leaves them out
+func fillRankingTemplate(template, listPlaceholder, list, format string, values ...string) string {
+	if !strings.Contains(template, listPlaceholder) {
+		template = strings.TrimRight(template, "\n") + "\n\n" + listPlaceholder
+	}
+
+	if !strings.Contains(template, rankingFormatPlaceholder) {
+		template = strings.TrimRight(template, "\n") + "\n\n" + rankingFormatPlaceholder
+	}
+
+	pairs := append([]string{listPlaceholder, list, rankingFormatPlaceholder, format}, values...)
+
+	return strings.NewReplacer(pairs...).Replace(template)
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kaeawc/auto-worktree/internal/git"
+	"github.com/kaeawc/auto-worktree/internal/github"
+	"github.com/kaeawc/auto-worktree/internal/providers"
+)
+
+// rankingRepo returns a repository at root whose config sets key to value
+func rankingRepo(t *testing.T, root, key, value string) *git.Repository {
+	t.Helper()
+
+	executor := git.NewFakeGitExecutor()
+	if key != "" {
+		executor.SetResponse("config --local --get "+key, value)
+	}
+
+	repo, err := git.NewRepositoryFromPathWithDeps(root, executor, git.NewFakeFileSystem())
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+
+	repo.RootPath = root
+	repo.Config = git.NewConfigWithExecutor(root, executor)
+
+	return repo
+}
+
+func TestBuildIssueSelectionPrompt(t *testing.T) {
+	issues := []providers.Issue{{ID: "42", Title: "Fix {repo} crash", Labels: []string{"bug"}}}
+
+	prompt := buildIssueSelectionPrompt(issues, "github", rankingRepo(t, "/src/app", "", ""))
+	for _, want := range []string{"Repository: /src/app", "#42 | Fix {repo} crash [bug]", "top 5 issue numbers", "Bug fixes are often"} {
+		if !strings.Contains(strings.ToLower(prompt), strings.ToLower(want)) {
+			t.Errorf("built-in prompt is missing %q:\n%s", want, prompt)
+		}
+	}
+
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "ranking.md"), []byte("Security issues first, in {repo} on {provider}.\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	prompt = buildIssueSelectionPrompt(issues, "linear", rankingRepo(t, root, git.ConfigIssueRankingTemplate, "ranking.md"))
+	if !strings.HasPrefix(prompt, "Security issues first, in "+root+" on linear.") {
+		t.Errorf("custom prompt doesn't start with the template:\n%s", prompt)
+	}
+
+	// The list and the answer format are appended when the template leaves them out
+	if !strings.Contains(prompt, "#42 | Fix {repo} crash") || !strings.Contains(prompt, "'TEAM-42'") || strings.Contains(prompt, "Bug fixes") {
+		t.Errorf("custom prompt = %q", prompt)
+	}
+}
+
+func TestBuildPRSelectionPrompt(t *testing.T) {
+	prs := []github.PullRequest{{
+		Number:            7,
+		Title:             "Add login",
+		Additions:         50,
+		Deletions:         20,
+		StatusCheckRollup: []github.StatusCheck{{Status: "SUCCESS"}, {Status: "FAILURE"}, {Status: "PENDING"}},
+	}}
+
+	root := t.TempDir()
+	template := "Rank for {user}:\n{prs}\nPrefer green CI.\n{format}\n"
+	if err := os.WriteFile(filepath.Join(root, "prs.md"), []byte(template), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	prompt := buildPRSelectionPrompt(prs, "alice", rankingRepo(t, "/src/app", git.ConfigPRRankingTemplate, filepath.Join(root, "prs.md")))
+
+	want := "Rank for alice:\n#7 | Add login | +50/-20 | CI: ✓1 ✗1 ○1\n\nPrefer green CI.\n" +
+		"Return ONLY the top 5 PR numbers in priority order (one per line), formatted as just the numbers (e.g., '42').\n"
+	if prompt != want {
+		t.Errorf("buildPRSelectionPrompt() = %q, want %q", prompt, want)
+	}
+
+	// A missing template falls back to the built-in criteria
+	prompt = buildPRSelectionPrompt(prs, "alice", rankingRepo(t, "/src/app", git.ConfigPRRankingTemplate, "missing.md"))
+	if !strings.Contains(prompt, "requested as a reviewer") {
+		t.Errorf("prompt with a missing template = %q", prompt)
+	}
+}
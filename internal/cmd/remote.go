@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/kaeawc/auto-worktree/internal/remote"
+)
+
+// UseHost makes the rest of the invocation run git, tmux and AI tools on
+// another machine over SSH, as --host does. The menus and prompts stay local.
+func UseHost(target string) error {
+	if err := remote.Configure(target); err != nil {
+		return err
+	}
+
+	// Fail early with ssh's own message rather than on the first git command
+	output, err := remote.Shell(context.Background(), "command -v git >/dev/null").CombinedOutput()
+	if err != nil {
+		if msg := strings.TrimSpace(string(output)); msg != "" {
+			err = fmt.Errorf("%w: %s", err, msg)
+		}
+
+		return fmt.Errorf("cannot run git on %s over ssh (check 'ssh %s git --version' works without a password prompt): %w",
+			remote.Host(), remote.Host(), err)
+	}
+
+	return nil
+}
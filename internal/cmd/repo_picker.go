@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kaeawc/auto-worktree/internal/git"
+	"github.com/kaeawc/auto-worktree/internal/registry"
+	"github.com/kaeawc/auto-worktree/internal/ui"
+)
+
+// repoCandidate is a repository offered when auto-worktree runs outside of one
+type repoCandidate struct {
+	Name string
+	Path string
+	// Source says where it was found: the registry or the worktree base
+	Source string
+}
+
+// inGitRepository reports whether the current directory is inside a git working tree
+func inGitRepository() bool {
+	_, err := git.NewGitExecutor().Execute("rev-parse", "--git-dir")
+	return err == nil
+}
+
+// EnsureRepository lets the user pick a repository when run outside of one,
+// then changes into it so the command proceeds as if started there. Without
+// a terminal to ask on, or nothing to offer, the command runs unchanged and
+// reports the usual "not a git repository" error.
+func EnsureRepository() error {
+	if inGitRepository() || !ui.IsTerminal(os.Stdin) {
+		return nil
+	}
+
+	var registered []registry.Repo
+	if reg, err := openRegistry(); err == nil {
+		registered, _ = reg.List() //nolint:errcheck // the worktree base is still searched
+	}
+
+	parent, _ := git.WorktreeParentDir() //nolint:errcheck // the registry is still offered
+
+	candidates := repositoryCandidates(registered, parent)
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	fmt.Println(ui.WarningStyle.Render("Not inside a git repository."))
+
+	items := make([]ui.MenuItem, len(candidates))
+	for i, c := range candidates {
+		items[i] = ui.NewMenuItem(c.Name, fmt.Sprintf("%s (%s)", c.Path, c.Source), c.Path)
+	}
+
+	choice, err := runSetupMenu("Choose a repository", items)
+	if err != nil || choice == "" {
+		return err
+	}
+
+	if err := os.Chdir(choice); err != nil {
+		return fmt.Errorf("failed to open repository: %w", err)
+	}
+
+	return nil
+}
+
+// repositoryCandidates lists registered repositories that still exist, then any
+// other repositories that own worktrees under the worktree parent directory
+func repositoryCandidates(registered []registry.Repo, worktreeParent string) []repoCandidate {
+	seen := map[string]bool{}
+
+	var candidates []repoCandidate
+
+	for _, r := range registered {
+		if info, err := os.Stat(r.Path); err != nil || !info.IsDir() {
+			continue
+		}
+
+		seen[r.Path] = true
+		candidates = append(candidates, repoCandidate{Name: r.Name, Path: r.Path, Source: "registered"})
+	}
+
+	if worktreeParent == "" {
+		return candidates
+	}
+
+	// Layout is <parent>/<repo>/<worktree>, and each worktree's .git file names its repository
+	gitFiles, _ := filepath.Glob(filepath.Join(worktreeParent, "*", "*", ".git")) //nolint:errcheck // pattern is valid
+
+	for _, gitFile := range gitFiles {
+		root := mainRepositoryFromGitFile(gitFile)
+		if root == "" || seen[root] {
+			continue
+		}
+
+		if info, err := os.Stat(root); err != nil || !info.IsDir() {
+			continue
+		}
+
+		seen[root] = true
+		candidates = append(candidates, repoCandidate{Name: filepath.Base(root), Path: root, Source: "has worktrees in " + worktreeParent})
+	}
+
+	return candidates
+}
+
+// mainRepositoryFromGitFile reads a linked worktree's .git file
+// ("gitdir: <repo>/.git/worktrees/<name>") and returns <repo>
+func mainRepositoryFromGitFile(path string) string {
+	data, err := os.ReadFile(path) //nolint:gosec // path comes from globbing the worktree base
+	if err != nil {
+		return ""
+	}
+
+	gitdir, ok := strings.CutPrefix(strings.TrimSpace(string(data)), "gitdir: ")
+	if !ok {
+		return ""
+	}
+
+	if !filepath.IsAbs(gitdir) {
+		gitdir = filepath.Join(filepath.Dir(path), gitdir)
+	}
+
+	worktrees := filepath.Dir(filepath.Clean(gitdir))
+	dotGit := filepath.Dir(worktrees)
+
+	if filepath.Base(worktrees) != "worktrees" || filepath.Base(dotGit) != ".git" {
+		return ""
+	}
+
+	return filepath.Dir(dotGit)
+}
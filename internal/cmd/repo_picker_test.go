@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kaeawc/auto-worktree/internal/registry"
+)
+
+func TestRepositoryCandidates(t *testing.T) {
+	dir := t.TempDir()
+
+	api := filepath.Join(dir, "src", "api")
+	web := filepath.Join(dir, "src", "web")
+	parent := filepath.Join(dir, "worktrees")
+
+	for _, path := range []string{
+		filepath.Join(api, ".git", "worktrees", "fix-login"),
+		filepath.Join(web, ".git", "worktrees", "new-nav"),
+		filepath.Join(parent, "api", "fix-login"),
+		filepath.Join(parent, "web", "new-nav"),
+		filepath.Join(parent, "orphan", "gone"),
+	} {
+		if err := os.MkdirAll(path, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	writeGitFile := func(worktree, gitdir string) {
+		if err := os.WriteFile(filepath.Join(worktree, ".git"), []byte("gitdir: "+gitdir+"\n"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	writeGitFile(filepath.Join(parent, "api", "fix-login"), filepath.Join(api, ".git", "worktrees", "fix-login"))
+	writeGitFile(filepath.Join(parent, "web", "new-nav"), filepath.Join(web, ".git", "worktrees", "new-nav"))
+	writeGitFile(filepath.Join(parent, "orphan", "gone"), filepath.Join(dir, "deleted", ".git", "worktrees", "gone"))
+
+	registered := []registry.Repo{
+		{Name: "api", Path: api},
+		{Name: "moved", Path: filepath.Join(dir, "moved")},
+	}
+
+	candidates := repositoryCandidates(registered, parent)
+
+	if len(candidates) != 2 {
+		t.Fatalf("repositoryCandidates() = %+v, want api then web", candidates)
+	}
+
+	if candidates[0].Path != api || candidates[0].Source != "registered" {
+		t.Errorf("candidates[0] = %+v, want registered api first", candidates[0])
+	}
+
+	if candidates[1].Path != web || candidates[1].Name != "web" {
+		t.Errorf("candidates[1] = %+v, want web found through its worktree", candidates[1])
+	}
+}
+
+func TestMainRepositoryFromGitFile(t *testing.T) {
+	dir := t.TempDir()
+	gitFile := filepath.Join(dir, ".git")
+
+	tests := []struct {
+		content string
+		want    string
+	}{
+		{"gitdir: /src/api/.git/worktrees/fix-login\n", "/src/api"},
+		{"gitdir: /src/api/.git/modules/lib\n", ""},
+		{"not a git file", ""},
+	}
+
+	for _, tt := range tests {
+		if err := os.WriteFile(gitFile, []byte(tt.content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		if got := mainRepositoryFromGitFile(gitFile); got != tt.want {
+			t.Errorf("mainRepositoryFromGitFile(%q) = %q, want %q", tt.content, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,275 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/kaeawc/auto-worktree/internal/git"
+	"github.com/kaeawc/auto-worktree/internal/logging"
+	"github.com/kaeawc/auto-worktree/internal/registry"
+	"github.com/kaeawc/auto-worktree/internal/remote"
+	"github.com/kaeawc/auto-worktree/internal/session"
+	"github.com/kaeawc/auto-worktree/internal/state"
+	"github.com/kaeawc/auto-worktree/internal/ui"
+)
+
+// reposCleanupAll is the All Repositories menu action that cleans up every repository
+const reposCleanupAll = "cleanup-all"
+
+// repoSummary is one row of the All Repositories view
+type repoSummary struct {
+	Repo      registry.Repo
+	Worktrees []*git.Worktree
+	Sessions  int
+	Err       error
+}
+
+// openRegistry opens the repository registry in the default state database
+func openRegistry() (*registry.Store, error) {
+	path, err := state.DefaultPath()
+	if err != nil {
+		return nil, err
+	}
+
+	return registry.NewStore(path), nil
+}
+
+// rememberRepository adds the repository to the registry; best-effort so it never interrupts a command
+func rememberRepository(rootPath string) {
+	// The registry holds local paths; a --host path means nothing here
+	if remote.Enabled() {
+		return
+	}
+
+	reg, err := openRegistry()
+	if err != nil {
+		return
+	}
+
+	if err := reg.Touch(rootPath, time.Now()); err != nil {
+		logging.Debug("failed to register repository", "path", rootPath, "err", err)
+	}
+}
+
+// UseRepository changes into a registered repository so the command that
+// follows runs against it, as --repo <name> does
+func UseRepository(name string) error {
+	reg, err := openRegistry()
+	if err != nil {
+		return err
+	}
+
+	repo, err := reg.Lookup(name)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Chdir(repo.Path); err != nil {
+		return fmt.Errorf("repository %s is no longer available (remove it with 'auto-worktree repos forget %s'): %w",
+			repo.Name, repo.Name, err)
+	}
+
+	return nil
+}
+
+// summarizeRepositories loads worktrees and running sessions for every registered repository
+func summarizeRepositories() ([]repoSummary, error) {
+	reg, err := openRegistry()
+	if err != nil {
+		return nil, err
+	}
+
+	repos, err := reg.List()
+	if err != nil {
+		return nil, err
+	}
+
+	allMetadata, _ := session.NewManager().LoadAllSessionMetadata() //nolint:errcheck // sessions are optional
+
+	summaries := make([]repoSummary, 0, len(repos))
+
+	for _, r := range repos {
+		summary := repoSummary{Repo: r}
+
+		repo, err := git.NewRepositoryFromPath(r.Path)
+		if err == nil {
+			var worktrees []*git.Worktree
+
+			worktrees, err = repo.ListWorktrees()
+			summary.Worktrees = repo.FilterOutMainBranch(worktrees)
+		}
+
+		summary.Err = err
+		summary.Sessions = countRunningSessions(summary.Worktrees, allMetadata)
+		summaries = append(summaries, summary)
+	}
+
+	return summaries, nil
+}
+
+// countRunningSessions counts sessions that are running in any of the worktrees
+func countRunningSessions(worktrees []*git.Worktree, metadata []*session.Metadata) int {
+	paths := make(map[string]bool, len(worktrees))
+	for _, wt := range worktrees {
+		paths[wt.Path] = true
+	}
+
+	count := 0
+
+	for _, m := range metadata {
+		if paths[m.WorktreePath] && m.Status == session.StatusRunning {
+			count++
+		}
+	}
+
+	return count
+}
+
+// printRepositories prints every registered repository with its worktrees
+func printRepositories(summaries []repoSummary) {
+	fmt.Println(ui.TitleStyle.Render("All Repositories"))
+	fmt.Println()
+
+	for _, s := range summaries {
+		header := fmt.Sprintf("%s %s", ui.BoldStyle.Render(s.Repo.Name), ui.SubtleStyle.Render(s.Repo.Path))
+
+		if s.Err != nil {
+			fmt.Printf("%s  %s\n\n", header, ui.ErrorStyle.Render("unavailable"))
+			continue
+		}
+
+		fmt.Printf("%s  %d worktree(s), %d running session(s), used %s ago\n",
+			header, len(s.Worktrees), s.Sessions, formatAge(time.Since(s.Repo.LastUsed)))
+
+		for _, wt := range s.Worktrees {
+			branch := wt.Branch
+			if branch == "" {
+				branch = "(detached)"
+			}
+
+			fmt.Printf("    %-40s %s\n", filepath.Base(wt.Path), ui.SubtleStyle.Render(branch))
+		}
+
+		fmt.Println()
+	}
+}
+
+// RunRepos prints every registered repository with its worktrees and sessions
+func RunRepos() error {
+	summaries, err := summarizeRepositories()
+	if err != nil {
+		return err
+	}
+
+	if len(summaries) == 0 {
+		fmt.Println("No repositories registered yet. Run auto-worktree inside a repository to add it.")
+		return nil
+	}
+
+	printRepositories(summaries)
+
+	return nil
+}
+
+// RunReposForget removes a repository from the registry
+func RunReposForget(name string) error {
+	reg, err := openRegistry()
+	if err != nil {
+		return err
+	}
+
+	repo, err := reg.Lookup(name)
+	if err != nil {
+		return err
+	}
+
+	if err := reg.Remove(repo.Path); err != nil {
+		return err
+	}
+
+	fmt.Printf("%s Forgot %s (%s)\n", ui.SuccessStyle.Render("✓"), repo.Name, repo.Path)
+
+	return nil
+}
+
+// RunAllRepositories shows every registered repository and lets the user switch
+// to one or clean up merged worktrees across all of them
+func RunAllRepositories() error {
+	summaries, err := summarizeRepositories()
+	if err != nil {
+		return err
+	}
+
+	if len(summaries) == 0 {
+		fmt.Println("No repositories registered yet. Run auto-worktree inside a repository to add it.")
+		return nil
+	}
+
+	printRepositories(summaries)
+
+	items := make([]ui.MenuItem, 0, len(summaries)+1)
+
+	for _, s := range summaries {
+		if s.Err == nil {
+			items = append(items, ui.NewMenuItem("Open "+s.Repo.Name, s.Repo.Path, s.Repo.Path))
+		}
+	}
+
+	items = append(items, ui.NewMenuItem("Clean Up All Repositories", "Run cleanup in every repository in turn", reposCleanupAll))
+
+	choice, err := runSetupMenu("All Repositories", items)
+	if err != nil || choice == "" {
+		return err
+	}
+
+	if choice == reposCleanupAll {
+		return cleanupAllRepositories(summaries)
+	}
+
+	// The menu loop reopens in the current directory, so switching is a chdir
+	if err := os.Chdir(choice); err != nil {
+		return fmt.Errorf("failed to open repository: %w", err)
+	}
+
+	return nil
+}
+
+// cleanupAllRepositories runs the interactive cleanup in each repository, then returns to the current one
+func cleanupAllRepositories(summaries []repoSummary) error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	defer os.Chdir(cwd) //nolint:errcheck // best effort return to where we started
+
+	var failed []string
+
+	for _, s := range summaries {
+		if s.Err != nil || len(s.Worktrees) == 0 {
+			continue
+		}
+
+		fmt.Println()
+		fmt.Println(ui.BoldStyle.Render("── " + s.Repo.Name + " ──"))
+
+		if err := os.Chdir(s.Repo.Path); err != nil {
+			failed = append(failed, s.Repo.Name)
+			continue
+		}
+
+		if err := RunCleanup(CleanupOptions{}); err != nil {
+			fmt.Printf("  %s %v\n", ui.ErrorStyle.Render("✗"), err)
+			failed = append(failed, s.Repo.Name)
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("cleanup failed in: %s", strings.Join(failed, ", "))
+	}
+
+	return nil
+}
@@ -0,0 +1,21 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/kaeawc/auto-worktree/internal/git"
+	"github.com/kaeawc/auto-worktree/internal/session"
+)
+
+func TestCountRunningSessions(t *testing.T) {
+	worktrees := []*git.Worktree{{Path: "/wt/api-a"}, {Path: "/wt/api-b"}}
+	metadata := []*session.Metadata{
+		{WorktreePath: "/wt/api-a", Status: session.StatusRunning},
+		{WorktreePath: "/wt/api-b", Status: session.StatusIdle},
+		{WorktreePath: "/wt/web-a", Status: session.StatusRunning},
+	}
+
+	if got := countRunningSessions(worktrees, metadata); got != 1 {
+		t.Errorf("countRunningSessions() = %d, want 1 (other repositories' sessions excluded)", got)
+	}
+}
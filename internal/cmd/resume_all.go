@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/kaeawc/auto-worktree/internal/git"
+	"github.com/kaeawc/auto-worktree/internal/remote"
+	"github.com/kaeawc/auto-worktree/internal/session"
+	"github.com/kaeawc/auto-worktree/internal/ui"
+)
+
+// sessionsToRestore returns the sessions with saved metadata whose tmux session
+// is gone but whose worktree still exists. Paused sessions were stopped on
+// purpose (e.g. for being idle) and are left alone.
+func sessionsToRestore(all []*session.Metadata, live map[string]bool, worktreeExists func(string) bool) []*session.Metadata {
+	var restore []*session.Metadata
+
+	for _, m := range all {
+		if live[m.SessionName] || m.Status == session.StatusPaused || m.WorktreePath == "" {
+			continue
+		}
+
+		if worktreeExists(m.WorktreePath) {
+			restore = append(restore, m)
+		}
+	}
+
+	return restore
+}
+
+// RunResumeAll recreates the sessions of every worktree that had one when tmux
+// went away, e.g. after a reboot, continuing each AI conversation where there is one
+func RunResumeAll() error {
+	sessionMgr := session.NewManager()
+	if !sessionMgr.IsAvailable() {
+		return fmt.Errorf("tmux is not available")
+	}
+
+	all, err := sessionMgr.LoadAllSessionMetadata()
+	if err != nil {
+		return fmt.Errorf("failed to load sessions: %w", err)
+	}
+
+	names, err := sessionMgr.ListSessions()
+	if err != nil {
+		return fmt.Errorf("error listing sessions: %w", err)
+	}
+
+	live := make(map[string]bool, len(names))
+	for _, name := range names {
+		live[name] = true
+	}
+
+	fs := git.NewFileSystem()
+	restore := sessionsToRestore(all, live, fs.Exists)
+
+	if len(restore) == 0 {
+		fmt.Println("No sessions to restore: every worktree with a saved session is already running.")
+		return nil
+	}
+
+	fmt.Printf("Restoring %d session(s)...\n", len(restore))
+
+	var failed int
+
+	for _, m := range restore {
+		fmt.Printf("\n%s (%s)\n", m.BranchName, m.WorktreePath)
+
+		if err := restoreSession(sessionMgr, m); err != nil {
+			fmt.Println(ui.WarningStyle.Render(fmt.Sprintf("⚠ %v", err)))
+			failed++
+
+			continue
+		}
+
+		fmt.Printf("✓ Tmux session created: %s\n", m.SessionName)
+	}
+
+	fmt.Printf("\nRestored %d of %d session(s). Attach with:\n", len(restore)-failed, len(restore))
+	fmt.Printf("  %s\n", remote.AttachCommand("tmux attach-session -t <name>"))
+
+	if failed > 0 {
+		return fmt.Errorf("%d session(s) could not be restored", failed)
+	}
+
+	return nil
+}
+
+// restoreSession recreates one session in its worktree, resuming the AI tool
+func restoreSession(sessionMgr *session.SessionManager, m *session.Metadata) error {
+	// Each worktree may belong to a different repository, with its own settings
+	repo, err := git.NewRepositoryFromPath(m.WorktreePath)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", m.WorktreePath, err)
+	}
+
+	scope := m.Scope
+	if scope != "" {
+		if _, err := os.Stat(scopeWorkDir(m.WorktreePath, scope)); err != nil {
+			scope = ""
+		}
+	}
+
+	previous := &aiConversation{Tool: m.AITool, ID: m.ConversationID}
+
+	aiCommand, conversation, err := resolveAICommand(repo.Config, "", previous, scopeWorkDir(m.WorktreePath, scope))
+	if err != nil {
+		return err
+	}
+
+	return createSessionWithAICommand(sessionMgr, repo.Config, m.SessionName, m.BranchName, m.WorktreePath, scope, aiCommand, conversation)
+}
@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/kaeawc/auto-worktree/internal/session"
+)
+
+func TestSessionsToRestore(t *testing.T) {
+	all := []*session.Metadata{
+		{SessionName: "running", WorktreePath: "/wt/running", Status: session.StatusRunning},
+		{SessionName: "lost", WorktreePath: "/wt/lost", Status: session.StatusRunning},
+		{SessionName: "failed", WorktreePath: "/wt/failed", Status: session.StatusFailed},
+		{SessionName: "paused", WorktreePath: "/wt/paused", Status: session.StatusPaused},
+		{SessionName: "removed", WorktreePath: "/wt/removed", Status: session.StatusRunning},
+	}
+	live := map[string]bool{"running": true}
+	exists := func(path string) bool { return path != "/wt/removed" }
+
+	got := sessionsToRestore(all, live, exists)
+
+	var names []string
+	for _, m := range got {
+		names = append(names, m.SessionName)
+	}
+
+	if len(names) != 2 || names[0] != "lost" || names[1] != "failed" {
+		t.Errorf("sessionsToRestore() = %v, want [lost failed]", names)
+	}
+}
@@ -0,0 +1,191 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/kaeawc/auto-worktree/internal/interrupt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kaeawc/auto-worktree/internal/git"
+	"github.com/kaeawc/auto-worktree/internal/logging"
+	"github.com/kaeawc/auto-worktree/internal/remote"
+	"github.com/kaeawc/auto-worktree/internal/sandbox"
+	"github.com/kaeawc/auto-worktree/internal/session"
+	"github.com/kaeawc/auto-worktree/internal/ui"
+)
+
+// sandboxGitWritable are the directories of the git common dir a sandboxed
+// session may write to, so it can commit; hooks and config stay read-only so
+// nothing the agent writes there runs on the host
+var sandboxGitWritable = []string{"objects", "refs", "logs"}
+
+// sandboxSpec describes the container for a worktree. The worktree is mounted
+// read-write; the git common dir its .git file points into is mounted
+// read-only, except for the worktree's own admin directory and the objects,
+// refs and reflogs a commit writes.
+func sandboxSpec(config *git.Config, runtime, name, worktreePath, workDir string) sandbox.Spec {
+	commonDir, adminDir := worktreeGitDirs(config.RootPath, worktreePath)
+
+	mounts := []string{worktreePath, adminDir}
+
+	for _, dir := range sandboxGitWritable {
+		path := filepath.Join(commonDir, dir)
+
+		// A missing directory would be created by the runtime, owned by root;
+		// under --host it is on the other machine, where git has made it already
+		if _, err := os.Stat(path); err == nil || remote.Enabled() {
+			mounts = append(mounts, path)
+		}
+	}
+
+	return sandbox.Spec{
+		Runtime:        runtime,
+		Image:          config.GetSandboxImage(),
+		Name:           name,
+		ReadOnlyMounts: []string{commonDir},
+		Mounts:         mounts,
+		WorkDir:        workDir,
+		Limits:         sessionLimits(config),
+	}
+}
+
+// worktreeGitDirs returns the git common dir of a linked worktree and its admin
+// directory there, read from the worktree's .git file. When that cannot be read
+// they are assumed to be rootPath/.git and its worktrees/<name>.
+func worktreeGitDirs(rootPath, worktreePath string) (string, string) {
+	commonDir := filepath.Join(rootPath, ".git")
+	adminDir := filepath.Join(commonDir, "worktrees", filepath.Base(worktreePath))
+
+	data, err := os.ReadFile(filepath.Join(worktreePath, ".git"))
+	if err != nil {
+		return commonDir, adminDir
+	}
+
+	gitDir, ok := strings.CutPrefix(strings.TrimSpace(string(data)), "gitdir:")
+	if !ok {
+		return commonDir, adminDir
+	}
+
+	adminDir = resolveGitPath(worktreePath, strings.TrimSpace(gitDir))
+
+	// The admin directory's commondir file points back at the common dir
+	commonDir = filepath.Dir(filepath.Dir(adminDir))
+	if data, err := os.ReadFile(filepath.Join(adminDir, "commondir")); err == nil {
+		commonDir = resolveGitPath(adminDir, strings.TrimSpace(string(data)))
+	}
+
+	return commonDir, adminDir
+}
+
+// resolveGitPath resolves path, as written in a git pointer file, against dir
+func resolveGitPath(dir, path string) string {
+	if filepath.IsAbs(path) {
+		return filepath.Clean(path)
+	}
+
+	return filepath.Join(dir, path)
+}
+
+// sessionLimits reads the per-session CPU and memory limits from config
+func sessionLimits(config *git.Config) sandbox.Limits {
+	return sandbox.Limits{CPUs: config.GetSessionCPUs(), Memory: config.GetSessionMemory()}
+}
+
+// limitedCommand runs a session on the host inside a systemd scope named unit
+// that enforces the limits. It returns the command unchanged, and no unit, when
+// there are no limits or systemd-run is not available.
+func limitedCommand(unit string, limits sandbox.Limits, command []string) ([]string, string) {
+	if limits.IsZero() {
+		return command, ""
+	}
+
+	if !sandbox.HasSystemdRun() {
+		logging.Warn("session resource limits need a sandbox container or systemd-run; starting without limits")
+		return command, ""
+	}
+
+	if len(command) == 0 {
+		// systemd-run needs a command where tmux would start the default shell
+		command = []string{"sh", "-c", `exec "${SHELL:-/bin/sh}"`}
+	}
+
+	wrapped, err := sandbox.SystemdRunCommand(unit, limits, command)
+	if err != nil {
+		logging.Warn("starting without resource limits", "err", err)
+		return command, ""
+	}
+
+	return wrapped, unit
+}
+
+// sessionResources records the limits a session was started under, or nil when none apply
+func sessionResources(limits sandbox.Limits, containerID, unit string) *session.ResourceInfo {
+	if limits.IsZero() || (containerID == "" && unit == "") {
+		return nil
+	}
+
+	return &session.ResourceInfo{CPUs: limits.CPUs, Memory: limits.Memory, Unit: unit}
+}
+
+// sessionUsage reads a session's current CPU and memory use from its container
+// or systemd scope; "" when it runs in neither or the usage cannot be read
+func sessionUsage(m *session.Metadata) string {
+	var (
+		usage string
+		err   error
+	)
+
+	switch {
+	case m.ContainerID != "":
+		usage, err = sandbox.ContainerUsage(interrupt.Context(), m.ContainerRuntime, m.ContainerID)
+	case m.Resources != nil && m.Resources.Unit != "":
+		usage, err = sandbox.UnitUsage(m.Resources.Unit)
+	default:
+		return ""
+	}
+
+	if err != nil {
+		logging.Debug("failed to read session usage", "session", m.SessionName, "err", err)
+		return ""
+	}
+
+	return usage
+}
+
+// sandboxSession starts, or reuses, the container for a session and returns
+// the command that runs aiCommand (or a shell) inside it, and the container ID
+func sandboxSession(config *git.Config, runtime, sessionName, worktreePath, workDir string, aiCommand []string) ([]string, string, error) {
+	spec := sandboxSpec(config, runtime, sessionName, worktreePath, workDir)
+
+	fmt.Printf("Starting %s sandbox from %s...\n", runtime, spec.Image)
+
+	id, err := sandbox.Start(interrupt.Context(), spec)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to start sandbox (set auto-worktree.sandbox off to run on the host): %w", err)
+	}
+
+	return sandbox.ExecCommand(runtime, id, workDir, aiCommand), id, nil
+}
+
+// removeSandboxes removes the containers of sessions that ran in a worktree
+// being removed; best-effort so it never blocks the removal itself
+func removeSandboxes(worktreePath string) {
+	allMetadata, err := session.NewManager().LoadAllSessionMetadata()
+	if err != nil {
+		return
+	}
+
+	for _, m := range allMetadata {
+		if m.WorktreePath != worktreePath || m.ContainerID == "" {
+			continue
+		}
+
+		if err := sandbox.Remove(interrupt.Context(), m.ContainerRuntime, m.ContainerID); err != nil {
+			logging.Warn("failed to remove sandbox container", "container", m.ContainerID, "err", err)
+			continue
+		}
+
+		fmt.Println(ui.SubtleStyle.Render(fmt.Sprintf("  Removed %s sandbox %.12s", m.ContainerRuntime, m.ContainerID)))
+	}
+}
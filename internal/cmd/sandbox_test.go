@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/kaeawc/auto-worktree/internal/git"
+)
+
+func TestSandboxSpecMountsGitDirReadOnly(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "repo")
+	commonDir := filepath.Join(root, ".git")
+	adminDir := filepath.Join(commonDir, "worktrees", "feature")
+	worktree := filepath.Join(t.TempDir(), "feature")
+
+	for _, dir := range []string{adminDir, filepath.Join(commonDir, "objects"), filepath.Join(commonDir, "refs"), worktree} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := os.WriteFile(filepath.Join(worktree, ".git"), []byte("gitdir: "+adminDir+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(adminDir, "commondir"), []byte("../..\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	spec := sandboxSpec(git.NewConfig(root), "docker", "s", worktree, worktree)
+
+	if want := []string{commonDir}; !reflect.DeepEqual(spec.ReadOnlyMounts, want) {
+		t.Errorf("ReadOnlyMounts = %v, want %v", spec.ReadOnlyMounts, want)
+	}
+
+	// logs doesn't exist yet, so it is left for the runtime not to create as root
+	want := []string{worktree, adminDir, filepath.Join(commonDir, "objects"), filepath.Join(commonDir, "refs")}
+	if !reflect.DeepEqual(spec.Mounts, want) {
+		t.Errorf("Mounts = %v, want %v", spec.Mounts, want)
+	}
+}
+
+func TestWorktreeGitDirsWithoutGitFile(t *testing.T) {
+	commonDir, adminDir := worktreeGitDirs("/src/repo", "/wt/missing")
+
+	if commonDir != "/src/repo/.git" || adminDir != "/src/repo/.git/worktrees/missing" {
+		t.Errorf("worktreeGitDirs() = %q, %q; want the default layout", commonDir, adminDir)
+	}
+}
@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kaeawc/auto-worktree/internal/git"
+	"github.com/kaeawc/auto-worktree/internal/session"
+	"github.com/kaeawc/auto-worktree/internal/ui"
+)
+
+// newOptions are the arguments to 'auto-worktree new'
+type newOptions struct {
+	Branch   string
+	Existing bool
+	// Scope is a package directory, relative to the repository root, that the
+	// session starts in and the AI tool is told to work within
+	Scope string
+	// Sparse checks out only Scope (and root-level files)
+	Sparse bool
+	// Prefix replaces the configured prefix of a generated branch name
+	Prefix string
+	// NoSession creates the worktree without starting a tmux session or AI tool
+	NoSession bool
+}
+
+// parseNewArgs parses: new [branch] [--existing <branch>] [--scope <dir>] [--sparse] [--prefix <prefix>] [--no-session]
+func parseNewArgs(args []string) (newOptions, error) {
+	var opts newOptions
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+
+		switch {
+		case arg == "--existing":
+			if i+1 >= len(args) {
+				return opts, fmt.Errorf("branch name required after --existing")
+			}
+
+			i++
+			opts.Branch = args[i]
+			opts.Existing = true
+		case arg == "--scope":
+			if i+1 >= len(args) {
+				return opts, fmt.Errorf("directory required after --scope")
+			}
+
+			i++
+			opts.Scope = args[i]
+		case strings.HasPrefix(arg, "--scope="):
+			opts.Scope = strings.TrimPrefix(arg, "--scope=")
+		case arg == "--sparse":
+			opts.Sparse = true
+		case arg == "--prefix":
+			if i+1 >= len(args) {
+				return opts, fmt.Errorf("prefix required after --prefix")
+			}
+
+			i++
+			opts.Prefix = args[i]
+		case strings.HasPrefix(arg, "--prefix="):
+			opts.Prefix = strings.TrimPrefix(arg, "--prefix=")
+		case arg == "--no-session":
+			opts.NoSession = true
+		case strings.HasPrefix(arg, "-"):
+			return opts, fmt.Errorf("unknown flag for new: %s", arg)
+		default:
+			opts.Branch = arg
+		}
+	}
+
+	if opts.Scope != "" {
+		scope, err := normalizeScope(opts.Scope)
+		if err != nil {
+			return opts, err
+		}
+
+		opts.Scope = scope
+	} else if opts.Sparse {
+		return opts, fmt.Errorf("--sparse requires --scope")
+	}
+
+	return opts, nil
+}
+
+// normalizeScope cleans a scope into a slash-separated path inside the repository
+func normalizeScope(scope string) (string, error) {
+	cleaned := filepath.ToSlash(filepath.Clean(strings.TrimSpace(scope)))
+
+	if filepath.IsAbs(cleaned) || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", fmt.Errorf("scope %q must be a directory inside the repository", scope)
+	}
+
+	if cleaned == "." {
+		return "", nil
+	}
+
+	return cleaned, nil
+}
+
+// scopeWorkDir is the directory a session starts in: the scope inside the worktree, or the worktree itself
+func scopeWorkDir(worktreePath, scope string) string {
+	if scope == "" {
+		return worktreePath
+	}
+
+	return filepath.Join(worktreePath, filepath.FromSlash(scope))
+}
+
+// scopeContext tells the AI tool which package of a monorepo the worktree is for
+func scopeContext(scope string) string {
+	if scope == "" {
+		return ""
+	}
+
+	return fmt.Sprintf("This worktree is scoped to the %s package of a monorepo, and you are starting in that directory. "+
+		"Keep changes within %s unless a change elsewhere in the repository is required.", scope, scope)
+}
+
+// applyScope configures sparse-checkout for a scoped worktree when asked to, then
+// checks the scope exists. A missing scope is only a warning: the session starts
+// at the worktree root instead, so the returned scope is empty.
+func applyScope(repo *git.Repository, config *git.Config, worktreePath string, opts newOptions) string {
+	if opts.Scope == "" {
+		return ""
+	}
+
+	if opts.Sparse || config.GetScopeSparseCheckout() {
+		fmt.Printf("Limiting checkout to %s (sparse-checkout)...\n", opts.Scope)
+
+		if err := repo.SetSparseCheckout(worktreePath, opts.Scope); err != nil {
+			fmt.Println(ui.WarningStyle.Render(fmt.Sprintf("⚠ %v; keeping the full checkout", err)))
+		}
+	}
+
+	if info, err := os.Stat(scopeWorkDir(worktreePath, opts.Scope)); err != nil || !info.IsDir() {
+		fmt.Println(ui.WarningStyle.Render(fmt.Sprintf(
+			"⚠ %s is not a directory in this branch; starting at the worktree root", opts.Scope)))
+
+		return ""
+	}
+
+	fmt.Printf("✓ Scoped to %s\n", opts.Scope)
+
+	return opts.Scope
+}
+
+// savedScope returns the scope recorded for a session, so a recreated session starts in the same package
+func savedScope(sessionMgr session.Manager, sessionName string) string {
+	metadata, err := sessionMgr.LoadSessionMetadata(sessionName)
+	if err != nil || metadata == nil {
+		return ""
+	}
+
+	return metadata.Scope
+}
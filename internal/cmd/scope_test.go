@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseNewArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    newOptions
+		wantErr bool
+	}{
+		{"none", nil, newOptions{}, false},
+		{"branch", []string{"feature/x"}, newOptions{Branch: "feature/x"}, false},
+		{"existing", []string{"--existing", "main"}, newOptions{Branch: "main", Existing: true}, false},
+		{"scope", []string{"feature/x", "--scope", "packages/foo/"}, newOptions{Branch: "feature/x", Scope: "packages/foo"}, false},
+		{"scope equals and sparse", []string{"--scope=./packages/foo", "--sparse"}, newOptions{Scope: "packages/foo", Sparse: true}, false},
+		{"scope is root", []string{"--scope", "."}, newOptions{}, false},
+		{"scope outside repo", []string{"--scope", "../other"}, newOptions{}, true},
+		{"absolute scope", []string{"--scope", "/tmp"}, newOptions{}, true},
+		{"sparse without scope", []string{"--sparse"}, newOptions{}, true},
+		{"missing scope value", []string{"--scope"}, newOptions{}, true},
+		{"prefix", []string{"--prefix", "feat/"}, newOptions{Prefix: "feat/"}, false},
+		{"prefix equals", []string{"--prefix=user/{user}/"}, newOptions{Prefix: "user/{user}/"}, false},
+		{"missing prefix value", []string{"--prefix"}, newOptions{}, true},
+		{"no session", []string{"feature/x", "--no-session"}, newOptions{Branch: "feature/x", NoSession: true}, false},
+		{"unknown flag", []string{"--bogus"}, newOptions{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseNewArgs(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseNewArgs(%v) error = %v, wantErr %v", tt.args, err, tt.wantErr)
+			}
+
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("parseNewArgs(%v) = %+v, want %+v", tt.args, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScopeWorkDirAndContext(t *testing.T) {
+	if got := scopeWorkDir("/wt", ""); got != "/wt" {
+		t.Errorf("scopeWorkDir() without scope = %q, want /wt", got)
+	}
+
+	if got := scopeWorkDir("/wt", "packages/foo"); got != "/wt/packages/foo" {
+		t.Errorf("scopeWorkDir() = %q, want /wt/packages/foo", got)
+	}
+
+	if scopeContext("") != "" {
+		t.Error("scopeContext() without scope should be empty")
+	}
+
+	if got := scopeContext("packages/foo"); !strings.Contains(got, "packages/foo") {
+		t.Errorf("scopeContext() = %q, want it to name the package", got)
+	}
+}
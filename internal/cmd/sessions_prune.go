@@ -0,0 +1,264 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/kaeawc/auto-worktree/internal/git"
+	"github.com/kaeawc/auto-worktree/internal/logging"
+	"github.com/kaeawc/auto-worktree/internal/session"
+	"github.com/kaeawc/auto-worktree/internal/state"
+	"github.com/kaeawc/auto-worktree/internal/ui"
+)
+
+// Why session metadata is pruned
+const (
+	pruneWorktreeGone = "worktree no longer exists"
+	pruneSessionEnded = "session ended"
+)
+
+// worktreeCachePrefixes start the state.BucketCache keys that hold something
+// about the worktree at the path that follows
+var worktreeCachePrefixes = []string{"status:", testResultPrefix}
+
+// SessionsPruneOptions configures `auto-worktree sessions prune`
+type SessionsPruneOptions struct {
+	// Ended also prunes sessions whose worktree is still there but whose
+	// tmux session has ended; without it they are kept for 'resume --all'
+	Ended bool
+	// DryRun reports what would be pruned without removing anything
+	DryRun bool
+}
+
+// prunedSession is session metadata a prune removes, and why
+type prunedSession struct {
+	metadata *session.Metadata
+	reason   string
+}
+
+// sessionsToPrune returns the sessions whose worktree is gone and, with ended,
+// those whose tmux session is gone too. Paused sessions were stopped on purpose
+// to be resumed later, so they are only pruned once their worktree is gone.
+func sessionsToPrune(all []*session.Metadata, live map[string]bool, worktreeExists func(string) bool, ended bool) []prunedSession {
+	var pruned []prunedSession
+
+	for _, m := range all {
+		switch {
+		case m.WorktreePath == "" || !worktreeExists(m.WorktreePath):
+			pruned = append(pruned, prunedSession{metadata: m, reason: pruneWorktreeGone})
+		case ended && !live[m.SessionName] && m.Status != session.StatusPaused:
+			pruned = append(pruned, prunedSession{metadata: m, reason: pruneSessionEnded})
+		}
+	}
+
+	return pruned
+}
+
+// orphanedCacheKeys returns the cache keys about worktrees that no longer exist
+func orphanedCacheKeys(keys []string, worktreeExists func(string) bool) []string {
+	var orphaned []string
+
+	for _, key := range keys {
+		for _, prefix := range worktreeCachePrefixes {
+			if path, ok := strings.CutPrefix(key, prefix); ok && !worktreeExists(path) {
+				orphaned = append(orphaned, key)
+				break
+			}
+		}
+	}
+
+	return orphaned
+}
+
+// RunSessionsPrune removes the saved metadata of sessions whose worktree is
+// gone (or, with Ended, whose tmux session is gone) and cached state about
+// removed worktrees, and reports what it removed
+func RunSessionsPrune(opts SessionsPruneOptions) error {
+	// Sessions are global, but run inside a frozen repository this waits for the thaw
+	if repo, err := git.NewRepository(); err == nil && !opts.DryRun {
+		if err := errIfFrozen(repo); err != nil {
+			return err
+		}
+	}
+
+	sessionMgr := session.NewManager()
+
+	all, err := sessionMgr.LoadAllSessionMetadata()
+	if err != nil {
+		return fmt.Errorf("failed to load sessions: %w", err)
+	}
+
+	live := map[string]bool{}
+
+	if opts.Ended {
+		names, err := sessionMgr.ListSessions()
+		if err != nil {
+			return fmt.Errorf("error listing sessions: %w", err)
+		}
+
+		for _, name := range names {
+			live[name] = true
+		}
+	}
+
+	exists := git.NewFileSystem().Exists
+	pruned := sessionsToPrune(all, live, exists, opts.Ended)
+
+	var orphaned []string
+
+	store, err := openStateStore()
+	if err == nil {
+		if keys, err := store.Keys(state.BucketCache); err == nil {
+			orphaned = orphanedCacheKeys(keys, exists)
+		}
+	}
+
+	if len(pruned) == 0 && len(orphaned) == 0 {
+		fmt.Printf("Nothing to prune: all %d session(s) have a worktree", len(all))
+
+		if !opts.Ended {
+			fmt.Print(" (--ended also prunes sessions whose tmux session is gone)")
+		}
+
+		fmt.Println()
+
+		return nil
+	}
+
+	verb := "Removed"
+	if opts.DryRun {
+		verb = "Would remove"
+	}
+
+	if len(pruned) > 0 {
+		fmt.Println(ui.TitleStyle.Render(fmt.Sprintf("%s the metadata of %d session(s):", verb, len(pruned))))
+
+		for _, p := range pruned {
+			if !opts.DryRun {
+				if err := sessionMgr.DeleteSessionMetadata(p.metadata.SessionName); err != nil {
+					fmt.Printf("  %s %s: %v\n", ui.ErrorStyle.Render("✗"), p.metadata.SessionName, err)
+					continue
+				}
+			}
+
+			fmt.Printf("  • %s  %s\n", p.metadata.SessionName, ui.SubtleStyle.Render(describePrunedSession(p)))
+		}
+	}
+
+	if len(orphaned) > 0 {
+		if !opts.DryRun {
+			deleteCacheKeys(store, orphaned)
+		}
+
+		fmt.Println(ui.SubtleStyle.Render(fmt.Sprintf("%s %d cached status and test result(s) of removed worktrees", verb, len(orphaned))))
+	}
+
+	return nil
+}
+
+// describePrunedSession says which worktree a pruned session was for and why it went
+func describePrunedSession(p prunedSession) string {
+	where := p.metadata.BranchName
+	if where == "" {
+		where = filepath.Base(p.metadata.WorktreePath)
+	}
+
+	detail := fmt.Sprintf("%s: %s", where, p.reason)
+
+	if p.metadata.TranscriptPath != "" {
+		detail += fmt.Sprintf(" (transcript kept at %s)", p.metadata.TranscriptPath)
+	}
+
+	return detail
+}
+
+// deleteCacheKeys removes keys from the cache bucket, logging failures
+func deleteCacheKeys(store *state.Store, keys []string) {
+	for _, key := range keys {
+		if err := store.Delete(state.BucketCache, key); err != nil {
+			logging.Warn("failed to delete cached state", "key", key, "err", err)
+		}
+	}
+}
+
+// orphanedSessions returns the sessions whose worktree under base is gone.
+// Sessions outside base, including those with no worktree recorded, belong to
+// other repositories or machines and are left to their own monitor.
+func orphanedSessions(all []*session.Metadata, base string, worktreeExists func(string) bool) []prunedSession {
+	return sessionsToPrune(sessionsUnder(all, base), nil, worktreeExists, false)
+}
+
+// collectOrphanedSessions removes the metadata of sessions whose worktree under
+// base is gone, and cached state about those worktrees. It returns the pruned
+// session names; with dryRun it only returns them.
+func collectOrphanedSessions(sessionMgr *session.SessionManager, base string, dryRun bool) []string {
+	fs := git.NewFileSystem()
+	gone := func(path string) bool {
+		return underWorktreeBase(base, path) && !fs.Exists(path)
+	}
+
+	all, err := sessionMgr.LoadAllSessionMetadata()
+	if err != nil {
+		logging.Warn("failed to load sessions to prune", "err", err)
+		return nil
+	}
+
+	var pruned []string
+
+	for _, p := range orphanedSessions(all, base, fs.Exists) {
+		if dryRun {
+			pruned = append(pruned, p.metadata.SessionName)
+			continue
+		}
+
+		if err := sessionMgr.DeleteSessionMetadata(p.metadata.SessionName); err != nil {
+			logging.Warn("failed to prune session metadata", "session", p.metadata.SessionName, "err", err)
+			continue
+		}
+
+		logging.Info("pruned session metadata", "session", p.metadata.SessionName, "worktree", p.metadata.WorktreePath, "reason", p.reason)
+		pruned = append(pruned, p.metadata.SessionName)
+	}
+
+	if dryRun {
+		return pruned
+	}
+
+	if store, err := openStateStore(); err == nil {
+		if keys, err := store.Keys(state.BucketCache); err == nil {
+			deleteCacheKeys(store, orphanedCacheKeys(keys, func(path string) bool { return !gone(path) }))
+		}
+	}
+
+	return pruned
+}
+
+// watchOrphanedSessions prunes orphaned sessions of repo now and then every
+// interval, skipping sweeps while it is frozen, until the returned function is called
+func watchOrphanedSessions(repo *git.Repository, interval time.Duration) func() {
+	sessionMgr := session.NewManager()
+	done := make(chan struct{})
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			if repo.IsFrozen() {
+				logging.Debug("repository is frozen; skipped pruning orphaned sessions")
+			} else {
+				collectOrphanedSessions(sessionMgr, repo.WorktreeBase, false)
+			}
+
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	return func() { close(done) }
+}
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kaeawc/auto-worktree/internal/session"
+)
+
+func TestSessionsToPrune(t *testing.T) {
+	all := []*session.Metadata{
+		{SessionName: "running", WorktreePath: "/wt/running", Status: session.StatusRunning},
+		{SessionName: "ended", WorktreePath: "/wt/ended", Status: session.StatusRunning},
+		{SessionName: "paused", WorktreePath: "/wt/paused", Status: session.StatusPaused},
+		{SessionName: "removed", WorktreePath: "/wt/removed", Status: session.StatusPaused},
+		{SessionName: "no-path", Status: session.StatusRunning},
+	}
+	live := map[string]bool{"running": true}
+	exists := func(path string) bool { return path != "/wt/removed" }
+
+	reasons := func(pruned []prunedSession) map[string]string {
+		got := map[string]string{}
+		for _, p := range pruned {
+			got[p.metadata.SessionName] = p.reason
+		}
+
+		return got
+	}
+
+	want := map[string]string{"removed": pruneWorktreeGone, "no-path": pruneWorktreeGone}
+	if got := reasons(sessionsToPrune(all, live, exists, false)); !reflect.DeepEqual(got, want) {
+		t.Errorf("sessionsToPrune() = %v, want %v", got, want)
+	}
+
+	want["ended"] = pruneSessionEnded
+	if got := reasons(sessionsToPrune(all, live, exists, true)); !reflect.DeepEqual(got, want) {
+		t.Errorf("sessionsToPrune(ended) = %v, want %v", got, want)
+	}
+}
+
+func TestOrphanedSessionsOnlyUnderBase(t *testing.T) {
+	all := []*session.Metadata{
+		{SessionName: "removed", WorktreePath: "/wt/app/removed"},
+		{SessionName: "kept", WorktreePath: "/wt/app/kept"},
+		{SessionName: "other-repo", WorktreePath: "/wt/api/removed"},
+		{SessionName: "no-path"},
+	}
+	exists := func(path string) bool { return path == "/wt/app/kept" }
+
+	got := orphanedSessions(all, "/wt/app", exists)
+	if len(got) != 1 || got[0].metadata.SessionName != "removed" {
+		t.Errorf("orphanedSessions() = %v, want only the removed worktree's session under the base", got)
+	}
+}
+
+func TestOrphanedCacheKeys(t *testing.T) {
+	keys := []string{"status:/wt/a", "status:/wt/gone", "test:/wt/gone", "test:/wt/a", "other:/wt/gone"}
+	exists := func(path string) bool { return path != "/wt/gone" }
+
+	want := []string{"status:/wt/gone", "test:/wt/gone"}
+	if got := orphanedCacheKeys(keys, exists); !reflect.DeepEqual(got, want) {
+		t.Errorf("orphanedCacheKeys() = %v, want %v", got, want)
+	}
+}
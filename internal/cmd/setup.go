@@ -0,0 +1,313 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/kaeawc/auto-worktree/internal/ai"
+	"github.com/kaeawc/auto-worktree/internal/git"
+	"github.com/kaeawc/auto-worktree/internal/logging"
+	"github.com/kaeawc/auto-worktree/internal/state"
+	"github.com/kaeawc/auto-worktree/internal/ui"
+)
+
+// setupSkip is the menu action for leaving a setup step unchanged
+const setupSkip = "skip"
+
+// setupDismissedKey is the state.BucketCache key recording that setup was declined for a repository
+func setupDismissedKey(rootPath string) string {
+	return "setup-dismissed:" + rootPath
+}
+
+// needsFirstRunSetup reports whether to offer the setup wizard: an interactive
+// terminal and no auto-worktree settings at any scope
+func needsFirstRunSetup(cfg *git.Config) bool {
+	return !ui.IsPlain() && !cfg.HasSettings()
+}
+
+// maybeRunFirstRunSetup offers the setup wizard the first time the menu opens
+// in an unconfigured repository. Declining is remembered per repository.
+func maybeRunFirstRunSetup() {
+	repo, err := git.NewRepository()
+	if err != nil || !needsFirstRunSetup(repo.Config) {
+		return
+	}
+
+	store, storeErr := openStateStore()
+	if storeErr == nil {
+		var dismissed bool
+		if err := store.Get(state.BucketCache, setupDismissedKey(repo.RootPath), &dismissed); err == nil && dismissed {
+			return
+		}
+	}
+
+	m, err := ui.Run(ui.NewConfirmModel("No auto-worktree settings found. Run the setup wizard now?"))
+	if err != nil {
+		return
+	}
+
+	if confirm, ok := m.(ui.ConfirmModel); !ok || !confirm.GetChoice() {
+		fmt.Println("Skipped setup. Run 'auto-worktree setup' any time.")
+
+		if storeErr == nil {
+			if err := store.Put(state.BucketCache, setupDismissedKey(repo.RootPath), true); err != nil {
+				logging.Debug("failed to record skipped setup", "err", err)
+			}
+		}
+
+		return
+	}
+
+	if err := runSetupWizard(repo); err != nil {
+		logging.Warn("setup did not finish", "err", err)
+	}
+}
+
+// RunSetup runs the guided setup wizard for the current repository
+func RunSetup() error {
+	repo, err := git.NewRepository()
+	if err != nil {
+		return fmt.Errorf("failed to initialize repository: %w", err)
+	}
+
+	return runSetupWizard(repo)
+}
+
+// runSetupWizard walks through provider, authentication, AI tool, worktree
+// location and cleanup policy, saving each answer to the repository's config
+func runSetupWizard(repo *git.Repository) error {
+	cfg := repo.Config
+
+	fmt.Println("\n" + ui.InfoStyle.Render("auto-worktree Setup"))
+	fmt.Println("===================")
+	fmt.Println("Every answer is saved to this repository's git config; change them later with 'auto-worktree settings'.")
+
+	remote, _ := cfg.Get("remote.origin.url", git.ConfigScopeLocal) //nolint:errcheck // a missing remote just disables detection
+	detected := detectProviderFromURL(remote)
+
+	fmt.Println("\n" + ui.BoldStyle.Render("1/5 Issue provider"))
+
+	provider, err := chooseSetupProvider(detected)
+	if err != nil {
+		return err
+	}
+
+	if provider != "" {
+		saveSetting(cfg, git.ConfigIssueProvider, provider)
+	}
+
+	// Issues elsewhere, code on GitHub/GitLab: pull requests go to the detected host
+	codeHost := ""
+	if detected != "" && provider != "" && provider != detected && (provider == providerJira || provider == providerLinear) {
+		codeHost = detected
+		saveSetting(cfg, git.ConfigCodeHost, codeHost)
+	}
+
+	fmt.Println("\n" + ui.BoldStyle.Render("2/5 Authentication"))
+	verifySetupAuth(cfg, provider, codeHost)
+
+	fmt.Println("\n" + ui.BoldStyle.Render("3/5 AI coding assistant"))
+
+	if err := chooseSetupAITool(cfg); err != nil {
+		return err
+	}
+
+	fmt.Println("\n" + ui.BoldStyle.Render("4/5 Worktree location"))
+
+	if err := chooseSetupWorktreeBase(cfg, filepath.Dir(repo.WorktreeBase), repo.SourceFolder); err != nil {
+		return err
+	}
+
+	fmt.Println("\n" + ui.BoldStyle.Render("5/5 Cleanup policy"))
+
+	if err := chooseSetupCleanupPolicy(cfg); err != nil {
+		return err
+	}
+
+	fmt.Println()
+	fmt.Println(ui.SuccessStyle.Render("✓ Setup complete"))
+	fmt.Println()
+
+	return nil
+}
+
+// detectProviderFromURL returns the code host a remote URL points at, or "" if unknown
+func detectProviderFromURL(url string) string {
+	url = strings.ToLower(url)
+
+	switch {
+	case strings.Contains(url, "github"):
+		return providerGitHub
+	case strings.Contains(url, "gitlab"):
+		return providerGitLab
+	default:
+		return ""
+	}
+}
+
+// setupProviderItems lists the issue providers with the detected one first
+func setupProviderItems(detected string) []ui.MenuItem {
+	items := []ui.MenuItem{
+		ui.NewMenuItem("GitHub", "GitHub Issues and Pull Requests (gh)", providerGitHub),
+		ui.NewMenuItem("GitLab", "GitLab Issues and Merge Requests (glab)", providerGitLab),
+		ui.NewMenuItem("JIRA", "Atlassian JIRA issues (jira)", providerJira),
+		ui.NewMenuItem("Linear", "Linear issues (linear)", providerLinear),
+	}
+
+	for i, item := range items {
+		if item.Action() == detected {
+			detectedItem := ui.NewMenuItem(item.Title()+" (detected from origin)", item.Description(), item.Action())
+			items = append([]ui.MenuItem{detectedItem}, append(items[:i:i], items[i+1:]...)...)
+
+			break
+		}
+	}
+
+	return append(items, ui.NewMenuItem("Skip", "Auto-detect on every run", setupSkip))
+}
+
+// chooseSetupProvider asks for the issue provider; "" means skip
+func chooseSetupProvider(detected string) (string, error) {
+	choice, err := runSetupMenu("Where do this repository's issues live?", setupProviderItems(detected))
+	if err != nil || choice == setupSkip {
+		return "", err
+	}
+
+	return choice, nil
+}
+
+// verifySetupAuth checks that the CLIs for the chosen providers are installed and signed in
+func verifySetupAuth(cfg *git.Config, provider, codeHost string) {
+	if provider == "" {
+		fmt.Println("  Skipped (no provider chosen)")
+		return
+	}
+
+	for _, p := range []string{provider, codeHost} {
+		if p == "" {
+			continue
+		}
+
+		if err := checkProviderAuth(p); err != nil {
+			fmt.Printf("  %s %s\n", ui.WarningStyle.Render("✗"), providerCLIs[p])
+			fmt.Println(indentLines(err.Error(), "    "))
+
+			continue
+		}
+
+		fmt.Printf("  %s %s is installed and signed in\n", ui.SuccessStyle.Render("✓"), providerCLIs[p])
+	}
+
+	if provider == providerJira && cfg.GetJiraServer() == "" {
+		if err := setupJIRAInteractive(cfg, git.ConfigScopeLocal); err != nil {
+			logging.Warn("JIRA setup did not finish", "err", err)
+		}
+	}
+}
+
+// chooseSetupAITool asks which installed AI assistant to start in new worktrees
+func chooseSetupAITool(cfg *git.Config) error {
+	tools := ai.NewResolver(cfg).ListAvailable()
+	if len(tools) == 0 {
+		showAIInstallInstructions()
+		return nil
+	}
+
+	tool, err := selectAIToolInteractive(tools)
+	if err != nil {
+		return err
+	}
+
+	if tool == nil {
+		saveSetting(cfg, git.ConfigAITool, aiToolSkip)
+		return nil
+	}
+
+	if err := saveAIToolChoice(cfg, tool.Name); err != nil {
+		return fmt.Errorf("failed to save AI tool: %w", err)
+	}
+
+	fmt.Printf("  %s %s = %s\n", ui.SuccessStyle.Render("✓"), git.ConfigAITool, tool.Name)
+
+	return nil
+}
+
+// chooseSetupWorktreeBase asks where worktrees go, saving only a non-default answer
+func chooseSetupWorktreeBase(cfg *git.Config, current, sourceFolder string) error {
+	input := ui.NewInput(fmt.Sprintf("Parent directory for worktrees (this repo uses <dir>/%s):", sourceFolder), "~/worktrees").
+		WithValue(current)
+
+	m, err := ui.Run(input)
+	if err != nil {
+		return fmt.Errorf("failed to get input: %w", err)
+	}
+
+	finalModel, ok := m.(ui.InputModel)
+	if !ok {
+		return fmt.Errorf("unexpected model type")
+	}
+
+	if finalModel.Err() != nil {
+		return finalModel.Err()
+	}
+
+	if base := strings.TrimSpace(finalModel.Value()); base != "" && base != current {
+		saveSetting(cfg, git.ConfigWorktreeBase, base)
+	}
+
+	return nil
+}
+
+// chooseSetupCleanupPolicy asks how merged worktrees are handled at startup
+func chooseSetupCleanupPolicy(cfg *git.Config) error {
+	choice, err := runSetupMenu("What should happen to merged worktrees?", []ui.MenuItem{
+		ui.NewMenuItem("Ask each time", "Prompt before removing each merged worktree", git.CleanupPolicyPrompt),
+		ui.NewMenuItem("Remove automatically", "Remove merged worktrees and branches with nothing unpushed", git.CleanupPolicyAuto),
+		ui.NewMenuItem("Never at startup", "Only clean up when running 'auto-worktree cleanup'", git.CleanupPolicyOff),
+	})
+	if err != nil || choice == "" {
+		return err
+	}
+
+	saveSetting(cfg, git.ConfigCleanupPolicy, choice)
+
+	return nil
+}
+
+// runSetupMenu shows a one-choice menu; "" means it was canceled
+func runSetupMenu(title string, items []ui.MenuItem) (string, error) {
+	m, err := ui.Run(ui.NewMenu(title, items))
+	if err != nil {
+		return "", fmt.Errorf("failed to run menu: %w", err)
+	}
+
+	finalModel, ok := m.(ui.MenuModel)
+	if !ok {
+		return "", fmt.Errorf("unexpected model type")
+	}
+
+	return finalModel.Choice(), nil
+}
+
+// saveSetting writes a validated setting to the repository config and reports the result
+func saveSetting(cfg *git.Config, key, value string) {
+	if err := cfg.SetValidated(key, value, git.ConfigScopeLocal); err != nil {
+		logging.Warn("failed to save "+key, "err", err)
+		return
+	}
+
+	fmt.Printf("  %s %s = %s\n", ui.SuccessStyle.Render("✓"), key, value)
+}
+
+// indentLines prefixes every non-empty line of s with indent
+func indentLines(s, indent string) string {
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+	for i, line := range lines {
+		if strings.TrimSpace(line) != "" {
+			lines[i] = indent + line
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/kaeawc/auto-worktree/internal/git"
+)
+
+func TestDetectProviderFromURL(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{"git@github.com:kaeawc/auto-worktree.git", providerGitHub},
+		{"https://github.example.com/team/repo.git", providerGitHub},
+		{"https://gitlab.com/group/project.git", providerGitLab},
+		{"ssh://git@GitLab.internal:2222/group/project.git", providerGitLab},
+		{"https://bitbucket.org/team/repo.git", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := detectProviderFromURL(tt.url); got != tt.want {
+			t.Errorf("detectProviderFromURL(%q) = %q, want %q", tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestSetupProviderItems(t *testing.T) {
+	items := setupProviderItems(providerGitLab)
+
+	if len(items) != 5 {
+		t.Fatalf("got %d items, want 5", len(items))
+	}
+
+	if items[0].Action() != providerGitLab || items[0].Title() != "GitLab (detected from origin)" {
+		t.Errorf("first item = %q (%s), want detected GitLab", items[0].Title(), items[0].Action())
+	}
+
+	if items[1].Action() != providerGitHub || items[4].Action() != setupSkip {
+		t.Errorf("unexpected order: %s ... %s", items[1].Action(), items[4].Action())
+	}
+
+	if undetected := setupProviderItems(""); undetected[0].Action() != providerGitHub {
+		t.Errorf("first item without detection = %s, want github", undetected[0].Action())
+	}
+}
+
+func TestNeedsFirstRunSetup(t *testing.T) {
+	fake := git.NewFakeGitExecutor()
+	cfg := git.NewConfigWithExecutor("/repo", fake)
+
+	if !needsFirstRunSetup(cfg) {
+		t.Error("needsFirstRunSetup() = false for an unconfigured repository")
+	}
+
+	fake.SetResponse(`config --get-regexp ^auto-worktree\.`, git.ConfigAITool+" claude")
+
+	if needsFirstRunSetup(cfg) {
+		t.Error("needsFirstRunSetup() = true for a configured repository")
+	}
+}
@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/kaeawc/auto-worktree/internal/remote"
+	"github.com/kaeawc/auto-worktree/internal/ui"
+)
+
+// cdFileEnv names the file the shell wrapper from 'auto-worktree shell-init'
+// reads after each command; a directory written there becomes the shell's cwd
+const cdFileEnv = "AUTO_WORKTREE_CD_FILE"
+
+// shellInitShells are the shells 'auto-worktree shell-init' supports
+var shellInitShells = []string{"bash", "zsh", "fish"}
+
+// posixShellInit defines auto-worktree and aw as functions that run the
+// binary and then cd to the directory it asked for, if any. zsh reserves
+// $status, so the exit code is kept in $ret.
+const posixShellInit = `# auto-worktree shell integration: eval "$(auto-worktree shell-init %[1]s)"
+auto-worktree() {
+  local cd_file ret
+  cd_file="$(mktemp "${TMPDIR:-/tmp}/auto-worktree-cd.XXXXXX")" || {
+    command auto-worktree "$@"
+    return
+  }
+  %[2]s="$cd_file" command auto-worktree "$@"
+  ret=$?
+  if [ -s "$cd_file" ]; then
+    cd -- "$(cat "$cd_file")" || ret=$?
+  fi
+  rm -f -- "$cd_file"
+  return $ret
+}
+aw() { auto-worktree "$@"; }
+`
+
+// fishShellInit is posixShellInit for fish
+const fishShellInit = `# auto-worktree shell integration: auto-worktree shell-init fish | source
+function auto-worktree --wraps auto-worktree
+    set -l cd_file (mktemp "$TMPDIR/auto-worktree-cd.XXXXXX" 2>/dev/null; or mktemp)
+    or begin
+        command auto-worktree $argv
+        return
+    end
+    env %[1]s=$cd_file auto-worktree $argv
+    set -l ret $status
+    if test -s $cd_file
+        cd (cat $cd_file); or set ret $status
+    end
+    rm -f -- $cd_file
+    return $ret
+end
+function aw --wraps auto-worktree
+    auto-worktree $argv
+end
+`
+
+// shellInitScript returns the wrapper functions for shell
+func shellInitScript(shell string) (string, error) {
+	switch shell {
+	case "bash", "zsh":
+		return fmt.Sprintf(posixShellInit, shell, cdFileEnv), nil
+	case "fish":
+		return fmt.Sprintf(fishShellInit, cdFileEnv), nil
+	default:
+		return "", fmt.Errorf("unsupported shell %q (want one of: %s)", shell, strings.Join(shellInitShells, ", "))
+	}
+}
+
+// RunShellInit prints the shell integration for shell, to be evaluated from
+// the shell's startup file
+func RunShellInit(shell string) error {
+	if shell == "" {
+		return fmt.Errorf("usage: auto-worktree shell-init <%s>", strings.Join(shellInitShells, "|"))
+	}
+
+	script, err := shellInitScript(shell)
+	if err != nil {
+		return err
+	}
+
+	fmt.Print(script)
+
+	return nil
+}
+
+// changeDirectoryOnExit asks the shell wrapper to cd to dir once this command
+// exits. It reports whether it could; without the wrapper (or on a remote
+// host, where dir isn't local) the caller should print the cd command instead.
+func changeDirectoryOnExit(dir string) bool {
+	cdFile := os.Getenv(cdFileEnv)
+	if cdFile == "" || remote.Enabled() {
+		return false
+	}
+
+	if err := os.WriteFile(cdFile, []byte(dir), 0o600); err != nil {
+		return false
+	}
+
+	return true
+}
+
+// showWorktreeDirectory moves the parent shell to dir when the shell
+// integration is installed, or prints how to get there
+func showWorktreeDirectory(heading, dir string) {
+	if changeDirectoryOnExit(dir) {
+		fmt.Println(ui.SuccessStyle.Render("✓ Changing directory to " + dir))
+		return
+	}
+
+	fmt.Printf("\n%s:\n", heading)
+	fmt.Printf("  cd %s\n", dir)
+}
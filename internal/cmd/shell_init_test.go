@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestShellInitScript(t *testing.T) {
+	for _, shell := range shellInitShells {
+		t.Run(shell, func(t *testing.T) {
+			script, err := shellInitScript(shell)
+			if err != nil {
+				t.Fatalf("shellInitScript(%q) error = %v", shell, err)
+			}
+
+			if !strings.Contains(script, cdFileEnv+"=") || !strings.Contains(script, "command auto-worktree") {
+				t.Errorf("shellInitScript(%q) does not pass %s to the binary:\n%s", shell, cdFileEnv, script)
+			}
+
+			// Check the syntax when the shell is installed
+			path, err := exec.LookPath(shell)
+			if err != nil {
+				return
+			}
+
+			flag := "-n"
+			if shell == "fish" {
+				flag = "--no-execute"
+			}
+
+			if out, err := exec.Command(path, flag, "-c", script).CombinedOutput(); err != nil {
+				t.Errorf("%s %s: %v\n%s", shell, flag, err, out)
+			}
+		})
+	}
+
+	if _, err := shellInitScript("powershell"); err == nil {
+		t.Error("shellInitScript(powershell) = nil error")
+	}
+}
+
+func TestShellInitChangesDirectory(t *testing.T) {
+	bash, err := exec.LookPath("bash")
+	if err != nil {
+		t.Skip("bash not installed")
+	}
+
+	script, err := shellInitScript("bash")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A fake auto-worktree that asks to move to $TARGET
+	bin := t.TempDir()
+	fake := "#!/bin/sh\nprintf '%s' \"$TARGET\" > \"$" + cdFileEnv + "\"\n"
+
+	if err := os.WriteFile(filepath.Join(bin, "auto-worktree"), []byte(fake), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	target := t.TempDir()
+
+	cmd := exec.Command(bash, "-c", script+"\naw new --no-session && pwd -P")
+	cmd.Env = append(os.Environ(), "PATH="+bin+string(os.PathListSeparator)+os.Getenv("PATH"), "TARGET="+target)
+
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("bash: %v", err)
+	}
+
+	want, _ := filepath.EvalSymlinks(target) //nolint:errcheck
+	if got := strings.TrimSpace(string(out)); got != want {
+		t.Errorf("pwd after aw = %q, want %q", got, want)
+	}
+}
+
+func TestChangeDirectoryOnExit(t *testing.T) {
+	t.Setenv(cdFileEnv, "")
+
+	if changeDirectoryOnExit("/tmp") {
+		t.Error("changeDirectoryOnExit() = true without the shell integration")
+	}
+
+	cdFile := filepath.Join(t.TempDir(), "cd")
+	t.Setenv(cdFileEnv, cdFile)
+
+	if !changeDirectoryOnExit("/work/feature-x") {
+		t.Fatal("changeDirectoryOnExit() = false with the shell integration")
+	}
+
+	if got, err := os.ReadFile(cdFile); err != nil || string(got) != "/work/feature-x" {
+		t.Errorf("cd file = %q, %v; want /work/feature-x", got, err)
+	}
+}
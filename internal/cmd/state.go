@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/kaeawc/auto-worktree/internal/state"
+	"github.com/kaeawc/auto-worktree/internal/ui"
+)
+
+// openStateStore opens the state database in the default location
+func openStateStore() (*state.Store, error) {
+	path, err := state.DefaultPath()
+	if err != nil {
+		return nil, err
+	}
+
+	return state.NewStore(path), nil
+}
+
+// RunStateInfo prints the state database location, schema version, and bucket sizes
+func RunStateInfo() error {
+	store, err := openStateStore()
+	if err != nil {
+		return err
+	}
+
+	version, buckets, err := store.Info()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Database: %s\n", store.Path())
+	fmt.Printf("Schema:   v%d\n\n", version)
+
+	fmt.Printf("  %-16s %6s\n", "BUCKET", "KEYS")
+
+	for _, b := range buckets {
+		fmt.Printf("  %-16s %6d\n", b.Name, b.Keys)
+	}
+
+	fmt.Println()
+	fmt.Println(ui.SubtleStyle.Render("Inspect a bucket with: auto-worktree state dump <bucket>"))
+
+	return nil
+}
+
+// RunStateDump prints every key and value in a bucket
+func RunStateDump(bucket string) error {
+	store, err := openStateStore()
+	if err != nil {
+		return err
+	}
+
+	count := 0
+
+	err = store.ForEach(bucket, func(key string, value []byte) error {
+		count++
+
+		fmt.Println(ui.BoldStyle.Render(key))
+		fmt.Println(indentJSON(value))
+		fmt.Println()
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		fmt.Printf("Bucket %q is empty\n", bucket)
+	}
+
+	return nil
+}
+
+// RunStateGet prints a single value from a bucket
+func RunStateGet(bucket, key string) error {
+	store, err := openStateStore()
+	if err != nil {
+		return err
+	}
+
+	var value json.RawMessage
+	if err := store.Get(bucket, key, &value); err != nil {
+		return err
+	}
+
+	fmt.Println(indentJSON(value))
+
+	return nil
+}
+
+// indentJSON pretty-prints a JSON value, falling back to the raw bytes
+func indentJSON(value []byte) string {
+	var out bytes.Buffer
+	if err := json.Indent(&out, value, "", "  "); err != nil {
+		return string(value)
+	}
+
+	return out.String()
+}
@@ -0,0 +1,347 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kaeawc/auto-worktree/internal/ai"
+	"github.com/kaeawc/auto-worktree/internal/events"
+	"github.com/kaeawc/auto-worktree/internal/git"
+	"github.com/kaeawc/auto-worktree/internal/logging"
+	"github.com/kaeawc/auto-worktree/internal/session"
+	"github.com/kaeawc/auto-worktree/internal/state"
+	"github.com/kaeawc/auto-worktree/internal/ui"
+)
+
+// DefaultStatsWeeks is how many weeks `auto-worktree stats` covers without --weeks
+const DefaultStatsWeeks = 8
+
+// maxStatsUsageRows is how many of the costliest worktrees and repositories stats lists
+const maxStatsUsageRows = 5
+
+// StatsOptions configures `auto-worktree stats`
+type StatsOptions struct {
+	Weeks int
+	// AllRepos includes every repository instead of the current one
+	AllRepos bool
+	JSON     bool
+}
+
+// WeekCount is the number of worktrees created in one ISO week, e.g. "2024-W18"
+type WeekCount struct {
+	Week  string `json:"week"`
+	Count int    `json:"count"`
+}
+
+// Stats are productivity metrics derived from the event log
+type Stats struct {
+	Since          time.Time   `json:"since"`
+	CreatedPerWeek []WeekCount `json:"createdPerWeek"`
+	Created        int         `json:"created"`
+	Removed        int         `json:"removed"`
+	// AverageLifetimeHours covers worktrees whose creation and removal are both in the log
+	AverageLifetimeHours float64 `json:"averageLifetimeHours"`
+	LifetimeSamples      int     `json:"lifetimeSamples"`
+	// MergeRate is the share of removed worktrees that cleanup found merged
+	MergeRate float64 `json:"mergeRate"`
+	Merged    int     `json:"merged"`
+	// Cleanups counts cleanup removals by reason (merged, stale, orphaned, manual)
+	Cleanups map[string]int `json:"cleanups"`
+	// AISessions counts sessions started per AI tool ("shell" when none ran)
+	AISessions map[string]int `json:"aiSessions"`
+	// AIUsage totals the AI tool usage recorded in session metadata, over all time
+	AIUsage ai.Usage `json:"aiUsage"`
+	// UsageByWorktree and UsageByRepo break AIUsage down, costliest first
+	UsageByWorktree []UsageTotal `json:"usageByWorktree,omitempty"`
+	UsageByRepo     []UsageTotal `json:"usageByRepo,omitempty"`
+}
+
+// RunStats prints metrics about worktrees and sessions from the event log
+func RunStats(opts StatsOptions) error {
+	if opts.Weeks <= 0 {
+		opts.Weeks = DefaultStatsWeeks
+	}
+
+	now := time.Now()
+	filter := events.Filter{Since: startOfWeek(now).AddDate(0, 0, -7*(opts.Weeks-1))}
+
+	if !opts.AllRepos {
+		repo, err := git.NewRepository()
+		if err != nil {
+			return fmt.Errorf("error: %w (use --all outside a repository)", err)
+		}
+
+		filter.RepoPath = repo.RootPath
+		filter.Dirs = []string{repo.RootPath, repo.WorktreeBase}
+	}
+
+	path, err := state.DefaultPath()
+	if err != nil {
+		return err
+	}
+
+	// Lifetimes need creations from before the window, so read the whole log
+	all, err := events.NewStore(path).List(events.Filter{RepoPath: filter.RepoPath, Dirs: filter.Dirs})
+	if err != nil {
+		return err
+	}
+
+	stats := computeStats(all, filter.Since, opts.Weeks)
+	stats.AIUsage, stats.UsageByWorktree, stats.UsageByRepo = statsUsage(all, filter)
+
+	if opts.JSON {
+		data, err := json.MarshalIndent(stats, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal stats: %w", err)
+		}
+
+		fmt.Println(string(data))
+
+		return nil
+	}
+
+	printStats(stats, opts.Weeks)
+
+	return nil
+}
+
+// computeStats summarizes the events since a time; earlier events only supply creation times for lifetimes
+func computeStats(list []events.Event, since time.Time, weeks int) Stats {
+	stats := Stats{Since: since, Cleanups: map[string]int{}, AISessions: map[string]int{}}
+
+	perWeek := map[string]int{}
+	createdAt := map[string]time.Time{}
+
+	var lifetime time.Duration
+
+	for _, e := range list {
+		if e.Error != "" {
+			continue
+		}
+
+		inWindow := !e.Time.Before(since)
+
+		switch e.Action {
+		case events.ActionCreate:
+			createdAt[e.Path] = e.Time
+
+			if inWindow {
+				stats.Created++
+				perWeek[weekLabel(e.Time)]++
+			}
+		case events.ActionRemove:
+			created, ok := createdAt[e.Path]
+			delete(createdAt, e.Path)
+
+			if !inWindow {
+				continue
+			}
+
+			stats.Removed++
+
+			if ok {
+				lifetime += e.Time.Sub(created)
+				stats.LifetimeSamples++
+			}
+		case events.ActionCleanup:
+			if inWindow {
+				stats.Cleanups[cleanupCategory(e.Detail)]++
+
+				if e.Merged {
+					stats.Merged++
+				}
+			}
+		case events.ActionSessionStart:
+			if inWindow {
+				stats.AISessions[aiToolOf(e.Detail)]++
+			}
+		}
+	}
+
+	for i := 0; i < weeks; i++ {
+		label := weekLabel(since.AddDate(0, 0, 7*i))
+		stats.CreatedPerWeek = append(stats.CreatedPerWeek, WeekCount{Week: label, Count: perWeek[label]})
+	}
+
+	if stats.LifetimeSamples > 0 {
+		stats.AverageLifetimeHours = (lifetime / time.Duration(stats.LifetimeSamples)).Hours()
+	}
+
+	if stats.Removed > 0 {
+		stats.MergeRate = float64(stats.Merged) / float64(stats.Removed)
+	}
+
+	return stats
+}
+
+// statsUsage totals the AI tool usage of the sessions the filter selects,
+// reading it from each tool's transcripts first. Usage lives in session
+// metadata rather than the event log, whose create events give the
+// repository of each worktree.
+func statsUsage(all []events.Event, filter events.Filter) (ai.Usage, []UsageTotal, []UsageTotal) {
+	sessionMgr := session.NewManager()
+
+	list, err := sessionMgr.LoadAllSessionMetadata()
+	if err != nil {
+		logging.Warn("failed to load session metadata", "err", err)
+		return ai.Usage{}, nil, nil
+	}
+
+	scope := events.Filter{RepoPath: filter.RepoPath, Dirs: filter.Dirs}
+	repoOf := map[string]string{}
+
+	for _, e := range all {
+		if e.Action == events.ActionCreate && e.RepoPath != "" {
+			repoOf[e.Path] = e.RepoPath
+		}
+	}
+
+	selected := make([]*session.Metadata, 0, len(list))
+
+	for _, metadata := range list {
+		if !scope.Matches(events.Event{Path: metadata.WorktreePath}) {
+			continue
+		}
+
+		if filter.RepoPath != "" {
+			repoOf[metadata.WorktreePath] = filter.RepoPath
+		}
+
+		selected = append(selected, metadata)
+	}
+
+	refreshAllUsage(sessionMgr, selected)
+
+	return computeUsage(selected, repoOf)
+}
+
+// startOfWeek returns midnight on the Monday of t's week
+func startOfWeek(t time.Time) time.Time {
+	days := (int(t.Weekday()) + 6) % 7
+	y, m, d := t.AddDate(0, 0, -days).Date()
+
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
+
+// weekLabel names t's ISO week, e.g. "2024-W18"
+func weekLabel(t time.Time) string {
+	year, week := t.ISOWeek()
+	return fmt.Sprintf("%d-W%02d", year, week)
+}
+
+// cleanupCategory reduces a cleanup reason such as "stale (40 days old)" to its kind
+func cleanupCategory(reason string) string {
+	kind, _, _ := strings.Cut(reason, " ")
+	if kind == "" {
+		return "manual"
+	}
+
+	return kind
+}
+
+// aiToolOf finds the AI tool in a session command, which may be wrapped by
+// a sandbox or systemd-run, or "shell" when the session ran none
+func aiToolOf(command string) string {
+	for _, word := range strings.Fields(command) {
+		if tool := filepath.Base(word); tool != "skip" && slices.Contains(git.ValidAITools, tool) {
+			return tool
+		}
+	}
+
+	return "shell"
+}
+
+// printStats prints the metrics as a short report
+func printStats(s Stats, weeks int) {
+	fmt.Println(ui.TitleStyle.Render(fmt.Sprintf("Worktree stats (last %d weeks)", weeks)))
+	fmt.Println()
+
+	if s.Created == 0 && s.Removed == 0 && len(s.AISessions) == 0 && s.AIUsage.Tokens() == 0 {
+		fmt.Println("No activity recorded yet. Stats come from the event log ('auto-worktree history events').")
+		return
+	}
+
+	most := 0
+	for _, w := range s.CreatedPerWeek {
+		most = max(most, w.Count)
+	}
+
+	fmt.Println("Created per week:")
+
+	for _, w := range s.CreatedPerWeek {
+		bar := ""
+		if most > 0 {
+			bar = strings.Repeat("█", w.Count*20/most)
+		}
+
+		fmt.Printf("  %s  %-20s %d\n", w.Week, bar, w.Count)
+	}
+
+	fmt.Println()
+	fmt.Printf("Worktrees created:  %d\n", s.Created)
+	fmt.Printf("Worktrees removed:  %d\n", s.Removed)
+
+	if s.LifetimeSamples > 0 {
+		fmt.Printf("Average lifetime:   %s %s\n", formatAge(time.Duration(s.AverageLifetimeHours*float64(time.Hour))),
+			ui.SubtleStyle.Render(fmt.Sprintf("(%d worktree(s) created and removed)", s.LifetimeSamples)))
+	}
+
+	if s.Removed > 0 {
+		fmt.Printf("Merge rate:         %.0f%% %s\n", s.MergeRate*100,
+			ui.SubtleStyle.Render(fmt.Sprintf("(%d of %d removed worktree(s) were merged)", s.Merged, s.Removed)))
+	}
+
+	fmt.Printf("Cleanups:           %s\n", formatCounts(s.Cleanups))
+	fmt.Printf("AI sessions:        %s\n", formatCounts(s.AISessions))
+
+	if s.AIUsage.Tokens() == 0 {
+		return
+	}
+
+	fmt.Printf("AI usage:           %s %s\n", formatUsage(&s.AIUsage), ui.SubtleStyle.Render("(all time)"))
+
+	for _, wt := range s.UsageByWorktree[:min(len(s.UsageByWorktree), maxStatsUsageRows)] {
+		fmt.Printf("  %-28s %s\n", filepath.Base(wt.Path), formatUsage(&wt.Usage))
+	}
+
+	// Repositories are only told apart with --all
+	if len(s.UsageByRepo) > 1 {
+		fmt.Println("AI usage by repository:")
+
+		for _, repo := range s.UsageByRepo[:min(len(s.UsageByRepo), maxStatsUsageRows)] {
+			fmt.Printf("  %-28s %s\n", filepath.Base(repo.Path), formatUsage(&repo.Usage))
+		}
+	}
+}
+
+// formatCounts renders counts as "claude 10, codex 2", largest first
+func formatCounts(counts map[string]int) string {
+	if len(counts) == 0 {
+		return "none"
+	}
+
+	keys := make([]string, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if counts[keys[i]] != counts[keys[j]] {
+			return counts[keys[i]] > counts[keys[j]]
+		}
+
+		return keys[i] < keys[j]
+	})
+
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		parts[i] = fmt.Sprintf("%s %d", k, counts[k])
+	}
+
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kaeawc/auto-worktree/internal/events"
+)
+
+func TestComputeStats(t *testing.T) {
+	since := time.Date(2024, 4, 29, 0, 0, 0, 0, time.UTC) // Monday of 2024-W18
+	day := func(n int) time.Time { return since.AddDate(0, 0, n) }
+
+	list := []events.Event{
+		// Created before the window: counts for lifetime only
+		{Action: events.ActionCreate, Path: "/wt/old", Time: day(-3)},
+		{Action: events.ActionCreate, Path: "/wt/a", Time: day(0)},
+		{Action: events.ActionCreate, Path: "/wt/b", Time: day(1)},
+		{Action: events.ActionCreate, Path: "/wt/c", Time: day(8)},
+		{Action: events.ActionCreate, Path: "/wt/failed", Time: day(8), Error: "exists"},
+		{Action: events.ActionSessionStart, Path: "/wt/a", Detail: "docker exec -it abc /usr/local/bin/claude", Time: day(0)},
+		{Action: events.ActionSessionStart, Path: "/wt/b", Detail: "codex", Time: day(1)},
+		{Action: events.ActionSessionStart, Path: "/wt/c", Detail: "/bin/zsh", Time: day(8)},
+		{Action: events.ActionRemove, Path: "/wt/a", Time: day(2)},
+		{Action: events.ActionCleanup, Path: "/wt/a", Detail: "merged (#42)", Merged: true, Time: day(2)},
+		{Action: events.ActionRemove, Path: "/wt/old", Time: day(1)},
+		{Action: events.ActionCleanup, Path: "/wt/old", Detail: "stale (40 days old)", Time: day(1)},
+	}
+
+	stats := computeStats(list, since, 2)
+
+	if stats.Created != 3 || stats.Removed != 2 {
+		t.Errorf("Created, Removed = %d, %d; want 3, 2", stats.Created, stats.Removed)
+	}
+
+	wantWeeks := []WeekCount{{Week: "2024-W18", Count: 2}, {Week: "2024-W19", Count: 1}}
+	if len(stats.CreatedPerWeek) != 2 || stats.CreatedPerWeek[0] != wantWeeks[0] || stats.CreatedPerWeek[1] != wantWeeks[1] {
+		t.Errorf("CreatedPerWeek = %v, want %v", stats.CreatedPerWeek, wantWeeks)
+	}
+
+	// a lived 2 days and old 4 days
+	if stats.LifetimeSamples != 2 || stats.AverageLifetimeHours != 72 {
+		t.Errorf("lifetime = %v hours over %d, want 72 over 2", stats.AverageLifetimeHours, stats.LifetimeSamples)
+	}
+
+	if stats.Merged != 1 || stats.MergeRate != 0.5 {
+		t.Errorf("Merged, MergeRate = %d, %v; want 1, 0.5", stats.Merged, stats.MergeRate)
+	}
+
+	if stats.Cleanups["merged"] != 1 || stats.Cleanups["stale"] != 1 {
+		t.Errorf("Cleanups = %v", stats.Cleanups)
+	}
+
+	if stats.AISessions["claude"] != 1 || stats.AISessions["codex"] != 1 || stats.AISessions["shell"] != 1 {
+		t.Errorf("AISessions = %v", stats.AISessions)
+	}
+}
+
+func TestFormatCounts(t *testing.T) {
+	if got := formatCounts(map[string]int{"codex": 2, "claude": 10, "aider": 2}); got != "claude 10, aider 2, codex 2" {
+		t.Errorf("formatCounts() = %q", got)
+	}
+
+	if got := formatCounts(nil); got != "none" {
+		t.Errorf("formatCounts(nil) = %q, want none", got)
+	}
+}
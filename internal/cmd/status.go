@@ -0,0 +1,306 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/kaeawc/auto-worktree/internal/git"
+	"github.com/kaeawc/auto-worktree/internal/logging"
+	"github.com/kaeawc/auto-worktree/internal/offline"
+	"github.com/kaeawc/auto-worktree/internal/provider"
+	"github.com/kaeawc/auto-worktree/internal/session"
+	"github.com/kaeawc/auto-worktree/internal/state"
+	"github.com/kaeawc/auto-worktree/internal/ui"
+)
+
+// statusCacheTTL is how long a worktree's cached issue and PR are shown
+// before 'auto-worktree status' asks the code host again
+const statusCacheTTL = 5 * time.Minute
+
+// statusUnknown fills a porcelain field with nothing to show
+const statusUnknown = "-"
+
+// worktreeStatus is what 'auto-worktree status' caches about a worktree's
+// branch: the issue it is for and the state of its pull request
+type worktreeStatus struct {
+	Branch string `json:"branch"`
+	// Issue is the branch's issue, e.g. "#123" or "PROJ-7"
+	Issue string `json:"issue,omitempty"`
+	// PRRef and PRState describe the latest pull request, e.g. "#45" and
+	// "open"; PRState is "none" when there isn't one
+	PRRef     string    `json:"pr_ref,omitempty"`
+	PRState   string    `json:"pr_state,omitempty"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// statusCacheKey is the state.BucketCache key for the worktree at path
+func statusCacheKey(path string) string {
+	return "status:" + path
+}
+
+// stale reports whether the cached status is missing, for another branch, or old
+func (s *worktreeStatus) stale(branch string, now time.Time) bool {
+	return s == nil || s.Branch != branch || now.Sub(s.CheckedAt) > statusCacheTTL
+}
+
+// RunStatus shows the branch, issue, pull request and session of the current
+// worktree. With --porcelain it prints them on one line from local state only,
+// fast enough for a shell prompt or tmux status bar, and refreshes the cached
+// issue and PR in the background; --refresh is that background refresh.
+func RunStatus(args []string) error {
+	var porcelain, refresh bool
+
+	for _, arg := range args {
+		switch arg {
+		case "--porcelain":
+			porcelain = true
+		case "--refresh":
+			refresh = true
+		default:
+			return fmt.Errorf("unknown flag for status: %s", arg)
+		}
+	}
+
+	path, branch, err := currentWorktree()
+	if err != nil {
+		if porcelain {
+			// Outside a repository the prompt shows nothing
+			return nil
+		}
+
+		return fmt.Errorf("not in a git worktree: %w", err)
+	}
+
+	store, err := openStateStore()
+	if err != nil {
+		return err
+	}
+
+	var cached *worktreeStatus
+
+	var entry worktreeStatus
+	if err := store.Get(state.BucketCache, statusCacheKey(path), &entry); err == nil {
+		cached = &entry
+	}
+
+	now := time.Now()
+
+	switch {
+	case refresh:
+		_, err := refreshWorktreeStatus(store, path, branch, now)
+		return err
+	case porcelain:
+		if cached.stale(branch, now) && !offline.Enabled() {
+			startStatusRefresh(store, path, branch, cached, now)
+		}
+
+		fmt.Println(porcelainStatus(branch, cached, sessionState(branch)))
+
+		return nil
+	}
+
+	if cached.stale(branch, now) && !offline.Enabled() {
+		if fresh, err := refreshWorktreeStatus(store, path, branch, now); err == nil {
+			cached = fresh
+		} else {
+			logging.Debug("failed to refresh worktree status", "err", err)
+		}
+	}
+
+	printStatus(branch, cached, sessionState(branch))
+
+	return nil
+}
+
+// currentWorktree returns the top-level directory and branch of the worktree
+// containing the working directory, with a single git call
+func currentWorktree() (path, branch string, err error) {
+	output, err := exec.CommandContext(context.Background(), "git", "rev-parse", "--show-toplevel", "--abbrev-ref", "HEAD").Output()
+	if err != nil {
+		return "", "", err
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
+	if len(lines) != 2 {
+		return "", "", errors.New("unexpected git rev-parse output")
+	}
+
+	return lines[0], lines[1], nil
+}
+
+// sessionState is the state of the branch's session from its saved metadata,
+// "stopped" if tmux no longer has it, or "" without one
+func sessionState(branch string) string {
+	if branch == "HEAD" {
+		return ""
+	}
+
+	mgr := session.NewManager()
+	name := session.GenerateSessionName(branch)
+
+	metadata, err := mgr.LoadSessionMetadata(name)
+	if err != nil || metadata == nil {
+		return ""
+	}
+
+	if exists, err := mgr.HasSession(name); err == nil && !exists {
+		return "stopped"
+	}
+
+	return string(metadata.Status)
+}
+
+// porcelainStatus formats the status as "<branch> <issue> <pr-state> <session>",
+// with "-" for any field that isn't known
+func porcelainStatus(branch string, cached *worktreeStatus, sessionState string) string {
+	fields := []string{branch, statusUnknown, statusUnknown, statusUnknown}
+
+	if branch == "HEAD" {
+		fields[0] = "(detached)"
+	}
+
+	if cached != nil && cached.Branch == branch {
+		if cached.Issue != "" {
+			fields[1] = cached.Issue
+		}
+
+		if cached.PRState != "" {
+			fields[2] = cached.PRState
+		}
+	}
+
+	if sessionState != "" {
+		fields[3] = sessionState
+	}
+
+	return strings.Join(fields, " ")
+}
+
+// printStatus shows the status for a person rather than a prompt
+func printStatus(branch string, cached *worktreeStatus, sessionState string) {
+	issue, pr := "none", "unknown"
+
+	if cached != nil && cached.Branch == branch {
+		if cached.Issue != "" {
+			issue = cached.Issue
+		}
+
+		switch cached.PRState {
+		case "":
+		case "none":
+			pr = "none"
+		default:
+			pr = cached.PRRef + " " + cached.PRState
+		}
+	}
+
+	if sessionState == "" {
+		sessionState = "none"
+	}
+
+	fmt.Printf("Branch:  %s\n", branch)
+	fmt.Printf("Issue:   %s\n", issue)
+	fmt.Printf("PR:      %s\n", pr)
+	fmt.Printf("Session: %s\n", sessionState)
+
+	if cached != nil && !cached.CheckedAt.IsZero() {
+		fmt.Println(ui.SubtleStyle.Render("Issue and PR checked " + formatAge(time.Since(cached.CheckedAt)) + " ago"))
+	}
+}
+
+// startStatusRefresh runs 'auto-worktree status --refresh' in the background so
+// the prompt doesn't wait on the code host. The attempt is recorded first so
+// prompts drawn meanwhile don't start refreshes of their own.
+func startStatusRefresh(store *state.Store, path, branch string, cached *worktreeStatus, now time.Time) {
+	pending := worktreeStatus{Branch: branch, CheckedAt: now}
+	if cached != nil && cached.Branch == branch {
+		pending = *cached
+		pending.CheckedAt = now
+	}
+
+	if err := store.Put(state.BucketCache, statusCacheKey(path), pending); err != nil {
+		logging.Debug("failed to record status refresh", "err", err)
+		return
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		return
+	}
+
+	// No stdin/stdout, so a prompt reading our output isn't held open by the child
+	refresh := exec.Command(exe, "status", "--refresh") //nolint:gosec // our own binary
+	refresh.Dir = path
+
+	if err := refresh.Start(); err != nil {
+		logging.Debug("failed to start status refresh", "err", err)
+		return
+	}
+
+	if err := refresh.Process.Release(); err != nil {
+		logging.Debug("failed to release status refresh", "err", err)
+	}
+}
+
+// refreshWorktreeStatus looks up the branch's issue and pull request and caches them
+func refreshWorktreeStatus(store *state.Store, path, branch string, now time.Time) (*worktreeStatus, error) {
+	if branch == "HEAD" {
+		return cacheWorktreeStatus(store, nil, nil, path, branch, now)
+	}
+
+	repo, err := git.NewRepository()
+	if err != nil {
+		return nil, err
+	}
+
+	return cacheWorktreeStatus(store, repo, newPRLookup(repo), path, branch, now)
+}
+
+// cacheWorktreeStatus looks up the branch's issue and, through prs, its pull
+// request, and caches them; repo and prs may be nil for a detached HEAD
+func cacheWorktreeStatus(store *state.Store, repo *git.Repository, prs *prLookup, path, branch string, now time.Time) (*worktreeStatus, error) {
+	status := &worktreeStatus{Branch: branch, CheckedAt: now}
+
+	if branch != "HEAD" && repo != nil {
+		status.Issue = branchIssue(repo.Config, branch)
+
+		if pr, ok := prs.latest(branch); ok {
+			status.PRState = "none"
+
+			if pr != nil {
+				status.PRRef, status.PRState = pr.Ref, pr.State
+			}
+		}
+	}
+
+	if err := store.Put(state.BucketCache, statusCacheKey(path), status); err != nil {
+		return nil, fmt.Errorf("failed to cache worktree status: %w", err)
+	}
+
+	return status, nil
+}
+
+// branchIssue returns the issue a branch is named for, e.g. "#123" for
+// work/123-login or "PROJ-7" for issue/PROJ-7-login, or "" if none
+func branchIssue(cfg *git.Config, branch string) string {
+	providerType, id, found := provider.ParseBranchNameWithPrefixes(branch, cfg.GetIssueProvider(),
+		git.IssueBranchPrefixes(cfg.GetIssueBranchPrefixes()))
+	if !found {
+		return ""
+	}
+
+	switch providerType {
+	case provider.ProviderTypeGitHubIssue:
+		return "#" + id
+	case provider.ProviderTypeJira, provider.ProviderTypeLinear:
+		return id
+	default:
+		// pr/ and mr/ branches are for a pull request, not an issue
+		return ""
+	}
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPorcelainStatus(t *testing.T) {
+	cached := &worktreeStatus{Branch: "work/123-login", Issue: "#123", PRRef: "#45", PRState: "open"}
+
+	tests := []struct {
+		name    string
+		branch  string
+		cached  *worktreeStatus
+		session string
+		want    string
+	}{
+		{"everything known", "work/123-login", cached, "running", "work/123-login #123 open running"},
+		{"nothing cached", "work/123-login", nil, "", "work/123-login - - -"},
+		{"cache for another branch", "feature/x", cached, "paused", "feature/x - - paused"},
+		{"no pull request", "work/7-fix", &worktreeStatus{Branch: "work/7-fix", Issue: "#7", PRState: "none"}, "", "work/7-fix #7 none -"},
+		{"detached", "HEAD", nil, "", "(detached) - - -"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := porcelainStatus(tt.branch, tt.cached, tt.session); got != tt.want {
+				t.Errorf("porcelainStatus() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWorktreeStatusStale(t *testing.T) {
+	now := time.Now()
+	status := &worktreeStatus{Branch: "feature/x", CheckedAt: now.Add(-time.Minute)}
+
+	if status.stale("feature/x", now) {
+		t.Error("stale() = true for a recent check")
+	}
+
+	if !status.stale("feature/y", now) {
+		t.Error("stale() = false after switching branches")
+	}
+
+	if !status.stale("feature/x", now.Add(statusCacheTTL)) {
+		t.Error("stale() = false after the TTL")
+	}
+
+	var missing *worktreeStatus
+	if !missing.stale("feature/x", now) {
+		t.Error("stale() = false without a cached status")
+	}
+}
@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kaeawc/auto-worktree/internal/git"
+	"github.com/kaeawc/auto-worktree/internal/logging"
+	"github.com/kaeawc/auto-worktree/internal/state"
+	"github.com/kaeawc/auto-worktree/internal/ui"
+)
+
+// testResultPrefix starts the state.BucketCache keys of test results
+const testResultPrefix = "test:"
+
+// TestOptions configures `auto-worktree test`
+type TestOptions struct {
+	// All runs the tests in every worktree except the main one
+	All bool
+	// Branches are the worktrees to test; with neither, the current worktree
+	// or one picked from the list
+	Branches []string
+}
+
+// testResult is how the test command last went in a worktree
+type testResult struct {
+	// Commit is the worktree's HEAD when the tests ran
+	Commit   string        `json:"commit"`
+	Passed   bool          `json:"passed"`
+	Duration time.Duration `json:"duration"`
+	RanAt    time.Time     `json:"ran_at"`
+}
+
+// testResultKey is the state.BucketCache key for the worktree at path
+func testResultKey(path string) string {
+	return testResultPrefix + path
+}
+
+// RunTest runs auto-worktree.test-command in the selected worktrees, several
+// at once, and records whether it passed in each for list and monitor to show.
+// It returns ErrExecFailed if the tests failed anywhere.
+func RunTest(opts TestOptions) error {
+	repo, err := git.NewRepository()
+	if err != nil {
+		return fmt.Errorf("error: %w", err)
+	}
+
+	command := repo.Config.GetTestCommand()
+	if command == "" {
+		return fmt.Errorf("no test command configured; set one with: git config %s \"go test ./...\"", git.ConfigTestCommand)
+	}
+
+	worktrees, err := execWorktrees(repo, ExecOptions{All: opts.All, Branches: opts.Branches})
+	if err != nil || len(worktrees) == 0 {
+		return err
+	}
+
+	shell := []string{"sh", "-c", command}
+
+	var results []execResult
+
+	if len(worktrees) > 1 {
+		fmt.Println(ui.InfoStyle.Render(fmt.Sprintf("Running %q in %d worktrees", command, len(worktrees))))
+		fmt.Println()
+
+		results = execParallel(worktrees, shell)
+	} else {
+		results = execSequential(worktrees, shell)
+	}
+
+	saveTestResults(results, time.Now())
+
+	if err := summarizeExec(results); err != nil {
+		if len(results) == 1 {
+			return fmt.Errorf("%w: %w", ErrExecFailed, results[0].err)
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// saveTestResults records each worktree's result, keyed by its path
+func saveTestResults(results []execResult, now time.Time) {
+	store, err := openStateStore()
+	if err != nil {
+		logging.Warn("failed to open state to save test results", "err", err)
+		return
+	}
+
+	for _, r := range results {
+		result := testResult{Commit: r.wt.HEAD, Passed: r.err == nil, Duration: r.duration, RanAt: now}
+		if err := store.Put(state.BucketCache, testResultKey(r.wt.Path), result); err != nil {
+			logging.Warn("failed to save test result", "path", r.wt.Path, "err", err)
+		}
+	}
+}
+
+// loadTestResults returns the last test result of each worktree, by path
+func loadTestResults() map[string]*testResult {
+	results := map[string]*testResult{}
+
+	store, err := openStateStore()
+	if err != nil {
+		return results
+	}
+
+	err = store.ForEach(state.BucketCache, func(key string, value []byte) error {
+		if !strings.HasPrefix(key, testResultPrefix) {
+			return nil
+		}
+
+		var r testResult
+		if json.Unmarshal(value, &r) == nil {
+			results[strings.TrimPrefix(key, testResultPrefix)] = &r
+		}
+
+		return nil
+	})
+	if err != nil {
+		logging.Debug("failed to read test results", "err", err)
+	}
+
+	return results
+}
+
+// formatTestResult shows a test result in a TEST column: ✓ or ✗ and how long
+// the tests took, dimmed when the worktree has moved on from the tested commit
+func formatTestResult(r *testResult, head string) string {
+	if r == nil {
+		return "-"
+	}
+
+	took := r.Duration.Round(time.Second).String()
+	if r.Duration < time.Second {
+		took = "<1s"
+	}
+
+	switch {
+	case r.Commit != head:
+		mark := "✓"
+		if !r.Passed {
+			mark = "✗"
+		}
+
+		return ui.SubtleStyle.Render(fmt.Sprintf("%s %s (old)", mark, took))
+	case r.Passed:
+		return ui.SuccessStyle.Render("✓ " + took)
+	default:
+		return ui.ErrorStyle.Render("✗ " + took)
+	}
+}
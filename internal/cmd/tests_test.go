@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kaeawc/auto-worktree/internal/git"
+)
+
+func TestSaveAndLoadTestResults(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	results := []execResult{
+		{wt: &git.Worktree{Path: "/wt/a", HEAD: "aaa"}, duration: 12 * time.Second},
+		{wt: &git.Worktree{Path: "/wt/b", HEAD: "bbb"}, err: errors.New("exit status 1"), duration: 3 * time.Second},
+	}
+
+	saveTestResults(results, now)
+
+	loaded := loadTestResults()
+	if len(loaded) != 2 {
+		t.Fatalf("loadTestResults() = %v, want 2 results", loaded)
+	}
+
+	if a := loaded["/wt/a"]; a == nil || !a.Passed || a.Commit != "aaa" || a.Duration != 12*time.Second || !a.RanAt.Equal(now) {
+		t.Errorf("result for /wt/a = %+v", a)
+	}
+
+	if b := loaded["/wt/b"]; b == nil || b.Passed {
+		t.Errorf("result for /wt/b = %+v, want failed", b)
+	}
+}
+
+func TestFormatTestResult(t *testing.T) {
+	passed := &testResult{Commit: "aaa", Passed: true, Duration: 12 * time.Second}
+	failed := &testResult{Commit: "aaa", Duration: 300 * time.Millisecond}
+
+	tests := []struct {
+		name   string
+		result *testResult
+		head   string
+		want   string
+	}{
+		{"never run", nil, "aaa", "-"},
+		{"passed", passed, "aaa", "✓ 12s"},
+		{"failed quickly", failed, "aaa", "✗ <1s"},
+		{"commit moved on", passed, "bbb", "✓ 12s (old)"},
+	}
+
+	for _, tt := range tests {
+		if got := formatTestResult(tt.result, tt.head); !strings.Contains(got, tt.want) {
+			t.Errorf("%s: formatTestResult() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/kaeawc/auto-worktree/internal/git"
+	"github.com/kaeawc/auto-worktree/internal/logging"
+	"github.com/kaeawc/auto-worktree/internal/ui"
+)
+
+// ApplyConfiguredTheme applies the configured theme and color overrides to the UI.
+// Invalid settings fall back to the default theme with a warning rather than failing the command.
+func ApplyConfiguredTheme() {
+	theme, err := resolveTheme(git.NewConfig(""), os.Getenv("NO_COLOR"))
+	if err != nil {
+		logging.Warn("invalid theme settings", "err", err)
+	}
+
+	ui.ApplyTheme(theme)
+}
+
+// resolveTheme builds the theme from configuration. A non-empty NO_COLOR
+// (https://no-color.org) always selects the mono theme and ignores overrides.
+func resolveTheme(cfg *git.Config, noColor string) (ui.Theme, error) {
+	if noColor != "" {
+		return ui.LookupTheme(ui.ThemeMono)
+	}
+
+	theme, err := ui.LookupTheme(cfg.GetTheme())
+	if err != nil {
+		return ui.DefaultTheme(), err
+	}
+
+	overridden, err := theme.WithOverrides(cfg.GetThemeColors())
+	if err != nil {
+		return theme, fmt.Errorf("ignoring %s: %w", git.ConfigThemeColors, err)
+	}
+
+	return overridden, nil
+}
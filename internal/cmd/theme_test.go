@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/kaeawc/auto-worktree/internal/git"
+	"github.com/kaeawc/auto-worktree/internal/ui"
+)
+
+func TestResolveTheme(t *testing.T) {
+	executor := git.NewFakeGitExecutor()
+	executor.SetResponse("config --local --get "+git.ConfigTheme, "light")
+	executor.SetResponse("config --local --get "+git.ConfigThemeColors, "success=#00aa00")
+	cfg := git.NewConfigWithExecutor("/repo", executor)
+
+	theme, err := resolveTheme(cfg, "")
+	if err != nil {
+		t.Fatalf("resolveTheme() error = %v", err)
+	}
+
+	if theme.Name != ui.ThemeLight || theme.Success != lipgloss.Color("#00aa00") {
+		t.Errorf("resolveTheme() = %s with success %v, want light with the override", theme.Name, theme.Success)
+	}
+
+	if theme, _ := resolveTheme(cfg, "1"); theme.Name != ui.ThemeMono {
+		t.Errorf("NO_COLOR should select the mono theme, got %s", theme.Name)
+	}
+
+	executor.SetResponse("config --local --get "+git.ConfigThemeColors, "sucess=2")
+
+	theme, err = resolveTheme(cfg, "")
+	if err == nil || theme.Name != ui.ThemeLight {
+		t.Errorf("bad override: theme %s, err %v; want light without overrides and an error", theme.Name, err)
+	}
+}
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/kaeawc/auto-worktree/internal/git"
+	"github.com/kaeawc/auto-worktree/internal/ui"
+)
+
+// RunTidy lists a worktree's unpushed commits in a screen for squashing,
+// rewording or dropping them, then rewrites them as chosen
+func RunTidy(branch string) error {
+	repo, err := git.NewRepository()
+	if err != nil {
+		return fmt.Errorf("error: %w", err)
+	}
+
+	wt, err := branchWorktree(repo, branch, "Select a worktree to tidy")
+	if err != nil || wt == nil {
+		return err
+	}
+
+	if wt.Branch == "" {
+		return fmt.Errorf("%s has no branch checked out", wt.Path)
+	}
+
+	dirty, err := repo.HasUncommittedChanges(wt.Path)
+	if err != nil {
+		return fmt.Errorf("error checking %s for changes: %w", wt.Path, err)
+	}
+
+	if dirty {
+		return fmt.Errorf("%s has uncommitted changes; commit or stash them before tidying", wt.Branch)
+	}
+
+	base, err := repo.TidyBase(wt.Path)
+	if err != nil {
+		return err
+	}
+
+	commits, err := repo.ListTidyCommits(wt.Path, base)
+	if err != nil {
+		return err
+	}
+
+	if len(commits) < 2 {
+		fmt.Printf("Nothing to tidy: %s has %d unpushed commit(s)\n", wt.Branch, len(commits))
+		return nil
+	}
+
+	result, err := ui.Run(ui.NewTidy(wt.Branch, commits))
+	if err != nil {
+		return fmt.Errorf("error running tidy: %w", err)
+	}
+
+	tidy, ok := result.(ui.TidyModel)
+	if !ok {
+		return fmt.Errorf("unexpected model type")
+	}
+
+	steps := tidy.Steps()
+	if !tidy.Applied() || !tidyChanges(steps) {
+		fmt.Println(ui.SubtleStyle.Render("Nothing changed"))
+		return nil
+	}
+
+	oldHead, err := repo.Tidy(wt.Path, base, steps)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(ui.SuccessStyle.Render(fmt.Sprintf("✓ Tidied %s: %d commits → %d", wt.Branch, len(commits), ui.TidyCommitCount(steps))))
+	fmt.Println(ui.SubtleStyle.Render(fmt.Sprintf("Undo with: git -C %s reset --hard %s", wt.Path, oldHead[:min(len(oldHead), 12)])))
+
+	return nil
+}
+
+// tidyChanges reports whether a plan does anything other than pick every commit
+func tidyChanges(steps []git.TidyStep) bool {
+	for _, step := range steps {
+		if step.Action != git.TidyPick {
+			return true
+		}
+	}
+
+	return false
+}
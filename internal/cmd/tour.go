@@ -0,0 +1,370 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/kaeawc/auto-worktree/internal/git"
+	"github.com/kaeawc/auto-worktree/internal/ui"
+)
+
+const tourBranch = "work/tour-first-change"
+
+// errTourAborted is returned when the user leaves the tour before finishing
+var errTourAborted = errors.New("tour aborted")
+
+// tourSandbox is a throwaway repository (with a local bare remote) used by the tour
+type tourSandbox struct {
+	Dir          string
+	RemotePath   string
+	Repo         *git.Repository
+	BaseBranch   string
+	Branch       string
+	WorktreePath string
+	executor     git.GitExecutor
+}
+
+// tourGitEnv keeps the user's global and system git config, with any commit
+// signing or hooks it sets up, out of the sandbox's git commands
+var tourGitEnv = []string{"GIT_CONFIG_GLOBAL=" + os.DevNull, "GIT_CONFIG_NOSYSTEM=1"}
+
+// tourExecutor runs every git command of the sandbox with tourGitEnv
+type tourExecutor struct {
+	git.GitExecutor
+}
+
+// Execute runs a git command with tourGitEnv
+func (e tourExecutor) Execute(args ...string) (string, error) {
+	return e.ExecuteInDirWithEnv("", nil, args...)
+}
+
+// ExecuteInDir runs a git command in dir with tourGitEnv
+func (e tourExecutor) ExecuteInDir(dir string, args ...string) (string, error) {
+	return e.ExecuteInDirWithEnv(dir, nil, args...)
+}
+
+// ExecuteInDirWithEnv runs a git command in dir with tourGitEnv and env
+func (e tourExecutor) ExecuteInDirWithEnv(dir string, env []string, args ...string) (string, error) {
+	return e.GitExecutor.ExecuteInDirWithEnv(dir, append(slices.Clone(tourGitEnv), env...), args...)
+}
+
+// tourStep is one stage of the core worktree loop
+type tourStep struct {
+	Title       string
+	Explanation string
+	Command     string
+	Run         func(s *tourSandbox) error
+	Validate    func(s *tourSandbox) error
+}
+
+// RunTour walks a new user through the core loop in a sandbox repository
+func RunTour() error {
+	fmt.Println(ui.TitleStyle.Render("Welcome to the auto-worktree tour"))
+	fmt.Println()
+	fmt.Println("This tour runs the core loop in a throwaway sandbox repository:")
+	fmt.Println("  create a worktree → let the agent work → push → clean up")
+	fmt.Println(ui.SubtleStyle.Render("Nothing in your real repositories is touched."))
+	fmt.Println()
+
+	sandbox, err := newTourSandbox()
+	if err != nil {
+		return fmt.Errorf("failed to create tour sandbox: %w", err)
+	}
+	defer sandbox.Close()
+
+	fmt.Printf("Sandbox repository: %s\n\n", sandbox.Repo.RootPath)
+
+	err = runTourSteps(sandbox, tourSteps(), confirmTourStep)
+	if errors.Is(err, errTourAborted) {
+		fmt.Println(ui.SubtleStyle.Render("Tour ended early. Run 'auto-worktree tour' any time to start again."))
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+	fmt.Println(ui.SuccessStyle.Render(fmt.Sprintf("%s Tour complete!", iconCheckmark)))
+	fmt.Println()
+	fmt.Println("In your own repositories the same loop is:")
+	fmt.Println("  auto-worktree issue <id>   # or: auto-worktree new <branch>")
+	fmt.Println("  auto-worktree resume       # reattach to the agent session")
+	fmt.Println("  git push                   # from inside the worktree")
+	fmt.Println("  auto-worktree cleanup      # remove merged worktrees")
+
+	return nil
+}
+
+// confirmTourStep shows the guidance for a step and waits for the user to continue
+func confirmTourStep(index, total int, step tourStep) (bool, error) {
+	model := ui.NewTourStepModel(index, total, step.Title, step.Explanation, step.Command)
+
+	m, err := ui.Run(model)
+	if err != nil {
+		return false, fmt.Errorf("failed to run tour step: %w", err)
+	}
+
+	finalModel, ok := m.(ui.TourStepModel)
+	if !ok {
+		return false, fmt.Errorf("unexpected model type")
+	}
+
+	return finalModel.Proceed(), nil
+}
+
+// runTourSteps runs each step after confirmation and validates its outcome
+func runTourSteps(s *tourSandbox, steps []tourStep,
+	confirm func(index, total int, step tourStep) (bool, error)) error {
+	for i, step := range steps {
+		proceed, err := confirm(i, len(steps), step)
+		if err != nil {
+			return err
+		}
+
+		if !proceed {
+			return errTourAborted
+		}
+
+		if err := step.Run(s); err != nil {
+			return fmt.Errorf("step %q failed: %w", step.Title, err)
+		}
+
+		if err := step.Validate(s); err != nil {
+			return fmt.Errorf("step %q did not complete: %w", step.Title, err)
+		}
+
+		fmt.Println(ui.SuccessStyle.Render(fmt.Sprintf("✓ %s", step.Title)))
+		fmt.Println()
+	}
+
+	return nil
+}
+
+// tourSteps returns the steps of the core worktree loop
+func tourSteps() []tourStep {
+	return []tourStep{
+		{
+			Title: "Create a worktree",
+			Explanation: "Each task gets its own worktree and branch, so you can work on several\n" +
+				"things at once without stashing or switching branches.",
+			Command:  "auto-worktree new " + tourBranch,
+			Run:      tourCreateWorktree,
+			Validate: tourValidateWorktree,
+		},
+		{
+			Title: "Let the agent work",
+			Explanation: "auto-worktree starts your AI tool in a tmux session inside the worktree.\n" +
+				"For the tour we simulate the agent by committing a small change.",
+			Command:  "auto-worktree resume",
+			Run:      tourSimulateAgent,
+			Validate: tourValidateCommit,
+		},
+		{
+			Title:       "Push your branch",
+			Explanation: "When the work is ready, push the branch and open a pull request as usual.",
+			Command:     "git push -u origin " + tourBranch,
+			Run:         tourPush,
+			Validate:    tourValidatePush,
+		},
+		{
+			Title: "Clean up",
+			Explanation: "Once the branch is merged, remove the worktree and its branch.\n" +
+				"auto-worktree also offers this automatically for merged worktrees.",
+			Command:  "auto-worktree cleanup",
+			Run:      tourCleanup,
+			Validate: tourValidateCleanup,
+		},
+	}
+}
+
+// newTourSandbox creates a temporary repository with a bare remote and an initial commit
+func newTourSandbox() (*tourSandbox, error) {
+	dir, err := os.MkdirTemp("", "auto-worktree-tour-")
+	if err != nil {
+		return nil, err
+	}
+
+	s := &tourSandbox{
+		Dir:        dir,
+		RemotePath: filepath.Join(dir, "origin.git"),
+		Branch:     tourBranch,
+		executor:   tourExecutor{git.NewGitExecutor()},
+	}
+
+	repoPath := filepath.Join(dir, "tour-repo")
+
+	if err := s.setup(repoPath); err != nil {
+		s.Close()
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// setup initializes the remote and working repository
+func (s *tourSandbox) setup(repoPath string) error {
+	if _, err := s.executor.ExecuteInDir(s.Dir, "init", "--bare", s.RemotePath); err != nil {
+		return fmt.Errorf("failed to init remote: %w", err)
+	}
+
+	if _, err := s.executor.ExecuteInDir(s.Dir, "clone", s.RemotePath, repoPath); err != nil {
+		return fmt.Errorf("failed to clone remote: %w", err)
+	}
+
+	readme := "# Tour repository\n\nA sandbox created by `auto-worktree tour`.\n"
+	if err := os.WriteFile(filepath.Join(repoPath, "README.md"), []byte(readme), 0o600); err != nil {
+		return err
+	}
+
+	if err := s.commit(repoPath, "Initial commit"); err != nil {
+		return err
+	}
+
+	if _, err := s.executor.ExecuteInDir(repoPath, "push", "-u", "origin", "HEAD"); err != nil {
+		return fmt.Errorf("failed to push initial commit: %w", err)
+	}
+
+	repo, err := git.NewRepositoryFromPathWithDeps(repoPath, s.executor, git.NewFileSystem())
+	if err != nil {
+		return err
+	}
+
+	// The tour's worktrees and branches are not the user's history
+	repo.DisableEvents()
+
+	// Keep tour worktrees inside the sandbox instead of ~/worktrees
+	repo.WorktreeBase = filepath.Join(s.Dir, "worktrees")
+	s.Repo = repo
+	s.WorktreePath = filepath.Join(repo.WorktreeBase, git.SanitizeBranchName(s.Branch))
+
+	base, err := repo.GetCurrentBranch()
+	if err != nil {
+		return err
+	}
+
+	s.BaseBranch = base
+
+	return nil
+}
+
+// commit stages everything in dir and commits with a fixed sandbox identity
+func (s *tourSandbox) commit(dir, message string) error {
+	if _, err := s.executor.ExecuteInDir(dir, "add", "-A"); err != nil {
+		return fmt.Errorf("failed to stage changes: %w", err)
+	}
+
+	// commit.gpgsign for git older than 2.32, which ignores GIT_CONFIG_GLOBAL
+	_, err := s.executor.ExecuteInDir(dir,
+		"-c", "user.name=auto-worktree tour",
+		"-c", "user.email=tour@auto-worktree.invalid",
+		"-c", "commit.gpgsign=false",
+		"commit", "-m", message)
+	if err != nil {
+		return fmt.Errorf("failed to commit: %w", err)
+	}
+
+	return nil
+}
+
+// Close removes the sandbox directory
+func (s *tourSandbox) Close() {
+	_ = os.RemoveAll(s.Dir) //nolint:errcheck // best-effort cleanup of temp dir
+}
+
+func tourCreateWorktree(s *tourSandbox) error {
+	return s.Repo.CreateWorktreeWithNewBranch(s.WorktreePath, s.Branch, s.BaseBranch)
+}
+
+func tourValidateWorktree(s *tourSandbox) error {
+	wt, err := s.Repo.GetWorktreeForBranch(s.Branch)
+	if err != nil {
+		return err
+	}
+
+	if wt == nil || wt.IsOrphaned() {
+		return fmt.Errorf("no worktree found for %s", s.Branch)
+	}
+
+	return nil
+}
+
+func tourSimulateAgent(s *tourSandbox) error {
+	content := "Changes made by the (simulated) agent.\n"
+	if err := os.WriteFile(filepath.Join(s.WorktreePath, "TOUR.md"), []byte(content), 0o600); err != nil {
+		return err
+	}
+
+	return s.commit(s.WorktreePath, "Add tour notes")
+}
+
+func tourValidateCommit(s *tourSandbox) error {
+	status, err := s.executor.ExecuteInDir(s.WorktreePath, "status", "--porcelain")
+	if err != nil {
+		return err
+	}
+
+	if status != "" {
+		return fmt.Errorf("worktree has uncommitted changes")
+	}
+
+	count, err := s.executor.ExecuteInDir(s.WorktreePath, "rev-list", "--count", s.BaseBranch+"..HEAD")
+	if err != nil {
+		return err
+	}
+
+	if count == "0" {
+		return fmt.Errorf("no new commits on %s", s.Branch)
+	}
+
+	return nil
+}
+
+func tourPush(s *tourSandbox) error {
+	_, err := s.executor.ExecuteInDir(s.WorktreePath, "push", "-u", "origin", s.Branch)
+	return err
+}
+
+func tourValidatePush(s *tourSandbox) error {
+	output, err := s.executor.ExecuteInDir(s.WorktreePath, "ls-remote", "--heads", "origin", s.Branch)
+	if err != nil {
+		return err
+	}
+
+	if !strings.Contains(output, "refs/heads/"+s.Branch) {
+		return fmt.Errorf("branch %s not found on remote", s.Branch)
+	}
+
+	return nil
+}
+
+func tourCleanup(s *tourSandbox) error {
+	return s.Repo.WithOperationLock("removing a worktree", func(locked *git.Repository) error {
+		if err := locked.RemoveWorktree(s.WorktreePath); err != nil {
+			return err
+		}
+
+		return locked.DeleteBranch(s.Branch)
+	})
+}
+
+func tourValidateCleanup(s *tourSandbox) error {
+	wt, err := s.Repo.GetWorktreeForBranch(s.Branch)
+	if err != nil {
+		return err
+	}
+
+	if wt != nil {
+		return fmt.Errorf("worktree for %s still exists", s.Branch)
+	}
+
+	if s.Repo.BranchExists(s.Branch) {
+		return fmt.Errorf("branch %s still exists", s.Branch)
+	}
+
+	return nil
+}
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/kaeawc/auto-worktree/internal/events"
+)
+
+func TestRunTourStepsInSandbox(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	// A global config that signs commits with a failing program: the tour
+	// must not read it
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+
+	gitconfig := "[commit]\n\tgpgsign = true\n[gpg]\n\tprogram = false\n"
+	if err := os.WriteFile(filepath.Join(home, ".gitconfig"), []byte(gitconfig), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	statePath := filepath.Join(t.TempDir(), "state.db")
+	events.Enable(statePath)
+	t.Cleanup(events.Disable)
+
+	sandbox, err := newTourSandbox()
+	if err != nil {
+		t.Fatalf("newTourSandbox() error = %v", err)
+	}
+	defer sandbox.Close()
+
+	confirmed := 0
+	confirm := func(_, _ int, _ tourStep) (bool, error) {
+		confirmed++
+		return true, nil
+	}
+
+	if err := runTourSteps(sandbox, tourSteps(), confirm); err != nil {
+		t.Fatalf("runTourSteps() error = %v", err)
+	}
+
+	if confirmed != len(tourSteps()) {
+		t.Errorf("confirmed %d steps, want %d", confirmed, len(tourSteps()))
+	}
+
+	recorded, err := events.NewStore(statePath).List(events.Filter{})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+
+	if len(recorded) != 0 {
+		t.Errorf("the tour recorded %d events in the event log, want none", len(recorded))
+	}
+}
+
+func TestRunTourStepsAbort(t *testing.T) {
+	ran := false
+	steps := []tourStep{
+		{
+			Title:    "never runs",
+			Run:      func(_ *tourSandbox) error { ran = true; return nil },
+			Validate: func(_ *tourSandbox) error { return nil },
+		},
+	}
+
+	decline := func(_, _ int, _ tourStep) (bool, error) { return false, nil }
+
+	err := runTourSteps(&tourSandbox{}, steps, decline)
+	if !errors.Is(err, errTourAborted) {
+		t.Errorf("runTourSteps() error = %v, want errTourAborted", err)
+	}
+
+	if ran {
+		t.Error("step ran after user declined")
+	}
+}
+
+func TestRunTourStepsValidationFailure(t *testing.T) {
+	steps := []tourStep{
+		{
+			Title:    "broken",
+			Run:      func(_ *tourSandbox) error { return nil },
+			Validate: func(_ *tourSandbox) error { return errors.New("not done") },
+		},
+	}
+
+	accept := func(_, _ int, _ tourStep) (bool, error) { return true, nil }
+
+	if err := runTourSteps(&tourSandbox{}, steps, accept); err == nil {
+		t.Error("runTourSteps() expected validation error, got nil")
+	}
+}
@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kaeawc/auto-worktree/internal/git"
+	"github.com/kaeawc/auto-worktree/internal/logging"
+	"github.com/kaeawc/auto-worktree/internal/offline"
+	"github.com/kaeawc/auto-worktree/internal/ui"
+	"github.com/kaeawc/auto-worktree/internal/update"
+)
+
+// Version is the version of the running build, set by main
+var Version = "dev"
+
+// ErrUpdateCanceled is returned when the user declines to install an update
+var ErrUpdateCanceled = errors.New("update canceled")
+
+var (
+	updateNoticeOnce   sync.Once
+	cachedUpdateNotice string
+)
+
+// RunUpdate installs the latest release over the running binary after verifying
+// its checksum. With checkOnly it only reports whether an update is available.
+func RunUpdate(checkOnly, yes bool) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	client := update.NewClient()
+
+	fmt.Println("Checking for updates...")
+
+	release, err := client.Latest(ctx)
+	if err != nil {
+		return err
+	}
+
+	if store, err := openStateStore(); err == nil {
+		if err := update.Record(store, release, time.Now()); err != nil {
+			logging.Debug("failed to cache update check", "err", err)
+		}
+	}
+
+	if !update.IsNewer(release.Version(), Version) {
+		fmt.Printf("%s auto-worktree %s is up to date\n", ui.SuccessStyle.Render("✓"), Version)
+		return nil
+	}
+
+	fmt.Printf("New version available: %s (current: %s)\n", release.Version(), Version)
+
+	if release.URL != "" {
+		fmt.Printf("  %s\n", ui.SubtleStyle.Render(release.URL))
+	}
+
+	if checkOnly {
+		return nil
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to locate the running binary: %w", err)
+	}
+
+	if manager := update.ManagedInstall(exe); manager != "" {
+		fmt.Printf("This binary is managed by a package manager; update it with %s\n", manager)
+		return nil
+	}
+
+	if !yes {
+		m, err := ui.Run(ui.NewConfirmModel(fmt.Sprintf("Replace %s with %s?", exe, release.Tag)))
+		if err != nil {
+			return fmt.Errorf("failed to confirm update: %w", err)
+		}
+
+		if confirm, ok := m.(ui.ConfirmModel); !ok || !confirm.GetChoice() {
+			return ErrUpdateCanceled
+		}
+	}
+
+	fmt.Println("Downloading and verifying checksum...")
+
+	data, err := client.Download(ctx, release)
+	if err != nil {
+		return err
+	}
+
+	if err := update.Replace(exe, data); err != nil {
+		return err
+	}
+
+	fmt.Printf("%s Updated to %s\n", ui.SuccessStyle.Render("✓"), release.Version())
+
+	return nil
+}
+
+// updateNotice returns the passive "new version available" notice from the
+// cached check, refreshing the cache in the background at most once a day
+func updateNotice() string {
+	updateNoticeOnce.Do(func() {
+		if strings.HasSuffix(Version, "-dev") || Version == "dev" || offline.Enabled() || !git.NewConfig("").GetUpdateCheckEnabled() {
+			return
+		}
+
+		store, err := openStateStore()
+		if err != nil {
+			return
+		}
+
+		notice, stale := update.CachedNotice(store, Version, time.Now())
+		cachedUpdateNotice = notice
+
+		if stale {
+			go func() {
+				ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+				defer cancel()
+
+				if err := update.Refresh(ctx, store, update.NewClient()); err != nil {
+					logging.Debug("background update check failed", "err", err)
+				}
+			}()
+		}
+	})
+
+	return cachedUpdateNotice
+}
@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/kaeawc/auto-worktree/internal/ai"
+	"github.com/kaeawc/auto-worktree/internal/logging"
+	"github.com/kaeawc/auto-worktree/internal/session"
+)
+
+// UsageTotal is the AI tool usage of a worktree, or of every worktree of a repository
+type UsageTotal struct {
+	// Path is the worktree, or the repository for a repository's total
+	Path   string `json:"path"`
+	Branch string `json:"branch,omitempty"`
+	ai.Usage
+}
+
+// refreshUsage reads the usage of the session's current conversation from the
+// AI tool's transcript into its metadata, reporting whether it changed. Codex
+// chooses its own conversation IDs, so the latest one in the worktree is used
+// when the session has none recorded.
+func refreshUsage(metadata *session.Metadata) bool {
+	id := metadata.ConversationID
+	if id == "" {
+		id = ai.LatestConversationID(metadata.AITool, scopeWorkDir(metadata.WorktreePath, metadata.Scope))
+	}
+
+	usage := ai.ConversationUsage(metadata.AITool, id)
+	if usage == nil {
+		return false
+	}
+
+	if previous := metadata.Usage[id]; previous != nil && previous.Tokens() == usage.Tokens() {
+		return false
+	}
+
+	if metadata.Usage == nil {
+		metadata.Usage = map[string]*ai.Usage{}
+	}
+
+	metadata.Usage[id] = usage
+
+	return true
+}
+
+// previousUsage returns the usage recorded for a session about to be started
+// again, brought up to date, so its earlier conversations keep counting
+func previousUsage(sessionMgr session.Manager, sessionName string) map[string]*ai.Usage {
+	metadata, err := sessionMgr.LoadSessionMetadata(sessionName)
+	if err != nil || metadata == nil {
+		return nil
+	}
+
+	refreshUsage(metadata)
+
+	return metadata.Usage
+}
+
+// refreshAllUsage brings the usage in each session's metadata up to date
+func refreshAllUsage(sessionMgr session.Manager, list []*session.Metadata) {
+	for _, metadata := range list {
+		if !refreshUsage(metadata) {
+			continue
+		}
+
+		if err := sessionMgr.SaveSessionMetadata(metadata); err != nil {
+			logging.Warn("failed to save session usage", "session", metadata.SessionName, "err", err)
+		}
+	}
+}
+
+// totalUsage adds up the usage of every conversation in a session
+func totalUsage(metadata *session.Metadata) *ai.Usage {
+	total := &ai.Usage{}
+	for _, usage := range metadata.Usage {
+		total.Add(usage)
+	}
+
+	return total
+}
+
+// computeUsage totals session usage per worktree and per repository, costliest
+// first. repoOf maps worktree paths to their repository; worktrees it doesn't
+// know are counted under their own path.
+func computeUsage(list []*session.Metadata, repoOf map[string]string) (ai.Usage, []UsageTotal, []UsageTotal) {
+	var total ai.Usage
+
+	worktrees := map[string]*UsageTotal{}
+	repos := map[string]*UsageTotal{}
+
+	for _, metadata := range list {
+		usage := totalUsage(metadata)
+		if usage.Tokens() == 0 {
+			continue
+		}
+
+		total.Add(usage)
+
+		wt := worktrees[metadata.WorktreePath]
+		if wt == nil {
+			wt = &UsageTotal{Path: metadata.WorktreePath, Branch: metadata.BranchName}
+			worktrees[metadata.WorktreePath] = wt
+		}
+
+		wt.Add(usage)
+
+		repoPath := repoOf[metadata.WorktreePath]
+		if repoPath == "" {
+			repoPath = metadata.WorktreePath
+		}
+
+		repo := repos[repoPath]
+		if repo == nil {
+			repo = &UsageTotal{Path: repoPath}
+			repos[repoPath] = repo
+		}
+
+		repo.Add(usage)
+	}
+
+	return total, sortedUsage(worktrees), sortedUsage(repos)
+}
+
+// sortedUsage lists usage totals by cost, then tokens, largest first
+func sortedUsage(totals map[string]*UsageTotal) []UsageTotal {
+	list := make([]UsageTotal, 0, len(totals))
+	for _, t := range totals {
+		list = append(list, *t)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].CostUSD != list[j].CostUSD {
+			return list[i].CostUSD > list[j].CostUSD
+		}
+
+		if list[i].Tokens() != list[j].Tokens() {
+			return list[i].Tokens() > list[j].Tokens()
+		}
+
+		return list[i].Path < list[j].Path
+	})
+
+	return list
+}
+
+// formatUsage renders usage as e.g. "1.2M tokens, $4.31 (estimated)"
+func formatUsage(u *ai.Usage) string {
+	text := formatTokens(u.Tokens()) + " tokens"
+
+	switch {
+	case u.CostUSD > 0 && u.Estimated:
+		text += fmt.Sprintf(", $%.2f (estimated)", u.CostUSD)
+	case u.CostUSD > 0:
+		text += fmt.Sprintf(", $%.2f", u.CostUSD)
+	}
+
+	return text
+}
+
+// formatTokens renders a token count as e.g. "950", "12.3k" or "1.2M"
+func formatTokens(n int64) string {
+	switch {
+	case n >= 1_000_000:
+		return fmt.Sprintf("%.1fM", float64(n)/1e6)
+	case n >= 1_000:
+		return fmt.Sprintf("%.1fk", float64(n)/1e3)
+	default:
+		return fmt.Sprintf("%d", n)
+	}
+}
@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/kaeawc/auto-worktree/internal/ai"
+	"github.com/kaeawc/auto-worktree/internal/session"
+)
+
+func TestComputeUsage(t *testing.T) {
+	list := []*session.Metadata{
+		{WorktreePath: "/wt/app/a", BranchName: "a", Usage: map[string]*ai.Usage{
+			"c1": {InputTokens: 100, OutputTokens: 50, CostUSD: 1},
+			"c2": {InputTokens: 100, OutputTokens: 50, CostUSD: 2, Estimated: true},
+		}},
+		{WorktreePath: "/wt/app/b", BranchName: "b", Usage: map[string]*ai.Usage{"c3": {InputTokens: 10, CostUSD: 5}}},
+		{WorktreePath: "/wt/lib/c", BranchName: "c", Usage: map[string]*ai.Usage{"c4": {OutputTokens: 1000}}},
+		{WorktreePath: "/wt/app/shell", BranchName: "shell"},
+	}
+	repoOf := map[string]string{"/wt/app/a": "/src/app", "/wt/app/b": "/src/app"}
+
+	total, worktrees, repos := computeUsage(list, repoOf)
+
+	if total.Tokens() != 1310 || total.CostUSD != 8 || !total.Estimated {
+		t.Errorf("total = %+v", total)
+	}
+
+	if len(worktrees) != 3 || worktrees[0].Path != "/wt/app/b" || worktrees[1].Path != "/wt/app/a" || worktrees[1].Tokens() != 300 {
+		t.Errorf("worktrees = %+v", worktrees)
+	}
+
+	// c has no known repository, so it counts under its own path
+	if len(repos) != 2 || repos[0].Path != "/src/app" || repos[0].CostUSD != 8 || repos[1].Path != "/wt/lib/c" {
+		t.Errorf("repos = %+v", repos)
+	}
+}
+
+func TestFormatUsage(t *testing.T) {
+	tests := []struct {
+		usage ai.Usage
+		want  string
+	}{
+		{ai.Usage{InputTokens: 950}, "950 tokens"},
+		{ai.Usage{InputTokens: 12_300, CostUSD: 0.5}, "12.3k tokens, $0.50"},
+		{ai.Usage{InputTokens: 1_000_000, OutputTokens: 200_000, CostUSD: 4.314, Estimated: true}, "1.2M tokens, $4.31 (estimated)"},
+	}
+
+	for _, tt := range tests {
+		if got := formatUsage(&tt.usage); got != tt.want {
+			t.Errorf("formatUsage(%+v) = %q, want %q", tt.usage, got, tt.want)
+		}
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"os/exec"
 	"strings"
 	"time"
+
+	"github.com/kaeawc/auto-worktree/internal/interrupt"
 )
 
 // RealInstaller implements the Installer interface
@@ -55,7 +57,7 @@ func (i *RealInstaller) Install(result *DetectionResult) *InstallResult {
 	}
 
 	// Execute install command with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	ctx, cancel := context.WithTimeout(interrupt.Context(), 10*time.Minute)
 	defer cancel()
 
 	execCmd := exec.CommandContext(ctx, cmd, args...)
@@ -0,0 +1,270 @@
+// Package events is the append-only audit log of operations that change
+// worktrees, branches and sessions, so a worktree that disappears can be
+// traced back to the command and code path that removed it.
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/user"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kaeawc/auto-worktree/internal/logging"
+	"github.com/kaeawc/auto-worktree/internal/state"
+)
+
+// Actions recorded in the log
+const (
+	ActionCreate       = "create"
+	ActionRemove       = "remove"
+	ActionDeleteBranch = "delete-branch"
+	ActionPrune        = "prune"
+	ActionRepair       = "repair"
+	ActionSessionStart = "session-start"
+	ActionSessionKill  = "session-kill"
+
+	// ActionCleanup follows the remove event of a worktree removed by cleanup, with the reason as Detail
+	ActionCleanup = "cleanup"
+	// ActionPick marks the worktree chosen over the other attempts at its issue, with the issue as Detail
+	ActionPick = "pick"
+)
+
+// Actions lists every action, for validating filters
+var Actions = []string{
+	ActionCreate, ActionRemove, ActionCleanup, ActionDeleteBranch, ActionPrune, ActionRepair, ActionSessionStart, ActionSessionKill,
+	ActionPick,
+}
+
+// maxSourceFrames is how many callers are kept in Event.Source
+const maxSourceFrames = 3
+
+// Event is one recorded operation
+type Event struct {
+	Time     time.Time `json:"time"`
+	Action   string    `json:"action"`
+	RepoPath string    `json:"repoPath,omitempty"`
+	// Path is the worktree (or session working directory) acted on
+	Path    string `json:"path,omitempty"`
+	Branch  string `json:"branch,omitempty"`
+	Session string `json:"session,omitempty"`
+	Detail  string `json:"detail,omitempty"`
+	// Merged is set on cleanup events for a branch that was merged
+	Merged bool `json:"merged,omitempty"`
+	// Error is set when the operation was attempted but failed
+	Error string `json:"error,omitempty"`
+	// Actor is the OS user, and Command the auto-worktree invocation, that did it
+	Actor   string `json:"actor,omitempty"`
+	Command string `json:"command,omitempty"`
+	// Source is the chain of functions that asked for the operation, innermost first
+	Source string `json:"source,omitempty"`
+}
+
+// Filter selects events; zero fields match everything
+type Filter struct {
+	Action   string
+	RepoPath string
+	// Dirs also selects events not tied to a repository, such as sessions,
+	// whose path is inside one of these directories
+	Dirs []string
+	// Match is a substring of the path, branch or session
+	Match string
+	Since time.Time
+	// Limit keeps only the most recent events
+	Limit int
+}
+
+// Matches reports whether e passes the filter (ignoring Limit)
+func (f Filter) Matches(e Event) bool {
+	switch {
+	case f.Action != "" && e.Action != f.Action:
+		return false
+	case f.RepoPath != "" && e.RepoPath != f.RepoPath && (e.RepoPath != "" || !isInside(e.Path, f.Dirs)):
+		return false
+	case !f.Since.IsZero() && e.Time.Before(f.Since):
+		return false
+	case f.Match != "" && !strings.Contains(e.Path, f.Match) && !strings.Contains(e.Branch, f.Match) &&
+		!strings.Contains(e.Session, f.Match):
+		return false
+	}
+
+	return true
+}
+
+// isInside reports whether path is one of dirs or below one
+func isInside(path string, dirs []string) bool {
+	for _, dir := range dirs {
+		if path != "" && (path == dir || strings.HasPrefix(path, strings.TrimSuffix(dir, "/")+"/")) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Store appends to and reads the log in the state database
+type Store struct {
+	db *state.Store
+}
+
+// NewStore creates an event store backed by the state database at path
+func NewStore(path string) *Store {
+	return &Store{db: state.NewStore(path)}
+}
+
+// Append adds an event to the end of the log. Events are never rewritten.
+func (s *Store) Append(e Event) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	err = s.db.Update(func(tx *state.Tx) error {
+		seq, err := tx.NextSequence(state.BucketEvents)
+		if err != nil {
+			return err
+		}
+
+		// Zero-padded so key order is append order
+		return tx.PutRaw(state.BucketEvents, fmt.Sprintf("%016x", seq), data)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record event: %w", err)
+	}
+
+	return nil
+}
+
+// List returns the events passing filter, oldest first
+func (s *Store) List(filter Filter) ([]Event, error) {
+	var result []Event
+
+	err := s.db.ForEach(state.BucketEvents, func(_ string, value []byte) error {
+		var e Event
+		if err := json.Unmarshal(value, &e); err != nil {
+			return nil //nolint:nilerr // skip an unreadable event rather than hide the rest
+		}
+
+		if filter.Matches(e) {
+			result = append(result, e)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read events: %w", err)
+	}
+
+	if filter.Limit > 0 && len(result) > filter.Limit {
+		result = result[len(result)-filter.Limit:]
+	}
+
+	return result, nil
+}
+
+var (
+	mu      sync.Mutex
+	current *Store
+)
+
+// Enable sends Record to the log in the state database at path. Until it is
+// called Record does nothing, so tests never write to the real log.
+func Enable(path string) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	current = NewStore(path)
+}
+
+// Disable stops recording
+func Disable() {
+	mu.Lock()
+	defer mu.Unlock()
+
+	current = nil
+}
+
+// Record fills in the time, actor, command and source of e and appends it to
+// the enabled log. The log is best-effort: failures are only logged.
+func Record(e Event) {
+	mu.Lock()
+	store := current
+	mu.Unlock()
+
+	if store == nil {
+		return
+	}
+
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+
+	if e.Actor == "" {
+		e.Actor = actor()
+	}
+
+	if e.Command == "" && len(os.Args) > 0 {
+		e.Command = strings.Join(append([]string{"auto-worktree"}, os.Args[1:]...), " ")
+	}
+
+	if e.Source == "" {
+		e.Source = source()
+	}
+
+	if err := store.Append(e); err != nil {
+		logging.Debug("event not recorded", "action", e.Action, "err", err)
+	}
+}
+
+// actor is the OS user running auto-worktree
+func actor() string {
+	if u, err := user.Current(); err == nil {
+		return u.Username
+	}
+
+	return os.Getenv("USER")
+}
+
+// source names the functions that led to the operation, skipping this package
+// and the git and session packages that perform it
+func source() string {
+	pcs := make([]uintptr, 32)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(3, pcs)])
+
+	var names []string
+
+	for {
+		frame, more := frames.Next()
+		name := shortFuncName(frame.Function)
+
+		if name == "main.main" || strings.HasPrefix(name, "runtime.") {
+			break
+		}
+
+		if name != "" && !strings.HasPrefix(name, "events.") && !strings.HasPrefix(name, "git.") &&
+			!strings.HasPrefix(name, "session.") {
+			names = append(names, name)
+			if len(names) == maxSourceFrames {
+				break
+			}
+		}
+
+		if !more {
+			break
+		}
+	}
+
+	return strings.Join(names, " < ")
+}
+
+// shortFuncName trims the module path, e.g. ".../internal/cmd.cleanupWorktree" to "cmd.cleanupWorktree"
+func shortFuncName(name string) string {
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+
+	return name
+}
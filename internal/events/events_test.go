@@ -0,0 +1,93 @@
+package events
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStoreAppendAndList(t *testing.T) {
+	store := NewStore(filepath.Join(t.TempDir(), "state.db"))
+	now := time.Now()
+
+	for i, e := range []Event{
+		{Action: ActionCreate, RepoPath: "/repo", Path: "/wt/a", Branch: "feature-a", Time: now.Add(-48 * time.Hour)},
+		{Action: ActionRemove, RepoPath: "/repo", Path: "/wt/a", Time: now.Add(-time.Hour)},
+		{Action: ActionCreate, RepoPath: "/other", Path: "/wt/b", Branch: "feature-b", Time: now},
+		{Action: ActionSessionStart, Session: "auto-worktree-a", Path: "/wt/a", Time: now},
+	} {
+		if err := store.Append(e); err != nil {
+			t.Fatalf("Append(%d) error = %v", i, err)
+		}
+	}
+
+	all, err := store.List(Filter{})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+
+	if len(all) != 4 || all[0].Branch != "feature-a" || all[3].Session != "auto-worktree-a" {
+		t.Fatalf("List() = %+v, want all events in append order", all)
+	}
+
+	tests := []struct {
+		name   string
+		filter Filter
+		want   int
+	}{
+		{"action", Filter{Action: ActionCreate}, 2},
+		{"repository", Filter{RepoPath: "/repo"}, 2},
+		{"repository and its sessions", Filter{RepoPath: "/repo", Dirs: []string{"/wt/a"}}, 3},
+		{"match branch or path", Filter{Match: "wt/a"}, 3},
+		{"since", Filter{Since: now.Add(-2 * time.Hour)}, 3},
+		{"limit keeps latest", Filter{Limit: 1}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := store.List(tt.filter)
+			if err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+
+			if len(got) != tt.want {
+				t.Errorf("List(%+v) returned %d events, want %d", tt.filter, len(got), tt.want)
+			}
+		})
+	}
+
+	if latest, _ := store.List(Filter{Limit: 1}); latest[0].Action != ActionSessionStart { //nolint:errcheck // checked above
+		t.Errorf("Limit kept %+v, want the latest event", latest[0])
+	}
+}
+
+func TestRecord(t *testing.T) {
+	// Nothing is written until the log is enabled
+	Record(Event{Action: ActionRemove})
+
+	path := filepath.Join(t.TempDir(), "state.db")
+	Enable(path)
+	t.Cleanup(Disable)
+
+	Record(Event{Action: ActionRemove, RepoPath: "/repo", Path: "/wt/a"})
+
+	got, err := NewStore(path).List(Filter{})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+
+	if len(got) != 1 {
+		t.Fatalf("List() returned %d events, want 1", len(got))
+	}
+
+	e := got[0]
+	if e.Time.IsZero() || !strings.HasPrefix(e.Command, "auto-worktree") {
+		t.Errorf("Record() did not fill in the time and command: %+v", e)
+	}
+
+	// The test itself is in this package, so the first caller outside it is the test runner
+	if e.Source == "" || strings.Contains(e.Source, "events.") {
+		t.Errorf("Source = %q, want the callers outside this package", e.Source)
+	}
+}
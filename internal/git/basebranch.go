@@ -0,0 +1,110 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+)
+
+// BaseBranches are the branches new branches start from, set with
+// auto-worktree.base-branch, for repositories where work doesn't branch off
+// the default branch, e.g. off develop or release/x
+type BaseBranches struct {
+	// Default is the base of branches no prefix matches, or "" for the
+	// repository's default branch
+	Default string
+	// Prefixes are the bases of branches by name prefix
+	Prefixes []BaseBranchPrefix
+}
+
+// BaseBranchPrefix gives branches starting with Prefix the base Branch
+type BaseBranchPrefix struct {
+	Prefix string
+	Branch string
+}
+
+// ParseBaseBranches parses "develop" or "develop,hotfix/=main,release/=release/2.x":
+// an entry without = is the base of every other branch. Invalid entries are
+// skipped and reported in the error.
+func ParseBaseBranches(value string) (BaseBranches, error) {
+	var (
+		bases   BaseBranches
+		invalid []string
+	)
+
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		prefix, branch, ok := strings.Cut(entry, "=")
+		prefix, branch = strings.TrimSpace(prefix), strings.TrimSpace(branch)
+
+		if !ok {
+			prefix, branch = "", entry
+		}
+
+		if branch == "" || (ok && prefix == "") || !validBranchName(branch) || (bases.Default != "" && !ok) {
+			invalid = append(invalid, entry)
+			continue
+		}
+
+		if !ok {
+			bases.Default = branch
+			continue
+		}
+
+		bases.Prefixes = append(bases.Prefixes, BaseBranchPrefix{Prefix: prefix, Branch: branch})
+	}
+
+	if len(invalid) > 0 {
+		return bases, fmt.Errorf("invalid base branches: %s (use a branch, prefix=branch or both, e.g. develop,hotfix/=main)",
+			strings.Join(invalid, ", "))
+	}
+
+	return bases, nil
+}
+
+// For returns the base of branch: that of the longest prefix it starts with,
+// else Default, which is "" when the default branch is the base
+func (b BaseBranches) For(branch string) string {
+	base, matched := b.Default, 0
+
+	for _, p := range b.Prefixes {
+		if strings.HasPrefix(branch, p.Prefix) && len(p.Prefix) > matched {
+			base, matched = p.Branch, len(p.Prefix)
+		}
+	}
+
+	return base
+}
+
+// validBranchName reports whether name can be a branch, roughly as git check-ref-format does
+func validBranchName(name string) bool {
+	return !strings.ContainsAny(name, " ~^:?*[\\") && !strings.Contains(name, "..") &&
+		!strings.HasPrefix(name, "-") && !strings.HasSuffix(name, "/")
+}
+
+// BaseBranchFor returns the branch a new branch starts from: the one
+// auto-worktree.base-branch sets for it, or the default branch. A configured
+// base only on origin is returned as origin/<base>.
+func (r *Repository) BaseBranchFor(branch string) (string, error) {
+	base := ""
+	if r.Config != nil {
+		base = r.Config.GetBaseBranches().For(branch)
+	}
+
+	if base == "" {
+		return r.GetDefaultBranch()
+	}
+
+	if r.BranchExists(base) {
+		return base, nil
+	}
+
+	if r.remoteBranchExists("origin/" + base) {
+		return "origin/" + base, nil
+	}
+
+	return "", fmt.Errorf("base branch %s does not exist locally or on origin (see %s)", base, ConfigBaseBranch)
+}
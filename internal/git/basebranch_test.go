@@ -0,0 +1,88 @@
+package git
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseBaseBranches(t *testing.T) {
+	bases, err := ParseBaseBranches("develop, hotfix/=main,,release/=release/2.x, =bad")
+	if err == nil || !strings.Contains(err.Error(), "=bad") {
+		t.Errorf("ParseBaseBranches() error = %v, want it to report the bad entry", err)
+	}
+
+	want := BaseBranches{
+		Default:  "develop",
+		Prefixes: []BaseBranchPrefix{{Prefix: "hotfix/", Branch: "main"}, {Prefix: "release/", Branch: "release/2.x"}},
+	}
+	if !reflect.DeepEqual(bases, want) {
+		t.Errorf("ParseBaseBranches() = %+v, want %+v", bases, want)
+	}
+}
+
+func TestBaseBranchesFor(t *testing.T) {
+	bases := BaseBranches{
+		Default:  "develop",
+		Prefixes: []BaseBranchPrefix{{Prefix: "release/", Branch: "main"}, {Prefix: "release/2", Branch: "release/2.x"}},
+	}
+
+	tests := map[string]string{
+		"work/red-fox":   "develop",
+		"release/1-fix":  "main",
+		"release/2-fix":  "release/2.x",
+		"releases/notes": "develop",
+	}
+
+	for branch, want := range tests {
+		if got := bases.For(branch); got != want {
+			t.Errorf("For(%q) = %q, want %q", branch, got, want)
+		}
+	}
+
+	if got := (BaseBranches{}).For("work/red-fox"); got != "" {
+		t.Errorf("For() with nothing configured = %q, want the default branch", got)
+	}
+}
+
+func TestBaseBranchFor(t *testing.T) {
+	executor := NewFakeGitExecutor()
+
+	repo, err := NewRepositoryFromPathWithDeps("/fake/repo", executor, NewFakeFileSystem())
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+
+	repo.Config = NewConfigWithExecutor("/fake/repo", executor)
+
+	executor.SetResponse("config --local --get "+ConfigBaseBranch, "develop,hotfix/=main,release/=release/9.x")
+	executor.SetResponse("symbolic-ref refs/remotes/origin/HEAD", "refs/remotes/origin/main")
+	executor.SetError("show-ref --verify --quiet refs/heads/develop", errors.New("exit status 1"))
+	executor.SetError("show-ref --verify --quiet refs/heads/release/9.x", errors.New("exit status 1"))
+	executor.SetError("show-ref --verify --quiet refs/remotes/origin/release/9.x", errors.New("exit status 1"))
+
+	tests := []struct {
+		branch  string
+		want    string
+		wantErr bool
+	}{
+		{"hotfix/crash", "main", false},
+		{"work/red-fox", "origin/develop", false},
+		{"release/9.1", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := repo.BaseBranchFor(tt.branch)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("BaseBranchFor(%q) = %q, %v, want %q (error: %v)", tt.branch, got, err, tt.want, tt.wantErr)
+		}
+	}
+
+	executor.SetResponse("config --local --get "+ConfigBaseBranch, "")
+	executor.SetResponse("config --global --get "+ConfigBaseBranch, "")
+
+	if got, err := repo.BaseBranchFor("work/red-fox"); err != nil || got != "main" {
+		t.Errorf("BaseBranchFor() without config = %q, %v, want the default branch main", got, err)
+	}
+}
@@ -0,0 +1,170 @@
+package git
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kaeawc/auto-worktree/internal/logging"
+)
+
+const (
+	// checkpointRefPrefix keeps checkpoints out of refs/heads, so they never
+	// show up as branches but still keep their commits from being collected
+	checkpointRefPrefix = "refs/auto-worktree/checkpoints/"
+	// MaxCheckpoints is how many checkpoints are kept for each branch; older
+	// ones are deleted as new ones are taken
+	MaxCheckpoints = 50
+)
+
+// Checkpoint is a snapshot of a worktree's files, tracked and untracked, stored
+// as a commit on top of the HEAD it was taken from
+type Checkpoint struct {
+	Ref       string
+	Commit    string
+	CreatedAt time.Time
+}
+
+// checkpointRefs returns the ref namespace holding branch's checkpoints
+func checkpointRefs(branch string) string {
+	return checkpointRefPrefix + branch + "/"
+}
+
+// CreateCheckpoint snapshots the worktree's files into a checkpoint of branch,
+// using a separate index so the worktree's own index, HEAD and files are left
+// alone. It returns nil when nothing changed since HEAD or the last checkpoint.
+func (r *Repository) CreateCheckpoint(worktreePath, branch string, now time.Time) (*Checkpoint, error) {
+	indexPath, err := r.executor.ExecuteInDir(worktreePath, "rev-parse", "--git-path", "auto-worktree-checkpoint-index")
+	if err != nil {
+		return nil, fmt.Errorf("failed to locate the checkpoint index: %w", err)
+	}
+
+	if !filepath.IsAbs(indexPath) {
+		indexPath = filepath.Join(worktreePath, indexPath)
+	}
+
+	env := []string{"GIT_INDEX_FILE=" + indexPath}
+
+	// Start from HEAD so files that are tracked but ignored stay in the snapshot
+	if _, err := r.executor.ExecuteInDirWithEnv(worktreePath, env, "read-tree", "HEAD"); err != nil {
+		return nil, fmt.Errorf("failed to prepare checkpoint: %w", err)
+	}
+
+	if _, err := r.executor.ExecuteInDirWithEnv(worktreePath, env, "add", "-A"); err != nil {
+		return nil, fmt.Errorf("failed to snapshot worktree: %w", err)
+	}
+
+	tree, err := r.executor.ExecuteInDirWithEnv(worktreePath, env, "write-tree")
+	if err != nil {
+		return nil, fmt.Errorf("failed to snapshot worktree: %w", err)
+	}
+
+	if headTree, err := r.executor.ExecuteInDir(worktreePath, "rev-parse", "HEAD^{tree}"); err == nil && headTree == tree {
+		return nil, nil
+	}
+
+	checkpoints, err := r.ListCheckpoints(branch)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(checkpoints) > 0 {
+		if lastTree, err := r.executor.ExecuteInDir(worktreePath, "rev-parse", checkpoints[0].Commit+"^{tree}"); err == nil && lastTree == tree {
+			return nil, nil
+		}
+	}
+
+	commit, err := r.executor.ExecuteInDir(worktreePath, "commit-tree", tree, "-p", "HEAD", "-m", "auto-worktree checkpoint of "+branch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to commit checkpoint: %w", err)
+	}
+
+	checkpoint := &Checkpoint{
+		Ref:       checkpointRefs(branch) + strconv.FormatInt(now.Unix(), 10),
+		Commit:    commit,
+		CreatedAt: time.Unix(now.Unix(), 0),
+	}
+
+	if _, err := r.executor.ExecuteInDir(worktreePath, "update-ref", checkpoint.Ref, commit); err != nil {
+		return nil, fmt.Errorf("failed to save checkpoint: %w", err)
+	}
+
+	// The list above is newest first and doesn't include the new checkpoint yet
+	if len(checkpoints) >= MaxCheckpoints {
+		for _, old := range checkpoints[MaxCheckpoints-1:] {
+			if _, err := r.executor.ExecuteInDir(r.RootPath, "update-ref", "-d", old.Ref); err != nil {
+				logging.Debug("failed to delete old checkpoint", "ref", old.Ref, "err", err)
+			}
+		}
+	}
+
+	return checkpoint, nil
+}
+
+// ListCheckpoints returns branch's checkpoints, newest first
+func (r *Repository) ListCheckpoints(branch string) ([]Checkpoint, error) {
+	output, err := r.executor.ExecuteInDir(r.RootPath, "for-each-ref", "--format=%(refname) %(objectname)", checkpointRefs(branch))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list checkpoints: %w", err)
+	}
+
+	return parseCheckpoints(output, branch), nil
+}
+
+// parseCheckpoints reads for-each-ref lines of "<ref> <commit>", skipping
+// refs of other branches nested under branch (e.g. feature/x under feature)
+func parseCheckpoints(output, branch string) []Checkpoint {
+	var checkpoints []Checkpoint
+
+	prefix := checkpointRefs(branch)
+
+	for _, line := range strings.Split(output, "\n") {
+		ref, commit, ok := strings.Cut(strings.TrimSpace(line), " ")
+		if !ok || !strings.HasPrefix(ref, prefix) {
+			continue
+		}
+
+		seconds, err := strconv.ParseInt(strings.TrimPrefix(ref, prefix), 10, 64)
+		if err != nil {
+			continue
+		}
+
+		checkpoints = append(checkpoints, Checkpoint{Ref: ref, Commit: commit, CreatedAt: time.Unix(seconds, 0)})
+	}
+
+	sort.Slice(checkpoints, func(i, j int) bool {
+		return checkpoints[i].CreatedAt.After(checkpoints[j].CreatedAt)
+	})
+
+	return checkpoints
+}
+
+// RestoreCheckpoint puts the worktree's files back as they were at the
+// checkpoint. Files added since are removed if tracked and kept if untracked;
+// the index and HEAD are left alone.
+func (r *Repository) RestoreCheckpoint(worktreePath string, checkpoint Checkpoint) error {
+	if _, err := r.executor.ExecuteInDir(worktreePath, "restore", "--source="+checkpoint.Commit, "--worktree", "--", "."); err != nil {
+		return fmt.Errorf("failed to restore checkpoint: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteCheckpoints removes all of branch's checkpoints
+func (r *Repository) DeleteCheckpoints(branch string) error {
+	checkpoints, err := r.ListCheckpoints(branch)
+	if err != nil {
+		return err
+	}
+
+	for _, checkpoint := range checkpoints {
+		if _, err := r.executor.ExecuteInDir(r.RootPath, "update-ref", "-d", checkpoint.Ref); err != nil {
+			return fmt.Errorf("failed to delete checkpoint: %w", err)
+		}
+	}
+
+	return nil
+}
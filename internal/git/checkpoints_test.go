@@ -0,0 +1,81 @@
+package git
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseCheckpoints(t *testing.T) {
+	output := "refs/auto-worktree/checkpoints/feature/1700000000 aaa\n" +
+		"refs/auto-worktree/checkpoints/feature/x/1700000500 bbb\n" +
+		"refs/auto-worktree/checkpoints/feature/1700000600 ccc\n" +
+		"refs/auto-worktree/checkpoints/feature/not-a-time ddd\n"
+
+	checkpoints := parseCheckpoints(output, "feature")
+
+	if len(checkpoints) != 2 {
+		t.Fatalf("parseCheckpoints() returned %d checkpoints, want 2: %+v", len(checkpoints), checkpoints)
+	}
+
+	if checkpoints[0].Commit != "ccc" || checkpoints[1].Commit != "aaa" {
+		t.Errorf("checkpoints = %+v, want ccc then aaa", checkpoints)
+	}
+
+	if !checkpoints[0].CreatedAt.Equal(time.Unix(1700000600, 0)) {
+		t.Errorf("CreatedAt = %v", checkpoints[0].CreatedAt)
+	}
+}
+
+func TestCreateCheckpoint(t *testing.T) {
+	executor := NewFakeGitExecutor()
+
+	repo, err := NewRepositoryFromPathWithDeps("/fake/repo", executor, NewFakeFileSystem())
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+
+	executor.Responses["rev-parse --git-path auto-worktree-checkpoint-index"] = "/fake/repo/.git/worktrees/fix/auto-worktree-checkpoint-index"
+	executor.Responses["write-tree"] = "tree2"
+	executor.Responses["rev-parse HEAD^{tree}"] = "tree1"
+	executor.Responses["commit-tree tree2 -p HEAD -m auto-worktree checkpoint of fix"] = "commit2"
+
+	now := time.Unix(1700000000, 0)
+
+	checkpoint, err := repo.CreateCheckpoint("/fake/worktrees/fix", "fix", now)
+	if err != nil {
+		t.Fatalf("CreateCheckpoint() error = %v", err)
+	}
+
+	if checkpoint == nil || checkpoint.Ref != "refs/auto-worktree/checkpoints/fix/1700000000" || checkpoint.Commit != "commit2" {
+		t.Fatalf("CreateCheckpoint() = %+v", checkpoint)
+	}
+
+	wrote := false
+
+	for _, cmd := range executor.Commands {
+		if strings.Join(cmd[1:], " ") == "update-ref refs/auto-worktree/checkpoints/fix/1700000000 commit2" {
+			wrote = true
+		}
+	}
+
+	if !wrote {
+		t.Error("checkpoint ref was not written")
+	}
+
+	// Nothing changed since the last checkpoint
+	executor.Responses["for-each-ref --format=%(refname) %(objectname) refs/auto-worktree/checkpoints/fix/"] =
+		"refs/auto-worktree/checkpoints/fix/1700000000 commit2"
+	executor.Responses["rev-parse commit2^{tree}"] = "tree2"
+
+	if checkpoint, err := repo.CreateCheckpoint("/fake/worktrees/fix", "fix", now.Add(time.Minute)); err != nil || checkpoint != nil {
+		t.Errorf("CreateCheckpoint() unchanged = %+v, %v; want nil, nil", checkpoint, err)
+	}
+
+	// Nothing changed since HEAD
+	executor.Responses["rev-parse HEAD^{tree}"] = "tree2"
+
+	if checkpoint, err := repo.CreateCheckpoint("/fake/worktrees/fix", "fix", now.Add(time.Minute)); err != nil || checkpoint != nil {
+		t.Errorf("CreateCheckpoint() clean = %+v, %v; want nil, nil", checkpoint, err)
+	}
+}
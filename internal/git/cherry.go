@@ -0,0 +1,258 @@
+package git
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// CherryCommits returns the commits to copy from branch into a worktree,
+// oldest first. With an empty commits it is every commit of branch that the
+// worktree's HEAD has no equivalent of; otherwise commits is a range like
+// a..b or a single commit. Merges are left out.
+func (r *Repository) CherryCommits(worktreePath, branch, commits string) ([]string, error) {
+	args := []string{"rev-list", "--reverse", "--no-merges"}
+
+	switch {
+	case commits == "":
+		args = append(args, "--right-only", "--cherry-pick", "HEAD..."+branch)
+	case strings.Contains(commits, ".."):
+		args = append(args, commits)
+	default:
+		args = append(args, "--max-count=1", commits)
+	}
+
+	output, err := r.executor.ExecuteInDir(worktreePath, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list commits to copy: %w", err)
+	}
+
+	return strings.Fields(output), nil
+}
+
+// CherryPick applies commits on top of a worktree's HEAD. A cherry-pick that
+// stops on a conflict is aborted, leaving the worktree as it was.
+func (r *Repository) CherryPick(worktreePath string, commits []string) error {
+	args := append([]string{"cherry-pick", "-x"}, commits...)
+
+	if _, err := r.executor.ExecuteInDir(worktreePath, args...); err != nil {
+		if _, abortErr := r.executor.ExecuteInDir(worktreePath, "cherry-pick", "--abort"); abortErr != nil {
+			return fmt.Errorf("cherry-pick failed and could not be undone (run git cherry-pick --abort): %w", err)
+		}
+
+		return fmt.Errorf("cherry-pick failed, so the worktree was left as it was: %w", err)
+	}
+
+	return nil
+}
+
+// ChangedPaths returns the files under paths with uncommitted changes in a
+// worktree, including untracked ones
+func (r *Repository) ChangedPaths(worktreePath string, paths []string) ([]string, error) {
+	args := append([]string{"status", "--porcelain", "--untracked-files=all", "--"}, paths...)
+
+	output, err := r.executor.ExecuteInDir(worktreePath, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get status of %s: %w", worktreePath, err)
+	}
+
+	var changed []string
+
+	for _, line := range strings.Split(output, "\n") {
+		if len(line) > 3 {
+			changed = append(changed, line[3:])
+		}
+	}
+
+	return changed, nil
+}
+
+// CopyPaths makes paths in the worktree at toPath match the worktree at
+// fromPath as it is on disk, committed or not: files are copied over, and
+// files missing from fromPath are deleted. Ignored files are left alone.
+// Symlinks are copied as symlinks, and one pointing outside fromPath is
+// refused. It returns the files it changed; they are left uncommitted for review.
+func (r *Repository) CopyPaths(fromPath, toPath string, paths []string) ([]string, error) {
+	source, err := r.listPathFiles(fromPath, paths)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := r.listPathFiles(toPath, paths)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(source) == 0 && len(target) == 0 {
+		return nil, fmt.Errorf("no files match %s in either worktree", strings.Join(paths, " "))
+	}
+
+	// Check every link before copying anything, so a refusal leaves toPath untouched
+	for file := range source {
+		if err := r.checkSymlink(fromPath, file); err != nil {
+			return nil, err
+		}
+	}
+
+	var changed []string
+
+	for file := range source {
+		copied, err := r.copyFile(fromPath, toPath, file)
+		if err != nil {
+			return changed, err
+		}
+
+		if copied {
+			changed = append(changed, file)
+		}
+	}
+
+	for file := range target {
+		if source[file] {
+			continue
+		}
+
+		if err := r.filesystem.Remove(filepath.Join(toPath, file)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return changed, fmt.Errorf("failed to remove %s: %w", file, err)
+		}
+
+		changed = append(changed, file)
+	}
+
+	sort.Strings(changed)
+
+	return changed, nil
+}
+
+// listPathFiles returns the tracked and untracked, not ignored, files under
+// paths in a worktree, relative to it
+func (r *Repository) listPathFiles(worktreePath string, paths []string) (map[string]bool, error) {
+	args := append([]string{"ls-files", "-z", "--cached", "--others", "--exclude-standard", "--"}, paths...)
+
+	output, err := r.executor.ExecuteInDir(worktreePath, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files in %s: %w", worktreePath, err)
+	}
+
+	files := map[string]bool{}
+
+	// Deleted tracked files are still listed until the deletion is committed
+	for _, file := range strings.Split(output, "\x00") {
+		if file == "" {
+			continue
+		}
+
+		// Lstat, so a symlink to a missing file still counts
+		if _, err := r.filesystem.Lstat(filepath.Join(worktreePath, file)); err == nil {
+			files[file] = true
+		}
+	}
+
+	return files, nil
+}
+
+// copyFile copies file from the worktree at fromPath to the one at toPath,
+// with its permissions when it is new, and reports whether the destination
+// changed. Symlinks are recreated rather than followed.
+func (r *Repository) copyFile(fromPath, toPath, file string) (bool, error) {
+	from, to := filepath.Join(fromPath, file), filepath.Join(toPath, file)
+
+	info, err := r.filesystem.Lstat(from)
+	if err != nil {
+		return false, fmt.Errorf("failed to stat %s: %w", from, err)
+	}
+
+	if info.Mode()&os.ModeSymlink != 0 {
+		return r.copySymlink(from, to)
+	}
+
+	if !info.Mode().IsRegular() {
+		return false, fmt.Errorf("%s is not a regular file", from)
+	}
+
+	data, err := r.filesystem.ReadFile(from)
+	if err != nil {
+		return false, fmt.Errorf("failed to read %s: %w", from, err)
+	}
+
+	// Writing through a symlink would change whatever it points to, so it is replaced
+	if existing, err := r.filesystem.Lstat(to); err == nil && existing.Mode()&os.ModeSymlink != 0 {
+		if err := r.filesystem.Remove(to); err != nil {
+			return false, fmt.Errorf("failed to remove %s: %w", to, err)
+		}
+	} else if current, err := r.filesystem.ReadFile(to); err == nil && bytes.Equal(current, data) {
+		return false, nil
+	}
+
+	if err := r.filesystem.MkdirAll(filepath.Dir(to), 0o755); err != nil {
+		return false, fmt.Errorf("failed to create %s: %w", filepath.Dir(to), err)
+	}
+
+	if err := r.filesystem.WriteFile(to, data, info.Mode().Perm()); err != nil {
+		return false, fmt.Errorf("failed to write %s: %w", to, err)
+	}
+
+	return true, nil
+}
+
+// checkSymlink refuses file when it is a symlink pointing outside the worktree at fromPath
+func (r *Repository) checkSymlink(fromPath, file string) error {
+	from := filepath.Join(fromPath, file)
+
+	info, err := r.filesystem.Lstat(from)
+	if err != nil || info.Mode()&os.ModeSymlink == 0 {
+		// copyFile reports a file it can't stat
+		return nil
+	}
+
+	target, err := r.filesystem.Readlink(from)
+	if err != nil {
+		return fmt.Errorf("failed to read link %s: %w", from, err)
+	}
+
+	resolved := target
+	if !filepath.IsAbs(resolved) {
+		resolved = filepath.Join(filepath.Dir(from), target)
+	}
+
+	if !isUnderAny(filepath.Clean(resolved), []string{filepath.Clean(fromPath)}) {
+		return fmt.Errorf("refusing to copy %s: it links to %s, outside %s", file, target, fromPath)
+	}
+
+	return nil
+}
+
+// copySymlink recreates the symlink from at to and reports whether the destination changed
+func (r *Repository) copySymlink(from, to string) (bool, error) {
+	target, err := r.filesystem.Readlink(from)
+	if err != nil {
+		return false, fmt.Errorf("failed to read link %s: %w", from, err)
+	}
+
+	if existing, err := r.filesystem.Lstat(to); err == nil {
+		if existing.Mode()&os.ModeSymlink != 0 {
+			if current, err := r.filesystem.Readlink(to); err == nil && current == target {
+				return false, nil
+			}
+		}
+
+		if err := r.filesystem.Remove(to); err != nil {
+			return false, fmt.Errorf("failed to remove %s: %w", to, err)
+		}
+	}
+
+	if err := r.filesystem.MkdirAll(filepath.Dir(to), 0o755); err != nil {
+		return false, fmt.Errorf("failed to create %s: %w", filepath.Dir(to), err)
+	}
+
+	if err := r.filesystem.Symlink(target, to); err != nil {
+		return false, fmt.Errorf("failed to create link %s: %w", to, err)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,135 @@
+package git
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCherryCommits(t *testing.T) {
+	tests := []struct {
+		commits string
+		want    string
+	}{
+		{commits: "", want: "rev-list --reverse --no-merges --right-only --cherry-pick HEAD...work/a"},
+		{commits: "abc..def", want: "rev-list --reverse --no-merges abc..def"},
+		{commits: "abc", want: "rev-list --reverse --no-merges --max-count=1 abc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.commits, func(t *testing.T) {
+			executor := NewFakeGitExecutor()
+
+			repo, err := NewRepositoryFromPathWithDeps("/fake/repo", executor, NewFakeFileSystem())
+			if err != nil {
+				t.Fatalf("Failed to create repository: %v", err)
+			}
+
+			executor.Responses[tt.want] = "aaa\nbbb\n"
+
+			hashes, err := repo.CherryCommits("/fake/worktrees/b", "work/a", tt.commits)
+			if err != nil || strings.Join(hashes, " ") != "aaa bbb" {
+				t.Errorf("CherryCommits() = %v, %v; want [aaa bbb] from %q", hashes, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestCopyPaths(t *testing.T) {
+	executor := NewFakeGitExecutor()
+	filesystem := NewFakeFileSystem()
+
+	repo, err := NewRepositoryFromPathWithDeps("/fake/repo", executor, filesystem)
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+
+	// Both worktrees get this listing; files missing from one on disk are skipped
+	executor.Responses["ls-files -z --cached --others --exclude-standard -- src"] = "src/new.go\x00src/same.go\x00src/changed.go\x00src/old.go\x00"
+	filesystem.Files["/fake/worktrees/a/src/new.go"] = []byte("new")
+	filesystem.Files["/fake/worktrees/a/src/same.go"] = []byte("same")
+	filesystem.Files["/fake/worktrees/a/src/changed.go"] = []byte("after")
+	filesystem.Files["/fake/worktrees/b/src/same.go"] = []byte("same")
+	filesystem.Files["/fake/worktrees/b/src/changed.go"] = []byte("before")
+	filesystem.Files["/fake/worktrees/b/src/old.go"] = []byte("old")
+
+	changed, err := repo.CopyPaths("/fake/worktrees/a", "/fake/worktrees/b", []string{"src"})
+	if err != nil {
+		t.Fatalf("CopyPaths() error = %v", err)
+	}
+
+	if got := strings.Join(changed, " "); got != "src/changed.go src/new.go src/old.go" {
+		t.Errorf("CopyPaths() changed %q", got)
+	}
+
+	if got := string(filesystem.Files["/fake/worktrees/b/src/changed.go"]); got != "after" {
+		t.Errorf("changed.go = %q, want it copied", got)
+	}
+
+	if filesystem.Exists("/fake/worktrees/b/src/old.go") {
+		t.Error("old.go should be removed, since the source has no such file")
+	}
+}
+
+func TestCopyPathsSymlinks(t *testing.T) {
+	executor := NewFakeGitExecutor()
+	filesystem := NewFakeFileSystem()
+
+	repo, err := NewRepositoryFromPathWithDeps("/fake/repo", executor, filesystem)
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+
+	executor.Responses["ls-files -z --cached --others --exclude-standard -- src"] = "src/link\x00src/target.go\x00src/dangling\x00"
+	filesystem.Files["/fake/worktrees/a/src/target.go"] = []byte("real")
+	filesystem.Symlinks["/fake/worktrees/a/src/link"] = "target.go"
+	filesystem.Symlinks["/fake/worktrees/a/src/dangling"] = "missing.go"
+	// A link in the destination must be replaced, not written through
+	filesystem.Symlinks["/fake/worktrees/b/src/target.go"] = "/etc/passwd"
+
+	changed, err := repo.CopyPaths("/fake/worktrees/a", "/fake/worktrees/b", []string{"src"})
+	if err != nil {
+		t.Fatalf("CopyPaths() error = %v", err)
+	}
+
+	if got := strings.Join(changed, " "); got != "src/dangling src/link src/target.go" {
+		t.Errorf("CopyPaths() changed %q", got)
+	}
+
+	if got := filesystem.Symlinks["/fake/worktrees/b/src/link"]; got != "target.go" {
+		t.Errorf("link = %q, want it recreated pointing at target.go", got)
+	}
+
+	if got := filesystem.Symlinks["/fake/worktrees/b/src/dangling"]; got != "missing.go" {
+		t.Errorf("dangling = %q, want it recreated pointing at missing.go", got)
+	}
+
+	if _, isLink := filesystem.Symlinks["/fake/worktrees/b/src/target.go"]; isLink || string(filesystem.Files["/fake/worktrees/b/src/target.go"]) != "real" {
+		t.Error("target.go should replace the destination's symlink with the file")
+	}
+}
+
+func TestCopyPathsRefusesLinksOutsideWorktree(t *testing.T) {
+	for _, target := range []string{"/etc/passwd", "../../secret"} {
+		t.Run(target, func(t *testing.T) {
+			executor := NewFakeGitExecutor()
+			filesystem := NewFakeFileSystem()
+
+			repo, err := NewRepositoryFromPathWithDeps("/fake/repo", executor, filesystem)
+			if err != nil {
+				t.Fatalf("Failed to create repository: %v", err)
+			}
+
+			executor.Responses["ls-files -z --cached --others --exclude-standard -- src"] = "src/link\x00src/new.go\x00"
+			filesystem.Symlinks["/fake/worktrees/a/src/link"] = target
+			filesystem.Files["/fake/worktrees/a/src/new.go"] = []byte("new")
+
+			if _, err := repo.CopyPaths("/fake/worktrees/a", "/fake/worktrees/b", []string{"src"}); err == nil {
+				t.Fatal("CopyPaths() succeeded, want a link outside the worktree refused")
+			}
+
+			if filesystem.Exists("/fake/worktrees/b/src/new.go") || filesystem.Symlinks["/fake/worktrees/b/src/link"] != "" {
+				t.Error("nothing should be copied when a link is refused")
+			}
+		})
+	}
+}
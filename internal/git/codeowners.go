@@ -0,0 +1,186 @@
+package git
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// codeOwnersLocations are where GitHub and GitLab look for a CODEOWNERS file, in order
+var codeOwnersLocations = []string{
+	".github/CODEOWNERS",
+	"CODEOWNERS",
+	"docs/CODEOWNERS",
+	".gitlab/CODEOWNERS",
+}
+
+// CodeOwners maps paths in a repository to their owners, as read from a CODEOWNERS file
+type CodeOwners struct {
+	rules []codeOwnersRule
+}
+
+// codeOwnersRule is one pattern line of a CODEOWNERS file
+type codeOwnersRule struct {
+	pattern *regexp.Regexp
+	owners  []string
+}
+
+// LoadCodeOwners reads the CODEOWNERS file of the checkout at dir, or returns
+// nil when it has none
+func LoadCodeOwners(dir string) (*CodeOwners, error) {
+	for _, location := range codeOwnersLocations {
+		content, err := os.ReadFile(filepath.Join(dir, location))
+		if os.IsNotExist(err) {
+			continue
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", location, err)
+		}
+
+		return ParseCodeOwners(string(content)), nil
+	}
+
+	return nil, nil
+}
+
+// ParseCodeOwners parses a CODEOWNERS file. GitLab section headers are
+// skipped, and lines with patterns that can't be matched are ignored, as
+// GitHub does.
+func ParseCodeOwners(content string) *CodeOwners {
+	owners := &CodeOwners{}
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "[") || strings.HasPrefix(line, "^[") {
+			continue
+		}
+
+		if i := strings.Index(line, " #"); i >= 0 {
+			line = line[:i]
+		}
+
+		fields := strings.Fields(line)
+
+		pattern, err := codeOwnersPattern(fields[0])
+		if err != nil {
+			continue
+		}
+
+		rule := codeOwnersRule{pattern: pattern}
+		if len(fields) > 1 {
+			rule.owners = fields[1:]
+		}
+
+		owners.rules = append(owners.rules, rule)
+	}
+
+	return owners
+}
+
+// codeOwnersPattern compiles a gitignore-style CODEOWNERS pattern. Patterns
+// with a leading or inner slash are relative to the repository root, others
+// match at any depth; a directory matches everything below it, except that
+// dir/* only matches the directory's own files.
+func codeOwnersPattern(pattern string) (*regexp.Regexp, error) {
+	anchored := strings.HasPrefix(pattern, "/") || strings.Contains(strings.TrimSuffix(pattern, "/"), "/")
+	dirOnly := strings.HasSuffix(pattern, "/")
+	shallow := strings.HasSuffix(pattern, "/*")
+
+	pattern = strings.Trim(pattern, "/")
+	if pattern == "" {
+		return nil, fmt.Errorf("empty pattern")
+	}
+
+	var sb strings.Builder
+
+	if anchored {
+		sb.WriteString("^")
+	} else {
+		sb.WriteString("^(?:.*/)?")
+	}
+
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; {
+		case c == '*' && strings.HasPrefix(pattern[i:], "**/"):
+			sb.WriteString("(?:.*/)?")
+			i += 2
+		case c == '*' && strings.HasPrefix(pattern[i:], "**"):
+			sb.WriteString(".*")
+			i++
+		case c == '*':
+			sb.WriteString("[^/]*")
+		case c == '?':
+			sb.WriteString("[^/]")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	switch {
+	case shallow:
+		sb.WriteString("$")
+	case dirOnly:
+		sb.WriteString("/.*$")
+	default:
+		sb.WriteString("(?:/.*)?$")
+	}
+
+	return regexp.Compile(sb.String())
+}
+
+// Owners returns the owners of path, relative to the repository root: those
+// of the last matching pattern, or none when no pattern matches or the last
+// match lists no owners
+func (c *CodeOwners) Owners(path string) []string {
+	if c == nil {
+		return nil
+	}
+
+	path = strings.TrimPrefix(filepath.ToSlash(path), "/")
+
+	for i := len(c.rules) - 1; i >= 0; i-- {
+		if c.rules[i].pattern.MatchString(path) {
+			return c.rules[i].owners
+		}
+	}
+
+	return nil
+}
+
+// SuggestReviewers returns the owners of the changed paths without their @,
+// those owning the most paths first. Owners given by email are left out,
+// since review requests are made by username.
+func (c *CodeOwners) SuggestReviewers(paths []string) []string {
+	counts := map[string]int{}
+
+	for _, path := range paths {
+		for _, owner := range c.Owners(path) {
+			if !strings.HasPrefix(owner, "@") {
+				continue
+			}
+
+			counts[strings.TrimPrefix(owner, "@")]++
+		}
+	}
+
+	reviewers := make([]string, 0, len(counts))
+	for reviewer := range counts {
+		reviewers = append(reviewers, reviewer)
+	}
+
+	sort.Slice(reviewers, func(i, j int) bool {
+		if counts[reviewers[i]] != counts[reviewers[j]] {
+			return counts[reviewers[i]] > counts[reviewers[j]]
+		}
+
+		return reviewers[i] < reviewers[j]
+	})
+
+	return reviewers
+}
@@ -0,0 +1,93 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testCodeOwners = `# Default owners
+*           @acme/core
+
+*.md        @docs-team
+/build/     @ops
+docs/*      @writer
+apps/**/test/ @qa
+internal/api @api-owner dev@example.com # email owners can't be requested
+
+[Frontend]
+web/ @web
+/vendor/
+`
+
+func TestCodeOwnersOwners(t *testing.T) {
+	owners := ParseCodeOwners(testCodeOwners)
+
+	tests := []struct {
+		path string
+		want []string
+	}{
+		{"main.go", []string{"@acme/core"}},
+		{"README.md", []string{"@docs-team"}},
+		{"pkg/guide.md", []string{"@docs-team"}},
+		{"build/ci/run.sh", []string{"@ops"}},
+		{"src/build/x.go", []string{"@acme/core"}},
+		{"docs/intro.txt", []string{"@writer"}},
+		{"docs/deep/intro.txt", []string{"@acme/core"}},
+		{"apps/mobile/test/a_test.go", []string{"@qa"}},
+		{"apps/test/a_test.go", []string{"@qa"}},
+		{"internal/api/handler.go", []string{"@api-owner", "dev@example.com"}},
+		{"web/index.html", []string{"@web"}},
+		{"vendor/lib.go", nil},
+	}
+
+	for _, tt := range tests {
+		if got := owners.Owners(tt.path); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Owners(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestCodeOwnersSuggestReviewers(t *testing.T) {
+	owners := ParseCodeOwners(testCodeOwners)
+
+	got := owners.SuggestReviewers([]string{"README.md", "CHANGELOG.md", "main.go", "internal/api/handler.go", "vendor/lib.go"})
+	want := []string{"docs-team", "acme/core", "api-owner"}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SuggestReviewers() = %v, want %v", got, want)
+	}
+
+	var none *CodeOwners
+	if got := none.SuggestReviewers([]string{"main.go"}); len(got) != 0 {
+		t.Errorf("SuggestReviewers() without CODEOWNERS = %v", got)
+	}
+}
+
+func TestLoadCodeOwners(t *testing.T) {
+	dir := t.TempDir()
+
+	owners, err := LoadCodeOwners(dir)
+	if err != nil || owners != nil {
+		t.Fatalf("LoadCodeOwners() without a file = %v, %v", owners, err)
+	}
+
+	if err := os.MkdirAll(filepath.Join(dir, ".github"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, ".github", "CODEOWNERS"), []byte("* @alice\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	owners, err = LoadCodeOwners(dir)
+	if err != nil {
+		t.Fatalf("LoadCodeOwners() error = %v", err)
+	}
+
+	if got := strings.Join(owners.Owners("x/y.go"), ","); got != "@alice" {
+		t.Errorf("Owners() = %q, want @alice", got)
+	}
+}
@@ -2,8 +2,16 @@ package git
 
 import (
 	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
+
+	"github.com/kaeawc/auto-worktree/internal/limits"
+	"github.com/kaeawc/auto-worktree/internal/redact"
 )
 
 // Configuration key constants
@@ -11,14 +19,46 @@ const (
 	// Issue provider configuration
 	ConfigIssueProvider = "auto-worktree.issue-provider"
 
+	// Code host configuration (where pull/merge requests live, if different from the issue provider)
+	ConfigCodeHost = "auto-worktree.code-host"
+
 	// AI tool configuration
 	ConfigAITool          = "auto-worktree.ai-tool"
 	ConfigIssueAutoselect = "auto-worktree.issue-autoselect"
 	ConfigPRAutoselect    = "auto-worktree.pr-autoselect"
+	ConfigAIBranchNames   = "auto-worktree.ai-branch-names"
+
+	// Minutes an AI ranking of issues or PRs is reused before asking again, and
+	// how many times an hour the menus may ask
+	ConfigAIRankCacheTTL = "auto-worktree.ai-rank-cache-ttl"
+	ConfigAIRankMaxCalls = "auto-worktree.ai-rank-max-calls"
+
+	// Prompt template files (absolute or relative to the repository root) for
+	// the AI ranking of issues and PRs, replacing the built-in criteria
+	ConfigIssueRankingTemplate = "auto-worktree.issue-ranking-template"
+	ConfigPRRankingTemplate    = "auto-worktree.pr-ranking-template"
+
+	// Issue workflow configuration
+	ConfigIssueSelfAssign     = "auto-worktree.issue-self-assign"
+	ConfigIssueFilterLabels   = "auto-worktree.issue-filter-labels"
+	ConfigIssueFilterAssignee = "auto-worktree.issue-filter-assignee"
+	ConfigIssueListLimit      = "auto-worktree.issue-list-limit"
+	// Milestone, sprint or cycle the selector lists ("current" for the active one), and its order
+	ConfigIssueFilterMilestone = "auto-worktree.issue-filter-milestone"
+	ConfigIssueSort            = "auto-worktree.issue-sort"
+
+	// Pull requests opened by describe --pr --apply: reviewers and labels to add,
+	// whether to open drafts, and whether to also ask the changed files' CODEOWNERS
+	ConfigPRReviewers  = "auto-worktree.pr-reviewers"
+	ConfigPRLabels     = "auto-worktree.pr-labels"
+	ConfigPRDraft      = "auto-worktree.pr-draft"
+	ConfigPRCodeOwners = "auto-worktree.pr-codeowners"
 
 	// JIRA provider configuration
 	ConfigJiraServer  = "auto-worktree.jira-server"
 	ConfigJiraProject = "auto-worktree.jira-project"
+	// Login for JIRA Cloud API tokens; leave unset for Server/Data Center personal access tokens
+	ConfigJiraEmail = "auto-worktree.jira-email"
 
 	// GitLab provider configuration
 	ConfigGitLabServer  = "auto-worktree.gitlab-server"
@@ -26,12 +66,102 @@ const (
 
 	// Linear provider configuration
 	ConfigLinearTeam = "auto-worktree.linear-team"
+	// Project and cycle ("current", a number or a name) narrow issues listed through the API
+	ConfigLinearProject = "auto-worktree.linear-project"
+	ConfigLinearCycle   = "auto-worktree.linear-cycle"
+
+	// Worktree location and cleanup
+	ConfigWorktreeBase  = "auto-worktree.worktree-base"
+	ConfigCleanupPolicy = "auto-worktree.cleanup-policy"
+	// Branch patterns that must not get a worktree or be cleaned up
+	ConfigProtectedBranches = "auto-worktree.protected-branches"
+	// Branch new branches start from, by prefix, e.g. "develop,hotfix/=main"
+	ConfigBaseBranch = "auto-worktree.base-branch"
+
+	// Format of generated branch names
+	ConfigBranchNamePrefix     = "auto-worktree.branch-name-prefix"
+	ConfigBranchNameWords      = "auto-worktree.branch-name-words"
+	ConfigBranchNameSeparator  = "auto-worktree.branch-name-separator"
+	ConfigBranchNameDictionary = "auto-worktree.branch-name-dictionary"
+
+	// Branch prefixes for issues by label or type, e.g. "bug=fix/,enhancement=feat/"
+	ConfigIssueBranchPrefixes = "auto-worktree.issue-branch-prefixes"
+
+	// Submodules in new worktrees
+	ConfigSubmoduleInit    = "auto-worktree.submodule-init"
+	ConfigSubmoduleShallow = "auto-worktree.submodule-shallow"
+
+	// Git LFS objects in new worktrees
+	ConfigLFSPull = "auto-worktree.lfs-pull"
+
+	// Sparse-checkout of the package when a worktree is created with --scope
+	ConfigScopeSparseCheckout = "auto-worktree.scope-sparse-checkout"
+
+	// Container sandbox for AI sessions
+	ConfigSandbox      = "auto-worktree.sandbox"
+	ConfigSandboxImage = "auto-worktree.sandbox-image"
+
+	// Resource limits for each AI session (container or systemd scope)
+	ConfigSessionCPUs   = "auto-worktree.session-cpus"
+	ConfigSessionMemory = "auto-worktree.session-memory"
+
+	// Hours a session may sit idle before the monitor saves its transcript and stops it
+	ConfigSessionIdleTimeout = "auto-worktree.session-idle-timeout"
+
+	// Minutes between checkpoints of a worktree while its AI session runs
+	ConfigCheckpointInterval = "auto-worktree.checkpoint-interval"
+
+	// Shell command 'auto-worktree test' runs in each worktree, e.g. "go test ./..."
+	ConfigTestCommand = "auto-worktree.test-command"
 
 	// Hook configuration
 	ConfigRunHooks        = "auto-worktree.run-hooks"
 	ConfigFailOnHookError = "auto-worktree.fail-on-hook-error"
 	ConfigCustomHooks     = "auto-worktree.custom-hooks"
 
+	// Local-only usage analytics (opt-in)
+	ConfigAnalytics = "auto-worktree.analytics"
+
+	// Diagnostics
+	ConfigLogFile = "auto-worktree.log-file"
+
+	// Extra regular expressions for secrets to scrub from logs, transcripts, hook output and AI context
+	ConfigRedactPatterns = "auto-worktree.redact-patterns"
+
+	// What is sent to AI tools in one-shot prompts: a confirmation first, and how much diff
+	ConfigAIConfirmContext = "auto-worktree.ai-confirm-context"
+	ConfigAIDiffLimit      = "auto-worktree.ai-diff-limit"
+
+	// Timeouts for external commands, e.g. "gh=30s,git=2m", and how many run at once
+	ConfigCommandTimeouts = "auto-worktree.command-timeouts"
+	ConfigMaxParallel     = "auto-worktree.max-parallel"
+
+	// How GitHub and GitLab are reached: their CLI or their API with a token
+	ConfigGitHubTransport = "auto-worktree.github-transport"
+	ConfigGitLabTransport = "auto-worktree.gitlab-transport"
+
+	// GitHub project board (Projects v2) to list issues from: its number (or
+	// owner/number), the single-select field holding its columns, the column to
+	// list and the column an issue moves to when its worktree is created
+	ConfigGitHubProject           = "auto-worktree.github-project"
+	ConfigGitHubProjectField      = "auto-worktree.github-project-field"
+	ConfigGitHubProjectReady      = "auto-worktree.github-project-ready"
+	ConfigGitHubProjectInProgress = "auto-worktree.github-project-in-progress"
+
+	// Passive "new version available" notice in the menu
+	ConfigUpdateCheck = "auto-worktree.update-check"
+
+	// UI appearance
+	ConfigTheme       = "auto-worktree.theme"
+	ConfigThemeColors = "auto-worktree.theme-colors"
+	ConfigKeybindings = "auto-worktree.keybindings"
+	// Width of the PATH column of 'list'; longer paths are shortened in the middle
+	ConfigListPathWidth = "auto-worktree.list-path-width"
+	// Default order and columns of 'list'
+	ConfigListSort    = "auto-worktree.list-sort"
+	ConfigListColumns = "auto-worktree.list-columns"
+	ConfigListCompact = "auto-worktree.list-compact"
+
 	// Issue template configuration
 	ConfigIssueTemplatesDir      = "auto-worktree.issue-templates-dir"
 	ConfigIssueTemplatesDisabled = "auto-worktree.issue-templates-disabled"
@@ -56,12 +186,54 @@ const (
 	ConfigTmuxPreKillHook    = "auto-worktree.tmux-pre-kill-hook"
 )
 
+// Cleanup policies for merged worktrees found at startup
+const (
+	// CleanupPolicyPrompt asks before removing each merged worktree (default)
+	CleanupPolicyPrompt = "prompt"
+	// CleanupPolicyAuto removes merged worktrees without unpushed commits automatically
+	CleanupPolicyAuto = "auto"
+	// CleanupPolicyOff skips startup cleanup; run 'auto-worktree cleanup' instead
+	CleanupPolicyOff = "off"
+)
+
+// Container runtimes for sandboxed AI sessions
+const (
+	// SandboxOff runs sessions directly on the host (default)
+	SandboxOff = "off"
+	// SandboxDocker runs sessions in a Docker container
+	SandboxDocker = "docker"
+	// SandboxPodman runs sessions in a Podman container
+	SandboxPodman = "podman"
+)
+
 // Valid values for specific configuration keys
 var (
-	ValidIssueProviders = []string{"github", "gitlab", "jira", "linear"}
-	ValidAITools        = []string{"claude", "codex", "gemini", "jules", "skip"}
+	ValidIssueProviders   = []string{"github", "gitlab", "jira", "linear"}
+	ValidCodeHosts        = []string{"github", "gitlab"}
+	ValidAITools          = []string{"claude", "codex", "gemini", "jules", "skip"}
+	ValidThemes           = []string{"default", "dark", "light", "high-contrast", "mono"}
+	ValidCleanupPolicies  = []string{CleanupPolicyPrompt, CleanupPolicyAuto, CleanupPolicyOff}
+	ValidSandboxes        = []string{SandboxOff, SandboxDocker, SandboxPodman}
+	ValidGitHubTransports = []string{"cli", "api"}
+	ValidGitLabTransports = []string{"cli", "api"}
+	ValidIssueSorts       = []string{"recent", "priority"}
+	ValidListSorts        = []string{"age", "branch", "status", "size"}
+	ValidListColumns      = []string{"path", "branch", "age", "status", "session", "test", "unpushed", "size"}
 )
 
+// IssueProviderPluginPrefix starts an issue-provider value naming a provider
+// plugin, e.g. plugin:acme for ~/.auto-worktree/plugins/acme.json
+const IssueProviderPluginPrefix = "plugin:"
+
+// PluginProviderName returns the plugin an issue-provider value names, if it names one
+func PluginProviderName(issueProvider string) (string, bool) {
+	name, ok := strings.CutPrefix(issueProvider, IssueProviderPluginPrefix)
+	return name, ok && name != ""
+}
+
+// memoryLimitPattern matches a memory size in bytes with an optional k, m, g or t suffix
+var memoryLimitPattern = regexp.MustCompile(`^[0-9]+[kmgtKMGT]?$`)
+
 // ConfigScope represents the scope of a git config operation
 type ConfigScope string
 
@@ -259,7 +431,21 @@ func (c *Config) Validate(key, value string) error {
 				return nil
 			}
 		}
-		return fmt.Errorf("invalid issue provider: %s (must be one of: %s)", value, strings.Join(ValidIssueProviders, ", "))
+
+		if _, ok := PluginProviderName(value); ok {
+			return nil
+		}
+
+		return fmt.Errorf("invalid issue provider: %s (must be one of: %s, or %s<name> for a provider plugin)",
+			value, strings.Join(ValidIssueProviders, ", "), IssueProviderPluginPrefix)
+
+	case ConfigCodeHost:
+		for _, valid := range ValidCodeHosts {
+			if value == valid {
+				return nil
+			}
+		}
+		return fmt.Errorf("invalid code host: %s (must be one of: %s)", value, strings.Join(ValidCodeHosts, ", "))
 
 	case ConfigAITool:
 		for _, valid := range ValidAITools {
@@ -269,15 +455,191 @@ func (c *Config) Validate(key, value string) error {
 		}
 		return fmt.Errorf("invalid AI tool: %s (must be one of: %s)", value, strings.Join(ValidAITools, ", "))
 
+	case ConfigTheme:
+		for _, valid := range ValidThemes {
+			if value == valid {
+				return nil
+			}
+		}
+		return fmt.Errorf("invalid theme: %s (must be one of: %s)", value, strings.Join(ValidThemes, ", "))
+
+	case ConfigCleanupPolicy:
+		for _, valid := range ValidCleanupPolicies {
+			if value == valid {
+				return nil
+			}
+		}
+		return fmt.Errorf("invalid cleanup policy: %s (must be one of: %s)", value, strings.Join(ValidCleanupPolicies, ", "))
+
+	case ConfigSandbox:
+		for _, valid := range ValidSandboxes {
+			if value == valid {
+				return nil
+			}
+		}
+		return fmt.Errorf("invalid sandbox: %s (must be one of: %s)", value, strings.Join(ValidSandboxes, ", "))
+
 	case ConfigIssueAutoselect, ConfigPRAutoselect, ConfigRunHooks, ConfigFailOnHookError,
 		ConfigIssueTemplatesDisabled, ConfigIssueTemplatesNoPrompt, ConfigIssueTemplatesDetected,
-		ConfigAutoInstall:
+		ConfigAutoInstall, ConfigIssueSelfAssign, ConfigAIBranchNames, ConfigAnalytics, ConfigLogFile,
+		ConfigUpdateCheck, ConfigSubmoduleInit, ConfigSubmoduleShallow, ConfigLFSPull,
+		ConfigScopeSparseCheckout, ConfigAIConfirmContext, ConfigPRDraft, ConfigPRCodeOwners,
+		ConfigListCompact:
 		// These should be boolean values
 		if value != "true" && value != "false" {
 			return fmt.Errorf("invalid boolean value: %s (must be 'true' or 'false')", value)
 		}
 		return nil
 
+	case ConfigIssueListLimit:
+		if n, err := strconv.Atoi(value); err != nil || n <= 0 {
+			return fmt.Errorf("invalid issue list limit: %s (must be a positive number)", value)
+		}
+		return nil
+
+	case ConfigIssueSort:
+		for _, valid := range ValidIssueSorts {
+			if value == valid {
+				return nil
+			}
+		}
+		return fmt.Errorf("invalid issue sort: %s (must be one of: %s)", value, strings.Join(ValidIssueSorts, ", "))
+
+	case ConfigSessionCPUs:
+		if n, err := strconv.ParseFloat(value, 64); err != nil || n <= 0 {
+			return fmt.Errorf("invalid CPU limit: %s (must be a positive number of CPUs, e.g. 1.5)", value)
+		}
+		return nil
+
+	case ConfigProtectedBranches:
+		for _, pattern := range strings.Fields(strings.ReplaceAll(value, ",", " ")) {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("invalid branch pattern: %s", pattern)
+			}
+		}
+		return nil
+
+	case ConfigBaseBranch:
+		if _, err := ParseBaseBranches(value); err != nil {
+			return err
+		}
+		return nil
+
+	case ConfigBranchNameWords:
+		if n, err := strconv.Atoi(value); err != nil || n < 1 || n > MaxBranchNameWords {
+			return fmt.Errorf("invalid word count: %s (must be 1 to %d)", value, MaxBranchNameWords)
+		}
+		return nil
+
+	case ConfigBranchNameSeparator:
+		if value == "" || strings.ContainsAny(value, " ~^:?*[\\") {
+			return fmt.Errorf("invalid separator: %q (must be non-empty and valid in a branch name)", value)
+		}
+		return nil
+
+	case ConfigIssueBranchPrefixes:
+		if _, err := ParseIssueBranchPrefixes(value); err != nil {
+			return err
+		}
+		return nil
+
+	case ConfigCommandTimeouts:
+		if _, err := limits.ParseTimeouts(value); err != nil {
+			return err
+		}
+		return nil
+
+	case ConfigWorktreeBase:
+		return validateWorktreeBase(value)
+
+	case ConfigListPathWidth:
+		if n, err := strconv.Atoi(value); err != nil || n < MinListPathWidth {
+			return fmt.Errorf("invalid list path width: %s (must be a number of at least %d)", value, MinListPathWidth)
+		}
+		return nil
+
+	case ConfigListSort:
+		for _, valid := range ValidListSorts {
+			if value == valid {
+				return nil
+			}
+		}
+		return fmt.Errorf("invalid list sort: %s (must be one of: %s)", value, strings.Join(ValidListSorts, ", "))
+
+	case ConfigListColumns:
+		_, err := ParseListColumns(value)
+		return err
+
+	case ConfigAIDiffLimit:
+		if n, err := strconv.Atoi(value); err != nil || n < 1 {
+			return fmt.Errorf("invalid AI diff limit: %s (must be a positive number of bytes)", value)
+		}
+		return nil
+
+	case ConfigRedactPatterns:
+		if _, err := redact.ParsePatterns(value); err != nil {
+			return err
+		}
+		return nil
+
+	case ConfigMaxParallel:
+		if n, err := strconv.Atoi(value); err != nil || n < 1 {
+			return fmt.Errorf("invalid max parallel: %s (must be a positive number)", value)
+		}
+		return nil
+
+	case ConfigGitHubTransport:
+		for _, valid := range ValidGitHubTransports {
+			if value == valid {
+				return nil
+			}
+		}
+		return fmt.Errorf("invalid GitHub transport: %s (must be one of: %s)", value, strings.Join(ValidGitHubTransports, ", "))
+
+	case ConfigGitHubProject:
+		if _, _, err := ParseGitHubProject(value); err != nil {
+			return err
+		}
+		return nil
+
+	case ConfigGitLabTransport:
+		for _, valid := range ValidGitLabTransports {
+			if value == valid {
+				return nil
+			}
+		}
+		return fmt.Errorf("invalid GitLab transport: %s (must be one of: %s)", value, strings.Join(ValidGitLabTransports, ", "))
+
+	case ConfigSessionIdleTimeout:
+		if n, err := strconv.ParseFloat(value, 64); err != nil || n <= 0 {
+			return fmt.Errorf("invalid idle timeout: %s (must be a positive number of hours)", value)
+		}
+		return nil
+
+	case ConfigAIRankCacheTTL:
+		if n, err := strconv.ParseFloat(value, 64); err != nil || n < 0 {
+			return fmt.Errorf("invalid AI ranking cache TTL: %s (must be a number of minutes, 0 to always ask)", value)
+		}
+		return nil
+
+	case ConfigAIRankMaxCalls:
+		if n, err := strconv.Atoi(value); err != nil || n < 1 {
+			return fmt.Errorf("invalid AI ranking call limit: %s (must be a positive number of calls per hour)", value)
+		}
+		return nil
+
+	case ConfigCheckpointInterval:
+		if n, err := strconv.ParseFloat(value, 64); err != nil || n <= 0 {
+			return fmt.Errorf("invalid checkpoint interval: %s (must be a positive number of minutes)", value)
+		}
+		return nil
+
+	case ConfigSessionMemory:
+		if !memoryLimitPattern.MatchString(value) {
+			return fmt.Errorf("invalid memory limit: %s (must be a size such as 512m or 4g)", value)
+		}
+		return nil
+
 	// No specific validation for other keys
 	default:
 		return nil
@@ -292,11 +654,22 @@ func (c *Config) SetValidated(key, value string, scope ConfigScope) error {
 	return c.Set(key, value, scope)
 }
 
+// HasSettings reports whether any auto-worktree setting is configured at any scope
+func (c *Config) HasSettings() bool {
+	output, err := c.executor.ExecuteInDir(c.RootPath, "config", "--get-regexp", `^auto-worktree\.`)
+	return err == nil && strings.TrimSpace(output) != ""
+}
+
 // GetIssueProvider returns the configured issue provider
 func (c *Config) GetIssueProvider() string {
 	return c.GetWithDefault(ConfigIssueProvider, "", ConfigScopeAuto)
 }
 
+// GetCodeHost returns the configured code host for pull/merge requests (default: none, follow the issue provider)
+func (c *Config) GetCodeHost() string {
+	return c.GetWithDefault(ConfigCodeHost, "", ConfigScopeAuto)
+}
+
 // GetAITool returns the configured AI tool
 func (c *Config) GetAITool() string {
 	return c.GetWithDefault(ConfigAITool, "", ConfigScopeAuto)
@@ -312,6 +685,443 @@ func (c *Config) GetPRAutoselect() bool {
 	return c.GetBoolWithDefault(ConfigPRAutoselect, false, ConfigScopeAuto)
 }
 
+// Defaults for the AI ranking of issues and PRs in the selection menus
+const (
+	DefaultAIRankCacheTTL = 30 * time.Minute
+	DefaultAIRankMaxCalls = 10
+)
+
+// GetAIRankCacheTTL returns how long an AI ranking of issues or PRs is reused
+// (default: DefaultAIRankCacheTTL); 0 asks the AI every time
+func (c *Config) GetAIRankCacheTTL() time.Duration {
+	minutes, err := strconv.ParseFloat(c.GetWithDefault(ConfigAIRankCacheTTL, "", ConfigScopeAuto), 64)
+	if err != nil || minutes < 0 {
+		return DefaultAIRankCacheTTL
+	}
+
+	return time.Duration(minutes * float64(time.Minute))
+}
+
+// GetAIRankMaxCalls returns how many times an hour issues or PRs may be ranked
+// by the AI tool (default: DefaultAIRankMaxCalls)
+func (c *Config) GetAIRankMaxCalls() int {
+	if n := c.GetIntWithDefault(ConfigAIRankMaxCalls, DefaultAIRankMaxCalls, ConfigScopeAuto); n > 0 {
+		return n
+	}
+
+	return DefaultAIRankMaxCalls
+}
+
+// GetAIBranchNames returns whether to suggest branch names with the AI tool when none is given (default: false)
+func (c *Config) GetAIBranchNames() bool {
+	return c.GetBoolWithDefault(ConfigAIBranchNames, false, ConfigScopeAuto)
+}
+
+// GetAnalyticsEnabled returns whether local-only usage analytics are recorded (default: false)
+func (c *Config) GetAnalyticsEnabled() bool {
+	return c.GetBoolWithDefault(ConfigAnalytics, false, ConfigScopeAuto)
+}
+
+// GetLogFileEnabled returns whether debug logs are also written to the log file (default: false)
+func (c *Config) GetLogFileEnabled() bool {
+	return c.GetBoolWithDefault(ConfigLogFile, false, ConfigScopeAuto)
+}
+
+// GetUpdateCheckEnabled returns whether the menu checks GitHub for new releases (default: true)
+func (c *Config) GetUpdateCheckEnabled() bool {
+	return c.GetBoolWithDefault(ConfigUpdateCheck, true, ConfigScopeAuto)
+}
+
+// GetWorktreeBase returns the configured parent directory for worktrees, or "" for ~/worktrees
+func (c *Config) GetWorktreeBase() string {
+	return c.GetWithDefault(ConfigWorktreeBase, "", ConfigScopeAuto)
+}
+
+// GetCleanupPolicy returns how merged worktrees are handled at startup (default: prompt)
+func (c *Config) GetCleanupPolicy() string {
+	return c.GetWithDefault(ConfigCleanupPolicy, CleanupPolicyPrompt, ConfigScopeAuto)
+}
+
+// DefaultProtectedBranches are protected when auto-worktree.protected-branches is not set
+const DefaultProtectedBranches = "main,master,release/*"
+
+// GetProtectedBranches returns the branch patterns (e.g. release/*) that worktrees
+// may not be created on or cleaned up for (default: main, master, release/*)
+func (c *Config) GetProtectedBranches() []string {
+	value := c.GetWithDefault(ConfigProtectedBranches, DefaultProtectedBranches, ConfigScopeAuto)
+
+	return strings.Fields(strings.ReplaceAll(value, ",", " "))
+}
+
+// GetBaseBranches returns the branches new branches start from; an empty
+// BaseBranches starts them from the default branch. Invalid entries are ignored.
+func (c *Config) GetBaseBranches() BaseBranches {
+	bases, _ := ParseBaseBranches(c.GetWithDefault(ConfigBaseBranch, "", ConfigScopeAuto)) //nolint:errcheck // keeps the valid entries
+
+	return bases
+}
+
+// MaxBranchNameWords caps auto-worktree.branch-name-words
+const MaxBranchNameWords = 6
+
+// GetBranchNameFormat returns how random branch names are generated (default: work/color-adjective-animal)
+func (c *Config) GetBranchNameFormat() BranchNameFormat {
+	format := DefaultBranchNameFormat()
+	format.Prefix = c.GetWithDefault(ConfigBranchNamePrefix, format.Prefix, ConfigScopeAuto)
+	format.Words = min(c.GetIntWithDefault(ConfigBranchNameWords, format.Words, ConfigScopeAuto), MaxBranchNameWords)
+	format.Separator = c.GetWithDefault(ConfigBranchNameSeparator, format.Separator, ConfigScopeAuto)
+	format.Dictionary = c.GetWithDefault(ConfigBranchNameDictionary, "", ConfigScopeAuto)
+
+	format.Prefix = c.ExpandBranchPrefix(format.Prefix)
+
+	return format
+}
+
+// ExpandBranchPrefix replaces {user} in a branch name prefix with the local part of the user's git email
+func (c *Config) ExpandBranchPrefix(prefix string) string {
+	if !strings.Contains(prefix, "{user}") {
+		return prefix
+	}
+
+	return strings.ReplaceAll(prefix, "{user}", c.userName())
+}
+
+// GetIssueBranchPrefixes returns the label or issue type to branch prefix rules,
+// in the order they are configured. Invalid entries are ignored.
+func (c *Config) GetIssueBranchPrefixes() []IssueBranchPrefix {
+	rules, _ := ParseIssueBranchPrefixes(c.GetWithDefault(ConfigIssueBranchPrefixes, "", ConfigScopeAuto)) //nolint:errcheck // keeps the valid rules
+
+	return rules
+}
+
+// userName returns the local part of git's user.email, or $USER when it is not set
+func (c *Config) userName() string {
+	if email, err := c.executor.ExecuteInDir(c.RootPath, "config", "--get", "user.email"); err == nil {
+		if name, _, _ := strings.Cut(strings.TrimSpace(email), "@"); name != "" {
+			return name
+		}
+	}
+
+	return os.Getenv("USER")
+}
+
+// GetSandbox returns the container runtime AI sessions run in, or "off" (default: off)
+func (c *Config) GetSandbox() string {
+	return c.GetWithDefault(ConfigSandbox, SandboxOff, ConfigScopeAuto)
+}
+
+// GetSandboxImage returns the container image for sandboxed sessions (default: none)
+func (c *Config) GetSandboxImage() string {
+	return c.GetWithDefault(ConfigSandboxImage, "", ConfigScopeAuto)
+}
+
+// GetSessionCPUs returns the CPU limit for each AI session, e.g. "2" (default: none)
+func (c *Config) GetSessionCPUs() string {
+	return c.GetWithDefault(ConfigSessionCPUs, "", ConfigScopeAuto)
+}
+
+// GetSessionMemory returns the memory limit for each AI session, e.g. "4g" (default: none)
+func (c *Config) GetSessionMemory() string {
+	return c.GetWithDefault(ConfigSessionMemory, "", ConfigScopeAuto)
+}
+
+// GetSessionIdleTimeout returns how long a session may be idle before it is
+// stopped, or 0 when idle sessions are left running (the default)
+func (c *Config) GetSessionIdleTimeout() time.Duration {
+	hours, err := strconv.ParseFloat(c.GetWithDefault(ConfigSessionIdleTimeout, "", ConfigScopeAuto), 64)
+	if err != nil || hours <= 0 {
+		return 0
+	}
+
+	return time.Duration(hours * float64(time.Hour))
+}
+
+// GetCheckpointInterval returns how often to checkpoint a worktree while its
+// AI session runs, or 0 when sessions aren't checkpointed (the default)
+func (c *Config) GetCheckpointInterval() time.Duration {
+	minutes, err := strconv.ParseFloat(c.GetWithDefault(ConfigCheckpointInterval, "", ConfigScopeAuto), 64)
+	if err != nil || minutes <= 0 {
+		return 0
+	}
+
+	return time.Duration(minutes * float64(time.Minute))
+}
+
+// GetCommandTimeouts returns the configured per-tool command timeouts; tools
+// not listed (or an invalid setting) keep the defaults in the limits package
+func (c *Config) GetCommandTimeouts() map[string]time.Duration {
+	timeouts, err := limits.ParseTimeouts(c.GetWithDefault(ConfigCommandTimeouts, "", ConfigScopeAuto))
+	if err != nil {
+		return nil
+	}
+
+	return timeouts
+}
+
+// GetMaxParallel returns how many external commands may run at once (default: limits.DefaultParallelism)
+func (c *Config) GetMaxParallel() int {
+	n, err := strconv.Atoi(c.GetWithDefault(ConfigMaxParallel, "", ConfigScopeAuto))
+	if err != nil || n < 1 {
+		return limits.DefaultParallelism
+	}
+
+	return n
+}
+
+// GetAIConfirmContext returns whether to ask before sending diffs to the AI tool (default: false)
+func (c *Config) GetAIConfirmContext() bool {
+	return c.GetBoolWithDefault(ConfigAIConfirmContext, false, ConfigScopeAuto)
+}
+
+// GetAIDiffLimit returns how many bytes of diff to send to the AI tool, or
+// defaultLimit when auto-worktree.ai-diff-limit is not set
+func (c *Config) GetAIDiffLimit(defaultLimit int) int {
+	if n := c.GetIntWithDefault(ConfigAIDiffLimit, defaultLimit, ConfigScopeAuto); n > 0 {
+		return n
+	}
+
+	return defaultLimit
+}
+
+// GetRedactPatterns returns the configured redaction patterns added to the
+// built-in ones; invalid patterns are ignored
+func (c *Config) GetRedactPatterns() []*regexp.Regexp {
+	patterns, err := redact.ParsePatterns(c.GetWithDefault(ConfigRedactPatterns, "", ConfigScopeAuto))
+	if err != nil {
+		return nil
+	}
+
+	return patterns
+}
+
+// GetGitHubTransport returns how GitHub is reached: "cli" (the default) or "api"
+func (c *Config) GetGitHubTransport() string {
+	return c.GetWithDefault(ConfigGitHubTransport, "cli", ConfigScopeAuto)
+}
+
+// GetGitHubProject returns the GitHub project board issues are listed from, e.g. "5" or "acme/5"
+func (c *Config) GetGitHubProject() string {
+	return c.GetWithDefault(ConfigGitHubProject, "", ConfigScopeAuto)
+}
+
+// GetGitHubProjectField returns the project field whose options are the board's columns (default: Status)
+func (c *Config) GetGitHubProjectField() string {
+	return c.GetWithDefault(ConfigGitHubProjectField, "Status", ConfigScopeAuto)
+}
+
+// GetGitHubProjectReady returns the column issues are listed from (default: every column)
+func (c *Config) GetGitHubProjectReady() string {
+	return c.GetWithDefault(ConfigGitHubProjectReady, "", ConfigScopeAuto)
+}
+
+// GetGitHubProjectInProgress returns the column an issue moves to when its
+// worktree is created (default: In Progress), or "" for "none"
+func (c *Config) GetGitHubProjectInProgress() string {
+	column := c.GetWithDefault(ConfigGitHubProjectInProgress, "In Progress", ConfigScopeAuto)
+	if strings.EqualFold(column, "none") {
+		return ""
+	}
+
+	return column
+}
+
+// ParseGitHubProject parses a project board reference: a project number, owned
+// by the repository's owner, or owner/number for another user's or org's project
+func ParseGitHubProject(value string) (owner string, number int, err error) {
+	owner, numberText, found := strings.Cut(value, "/")
+	if !found {
+		owner, numberText = "", value
+	}
+
+	number, err = strconv.Atoi(numberText)
+	if err != nil || number < 1 || (found && owner == "") {
+		return "", 0, fmt.Errorf("invalid GitHub project: %s (must be a project number or owner/number)", value)
+	}
+
+	return owner, number, nil
+}
+
+// GetGitLabTransport returns how GitLab is reached: "cli" (the default) or "api"
+func (c *Config) GetGitLabTransport() string {
+	return c.GetWithDefault(ConfigGitLabTransport, "cli", ConfigScopeAuto)
+}
+
+// GetSubmoduleInit returns whether submodules are initialized in new worktrees (default: true)
+func (c *Config) GetSubmoduleInit() bool {
+	return c.GetBoolWithDefault(ConfigSubmoduleInit, true, ConfigScopeAuto)
+}
+
+// GetSubmoduleShallow returns whether submodules are cloned with --depth 1 (default: false)
+func (c *Config) GetSubmoduleShallow() bool {
+	return c.GetBoolWithDefault(ConfigSubmoduleShallow, false, ConfigScopeAuto)
+}
+
+// GetLFSPull returns whether Git LFS objects are downloaded in new worktrees (default: true)
+func (c *Config) GetLFSPull() bool {
+	return c.GetBoolWithDefault(ConfigLFSPull, true, ConfigScopeAuto)
+}
+
+// GetScopeSparseCheckout returns whether scoped worktrees use sparse-checkout (default: false)
+func (c *Config) GetScopeSparseCheckout() bool {
+	return c.GetBoolWithDefault(ConfigScopeSparseCheckout, false, ConfigScopeAuto)
+}
+
+// GetTheme returns the configured UI theme (default: "default")
+func (c *Config) GetTheme() string {
+	return c.GetWithDefault(ConfigTheme, "default", ConfigScopeAuto)
+}
+
+// GetThemeColors returns per-element color overrides, e.g. "success=#00aa00,subtle=245" (default: none)
+func (c *Config) GetThemeColors() string {
+	return c.GetWithDefault(ConfigThemeColors, "", ConfigScopeAuto)
+}
+
+// GetKeybindings returns key remappings for lists and menus, e.g. "up=k|up,cancel=esc" (default: none)
+func (c *Config) GetKeybindings() string {
+	return c.GetWithDefault(ConfigKeybindings, "", ConfigScopeAuto)
+}
+
+// Widths of the PATH column of list
+const (
+	DefaultListPathWidth = 45
+	MinListPathWidth     = 12
+)
+
+// GetListPathWidth returns the width of the PATH column of list (default: 45)
+func (c *Config) GetListPathWidth() int {
+	if n := c.GetIntWithDefault(ConfigListPathWidth, DefaultListPathWidth, ConfigScopeAuto); n >= MinListPathWidth {
+		return n
+	}
+
+	return DefaultListPathWidth
+}
+
+// DefaultListColumns are the columns list shows unless configured otherwise
+var DefaultListColumns = []string{"path", "branch", "age", "status", "session", "test", "unpushed"}
+
+// GetListSort returns how list orders worktrees: age, branch, status, size, or
+// git's order when unset
+func (c *Config) GetListSort() string {
+	return c.GetWithDefault(ConfigListSort, "", ConfigScopeAuto)
+}
+
+// GetListColumns returns the columns list shows (default: every column but size)
+func (c *Config) GetListColumns() []string {
+	columns, err := ParseListColumns(c.GetWithDefault(ConfigListColumns, "", ConfigScopeAuto))
+	if err != nil || len(columns) == 0 {
+		return DefaultListColumns
+	}
+
+	return columns
+}
+
+// GetListCompact returns whether list prints one unpadded line per worktree
+// instead of a table (default: false)
+func (c *Config) GetListCompact() bool {
+	return c.GetBoolWithDefault(ConfigListCompact, false, ConfigScopeAuto)
+}
+
+// ParseListColumns parses a comma-separated list of list columns
+func ParseListColumns(value string) ([]string, error) {
+	var columns []string
+
+	for _, column := range strings.Split(value, ",") {
+		if column = strings.TrimSpace(column); column == "" {
+			continue
+		}
+
+		if !slices.Contains(ValidListColumns, column) {
+			return nil, fmt.Errorf("invalid list column: %s (must be among: %s)", column, strings.Join(ValidListColumns, ", "))
+		}
+
+		columns = append(columns, column)
+	}
+
+	return columns, nil
+}
+
+// GetIssueSelfAssign returns whether to self-assign and comment on issues when starting work (default: false)
+func (c *Config) GetIssueSelfAssign() bool {
+	return c.GetBoolWithDefault(ConfigIssueSelfAssign, false, ConfigScopeAuto)
+}
+
+// GetIssueFilterLabels returns the labels used to filter the issue selector (default: none)
+func (c *Config) GetIssueFilterLabels() []string {
+	value := c.GetWithDefault(ConfigIssueFilterLabels, "", ConfigScopeAuto)
+
+	var labels []string
+
+	for _, label := range strings.Split(value, ",") {
+		if label = strings.TrimSpace(label); label != "" {
+			labels = append(labels, label)
+		}
+	}
+
+	return labels
+}
+
+// GetIssueFilterAssignee returns the assignee filter for the issue selector (default: none)
+func (c *Config) GetIssueFilterAssignee() string {
+	return c.GetWithDefault(ConfigIssueFilterAssignee, "", ConfigScopeAuto)
+}
+
+// GetIssueFilterMilestone returns the milestone, sprint or cycle filter for the
+// issue selector, or "current" for the active one (default: none)
+func (c *Config) GetIssueFilterMilestone() string {
+	return c.GetWithDefault(ConfigIssueFilterMilestone, "", ConfigScopeAuto)
+}
+
+// GetIssueSort returns how the issue selector orders issues: recent (default) or priority
+func (c *Config) GetIssueSort() string {
+	return c.GetWithDefault(ConfigIssueSort, "recent", ConfigScopeAuto)
+}
+
+// GetPRReviewers returns the users (or org/team) asked to review new pull requests (default: none)
+func (c *Config) GetPRReviewers() []string {
+	value := c.GetWithDefault(ConfigPRReviewers, "", ConfigScopeAuto)
+
+	reviewers := strings.Fields(strings.ReplaceAll(value, ",", " "))
+	for i, reviewer := range reviewers {
+		reviewers[i] = strings.TrimPrefix(reviewer, "@")
+	}
+
+	return reviewers
+}
+
+// GetPRLabels returns the comma-separated labels added to new pull requests (default: none)
+func (c *Config) GetPRLabels() []string {
+	var labels []string
+
+	for _, label := range strings.Split(c.GetWithDefault(ConfigPRLabels, "", ConfigScopeAuto), ",") {
+		if label = strings.TrimSpace(label); label != "" {
+			labels = append(labels, label)
+		}
+	}
+
+	return labels
+}
+
+// GetPRDraft returns whether new pull requests are opened as drafts (default: false)
+func (c *Config) GetPRDraft() bool {
+	return c.GetBoolWithDefault(ConfigPRDraft, false, ConfigScopeAuto)
+}
+
+// GetPRCodeOwners returns whether the CODEOWNERS of a pull request's changed
+// files are asked to review it (default: true)
+func (c *Config) GetPRCodeOwners() bool {
+	return c.GetBoolWithDefault(ConfigPRCodeOwners, true, ConfigScopeAuto)
+}
+
+// GetTestCommand returns the shell command 'auto-worktree test' runs (default: none)
+func (c *Config) GetTestCommand() string {
+	return strings.TrimSpace(c.GetWithDefault(ConfigTestCommand, "", ConfigScopeAuto))
+}
+
+// GetIssueListLimit returns how many issues the issue selector fetches per page (default: 20)
+func (c *Config) GetIssueListLimit() int {
+	return c.GetIntWithDefault(ConfigIssueListLimit, 20, ConfigScopeAuto)
+}
+
 // GetRunHooks returns whether git hooks should be run (default: true)
 func (c *Config) GetRunHooks() bool {
 	return c.GetBoolWithDefault(ConfigRunHooks, true, ConfigScopeAuto)
@@ -374,6 +1184,11 @@ func (c *Config) SetJiraProject(project string, scope ConfigScope) error {
 	return c.Set(ConfigJiraProject, project, scope)
 }
 
+// GetJiraEmail returns the login used with a JIRA Cloud API token
+func (c *Config) GetJiraEmail() string {
+	return c.GetWithDefault(ConfigJiraEmail, "", ConfigScopeAuto)
+}
+
 // UnsetAll removes all auto-worktree configuration
 func (c *Config) UnsetAll(scope ConfigScope) error {
 	keys := []string{
@@ -395,6 +1210,66 @@ func (c *Config) UnsetAll(scope ConfigScope) error {
 		ConfigIssueTemplatesDetected,
 		ConfigAutoInstall,
 		ConfigPackageManager,
+		ConfigIssueSelfAssign,
+		ConfigIssueFilterLabels,
+		ConfigIssueFilterAssignee,
+		ConfigIssueListLimit,
+		ConfigCodeHost,
+		ConfigAIBranchNames,
+		ConfigAnalytics,
+		ConfigTheme,
+		ConfigThemeColors,
+		ConfigKeybindings,
+		ConfigLogFile,
+		ConfigUpdateCheck,
+		ConfigWorktreeBase,
+		ConfigCleanupPolicy,
+		ConfigSubmoduleInit,
+		ConfigSubmoduleShallow,
+		ConfigLFSPull,
+		ConfigScopeSparseCheckout,
+		ConfigSandbox,
+		ConfigSandboxImage,
+		ConfigSessionCPUs,
+		ConfigSessionMemory,
+		ConfigSessionIdleTimeout,
+		ConfigProtectedBranches,
+		ConfigBranchNamePrefix,
+		ConfigBranchNameWords,
+		ConfigBranchNameSeparator,
+		ConfigBranchNameDictionary,
+		ConfigIssueBranchPrefixes,
+		ConfigCommandTimeouts,
+		ConfigMaxParallel,
+		ConfigGitHubTransport,
+		ConfigGitLabTransport,
+		ConfigJiraEmail,
+		ConfigLinearProject,
+		ConfigLinearCycle,
+		ConfigRedactPatterns,
+		ConfigAIConfirmContext,
+		ConfigAIDiffLimit,
+		ConfigGitHubProject,
+		ConfigGitHubProjectField,
+		ConfigGitHubProjectReady,
+		ConfigGitHubProjectInProgress,
+		ConfigIssueFilterMilestone,
+		ConfigIssueSort,
+		ConfigPRReviewers,
+		ConfigPRLabels,
+		ConfigPRDraft,
+		ConfigPRCodeOwners,
+		ConfigTestCommand,
+		ConfigCheckpointInterval,
+		ConfigListPathWidth,
+		ConfigListSort,
+		ConfigListColumns,
+		ConfigListCompact,
+		ConfigBaseBranch,
+		ConfigAIRankCacheTTL,
+		ConfigAIRankMaxCalls,
+		ConfigIssueRankingTemplate,
+		ConfigPRRankingTemplate,
 	}
 
 	for _, key := range keys {
@@ -55,7 +55,7 @@ func ExampleConfig_validation() {
 
 	// Output:
 	// Valid provider
-	// Error: invalid issue provider: invalid (must be one of: github, gitlab, jira, linear)
+	// Error: invalid issue provider: invalid (must be one of: github, gitlab, jira, linear, or plugin:<name> for a provider plugin)
 }
 
 // ExampleConfig_scopePriority demonstrates local/global scope priority
@@ -355,6 +355,8 @@ func TestConfig_Validate(t *testing.T) {
 		{"valid gitlab", ConfigIssueProvider, "gitlab", false},
 		{"valid jira", ConfigIssueProvider, "jira", false},
 		{"valid linear", ConfigIssueProvider, "linear", false},
+		{"valid plugin provider", ConfigIssueProvider, "plugin:acme", false},
+		{"plugin provider without a name", ConfigIssueProvider, "plugin:", true},
 		{"invalid provider", ConfigIssueProvider, "invalid", true},
 
 		// Valid AI tools
@@ -364,6 +366,74 @@ func TestConfig_Validate(t *testing.T) {
 		{"valid jules", ConfigAITool, "jules", false},
 		{"valid skip", ConfigAITool, "skip", false},
 		{"invalid ai tool", ConfigAITool, "invalid", true},
+		{"valid code host", ConfigCodeHost, "gitlab", false},
+		{"issue-only code host", ConfigCodeHost, "jira", true},
+		{"valid cleanup policy", ConfigCleanupPolicy, "auto", false},
+		{"invalid cleanup policy", ConfigCleanupPolicy, "always", true},
+		{"valid cpu limit", ConfigSessionCPUs, "1.5", false},
+		{"zero cpu limit", ConfigSessionCPUs, "0", true},
+		{"valid memory limit", ConfigSessionMemory, "4g", false},
+		{"invalid memory limit", ConfigSessionMemory, "4 GB", true},
+		{"valid command timeouts", ConfigCommandTimeouts, "gh=30s,git=2m,ai=0", false},
+		{"command timeout for unknown tool", ConfigCommandTimeouts, "svn=1m", true},
+		{"invalid command timeout", ConfigCommandTimeouts, "gh=soon", true},
+		{"valid max parallel", ConfigMaxParallel, "4", false},
+		{"zero max parallel", ConfigMaxParallel, "0", true},
+		{"valid GitHub transport", ConfigGitHubTransport, "api", false},
+		{"invalid GitHub transport", ConfigGitHubTransport, "rest", true},
+		{"valid GitLab transport", ConfigGitLabTransport, "api", false},
+		{"invalid GitLab transport", ConfigGitLabTransport, "graphql", true},
+		{"JIRA email", ConfigJiraEmail, "dev@example.com", false},
+		{"Linear project", ConfigLinearProject, "Mobile App", false},
+		{"Linear cycle", ConfigLinearCycle, "current", false},
+		{"issue sort by priority", ConfigIssueSort, "priority", false},
+		{"invalid issue sort", ConfigIssueSort, "oldest", true},
+		{"pr draft", ConfigPRDraft, "true", false},
+		{"invalid pr draft", ConfigPRDraft, "sometimes", true},
+		{"invalid pr codeowners", ConfigPRCodeOwners, "yes", true},
+		{"GitHub project number", ConfigGitHubProject, "5", false},
+		{"GitHub project of another owner", ConfigGitHubProject, "acme/12", false},
+		{"GitHub project without a number", ConfigGitHubProject, "acme", true},
+		{"GitHub project without an owner", ConfigGitHubProject, "/12", true},
+		{"GitHub project column", ConfigGitHubProjectReady, "Ready", false},
+		{"valid redact patterns", ConfigRedactPatterns, `acme_[a-z0-9]{32} internal-\S+`, false},
+		{"invalid redact pattern", ConfigRedactPatterns, "acme_[a-z", true},
+		{"AI confirm context", ConfigAIConfirmContext, "true", false},
+		{"valid AI diff limit", ConfigAIDiffLimit, "20000", false},
+		{"invalid AI diff limit", ConfigAIDiffLimit, "0", true},
+		{"valid idle timeout", ConfigSessionIdleTimeout, "0.5", false},
+		{"invalid idle timeout", ConfigSessionIdleTimeout, "2h", true},
+		{"valid checkpoint interval", ConfigCheckpointInterval, "10", false},
+		{"invalid checkpoint interval", ConfigCheckpointInterval, "0", true},
+		{"AI ranking cache off", ConfigAIRankCacheTTL, "0", false},
+		{"invalid AI ranking cache", ConfigAIRankCacheTTL, "30m", true},
+		{"valid AI ranking limit", ConfigAIRankMaxCalls, "5", false},
+		{"invalid AI ranking limit", ConfigAIRankMaxCalls, "0", true},
+		{"worktree base directory", ConfigWorktreeBase, "~/src/worktrees", false},
+		{"worktree base template", ConfigWorktreeBase, "{repo_parent}/{repo}-worktrees/{branch}", false},
+		{"worktree base unknown placeholder", ConfigWorktreeBase, "{home}/{repo}", true},
+		{"worktree base unclosed placeholder", ConfigWorktreeBase, "{repo_parent/x", true},
+		{"valid list path width", ConfigListPathWidth, "60", false},
+		{"too narrow list path width", ConfigListPathWidth, "5", true},
+		{"valid list sort", ConfigListSort, "status", false},
+		{"invalid list sort", ConfigListSort, "color", true},
+		{"valid list columns", ConfigListColumns, "path, branch,status", false},
+		{"invalid list column", ConfigListColumns, "path,color", true},
+		{"valid list compact", ConfigListCompact, "true", false},
+		{"invalid list compact", ConfigListCompact, "narrow", true},
+		{"valid protected branches", ConfigProtectedBranches, "main, release/*", false},
+		{"invalid protected branch pattern", ConfigProtectedBranches, "release/[", true},
+		{"valid branch name words", ConfigBranchNameWords, "2", false},
+		{"too many branch name words", ConfigBranchNameWords, "12", true},
+		{"valid branch name separator", ConfigBranchNameSeparator, "_", false},
+		{"invalid branch name separator", ConfigBranchNameSeparator, "~", true},
+		{"valid base branch", ConfigBaseBranch, "develop, hotfix/=main, release/=release/2.x", false},
+		{"base branch without prefix", ConfigBaseBranch, "=main", true},
+		{"two default base branches", ConfigBaseBranch, "develop,main", true},
+		{"invalid base branch", ConfigBaseBranch, "feat/=dev elop", true},
+		{"valid issue branch prefixes", ConfigIssueBranchPrefixes, "bug=fix/, enhancement=feat", false},
+		{"issue branch prefix without label", ConfigIssueBranchPrefixes, "fix/", true},
+		{"invalid issue branch prefix", ConfigIssueBranchPrefixes, "bug=fix me/", true},
 
 		// Boolean values
 		{"valid bool true", ConfigIssueAutoselect, "true", false},
@@ -474,6 +544,28 @@ func TestConfig_HelperMethods(t *testing.T) {
 	}
 }
 
+func TestConfig_HasSettings(t *testing.T) {
+	fake := NewFakeGitExecutor()
+	config := NewConfigWithExecutor("/fake/repo", fake)
+
+	fake.SetError(`config --get-regexp ^auto-worktree\.`, fmt.Errorf("exit status 1"))
+
+	if config.HasSettings() {
+		t.Error("HasSettings() = true with no settings")
+	}
+
+	fake.SetResponse(`config --get-regexp ^auto-worktree\.`, ConfigIssueProvider+" github")
+	delete(fake.Errors, `config --get-regexp ^auto-worktree\.`)
+
+	if !config.HasSettings() {
+		t.Error("HasSettings() = false with a setting")
+	}
+
+	if got := config.GetCleanupPolicy(); got != CleanupPolicyPrompt {
+		t.Errorf("GetCleanupPolicy() = %q, want %q", got, CleanupPolicyPrompt)
+	}
+}
+
 func TestConfig_UnsetAll(t *testing.T) {
 	repoPath := "/fake/repo"
 	fake := NewFakeGitExecutor()
@@ -502,7 +594,7 @@ func TestConfig_UnsetAll(t *testing.T) {
 		}
 	}
 	// Should unset all the config keys defined in UnsetAll
-	expectedUnsetCount := 18 // Number of keys in UnsetAll method
+	expectedUnsetCount := 78 // Number of keys in UnsetAll method
 	if unsetCount != expectedUnsetCount {
 		t.Errorf("Expected %d unset commands, got %d", expectedUnsetCount, unsetCount)
 	}
@@ -0,0 +1,77 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ConflictTarget returns the ref branches are checked against for conflicts:
+// the remote default branch when there is one, since that is what a pull
+// request merges into, otherwise the local default branch
+func (r *Repository) ConflictTarget() (string, error) {
+	defaultBranch, err := r.GetDefaultBranch()
+	if err != nil {
+		return "", err
+	}
+
+	if r.remoteBranchExists("origin/" + defaultBranch) {
+		return "origin/" + defaultBranch, nil
+	}
+
+	return defaultBranch, nil
+}
+
+// ResolveCommit returns the commit SHA a ref points to
+func (r *Repository) ResolveCommit(ref string) (string, error) {
+	sha, err := r.executor.ExecuteInDir(r.RootPath, "rev-parse", "--verify", "--quiet", ref+"^{commit}")
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s: %w", ref, err)
+	}
+
+	return strings.TrimSpace(sha), nil
+}
+
+// MergeConflicts returns the files that would conflict if branch were merged
+// into target, or nil if it merges cleanly. The merge happens in memory with
+// git merge-tree (git 2.38 or later), so no worktree or index is touched.
+func (r *Repository) MergeConflicts(target, branch string) ([]string, error) {
+	_, err := r.executor.ExecuteInDir(r.RootPath, "merge-tree", "--write-tree", "--name-only", "--no-messages", target, branch)
+	if err == nil {
+		return nil, nil
+	}
+
+	// Exit status 1 means the merge has conflicts; the output is the
+	// resulting tree followed by the conflicted paths
+	var cmdErr *CommandError
+	if !errors.As(err, &cmdErr) || cmdErr.ExitCode != 1 {
+		return nil, fmt.Errorf("failed to check %s for conflicts: %w", branch, err)
+	}
+
+	return parseMergeTreeConflicts(cmdErr.Output), nil
+}
+
+// parseMergeTreeConflicts reads the conflicted paths from merge-tree --name-only output
+func parseMergeTreeConflicts(output string) []string {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	if len(lines) < 2 {
+		return []string{}
+	}
+
+	seen := make(map[string]bool)
+	files := []string{}
+
+	for _, line := range lines[1:] {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			break
+		}
+
+		if !seen[line] {
+			seen[line] = true
+			files = append(files, line)
+		}
+	}
+
+	return files
+}
@@ -0,0 +1,48 @@
+package git
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestMergeConflicts(t *testing.T) {
+	executor := NewFakeGitExecutor()
+
+	repo, err := NewRepositoryFromPathWithDeps("/fake/repo", executor, NewFakeFileSystem())
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+
+	files, err := repo.MergeConflicts("main", "clean")
+	if err != nil || files != nil {
+		t.Errorf("MergeConflicts() on a clean merge = %v, %v; want nil, nil", files, err)
+	}
+
+	executor.Errors["merge-tree --write-tree --name-only --no-messages main clash"] = &CommandError{
+		Args:     []string{"merge-tree"},
+		Output:   "4b825dc642cb6eb9a060e54bf8d69288fbee4904\nsrc/a.go\nsrc/a.go\nREADME.md",
+		ExitCode: 1,
+		Err:      errors.New("exit status 1"),
+	}
+
+	files, err = repo.MergeConflicts("main", "clash")
+	if err != nil {
+		t.Fatalf("MergeConflicts() error = %v", err)
+	}
+
+	if want := []string{"src/a.go", "README.md"}; !reflect.DeepEqual(files, want) {
+		t.Errorf("MergeConflicts() = %v, want %v", files, want)
+	}
+
+	executor.Errors["merge-tree --write-tree --name-only --no-messages main old-git"] = &CommandError{
+		Args:     []string{"merge-tree"},
+		Output:   "usage: git merge-tree",
+		ExitCode: 129,
+		Err:      errors.New("exit status 129"),
+	}
+
+	if _, err := repo.MergeConflicts("main", "old-git"); err == nil {
+		t.Error("MergeConflicts() should fail when merge-tree does not run")
+	}
+}
@@ -1,12 +1,19 @@
 package git
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"strings"
 	"sync"
 	"time"
+
+	"github.com/kaeawc/auto-worktree/internal/interrupt"
+	"github.com/kaeawc/auto-worktree/internal/limits"
+	"github.com/kaeawc/auto-worktree/internal/logging"
+	"github.com/kaeawc/auto-worktree/internal/remote"
 )
 
 // GitExecutor defines the interface for executing git commands
@@ -15,10 +22,16 @@ type GitExecutor interface {
 	Execute(args ...string) (string, error)
 	// ExecuteInDir runs a git command in a specific directory
 	ExecuteInDir(dir string, args ...string) (string, error)
+	// ExecuteInDirWithEnv runs a git command in a specific directory with extra
+	// environment variables, e.g. GIT_INDEX_FILE
+	ExecuteInDirWithEnv(dir string, env []string, args ...string) (string, error)
 }
 
-// RealGitExecutor executes actual git commands via exec.Command
-type RealGitExecutor struct{}
+// RealGitExecutor executes actual git commands, on the --host machine when one is configured
+type RealGitExecutor struct {
+	// ctx stops the commands; nil means they stop on Ctrl-C
+	ctx context.Context
+}
 
 // NewGitExecutor creates a new real git executor for production use
 func NewGitExecutor() GitExecutor {
@@ -27,34 +40,53 @@ func NewGitExecutor() GitExecutor {
 
 // Execute runs a git command and returns the output
 func (e *RealGitExecutor) Execute(args ...string) (string, error) {
-	return e.executeWithRetry("", args...)
+	return e.executeWithRetry("", nil, args...)
 }
 
 // ExecuteInDir runs a git command in a specific directory
 func (e *RealGitExecutor) ExecuteInDir(dir string, args ...string) (string, error) {
-	return e.executeWithRetry(dir, args...)
+	return e.executeWithRetry(dir, nil, args...)
+}
+
+// ExecuteInDirWithEnv runs a git command in a specific directory with extra environment variables
+func (e *RealGitExecutor) ExecuteInDirWithEnv(dir string, env []string, args ...string) (string, error) {
+	return e.executeWithRetry(dir, env, args...)
 }
 
 // executeWithRetry runs a git command with retry logic for lock file errors
-func (e *RealGitExecutor) executeWithRetry(dir string, args ...string) (string, error) {
+func (e *RealGitExecutor) executeWithRetry(dir string, env []string, args ...string) (string, error) {
 	const maxRetries = 3
 	const retryDelay = 1 * time.Second
 
 	var lastErr error
+	var lastOutput string
 	var lockFileWarningShown bool
 
+	parent := e.ctx
+	if parent == nil {
+		parent = interrupt.Context()
+	}
+
 	for attempt := 0; attempt < maxRetries; attempt++ {
-		cmd := exec.Command("git", args...)
-		if dir != "" {
-			cmd.Dir = dir
-		}
+		ctx, cancel := limits.Context(parent, limits.ToolGit)
+		cmd := remote.Command(ctx, dir, env, "git", args...)
+		start := time.Now()
 		output, err := cmd.CombinedOutput()
+		logging.Command(cmd, start, output, err)
+		err = limits.Wrap(ctx, limits.ToolGit, err)
+		cancel()
 
 		if err == nil {
 			return strings.TrimSpace(string(output)), nil
 		}
 
 		lastErr = err
+		lastOutput = strings.TrimSpace(string(output))
+
+		// Stopped by Ctrl-C: retrying would only be stopped again
+		if parent.Err() != nil {
+			break
+		}
 
 		// Check if this is a lock file error
 		if IsLockFileError(err) {
@@ -86,11 +118,44 @@ func (e *RealGitExecutor) executeWithRetry(dir string, args ...string) (string,
 		break
 	}
 
-	// Return the error with appropriate context
-	if dir != "" {
-		return "", fmt.Errorf("git %s failed in %s: %w", strings.Join(args, " "), dir, lastErr)
+	cmdErr := &CommandError{Args: args, Dir: dir, Output: lastOutput, Err: lastErr}
+
+	var exitErr *exec.ExitError
+	if errors.As(lastErr, &exitErr) {
+		cmdErr.ExitCode = exitErr.ExitCode()
 	}
-	return "", fmt.Errorf("git %s failed: %w", strings.Join(args, " "), lastErr)
+
+	return "", cmdErr
+}
+
+// CommandError is a failed git command. It keeps the command's output, since
+// some commands (such as merge-tree) report results through a non-zero exit.
+type CommandError struct {
+	Args []string
+	Dir  string
+	// Output is the command's combined output
+	Output string
+	// ExitCode is git's exit status, or 0 if it did not run
+	ExitCode int
+	Err      error
+}
+
+func (e *CommandError) Error() string {
+	// Keep git's own message so callers can tell failures apart
+	cause := e.Err.Error()
+	if e.Output != "" {
+		cause += ": " + e.Output
+	}
+
+	if e.Dir != "" {
+		return fmt.Sprintf("git %s failed in %s: %s", strings.Join(e.Args, " "), e.Dir, cause)
+	}
+
+	return fmt.Sprintf("git %s failed: %s", strings.Join(e.Args, " "), cause)
+}
+
+func (e *CommandError) Unwrap() error {
+	return e.Err
 }
 
 // FakeGitExecutor is a fake implementation for testing
@@ -157,6 +222,11 @@ func (e *FakeGitExecutor) ExecuteInDir(dir string, args ...string) (string, erro
 	return e.DefaultResponse, nil
 }
 
+// ExecuteInDirWithEnv records the command like ExecuteInDir; the environment is ignored
+func (e *FakeGitExecutor) ExecuteInDirWithEnv(dir string, _ []string, args ...string) (string, error) {
+	return e.ExecuteInDir(dir, args...)
+}
+
 // SetResponse configures a response for a specific command
 func (e *FakeGitExecutor) SetResponse(command string, response string) {
 	e.Responses[command] = response
@@ -6,6 +6,8 @@ import (
 	"path/filepath"
 	"strings"
 	"time"
+
+	"github.com/kaeawc/auto-worktree/internal/remote"
 )
 
 // FileSystem defines the interface for file system operations
@@ -22,6 +24,12 @@ type FileSystem interface {
 	WriteFile(path string, data []byte, perm os.FileMode) error
 	// Stat returns file info
 	Stat(path string) (os.FileInfo, error)
+	// Lstat returns file info without following a symlink
+	Lstat(path string) (os.FileInfo, error)
+	// Readlink returns the destination of a symlink
+	Readlink(path string) (string, error)
+	// Symlink creates newname as a symlink to oldname
+	Symlink(oldname, newname string) error
 	// UserHomeDir returns the current user's home directory
 	UserHomeDir() (string, error)
 	// Walk walks the file tree
@@ -37,8 +45,13 @@ type FileSystem interface {
 // RealFileSystem implements FileSystem using actual os/filepath functions
 type RealFileSystem struct{}
 
-// NewFileSystem creates a new real file system for production use
+// NewFileSystem creates a new real file system for production use,
+// on the --host machine when one is configured
 func NewFileSystem() FileSystem {
+	if remote.Enabled() {
+		return &RemoteFileSystem{}
+	}
+
 	return &RealFileSystem{}
 }
 
@@ -72,6 +85,21 @@ func (f *RealFileSystem) Stat(path string) (os.FileInfo, error) {
 	return os.Stat(path)
 }
 
+// Lstat returns file info without following a symlink
+func (f *RealFileSystem) Lstat(path string) (os.FileInfo, error) {
+	return os.Lstat(path)
+}
+
+// Readlink returns the destination of a symlink
+func (f *RealFileSystem) Readlink(path string) (string, error) {
+	return os.Readlink(path)
+}
+
+// Symlink creates newname as a symlink to oldname
+func (f *RealFileSystem) Symlink(oldname, newname string) error {
+	return os.Symlink(oldname, newname)
+}
+
 // UserHomeDir returns the current user's home directory
 func (f *RealFileSystem) UserHomeDir() (string, error) {
 	return os.UserHomeDir()
@@ -94,6 +122,8 @@ type FakeFileSystem struct {
 	Files map[string][]byte
 	// Dirs stores directories
 	Dirs map[string]bool
+	// Symlinks stores symlink destinations keyed by path
+	Symlinks map[string]string
 	// Permissions stores file permissions
 	Permissions map[string]os.FileMode
 	// ModTimes stores modification times
@@ -111,6 +141,7 @@ func NewFakeFileSystem() *FakeFileSystem {
 	return &FakeFileSystem{
 		Files:        make(map[string][]byte),
 		Dirs:         make(map[string]bool),
+		Symlinks:     make(map[string]string),
 		Permissions:  make(map[string]os.FileMode),
 		ModTimes:     make(map[string]time.Time),
 		HomeDir:      "/home/testuser",
@@ -169,6 +200,11 @@ func (f *FakeFileSystem) Remove(path string) error {
 		return nil
 	}
 
+	if _, exists := f.Symlinks[path]; exists {
+		delete(f.Symlinks, path)
+		return nil
+	}
+
 	return fmt.Errorf("remove %s: no such file or directory", path)
 }
 
@@ -266,6 +302,56 @@ func (f *FakeFileSystem) Stat(path string) (os.FileInfo, error) {
 	return nil, fmt.Errorf("stat %s: no such file or directory", path)
 }
 
+// Lstat returns file info from memory, reporting symlinks as such
+func (f *FakeFileSystem) Lstat(path string) (os.FileInfo, error) {
+	if _, exists := f.Symlinks[path]; exists {
+		f.OperationLog = append(f.OperationLog, fmt.Sprintf("Lstat(%s)", path))
+
+		if err, ok := f.Errors[path]; ok {
+			return nil, err
+		}
+
+		return &fakeFileInfo{name: filepath.Base(path), mode: os.ModeSymlink | 0o777}, nil
+	}
+
+	return f.Stat(path)
+}
+
+// Readlink returns the destination of a symlink in memory
+func (f *FakeFileSystem) Readlink(path string) (string, error) {
+	f.OperationLog = append(f.OperationLog, fmt.Sprintf("Readlink(%s)", path))
+
+	if err, ok := f.Errors[path]; ok {
+		return "", err
+	}
+
+	if target, exists := f.Symlinks[path]; exists {
+		return target, nil
+	}
+
+	return "", fmt.Errorf("readlink %s: invalid argument", path)
+}
+
+// Symlink creates a symlink in memory
+func (f *FakeFileSystem) Symlink(oldname, newname string) error {
+	f.OperationLog = append(f.OperationLog, fmt.Sprintf("Symlink(%s, %s)", oldname, newname))
+
+	if err, ok := f.Errors[newname]; ok {
+		return err
+	}
+
+	_, isFile := f.Files[newname]
+	_, isLink := f.Symlinks[newname]
+
+	if isFile || isLink || f.Dirs[newname] {
+		return fmt.Errorf("symlink %s %s: file exists", oldname, newname)
+	}
+
+	f.Symlinks[newname] = oldname
+
+	return nil
+}
+
 // UserHomeDir returns the configured home directory
 func (f *FakeFileSystem) UserHomeDir() (string, error) {
 	f.OperationLog = append(f.OperationLog, "UserHomeDir()")
@@ -382,6 +468,7 @@ func (f *FakeFileSystem) GetLastOperation() string {
 func (f *FakeFileSystem) Reset() {
 	f.Files = make(map[string][]byte)
 	f.Dirs = make(map[string]bool)
+	f.Symlinks = make(map[string]string)
 	f.Permissions = make(map[string]os.FileMode)
 	f.ModTimes = make(map[string]time.Time)
 	f.Errors = make(map[string]error)
@@ -0,0 +1,76 @@
+package git
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// freezeFileName is stored in the git common dir so every worktree sees the same state
+const freezeFileName = "auto-worktree-frozen"
+
+// FreezeState records that a repository is paused and why
+type FreezeState struct {
+	// Reason is an optional note shown in list and menu (e.g. "history rewrite")
+	Reason string `json:"reason,omitempty"`
+	// FrozenAt is when the repository was frozen
+	FrozenAt time.Time `json:"frozenAt"`
+}
+
+// freezeFilePath returns the path of the freeze marker file
+func (r *Repository) freezeFilePath() string {
+	return r.filesystem.Join(r.GitDir(), freezeFileName)
+}
+
+// Freeze pauses automatic actions (startup cleanup, background work) for the repository
+func (r *Repository) Freeze(reason string) (*FreezeState, error) {
+	state := &FreezeState{Reason: reason, FrozenAt: time.Now()}
+
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode freeze state: %w", err)
+	}
+
+	if err := r.filesystem.WriteFile(r.freezeFilePath(), data, 0o600); err != nil {
+		return nil, fmt.Errorf("failed to freeze repository: %w", err)
+	}
+
+	return state, nil
+}
+
+// Thaw resumes automatic actions. Returns false if the repository was not frozen.
+func (r *Repository) Thaw() (bool, error) {
+	path := r.freezeFilePath()
+	if !r.filesystem.Exists(path) {
+		return false, nil
+	}
+
+	if err := r.filesystem.Remove(path); err != nil {
+		return false, fmt.Errorf("failed to thaw repository: %w", err)
+	}
+
+	return true, nil
+}
+
+// FrozenState returns the freeze state, or nil if the repository is not frozen.
+// An unreadable marker still counts as frozen so automatic actions stay paused.
+func (r *Repository) FrozenState() *FreezeState {
+	path := r.freezeFilePath()
+	if !r.filesystem.Exists(path) {
+		return nil
+	}
+
+	state := &FreezeState{}
+
+	data, err := r.filesystem.ReadFile(path)
+	if err == nil {
+		_ = json.Unmarshal(data, state) //nolint:errcheck
+	}
+
+	return state
+}
+
+// IsFrozen reports whether automatic actions are paused for the repository
+func (r *Repository) IsFrozen() bool {
+	return r.FrozenState() != nil
+}
@@ -0,0 +1,105 @@
+package git
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestFreezeAndThaw(t *testing.T) {
+	fakeExec := NewFakeGitExecutor()
+	fakeExec.SetResponse("rev-parse --show-toplevel", "/src/repo")
+
+	fs := NewFakeFileSystem()
+
+	repo, err := NewRepositoryFromPathWithDeps("/src/repo", fakeExec, fs)
+	if err != nil {
+		t.Fatalf("NewRepositoryFromPathWithDeps() error = %v", err)
+	}
+
+	if repo.IsFrozen() {
+		t.Fatal("new repository should not be frozen")
+	}
+
+	if _, err := repo.Freeze("history rewrite"); err != nil {
+		t.Fatalf("Freeze() error = %v", err)
+	}
+
+	if _, ok := fs.Files[filepath.Join("/src/repo", ".git", freezeFileName)]; !ok {
+		t.Error("Freeze() did not write the marker to the main .git directory")
+	}
+
+	state := repo.FrozenState()
+	if state == nil || state.Reason != "history rewrite" || state.FrozenAt.IsZero() {
+		t.Errorf("FrozenState() = %+v", state)
+	}
+
+	thawed, err := repo.Thaw()
+	if err != nil || !thawed {
+		t.Fatalf("Thaw() = %v, %v; want true, nil", thawed, err)
+	}
+
+	if repo.IsFrozen() {
+		t.Error("repository still frozen after Thaw()")
+	}
+
+	thawed, err = repo.Thaw()
+	if err != nil || thawed {
+		t.Errorf("second Thaw() = %v, %v; want false, nil", thawed, err)
+	}
+}
+
+func TestFrozenStateWithCorruptMarker(t *testing.T) {
+	fakeExec := NewFakeGitExecutor()
+	fakeExec.SetResponse("rev-parse --show-toplevel", "/src/repo")
+
+	fs := NewFakeFileSystem()
+	fs.Files[filepath.Join("/src/repo", ".git", freezeFileName)] = []byte("not json")
+
+	repo, err := NewRepositoryFromPathWithDeps("/src/repo", fakeExec, fs)
+	if err != nil {
+		t.Fatalf("NewRepositoryFromPathWithDeps() error = %v", err)
+	}
+
+	if !repo.IsFrozen() {
+		t.Error("an unreadable marker should still count as frozen")
+	}
+}
+
+func TestFreezeFromLinkedWorktreeOfBareRepository(t *testing.T) {
+	fakeExec := NewFakeGitExecutor()
+	fakeExec.SetResponse("rev-parse --show-toplevel", "/src/proj/feature-x")
+	fakeExec.SetResponse("rev-parse --git-common-dir", "/src/proj/repo.git")
+	fakeExec.SetResponse("rev-parse --is-bare-repository", "true")
+
+	fs := NewFakeFileSystem()
+	fs.Files["/src/proj/feature-x/.git"] = []byte("gitdir: /src/proj/repo.git/worktrees/feature-x\n")
+
+	repo, err := NewRepositoryFromPathWithDeps("/src/proj/feature-x", fakeExec, fs)
+	if err != nil {
+		t.Fatalf("NewRepositoryFromPathWithDeps() error = %v", err)
+	}
+
+	if _, err := repo.Freeze(""); err != nil {
+		t.Fatalf("Freeze() error = %v", err)
+	}
+
+	if _, ok := fs.Files[filepath.Join("/src/proj/repo.git", freezeFileName)]; !ok {
+		t.Errorf("Freeze() did not write the marker to the common dir; files = %v", fs.Files)
+	}
+}
+
+func TestResolveCommonDirFollowsGitFile(t *testing.T) {
+	fakeExec := NewFakeGitExecutor()
+	fakeExec.SetResponse("rev-parse --git-common-dir", "../store/app.git")
+
+	fs := NewFakeFileSystem()
+	fs.Files["/src/app/.git"] = []byte("gitdir: ../store/app.git\n")
+
+	if got, want := resolveCommonDir("/src/app", false, fakeExec, fs), "/src/store/app.git"; got != want {
+		t.Errorf("resolveCommonDir() = %q, want %q", got, want)
+	}
+
+	if got, want := resolveCommonDir("/src/other", false, fakeExec, fs), "/src/other/.git"; got != want {
+		t.Errorf("resolveCommonDir() without a .git file = %q, want %q", got, want)
+	}
+}
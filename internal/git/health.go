@@ -33,21 +33,40 @@ func (s HealthCheckSeverity) String() string {
 	}
 }
 
+// MarshalText writes the severity as its lowercase name in JSON reports
+func (s HealthCheckSeverity) MarshalText() ([]byte, error) {
+	return []byte(strings.ToLower(s.String())), nil
+}
+
+// ParseHealthCheckSeverity parses a severity name: warning, error or critical
+func ParseHealthCheckSeverity(name string) (HealthCheckSeverity, error) {
+	switch strings.ToLower(name) {
+	case "warning", "warn":
+		return SeverityWarning, nil
+	case "error":
+		return SeverityError, nil
+	case "critical":
+		return SeverityCritical, nil
+	default:
+		return SeverityOK, fmt.Errorf("unknown severity %q (use warning, error or critical)", name)
+	}
+}
+
 // HealthCheckIssue represents a single health check finding
 type HealthCheckIssue struct {
-	Severity    HealthCheckSeverity
-	Category    string
-	Description string
-	Repairable  bool
-	RepairHint  string
+	Severity    HealthCheckSeverity `json:"severity"`
+	Category    string              `json:"category"`
+	Description string              `json:"description"`
+	Repairable  bool                `json:"repairable"`
+	RepairHint  string              `json:"repairHint,omitempty"`
 }
 
 // HealthCheckResult contains the results of a health check
 type HealthCheckResult struct {
-	WorktreePath string
-	CheckTime    time.Time
-	Issues       []HealthCheckIssue
-	Healthy      bool
+	WorktreePath string             `json:"worktreePath"`
+	CheckTime    time.Time          `json:"checkTime"`
+	Issues       []HealthCheckIssue `json:"issues"`
+	Healthy      bool               `json:"healthy"`
 }
 
 // GetMaxSeverity returns the highest severity found in the issues
@@ -61,6 +80,17 @@ func (r *HealthCheckResult) GetMaxSeverity() HealthCheckSeverity {
 	return maxSeverity
 }
 
+// IssuesAtLeast returns the issues of severity min or higher
+func (r *HealthCheckResult) IssuesAtLeast(minSeverity HealthCheckSeverity) []HealthCheckIssue {
+	issues := []HealthCheckIssue{}
+	for _, issue := range r.Issues {
+		if issue.Severity >= minSeverity {
+			issues = append(issues, issue)
+		}
+	}
+	return issues
+}
+
 // GetRepairableIssues returns only issues that can be automatically repaired
 func (r *HealthCheckResult) GetRepairableIssues() []HealthCheckIssue {
 	var repairable []HealthCheckIssue
@@ -105,7 +135,13 @@ func (r *Repository) PerformHealthCheck(worktreePath string) (*HealthCheckResult
 	// 5. Verify git commands execute successfully
 	r.checkGitCommandExecution(worktreePath, result)
 
-	// 6. Check for orphaned worktrees (only for main repo)
+	// 6. Check submodules are checked out
+	r.checkSubmodules(worktreePath, result)
+
+	// 7. Check Git LFS content was downloaded
+	r.checkLFS(worktreePath, result)
+
+	// 8. Check for orphaned worktrees (only for main repo)
 	if isMainWorktree {
 		r.checkOrphanedWorktrees(result)
 	}
@@ -275,7 +311,75 @@ func (r *Repository) checkGitFileIntegrity(path string, result *HealthCheckResul
 			Repairable:  true,
 			RepairHint:  "Can be repaired with 'git worktree repair'",
 		})
+		return
 	}
+
+	r.checkWorktreeBacklink(path, gitdir, result)
+}
+
+// checkWorktreeBacklink verifies the two-way link between a linked worktree and its
+// administrative directory (.git/worktrees/<name>). Both diverge when the main
+// repository or the worktree is moved or copied outside of git.
+func (r *Repository) checkWorktreeBacklink(path, gitdir string, result *HealthCheckResult) {
+	if !filepath.IsAbs(gitdir) {
+		gitdir = filepath.Join(path, gitdir)
+	}
+
+	gitdir = filepath.Clean(gitdir)
+
+	// The worktree should be registered with this repository, not a copy or former location of it
+	worktreesDir := filepath.Join(r.GitDir(), "worktrees")
+	if info, err := r.filesystem.Stat(worktreesDir); err == nil && info.IsDir() &&
+		!strings.HasPrefix(gitdir, worktreesDir+string(filepath.Separator)) {
+		result.Issues = append(result.Issues, HealthCheckIssue{
+			Severity:    SeverityError,
+			Category:    "Git Metadata",
+			Description: fmt.Sprintf(".git file points at another repository's metadata: %s (expected under %s)", gitdir, worktreesDir),
+			Repairable:  true,
+			RepairHint:  "Can be repaired with 'git worktree repair'",
+		})
+
+		return
+	}
+
+	// The administrative directory's gitdir file should point back at this worktree's .git file
+	expected := filepath.Join(path, ".git")
+
+	content, err := r.filesystem.ReadFile(filepath.Join(gitdir, "gitdir"))
+	if err != nil {
+		result.Issues = append(result.Issues, HealthCheckIssue{
+			Severity:    SeverityError,
+			Category:    "Git Metadata",
+			Description: fmt.Sprintf("Worktree metadata has no backlink to this worktree: %s", filepath.Join(gitdir, "gitdir")),
+			Repairable:  true,
+			RepairHint:  "Can be repaired with 'git worktree repair'",
+		})
+
+		return
+	}
+
+	backlink := strings.TrimSpace(string(content))
+	if !r.samePath(backlink, expected) {
+		result.Issues = append(result.Issues, HealthCheckIssue{
+			Severity:    SeverityError,
+			Category:    "Git Metadata",
+			Description: fmt.Sprintf("Worktree metadata points to %s instead of %s", backlink, expected),
+			Repairable:  true,
+			RepairHint:  "Can be repaired with 'git worktree repair'",
+		})
+	}
+}
+
+// samePath reports whether a and b name the same file, allowing for symlinked parents
+func (r *Repository) samePath(a, b string) bool {
+	if filepath.Clean(a) == filepath.Clean(b) {
+		return true
+	}
+
+	aInfo, aErr := r.filesystem.Stat(a)
+	bInfo, bErr := r.filesystem.Stat(b)
+
+	return aErr == nil && bErr == nil && os.SameFile(aInfo, bInfo)
 }
 
 // checkStaleLockFiles looks for stale git lock files
@@ -396,13 +500,25 @@ func (r *Repository) checkGitCommandExecution(path string, result *HealthCheckRe
 	// Try a simple git status command
 	_, err := r.executor.ExecuteInDir(path, "status", "--porcelain")
 	if err != nil {
+		if IsIndexCorruption(err) {
+			result.Issues = append(result.Issues, HealthCheckIssue{
+				Severity:    SeverityError,
+				Category:    "Git Operations",
+				Description: fmt.Sprintf("Git index is corrupt: %v", err),
+				Repairable:  true,
+				RepairHint:  "Can attempt index rebuild from HEAD (staged changes are lost; working tree files are kept)",
+			})
+
+			return
+		}
+
 		result.Issues = append(result.Issues, HealthCheckIssue{
 			Severity:    SeverityError,
 			Category:    "Git Operations",
 			Description: fmt.Sprintf("Git status command failed: %v", err),
-			Repairable:  true,
-			RepairHint:  "May indicate index corruption; can attempt index rebuild",
+			Repairable:  false,
 		})
+
 		return
 	}
 
@@ -418,6 +534,104 @@ func (r *Repository) checkGitCommandExecution(path string, result *HealthCheckRe
 	}
 }
 
+// checkSubmodules reports submodules declared in .gitmodules that are not checked out
+func (r *Repository) checkSubmodules(path string, result *HealthCheckResult) {
+	if !r.HasSubmodules(path) {
+		return
+	}
+
+	missing, err := r.UninitializedSubmodules(path)
+	if err != nil {
+		result.Issues = append(result.Issues, HealthCheckIssue{
+			Severity:    SeverityWarning,
+			Category:    "Submodules",
+			Description: fmt.Sprintf("Cannot read submodule status: %v", err),
+			Repairable:  false,
+		})
+
+		return
+	}
+
+	for _, submodule := range missing {
+		result.Issues = append(result.Issues, HealthCheckIssue{
+			Severity:    SeverityWarning,
+			Category:    "Submodules",
+			Description: fmt.Sprintf("Uninitialized submodule: %s", submodule),
+			Repairable:  true,
+			RepairHint:  "Can be initialized with 'git submodule update --init --recursive'",
+		})
+	}
+}
+
+// checkLFS reports LFS-tracked files that are still pointer files on disk
+func (r *Repository) checkLFS(path string, result *HealthCheckResult) {
+	if !r.UsesLFS(path) {
+		return
+	}
+
+	if !r.HasLFSInstalled() {
+		result.Issues = append(result.Issues, HealthCheckIssue{
+			Severity:    SeverityWarning,
+			Category:    "Git LFS",
+			Description: "Repository uses Git LFS but git-lfs is not installed",
+			Repairable:  false,
+			RepairHint:  "Install git-lfs (https://git-lfs.com), then run 'auto-worktree repair'",
+		})
+
+		return
+	}
+
+	missing, err := r.MissingLFSObjects(path)
+	if err != nil {
+		result.Issues = append(result.Issues, HealthCheckIssue{
+			Severity:    SeverityWarning,
+			Category:    "Git LFS",
+			Description: fmt.Sprintf("Cannot list LFS files: %v", err),
+			Repairable:  false,
+		})
+
+		return
+	}
+
+	if len(missing) > 0 {
+		result.Issues = append(result.Issues, HealthCheckIssue{
+			Severity:    SeverityWarning,
+			Category:    "Git LFS",
+			Description: fmt.Sprintf("%d LFS file(s) are pointer files, e.g. %s", len(missing), missing[0]),
+			Repairable:  true,
+			RepairHint:  "Can be downloaded with 'git lfs pull'",
+		})
+	}
+}
+
+// indexCorruptionPatterns are fragments of git's messages for an unreadable index
+var indexCorruptionPatterns = []string{
+	"index file corrupt",
+	"bad index file",
+	"index file smaller than expected",
+	"bad signature",
+	"bad index version",
+	"unknown index entry format",
+	"extension, which we do not understand",
+	"corrupted index",
+}
+
+// IsIndexCorruption reports whether a git error was caused by a corrupt index file
+func IsIndexCorruption(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	errStr := strings.ToLower(err.Error())
+	for _, pattern := range indexCorruptionPatterns {
+		if strings.Contains(errStr, pattern) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // checkOrphanedWorktrees looks for worktree metadata without corresponding directories
 func (r *Repository) checkOrphanedWorktrees(result *HealthCheckResult) {
 	worktrees, err := r.ListWorktrees()
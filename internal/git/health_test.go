@@ -1,6 +1,7 @@
 package git
 
 import (
+	"errors"
 	"testing"
 )
 
@@ -101,3 +102,148 @@ func TestHealthCheckResult_GetRepairableIssues(t *testing.T) {
 		}
 	}
 }
+
+func TestHealthCheckResult_IssuesAtLeast(t *testing.T) {
+	result := &HealthCheckResult{Issues: []HealthCheckIssue{
+		{Description: "info", Severity: SeverityOK},
+		{Description: "warning", Severity: SeverityWarning},
+		{Description: "error", Severity: SeverityError},
+		{Description: "critical", Severity: SeverityCritical},
+	}}
+
+	if got := result.IssuesAtLeast(SeverityError); len(got) != 2 || got[0].Description != "error" {
+		t.Errorf("IssuesAtLeast(Error) = %v, want the error and critical issues", got)
+	}
+
+	if got := result.IssuesAtLeast(SeverityOK); len(got) != 4 {
+		t.Errorf("IssuesAtLeast(OK) returned %d issues, want 4", len(got))
+	}
+}
+
+func TestParseHealthCheckSeverity(t *testing.T) {
+	for name, want := range map[string]HealthCheckSeverity{"warning": SeverityWarning, "warn": SeverityWarning, "Error": SeverityError, "critical": SeverityCritical} {
+		if got, err := ParseHealthCheckSeverity(name); err != nil || got != want {
+			t.Errorf("ParseHealthCheckSeverity(%q) = %v, %v; want %v", name, got, err, want)
+		}
+	}
+
+	if _, err := ParseHealthCheckSeverity("ok"); err == nil {
+		t.Error("ParseHealthCheckSeverity(ok) should fail")
+	}
+
+	if data, err := SeverityCritical.MarshalText(); err != nil || string(data) != "critical" {
+		t.Errorf("MarshalText() = %s, %v; want critical", data, err)
+	}
+}
+
+func TestCheckWorktreeBacklink(t *testing.T) {
+	tests := []struct {
+		name      string
+		gitdir    string
+		backlink  string
+		wantIssue bool
+	}{
+		{
+			name:     "links agree",
+			gitdir:   "/fake/repo/.git/worktrees/wt",
+			backlink: "/fake/wt/.git\n",
+		},
+		{
+			name:      "metadata belongs to another repository",
+			gitdir:    "/old/repo/.git/worktrees/wt",
+			backlink:  "/fake/wt/.git\n",
+			wantIssue: true,
+		},
+		{
+			name:      "backlink points at the old location",
+			gitdir:    "/fake/repo/.git/worktrees/wt",
+			backlink:  "/moved/wt/.git\n",
+			wantIssue: true,
+		},
+		{
+			name:      "backlink missing",
+			gitdir:    "/fake/repo/.git/worktrees/wt",
+			wantIssue: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := NewFakeFileSystem()
+			fs.Dirs["/fake/repo/.git/worktrees"] = true
+			fs.Dirs[tt.gitdir] = true
+
+			if tt.backlink != "" {
+				fs.Files[tt.gitdir+"/gitdir"] = []byte(tt.backlink)
+			}
+
+			repo := &Repository{RootPath: "/fake/repo", filesystem: fs}
+			result := &HealthCheckResult{WorktreePath: "/fake/wt"}
+
+			repo.checkWorktreeBacklink("/fake/wt", tt.gitdir, result)
+
+			if got := len(result.Issues) > 0; got != tt.wantIssue {
+				t.Fatalf("checkWorktreeBacklink() issues = %v, want issue: %v", result.Issues, tt.wantIssue)
+			}
+
+			for _, issue := range result.Issues {
+				if !issue.Repairable || issue.Category != "Git Metadata" {
+					t.Errorf("issue %q should be a repairable Git Metadata issue", issue.Description)
+				}
+			}
+		})
+	}
+}
+
+func TestIsIndexCorruption(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"bad signature", errors.New("git status --porcelain failed: exit status 128: error: bad signature 0x00000000\nfatal: index file corrupt"), true},
+		{"truncated", errors.New("fatal: index file smaller than expected"), true},
+		{"unknown extension", errors.New("error: index uses ZZZZ extension, which we do not understand"), true},
+		{"not a repository", errors.New("fatal: not a git repository (or any of the parent directories): .git"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsIndexCorruption(tt.err); got != tt.want {
+				t.Errorf("IsIndexCorruption() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckGitCommandExecution_IndexCorruption(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		wantRepairable bool
+	}{
+		{"corrupt index", errors.New("exit status 128: fatal: index file corrupt"), true},
+		{"other failure", errors.New("exit status 128: fatal: detected dubious ownership"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			executor := NewFakeGitExecutor()
+			executor.SetError("status --porcelain", tt.err)
+
+			repo := &Repository{RootPath: "/fake/repo", executor: executor}
+			result := &HealthCheckResult{WorktreePath: "/fake/wt"}
+
+			repo.checkGitCommandExecution("/fake/wt", result)
+
+			if len(result.Issues) != 1 {
+				t.Fatalf("expected 1 issue, got %v", result.Issues)
+			}
+
+			if got := result.Issues[0].Repairable; got != tt.wantRepairable {
+				t.Errorf("Repairable = %v, want %v", got, tt.wantRepairable)
+			}
+		})
+	}
+}
@@ -1,12 +1,17 @@
 package git
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
+
+	"github.com/kaeawc/auto-worktree/internal/interrupt"
+	"github.com/kaeawc/auto-worktree/internal/redact"
+	"github.com/kaeawc/auto-worktree/internal/remote"
 )
 
 // HookExecutor defines the interface for executing git hooks
@@ -28,11 +33,17 @@ func NewHookExecutor() HookExecutor {
 
 // Execute runs a hook script with the given parameters
 func (e *RealHookExecutor) Execute(hookPath string, params []string, env []string, workingDir string, output io.Writer) error {
-	cmd := exec.Command(hookPath, params...)
-	cmd.Env = env
-	cmd.Dir = workingDir
-	cmd.Stdout = output
-	cmd.Stderr = output
+	cmd := remote.Command(interrupt.Context(), workingDir, nil, hookPath, params...)
+	if !remote.Enabled() {
+		// A remote hook gets the remote host's environment, not ours
+		cmd.Env = env
+	}
+	// Hooks often print environment or config, so scrub tokens from what they show
+	redacted := redact.NewWriter(output)
+	defer redacted.Close()
+
+	cmd.Stdout = redacted
+	cmd.Stderr = redacted
 
 	if err := cmd.Run(); err != nil {
 		if exitErr, ok := err.(*exec.ExitError); ok {
@@ -46,6 +57,11 @@ func (e *RealHookExecutor) Execute(hookPath string, params []string, env []strin
 
 // IsExecutable checks if a file exists and is executable
 func (e *RealHookExecutor) IsExecutable(path string) bool {
+	if remote.Enabled() {
+		q := remote.Quote(path)
+		return remote.Shell(context.Background(), "[ -f "+q+" ] && [ -x "+q+" ]").Run() == nil
+	}
+
 	info, err := os.Stat(path)
 	if err != nil {
 		return false
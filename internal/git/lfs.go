@@ -0,0 +1,90 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kaeawc/auto-worktree/internal/logging"
+	"github.com/kaeawc/auto-worktree/internal/offline"
+)
+
+// UsesLFS reports whether the worktree's .gitattributes routes any files through Git LFS
+func (r *Repository) UsesLFS(worktreePath string) bool {
+	content, err := r.filesystem.ReadFile(r.filesystem.Join(worktreePath, ".gitattributes"))
+	if err != nil {
+		return false
+	}
+
+	return strings.Contains(string(content), "filter=lfs")
+}
+
+// HasLFSInstalled reports whether the git-lfs extension is available
+func (r *Repository) HasLFSInstalled() bool {
+	_, err := r.executor.ExecuteInDir(r.RootPath, "lfs", "version")
+	return err == nil
+}
+
+// MissingLFSObjects returns the LFS-tracked files in the worktree that are still
+// pointer files because their content was never downloaded
+func (r *Repository) MissingLFSObjects(worktreePath string) ([]string, error) {
+	output, err := r.executor.ExecuteInDir(worktreePath, "lfs", "ls-files")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list LFS files: %w", err)
+	}
+
+	return parseMissingLFSObjects(output), nil
+}
+
+// parseMissingLFSObjects picks the "<oid> - <path>" lines out of git lfs ls-files;
+// "*" marks files whose content is checked out
+func parseMissingLFSObjects(output string) []string {
+	var paths []string
+
+	for _, line := range strings.Split(output, "\n") {
+		oid, rest, ok := strings.Cut(strings.TrimSpace(line), " - ")
+		if ok && oid != "" && !strings.Contains(oid, " ") {
+			paths = append(paths, rest)
+		}
+	}
+
+	return paths
+}
+
+// PullLFS installs the LFS hooks for the repository and downloads the worktree's LFS objects
+func (r *Repository) PullLFS(worktreePath string) error {
+	if _, err := r.executor.ExecuteInDir(worktreePath, "lfs", "install", "--local"); err != nil {
+		return fmt.Errorf("failed to install git lfs hooks: %w", err)
+	}
+
+	if _, err := r.executor.ExecuteInDir(worktreePath, "lfs", "pull"); err != nil {
+		return fmt.Errorf("failed to pull LFS objects: %w", err)
+	}
+
+	return nil
+}
+
+// initWorktreeLFS downloads LFS objects in a new worktree. A failure is only a
+// warning: the worktree is usable, and health-check reports the pointer files.
+func (r *Repository) initWorktreeLFS(worktreePath string) {
+	// Skip if Config is not initialized (e.g., in tests), like worktree hooks
+	if r.Config == nil || !r.Config.GetLFSPull() || !r.UsesLFS(worktreePath) {
+		return
+	}
+
+	if !r.HasLFSInstalled() {
+		logging.Warn("this repository uses Git LFS but git-lfs is not installed; large files are pointer files",
+			"worktree", worktreePath)
+		return
+	}
+
+	if offline.Enabled() {
+		fmt.Println("📦 Offline: skipping Git LFS download (run 'auto-worktree repair' later)")
+		return
+	}
+
+	fmt.Println("📦 Downloading Git LFS objects...")
+
+	if err := r.PullLFS(worktreePath); err != nil {
+		logging.Warn("LFS objects were not downloaded; run 'auto-worktree repair' to retry", "err", err)
+	}
+}
@@ -0,0 +1,106 @@
+package git
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseMissingLFSObjects(t *testing.T) {
+	output := `4d7a214614 * assets/logo.png
+9f86d08188 - assets/video.mp4
+e3b0c44298 - models/weights file.bin`
+
+	got := parseMissingLFSObjects(output)
+	want := []string{"assets/video.mp4", "models/weights file.bin"}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseMissingLFSObjects() = %v, want %v", got, want)
+	}
+}
+
+func TestUsesLFS(t *testing.T) {
+	fs := NewFakeFileSystem()
+	fs.Files["/lfs/.gitattributes"] = []byte("*.png filter=lfs diff=lfs merge=lfs -text\n")
+	fs.Files["/plain/.gitattributes"] = []byte("*.go text eol=lf\n")
+
+	repo := &Repository{RootPath: "/repo", filesystem: fs}
+
+	if !repo.UsesLFS("/lfs") {
+		t.Error("UsesLFS() = false for a filter=lfs attribute")
+	}
+
+	if repo.UsesLFS("/plain") || repo.UsesLFS("/none") {
+		t.Error("UsesLFS() = true without a filter=lfs attribute")
+	}
+}
+
+func TestCheckLFS(t *testing.T) {
+	tests := []struct {
+		name           string
+		lfsInstalled   bool
+		lsFiles        string
+		wantIssues     int
+		wantRepairable bool
+	}{
+		{"all downloaded", true, "4d7a214614 * assets/logo.png", 0, false},
+		{"pointer files", true, "4d7a214614 - assets/logo.png\n9f86d08188 - assets/video.mp4", 1, true},
+		{"git-lfs missing", false, "", 1, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			executor := NewFakeGitExecutor()
+			if !tt.lfsInstalled {
+				executor.SetError("lfs version", errors.New("git: 'lfs' is not a git command"))
+			}
+			executor.SetResponse("lfs ls-files", tt.lsFiles)
+
+			fs := NewFakeFileSystem()
+			fs.Files["/wt/.gitattributes"] = []byte("*.png filter=lfs diff=lfs merge=lfs -text\n")
+
+			repo := &Repository{RootPath: "/repo", executor: executor, filesystem: fs}
+			result := &HealthCheckResult{WorktreePath: "/wt"}
+
+			repo.checkLFS("/wt", result)
+
+			if len(result.Issues) != tt.wantIssues {
+				t.Fatalf("checkLFS() issues = %v, want %d", result.Issues, tt.wantIssues)
+			}
+
+			if tt.wantIssues > 0 && result.Issues[0].Repairable != tt.wantRepairable {
+				t.Errorf("Repairable = %v, want %v", result.Issues[0].Repairable, tt.wantRepairable)
+			}
+		})
+	}
+}
+
+func TestRepairPullLFS(t *testing.T) {
+	executor := NewFakeGitExecutor()
+	repo := &Repository{RootPath: "/repo", executor: executor}
+
+	actions := repo.GetRepairActions([]*HealthCheckResult{{
+		WorktreePath: "/wt",
+		Issues: []HealthCheckIssue{
+			{Category: "Git LFS", Repairable: true, RepairHint: "Can be downloaded with 'git lfs pull'"},
+		},
+	}})
+	if len(actions) != 1 || actions[0].Type != RepairPullLFS || !actions[0].Safe {
+		t.Fatalf("GetRepairActions() = %+v, want one safe LFS pull", actions)
+	}
+
+	if res := repo.PerformRepair(actions[0]); !res.Success {
+		t.Fatalf("PerformRepair() failed: %v", res.Error)
+	}
+
+	var got []string
+	for _, cmd := range executor.Commands {
+		got = append(got, strings.Join(cmd, " "))
+	}
+
+	want := []string{"[in:/wt] lfs install --local", "[in:/wt] lfs pull"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("commands = %v, want %v", got, want)
+	}
+}
@@ -33,6 +33,13 @@ func DetectLockFiles(repoPath string) ([]LockFile, error) {
 
 	gitDir := filepath.Join(repoPath, ".git")
 
+	// A bare repository is its own git directory
+	if _, err := os.Stat(filepath.Join(repoPath, "objects")); err == nil {
+		if _, err := os.Stat(gitDir); os.IsNotExist(err) {
+			gitDir = repoPath
+		}
+	}
+
 	// Check if .git exists and is a directory
 	info, err := os.Stat(gitDir)
 	if err != nil {
@@ -4,6 +4,10 @@ package git
 import (
 	"fmt"
 	"math/rand"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 )
 
 // Word lists for generating random branch names
@@ -30,25 +34,191 @@ var (
 	}
 )
 
+// BranchNameFormat describes generated branch names: Prefix followed by Words
+// random words joined with Separator, e.g. work/coral-swift-zebra
+type BranchNameFormat struct {
+	Prefix    string
+	Words     int
+	Separator string
+	// Dictionary is a file of words, one per line, to draw from instead of
+	// the built-in color, adjective and animal lists
+	Dictionary string
+}
+
+// DefaultBranchNameFormat is work/color-adjective-animal
+func DefaultBranchNameFormat() BranchNameFormat {
+	return BranchNameFormat{Prefix: "work/", Words: 3, Separator: "-"}
+}
+
+// Generate returns a random name in this format
+func (f BranchNameFormat) Generate() (string, error) {
+	words := make([]string, 0, f.Words)
+
+	if f.Dictionary != "" {
+		dictionary, err := loadDictionary(f.Dictionary)
+		if err != nil {
+			return "", err
+		}
+
+		for i := 0; i < f.Words; i++ {
+			words = append(words, dictionary[rand.Intn(len(dictionary))])
+		}
+	} else {
+		for _, list := range builtinWordLists(f.Words) {
+			words = append(words, list[rand.Intn(len(list))])
+		}
+	}
+
+	return f.Prefix + strings.Join(words, f.Separator), nil
+}
+
+// builtinWordLists picks a word list per word: color-adjective-animal for
+// three, ending in the animal for fewer, with extra adjectives for more
+func builtinWordLists(n int) [][]string {
+	lists := [][]string{colors, adjectives, animals}
+	if n <= len(lists) {
+		return lists[len(lists)-max(n, 1):]
+	}
+
+	for len(lists) < n {
+		lists = append([][]string{adjectives}, lists...)
+	}
+
+	return lists
+}
+
+// loadDictionary reads a word list, skipping blank lines and # comments
+func loadDictionary(path string) ([]string, error) {
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, rest)
+		}
+	}
+
+	data, err := os.ReadFile(path) //nolint:gosec // path comes from the user's config
+	if err != nil {
+		return nil, fmt.Errorf("failed to read branch name dictionary: %w", err)
+	}
+
+	var words []string
+
+	for _, line := range strings.Split(string(data), "\n") {
+		if word := strings.TrimSpace(line); word != "" && !strings.HasPrefix(word, "#") {
+			words = append(words, SanitizeBranchName(word))
+		}
+	}
+
+	if len(words) == 0 {
+		return nil, fmt.Errorf("branch name dictionary %s has no words", path)
+	}
+
+	return words, nil
+}
+
+// IssueBranchPrefix gives issues with a label or issue type (Match, case-insensitive)
+// branches starting with Prefix instead of work/
+type IssueBranchPrefix struct {
+	Match  string
+	Prefix string
+}
+
+// ParseIssueBranchPrefixes parses "bug=fix/,enhancement=feat/". A prefix without
+// a trailing slash gets one. Invalid entries are skipped and reported in the error.
+func ParseIssueBranchPrefixes(value string) ([]IssueBranchPrefix, error) {
+	var (
+		rules   []IssueBranchPrefix
+		invalid []string
+	)
+
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		match, prefix, ok := strings.Cut(entry, "=")
+		match, prefix = strings.TrimSpace(match), strings.TrimSpace(prefix)
+
+		if !ok || match == "" || prefix == "" || strings.ContainsAny(prefix, " ~^:?*[\\") || strings.Contains(prefix, "..") {
+			invalid = append(invalid, entry)
+			continue
+		}
+
+		if !strings.HasSuffix(prefix, "/") {
+			prefix += "/"
+		}
+
+		rules = append(rules, IssueBranchPrefix{Match: match, Prefix: prefix})
+	}
+
+	if len(invalid) > 0 {
+		return rules, fmt.Errorf("invalid issue branch prefixes: %s (use label=prefix/, e.g. bug=fix/)", strings.Join(invalid, ", "))
+	}
+
+	return rules, nil
+}
+
+// BranchPrefixForIssue returns the prefix of the first rule matching one of the
+// labels or the issue type, or work/ when none does
+func BranchPrefixForIssue(rules []IssueBranchPrefix, labels []string, issueType string) string {
+	for _, rule := range rules {
+		if strings.EqualFold(rule.Match, issueType) {
+			return rule.Prefix
+		}
+
+		for _, label := range labels {
+			if strings.EqualFold(rule.Match, label) {
+				return rule.Prefix
+			}
+		}
+	}
+
+	return "work/"
+}
+
+// IssueBranchPrefixes lists the distinct prefixes of rules, for recognising issue branches
+func IssueBranchPrefixes(rules []IssueBranchPrefix) []string {
+	var prefixes []string
+
+	for _, rule := range rules {
+		if !slices.Contains(prefixes, rule.Prefix) {
+			prefixes = append(prefixes, rule.Prefix)
+		}
+	}
+
+	return prefixes
+}
+
 // RandomBranchName generates a random branch name using the pattern: work/color-adjective-animal
 // Example: work/coral-swift-zebra
 func RandomBranchName() string {
-	color := colors[rand.Intn(len(colors))]
-	adjective := adjectives[rand.Intn(len(adjectives))]
-	animal := animals[rand.Intn(len(animals))]
+	name, _ := DefaultBranchNameFormat().Generate() //nolint:errcheck // the built-in lists cannot fail
 
-	return fmt.Sprintf("work/%s-%s-%s", color, adjective, animal)
+	return name
 }
 
-// GenerateUniqueBranchName generates a unique branch name by checking against existing branches
-// It will try up to maxAttempts times before giving up
+// GenerateUniqueBranchName generates a unique branch name in the configured format
+// by checking against existing branches. It will try up to maxAttempts times before giving up
 func (r *Repository) GenerateUniqueBranchName(maxAttempts int) (string, error) {
+	format := DefaultBranchNameFormat()
+	if r.Config != nil {
+		format = r.Config.GetBranchNameFormat()
+	}
+
+	return r.GenerateUniqueBranchNameWithFormat(format, maxAttempts)
+}
+
+// GenerateUniqueBranchNameWithFormat generates a unique branch name in format
+func (r *Repository) GenerateUniqueBranchNameWithFormat(format BranchNameFormat, maxAttempts int) (string, error) {
 	if maxAttempts <= 0 {
 		maxAttempts = 100 // Default to 100 attempts
 	}
 
 	for i := 0; i < maxAttempts; i++ {
-		branchName := RandomBranchName()
+		branchName, err := format.Generate()
+		if err != nil {
+			return "", err
+		}
 
 		// Check if branch already exists
 		if !r.BranchExists(branchName) {
@@ -2,6 +2,9 @@ package git
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -163,6 +166,104 @@ func TestGenerateUniqueBranchNameMaxAttemptsExceeded(t *testing.T) {
 	}
 }
 
+func TestBranchNameFormatGenerate(t *testing.T) {
+	for _, words := range []int{1, 2, 3, 5} {
+		format := BranchNameFormat{Prefix: "feat/", Words: words, Separator: "_"}
+
+		name, err := format.Generate()
+		if err != nil {
+			t.Fatalf("Generate() error = %v", err)
+		}
+
+		rest, ok := strings.CutPrefix(name, "feat/")
+		if !ok {
+			t.Fatalf("Generate() = %q, want prefix feat/", name)
+		}
+
+		if parts := strings.Split(rest, "_"); len(parts) != words {
+			t.Errorf("Generate() = %q, want %d words", name, words)
+		}
+	}
+}
+
+func TestBranchNameFormatDictionary(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "words.txt")
+	if err := os.WriteFile(path, []byte("# team words\nalpha\n\nBeta\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	format := BranchNameFormat{Prefix: "x/", Words: 2, Separator: "-", Dictionary: path}
+	allowed := map[string]bool{"alpha": true, "beta": true}
+
+	for i := 0; i < 20; i++ {
+		name, err := format.Generate()
+		if err != nil {
+			t.Fatalf("Generate() error = %v", err)
+		}
+
+		for _, word := range strings.Split(strings.TrimPrefix(name, "x/"), "-") {
+			if !allowed[word] {
+				t.Errorf("Generate() = %q, %q is not a (sanitized) dictionary word", name, word)
+			}
+		}
+	}
+
+	empty := filepath.Join(t.TempDir(), "empty.txt")
+	if err := os.WriteFile(empty, []byte("# nothing\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := (BranchNameFormat{Words: 1, Dictionary: empty}).Generate(); err == nil {
+		t.Error("Generate() with an empty dictionary should fail")
+	}
+}
+
+func TestBuiltinWordLists(t *testing.T) {
+	if got := builtinWordLists(1); len(got) != 1 || got[0][0] != animals[0] {
+		t.Errorf("builtinWordLists(1) should be the animals list")
+	}
+
+	if got := builtinWordLists(5); len(got) != 5 || got[4][0] != animals[0] || got[0][0] != adjectives[0] {
+		t.Errorf("builtinWordLists(5) should pad with adjectives and end in animals")
+	}
+}
+
+func TestParseIssueBranchPrefixes(t *testing.T) {
+	rules, err := ParseIssueBranchPrefixes("bug=fix/, enhancement=feat,,bad")
+	if err == nil || !strings.Contains(err.Error(), "bad") {
+		t.Errorf("ParseIssueBranchPrefixes() error = %v, want it to report the bad entry", err)
+	}
+
+	want := []IssueBranchPrefix{{Match: "bug", Prefix: "fix/"}, {Match: "enhancement", Prefix: "feat/"}}
+	if !reflect.DeepEqual(rules, want) {
+		t.Errorf("ParseIssueBranchPrefixes() = %+v, want %+v", rules, want)
+	}
+}
+
+func TestBranchPrefixForIssue(t *testing.T) {
+	rules := []IssueBranchPrefix{{Match: "bug", Prefix: "fix/"}, {Match: "Story", Prefix: "feat/"}}
+
+	tests := []struct {
+		name      string
+		labels    []string
+		issueType string
+		want      string
+	}{
+		{"no match", []string{"docs"}, "", "work/"},
+		{"label", []string{"docs", "Bug"}, "", "fix/"},
+		{"issue type", nil, "story", "feat/"},
+		{"first rule wins", []string{"bug"}, "Story", "fix/"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := BranchPrefixForIssue(rules, tt.labels, tt.issueType); got != tt.want {
+				t.Errorf("BranchPrefixForIssue() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
 // customTestExecutor is a custom executor for testing that allows custom logic
 type customTestExecutor struct {
 	executeFunc      func(args ...string) (string, error)
@@ -182,3 +283,7 @@ func (e *customTestExecutor) ExecuteInDir(dir string, args ...string) (string, e
 	}
 	return "", nil
 }
+
+func (e *customTestExecutor) ExecuteInDirWithEnv(dir string, _ []string, args ...string) (string, error) {
+	return e.ExecuteInDir(dir, args...)
+}
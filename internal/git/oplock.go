@@ -0,0 +1,274 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// OperationLockFile is the name of the lock file auto-worktree keeps in the
+// git common directory while it changes a repository's worktrees
+const OperationLockFile = "auto-worktree.lock"
+
+// Waiting and staleness of the operation lock
+const (
+	// operationLockWait is how long to wait for another instance to finish
+	// before giving up; the operations it guards take seconds
+	operationLockWait = 10 * time.Second
+	// operationLockPoll is how often a held lock is checked while waiting
+	operationLockPoll = 200 * time.Millisecond
+	// operationLockStaleAge is when a lock whose process can't be checked,
+	// because it was taken on another host, is assumed to be left over
+	operationLockStaleAge = time.Hour
+	// operationLockGuardStaleAge is when the side file guarding the takeover
+	// of a stale lock, held for a moment, is assumed to be left by a crash
+	operationLockGuardStaleAge = operationLockWait
+)
+
+// operationLockGuardSuffix names the side file of a lock that instances
+// taking over the stale lock create in turn
+const operationLockGuardSuffix = ".takeover"
+
+// ErrOperationLocked is returned when another auto-worktree is changing the repository
+var ErrOperationLocked = errors.New("another auto-worktree is running")
+
+// operationLockHolder is what a lock file says about the process holding it
+type operationLockHolder struct {
+	PID       int
+	Host      string
+	Operation string
+	Since     time.Time
+}
+
+// String describes the holder for the "another instance" message
+func (h operationLockHolder) String() string {
+	desc := fmt.Sprintf("pid %d", h.PID)
+	if h.Host != "" && h.Host != hostname() {
+		desc += " on " + h.Host
+	}
+
+	if h.Operation != "" {
+		desc += ", " + h.Operation
+	}
+
+	if !h.Since.IsZero() {
+		desc += " since " + h.Since.Format("15:04:05")
+	}
+
+	return desc
+}
+
+// same reports whether h and other describe the same hold of a lock
+func (h operationLockHolder) same(other operationLockHolder) bool {
+	return h.PID == other.PID && h.Host == other.Host && h.Operation == other.Operation && h.Since.Equal(other.Since)
+}
+
+// stale reports whether the holder is gone: its process has exited, or it ran
+// on another host and the lock is older than operationLockStaleAge
+func (h operationLockHolder) stale(now time.Time) bool {
+	if h.Host == "" || h.Host == hostname() {
+		return !isProcessAlive(h.PID)
+	}
+
+	return now.Sub(h.Since) > operationLockStaleAge
+}
+
+// LockOperations takes the repository's operation lock for operation, waiting
+// a few seconds for another instance to finish, and returns the function that
+// releases it. A lock left by a process that is gone is taken over. Without a
+// lock directory (in tests, or with --host), or on the repository
+// WithOperationLock passes on, which already holds it, it does nothing.
+func (r *Repository) LockOperations(operation string) (func(), error) {
+	if r.lockDir == "" || r.holdsLock {
+		return func() {}, nil
+	}
+
+	return acquireOperationLock(filepath.Join(r.lockDir, OperationLockFile), operation, operationLockWait)
+}
+
+// WithOperationLock runs fn holding the operation lock, so a sequence such as
+// removing a worktree, deleting its branch and recording why happens without
+// another instance changing the repository in between. fn gets a copy of the
+// repository that holds the lock; changes made through it don't take it again.
+func (r *Repository) WithOperationLock(operation string, fn func(locked *Repository) error) error {
+	unlock, err := r.LockOperations(operation)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	locked := *r
+	locked.holdsLock = true
+
+	return fn(&locked)
+}
+
+// acquireOperationLock creates the lock file at path, waiting for another
+// holder to release it. The lock is not re-entrant: work nested in a locked
+// operation goes through the repository WithOperationLock passes on.
+func acquireOperationLock(path, operation string, wait time.Duration) (func(), error) {
+	deadline := time.Now().Add(wait)
+
+	for {
+		holder, err := createOperationLock(path, operation)
+		if err == nil {
+			return func() { releaseOperationLock(path) }, nil
+		}
+
+		if !errors.Is(err, os.ErrExist) {
+			return nil, fmt.Errorf("failed to create %s: %w", path, err)
+		}
+
+		if holder.stale(time.Now()) {
+			if err := takeOverStaleLock(path, holder); err != nil {
+				return nil, err
+			}
+
+			continue
+		}
+
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("%w (%s) and is changing this repository; try again when it finishes, or delete %s if it is stuck",
+				ErrOperationLocked, holder, path)
+		}
+
+		time.Sleep(operationLockPoll)
+	}
+}
+
+// takeOverStaleLock removes the stale lock at path, held by stale. Instances
+// taking it over at the same time take turns through a side file created
+// with O_EXCL, and each checks that the lock is still the stale one before
+// removing it: one removes it and creates its own lock, and the others then
+// find that lock held.
+func takeOverStaleLock(path string, stale operationLockHolder) error {
+	guard := path + operationLockGuardSuffix
+
+	f, err := os.OpenFile(guard, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644) //nolint:gosec // G302: like the lock file itself
+	if errors.Is(err, os.ErrExist) {
+		// Another instance is taking it over: wait for it, unless it died
+		// holding the side file, which is only ever held for a moment
+		if info, err := os.Stat(guard); err == nil && time.Since(info.ModTime()) > operationLockGuardStaleAge {
+			_ = os.Remove(guard) //nolint:errcheck // Another instance may have removed it first
+		} else {
+			time.Sleep(operationLockPoll)
+		}
+
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to take over the stale lock %s: %w", path, err)
+	}
+
+	defer func() {
+		_ = f.Close()        //nolint:errcheck // Nothing was written
+		_ = os.Remove(guard) //nolint:errcheck // Left behind, it is removed once old
+	}()
+
+	// Only a guard holder removes a lock whose holder is gone, so one still
+	// held by stale can't be replaced before it is removed here
+	if current := readOperationLock(path); !current.same(stale) {
+		return nil
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove the stale lock %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// createOperationLock creates the lock file, or returns who holds it
+func createOperationLock(path, operation string) (operationLockHolder, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644) //nolint:gosec // G302: other users' auto-worktree must be able to read who holds it
+	if err != nil {
+		if errors.Is(err, os.ErrExist) {
+			return readOperationLock(path), err
+		}
+
+		return operationLockHolder{}, err
+	}
+
+	holder := operationLockHolder{PID: os.Getpid(), Host: hostname(), Operation: operation, Since: time.Now()}
+
+	// The PID comes first, as in git's own lock files, so doctor --check-locks
+	// and maintain can tell whether it is stale
+	_, err = fmt.Fprintf(f, "%d\n%s\n%s\n%s\n", holder.PID, holder.Host, holder.Since.Format(time.RFC3339), holder.Operation)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		_ = os.Remove(path) //nolint:errcheck // Cleanup attempt on failure
+		return operationLockHolder{}, err
+	}
+
+	return holder, nil
+}
+
+// readOperationLock parses a lock file. One that can't be read or is being
+// written has no PID; it counts as stale only once it is old.
+func readOperationLock(path string) operationLockHolder {
+	var holder operationLockHolder
+
+	data, err := os.ReadFile(path) //nolint:gosec // G304: path is the lock file in the git directory
+	if err != nil {
+		return holder
+	}
+
+	lines := strings.SplitN(string(data), "\n", 4)
+	holder.PID, _ = strconv.Atoi(strings.TrimSpace(lines[0])) //nolint:errcheck // a bad PID counts as a dead process
+
+	if len(lines) > 1 {
+		holder.Host = strings.TrimSpace(lines[1])
+	}
+
+	if len(lines) > 2 {
+		holder.Since, _ = time.Parse(time.RFC3339, strings.TrimSpace(lines[2])) //nolint:errcheck // zero time is shown as unknown
+	}
+
+	if len(lines) > 3 {
+		holder.Operation = strings.TrimSpace(lines[3])
+	}
+
+	if holder.PID == 0 {
+		// Just created and not yet written, or garbage: wait for it to age
+		if info, err := os.Stat(path); err == nil && time.Since(info.ModTime()) < operationLockStaleAge {
+			holder.Host = "?"
+			holder.Since = info.ModTime()
+		}
+	}
+
+	return holder
+}
+
+// releaseOperationLock removes the lock file
+func releaseOperationLock(path string) {
+	_ = os.Remove(path) //nolint:errcheck // A lock left behind is taken over as stale
+}
+
+// hostname returns this machine's name, or "" if it is unknown
+func hostname() string {
+	name, _ := os.Hostname() //nolint:errcheck // "" means unknown
+	return name
+}
+
+// runLocked runs a git command in the repository that changes its worktrees
+// while holding the operation lock; hooks and other slow work run after it is
+// released
+func (r *Repository) runLocked(operation string, args ...string) error {
+	unlock, err := r.LockOperations(operation)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	_, err = r.executor.ExecuteInDir(r.RootPath, args...)
+
+	return err
+}
@@ -0,0 +1,244 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAcquireOperationLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), OperationLockFile)
+
+	unlock, err := acquireOperationLock(path, "removing a worktree", 0)
+	if err != nil {
+		t.Fatalf("acquireOperationLock() error = %v", err)
+	}
+
+	holder := readOperationLock(path)
+	if holder.PID != os.Getpid() || holder.Operation != "removing a worktree" {
+		t.Errorf("lock holder = %+v, want this process removing a worktree", holder)
+	}
+
+	// The lock is not re-entrant: a second hold, even in this process, waits
+	if _, err := acquireOperationLock(path, "pruning worktrees", 0); !errors.Is(err, ErrOperationLocked) {
+		t.Errorf("second acquireOperationLock() error = %v, want ErrOperationLocked", err)
+	}
+
+	unlock()
+
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Error("lock file should be removed once released")
+	}
+}
+
+func TestAcquireOperationLockTakesOverStaleLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), OperationLockFile)
+
+	// PIDs this high aren't in use, so the holder counts as gone
+	stale := fmt.Sprintf("999999\n%s\n%s\ncreating a worktree\n", hostname(), time.Now().Format(time.RFC3339))
+	if err := os.WriteFile(path, []byte(stale), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	unlock, err := acquireOperationLock(path, "pruning worktrees", 0)
+	if err != nil {
+		t.Fatalf("acquireOperationLock() error = %v, want the stale lock taken over", err)
+	}
+	defer unlock()
+
+	if holder := readOperationLock(path); holder.PID != os.Getpid() {
+		t.Errorf("lock PID = %d, want %d", holder.PID, os.Getpid())
+	}
+}
+
+func TestAcquireOperationLockHeldByAnotherInstance(t *testing.T) {
+	path := filepath.Join(t.TempDir(), OperationLockFile)
+
+	// The test's parent process stands in for another running instance
+	held := fmt.Sprintf("%d\n%s\n%s\ncreating a worktree\n", os.Getppid(), hostname(), time.Now().Format(time.RFC3339))
+	if err := os.WriteFile(path, []byte(held), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := acquireOperationLock(path, "removing a worktree", 10*time.Millisecond)
+	if !errors.Is(err, ErrOperationLocked) {
+		t.Fatalf("acquireOperationLock() error = %v, want ErrOperationLocked", err)
+	}
+
+	for _, want := range []string{fmt.Sprintf("pid %d", os.Getppid()), "creating a worktree", path} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q should mention %q", err, want)
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil || string(data) != held {
+		t.Error("a lock held by a running instance must be left alone")
+	}
+}
+
+func TestOperationLockHolderStale(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name   string
+		holder operationLockHolder
+		want   bool
+	}{
+		{"this process", operationLockHolder{PID: os.Getpid(), Host: hostname()}, false},
+		{"exited process", operationLockHolder{PID: 999999, Host: hostname()}, true},
+		{"other host, recent", operationLockHolder{PID: 1, Host: "elsewhere", Since: now.Add(-time.Minute)}, false},
+		{"other host, old", operationLockHolder{PID: 1, Host: "elsewhere", Since: now.Add(-2 * time.Hour)}, true},
+	}
+
+	for _, tt := range tests {
+		if got := tt.holder.stale(now); got != tt.want {
+			t.Errorf("%s: stale() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestLockOperationsWithoutLockDir(t *testing.T) {
+	repo := &Repository{RootPath: t.TempDir()}
+
+	unlock, err := repo.LockOperations("pruning worktrees")
+	if err != nil {
+		t.Fatalf("LockOperations() error = %v", err)
+	}
+
+	unlock()
+}
+
+func TestWithOperationLock(t *testing.T) {
+	fakeExec := NewFakeGitExecutor()
+	repo := &Repository{RootPath: "/test/repo", lockDir: t.TempDir(), executor: fakeExec, filesystem: NewFakeFileSystem()}
+	path := filepath.Join(repo.lockDir, OperationLockFile)
+
+	err := repo.WithOperationLock("removing a worktree", func(locked *Repository) error {
+		if holder := readOperationLock(path); holder.Operation != "removing a worktree" {
+			t.Errorf("lock holder = %+v, want it held while fn runs", holder)
+		}
+
+		// Changes through the locked repository run under the same hold
+		if err := locked.RemoveWorktree("/wt/feature"); err != nil {
+			return err
+		}
+
+		return locked.DeleteBranch("feature")
+	})
+	if err != nil {
+		t.Fatalf("WithOperationLock() error = %v", err)
+	}
+
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Error("lock file should be removed once fn returns")
+	}
+
+	if repo.holdsLock {
+		t.Error("WithOperationLock() must not mark the caller's repository as holding the lock")
+	}
+}
+
+func TestTakeOverStaleLockChecksItIsStillStale(t *testing.T) {
+	path := filepath.Join(t.TempDir(), OperationLockFile)
+
+	stale := operationLockHolder{PID: 999999, Host: hostname(), Since: time.Now().Add(-time.Minute).Truncate(time.Second)}
+
+	// Another instance took the stale lock over and holds it now
+	fresh := fmt.Sprintf("%d\n%s\n%s\ncreating a worktree\n", os.Getppid(), hostname(), time.Now().Format(time.RFC3339))
+	if err := os.WriteFile(path, []byte(fresh), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := takeOverStaleLock(path, stale); err != nil {
+		t.Fatalf("takeOverStaleLock() error = %v", err)
+	}
+
+	if data, err := os.ReadFile(path); err != nil || string(data) != fresh {
+		t.Error("a lock taken over by another instance must be left alone")
+	}
+
+	if _, err := os.Stat(path + operationLockGuardSuffix); !errors.Is(err, os.ErrNotExist) {
+		t.Error("the takeover side file should be removed")
+	}
+}
+
+func TestTakeOverStaleLockWaitsForAnotherTakeover(t *testing.T) {
+	path := filepath.Join(t.TempDir(), OperationLockFile)
+	guard := path + operationLockGuardSuffix
+
+	stale := fmt.Sprintf("999999\n%s\n%s\ncreating a worktree\n", hostname(), time.Now().Format(time.RFC3339))
+	if err := os.WriteFile(path, []byte(stale), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(guard, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := takeOverStaleLock(path, readOperationLock(path)); err != nil {
+		t.Fatalf("takeOverStaleLock() error = %v", err)
+	}
+
+	if _, err := os.Stat(path); err != nil {
+		t.Error("the stale lock should be left to the instance taking it over")
+	}
+
+	// A side file left by a crash is removed once old
+	old := time.Now().Add(-2 * operationLockGuardStaleAge)
+	if err := os.Chtimes(guard, old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := takeOverStaleLock(path, readOperationLock(path)); err != nil {
+		t.Fatalf("takeOverStaleLock() error = %v", err)
+	}
+
+	if _, err := os.Stat(guard); !errors.Is(err, os.ErrNotExist) {
+		t.Error("an old takeover side file should be removed")
+	}
+}
+
+func TestAcquireOperationLockDoesNotBlockOthersWhileWaiting(t *testing.T) {
+	dir := t.TempDir()
+	busy := filepath.Join(dir, "busy.lock")
+
+	held := fmt.Sprintf("%d\n%s\n%s\ncreating a worktree\n", os.Getppid(), hostname(), time.Now().Format(time.RFC3339))
+	if err := os.WriteFile(busy, []byte(held), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	waiting := make(chan error)
+
+	go func() {
+		_, err := acquireOperationLock(busy, "removing a worktree", time.Second)
+		waiting <- err
+	}()
+
+	time.Sleep(2 * operationLockPoll)
+
+	acquired := make(chan struct{})
+
+	go func() {
+		unlock, err := acquireOperationLock(filepath.Join(dir, OperationLockFile), "pruning worktrees", 0)
+		if err == nil {
+			unlock()
+		}
+
+		close(acquired)
+	}()
+
+	select {
+	case <-acquired:
+	case <-waiting:
+		t.Fatal("another lock should be taken while one is waited for")
+	}
+
+	if err := <-waiting; !errors.Is(err, ErrOperationLocked) {
+		t.Errorf("waiting acquireOperationLock() error = %v, want ErrOperationLocked", err)
+	}
+}
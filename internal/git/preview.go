@@ -0,0 +1,128 @@
+package git
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// WorktreePreview summarizes a worktree's work, so a cleanup candidate can be
+// judged without opening it
+type WorktreePreview struct {
+	// LastCommit is the subject of the commit checked out in the worktree
+	LastCommit string
+	// Target is the default branch the diffstat is against
+	Target       string
+	FilesChanged int
+	Insertions   int
+	Deletions    int
+}
+
+// DiffStat renders the change against the default branch, e.g. "3 files changed, +40 -12 vs main"
+func (p WorktreePreview) DiffStat() string {
+	if p.Target == "" {
+		return ""
+	}
+
+	if p.FilesChanged == 0 {
+		return "no changes vs " + p.Target
+	}
+
+	files := "files"
+	if p.FilesChanged == 1 {
+		files = "file"
+	}
+
+	return fmt.Sprintf("%d %s changed, +%d -%d vs %s", p.FilesChanged, files, p.Insertions, p.Deletions, p.Target)
+}
+
+// PreviewWorktree reads the last commit subject of a worktree and its diffstat
+// against the default branch since they diverged. Either part is left empty
+// when git cannot provide it.
+func (r *Repository) PreviewWorktree(wt *Worktree) WorktreePreview {
+	var preview WorktreePreview
+
+	if subject, err := r.executor.ExecuteInDir(wt.Path, "log", "-1", "--format=%s"); err == nil {
+		preview.LastCommit = strings.TrimSpace(subject)
+	}
+
+	target, err := r.ConflictTarget()
+	if err != nil {
+		return preview
+	}
+
+	output, err := r.executor.ExecuteInDir(wt.Path, "diff", "--shortstat", target+"...HEAD")
+	if err != nil {
+		return preview
+	}
+
+	preview.Target = strings.TrimPrefix(target, "origin/")
+	preview.FilesChanged, preview.Insertions, preview.Deletions = parseShortstat(output)
+
+	return preview
+}
+
+var shortstatPattern = regexp.MustCompile(`(\d+) (files? changed|insertions?\(\+\)|deletions?\(-\))`)
+
+// parseShortstat reads git diff --shortstat output such as
+// " 3 files changed, 40 insertions(+), 12 deletions(-)"; parts git leaves out are zero
+func parseShortstat(output string) (files, insertions, deletions int) {
+	for _, match := range shortstatPattern.FindAllStringSubmatch(output, -1) {
+		n, err := strconv.Atoi(match[1])
+		if err != nil {
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(match[2], "file"):
+			files = n
+		case strings.HasPrefix(match[2], "insertion"):
+			insertions = n
+		default:
+			deletions = n
+		}
+	}
+
+	return files, insertions, deletions
+}
+
+// FileStat is how much one file changed; binary files have no line counts
+type FileStat struct {
+	Path       string
+	Insertions int
+	Deletions  int
+	Binary     bool
+}
+
+// BranchFileStats returns the files changed on HEAD in the worktree since it
+// diverged from target, with their line counts
+func (r *Repository) BranchFileStats(worktreePath, target string) ([]FileStat, error) {
+	output, err := r.executor.ExecuteInDir(worktreePath, "diff", "--numstat", target+"...HEAD")
+	if err != nil {
+		return nil, fmt.Errorf("failed to diff against %s: %w", target, err)
+	}
+
+	return parseNumstat(output), nil
+}
+
+// parseNumstat reads git diff --numstat output, one "insertions<TAB>deletions<TAB>path"
+// line per file, with "-" for both counts of a binary file
+func parseNumstat(output string) []FileStat {
+	var stats []FileStat
+
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.SplitN(line, "\t", 3)
+		if len(fields) != 3 {
+			continue
+		}
+
+		stat := FileStat{Path: fields[2], Binary: fields[0] == "-"}
+		stat.Insertions, _ = strconv.Atoi(fields[0]) //nolint:errcheck // "-" for binary files
+		stat.Deletions, _ = strconv.Atoi(fields[1])  //nolint:errcheck // "-" for binary files
+
+		stats = append(stats, stat)
+	}
+
+	return stats
+}
@@ -0,0 +1,89 @@
+package git
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseShortstat(t *testing.T) {
+	tests := []struct {
+		output                       string
+		files, insertions, deletions int
+	}{
+		{" 3 files changed, 40 insertions(+), 12 deletions(-)", 3, 40, 12},
+		{" 1 file changed, 1 insertion(+)", 1, 1, 0},
+		{" 2 files changed, 5 deletions(-)", 2, 0, 5},
+		{"", 0, 0, 0},
+	}
+
+	for _, tt := range tests {
+		files, insertions, deletions := parseShortstat(tt.output)
+		if files != tt.files || insertions != tt.insertions || deletions != tt.deletions {
+			t.Errorf("parseShortstat(%q) = %d, %d, %d; want %d, %d, %d", tt.output,
+				files, insertions, deletions, tt.files, tt.insertions, tt.deletions)
+		}
+	}
+}
+
+func TestPreviewWorktree(t *testing.T) {
+	executor := NewFakeGitExecutor()
+
+	repo, err := NewRepositoryFromPathWithDeps("/fake/repo", executor, NewFakeFileSystem())
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+
+	executor.Responses["symbolic-ref refs/remotes/origin/HEAD"] = "refs/remotes/origin/main"
+	executor.Responses["log -1 --format=%s"] = "Add retry to the sync job"
+	executor.Responses["diff --shortstat origin/main...HEAD"] = " 3 files changed, 40 insertions(+), 12 deletions(-)"
+
+	preview := repo.PreviewWorktree(&Worktree{Path: "/fake/worktrees/retry", Branch: "work/retry"})
+
+	if preview.LastCommit != "Add retry to the sync job" {
+		t.Errorf("LastCommit = %q", preview.LastCommit)
+	}
+
+	if got, want := preview.DiffStat(), "3 files changed, +40 -12 vs main"; got != want {
+		t.Errorf("DiffStat() = %q, want %q", got, want)
+	}
+
+	executor.Errors["diff --shortstat origin/main...HEAD"] = errors.New("bad revision")
+
+	if got := repo.PreviewWorktree(&Worktree{Path: "/fake/worktrees/retry"}).DiffStat(); got != "" {
+		t.Errorf("DiffStat() without a diff = %q, want empty", got)
+	}
+}
+
+func TestWorktreePreview_DiffStat(t *testing.T) {
+	if got := (WorktreePreview{Target: "main"}).DiffStat(); got != "no changes vs main" {
+		t.Errorf("DiffStat() = %q", got)
+	}
+
+	if got := (WorktreePreview{Target: "main", FilesChanged: 1, Insertions: 2}).DiffStat(); got != "1 file changed, +2 -0 vs main" {
+		t.Errorf("DiffStat() = %q", got)
+	}
+}
+
+func TestParseNumstat(t *testing.T) {
+	stats := parseNumstat("10\t2\tapi/handler.go\n-\t-\tassets/logo.png\n0\t7\tdocs/old.md\n")
+
+	want := []FileStat{
+		{Path: "api/handler.go", Insertions: 10, Deletions: 2},
+		{Path: "assets/logo.png", Binary: true},
+		{Path: "docs/old.md", Deletions: 7},
+	}
+
+	if len(stats) != len(want) {
+		t.Fatalf("parseNumstat() = %+v, want %+v", stats, want)
+	}
+
+	for i := range want {
+		if stats[i] != want[i] {
+			t.Errorf("parseNumstat()[%d] = %+v, want %+v", i, stats[i], want[i])
+		}
+	}
+
+	if stats := parseNumstat(""); stats != nil {
+		t.Errorf("parseNumstat(\"\") = %+v, want nil", stats)
+	}
+}
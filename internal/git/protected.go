@@ -0,0 +1,34 @@
+package git
+
+import "path"
+
+// IsProtectedBranch reports whether branch matches one of patterns (e.g. "release/*").
+// A * does not cross a /, as in shell globs.
+func IsProtectedBranch(branch string, patterns []string) bool {
+	if branch == "" {
+		return false
+	}
+
+	for _, pattern := range patterns {
+		if matched, err := path.Match(pattern, branch); err == nil && matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ProtectedBranchPatterns returns the configured protected branch patterns plus
+// the repository's default branch, which is always protected
+func (r *Repository) ProtectedBranchPatterns() []string {
+	var patterns []string
+	if r.Config != nil {
+		patterns = r.Config.GetProtectedBranches()
+	}
+
+	if defaultBranch, err := r.GetDefaultBranch(); err == nil {
+		patterns = append(patterns, defaultBranch)
+	}
+
+	return patterns
+}
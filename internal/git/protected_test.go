@@ -0,0 +1,52 @@
+package git
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIsProtectedBranch(t *testing.T) {
+	patterns := []string{"main", "master", "release/*"}
+
+	tests := []struct {
+		branch string
+		want   bool
+	}{
+		{"main", true},
+		{"master", true},
+		{"release/1.0", true},
+		{"release/1.0/hotfix", false},
+		{"work/42-fix-main", false},
+		{"mainline", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsProtectedBranch(tt.branch, patterns); got != tt.want {
+			t.Errorf("IsProtectedBranch(%q) = %v, want %v", tt.branch, got, tt.want)
+		}
+	}
+}
+
+func TestProtectedWorktreeIsNeverCleanedUp(t *testing.T) {
+	wt := &Worktree{
+		Path:           "/tmp/wt/release",
+		Branch:         "release/1.0",
+		IsBranchMerged: true,
+		LastCommitTime: time.Now().Add(-30 * 24 * time.Hour),
+		IsProtected:    true,
+	}
+
+	if wt.ShouldCleanup() {
+		t.Error("a protected worktree should never be a cleanup candidate")
+	}
+
+	if reason := wt.CleanupReason(); reason != "" {
+		t.Errorf("CleanupReason() = %q, want none for a protected worktree", reason)
+	}
+
+	wt.IsProtected = false
+	if !wt.ShouldCleanup() {
+		t.Error("the same worktree on an unprotected branch should be a cleanup candidate")
+	}
+}
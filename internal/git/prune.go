@@ -0,0 +1,108 @@
+package git
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/kaeawc/auto-worktree/internal/events"
+)
+
+// gcWorktreePruneExpire is git's setting for how long the records of missing
+// worktrees are kept; prune honors it when no expiry is given
+const gcWorktreePruneExpire = "gc.worktreePruneExpire"
+
+// PruneEntry is a worktree record, or administrative entry under
+// .git/worktrees, that `git worktree prune` removes
+type PruneEntry struct {
+	// Name is the entry's directory under .git/worktrees
+	Name string
+	// Path is the worktree the entry was for, or "" if git no longer knows it
+	Path string
+	// Reason is git's, e.g. "gitdir file points to non-existent location"
+	Reason string
+}
+
+// WorktreePruneExpire returns the configured gc.worktreePruneExpire, or "" when unset
+func (r *Repository) WorktreePruneExpire() string {
+	expire, err := r.executor.ExecuteInDir(r.RootPath, "config", "--get", gcWorktreePruneExpire)
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimSpace(expire)
+}
+
+// PrunableWorktrees lists the entries PruneWorktreesExpire(expire) would remove
+func (r *Repository) PrunableWorktrees(expire string) ([]PruneEntry, error) {
+	args := append([]string{"worktree", "prune", "--dry-run", "--verbose"}, pruneExpireArgs(expire)...)
+
+	output, err := r.executor.ExecuteInDir(r.RootPath, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list prunable worktrees: %w", err)
+	}
+
+	entries := parsePruneOutput(output)
+	for i := range entries {
+		entries[i].Path = r.prunedWorktreePath(entries[i].Name)
+	}
+
+	return entries, nil
+}
+
+// PruneWorktreesExpire removes the entries of missing worktrees older than
+// expire, a git date such as "2.weeks.ago" or "never"; "" removes them all
+func (r *Repository) PruneWorktreesExpire(expire string) error {
+	args := append([]string{"worktree", "prune"}, pruneExpireArgs(expire)...)
+
+	err := r.runLocked("pruning worktrees", args...)
+	r.recordEvent(events.ActionPrune, "", "", expire, err)
+
+	if err != nil {
+		return fmt.Errorf("failed to prune worktrees: %w", err)
+	}
+
+	return nil
+}
+
+// prunedWorktreePath reads the worktree path an entry's gitdir file points
+// at, or returns "" when it has none
+func (r *Repository) prunedWorktreePath(name string) string {
+	content, err := r.filesystem.ReadFile(filepath.Join(r.GitDir(), "worktrees", name, "gitdir"))
+	if err != nil {
+		return ""
+	}
+
+	gitdir := strings.TrimSpace(string(content))
+	if gitdir == "" {
+		return ""
+	}
+
+	return filepath.Dir(gitdir)
+}
+
+func pruneExpireArgs(expire string) []string {
+	if expire == "" {
+		return nil
+	}
+
+	return []string{"--expire", expire}
+}
+
+// parsePruneOutput reads the entries from `git worktree prune --verbose`,
+// whose lines look like "Removing worktrees/<name>: <reason>"
+func parsePruneOutput(output string) []PruneEntry {
+	var entries []PruneEntry
+
+	for _, line := range strings.Split(output, "\n") {
+		rest, ok := strings.CutPrefix(strings.TrimSpace(line), "Removing ")
+		if !ok {
+			continue
+		}
+
+		entry, reason, _ := strings.Cut(rest, ": ")
+		entries = append(entries, PruneEntry{Name: strings.TrimPrefix(entry, "worktrees/"), Reason: reason})
+	}
+
+	return entries
+}
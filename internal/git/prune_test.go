@@ -0,0 +1,63 @@
+package git
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPrunableWorktrees(t *testing.T) {
+	fake := NewFakeGitExecutor()
+	fake.SetResponse("worktree prune --dry-run --verbose --expire 2.weeks.ago",
+		"Removing worktrees/junk: gitdir file does not exist\n"+
+			"Removing worktrees/feature: gitdir file points to non-existent location")
+
+	fs := NewFakeFileSystem()
+	fs.Files["/home/user/repo/.git/worktrees/feature/gitdir"] = []byte("/home/user/worktrees/feature/.git\n")
+
+	repo := &Repository{RootPath: "/home/user/repo", executor: fake, filesystem: fs}
+
+	entries, err := repo.PrunableWorktrees("2.weeks.ago")
+	if err != nil {
+		t.Fatalf("PrunableWorktrees() error = %v", err)
+	}
+
+	want := []PruneEntry{
+		{Name: "junk", Reason: "gitdir file does not exist"},
+		{Name: "feature", Path: "/home/user/worktrees/feature", Reason: "gitdir file points to non-existent location"},
+	}
+
+	if len(entries) != len(want) {
+		t.Fatalf("PrunableWorktrees() = %+v, want %+v", entries, want)
+	}
+
+	for i := range want {
+		if entries[i] != want[i] {
+			t.Errorf("entry %d = %+v, want %+v", i, entries[i], want[i])
+		}
+	}
+
+	fake.SetError("worktree prune --dry-run --verbose --expire bogus", errors.New("malformed expiration date"))
+
+	if _, err := repo.PrunableWorktrees("bogus"); err == nil {
+		t.Error("PrunableWorktrees() with a bad expiry should fail")
+	}
+}
+
+func TestPruneWorktreesExpire(t *testing.T) {
+	fake := NewFakeGitExecutor()
+	repo := &Repository{RootPath: "/home/user/repo", executor: fake, filesystem: NewFakeFileSystem()}
+
+	if err := repo.PruneWorktreesExpire("3.months.ago"); err != nil {
+		t.Fatalf("PruneWorktreesExpire() error = %v", err)
+	}
+
+	if got := fake.GetLastCommand(); len(got) != 5 || got[3] != "--expire" || got[4] != "3.months.ago" {
+		t.Errorf("PruneWorktreesExpire() ran %v, want worktree prune --expire 3.months.ago", got)
+	}
+
+	fake.SetResponse("config --get gc.worktreePruneExpire", "1.week.ago")
+
+	if got := repo.WorktreePruneExpire(); got != "1.week.ago" {
+		t.Errorf("WorktreePruneExpire() = %q, want 1.week.ago", got)
+	}
+}
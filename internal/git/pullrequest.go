@@ -0,0 +1,177 @@
+package git
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/kaeawc/auto-worktree/internal/logging"
+)
+
+// PullRequestHead is where a pull request's commits are, for CheckoutPullRequest
+type PullRequestHead struct {
+	Number int
+	// Branch is the head branch, in origin or in the fork
+	Branch string
+	// ForkOwner and ForkName name the fork the pull request is from, and are
+	// empty for a pull request from a branch of origin
+	ForkOwner string
+	ForkName  string
+	// Pushable is set when commits can be pushed back to the head branch:
+	// always in origin, and in a fork when its author lets maintainers edit
+	Pushable bool
+	// Ref is where origin keeps the head: GitHub's refs/pull/<n>/head when
+	// empty, refs/merge-requests/<n>/head on GitLab
+	Ref string
+}
+
+// HeadRef returns the ref origin keeps the pull request's head under
+func (h PullRequestHead) HeadRef() string {
+	if h.Ref != "" {
+		return h.Ref
+	}
+
+	return "refs/pull/" + strconv.Itoa(h.Number) + "/head"
+}
+
+// IsFork reports whether the pull request is from a fork
+func (h PullRequestHead) IsFork() bool {
+	return h.ForkOwner != ""
+}
+
+// CheckoutPullRequest points branch, checked out in the new worktree at
+// worktreePath, at the head of a pull request and sets where it pulls from
+// and pushes to, as gh pr checkout does. A branch of origin is tracked there;
+// a fork's branch that maintainers may edit is tracked in a remote for the
+// fork, added if need be; any other fork's pull request pulls from its
+// refs/pull/<n>/head on origin. It returns the remote the branch pushes to,
+// or "" when it can't be pushed back.
+func (r *Repository) CheckoutPullRequest(worktreePath, branch string, head PullRequestHead) (string, error) {
+	remote, merge := "origin", "refs/heads/"+head.Branch
+	target, refspec := "refs/remotes/origin/"+head.Branch, "+"+merge+":refs/remotes/origin/"+head.Branch
+
+	if head.IsFork() {
+		// The head ref is on origin even when the fork is private or gone
+		merge = head.HeadRef()
+		target, refspec = "FETCH_HEAD", merge
+	}
+
+	if _, err := r.executor.ExecuteInDir(worktreePath, "fetch", "origin", refspec); err != nil {
+		return "", fmt.Errorf("failed to fetch PR #%d: %w", head.Number, err)
+	}
+
+	if _, err := r.executor.ExecuteInDir(worktreePath, "reset", "--hard", target); err != nil {
+		return "", fmt.Errorf("failed to check out PR #%d: %w", head.Number, err)
+	}
+
+	if head.IsFork() && head.Pushable {
+		if forkRemote, err := r.trackFork(worktreePath, head); err == nil {
+			remote, merge = forkRemote, "refs/heads/"+head.Branch
+		} else {
+			logging.Warn("could not track the PR's fork, so it can't be pushed back to", "fork", head.ForkOwner+"/"+head.ForkName, "err", err)
+			head.Pushable = false
+		}
+	}
+
+	settings := [][2]string{{"remote", remote}, {"merge", merge}}
+	if head.Pushable {
+		settings = append(settings, [2]string{"pushRemote", remote})
+	}
+
+	for _, setting := range settings {
+		if _, err := r.executor.ExecuteInDir(worktreePath, "config", "branch."+branch+"."+setting[0], setting[1]); err != nil {
+			return "", fmt.Errorf("failed to set the upstream of %s: %w", branch, err)
+		}
+	}
+
+	if !head.Pushable {
+		return "", nil
+	}
+
+	return remote, nil
+}
+
+// trackFork fetches the head branch from the fork's remote, adding a remote
+// named after the fork's owner unless one already points at the fork, and
+// returns the remote
+func (r *Repository) trackFork(worktreePath string, head PullRequestHead) (string, error) {
+	remote, err := r.forkRemote(head.ForkOwner, head.ForkName)
+	if err != nil {
+		return "", err
+	}
+
+	refspec := "+refs/heads/" + head.Branch + ":refs/remotes/" + remote + "/" + head.Branch
+	if _, err := r.executor.ExecuteInDir(worktreePath, "fetch", remote, refspec); err != nil {
+		return "", fmt.Errorf("failed to fetch %s from %s: %w", head.Branch, remote, err)
+	}
+
+	return remote, nil
+}
+
+// forkRemote returns the remote for owner/name, adding it with origin's host
+// and protocol if no remote points at it yet
+func (r *Repository) forkRemote(owner, name string) (string, error) {
+	output, err := r.executor.ExecuteInDir(r.RootPath, "config", "--get-regexp", `^remote\..*\.url$`)
+	if err != nil {
+		return "", fmt.Errorf("failed to list remotes: %w", err)
+	}
+
+	originURL := ""
+
+	for _, line := range strings.Split(output, "\n") {
+		key, url, ok := strings.Cut(strings.TrimSpace(line), " ")
+		if !ok {
+			continue
+		}
+
+		remote := strings.TrimSuffix(strings.TrimPrefix(key, "remote."), ".url")
+		if remoteOwner, remoteName := repoPath(url); strings.EqualFold(remoteOwner, owner) && strings.EqualFold(remoteName, name) {
+			return remote, nil
+		}
+
+		if remote == "origin" {
+			originURL = url
+		}
+	}
+
+	if originURL == "" {
+		return "", fmt.Errorf("origin has no URL to derive the fork's from")
+	}
+
+	url := forkURL(originURL, owner, name)
+	if _, err := r.executor.ExecuteInDir(r.RootPath, "remote", "add", owner, url); err != nil {
+		return "", fmt.Errorf("failed to add remote %s for %s: %w", owner, url, err)
+	}
+
+	return owner, nil
+}
+
+// repoPath returns the owner and name of the repository a remote URL points
+// at, e.g. acme and app for git@github.com:acme/app.git
+func repoPath(url string) (owner, name string) {
+	path := strings.TrimSuffix(strings.TrimSuffix(url, "/"), ".git")
+
+	slash := strings.LastIndex(path, "/")
+	if slash < 0 {
+		return "", ""
+	}
+
+	name, path = path[slash+1:], path[:slash]
+
+	return path[strings.LastIndexAny(path, "/:")+1:], name
+}
+
+// forkURL returns the URL of owner/name on the same host and with the same
+// protocol as originURL
+func forkURL(originURL, owner, name string) string {
+	path := strings.TrimSuffix(strings.TrimSuffix(originURL, "/"), ".git")
+
+	slash := strings.LastIndex(path, "/")
+	if slash < 0 {
+		return originURL
+	}
+
+	path = path[:slash]
+
+	return path[:strings.LastIndexAny(path, "/:")+1] + owner + "/" + name + ".git"
+}
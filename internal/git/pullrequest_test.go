@@ -0,0 +1,144 @@
+package git
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestCheckoutPullRequest(t *testing.T) {
+	remotes := "remote.origin.url git@github.com:acme/app.git\nremote.bob-fork.url https://github.com/bob/app-fork"
+
+	tests := []struct {
+		name       string
+		head       PullRequestHead
+		failFork   bool
+		wantRemote string
+		want       []string
+		notWant    []string
+	}{
+		{
+			name:       "branch of origin",
+			head:       PullRequestHead{Number: 7, Branch: "fix-login", Pushable: true},
+			wantRemote: "origin",
+			want: []string{
+				"fetch origin +refs/heads/fix-login:refs/remotes/origin/fix-login",
+				"reset --hard refs/remotes/origin/fix-login",
+				"config branch.pr/7-fix.remote origin",
+				"config branch.pr/7-fix.merge refs/heads/fix-login",
+				"config branch.pr/7-fix.pushRemote origin",
+			},
+		},
+		{
+			name:       "fork maintainers may edit",
+			head:       PullRequestHead{Number: 7, Branch: "fix-login", ForkOwner: "alice", ForkName: "app", Pushable: true},
+			wantRemote: "alice",
+			want: []string{
+				"fetch origin refs/pull/7/head",
+				"reset --hard FETCH_HEAD",
+				"remote add alice git@github.com:alice/app.git",
+				"fetch alice +refs/heads/fix-login:refs/remotes/alice/fix-login",
+				"config branch.pr/7-fix.remote alice",
+				"config branch.pr/7-fix.merge refs/heads/fix-login",
+				"config branch.pr/7-fix.pushRemote alice",
+			},
+		},
+		{
+			name:       "fork with a remote already",
+			head:       PullRequestHead{Number: 7, Branch: "fix-login", ForkOwner: "Bob", ForkName: "app-fork", Pushable: true},
+			wantRemote: "bob-fork",
+			want:       []string{"fetch bob-fork +refs/heads/fix-login:refs/remotes/bob-fork/fix-login", "config branch.pr/7-fix.pushRemote bob-fork"},
+			notWant:    []string{"remote add"},
+		},
+		{
+			name: "fork maintainers may not edit",
+			head: PullRequestHead{Number: 7, Branch: "fix-login", ForkOwner: "alice", ForkName: "app"},
+			want: []string{
+				"fetch origin refs/pull/7/head",
+				"config branch.pr/7-fix.remote origin",
+				"config branch.pr/7-fix.merge refs/pull/7/head",
+			},
+			notWant: []string{"remote add", "pushRemote"},
+		},
+		{
+			name: "GitLab merge request from a fork",
+			head: PullRequestHead{Number: 7, Branch: "fix-login", ForkOwner: "alice", Ref: "refs/merge-requests/7/head"},
+			want: []string{
+				"fetch origin refs/merge-requests/7/head",
+				"config branch.pr/7-fix.merge refs/merge-requests/7/head",
+			},
+			notWant: []string{"remote add", "pushRemote"},
+		},
+		{
+			name:     "fork that can't be fetched",
+			head:     PullRequestHead{Number: 7, Branch: "fix-login", ForkOwner: "alice", ForkName: "app", Pushable: true},
+			failFork: true,
+			want:     []string{"config branch.pr/7-fix.merge refs/pull/7/head"},
+			notWant:  []string{"pushRemote"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			executor := NewFakeGitExecutor()
+			executor.SetResponse(`config --get-regexp ^remote\..*\.url$`, remotes)
+
+			if tt.failFork {
+				executor.SetError("fetch alice +refs/heads/fix-login:refs/remotes/alice/fix-login", errors.New("repository not found"))
+			}
+
+			repo, err := NewRepositoryFromPathWithDeps("/fake/repo", executor, NewFakeFileSystem())
+			if err != nil {
+				t.Fatalf("Failed to create repository: %v", err)
+			}
+
+			remote, err := repo.CheckoutPullRequest("/wt/pr-7", "pr/7-fix", tt.head)
+			if err != nil {
+				t.Fatalf("CheckoutPullRequest() error = %v", err)
+			}
+
+			if remote != tt.wantRemote {
+				t.Errorf("CheckoutPullRequest() = %q, want push remote %q", remote, tt.wantRemote)
+			}
+
+			ran := make([]string, 0, len(executor.Commands))
+			for _, args := range executor.Commands {
+				ran = append(ran, strings.Join(args[1:], " "))
+			}
+
+			for _, want := range tt.want {
+				if !slices.Contains(ran, want) {
+					t.Errorf("expected %q, ran %q", want, ran)
+				}
+			}
+
+			for _, notWant := range tt.notWant {
+				if slices.ContainsFunc(ran, func(cmd string) bool { return strings.Contains(cmd, notWant) }) {
+					t.Errorf("expected no %q, ran %q", notWant, ran)
+				}
+			}
+		})
+	}
+}
+
+func TestForkURL(t *testing.T) {
+	tests := []struct {
+		origin string
+		want   string
+	}{
+		{"git@github.com:acme/app.git", "git@github.com:alice/app.git"},
+		{"https://github.com/acme/app", "https://github.com/alice/app.git"},
+		{"ssh://git@github.example.com/acme/app.git/", "ssh://git@github.example.com/alice/app.git"},
+	}
+
+	for _, tt := range tests {
+		if got := forkURL(tt.origin, "alice", "app"); got != tt.want {
+			t.Errorf("forkURL(%q) = %q, want %q", tt.origin, got, tt.want)
+		}
+
+		if owner, name := repoPath(tt.want); owner != "alice" || name != "app" {
+			t.Errorf("repoPath(%q) = %q, %q, want alice, app", tt.want, owner, name)
+		}
+	}
+}
@@ -0,0 +1,247 @@
+package git
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kaeawc/auto-worktree/internal/interrupt"
+	"github.com/kaeawc/auto-worktree/internal/remote"
+)
+
+// remoteMissingExit is the exit status the scripts below use for a missing path
+const remoteMissingExit = 44
+
+// RemoteFileSystem implements FileSystem on the --host machine with POSIX shell
+// commands over SSH. Stat only reports the name and whether a path is a directory.
+type RemoteFileSystem struct {
+	homeOnce sync.Once
+	home     string
+	homeErr  error
+}
+
+// run executes a script on the remote host and returns its standard output,
+// mapping the "missing" exit status to fs.ErrNotExist
+func (f *RemoteFileSystem) run(op, path, script string, stdin []byte) ([]byte, error) {
+	cmd := remote.Shell(interrupt.Context(), script)
+	if stdin != nil {
+		cmd.Stdin = bytes.NewReader(stdin)
+	}
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err == nil {
+		return out, nil
+	}
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == remoteMissingExit {
+		return nil, &fs.PathError{Op: op, Path: path, Err: fs.ErrNotExist}
+	}
+
+	if msg := strings.TrimSpace(stderr.String()); msg != "" {
+		err = fmt.Errorf("%w: %s", err, msg)
+	}
+
+	return nil, &fs.PathError{Op: op, Path: path, Err: err}
+}
+
+// MkdirAll creates a directory path
+func (f *RemoteFileSystem) MkdirAll(path string, perm os.FileMode) error {
+	_, err := f.run("mkdir", path, fmt.Sprintf("mkdir -p -m %o %s", perm.Perm(), remote.Quote(path)), nil)
+	return err
+}
+
+// Remove removes a file or empty directory
+func (f *RemoteFileSystem) Remove(path string) error {
+	q := remote.Quote(path)
+	_, err := f.run("remove", path, fmt.Sprintf("[ -e %s ] || [ -L %s ] || exit %d; if [ -d %s ]; then rmdir %s; else rm %s; fi",
+		q, q, remoteMissingExit, q, q, q), nil)
+
+	return err
+}
+
+// RemoveAll removes a path and any children it contains
+func (f *RemoteFileSystem) RemoveAll(path string) error {
+	_, err := f.run("removeall", path, "rm -rf "+remote.Quote(path), nil)
+	return err
+}
+
+// ReadFile reads the entire file
+func (f *RemoteFileSystem) ReadFile(path string) ([]byte, error) {
+	q := remote.Quote(path)
+	return f.run("open", path, fmt.Sprintf("[ -f %s ] || exit %d; cat %s", q, remoteMissingExit, q), nil)
+}
+
+// WriteFile writes data to a file
+func (f *RemoteFileSystem) WriteFile(path string, data []byte, perm os.FileMode) error {
+	q := remote.Quote(path)
+	_, err := f.run("open", path, fmt.Sprintf("cat > %s && chmod %o %s", q, perm.Perm(), q), data)
+
+	return err
+}
+
+// Stat returns file info
+func (f *RemoteFileSystem) Stat(path string) (os.FileInfo, error) {
+	q := remote.Quote(path)
+
+	out, err := f.run("stat", path, fmt.Sprintf("if [ -d %s ]; then echo d; elif [ -e %s ]; then echo f; else exit %d; fi",
+		q, q, remoteMissingExit), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return remoteFileInfo{name: filepath.Base(path), isDir: strings.TrimSpace(string(out)) == "d"}, nil
+}
+
+// Lstat returns file info without following a symlink
+func (f *RemoteFileSystem) Lstat(path string) (os.FileInfo, error) {
+	q := remote.Quote(path)
+
+	out, err := f.run("lstat", path, fmt.Sprintf("if [ -L %s ]; then echo l; elif [ -d %s ]; then echo d; elif [ -e %s ]; then echo f; else exit %d; fi",
+		q, q, q, remoteMissingExit), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	kind := strings.TrimSpace(string(out))
+
+	return remoteFileInfo{name: filepath.Base(path), isDir: kind == "d", isLink: kind == "l"}, nil
+}
+
+// Readlink returns the destination of a symlink
+func (f *RemoteFileSystem) Readlink(path string) (string, error) {
+	q := remote.Quote(path)
+
+	out, err := f.run("readlink", path, fmt.Sprintf("[ -L %s ] || exit %d; readlink %s", q, remoteMissingExit, q), nil)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(string(out), "\n"), nil
+}
+
+// Symlink creates newname as a symlink to oldname
+func (f *RemoteFileSystem) Symlink(oldname, newname string) error {
+	_, err := f.run("symlink", newname, "ln -s -- "+remote.Quote(oldname)+" "+remote.Quote(newname), nil)
+	return err
+}
+
+// UserHomeDir returns the remote user's home directory
+func (f *RemoteFileSystem) UserHomeDir() (string, error) {
+	f.homeOnce.Do(func() {
+		var out []byte
+
+		out, f.homeErr = f.run("home", "~", `printf %s "$HOME"`, nil)
+		f.home = string(out)
+
+		if f.homeErr == nil && f.home == "" {
+			f.homeErr = fmt.Errorf("$HOME is not set on %s", remote.Host())
+		}
+	})
+
+	return f.home, f.homeErr
+}
+
+// Walk walks the file tree in lexical order, like filepath.Walk
+func (f *RemoteFileSystem) Walk(root string, fn filepath.WalkFunc) error {
+	out, err := f.run("walk", root, "find "+remote.Quote(root)+` -type d -exec printf 'd %s\n' {} + -o -exec printf 'f %s\n' {} +`, nil)
+	if err != nil {
+		return fn(root, nil, err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+	sort.Slice(lines, func(i, j int) bool { return lines[i][2:] < lines[j][2:] })
+
+	var skipped []string
+
+	for _, line := range lines {
+		if len(line) < 3 {
+			continue
+		}
+
+		path := line[2:]
+		if isUnderAny(path, skipped) {
+			continue
+		}
+
+		info := remoteFileInfo{name: filepath.Base(path), isDir: line[0] == 'd'}
+
+		if err := fn(path, info, nil); err != nil {
+			if !errors.Is(err, filepath.SkipDir) {
+				return err
+			}
+
+			if !info.isDir {
+				// SkipDir on a file skips the rest of its directory
+				skipped = append(skipped, filepath.Dir(path))
+			} else {
+				skipped = append(skipped, path)
+			}
+		}
+	}
+
+	return nil
+}
+
+// isUnderAny reports whether path is one of dirs or inside one of them
+func isUnderAny(path string, dirs []string) bool {
+	for _, d := range dirs {
+		if path == d || strings.HasPrefix(path, d+"/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Exists checks if a path exists
+func (f *RemoteFileSystem) Exists(path string) bool {
+	_, err := f.Stat(path)
+	return err == nil
+}
+
+// Base returns the last element of path
+func (f *RemoteFileSystem) Base(path string) string {
+	return filepath.Base(path)
+}
+
+// Join joins path elements
+func (f *RemoteFileSystem) Join(elem ...string) string {
+	return filepath.Join(elem...)
+}
+
+// remoteFileInfo is the little RemoteFileSystem.Stat and Lstat know about a remote path
+type remoteFileInfo struct {
+	name   string
+	isDir  bool
+	isLink bool
+}
+
+func (i remoteFileInfo) Name() string       { return i.name }
+func (i remoteFileInfo) Size() int64        { return 0 }
+func (i remoteFileInfo) ModTime() time.Time { return time.Time{} }
+func (i remoteFileInfo) IsDir() bool        { return i.isDir }
+func (i remoteFileInfo) Sys() interface{}   { return nil }
+
+func (i remoteFileInfo) Mode() os.FileMode {
+	if i.isLink {
+		return os.ModeSymlink | 0o777
+	}
+
+	if i.isDir {
+		return os.ModeDir | 0o755
+	}
+
+	return 0o644
+}
@@ -0,0 +1,69 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Without --host the shell scripts run locally, which exercises them against a real directory
+func TestRemoteFileSystem(t *testing.T) {
+	dir := t.TempDir()
+	fs := &RemoteFileSystem{}
+
+	nested := filepath.Join(dir, "a", "b")
+	if err := fs.MkdirAll(nested, 0o755); err != nil {
+		t.Fatalf("MkdirAll() error = %v", err)
+	}
+
+	file := filepath.Join(nested, "it's here.txt")
+	if err := fs.WriteFile(file, []byte("data\n"), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	data, err := fs.ReadFile(file)
+	if err != nil || string(data) != "data\n" {
+		t.Fatalf("ReadFile() = %q, %v; want data", data, err)
+	}
+
+	if info, err := fs.Stat(nested); err != nil || !info.IsDir() {
+		t.Errorf("Stat(dir) = %v, %v; want a directory", info, err)
+	}
+
+	if _, err := fs.ReadFile(filepath.Join(dir, "missing")); !os.IsNotExist(err) {
+		t.Errorf("ReadFile(missing) error = %v, want not exist", err)
+	}
+
+	var walked []string
+
+	err = fs.Walk(dir, func(path string, _ os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, _ := filepath.Rel(dir, path) //nolint:errcheck // path is under dir
+		walked = append(walked, rel)
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Walk() error = %v", err)
+	}
+
+	if want := []string{".", "a", "a/b", "a/b/it's here.txt"}; len(walked) != len(want) || walked[3] != want[3] {
+		t.Errorf("Walk() visited %v, want %v", walked, want)
+	}
+
+	if err := fs.Remove(file); err != nil || fs.Exists(file) {
+		t.Errorf("Remove() error = %v, exists afterwards = %v", err, fs.Exists(file))
+	}
+
+	if err := fs.Remove(file); !os.IsNotExist(err) {
+		t.Errorf("Remove(missing) error = %v, want not exist", err)
+	}
+
+	home, err := fs.UserHomeDir()
+	if err != nil || home != os.Getenv("HOME") {
+		t.Errorf("UserHomeDir() = %q, %v; want $HOME", home, err)
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+
+	"github.com/kaeawc/auto-worktree/internal/events"
 )
 
 // RepairActionType identifies the type of repair action
@@ -15,6 +17,8 @@ const (
 	RepairPruneOrphan
 	RepairWorktreeLink
 	RepairRebuildIndex
+	RepairInitSubmodules
+	RepairPullLFS
 )
 
 func (t RepairActionType) String() string {
@@ -27,6 +31,10 @@ func (t RepairActionType) String() string {
 		return "Repair Worktree Link"
 	case RepairRebuildIndex:
 		return "Rebuild Git Index"
+	case RepairInitSubmodules:
+		return "Initialize Submodules"
+	case RepairPullLFS:
+		return "Pull LFS Objects"
 	default:
 		return "Unknown"
 	}
@@ -53,6 +61,9 @@ type RepairResult struct {
 func (r *Repository) GetRepairActions(results []*HealthCheckResult) []RepairAction {
 	var actions []RepairAction
 
+	linkRepaired := map[string]bool{}
+	submodules := map[string][]string{}
+
 	for _, result := range results {
 		for _, issue := range result.Issues {
 			if !issue.Repairable {
@@ -89,11 +100,13 @@ func (r *Repository) GetRepairActions(results []*HealthCheckResult) []RepairActi
 				}
 
 			case "Git Metadata":
-				if strings.Contains(issue.RepairHint, "git worktree repair") {
+				// One repair per worktree fixes every link issue found in it
+				if strings.Contains(issue.RepairHint, "git worktree repair") && !linkRepaired[result.WorktreePath] {
+					linkRepaired[result.WorktreePath] = true
 					actions = append(actions, RepairAction{
 						Type:         RepairWorktreeLink,
 						WorktreePath: result.WorktreePath,
-						Description:  "Repair worktree link",
+						Description:  fmt.Sprintf("Repair worktree link: git worktree repair %s", result.WorktreePath),
 						Target:       result.WorktreePath,
 						Safe:         true,
 					})
@@ -101,15 +114,32 @@ func (r *Repository) GetRepairActions(results []*HealthCheckResult) []RepairActi
 
 			case "Git Operations":
 				if strings.Contains(issue.RepairHint, "index rebuild") {
+					indexPath := r.indexPath(result.WorktreePath)
 					actions = append(actions, RepairAction{
 						Type:         RepairRebuildIndex,
 						WorktreePath: result.WorktreePath,
-						Description:  "Rebuild corrupted git index",
-						Target:       filepath.Join(result.WorktreePath, ".git", "index"),
+						Description:  fmt.Sprintf("Rebuild corrupted git index from HEAD (staged changes are lost; backup kept at %s.backup)", indexPath),
+						Target:       indexPath,
 						Safe:         false, // Index rebuild requires confirmation
 					})
 				}
 
+			case "Submodules":
+				if path, ok := strings.CutPrefix(issue.Description, "Uninitialized submodule: "); ok {
+					submodules[result.WorktreePath] = append(submodules[result.WorktreePath], path)
+				}
+
+			case "Git LFS":
+				if strings.Contains(issue.RepairHint, "git lfs pull") {
+					actions = append(actions, RepairAction{
+						Type:         RepairPullLFS,
+						WorktreePath: result.WorktreePath,
+						Description:  "Download LFS objects: git lfs pull",
+						Target:       result.WorktreePath,
+						Safe:         true,
+					})
+				}
+
 			case "Directory":
 				if strings.Contains(issue.RepairHint, "pruned") {
 					actions = append(actions, RepairAction{
@@ -124,6 +154,23 @@ func (r *Repository) GetRepairActions(results []*HealthCheckResult) []RepairActi
 		}
 	}
 
+	// One submodule update per worktree covers all of its uninitialized submodules
+	for _, result := range results {
+		paths := submodules[result.WorktreePath]
+		if len(paths) == 0 {
+			continue
+		}
+
+		delete(submodules, result.WorktreePath)
+		actions = append(actions, RepairAction{
+			Type:         RepairInitSubmodules,
+			WorktreePath: result.WorktreePath,
+			Description:  fmt.Sprintf("Initialize submodules: %s", strings.Join(paths, ", ")),
+			Target:       result.WorktreePath,
+			Safe:         true,
+		})
+	}
+
 	return actions
 }
 
@@ -166,16 +213,36 @@ func (r *Repository) PerformRepair(action RepairAction) RepairResult {
 		result.Error = r.performRebuildIndex(action)
 		if result.Error == nil {
 			result.Success = true
-			result.Message = "Successfully rebuilt git index"
+			result.Message = fmt.Sprintf("Successfully rebuilt git index (previous index saved to %s.backup)", action.Target)
 		} else {
 			result.Message = fmt.Sprintf("Failed to rebuild index: %v", result.Error)
 		}
 
+	case RepairInitSubmodules:
+		result.Error = r.performInitSubmodules(action)
+		if result.Error == nil {
+			result.Success = true
+			result.Message = "Successfully initialized submodules"
+		} else {
+			result.Message = fmt.Sprintf("Failed to initialize submodules: %v", result.Error)
+		}
+
+	case RepairPullLFS:
+		result.Error = r.PullLFS(action.Target)
+		if result.Error == nil {
+			result.Success = true
+			result.Message = "Successfully downloaded LFS objects"
+		} else {
+			result.Message = fmt.Sprintf("Failed to download LFS objects: %v", result.Error)
+		}
+
 	default:
 		result.Error = fmt.Errorf("unknown repair action type: %v", action.Type)
 		result.Message = result.Error.Error()
 	}
 
+	r.recordEvent(events.ActionRepair, action.WorktreePath, "", action.Type.String()+": "+action.Target, result.Error)
+
 	return result
 }
 
@@ -257,10 +324,15 @@ func (r *Repository) performPruneOrphan(action RepairAction) error {
 	return nil
 }
 
-// performRepairWorktreeLink repairs worktree links
+// performRepairWorktreeLink repairs the links between a worktree and its
+// metadata in both directions by running git worktree repair <path> from the main repository
 func (r *Repository) performRepairWorktreeLink(action RepairAction) error {
-	// Run git worktree repair
-	_, err := r.executor.Execute("worktree", "repair")
+	args := []string{"worktree", "repair"}
+	if action.Target != "" && action.Target != r.RootPath {
+		args = append(args, action.Target)
+	}
+
+	_, err := r.executor.ExecuteInDir(r.RootPath, args...)
 	if err != nil {
 		return fmt.Errorf("git worktree repair failed: %w", err)
 	}
@@ -268,28 +340,47 @@ func (r *Repository) performRepairWorktreeLink(action RepairAction) error {
 	return nil
 }
 
-// performRebuildIndex rebuilds a corrupted git index
-func (r *Repository) performRebuildIndex(action RepairAction) error {
-	worktreePath := action.WorktreePath
-
-	// First, try to backup the existing index
+// indexPath returns the index file of a worktree; linked worktrees keep theirs
+// in the administrative directory their .git file points to
+func (r *Repository) indexPath(worktreePath string) string {
 	indexPath := filepath.Join(worktreePath, ".git", "index")
-	backupPath := indexPath + ".backup"
 
-	// For linked worktrees, the index path is in the worktrees directory
 	if worktreePath != r.RootPath {
-		gitFilePath := filepath.Join(worktreePath, ".git")
-		content, err := r.filesystem.ReadFile(gitFilePath)
+		content, err := r.filesystem.ReadFile(filepath.Join(worktreePath, ".git"))
 		if err == nil {
 			gitdirLine := strings.TrimSpace(string(content))
 			if strings.HasPrefix(gitdirLine, "gitdir: ") {
 				gitDir := strings.TrimPrefix(gitdirLine, "gitdir: ")
+				if !filepath.IsAbs(gitDir) {
+					gitDir = filepath.Join(worktreePath, gitDir)
+				}
 				indexPath = filepath.Join(gitDir, "index")
-				backupPath = indexPath + ".backup"
 			}
 		}
 	}
 
+	return indexPath
+}
+
+// performInitSubmodules checks out the worktree's uninitialized submodules,
+// leaving submodules that are already checked out at whatever commit they are on
+func (r *Repository) performInitSubmodules(action RepairAction) error {
+	paths, err := r.UninitializedSubmodules(action.Target)
+	if err != nil || len(paths) == 0 {
+		return err
+	}
+
+	return r.InitSubmodules(action.Target, paths...)
+}
+
+// performRebuildIndex backs up a corrupted git index and rebuilds it from HEAD
+// (rm index && git read-tree HEAD). The backup is kept so staged work can be recovered.
+func (r *Repository) performRebuildIndex(action RepairAction) error {
+	worktreePath := action.WorktreePath
+
+	indexPath := r.indexPath(worktreePath)
+	backupPath := indexPath + ".backup"
+
 	// Backup the corrupted index by reading and writing
 	if indexContent, err := r.filesystem.ReadFile(indexPath); err == nil {
 		if err := r.filesystem.WriteFile(backupPath, indexContent, 0644); err != nil {
@@ -312,9 +403,6 @@ func (r *Repository) performRebuildIndex(action RepairAction) error {
 		return fmt.Errorf("failed to rebuild index: %w", err)
 	}
 
-	// If successful, remove the backup
-	r.filesystem.Remove(backupPath)
-
 	return nil
 }
 
@@ -1,6 +1,7 @@
 package git
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -237,3 +238,96 @@ func TestGetRepairActions_NonRepairableIgnored(t *testing.T) {
 		t.Errorf("GetRepairActions() returned action type %v, want %v", actions[0].Type, RepairRemoveStaleLock)
 	}
 }
+
+func TestGetRepairActions_GitMetadataOncePerWorktree(t *testing.T) {
+	repo := &Repository{RootPath: "/fake/repo"}
+
+	hint := "Can be repaired with 'git worktree repair'"
+	results := []*HealthCheckResult{
+		{
+			WorktreePath: "/fake/wt1",
+			Issues: []HealthCheckIssue{
+				{Category: "Git Metadata", Repairable: true, RepairHint: hint},
+				{Category: "Git Metadata", Repairable: true, RepairHint: hint},
+			},
+		},
+		{
+			WorktreePath: "/fake/wt2",
+			Issues: []HealthCheckIssue{
+				{Category: "Git Metadata", Repairable: true, RepairHint: hint},
+			},
+		},
+	}
+
+	actions := repo.GetRepairActions(results)
+
+	if len(actions) != 2 {
+		t.Fatalf("GetRepairActions() returned %d actions, want one per worktree", len(actions))
+	}
+}
+
+func TestPerformRepairWorktreeLink(t *testing.T) {
+	tests := []struct {
+		target string
+		want   string
+	}{
+		{"/fake/wt", "[in:/fake/repo] worktree repair /fake/wt"},
+		{"/fake/repo", "[in:/fake/repo] worktree repair"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			executor := NewFakeGitExecutor()
+			repo := &Repository{RootPath: "/fake/repo", executor: executor}
+
+			result := repo.PerformRepair(RepairAction{Type: RepairWorktreeLink, Target: tt.target, Safe: true})
+			if !result.Success {
+				t.Fatalf("PerformRepair() failed: %v", result.Error)
+			}
+
+			if len(executor.Commands) != 1 {
+				t.Fatalf("expected 1 command, got %v", executor.Commands)
+			}
+
+			if got := strings.Join(executor.Commands[0], " "); got != tt.want {
+				t.Errorf("command = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPerformRebuildIndex_LinkedWorktree(t *testing.T) {
+	executor := NewFakeGitExecutor()
+	fs := NewFakeFileSystem()
+	fs.Files["/fake/wt/.git"] = []byte("gitdir: /fake/repo/.git/worktrees/wt\n")
+	fs.Files["/fake/repo/.git/worktrees/wt/index"] = []byte("corrupt")
+
+	repo := &Repository{RootPath: "/fake/repo", executor: executor, filesystem: fs}
+
+	actions := repo.GetRepairActions([]*HealthCheckResult{{
+		WorktreePath: "/fake/wt",
+		Issues: []HealthCheckIssue{
+			{Category: "Git Operations", Repairable: true, RepairHint: "Can attempt index rebuild from HEAD"},
+		},
+	}})
+	if len(actions) != 1 || actions[0].Target != "/fake/repo/.git/worktrees/wt/index" {
+		t.Fatalf("GetRepairActions() = %+v, want one rebuild of the linked worktree's index", actions)
+	}
+
+	result := repo.PerformRepair(actions[0])
+	if !result.Success {
+		t.Fatalf("PerformRepair() failed: %v", result.Error)
+	}
+
+	if got := string(fs.Files["/fake/repo/.git/worktrees/wt/index.backup"]); got != "corrupt" {
+		t.Errorf("backup = %q, want the previous index kept", got)
+	}
+
+	if _, ok := fs.Files["/fake/repo/.git/worktrees/wt/index"]; ok {
+		t.Error("corrupt index should have been removed")
+	}
+
+	if got := strings.Join(executor.Commands[len(executor.Commands)-1], " "); got != "[in:/fake/wt] read-tree HEAD" {
+		t.Errorf("last command = %q, want read-tree HEAD in the worktree", got)
+	}
+}
@@ -3,14 +3,29 @@ package git
 import (
 	"context"
 	"fmt"
+	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
-	"sync"
+	"time"
 
+	"github.com/kaeawc/auto-worktree/internal/events"
+	"github.com/kaeawc/auto-worktree/internal/interrupt"
+	"github.com/kaeawc/auto-worktree/internal/limits"
+	"github.com/kaeawc/auto-worktree/internal/logging"
 	"github.com/kaeawc/auto-worktree/internal/perf"
 	"github.com/kaeawc/auto-worktree/internal/provider"
 	"github.com/kaeawc/auto-worktree/internal/providers"
+	"github.com/kaeawc/auto-worktree/internal/remote"
 )
 
+// worktreeBasePlaceholders are the placeholders of a worktree-base template
+var worktreeBasePlaceholders = []string{"{repo}", "{repo_parent}", branchPlaceholder}
+
+// branchPlaceholder stands for a worktree's sanitized branch name in a
+// worktree-base template
+const branchPlaceholder = "{branch}"
+
 // Repository represents a Git repository
 type Repository struct {
 	// RootPath is the absolute path to the git repository root
@@ -21,6 +36,27 @@ type Repository struct {
 	SourceFolder string
 	// Config provides access to git configuration for this repository
 	Config *Config
+	// InvokedFromWorktree is the linked worktree the repository was opened from,
+	// or empty when opened from the main working tree
+	InvokedFromWorktree string
+	// Bare is set when RootPath is a bare repository, which has no main working
+	// tree; every worktree is a linked one
+	Bare bool
+	// worktreeLeaf is a worktree's path under WorktreeBase, with {branch} for
+	// its sanitized branch name
+	worktreeLeaf string
+	// gitDir is the git common dir shared by every worktree; empty (in tests)
+	// falls back to RootPath/.git
+	gitDir string
+	// lockDir is where the operation lock is kept; empty (in tests and with
+	// --host) disables it
+	lockDir string
+	// holdsLock is set on the copy WithOperationLock passes on, whose worktree
+	// changes run under the lock it already holds
+	holdsLock bool
+	// eventsDisabled keeps the repository's changes out of the event log,
+	// for throwaway repositories such as the tour's
+	eventsDisabled bool
 	// executor handles git command execution
 	executor GitExecutor
 	// filesystem handles filesystem operations
@@ -36,7 +72,18 @@ func NewRepository() (*Repository, error) {
 func NewRepositoryFromPath(path string) (*Repository, error) {
 	executor := NewGitExecutor()
 	filesystem := NewFileSystem()
-	return NewRepositoryFromPathWithDeps(path, executor, filesystem)
+
+	repo, err := NewRepositoryFromPathWithDeps(path, executor, filesystem)
+	if err != nil {
+		return nil, err
+	}
+
+	// A remote repository's git directory is on another machine
+	if !remote.Enabled() {
+		repo.lockDir = repo.GitDir()
+	}
+
+	return repo, nil
 }
 
 // NewRepositoryWithDeps creates a Repository instance with provided dependencies
@@ -56,12 +103,26 @@ func NewRepositoryFromPathWithDeps(path string, executor GitExecutor, filesystem
 	rootPath, err := getRepositoryRoot(path, executor)
 	endGetRoot()
 
+	var (
+		invokedFrom string
+		bare        bool
+	)
+
 	if err != nil {
-		return nil, fmt.Errorf("not a git repository (or any of the parent directories): %s", path)
+		// A bare repository has no top level; it is the repository itself
+		rootPath, err = getBareRepositoryRoot(path, executor)
+		if err != nil {
+			return nil, fmt.Errorf("not a git repository (or any of the parent directories): %s", path)
+		}
+
+		bare = true
+	} else {
+		// When invoked from inside a linked worktree, operate on the main working tree
+		// so worktree paths, config and sessions are derived from the real repository
+		rootPath, invokedFrom, bare = resolveMainWorktree(rootPath, executor, filesystem)
 	}
 
-	// Get the source folder name
-	sourceFolder := filesystem.Base(rootPath)
+	sourceFolder := repositoryName(rootPath, bare)
 
 	// Construct worktree base path: ~/worktrees/<repo-name>
 	endHomeDir := perf.StartSpanWithParent("git-get-homedir", "git-repo-init-total")
@@ -71,22 +132,320 @@ func NewRepositoryFromPathWithDeps(path string, executor GitExecutor, filesystem
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user home directory: %w", err)
 	}
-	worktreeBase := filesystem.Join(homeDir, "worktrees", sourceFolder)
+	worktreeBase, worktreeLeaf := worktreeLocation(rootPath, sourceFolder, homeDir, bare, executor, filesystem)
 
 	endNewConfig := perf.StartSpanWithParent("git-new-config", "git-repo-init-total")
 	config := NewConfig(rootPath)
 	endNewConfig()
 
 	return &Repository{
-		RootPath:     rootPath,
-		WorktreeBase: worktreeBase,
-		SourceFolder: sourceFolder,
-		Config:       config,
-		executor:     executor,
-		filesystem:   filesystem,
+		RootPath:            rootPath,
+		WorktreeBase:        worktreeBase,
+		SourceFolder:        sourceFolder,
+		Config:              config,
+		executor:            executor,
+		filesystem:          filesystem,
+		InvokedFromWorktree: invokedFrom,
+		Bare:                bare,
+		worktreeLeaf:        worktreeLeaf,
+		gitDir:              resolveCommonDir(rootPath, bare, executor, filesystem),
 	}, nil
 }
 
+// ForCleanup returns a copy of the repository whose git commands still run
+// after Ctrl-C, for undoing what an interrupted operation had started
+func (r *Repository) ForCleanup() *Repository {
+	cleanup := *r
+
+	if _, ok := r.executor.(*RealGitExecutor); ok {
+		cleanup.executor = &RealGitExecutor{ctx: context.WithoutCancel(interrupt.Context())}
+	}
+
+	return &cleanup
+}
+
+// WorktreePath returns where the worktree for branch is created
+func (r *Repository) WorktreePath(branch string) string {
+	leaf := r.worktreeLeaf
+	if leaf == "" {
+		leaf = branchPlaceholder
+	}
+
+	return filepath.Join(r.WorktreeBase, strings.ReplaceAll(leaf, branchPlaceholder, SanitizeBranchName(branch)))
+}
+
+// repositoryName returns the name worktree folders and sessions are derived
+// from: the main working tree's folder, or for a bare repository its folder
+// without .git, or the folder holding it when it is a .bare or .git directory
+func repositoryName(rootPath string, bare bool) string {
+	name := filepath.Base(rootPath)
+	if !bare {
+		return name
+	}
+
+	if name == ".bare" || name == ".git" {
+		return filepath.Base(filepath.Dir(rootPath))
+	}
+
+	return strings.TrimSuffix(name, ".git")
+}
+
+// GitDir returns the repository's git directory, shared by every worktree
+func (r *Repository) GitDir() string {
+	if r.gitDir != "" {
+		return r.gitDir
+	}
+
+	if r.Bare {
+		return r.RootPath
+	}
+
+	return r.filesystem.Join(r.RootPath, ".git")
+}
+
+// configuredWorktreeBase returns the auto-worktree.worktree-base setting, or ""
+func configuredWorktreeBase(rootPath string, executor GitExecutor) string {
+	configured, err := executor.ExecuteInDir(rootPath, "config", "--get", ConfigWorktreeBase)
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimSpace(configured)
+}
+
+// worktreeLocation returns the worktree base and a worktree's path under it.
+// A worktree-base with placeholders is a template for the whole path, such as
+// {repo_parent}/{repo}-worktrees/{branch}; /{branch} is added when it has none,
+// and the base is the directory above the first folder named after the branch.
+// Without placeholders it holds per-repository folders, as ~/worktrees does by
+// default; a bare clone's worktrees are kept beside it instead.
+func worktreeLocation(rootPath, repoName, homeDir string, bare bool, executor GitExecutor, filesystem FileSystem) (string, string) {
+	configured := configuredWorktreeBase(rootPath, executor)
+
+	switch {
+	case configured == "" && bare:
+		return filepath.Dir(rootPath), branchPlaceholder
+	case !strings.Contains(configured, "{"):
+		return filesystem.Join(parentDirOf(configured, rootPath, homeDir, filesystem), repoName), branchPlaceholder
+	}
+
+	template := strings.NewReplacer(
+		"{repo_parent}", filepath.ToSlash(filepath.Dir(rootPath)),
+		"{repo}", repoName,
+	).Replace(filepath.ToSlash(configured))
+
+	if !strings.Contains(template, branchPlaceholder) {
+		template = strings.TrimSuffix(template, "/") + "/" + branchPlaceholder
+	}
+
+	base, leaf := "~", template
+	if cut := strings.LastIndex(template[:strings.Index(template, branchPlaceholder)], "/"); cut >= 0 {
+		base, leaf = template[:cut], template[cut+1:]
+	}
+
+	if base == "" {
+		base = "/"
+	}
+
+	return resolveWorktreeDir(filepath.FromSlash(base), rootPath, homeDir, filesystem), leaf
+}
+
+// validateWorktreeBase rejects placeholders a worktree-base template does not have
+func validateWorktreeBase(value string) error {
+	rest := value
+
+	for {
+		start := strings.Index(rest, "{")
+		if start < 0 {
+			return nil
+		}
+
+		end := strings.Index(rest[start:], "}")
+		if end < 0 {
+			return fmt.Errorf("invalid worktree base %q: unclosed {", value)
+		}
+
+		if placeholder := rest[start : start+end+1]; !slices.Contains(worktreeBasePlaceholders, placeholder) {
+			return fmt.Errorf("invalid worktree base %q: unknown placeholder %s (use %s)",
+				value, placeholder, strings.Join(worktreeBasePlaceholders, ", "))
+		}
+
+		rest = rest[start+end+1:]
+	}
+}
+
+// worktreeParentDir returns the directory holding per-repository worktree folders:
+// the auto-worktree.worktree-base setting with ~ expanded, or ~/worktrees. For
+// a template, it is the part before the first placeholder.
+func worktreeParentDir(rootPath, homeDir string, executor GitExecutor, filesystem FileSystem) string {
+	return parentDirOf(configuredWorktreeBase(rootPath, executor), rootPath, homeDir, filesystem)
+}
+
+// parentDirOf returns the directory holding per-repository worktree folders
+// for a worktree-base setting
+func parentDirOf(configured, rootPath, homeDir string, filesystem FileSystem) string {
+	if i := strings.Index(configured, "{"); i >= 0 {
+		if configured = filepath.Dir(configured[:i] + "x"); configured == "." {
+			configured = ""
+		}
+	}
+
+	if configured == "" {
+		return filesystem.Join(homeDir, "worktrees")
+	}
+
+	return resolveWorktreeDir(configured, rootPath, homeDir, filesystem)
+}
+
+// resolveWorktreeDir expands a worktree-base directory. ~ is the home
+// directory; paths starting with ./ or ../ are relative to the repository (the
+// main working tree, or a bare repository), and other relative ones to the
+// home directory.
+func resolveWorktreeDir(configured, rootPath, homeDir string, filesystem FileSystem) string {
+	if configured == "~" || strings.HasPrefix(configured, "~/") {
+		return filesystem.Join(homeDir, strings.TrimPrefix(configured, "~"))
+	}
+
+	if rootPath != "" && (configured == "." || configured == ".." ||
+		strings.HasPrefix(configured, "./") || strings.HasPrefix(configured, "../")) {
+		return filesystem.Join(rootPath, configured)
+	}
+
+	if !filepath.IsAbs(configured) {
+		return filesystem.Join(homeDir, configured)
+	}
+
+	return filepath.Clean(configured)
+}
+
+// WorktreeParentDir returns the directory holding every repository's worktree
+// folder, from the global auto-worktree.worktree-base or ~/worktrees
+func WorktreeParentDir() (string, error) {
+	fs := NewFileSystem()
+
+	homeDir, err := fs.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+
+	return worktreeParentDir("", homeDir, NewGitExecutor(), fs), nil
+}
+
+// resolveMainWorktree maps the top level of a linked worktree to the main working tree.
+// Returns the main root, the linked worktree path ("" when topLevel is the main working
+// tree), and whether the root is a bare repository, which has no main working tree.
+func resolveMainWorktree(topLevel string, executor GitExecutor, filesystem FileSystem) (string, string, bool) {
+	// In a linked worktree .git is a file ("gitdir: ..."); in the main working tree it is a directory.
+	// Checking first avoids a subprocess in the common case.
+	data, err := filesystem.ReadFile(filesystem.Join(topLevel, ".git"))
+	if err != nil || !strings.HasPrefix(string(data), "gitdir:") {
+		return topLevel, "", false
+	}
+
+	commonDir, err := executor.ExecuteInDir(topLevel, "rev-parse", "--git-common-dir")
+	if err != nil {
+		return topLevel, "", false
+	}
+
+	if !filepath.IsAbs(commonDir) {
+		commonDir = filepath.Join(topLevel, commonDir)
+	}
+
+	commonDir = filepath.Clean(commonDir)
+
+	// The usual layout: the common dir is the main working tree's .git
+	if filepath.Base(commonDir) == ".git" {
+		return filepath.Dir(commonDir), topLevel, false
+	}
+
+	// A bare repository is its own root
+	if isBareRepository(commonDir, executor) {
+		return commonDir, topLevel, true
+	}
+
+	// --separate-git-dir clones and submodules keep the common dir elsewhere.
+	// topLevel is their main working tree unless its git dir is a linked one.
+	gitDir, err := executor.ExecuteInDir(topLevel, "rev-parse", "--absolute-git-dir")
+	if err != nil || filepath.Clean(strings.TrimSpace(gitDir)) == commonDir {
+		return topLevel, "", false
+	}
+
+	if mainTree := configuredWorktree(commonDir, executor); mainTree != "" {
+		return mainTree, topLevel, false
+	}
+
+	// git does not record where a --separate-git-dir clone's main working tree
+	// is, so the linked worktree stands in for it
+	return topLevel, "", false
+}
+
+// isBareRepository reports whether the git directory gitDir is a bare repository
+func isBareRepository(gitDir string, executor GitExecutor) bool {
+	out, err := executor.ExecuteInDir(gitDir, "rev-parse", "--is-bare-repository")
+	return err == nil && strings.TrimSpace(out) == "true"
+}
+
+// configuredWorktree returns the core.worktree of the git directory gitDir,
+// which submodules set to their working tree, or "" when unset
+func configuredWorktree(gitDir string, executor GitExecutor) string {
+	out, err := executor.ExecuteInDir(gitDir, "config", "--get", "core.worktree")
+	worktree := strings.TrimSpace(out)
+
+	if err != nil || worktree == "" {
+		return ""
+	}
+
+	if !filepath.IsAbs(worktree) {
+		worktree = filepath.Join(gitDir, worktree)
+	}
+
+	return filepath.Clean(worktree)
+}
+
+// resolveCommonDir returns the git common dir of the repository at rootPath.
+// A main working tree's .git is usually that directory; when it is a file
+// (--separate-git-dir, submodules) git is asked where it points.
+func resolveCommonDir(rootPath string, bare bool, executor GitExecutor, filesystem FileSystem) string {
+	if bare {
+		return rootPath
+	}
+
+	// Only a .git file needs a subprocess; a directory is the common dir itself
+	dotGit := filesystem.Join(rootPath, ".git")
+	if data, err := filesystem.ReadFile(dotGit); err != nil || !strings.HasPrefix(string(data), "gitdir:") {
+		return dotGit
+	}
+
+	commonDir, err := executor.ExecuteInDir(rootPath, "rev-parse", "--git-common-dir")
+	commonDir = strings.TrimSpace(commonDir)
+
+	if err != nil || commonDir == "" {
+		return dotGit
+	}
+
+	if !filepath.IsAbs(commonDir) {
+		commonDir = filepath.Join(rootPath, commonDir)
+	}
+
+	return filepath.Clean(commonDir)
+}
+
+// getBareRepositoryRoot returns the absolute path of the bare repository at
+// path, which may be a folder whose .git file points to it (the .bare layout)
+func getBareRepositoryRoot(path string, executor GitExecutor) (string, error) {
+	output, err := executor.ExecuteInDir(path, "rev-parse", "--is-bare-repository", "--absolute-git-dir")
+	if err != nil {
+		return "", fmt.Errorf("failed to get repository root: %w", err)
+	}
+
+	isBare, gitDir, _ := strings.Cut(strings.TrimSpace(output), "\n")
+	if isBare != "true" || gitDir == "" {
+		return "", fmt.Errorf("%s is not in a work tree or a bare repository", path)
+	}
+
+	return strings.TrimSpace(gitDir), nil
+}
+
 // IsGitRepository checks if the given path is within a git repository
 func IsGitRepository(path string) bool {
 	executor := NewGitExecutor()
@@ -153,6 +512,11 @@ func (r *Repository) remoteBranchExists(refName string) bool {
 	return err == nil
 }
 
+// RemoteBranchExists checks if a branch exists on origin, whether or not it is checked out locally
+func (r *Repository) RemoteBranchExists(branchName string) bool {
+	return r.remoteBranchExists("origin/" + branchName)
+}
+
 // GetCurrentBranch returns the current branch name, or empty string if in detached HEAD
 func (r *Repository) GetCurrentBranch() (string, error) {
 	output, err := r.executor.ExecuteInDir(r.RootPath, "rev-parse", "--abbrev-ref", "HEAD")
@@ -174,14 +538,169 @@ func (r *Repository) CreateBranch(branchName, baseBranch string) error {
 	return nil
 }
 
+// CreateTrackingBranch creates a local branch from its namesake on origin, tracking it
+func (r *Repository) CreateTrackingBranch(branchName string) error {
+	if _, err := r.executor.ExecuteInDir(r.RootPath, "branch", "--track", branchName, "origin/"+branchName); err != nil {
+		return fmt.Errorf("failed to create branch %s tracking origin/%s: %w", branchName, branchName, err)
+	}
+	return nil
+}
+
 // DeleteBranch deletes a branch (force delete)
 func (r *Repository) DeleteBranch(branchName string) error {
-	if _, err := r.executor.ExecuteInDir(r.RootPath, "branch", "-D", branchName); err != nil {
+	err := r.runLocked("deleting a branch", "branch", "-D", branchName)
+	r.recordEvent(events.ActionDeleteBranch, "", branchName, "", err)
+
+	if err != nil {
 		return fmt.Errorf("failed to delete branch %s: %w", branchName, err)
 	}
+
+	// Checkpoints would otherwise keep the deleted branch's work around forever
+	if err := r.DeleteCheckpoints(branchName); err != nil {
+		logging.Debug("failed to delete checkpoints", "branch", branchName, "err", err)
+	}
+
+	return nil
+}
+
+// SwitchBranch checks out an existing branch in the given worktree
+func (r *Repository) SwitchBranch(worktreePath, branchName string) error {
+	if _, err := r.executor.ExecuteInDir(worktreePath, "switch", branchName); err != nil {
+		return fmt.Errorf("failed to switch %s to branch %s: %w", worktreePath, branchName, err)
+	}
+	return nil
+}
+
+// StagedDiff returns the staged (cached) diff in the given worktree
+func (r *Repository) StagedDiff(worktreePath string) (string, error) {
+	output, err := r.executor.ExecuteInDir(worktreePath, "diff", "--cached")
+	if err != nil {
+		return "", fmt.Errorf("failed to get staged diff: %w", err)
+	}
+	return output, nil
+}
+
+// BranchDiff returns the diff of commits on HEAD since it diverged from baseBranch
+func (r *Repository) BranchDiff(worktreePath, baseBranch string) (string, error) {
+	output, err := r.executor.ExecuteInDir(worktreePath, "diff", baseBranch+"...HEAD")
+	if err != nil {
+		return "", fmt.Errorf("failed to diff against %s: %w", baseBranch, err)
+	}
+	return output, nil
+}
+
+// BranchChangedFiles returns the paths changed on HEAD since it diverged from baseBranch
+func (r *Repository) BranchChangedFiles(worktreePath, baseBranch string) ([]string, error) {
+	output, err := r.executor.ExecuteInDir(worktreePath, "diff", "--name-only", baseBranch+"...HEAD")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files changed since %s: %w", baseBranch, err)
+	}
+
+	var files []string
+	for _, line := range strings.Split(output, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			files = append(files, line)
+		}
+	}
+	return files, nil
+}
+
+// PushBranch pushes branch to origin and sets it as the branch's upstream.
+// Pushes from a review-only worktree are refused.
+func (r *Repository) PushBranch(worktreePath, branch string) error {
+	if r.ReviewOnly(worktreePath) != nil {
+		return fmt.Errorf("not pushing from %s: %w", worktreePath, ErrReviewOnly)
+	}
+
+	if _, err := r.executor.ExecuteInDir(worktreePath, "push", "-u", "origin", branch); err != nil {
+		return fmt.Errorf("failed to push %s: %w", branch, err)
+	}
+	return nil
+}
+
+// BranchLog returns one-line summaries of commits on HEAD since it diverged from baseBranch
+func (r *Repository) BranchLog(worktreePath, baseBranch string) (string, error) {
+	output, err := r.executor.ExecuteInDir(worktreePath, "log", "--oneline", baseBranch+"..HEAD")
+	if err != nil {
+		return "", fmt.Errorf("failed to list commits since %s: %w", baseBranch, err)
+	}
+	return output, nil
+}
+
+// HasUncommittedChanges reports whether the worktree has staged, unstaged, or untracked changes
+func (r *Repository) HasUncommittedChanges(worktreePath string) (bool, error) {
+	output, err := r.executor.ExecuteInDir(worktreePath, "status", "--porcelain")
+	if err != nil {
+		return false, fmt.Errorf("failed to get status of %s: %w", worktreePath, err)
+	}
+	return strings.TrimSpace(output) != "", nil
+}
+
+// Commit records the staged changes in the given worktree with message
+func (r *Repository) Commit(worktreePath, message string) error {
+	if _, err := r.executor.ExecuteInDir(worktreePath, "commit", "-m", message); err != nil {
+		return fmt.Errorf("failed to commit: %w", err)
+	}
 	return nil
 }
 
+// ListLocalBranches returns the names of all local branches
+func (r *Repository) ListLocalBranches() ([]string, error) {
+	output, err := r.executor.ExecuteInDir(r.RootPath, "for-each-ref", "--format=%(refname:short)", "refs/heads/")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list local branches: %w", err)
+	}
+
+	var branches []string
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" {
+			branches = append(branches, line)
+		}
+	}
+
+	return branches, nil
+}
+
+// ListActiveBranches returns the local branches with work still going on in
+// them: commits not in base, or a commit since since. Without a base only the
+// commit date counts.
+func (r *Repository) ListActiveBranches(base string, since time.Time) ([]string, error) {
+	output, err := r.executor.ExecuteInDir(r.RootPath, "for-each-ref", "--format=%(refname:short) %(committerdate:unix)", "refs/heads/")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list local branches: %w", err)
+	}
+
+	unmerged := map[string]bool{}
+
+	if base != "" {
+		names, err := r.executor.ExecuteInDir(r.RootPath, "for-each-ref", "--no-merged="+base, "--format=%(refname:short)", "refs/heads/")
+		if err != nil {
+			return nil, fmt.Errorf("failed to list branches not merged into %s: %w", base, err)
+		}
+
+		for _, name := range strings.Fields(names) {
+			unmerged[name] = true
+		}
+	}
+
+	var active []string
+
+	for _, line := range strings.Split(output, "\n") {
+		name, date, ok := strings.Cut(strings.TrimSpace(line), " ")
+		if !ok {
+			continue
+		}
+
+		secs, err := strconv.ParseInt(date, 10, 64)
+		if unmerged[name] || (err == nil && time.Unix(secs, 0).After(since)) {
+			active = append(active, name)
+		}
+	}
+
+	return active, nil
+}
+
 // EnrichWorktreeWithMergeStatus adds merge status information to a worktree
 // This checks both git merge status and external provider status
 func (r *Repository) EnrichWorktreeWithMergeStatus(wt *Worktree) error {
@@ -197,6 +716,11 @@ func (r *Repository) EnrichWorktreeWithMergeStatus(wt *Worktree) error {
 		return nil
 	}
 
+	// The default branch's own worktree (as in a bare repository's) is never merged
+	if wt.Branch == defaultBranch {
+		return nil
+	}
+
 	// Check if branch is merged into default branch
 	isMerged, err := IsBranchMergedInto(r.RootPath, wt.Branch, defaultBranch)
 	if err == nil {
@@ -213,14 +737,18 @@ func (r *Repository) EnrichWorktreeWithProviderStatus(wt *Worktree, p providers.
 		return nil
 	}
 
-	// Get configured provider type for branch parsing
+	// Get configured provider type and issue branch prefixes for branch parsing
 	providerType := ""
+
+	var issuePrefixes []string
+
 	if r.Config != nil {
 		providerType = r.Config.GetIssueProvider()
+		issuePrefixes = IssueBranchPrefixes(r.Config.GetIssueBranchPrefixes())
 	}
 
 	// Parse branch name to extract issue/PR ID
-	parsedType, id, found := provider.ParseBranchNameWithProvider(wt.Branch, providerType)
+	parsedType, id, found := provider.ParseBranchNameWithPrefixes(wt.Branch, providerType, issuePrefixes)
 	if !found {
 		return nil
 	}
@@ -231,7 +759,7 @@ func (r *Repository) EnrichWorktreeWithProviderStatus(wt *Worktree, p providers.
 		ID:       id,
 	}
 
-	ctx := context.Background()
+	ctx := interrupt.Context()
 
 	// Check status based on type
 	switch parsedType {
@@ -312,18 +840,12 @@ func (r *Repository) ListWorktreesWithAllStatus(p providers.Provider) ([]*Worktr
 	}
 
 	// Enrich all status in parallel
-	var wg sync.WaitGroup
-	for _, wt := range worktrees {
-		wg.Add(1)
-		go func(w *Worktree, prov providers.Provider) {
-			defer wg.Done()
-			// Errors are non-fatal, continue with partial data
-			_ = r.EnrichWorktreeWithMergeStatus(w)
-			_ = r.EnrichWorktreeWithProviderStatus(w, prov)
-			_ = r.EnrichWorktreeWithNoChangesCheck(w)
-		}(wt, p)
-	}
-	wg.Wait()
+	limits.ForEach(worktrees, func(w *Worktree) {
+		// Errors are non-fatal, continue with partial data
+		_ = r.EnrichWorktreeWithMergeStatus(w)
+		_ = r.EnrichWorktreeWithProviderStatus(w, p)
+		_ = r.EnrichWorktreeWithNoChangesCheck(w)
+	})
 
 	return worktrees, nil
 }
@@ -354,16 +876,10 @@ func (r *Repository) ListWorktreesWithMergeStatus() ([]*Worktree, error) {
 
 	// Enrich merge status in parallel
 	endEnrichAll := perf.StartSpanWithParent("git-enrich-merge-status-parallel", "git-list-worktrees-with-merge-status")
-	var wg sync.WaitGroup
-	for _, wt := range worktrees {
-		wg.Add(1)
-		go func(w *Worktree) {
-			defer wg.Done()
-			// Errors are non-fatal, continue with partial data
-			_ = r.EnrichWorktreeWithMergeStatus(w)
-		}(wt)
-	}
-	wg.Wait()
+	limits.ForEach(worktrees, func(w *Worktree) {
+		// Errors are non-fatal, continue with partial data
+		_ = r.EnrichWorktreeWithMergeStatus(w)
+	})
 	endEnrichAll()
 
 	return worktrees, nil
@@ -408,6 +924,10 @@ func (r *Repository) GetCleanupCandidates() ([]*Worktree, error) {
 	var stale []*Worktree
 
 	for _, wt := range worktrees {
+		if wt.IsProtected {
+			continue
+		}
+
 		if wt.IsMerged() {
 			merged = append(merged, wt)
 		} else if wt.IsStale() {
@@ -426,7 +946,8 @@ type StartupCleanupCandidates struct {
 }
 
 // GetStartupCleanupCandidates returns worktrees that need cleanup at startup
-// Orphaned worktrees are automatically cleaned, merged ones are interactive
+// Orphaned worktrees are automatically cleaned, merged ones are interactive.
+// Review-only worktrees past ReviewOnlyMaxAge are handled like merged ones.
 func (r *Repository) GetStartupCleanupCandidates() (*StartupCleanupCandidates, error) {
 	worktrees, err := r.ListWorktreesWithMergeStatus()
 	if err != nil {
@@ -442,9 +963,13 @@ func (r *Repository) GetStartupCleanupCandidates() (*StartupCleanupCandidates, e
 	}
 
 	for _, wt := range worktrees {
+		if wt.IsProtected {
+			continue
+		}
+
 		if wt.IsOrphaned() {
 			candidates.Orphaned = append(candidates.Orphaned, wt)
-		} else if wt.IsMerged() {
+		} else if wt.IsMerged() || wt.IsExpiredReview() {
 			candidates.Merged = append(candidates.Merged, wt)
 		}
 	}
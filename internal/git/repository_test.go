@@ -2,8 +2,12 @@ package git
 
 import (
 	"errors"
+	"fmt"
 	"path/filepath"
+	"reflect"
+	"slices"
 	"testing"
+	"time"
 )
 
 func TestIsGitRepository(t *testing.T) {
@@ -151,9 +155,9 @@ func TestNewRepositoryFromPath(t *testing.T) {
 		t.Errorf("WorktreeBase = %v, want %v", repo.WorktreeBase, expectedBase)
 	}
 
-	// Verify commands executed (optimized: single combined call instead of two)
-	if len(fakeExec.Commands) != 1 {
-		t.Errorf("Expected 1 command, got %d: %v", len(fakeExec.Commands), fakeExec.Commands)
+	// Verify commands executed: one combined root check, then the worktree-base setting
+	if len(fakeExec.Commands) != 2 {
+		t.Errorf("Expected 2 commands, got %d: %v", len(fakeExec.Commands), fakeExec.Commands)
 	}
 
 	// Verify filesystem operations
@@ -169,6 +173,43 @@ func TestNewRepositoryFromPath(t *testing.T) {
 	}
 }
 
+func TestNewRepositoryWorktreeBaseSetting(t *testing.T) {
+	tests := []struct {
+		name       string
+		configured string
+		want       string
+	}{
+		{"unset", "", "/home/testuser/worktrees/repo"},
+		{"tilde", "~/src/wt", "/home/testuser/src/wt/repo"},
+		{"absolute", "/data/worktrees/", "/data/worktrees/repo"},
+		{"relative to home", "code/worktrees", "/home/testuser/code/worktrees/repo"},
+		{"relative to the repository", "../worktrees", "/test/worktrees/repo"},
+		{"template beside the repository", "{repo_parent}/{repo}-worktrees/{branch}", "/test/repo-worktrees"},
+		{"template without a branch", "~/wt/{repo}", "/home/testuser/wt/repo"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeExec := NewFakeGitExecutor()
+			fakeExec.SetResponse("rev-parse --show-toplevel", "/test/repo")
+			fakeExec.SetResponse("config --get "+ConfigWorktreeBase, tt.configured)
+
+			fakeFS := NewFakeFileSystem()
+			fakeFS.HomeDir = "/home/testuser"
+			fakeFS.Dirs["/test/repo"] = true
+
+			repo, err := NewRepositoryFromPathWithDeps("/test/repo", fakeExec, fakeFS)
+			if err != nil {
+				t.Fatalf("NewRepositoryFromPathWithDeps() error = %v", err)
+			}
+
+			if want := filepath.FromSlash(tt.want); repo.WorktreeBase != want {
+				t.Errorf("WorktreeBase = %v, want %v", repo.WorktreeBase, want)
+			}
+		})
+	}
+}
+
 func TestBranchExists(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -395,6 +436,275 @@ func TestCreateAndDeleteBranch(t *testing.T) {
 	}
 }
 
+func TestNewRepositoryFromLinkedWorktree(t *testing.T) {
+	fakeExec := NewFakeGitExecutor()
+	fakeExec.SetResponse("rev-parse --show-toplevel", "/home/testuser/worktrees/repo/feature-x")
+	fakeExec.SetResponse("rev-parse --git-common-dir", "/src/repo/.git")
+
+	fakeFS := NewFakeFileSystem()
+	fakeFS.Files["/home/testuser/worktrees/repo/feature-x/.git"] = []byte("gitdir: /src/repo/.git/worktrees/feature-x\n")
+
+	repo, err := NewRepositoryFromPathWithDeps("/home/testuser/worktrees/repo/feature-x/sub", fakeExec, fakeFS)
+	if err != nil {
+		t.Fatalf("NewRepositoryFromPathWithDeps() error = %v", err)
+	}
+
+	if repo.RootPath != "/src/repo" {
+		t.Errorf("RootPath = %q, want main working tree /src/repo", repo.RootPath)
+	}
+
+	if repo.WorktreeBase != "/home/testuser/worktrees/repo" {
+		t.Errorf("WorktreeBase = %q, want /home/testuser/worktrees/repo", repo.WorktreeBase)
+	}
+
+	if repo.InvokedFromWorktree != "/home/testuser/worktrees/repo/feature-x" {
+		t.Errorf("InvokedFromWorktree = %q", repo.InvokedFromWorktree)
+	}
+}
+
+func TestWorktreePathTemplate(t *testing.T) {
+	tests := []struct {
+		name       string
+		configured string
+		want       string
+	}{
+		{"default", "", "/home/testuser/worktrees/repo/work-fix"},
+		{"template", "{repo_parent}/{repo}-worktrees/{branch}", "/test/repo-worktrees/work-fix"},
+		{"branch inside a folder", "/wt/{repo}/{branch}/src", "/wt/repo/work-fix/src"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeExec := NewFakeGitExecutor()
+			fakeExec.SetResponse("rev-parse --show-toplevel", "/test/repo")
+			fakeExec.SetResponse("config --get "+ConfigWorktreeBase, tt.configured)
+
+			fakeFS := NewFakeFileSystem()
+			fakeFS.HomeDir = "/home/testuser"
+
+			repo, err := NewRepositoryFromPathWithDeps("/test/repo", fakeExec, fakeFS)
+			if err != nil {
+				t.Fatalf("NewRepositoryFromPathWithDeps() error = %v", err)
+			}
+
+			if got := repo.WorktreePath("work/fix"); got != filepath.FromSlash(tt.want) {
+				t.Errorf("WorktreePath() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewRepositoryFromBareRepository(t *testing.T) {
+	tests := []struct {
+		name       string
+		gitDir     string
+		configured string
+		wantName   string
+		wantBase   string
+	}{
+		{"bare clone", "/src/proj/repo.git", "", "repo", "/src/proj"},
+		{".bare layout", "/src/proj/.bare", "", "proj", "/src/proj"},
+		{"configured base", "/src/proj/repo.git", "~/wt", "repo", "/home/testuser/wt/repo"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeExec := NewFakeGitExecutor()
+			fakeExec.SetError("rev-parse --show-toplevel", errors.New("fatal: this operation must be run in a work tree"))
+			fakeExec.SetResponse("rev-parse --is-bare-repository --absolute-git-dir", "true\n"+tt.gitDir)
+			fakeExec.SetResponse("config --get "+ConfigWorktreeBase, tt.configured)
+
+			fakeFS := NewFakeFileSystem()
+			fakeFS.HomeDir = "/home/testuser"
+
+			repo, err := NewRepositoryFromPathWithDeps(tt.gitDir, fakeExec, fakeFS)
+			if err != nil {
+				t.Fatalf("NewRepositoryFromPathWithDeps() error = %v", err)
+			}
+
+			if !repo.Bare || repo.RootPath != tt.gitDir || repo.GitDir() != tt.gitDir {
+				t.Errorf("Bare = %v, RootPath = %q, GitDir() = %q; want a bare repository at %s", repo.Bare, repo.RootPath, repo.GitDir(), tt.gitDir)
+			}
+
+			if repo.SourceFolder != tt.wantName || repo.WorktreeBase != tt.wantBase {
+				t.Errorf("SourceFolder = %q, WorktreeBase = %q; want %q, %q", repo.SourceFolder, repo.WorktreeBase, tt.wantName, tt.wantBase)
+			}
+		})
+	}
+}
+
+func TestNewRepositoryFromWorktreeOfBareRepository(t *testing.T) {
+	fakeExec := NewFakeGitExecutor()
+	fakeExec.SetResponse("rev-parse --show-toplevel", "/src/proj/feature-x")
+	fakeExec.SetResponse("rev-parse --git-common-dir", "/src/proj/repo.git")
+	fakeExec.SetResponse("rev-parse --is-bare-repository", "true")
+
+	fakeFS := NewFakeFileSystem()
+	fakeFS.Files["/src/proj/feature-x/.git"] = []byte("gitdir: /src/proj/repo.git/worktrees/feature-x\n")
+
+	repo, err := NewRepositoryFromPathWithDeps("/src/proj/feature-x", fakeExec, fakeFS)
+	if err != nil {
+		t.Fatalf("NewRepositoryFromPathWithDeps() error = %v", err)
+	}
+
+	if !repo.Bare || repo.RootPath != "/src/proj/repo.git" || repo.InvokedFromWorktree != "/src/proj/feature-x" {
+		t.Errorf("Bare = %v, RootPath = %q, InvokedFromWorktree = %q", repo.Bare, repo.RootPath, repo.InvokedFromWorktree)
+	}
+
+	if repo.WorktreeBase != "/src/proj" {
+		t.Errorf("WorktreeBase = %q, want the bare repository's folder /src/proj", repo.WorktreeBase)
+	}
+}
+
+func TestNewRepositoryFromSeparateGitDirClone(t *testing.T) {
+	fakeExec := NewFakeGitExecutor()
+	fakeExec.SetResponse("rev-parse --show-toplevel", "/src/app")
+	fakeExec.SetResponse("rev-parse --git-common-dir", "/src/store")
+	fakeExec.SetResponse("rev-parse --is-bare-repository", "false")
+	fakeExec.SetResponse("rev-parse --absolute-git-dir", "/src/store")
+
+	fakeFS := NewFakeFileSystem()
+	fakeFS.HomeDir = "/home/testuser"
+	fakeFS.Files["/src/app/.git"] = []byte("gitdir: /src/store\n")
+
+	repo, err := NewRepositoryFromPathWithDeps("/src/app", fakeExec, fakeFS)
+	if err != nil {
+		t.Fatalf("NewRepositoryFromPathWithDeps() error = %v", err)
+	}
+
+	if repo.Bare || repo.RootPath != "/src/app" || repo.InvokedFromWorktree != "" {
+		t.Errorf("Bare = %v, RootPath = %q, InvokedFromWorktree = %q; want the working tree /src/app", repo.Bare, repo.RootPath, repo.InvokedFromWorktree)
+	}
+
+	if repo.SourceFolder != "app" || repo.WorktreeBase != "/home/testuser/worktrees/app" {
+		t.Errorf("SourceFolder = %q, WorktreeBase = %q", repo.SourceFolder, repo.WorktreeBase)
+	}
+
+	if repo.GitDir() != "/src/store" {
+		t.Errorf("GitDir() = %q, want /src/store", repo.GitDir())
+	}
+}
+
+func TestNewRepositoryFromSubmoduleWorktree(t *testing.T) {
+	fakeExec := NewFakeGitExecutor()
+	fakeExec.SetResponse("rev-parse --show-toplevel", "/home/testuser/worktrees/lib/feature-x")
+	fakeExec.SetResponse("rev-parse --git-common-dir", "/src/app/.git/modules/lib")
+	fakeExec.SetResponse("rev-parse --is-bare-repository", "false")
+	fakeExec.SetResponse("rev-parse --absolute-git-dir", "/src/app/.git/modules/lib/worktrees/feature-x")
+	fakeExec.SetResponse("config --get core.worktree", "../../../lib")
+
+	fakeFS := NewFakeFileSystem()
+	fakeFS.Files["/home/testuser/worktrees/lib/feature-x/.git"] = []byte("gitdir: /src/app/.git/modules/lib/worktrees/feature-x\n")
+
+	repo, err := NewRepositoryFromPathWithDeps("/home/testuser/worktrees/lib/feature-x", fakeExec, fakeFS)
+	if err != nil {
+		t.Fatalf("NewRepositoryFromPathWithDeps() error = %v", err)
+	}
+
+	if repo.Bare || repo.RootPath != "/src/app/lib" || repo.InvokedFromWorktree != "/home/testuser/worktrees/lib/feature-x" {
+		t.Errorf("Bare = %v, RootPath = %q, InvokedFromWorktree = %q; want the submodule's working tree /src/app/lib", repo.Bare, repo.RootPath, repo.InvokedFromWorktree)
+	}
+}
+
+func TestNewRepositoryFromMainWorktree(t *testing.T) {
+	fakeExec := NewFakeGitExecutor()
+	fakeExec.SetResponse("rev-parse --show-toplevel", "/src/repo")
+
+	repo, err := NewRepositoryFromPathWithDeps("/src/repo", fakeExec, NewFakeFileSystem())
+	if err != nil {
+		t.Fatalf("NewRepositoryFromPathWithDeps() error = %v", err)
+	}
+
+	if repo.RootPath != "/src/repo" || repo.InvokedFromWorktree != "" {
+		t.Errorf("RootPath = %q, InvokedFromWorktree = %q", repo.RootPath, repo.InvokedFromWorktree)
+	}
+}
+
+func TestListActiveBranches(t *testing.T) {
+	now := time.Now()
+
+	fakeExec := NewFakeGitExecutor()
+	fakeExec.SetResponse("rev-parse --show-toplevel", "/test/repo")
+	fakeExec.SetResponse("for-each-ref --format=%(refname:short) %(committerdate:unix) refs/heads/", fmt.Sprintf(
+		"main %d\nold-merged %d\nold-unmerged %d\nrecent-merged %d\n",
+		now.Unix(), now.AddDate(0, -2, 0).Unix(), now.AddDate(0, -2, 0).Unix(), now.Add(-time.Hour).Unix()))
+	fakeExec.SetResponse("for-each-ref --no-merged=main --format=%(refname:short) refs/heads/", "old-unmerged\n")
+
+	fakeFS := NewFakeFileSystem()
+	fakeFS.HomeDir = "/home/testuser"
+
+	repo, err := NewRepositoryFromPathWithDeps("/test/repo", fakeExec, fakeFS)
+	if err != nil {
+		t.Fatalf("NewRepositoryFromPathWithDeps() error = %v", err)
+	}
+
+	got, err := repo.ListActiveBranches("main", now.AddDate(0, 0, -14))
+	if err != nil {
+		t.Fatalf("ListActiveBranches() error = %v", err)
+	}
+
+	if want := []string{"main", "old-unmerged", "recent-merged"}; !slices.Equal(got, want) {
+		t.Errorf("ListActiveBranches() = %v, want %v", got, want)
+	}
+}
+
+func TestListLocalBranches(t *testing.T) {
+	tests := []struct {
+		name     string
+		output   string
+		cmdErr   error
+		wantErr  bool
+		expected []string
+	}{
+		{
+			name:     "multiple branches",
+			output:   "main\nfeature/login\nwork/42-fix-bug\n",
+			expected: []string{"main", "feature/login", "work/42-fix-bug"},
+		},
+		{
+			name:     "no branches",
+			output:   "",
+			expected: nil,
+		},
+		{
+			name:    "git error",
+			cmdErr:  errors.New("git error"),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeExec := NewFakeGitExecutor()
+			fakeFS := NewFakeFileSystem()
+
+			fakeExec.SetResponse("rev-parse --show-toplevel", "/test/repo")
+			fakeFS.HomeDir = "/home/testuser"
+
+			repo, err := NewRepositoryFromPathWithDeps("/test/repo", fakeExec, fakeFS)
+			if err != nil {
+				t.Fatalf("NewRepositoryFromPathWithDeps() error = %v", err)
+			}
+
+			cmd := "for-each-ref --format=%(refname:short) refs/heads/"
+			if tt.cmdErr != nil {
+				fakeExec.SetError(cmd, tt.cmdErr)
+			} else {
+				fakeExec.SetResponse(cmd, tt.output)
+			}
+
+			branches, err := repo.ListLocalBranches()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ListLocalBranches() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(branches, tt.expected) {
+				t.Errorf("ListLocalBranches() = %v, want %v", branches, tt.expected)
+			}
+		})
+	}
+}
+
 func TestGetDefaultBranch(t *testing.T) {
 	tests := []struct {
 		name              string
@@ -0,0 +1,143 @@
+package git
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/kaeawc/auto-worktree/internal/events"
+)
+
+// reviewOnlyFileName is stored in a worktree's own git directory, so it goes
+// away with the worktree
+const reviewOnlyFileName = "auto-worktree-review-only"
+
+// ReviewOnlyMaxAge is how long a review-only worktree is kept before it is a
+// cleanup candidate, much sooner than a worktree is stale
+const ReviewOnlyMaxAge = 24 * time.Hour
+
+// ErrReviewOnly is returned for pushes from a review-only worktree
+var ErrReviewOnly = errors.New("the worktree is review-only")
+
+// ReviewOnlyState marks a worktree created to review a pull request at its
+// head, detached, with no local branch
+type ReviewOnlyState struct {
+	// PR is the number of the pull request under review
+	PR int `json:"pr"`
+	// CreatedAt is when the worktree was created
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// FetchPullRequestHead fetches the head of a pull request from origin and returns its commit
+func (r *Repository) FetchPullRequestHead(head PullRequestHead) (string, error) {
+	if _, err := r.executor.ExecuteInDir(r.RootPath, "fetch", "origin", head.HeadRef()); err != nil {
+		return "", fmt.Errorf("failed to fetch PR #%d: %w", head.Number, err)
+	}
+
+	commit, err := r.executor.ExecuteInDir(r.RootPath, "rev-parse", "FETCH_HEAD")
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve PR #%d: %w", head.Number, err)
+	}
+
+	return strings.TrimSpace(commit), nil
+}
+
+// CreateReviewWorktree creates a worktree at commit with a detached HEAD and
+// marks it review-only for pull request number
+func (r *Repository) CreateReviewWorktree(path, commit string, number int) error {
+	err := r.runLocked("creating a worktree", "worktree", "add", "--detach", path, commit)
+	r.recordEvent(events.ActionCreate, path, "", fmt.Sprintf("review-only for PR #%d", number), err)
+
+	if err != nil {
+		return fmt.Errorf("failed to create worktree: %w", err)
+	}
+
+	if err := r.markReviewOnly(path, number); err != nil {
+		return err
+	}
+
+	r.initWorktreeSubmodules(path)
+	r.initWorktreeLFS(path)
+
+	// Execute git hooks after worktree creation
+	return r.executeWorktreeHooks(path)
+}
+
+// markReviewOnly records that the worktree at path is review-only
+func (r *Repository) markReviewOnly(path string, number int) error {
+	gitDir, err := r.worktreeGitDir(path)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(ReviewOnlyState{PR: number, CreatedAt: time.Now()}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode review-only state: %w", err)
+	}
+
+	if err := r.filesystem.WriteFile(r.filesystem.Join(gitDir, reviewOnlyFileName), data, 0o600); err != nil {
+		return fmt.Errorf("failed to mark %s review-only: %w", path, err)
+	}
+
+	return nil
+}
+
+// ReviewOnly returns the review-only state of the worktree at path, or nil
+// if it is an ordinary worktree
+func (r *Repository) ReviewOnly(path string) *ReviewOnlyState {
+	gitDir, err := r.worktreeGitDir(path)
+	if err != nil {
+		return nil
+	}
+
+	data, err := r.filesystem.ReadFile(r.filesystem.Join(gitDir, reviewOnlyFileName))
+	if err != nil {
+		return nil
+	}
+
+	state := &ReviewOnlyState{}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil
+	}
+
+	return state
+}
+
+// GetReviewWorktree returns the review-only worktree of pull request number, or nil if there is none
+func (r *Repository) GetReviewWorktree(number int) (*Worktree, error) {
+	worktrees, err := r.ListWorktrees()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, wt := range worktrees {
+		if wt.ReviewOnly != nil && wt.ReviewOnly.PR == number {
+			return wt, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// worktreeGitDir returns the git directory of the linked worktree at path,
+// which its .git file points at
+func (r *Repository) worktreeGitDir(path string) (string, error) {
+	data, err := r.filesystem.ReadFile(r.filesystem.Join(path, ".git"))
+	if err != nil {
+		return "", fmt.Errorf("%s is not a linked worktree: %w", path, err)
+	}
+
+	gitDir, ok := strings.CutPrefix(strings.TrimSpace(string(data)), "gitdir: ")
+	if !ok {
+		return "", fmt.Errorf("%s is not a linked worktree", path)
+	}
+
+	if !filepath.IsAbs(gitDir) {
+		gitDir = r.filesystem.Join(path, gitDir)
+	}
+
+	return gitDir, nil
+}
@@ -0,0 +1,64 @@
+package git
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCreateReviewWorktree(t *testing.T) {
+	executor := NewFakeGitExecutor()
+	fs := NewFakeFileSystem()
+	fs.Files["/wt/pr-7/.git"] = []byte("gitdir: /fake/repo/.git/worktrees/pr-7\n")
+
+	repo, err := NewRepositoryFromPathWithDeps("/fake/repo", executor, fs)
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+
+	if err := repo.CreateReviewWorktree("/wt/pr-7", "abc1234", 7); err != nil {
+		t.Fatalf("CreateReviewWorktree() error = %v", err)
+	}
+
+	ran := make([]string, 0, len(executor.Commands))
+	for _, args := range executor.Commands {
+		ran = append(ran, strings.Join(args[1:], " "))
+	}
+
+	if !slices.Contains(ran, "worktree add --detach /wt/pr-7 abc1234") {
+		t.Errorf("expected a detached worktree add, ran %q", ran)
+	}
+
+	state := repo.ReviewOnly("/wt/pr-7")
+	if state == nil || state.PR != 7 {
+		t.Fatalf("ReviewOnly() = %+v, want PR 7", state)
+	}
+
+	if repo.ReviewOnly("/wt/other") != nil {
+		t.Error("ReviewOnly() of an ordinary worktree should be nil")
+	}
+
+	if err := repo.PushBranch("/wt/pr-7", "pr/7"); !errors.Is(err, ErrReviewOnly) {
+		t.Errorf("PushBranch() error = %v, want ErrReviewOnly", err)
+	}
+}
+
+func TestReviewOnlyWorktreeCleanup(t *testing.T) {
+	path := t.TempDir()
+
+	fresh := &Worktree{Path: path, ReviewOnly: &ReviewOnlyState{PR: 7, CreatedAt: time.Now()}}
+	if fresh.IsStale() || fresh.IsExpiredReview() || fresh.CleanupReason() != "" {
+		t.Errorf("fresh review worktree: stale=%v expired=%v reason=%q", fresh.IsStale(), fresh.IsExpiredReview(), fresh.CleanupReason())
+	}
+
+	old := &Worktree{Path: path, ReviewOnly: &ReviewOnlyState{PR: 7, CreatedAt: time.Now().Add(-ReviewOnlyMaxAge - time.Hour)}}
+	if !old.IsExpiredReview() {
+		t.Error("review worktree past ReviewOnlyMaxAge should be expired")
+	}
+
+	if got := old.CleanupReason(); got != "stale (review of PR #7)" {
+		t.Errorf("CleanupReason() = %q", got)
+	}
+}
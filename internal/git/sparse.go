@@ -0,0 +1,18 @@
+package git
+
+import (
+	"fmt"
+)
+
+// SetSparseCheckout limits the worktree's checkout to dirs (plus files at the
+// repository root) using cone-mode sparse-checkout. The setting only applies
+// to this worktree; other worktrees keep their full checkout.
+func (r *Repository) SetSparseCheckout(worktreePath string, dirs ...string) error {
+	args := append([]string{"sparse-checkout", "set", "--cone"}, dirs...)
+
+	if _, err := r.executor.ExecuteInDir(worktreePath, args...); err != nil {
+		return fmt.Errorf("failed to configure sparse-checkout: %w", err)
+	}
+
+	return nil
+}
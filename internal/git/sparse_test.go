@@ -0,0 +1,23 @@
+package git
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSetSparseCheckout(t *testing.T) {
+	executor := NewFakeGitExecutor()
+	repo := &Repository{RootPath: "/repo", executor: executor}
+
+	if err := repo.SetSparseCheckout("/wt", "packages/foo"); err != nil {
+		t.Fatalf("SetSparseCheckout() error = %v", err)
+	}
+
+	if len(executor.Commands) != 1 {
+		t.Fatalf("commands = %v, want one", executor.Commands)
+	}
+
+	if got, want := strings.Join(executor.Commands[0], " "), "[in:/wt] sparse-checkout set --cone packages/foo"; got != want {
+		t.Errorf("command = %q, want %q", got, want)
+	}
+}
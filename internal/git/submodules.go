@@ -0,0 +1,81 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kaeawc/auto-worktree/internal/logging"
+	"github.com/kaeawc/auto-worktree/internal/offline"
+)
+
+// HasSubmodules reports whether the worktree declares submodules in .gitmodules
+func (r *Repository) HasSubmodules(worktreePath string) bool {
+	return r.filesystem.Exists(r.filesystem.Join(worktreePath, ".gitmodules"))
+}
+
+// UninitializedSubmodules returns the paths of submodules that have not been
+// checked out in the worktree, including nested ones
+func (r *Repository) UninitializedSubmodules(worktreePath string) ([]string, error) {
+	output, err := r.executor.ExecuteInDir(worktreePath, "submodule", "status", "--recursive")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read submodule status: %w", err)
+	}
+
+	return parseUninitializedSubmodules(output), nil
+}
+
+// parseUninitializedSubmodules picks the "-<sha> <path>" lines out of git submodule status
+func parseUninitializedSubmodules(output string) []string {
+	var paths []string
+
+	for _, line := range strings.Split(output, "\n") {
+		if !strings.HasPrefix(line, "-") {
+			continue
+		}
+
+		if fields := strings.Fields(line); len(fields) >= 2 {
+			paths = append(paths, fields[1])
+		}
+	}
+
+	return paths
+}
+
+// InitSubmodules runs git submodule update --init --recursive in the worktree,
+// limited to paths when given, and shallow when auto-worktree.submodule-shallow is set
+func (r *Repository) InitSubmodules(worktreePath string, paths ...string) error {
+	args := []string{"submodule", "update", "--init", "--recursive"}
+	if r.Config != nil && r.Config.GetSubmoduleShallow() {
+		args = append(args, "--depth", "1")
+	}
+
+	if len(paths) > 0 {
+		args = append(append(args, "--"), paths...)
+	}
+
+	if _, err := r.executor.ExecuteInDir(worktreePath, args...); err != nil {
+		return fmt.Errorf("failed to initialize submodules: %w", err)
+	}
+
+	return nil
+}
+
+// initWorktreeSubmodules initializes submodules in a new worktree. A failure is
+// only a warning: the worktree is usable, and health-check reports what is missing.
+func (r *Repository) initWorktreeSubmodules(worktreePath string) {
+	// Skip if Config is not initialized (e.g., in tests), like worktree hooks
+	if r.Config == nil || !r.Config.GetSubmoduleInit() || !r.HasSubmodules(worktreePath) {
+		return
+	}
+
+	if offline.Enabled() {
+		fmt.Println("📦 Offline: skipping submodule initialization (run 'auto-worktree repair' later)")
+		return
+	}
+
+	fmt.Println("📦 Initializing submodules...")
+
+	if err := r.InitSubmodules(worktreePath); err != nil {
+		logging.Warn("submodules were not initialized; run 'auto-worktree repair' to retry", "err", err)
+	}
+}
@@ -0,0 +1,95 @@
+package git
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseUninitializedSubmodules(t *testing.T) {
+	output := `-1234567890abcdef1234567890abcdef12345678 vendor/lib
+ abcdef1234567890abcdef1234567890abcdef12 third_party/tool (v1.2.0)
++fedcba0987654321fedcba0987654321fedcba09 third_party/other (heads/main)
+-0987654321fedcba0987654321fedcba09876543 third_party/tool/nested`
+
+	got := parseUninitializedSubmodules(output)
+	want := []string{"vendor/lib", "third_party/tool/nested"}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseUninitializedSubmodules() = %v, want %v", got, want)
+	}
+}
+
+func TestInitSubmodules(t *testing.T) {
+	tests := []struct {
+		name    string
+		shallow string
+		paths   []string
+		want    string
+	}{
+		{"all", "", nil, "[in:/wt] submodule update --init --recursive"},
+		{"shallow", "true", nil, "[in:/wt] submodule update --init --recursive --depth 1"},
+		{"selected paths", "", []string{"vendor/lib"}, "[in:/wt] submodule update --init --recursive -- vendor/lib"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			executor := NewFakeGitExecutor()
+			executor.SetResponse("config --local --get --bool "+ConfigSubmoduleShallow, tt.shallow)
+
+			repo := &Repository{RootPath: "/repo", executor: executor, Config: NewConfigWithExecutor("/repo", executor)}
+
+			if err := repo.InitSubmodules("/wt", tt.paths...); err != nil {
+				t.Fatalf("InitSubmodules() error = %v", err)
+			}
+
+			last := executor.Commands[len(executor.Commands)-1]
+			if got := strings.Join(last, " "); got != tt.want {
+				t.Errorf("command = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckSubmodules(t *testing.T) {
+	executor := NewFakeGitExecutor()
+	executor.SetResponse("submodule status --recursive", "-1234567890abcdef1234567890abcdef12345678 vendor/lib")
+
+	fs := NewFakeFileSystem()
+	fs.Files["/wt/.gitmodules"] = []byte("[submodule \"vendor/lib\"]\n")
+
+	repo := &Repository{RootPath: "/repo", executor: executor, filesystem: fs}
+	result := &HealthCheckResult{WorktreePath: "/wt"}
+
+	repo.checkSubmodules("/wt", result)
+
+	if len(result.Issues) != 1 || !result.Issues[0].Repairable {
+		t.Fatalf("checkSubmodules() issues = %v, want one repairable issue", result.Issues)
+	}
+
+	actions := repo.GetRepairActions([]*HealthCheckResult{result})
+	if len(actions) != 1 || actions[0].Type != RepairInitSubmodules || !actions[0].Safe {
+		t.Fatalf("GetRepairActions() = %+v, want one safe submodule init", actions)
+	}
+
+	if res := repo.PerformRepair(actions[0]); !res.Success {
+		t.Fatalf("PerformRepair() failed: %v", res.Error)
+	}
+
+	last := strings.Join(executor.Commands[len(executor.Commands)-1], " ")
+	if last != "[in:/wt] submodule update --init --recursive -- vendor/lib" {
+		t.Errorf("repair command = %q", last)
+	}
+}
+
+func TestCheckSubmodules_NoGitmodules(t *testing.T) {
+	executor := NewFakeGitExecutor()
+	repo := &Repository{RootPath: "/repo", executor: executor, filesystem: NewFakeFileSystem()}
+	result := &HealthCheckResult{WorktreePath: "/wt"}
+
+	repo.checkSubmodules("/wt", result)
+
+	if len(result.Issues) != 0 || len(executor.Commands) != 0 {
+		t.Errorf("expected no issues and no commands, got %v / %v", result.Issues, executor.Commands)
+	}
+}
@@ -0,0 +1,172 @@
+package git
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/kaeawc/auto-worktree/internal/remote"
+)
+
+// TidyAction is what a tidy does with one commit, named as in git rebase -i
+type TidyAction string
+
+// Tidy actions
+const (
+	TidyPick   TidyAction = "pick"
+	TidySquash TidyAction = "squash"
+	TidyFixup  TidyAction = "fixup"
+	TidyReword TidyAction = "reword"
+	TidyDrop   TidyAction = "drop"
+)
+
+// TidyCommit is an unpushed commit a tidy can rewrite
+type TidyCommit struct {
+	Hash    string
+	Subject string
+}
+
+// TidyStep is one line of a tidy plan
+type TidyStep struct {
+	Commit TidyCommit
+	Action TidyAction
+	// Message replaces the commit message of a reword
+	Message string
+}
+
+// TidyBase returns the commit a worktree's unpushed commits start from: where
+// HEAD left its upstream, or the default branch when it has none
+func (r *Repository) TidyBase(worktreePath string) (string, error) {
+	upstream := "@{u}"
+
+	if _, err := r.executor.ExecuteInDir(worktreePath, "rev-parse", "--verify", "--quiet", upstream); err != nil {
+		defaultBranch, err := r.GetDefaultBranch()
+		if err != nil {
+			return "", fmt.Errorf("no upstream and no default branch to compare with: %w", err)
+		}
+
+		upstream = defaultBranch
+		if r.remoteBranchExists("origin/" + defaultBranch) {
+			upstream = "origin/" + defaultBranch
+		}
+	}
+
+	base, err := r.executor.ExecuteInDir(worktreePath, "merge-base", "HEAD", upstream)
+	if err != nil {
+		return "", fmt.Errorf("failed to find where HEAD left %s: %w", upstream, err)
+	}
+
+	return strings.TrimSpace(base), nil
+}
+
+// ListTidyCommits returns the commits after base, oldest first. Merges are
+// refused, since a tidy would flatten them.
+func (r *Repository) ListTidyCommits(worktreePath, base string) ([]TidyCommit, error) {
+	merges, err := r.executor.ExecuteInDir(worktreePath, "rev-list", "--count", "--merges", base+"..HEAD")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list commits: %w", err)
+	}
+
+	if n, _ := strconv.Atoi(strings.TrimSpace(merges)); n > 0 { //nolint:errcheck // a bad count means no merges
+		return nil, fmt.Errorf("the unpushed commits include %d merge(s), which tidy would flatten; rebase them by hand", n)
+	}
+
+	output, err := r.executor.ExecuteInDir(worktreePath, "log", "--reverse", "--format=%H %s", base+"..HEAD")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list commits: %w", err)
+	}
+
+	return parseTidyCommits(output), nil
+}
+
+// parseTidyCommits parses "<hash> <subject>" lines
+func parseTidyCommits(output string) []TidyCommit {
+	var commits []TidyCommit
+
+	for _, line := range strings.Split(output, "\n") {
+		if line = strings.TrimSpace(line); line == "" {
+			continue
+		}
+
+		hash, subject, _ := strings.Cut(line, " ")
+		commits = append(commits, TidyCommit{Hash: hash, Subject: subject})
+	}
+
+	return commits
+}
+
+// CheckTidyPlan returns an error when a plan squashes or fixes up a commit
+// with no kept commit before it, or keeps no commits at all
+func CheckTidyPlan(steps []TidyStep) error {
+	for _, step := range steps {
+		switch step.Action {
+		case TidyDrop:
+			continue
+		case TidySquash, TidyFixup:
+			return fmt.Errorf("%s has no kept commit before it to %s into", step.Commit.Subject, step.Action)
+		default:
+			return nil
+		}
+	}
+
+	return fmt.Errorf("the plan drops every commit; use git reset to discard them")
+}
+
+// tidyTodo writes a plan as a git rebase -i todo list. Rewords amend the
+// commit right after it is picked, so no editor is needed.
+func tidyTodo(steps []TidyStep) []string {
+	todo := make([]string, 0, len(steps))
+
+	for _, step := range steps {
+		action := step.Action
+		if action == TidyReword {
+			action = TidyPick
+		}
+
+		todo = append(todo, fmt.Sprintf("%s %s %s", action, step.Commit.Hash, step.Commit.Subject))
+
+		if step.Action == TidyReword {
+			todo = append(todo, "exec git commit --amend --allow-empty --no-verify --quiet -m "+remote.Quote(step.Message))
+		}
+	}
+
+	return todo
+}
+
+// Tidy rewrites the commits after base as planned and returns the commit HEAD
+// pointed to before, for undoing it. The plan must list every commit after
+// base, oldest first. A rebase that stops on a conflict (dropping a commit
+// later ones build on) is aborted, leaving the branch as it was.
+func (r *Repository) Tidy(worktreePath, base string, steps []TidyStep) (string, error) {
+	if err := CheckTidyPlan(steps); err != nil {
+		return "", err
+	}
+
+	head, err := r.executor.ExecuteInDir(worktreePath, "rev-parse", "HEAD")
+	if err != nil {
+		return "", fmt.Errorf("failed to read HEAD: %w", err)
+	}
+
+	// git runs the sequence editor as `sh -c '<editor> "$@"' <todo file>`, so
+	// this writes the plan over the todo list it generated
+	script := "printf '%s\\n'"
+	for _, line := range tidyTodo(steps) {
+		script += " " + remote.Quote(line)
+	}
+
+	env := []string{
+		"GIT_SEQUENCE_EDITOR=" + script + " >",
+		// Squashes keep git's combined message
+		"GIT_EDITOR=true",
+	}
+
+	if _, err := r.executor.ExecuteInDirWithEnv(worktreePath, env, "rebase", "-i", base); err != nil {
+		if _, abortErr := r.executor.ExecuteInDir(worktreePath, "rebase", "--abort"); abortErr != nil {
+			return "", fmt.Errorf("tidy failed and could not be undone (run git rebase --abort): %w", err)
+		}
+
+		return "", fmt.Errorf("tidy failed, so the branch was left as it was: %w", err)
+	}
+
+	return strings.TrimSpace(head), nil
+}
@@ -0,0 +1,93 @@
+package git
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseTidyCommits(t *testing.T) {
+	commits := parseTidyCommits("aaa wip\n\nbbb fix the login form\nccc\n")
+
+	want := []TidyCommit{{Hash: "aaa", Subject: "wip"}, {Hash: "bbb", Subject: "fix the login form"}, {Hash: "ccc"}}
+	if len(commits) != len(want) {
+		t.Fatalf("parseTidyCommits() = %+v, want %+v", commits, want)
+	}
+
+	for i := range want {
+		if commits[i] != want[i] {
+			t.Errorf("commits[%d] = %+v, want %+v", i, commits[i], want[i])
+		}
+	}
+}
+
+func TestCheckTidyPlan(t *testing.T) {
+	a := TidyCommit{Hash: "aaa", Subject: "a"}
+	b := TidyCommit{Hash: "bbb", Subject: "b"}
+
+	tests := []struct {
+		name    string
+		steps   []TidyStep
+		wantErr bool
+	}{
+		{name: "fixup into a pick", steps: []TidyStep{{Commit: a, Action: TidyPick}, {Commit: b, Action: TidyFixup}}},
+		{name: "fixup into a reword", steps: []TidyStep{{Commit: a, Action: TidyReword}, {Commit: b, Action: TidySquash}}},
+		{name: "drop then pick", steps: []TidyStep{{Commit: a, Action: TidyDrop}, {Commit: b, Action: TidyPick}}},
+		{name: "fixup first", steps: []TidyStep{{Commit: a, Action: TidyFixup}, {Commit: b, Action: TidyPick}}, wantErr: true},
+		{name: "fixup after a drop", steps: []TidyStep{{Commit: a, Action: TidyDrop}, {Commit: b, Action: TidySquash}}, wantErr: true},
+		{name: "drop everything", steps: []TidyStep{{Commit: a, Action: TidyDrop}, {Commit: b, Action: TidyDrop}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := CheckTidyPlan(tt.steps); (err != nil) != tt.wantErr {
+				t.Errorf("CheckTidyPlan() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTidyTodo(t *testing.T) {
+	todo := tidyTodo([]TidyStep{
+		{Commit: TidyCommit{Hash: "aaa", Subject: "wip"}, Action: TidyReword, Message: "Fix the login form's validation"},
+		{Commit: TidyCommit{Hash: "bbb", Subject: "wip 2"}, Action: TidyFixup},
+		{Commit: TidyCommit{Hash: "ccc", Subject: "debug"}, Action: TidyDrop},
+	})
+
+	want := []string{
+		"pick aaa wip",
+		`exec git commit --amend --allow-empty --no-verify --quiet -m 'Fix the login form'\''s validation'`,
+		"fixup bbb wip 2",
+		"drop ccc debug",
+	}
+
+	if strings.Join(todo, "\n") != strings.Join(want, "\n") {
+		t.Errorf("tidyTodo() =\n%s\nwant\n%s", strings.Join(todo, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestTidyAbortsOnFailure(t *testing.T) {
+	executor := NewFakeGitExecutor()
+
+	repo, err := NewRepositoryFromPathWithDeps("/fake/repo", executor, NewFakeFileSystem())
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+
+	executor.Responses["rev-parse HEAD"] = "ccc"
+	executor.SetError("rebase -i base", errors.New("could not apply bbb"))
+
+	steps := []TidyStep{
+		{Commit: TidyCommit{Hash: "aaa", Subject: "a"}, Action: TidyDrop},
+		{Commit: TidyCommit{Hash: "bbb", Subject: "b"}, Action: TidyPick},
+	}
+
+	if _, err := repo.Tidy("/fake/worktrees/fix", "base", steps); err == nil {
+		t.Fatal("Tidy() error = nil, want the rebase failure")
+	}
+
+	last := executor.Commands[len(executor.Commands)-1]
+	if strings.Join(last[1:], " ") != "rebase --abort" {
+		t.Errorf("last command = %v, want rebase --abort", last)
+	}
+}
@@ -7,9 +7,10 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
-	"sync"
 	"time"
 
+	"github.com/kaeawc/auto-worktree/internal/events"
+	"github.com/kaeawc/auto-worktree/internal/limits"
 	"github.com/kaeawc/auto-worktree/internal/perf"
 )
 
@@ -31,6 +32,12 @@ type Worktree struct {
 	IsBranchMerged bool
 	// HasNoChanges indicates if the worktree HEAD matches the default branch HEAD
 	HasNoChanges bool
+	// IsProtected indicates the branch matches auto-worktree.protected-branches,
+	// so the worktree is never a cleanup candidate
+	IsProtected bool
+	// ReviewOnly is set for a detached worktree made to review a pull
+	// request, which cleanup removes sooner and pushes from are refused
+	ReviewOnly *ReviewOnlyState
 	// IssueStatus holds the status from external providers (GitHub, JIRA, etc.)
 	IssueStatus *IssueStatus
 	// executor is the git command executor for this worktree
@@ -67,7 +74,26 @@ func (r *Repository) ListWorktrees() ([]*Worktree, error) {
 	worktrees, err := parseWorktreeList(output, r.executor)
 	endParse()
 
-	return worktrees, err
+	if err != nil {
+		return nil, err
+	}
+
+	// git lists a --separate-git-dir clone's main working tree at its git dir
+	if !r.Bare && r.gitDir != "" && len(worktrees) > 0 && filepath.Clean(worktrees[0].Path) == filepath.Clean(r.gitDir) {
+		worktrees[0].Path = r.RootPath
+	}
+
+	patterns := r.ProtectedBranchPatterns()
+	for _, wt := range worktrees {
+		wt.IsProtected = IsProtectedBranch(wt.Branch, patterns)
+
+		// Only a detached worktree can be review-only
+		if wt.IsDetached {
+			wt.ReviewOnly = r.ReviewOnly(wt.Path)
+		}
+	}
+
+	return worktrees, nil
 }
 
 // parseWorktreeList parses the output of 'git worktree list --porcelain'
@@ -100,6 +126,12 @@ func parseWorktreeList(output string, executor GitExecutor) ([]*Worktree, error)
 			continue
 		}
 
+		// A bare repository is listed first but has no working tree
+		if field == "bare" {
+			current = nil
+			continue
+		}
+
 		// All other fields require a value
 		if len(parts) < 2 {
 			continue
@@ -132,16 +164,10 @@ func parseWorktreeList(output string, executor GitExecutor) ([]*Worktree, error)
 
 	// Enrich worktrees with additional information in parallel
 	endEnrich := perf.StartSpan("worktree-enrich-parallel")
-	var wg sync.WaitGroup
-	for _, wt := range worktrees {
-		wg.Add(1)
-		go func(w *Worktree) {
-			defer wg.Done()
-			// Errors are non-fatal, continue with partial data
-			_ = enrichWorktree(w, executor)
-		}(wt)
-	}
-	wg.Wait()
+	limits.ForEach(worktrees, func(w *Worktree) {
+		// Errors are non-fatal, continue with partial data
+		_ = enrichWorktree(w, executor)
+	})
 	endEnrich()
 
 	return worktrees, scanner.Err()
@@ -254,11 +280,22 @@ func (w *Worktree) Age() time.Duration {
 	return time.Since(w.LastCommitTime)
 }
 
-// IsStale returns true if the worktree is older than 4 days
+// IsStale returns true if the worktree is older than 4 days, or is
+// review-only and was created over ReviewOnlyMaxAge ago
 func (w *Worktree) IsStale() bool {
+	if w.ReviewOnly != nil {
+		return time.Since(w.ReviewOnly.CreatedAt) > ReviewOnlyMaxAge
+	}
+
 	return w.Age() > 4*24*time.Hour
 }
 
+// IsExpiredReview reports whether the worktree is review-only and past
+// ReviewOnlyMaxAge, so cleanup treats it like a merged one
+func (w *Worktree) IsExpiredReview() bool {
+	return w.ReviewOnly != nil && w.IsStale()
+}
+
 // IsMerged returns true if both the branch is merged AND the issue/PR is completed
 func (w *Worktree) IsMerged() bool {
 	// A worktree is considered merged if both:
@@ -275,9 +312,9 @@ func (w *Worktree) IsMerged() bool {
 }
 
 // ShouldCleanup returns true if the worktree is a candidate for cleanup
-// Either it's merged or it's stale
+// Either it's merged or it's stale, and its branch is not protected
 func (w *Worktree) ShouldCleanup() bool {
-	return w.IsMerged() || w.IsStale()
+	return !w.IsProtected && (w.IsMerged() || w.IsStale())
 }
 
 // IsOrphaned returns true if the worktree path doesn't exist or is broken
@@ -289,6 +326,9 @@ func (w *Worktree) IsOrphaned() bool {
 
 // CleanupReason returns a string describing why this worktree should be cleaned up
 func (w *Worktree) CleanupReason() string {
+	if w.IsProtected {
+		return ""
+	}
 	if w.IsOrphaned() {
 		return "orphaned"
 	}
@@ -298,6 +338,9 @@ func (w *Worktree) CleanupReason() string {
 		}
 		return "merged"
 	}
+	if w.IsExpiredReview() {
+		return fmt.Sprintf("stale (review of PR #%d)", w.ReviewOnly.PR)
+	}
 	if w.IsStale() {
 		days := int(w.Age().Hours() / 24)
 		return fmt.Sprintf("stale (%d days old)", days)
@@ -307,11 +350,16 @@ func (w *Worktree) CleanupReason() string {
 
 // CreateWorktree creates a new worktree with an existing branch
 func (r *Repository) CreateWorktree(path, branchName string) error {
-	_, err := r.executor.ExecuteInDir(r.RootPath, "worktree", "add", path, branchName)
+	err := r.runLocked("creating a worktree", "worktree", "add", path, branchName)
+	r.recordEvent(events.ActionCreate, path, branchName, "", err)
+
 	if err != nil {
 		return fmt.Errorf("failed to create worktree: %w", err)
 	}
 
+	r.initWorktreeSubmodules(path)
+	r.initWorktreeLFS(path)
+
 	// Execute git hooks after worktree creation
 	if err := r.executeWorktreeHooks(path); err != nil {
 		return err
@@ -322,11 +370,16 @@ func (r *Repository) CreateWorktree(path, branchName string) error {
 
 // CreateWorktreeWithNewBranch creates a new worktree with a new branch
 func (r *Repository) CreateWorktreeWithNewBranch(path, branchName, baseBranch string) error {
-	_, err := r.executor.ExecuteInDir(r.RootPath, "worktree", "add", "-b", branchName, path, baseBranch)
+	err := r.runLocked("creating a worktree", "worktree", "add", "-b", branchName, path, baseBranch)
+	r.recordEvent(events.ActionCreate, path, branchName, "new branch from "+baseBranch, err)
+
 	if err != nil {
 		return fmt.Errorf("failed to create worktree with new branch: %w", err)
 	}
 
+	r.initWorktreeSubmodules(path)
+	r.initWorktreeLFS(path)
+
 	// Execute git hooks after worktree creation
 	if err := r.executeWorktreeHooks(path); err != nil {
 		return err
@@ -337,7 +390,9 @@ func (r *Repository) CreateWorktreeWithNewBranch(path, branchName, baseBranch st
 
 // RemoveWorktree removes a worktree (force removal)
 func (r *Repository) RemoveWorktree(path string) error {
-	_, err := r.executor.ExecuteInDir(r.RootPath, "worktree", "remove", "--force", path)
+	err := r.runLocked("removing a worktree", "worktree", "remove", "--force", path)
+	r.recordEvent(events.ActionRemove, path, "", "", err)
+
 	if err != nil {
 		return fmt.Errorf("failed to remove worktree: %w", err)
 	}
@@ -346,11 +401,27 @@ func (r *Repository) RemoveWorktree(path string) error {
 
 // PruneWorktrees removes worktree information for deleted directories
 func (r *Repository) PruneWorktrees() error {
-	_, err := r.executor.ExecuteInDir(r.RootPath, "worktree", "prune")
+	return r.PruneWorktreesExpire("")
+}
+
+// DisableEvents stops recording the repository's operations in the event log,
+// for throwaway repositories that shouldn't show up in history or stats
+func (r *Repository) DisableEvents() {
+	r.eventsDisabled = true
+}
+
+// recordEvent adds an operation on this repository to the event log
+func (r *Repository) recordEvent(action, path, branch, detail string, err error) {
+	if r.eventsDisabled {
+		return
+	}
+
+	e := events.Event{Action: action, RepoPath: r.RootPath, Path: path, Branch: branch, Detail: detail}
 	if err != nil {
-		return fmt.Errorf("failed to prune worktrees: %w", err)
+		e.Error = err.Error()
 	}
-	return nil
+
+	events.Record(e)
 }
 
 // GetWorktreeForBranch returns the worktree for a specific branch, or nil if none exists
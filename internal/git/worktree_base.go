@@ -0,0 +1,259 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// trashSuffix names entries set aside to be deleted, like <name>.trash or a
+// .trash directory; one still there means the removal was interrupted
+const trashSuffix = ".trash"
+
+// BaseIssueKind identifies a problem with an entry of the worktree base directory
+type BaseIssueKind int
+
+const (
+	// BaseIssueUnregistered is a directory git does not know as a worktree
+	BaseIssueUnregistered BaseIssueKind = iota
+	// BaseIssueBrokenSymlink is a symlink whose target is gone
+	BaseIssueBrokenSymlink
+	// BaseIssueTrash is a leftover of an interrupted removal
+	BaseIssueTrash
+	// BaseIssueCaseConflict is a name that differs from another only in case,
+	// which collide on case-insensitive filesystems
+	BaseIssueCaseConflict
+	// BaseIssuePermission is an entry auto-worktree cannot read or change
+	BaseIssuePermission
+)
+
+// String returns a human-readable name for the issue kind
+func (k BaseIssueKind) String() string {
+	switch k {
+	case BaseIssueUnregistered:
+		return "Not a worktree"
+	case BaseIssueBrokenSymlink:
+		return "Broken symlink"
+	case BaseIssueTrash:
+		return "Leftover trash"
+	case BaseIssueCaseConflict:
+		return "Case conflict"
+	case BaseIssuePermission:
+		return "Permissions"
+	default:
+		return "Unknown"
+	}
+}
+
+// BaseIssue is one problem entry in the worktree base directory
+type BaseIssue struct {
+	Kind   BaseIssueKind
+	Path   string
+	Detail string
+	// Adoptable is set on unregistered worktrees of this repository whose
+	// administrative directory still exists, so git worktree repair can
+	// register them again
+	Adoptable bool
+}
+
+// Deletable reports whether repairing the issue removes the entry
+func (i BaseIssue) Deletable() bool {
+	switch i.Kind {
+	case BaseIssueUnregistered:
+		return !i.Adoptable
+	case BaseIssueBrokenSymlink, BaseIssueTrash:
+		return true
+	default:
+		return false
+	}
+}
+
+// ScanWorktreeBase looks for entries in the worktree base that are not
+// registered worktrees, broken symlinks, leftover trash, names that differ only
+// in case, and entries that cannot be read or changed
+func (r *Repository) ScanWorktreeBase() ([]BaseIssue, error) {
+	worktrees, err := r.ListWorktrees()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list worktrees: %w", err)
+	}
+
+	// A bare repository may sit among its worktrees
+	registered := map[string]bool{resolvePath(r.RootPath): true}
+	for _, wt := range worktrees {
+		registered[resolvePath(wt.Path)] = true
+	}
+
+	return scanWorktreeBase(r.WorktreeBase, registered, r.adoptable)
+}
+
+// scanWorktreeBase classifies the entries of base; registered holds the
+// resolved paths of the repository's worktrees
+func scanWorktreeBase(base string, registered map[string]bool, adoptable func(path string) bool) ([]BaseIssue, error) {
+	entries, err := os.ReadDir(base)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+
+	if err != nil {
+		if errors.Is(err, fs.ErrPermission) {
+			return []BaseIssue{{Kind: BaseIssuePermission, Path: base, Detail: "the worktree base cannot be read"}}, nil
+		}
+
+		return nil, fmt.Errorf("failed to read worktree base: %w", err)
+	}
+
+	var issues []BaseIssue
+
+	// Of names that differ only in case, keep quiet about a registered worktree
+	// and flag the others
+	keep := map[string]string{}
+
+	for _, entry := range entries {
+		fold := strings.ToLower(entry.Name())
+		if _, ok := keep[fold]; !ok || registered[resolvePath(filepath.Join(base, entry.Name()))] {
+			keep[fold] = entry.Name()
+		}
+	}
+
+	for _, entry := range entries {
+		name := entry.Name()
+		path := filepath.Join(base, name)
+
+		if name == trashSuffix || strings.HasSuffix(name, trashSuffix) {
+			issues = append(issues, BaseIssue{Kind: BaseIssueTrash, Path: path, Detail: "left behind by an interrupted removal"})
+			continue
+		}
+
+		// Dotfiles are not worktrees auto-worktree made; leave them to their owners
+		if strings.HasPrefix(name, ".") {
+			continue
+		}
+
+		if other := keep[strings.ToLower(name)]; other != name {
+			issues = append(issues, BaseIssue{
+				Kind:   BaseIssueCaseConflict,
+				Path:   path,
+				Detail: fmt.Sprintf("differs from %s only in case", other),
+			})
+		}
+
+		if entry.Type()&fs.ModeSymlink != 0 {
+			if _, err := os.Stat(path); err != nil {
+				target, _ := os.Readlink(path) //nolint:errcheck // only used in the message
+				issues = append(issues, BaseIssue{Kind: BaseIssueBrokenSymlink, Path: path, Detail: "points to missing " + target})
+
+				continue
+			}
+		}
+
+		if detail := permissionProblem(path); detail != "" {
+			issues = append(issues, BaseIssue{Kind: BaseIssuePermission, Path: path, Detail: detail})
+		}
+
+		if registered[resolvePath(path)] {
+			continue
+		}
+
+		issue := BaseIssue{Kind: BaseIssueUnregistered, Path: path, Detail: "not a registered worktree"}
+		if adoptable(path) {
+			issue.Adoptable = true
+			issue.Detail = "a worktree of this repository that git lost track of"
+		}
+
+		issues = append(issues, issue)
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool { return issues[i].Path < issues[j].Path })
+
+	return issues, nil
+}
+
+// permissionProblem describes why a base entry cannot be read or changed, or
+// returns "" when it can
+func permissionProblem(path string) string {
+	info, err := os.Stat(path)
+	if err != nil || !info.IsDir() {
+		return ""
+	}
+
+	dir, err := os.Open(path) //nolint:gosec // an entry of the worktree base
+	if err != nil {
+		if errors.Is(err, fs.ErrPermission) {
+			return "cannot be read"
+		}
+
+		return ""
+	}
+
+	_, err = dir.Readdirnames(1)
+	_ = dir.Close() //nolint:errcheck // read-only
+
+	if errors.Is(err, fs.ErrPermission) {
+		return "cannot be read"
+	}
+
+	if info.Mode().Perm()&0o200 == 0 {
+		return "is not writable, so it cannot be updated or removed"
+	}
+
+	return ""
+}
+
+// adoptable reports whether path is a worktree of this repository that git
+// worktree repair can register: its .git file points to an administrative
+// directory that still exists
+func (r *Repository) adoptable(path string) bool {
+	content, err := os.ReadFile(filepath.Join(path, ".git")) //nolint:gosec // an entry of the worktree base
+	if err != nil {
+		return false
+	}
+
+	gitDir, ok := strings.CutPrefix(strings.TrimSpace(string(content)), "gitdir: ")
+	if !ok {
+		return false
+	}
+
+	if !filepath.IsAbs(gitDir) {
+		gitDir = filepath.Join(path, gitDir)
+	}
+
+	rel, err := filepath.Rel(resolvePath(filepath.Join(r.GitDir(), "worktrees")), resolvePath(gitDir))
+	if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+		return false
+	}
+
+	info, err := os.Stat(gitDir)
+
+	return err == nil && info.IsDir()
+}
+
+// AdoptWorktree registers a worktree git lost track of again
+func (r *Repository) AdoptWorktree(path string) error {
+	if _, err := r.executor.ExecuteInDir(r.RootPath, "worktree", "repair", path); err != nil {
+		return fmt.Errorf("failed to adopt %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// RemoveBaseEntry deletes an entry of the worktree base
+func RemoveBaseEntry(path string) error {
+	if err := os.RemoveAll(path); err != nil {
+		return fmt.Errorf("failed to remove %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// resolvePath returns path with symlinks resolved when it exists
+func resolvePath(path string) string {
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		return resolved
+	}
+
+	return filepath.Clean(path)
+}
@@ -0,0 +1,92 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestScanWorktreeBase(t *testing.T) {
+	base := t.TempDir()
+
+	for _, dir := range []string{"feature", "Feature", "stray", "lost", "old.trash", ".cache"} {
+		if err := os.Mkdir(filepath.Join(base, dir), 0o750); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := os.Symlink(filepath.Join(base, "missing"), filepath.Join(base, "dangling")); err != nil {
+		t.Fatal(err)
+	}
+
+	registered := map[string]bool{resolvePath(filepath.Join(base, "feature")): true}
+	adoptable := func(path string) bool { return filepath.Base(path) == "lost" }
+
+	issues, err := scanWorktreeBase(base, registered, adoptable)
+	if err != nil {
+		t.Fatalf("scanWorktreeBase() error = %v", err)
+	}
+
+	got := map[string]BaseIssue{}
+	for _, issue := range issues {
+		got[filepath.Base(issue.Path)+" "+issue.Kind.String()] = issue
+	}
+
+	for _, want := range []string{
+		"Feature Case conflict",
+		"Feature Not a worktree",
+		"stray Not a worktree",
+		"lost Not a worktree",
+		"old.trash Leftover trash",
+		"dangling Broken symlink",
+	} {
+		if _, ok := got[want]; !ok {
+			t.Errorf("scanWorktreeBase() is missing %q; got %v", want, issues)
+		}
+	}
+
+	if len(issues) != 6 {
+		t.Errorf("scanWorktreeBase() returned %d issues, want 6: %v", len(issues), issues)
+	}
+
+	if !got["lost Not a worktree"].Adoptable || got["lost Not a worktree"].Deletable() {
+		t.Errorf("lost = %+v, want adoptable rather than deletable", got["lost Not a worktree"])
+	}
+
+	if !got["stray Not a worktree"].Deletable() || got["Feature Case conflict"].Deletable() {
+		t.Error("only stray entries should be deletable")
+	}
+
+	if issues, err := scanWorktreeBase(filepath.Join(base, "none"), nil, adoptable); err != nil || len(issues) != 0 {
+		t.Errorf("scanWorktreeBase(missing) = %v, %v; want nothing", issues, err)
+	}
+}
+
+func TestScanWorktreeBase_Permissions(t *testing.T) {
+	if runtime.GOOS == "windows" || os.Geteuid() == 0 {
+		t.Skip("permissions are not enforced")
+	}
+
+	base := t.TempDir()
+	locked := filepath.Join(base, "locked")
+
+	if err := os.Mkdir(locked, 0o750); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.Chmod(locked, 0o500); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { _ = os.Chmod(locked, 0o750) }) //nolint:errcheck // let TempDir clean up
+
+	issues, err := scanWorktreeBase(base, map[string]bool{resolvePath(locked): true}, func(string) bool { return false })
+	if err != nil {
+		t.Fatalf("scanWorktreeBase() error = %v", err)
+	}
+
+	if len(issues) != 1 || issues[0].Kind != BaseIssuePermission {
+		t.Errorf("scanWorktreeBase() = %v, want one permission issue", issues)
+	}
+}
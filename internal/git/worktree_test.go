@@ -227,6 +227,27 @@ branch refs/heads/feature-branch
 	}
 }
 
+func TestParseWorktreeListSkipsBareRepository(t *testing.T) {
+	fake := NewFakeGitExecutor()
+
+	porcelainOutput := `worktree /src/proj/repo.git
+bare
+
+worktree /src/proj/feature
+HEAD abcdef1234567890abcdef1234567890abcdef12
+branch refs/heads/feature
+`
+
+	worktrees, err := parseWorktreeList(porcelainOutput, fake)
+	if err != nil {
+		t.Fatalf("parseWorktreeList() error = %v", err)
+	}
+
+	if len(worktrees) != 1 || worktrees[0].Path != "/src/proj/feature" {
+		t.Fatalf("parseWorktreeList() = %+v, want only the feature worktree", worktrees)
+	}
+}
+
 func TestParseWorktreeList(t *testing.T) {
 	fake := NewFakeGitExecutor()
 
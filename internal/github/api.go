@@ -0,0 +1,771 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kaeawc/auto-worktree/internal/interrupt"
+	"github.com/kaeawc/auto-worktree/internal/limits"
+	"github.com/kaeawc/auto-worktree/internal/logging"
+	"github.com/kaeawc/auto-worktree/internal/retry"
+)
+
+// Transports for reaching GitHub
+const (
+	// TransportCLI runs the gh CLI (the default)
+	TransportCLI = "cli"
+	// TransportAPI calls the GitHub API directly with a token, for machines without gh
+	TransportAPI = "api"
+)
+
+// defaultAPIURL is the REST API root when GITHUB_API_URL is not set
+const defaultAPIURL = "https://api.github.com"
+
+// ErrAPIUnsupported is returned for gh commands the API transport cannot answer
+var ErrAPIUnsupported = errors.New("not available with the GitHub API transport")
+
+var (
+	transportMu sync.RWMutex
+	transport   = TransportCLI
+)
+
+// SetTransport chooses how NewGitHubExecutor reaches GitHub
+func SetTransport(t string) {
+	transportMu.Lock()
+	defer transportMu.Unlock()
+
+	transport = t
+}
+
+// useAPI reports whether new executors call the API: when configured to, or
+// when gh is not installed but a token is in the environment (as in CI)
+func useAPI() bool {
+	transportMu.RLock()
+	t := transport
+	transportMu.RUnlock()
+
+	if t == TransportAPI {
+		return true
+	}
+
+	if _, err := exec.LookPath("gh"); err != nil {
+		return envToken() != ""
+	}
+
+	return false
+}
+
+// envToken returns GITHUB_TOKEN, or GH_TOKEN as gh itself accepts
+func envToken() string {
+	if token := os.Getenv("GITHUB_TOKEN"); token != "" {
+		return token
+	}
+
+	return os.Getenv("GH_TOKEN")
+}
+
+// APIToken finds a token for the API: GITHUB_TOKEN, GH_TOKEN, or the one gh is
+// signed in with, asking gh until ctx is done
+func APIToken(ctx context.Context) string {
+	if token := envToken(); token != "" {
+		return token
+	}
+
+	if _, err := exec.LookPath("gh"); err != nil {
+		return ""
+	}
+
+	token, err := runGH(ctx, "", []string{"auth", "token"})
+	if err != nil {
+		return ""
+	}
+
+	return token
+}
+
+// APIExecutor answers the gh commands Client runs by calling the GitHub
+// GraphQL and REST APIs, so issue and PR features work without the gh binary.
+// Output has the same JSON shape gh prints for --json.
+type APIExecutor struct {
+	// Token authenticates requests; when empty it is looked up on first use
+	Token string
+	// BaseURL is the REST API root (GITHUB_API_URL, or https://api.github.com)
+	BaseURL string
+	// GraphQLURL is the GraphQL endpoint (GITHUB_GRAPHQL_URL, or BaseURL/graphql)
+	GraphQLURL string
+	HTTP       *http.Client
+
+	// ctx stops requests when it is done; nil never does
+	ctx       context.Context
+	tokenOnce sync.Once
+}
+
+// NewAPIExecutor creates an executor that uses the API with the given token
+// (or, when empty, the token from APIToken)
+func NewAPIExecutor(token string) *APIExecutor {
+	base := strings.TrimSuffix(os.Getenv("GITHUB_API_URL"), "/")
+	if base == "" {
+		base = defaultAPIURL
+	}
+
+	graphQL := os.Getenv("GITHUB_GRAPHQL_URL")
+	if graphQL == "" {
+		graphQL = base + "/graphql"
+	}
+
+	return &APIExecutor{Token: token, BaseURL: base, GraphQLURL: graphQL, HTTP: &http.Client{}, ctx: interrupt.Context()}
+}
+
+// ExecuteInDir runs a gh command; the API needs no working directory
+func (e *APIExecutor) ExecuteInDir(_ string, args ...string) (string, error) {
+	return e.Execute(args...)
+}
+
+// Execute answers a gh command, e.g. "-R owner/repo issue view 7 --json title"
+func (e *APIExecutor) Execute(args ...string) (string, error) {
+	e.tokenOnce.Do(func() {
+		if e.Token == "" {
+			e.Token = APIToken(orBackground(e.ctx))
+		}
+	})
+
+	repo := ""
+	if len(args) >= 2 && args[0] == "-R" {
+		repo, args = args[1], args[2:]
+	}
+
+	positional, flags := parseGHArgs(args)
+	command := strings.Join(positional[:min(2, len(positional))], " ")
+
+	var (
+		output string
+		err    error
+	)
+
+	switch {
+	case len(args) == 1 && args[0] == "--version":
+		return "gh (GitHub API transport)", nil
+	case command == "auth status":
+		if e.Token == "" {
+			return "", fmt.Errorf("no token: set GITHUB_TOKEN or sign in with gh auth login")
+		}
+
+		return "Logged in with a token", nil
+	case command == "issue list", command == "pr list":
+		output, err = e.list(repo, positional[0], flags)
+	case command == "issue view", command == "pr view":
+		output, err = e.view(repo, positional, flags)
+	case command == "issue create":
+		output, err = e.createIssue(repo, flags)
+	case command == "issue edit":
+		output, err = e.editIssue(repo, positional, flags)
+	case command == "issue comment" && len(positional) == 3:
+		output, err = e.rest(http.MethodPost, "/repos/"+repo+"/issues/"+positional[2]+"/comments", "",
+			map[string]string{"body": flags.get("--body")})
+	case command == "pr create":
+		output, err = e.createPR(repo, flags)
+	case command == "pr edit" && len(positional) == 3:
+		output, err = e.rest(http.MethodPatch, "/repos/"+repo+"/pulls/"+positional[2], "",
+			map[string]string{"title": flags.get("--title"), "body": flags.get("--body")})
+	case command == "pr diff" && len(positional) == 3:
+		output, err = e.rest(http.MethodGet, "/repos/"+repo+"/pulls/"+positional[2], "application/vnd.github.diff", nil)
+	case command == "run view" && flags.get("--job") != "":
+		output, err = e.rest(http.MethodGet, "/repos/"+repo+"/actions/jobs/"+flags.get("--job")+"/logs", "", nil)
+	case command == "api graphql":
+		output, err = e.graphQLArgs(flags)
+	default:
+		return "", fmt.Errorf("gh %s: %w", strings.Join(args, " "), ErrAPIUnsupported)
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("gh %s failed: %w", strings.Join(args, " "), err)
+	}
+
+	return strings.TrimSpace(output), nil
+}
+
+// ghFlags are the flags of a gh command; repeated flags keep every value
+type ghFlags map[string][]string
+
+func (f ghFlags) get(name string) string {
+	if values := f[name]; len(values) > 0 {
+		return values[len(values)-1]
+	}
+
+	return ""
+}
+
+// ghBoolFlags take no value
+var ghBoolFlags = map[string]bool{"--web": true, "--log-failed": true, "--draft": true}
+
+// parseGHArgs splits gh arguments into positional words and flags
+func parseGHArgs(args []string) ([]string, ghFlags) {
+	var positional []string
+
+	flags := ghFlags{}
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+
+		switch {
+		case !strings.HasPrefix(arg, "-"):
+			positional = append(positional, arg)
+		case ghBoolFlags[arg] || i+1 >= len(args):
+			flags[arg] = append(flags[arg], "true")
+		default:
+			flags[arg] = append(flags[arg], args[i+1])
+			i++
+		}
+	}
+
+	return positional, flags
+}
+
+// graphQLSelections are the GraphQL selections for gh --json fields that are
+// not plain scalars; other fields are selected by name
+var graphQLSelections = map[string]string{
+	"author":        "author { login }",
+	"labels":        "labels(first: 100) { nodes { name color } }",
+	"comments":      "comments(first: 100) { nodes { author { login } body createdAt } }",
+	"reviews":       "reviews(first: 100) { nodes { author { login } body state submittedAt } }",
+	"latestReviews": "latestReviews(first: 100) { nodes { author { login } state } }",
+	"reviewRequests": "reviewRequests(first: 50) { nodes { requestedReviewer { __typename " +
+		"... on User { login } ... on Team { login: slug } } } }",
+	"headRepository":      "headRepository { name }",
+	"headRepositoryOwner": "headRepositoryOwner { login }",
+	"statusCheckRollup": "commits(last: 1) { nodes { commit { statusCheckRollup { contexts(first: 100) { nodes { " +
+		"__typename ... on CheckRun { name status conclusion detailsUrl checkSuite { workflowRun { workflow { name } } } } " +
+		"... on StatusContext { context state targetUrl } } } } } } }",
+}
+
+// selection builds the GraphQL selection for a --json field list
+func selection(fields string) string {
+	parts := strings.Split(fields, ",")
+	for i, field := range parts {
+		if sel, ok := graphQLSelections[field]; ok {
+			parts[i] = sel
+		}
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// list answers issue list and pr list with a search, which supports every filter gh offers
+func (e *APIExecutor) list(repo, kind string, flags ghFlags) (string, error) {
+	query := []string{"repo:" + repo}
+
+	if kind == "pr" {
+		query = append(query, "is:pr")
+	} else {
+		query = append(query, "is:issue")
+	}
+
+	switch state := flags.get("--state"); state {
+	case "", "open":
+		query = append(query, "is:open")
+	case "closed", "merged":
+		query = append(query, "is:"+state)
+	}
+
+	for _, label := range flags["--label"] {
+		query = append(query, "label:"+strconv.Quote(label))
+	}
+
+	switch assignee := flags.get("--assignee"); assignee {
+	case "":
+	case "none":
+		query = append(query, "no:assignee")
+	default:
+		query = append(query, "assignee:"+assignee)
+	}
+
+	if milestone := flags.get("--milestone"); milestone != "" {
+		query = append(query, "milestone:"+strconv.Quote(milestone))
+	}
+
+	if head := flags.get("--head"); head != "" {
+		query = append(query, "head:"+head)
+	}
+
+	query = append(query, "sort:created-desc")
+
+	if search := flags.get("--search"); search != "" {
+		query = append(query, search)
+	}
+
+	limit, err := strconv.Atoi(flags.get("--limit"))
+	if err != nil || limit <= 0 {
+		limit = 30
+	}
+
+	nodeType := "Issue"
+	if kind == "pr" {
+		nodeType = "PullRequest"
+	}
+
+	fields := flags.get("--json")
+	if fields == "" {
+		fields = "number,title,url"
+	}
+
+	gql := fmt.Sprintf("query($q: String!, $n: Int!) { search(query: $q, type: ISSUE, first: $n) { nodes { ... on %s { %s } } } }",
+		nodeType, selection(fields))
+
+	var result struct {
+		Search struct {
+			Nodes []json.RawMessage `json:"nodes"`
+		} `json:"search"`
+	}
+
+	variables := map[string]any{"q": strings.Join(query, " "), "n": min(limit, 100)}
+	if err := e.graphQL(gql, variables, &result); err != nil {
+		return "", err
+	}
+
+	items := make([]any, 0, len(result.Search.Nodes))
+
+	for _, raw := range result.Search.Nodes {
+		item, err := ghShape(raw)
+		if err != nil {
+			return "", err
+		}
+
+		items = append(items, item)
+	}
+
+	if flags.get("--jq") == "length" {
+		return strconv.Itoa(len(items)), nil
+	}
+
+	return marshal(items)
+}
+
+// view answers issue view and pr view
+func (e *APIExecutor) view(repo string, positional []string, flags ghFlags) (string, error) {
+	if flags.get("--web") != "" {
+		return "", fmt.Errorf("opening a browser is %w", ErrAPIUnsupported)
+	}
+
+	if len(positional) < 3 {
+		return "", fmt.Errorf("a number is required")
+	}
+
+	number, err := strconv.Atoi(positional[2])
+	if err != nil {
+		return "", fmt.Errorf("invalid number %q", positional[2])
+	}
+
+	owner, name, _ := strings.Cut(repo, "/")
+
+	field := "issue"
+	if positional[0] == "pr" {
+		field = "pullRequest"
+	}
+
+	gql := fmt.Sprintf("query($owner: String!, $repo: String!, $number: Int!) { repository(owner: $owner, name: $repo) { %s(number: $number) { %s } } }",
+		field, selection(flags.get("--json")))
+
+	var result struct {
+		Repository map[string]json.RawMessage `json:"repository"`
+	}
+
+	if err := e.graphQL(gql, map[string]any{"owner": owner, "repo": name, "number": number}, &result); err != nil {
+		return "", err
+	}
+
+	item, err := ghShape(result.Repository[field])
+	if err != nil {
+		return "", err
+	}
+
+	return marshal(item)
+}
+
+// createIssue answers issue create, printing the new issue as gh --json would
+func (e *APIExecutor) createIssue(repo string, flags ghFlags) (string, error) {
+	output, err := e.rest(http.MethodPost, "/repos/"+repo+"/issues", "",
+		map[string]string{"title": flags.get("--title"), "body": flags.get("--body")})
+	if err != nil {
+		return "", err
+	}
+
+	var created struct {
+		Number  int    `json:"number"`
+		Title   string `json:"title"`
+		Body    string `json:"body"`
+		HTMLURL string `json:"html_url"`
+	}
+
+	if err := json.Unmarshal([]byte(output), &created); err != nil {
+		return "", fmt.Errorf("failed to parse created issue: %w", err)
+	}
+
+	return marshal(map[string]any{"number": created.Number, "title": created.Title, "body": created.Body, "url": created.HTMLURL})
+}
+
+// createPR answers pr create, requesting reviews (org/team ones as team
+// reviews) and adding labels as gh does, and prints the new PR's URL
+func (e *APIExecutor) createPR(repo string, flags ghFlags) (string, error) {
+	output, err := e.rest(http.MethodPost, "/repos/"+repo+"/pulls", "", map[string]any{
+		"title": flags.get("--title"),
+		"body":  flags.get("--body"),
+		"base":  flags.get("--base"),
+		"head":  flags.get("--head"),
+		"draft": flags.get("--draft") == "true",
+	})
+	if err != nil {
+		return "", err
+	}
+
+	var created struct {
+		Number  int    `json:"number"`
+		HTMLURL string `json:"html_url"`
+	}
+
+	if err := json.Unmarshal([]byte(output), &created); err != nil {
+		return "", fmt.Errorf("failed to parse created PR: %w", err)
+	}
+
+	number := strconv.Itoa(created.Number)
+
+	if reviewers := flags["--reviewer"]; len(reviewers) > 0 {
+		users, teams := []string{}, []string{}
+
+		for _, reviewer := range reviewers {
+			if _, team, ok := strings.Cut(reviewer, "/"); ok {
+				teams = append(teams, team)
+			} else {
+				users = append(users, reviewer)
+			}
+		}
+
+		if _, err := e.rest(http.MethodPost, "/repos/"+repo+"/pulls/"+number+"/requested_reviewers", "",
+			map[string][]string{"reviewers": users, "team_reviewers": teams}); err != nil {
+			return "", fmt.Errorf("opened %s but failed to request reviews: %w", created.HTMLURL, err)
+		}
+	}
+
+	if labels := flags["--label"]; len(labels) > 0 {
+		if _, err := e.rest(http.MethodPost, "/repos/"+repo+"/issues/"+number+"/labels", "",
+			map[string][]string{"labels": labels}); err != nil {
+			return "", fmt.Errorf("opened %s but failed to add labels: %w", created.HTMLURL, err)
+		}
+	}
+
+	return created.HTMLURL, nil
+}
+
+// editIssue answers issue edit --add-assignee and --add-label
+func (e *APIExecutor) editIssue(repo string, positional []string, flags ghFlags) (string, error) {
+	if len(positional) < 3 {
+		return "", fmt.Errorf("an issue number is required")
+	}
+
+	path := "/repos/" + repo + "/issues/" + positional[2]
+
+	if assignee := flags.get("--add-assignee"); assignee != "" {
+		if assignee == "@me" {
+			login, err := e.login()
+			if err != nil {
+				return "", err
+			}
+
+			assignee = login
+		}
+
+		if _, err := e.rest(http.MethodPost, path+"/assignees", "", map[string][]string{"assignees": {assignee}}); err != nil {
+			return "", err
+		}
+	}
+
+	if labels := flags.get("--add-label"); labels != "" {
+		if _, err := e.rest(http.MethodPost, path+"/labels", "", map[string][]string{"labels": strings.Split(labels, ",")}); err != nil {
+			return "", err
+		}
+	}
+
+	return "", nil
+}
+
+// login returns the signed-in user's login
+func (e *APIExecutor) login() (string, error) {
+	output, err := e.rest(http.MethodGet, "/user", "", nil)
+	if err != nil {
+		return "", err
+	}
+
+	var user struct {
+		Login string `json:"login"`
+	}
+
+	if err := json.Unmarshal([]byte(output), &user); err != nil {
+		return "", fmt.Errorf("failed to parse user: %w", err)
+	}
+
+	return user.Login, nil
+}
+
+// graphQLArgs answers gh api graphql -f query=... -f key=value -F key=number
+func (e *APIExecutor) graphQLArgs(flags ghFlags) (string, error) {
+	variables := map[string]any{}
+
+	var query string
+
+	for _, flag := range []string{"-f", "-F"} {
+		for _, pair := range flags[flag] {
+			key, value, _ := strings.Cut(pair, "=")
+
+			switch {
+			case key == "query":
+				query = value
+			case flag == "-F":
+				// -F sends numbers and booleans typed, as gh does
+				if n, err := strconv.Atoi(value); err == nil {
+					variables[key] = n
+				} else if b, err := strconv.ParseBool(value); err == nil {
+					variables[key] = b
+				} else {
+					variables[key] = value
+				}
+			default:
+				variables[key] = value
+			}
+		}
+	}
+
+	var data json.RawMessage
+	if err := e.graphQL(query, variables, &data); err != nil {
+		return "", err
+	}
+
+	return marshal(map[string]json.RawMessage{"data": data})
+}
+
+// graphQL runs a query and decodes its data into out
+func (e *APIExecutor) graphQL(query string, variables map[string]any, out any) error {
+	body, err := json.Marshal(map[string]any{"query": query, "variables": variables})
+	if err != nil {
+		return fmt.Errorf("failed to encode query: %w", err)
+	}
+
+	output, err := e.do(http.MethodPost, e.GraphQLURL, "", body)
+	if err != nil {
+		return err
+	}
+
+	var response struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+
+	if err := json.Unmarshal(output, &response); err != nil {
+		return fmt.Errorf("failed to parse GraphQL response: %w", err)
+	}
+
+	if len(response.Errors) > 0 {
+		return fmt.Errorf("GraphQL: %s", response.Errors[0].Message)
+	}
+
+	if err := json.Unmarshal(response.Data, out); err != nil {
+		return fmt.Errorf("failed to parse GraphQL data: %w", err)
+	}
+
+	return nil
+}
+
+// rest calls a REST endpoint, sending payload as JSON when it is not nil
+func (e *APIExecutor) rest(method, path, accept string, payload any) (string, error) {
+	var body []byte
+
+	if payload != nil {
+		var err error
+		if body, err = json.Marshal(payload); err != nil {
+			return "", fmt.Errorf("failed to encode request: %w", err)
+		}
+	}
+
+	output, err := e.do(method, e.BaseURL+path, accept, body)
+
+	return string(output), err
+}
+
+// do sends a request under the gh timeout, retrying transient failures and rate limits
+func (e *APIExecutor) do(method, url, accept string, body []byte) ([]byte, error) {
+	if e.Token == "" {
+		return nil, ErrGHNotAuthenticated
+	}
+
+	return retry.DefaultPolicy.Run("GitHub", func() ([]byte, error) {
+		ctx, cancel := limits.Context(orBackground(e.ctx), limits.ToolGitHub)
+		defer cancel()
+
+		req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+		if err != nil {
+			return nil, fmt.Errorf("failed to build request: %w", err)
+		}
+
+		req.Header.Set("Authorization", "Bearer "+e.Token)
+		req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+
+		if accept == "" {
+			accept = "application/vnd.github+json"
+		}
+
+		req.Header.Set("Accept", accept)
+
+		if body != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+
+		start := time.Now()
+		resp, err := e.HTTP.Do(req)
+		logging.Debug("github api", "method", method, "url", url, "duration", time.Since(start), "err", err)
+
+		if err != nil {
+			return nil, limits.Wrap(ctx, limits.ToolGitHub, err)
+		}
+		defer resp.Body.Close()
+
+		output, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, limits.Wrap(ctx, limits.ToolGitHub, fmt.Errorf("failed to read response: %w", err))
+		}
+
+		if resp.StatusCode >= http.StatusMultipleChoices {
+			return output, fmt.Errorf("HTTP %d: %s", resp.StatusCode, apiMessage(output))
+		}
+
+		return output, nil
+	})
+}
+
+// apiMessage extracts the message of an API error response
+func apiMessage(body []byte) string {
+	var apiErr struct {
+		Message string `json:"message"`
+	}
+
+	if json.Unmarshal(body, &apiErr) == nil && apiErr.Message != "" {
+		return apiErr.Message
+	}
+
+	return strings.TrimSpace(string(body))
+}
+
+// ghShape converts a GraphQL node to the JSON gh prints: connections become
+// lists, review requests list the reviewers, and the status check rollup is
+// lifted out of the last commit
+func ghShape(raw json.RawMessage) (any, error) {
+	var node any
+	if err := json.Unmarshal(raw, &node); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	node = flattenNodes(node)
+
+	obj, ok := node.(map[string]any)
+	if !ok {
+		return node, nil
+	}
+
+	if requests, ok := obj["reviewRequests"].([]any); ok {
+		reviewers := make([]any, 0, len(requests))
+		for _, r := range requests {
+			if m, ok := r.(map[string]any); ok {
+				reviewers = append(reviewers, m["requestedReviewer"])
+			}
+		}
+
+		obj["reviewRequests"] = reviewers
+	}
+
+	if commits, ok := obj["commits"].([]any); ok {
+		obj["statusCheckRollup"] = statusChecks(commits)
+		delete(obj, "commits")
+	}
+
+	return obj, nil
+}
+
+// flattenNodes replaces every {"nodes": [...]} object with its list
+func flattenNodes(v any) any {
+	switch value := v.(type) {
+	case map[string]any:
+		if nodes, ok := value["nodes"]; ok && len(value) == 1 {
+			return flattenNodes(nodes)
+		}
+
+		for k, child := range value {
+			value[k] = flattenNodes(child)
+		}
+
+		return value
+	case []any:
+		for i, child := range value {
+			value[i] = flattenNodes(child)
+		}
+
+		return value
+	default:
+		return v
+	}
+}
+
+// statusChecks reads the check runs and statuses of the last commit, naming each
+// check run's workflow as gh does
+func statusChecks(commits []any) []any {
+	checks := []any{}
+
+	if len(commits) == 0 {
+		return checks
+	}
+
+	last, _ := commits[len(commits)-1].(map[string]any)
+	commit, _ := last["commit"].(map[string]any)
+	rollup, _ := commit["statusCheckRollup"].(map[string]any)
+	contexts, _ := rollup["contexts"].([]any)
+
+	for _, c := range contexts {
+		check, ok := c.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		if suite, ok := check["checkSuite"].(map[string]any); ok {
+			run, _ := suite["workflowRun"].(map[string]any)
+			workflow, _ := run["workflow"].(map[string]any)
+
+			if name, ok := workflow["name"].(string); ok {
+				check["workflowName"] = name
+			}
+
+			delete(check, "checkSuite")
+		}
+
+		checks = append(checks, check)
+	}
+
+	return checks
+}
+
+func marshal(v any) (string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode output: %w", err)
+	}
+
+	return string(data), nil
+}
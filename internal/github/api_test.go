@@ -0,0 +1,250 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kaeawc/auto-worktree/internal/retry"
+)
+
+// newTestAPIExecutor serves the API from handler and returns an executor that calls it
+func newTestAPIExecutor(t *testing.T, handler http.HandlerFunc) *APIExecutor {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	policy := retry.DefaultPolicy
+	retry.DefaultPolicy = retry.Policy{Attempts: 1}
+
+	t.Cleanup(func() { retry.DefaultPolicy = policy })
+
+	return &APIExecutor{Token: "test-token", BaseURL: server.URL, GraphQLURL: server.URL + "/graphql", HTTP: server.Client()}
+}
+
+// graphQLRequest is the body the executor posts to the GraphQL endpoint
+type graphQLRequest struct {
+	Query     string         `json:"query"`
+	Variables map[string]any `json:"variables"`
+}
+
+func decodeGraphQL(t *testing.T, r *http.Request) graphQLRequest {
+	t.Helper()
+
+	var req graphQLRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		t.Fatalf("failed to decode GraphQL request: %v", err)
+	}
+
+	return req
+}
+
+func TestAPIExecutor_StopsWithItsContext(t *testing.T) {
+	executor := newTestAPIExecutor(t, func(http.ResponseWriter, *http.Request) {
+		t.Error("a canceled executor should not send requests")
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	executor.ctx = ctx
+
+	if _, err := executor.Execute("issue", "list", "--json", "number"); !errors.Is(err, context.Canceled) {
+		t.Errorf("Execute() error = %v, want context.Canceled", err)
+	}
+}
+
+func TestAPIExecutor_IssueList(t *testing.T) {
+	var got graphQLRequest
+
+	executor := newTestAPIExecutor(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			t.Errorf("Authorization = %q", r.Header.Get("Authorization"))
+		}
+
+		got = decodeGraphQL(t, r)
+		io.WriteString(w, `{"data":{"search":{"nodes":[
+			{"number":7,"title":"Fix login","url":"https://github.com/o/r/issues/7",
+			 "labels":{"nodes":[{"name":"bug","color":"d73a4a"}]}}]}}}`)
+	})
+
+	client := &Client{Owner: "o", Repo: "r", executor: executor}
+
+	issues, err := client.ListFilteredIssues(10, IssueFilter{Labels: []string{"good first issue"}, Assignee: "@me"})
+	if err != nil {
+		t.Fatalf("ListFilteredIssues() error = %v", err)
+	}
+
+	if len(issues) != 1 || issues[0].Number != 7 || len(issues[0].Labels) != 1 || issues[0].Labels[0].Name != "bug" {
+		t.Errorf("ListFilteredIssues() = %+v", issues)
+	}
+
+	wantQuery := `repo:o/r is:issue is:open label:"good first issue" assignee:@me sort:created-desc`
+	if got.Variables["q"] != wantQuery {
+		t.Errorf("search query = %q, want %q", got.Variables["q"], wantQuery)
+	}
+
+	if !strings.Contains(got.Query, "... on Issue { number title labels(first: 100)") {
+		t.Errorf("query does not select the requested fields: %s", got.Query)
+	}
+}
+
+func TestAPIExecutor_PRView(t *testing.T) {
+	executor := newTestAPIExecutor(t, func(w http.ResponseWriter, r *http.Request) {
+		req := decodeGraphQL(t, r)
+		if req.Variables["owner"] != "o" || req.Variables["repo"] != "r" || req.Variables["number"] != float64(12) {
+			t.Errorf("variables = %v", req.Variables)
+		}
+
+		io.WriteString(w, `{"data":{"repository":{"pullRequest":{"number":12,"title":"Add retry",
+			"reviewRequests":{"nodes":[{"requestedReviewer":{"__typename":"User","login":"alice"}}]},
+			"commits":{"nodes":[{"commit":{"statusCheckRollup":{"contexts":{"nodes":[
+				{"__typename":"CheckRun","name":"test","status":"COMPLETED","conclusion":"FAILURE",
+				 "detailsUrl":"https://github.com/o/r/actions/runs/1/job/2",
+				 "checkSuite":{"workflowRun":{"workflow":{"name":"CI"}}}}]}}}}]}}}}}`)
+	})
+
+	output, err := executor.Execute("-R", "o/r", "pr", "view", "12", "--json", "number,title,reviewRequests,statusCheckRollup")
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	var pr struct {
+		Number         int `json:"number"`
+		ReviewRequests []struct {
+			Login string `json:"login"`
+		} `json:"reviewRequests"`
+		StatusCheckRollup []struct {
+			Name         string `json:"name"`
+			Conclusion   string `json:"conclusion"`
+			WorkflowName string `json:"workflowName"`
+		} `json:"statusCheckRollup"`
+	}
+
+	if err := json.Unmarshal([]byte(output), &pr); err != nil {
+		t.Fatalf("output is not gh JSON: %v\n%s", err, output)
+	}
+
+	if pr.Number != 12 || len(pr.ReviewRequests) != 1 || pr.ReviewRequests[0].Login != "alice" {
+		t.Errorf("pr = %+v", pr)
+	}
+
+	if len(pr.StatusCheckRollup) != 1 || pr.StatusCheckRollup[0].WorkflowName != "CI" || pr.StatusCheckRollup[0].Conclusion != "FAILURE" {
+		t.Errorf("statusCheckRollup = %+v", pr.StatusCheckRollup)
+	}
+}
+
+func TestAPIExecutor_CreateIssue(t *testing.T) {
+	executor := newTestAPIExecutor(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/repos/o/r/issues" {
+			t.Errorf("request = %s %s", r.Method, r.URL.Path)
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		io.WriteString(w, `{"number":42,"title":"New","body":"Details","html_url":"https://github.com/o/r/issues/42"}`)
+	})
+
+	client := &Client{Owner: "o", Repo: "r", executor: executor}
+
+	issue, err := client.CreateIssue("New", "Details")
+	if err != nil {
+		t.Fatalf("CreateIssue() error = %v", err)
+	}
+
+	if issue.Number != 42 || issue.URL != "https://github.com/o/r/issues/42" {
+		t.Errorf("CreateIssue() = %+v", issue)
+	}
+}
+
+func TestAPIExecutor_CreatePR(t *testing.T) {
+	var requested, labeled map[string][]string
+
+	executor := newTestAPIExecutor(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/graphql":
+			io.WriteString(w, `{"data":{"viewer":{"login":"me"}}}`)
+		case "/repos/o/r/pulls":
+			var body map[string]any
+			_ = json.NewDecoder(r.Body).Decode(&body)
+
+			if body["head"] != "feature" || body["base"] != "main" || body["draft"] != true {
+				t.Errorf("pulls body = %v", body)
+			}
+
+			w.WriteHeader(http.StatusCreated)
+			io.WriteString(w, `{"number":7,"html_url":"https://github.com/o/r/pull/7"}`)
+		case "/repos/o/r/pulls/7/requested_reviewers":
+			_ = json.NewDecoder(r.Body).Decode(&requested)
+			io.WriteString(w, `{}`)
+		case "/repos/o/r/issues/7/labels":
+			_ = json.NewDecoder(r.Body).Decode(&labeled)
+			io.WriteString(w, `[]`)
+		default:
+			t.Errorf("request = %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	client := &Client{Owner: "o", Repo: "r", executor: executor}
+
+	pr, err := client.CreatePR(CreatePROptions{
+		Title: "Add feature", Body: "Details", Base: "main", Head: "feature",
+		Reviewers: []string{"alice", "Me", "acme/core"}, Labels: []string{"agent"}, Draft: true,
+	})
+	if err != nil {
+		t.Fatalf("CreatePR() error = %v", err)
+	}
+
+	if pr.Number != 7 || pr.URL != "https://github.com/o/r/pull/7" || !pr.IsDraft {
+		t.Errorf("CreatePR() = %+v", pr)
+	}
+
+	if strings.Join(requested["reviewers"], ",") != "alice" || strings.Join(requested["team_reviewers"], ",") != "core" {
+		t.Errorf("requested reviewers = %v", requested)
+	}
+
+	if strings.Join(labeled["labels"], ",") != "agent" {
+		t.Errorf("labels = %v", labeled)
+	}
+}
+
+func TestAPIExecutor_Errors(t *testing.T) {
+	executor := newTestAPIExecutor(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		io.WriteString(w, `{"message":"API rate limit exceeded for user ID 1."}`)
+	})
+
+	_, err := executor.Execute("-R", "o/r", "issue", "comment", "7", "--body", "hi")
+	if !errors.Is(err, retry.ErrRateLimited) {
+		t.Errorf("Execute() error = %v, want ErrRateLimited", err)
+	}
+
+	if _, err := executor.Execute("-R", "o/r", "pr", "checkout", "7"); !errors.Is(err, ErrAPIUnsupported) {
+		t.Errorf("Execute(pr checkout) error = %v, want ErrAPIUnsupported", err)
+	}
+
+	t.Setenv("GITHUB_TOKEN", "")
+	t.Setenv("GH_TOKEN", "")
+	t.Setenv("PATH", t.TempDir())
+
+	if err := IsAuthenticated(&APIExecutor{}); err == nil {
+		t.Error("IsAuthenticated() without a token = nil, want error")
+	}
+}
+
+func TestParseGHArgs(t *testing.T) {
+	positional, flags := parseGHArgs([]string{"issue", "list", "--label", "a", "--label", "b", "--web", "--limit", "5"})
+
+	if strings.Join(positional, " ") != "issue list" {
+		t.Errorf("positional = %v", positional)
+	}
+
+	if strings.Join(flags["--label"], ",") != "a,b" || flags.get("--web") != "true" || flags.get("--limit") != "5" {
+		t.Errorf("flags = %v", flags)
+	}
+}
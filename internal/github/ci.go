@@ -0,0 +1,138 @@
+package github
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// actionsJobURL matches the details URL of a GitHub Actions job
+var actionsJobURL = regexp.MustCompile(`/actions/runs/\d+/job/(\d+)`)
+
+// Failed reports whether the check completed without passing
+func (s *StatusCheck) Failed() bool {
+	if s.Status != "COMPLETED" {
+		return false
+	}
+
+	switch s.Conclusion {
+	case "SUCCESS", "NEUTRAL", "SKIPPED":
+		return false
+	default:
+		return true
+	}
+}
+
+// JobID returns the GitHub Actions job behind the check, if it is one
+func (s *StatusCheck) JobID() (int64, bool) {
+	m := actionsJobURL.FindStringSubmatch(s.DetailsURL)
+	if m == nil {
+		return 0, false
+	}
+
+	id, err := strconv.ParseInt(m[1], 10, 64)
+
+	return id, err == nil
+}
+
+// FailingChecks returns the checks that completed without passing
+func (pr *PullRequest) FailingChecks() []StatusCheck {
+	var failing []StatusCheck
+
+	for i := range pr.StatusCheckRollup {
+		if pr.StatusCheckRollup[i].Failed() {
+			failing = append(failing, pr.StatusCheckRollup[i])
+		}
+	}
+
+	return failing
+}
+
+// GetFailedJobLog fetches the output of the failed steps of a GitHub Actions job
+// Uses: gh run view --job <id> --log-failed
+func (c *Client) GetFailedJobLog(jobID int64) (string, error) {
+	output, err := c.execGHInRepo("run", "view", "--job", strconv.FormatInt(jobID, 10), "--log-failed")
+	if err != nil {
+		return "", fmt.Errorf("failed to get log of job %d: %w", jobID, err)
+	}
+
+	return string(output), nil
+}
+
+// failureMarkers are substrings of log lines that usually explain a failure
+var failureMarkers = []string{"##[error]", "error", "fail", "panic", "exception", "traceback", "assert", "expected"}
+
+// SummarizeLog keeps the lines of a --log-failed log that explain the failure:
+// lines that mention errors or failures with a little context around them, or
+// the tail of the log when nothing matches. The job and step columns and
+// timestamps gh adds are removed, and at most maxLines lines are returned.
+func SummarizeLog(log string, maxLines int) string {
+	lines := strings.Split(strings.TrimRight(log, "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = stripLogPrefix(line)
+	}
+
+	const context = 2
+
+	keep := make([]bool, len(lines))
+	matched := false
+
+	for i, line := range lines {
+		lower := strings.ToLower(line)
+
+		for _, marker := range failureMarkers {
+			if strings.Contains(lower, marker) {
+				matched = true
+
+				for j := max(0, i-context); j <= min(len(lines)-1, i+context); j++ {
+					keep[j] = true
+				}
+
+				break
+			}
+		}
+	}
+
+	var summary []string
+
+	if !matched {
+		summary = lines[max(0, len(lines)-maxLines):]
+	} else {
+		for i, line := range lines {
+			if !keep[i] {
+				continue
+			}
+
+			if i > 0 && !keep[i-1] && len(summary) > 0 {
+				summary = append(summary, "...")
+			}
+
+			summary = append(summary, line)
+		}
+
+		// The last errors are usually the ones that stopped the job
+		if len(summary) > maxLines {
+			summary = append([]string{"..."}, summary[len(summary)-maxLines:]...)
+		}
+	}
+
+	return strings.Join(summary, "\n")
+}
+
+// stripLogPrefix removes the "job<TAB>step<TAB>" columns and the timestamp
+// that gh run view --log adds to each line
+func stripLogPrefix(line string) string {
+	if parts := strings.SplitN(line, "\t", 3); len(parts) == 3 {
+		line = parts[2]
+	}
+
+	// Timestamps look like 2024-05-01T12:00:00.1234567Z
+	if len(line) > 28 && line[4] == '-' && line[10] == 'T' {
+		if _, rest, ok := strings.Cut(line, "Z "); ok {
+			line = rest
+		}
+	}
+
+	return strings.TrimPrefix(line, "\ufeff")
+}
@@ -0,0 +1,60 @@
+package github
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStatusCheckJobID(t *testing.T) {
+	check := StatusCheck{DetailsURL: "https://github.com/o/r/actions/runs/123/job/456"}
+	if id, ok := check.JobID(); !ok || id != 456 {
+		t.Errorf("JobID() = %d, %v; want 456, true", id, ok)
+	}
+
+	other := StatusCheck{DetailsURL: "https://ci.example.com/build/9"}
+	if _, ok := other.JobID(); ok {
+		t.Error("JobID() should not find a job in a non-Actions URL")
+	}
+}
+
+func TestFailingChecks(t *testing.T) {
+	pr := PullRequest{StatusCheckRollup: []StatusCheck{
+		{Name: "lint", Status: "COMPLETED", Conclusion: "SUCCESS"},
+		{Name: "test", Status: "COMPLETED", Conclusion: "FAILURE"},
+		{Name: "build", Status: "IN_PROGRESS"},
+		{Name: "deploy", Status: "COMPLETED", Conclusion: "SKIPPED"},
+		{Name: "e2e", Status: "COMPLETED", Conclusion: "TIMED_OUT"},
+	}}
+
+	failing := pr.FailingChecks()
+	if len(failing) != 2 || failing[0].Name != "test" || failing[1].Name != "e2e" {
+		t.Errorf("FailingChecks() = %+v, want test and e2e", failing)
+	}
+}
+
+func TestSummarizeLog(t *testing.T) {
+	log := strings.Join([]string{
+		"test\tRun tests\t2024-05-01T12:00:00.0000000Z go test ./...",
+		"test\tRun tests\t2024-05-01T12:00:01.0000000Z ok   pkg/a",
+		"test\tRun tests\t2024-05-01T12:00:02.0000000Z ok   pkg/b",
+		"test\tRun tests\t2024-05-01T12:00:03.0000000Z ok   pkg/c",
+		"test\tRun tests\t2024-05-01T12:00:04.0000000Z ok   pkg/d",
+		"test\tRun tests\t2024-05-01T12:00:05.0000000Z --- FAIL: TestParse (0.00s)",
+		"test\tRun tests\t2024-05-01T12:00:06.0000000Z     parse_test.go:12: got 1, want 2",
+		"test\tRun tests\t2024-05-01T12:00:07.0000000Z done",
+	}, "\n")
+
+	got := SummarizeLog(log, 80)
+
+	if !strings.Contains(got, "--- FAIL: TestParse (0.00s)\n    parse_test.go:12: got 1, want 2") {
+		t.Errorf("SummarizeLog() = %q, want the failure with its context", got)
+	}
+
+	if strings.Contains(got, "go test ./...") || strings.Contains(got, "2024-05-01") || strings.Contains(got, "Run tests") {
+		t.Errorf("SummarizeLog() = %q, want unrelated lines and prefixes removed", got)
+	}
+
+	if tail := SummarizeLog("a\nb\nc\nd", 2); tail != "c\nd" {
+		t.Errorf("SummarizeLog() without failures = %q, want the last lines", tail)
+	}
+}
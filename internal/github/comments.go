@@ -0,0 +1,140 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// Comment is a comment on an issue or pull request, or a submitted review
+type Comment struct {
+	Author    Author `json:"author"`
+	Body      string `json:"body"`
+	CreatedAt string `json:"createdAt"`
+	// State is set on reviews: "APPROVED", "CHANGES_REQUESTED", "COMMENTED"
+	State       string `json:"state"`
+	SubmittedAt string `json:"submittedAt"`
+}
+
+// Time returns when the comment was posted, or the review submitted
+func (c *Comment) Time() string {
+	if c.SubmittedAt != "" {
+		return c.SubmittedAt
+	}
+
+	return c.CreatedAt
+}
+
+// GetIssueComments fetches the comments on an issue, oldest first
+// Uses: gh issue view <number> --json comments
+func (c *Client) GetIssueComments(number int) ([]Comment, error) {
+	output, err := c.execGHInRepo("issue", "view", strconv.Itoa(number), "--json", "comments")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get comments on issue #%d: %w", number, err)
+	}
+
+	var result struct {
+		Comments []Comment `json:"comments"`
+	}
+
+	if err := json.Unmarshal(output, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse comments: %w", err)
+	}
+
+	return result.Comments, nil
+}
+
+// GetPRFeedback fetches a pull request's reviews and conversation comments, oldest first
+// Uses: gh pr view <number> --json reviews,comments
+func (c *Client) GetPRFeedback(number int) ([]Comment, error) {
+	output, err := c.execGHInRepo("pr", "view", strconv.Itoa(number), "--json", "reviews,comments")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get feedback on PR #%d: %w", number, err)
+	}
+
+	var result struct {
+		Reviews  []Comment `json:"reviews"`
+		Comments []Comment `json:"comments"`
+	}
+
+	if err := json.Unmarshal(output, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse PR feedback: %w", err)
+	}
+
+	feedback := append(result.Reviews, result.Comments...)
+	sort.SliceStable(feedback, func(i, j int) bool { return feedback[i].Time() < feedback[j].Time() })
+
+	return feedback, nil
+}
+
+// ReviewThread is a line comment on a pull request's diff with its replies
+type ReviewThread struct {
+	Path string `json:"path"`
+	// Line is the line in the current diff; 0 when the thread is outdated
+	Line       int  `json:"line"`
+	IsResolved bool `json:"isResolved"`
+	IsOutdated bool `json:"isOutdated"`
+	Comments   struct {
+		Nodes []Comment `json:"nodes"`
+	} `json:"comments"`
+}
+
+// reviewThreadsQuery reads a pull request's review threads; gh pr view does not expose them
+const reviewThreadsQuery = `query($owner: String!, $repo: String!, $number: Int!) {
+  repository(owner: $owner, name: $repo) {
+    pullRequest(number: $number) {
+      reviewThreads(first: 100) {
+        nodes {
+          path
+          line
+          isResolved
+          isOutdated
+          comments(first: 50) {
+            nodes { author { login } body createdAt }
+          }
+        }
+      }
+    }
+  }
+}`
+
+// GetUnresolvedReviewThreads fetches the review threads on a pull request that
+// have not been resolved
+// Uses: gh api graphql
+func (c *Client) GetUnresolvedReviewThreads(number int) ([]ReviewThread, error) {
+	output, err := c.execGH("api", "graphql",
+		"-f", "query="+reviewThreadsQuery,
+		"-f", "owner="+c.Owner,
+		"-f", "repo="+c.Repo,
+		"-F", "number="+strconv.Itoa(number))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get review threads on PR #%d: %w", number, err)
+	}
+
+	var result struct {
+		Data struct {
+			Repository struct {
+				PullRequest struct {
+					ReviewThreads struct {
+						Nodes []ReviewThread `json:"nodes"`
+					} `json:"reviewThreads"`
+				} `json:"pullRequest"`
+			} `json:"repository"`
+		} `json:"data"`
+	}
+
+	if err := json.Unmarshal(output, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse review threads: %w", err)
+	}
+
+	var unresolved []ReviewThread
+
+	for _, t := range result.Data.Repository.PullRequest.ReviewThreads.Nodes {
+		if !t.IsResolved && len(t.Comments.Nodes) > 0 {
+			unresolved = append(unresolved, t)
+		}
+	}
+
+	return unresolved, nil
+}
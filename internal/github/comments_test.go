@@ -0,0 +1,39 @@
+package github
+
+import "testing"
+
+func TestGetUnresolvedReviewThreads(t *testing.T) {
+	fake := NewFakeGitHubExecutor()
+	fake.DefaultResponse = `{"data": {"repository": {"pullRequest": {"reviewThreads": {"nodes": [
+  {"path": "main.go", "line": 12, "isResolved": false, "isOutdated": false,
+   "comments": {"nodes": [{"author": {"login": "alice"}, "body": "Handle the error", "createdAt": "2024-05-01T12:00:00Z"}]}},
+  {"path": "main.go", "line": 30, "isResolved": true, "isOutdated": false,
+   "comments": {"nodes": [{"author": {"login": "bob"}, "body": "Done", "createdAt": "2024-05-01T12:00:00Z"}]}},
+  {"path": "old.go", "line": null, "isResolved": false, "isOutdated": true,
+   "comments": {"nodes": [{"author": {"login": "carol"}, "body": "Rename", "createdAt": "2024-05-02T12:00:00Z"}]}}
+]}}}}}`
+
+	client := &Client{Owner: "testowner", Repo: "testrepo", executor: fake}
+
+	threads, err := client.GetUnresolvedReviewThreads(5)
+	if err != nil {
+		t.Fatalf("GetUnresolvedReviewThreads failed: %v", err)
+	}
+
+	if len(threads) != 2 {
+		t.Fatalf("expected 2 unresolved threads, got %d", len(threads))
+	}
+
+	if threads[0].Path != "main.go" || threads[0].Line != 12 || threads[0].Comments.Nodes[0].Author.Login != "alice" {
+		t.Errorf("unexpected first thread: %+v", threads[0])
+	}
+
+	if !threads[1].IsOutdated || threads[1].Line != 0 {
+		t.Errorf("expected an outdated thread without a line, got %+v", threads[1])
+	}
+
+	args := fake.GetLastCommand()
+	if len(args) < 2 || args[0] != "api" || args[1] != "graphql" {
+		t.Errorf("expected gh api graphql, got %v", args)
+	}
+}
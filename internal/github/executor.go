@@ -1,9 +1,16 @@
 package github
 
 import (
+	"context"
 	"fmt"
 	"os/exec"
 	"strings"
+	"time"
+
+	"github.com/kaeawc/auto-worktree/internal/interrupt"
+	"github.com/kaeawc/auto-worktree/internal/limits"
+	"github.com/kaeawc/auto-worktree/internal/logging"
+	"github.com/kaeawc/auto-worktree/internal/retry"
 )
 
 // GitHubExecutor defines the interface for executing gh CLI commands
@@ -15,31 +22,76 @@ type GitHubExecutor interface {
 }
 
 // RealGitHubExecutor executes actual gh commands via exec.Command
-type RealGitHubExecutor struct{}
+type RealGitHubExecutor struct {
+	// ctx stops the commands when it is done
+	ctx context.Context
+}
 
-// NewGitHubExecutor creates a new real GitHub executor for production use
+// NewGitHubExecutor creates a new real GitHub executor for production use: the
+// gh CLI, or the GitHub API when that transport is selected or gh is missing
+// but a token is set. Its commands stop on Ctrl-C.
 func NewGitHubExecutor() GitHubExecutor {
-	return &RealGitHubExecutor{}
+	return NewGitHubExecutorContext(interrupt.Context())
+}
+
+// NewGitHubExecutorContext creates a GitHub executor whose commands stop when ctx is done
+func NewGitHubExecutorContext(ctx context.Context) GitHubExecutor {
+	if useAPI() {
+		executor := NewAPIExecutor("")
+		executor.ctx = ctx
+
+		return executor
+	}
+
+	return &RealGitHubExecutor{ctx: ctx}
+}
+
+// orBackground returns ctx, or context.Background() for an executor built without one
+func orBackground(ctx context.Context) context.Context {
+	if ctx == nil {
+		return context.Background()
+	}
+
+	return ctx
 }
 
 // Execute runs a gh command and returns the output
 func (e *RealGitHubExecutor) Execute(args ...string) (string, error) {
-	cmd := exec.Command("gh", args...)
-	output, err := cmd.CombinedOutput()
+	output, err := runGH(orBackground(e.ctx), "", args)
 	if err != nil {
 		return "", fmt.Errorf("gh %s failed: %w", strings.Join(args, " "), err)
 	}
-	return strings.TrimSpace(string(output)), nil
+	return output, nil
 }
 
 // ExecuteInDir runs a gh command in a specific directory
 func (e *RealGitHubExecutor) ExecuteInDir(dir string, args ...string) (string, error) {
-	cmd := exec.Command("gh", args...)
-	cmd.Dir = dir
-	output, err := cmd.CombinedOutput()
+	output, err := runGH(orBackground(e.ctx), dir, args)
 	if err != nil {
 		return "", fmt.Errorf("gh %s failed in %s: %w", strings.Join(args, " "), dir, err)
 	}
+	return output, nil
+}
+
+// runGH runs gh under its timeout, retrying transient failures and rate limits;
+// it stops when parent is done
+func runGH(parent context.Context, dir string, args []string) (string, error) {
+	output, err := retry.DefaultPolicy.Run("GitHub", func() ([]byte, error) {
+		ctx, cancel := limits.Context(parent, limits.ToolGitHub)
+		defer cancel()
+
+		cmd := exec.CommandContext(ctx, "gh", args...)
+		cmd.Dir = dir
+		start := time.Now()
+		output, err := cmd.CombinedOutput()
+		logging.Command(cmd, start, output, err)
+
+		return output, limits.Wrap(ctx, limits.ToolGitHub, err)
+	})
+	if err != nil {
+		return "", err
+	}
+
 	return strings.TrimSpace(string(output)), nil
 }
 
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/kaeawc/auto-worktree/internal/git"
 )
@@ -18,6 +19,7 @@ type Issue struct {
 	StateReason string  `json:"stateReason"` // "COMPLETED", "NOT_PLANNED", etc.
 	Labels      []Label `json:"labels"`
 	URL         string  `json:"url"`
+	UpdatedAt   string  `json:"updatedAt"`
 }
 
 // Label represents a GitHub label
@@ -26,15 +28,133 @@ type Label struct {
 	Color string `json:"color"`
 }
 
+// IssueFilter narrows the issues returned by ListFilteredIssues
+type IssueFilter struct {
+	Labels    []string
+	Assignee  string // "@me", "none" for unassigned, or a login
+	Milestone string
+	Search    string
+}
+
 // ListOpenIssues fetches open issues (up to limit)
 // Uses: gh issue list --limit <limit> --state open --json number,title,labels,url
 func (c *Client) ListOpenIssues(limit int) ([]Issue, error) {
+	return c.ListFilteredIssues(limit, IssueFilter{})
+}
+
+// ListFilteredIssues fetches open issues matching the filter (up to limit)
+// Uses: gh issue list --limit <limit> --state open [--label ...] [--assignee ...]
+// [--milestone ...] [--search ...] --json number,title,labels,url
+func (c *Client) ListFilteredIssues(limit int, filter IssueFilter) ([]Issue, error) {
+	args := []string{"issue", "list",
+		"--limit", strconv.Itoa(limit),
+		"--state", "open"}
+	args = append(args, filter.args()...)
+	args = append(args, "--json", "number,title,labels,url")
+
+	output, err := c.execGHInRepo(args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list issues: %w", err)
+	}
+
+	var issues []Issue
+	if err := json.Unmarshal(output, &issues); err != nil {
+		return nil, fmt.Errorf("failed to parse issues: %w", err)
+	}
+
+	return issues, nil
+}
+
+// args converts the filter to gh issue list flags
+func (f IssueFilter) args() []string {
+	var args []string
+
+	for _, label := range f.Labels {
+		args = append(args, "--label", label)
+	}
+
+	search := f.Search
+
+	switch f.Assignee {
+	case "":
+	case "none":
+		// gh has no flag for unassigned issues, so use the search qualifier
+		search = strings.TrimSpace("no:assignee " + search)
+	default:
+		args = append(args, "--assignee", f.Assignee)
+	}
+
+	if f.Milestone != "" {
+		args = append(args, "--milestone", f.Milestone)
+	}
+
+	if search != "" {
+		args = append(args, "--search", search)
+	}
+
+	return args
+}
+
+// currentMilestoneQuery lists a repository's open milestones, soonest due first
+const currentMilestoneQuery = `query($owner: String!, $repo: String!) {
+  repository(owner: $owner, name: $repo) {
+    milestones(first: 20, states: OPEN, orderBy: {field: DUE_DATE, direction: ASC}) { nodes { title dueOn } }
+  }
+}`
+
+// CurrentMilestone returns the title of the open milestone due soonest, or of
+// the first open milestone when none has a due date
+// Uses: gh api graphql
+func (c *Client) CurrentMilestone() (string, error) {
+	output, err := c.execGH("api", "graphql",
+		"-f", "query="+currentMilestoneQuery,
+		"-f", "owner="+c.Owner,
+		"-f", "repo="+c.Repo)
+	if err != nil {
+		return "", fmt.Errorf("failed to list milestones: %w", err)
+	}
+
+	var result struct {
+		Data struct {
+			Repository struct {
+				Milestones struct {
+					Nodes []struct {
+						Title string `json:"title"`
+						DueOn string `json:"dueOn"`
+					} `json:"nodes"`
+				} `json:"milestones"`
+			} `json:"repository"`
+		} `json:"data"`
+	}
+
+	if err := json.Unmarshal(output, &result); err != nil {
+		return "", fmt.Errorf("failed to parse milestones: %w", err)
+	}
+
+	milestones := result.Data.Repository.Milestones.Nodes
+	if len(milestones) == 0 {
+		return "", fmt.Errorf("%s/%s has no open milestones", c.Owner, c.Repo)
+	}
+
+	for _, m := range milestones {
+		if m.DueOn != "" {
+			return m.Title, nil
+		}
+	}
+
+	return milestones[0].Title, nil
+}
+
+// ListAssignedIssues fetches open issues assigned to the authenticated user (up to limit)
+// Uses: gh issue list --limit <limit> --state open --assignee @me --json number,title,labels,url,updatedAt
+func (c *Client) ListAssignedIssues(limit int) ([]Issue, error) {
 	output, err := c.execGHInRepo("issue", "list",
 		"--limit", strconv.Itoa(limit),
 		"--state", "open",
-		"--json", "number,title,labels,url")
+		"--assignee", "@me",
+		"--json", "number,title,labels,url,updatedAt")
 	if err != nil {
-		return nil, fmt.Errorf("failed to list issues: %w", err)
+		return nil, fmt.Errorf("failed to list assigned issues: %w", err)
 	}
 
 	var issues []Issue
@@ -45,6 +165,16 @@ func (c *Client) ListOpenIssues(limit int) ([]Issue, error) {
 	return issues, nil
 }
 
+// LastUpdated returns the time the issue was last updated, or the zero time if unknown
+func (i *Issue) LastUpdated() time.Time {
+	t, err := time.Parse(time.RFC3339, i.UpdatedAt)
+	if err != nil {
+		return time.Time{}
+	}
+
+	return t
+}
+
 // GetIssue fetches a specific issue by number
 // Uses: gh issue view <number> --json number,title,body,state,stateReason,labels,url
 func (c *Client) GetIssue(number int) (*Issue, error) {
@@ -62,6 +192,36 @@ func (c *Client) GetIssue(number int) (*Issue, error) {
 	return &issue, nil
 }
 
+// AddAssignee assigns a user to an issue
+// Uses: gh issue edit <number> --add-assignee <login>
+func (c *Client) AddAssignee(number int, assignee string) error {
+	if _, err := c.execGHInRepo("issue", "edit", strconv.Itoa(number), "--add-assignee", assignee); err != nil {
+		return fmt.Errorf("failed to assign issue #%d: %w", number, err)
+	}
+
+	return nil
+}
+
+// AddComment posts a comment on an issue
+// Uses: gh issue comment <number> --body <body>
+func (c *Client) AddComment(number int, body string) error {
+	if _, err := c.execGHInRepo("issue", "comment", strconv.Itoa(number), "--body", body); err != nil {
+		return fmt.Errorf("failed to comment on issue #%d: %w", number, err)
+	}
+
+	return nil
+}
+
+// AddLabels adds labels to an issue
+// Uses: gh issue edit <number> --add-label <label,...>
+func (c *Client) AddLabels(number int, labels []string) error {
+	if _, err := c.execGHInRepo("issue", "edit", strconv.Itoa(number), "--add-label", strings.Join(labels, ",")); err != nil {
+		return fmt.Errorf("failed to label issue #%d: %w", number, err)
+	}
+
+	return nil
+}
+
 // IsIssueMerged checks if an issue is closed and was completed (merged PR)
 // Searches for merged PRs that reference the issue
 func (c *Client) IsIssueMerged(number int) (bool, error) {
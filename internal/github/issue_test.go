@@ -1,7 +1,9 @@
 package github
 
 import (
+	"errors"
 	"testing"
+	"time"
 )
 
 func TestIssueSanitizedTitle(t *testing.T) {
@@ -253,6 +255,96 @@ func TestListOpenIssues(t *testing.T) {
 	}
 }
 
+func TestListAssignedIssues(t *testing.T) {
+	fake := NewFakeGitHubExecutor()
+	fake.SetResponse("--version", "gh version 2.0.0")
+	fake.SetResponse("auth status", "Logged in to github.com")
+	fake.SetResponse("-R testowner/testrepo issue list --limit 20 --state open --assignee @me --json number,title,labels,url,updatedAt", `[
+		{"number":42,"title":"Old work","labels":[],"url":"https://github.com/testowner/testrepo/issues/42","updatedAt":"2024-01-15T10:00:00Z"}
+	]`)
+
+	client, err := NewClientWithRepoAndExecutor("testowner", "testrepo", fake)
+	if err != nil {
+		t.Fatalf("NewClientWithRepoAndExecutor() error = %v", err)
+	}
+
+	issues, err := client.ListAssignedIssues(20)
+	if err != nil {
+		t.Fatalf("ListAssignedIssues() unexpected error: %v", err)
+	}
+
+	if len(issues) != 1 {
+		t.Fatalf("ListAssignedIssues() returned %d issues, want 1", len(issues))
+	}
+
+	want := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	if got := issues[0].LastUpdated(); !got.Equal(want) {
+		t.Errorf("LastUpdated() = %v, want %v", got, want)
+	}
+}
+
+func TestListFilteredIssues(t *testing.T) {
+	fake := NewFakeGitHubExecutor()
+	fake.SetResponse("--version", "gh version 2.0.0")
+	fake.SetResponse("auth status", "Logged in to github.com")
+	fake.SetResponse("-R testowner/testrepo issue list --limit 40 --state open --label bug --label ui "+
+		"--milestone v2 --search no:assignee crash --json number,title,labels,url", `[
+		{"number":7,"title":"Crash on start","labels":[{"name":"bug"},{"name":"ui"}],"url":"https://github.com/testowner/testrepo/issues/7"}
+	]`)
+
+	client, err := NewClientWithRepoAndExecutor("testowner", "testrepo", fake)
+	if err != nil {
+		t.Fatalf("NewClientWithRepoAndExecutor() error = %v", err)
+	}
+
+	issues, err := client.ListFilteredIssues(40, IssueFilter{
+		Labels:    []string{"bug", "ui"},
+		Assignee:  "none",
+		Milestone: "v2",
+		Search:    "crash",
+	})
+	if err != nil {
+		t.Fatalf("ListFilteredIssues() unexpected error: %v", err)
+	}
+
+	if len(issues) != 1 || issues[0].Number != 7 {
+		t.Errorf("ListFilteredIssues() = %+v, want issue #7", issues)
+	}
+}
+
+func TestIssueLastUpdatedInvalid(t *testing.T) {
+	issue := &Issue{UpdatedAt: "not-a-date"}
+	if got := issue.LastUpdated(); !got.IsZero() {
+		t.Errorf("LastUpdated() = %v, want zero time", got)
+	}
+}
+
+func TestAddAssigneeAndComment(t *testing.T) {
+	fake := NewFakeGitHubExecutor()
+	fake.SetResponse("--version", "gh version 2.0.0")
+	fake.SetResponse("auth status", "Logged in to github.com")
+	fake.SetResponse("-R testowner/testrepo issue edit 42 --add-assignee @me", "")
+	fake.SetResponse("-R testowner/testrepo issue comment 42 --body Started work", "")
+
+	client, err := NewClientWithRepoAndExecutor("testowner", "testrepo", fake)
+	if err != nil {
+		t.Fatalf("NewClientWithRepoAndExecutor() error = %v", err)
+	}
+
+	if err := client.AddAssignee(42, "@me"); err != nil {
+		t.Errorf("AddAssignee() unexpected error: %v", err)
+	}
+
+	if err := client.AddComment(42, "Started work"); err != nil {
+		t.Errorf("AddComment() unexpected error: %v", err)
+	}
+
+	fake.SetError("-R testowner/testrepo issue comment 42 --body Started work", errors.New("forbidden"))
+	if err := client.AddComment(42, "Started work"); err == nil {
+		t.Error("AddComment() expected error, got nil")
+	}
+}
+
 func TestGetIssue(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -471,3 +563,24 @@ func TestIsIssueMerged(t *testing.T) {
 		})
 	}
 }
+
+func TestCurrentMilestone(t *testing.T) {
+	fake := NewFakeGitHubExecutor()
+	fake.DefaultResponse = `{"data": {"repository": {"milestones": {"nodes": [
+  {"title": "Someday", "dueOn": null},
+  {"title": "v2.1", "dueOn": "2024-06-30T00:00:00Z"},
+  {"title": "v3", "dueOn": "2024-09-30T00:00:00Z"}
+]}}}}`
+
+	client := &Client{Owner: "testowner", Repo: "testrepo", executor: fake}
+
+	milestone, err := client.CurrentMilestone()
+	if err != nil || milestone != "v2.1" {
+		t.Errorf("CurrentMilestone() = %q, %v; want v2.1", milestone, err)
+	}
+
+	fake.DefaultResponse = `{"data": {"repository": {"milestones": {"nodes": []}}}}`
+	if _, err := client.CurrentMilestone(); err == nil {
+		t.Error("CurrentMilestone() without open milestones should fail")
+	}
+}
@@ -11,21 +11,37 @@ import (
 
 // PullRequest represents a GitHub pull request
 type PullRequest struct {
-	Number            int             `json:"number"`
-	Title             string          `json:"title"`
-	Body              string          `json:"body"`
-	State             string          `json:"state"` // "OPEN", "CLOSED", "MERGED"
-	Author            Author          `json:"author"`
-	HeadRefName       string          `json:"headRefName"`
-	BaseRefName       string          `json:"baseRefName"`
-	Labels            []Label         `json:"labels"`
-	URL               string          `json:"url"`
-	IsDraft           bool            `json:"isDraft"`
-	ReviewRequests    []ReviewRequest `json:"reviewRequests"`
-	Additions         int             `json:"additions"`
-	Deletions         int             `json:"deletions"`
-	ChangedFiles      int             `json:"changedFiles"`
-	StatusCheckRollup []StatusCheck   `json:"statusCheckRollup"`
+	Number         int             `json:"number"`
+	Title          string          `json:"title"`
+	Body           string          `json:"body"`
+	State          string          `json:"state"` // "OPEN", "CLOSED", "MERGED"
+	Author         Author          `json:"author"`
+	HeadRefName    string          `json:"headRefName"`
+	BaseRefName    string          `json:"baseRefName"`
+	Labels         []Label         `json:"labels"`
+	URL            string          `json:"url"`
+	IsDraft        bool            `json:"isDraft"`
+	ReviewRequests []ReviewRequest `json:"reviewRequests"`
+	// LatestReviews holds each reviewer's latest review; GitHub drops a
+	// reviewer from ReviewRequests once they review
+	LatestReviews     []Review      `json:"latestReviews"`
+	Additions         int           `json:"additions"`
+	Deletions         int           `json:"deletions"`
+	ChangedFiles      int           `json:"changedFiles"`
+	StatusCheckRollup []StatusCheck `json:"statusCheckRollup"`
+	// HeadRepository and HeadRepositoryOwner name the repository of the head
+	// branch, a fork when IsCrossRepository is set
+	HeadRepository      HeadRepository `json:"headRepository"`
+	HeadRepositoryOwner Author         `json:"headRepositoryOwner"`
+	IsCrossRepository   bool           `json:"isCrossRepository"`
+	// MaintainerCanModify is set when the author of a PR from a fork lets
+	// maintainers push to its head branch
+	MaintainerCanModify bool `json:"maintainerCanModify"`
+}
+
+// HeadRepository is the repository a pull request's head branch is in
+type HeadRepository struct {
+	Name string `json:"name"`
 }
 
 // Author represents a GitHub user
@@ -40,18 +56,27 @@ type ReviewRequest struct {
 	Login string `json:"login"`
 }
 
+// Review is a submitted review of a pull request
+type Review struct {
+	Author Author `json:"author"`
+	State  string `json:"state"` // "APPROVED", "CHANGES_REQUESTED", "COMMENTED", etc.
+}
+
 // StatusCheck represents a CI check result
 type StatusCheck struct {
 	TypeName   string `json:"__typename"`
 	Name       string `json:"name"`
 	Status     string `json:"status"`     // "COMPLETED", "IN_PROGRESS", etc.
 	Conclusion string `json:"conclusion"` // "SUCCESS", "FAILURE", "NEUTRAL", etc.
+	// DetailsURL links to the check's page; for GitHub Actions it names the job
+	DetailsURL   string `json:"detailsUrl"`
+	WorkflowName string `json:"workflowName"`
 }
 
 // ListOpenPRs fetches open pull requests (up to limit)
 // Uses: gh pr list --limit <limit> --state open --json <fields>
 func (c *Client) ListOpenPRs(limit int) ([]PullRequest, error) {
-	fields := "number,title,body,state,author,headRefName,baseRefName,labels,url,isDraft,reviewRequests,additions,deletions,changedFiles,statusCheckRollup"
+	fields := "number,title,body,state,author,headRefName,baseRefName,labels,url,isDraft,reviewRequests,latestReviews,additions,deletions,changedFiles,statusCheckRollup"
 	output, err := c.execGHInRepo("pr", "list",
 		"--limit", strconv.Itoa(limit),
 		"--state", "open",
@@ -71,7 +96,7 @@ func (c *Client) ListOpenPRs(limit int) ([]PullRequest, error) {
 // GetPR fetches a specific pull request by number
 // Uses: gh pr view <number> --json <fields>
 func (c *Client) GetPR(number int) (*PullRequest, error) {
-	fields := "number,title,body,state,author,headRefName,baseRefName,labels,url,isDraft,reviewRequests,additions,deletions,changedFiles,statusCheckRollup"
+	fields := "number,title,body,state,author,headRefName,baseRefName,labels,url,isDraft,reviewRequests,additions,deletions,changedFiles,statusCheckRollup,headRepository,headRepositoryOwner,isCrossRepository,maintainerCanModify"
 	output, err := c.execGHInRepo("pr", "view", strconv.Itoa(number),
 		"--json", fields)
 	if err != nil {
@@ -86,6 +111,184 @@ func (c *Client) GetPR(number int) (*PullRequest, error) {
 	return &pr, nil
 }
 
+// FindPRForBranch returns the open pull request whose head is branch, or nil if there is none
+// Uses: gh pr list --head <branch> --state open --limit 1 --json <fields>
+func (c *Client) FindPRForBranch(branch string) (*PullRequest, error) {
+	return c.findPRForBranch(branch, "open")
+}
+
+// LatestPRForBranch returns the most recent pull request whose head is branch,
+// whether open, closed or merged, or nil if there is none
+// Uses: gh pr list --head <branch> --state all --limit 1 --json <fields>
+func (c *Client) LatestPRForBranch(branch string) (*PullRequest, error) {
+	return c.findPRForBranch(branch, "all")
+}
+
+func (c *Client) findPRForBranch(branch, state string) (*PullRequest, error) {
+	output, err := c.execGHInRepo("pr", "list",
+		"--head", branch,
+		"--state", state,
+		"--limit", "1",
+		"--json", "number,title,body,state,headRefName,baseRefName,url")
+	if err != nil {
+		return nil, fmt.Errorf("failed to find PR for branch %s: %w", branch, err)
+	}
+
+	var prs []PullRequest
+	if err := json.Unmarshal(output, &prs); err != nil {
+		return nil, fmt.Errorf("failed to parse PRs: %w", err)
+	}
+
+	if len(prs) == 0 {
+		return nil, nil
+	}
+
+	return &prs[0], nil
+}
+
+// EditPR replaces a pull request's title and body
+// Uses: gh pr edit <number> --title <title> --body <body>
+func (c *Client) EditPR(number int, title, body string) error {
+	if _, err := c.execGHInRepo("pr", "edit", strconv.Itoa(number), "--title", title, "--body", body); err != nil {
+		return fmt.Errorf("failed to edit PR #%d: %w", number, err)
+	}
+
+	return nil
+}
+
+// CreatePROptions describes a pull request for CreatePR
+type CreatePROptions struct {
+	Title string
+	Body  string
+	Base  string
+	Head  string
+	// Reviewers are logins, or org/team slugs
+	Reviewers []string
+	Labels    []string
+	Draft     bool
+}
+
+// CreatePR opens a pull request for a pushed branch. The signed-in user is
+// dropped from the reviewers, since GitHub rejects review requests from a PR's author.
+// Uses: gh pr create --title <title> --body <body> --base <base> --head <head> [--draft] [--reviewer <login>]... [--label <label>]...
+func (c *Client) CreatePR(opts CreatePROptions) (*PullRequest, error) {
+	if opts.Title == "" {
+		return nil, fmt.Errorf("PR title cannot be empty")
+	}
+
+	reviewers := opts.Reviewers
+	if len(reviewers) > 0 {
+		if login, err := c.ViewerLogin(); err == nil {
+			reviewers = withoutLogin(reviewers, login)
+		}
+	}
+
+	args := []string{"pr", "create",
+		"--title", opts.Title,
+		"--body", opts.Body,
+		"--base", opts.Base,
+		"--head", opts.Head}
+	if opts.Draft {
+		args = append(args, "--draft")
+	}
+
+	for _, reviewer := range reviewers {
+		args = append(args, "--reviewer", reviewer)
+	}
+
+	for _, label := range opts.Labels {
+		args = append(args, "--label", label)
+	}
+
+	output, err := c.execGHInRepo(args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create PR for %s: %w", opts.Head, err)
+	}
+
+	// gh prints the new PR's URL last, after any notices
+	lines := strings.Fields(string(output))
+	if len(lines) == 0 {
+		return nil, fmt.Errorf("gh pr create did not print the new PR's URL")
+	}
+
+	url := lines[len(lines)-1]
+
+	number, err := strconv.Atoi(url[strings.LastIndex(url, "/")+1:])
+	if err != nil {
+		return nil, fmt.Errorf("unexpected URL from gh pr create: %s", url)
+	}
+
+	pr := &PullRequest{
+		Number:      number,
+		Title:       opts.Title,
+		Body:        opts.Body,
+		State:       "OPEN",
+		HeadRefName: opts.Head,
+		BaseRefName: opts.Base,
+		URL:         url,
+		IsDraft:     opts.Draft,
+	}
+
+	for _, reviewer := range reviewers {
+		pr.ReviewRequests = append(pr.ReviewRequests, ReviewRequest{Login: reviewer})
+	}
+
+	for _, label := range opts.Labels {
+		pr.Labels = append(pr.Labels, Label{Name: label})
+	}
+
+	return pr, nil
+}
+
+// withoutLogin returns logins without login, compared without case
+func withoutLogin(logins []string, login string) []string {
+	kept := make([]string, 0, len(logins))
+
+	for _, l := range logins {
+		if !strings.EqualFold(l, login) {
+			kept = append(kept, l)
+		}
+	}
+
+	return kept
+}
+
+// viewerQuery reads the signed-in user's login
+const viewerQuery = `query { viewer { login } }`
+
+// ViewerLogin returns the signed-in user's login
+// Uses: gh api graphql
+func (c *Client) ViewerLogin() (string, error) {
+	output, err := c.execGH("api", "graphql", "-f", "query="+viewerQuery)
+	if err != nil {
+		return "", fmt.Errorf("failed to get the signed-in user: %w", err)
+	}
+
+	var result struct {
+		Data struct {
+			Viewer struct {
+				Login string `json:"login"`
+			} `json:"viewer"`
+		} `json:"data"`
+	}
+
+	if err := json.Unmarshal(output, &result); err != nil {
+		return "", fmt.Errorf("failed to parse the signed-in user: %w", err)
+	}
+
+	return result.Data.Viewer.Login, nil
+}
+
+// OpenPRInBrowser opens a pull request in the default web browser
+// Uses: gh pr view <number> --web
+func (c *Client) OpenPRInBrowser(number int) error {
+	if _, err := c.execGHInRepo("pr", "view", strconv.Itoa(number), "--web"); err != nil {
+		return fmt.Errorf("failed to open PR #%d: %w", number, err)
+	}
+
+	return nil
+}
+
 // IsPRMerged checks if a pull request is merged
 func (c *Client) IsPRMerged(number int) (bool, error) {
 	pr, err := c.GetPR(number)
@@ -141,6 +344,18 @@ func (pr *PullRequest) BranchName() string {
 	return fmt.Sprintf("pr/%d-%s", pr.Number, pr.SanitizedTitle())
 }
 
+// Head returns where the PR's commits are, to check it out with git.Repository.CheckoutPullRequest.
+// Only GetPR fetches the fields of a PR from a fork.
+func (pr *PullRequest) Head() git.PullRequestHead {
+	head := git.PullRequestHead{Number: pr.Number, Branch: pr.HeadRefName, Pushable: true}
+	if pr.IsCrossRepository {
+		head.ForkOwner, head.ForkName = pr.HeadRepositoryOwner.Login, pr.HeadRepository.Name
+		head.Pushable = pr.MaintainerCanModify
+	}
+
+	return head
+}
+
 // HasMergeConflicts checks if PR has merge conflicts with base branch
 // Uses: gh pr view <number> --json mergeable
 func (c *Client) HasMergeConflicts(number int) (bool, error) {
@@ -186,6 +401,42 @@ func (pr *PullRequest) AllChecksPass() bool {
 	return true
 }
 
+// CI states returned by CIState
+const (
+	CIStateNone    = "none"
+	CIStatePending = "pending"
+	CIStateFailing = "failing"
+	CIStatePassing = "passing"
+)
+
+// CIState summarizes the PR's checks as none, pending, failing, or passing
+func (pr *PullRequest) CIState() string {
+	if len(pr.StatusCheckRollup) == 0 {
+		return CIStateNone
+	}
+
+	pending := false
+
+	for _, check := range pr.StatusCheckRollup {
+		if check.Status != "COMPLETED" {
+			pending = true
+			continue
+		}
+
+		switch check.Conclusion {
+		case "SUCCESS", "NEUTRAL", "SKIPPED":
+		default:
+			return CIStateFailing
+		}
+	}
+
+	if pending {
+		return CIStatePending
+	}
+
+	return CIStatePassing
+}
+
 // ChangeSize returns a categorical size based on lines changed
 func (pr *PullRequest) ChangeSize() string {
 	total := pr.Additions + pr.Deletions
@@ -212,3 +463,9 @@ func (pr *PullRequest) IsRequestedReviewer(username string) bool {
 	}
 	return false
 }
+
+// NeedsReviewers returns true if the PR is ready for review but has no reviewers
+// requested and no reviews yet
+func (pr *PullRequest) NeedsReviewers() bool {
+	return !pr.IsDraft && len(pr.ReviewRequests) == 0 && len(pr.LatestReviews) == 0
+}
@@ -1,6 +1,7 @@
 package github
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -186,7 +187,7 @@ func TestListOpenPRs(t *testing.T) {
 				fake := NewFakeGitHubExecutor()
 				fake.SetResponse("--version", "gh version 2.0.0")
 				fake.SetResponse("auth status", "Logged in to github.com")
-				fake.SetResponse("-R testowner/testrepo pr list --limit 10 --state open --json number,title,body,state,author,headRefName,baseRefName,labels,url,isDraft,reviewRequests,additions,deletions,changedFiles,statusCheckRollup", `[
+				fake.SetResponse("-R testowner/testrepo pr list --limit 10 --state open --json number,title,body,state,author,headRefName,baseRefName,labels,url,isDraft,reviewRequests,latestReviews,additions,deletions,changedFiles,statusCheckRollup", `[
 					{
 						"number":123,
 						"title":"Fix bug",
@@ -234,7 +235,7 @@ func TestListOpenPRs(t *testing.T) {
 				fake := NewFakeGitHubExecutor()
 				fake.SetResponse("--version", "gh version 2.0.0")
 				fake.SetResponse("auth status", "Logged in to github.com")
-				fake.SetResponse("-R testowner/testrepo pr list --limit 5 --state open --json number,title,body,state,author,headRefName,baseRefName,labels,url,isDraft,reviewRequests,additions,deletions,changedFiles,statusCheckRollup", `[]`)
+				fake.SetResponse("-R testowner/testrepo pr list --limit 5 --state open --json number,title,body,state,author,headRefName,baseRefName,labels,url,isDraft,reviewRequests,latestReviews,additions,deletions,changedFiles,statusCheckRollup", `[]`)
 				return fake
 			},
 			wantCount: 0,
@@ -304,7 +305,7 @@ func TestGetPR(t *testing.T) {
 				fake := NewFakeGitHubExecutor()
 				fake.SetResponse("--version", "gh version 2.0.0")
 				fake.SetResponse("auth status", "Logged in to github.com")
-				fake.SetResponse("-R testowner/testrepo pr view 123 --json number,title,body,state,author,headRefName,baseRefName,labels,url,isDraft,reviewRequests,additions,deletions,changedFiles,statusCheckRollup", `{
+				fake.SetResponse("-R testowner/testrepo pr view 123 --json number,title,body,state,author,headRefName,baseRefName,labels,url,isDraft,reviewRequests,additions,deletions,changedFiles,statusCheckRollup,headRepository,headRepositoryOwner,isCrossRepository,maintainerCanModify", `{
 					"number":123,
 					"title":"Fix authentication bug",
 					"body":"This is the bug fix",
@@ -335,7 +336,7 @@ func TestGetPR(t *testing.T) {
 				fake := NewFakeGitHubExecutor()
 				fake.SetResponse("--version", "gh version 2.0.0")
 				fake.SetResponse("auth status", "Logged in to github.com")
-				fake.SetResponse("-R testowner/testrepo pr view 456 --json number,title,body,state,author,headRefName,baseRefName,labels,url,isDraft,reviewRequests,additions,deletions,changedFiles,statusCheckRollup", `{
+				fake.SetResponse("-R testowner/testrepo pr view 456 --json number,title,body,state,author,headRefName,baseRefName,labels,url,isDraft,reviewRequests,additions,deletions,changedFiles,statusCheckRollup,headRepository,headRepositoryOwner,isCrossRepository,maintainerCanModify", `{
 					"number":456,
 					"title":"Add new feature",
 					"body":"Feature description",
@@ -417,7 +418,7 @@ func TestIsPRMerged(t *testing.T) {
 				fake := NewFakeGitHubExecutor()
 				fake.SetResponse("--version", "gh version 2.0.0")
 				fake.SetResponse("auth status", "Logged in to github.com")
-				fake.SetResponse("-R testowner/testrepo pr view 123 --json number,title,body,state,author,headRefName,baseRefName,labels,url,isDraft,reviewRequests,additions,deletions,changedFiles,statusCheckRollup", `{
+				fake.SetResponse("-R testowner/testrepo pr view 123 --json number,title,body,state,author,headRefName,baseRefName,labels,url,isDraft,reviewRequests,additions,deletions,changedFiles,statusCheckRollup,headRepository,headRepositoryOwner,isCrossRepository,maintainerCanModify", `{
 					"number":123,
 					"title":"Fix bug",
 					"body":"",
@@ -446,7 +447,7 @@ func TestIsPRMerged(t *testing.T) {
 				fake := NewFakeGitHubExecutor()
 				fake.SetResponse("--version", "gh version 2.0.0")
 				fake.SetResponse("auth status", "Logged in to github.com")
-				fake.SetResponse("-R testowner/testrepo pr view 456 --json number,title,body,state,author,headRefName,baseRefName,labels,url,isDraft,reviewRequests,additions,deletions,changedFiles,statusCheckRollup", `{
+				fake.SetResponse("-R testowner/testrepo pr view 456 --json number,title,body,state,author,headRefName,baseRefName,labels,url,isDraft,reviewRequests,additions,deletions,changedFiles,statusCheckRollup,headRepository,headRepositoryOwner,isCrossRepository,maintainerCanModify", `{
 					"number":456,
 					"title":"Won't merge",
 					"body":"",
@@ -475,7 +476,7 @@ func TestIsPRMerged(t *testing.T) {
 				fake := NewFakeGitHubExecutor()
 				fake.SetResponse("--version", "gh version 2.0.0")
 				fake.SetResponse("auth status", "Logged in to github.com")
-				fake.SetResponse("-R testowner/testrepo pr view 789 --json number,title,body,state,author,headRefName,baseRefName,labels,url,isDraft,reviewRequests,additions,deletions,changedFiles,statusCheckRollup", `{
+				fake.SetResponse("-R testowner/testrepo pr view 789 --json number,title,body,state,author,headRefName,baseRefName,labels,url,isDraft,reviewRequests,additions,deletions,changedFiles,statusCheckRollup,headRepository,headRepositoryOwner,isCrossRepository,maintainerCanModify", `{
 					"number":789,
 					"title":"In progress",
 					"body":"",
@@ -765,3 +766,146 @@ func TestIsRequestedReviewer(t *testing.T) {
 		})
 	}
 }
+
+func TestNeedsReviewers(t *testing.T) {
+	tests := []struct {
+		name string
+		pr   PullRequest
+		want bool
+	}{
+		{
+			name: "Open PR without reviewers",
+			pr:   PullRequest{},
+			want: true,
+		},
+		{
+			name: "Open PR with reviewers",
+			pr: PullRequest{
+				ReviewRequests: []ReviewRequest{{Login: "reviewer1"}},
+			},
+			want: false,
+		},
+		{
+			name: "Draft PR without reviewers",
+			pr:   PullRequest{IsDraft: true},
+			want: false,
+		},
+		{
+			// A reviewer leaves reviewRequests once they review
+			name: "Open PR already reviewed",
+			pr: PullRequest{
+				LatestReviews: []Review{{Author: Author{Login: "reviewer1"}, State: "APPROVED"}},
+			},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.pr.NeedsReviewers()
+
+			if got != tt.want {
+				t.Errorf("NeedsReviewers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindPRForBranchAndEditPR(t *testing.T) {
+	fake := NewFakeGitHubExecutor()
+	fake.SetResponse("--version", "gh version 2.0.0")
+	fake.SetResponse("auth status", "Logged in to github.com")
+	fake.SetResponse("-R testowner/testrepo pr list --head work/42-fix --state open --limit 1 "+
+		"--json number,title,body,state,headRefName,baseRefName,url",
+		`[{"number":99,"title":"Fix","headRefName":"work/42-fix","state":"OPEN"}]`)
+	fake.SetResponse("-R testowner/testrepo pr list --head work/none --state open --limit 1 "+
+		"--json number,title,body,state,headRefName,baseRefName,url", `[]`)
+	fake.SetResponse("-R testowner/testrepo pr edit 99 --title New title --body New body", "")
+
+	client, err := NewClientWithRepoAndExecutor("testowner", "testrepo", fake)
+	if err != nil {
+		t.Fatalf("NewClientWithRepoAndExecutor() error = %v", err)
+	}
+
+	pr, err := client.FindPRForBranch("work/42-fix")
+	if err != nil || pr == nil || pr.Number != 99 {
+		t.Fatalf("FindPRForBranch() = %+v, %v; want PR #99", pr, err)
+	}
+
+	pr, err = client.FindPRForBranch("work/none")
+	if err != nil || pr != nil {
+		t.Errorf("FindPRForBranch(no PR) = %+v, %v; want nil, nil", pr, err)
+	}
+
+	if err := client.EditPR(99, "New title", "New body"); err != nil {
+		t.Errorf("EditPR() error = %v", err)
+	}
+
+	fake.SetResponse("-R testowner/testrepo pr list --head work/41-done --state all --limit 1 "+
+		"--json number,title,body,state,headRefName,baseRefName,url",
+		`[{"number":98,"title":"Done","headRefName":"work/41-done","state":"MERGED"}]`)
+
+	pr, err = client.LatestPRForBranch("work/41-done")
+	if err != nil || pr == nil || pr.Number != 98 || pr.State != "MERGED" {
+		t.Errorf("LatestPRForBranch() = %+v, %v; want merged PR #98", pr, err)
+	}
+}
+
+func TestCIState(t *testing.T) {
+	tests := []struct {
+		name   string
+		checks []StatusCheck
+		want   string
+	}{
+		{name: "no checks", want: CIStateNone},
+		{
+			name:   "all passing or skipped",
+			checks: []StatusCheck{{Status: "COMPLETED", Conclusion: "SUCCESS"}, {Status: "COMPLETED", Conclusion: "SKIPPED"}},
+			want:   CIStatePassing,
+		},
+		{
+			name:   "one still running",
+			checks: []StatusCheck{{Status: "COMPLETED", Conclusion: "SUCCESS"}, {Status: "IN_PROGRESS"}},
+			want:   CIStatePending,
+		},
+		{
+			name:   "failure wins over pending",
+			checks: []StatusCheck{{Status: "IN_PROGRESS"}, {Status: "COMPLETED", Conclusion: "FAILURE"}},
+			want:   CIStateFailing,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pr := &PullRequest{StatusCheckRollup: tt.checks}
+			if got := pr.CIState(); got != tt.want {
+				t.Errorf("CIState() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreatePR(t *testing.T) {
+	fake := NewFakeGitHubExecutor()
+	fake.SetResponse("api graphql -f query="+viewerQuery, `{"data":{"viewer":{"login":"me"}}}`)
+	fake.DefaultResponse = "Warning: 1 uncommitted change\nhttps://github.com/o/r/pull/12"
+
+	client := &Client{Owner: "o", Repo: "r", executor: fake}
+
+	pr, err := client.CreatePR(CreatePROptions{
+		Title: "Add feature", Base: "main", Head: "feature",
+		Reviewers: []string{"me", "alice"}, Labels: []string{"agent"},
+	})
+	if err != nil {
+		t.Fatalf("CreatePR() error = %v", err)
+	}
+
+	if pr.Number != 12 || pr.URL != "https://github.com/o/r/pull/12" {
+		t.Errorf("CreatePR() = %+v", pr)
+	}
+
+	want := "-R o/r pr create --title Add feature --body  --base main --head feature --reviewer alice --label agent"
+	if got := strings.Join(fake.GetLastCommand(), " "); got != want {
+		t.Errorf("command = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,365 @@
+package github
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// maxProjectPages bounds how much of a large board is read when listing, at
+// 100 items a page
+const maxProjectPages = 10
+
+// ErrProjectNotFound is returned when a project board can't be read, usually
+// because gh hasn't been granted the project scope
+var ErrProjectNotFound = errors.New("project not found (grant access with: gh auth refresh -s project)")
+
+// ProjectBoard is a GitHub project (Projects v2) used as an issue board. Its
+// columns are the options of a single-select field, Status by default.
+type ProjectBoard struct {
+	// Owner is the user or organization owning the project
+	Owner  string
+	Number int
+	// Field is the single-select field holding the columns
+	Field string
+}
+
+func (b ProjectBoard) String() string {
+	return fmt.Sprintf("%s/%d", b.Owner, b.Number)
+}
+
+// ProjectIssue is an issue on a project board
+type ProjectIssue struct {
+	Issue
+	// Column is the value of the board's field, or "" when unset
+	Column    string
+	Assignees []string
+	Milestone string
+}
+
+// projectItemsQuery reads a page of a board's items with their issues; items
+// that are draft issues or pull requests have no issue number
+const projectItemsQuery = `query($owner: String!, $number: Int!, $field: String!, $cursor: String) {
+  viewer { login }
+  repositoryOwner(login: $owner) {
+    ... on ProjectV2Owner {
+      projectV2(number: $number) {
+        items(first: 100, after: $cursor) {
+          pageInfo { hasNextPage endCursor }
+          nodes {
+            fieldValueByName(name: $field) {
+              ... on ProjectV2ItemFieldSingleSelectValue { name }
+            }
+            content {
+              ... on Issue {
+                number title body state url
+                labels(first: 20) { nodes { name color } }
+                assignees(first: 10) { nodes { login } }
+                milestone { title }
+                repository { nameWithOwner }
+              }
+            }
+          }
+        }
+      }
+    }
+  }
+}`
+
+// projectItemsResponse is the result of projectItemsQuery
+type projectItemsResponse struct {
+	Data struct {
+		Viewer struct {
+			Login string `json:"login"`
+		} `json:"viewer"`
+		RepositoryOwner *struct {
+			ProjectV2 *struct {
+				Items struct {
+					PageInfo struct {
+						HasNextPage bool   `json:"hasNextPage"`
+						EndCursor   string `json:"endCursor"`
+					} `json:"pageInfo"`
+					Nodes []struct {
+						FieldValueByName *struct {
+							Name string `json:"name"`
+						} `json:"fieldValueByName"`
+						Content struct {
+							Number int    `json:"number"`
+							Title  string `json:"title"`
+							Body   string `json:"body"`
+							State  string `json:"state"`
+							URL    string `json:"url"`
+							Labels struct {
+								Nodes []Label `json:"nodes"`
+							} `json:"labels"`
+							Assignees struct {
+								Nodes []Author `json:"nodes"`
+							} `json:"assignees"`
+							Milestone *struct {
+								Title string `json:"title"`
+							} `json:"milestone"`
+							Repository struct {
+								NameWithOwner string `json:"nameWithOwner"`
+							} `json:"repository"`
+						} `json:"content"`
+					} `json:"nodes"`
+				} `json:"items"`
+			} `json:"projectV2"`
+		} `json:"repositoryOwner"`
+	} `json:"data"`
+}
+
+// ListProjectIssues fetches the open issues of this repository on a project
+// board, in board order, optionally only those in column (matched without
+// case), up to limit (0 for no limit). It also returns the signed-in user's
+// login, so assignee filters can be applied to the result.
+// Uses: gh api graphql
+func (c *Client) ListProjectIssues(board ProjectBoard, column string, limit int) ([]ProjectIssue, string, error) {
+	var (
+		issues []ProjectIssue
+		viewer string
+		cursor string
+	)
+
+	repo := c.Owner + "/" + c.Repo
+
+	for page := 0; page < maxProjectPages; page++ {
+		args := []string{"api", "graphql",
+			"-f", "query=" + projectItemsQuery,
+			"-f", "owner=" + board.Owner,
+			"-F", "number=" + strconv.Itoa(board.Number),
+			"-f", "field=" + board.Field}
+		if cursor != "" {
+			args = append(args, "-f", "cursor="+cursor)
+		}
+
+		output, err := c.execGH(args...)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to list issues on project %s: %w", board, err)
+		}
+
+		var result projectItemsResponse
+		if err := json.Unmarshal(output, &result); err != nil {
+			return nil, "", fmt.Errorf("failed to parse project items: %w", err)
+		}
+
+		if result.Data.RepositoryOwner == nil || result.Data.RepositoryOwner.ProjectV2 == nil {
+			return nil, "", fmt.Errorf("%s: %w", board, ErrProjectNotFound)
+		}
+
+		viewer = result.Data.Viewer.Login
+		items := result.Data.RepositoryOwner.ProjectV2.Items
+
+		for _, node := range items.Nodes {
+			content := node.Content
+			if content.Number == 0 || content.State != "OPEN" || !strings.EqualFold(content.Repository.NameWithOwner, repo) {
+				continue
+			}
+
+			issue := ProjectIssue{Issue: Issue{
+				Number: content.Number,
+				Title:  content.Title,
+				Body:   content.Body,
+				State:  content.State,
+				Labels: content.Labels.Nodes,
+				URL:    content.URL,
+			}}
+
+			if node.FieldValueByName != nil {
+				issue.Column = node.FieldValueByName.Name
+			}
+
+			if column != "" && !strings.EqualFold(issue.Column, column) {
+				continue
+			}
+
+			for _, assignee := range content.Assignees.Nodes {
+				issue.Assignees = append(issue.Assignees, assignee.Login)
+			}
+
+			if content.Milestone != nil {
+				issue.Milestone = content.Milestone.Title
+			}
+
+			issues = append(issues, issue)
+
+			if limit > 0 && len(issues) >= limit {
+				return issues, viewer, nil
+			}
+		}
+
+		if !items.PageInfo.HasNextPage {
+			break
+		}
+
+		cursor = items.PageInfo.EndCursor
+	}
+
+	return issues, viewer, nil
+}
+
+// projectCardQuery finds an issue's cards and a board's field with its options
+const projectCardQuery = `query($owner: String!, $repo: String!, $issue: Int!, $projectOwner: String!, $number: Int!, $field: String!) {
+  repository(owner: $owner, name: $repo) {
+    issue(number: $issue) {
+      id
+      projectItems(first: 50) { nodes { id project { id } } }
+    }
+  }
+  repositoryOwner(login: $projectOwner) {
+    ... on ProjectV2Owner {
+      projectV2(number: $number) {
+        id
+        field(name: $field) {
+          ... on ProjectV2SingleSelectField { id options { id name } }
+        }
+      }
+    }
+  }
+}`
+
+// projectCardResponse is the result of projectCardQuery
+type projectCardResponse struct {
+	Data struct {
+		Repository struct {
+			Issue *struct {
+				ID           string `json:"id"`
+				ProjectItems struct {
+					Nodes []struct {
+						ID      string `json:"id"`
+						Project struct {
+							ID string `json:"id"`
+						} `json:"project"`
+					} `json:"nodes"`
+				} `json:"projectItems"`
+			} `json:"issue"`
+		} `json:"repository"`
+		RepositoryOwner *struct {
+			ProjectV2 *struct {
+				ID    string `json:"id"`
+				Field *struct {
+					ID      string `json:"id"`
+					Options []struct {
+						ID   string `json:"id"`
+						Name string `json:"name"`
+					} `json:"options"`
+				} `json:"field"`
+			} `json:"projectV2"`
+		} `json:"repositoryOwner"`
+	} `json:"data"`
+}
+
+const addProjectItemMutation = `mutation($project: ID!, $content: ID!) {
+  addProjectV2ItemById(input: {projectId: $project, contentId: $content}) { item { id } }
+}`
+
+const setProjectColumnMutation = `mutation($project: ID!, $item: ID!, $field: ID!, $option: String!) {
+  updateProjectV2ItemFieldValue(input: {projectId: $project, itemId: $item, fieldId: $field,
+    value: {singleSelectOptionId: $option}}) { projectV2Item { id } }
+}`
+
+// MoveProjectIssue moves an issue's card to column, adding the issue to the
+// board first if it isn't on it
+// Uses: gh api graphql
+func (c *Client) MoveProjectIssue(board ProjectBoard, number int, column string) error {
+	output, err := c.execGH("api", "graphql",
+		"-f", "query="+projectCardQuery,
+		"-f", "owner="+c.Owner,
+		"-f", "repo="+c.Repo,
+		"-F", "issue="+strconv.Itoa(number),
+		"-f", "projectOwner="+board.Owner,
+		"-F", "number="+strconv.Itoa(board.Number),
+		"-f", "field="+board.Field)
+	if err != nil {
+		return fmt.Errorf("failed to find issue #%d on project %s: %w", number, board, err)
+	}
+
+	var result projectCardResponse
+	if err := json.Unmarshal(output, &result); err != nil {
+		return fmt.Errorf("failed to parse project card: %w", err)
+	}
+
+	issue := result.Data.Repository.Issue
+	if issue == nil {
+		return fmt.Errorf("issue #%d not found", number)
+	}
+
+	if result.Data.RepositoryOwner == nil || result.Data.RepositoryOwner.ProjectV2 == nil {
+		return fmt.Errorf("%s: %w", board, ErrProjectNotFound)
+	}
+
+	project := result.Data.RepositoryOwner.ProjectV2
+	if project.Field == nil || project.Field.ID == "" {
+		return fmt.Errorf("project %s has no single-select field %q", board, board.Field)
+	}
+
+	var optionID string
+
+	names := make([]string, 0, len(project.Field.Options))
+
+	for _, option := range project.Field.Options {
+		names = append(names, option.Name)
+
+		if strings.EqualFold(option.Name, column) {
+			optionID = option.ID
+		}
+	}
+
+	if optionID == "" {
+		return fmt.Errorf("project %s has no %q column in %s (columns: %s)", board, column, board.Field, strings.Join(names, ", "))
+	}
+
+	var itemID string
+
+	for _, item := range issue.ProjectItems.Nodes {
+		if item.Project.ID == project.ID {
+			itemID = item.ID
+		}
+	}
+
+	if itemID == "" {
+		if itemID, err = c.addProjectItem(project.ID, issue.ID); err != nil {
+			return fmt.Errorf("failed to add issue #%d to project %s: %w", number, board, err)
+		}
+	}
+
+	if _, err := c.execGH("api", "graphql",
+		"-f", "query="+setProjectColumnMutation,
+		"-f", "project="+project.ID,
+		"-f", "item="+itemID,
+		"-f", "field="+project.Field.ID,
+		"-f", "option="+optionID); err != nil {
+		return fmt.Errorf("failed to move issue #%d to %s: %w", number, column, err)
+	}
+
+	return nil
+}
+
+// addProjectItem adds an issue to a project and returns its new item's ID
+func (c *Client) addProjectItem(projectID, contentID string) (string, error) {
+	output, err := c.execGH("api", "graphql",
+		"-f", "query="+addProjectItemMutation,
+		"-f", "project="+projectID,
+		"-f", "content="+contentID)
+	if err != nil {
+		return "", err
+	}
+
+	var result struct {
+		Data struct {
+			AddProjectV2ItemByID struct {
+				Item struct {
+					ID string `json:"id"`
+				} `json:"item"`
+			} `json:"addProjectV2ItemById"`
+		} `json:"data"`
+	}
+
+	if err := json.Unmarshal(output, &result); err != nil {
+		return "", fmt.Errorf("failed to parse added project item: %w", err)
+	}
+
+	return result.Data.AddProjectV2ItemByID.Item.ID, nil
+}
@@ -0,0 +1,154 @@
+package github
+
+import (
+	"strings"
+	"testing"
+)
+
+// queryExecutor answers gh api graphql by the first query or mutation name
+// found in the request, and records each request
+type queryExecutor struct {
+	responses map[string]string
+	calls     [][]string
+}
+
+func (e *queryExecutor) Execute(args ...string) (string, error) {
+	e.calls = append(e.calls, args)
+
+	request := strings.Join(args, " ")
+	for name, response := range e.responses {
+		if strings.Contains(request, name) {
+			return response, nil
+		}
+	}
+
+	return `{"data": {}}`, nil
+}
+
+func (e *queryExecutor) ExecuteInDir(_ string, args ...string) (string, error) {
+	return e.Execute(args...)
+}
+
+const projectItemsResponseJSON = `{"data": {"viewer": {"login": "alice"}, "repositoryOwner": {"projectV2": {"items": {
+  "pageInfo": {"hasNextPage": false, "endCursor": "c1"},
+  "nodes": [
+    {"fieldValueByName": {"name": "Ready"}, "content": {"number": 7, "title": "Fix login", "state": "OPEN",
+     "url": "https://github.com/acme/web/issues/7", "labels": {"nodes": [{"name": "bug"}]},
+     "assignees": {"nodes": [{"login": "alice"}]}, "milestone": {"title": "v2"},
+     "repository": {"nameWithOwner": "acme/web"}}},
+    {"fieldValueByName": {"name": "In Progress"}, "content": {"number": 8, "title": "Dark mode", "state": "OPEN",
+     "repository": {"nameWithOwner": "acme/web"}}},
+    {"fieldValueByName": {"name": "Ready"}, "content": {"number": 9, "title": "Closed", "state": "CLOSED",
+     "repository": {"nameWithOwner": "acme/web"}}},
+    {"fieldValueByName": {"name": "Ready"}, "content": {"number": 3, "title": "Other repo", "state": "OPEN",
+     "repository": {"nameWithOwner": "acme/api"}}},
+    {"fieldValueByName": {"name": "Ready"}, "content": {}},
+    {"fieldValueByName": null, "content": {"number": 10, "title": "No status", "state": "OPEN",
+     "repository": {"nameWithOwner": "acme/web"}}}
+  ]}}}}}`
+
+func TestListProjectIssues(t *testing.T) {
+	exec := &queryExecutor{responses: map[string]string{"viewer": projectItemsResponseJSON}}
+	client := &Client{Owner: "acme", Repo: "web", executor: exec}
+	board := ProjectBoard{Owner: "acme", Number: 5, Field: "Status"}
+
+	issues, viewer, err := client.ListProjectIssues(board, "ready", 0)
+	if err != nil {
+		t.Fatalf("ListProjectIssues() error = %v", err)
+	}
+
+	if viewer != "alice" {
+		t.Errorf("viewer = %q, want alice", viewer)
+	}
+
+	if len(issues) != 1 || issues[0].Number != 7 {
+		t.Fatalf("ListProjectIssues(ready) = %+v, want only #7", issues)
+	}
+
+	if issues[0].Column != "Ready" || issues[0].Milestone != "v2" || issues[0].Assignees[0] != "alice" || issues[0].Labels[0].Name != "bug" {
+		t.Errorf("unexpected issue: %+v", issues[0])
+	}
+
+	all, _, err := client.ListProjectIssues(board, "", 2)
+	if err != nil || len(all) != 2 || all[1].Number != 8 {
+		t.Errorf("ListProjectIssues(all, limit 2) = %+v, %v; want #7 and #8", all, err)
+	}
+
+	args := strings.Join(exec.calls[0], " ")
+	if !strings.Contains(args, "owner=acme") || !strings.Contains(args, "number=5") || !strings.Contains(args, "field=Status") {
+		t.Errorf("unexpected query arguments: %v", exec.calls[0])
+	}
+}
+
+func TestListProjectIssuesNotFound(t *testing.T) {
+	exec := &queryExecutor{responses: map[string]string{"viewer": `{"data": {"viewer": {"login": "alice"}, "repositoryOwner": null}}`}}
+	client := &Client{Owner: "acme", Repo: "web", executor: exec}
+
+	_, _, err := client.ListProjectIssues(ProjectBoard{Owner: "acme", Number: 5, Field: "Status"}, "", 0)
+	if err == nil || !strings.Contains(err.Error(), "gh auth refresh -s project") {
+		t.Errorf("ListProjectIssues() error = %v, want a hint about the project scope", err)
+	}
+}
+
+const projectCardResponseJSON = `{"data": {
+  "repository": {"issue": {"id": "I_7", "projectItems": {"nodes": [%s]}}},
+  "repositoryOwner": {"projectV2": {"id": "P_5", "field": {"id": "F_status", "options": [
+    {"id": "O_ready", "name": "Ready"}, {"id": "O_doing", "name": "In Progress"}]}}}}}`
+
+func TestMoveProjectIssue(t *testing.T) {
+	board := ProjectBoard{Owner: "acme", Number: 5, Field: "Status"}
+
+	t.Run("card on the board", func(t *testing.T) {
+		exec := &queryExecutor{responses: map[string]string{
+			"projectItems": strings.Replace(projectCardResponseJSON, "%s", `{"id": "PVTI_other", "project": {"id": "P_1"}}, {"id": "PVTI_7", "project": {"id": "P_5"}}`, 1),
+		}}
+		client := &Client{Owner: "acme", Repo: "web", executor: exec}
+
+		if err := client.MoveProjectIssue(board, 7, "in progress"); err != nil {
+			t.Fatalf("MoveProjectIssue() error = %v", err)
+		}
+
+		if len(exec.calls) != 2 {
+			t.Fatalf("expected a lookup and an update, got %d calls", len(exec.calls))
+		}
+
+		update := strings.Join(exec.calls[1], " ")
+		for _, want := range []string{"updateProjectV2ItemFieldValue", "project=P_5", "item=PVTI_7", "field=F_status", "option=O_doing"} {
+			if !strings.Contains(update, want) {
+				t.Errorf("update is missing %s: %v", want, exec.calls[1])
+			}
+		}
+	})
+
+	t.Run("card added to the board", func(t *testing.T) {
+		exec := &queryExecutor{responses: map[string]string{
+			"projectItems":         strings.Replace(projectCardResponseJSON, "%s", "", 1),
+			"addProjectV2ItemById": `{"data": {"addProjectV2ItemById": {"item": {"id": "PVTI_new"}}}}`,
+		}}
+		client := &Client{Owner: "acme", Repo: "web", executor: exec}
+
+		if err := client.MoveProjectIssue(board, 7, "Ready"); err != nil {
+			t.Fatalf("MoveProjectIssue() error = %v", err)
+		}
+
+		if len(exec.calls) != 3 || !strings.Contains(strings.Join(exec.calls[2], " "), "item=PVTI_new") {
+			t.Errorf("expected the new card to be moved, got %v", exec.calls)
+		}
+	})
+
+	t.Run("unknown column", func(t *testing.T) {
+		exec := &queryExecutor{responses: map[string]string{
+			"projectItems": strings.Replace(projectCardResponseJSON, "%s", "", 1),
+		}}
+		client := &Client{Owner: "acme", Repo: "web", executor: exec}
+
+		err := client.MoveProjectIssue(board, 7, "Doing")
+		if err == nil || !strings.Contains(err.Error(), "Ready, In Progress") {
+			t.Errorf("MoveProjectIssue() error = %v, want the board's columns", err)
+		}
+
+		if len(exec.calls) != 1 {
+			t.Errorf("expected no changes to the board, got %v", exec.calls)
+		}
+	})
+}
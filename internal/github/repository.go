@@ -6,6 +6,9 @@ import (
 	"os/exec"
 	"regexp"
 	"strings"
+	"time"
+
+	"github.com/kaeawc/auto-worktree/internal/logging"
 )
 
 var (
@@ -29,13 +32,17 @@ func DetectRepository(gitRoot string) (*RepositoryInfo, error) {
 	// Try origin remote first
 	cmd := exec.Command("git", "config", "--get", "remote.origin.url")
 	cmd.Dir = gitRoot
+	start := time.Now()
 	output, err := cmd.Output()
+	logging.Command(cmd, start, output, err)
 
 	if err != nil {
 		// Origin not found, try to get first remote
 		cmd = exec.Command("git", "remote")
 		cmd.Dir = gitRoot
+		start = time.Now()
 		remotesOutput, remotesErr := cmd.Output()
+		logging.Command(cmd, start, remotesOutput, remotesErr)
 		if remotesErr != nil {
 			return nil, ErrNoRemote
 		}
@@ -48,7 +55,9 @@ func DetectRepository(gitRoot string) (*RepositoryInfo, error) {
 		// Get URL for first remote
 		cmd = exec.Command("git", "config", "--get", fmt.Sprintf("remote.%s.url", remotes[0]))
 		cmd.Dir = gitRoot
+		start = time.Now()
 		output, err = cmd.Output()
+		logging.Command(cmd, start, output, err)
 		if err != nil {
 			return nil, ErrNoRemote
 		}
@@ -0,0 +1,492 @@
+package gitlab
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kaeawc/auto-worktree/internal/interrupt"
+	"github.com/kaeawc/auto-worktree/internal/limits"
+	"github.com/kaeawc/auto-worktree/internal/logging"
+	"github.com/kaeawc/auto-worktree/internal/retry"
+)
+
+// Transports for reaching GitLab
+const (
+	// TransportCLI runs the glab CLI (the default)
+	TransportCLI = "cli"
+	// TransportAPI calls the GitLab REST API directly with a token, for machines without glab
+	TransportAPI = "api"
+)
+
+// ErrAPIUnsupported is returned for glab commands the API transport cannot answer
+var ErrAPIUnsupported = errors.New("not available with the GitLab API transport")
+
+var (
+	transportMu sync.RWMutex
+	transport   = TransportCLI
+)
+
+// SetTransport chooses how NewGitLabExecutor reaches GitLab
+func SetTransport(t string) {
+	transportMu.Lock()
+	defer transportMu.Unlock()
+
+	transport = t
+}
+
+// useAPI reports whether new executors call the API: when configured to, or
+// when glab is not installed but a token is in the environment (as in CI)
+func useAPI() bool {
+	transportMu.RLock()
+	t := transport
+	transportMu.RUnlock()
+
+	if t == TransportAPI {
+		return true
+	}
+
+	if _, err := exec.LookPath("glab"); err != nil {
+		header, _ := envToken()
+		return header != ""
+	}
+
+	return false
+}
+
+// envToken returns the header and token to authenticate with: a personal access
+// token from GITLAB_TOKEN (as glab reads it), or CI_JOB_TOKEN inside a GitLab CI job
+func envToken() (string, string) {
+	if token := os.Getenv("GITLAB_TOKEN"); token != "" {
+		return "PRIVATE-TOKEN", token
+	}
+
+	if token := os.Getenv("CI_JOB_TOKEN"); token != "" {
+		return "JOB-TOKEN", token
+	}
+
+	return "", ""
+}
+
+// APIExecutor answers the glab commands Client runs by calling the GitLab
+// REST API, so issue and merge request features work without the glab binary.
+// glab prints REST objects for --json, so output has the same shape.
+type APIExecutor struct {
+	// TokenHeader is PRIVATE-TOKEN for personal access tokens or JOB-TOKEN for CI_JOB_TOKEN
+	TokenHeader string
+	Token       string
+	// BaseURL overrides https://<host>/api/v4, e.g. for tests
+	BaseURL string
+	HTTP    *http.Client
+
+	// ctx stops requests when it is done; nil never does
+	ctx context.Context
+}
+
+// NewAPIExecutor creates an executor that uses the API with the token from the environment
+func NewAPIExecutor() *APIExecutor {
+	header, token := envToken()
+
+	return &APIExecutor{TokenHeader: header, Token: token, HTTP: &http.Client{}, ctx: interrupt.Context()}
+}
+
+// ExecuteInDir runs a glab command; the API needs no working directory
+func (e *APIExecutor) ExecuteInDir(_ string, args ...string) (string, error) {
+	return e.Execute(args...)
+}
+
+// Execute answers a glab command, e.g. "-R group/project issue view 7 --json"
+func (e *APIExecutor) Execute(args ...string) (string, error) {
+	host, project := "gitlab.com", ""
+
+	// Client puts --host (or --hostname for glab api) and -R before the command
+	rest := args
+	for len(rest) >= 2 && (rest[0] == "--host" || rest[0] == "--hostname" || rest[0] == "-R") {
+		if rest[0] == "-R" {
+			project = url.PathEscape(rest[1])
+		} else {
+			host = rest[1]
+		}
+
+		rest = rest[2:]
+	}
+
+	positional, flags := parseGlabArgs(rest)
+	command := strings.Join(positional[:min(2, len(positional))], " ")
+	base := e.baseURL(host)
+	projectURL := base + "/projects/" + project
+
+	var (
+		output string
+		err    error
+	)
+
+	switch {
+	case len(rest) == 1 && rest[0] == "--version":
+		return "glab (GitLab API transport)", nil
+	case command == "auth status":
+		if e.Token == "" {
+			return "", fmt.Errorf("no token: set GITLAB_TOKEN or sign in with glab auth login")
+		}
+
+		return "Logged in with a token", nil
+	case command == "issue list", command == "mr list":
+		output, err = e.list(projectURL, positional[0], flags)
+	case command == "issue view" && len(positional) == 3:
+		output, err = e.do(http.MethodGet, projectURL+"/issues/"+positional[2], nil)
+	case command == "mr view" && len(positional) == 3:
+		output, err = e.do(http.MethodGet, projectURL+"/merge_requests/"+positional[2], nil)
+	case command == "mr diff" && len(positional) == 3:
+		output, err = e.diff(projectURL + "/merge_requests/" + positional[2] + "/diffs?per_page=100")
+	case command == "issue create":
+		output, err = e.do(http.MethodPost, projectURL+"/issues",
+			map[string]string{"title": flags["--title"], "description": flags["--description"]})
+	case command == "mr create":
+		output, err = e.createMR(base, projectURL, flags)
+	case command == "issue update" && len(positional) == 3:
+		output, err = e.updateIssue(base, projectURL+"/issues/"+positional[2], flags)
+	case command == "issue note" && len(positional) == 3:
+		output, err = e.do(http.MethodPost, projectURL+"/issues/"+positional[2]+"/notes",
+			map[string]string{"body": flags["--message"]})
+	case len(positional) == 2 && positional[0] == "api":
+		output, err = e.do(http.MethodGet, base+"/"+positional[1], nil)
+	default:
+		return "", fmt.Errorf("glab %s: %w", strings.Join(args, " "), ErrAPIUnsupported)
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("glab %s failed: %w", strings.Join(args, " "), err)
+	}
+
+	return strings.TrimSpace(output), nil
+}
+
+// baseURL is the REST API root for host; inside a GitLab CI job on that host it
+// is the job's CI_API_V4_URL
+func (e *APIExecutor) baseURL(host string) string {
+	if e.BaseURL != "" {
+		return strings.TrimSuffix(e.BaseURL, "/")
+	}
+
+	if api := os.Getenv("CI_API_V4_URL"); api != "" && os.Getenv("CI_SERVER_HOST") == host {
+		return strings.TrimSuffix(api, "/")
+	}
+
+	return "https://" + host + "/api/v4"
+}
+
+// glabValueFlags take a value; every other flag (such as --json) is a switch
+var glabValueFlags = map[string]bool{
+	"--state": true, "--per-page": true, "--label": true, "--assignee": true, "--milestone": true,
+	"--search": true, "--title": true, "--description": true, "--message": true,
+	"--source-branch": true, "--target-branch": true, "--reviewer": true,
+}
+
+// parseGlabArgs splits glab arguments into positional words and flags
+func parseGlabArgs(args []string) ([]string, map[string]string) {
+	var positional []string
+
+	flags := map[string]string{}
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+
+		switch {
+		case !strings.HasPrefix(arg, "-"):
+			positional = append(positional, arg)
+		case glabValueFlags[arg] && i+1 < len(args):
+			flags[arg] = args[i+1]
+			i++
+		default:
+			flags[arg] = "true"
+		}
+	}
+
+	return positional, flags
+}
+
+// list answers issue list and mr list
+func (e *APIExecutor) list(projectURL, kind string, flags map[string]string) (string, error) {
+	query := url.Values{}
+
+	if state := flags["--state"]; state != "" {
+		query.Set("state", state)
+	}
+
+	if perPage := flags["--per-page"]; perPage != "" {
+		query.Set("per_page", perPage)
+	}
+
+	if labels := flags["--label"]; labels != "" {
+		query.Set("labels", labels)
+	}
+
+	switch assignee := flags["--assignee"]; assignee {
+	case "":
+	case "@me":
+		query.Set("scope", "assigned_to_me")
+	default:
+		query.Set("assignee_username", assignee)
+	}
+
+	if milestone := flags["--milestone"]; milestone != "" {
+		query.Set("milestone", milestone)
+	}
+
+	if search := flags["--search"]; search != "" {
+		query.Set("search", search)
+	}
+
+	endpoint := "/issues"
+	if kind == "mr" {
+		endpoint = "/merge_requests"
+	}
+
+	return e.do(http.MethodGet, projectURL+endpoint+"?"+query.Encode(), nil)
+}
+
+// updateIssue answers issue update --assignee +<username> and --label a,b,
+// which add to the issue's assignees and labels as glab does
+func (e *APIExecutor) updateIssue(base, issueURL string, flags map[string]string) (string, error) {
+	update := map[string]any{}
+
+	if labels := flags["--label"]; labels != "" {
+		update["add_labels"] = labels
+	}
+
+	if assignee := strings.TrimPrefix(flags["--assignee"], "+"); assignee != "" {
+		id, err := e.userID(base, assignee)
+		if err != nil {
+			return "", err
+		}
+
+		output, err := e.do(http.MethodGet, issueURL, nil)
+		if err != nil {
+			return "", err
+		}
+
+		var issue struct {
+			Assignees []struct {
+				ID int `json:"id"`
+			} `json:"assignees"`
+		}
+
+		if err := json.Unmarshal([]byte(output), &issue); err != nil {
+			return "", fmt.Errorf("failed to parse issue: %w", err)
+		}
+
+		ids := []int{id}
+		for _, a := range issue.Assignees {
+			if a.ID != id {
+				ids = append(ids, a.ID)
+			}
+		}
+
+		update["assignee_ids"] = ids
+	}
+
+	return e.do(http.MethodPut, issueURL, update)
+}
+
+// createMR answers mr create and prints the new merge request's URL, as glab does
+func (e *APIExecutor) createMR(base, projectURL string, flags map[string]string) (string, error) {
+	title := flags["--title"]
+	if flags["--draft"] == "true" {
+		title = "Draft: " + title
+	}
+
+	create := map[string]any{
+		"title":         title,
+		"description":   flags["--description"],
+		"source_branch": flags["--source-branch"],
+		"target_branch": flags["--target-branch"],
+	}
+
+	if labels := flags["--label"]; labels != "" {
+		create["labels"] = labels
+	}
+
+	if reviewers := flags["--reviewer"]; reviewers != "" {
+		var ids []int
+
+		for _, username := range strings.Split(reviewers, ",") {
+			id, err := e.userID(base, username)
+			if err != nil {
+				return "", err
+			}
+
+			ids = append(ids, id)
+		}
+
+		create["reviewer_ids"] = ids
+	}
+
+	output, err := e.do(http.MethodPost, projectURL+"/merge_requests", create)
+	if err != nil {
+		return "", err
+	}
+
+	var created struct {
+		WebURL string `json:"web_url"`
+	}
+
+	if err := json.Unmarshal([]byte(output), &created); err != nil {
+		return "", fmt.Errorf("failed to parse created merge request: %w", err)
+	}
+
+	return created.WebURL, nil
+}
+
+// userID looks up a user's ID by username, or the signed-in user's for "@me"
+func (e *APIExecutor) userID(base, username string) (int, error) {
+	var user struct {
+		ID int `json:"id"`
+	}
+
+	if username == "@me" {
+		output, err := e.do(http.MethodGet, base+"/user", nil)
+		if err != nil {
+			return 0, err
+		}
+
+		if err := json.Unmarshal([]byte(output), &user); err != nil {
+			return 0, fmt.Errorf("failed to parse user: %w", err)
+		}
+
+		return user.ID, nil
+	}
+
+	output, err := e.do(http.MethodGet, base+"/users?username="+url.QueryEscape(username), nil)
+	if err != nil {
+		return 0, err
+	}
+
+	var users []struct {
+		ID int `json:"id"`
+	}
+
+	if err := json.Unmarshal([]byte(output), &users); err != nil {
+		return 0, fmt.Errorf("failed to parse users: %w", err)
+	}
+
+	if len(users) == 0 {
+		return 0, fmt.Errorf("no GitLab user named %s", username)
+	}
+
+	return users[0].ID, nil
+}
+
+// diff answers mr diff by joining the per-file diffs into one unified diff
+func (e *APIExecutor) diff(diffsURL string) (string, error) {
+	output, err := e.do(http.MethodGet, diffsURL, nil)
+	if err != nil {
+		return "", err
+	}
+
+	var files []struct {
+		OldPath string `json:"old_path"`
+		NewPath string `json:"new_path"`
+		Diff    string `json:"diff"`
+	}
+
+	if err := json.Unmarshal([]byte(output), &files); err != nil {
+		return "", fmt.Errorf("failed to parse diff: %w", err)
+	}
+
+	var b strings.Builder
+
+	for _, f := range files {
+		fmt.Fprintf(&b, "diff --git a/%s b/%s\n--- a/%s\n+++ b/%s\n%s", f.OldPath, f.NewPath, f.OldPath, f.NewPath, f.Diff)
+
+		if !strings.HasSuffix(f.Diff, "\n") {
+			b.WriteString("\n")
+		}
+	}
+
+	return b.String(), nil
+}
+
+// do sends a request under the glab timeout, retrying transient failures and
+// rate limits; payload is sent as JSON when it is not nil
+func (e *APIExecutor) do(method, target string, payload any) (string, error) {
+	if e.Token == "" {
+		return "", ErrGlabNotAuthenticated
+	}
+
+	var body []byte
+
+	if payload != nil {
+		var err error
+		if body, err = json.Marshal(payload); err != nil {
+			return "", fmt.Errorf("failed to encode request: %w", err)
+		}
+	}
+
+	output, err := retry.DefaultPolicy.Run("GitLab", func() ([]byte, error) {
+		ctx, cancel := limits.Context(orBackground(e.ctx), limits.ToolGitLab)
+		defer cancel()
+
+		req, err := http.NewRequestWithContext(ctx, method, target, bytes.NewReader(body))
+		if err != nil {
+			return nil, fmt.Errorf("failed to build request: %w", err)
+		}
+
+		req.Header.Set(e.TokenHeader, e.Token)
+
+		if body != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+
+		start := time.Now()
+		resp, err := e.HTTP.Do(req)
+		logging.Debug("gitlab api", "method", method, "url", target, "duration", time.Since(start), "err", err)
+
+		if err != nil {
+			return nil, limits.Wrap(ctx, limits.ToolGitLab, err)
+		}
+		defer resp.Body.Close()
+
+		output, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, limits.Wrap(ctx, limits.ToolGitLab, fmt.Errorf("failed to read response: %w", err))
+		}
+
+		if resp.StatusCode >= http.StatusMultipleChoices {
+			return output, fmt.Errorf("HTTP %d: %s", resp.StatusCode, apiMessage(output))
+		}
+
+		return output, nil
+	})
+
+	return string(output), err
+}
+
+// apiMessage extracts the message of an API error response
+func apiMessage(body []byte) string {
+	var apiErr struct {
+		Message any    `json:"message"`
+		Error   string `json:"error"`
+	}
+
+	if json.Unmarshal(body, &apiErr) == nil {
+		if apiErr.Message != nil {
+			return fmt.Sprint(apiErr.Message)
+		}
+
+		if apiErr.Error != "" {
+			return apiErr.Error
+		}
+	}
+
+	return strings.TrimSpace(string(body))
+}
@@ -0,0 +1,168 @@
+package gitlab
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kaeawc/auto-worktree/internal/retry"
+)
+
+// newTestAPIClient serves the API from handler and returns a client that calls it
+func newTestAPIClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	policy := retry.DefaultPolicy
+	retry.DefaultPolicy = retry.Policy{Attempts: 1}
+
+	t.Cleanup(func() { retry.DefaultPolicy = policy })
+
+	executor := &APIExecutor{TokenHeader: "PRIVATE-TOKEN", Token: "test-token", BaseURL: server.URL, HTTP: server.Client()}
+
+	return &Client{Owner: "group", Project: "project", Host: "gitlab.com", executor: executor}
+}
+
+func TestAPIExecutor_ListIssues(t *testing.T) {
+	client := newTestAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("PRIVATE-TOKEN") != "test-token" {
+			t.Errorf("PRIVATE-TOKEN = %q", r.Header.Get("PRIVATE-TOKEN"))
+		}
+
+		if r.URL.EscapedPath() != "/projects/group%2Fproject/issues" {
+			t.Errorf("path = %s", r.URL.EscapedPath())
+		}
+
+		q := r.URL.Query()
+		if q.Get("state") != "opened" || q.Get("labels") != "bug,ui" || q.Get("scope") != "assigned_to_me" || q.Get("per_page") != "5" {
+			t.Errorf("query = %s", r.URL.RawQuery)
+		}
+
+		io.WriteString(w, `[{"iid":3,"title":"Broken button","state":"opened","labels":["bug"]}]`)
+	})
+
+	issues, err := client.ListFilteredIssues(5, IssueFilter{Labels: []string{"bug", "ui"}, Assignee: "@me"})
+	if err != nil {
+		t.Fatalf("ListFilteredIssues() error = %v", err)
+	}
+
+	if len(issues) != 1 || issues[0].IID != 3 || issues[0].Title != "Broken button" {
+		t.Errorf("ListFilteredIssues() = %+v", issues)
+	}
+}
+
+func TestAPIExecutor_FindMRForBranch(t *testing.T) {
+	client := newTestAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("source_branch") != "work/retry" {
+			t.Errorf("query = %s", r.URL.RawQuery)
+		}
+
+		io.WriteString(w, `[{"iid":12,"title":"Add retry","state":"opened","source_branch":"work/retry"}]`)
+	})
+
+	mr, err := client.FindMRForBranch("work/retry")
+	if err != nil {
+		t.Fatalf("FindMRForBranch() error = %v", err)
+	}
+
+	if mr == nil || mr.IID != 12 {
+		t.Errorf("FindMRForBranch() = %+v", mr)
+	}
+}
+
+func TestAPIExecutor_AddAssignee(t *testing.T) {
+	var update string
+
+	client := newTestAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/users":
+			io.WriteString(w, `[{"id":42,"username":"alice"}]`)
+		case r.Method == http.MethodGet:
+			io.WriteString(w, `{"iid":3,"assignees":[{"id":7}]}`)
+		case r.Method == http.MethodPut:
+			body, _ := io.ReadAll(r.Body)
+			update = string(body)
+			io.WriteString(w, `{"iid":3}`)
+		}
+	})
+
+	if err := client.AddAssignee(3, "alice"); err != nil {
+		t.Fatalf("AddAssignee() error = %v", err)
+	}
+
+	if update != `{"assignee_ids":[42,7]}` {
+		t.Errorf("update = %s, want the new assignee added to the existing one", update)
+	}
+}
+
+func TestAPIExecutor_GetMRDiff(t *testing.T) {
+	client := newTestAPIClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		io.WriteString(w, `[{"old_path":"a.go","new_path":"a.go","diff":"@@ -1 +1 @@\n-old\n+new\n"}]`)
+	})
+
+	diff, err := client.GetMRDiff(12)
+	if err != nil {
+		t.Fatalf("GetMRDiff() error = %v", err)
+	}
+
+	if !strings.HasPrefix(diff, "diff --git a/a.go b/a.go\n--- a/a.go\n+++ b/a.go\n@@ -1 +1 @@") {
+		t.Errorf("GetMRDiff() = %q", diff)
+	}
+}
+
+func TestAPIExecutor_CreateMR(t *testing.T) {
+	var create string
+
+	client := newTestAPIClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/users":
+			io.WriteString(w, `[{"id":42,"username":"alice"}]`)
+		case r.Method == http.MethodPost:
+			body, _ := io.ReadAll(r.Body)
+			create = string(body)
+			io.WriteString(w, `{"iid":9,"web_url":"https://gitlab.com/group/project/-/merge_requests/9"}`)
+		}
+	})
+
+	mr, err := client.CreateMR(CreateMROptions{
+		Title: "Add retry", SourceBranch: "work/retry", TargetBranch: "main",
+		Reviewers: []string{"alice"}, Labels: []string{"agent"}, Draft: true,
+	})
+	if err != nil {
+		t.Fatalf("CreateMR() error = %v", err)
+	}
+
+	if mr.IID != 9 || mr.WebURL != "https://gitlab.com/group/project/-/merge_requests/9" {
+		t.Errorf("CreateMR() = %+v", mr)
+	}
+
+	want := `{"description":"","labels":"agent","reviewer_ids":[42],"source_branch":"work/retry","target_branch":"main","title":"Draft: Add retry"}`
+	if create != want {
+		t.Errorf("create = %s, want %s", create, want)
+	}
+}
+
+func TestAPIExecutor_Errors(t *testing.T) {
+	client := newTestAPIClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		io.WriteString(w, `{"message":"404 Project Not Found"}`)
+	})
+
+	_, err := client.GetIssue(3)
+	if err == nil || !strings.Contains(err.Error(), "HTTP 404: 404 Project Not Found") {
+		t.Errorf("GetIssue() error = %v", err)
+	}
+
+	if _, err := client.executor.Execute("mr", "checkout", "3"); !errors.Is(err, ErrAPIUnsupported) {
+		t.Errorf("Execute(mr checkout) error = %v, want ErrAPIUnsupported", err)
+	}
+
+	if err := IsAuthenticated(&APIExecutor{}); err == nil {
+		t.Error("IsAuthenticated() without a token = nil, want error")
+	}
+}
@@ -1,9 +1,16 @@
 package gitlab
 
 import (
+	"context"
 	"fmt"
 	"os/exec"
 	"strings"
+	"time"
+
+	"github.com/kaeawc/auto-worktree/internal/interrupt"
+	"github.com/kaeawc/auto-worktree/internal/limits"
+	"github.com/kaeawc/auto-worktree/internal/logging"
+	"github.com/kaeawc/auto-worktree/internal/retry"
 )
 
 // GitLabExecutor defines the interface for executing glab CLI commands
@@ -15,31 +22,76 @@ type GitLabExecutor interface {
 }
 
 // RealGitLabExecutor executes actual glab commands via exec.Command
-type RealGitLabExecutor struct{}
+type RealGitLabExecutor struct {
+	// ctx stops the commands when it is done
+	ctx context.Context
+}
 
-// NewGitLabExecutor creates a new real GitLab executor for production use
+// NewGitLabExecutor creates a new real GitLab executor for production use: the
+// glab CLI, or the GitLab API when that transport is selected or glab is missing
+// but a token is set. Its commands stop on Ctrl-C.
 func NewGitLabExecutor() GitLabExecutor {
-	return &RealGitLabExecutor{}
+	return NewGitLabExecutorContext(interrupt.Context())
+}
+
+// NewGitLabExecutorContext creates a GitLab executor whose commands stop when ctx is done
+func NewGitLabExecutorContext(ctx context.Context) GitLabExecutor {
+	if useAPI() {
+		executor := NewAPIExecutor()
+		executor.ctx = ctx
+
+		return executor
+	}
+
+	return &RealGitLabExecutor{ctx: ctx}
+}
+
+// orBackground returns ctx, or context.Background() for an executor built without one
+func orBackground(ctx context.Context) context.Context {
+	if ctx == nil {
+		return context.Background()
+	}
+
+	return ctx
 }
 
 // Execute runs a glab command and returns the output
 func (e *RealGitLabExecutor) Execute(args ...string) (string, error) {
-	cmd := exec.Command("glab", args...)
-	output, err := cmd.CombinedOutput()
+	output, err := runGlab(orBackground(e.ctx), "", args)
 	if err != nil {
 		return "", fmt.Errorf("glab %s failed: %w", strings.Join(args, " "), err)
 	}
-	return strings.TrimSpace(string(output)), nil
+	return output, nil
 }
 
 // ExecuteInDir runs a glab command in a specific directory
 func (e *RealGitLabExecutor) ExecuteInDir(dir string, args ...string) (string, error) {
-	cmd := exec.Command("glab", args...)
-	cmd.Dir = dir
-	output, err := cmd.CombinedOutput()
+	output, err := runGlab(orBackground(e.ctx), dir, args)
 	if err != nil {
 		return "", fmt.Errorf("glab %s failed in %s: %w", strings.Join(args, " "), dir, err)
 	}
+	return output, nil
+}
+
+// runGlab runs glab under its timeout, retrying transient failures and rate
+// limits; it stops when parent is done
+func runGlab(parent context.Context, dir string, args []string) (string, error) {
+	output, err := retry.DefaultPolicy.Run("GitLab", func() ([]byte, error) {
+		ctx, cancel := limits.Context(parent, limits.ToolGitLab)
+		defer cancel()
+
+		cmd := exec.CommandContext(ctx, "glab", args...)
+		cmd.Dir = dir
+		start := time.Now()
+		output, err := cmd.CombinedOutput()
+		logging.Command(cmd, start, output, err)
+
+		return output, limits.Wrap(ctx, limits.ToolGitLab, err)
+	})
+	if err != nil {
+		return "", err
+	}
+
 	return strings.TrimSpace(string(output)), nil
 }
 
@@ -11,15 +11,68 @@ import (
 
 // Issue represents a GitLab issue
 type Issue struct {
-	IID         int      `json:"iid"` // Issue IID (internal ID, scoped to project)
-	Title       string   `json:"title"`
-	Description string   `json:"description"` // GitLab uses "description" not "body"
-	State       string   `json:"state"`       // "opened" or "closed"
-	Labels      []string `json:"labels"`
-	WebURL      string   `json:"web_url"`
-	Author      Author   `json:"author"`
-	CreatedAt   string   `json:"created_at"`
-	UpdatedAt   string   `json:"updated_at"`
+	IID         int        `json:"iid"` // Issue IID (internal ID, scoped to project)
+	Title       string     `json:"title"`
+	Description string     `json:"description"` // GitLab uses "description" not "body"
+	State       string     `json:"state"`       // "opened" or "closed"
+	Labels      []string   `json:"labels"`
+	WebURL      string     `json:"web_url"`
+	Author      Author     `json:"author"`
+	Assignees   []Author   `json:"assignees"`
+	Milestone   *Milestone `json:"milestone"`
+	CreatedAt   string     `json:"created_at"`
+	UpdatedAt   string     `json:"updated_at"`
+}
+
+// Milestone represents a GitLab milestone
+type Milestone struct {
+	Title string `json:"title"`
+}
+
+// activeMilestone is a milestone as the milestones API returns it
+type activeMilestone struct {
+	Title     string `json:"title"`
+	StartDate string `json:"start_date"`
+	DueDate   string `json:"due_date"`
+}
+
+// CurrentMilestone returns the title of the active milestone that has started
+// and is due soonest, or of the first active milestone when none has dates.
+// today is a YYYY-MM-DD date.
+// Uses: glab api projects/<project>/milestones?state=active
+func (c *Client) CurrentMilestone(today string) (string, error) {
+	output, err := c.execAPI("milestones?state=active&per_page=100")
+	if err != nil {
+		return "", fmt.Errorf("failed to list milestones: %w", err)
+	}
+
+	var milestones []activeMilestone
+	if err := json.Unmarshal(output, &milestones); err != nil {
+		return "", fmt.Errorf("failed to parse milestones: %w", err)
+	}
+
+	if len(milestones) == 0 {
+		return "", fmt.Errorf("%s/%s has no active milestones", c.Owner, c.Project)
+	}
+
+	var current *activeMilestone
+
+	for i := range milestones {
+		m := &milestones[i]
+		if m.DueDate == "" || m.DueDate < today || (m.StartDate != "" && m.StartDate > today) {
+			continue
+		}
+
+		if current == nil || m.DueDate < current.DueDate {
+			current = m
+		}
+	}
+
+	if current == nil {
+		return milestones[0].Title, nil
+	}
+
+	return current.Title, nil
 }
 
 // Author represents a GitLab user
@@ -28,13 +81,48 @@ type Author struct {
 	Name     string `json:"name"`
 }
 
+// IssueFilter narrows the issues returned by ListFilteredIssues
+type IssueFilter struct {
+	Labels    []string
+	Assignee  string // "@me", "none" for unassigned, or a username
+	Milestone string
+	Search    string
+}
+
 // ListOpenIssues fetches open issues (up to limit)
 // Uses: glab issue list --state opened --per-page <limit> --json
 func (c *Client) ListOpenIssues(limit int) ([]Issue, error) {
-	output, err := c.execGlabInRepo("issue", "list",
+	return c.ListFilteredIssues(limit, IssueFilter{})
+}
+
+// ListFilteredIssues fetches open issues matching the filter (up to limit)
+// Uses: glab issue list --state opened --per-page <limit> [--label ...] [--assignee ...]
+// [--milestone ...] [--search ...] --json
+func (c *Client) ListFilteredIssues(limit int, filter IssueFilter) ([]Issue, error) {
+	args := []string{"issue", "list",
 		"--state", "opened",
-		"--per-page", strconv.Itoa(limit),
-		"--json")
+		"--per-page", strconv.Itoa(limit)}
+
+	if len(filter.Labels) > 0 {
+		args = append(args, "--label", strings.Join(filter.Labels, ","))
+	}
+
+	// glab has no flag for unassigned issues; those are filtered after fetching
+	if filter.Assignee != "" && filter.Assignee != "none" {
+		args = append(args, "--assignee", filter.Assignee)
+	}
+
+	if filter.Milestone != "" {
+		args = append(args, "--milestone", filter.Milestone)
+	}
+
+	if filter.Search != "" {
+		args = append(args, "--search", filter.Search)
+	}
+
+	args = append(args, "--json")
+
+	output, err := c.execGlabInRepo(args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list issues: %w", err)
 	}
@@ -44,6 +132,18 @@ func (c *Client) ListOpenIssues(limit int) ([]Issue, error) {
 		return nil, fmt.Errorf("failed to parse issues: %w", err)
 	}
 
+	if filter.Assignee == "none" {
+		unassigned := issues[:0]
+
+		for i := range issues {
+			if len(issues[i].Assignees) == 0 {
+				unassigned = append(unassigned, issues[i])
+			}
+		}
+
+		issues = unassigned
+	}
+
 	return issues, nil
 }
 
@@ -72,6 +172,36 @@ func (c *Client) IsIssueClosed(iid int) (bool, error) {
 	return issue.State == "closed", nil
 }
 
+// AddAssignee assigns a user to an issue
+// Uses: glab issue update <iid> --assignee +<username>
+func (c *Client) AddAssignee(iid int, assignee string) error {
+	if _, err := c.execGlabInRepo("issue", "update", strconv.Itoa(iid), "--assignee", "+"+assignee); err != nil {
+		return fmt.Errorf("failed to assign issue #%d: %w", iid, err)
+	}
+
+	return nil
+}
+
+// AddComment posts a comment (note) on an issue
+// Uses: glab issue note <iid> --message <body>
+func (c *Client) AddComment(iid int, body string) error {
+	if _, err := c.execGlabInRepo("issue", "note", strconv.Itoa(iid), "--message", body); err != nil {
+		return fmt.Errorf("failed to comment on issue #%d: %w", iid, err)
+	}
+
+	return nil
+}
+
+// AddLabels adds labels to an issue
+// Uses: glab issue update <iid> --label <label,...>
+func (c *Client) AddLabels(iid int, labels []string) error {
+	if _, err := c.execGlabInRepo("issue", "update", strconv.Itoa(iid), "--label", strings.Join(labels, ",")); err != nil {
+		return fmt.Errorf("failed to label issue #%d: %w", iid, err)
+	}
+
+	return nil
+}
+
 // SanitizedTitle returns sanitized title suitable for branch names
 func (i *Issue) SanitizedTitle() string {
 	title := i.Title
@@ -214,3 +214,32 @@ func TestIsIssueClosed(t *testing.T) {
 		t.Error("expected closed issue to return true for IsIssueClosed")
 	}
 }
+
+func TestCurrentMilestone(t *testing.T) {
+	fake := NewFakeGitLabExecutor()
+	fake.SetResponse("api projects/owner%2Fproject/milestones?state=active&per_page=100", `[
+  {"title": "Backlog"},
+  {"title": "Sprint 5", "start_date": "2024-06-10", "due_date": "2024-06-21"},
+  {"title": "Sprint 4", "start_date": "2024-05-27", "due_date": "2024-06-07"},
+  {"title": "Q3", "start_date": "2024-06-01", "due_date": "2024-09-30"}
+]`)
+
+	client := &Client{Owner: "owner", Project: "project", Host: "gitlab.com", executor: fake}
+
+	tests := []struct {
+		today string
+		want  string
+	}{
+		{"2024-06-03", "Sprint 4"},
+		{"2024-06-12", "Sprint 5"},
+		{"2024-07-01", "Q3"},
+		{"2024-12-01", "Backlog"},
+	}
+
+	for _, tt := range tests {
+		got, err := client.CurrentMilestone(tt.today)
+		if err != nil || got != tt.want {
+			t.Errorf("CurrentMilestone(%s) = %q, %v; want %q", tt.today, got, err, tt.want)
+		}
+	}
+}
@@ -26,6 +26,9 @@ type MergeRequest struct {
 	WorkInProgress bool     `json:"work_in_progress"` // GitLab's draft equivalent
 	ChangesCount   string   `json:"changes_count"`
 	UserNotesCount int      `json:"user_notes_count"`
+	// SourceProjectID and TargetProjectID differ for a merge request from a fork
+	SourceProjectID int `json:"source_project_id"`
+	TargetProjectID int `json:"target_project_id"`
 }
 
 // ListOpenMRs fetches open merge requests (up to limit)
@@ -81,6 +84,74 @@ func (c *Client) HasMergeConflicts(iid int) (bool, error) {
 	return mr.MergeStatus != "can_be_merged" && mr.MergeStatus != "can_be_merged_automerge", nil
 }
 
+// CreateMROptions describes a merge request for CreateMR
+type CreateMROptions struct {
+	Title        string
+	Description  string
+	SourceBranch string
+	TargetBranch string
+	// Reviewers are usernames
+	Reviewers []string
+	Labels    []string
+	Draft     bool
+}
+
+// CreateMR opens a merge request for a pushed branch
+// Uses: glab mr create --title <title> --description <description> --source-branch <branch> --target-branch <branch> --yes [--draft] [--reviewer a,b] [--label a,b]
+func (c *Client) CreateMR(opts CreateMROptions) (*MergeRequest, error) {
+	if opts.Title == "" {
+		return nil, fmt.Errorf("merge request title cannot be empty")
+	}
+
+	args := []string{"mr", "create",
+		"--title", opts.Title,
+		"--description", opts.Description,
+		"--source-branch", opts.SourceBranch,
+		"--target-branch", opts.TargetBranch,
+		"--yes"}
+	if opts.Draft {
+		args = append(args, "--draft")
+	}
+
+	if len(opts.Reviewers) > 0 {
+		args = append(args, "--reviewer", strings.Join(opts.Reviewers, ","))
+	}
+
+	if len(opts.Labels) > 0 {
+		args = append(args, "--label", strings.Join(opts.Labels, ","))
+	}
+
+	output, err := c.execGlabInRepo(args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create merge request for %s: %w", opts.SourceBranch, err)
+	}
+
+	// glab prints the new merge request's URL last
+	fields := strings.Fields(string(output))
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("glab mr create did not print the new merge request's URL")
+	}
+
+	webURL := fields[len(fields)-1]
+
+	iid, err := strconv.Atoi(webURL[strings.LastIndex(webURL, "/")+1:])
+	if err != nil || !strings.Contains(webURL, "/merge_requests/") {
+		return nil, fmt.Errorf("unexpected URL from glab mr create: %s", webURL)
+	}
+
+	return &MergeRequest{
+		IID:            iid,
+		Title:          opts.Title,
+		Description:    opts.Description,
+		State:          "opened",
+		SourceBranch:   opts.SourceBranch,
+		TargetBranch:   opts.TargetBranch,
+		Labels:         opts.Labels,
+		WebURL:         webURL,
+		WorkInProgress: opts.Draft,
+	}, nil
+}
+
 // GetMRDiff fetches the diff for a merge request
 // Uses: glab mr diff <iid>
 func (c *Client) GetMRDiff(iid int) (string, error) {
@@ -115,6 +186,29 @@ func (mr *MergeRequest) FormatForDisplay() string {
 	return fmt.Sprintf("!%d | %s | @%s%s", mr.IID, mr.Title, author, labels)
 }
 
+// IsFromFork reports whether the merge request's source branch is in a fork
+func (mr *MergeRequest) IsFromFork() bool {
+	return mr.SourceProjectID != 0 && mr.SourceProjectID != mr.TargetProjectID
+}
+
+// HeadRef returns the ref the project keeps the merge request's head under,
+// which is there even when the source branch is in a fork
+func (mr *MergeRequest) HeadRef() string {
+	return fmt.Sprintf("refs/merge-requests/%d/head", mr.IID)
+}
+
+// Head describes the merge request's head for checking it out. A fork's
+// branch is only known by the fork's project ID, so it is checked out from
+// the merge request's ref and can't be pushed back to.
+func (mr *MergeRequest) Head() git.PullRequestHead {
+	head := git.PullRequestHead{Number: mr.IID, Branch: mr.SourceBranch, Pushable: true, Ref: mr.HeadRef()}
+	if mr.IsFromFork() {
+		head.ForkOwner, head.Pushable = mr.Author.Username, false
+	}
+
+	return head
+}
+
 // BranchName generates the branch name for this MR
 // Format: mr/<iid>-<sanitized-title>
 func (mr *MergeRequest) BranchName() string {
@@ -48,6 +48,40 @@ func TestMRBranchName(t *testing.T) {
 	}
 }
 
+func TestMRIsFromFork(t *testing.T) {
+	tests := []struct {
+		name string
+		mr   MergeRequest
+		want bool
+	}{
+		{"same project", MergeRequest{SourceProjectID: 3, TargetProjectID: 3}, false},
+		{"fork", MergeRequest{SourceProjectID: 9, TargetProjectID: 3}, true},
+		{"unknown", MergeRequest{}, false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.mr.IsFromFork(); got != tt.want {
+			t.Errorf("%s: IsFromFork() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestMRHead(t *testing.T) {
+	mr := &MergeRequest{IID: 12, SourceBranch: "fix-login", SourceProjectID: 3, TargetProjectID: 3, Author: Author{Username: "alice"}}
+
+	head := mr.Head()
+	if head.Number != 12 || head.Branch != "fix-login" || !head.Pushable || head.IsFork() || head.HeadRef() != "refs/merge-requests/12/head" {
+		t.Errorf("Head() of a same-project MR = %+v", head)
+	}
+
+	mr.SourceProjectID = 9
+
+	head = mr.Head()
+	if !head.IsFork() || head.Pushable || head.HeadRef() != "refs/merge-requests/12/head" {
+		t.Errorf("Head() of a fork's MR = %+v, want a fork that can't be pushed to, fetched from the MR ref", head)
+	}
+}
+
 func TestMRFormatForDisplay(t *testing.T) {
 	tests := []struct {
 		mr       *MergeRequest
@@ -0,0 +1,172 @@
+package gitlab
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// Note is a comment on an issue or merge request
+type Note struct {
+	Body      string `json:"body"`
+	Author    Author `json:"author"`
+	CreatedAt string `json:"created_at"`
+	// System notes record events such as label changes rather than discussion
+	System bool `json:"system"`
+}
+
+// GetIssueNotes fetches the comments on an issue, oldest first, without system notes
+// Uses: glab api projects/<project>/issues/<iid>/notes
+func (c *Client) GetIssueNotes(iid int) ([]Note, error) {
+	notes, err := c.getNotes(fmt.Sprintf("issues/%d/notes", iid))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get comments on issue #%d: %w", iid, err)
+	}
+
+	return notes, nil
+}
+
+// GetMRNotes fetches the comments on a merge request, oldest first, without system notes
+// Uses: glab api projects/<project>/merge_requests/<iid>/notes
+func (c *Client) GetMRNotes(iid int) ([]Note, error) {
+	notes, err := c.getNotes(fmt.Sprintf("merge_requests/%d/notes", iid))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get comments on merge request !%d: %w", iid, err)
+	}
+
+	return notes, nil
+}
+
+// FindMRForBranch returns the open merge request from branch, or nil if there is none
+// Uses: glab api projects/<project>/merge_requests?source_branch=<branch>&state=opened
+func (c *Client) FindMRForBranch(branch string) (*MergeRequest, error) {
+	return c.findMRForBranch(branch, "state=opened&")
+}
+
+// LatestMRForBranch returns the most recent merge request from branch, whether
+// open, closed or merged, or nil if there is none
+// Uses: glab api projects/<project>/merge_requests?source_branch=<branch>
+func (c *Client) LatestMRForBranch(branch string) (*MergeRequest, error) {
+	return c.findMRForBranch(branch, "")
+}
+
+func (c *Client) findMRForBranch(branch, stateParam string) (*MergeRequest, error) {
+	output, err := c.execAPI("merge_requests?" + stateParam + "source_branch=" + url.QueryEscape(branch))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find merge request for branch %s: %w", branch, err)
+	}
+
+	var mrs []MergeRequest
+	if err := json.Unmarshal(output, &mrs); err != nil {
+		return nil, fmt.Errorf("failed to parse merge requests: %w", err)
+	}
+
+	if len(mrs) == 0 {
+		return nil, nil
+	}
+
+	return &mrs[0], nil
+}
+
+// getNotes reads a project notes endpoint and drops system notes
+func (c *Client) getNotes(endpoint string) ([]Note, error) {
+	output, err := c.execAPI(endpoint + "?sort=asc&order_by=created_at&per_page=100")
+	if err != nil {
+		return nil, err
+	}
+
+	var notes []Note
+	if err := json.Unmarshal(output, &notes); err != nil {
+		return nil, fmt.Errorf("failed to parse comments: %w", err)
+	}
+
+	comments := notes[:0]
+	for _, n := range notes {
+		if !n.System {
+			comments = append(comments, n)
+		}
+	}
+
+	return comments, nil
+}
+
+// execAPI calls the REST API for this project; glab api takes no -R flag, so the
+// project is part of the path
+func (c *Client) execAPI(endpoint string) ([]byte, error) {
+	args := []string{"api"}
+	if c.Host != "gitlab.com" {
+		args = append(args, "--hostname", c.Host)
+	}
+
+	project := url.PathEscape(c.Owner + "/" + c.Project)
+
+	return c.execGlab(append(args, "projects/"+project+"/"+endpoint)...)
+}
+
+// Discussion is a thread of notes on a merge request
+type Discussion struct {
+	ID    string           `json:"id"`
+	Notes []DiscussionNote `json:"notes"`
+}
+
+// DiscussionNote is a note in a discussion; diff notes carry the line they are on
+type DiscussionNote struct {
+	Note
+	Resolvable bool `json:"resolvable"`
+	Resolved   bool `json:"resolved"`
+	Position   *struct {
+		NewPath string `json:"new_path"`
+		NewLine int    `json:"new_line"`
+		OldPath string `json:"old_path"`
+		OldLine int    `json:"old_line"`
+	} `json:"position"`
+}
+
+// IsUnresolved reports whether the discussion still needs to be resolved
+func (d *Discussion) IsUnresolved() bool {
+	for _, n := range d.Notes {
+		if n.Resolvable && !n.Resolved {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Location returns the file and line the discussion is on, or "" and 0 for a general discussion
+func (d *Discussion) Location() (string, int) {
+	if len(d.Notes) == 0 || d.Notes[0].Position == nil {
+		return "", 0
+	}
+
+	p := d.Notes[0].Position
+	if p.NewPath != "" {
+		return p.NewPath, p.NewLine
+	}
+
+	return p.OldPath, p.OldLine
+}
+
+// GetUnresolvedMRDiscussions fetches the merge request's discussions that still need resolving
+// Uses: glab api projects/<project>/merge_requests/<iid>/discussions
+func (c *Client) GetUnresolvedMRDiscussions(iid int) ([]Discussion, error) {
+	output, err := c.execAPI(fmt.Sprintf("merge_requests/%d/discussions?per_page=100", iid))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get discussions on merge request !%d: %w", iid, err)
+	}
+
+	var discussions []Discussion
+	if err := json.Unmarshal(output, &discussions); err != nil {
+		return nil, fmt.Errorf("failed to parse discussions: %w", err)
+	}
+
+	var unresolved []Discussion
+
+	for i := range discussions {
+		if discussions[i].IsUnresolved() {
+			unresolved = append(unresolved, discussions[i])
+		}
+	}
+
+	return unresolved, nil
+}
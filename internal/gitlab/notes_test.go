@@ -0,0 +1,56 @@
+package gitlab
+
+import "testing"
+
+func TestGetUnresolvedMRDiscussions(t *testing.T) {
+	fake := NewFakeGitLabExecutor()
+	fake.SetResponse("api projects/owner%2Fproject/merge_requests/7/discussions?per_page=100", `[
+  {"id": "a", "notes": [{"body": "Handle the error", "author": {"username": "alice"}, "created_at": "2024-05-01T12:00:00Z",
+    "resolvable": true, "resolved": false, "position": {"new_path": "main.go", "new_line": 12, "old_path": "main.go", "old_line": 10}}]},
+  {"id": "b", "notes": [{"body": "Fixed", "author": {"username": "bob"}, "resolvable": true, "resolved": true}]},
+  {"id": "c", "notes": [{"body": "Thanks!", "author": {"username": "carol"}, "resolvable": false}]},
+  {"id": "d", "notes": [{"body": "Why?", "author": {"username": "dave"}, "resolvable": true, "resolved": false}]}
+]`)
+
+	client := &Client{Owner: "owner", Project: "project", Host: "gitlab.com", executor: fake}
+
+	discussions, err := client.GetUnresolvedMRDiscussions(7)
+	if err != nil {
+		t.Fatalf("GetUnresolvedMRDiscussions failed: %v", err)
+	}
+
+	if len(discussions) != 2 || discussions[0].ID != "a" || discussions[1].ID != "d" {
+		t.Fatalf("expected discussions a and d, got %+v", discussions)
+	}
+
+	if path, line := discussions[0].Location(); path != "main.go" || line != 12 {
+		t.Errorf("Location() = %s:%d, want main.go:12", path, line)
+	}
+
+	if path, line := discussions[1].Location(); path != "" || line != 0 {
+		t.Errorf("Location() of a general discussion = %s:%d, want none", path, line)
+	}
+
+	if author := discussions[0].Notes[0].Author.Username; author != "alice" {
+		t.Errorf("expected author alice, got %q", author)
+	}
+}
+
+func TestLatestMRForBranch(t *testing.T) {
+	fake := NewFakeGitLabExecutor()
+	fake.SetResponse("api projects/owner%2Fproject/merge_requests?source_branch=work%2F42-fix",
+		`[{"iid": 12, "state": "merged", "source_branch": "work/42-fix"}]`)
+	fake.SetResponse("api projects/owner%2Fproject/merge_requests?state=opened&source_branch=work%2F42-fix", `[]`)
+
+	client := &Client{Owner: "owner", Project: "project", Host: "gitlab.com", executor: fake}
+
+	mr, err := client.LatestMRForBranch("work/42-fix")
+	if err != nil || mr == nil || mr.IID != 12 || mr.State != "merged" {
+		t.Fatalf("LatestMRForBranch() = %+v, %v; want merged !12", mr, err)
+	}
+
+	mr, err = client.FindMRForBranch("work/42-fix")
+	if err != nil || mr != nil {
+		t.Errorf("FindMRForBranch() = %+v, %v; want no open merge request", mr, err)
+	}
+}
@@ -0,0 +1,130 @@
+// Package history records fully-resolved command invocations so they can be repeated.
+package history
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kaeawc/auto-worktree/internal/state"
+)
+
+// MaxEntries is the number of invocations kept in the history
+const MaxEntries = 100
+
+// entriesKey is the state database key holding the history list
+const entriesKey = "entries"
+
+// Entry is a single resolved invocation, e.g. "issue 42" after picking #42 from the selector
+type Entry struct {
+	Args      []string  `json:"args"`
+	RepoPath  string    `json:"repoPath"`
+	Summary   string    `json:"summary,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Command returns the invocation as it would be typed on the command line
+func (e Entry) Command() string {
+	return "auto-worktree " + strings.Join(e.Args, " ")
+}
+
+// sameInvocation reports whether two entries would run the same command in the same repository
+func (e Entry) sameInvocation(other Entry) bool {
+	return e.RepoPath == other.RepoPath && strings.Join(e.Args, "\x00") == strings.Join(other.Args, "\x00")
+}
+
+// Store reads and writes the history in the state database
+type Store struct {
+	db *state.Store
+	mu sync.Mutex
+}
+
+// NewStore creates a history store backed by the state database at path
+func NewStore(path string) *Store {
+	return &Store{db: state.NewStore(path)}
+}
+
+// Record adds an entry as the most recent invocation.
+// An earlier identical invocation is moved to the top instead of being duplicated.
+func (s *Store) Record(entry Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(entry.Args) == 0 {
+		return fmt.Errorf("history entry has no arguments")
+	}
+
+	if entry.Timestamp.IsZero() {
+		entry.Timestamp = time.Now()
+	}
+
+	entries, err := s.load()
+	if err != nil {
+		return err
+	}
+
+	updated := make([]Entry, 0, len(entries)+1)
+	updated = append(updated, entry)
+
+	for _, e := range entries {
+		if !e.sameInvocation(entry) {
+			updated = append(updated, e)
+		}
+	}
+
+	if len(updated) > MaxEntries {
+		updated = updated[:MaxEntries]
+	}
+
+	return s.save(updated)
+}
+
+// Load returns all entries, most recent first
+func (s *Store) Load() ([]Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.load()
+}
+
+// ForRepo returns the entries recorded in the given repository, most recent first
+func (s *Store) ForRepo(repoPath string) ([]Entry, error) {
+	entries, err := s.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	var result []Entry
+
+	for _, e := range entries {
+		if e.RepoPath == repoPath {
+			result = append(result, e)
+		}
+	}
+
+	return result, nil
+}
+
+func (s *Store) load() ([]Entry, error) {
+	var entries []Entry
+
+	if err := s.db.Get(state.BucketHistory, entriesKey, &entries); err != nil {
+		if errors.Is(err, state.ErrNotFound) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to read history: %w", err)
+	}
+
+	return entries, nil
+}
+
+func (s *Store) save(entries []Entry) error {
+	if err := s.db.Put(state.BucketHistory, entriesKey, entries); err != nil {
+		return fmt.Errorf("failed to save history: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package history
+
+import (
+	"path/filepath"
+	"strconv"
+	"testing"
+)
+
+func TestStoreRecordAndLoad(t *testing.T) {
+	store := NewStore(filepath.Join(t.TempDir(), "state.db"))
+
+	entries, err := store.Load()
+	if err != nil {
+		t.Fatalf("Load() on missing file error = %v", err)
+	}
+
+	if len(entries) != 0 {
+		t.Fatalf("Load() on missing file returned %d entries", len(entries))
+	}
+
+	for _, args := range [][]string{{"issue", "42"}, {"pr", "7"}, {"issue", "42"}} {
+		if err := store.Record(Entry{Args: args, RepoPath: "/repo"}); err != nil {
+			t.Fatalf("Record(%v) error = %v", args, err)
+		}
+	}
+
+	entries, err = store.Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	// The repeated "issue 42" moves to the top rather than being duplicated
+	if len(entries) != 2 {
+		t.Fatalf("Load() returned %d entries, want 2", len(entries))
+	}
+
+	if got := entries[0].Command(); got != "auto-worktree issue 42" {
+		t.Errorf("entries[0].Command() = %q, want %q", got, "auto-worktree issue 42")
+	}
+
+	if entries[0].Timestamp.IsZero() {
+		t.Error("Record() did not set a timestamp")
+	}
+}
+
+func TestStoreForRepoAndCap(t *testing.T) {
+	store := NewStore(filepath.Join(t.TempDir(), "nested", "state.db"))
+
+	for i := 0; i < MaxEntries+5; i++ {
+		repo := "/repo-a"
+		if i%2 == 1 {
+			repo = "/repo-b"
+		}
+
+		if err := store.Record(Entry{Args: []string{"pr", strconv.Itoa(i)}, RepoPath: repo}); err != nil {
+			t.Fatalf("Record() error = %v", err)
+		}
+	}
+
+	all, err := store.Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if len(all) != MaxEntries {
+		t.Errorf("Load() returned %d entries, want %d", len(all), MaxEntries)
+	}
+
+	repoB, err := store.ForRepo("/repo-b")
+	if err != nil {
+		t.Fatalf("ForRepo() error = %v", err)
+	}
+
+	for _, e := range repoB {
+		if e.RepoPath != "/repo-b" {
+			t.Errorf("ForRepo() returned entry for %s", e.RepoPath)
+		}
+	}
+
+	if err := store.Record(Entry{}); err == nil {
+		t.Error("Record() with no args expected error")
+	}
+}
@@ -2,7 +2,6 @@
 package hooks
 
 import (
-	"context"
 	"fmt"
 	"os"
 	"os/exec"
@@ -10,6 +9,8 @@ import (
 	"strings"
 
 	"github.com/kaeawc/auto-worktree/internal/git"
+	"github.com/kaeawc/auto-worktree/internal/interrupt"
+	"github.com/kaeawc/auto-worktree/internal/redact"
 )
 
 // Runner executes git hooks in a worktree
@@ -163,13 +164,18 @@ func (r *Runner) executeHook(hookPath string) hookResult {
 	branchFlag := "1" // 1 = branch checkout
 
 	// Execute hook
-	cmd := exec.CommandContext(context.Background(), hookPath, prevHead, newHead, branchFlag)
+	cmd := exec.CommandContext(interrupt.Context(), hookPath, prevHead, newHead, branchFlag)
 	cmd.Dir = r.worktreePath
 	cmd.Env = r.prepareHookEnvironment()
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
+	// Hooks often print environment or config, so scrub tokens from what they show
+	output := redact.NewWriter(os.Stdout)
+	cmd.Stdout = output
+	cmd.Stderr = output
 
-	if err := cmd.Run(); err != nil {
+	err = cmd.Run()
+	_ = output.Close() //nolint:errcheck // a failed write to stdout is not actionable
+
+	if err != nil {
 		return hookFailed
 	}
 
@@ -0,0 +1,112 @@
+// Package interrupt turns Ctrl-C into cancellation. Commands auto-worktree
+// runs derive their context from Context, so the first Ctrl-C stops the git,
+// gh and install commands in flight and lets the operation undo what it had
+// started; a second Ctrl-C quits at once.
+package interrupt
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+)
+
+// ErrInterrupted is the cause of Context's cancellation
+var ErrInterrupted = errors.New("interrupted")
+
+// ExitCode is the exit status after an interrupt, as shells report for SIGINT
+const ExitCode = 130
+
+var (
+	mu     sync.Mutex
+	ctx                            = context.Background()
+	cancel context.CancelCauseFunc = func(error) {}
+	// foreground counts interactive commands running attached to the
+	// terminal; Ctrl-C is theirs while they run
+	foreground int
+)
+
+// Watch starts turning Ctrl-C and SIGTERM into cancellation of Context, and
+// returns the function that stops it. main calls it once before running a command.
+func Watch() func() {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+
+	mu.Lock()
+	ctx, cancel = context.WithCancelCause(context.Background())
+	mu.Unlock()
+
+	done := make(chan struct{})
+
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			case <-signals:
+				handleSignal()
+			}
+		}
+	}()
+
+	return func() {
+		signal.Stop(signals)
+		close(done)
+	}
+}
+
+// handleSignal cancels Context on the first signal and exits on the next
+func handleSignal() {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if foreground > 0 {
+		return
+	}
+
+	if ctx.Err() != nil {
+		os.Exit(ExitCode)
+	}
+
+	cancel(ErrInterrupted)
+}
+
+// Context is canceled when the user presses Ctrl-C; without Watch it never is
+func Context() context.Context {
+	mu.Lock()
+	defer mu.Unlock()
+
+	return ctx
+}
+
+// Cancel cancels Context as Ctrl-C would, for full-screen UIs that read
+// Ctrl-C as a key instead of receiving the signal
+func Cancel() {
+	mu.Lock()
+	defer mu.Unlock()
+
+	cancel(ErrInterrupted)
+}
+
+// Interrupted reports whether the user pressed Ctrl-C
+func Interrupted() bool {
+	return errors.Is(context.Cause(Context()), ErrInterrupted)
+}
+
+// Foreground runs an interactive command attached to the terminal, such as
+// the AI tool, during which Ctrl-C goes to the command alone
+func Foreground(run func() error) error {
+	mu.Lock()
+	foreground++
+	mu.Unlock()
+
+	defer func() {
+		mu.Lock()
+		foreground--
+		mu.Unlock()
+	}()
+
+	return run()
+}
@@ -0,0 +1,38 @@
+package interrupt
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestWatch(t *testing.T) {
+	stop := Watch()
+	defer stop()
+
+	if Interrupted() || Context().Err() != nil {
+		t.Fatal("Context() is canceled before any Ctrl-C")
+	}
+
+	// Ctrl-C while an interactive command runs is for that command
+	if err := Foreground(func() error { handleSignal(); return nil }); err != nil {
+		t.Fatal(err)
+	}
+
+	if Interrupted() {
+		t.Fatal("Ctrl-C during Foreground() canceled Context()")
+	}
+
+	handleSignal()
+
+	if Context().Err() == nil || !Interrupted() {
+		t.Error("Ctrl-C did not cancel Context()")
+	}
+}
+
+func TestForegroundReturnsError(t *testing.T) {
+	want := errors.New("exit status 1")
+
+	if err := Foreground(func() error { return want }); !errors.Is(err, want) {
+		t.Errorf("Foreground() = %v, want %v", err, want)
+	}
+}
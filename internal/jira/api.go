@@ -0,0 +1,414 @@
+package jira
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kaeawc/auto-worktree/internal/limits"
+	"github.com/kaeawc/auto-worktree/internal/logging"
+	"github.com/kaeawc/auto-worktree/internal/retry"
+)
+
+// ErrAPIUnsupported is returned for jira commands the REST executor cannot answer
+var ErrAPIUnsupported = errors.New("not available with the JIRA REST API")
+
+// ErrJiraUnauthorized is returned when JIRA rejects the API token
+var ErrJiraUnauthorized = errors.New("JIRA rejected the API token")
+
+// errNotFound marks a 404 response, so a missing endpoint can fall back to an older one
+var errNotFound = errors.New("not found")
+
+// issueFields are the fields requested when listing issues, matching Issue
+var issueFields = []string{
+	"summary", "description", "status", "resolution", "issuetype",
+	"assignee", "creator", "created", "updated", "labels", "priority",
+}
+
+// defaultIssueType is the type of issues created through the REST API
+const defaultIssueType = "Task"
+
+// TokenFromEnv returns the JIRA API token from JIRA_API_TOKEN (the variable jira-cli reads), if set
+func TokenFromEnv() string {
+	return os.Getenv("JIRA_API_TOKEN")
+}
+
+// APIExecutor answers the jira-cli commands Client runs by calling the JIRA
+// REST API, so JIRA works with a server URL and API token alone: no jira-cli
+// and no interactive jira init. With an email it authenticates as JIRA Cloud
+// does (email and API token); without one it sends the token as a personal
+// access token, as JIRA Server and Data Center expect.
+type APIExecutor struct {
+	Server string
+	Email  string
+	Token  string
+	HTTP   *http.Client
+
+	userOnce sync.Once
+	user     apiUser
+	userErr  error
+}
+
+// apiUser is the signed-in user; Cloud identifies users by account ID, Server by name
+type apiUser struct {
+	AccountID    string `json:"accountId"`
+	Name         string `json:"name"`
+	EmailAddress string `json:"emailAddress"`
+}
+
+// NewAPIExecutor creates an executor for the JIRA server at server
+func NewAPIExecutor(server, email, token string) *APIExecutor {
+	return &APIExecutor{Server: strings.TrimSuffix(server, "/"), Email: email, Token: token, HTTP: &http.Client{}}
+}
+
+// Execute answers a jira command, e.g. "issue view PROJ-1 --json"
+func (e *APIExecutor) Execute(ctx context.Context, args ...string) (string, error) {
+	positional, flags := parseJiraArgs(args)
+	command := strings.Join(positional[:min(2, len(positional))], " ")
+
+	var (
+		output string
+		err    error
+	)
+
+	switch {
+	case command == "me":
+		output, err = e.me(ctx)
+	case command == "issue list":
+		output, err = e.search(ctx, last(flags["--jql"]))
+	case command == "issue view" && len(positional) == 3:
+		output, err = e.issue(ctx, positional[2])
+	case command == "issue create":
+		output, err = e.create(ctx, flags)
+	case command == "issue assign" && len(positional) == 4:
+		output, err = e.assign(ctx, positional[2], positional[3])
+	case command == "issue edit" && len(positional) == 3:
+		output, err = e.addLabels(ctx, positional[2], flags["--label"])
+	case command == "issue comment" && len(positional) == 5 && positional[2] == "add":
+		output, err = e.do(ctx, http.MethodPost, "/issue/"+url.PathEscape(positional[3])+"/comment",
+			map[string]string{"body": positional[4]})
+	default:
+		return "", fmt.Errorf("jira %s: %w", strings.Join(args, " "), ErrAPIUnsupported)
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("jira %s failed: %w", strings.Join(args, " "), err)
+	}
+
+	return strings.TrimSpace(output), nil
+}
+
+// parseJiraArgs splits jira arguments into positional words and flags; --json
+// and --no-input are switches and repeated flags (--label) keep every value
+func parseJiraArgs(args []string) ([]string, map[string][]string) {
+	var positional []string
+
+	flags := map[string][]string{}
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+
+		switch {
+		case !strings.HasPrefix(arg, "-"):
+			positional = append(positional, arg)
+		case arg == "--json" || arg == "--no-input" || i+1 >= len(args):
+			flags[arg] = append(flags[arg], "true")
+		default:
+			flags[arg] = append(flags[arg], args[i+1])
+			i++
+		}
+	}
+
+	return positional, flags
+}
+
+// me returns the signed-in user's login: email on Cloud (or account ID when
+// the email is private), user name on Server
+func (e *APIExecutor) me(ctx context.Context) (string, error) {
+	user, err := e.currentUser(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	switch {
+	case user.EmailAddress != "":
+		return user.EmailAddress, nil
+	case user.Name != "":
+		return user.Name, nil
+	default:
+		return user.AccountID, nil
+	}
+}
+
+func (e *APIExecutor) currentUser(ctx context.Context) (apiUser, error) {
+	e.userOnce.Do(func() {
+		var output string
+
+		output, e.userErr = e.do(ctx, http.MethodGet, "/myself", nil)
+		if e.userErr == nil {
+			if err := json.Unmarshal([]byte(output), &e.user); err != nil {
+				e.userErr = fmt.Errorf("failed to parse user: %w", err)
+			}
+		}
+	})
+
+	return e.user, e.userErr
+}
+
+// search lists the issues matching jql, as jira issue list --json does. JIRA
+// Cloud serves search at /search/jql; Server and Data Center only have /search.
+func (e *APIExecutor) search(ctx context.Context, jql string) (string, error) {
+	request := map[string]any{"jql": jql, "maxResults": 100, "fields": issueFields}
+
+	output, err := e.do(ctx, http.MethodPost, "/search/jql", request)
+	if errors.Is(err, errNotFound) {
+		output, err = e.do(ctx, http.MethodPost, "/search", request)
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	var result struct {
+		Issues []map[string]any `json:"issues"`
+	}
+
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		return "", fmt.Errorf("failed to parse search results: %w", err)
+	}
+
+	for _, issue := range result.Issues {
+		e.addBrowseURL(issue)
+	}
+
+	return marshal(result.Issues)
+}
+
+// issue returns one issue, as jira issue view --json does
+func (e *APIExecutor) issue(ctx context.Context, key string) (string, error) {
+	output, err := e.do(ctx, http.MethodGet, "/issue/"+url.PathEscape(key), nil)
+	if err != nil {
+		return "", err
+	}
+
+	var issue map[string]any
+	if err := json.Unmarshal([]byte(output), &issue); err != nil {
+		return "", fmt.Errorf("failed to parse issue: %w", err)
+	}
+
+	e.addBrowseURL(issue)
+
+	return marshal(issue)
+}
+
+// addBrowseURL sets fields.url to the issue's page, which the API does not return
+func (e *APIExecutor) addBrowseURL(issue map[string]any) {
+	key, _ := issue["key"].(string)
+	if fields, ok := issue["fields"].(map[string]any); ok && key != "" {
+		fields["url"] = e.Server + "/browse/" + key
+	}
+}
+
+// create creates an issue and returns it as jira issue view --json would
+func (e *APIExecutor) create(ctx context.Context, flags map[string][]string) (string, error) {
+	project := last(flags["--project"])
+	if project == "" {
+		return "", fmt.Errorf("a project is required to create issues (set auto-worktree.jira-project)")
+	}
+
+	fields := map[string]any{
+		"project":   map[string]string{"key": project},
+		"summary":   last(flags["--summary"]),
+		"issuetype": map[string]string{"name": defaultIssueType},
+	}
+
+	if description := last(flags["--description"]); description != "" {
+		fields["description"] = description
+	}
+
+	output, err := e.do(ctx, http.MethodPost, "/issue", map[string]any{"fields": fields})
+	if err != nil {
+		return "", err
+	}
+
+	var created struct {
+		Key string `json:"key"`
+	}
+
+	if err := json.Unmarshal([]byte(output), &created); err != nil {
+		return "", fmt.Errorf("failed to parse created issue: %w", err)
+	}
+
+	return e.issue(ctx, created.Key)
+}
+
+// assign assigns an issue. Cloud assigns by account ID, found by searching for
+// the login; Server assigns by user name.
+func (e *APIExecutor) assign(ctx context.Context, key, assignee string) (string, error) {
+	me, err := e.currentUser(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	body := map[string]string{"name": assignee}
+
+	if me.AccountID != "" {
+		accountID := me.AccountID
+
+		if assignee != me.AccountID && assignee != me.EmailAddress {
+			if accountID, err = e.findAccountID(ctx, assignee); err != nil {
+				return "", err
+			}
+		}
+
+		body = map[string]string{"accountId": accountID}
+	}
+
+	return e.do(ctx, http.MethodPut, "/issue/"+url.PathEscape(key)+"/assignee", body)
+}
+
+// findAccountID looks up a Cloud user's account ID by email or name
+func (e *APIExecutor) findAccountID(ctx context.Context, query string) (string, error) {
+	output, err := e.do(ctx, http.MethodGet, "/user/search?query="+url.QueryEscape(query), nil)
+	if err != nil {
+		return "", err
+	}
+
+	var users []apiUser
+	if err := json.Unmarshal([]byte(output), &users); err != nil {
+		return "", fmt.Errorf("failed to parse users: %w", err)
+	}
+
+	if len(users) == 0 {
+		return "", fmt.Errorf("no JIRA user matches %s", query)
+	}
+
+	return users[0].AccountID, nil
+}
+
+// addLabels adds labels to an issue, keeping the ones it has
+func (e *APIExecutor) addLabels(ctx context.Context, key string, labels []string) (string, error) {
+	adds := make([]map[string]string, 0, len(labels))
+	for _, label := range labels {
+		adds = append(adds, map[string]string{"add": label})
+	}
+
+	return e.do(ctx, http.MethodPut, "/issue/"+url.PathEscape(key),
+		map[string]any{"update": map[string]any{"labels": adds}})
+}
+
+// do calls the REST API under the jira timeout, retrying transient failures and
+// rate limits; payload is sent as JSON when it is not nil
+func (e *APIExecutor) do(ctx context.Context, method, path string, payload any) (string, error) {
+	if e.Server == "" {
+		return "", fmt.Errorf("no JIRA server: set auto-worktree.jira-server")
+	}
+
+	var body []byte
+
+	if payload != nil {
+		var err error
+		if body, err = json.Marshal(payload); err != nil {
+			return "", fmt.Errorf("failed to encode request: %w", err)
+		}
+	}
+
+	target := e.Server + "/rest/api/2" + path
+
+	output, err := retry.DefaultPolicy.Run("JIRA", func() ([]byte, error) {
+		ctx, cancel := limits.Context(ctx, limits.ToolJira)
+		defer cancel()
+
+		req, err := http.NewRequestWithContext(ctx, method, target, bytes.NewReader(body))
+		if err != nil {
+			return nil, fmt.Errorf("failed to build request: %w", err)
+		}
+
+		if e.Email != "" {
+			req.SetBasicAuth(e.Email, e.Token)
+		} else {
+			req.Header.Set("Authorization", "Bearer "+e.Token)
+		}
+
+		req.Header.Set("Accept", "application/json")
+
+		if body != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+
+		start := time.Now()
+		resp, err := e.HTTP.Do(req)
+		logging.Debug("jira api", "method", method, "url", target, "duration", time.Since(start), "err", err)
+
+		if err != nil {
+			return nil, limits.Wrap(ctx, limits.ToolJira, err)
+		}
+		defer resp.Body.Close()
+
+		output, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, limits.Wrap(ctx, limits.ToolJira, fmt.Errorf("failed to read response: %w", err))
+		}
+
+		switch {
+		case resp.StatusCode == http.StatusNotFound:
+			return output, fmt.Errorf("HTTP 404: %s: %w", apiMessage(output), errNotFound)
+		case resp.StatusCode == http.StatusUnauthorized:
+			return output, fmt.Errorf("HTTP 401: %w: check JIRA_API_TOKEN (and, for JIRA Cloud, auto-worktree.jira-email)",
+				ErrJiraUnauthorized)
+		case resp.StatusCode >= http.StatusMultipleChoices:
+			return output, fmt.Errorf("HTTP %d: %s", resp.StatusCode, apiMessage(output))
+		}
+
+		return output, nil
+	})
+
+	return string(output), err
+}
+
+// apiMessage extracts the messages of a JIRA error response
+func apiMessage(body []byte) string {
+	var apiErr struct {
+		ErrorMessages []string          `json:"errorMessages"`
+		Errors        map[string]string `json:"errors"`
+	}
+
+	if json.Unmarshal(body, &apiErr) == nil {
+		messages := apiErr.ErrorMessages
+		for field, message := range apiErr.Errors {
+			messages = append(messages, field+": "+message)
+		}
+
+		if len(messages) > 0 {
+			return strings.Join(messages, "; ")
+		}
+	}
+
+	return strings.TrimSpace(string(body))
+}
+
+func last(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+
+	return values[len(values)-1]
+}
+
+func marshal(v any) (string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode output: %w", err)
+	}
+
+	return string(data), nil
+}
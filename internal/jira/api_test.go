@@ -0,0 +1,153 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kaeawc/auto-worktree/internal/retry"
+)
+
+// newTestAPIClient serves the REST API from handler and returns a client that calls it
+func newTestAPIClient(t *testing.T, email string, handler http.HandlerFunc) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	policy := retry.DefaultPolicy
+	retry.DefaultPolicy = retry.Policy{Attempts: 1}
+
+	t.Cleanup(func() { retry.DefaultPolicy = policy })
+
+	client, err := NewAPIClient(server.URL, "PROJ", email, "test-token")
+	if err != nil {
+		t.Fatalf("NewAPIClient() error = %v", err)
+	}
+
+	return client
+}
+
+func TestAPIExecutor_ListIssuesByJQL(t *testing.T) {
+	var paths []string
+
+	client := newTestAPIClient(t, "dev@example.com", func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+
+		if user, token, ok := r.BasicAuth(); !ok || user != "dev@example.com" || token != "test-token" {
+			t.Errorf("basic auth = %q, %q, %t", user, token, ok)
+		}
+
+		// A Server instance has no /search/jql
+		if r.URL.Path == "/rest/api/2/search/jql" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		var req struct {
+			JQL string `json:"jql"`
+		}
+
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.JQL != "project = PROJ AND labels = \"ui\"" {
+			t.Errorf("jql = %q (%v)", req.JQL, err)
+		}
+
+		io.WriteString(w, `{"issues":[{"key":"PROJ-1","fields":{"summary":"Fix login","status":{"name":"To Do"},"labels":["ui"]}}]}`)
+	})
+
+	issues, err := client.ListIssuesByJQL(context.Background(), `labels = "ui"`)
+	if err != nil {
+		t.Fatalf("ListIssuesByJQL() error = %v", err)
+	}
+
+	if len(issues) != 1 || issues[0].Key != "PROJ-1" || issues[0].Fields.Summary != "Fix login" {
+		t.Fatalf("ListIssuesByJQL() = %+v", issues)
+	}
+
+	if want := client.Server + "/browse/PROJ-1"; issues[0].Fields.URL != want {
+		t.Errorf("URL = %q, want %q", issues[0].Fields.URL, want)
+	}
+
+	if len(paths) != 2 || paths[1] != "/rest/api/2/search" {
+		t.Errorf("paths = %v, want a fallback from /search/jql to /search", paths)
+	}
+}
+
+func TestAPIExecutor_CreateIssue(t *testing.T) {
+	client := newTestAPIClient(t, "", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			t.Errorf("Authorization = %q", r.Header.Get("Authorization"))
+		}
+
+		switch r.Method {
+		case http.MethodPost:
+			var req struct {
+				Fields struct {
+					Project   struct{ Key string }  `json:"project"`
+					Summary   string                `json:"summary"`
+					IssueType struct{ Name string } `json:"issuetype"`
+				} `json:"fields"`
+			}
+
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Fields.Project.Key != "PROJ" || req.Fields.IssueType.Name != "Task" {
+				t.Errorf("create request = %+v (%v)", req, err)
+			}
+
+			io.WriteString(w, `{"id":"10001","key":"PROJ-9"}`)
+		default:
+			io.WriteString(w, `{"key":"PROJ-9","fields":{"summary":"New","description":"Details"}}`)
+		}
+	})
+
+	issue, err := client.CreateIssue(context.Background(), "New", "Details")
+	if err != nil {
+		t.Fatalf("CreateIssue() error = %v", err)
+	}
+
+	if issue.Key != "PROJ-9" || issue.Fields.Description != "Details" {
+		t.Errorf("CreateIssue() = %+v", issue)
+	}
+}
+
+func TestAPIExecutor_AssignIssueCloud(t *testing.T) {
+	var assigned string
+
+	client := newTestAPIClient(t, "dev@example.com", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/api/2/myself":
+			io.WriteString(w, `{"accountId":"me-123","emailAddress":"dev@example.com"}`)
+		case "/rest/api/2/user/search":
+			io.WriteString(w, `[{"accountId":"alice-456"}]`)
+		case "/rest/api/2/issue/PROJ-1/assignee":
+			body, _ := io.ReadAll(r.Body)
+			assigned = string(body)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+
+	if err := client.AssignIssue(context.Background(), "PROJ-1", "alice@example.com"); err != nil {
+		t.Fatalf("AssignIssue() error = %v", err)
+	}
+
+	if assigned != `{"accountId":"alice-456"}` {
+		t.Errorf("assignee request = %s", assigned)
+	}
+}
+
+func TestAPIExecutor_Errors(t *testing.T) {
+	client := newTestAPIClient(t, "", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+
+	if _, err := client.GetIssue(context.Background(), "PROJ-1"); !errors.Is(err, ErrJiraUnauthorized) {
+		t.Errorf("GetIssue() error = %v, want ErrJiraUnauthorized", err)
+	}
+
+	if _, err := NewAPIClient("", "PROJ", "", "token"); err == nil {
+		t.Error("NewAPIClient() without a server = nil error")
+	}
+}
@@ -1,4 +1,5 @@
-// Package jira provides JIRA issue provider implementation using jira-cli.
+// Package jira provides JIRA issue provider implementation using jira-cli, or
+// the JIRA REST API when an API token is available.
 package jira
 
 import (
@@ -6,8 +7,12 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"github.com/kaeawc/auto-worktree/internal/limits"
 	"os/exec"
+	"strings"
 	"time"
+
+	"github.com/kaeawc/auto-worktree/internal/logging"
 )
 
 var (
@@ -45,24 +50,38 @@ func NewClientWithExecutor(server, project string, executor Executor) (*Client,
 	}, nil
 }
 
+// NewAPIClient creates a JIRA client that calls the REST API of server with an
+// API token; email is the login for JIRA Cloud and empty for a personal access token
+func NewAPIClient(server, project, email, token string) (*Client, error) {
+	if server == "" {
+		return nil, fmt.Errorf("%w: set auto-worktree.jira-server to use an API token", ErrJiraNotConfigured)
+	}
+
+	return NewClientWithExecutor(server, project, NewAPIExecutor(server, email, token))
+}
+
 // IsInstalled checks if jira CLI is installed
-func IsInstalled() bool {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func IsInstalled(ctx context.Context) bool {
+	ctx, cancel := limits.Context(ctx, limits.ToolJira)
 	defer cancel()
 
 	cmd := exec.CommandContext(ctx, "jira", "version")
+	start := time.Now()
 	err := cmd.Run()
+	logging.Command(cmd, start, nil, err)
 
 	return err == nil
 }
 
 // IsConfigured checks if jira CLI is configured
-func IsConfigured() error {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func IsConfigured(ctx context.Context) error {
+	ctx, cancel := limits.Context(ctx, limits.ToolJira)
 	defer cancel()
 
 	cmd := exec.CommandContext(ctx, "jira", "config")
+	start := time.Now()
 	err := cmd.Run()
+	logging.Command(cmd, start, nil, err)
 
 	if err != nil {
 		return ErrJiraNotConfigured
@@ -79,7 +98,11 @@ func (c *Client) exec(ctx context.Context, args ...string) (string, error) {
 // ListOpenIssues returns open issues assigned to the current user
 // Uses JQL: assignee = currentUser() AND status != Done
 func (c *Client) ListOpenIssues(ctx context.Context) ([]Issue, error) {
-	jql := "assignee = currentUser() AND status != Done"
+	return c.ListIssuesByJQL(ctx, "assignee = currentUser() AND status != Done")
+}
+
+// ListIssuesByJQL returns issues matching a JQL query, scoped to the configured project
+func (c *Client) ListIssuesByJQL(ctx context.Context, jql string) ([]Issue, error) {
 	if c.Project != "" {
 		jql = fmt.Sprintf("project = %s AND %s", c.Project, jql)
 	}
@@ -167,3 +190,51 @@ func (c *Client) CreateIssue(ctx context.Context, title, body string) (*Issue, e
 
 	return &issue, nil
 }
+
+// GetCurrentUser returns the login of the authenticated JIRA user
+// Uses: jira me
+func (c *Client) GetCurrentUser(ctx context.Context) (string, error) {
+	output, err := c.exec(ctx, "me")
+	if err != nil {
+		return "", fmt.Errorf("failed to get current user: %w", err)
+	}
+
+	return strings.TrimSpace(output), nil
+}
+
+// AssignIssue assigns a JIRA issue to a user
+// Uses: jira issue assign <key> <assignee>
+func (c *Client) AssignIssue(ctx context.Context, key, assignee string) error {
+	if _, err := c.exec(ctx, "issue", "assign", key, assignee); err != nil {
+		return fmt.Errorf("failed to assign issue %s: %w", key, err)
+	}
+
+	return nil
+}
+
+// AddLabels adds labels to a JIRA issue
+// Uses: jira issue edit <key> --label <label> ... --no-input
+func (c *Client) AddLabels(ctx context.Context, key string, labels []string) error {
+	args := []string{"issue", "edit", key}
+	for _, label := range labels {
+		args = append(args, "--label", label)
+	}
+
+	args = append(args, "--no-input")
+
+	if _, err := c.exec(ctx, args...); err != nil {
+		return fmt.Errorf("failed to label issue %s: %w", key, err)
+	}
+
+	return nil
+}
+
+// AddComment posts a comment on a JIRA issue
+// Uses: jira issue comment add <key> <body> --no-input
+func (c *Client) AddComment(ctx context.Context, key, body string) error {
+	if _, err := c.exec(ctx, "issue", "comment", "add", key, body, "--no-input"); err != nil {
+		return fmt.Errorf("failed to comment on issue %s: %w", key, err)
+	}
+
+	return nil
+}
@@ -2,9 +2,15 @@ package jira
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os/exec"
 	"strings"
+	"time"
+
+	"github.com/kaeawc/auto-worktree/internal/limits"
+	"github.com/kaeawc/auto-worktree/internal/logging"
+	"github.com/kaeawc/auto-worktree/internal/offline"
 )
 
 // Executor handles JIRA CLI command execution
@@ -22,12 +28,26 @@ func NewCLIExecutor() *CLIExecutor {
 
 // Execute runs a jira CLI command and returns its output
 func (e *CLIExecutor) Execute(ctx context.Context, args ...string) (string, error) {
+	if offline.Enabled() {
+		return "", offline.Skipped("JIRA")
+	}
+
+	ctx, cancel := limits.Context(ctx, limits.ToolJira)
+	defer cancel()
+
 	cmd := exec.CommandContext(ctx, "jira", args...)
+	start := time.Now()
 	output, err := cmd.Output()
+	logging.Command(cmd, start, output, err)
+
+	if err := limits.Wrap(ctx, limits.ToolJira, err); errors.Is(err, limits.ErrTimeout) {
+		return "", err
+	}
 
 	if err != nil {
 		if exitErr, ok := err.(*exec.ExitError); ok {
 			stderr := string(exitErr.Stderr)
+			offline.Detect("JIRA", exitErr.Stderr, err)
 
 			return "", fmt.Errorf("jira command failed: %s", stderr)
 		}
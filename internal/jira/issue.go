@@ -19,6 +19,12 @@ type Issue struct {
 		Resolution struct {
 			Name string `json:"name"`
 		} `json:"resolution"`
+		IssueType struct {
+			Name string `json:"name"`
+		} `json:"issuetype"`
+		Priority struct {
+			Name string `json:"name"`
+		} `json:"priority"`
 		Assignee struct {
 			DisplayName string `json:"displayName"`
 		} `json:"assignee"`
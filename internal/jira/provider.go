@@ -26,6 +26,18 @@ func NewProvider(server, project string) (*Provider, error) {
 	}, nil
 }
 
+// NewAPIProvider creates a JIRA provider that calls the REST API with an API token
+func NewAPIProvider(server, project, email, token string) (*Provider, error) {
+	client, err := NewAPIClient(server, project, email, token)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Provider{
+		client: client,
+	}, nil
+}
+
 // NewProviderWithExecutor creates a JIRA provider with custom executor (for testing)
 func NewProviderWithExecutor(server, project string, executor Executor) (*Provider, error) {
 	client, err := NewClientWithExecutor(server, project, executor)
@@ -48,16 +60,19 @@ func (p *Provider) ProviderType() string {
 	return "jira"
 }
 
-// ListIssues returns all open issues assigned to the current user
-func (p *Provider) ListIssues(ctx context.Context, limit int) ([]providers.Issue, error) {
-	jiraIssues, err := p.client.ListOpenIssues(ctx)
+// ListIssues returns open issues matching the options.
+// Without an assignee filter, only issues assigned to the current user are listed.
+func (p *Provider) ListIssues(ctx context.Context, opts providers.ListIssuesOptions) ([]providers.Issue, error) {
+	jiraIssues, err := p.client.ListIssuesByJQL(ctx, buildIssueJQL(opts))
 	if err != nil {
 		return nil, err
 	}
 
+	limit := opts.EffectiveLimit()
+
 	// Convert to providers.Issue format
 	capacity := len(jiraIssues)
-	if limit > 0 && limit < capacity {
+	if limit < capacity {
 		capacity = limit
 	}
 
@@ -72,16 +87,18 @@ func (p *Provider) ListIssues(ctx context.Context, limit int) ([]providers.Issue
 			URL:       jiraIssues[i].Fields.URL,
 			State:     jiraIssues[i].Fields.Status.Name,
 			Labels:    jiraIssues[i].Fields.Labels,
+			Type:      jiraIssues[i].Fields.IssueType.Name,
 			Author:    jiraIssues[i].Fields.Creator.DisplayName,
 			CreatedAt: jiraIssues[i].Fields.Created,
 			UpdatedAt: jiraIssues[i].Fields.Updated,
 			Assignee:  jiraIssues[i].Fields.Assignee.DisplayName,
+			Priority:  providers.PriorityFromName(jiraIssues[i].Fields.Priority.Name),
 			IsClosed:  jiraIssues[i].IsClosed(),
 		}
 		issues = append(issues, issue)
 
-		// Respect limit if specified
-		if limit > 0 && len(issues) >= limit {
+		// Respect limit
+		if len(issues) >= limit {
 			break
 		}
 	}
@@ -89,6 +106,52 @@ func (p *Provider) ListIssues(ctx context.Context, limit int) ([]providers.Issue
 	return issues, nil
 }
 
+// buildIssueJQL converts list options to a JQL query for open issues
+func buildIssueJQL(opts providers.ListIssuesOptions) string {
+	var clauses []string
+
+	switch opts.Assignee {
+	case "", providers.AssigneeSelf:
+		clauses = append(clauses, "assignee = currentUser()")
+	case providers.AssigneeNone:
+		clauses = append(clauses, "assignee is EMPTY")
+	default:
+		clauses = append(clauses, "assignee = "+quoteJQL(opts.Assignee))
+	}
+
+	clauses = append(clauses, "status != Done")
+
+	for _, label := range opts.Labels {
+		clauses = append(clauses, "labels = "+quoteJQL(label))
+	}
+
+	switch opts.Milestone {
+	case "":
+	case providers.MilestoneCurrent:
+		clauses = append(clauses, "sprint in openSprints()")
+	default:
+		clauses = append(clauses, "sprint = "+quoteJQL(opts.Milestone))
+	}
+
+	if opts.Search != "" {
+		clauses = append(clauses, "text ~ "+quoteJQL(opts.Search))
+	}
+
+	jql := strings.Join(clauses, " AND ")
+
+	if opts.Sort == providers.SortPriority {
+		// Fetch the most urgent issues first, not just the most recent
+		jql += " ORDER BY priority DESC, updated DESC"
+	}
+
+	return jql
+}
+
+// quoteJQL quotes a value for use in a JQL expression
+func quoteJQL(value string) string {
+	return `"` + strings.ReplaceAll(value, `"`, `\"`) + `"`
+}
+
 // GetIssue returns details for a specific JIRA issue
 func (p *Provider) GetIssue(ctx context.Context, id string) (*providers.Issue, error) {
 	jiraIssue, err := p.client.GetIssue(ctx, id)
@@ -104,10 +167,12 @@ func (p *Provider) GetIssue(ctx context.Context, id string) (*providers.Issue, e
 		URL:       jiraIssue.Fields.URL,
 		State:     jiraIssue.Fields.Status.Name,
 		Labels:    jiraIssue.Fields.Labels,
+		Type:      jiraIssue.Fields.IssueType.Name,
 		Author:    jiraIssue.Fields.Creator.DisplayName,
 		CreatedAt: jiraIssue.Fields.Created,
 		UpdatedAt: jiraIssue.Fields.Updated,
 		Assignee:  jiraIssue.Fields.Assignee.DisplayName,
+		Priority:  providers.PriorityFromName(jiraIssue.Fields.Priority.Name),
 		IsClosed:  jiraIssue.IsClosed(),
 	}, nil
 }
@@ -149,6 +214,7 @@ func (p *Provider) CreateIssue(ctx context.Context, title, body string) (*provid
 		URL:       jiraIssue.Fields.URL,
 		State:     jiraIssue.Fields.Status.Name,
 		Labels:    jiraIssue.Fields.Labels,
+		Type:      jiraIssue.Fields.IssueType.Name,
 		Author:    jiraIssue.Fields.Creator.DisplayName,
 		CreatedAt: jiraIssue.Fields.Created,
 		UpdatedAt: jiraIssue.Fields.Updated,
@@ -158,10 +224,34 @@ func (p *Provider) CreateIssue(ctx context.Context, title, body string) (*provid
 }
 
 // CreatePullRequest is not applicable for JIRA
-func (p *Provider) CreatePullRequest(_ context.Context, _, _, _, _ string) (*providers.PullRequest, error) {
+func (p *Provider) CreatePullRequest(_ context.Context, _ providers.CreatePullRequestOptions) (*providers.PullRequest, error) {
 	return nil, fmt.Errorf("JIRA does not support pull requests")
 }
 
+// AddAssignee assigns a JIRA issue, resolving AssigneeSelf to the current user
+func (p *Provider) AddAssignee(ctx context.Context, id, assignee string) error {
+	if assignee == providers.AssigneeSelf {
+		me, err := p.client.GetCurrentUser(ctx)
+		if err != nil {
+			return err
+		}
+
+		assignee = me
+	}
+
+	return p.client.AssignIssue(ctx, id, assignee)
+}
+
+// AddComment posts a comment on a JIRA issue
+func (p *Provider) AddComment(ctx context.Context, id, body string) error {
+	return p.client.AddComment(ctx, id, body)
+}
+
+// AddLabels adds labels to a JIRA issue
+func (p *Provider) AddLabels(ctx context.Context, id string, labels []string) error {
+	return p.client.AddLabels(ctx, id, labels)
+}
+
 // GetBranchNameSuffix returns the JIRA key for use in branch names
 func (p *Provider) GetBranchNameSuffix(issue *providers.Issue) string {
 	return issue.Key
@@ -49,7 +49,7 @@ func TestProviderListIssues(t *testing.T) {
 	}
 
 	ctx := context.Background()
-	issues, err := provider.ListIssues(ctx, 0)
+	issues, err := provider.ListIssues(ctx, providers.ListIssuesOptions{})
 	if err != nil {
 		t.Fatalf("ListIssues failed: %v", err)
 	}
@@ -71,6 +71,44 @@ func TestProviderListIssues(t *testing.T) {
 	}
 }
 
+// TestBuildIssueJQL tests conversion of list options to JQL
+func TestBuildIssueJQL(t *testing.T) {
+	tests := []struct {
+		name string
+		opts providers.ListIssuesOptions
+		want string
+	}{
+		{
+			name: "defaults to current user",
+			opts: providers.ListIssuesOptions{},
+			want: "assignee = currentUser() AND status != Done",
+		},
+		{
+			name: "unassigned with labels",
+			opts: providers.ListIssuesOptions{Assignee: providers.AssigneeNone, Labels: []string{"bug", "ui"}},
+			want: `assignee is EMPTY AND status != Done AND labels = "bug" AND labels = "ui"`,
+		},
+		{
+			name: "sprint and text search",
+			opts: providers.ListIssuesOptions{Assignee: "alice", Milestone: "Sprint 4", Search: `say "hi"`},
+			want: `assignee = "alice" AND status != Done AND sprint = "Sprint 4" AND text ~ "say \"hi\""`,
+		},
+		{
+			name: "current sprint by priority",
+			opts: providers.ListIssuesOptions{Milestone: providers.MilestoneCurrent, Sort: providers.SortPriority},
+			want: "assignee = currentUser() AND status != Done AND sprint in openSprints() ORDER BY priority DESC, updated DESC",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := buildIssueJQL(tt.opts); got != tt.want {
+				t.Errorf("buildIssueJQL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
 // TestProviderGetIssue tests GetIssue method
 func TestProviderGetIssue(t *testing.T) {
 	executor := NewMockExecutor()
@@ -190,7 +228,7 @@ func TestProviderPullRequestsNotSupported(t *testing.T) {
 		t.Errorf("expected error for IsPullRequestMerged, got nil")
 	}
 
-	_, err = provider.CreatePullRequest(ctx, "title", "body", "main", "feature")
+	_, err = provider.CreatePullRequest(ctx, providers.CreatePullRequestOptions{Title: "title", BaseBranch: "main", HeadBranch: "feature"})
 	if err == nil {
 		t.Errorf("expected error for CreatePullRequest, got nil")
 	}
@@ -265,3 +303,51 @@ func TestProviderMetadata(t *testing.T) {
 		t.Errorf("expected non-empty sanitized name")
 	}
 }
+
+// TestProviderAddAssigneeSelf tests that AssigneeSelf resolves to the current user
+func TestProviderAddAssigneeSelf(t *testing.T) {
+	executor := NewMockExecutor()
+	executor.SetResponse("me", "alice@example.com\n")
+
+	provider, err := NewProviderWithExecutor("https://jira.example.com", "PROJ", executor)
+	if err != nil {
+		t.Fatalf("failed to create provider: %v", err)
+	}
+
+	if err := provider.AddAssignee(context.Background(), "PROJ-123", providers.AssigneeSelf); err != nil {
+		t.Fatalf("AddAssignee failed: %v", err)
+	}
+
+	last := executor.calls[len(executor.calls)-1].Args
+	want := []string{"issue", "assign", "PROJ-123", "alice@example.com"}
+
+	if len(last) != len(want) {
+		t.Fatalf("expected args %v, got %v", want, last)
+	}
+
+	for i := range want {
+		if last[i] != want[i] {
+			t.Errorf("expected args %v, got %v", want, last)
+			break
+		}
+	}
+}
+
+// TestProviderAddComment tests AddComment method
+func TestProviderAddComment(t *testing.T) {
+	executor := NewMockExecutor()
+
+	provider, err := NewProviderWithExecutor("https://jira.example.com", "PROJ", executor)
+	if err != nil {
+		t.Fatalf("failed to create provider: %v", err)
+	}
+
+	if err := provider.AddComment(context.Background(), "PROJ-123", "Started work"); err != nil {
+		t.Fatalf("AddComment failed: %v", err)
+	}
+
+	last := executor.calls[len(executor.calls)-1].Args
+	if len(last) < 5 || last[2] != "add" || last[3] != "PROJ-123" || last[4] != "Started work" {
+		t.Errorf("unexpected comment args: %v", last)
+	}
+}
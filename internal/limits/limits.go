@@ -0,0 +1,171 @@
+// Package limits bounds the external commands auto-worktree runs: how long
+// each CLI may take, and how many run at once, so one hung gh call cannot
+// freeze list or the menu.
+package limits
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Tools whose commands have a timeout
+const (
+	ToolGit    = "git"
+	ToolGitHub = "gh"
+	ToolGitLab = "glab"
+	ToolJira   = "jira"
+	ToolLinear = "linear"
+	// ToolPlugin covers each request to a provider plugin
+	ToolPlugin = "plugin"
+	// ToolAI covers one-shot prompts to the AI tool, not interactive sessions
+	ToolAI = "ai"
+	// ToolContainer covers docker and podman, which may pull the sandbox image
+	ToolContainer = "container"
+	// ToolTmux covers tmux commands, not attaching to a session
+	ToolTmux = "tmux"
+)
+
+// Tools lists every tool with a timeout
+var Tools = []string{ToolGit, ToolGitHub, ToolGitLab, ToolJira, ToolLinear, ToolPlugin, ToolAI, ToolContainer, ToolTmux}
+
+// DefaultTimeouts are used for tools without a configured timeout
+var DefaultTimeouts = map[string]time.Duration{
+	ToolGit:    5 * time.Minute,
+	ToolGitHub: time.Minute,
+	ToolGitLab: time.Minute,
+	ToolJira:   time.Minute,
+	ToolLinear: time.Minute,
+	ToolPlugin: time.Minute,
+	ToolAI:     5 * time.Minute,
+	// Pulling an image on the first start can take a while
+	ToolContainer: 10 * time.Minute,
+	ToolTmux:      30 * time.Second,
+}
+
+// DefaultParallelism is how many commands run at once without a configured maximum
+const DefaultParallelism = 8
+
+// ErrTimeout marks a command that was stopped because it ran past its timeout
+var ErrTimeout = errors.New("command timed out")
+
+var (
+	mu          sync.RWMutex
+	timeouts    = map[string]time.Duration{}
+	parallelism = DefaultParallelism
+)
+
+// Configure sets the timeouts (a zero duration disables a tool's timeout) and
+// the maximum parallelism. Tools not in timeouts keep their default.
+func Configure(t map[string]time.Duration, maxParallel int) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	timeouts = make(map[string]time.Duration, len(t))
+	for tool, d := range t {
+		timeouts[tool] = d
+	}
+
+	parallelism = DefaultParallelism
+	if maxParallel > 0 {
+		parallelism = maxParallel
+	}
+}
+
+// Timeout returns how long a command of tool may run, or 0 for no limit
+func Timeout(tool string) time.Duration {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	if d, ok := timeouts[tool]; ok {
+		return d
+	}
+
+	return DefaultTimeouts[tool]
+}
+
+// Parallelism returns how many commands may run at once
+func Parallelism() int {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	return parallelism
+}
+
+// Context derives a context that expires after tool's timeout
+func Context(parent context.Context, tool string) (context.Context, context.CancelFunc) {
+	if d := Timeout(tool); d > 0 {
+		return context.WithTimeout(parent, d)
+	}
+
+	return context.WithCancel(parent)
+}
+
+// Wrap turns the error of a command killed by ctx's deadline into an
+// ErrTimeout that says which setting raises the limit; other errors pass through
+func Wrap(ctx context.Context, tool string, err error) error {
+	if err == nil || !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return err
+	}
+
+	return fmt.Errorf("%w: %s took longer than %s (raise it with auto-worktree.command-timeouts): %w",
+		ErrTimeout, tool, Timeout(tool), err)
+}
+
+// ForEach calls fn for every item, running at most Parallelism() at once, and
+// returns when all calls have finished
+func ForEach[T any](items []T, fn func(T)) {
+	sem := make(chan struct{}, Parallelism())
+
+	var wg sync.WaitGroup
+
+	for _, item := range items {
+		wg.Add(1)
+
+		sem <- struct{}{}
+
+		go func(item T) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			fn(item)
+		}(item)
+	}
+
+	wg.Wait()
+}
+
+// ParseTimeouts parses per-tool timeouts such as "gh=30s,git=2m,ai=0", where 0 means no limit
+func ParseTimeouts(value string) (map[string]time.Duration, error) {
+	result := map[string]time.Duration{}
+
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		tool, duration, ok := strings.Cut(entry, "=")
+		tool = strings.TrimSpace(tool)
+
+		if !ok || !slices.Contains(Tools, tool) {
+			return nil, fmt.Errorf("invalid timeout %q: use <tool>=<duration> with a tool from %s",
+				entry, strings.Join(Tools, ", "))
+		}
+
+		d, err := time.ParseDuration(strings.TrimSpace(duration))
+		if err != nil || d < 0 {
+			return nil, fmt.Errorf("invalid timeout %q: duration must look like 30s or 2m, or 0 for no limit", entry)
+		}
+
+		result[tool] = d
+	}
+
+	return result, nil
+}
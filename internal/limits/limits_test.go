@@ -0,0 +1,105 @@
+package limits
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestParseTimeouts(t *testing.T) {
+	got, err := ParseTimeouts(" gh=30s, git=2m,ai=0 ")
+	if err != nil {
+		t.Fatalf("ParseTimeouts() error = %v", err)
+	}
+
+	want := map[string]time.Duration{ToolGitHub: 30 * time.Second, ToolGit: 2 * time.Minute, ToolAI: 0}
+	if len(got) != len(want) {
+		t.Fatalf("ParseTimeouts() = %v, want %v", got, want)
+	}
+
+	for tool, d := range want {
+		if got[tool] != d {
+			t.Errorf("timeout for %s = %s, want %s", tool, got[tool], d)
+		}
+	}
+
+	for _, bad := range []string{"gh", "svn=1m", "gh=soon", "gh=-1s"} {
+		if _, err := ParseTimeouts(bad); err == nil {
+			t.Errorf("ParseTimeouts(%q) should fail", bad)
+		}
+	}
+}
+
+func TestConfigure(t *testing.T) {
+	t.Cleanup(func() { Configure(nil, 0) })
+
+	Configure(map[string]time.Duration{ToolGitHub: 10 * time.Second, ToolAI: 0}, 3)
+
+	if got := Timeout(ToolGitHub); got != 10*time.Second {
+		t.Errorf("Timeout(gh) = %s, want 10s", got)
+	}
+
+	if got := Timeout(ToolGit); got != DefaultTimeouts[ToolGit] {
+		t.Errorf("Timeout(git) = %s, want the default %s", got, DefaultTimeouts[ToolGit])
+	}
+
+	ctx, cancel := Context(context.Background(), ToolAI)
+	defer cancel()
+
+	if _, ok := ctx.Deadline(); ok {
+		t.Error("a zero timeout should leave the context without a deadline")
+	}
+
+	if got := Parallelism(); got != 3 {
+		t.Errorf("Parallelism() = %d, want 3", got)
+	}
+}
+
+func TestWrap(t *testing.T) {
+	failure := errors.New("signal: killed")
+
+	if err := Wrap(context.Background(), ToolGitHub, failure); err != failure {
+		t.Errorf("Wrap() without a deadline = %v, want the error unchanged", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancel()
+	<-ctx.Done()
+
+	err := Wrap(ctx, ToolGitHub, failure)
+	if !errors.Is(err, ErrTimeout) || !errors.Is(err, failure) {
+		t.Errorf("Wrap() after the deadline = %v, want ErrTimeout wrapping the failure", err)
+	}
+}
+
+func TestForEachLimitsParallelism(t *testing.T) {
+	t.Cleanup(func() { Configure(nil, 0) })
+	Configure(nil, 2)
+
+	var running, most, done atomic.Int32
+
+	items := make([]int, 10)
+	ForEach(items, func(int) {
+		n := running.Add(1)
+		for {
+			m := most.Load()
+			if n <= m || most.CompareAndSwap(m, n) {
+				break
+			}
+		}
+
+		time.Sleep(5 * time.Millisecond)
+		running.Add(-1)
+		done.Add(1)
+	})
+
+	if done.Load() != 10 {
+		t.Errorf("ForEach() ran %d calls, want 10", done.Load())
+	}
+
+	if most.Load() > 2 {
+		t.Errorf("ForEach() ran %d calls at once, want at most 2", most.Load())
+	}
+}
@@ -0,0 +1,326 @@
+package linear
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kaeawc/auto-worktree/internal/interrupt"
+	"github.com/kaeawc/auto-worktree/internal/limits"
+	"github.com/kaeawc/auto-worktree/internal/logging"
+	"github.com/kaeawc/auto-worktree/internal/retry"
+)
+
+// defaultAPIURL is Linear's GraphQL endpoint
+const defaultAPIURL = "https://api.linear.app/graphql"
+
+// CycleCurrent selects a team's active cycle
+const CycleCurrent = "current"
+
+// openStateTypes are the workflow state types of issues still to be worked on
+var openStateTypes = []string{"unstarted", "started"}
+
+// issueSelection is the GraphQL selection for Issue
+const issueSelection = `id identifier number title description url priority
+	state { name type } team { key } assignee { displayName }
+	labels { nodes { name color } }`
+
+// APIKeyFromEnv returns the key in LINEAR_API_KEY, which the linear CLI also reads
+func APIKeyFromEnv() string {
+	return os.Getenv("LINEAR_API_KEY")
+}
+
+// API calls the Linear GraphQL API with a personal API key, so Linear works
+// without the linear CLI
+type API struct {
+	Key  string
+	URL  string
+	HTTP *http.Client
+
+	// ctx stops requests when it is done; nil never does
+	ctx context.Context
+}
+
+// NewAPI creates an API client authenticated with key; its requests stop on Ctrl-C
+func NewAPI(key string) *API {
+	return &API{Key: key, URL: defaultAPIURL, HTTP: &http.Client{}, ctx: interrupt.Context()}
+}
+
+// IssueFilter narrows the issues returned by ListFilteredIssues
+type IssueFilter struct {
+	// Labels restricts results to issues carrying all of these labels
+	Labels []string
+	// Assignee is "@me", "none" for unassigned, or a display name or email
+	Assignee string
+	// Cycle is a cycle number or name, or CycleCurrent; it overrides the configured cycle
+	Cycle string
+	// Search matches the title and description
+	Search string
+}
+
+// apiIssue is an issue as the API returns it, with labels as a connection
+type apiIssue struct {
+	Issue
+	Labels struct {
+		Nodes []Label `json:"nodes"`
+	} `json:"labels"`
+}
+
+func (i apiIssue) issue() Issue {
+	issue := i.Issue
+	issue.Labels = i.Labels.Nodes
+
+	return issue
+}
+
+// listIssues fetches open issues of team, optionally in project and cycle, matching filter
+func (a *API) listIssues(team, project, cycle string, limit int, filter IssueFilter) ([]Issue, error) {
+	var result struct {
+		Issues struct {
+			Nodes []apiIssue `json:"nodes"`
+		} `json:"issues"`
+	}
+
+	query := `query($filter: IssueFilter, $first: Int) {
+		issues(filter: $filter, first: $first, orderBy: updatedAt) { nodes { ` + issueSelection + ` } }
+	}`
+
+	variables := map[string]any{"filter": issueFilterInput(team, project, cycle, filter), "first": min(limit, 250)}
+	if err := a.query(query, variables, &result); err != nil {
+		return nil, err
+	}
+
+	issues := make([]Issue, 0, len(result.Issues.Nodes))
+	for _, node := range result.Issues.Nodes {
+		issues = append(issues, node.issue())
+	}
+
+	return issues, nil
+}
+
+// issueFilterInput builds the GraphQL IssueFilter for open issues of team
+func issueFilterInput(team, project, cycle string, filter IssueFilter) map[string]any {
+	and := []map[string]any{
+		{"team": map[string]any{"key": map[string]any{"eq": team}}},
+		{"state": map[string]any{"type": map[string]any{"in": openStateTypes}}},
+	}
+
+	if project != "" {
+		and = append(and, map[string]any{"project": map[string]any{"name": map[string]any{"eqIgnoreCase": project}}})
+	}
+
+	if filter.Cycle != "" {
+		cycle = filter.Cycle
+	}
+
+	switch n, err := strconv.Atoi(cycle); {
+	case cycle == "":
+	case cycle == CycleCurrent:
+		and = append(and, map[string]any{"cycle": map[string]any{"isActive": map[string]any{"eq": true}}})
+	case err == nil:
+		and = append(and, map[string]any{"cycle": map[string]any{"number": map[string]any{"eq": n}}})
+	default:
+		and = append(and, map[string]any{"cycle": map[string]any{"name": map[string]any{"eqIgnoreCase": cycle}}})
+	}
+
+	for _, label := range filter.Labels {
+		and = append(and, map[string]any{"labels": map[string]any{"some": map[string]any{"name": map[string]any{"eqIgnoreCase": label}}}})
+	}
+
+	switch filter.Assignee {
+	case "":
+	case "@me":
+		and = append(and, map[string]any{"assignee": map[string]any{"isMe": map[string]any{"eq": true}}})
+	case "none":
+		and = append(and, map[string]any{"assignee": map[string]any{"null": true}})
+	default:
+		and = append(and, map[string]any{"assignee": map[string]any{"or": []map[string]any{
+			{"displayName": map[string]any{"eqIgnoreCase": filter.Assignee}},
+			{"email": map[string]any{"eqIgnoreCase": filter.Assignee}},
+		}}})
+	}
+
+	if filter.Search != "" {
+		and = append(and, map[string]any{"or": []map[string]any{
+			{"title": map[string]any{"containsIgnoreCase": filter.Search}},
+			{"description": map[string]any{"containsIgnoreCase": filter.Search}},
+		}})
+	}
+
+	return map[string]any{"and": and}
+}
+
+// issue fetches an issue by identifier (e.g. "ENG-123")
+func (a *API) issue(identifier string) (*Issue, error) {
+	var result struct {
+		Issue *apiIssue `json:"issue"`
+	}
+
+	query := `query($id: String!) { issue(id: $id) { ` + issueSelection + ` } }`
+	if err := a.query(query, map[string]any{"id": identifier}, &result); err != nil {
+		return nil, err
+	}
+
+	if result.Issue == nil {
+		return nil, fmt.Errorf("issue %s not found", identifier)
+	}
+
+	issue := result.Issue.issue()
+
+	return &issue, nil
+}
+
+// createIssue creates an issue in team, and in project when it is set
+func (a *API) createIssue(team, project, title, body string) (*Issue, error) {
+	var ids struct {
+		Teams struct {
+			Nodes []struct {
+				ID string `json:"id"`
+			} `json:"nodes"`
+		} `json:"teams"`
+		Projects struct {
+			Nodes []struct {
+				ID string `json:"id"`
+			} `json:"nodes"`
+		} `json:"projects"`
+	}
+
+	lookup := `query($team: String!, $project: String!) {
+		teams(filter: { key: { eq: $team } }) { nodes { id } }
+		projects(filter: { name: { eqIgnoreCase: $project } }) { nodes { id } }
+	}`
+	if err := a.query(lookup, map[string]any{"team": team, "project": project}, &ids); err != nil {
+		return nil, err
+	}
+
+	if len(ids.Teams.Nodes) == 0 {
+		return nil, fmt.Errorf("no Linear team with key %s", team)
+	}
+
+	input := map[string]any{"teamId": ids.Teams.Nodes[0].ID, "title": title, "description": body}
+
+	if project != "" {
+		if len(ids.Projects.Nodes) == 0 {
+			return nil, fmt.Errorf("no Linear project named %s", project)
+		}
+
+		input["projectId"] = ids.Projects.Nodes[0].ID
+	}
+
+	var result struct {
+		IssueCreate struct {
+			Success bool      `json:"success"`
+			Issue   *apiIssue `json:"issue"`
+		} `json:"issueCreate"`
+	}
+
+	mutation := `mutation($input: IssueCreateInput!) {
+		issueCreate(input: $input) { success issue { ` + issueSelection + ` } }
+	}`
+	if err := a.query(mutation, map[string]any{"input": input}, &result); err != nil {
+		return nil, err
+	}
+
+	if !result.IssueCreate.Success || result.IssueCreate.Issue == nil {
+		return nil, fmt.Errorf("linear did not create the issue")
+	}
+
+	issue := result.IssueCreate.Issue.issue()
+
+	return &issue, nil
+}
+
+// viewer checks the key by fetching the signed-in user
+func (a *API) viewer() error {
+	var result struct {
+		Viewer struct {
+			ID string `json:"id"`
+		} `json:"viewer"`
+	}
+
+	return a.query(`query { viewer { id } }`, nil, &result)
+}
+
+// query runs a GraphQL query under the linear timeout, retrying transient
+// failures and rate limits, and decodes its data into out
+func (a *API) query(query string, variables map[string]any, out any) error {
+	if a.Key == "" {
+		return ErrLinearNotAuthenticated
+	}
+
+	body, err := json.Marshal(map[string]any{"query": query, "variables": variables})
+	if err != nil {
+		return fmt.Errorf("failed to encode query: %w", err)
+	}
+
+	output, err := retry.DefaultPolicy.Run("Linear", func() ([]byte, error) {
+		ctx, cancel := limits.Context(orBackground(a.ctx), limits.ToolLinear)
+		defer cancel()
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.URL, bytes.NewReader(body))
+		if err != nil {
+			return nil, fmt.Errorf("failed to build request: %w", err)
+		}
+
+		// Personal API keys are sent as is, without "Bearer"
+		req.Header.Set("Authorization", a.Key)
+		req.Header.Set("Content-Type", "application/json")
+
+		start := time.Now()
+		resp, err := a.HTTP.Do(req)
+		logging.Debug("linear api", "duration", time.Since(start), "err", err)
+
+		if err != nil {
+			return nil, limits.Wrap(ctx, limits.ToolLinear, err)
+		}
+		defer resp.Body.Close()
+
+		output, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, limits.Wrap(ctx, limits.ToolLinear, fmt.Errorf("failed to read response: %w", err))
+		}
+
+		switch {
+		case bytes.Contains(output, []byte("RATELIMITED")):
+			return output, fmt.Errorf("HTTP 429: Linear rate limit exceeded")
+		case resp.StatusCode == http.StatusUnauthorized:
+			return output, fmt.Errorf("HTTP 401: %w: check LINEAR_API_KEY", ErrLinearNotAuthenticated)
+		case resp.StatusCode >= http.StatusMultipleChoices && resp.StatusCode != http.StatusBadRequest:
+			// Linear reports query errors as 400 with an errors list, handled below
+			return output, fmt.Errorf("HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(output)))
+		}
+
+		return output, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	var response struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+
+	if err := json.Unmarshal(output, &response); err != nil {
+		return fmt.Errorf("failed to parse Linear response: %w", err)
+	}
+
+	if len(response.Errors) > 0 {
+		return fmt.Errorf("linear API: %s", response.Errors[0].Message)
+	}
+
+	if err := json.Unmarshal(response.Data, out); err != nil {
+		return fmt.Errorf("failed to parse Linear data: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,151 @@
+package linear
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kaeawc/auto-worktree/internal/retry"
+)
+
+// graphQLRequest is the body the client posts to the API
+type graphQLRequest struct {
+	Query     string         `json:"query"`
+	Variables map[string]any `json:"variables"`
+}
+
+// newTestAPIClient serves the API from handler and returns a client for team ENG that calls it
+func newTestAPIClient(t *testing.T, handler func(w http.ResponseWriter, req graphQLRequest)) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "lin_api_test" {
+			t.Errorf("Authorization = %q", r.Header.Get("Authorization"))
+		}
+
+		var req graphQLRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+
+		handler(w, req)
+	}))
+	t.Cleanup(server.Close)
+
+	policy := retry.DefaultPolicy
+	retry.DefaultPolicy = retry.Policy{Attempts: 1}
+
+	t.Cleanup(func() { retry.DefaultPolicy = policy })
+
+	api := &API{Key: "lin_api_test", URL: server.URL, HTTP: server.Client()}
+
+	return &Client{Team: "ENG", Project: "Mobile", api: api}
+}
+
+func TestAPI_ListFilteredIssues(t *testing.T) {
+	var filter string
+
+	client := newTestAPIClient(t, func(w http.ResponseWriter, req graphQLRequest) {
+		data, _ := json.Marshal(req.Variables["filter"])
+		filter = string(data)
+
+		io.WriteString(w, `{"data":{"issues":{"nodes":[{"identifier":"ENG-7","number":7,"title":"Fix login",
+			"state":{"name":"Todo","type":"unstarted"},"assignee":{"displayName":"Ada"},
+			"labels":{"nodes":[{"name":"bug"}]}}]}}}`)
+	})
+
+	issues, err := client.ListFilteredIssues(10, IssueFilter{Labels: []string{"bug"}, Cycle: CycleCurrent, Assignee: "@me"})
+	if err != nil {
+		t.Fatalf("ListFilteredIssues() error = %v", err)
+	}
+
+	if len(issues) != 1 || issues[0].Identifier != "ENG-7" || len(issues[0].Labels) != 1 || issues[0].Labels[0].Name != "bug" {
+		t.Fatalf("ListFilteredIssues() = %+v", issues)
+	}
+
+	if issues[0].Assignee == nil || issues[0].Assignee.DisplayName != "Ada" {
+		t.Errorf("Assignee = %+v", issues[0].Assignee)
+	}
+
+	for _, want := range []string{
+		`{"team":{"key":{"eq":"ENG"}}}`,
+		`{"project":{"name":{"eqIgnoreCase":"Mobile"}}}`,
+		`{"cycle":{"isActive":{"eq":true}}}`,
+		`{"labels":{"some":{"name":{"eqIgnoreCase":"bug"}}}}`,
+		`{"assignee":{"isMe":{"eq":true}}}`,
+	} {
+		if !strings.Contains(filter, want) {
+			t.Errorf("filter %s does not contain %s", filter, want)
+		}
+	}
+}
+
+func TestIssueFilterInput_Cycle(t *testing.T) {
+	tests := []struct {
+		configured, requested, want string
+	}{
+		{"", "", ""},
+		{"12", "", `{"cycle":{"number":{"eq":12}}}`},
+		{"12", "Sprint 4", `{"cycle":{"name":{"eqIgnoreCase":"Sprint 4"}}}`},
+	}
+
+	for _, tt := range tests {
+		data, _ := json.Marshal(issueFilterInput("ENG", "", tt.configured, IssueFilter{Cycle: tt.requested}))
+
+		if got := string(data); tt.want == "" && strings.Contains(got, "cycle") || !strings.Contains(got, tt.want) {
+			t.Errorf("issueFilterInput(cycle %q, %q) = %s, want %s", tt.configured, tt.requested, got, tt.want)
+		}
+	}
+}
+
+func TestAPI_CreateIssue(t *testing.T) {
+	var input map[string]any
+
+	client := newTestAPIClient(t, func(w http.ResponseWriter, req graphQLRequest) {
+		if strings.Contains(req.Query, "issueCreate") {
+			input, _ = req.Variables["input"].(map[string]any)
+			io.WriteString(w, `{"data":{"issueCreate":{"success":true,"issue":{"identifier":"ENG-8","title":"New"}}}}`)
+
+			return
+		}
+
+		io.WriteString(w, `{"data":{"teams":{"nodes":[{"id":"team-1"}]},"projects":{"nodes":[{"id":"project-1"}]}}}`)
+	})
+
+	issue, err := client.CreateIssue("New", "Details")
+	if err != nil {
+		t.Fatalf("CreateIssue() error = %v", err)
+	}
+
+	if issue.Identifier != "ENG-8" {
+		t.Errorf("CreateIssue() = %+v", issue)
+	}
+
+	if input["teamId"] != "team-1" || input["projectId"] != "project-1" || input["description"] != "Details" {
+		t.Errorf("issueCreate input = %v", input)
+	}
+}
+
+func TestAPI_Errors(t *testing.T) {
+	client := newTestAPIClient(t, func(w http.ResponseWriter, _ graphQLRequest) {
+		w.WriteHeader(http.StatusBadRequest)
+		io.WriteString(w, `{"errors":[{"message":"Entity not found: Issue"}]}`)
+	})
+
+	if _, err := client.GetIssue("ENG-404"); err == nil || !strings.Contains(err.Error(), "Entity not found") {
+		t.Errorf("GetIssue() error = %v", err)
+	}
+
+	cli := &Client{Team: "ENG", executor: NewFakeExecutor()}
+	if _, err := cli.CreateIssue("New", ""); err == nil {
+		t.Error("CreateIssue() without the API = nil error")
+	}
+
+	if err := (&API{}).viewer(); !errors.Is(err, ErrLinearNotAuthenticated) {
+		t.Errorf("viewer() without a key = %v, want ErrLinearNotAuthenticated", err)
+	}
+}
@@ -1,4 +1,5 @@
-// Package linear provides a client for interacting with the Linear CLI tool.
+// Package linear provides a client for Linear, via its GraphQL API when
+// LINEAR_API_KEY is set or the linear CLI tool otherwise.
 package linear
 
 import (
@@ -20,12 +21,18 @@ var (
 	ErrNoTeamConfigured = errors.New("no Linear team configured")
 )
 
-// Client provides Linear operations via linear CLI
+// Client provides Linear operations via the API or the linear CLI
 type Client struct {
 	// Team is the Linear team key (e.g., "ENG", "PRODUCT")
 	Team string
+	// Project and Cycle narrow listed issues when the API is used; Cycle is a
+	// number, a name or CycleCurrent
+	Project string
+	Cycle   string
 	// executor handles linear CLI command execution
 	executor Executor
+	// api is set when Linear is reached through its API instead of the CLI
+	api *API
 }
 
 // NewClient creates a Linear client with team from git config
@@ -59,6 +66,36 @@ func NewClientWithExecutor(_ string, config *git.Config, executor Executor) (*Cl
 	}, nil
 }
 
+// NewAPIClient creates a Linear client that calls the GraphQL API with key,
+// with team, project and cycle from git config
+func NewAPIClient(config *git.Config, key string) (*Client, error) {
+	return newAPIClient(config, NewAPI(key))
+}
+
+func newAPIClient(config *git.Config, api *API) (*Client, error) {
+	if err := api.viewer(); err != nil {
+		return nil, err
+	}
+
+	team := config.GetWithDefault(git.ConfigLinearTeam, "", git.ConfigScopeAuto)
+	if team == "" {
+		return nil, ErrNoTeamConfigured
+	}
+
+	return &Client{
+		Team:    team,
+		Project: config.GetWithDefault(git.ConfigLinearProject, "", git.ConfigScopeAuto),
+		Cycle:   config.GetWithDefault(git.ConfigLinearCycle, "", git.ConfigScopeAuto),
+		api:     api,
+	}, nil
+}
+
+// FiltersServerSide reports whether ListFilteredIssues applies every filter;
+// the CLI cannot, so callers filter its results themselves
+func (c *Client) FiltersServerSide() bool {
+	return c.api != nil
+}
+
 // IsInstalled checks if linear CLI is installed
 func IsInstalled(executor Executor) bool {
 	_, err := executor.Execute("--version")
@@ -96,6 +133,10 @@ func (c *Client) execLinear(args ...string) ([]byte, error) {
 // Uses: linear issue list --team <team> --limit <limit> --state unstarted,started
 // Note: linear issue list does NOT support --json, so we parse text output then fetch JSON for each
 func (c *Client) ListOpenIssues(limit int) ([]Issue, error) {
+	if c.api != nil {
+		return c.ListFilteredIssues(limit, IssueFilter{})
+	}
+
 	// Fetch issues as text (no JSON support)
 	output, err := c.execLinear("issue", "list",
 		"--team", c.Team,
@@ -125,9 +166,51 @@ func (c *Client) ListOpenIssues(limit int) ([]Issue, error) {
 	return issues, nil
 }
 
+// ListFilteredIssues fetches open issues of the team, project and cycle that
+// match the filter (up to limit). Without the API, it lists open issues unfiltered.
+func (c *Client) ListFilteredIssues(limit int, filter IssueFilter) ([]Issue, error) {
+	if c.api == nil {
+		return c.ListOpenIssues(limit)
+	}
+
+	issues, err := c.api.listIssues(c.Team, c.Project, c.Cycle, limit, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list issues: %w", err)
+	}
+
+	return issues, nil
+}
+
+// CreateIssue creates an issue in the team (and project, if configured); it needs the API
+func (c *Client) CreateIssue(title, body string) (*Issue, error) {
+	if title == "" {
+		return nil, fmt.Errorf("issue title cannot be empty")
+	}
+
+	if c.api == nil {
+		return nil, fmt.Errorf("creating Linear issues needs LINEAR_API_KEY")
+	}
+
+	issue, err := c.api.createIssue(c.Team, c.Project, title, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create issue: %w", err)
+	}
+
+	return issue, nil
+}
+
 // GetIssue fetches a specific issue by identifier (e.g., "ENG-123")
 // Uses: linear issue view <identifier> --json
 func (c *Client) GetIssue(identifier string) (*Issue, error) {
+	if c.api != nil {
+		issue, err := c.api.issue(identifier)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get issue %s: %w", identifier, err)
+		}
+
+		return issue, nil
+	}
+
 	output, err := c.execLinear("issue", "view", identifier, "--json")
 	if err != nil {
 		if strings.Contains(err.Error(), "not found") {
@@ -5,6 +5,12 @@ import (
 	"fmt"
 	"os/exec"
 	"strings"
+	"time"
+
+	"github.com/kaeawc/auto-worktree/internal/interrupt"
+	"github.com/kaeawc/auto-worktree/internal/limits"
+	"github.com/kaeawc/auto-worktree/internal/logging"
+	"github.com/kaeawc/auto-worktree/internal/retry"
 )
 
 // Executor defines the interface for executing linear CLI commands
@@ -16,35 +22,70 @@ type Executor interface {
 }
 
 // RealExecutor executes actual linear commands via exec.Command
-type RealExecutor struct{}
+type RealExecutor struct {
+	// ctx stops the commands when it is done
+	ctx context.Context
+}
 
-// NewExecutor creates a new real Linear executor for production use
+// NewExecutor creates a new real Linear executor for production use; its
+// commands stop on Ctrl-C
 func NewExecutor() Executor {
-	return &RealExecutor{}
+	return NewExecutorContext(interrupt.Context())
+}
+
+// NewExecutorContext creates a Linear executor whose commands stop when ctx is done
+func NewExecutorContext(ctx context.Context) Executor {
+	return &RealExecutor{ctx: ctx}
+}
+
+// orBackground returns ctx, or context.Background() for an executor built without one
+func orBackground(ctx context.Context) context.Context {
+	if ctx == nil {
+		return context.Background()
+	}
+
+	return ctx
 }
 
 // Execute runs a linear command and returns the output
 func (e *RealExecutor) Execute(args ...string) (string, error) {
-	cmd := exec.CommandContext(context.Background(), "linear", args...)
-	output, err := cmd.CombinedOutput()
-
+	output, err := runLinear(orBackground(e.ctx), "", args)
 	if err != nil {
 		return "", fmt.Errorf("linear %s failed: %w", strings.Join(args, " "), err)
 	}
 
-	return strings.TrimSpace(string(output)), nil
+	return output, nil
 }
 
 // ExecuteInDir runs a linear command in a specific directory
 func (e *RealExecutor) ExecuteInDir(dir string, args ...string) (string, error) {
-	cmd := exec.CommandContext(context.Background(), "linear", args...)
-	cmd.Dir = dir
-	output, err := cmd.CombinedOutput()
-
+	output, err := runLinear(orBackground(e.ctx), dir, args)
 	if err != nil {
 		return "", fmt.Errorf("linear %s failed in %s: %w", strings.Join(args, " "), dir, err)
 	}
 
+	return output, nil
+}
+
+// runLinear runs linear under its timeout, retrying transient failures and
+// rate limits; it stops when parent is done
+func runLinear(parent context.Context, dir string, args []string) (string, error) {
+	output, err := retry.DefaultPolicy.Run("Linear", func() ([]byte, error) {
+		ctx, cancel := limits.Context(parent, limits.ToolLinear)
+		defer cancel()
+
+		cmd := exec.CommandContext(ctx, "linear", args...)
+		cmd.Dir = dir
+		start := time.Now()
+		output, err := cmd.CombinedOutput()
+		logging.Command(cmd, start, output, err)
+
+		return output, limits.Wrap(ctx, limits.ToolLinear, err)
+	})
+	if err != nil {
+		return "", err
+	}
+
 	return strings.TrimSpace(string(output)), nil
 }
 
@@ -28,6 +28,12 @@ type Issue struct {
 	Team struct {
 		Key string `json:"key"`
 	} `json:"team"`
+	// Assignee is the user the issue is assigned to, if any
+	Assignee *struct {
+		DisplayName string `json:"displayName"`
+	} `json:"assignee"`
+	// Priority is 0 for none, then 1 (urgent) to 4 (low)
+	Priority float64 `json:"priority"`
 	// Labels attached to the issue
 	Labels []Label `json:"labels"`
 	// URL to view issue in Linear
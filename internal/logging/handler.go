@@ -0,0 +1,124 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+)
+
+// consoleHandler prints records for people rather than machines:
+// "⚠ Warning: message: err (key=value)"
+type consoleHandler struct {
+	mu    sync.Mutex
+	w     io.Writer
+	level slog.Leveler
+	attrs []slog.Attr
+}
+
+// levelPrefixes matches the prefixes the CLI already used for printed warnings and errors
+var levelPrefixes = map[slog.Level]string{
+	slog.LevelDebug: "debug: ",
+	slog.LevelInfo:  "› ",
+	slog.LevelWarn:  "⚠ Warning: ",
+	slog.LevelError: "Error: ",
+}
+
+func (h *consoleHandler) Enabled(_ context.Context, l slog.Level) bool {
+	return l >= h.level.Level()
+}
+
+func (h *consoleHandler) Handle(_ context.Context, r slog.Record) error {
+	var b strings.Builder
+
+	b.WriteString(levelPrefixes[r.Level])
+	b.WriteString(r.Message)
+
+	var details []string
+
+	appendAttr := func(a slog.Attr) {
+		if a.Key == "err" {
+			fmt.Fprintf(&b, ": %v", a.Value.Any())
+			return
+		}
+
+		details = append(details, a.Key+"="+a.Value.String())
+	}
+
+	for _, a := range h.attrs {
+		appendAttr(a)
+	}
+
+	r.Attrs(func(a slog.Attr) bool {
+		appendAttr(a)
+		return true
+	})
+
+	if len(details) > 0 {
+		fmt.Fprintf(&b, " (%s)", strings.Join(details, " "))
+	}
+
+	b.WriteString("\n")
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	_, err := io.WriteString(h.w, b.String())
+
+	return err
+}
+
+func (h *consoleHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &consoleHandler{w: h.w, level: h.level, attrs: append(append([]slog.Attr{}, h.attrs...), attrs...)}
+}
+
+// WithGroup is unsupported for console output; group attributes are printed flat
+func (h *consoleHandler) WithGroup(_ string) slog.Handler {
+	return h
+}
+
+// fanoutHandler sends each record to every handler that accepts its level
+type fanoutHandler []slog.Handler
+
+func (f fanoutHandler) Enabled(ctx context.Context, l slog.Level) bool {
+	for _, h := range f {
+		if h.Enabled(ctx, l) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (f fanoutHandler) Handle(ctx context.Context, r slog.Record) error {
+	var errs []error
+
+	for _, h := range f {
+		if h.Enabled(ctx, r.Level) {
+			errs = append(errs, h.Handle(ctx, r.Clone()))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (f fanoutHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	out := make(fanoutHandler, len(f))
+	for i, h := range f {
+		out[i] = h.WithAttrs(attrs)
+	}
+
+	return out
+}
+
+func (f fanoutHandler) WithGroup(name string) slog.Handler {
+	out := make(fanoutHandler, len(f))
+	for i, h := range f {
+		out[i] = h.WithGroup(name)
+	}
+
+	return out
+}
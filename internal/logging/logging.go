@@ -0,0 +1,187 @@
+// Package logging provides the leveled logger shared by every package.
+// Warnings and errors always reach stderr; --verbose adds every external
+// command with its duration, --debug adds their output, and an optional log
+// file keeps debug-level history across invocations.
+package logging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kaeawc/auto-worktree/internal/redact"
+)
+
+// maxLogFileSize is the size at which the log file is rotated to log.1
+const maxLogFileSize = 5 << 20
+
+var (
+	mu      sync.Mutex
+	level             = new(slog.LevelVar)
+	console io.Writer = os.Stderr
+	file    *os.File
+	logger  = newLogger()
+)
+
+func init() {
+	level.Set(slog.LevelWarn)
+}
+
+// newLogger builds a logger over the console and, if open, the log file
+func newLogger() *slog.Logger {
+	// Command output logged with --debug can contain tokens
+	handlers := []slog.Handler{&consoleHandler{w: redact.NewWriter(console), level: level}}
+
+	if file != nil {
+		handlers = append(handlers, slog.NewTextHandler(redact.NewWriter(file), &slog.HandlerOptions{Level: slog.LevelDebug}))
+	}
+
+	if len(handlers) == 1 {
+		return slog.New(handlers[0])
+	}
+
+	return slog.New(fanoutHandler(handlers))
+}
+
+// SetLevel sets the lowest level printed to stderr
+func SetLevel(l slog.Level) {
+	level.Set(l)
+}
+
+// Enabled reports whether messages at l reach any output
+func Enabled(l slog.Level) bool {
+	return logger.Enabled(context.Background(), l)
+}
+
+// DefaultLogPath returns $XDG_STATE_HOME/auto-worktree/log, defaulting to ~/.local/state
+func DefaultLogPath() string {
+	stateHome := os.Getenv("XDG_STATE_HOME")
+	if stateHome == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			home = "."
+		}
+
+		stateHome = filepath.Join(home, ".local", "state")
+	}
+
+	return filepath.Join(stateHome, "auto-worktree", "log")
+}
+
+// OpenFile appends debug-level logs to path, rotating it once it grows past 5MB
+func OpenFile(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("failed to create log directory: %w", err)
+	}
+
+	if info, err := os.Stat(path); err == nil && info.Size() > maxLogFileSize {
+		_ = os.Rename(path, path+".1") //nolint:errcheck // a failed rotation just keeps appending
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600) //nolint:gosec // path is the configured log file
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if file != nil {
+		_ = file.Close() //nolint:errcheck // replaced by the new file
+	}
+
+	file = f
+	logger = newLogger()
+
+	return nil
+}
+
+// Close flushes and closes the log file, if one is open
+func Close() {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if file != nil {
+		_ = file.Close() //nolint:errcheck // nothing left to report to
+		file = nil
+		logger = newLogger()
+	}
+}
+
+// RedirectConsole sends what would reach stderr to w, for views that own the
+// terminal, until the returned function restores stderr
+func RedirectConsole(w io.Writer) func() {
+	mu.Lock()
+	defer mu.Unlock()
+
+	previous := console
+	console = w
+	logger = newLogger()
+
+	return func() {
+		mu.Lock()
+		defer mu.Unlock()
+
+		console = previous
+		logger = newLogger()
+	}
+}
+
+// Debug logs details useful only when diagnosing a problem
+func Debug(msg string, args ...any) {
+	logger.Debug(msg, args...)
+}
+
+// Info logs progress shown with --verbose
+func Info(msg string, args ...any) {
+	logger.Info(msg, args...)
+}
+
+// Warn logs a problem the command recovered from
+func Warn(msg string, args ...any) {
+	logger.Warn(msg, args...)
+}
+
+// Error logs a failure
+func Error(msg string, args ...any) {
+	logger.Error(msg, args...)
+}
+
+// Command logs an external command that ran since start: the command line and
+// duration at info level, and on failure its output at debug level
+func Command(cmd *exec.Cmd, start time.Time, output []byte, err error) {
+	if !Enabled(slog.LevelInfo) {
+		return
+	}
+
+	args := []any{"duration", time.Since(start).Round(time.Millisecond)}
+	if cmd.Dir != "" {
+		args = append(args, "dir", cmd.Dir)
+	}
+
+	line := strings.Join(cmd.Args, " ")
+
+	if err == nil {
+		Info(line, args...)
+		return
+	}
+
+	Info(line, append(args, "err", err)...)
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+		output = exitErr.Stderr
+	}
+
+	if len(output) > 0 {
+		Debug("command output", "output", strings.TrimSpace(string(output)))
+	}
+}
@@ -0,0 +1,101 @@
+package logging
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// captureConsole redirects console output to a buffer for the rest of the test
+func captureConsole(t *testing.T, l slog.Level) *bytes.Buffer {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	oldConsole, oldLevel := console, level.Level()
+	console = &buf
+	level.Set(l)
+	logger = newLogger()
+
+	t.Cleanup(func() {
+		Close()
+
+		console = oldConsole
+		level.Set(oldLevel)
+		logger = newLogger()
+	})
+
+	return &buf
+}
+
+func TestConsoleFormat(t *testing.T) {
+	buf := captureConsole(t, slog.LevelWarn)
+
+	Info("hidden at the default level")
+	Warn("could not check merge conflicts", "err", errors.New("timeout"), "pr", 42)
+
+	want := "⚠ Warning: could not check merge conflicts: timeout (pr=42)\n"
+	if buf.String() != want {
+		t.Errorf("console output = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestCommandLogging(t *testing.T) {
+	buf := captureConsole(t, slog.LevelInfo)
+
+	cmd := exec.Command("git", "status")
+	cmd.Dir = "/repo"
+	Command(cmd, time.Now(), []byte("fatal: not a git repository"), errors.New("exit status 128"))
+
+	got := buf.String()
+	if !strings.Contains(got, "git status: exit status 128") || !strings.Contains(got, "dir=/repo") {
+		t.Errorf("console output = %q, want the command, error and directory", got)
+	}
+
+	if strings.Contains(got, "not a git repository") {
+		t.Error("command output should only be logged with --debug")
+	}
+
+	level.Set(slog.LevelDebug)
+	buf.Reset()
+	Command(cmd, time.Now(), []byte("fatal: not a git repository"), errors.New("exit status 128"))
+
+	if !strings.Contains(buf.String(), "not a git repository") {
+		t.Errorf("debug output = %q, want the command output", buf.String())
+	}
+}
+
+func TestOpenFileLogsDebug(t *testing.T) {
+	captureConsole(t, slog.LevelWarn)
+
+	path := filepath.Join(t.TempDir(), "state", "log")
+	if err := OpenFile(path); err != nil {
+		t.Fatalf("OpenFile() error = %v", err)
+	}
+
+	Debug("debug detail", "worktree", "feature")
+	Close()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(string(data), "debug detail") || !strings.Contains(string(data), "worktree=feature") {
+		t.Errorf("log file = %q, want the debug record", data)
+	}
+}
+
+func TestDefaultLogPath(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", "/state")
+
+	if got := DefaultLogPath(); got != filepath.Join("/state", "auto-worktree", "log") {
+		t.Errorf("DefaultLogPath() = %q", got)
+	}
+}
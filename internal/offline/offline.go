@@ -0,0 +1,105 @@
+// Package offline tracks whether auto-worktree should stay off the network,
+// because --offline was given or a provider turned out to be unreachable, so
+// local commands keep working instead of waiting on timeouts.
+package offline
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/kaeawc/auto-worktree/internal/logging"
+)
+
+// ErrOffline is returned in place of a network call that was skipped
+var ErrOffline = errors.New("offline")
+
+var (
+	mu      sync.Mutex
+	enabled bool
+	reason  string
+)
+
+// unreachableMarkers appear in errors from gh, glab, git and Go's HTTP client
+// when the network or the host's DNS is not reachable at all
+var unreachableMarkers = []string{
+	"could not resolve host",
+	"no such host",
+	"temporary failure in name resolution",
+	"network is unreachable",
+	"no route to host",
+	"error connecting to",
+}
+
+// Enable turns offline mode on for the rest of the process; reason is shown to the user
+func Enable(why string) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if !enabled {
+		enabled, reason = true, why
+	}
+}
+
+// Enabled reports whether network calls should be skipped
+func Enabled() bool {
+	mu.Lock()
+	defer mu.Unlock()
+
+	return enabled
+}
+
+// Reason says why offline mode is on, e.g. "--offline" or "GitHub is unreachable"
+func Reason() string {
+	mu.Lock()
+	defer mu.Unlock()
+
+	return reason
+}
+
+// Reset turns offline mode off; used by tests
+func Reset() {
+	mu.Lock()
+	defer mu.Unlock()
+
+	enabled, reason = false, ""
+}
+
+// Skipped returns the error for a call to service that was skipped while offline
+func Skipped(service string) error {
+	return fmt.Errorf("%w: not contacting %s (%s)", ErrOffline, service, Reason())
+}
+
+// IsUnreachable reports whether a failed command's output and error show the
+// network was unreachable, as opposed to the service rejecting the request
+func IsUnreachable(output []byte, err error) bool {
+	if err == nil {
+		return false
+	}
+
+	text := strings.ToLower(string(output) + " " + err.Error())
+
+	for _, marker := range unreachableMarkers {
+		if strings.Contains(text, marker) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Detect switches to offline mode when a call to service failed because the
+// network is unreachable, so later calls fail fast instead of each waiting out
+// their own timeout. It reports whether it did.
+func Detect(service string, output []byte, err error) bool {
+	if !IsUnreachable(output, err) || Enabled() {
+		return false
+	}
+
+	Enable(service + " is unreachable")
+	logging.Warn(fmt.Sprintf("%s is unreachable; continuing offline (skipping provider status, AI selection and downloads)", service),
+		"err", err)
+
+	return true
+}
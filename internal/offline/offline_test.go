@@ -0,0 +1,54 @@
+package offline
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestIsUnreachable(t *testing.T) {
+	failed := errors.New("exit status 1")
+
+	tests := []struct {
+		output string
+		err    error
+		want   bool
+	}{
+		{"error connecting to api.github.com\ncheck your internet connection", failed, true},
+		{"", errors.New(`Get "https://api.linear.app/graphql": dial tcp: lookup api.linear.app: no such host`), true},
+		{"fatal: unable to access 'https://gitlab.com/x.git/': Could not resolve host: gitlab.com", failed, true},
+		{"connect: network is unreachable", failed, true},
+		{"HTTP 502: Bad Gateway", failed, false},
+		{"GraphQL: Could not resolve to an issue with the number of 7", failed, false},
+		{"could not resolve host", nil, false},
+	}
+
+	for _, tt := range tests {
+		if got := IsUnreachable([]byte(tt.output), tt.err); got != tt.want {
+			t.Errorf("IsUnreachable(%q, %v) = %v, want %v", tt.output, tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestDetectEnablesOnce(t *testing.T) {
+	t.Cleanup(Reset)
+
+	if Detect("GitHub", []byte("HTTP 404: Not Found"), errors.New("exit status 1")) || Enabled() {
+		t.Fatal("Detect() enabled offline mode for a service error")
+	}
+
+	if !Detect("GitHub", []byte("error connecting to api.github.com"), errors.New("exit status 1")) {
+		t.Fatal("Detect() = false for an unreachable host")
+	}
+
+	if Detect("JIRA", []byte("Could not resolve host: example.atlassian.net"), errors.New("exit status 1")) {
+		t.Error("Detect() = true when already offline")
+	}
+
+	if Reason() != "GitHub is unreachable" {
+		t.Errorf("Reason() = %q, want the first unreachable service", Reason())
+	}
+
+	if err := Skipped("JIRA"); !errors.Is(err, ErrOffline) {
+		t.Errorf("Skipped() = %v, want ErrOffline", err)
+	}
+}
@@ -0,0 +1,181 @@
+// Package plugin finds auto-worktree's plugins: command plugins, executables
+// named auto-worktree-<name> on PATH that run as 'auto-worktree <name>', and
+// provider plugins, issue trackers described by a JSON manifest in
+// ~/.auto-worktree/plugins that speak JSON over stdio (see Provider).
+package plugin
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// CommandPrefix starts the name of every command plugin executable
+const CommandPrefix = "auto-worktree-"
+
+// ErrNotFound is returned when there is no plugin of the given name
+var ErrNotFound = errors.New("plugin not found")
+
+// Manifest describes a provider plugin, from <name>.json in Dir()
+type Manifest struct {
+	// Name is what auto-worktree.issue-provider refers to, as plugin:<name>
+	Name string `json:"name"`
+	// Description is shown by 'auto-worktree plugins'
+	Description string `json:"description,omitempty"`
+	// Command is the executable to run, on PATH or relative to the manifest
+	Command string `json:"command"`
+	// Args are passed to Command; each request is written to its stdin
+	Args []string `json:"args,omitempty"`
+
+	// path is the manifest file
+	path string
+}
+
+// Dir returns the directory holding provider plugin manifests
+func Dir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+
+	return filepath.Join(home, ".auto-worktree", "plugins"), nil
+}
+
+// LoadManifests reads every *.json manifest in dir, sorted by name. A missing
+// directory has no manifests.
+func LoadManifests(dir string) ([]Manifest, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	manifests := make([]Manifest, 0, len(paths))
+
+	for _, path := range paths {
+		m, err := loadManifest(path)
+		if err != nil {
+			return nil, err
+		}
+
+		manifests = append(manifests, m)
+	}
+
+	sort.Slice(manifests, func(i, j int) bool { return manifests[i].Name < manifests[j].Name })
+
+	return manifests, nil
+}
+
+func loadManifest(path string) (Manifest, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // G304: path is a manifest in the plugin directory
+	if err != nil {
+		return Manifest{}, fmt.Errorf("failed to read plugin manifest: %w", err)
+	}
+
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return Manifest{}, fmt.Errorf("invalid plugin manifest %s: %w", path, err)
+	}
+
+	if m.Name == "" {
+		m.Name = strings.TrimSuffix(filepath.Base(path), ".json")
+	}
+
+	if m.Command == "" {
+		return Manifest{}, fmt.Errorf("invalid plugin manifest %s: no command", path)
+	}
+
+	m.path = path
+
+	return m, nil
+}
+
+// FindProvider returns the manifest of the provider plugin called name
+func FindProvider(name string) (*Manifest, error) {
+	dir, err := Dir()
+	if err != nil {
+		return nil, err
+	}
+
+	manifests, err := LoadManifests(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range manifests {
+		if manifests[i].Name == name {
+			return &manifests[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: no provider plugin %q in %s", ErrNotFound, name, dir)
+}
+
+// Executable resolves the manifest's command: a relative path with a
+// directory is taken from the manifest's directory, a bare name from PATH
+func (m *Manifest) Executable() (string, error) {
+	command := m.Command
+
+	if !filepath.IsAbs(command) && strings.ContainsRune(command, filepath.Separator) && m.path != "" {
+		command = filepath.Join(filepath.Dir(m.path), command)
+	}
+
+	path, err := exec.LookPath(command)
+	if err != nil {
+		return "", fmt.Errorf("plugin %s: %w", m.Name, err)
+	}
+
+	return path, nil
+}
+
+// FindCommand returns the executable of the command plugin called name
+func FindCommand(name string) (string, error) {
+	if name == "" || strings.ContainsAny(name, `/\`) || strings.HasPrefix(name, "-") {
+		return "", fmt.Errorf("%w: %q", ErrNotFound, name)
+	}
+
+	path, err := exec.LookPath(CommandPrefix + name)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s%s is not on PATH", ErrNotFound, CommandPrefix, name)
+	}
+
+	return path, nil
+}
+
+// Commands returns the names of the command plugins on PATH, sorted; when
+// several directories have the same plugin, the first one on PATH runs
+func Commands() []string {
+	seen := map[string]bool{}
+
+	var names []string
+
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+
+		for _, entry := range entries {
+			name, ok := strings.CutPrefix(entry.Name(), CommandPrefix)
+			if !ok || name == "" || entry.IsDir() {
+				continue
+			}
+
+			// On Windows the executable is auto-worktree-<name>.exe
+			name = strings.TrimSuffix(name, ".exe")
+
+			if _, err := FindCommand(name); err == nil && !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+
+	sort.Strings(names)
+
+	return names
+}
@@ -0,0 +1,108 @@
+package plugin
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// writeExecutable writes a shell script plugin to dir/name
+func writeExecutable(t *testing.T, dir, name, script string) string {
+	t.Helper()
+
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+script), 0o755); err != nil { //nolint:gosec // plugins must be executable
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func TestLoadManifests(t *testing.T) {
+	dir := t.TempDir()
+	writeExecutable(t, dir, "acme-provider", "exit 0\n")
+
+	files := map[string]string{
+		"zeta.json": `{"name": "acme", "description": "Acme tracker", "command": "./acme-provider", "args": ["--json"]}`,
+		"beta.json": `{"command": "beta-provider"}`,
+		"notes.txt": `not a manifest`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	manifests, err := LoadManifests(dir)
+	if err != nil {
+		t.Fatalf("LoadManifests() error = %v", err)
+	}
+
+	var names []string
+	for _, m := range manifests {
+		names = append(names, m.Name)
+	}
+
+	if !reflect.DeepEqual(names, []string{"acme", "beta"}) {
+		t.Fatalf("LoadManifests() names = %v, want [acme beta]", names)
+	}
+
+	if got := manifests[0].Args; !reflect.DeepEqual(got, []string{"--json"}) {
+		t.Errorf("Args = %v", got)
+	}
+
+	path, err := manifests[0].Executable()
+	if err != nil || path != filepath.Join(dir, "acme-provider") {
+		t.Errorf("Executable() = %q, %v; want the script next to the manifest", path, err)
+	}
+
+	if _, err := manifests[1].Executable(); err == nil {
+		t.Error("Executable() for a command not on PATH should fail")
+	}
+}
+
+func TestLoadManifestsInvalid(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "broken.json"), []byte(`{"name": "broken"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := LoadManifests(dir); err == nil {
+		t.Error("LoadManifests() should reject a manifest without a command")
+	}
+
+	manifests, err := LoadManifests(filepath.Join(dir, "missing"))
+	if err != nil || len(manifests) != 0 {
+		t.Errorf("LoadManifests() on a missing directory = %v, %v; want none", manifests, err)
+	}
+}
+
+func TestFindCommand(t *testing.T) {
+	first, second := t.TempDir(), t.TempDir()
+	want := writeExecutable(t, first, "auto-worktree-hello", "echo hello\n")
+	writeExecutable(t, second, "auto-worktree-hello", "echo shadowed\n")
+	writeExecutable(t, second, "auto-worktree-sync", "echo sync\n")
+
+	if err := os.WriteFile(filepath.Join(second, "auto-worktree-notes"), []byte("not executable"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("PATH", first+string(os.PathListSeparator)+second)
+
+	path, err := FindCommand("hello")
+	if err != nil || path != want {
+		t.Errorf("FindCommand(hello) = %q, %v; want %q", path, err, want)
+	}
+
+	for _, name := range []string{"missing", "", "-v", "../hello"} {
+		if _, err := FindCommand(name); !errors.Is(err, ErrNotFound) {
+			t.Errorf("FindCommand(%q) error = %v, want ErrNotFound", name, err)
+		}
+	}
+
+	if got := Commands(); !reflect.DeepEqual(got, []string{"hello", "sync"}) {
+		t.Errorf("Commands() = %v, want [hello sync]", got)
+	}
+}
@@ -0,0 +1,323 @@
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/kaeawc/auto-worktree/internal/git"
+	"github.com/kaeawc/auto-worktree/internal/limits"
+	"github.com/kaeawc/auto-worktree/internal/offline"
+	"github.com/kaeawc/auto-worktree/internal/providers"
+)
+
+// Provider is an issue tracker implemented by a plugin. Each call runs the
+// plugin's command once, writes a request to its stdin and reads the response
+// from its stdout:
+//
+//	{"method": "get_issue", "params": {"id": "ACME-7"}}
+//	{"result": {"id": "ACME-7", "title": "Fix login", ...}}
+//
+// or {"error": "message"} on failure. A plugin that doesn't support a method
+// returns an error for it. Methods and their params and results:
+//
+//	list_issues            {limit, labels, assignee, milestone, search} -> [issue]
+//	get_issue              {id} -> issue
+//	is_issue_closed        {id} -> bool
+//	list_pull_requests     {limit} -> [pull request]
+//	get_pull_request       {id} -> pull request
+//	is_pull_request_merged {id} -> bool
+//	create_issue           {title, body} -> issue
+//	create_pull_request    {title, body, base_branch, head_branch, reviewers, labels, draft} -> pull request
+//	add_assignee           {id, assignee} -> null
+//	add_comment            {id, body} -> null
+//	add_labels             {id, labels} -> null
+//
+// An issue's priority is 1 (urgent) to 4 (low), or 0 for none, and a
+// milestone of "current" asks for the current milestone or sprint.
+type Provider struct {
+	manifest Manifest
+	// dir is the repository the plugin runs in
+	dir string
+}
+
+// NewProvider creates the provider for a plugin, run from the repository at dir
+func NewProvider(m Manifest, dir string) *Provider {
+	return &Provider{manifest: m, dir: dir}
+}
+
+// request is what a plugin reads from stdin
+type request struct {
+	Method string      `json:"method"`
+	Params interface{} `json:"params"`
+}
+
+// response is what a plugin writes to stdout
+type response struct {
+	Result json.RawMessage `json:"result"`
+	Error  string          `json:"error"`
+}
+
+// issue is providers.Issue on the wire
+type issue struct {
+	ID        string   `json:"id"`
+	Number    int      `json:"number,omitempty"`
+	Key       string   `json:"key,omitempty"`
+	Title     string   `json:"title"`
+	Body      string   `json:"body,omitempty"`
+	URL       string   `json:"url,omitempty"`
+	State     string   `json:"state,omitempty"`
+	Labels    []string `json:"labels,omitempty"`
+	Type      string   `json:"type,omitempty"`
+	Author    string   `json:"author,omitempty"`
+	CreatedAt string   `json:"created_at,omitempty"`
+	UpdatedAt string   `json:"updated_at,omitempty"`
+	Assignee  string   `json:"assignee,omitempty"`
+	Milestone string   `json:"milestone,omitempty"`
+	Priority  int      `json:"priority,omitempty"`
+	IsClosed  bool     `json:"is_closed,omitempty"`
+}
+
+func (i *issue) provider() *providers.Issue {
+	return &providers.Issue{
+		ID: i.ID, Number: i.Number, Key: i.Key, Title: i.Title, Body: i.Body, URL: i.URL,
+		State: i.State, Labels: i.Labels, Type: i.Type, Author: i.Author, CreatedAt: i.CreatedAt,
+		UpdatedAt: i.UpdatedAt, Assignee: i.Assignee, Milestone: i.Milestone, Priority: i.Priority, IsClosed: i.IsClosed,
+	}
+}
+
+// pullRequest is providers.PullRequest on the wire
+type pullRequest struct {
+	ID                 string   `json:"id"`
+	Number             int      `json:"number,omitempty"`
+	Title              string   `json:"title"`
+	Body               string   `json:"body,omitempty"`
+	URL                string   `json:"url,omitempty"`
+	State              string   `json:"state,omitempty"`
+	HeadBranch         string   `json:"head_branch,omitempty"`
+	BaseBranch         string   `json:"base_branch,omitempty"`
+	Labels             []string `json:"labels,omitempty"`
+	Author             string   `json:"author,omitempty"`
+	CreatedAt          string   `json:"created_at,omitempty"`
+	UpdatedAt          string   `json:"updated_at,omitempty"`
+	IsMerged           bool     `json:"is_merged,omitempty"`
+	IsClosed           bool     `json:"is_closed,omitempty"`
+	ReviewersRequested []string `json:"reviewers_requested,omitempty"`
+	Approvals          []string `json:"approvals,omitempty"`
+}
+
+func (pr *pullRequest) provider() *providers.PullRequest {
+	return &providers.PullRequest{
+		ID: pr.ID, Number: pr.Number, Title: pr.Title, Body: pr.Body, URL: pr.URL, State: pr.State,
+		HeadBranch: pr.HeadBranch, BaseBranch: pr.BaseBranch, Labels: pr.Labels, Author: pr.Author,
+		CreatedAt: pr.CreatedAt, UpdatedAt: pr.UpdatedAt, IsMerged: pr.IsMerged, IsClosed: pr.IsClosed,
+		ReviewersRequested: pr.ReviewersRequested, Approvals: pr.Approvals,
+	}
+}
+
+// call runs one request through the plugin and decodes its result into out
+func (p *Provider) call(ctx context.Context, method string, params, out interface{}) error {
+	if offline.Enabled() {
+		return offline.Skipped(p.Name())
+	}
+
+	executable, err := p.manifest.Executable()
+	if err != nil {
+		return err
+	}
+
+	input, err := json.Marshal(request{Method: method, Params: params})
+	if err != nil {
+		return fmt.Errorf("failed to encode %s request: %w", method, err)
+	}
+
+	ctx, cancel := limits.Context(ctx, limits.ToolPlugin)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, executable, p.manifest.Args...)
+	cmd.Dir = p.dir
+	cmd.Stdin = bytes.NewReader(append(input, '\n'))
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		err = limits.Wrap(ctx, limits.ToolPlugin, err)
+
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("plugin %s %s failed: %w: %s", p.manifest.Name, method, err, msg)
+		}
+
+		return fmt.Errorf("plugin %s %s failed: %w", p.manifest.Name, method, err)
+	}
+
+	var resp response
+	if err := json.Unmarshal(stdout.Bytes(), &resp); err != nil {
+		return fmt.Errorf("plugin %s %s returned invalid JSON: %w", p.manifest.Name, method, err)
+	}
+
+	if resp.Error != "" {
+		return fmt.Errorf("plugin %s %s: %s", p.manifest.Name, method, resp.Error)
+	}
+
+	if out == nil || len(resp.Result) == 0 {
+		return nil
+	}
+
+	if err := json.Unmarshal(resp.Result, out); err != nil {
+		return fmt.Errorf("plugin %s %s returned an unexpected result: %w", p.manifest.Name, method, err)
+	}
+
+	return nil
+}
+
+// ListIssues asks the plugin for open issues
+func (p *Provider) ListIssues(ctx context.Context, opts providers.ListIssuesOptions) ([]providers.Issue, error) {
+	params := map[string]interface{}{
+		"limit":     opts.EffectiveLimit(),
+		"labels":    opts.Labels,
+		"assignee":  opts.Assignee,
+		"milestone": opts.Milestone,
+		"search":    opts.Search,
+	}
+
+	var found []issue
+	if err := p.call(ctx, "list_issues", params, &found); err != nil {
+		return nil, err
+	}
+
+	result := make([]providers.Issue, len(found))
+	for i := range found {
+		result[i] = *found[i].provider()
+	}
+
+	return result, nil
+}
+
+// GetIssue asks the plugin for one issue
+func (p *Provider) GetIssue(ctx context.Context, id string) (*providers.Issue, error) {
+	var found issue
+	if err := p.call(ctx, "get_issue", map[string]string{"id": id}, &found); err != nil {
+		return nil, err
+	}
+
+	return found.provider(), nil
+}
+
+// IsIssueClosed asks the plugin whether an issue is closed
+func (p *Provider) IsIssueClosed(ctx context.Context, id string) (bool, error) {
+	var closed bool
+	err := p.call(ctx, "is_issue_closed", map[string]string{"id": id}, &closed)
+
+	return closed, err
+}
+
+// ListPullRequests asks the plugin for open pull requests
+func (p *Provider) ListPullRequests(ctx context.Context, limit int) ([]providers.PullRequest, error) {
+	var found []pullRequest
+	if err := p.call(ctx, "list_pull_requests", map[string]int{"limit": limit}, &found); err != nil {
+		return nil, err
+	}
+
+	result := make([]providers.PullRequest, len(found))
+	for i := range found {
+		result[i] = *found[i].provider()
+	}
+
+	return result, nil
+}
+
+// GetPullRequest asks the plugin for one pull request
+func (p *Provider) GetPullRequest(ctx context.Context, id string) (*providers.PullRequest, error) {
+	var found pullRequest
+	if err := p.call(ctx, "get_pull_request", map[string]string{"id": id}, &found); err != nil {
+		return nil, err
+	}
+
+	return found.provider(), nil
+}
+
+// IsPullRequestMerged asks the plugin whether a pull request is merged
+func (p *Provider) IsPullRequestMerged(ctx context.Context, id string) (bool, error) {
+	var merged bool
+	err := p.call(ctx, "is_pull_request_merged", map[string]string{"id": id}, &merged)
+
+	return merged, err
+}
+
+// CreateIssue asks the plugin to create an issue
+func (p *Provider) CreateIssue(ctx context.Context, title, body string) (*providers.Issue, error) {
+	var created issue
+	if err := p.call(ctx, "create_issue", map[string]string{"title": title, "body": body}, &created); err != nil {
+		return nil, err
+	}
+
+	return created.provider(), nil
+}
+
+// CreatePullRequest asks the plugin to open a pull request
+func (p *Provider) CreatePullRequest(ctx context.Context, opts providers.CreatePullRequestOptions) (*providers.PullRequest, error) {
+	params := map[string]interface{}{
+		"title":       opts.Title,
+		"body":        opts.Body,
+		"base_branch": opts.BaseBranch,
+		"head_branch": opts.HeadBranch,
+		"reviewers":   opts.Reviewers,
+		"labels":      opts.Labels,
+		"draft":       opts.Draft,
+	}
+
+	var created pullRequest
+	if err := p.call(ctx, "create_pull_request", params, &created); err != nil {
+		return nil, err
+	}
+
+	return created.provider(), nil
+}
+
+// AddAssignee asks the plugin to assign an issue
+func (p *Provider) AddAssignee(ctx context.Context, id, assignee string) error {
+	return p.call(ctx, "add_assignee", map[string]string{"id": id, "assignee": assignee}, nil)
+}
+
+// AddComment asks the plugin to comment on an issue
+func (p *Provider) AddComment(ctx context.Context, id, body string) error {
+	return p.call(ctx, "add_comment", map[string]string{"id": id, "body": body}, nil)
+}
+
+// AddLabels asks the plugin to label an issue
+func (p *Provider) AddLabels(ctx context.Context, id string, labels []string) error {
+	return p.call(ctx, "add_labels", map[string]interface{}{"id": id, "labels": labels}, nil)
+}
+
+// GetBranchNameSuffix uses the issue's key, e.g. ACME-7, or its ID
+func (p *Provider) GetBranchNameSuffix(issue *providers.Issue) string {
+	if issue.Key != "" {
+		return issue.Key
+	}
+
+	return issue.ID
+}
+
+// SanitizeBranchName makes a branch name from an issue title
+func (p *Provider) SanitizeBranchName(title string) string {
+	return git.SanitizeBranchName(title)
+}
+
+// Name returns the plugin's description, or its name
+func (p *Provider) Name() string {
+	if p.manifest.Description != "" {
+		return p.manifest.Description
+	}
+
+	return p.manifest.Name
+}
+
+// ProviderType returns plugin:<name>, as set in auto-worktree.issue-provider
+func (p *Provider) ProviderType() string {
+	return git.IssueProviderPluginPrefix + p.manifest.Name
+}
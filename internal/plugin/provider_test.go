@@ -0,0 +1,100 @@
+package plugin
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kaeawc/auto-worktree/internal/offline"
+	"github.com/kaeawc/auto-worktree/internal/providers"
+)
+
+// fakeProvider is a plugin that records its request and answers by method
+const fakeProvider = `read -r request
+echo "$request" > request.json
+case "$request" in
+  *'"list_issues"'*)
+    echo '{"result": [{"id": "7", "key": "ACME-7", "title": "Fix login", "labels": ["bug"]}]}' ;;
+  *'"is_issue_closed"'*)
+    echo '{"result": true}' ;;
+  *'"add_comment"'*)
+    echo '{"result": null}' ;;
+  *'"crash"'*)
+    echo "something broke" >&2
+    exit 1 ;;
+  *)
+    echo '{"error": "unsupported method"}' ;;
+esac
+`
+
+func newFakeProvider(t *testing.T) (*Provider, string) {
+	t.Helper()
+
+	dir := t.TempDir()
+	path := writeExecutable(t, dir, "acme", fakeProvider)
+
+	return NewProvider(Manifest{Name: "acme", Command: path}, dir), dir
+}
+
+func TestProviderCalls(t *testing.T) {
+	p, dir := newFakeProvider(t)
+	ctx := context.Background()
+
+	issues, err := p.ListIssues(ctx, providers.ListIssuesOptions{Labels: []string{"bug"}})
+	if err != nil {
+		t.Fatalf("ListIssues() error = %v", err)
+	}
+
+	if len(issues) != 1 || issues[0].Key != "ACME-7" || issues[0].Title != "Fix login" {
+		t.Fatalf("ListIssues() = %+v", issues)
+	}
+
+	if got := p.GetBranchNameSuffix(&issues[0]); got != "ACME-7" {
+		t.Errorf("GetBranchNameSuffix() = %q, want ACME-7", got)
+	}
+
+	closed, err := p.IsIssueClosed(ctx, "7")
+	if err != nil || !closed {
+		t.Errorf("IsIssueClosed() = %v, %v; want true", closed, err)
+	}
+
+	if err := p.AddComment(ctx, "7", "done"); err != nil {
+		t.Errorf("AddComment() error = %v", err)
+	}
+
+	request, err := os.ReadFile(filepath.Join(dir, "request.json"))
+	if err != nil || strings.TrimSpace(string(request)) != `{"method":"add_comment","params":{"body":"done","id":"7"}}` {
+		t.Errorf("AddComment() sent %s (%v)", request, err)
+	}
+
+	if _, err := p.GetPullRequest(ctx, "3"); err == nil || !strings.Contains(err.Error(), "unsupported method") {
+		t.Errorf("GetPullRequest() error = %v, want the plugin's error", err)
+	}
+
+	if got := p.ProviderType(); got != "plugin:acme" {
+		t.Errorf("ProviderType() = %q", got)
+	}
+}
+
+func TestProviderFailure(t *testing.T) {
+	p, _ := newFakeProvider(t)
+
+	_, err := p.CreateIssue(context.Background(), "crash", "")
+	if err == nil || !strings.Contains(err.Error(), "something broke") {
+		t.Errorf("CreateIssue() error = %v, want the plugin's stderr", err)
+	}
+}
+
+func TestProviderOffline(t *testing.T) {
+	t.Cleanup(offline.Reset)
+	offline.Enable("test")
+
+	p, _ := newFakeProvider(t)
+
+	if _, err := p.GetIssue(context.Background(), "7"); !errors.Is(err, offline.ErrOffline) {
+		t.Errorf("GetIssue() offline error = %v, want ErrOffline", err)
+	}
+}
@@ -5,6 +5,9 @@ import (
 	"fmt"
 	"os/exec"
 	"strings"
+	"time"
+
+	"github.com/kaeawc/auto-worktree/internal/logging"
 )
 
 // GitHubProvider implements Provider for GitHub
@@ -18,7 +21,9 @@ func (g *GitHubProvider) Name() string {
 // IsAvailable checks if gh CLI is installed
 func (g *GitHubProvider) IsAvailable() bool {
 	cmd := exec.Command("gh", "--version")
+	start := time.Now()
 	err := cmd.Run()
+	logging.Command(cmd, start, nil, err)
 	return err == nil
 }
 
@@ -30,7 +35,9 @@ func (g *GitHubProvider) GetIssueStatus(issueID string) (bool, bool, error) {
 
 	// Use gh issue view with JSON output
 	cmd := exec.Command("gh", "issue", "view", issueID, "--json", "state,stateReason,title")
+	start := time.Now()
 	output, err := cmd.Output()
+	logging.Command(cmd, start, output, err)
 	if err != nil {
 		// Check if issue not found
 		if exitErr, ok := err.(*exec.ExitError); ok {
@@ -68,7 +75,9 @@ func (g *GitHubProvider) GetPRStatus(prID string) (bool, error) {
 
 	// Use gh pr view with JSON output
 	cmd := exec.Command("gh", "pr", "view", prID, "--json", "state,merged")
+	start := time.Now()
 	output, err := cmd.Output()
+	logging.Command(cmd, start, output, err)
 	if err != nil {
 		// Check if PR not found
 		if exitErr, ok := err.(*exec.ExitError); ok {
@@ -5,6 +5,9 @@ import (
 	"fmt"
 	"os/exec"
 	"strings"
+	"time"
+
+	"github.com/kaeawc/auto-worktree/internal/logging"
 )
 
 // GitLabProvider implements Provider for GitLab
@@ -18,7 +21,9 @@ func (g *GitLabProvider) Name() string {
 // IsAvailable checks if glab CLI is installed
 func (g *GitLabProvider) IsAvailable() bool {
 	cmd := exec.Command("glab", "--version")
+	start := time.Now()
 	err := cmd.Run()
+	logging.Command(cmd, start, nil, err)
 	return err == nil
 }
 
@@ -30,7 +35,9 @@ func (g *GitLabProvider) GetIssueStatus(issueID string) (bool, bool, error) {
 
 	// Use glab issue view with JSON output
 	cmd := exec.Command("glab", "issue", "view", issueID, "--json")
+	start := time.Now()
 	output, err := cmd.Output()
+	logging.Command(cmd, start, output, err)
 	if err != nil {
 		if exitErr, ok := err.(*exec.ExitError); ok {
 			if strings.Contains(string(exitErr.Stderr), "not found") ||
@@ -65,7 +72,9 @@ func (g *GitLabProvider) GetPRStatus(mrID string) (bool, error) {
 
 	// Use glab mr view with JSON output
 	cmd := exec.Command("glab", "mr", "view", mrID, "--json")
+	start := time.Now()
 	output, err := cmd.Output()
+	logging.Command(cmd, start, output, err)
 	if err != nil {
 		if exitErr, ok := err.(*exec.ExitError); ok {
 			if strings.Contains(string(exitErr.Stderr), "not found") ||
@@ -6,6 +6,9 @@ import (
 	"fmt"
 	"os/exec"
 	"strings"
+	"time"
+
+	"github.com/kaeawc/auto-worktree/internal/logging"
 )
 
 const (
@@ -26,7 +29,9 @@ func (j *JiraProvider) Name() string {
 // IsAvailable checks if jira CLI is installed
 func (j *JiraProvider) IsAvailable() bool {
 	cmd := exec.CommandContext(context.Background(), "jira", "version")
+	start := time.Now()
 	err := cmd.Run()
+	logging.Command(cmd, start, nil, err)
 
 	return err == nil
 }
@@ -41,7 +46,9 @@ func (j *JiraProvider) GetIssueStatus(issueID string) (bool, bool, error) {
 	// Note: The exact CLI command may vary depending on the jira CLI tool used
 	// This assumes go-jira (https://github.com/go-jira/jira)
 	cmd := exec.CommandContext(context.Background(), "jira", "view", issueID, "--template", "json")
+	start := time.Now()
 	output, err := cmd.Output()
+	logging.Command(cmd, start, output, err)
 
 	if err != nil {
 		if exitErr, ok := err.(*exec.ExitError); ok {
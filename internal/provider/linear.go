@@ -6,6 +6,9 @@ import (
 	"fmt"
 	"os/exec"
 	"strings"
+	"time"
+
+	"github.com/kaeawc/auto-worktree/internal/logging"
 )
 
 const (
@@ -25,7 +28,9 @@ func (l *LinearProvider) Name() string {
 // IsAvailable checks if linear CLI is installed
 func (l *LinearProvider) IsAvailable() bool {
 	cmd := exec.CommandContext(context.Background(), "linear", "--version")
+	start := time.Now()
 	err := cmd.Run()
+	logging.Command(cmd, start, nil, err)
 
 	return err == nil
 }
@@ -40,7 +45,9 @@ func (l *LinearProvider) GetIssueStatus(issueID string) (bool, bool, error) {
 
 	// Use linear issue view with JSON output
 	cmd := exec.CommandContext(context.Background(), "linear", "issue", issueID, "--json")
+	start := time.Now()
 	output, err := cmd.Output()
+	logging.Command(cmd, start, output, err)
 
 	if err != nil {
 		if exitErr, ok := err.(*exec.ExitError); ok {
@@ -74,11 +74,28 @@ func ParseBranchName(branchName string) (providerType, id string, found bool) {
 //   - mr/789-description (GitLab MR)
 //   - work/TEAM-123-description (Linear if configuredProvider="linear", else JIRA)
 func ParseBranchNameWithProvider(branchName, configuredProvider string) (providerType, id string, found bool) {
+	return ParseBranchNameWithPrefixes(branchName, configuredProvider, nil)
+}
+
+// ParseBranchNameWithPrefixes is ParseBranchNameWithProvider for repositories that
+// also name issue branches with other prefixes (e.g. fix/123-description), which
+// are then read like work/
+func ParseBranchNameWithPrefixes(branchName, configuredProvider string, issuePrefixes []string) (providerType, id string, found bool) {
 	// Try simple numeric patterns first (GitHub/GitLab) - unambiguous
 	if id, found := extractNumericID(branchName, BranchPrefixWork, 5); found {
 		return ProviderTypeGitHubIssue, id, true
 	}
 
+	for _, prefix := range issuePrefixes {
+		if prefix == BranchPrefixPR || prefix == BranchPrefixMR {
+			continue
+		}
+
+		if id, found := extractNumericID(branchName, prefix, len(prefix)); found {
+			return ProviderTypeGitHubIssue, id, true
+		}
+	}
+
 	if id, found := extractNumericID(branchName, BranchPrefixPR, 3); found {
 		return ProviderTypeGitHubPR, id, true
 	}
@@ -94,14 +111,20 @@ func ParseBranchNameWithProvider(branchName, configuredProvider string) (provide
 	}
 
 	// work/PROJ-123 could be JIRA or Linear - use configured provider
-	if id, found := extractProjectID(branchName, BranchPrefixWork, 5); found {
-		// Use configured provider to disambiguate
-		if configuredProvider == ProviderTypeLinear {
-			return ProviderTypeLinear, id, true
+	for _, prefix := range append([]string{BranchPrefixWork}, issuePrefixes...) {
+		if prefix == BranchPrefixIssue {
+			continue
+		}
+
+		if id, found := extractProjectID(branchName, prefix, len(prefix)); found {
+			// Use configured provider to disambiguate
+			if configuredProvider == ProviderTypeLinear {
+				return ProviderTypeLinear, id, true
+			}
+			// Default to JIRA for backward compatibility
+			// This includes: configuredProvider == "jira" or empty
+			return ProviderTypeJira, id, true
 		}
-		// Default to JIRA for backward compatibility
-		// This includes: configuredProvider == "jira" or empty
-		return ProviderTypeJira, id, true
 	}
 
 	return "", "", false
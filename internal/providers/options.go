@@ -0,0 +1,229 @@
+package providers
+
+import (
+	"sort"
+	"strings"
+)
+
+// AssigneeNone is the assignee filter value that matches only unassigned issues.
+const AssigneeNone = "none"
+
+// MilestoneCurrent is the milestone filter value for the current milestone,
+// sprint or cycle, as each provider defines it.
+const MilestoneCurrent = "current"
+
+// Issue orders for ListIssuesOptions.Sort
+const (
+	// SortRecent keeps the provider's order, most recently updated first
+	SortRecent = "recent"
+	// SortPriority puts the most urgent issues first, then those without a priority
+	SortPriority = "priority"
+)
+
+// Issue priorities, most urgent first; PriorityNone is an issue without one.
+const (
+	PriorityNone = iota
+	PriorityUrgent
+	PriorityHigh
+	PriorityMedium
+	PriorityLow
+)
+
+// DefaultIssueLimit is the number of issues fetched when no limit is given.
+const DefaultIssueLimit = 20
+
+// ListIssuesOptions narrows the set of issues returned by ListIssues.
+// The zero value lists open issues using the provider's defaults.
+type ListIssuesOptions struct {
+	// Limit controls how many issues to fetch (0 means DefaultIssueLimit)
+	Limit int
+	// Labels restricts results to issues carrying all of these labels
+	Labels []string
+	// Assignee filters by assignee: AssigneeSelf, AssigneeNone, or a username
+	Assignee string
+	// Milestone restricts results to issues in this milestone (or sprint/cycle)
+	Milestone string
+	// Search is a free-text query matched against the issue title and body
+	Search string
+	// Sort orders the issues: SortRecent (the default) or SortPriority
+	Sort string
+}
+
+// EffectiveLimit returns the limit to use, applying DefaultIssueLimit when unset.
+func (o ListIssuesOptions) EffectiveLimit() int {
+	if o.Limit > 0 {
+		return o.Limit
+	}
+
+	return DefaultIssueLimit
+}
+
+// IsFiltered returns true if any filter beyond the limit is set.
+func (o ListIssuesOptions) IsFiltered() bool {
+	return len(o.Labels) > 0 || o.Assignee != "" || o.Milestone != "" || o.Search != ""
+}
+
+// Matches reports whether an issue satisfies the label, assignee, milestone and
+// search filters. It is used by providers that cannot filter server-side.
+// AssigneeSelf and MilestoneCurrent cannot be resolved locally, so they are
+// not checked here.
+func (o ListIssuesOptions) Matches(issue *Issue) bool {
+	for _, want := range o.Labels {
+		if !hasLabel(issue.Labels, want) {
+			return false
+		}
+	}
+
+	switch o.Assignee {
+	case "", AssigneeSelf:
+	case AssigneeNone:
+		if issue.Assignee != "" {
+			return false
+		}
+	default:
+		if !strings.EqualFold(issue.Assignee, o.Assignee) {
+			return false
+		}
+	}
+
+	if o.Milestone != "" && o.Milestone != MilestoneCurrent && !strings.EqualFold(issue.Milestone, o.Milestone) {
+		return false
+	}
+
+	if o.Search != "" {
+		query := strings.ToLower(o.Search)
+		if !strings.Contains(strings.ToLower(issue.Title), query) &&
+			!strings.Contains(strings.ToLower(issue.Body), query) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// FilterIssues returns the issues that match the options, up to the effective limit.
+func FilterIssues(issues []Issue, opts ListIssuesOptions) []Issue {
+	limit := opts.EffectiveLimit()
+	result := make([]Issue, 0, len(issues))
+
+	for i := range issues {
+		if !opts.Matches(&issues[i]) {
+			continue
+		}
+
+		result = append(result, issues[i])
+
+		if len(result) >= limit {
+			break
+		}
+	}
+
+	return result
+}
+
+func hasLabel(labels []string, want string) bool {
+	for _, label := range labels {
+		if strings.EqualFold(label, want) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SortIssues orders issues in place as sort asks; issues of equal priority
+// keep the provider's order.
+func SortIssues(issues []Issue, order string) {
+	if order != SortPriority {
+		return
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		return priorityRank(issues[i].Priority) < priorityRank(issues[j].Priority)
+	})
+}
+
+// priorityRank sorts issues without a priority after every other
+func priorityRank(priority int) int {
+	if priority == PriorityNone {
+		return PriorityLow + 1
+	}
+
+	return priority
+}
+
+// PriorityFromName maps a tracker's priority name, e.g. JIRA's Highest or
+// Blocker, or a P0-P4 shorthand, to a priority.
+func PriorityFromName(name string) int {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "urgent", "highest", "blocker", "critical", "p0":
+		return PriorityUrgent
+	case "high", "major", "p1":
+		return PriorityHigh
+	case "medium", "normal", "p2":
+		return PriorityMedium
+	case "low", "lowest", "minor", "trivial", "p3", "p4":
+		return PriorityLow
+	default:
+		return PriorityNone
+	}
+}
+
+// priorityLabelPrefixes start labels that carry a priority, e.g. "priority: high"
+var priorityLabelPrefixes = []string{"priority::", "priority:", "priority/", "priority-", "prio:", "prio/"}
+
+// PriorityFromLabels finds an issue's priority in labels such as "P1",
+// "priority: high" or GitLab's scoped "priority::1"; the most urgent wins.
+func PriorityFromLabels(labels []string) int {
+	best := PriorityNone
+
+	for _, label := range labels {
+		if p := labelPriority(label); p != PriorityNone && priorityRank(p) < priorityRank(best) {
+			best = p
+		}
+	}
+
+	return best
+}
+
+// labelPriority is the priority a single label carries: any priority name
+// after a priority prefix, but only P0-P4 on its own
+func labelPriority(label string) int {
+	name := strings.ToLower(strings.TrimSpace(label))
+
+	for _, prefix := range priorityLabelPrefixes {
+		if rest, ok := strings.CutPrefix(name, prefix); ok {
+			rest = strings.TrimSpace(rest)
+			// A bare number counts like P<number>
+			if len(rest) == 1 && rest[0] >= '0' && rest[0] <= '9' {
+				rest = "p" + rest
+			}
+
+			return PriorityFromName(rest)
+		}
+	}
+
+	if len(name) == 2 && name[0] == 'p' {
+		return PriorityFromName(name)
+	}
+
+	return PriorityNone
+}
+
+// CreatePullRequestOptions describes a pull request to open.
+type CreatePullRequestOptions struct {
+	// Title is the PR title
+	Title string
+	// Body is the PR description
+	Body string
+	// BaseBranch is the branch to merge into (target branch)
+	BaseBranch string
+	// HeadBranch is the branch to merge (source branch); it must be pushed
+	HeadBranch string
+	// Reviewers are the users (or org/team for GitHub) asked to review
+	Reviewers []string
+	// Labels are added to the PR
+	Labels []string
+	// Draft opens the PR as a draft
+	Draft bool
+}
@@ -0,0 +1,105 @@
+package providers
+
+import "testing"
+
+func TestListIssuesOptionsMatches(t *testing.T) {
+	issue := &Issue{
+		Title:     "Crash when opening settings",
+		Body:      "Stack trace attached",
+		Labels:    []string{"bug", "UI"},
+		Milestone: "v2",
+	}
+
+	tests := []struct {
+		name string
+		opts ListIssuesOptions
+		want bool
+	}{
+		{name: "no filters", opts: ListIssuesOptions{}, want: true},
+		{name: "labels case-insensitive", opts: ListIssuesOptions{Labels: []string{"ui", "bug"}}, want: true},
+		{name: "missing label", opts: ListIssuesOptions{Labels: []string{"docs"}}, want: false},
+		{name: "unassigned", opts: ListIssuesOptions{Assignee: AssigneeNone}, want: true},
+		{name: "other assignee", opts: ListIssuesOptions{Assignee: "alice"}, want: false},
+		{name: "self is not checked locally", opts: ListIssuesOptions{Assignee: AssigneeSelf}, want: true},
+		{name: "milestone", opts: ListIssuesOptions{Milestone: "V2"}, want: true},
+		{name: "wrong milestone", opts: ListIssuesOptions{Milestone: "v3"}, want: false},
+		{name: "current milestone is not checked locally", opts: ListIssuesOptions{Milestone: MilestoneCurrent}, want: true},
+		{name: "search title", opts: ListIssuesOptions{Search: "settings"}, want: true},
+		{name: "search body", opts: ListIssuesOptions{Search: "stack trace"}, want: true},
+		{name: "search miss", opts: ListIssuesOptions{Search: "login"}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.opts.Matches(issue); got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterIssuesLimit(t *testing.T) {
+	issues := []Issue{
+		{ID: "1", Labels: []string{"bug"}},
+		{ID: "2"},
+		{ID: "3", Labels: []string{"bug"}},
+		{ID: "4", Labels: []string{"bug"}},
+	}
+
+	got := FilterIssues(issues, ListIssuesOptions{Labels: []string{"bug"}, Limit: 2})
+	if len(got) != 2 || got[0].ID != "1" || got[1].ID != "3" {
+		t.Errorf("FilterIssues() = %+v, want issues 1 and 3", got)
+	}
+
+	if n := (ListIssuesOptions{}).EffectiveLimit(); n != DefaultIssueLimit {
+		t.Errorf("EffectiveLimit() = %d, want %d", n, DefaultIssueLimit)
+	}
+}
+
+func TestPriorityFromLabels(t *testing.T) {
+	tests := []struct {
+		labels []string
+		want   int
+	}{
+		{nil, PriorityNone},
+		{[]string{"bug", "ui"}, PriorityNone},
+		{[]string{"P1"}, PriorityHigh},
+		{[]string{"priority: high"}, PriorityHigh},
+		{[]string{"priority::0"}, PriorityUrgent},
+		{[]string{"prio/low", "p2"}, PriorityMedium},
+		{[]string{"high"}, PriorityNone},
+		{[]string{"pending"}, PriorityNone},
+	}
+
+	for _, tt := range tests {
+		if got := PriorityFromLabels(tt.labels); got != tt.want {
+			t.Errorf("PriorityFromLabels(%v) = %d, want %d", tt.labels, got, tt.want)
+		}
+	}
+}
+
+func TestSortIssues(t *testing.T) {
+	issues := []Issue{
+		{ID: "1"},
+		{ID: "2", Priority: PriorityLow},
+		{ID: "3", Priority: PriorityUrgent},
+		{ID: "4", Priority: PriorityLow},
+	}
+
+	SortIssues(issues, SortRecent)
+
+	if issues[0].ID != "1" {
+		t.Errorf("SortIssues(recent) reordered issues: %+v", issues)
+	}
+
+	SortIssues(issues, SortPriority)
+
+	var ids string
+	for _, issue := range issues {
+		ids += issue.ID
+	}
+
+	if ids != "3241" {
+		t.Errorf("SortIssues(priority) order = %s, want 3241", ids)
+	}
+}
@@ -1,14 +1,20 @@
 // Package providers defines interfaces for different issue tracking and PR management providers.
 package providers
 
-import "context"
+import (
+	"context"
+	"time"
+)
+
+// AssigneeSelf is the assignee value that refers to the currently authenticated user.
+const AssigneeSelf = "@me"
 
 // Provider defines the interface for issue tracking and PR management providers.
 // Implementations should support GitHub, GitLab, JIRA, and Linear.
 type Provider interface {
-	// ListIssues returns all open issues.
-	// Limit controls how many issues to fetch (0 means default limit).
-	ListIssues(ctx context.Context, limit int) ([]Issue, error)
+	// ListIssues returns open issues matching the given options.
+	// The zero value of ListIssuesOptions lists the most recent open issues.
+	ListIssues(ctx context.Context, opts ListIssuesOptions) ([]Issue, error)
 
 	// GetIssue returns details for a specific issue by ID or key.
 	GetIssue(ctx context.Context, id string) (*Issue, error)
@@ -29,8 +35,18 @@ type Provider interface {
 	// CreateIssue creates a new issue with the given details.
 	CreateIssue(ctx context.Context, title, body string) (*Issue, error)
 
-	// CreatePullRequest creates a new pull request.
-	CreatePullRequest(ctx context.Context, title, body, baseBranch, headBranch string) (*PullRequest, error)
+	// CreatePullRequest opens a pull request for a pushed branch.
+	CreatePullRequest(ctx context.Context, opts CreatePullRequestOptions) (*PullRequest, error)
+
+	// AddAssignee assigns an issue to a user.
+	// Pass AssigneeSelf to assign the currently authenticated user.
+	AddAssignee(ctx context.Context, id, assignee string) error
+
+	// AddComment posts a comment on an issue.
+	AddComment(ctx context.Context, id, body string) error
+
+	// AddLabels adds labels to an issue.
+	AddLabels(ctx context.Context, id string, labels []string) error
 
 	// GetBranchNameSuffix returns the suffix to append to branch names
 	// (e.g., "123" for issue 123 in GitHub, "PROJ-456" for JIRA)
@@ -65,6 +81,8 @@ type Issue struct {
 	State string
 	// Labels are the issue labels
 	Labels []string
+	// Type is the issue type, e.g. Bug or Story (JIRA)
+	Type string
 	// Author is the person who created the issue
 	Author string
 	// CreatedAt is the creation timestamp
@@ -73,6 +91,10 @@ type Issue struct {
 	UpdatedAt string
 	// Assignee is the person assigned to the issue (if any)
 	Assignee string
+	// Milestone is the milestone, sprint or cycle the issue belongs to (if any)
+	Milestone string
+	// Priority is one of the Priority constants; PriorityNone when it has none
+	Priority int
 	// IsClosed is true if the issue is closed
 	IsClosed bool
 }
@@ -113,6 +135,51 @@ type PullRequest struct {
 	Approvals []string
 }
 
+// Comment is a comment on an issue, or a review or comment on a pull request.
+type Comment struct {
+	// Author is the commenter's username
+	Author string
+	// Body is the comment text
+	Body string
+	// CreatedAt is when the comment was posted (or the review submitted)
+	CreatedAt time.Time
+	// ReviewState is set on reviews (e.g. "CHANGES_REQUESTED", "APPROVED")
+	ReviewState string
+}
+
+// DiscussionReader is implemented by providers that can read the discussion on
+// an issue and the review feedback on a branch's pull request.
+type DiscussionReader interface {
+	// IssueComments returns the comments on an issue, oldest first.
+	IssueComments(ctx context.Context, id string) ([]Comment, error)
+
+	// PullRequestFeedback returns the reviews and comments on the open pull
+	// request for branch, oldest first; nil when the branch has no pull request.
+	PullRequestFeedback(ctx context.Context, branch string) ([]Comment, error)
+
+	// UnresolvedReviewThreads returns the review threads on the open pull
+	// request for branch that are not resolved; nil when there is no pull request.
+	UnresolvedReviewThreads(ctx context.Context, branch string) ([]ReviewThread, error)
+}
+
+// BoardTracker is implemented by providers that keep issues on a board, so
+// that starting work on an issue can move it to the board's in-progress column.
+type BoardTracker interface {
+	// StartWork moves the issue to the in-progress column and returns the
+	// column's name, or "" when the board isn't set up to move issues.
+	StartWork(ctx context.Context, id string) (string, error)
+}
+
+// ReviewThread is a review discussion on a pull request, usually anchored to a line of its diff.
+type ReviewThread struct {
+	// Path is the file the thread is on; empty for a general discussion
+	Path string
+	// Line is the line in the file; 0 when unknown or outdated
+	Line int
+	// Comments are the thread's comments, oldest first
+	Comments []Comment
+}
+
 // Config contains provider-specific configuration.
 type Config struct {
 	// Provider type (github, gitlab, jira, linear)
@@ -0,0 +1,147 @@
+package providers
+
+import "context"
+
+// SplitProvider routes issue operations to one provider and pull request
+// operations to another, for repositories that track issues in one system
+// (e.g. JIRA) but host code review elsewhere (e.g. GitHub).
+type SplitProvider struct {
+	issues   Provider
+	codeHost Provider
+}
+
+// NewSplitProvider creates a provider that uses issues for issue operations
+// and codeHost for pull request operations.
+func NewSplitProvider(issues, codeHost Provider) *SplitProvider {
+	return &SplitProvider{issues: issues, codeHost: codeHost}
+}
+
+// IssueProvider returns the provider used for issue operations.
+func (s *SplitProvider) IssueProvider() Provider {
+	return s.issues
+}
+
+// CodeHostProvider returns the provider used for pull request operations.
+func (s *SplitProvider) CodeHostProvider() Provider {
+	return s.codeHost
+}
+
+// ListIssues lists issues from the issue provider.
+func (s *SplitProvider) ListIssues(ctx context.Context, opts ListIssuesOptions) ([]Issue, error) {
+	return s.issues.ListIssues(ctx, opts)
+}
+
+// GetIssue fetches an issue from the issue provider.
+func (s *SplitProvider) GetIssue(ctx context.Context, id string) (*Issue, error) {
+	return s.issues.GetIssue(ctx, id)
+}
+
+// IsIssueClosed checks issue state in the issue provider.
+func (s *SplitProvider) IsIssueClosed(ctx context.Context, id string) (bool, error) {
+	return s.issues.IsIssueClosed(ctx, id)
+}
+
+// ListPullRequests lists pull requests from the code host.
+func (s *SplitProvider) ListPullRequests(ctx context.Context, limit int) ([]PullRequest, error) {
+	return s.codeHost.ListPullRequests(ctx, limit)
+}
+
+// GetPullRequest fetches a pull request from the code host.
+func (s *SplitProvider) GetPullRequest(ctx context.Context, id string) (*PullRequest, error) {
+	return s.codeHost.GetPullRequest(ctx, id)
+}
+
+// IsPullRequestMerged checks pull request state on the code host.
+func (s *SplitProvider) IsPullRequestMerged(ctx context.Context, id string) (bool, error) {
+	return s.codeHost.IsPullRequestMerged(ctx, id)
+}
+
+// CreateIssue creates an issue in the issue provider.
+func (s *SplitProvider) CreateIssue(ctx context.Context, title, body string) (*Issue, error) {
+	return s.issues.CreateIssue(ctx, title, body)
+}
+
+// CreatePullRequest creates a pull request on the code host.
+func (s *SplitProvider) CreatePullRequest(ctx context.Context, opts CreatePullRequestOptions) (*PullRequest, error) {
+	return s.codeHost.CreatePullRequest(ctx, opts)
+}
+
+// AddAssignee assigns an issue in the issue provider.
+func (s *SplitProvider) AddAssignee(ctx context.Context, id, assignee string) error {
+	return s.issues.AddAssignee(ctx, id, assignee)
+}
+
+// AddComment comments on an issue in the issue provider.
+func (s *SplitProvider) AddComment(ctx context.Context, id, body string) error {
+	return s.issues.AddComment(ctx, id, body)
+}
+
+// AddLabels labels an issue in the issue provider.
+func (s *SplitProvider) AddLabels(ctx context.Context, id string, labels []string) error {
+	return s.issues.AddLabels(ctx, id, labels)
+}
+
+// GetBranchNameSuffix uses the issue provider's branch naming.
+func (s *SplitProvider) GetBranchNameSuffix(issue *Issue) string {
+	return s.issues.GetBranchNameSuffix(issue)
+}
+
+// SanitizeBranchName uses the issue provider's branch naming.
+func (s *SplitProvider) SanitizeBranchName(title string) string {
+	return s.issues.SanitizeBranchName(title)
+}
+
+// Name returns both provider names (e.g., "JIRA + GitHub").
+func (s *SplitProvider) Name() string {
+	return s.issues.Name() + " + " + s.codeHost.Name()
+}
+
+// ProviderType returns the issue provider type, which determines branch naming.
+func (s *SplitProvider) ProviderType() string {
+	return s.issues.ProviderType()
+}
+
+// CodeHostType returns the code host's provider type, which pull request commands route on.
+func (s *SplitProvider) CodeHostType() string {
+	return s.codeHost.ProviderType()
+}
+
+// CodeHostType returns the provider type hosting p's pull requests: the code
+// host of a SplitProvider, otherwise p's own type.
+func CodeHostType(p Provider) string {
+	if split, ok := p.(*SplitProvider); ok {
+		return split.CodeHostType()
+	}
+
+	return p.ProviderType()
+}
+
+// IssueComments reads comments from the issue provider, when it supports it.
+func (s *SplitProvider) IssueComments(ctx context.Context, id string) ([]Comment, error) {
+	reader, ok := s.issues.(DiscussionReader)
+	if !ok {
+		return nil, nil
+	}
+
+	return reader.IssueComments(ctx, id)
+}
+
+// PullRequestFeedback reads review feedback from the code host, when it supports it.
+func (s *SplitProvider) PullRequestFeedback(ctx context.Context, branch string) ([]Comment, error) {
+	reader, ok := s.codeHost.(DiscussionReader)
+	if !ok {
+		return nil, nil
+	}
+
+	return reader.PullRequestFeedback(ctx, branch)
+}
+
+// UnresolvedReviewThreads reads review threads from the code host, when it supports it.
+func (s *SplitProvider) UnresolvedReviewThreads(ctx context.Context, branch string) ([]ReviewThread, error) {
+	reader, ok := s.codeHost.(DiscussionReader)
+	if !ok {
+		return nil, nil
+	}
+
+	return reader.UnresolvedReviewThreads(ctx, branch)
+}
@@ -0,0 +1,55 @@
+package providers_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kaeawc/auto-worktree/internal/providers"
+	"github.com/kaeawc/auto-worktree/internal/providers/stubs"
+)
+
+func TestSplitProviderRouting(t *testing.T) {
+	ctx := context.Background()
+	issues := stubs.NewJIRAStub()
+	codeHost := stubs.NewGitHubStub()
+
+	split := providers.NewSplitProvider(issues, codeHost)
+
+	if _, err := split.ListIssues(ctx, providers.ListIssuesOptions{}); err != nil {
+		t.Fatalf("ListIssues() error = %v", err)
+	}
+
+	if _, err := split.ListPullRequests(ctx, 0); err != nil {
+		t.Fatalf("ListPullRequests() error = %v", err)
+	}
+
+	_ = split.AddComment(ctx, "PROJ-1", "started") //nolint:errcheck // only routing is checked
+
+	if issues.GetCallCount("ListIssues") != 1 || codeHost.GetCallCount("ListIssues") != 0 {
+		t.Error("ListIssues was not routed to the issue provider")
+	}
+
+	if codeHost.GetCallCount("ListPullRequests") != 1 || issues.GetCallCount("ListPullRequests") != 0 {
+		t.Error("ListPullRequests was not routed to the code host")
+	}
+
+	if issues.GetCallCount("AddComment") != 1 {
+		t.Error("AddComment was not routed to the issue provider")
+	}
+
+	if split.ProviderType() != "jira" {
+		t.Errorf("ProviderType() = %q, want jira", split.ProviderType())
+	}
+
+	if split.CodeHostType() != "github" || providers.CodeHostType(split) != "github" {
+		t.Errorf("CodeHostType() = %q, want github", split.CodeHostType())
+	}
+
+	if got := providers.CodeHostType(codeHost); got != "github" {
+		t.Errorf("CodeHostType() of an unsplit provider = %q, want its own type", got)
+	}
+
+	if split.Name() != "JIRA + GitHub" {
+		t.Errorf("Name() = %q, want %q", split.Name(), "JIRA + GitHub")
+	}
+}
@@ -20,6 +20,8 @@ type StubProvider struct {
 	Issues map[string]*providers.Issue
 	// PRs stored by ID
 	PullRequests map[string]*providers.PullRequest
+	// Comments posted to issues, keyed by issue ID
+	Comments map[string][]string
 	// Errors to return for specific operations
 	Errors map[string]error
 	// Method call tracking for assertions
@@ -41,6 +43,7 @@ func NewStubProvider(name, providerType string) *StubProvider {
 		ProviderTypeValue: providerType,
 		Issues:            make(map[string]*providers.Issue),
 		PullRequests:      make(map[string]*providers.PullRequest),
+		Comments:          make(map[string][]string),
 		Errors:            make(map[string]error),
 		Calls:             []MethodCall{},
 		Config:            &providers.Config{},
@@ -260,9 +263,9 @@ func (s *StubProvider) SetError(method string, err error) {
 	s.Errors[method] = err
 }
 
-// ListIssues returns all issues (or error if configured).
-func (s *StubProvider) ListIssues(_ context.Context, limit int) ([]providers.Issue, error) { //nolint:dupl
-	s.recordCall("ListIssues", limit)
+// ListIssues returns issues matching the options (or error if configured).
+func (s *StubProvider) ListIssues(_ context.Context, opts providers.ListIssuesOptions) ([]providers.Issue, error) {
+	s.recordCall("ListIssues", opts)
 
 	if err, ok := s.Errors["ListIssues"]; ok {
 		return nil, err
@@ -277,11 +280,7 @@ func (s *StubProvider) ListIssues(_ context.Context, limit int) ([]providers.Iss
 		return issues[i].ID < issues[j].ID
 	})
 
-	if limit > 0 && len(issues) > limit {
-		issues = issues[:limit]
-	}
-
-	return issues, nil
+	return providers.FilterIssues(issues, opts), nil
 }
 
 // GetIssue returns a specific issue by ID.
@@ -398,11 +397,14 @@ func (s *StubProvider) CreateIssue(_ context.Context, title, body string) (*prov
 }
 
 // CreatePullRequest creates a new PR.
-func (s *StubProvider) CreatePullRequest(_ context.Context, title, body, baseBranch, headBranch string) (*providers.PullRequest, error) {
-	s.recordCall("CreatePullRequest", map[string]string{
-		"title":      title,
-		"baseBranch": baseBranch,
-		"headBranch": headBranch,
+func (s *StubProvider) CreatePullRequest(_ context.Context, opts providers.CreatePullRequestOptions) (*providers.PullRequest, error) {
+	s.recordCall("CreatePullRequest", map[string]interface{}{
+		"title":      opts.Title,
+		"baseBranch": opts.BaseBranch,
+		"headBranch": opts.HeadBranch,
+		"reviewers":  opts.Reviewers,
+		"labels":     opts.Labels,
+		"draft":      opts.Draft,
 	})
 
 	if err, ok := s.Errors["CreatePullRequest"]; ok {
@@ -411,17 +413,19 @@ func (s *StubProvider) CreatePullRequest(_ context.Context, title, body, baseBra
 
 	newID := fmt.Sprintf("%d", len(s.PullRequests)+1)
 	pr := &providers.PullRequest{
-		ID:         newID,
-		Number:     len(s.PullRequests) + 1,
-		Title:      title,
-		Body:       body,
-		State:      "OPEN",
-		HeadBranch: headBranch,
-		BaseBranch: baseBranch,
-		IsMerged:   false,
-		IsClosed:   false,
-		CreatedAt:  "2025-01-02T15:00:00Z",
-		UpdatedAt:  "2025-01-02T15:00:00Z",
+		ID:                 newID,
+		Number:             len(s.PullRequests) + 1,
+		Title:              opts.Title,
+		Body:               opts.Body,
+		State:              "OPEN",
+		HeadBranch:         opts.HeadBranch,
+		BaseBranch:         opts.BaseBranch,
+		Labels:             opts.Labels,
+		IsMerged:           false,
+		IsClosed:           false,
+		CreatedAt:          "2025-01-02T15:00:00Z",
+		UpdatedAt:          "2025-01-02T15:00:00Z",
+		ReviewersRequested: opts.Reviewers,
 	}
 
 	s.AddPullRequest(pr)
@@ -429,6 +433,63 @@ func (s *StubProvider) CreatePullRequest(_ context.Context, title, body, baseBra
 	return pr, nil
 }
 
+// AddAssignee assigns an issue to a user.
+func (s *StubProvider) AddAssignee(_ context.Context, id, assignee string) error {
+	s.recordCall("AddAssignee", map[string]string{"id": id, "assignee": assignee})
+
+	if err, ok := s.Errors["AddAssignee"]; ok {
+		return err
+	}
+
+	issue, ok := s.Issues[id]
+	if !ok {
+		return fmt.Errorf("issue not found: %s", id)
+	}
+
+	issue.Assignee = assignee
+
+	return nil
+}
+
+// AddComment records a comment on an issue.
+func (s *StubProvider) AddComment(_ context.Context, id, body string) error {
+	s.recordCall("AddComment", map[string]string{"id": id, "body": body})
+
+	if err, ok := s.Errors["AddComment"]; ok {
+		return err
+	}
+
+	if _, ok := s.Issues[id]; !ok {
+		return fmt.Errorf("issue not found: %s", id)
+	}
+
+	if s.Comments == nil {
+		s.Comments = make(map[string][]string)
+	}
+
+	s.Comments[id] = append(s.Comments[id], body)
+
+	return nil
+}
+
+// AddLabels adds labels to an issue.
+func (s *StubProvider) AddLabels(_ context.Context, id string, labels []string) error {
+	s.recordCall("AddLabels", map[string]interface{}{"id": id, "labels": labels})
+
+	if err, ok := s.Errors["AddLabels"]; ok {
+		return err
+	}
+
+	issue, ok := s.Issues[id]
+	if !ok {
+		return fmt.Errorf("issue not found: %s", id)
+	}
+
+	issue.Labels = append(issue.Labels, labels...)
+
+	return nil
+}
+
 // GetBranchNameSuffix returns the suffix for branch names.
 func (s *StubProvider) GetBranchNameSuffix(issue *providers.Issue) string {
 	if issue.Key != "" {
@@ -500,6 +561,7 @@ func (s *StubProvider) GetCallCount(method string) int {
 func (s *StubProvider) Reset() {
 	s.Issues = make(map[string]*providers.Issue)
 	s.PullRequests = make(map[string]*providers.PullRequest)
+	s.Comments = make(map[string][]string)
 	s.Errors = make(map[string]error)
 	s.Calls = []MethodCall{}
 }
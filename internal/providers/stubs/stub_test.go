@@ -46,7 +46,7 @@ func TestStubProvider_AddAndListIssues(t *testing.T) {
 	})
 
 	// List issues
-	issues, err := stub.ListIssues(ctx, 0)
+	issues, err := stub.ListIssues(ctx, providers.ListIssuesOptions{})
 	if err != nil {
 		t.Fatalf("ListIssues() error = %v", err)
 	}
@@ -56,7 +56,7 @@ func TestStubProvider_AddAndListIssues(t *testing.T) {
 	}
 
 	// Check limit
-	issues, err = stub.ListIssues(ctx, 1)
+	issues, err = stub.ListIssues(ctx, providers.ListIssuesOptions{Limit: 1})
 	if err != nil {
 		t.Fatalf("ListIssues() error = %v", err)
 	}
@@ -304,7 +304,7 @@ func TestStubProvider_SetError(t *testing.T) {
 
 	stub.SetError("ListIssues", nil)
 
-	_, err := stub.ListIssues(ctx, 0)
+	_, err := stub.ListIssues(ctx, providers.ListIssuesOptions{})
 	if err != nil {
 		t.Fatalf("ListIssues() error = %v (expected nil after SetError with nil)", err)
 	}
@@ -317,10 +317,10 @@ func TestStubProvider_CallTracking(t *testing.T) {
 	stub.AddIssue(&providers.Issue{ID: "1", Title: "Test"})
 
 	// Call some methods
-	stub.ListIssues(ctx, 0)
+	stub.ListIssues(ctx, providers.ListIssuesOptions{})
 	stub.GetIssue(ctx, "1")
 	stub.Name()
-	stub.ListIssues(ctx, 0)
+	stub.ListIssues(ctx, providers.ListIssuesOptions{})
 
 	// Check call counts
 	if count := stub.GetCallCount("ListIssues"); count != 2 {
@@ -403,7 +403,7 @@ func TestPreBuiltStubs(t *testing.T) {
 				t.Errorf("ProviderType() = %q, want %q", stub.ProviderType(), tt.expectedType)
 			}
 
-			issues, err := stub.ListIssues(context.Background(), 0)
+			issues, err := stub.ListIssues(context.Background(), providers.ListIssuesOptions{})
 			if err != nil {
 				t.Fatalf("ListIssues() error = %v", err)
 			}
@@ -414,3 +414,28 @@ func TestPreBuiltStubs(t *testing.T) {
 		})
 	}
 }
+
+func TestStubProvider_AddAssigneeAndComment(t *testing.T) {
+	stub := NewGitHubStub()
+	ctx := context.Background()
+
+	if err := stub.AddAssignee(ctx, "2", providers.AssigneeSelf); err != nil {
+		t.Fatalf("AddAssignee() error = %v", err)
+	}
+
+	if stub.Issues["2"].Assignee != providers.AssigneeSelf {
+		t.Errorf("Assignee = %q, want %q", stub.Issues["2"].Assignee, providers.AssigneeSelf)
+	}
+
+	if err := stub.AddComment(ctx, "2", "Started work in branch work/124-dark-mode"); err != nil {
+		t.Fatalf("AddComment() error = %v", err)
+	}
+
+	if got := len(stub.Comments["2"]); got != 1 {
+		t.Errorf("len(Comments) = %d, want 1", got)
+	}
+
+	if err := stub.AddComment(ctx, "missing", "hi"); err == nil {
+		t.Error("AddComment() on missing issue expected error, got nil")
+	}
+}
@@ -0,0 +1,282 @@
+// Package redact scrubs tokens and keys from text before it is logged, saved
+// to a transcript, printed from a hook, or sent to an AI tool.
+package redact
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Placeholder replaces each secret that is found
+const Placeholder = "[REDACTED]"
+
+// builtinPatterns match well-known token and key formats
+var builtinPatterns = []string{
+	// GitHub personal, OAuth, user-to-server, server-to-server and refresh tokens
+	`\bgh[pousr]_[A-Za-z0-9]{36,}\b`,
+	`\bgithub_pat_[A-Za-z0-9_]{22,}\b`,
+	// GitLab personal, project and group access tokens
+	`\bglpat-[A-Za-z0-9_\-]{20,}\b`,
+	// AWS access key IDs and secret access keys given as assignments
+	`\b(?:AKIA|ASIA|AGPA|AIDA|AROA|AIPA|ANPA|ANVA)[A-Z0-9]{16}\b`,
+	`(?i)aws_secret_access_key["']?\s*[:=]\s*["']?[A-Za-z0-9/+=]{40}`,
+	// Slack, Anthropic, OpenAI, Linear and Google API keys
+	`\bxox[abposr]-[A-Za-z0-9\-]{10,}\b`,
+	`\bsk-ant-[A-Za-z0-9_\-]{20,}`,
+	`\bsk-(?:proj-)?[A-Za-z0-9_\-]{32,}`,
+	`\blin_api_[A-Za-z0-9]{32,}\b`,
+	`\bAIza[0-9A-Za-z_\-]{35}\b`,
+	// PEM private keys
+	`-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----`,
+}
+
+// secretEnvSuffixes name environment variables whose values are secrets
+var secretEnvSuffixes = []string{"_TOKEN", "_API_KEY", "_SECRET", "_SECRET_KEY", "_PASSWORD"}
+
+// minSecretLength skips short variable values, which would match ordinary text
+const minSecretLength = 8
+
+// Redactor replaces secrets in text
+type Redactor struct {
+	patterns []*regexp.Regexp
+	// values are secret environment variable values, longest first so a
+	// value containing another is replaced whole
+	values []string
+}
+
+var (
+	mu      sync.RWMutex
+	current = New(nil, os.Environ())
+)
+
+// New creates a redactor for the built-in patterns, extra patterns, and the
+// values of secret variables (*_TOKEN, *_API_KEY, ...) in environ
+func New(extra []*regexp.Regexp, environ []string) *Redactor {
+	r := &Redactor{}
+
+	for _, pattern := range builtinPatterns {
+		r.patterns = append(r.patterns, regexp.MustCompile(pattern))
+	}
+
+	r.patterns = append(r.patterns, extra...)
+
+	for _, kv := range environ {
+		name, value, ok := strings.Cut(kv, "=")
+		if ok && len(value) >= minSecretLength && isSecretEnv(name) {
+			r.values = append(r.values, value)
+		}
+	}
+
+	sort.Slice(r.values, func(i, j int) bool { return len(r.values[i]) > len(r.values[j]) })
+
+	return r
+}
+
+// isSecretEnv reports whether the variable called name holds a secret
+func isSecretEnv(name string) bool {
+	name = strings.ToUpper(name)
+
+	for _, suffix := range secretEnvSuffixes {
+		if strings.HasSuffix(name, suffix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ParsePatterns parses whitespace-separated regular expressions, as in
+// auto-worktree.redact-patterns
+func ParsePatterns(value string) ([]*regexp.Regexp, error) {
+	var patterns []*regexp.Regexp
+
+	for _, field := range strings.Fields(value) {
+		re, err := regexp.Compile(field)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redaction pattern %q: %w", field, err)
+		}
+
+		patterns = append(patterns, re)
+	}
+
+	return patterns, nil
+}
+
+// Configure rebuilds the redactor with extra patterns and the current
+// environment; call it once stored credentials have been exported
+func Configure(extra []*regexp.Regexp) {
+	r := New(extra, os.Environ())
+
+	mu.Lock()
+	current = r
+	mu.Unlock()
+}
+
+// String replaces every secret in s with Placeholder
+func (r *Redactor) String(s string) string {
+	for _, value := range r.values {
+		s = strings.ReplaceAll(s, value, Placeholder)
+	}
+
+	for _, re := range r.patterns {
+		s = re.ReplaceAllLiteralString(s, Placeholder)
+	}
+
+	return s
+}
+
+// String redacts s with the configured redactor
+func String(s string) string {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	return current.String(s)
+}
+
+// Bytes redacts b with the configured redactor
+func Bytes(b []byte) []byte {
+	return []byte(String(string(b)))
+}
+
+// keyBegin and keyEnd delimit a PEM private key block, which spans lines
+var (
+	keyBegin = regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----`)
+	keyEnd   = regexp.MustCompile(`-----END [A-Z ]*PRIVATE KEY-----`)
+)
+
+// maxKeyBlock bounds how much of a private key block with no END line is
+// held back; past it the block is replaced and dropped until the END line
+const maxKeyBlock = 64 << 10
+
+// Writer redacts what is written to it a line at a time, so a secret split
+// across writes is still found. A private key block is held back until its
+// END line, so it is redacted whole. Close writes out a final partial line.
+type Writer struct {
+	mu  sync.Mutex
+	w   io.Writer
+	buf []byte
+	// skipping drops lines of a private key block too long to hold
+	skipping bool
+}
+
+// NewWriter returns a Writer that writes redacted text to w
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: w}
+}
+
+// Write buffers p and writes out each complete line, redacted, apart from an
+// unfinished private key block
+func (w *Writer) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.buf = append(w.buf, p...)
+
+	if w.skipping && !w.skipKeyBlock() {
+		return len(p), nil
+	}
+
+	end := bytes.LastIndexByte(w.buf, '\n')
+	if end < 0 {
+		return len(p), nil
+	}
+
+	ready := end + 1
+
+	if start := openKeyBlock(w.buf[:ready]); start >= 0 {
+		// Hold the block from the start of its line until the END line arrives
+		ready = bytes.LastIndexByte(w.buf[:start], '\n') + 1
+
+		if len(w.buf)-ready > maxKeyBlock {
+			if _, err := w.w.Write(append(Bytes(w.buf[:ready]), Placeholder+"\n"...)); err != nil {
+				return 0, err
+			}
+
+			w.buf = w.buf[:0]
+			w.skipping = true
+
+			return len(p), nil
+		}
+	}
+
+	if ready == 0 {
+		return len(p), nil
+	}
+
+	if _, err := w.w.Write(Bytes(w.buf[:ready])); err != nil {
+		return 0, err
+	}
+
+	w.buf = append(w.buf[:0], w.buf[ready:]...)
+
+	return len(p), nil
+}
+
+// skipKeyBlock drops buffered text up to the END line of the key block being
+// skipped and reports whether it was found; otherwise only a trailing partial
+// line, which may hold the start of the END line, is kept
+func (w *Writer) skipKeyBlock() bool {
+	if loc := keyEnd.FindIndex(w.buf); loc != nil {
+		// The placeholder written in its place already ended the line
+		rest := bytes.TrimPrefix(w.buf[loc[1]:], []byte("\n"))
+		w.buf = append(w.buf[:0], rest...)
+		w.skipping = false
+
+		return true
+	}
+
+	if end := bytes.LastIndexByte(w.buf, '\n'); end >= 0 {
+		w.buf = append(w.buf[:0], w.buf[end+1:]...)
+	}
+
+	return false
+}
+
+// openKeyBlock returns the offset of the first private key BEGIN line in b
+// that no END line follows, or -1
+func openKeyBlock(b []byte) int {
+	offset := 0
+	if ends := keyEnd.FindAllIndex(b, -1); len(ends) > 0 {
+		offset = ends[len(ends)-1][1]
+	}
+
+	if loc := keyBegin.FindIndex(b[offset:]); loc != nil {
+		return offset + loc[0]
+	}
+
+	return -1
+}
+
+// Close writes out any buffered partial line. A private key block that never
+// ended is replaced rather than written.
+func (w *Writer) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	buf := w.buf
+	w.buf = nil
+
+	if w.skipping {
+		w.skipping = false
+		return nil
+	}
+
+	if len(buf) == 0 {
+		return nil
+	}
+
+	out := Bytes(buf)
+	if start := openKeyBlock(buf); start >= 0 {
+		out = append(Bytes(buf[:start]), Placeholder...)
+	}
+
+	_, err := w.w.Write(out)
+
+	return err
+}
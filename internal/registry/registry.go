@@ -0,0 +1,164 @@
+// Package registry remembers the repositories auto-worktree has been used in,
+// so commands can target any of them by name without changing directory.
+package registry
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kaeawc/auto-worktree/internal/state"
+)
+
+// touchInterval limits how often using a repository rewrites its entry
+const touchInterval = time.Hour
+
+// ErrUnknownRepo is returned by Lookup when no registered repository matches
+var ErrUnknownRepo = errors.New("unknown repository")
+
+// Repo is a registered repository
+type Repo struct {
+	// Name is the short name used with --repo: the directory name, or
+	// parent/name when several registered repositories share a directory name
+	Name string `json:"-"`
+	// Path is the root of the repository's main working tree
+	Path string `json:"path"`
+	// LastUsed is when auto-worktree last ran in the repository
+	LastUsed time.Time `json:"lastUsed"`
+}
+
+// Store reads and writes the registry in the state database
+type Store struct {
+	db *state.Store
+	mu sync.Mutex
+}
+
+// NewStore creates a registry backed by the state database at path
+func NewStore(path string) *Store {
+	return &Store{db: state.NewStore(path)}
+}
+
+// Touch registers the repository rooted at path, or records that it was used again
+func (s *Store) Touch(path string, now time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var existing Repo
+
+	err := s.db.Get(state.BucketRepos, path, &existing)
+	if err == nil && now.Sub(existing.LastUsed) < touchInterval {
+		return nil
+	}
+
+	if err != nil && !errors.Is(err, state.ErrNotFound) {
+		return fmt.Errorf("failed to read repository registry: %w", err)
+	}
+
+	if err := s.db.Put(state.BucketRepos, path, Repo{Path: path, LastUsed: now}); err != nil {
+		return fmt.Errorf("failed to register repository: %w", err)
+	}
+
+	return nil
+}
+
+// Remove forgets a repository
+func (s *Store) Remove(path string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.db.Delete(state.BucketRepos, path); err != nil {
+		return fmt.Errorf("failed to remove repository: %w", err)
+	}
+
+	return nil
+}
+
+// List returns every registered repository, most recently used first
+func (s *Store) List() ([]Repo, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var repos []Repo
+
+	err := s.db.ForEach(state.BucketRepos, func(_ string, value []byte) error {
+		var r Repo
+		if err := json.Unmarshal(value, &r); err != nil {
+			return fmt.Errorf("failed to parse repository registry: %w", err)
+		}
+
+		repos = append(repos, r)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	assignNames(repos)
+
+	sort.Slice(repos, func(i, j int) bool {
+		if !repos[i].LastUsed.Equal(repos[j].LastUsed) {
+			return repos[i].LastUsed.After(repos[j].LastUsed)
+		}
+
+		return repos[i].Name < repos[j].Name
+	})
+
+	return repos, nil
+}
+
+// Lookup finds a registered repository by name, directory name, or path
+func (s *Store) Lookup(name string) (Repo, error) {
+	repos, err := s.List()
+	if err != nil {
+		return Repo{}, err
+	}
+
+	var matches []Repo
+
+	for _, r := range repos {
+		switch {
+		case r.Name == name, r.Path == filepath.Clean(name):
+			return r, nil
+		case filepath.Base(r.Path) == name:
+			matches = append(matches, r)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return Repo{}, fmt.Errorf("%w %q; run 'auto-worktree repos' to see registered repositories", ErrUnknownRepo, name)
+	case 1:
+		return matches[0], nil
+	default:
+		names := make([]string, len(matches))
+		for i, m := range matches {
+			names[i] = m.Name
+		}
+
+		return Repo{}, fmt.Errorf("%q matches several repositories: %s", name, strings.Join(names, ", "))
+	}
+}
+
+// assignNames gives each repository its directory name, qualified with the
+// parent directory when the directory name alone is ambiguous
+func assignNames(repos []Repo) {
+	counts := map[string]int{}
+	for _, r := range repos {
+		counts[filepath.Base(r.Path)]++
+	}
+
+	for i, r := range repos {
+		base := filepath.Base(r.Path)
+		if counts[base] > 1 {
+			base = filepath.Join(filepath.Base(filepath.Dir(r.Path)), base)
+		}
+
+		repos[i].Name = base
+	}
+}
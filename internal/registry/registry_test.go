@@ -0,0 +1,97 @@
+package registry
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestStoreTouchAndList(t *testing.T) {
+	store := NewStore(filepath.Join(t.TempDir(), "state.db"))
+	now := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+
+	repos, err := store.List()
+	if err != nil || len(repos) != 0 {
+		t.Fatalf("List() on missing file = %v, %v; want empty", repos, err)
+	}
+
+	for i, path := range []string{"/src/api", "/work/api", "/src/web"} {
+		if err := store.Touch(path, now.Add(time.Duration(i)*time.Hour)); err != nil {
+			t.Fatalf("Touch(%q) error = %v", path, err)
+		}
+	}
+
+	// Touching again within the interval keeps the entry unchanged
+	if err := store.Touch("/src/api", now.Add(30*time.Minute)); err != nil {
+		t.Fatalf("Touch() error = %v", err)
+	}
+
+	repos, err = store.List()
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+
+	want := []string{"web", "work/api", "src/api"}
+	if len(repos) != len(want) {
+		t.Fatalf("List() returned %d repos, want %d", len(repos), len(want))
+	}
+
+	for i, name := range want {
+		if repos[i].Name != name {
+			t.Errorf("repos[%d].Name = %q, want %q", i, repos[i].Name, name)
+		}
+	}
+
+	if !repos[2].LastUsed.Equal(now) {
+		t.Errorf("LastUsed = %v, want %v (touch within the interval is ignored)", repos[2].LastUsed, now)
+	}
+}
+
+func TestStoreLookup(t *testing.T) {
+	store := NewStore(filepath.Join(t.TempDir(), "state.db"))
+	now := time.Now()
+
+	for _, path := range []string{"/src/api", "/work/api", "/src/web"} {
+		if err := store.Touch(path, now); err != nil {
+			t.Fatalf("Touch(%q) error = %v", path, err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		wantPath string
+		wantErr  bool
+	}{
+		{"web", "/src/web", false},
+		{"work/api", "/work/api", false},
+		{"/src/api", "/src/api", false},
+		{"api", "", true},
+		{"missing", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, err := store.Lookup(tt.name)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Lookup(%q) error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+
+			if repo.Path != tt.wantPath {
+				t.Errorf("Lookup(%q) = %q, want %q", tt.name, repo.Path, tt.wantPath)
+			}
+		})
+	}
+
+	if _, err := store.Lookup("missing"); !errors.Is(err, ErrUnknownRepo) {
+		t.Errorf("Lookup(missing) error = %v, want ErrUnknownRepo", err)
+	}
+
+	if err := store.Remove("/src/web"); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+
+	if _, err := store.Lookup("web"); err == nil {
+		t.Error("Lookup() found a removed repository")
+	}
+}
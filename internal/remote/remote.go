@@ -0,0 +1,216 @@
+// Package remote runs commands on another machine over SSH, for --host.
+// When no host is configured every helper falls back to running locally,
+// so callers use it unconditionally.
+package remote
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// ErrNotFound is returned by LookPath when the command is not on the host's PATH
+var ErrNotFound = errors.New("executable file not found on remote host")
+
+var (
+	mu   sync.RWMutex
+	host string
+	// dir is the remote directory that stands in for the local working directory
+	dir string
+)
+
+// Configure sets the SSH destination from "host" or "host:dir" (as in scp).
+// The directory is where commands run when the caller gives none, in place
+// of the local working directory; without one, commands start in the remote
+// home directory.
+func Configure(target string) error {
+	h, d, _ := strings.Cut(target, ":")
+	if h == "" || strings.HasPrefix(h, "-") {
+		return fmt.Errorf("invalid host %q: use --host <host> or --host <host>:<dir>", target)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	host, dir = h, d
+
+	return nil
+}
+
+// Enabled reports whether commands run on a remote host
+func Enabled() bool {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	return host != ""
+}
+
+// Host returns the SSH destination, or "" when running locally
+func Host() string {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	return host
+}
+
+// Command builds a command that runs name in dir, on the remote host when one
+// is configured. env entries (KEY=VALUE) are added to the command's environment.
+// An empty dir means the current directory, or the --host directory remotely.
+func Command(ctx context.Context, dir string, env []string, name string, args ...string) *exec.Cmd {
+	if !Enabled() {
+		cmd := exec.CommandContext(ctx, name, args...)
+		cmd.Dir = dir
+
+		if len(env) > 0 {
+			cmd.Env = append(os.Environ(), env...)
+		}
+
+		return cmd
+	}
+
+	return sshCommand(ctx, false, Script(dir, env, name, args...))
+}
+
+// InteractiveCommand is like Command but allocates a terminal on the remote
+// host, for tools the user works in directly
+func InteractiveCommand(ctx context.Context, dir string, name string, args ...string) *exec.Cmd {
+	if !Enabled() {
+		cmd := exec.CommandContext(ctx, name, args...)
+		cmd.Dir = dir
+
+		return cmd
+	}
+
+	return sshCommand(ctx, true, Script(dir, nil, name, args...))
+}
+
+// Shell builds a command that runs a POSIX shell script, on the remote host when one is configured
+func Shell(ctx context.Context, script string) *exec.Cmd {
+	if !Enabled() {
+		return exec.CommandContext(ctx, "sh", "-c", script)
+	}
+
+	return sshCommand(ctx, false, script)
+}
+
+// LookPath reports whether name is on PATH, checking the remote host when one is configured
+func LookPath(name string) error {
+	if !Enabled() {
+		_, err := exec.LookPath(name)
+		return err
+	}
+
+	if err := Shell(context.Background(), "command -v "+Quote(name)+" >/dev/null").Run(); err != nil {
+		return fmt.Errorf("%s: %w", name, ErrNotFound)
+	}
+
+	return nil
+}
+
+// AttachCommand returns the shell command line a user types to run command in
+// a terminal, wrapped in ssh -t when running remotely
+func AttachCommand(command string) string {
+	h := Host()
+	if h == "" {
+		return command
+	}
+
+	return fmt.Sprintf("ssh -t %s %s", Quote(h), Quote(command))
+}
+
+// Script renders name and args as a shell command line that first changes into dir
+func Script(dir string, env []string, name string, args ...string) string {
+	var b strings.Builder
+
+	if d := workDir(dir); d != "" {
+		b.WriteString("cd " + quotePath(d) + " && ")
+	}
+
+	if len(env) > 0 {
+		b.WriteString("env ")
+
+		for _, e := range env {
+			b.WriteString(Quote(e) + " ")
+		}
+	}
+
+	b.WriteString(Quote(name))
+
+	for _, a := range args {
+		b.WriteString(" " + Quote(a))
+	}
+
+	return b.String()
+}
+
+// Quote single-quotes s for a POSIX shell
+func Quote(s string) string {
+	if s != "" && strings.IndexFunc(s, needsQuoting) < 0 {
+		return s
+	}
+
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// needsQuoting reports characters outside the set that is safe unquoted in a shell word
+func needsQuoting(r rune) bool {
+	switch {
+	case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		return false
+	case strings.ContainsRune("-_./=:,+@%", r):
+		return false
+	default:
+		return true
+	}
+}
+
+// quotePath quotes a path but leaves a leading ~/ for the remote shell to expand
+func quotePath(p string) string {
+	if rest, ok := strings.CutPrefix(p, "~/"); ok {
+		return "~/" + Quote(rest)
+	}
+
+	if p == "~" {
+		return p
+	}
+
+	return Quote(p)
+}
+
+// workDir resolves the directory a remote command runs in
+func workDir(d string) string {
+	if d == "" || d == "." {
+		mu.RLock()
+		defer mu.RUnlock()
+
+		return dir
+	}
+
+	return d
+}
+
+// sshCommand runs script on the remote host through sh, so the remote login
+// shell's syntax does not matter. Connections are shared for a minute so the
+// many short git commands of one invocation do not each pay for a handshake.
+func sshCommand(ctx context.Context, tty bool, script string) *exec.Cmd {
+	args := []string{
+		"-o", "ControlMaster=auto",
+		"-o", "ControlPath=" + filepath.Join(os.TempDir(), "auto-worktree-ssh-%C"),
+		"-o", "ControlPersist=60",
+	}
+
+	if tty {
+		args = append(args, "-t")
+	} else {
+		args = append(args, "-o", "BatchMode=yes")
+	}
+
+	args = append(args, Host(), "--", "sh -c "+Quote(script))
+
+	return exec.CommandContext(ctx, "ssh", args...) //nolint:gosec // host comes from --host
+}
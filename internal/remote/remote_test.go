@@ -0,0 +1,86 @@
+package remote
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+// useHost configures a remote host for one test
+func useHost(t *testing.T, target string) {
+	t.Helper()
+
+	if err := Configure(target); err != nil {
+		t.Fatalf("Configure(%q) error = %v", target, err)
+	}
+
+	t.Cleanup(func() {
+		mu.Lock()
+		host, dir = "", ""
+		mu.Unlock()
+	})
+}
+
+func TestConfigure(t *testing.T) {
+	for _, bad := range []string{"", ":src", "-oProxyCommand=x"} {
+		if err := Configure(bad); err == nil {
+			t.Errorf("Configure(%q) succeeded, want an error", bad)
+		}
+	}
+
+	useHost(t, "dev-box:~/src/app")
+
+	if !Enabled() || Host() != "dev-box" {
+		t.Fatalf("Enabled() = %v, Host() = %q; want dev-box", Enabled(), Host())
+	}
+}
+
+func TestQuote(t *testing.T) {
+	tests := map[string]string{
+		"status":          "status",
+		"--format=%H":     "--format=%H",
+		"":                "''",
+		"two words":       "'two words'",
+		"it's":            `'it'\''s'`,
+		"#{session_name}": "'#{session_name}'",
+	}
+
+	for in, want := range tests {
+		if got := Quote(in); got != want {
+			t.Errorf("Quote(%q) = %s, want %s", in, got, want)
+		}
+	}
+}
+
+func TestScript(t *testing.T) {
+	useHost(t, "dev-box:~/src/my app")
+
+	if got, want := Script("", nil, "git", "status"), "cd ~/'src/my app' && git status"; got != want {
+		t.Errorf("Script() = %q, want %q", got, want)
+	}
+
+	got := Script("/home/me/wt", []string{"TERM=tmux-256color"}, "tmux", "new-session", "-s", "a b")
+	if want := "cd /home/me/wt && env TERM=tmux-256color tmux new-session -s 'a b'"; got != want {
+		t.Errorf("Script() = %q, want %q", got, want)
+	}
+}
+
+func TestCommand(t *testing.T) {
+	local := Command(context.Background(), "/repo", nil, "git", "status")
+	if local.Args[0] != "git" || local.Dir != "/repo" {
+		t.Errorf("local Command() = %v in %q, want git in /repo", local.Args, local.Dir)
+	}
+
+	useHost(t, "dev-box")
+
+	cmd := Command(context.Background(), "/repo", nil, "git", "status")
+	args := strings.Join(cmd.Args, " ")
+
+	if cmd.Args[0] != "ssh" || !strings.Contains(args, "BatchMode=yes dev-box -- sh -c 'cd /repo && git status'") {
+		t.Errorf("remote Command() = %s", args)
+	}
+
+	if got, want := AttachCommand("tmux attach -t s"), "ssh -t dev-box 'tmux attach -t s'"; got != want {
+		t.Errorf("AttachCommand() = %q, want %q", got, want)
+	}
+}
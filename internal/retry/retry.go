@@ -0,0 +1,158 @@
+// Package retry reruns provider CLI commands (gh, glab, linear) that fail for
+// reasons that pass: dropped connections, 5xx responses and rate limits.
+package retry
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kaeawc/auto-worktree/internal/limits"
+	"github.com/kaeawc/auto-worktree/internal/logging"
+	"github.com/kaeawc/auto-worktree/internal/offline"
+)
+
+// ErrRateLimited marks a command that still hit the service's rate limit after every retry
+var ErrRateLimited = errors.New("rate limited")
+
+// Kind is how a failed command should be handled
+type Kind int
+
+// Kinds of failure
+const (
+	// Permanent failures are returned at once
+	Permanent Kind = iota
+	// Transient failures are retried after a short, growing backoff
+	Transient
+	// RateLimited failures are retried after a longer wait
+	RateLimited
+)
+
+// Policy is how many times, and how long apart, a command is attempted
+type Policy struct {
+	Attempts int
+	// Backoff is the wait before the first retry of a transient failure; it doubles each time
+	Backoff time.Duration
+	// RateLimitWait is the wait before the first retry of a rate-limited command; it doubles each time
+	RateLimitWait time.Duration
+	// MaxWait caps any single wait
+	MaxWait time.Duration
+}
+
+// DefaultPolicy is used by the provider executors
+var DefaultPolicy = Policy{
+	Attempts:      3,
+	Backoff:       time.Second,
+	RateLimitWait: 20 * time.Second,
+	MaxWait:       time.Minute,
+}
+
+// sleep is replaced in tests
+var sleep = time.Sleep
+
+var rateLimitMarkers = []string{
+	"rate limit", // "API rate limit exceeded", "You have exceeded a secondary rate limit"
+	"abuse detection",
+	"http 429",
+	"too many requests",
+}
+
+var transientMarkers = []string{
+	"connection reset",
+	"connection refused",
+	"i/o timeout",
+	"tls handshake timeout",
+	"unexpected eof",
+	"temporary failure in name resolution",
+	"http 500",
+	"http 502",
+	"http 503",
+	"http 504",
+	"bad gateway",
+	"service unavailable",
+	"gateway timeout",
+	"something went wrong while executing your query",
+}
+
+// Classify decides from a failed command's output and error whether retrying can help.
+// Commands stopped by their timeout are not retried: they already took as long as allowed.
+func Classify(output []byte, err error) Kind {
+	if err == nil || errors.Is(err, limits.ErrTimeout) {
+		return Permanent
+	}
+
+	text := strings.ToLower(string(output) + " " + err.Error())
+
+	for _, marker := range rateLimitMarkers {
+		if strings.Contains(text, marker) {
+			return RateLimited
+		}
+	}
+
+	for _, marker := range transientMarkers {
+		if strings.Contains(text, marker) {
+			return Transient
+		}
+	}
+
+	return Permanent
+}
+
+// Run calls attempt until it succeeds, fails permanently or runs out of
+// attempts. service names the code host or tracker in messages, e.g. "GitHub".
+// A command still rate limited at the end fails with ErrRateLimited. While
+// offline, attempt is not called and Run fails with offline.ErrOffline; a
+// failure showing the network is unreachable switches to offline.
+func (p Policy) Run(service string, attempt func() ([]byte, error)) ([]byte, error) {
+	if offline.Enabled() {
+		return nil, offline.Skipped(service)
+	}
+
+	var (
+		output []byte
+		err    error
+		kind   Kind
+	)
+
+	for i := 0; i < max(p.Attempts, 1); i++ {
+		output, err = attempt()
+
+		kind = Classify(output, err)
+		if kind == Permanent || i == p.Attempts-1 || offline.IsUnreachable(output, err) {
+			// No network is not a blip worth waiting out
+			break
+		}
+
+		if kind == RateLimited {
+			wait := p.wait(p.RateLimitWait, i)
+			logging.Warn(fmt.Sprintf("%s rate limit hit; retrying in %s", service, wait))
+			sleep(wait)
+
+			continue
+		}
+
+		wait := p.wait(p.Backoff, i)
+		logging.Debug("retrying after a transient failure", "service", service, "in", wait, "err", err)
+		sleep(wait)
+	}
+
+	if kind == RateLimited {
+		return output, fmt.Errorf("%w: %s is still limiting requests after %d attempts; wait a few minutes and try again: %w",
+			ErrRateLimited, service, p.Attempts, err)
+	}
+
+	offline.Detect(service, output, err)
+
+	return output, err
+}
+
+// wait is the delay before retry number i (from 0): first doubled i times, capped at MaxWait
+func (p Policy) wait(first time.Duration, i int) time.Duration {
+	d := first << i
+	if p.MaxWait > 0 && (d > p.MaxWait || d <= 0) {
+		return p.MaxWait
+	}
+
+	return d
+}
@@ -0,0 +1,134 @@
+package retry
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/kaeawc/auto-worktree/internal/limits"
+	"github.com/kaeawc/auto-worktree/internal/offline"
+)
+
+// noSleep records the waits instead of sleeping for the rest of the test
+func noSleep(t *testing.T) *[]time.Duration {
+	t.Helper()
+
+	var waits []time.Duration
+
+	old := sleep
+	sleep = func(d time.Duration) { waits = append(waits, d) }
+
+	t.Cleanup(func() { sleep = old })
+
+	return &waits
+}
+
+func TestClassify(t *testing.T) {
+	failed := errors.New("exit status 1")
+
+	tests := []struct {
+		output string
+		err    error
+		want   Kind
+	}{
+		{"You have exceeded a secondary rate limit. Please wait a few minutes", failed, RateLimited},
+		{"HTTP 403: API rate limit exceeded for user ID 1", failed, RateLimited},
+		{"HTTP 429: Too Many Requests", failed, RateLimited},
+		{"HTTP 502: Bad Gateway (https://api.github.com/graphql)", failed, Transient},
+		{"read tcp: connection reset by peer", failed, Transient},
+		{"GraphQL: Could not resolve to an issue with the number of 7", failed, Permanent},
+		{"", fmt.Errorf("%w: gh took too long", limits.ErrTimeout), Permanent},
+		{"HTTP 502", nil, Permanent},
+	}
+
+	for _, tt := range tests {
+		if got := Classify([]byte(tt.output), tt.err); got != tt.want {
+			t.Errorf("Classify(%q, %v) = %v, want %v", tt.output, tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestRunRetriesTransientFailures(t *testing.T) {
+	waits := noSleep(t)
+
+	calls := 0
+	output, err := DefaultPolicy.Run("GitHub", func() ([]byte, error) {
+		calls++
+		if calls < 3 {
+			return []byte("HTTP 503: Service Unavailable"), errors.New("exit status 1")
+		}
+
+		return []byte("ok"), nil
+	})
+
+	if err != nil || string(output) != "ok" || calls != 3 {
+		t.Fatalf("Run() = %q, %v after %d calls; want ok after 3", output, err, calls)
+	}
+
+	if want := []time.Duration{time.Second, 2 * time.Second}; len(*waits) != 2 || (*waits)[0] != want[0] || (*waits)[1] != want[1] {
+		t.Errorf("waits = %v, want %v", *waits, want)
+	}
+}
+
+func TestRunStopsOnPermanentFailure(t *testing.T) {
+	waits := noSleep(t)
+
+	calls := 0
+	_, err := DefaultPolicy.Run("GitHub", func() ([]byte, error) {
+		calls++
+		return []byte("GraphQL: Could not resolve to a PullRequest"), errors.New("exit status 1")
+	})
+
+	if err == nil || calls != 1 || len(*waits) != 0 {
+		t.Errorf("Run() = %v after %d calls and %d waits; want one failed call", err, calls, len(*waits))
+	}
+}
+
+func TestRunReportsRateLimit(t *testing.T) {
+	waits := noSleep(t)
+
+	policy := Policy{Attempts: 3, Backoff: time.Second, RateLimitWait: 20 * time.Second, MaxWait: 30 * time.Second}
+
+	calls := 0
+	_, err := policy.Run("GitHub", func() ([]byte, error) {
+		calls++
+		return []byte("You have exceeded a secondary rate limit"), errors.New("exit status 1")
+	})
+
+	if !errors.Is(err, ErrRateLimited) || calls != 3 {
+		t.Fatalf("Run() = %v after %d calls; want ErrRateLimited after 3", err, calls)
+	}
+
+	if want := []time.Duration{20 * time.Second, 30 * time.Second}; len(*waits) != 2 || (*waits)[0] != want[0] || (*waits)[1] != want[1] {
+		t.Errorf("waits = %v, want %v (doubled, capped at MaxWait)", *waits, want)
+	}
+}
+
+func TestRunStopsWhenOffline(t *testing.T) {
+	noSleep(t)
+	t.Cleanup(offline.Reset)
+
+	calls := 0
+	_, err := DefaultPolicy.Run("GitHub", func() ([]byte, error) {
+		calls++
+		return []byte("error connecting to api.github.com"), errors.New("exit status 1")
+	})
+
+	if err == nil || calls != 1 {
+		t.Fatalf("Run() = %v after %d calls, want an error after 1 call", err, calls)
+	}
+
+	if !offline.Enabled() {
+		t.Fatal("Run() did not switch to offline mode")
+	}
+
+	_, err = DefaultPolicy.Run("GitHub", func() ([]byte, error) {
+		calls++
+		return nil, nil
+	})
+
+	if !errors.Is(err, offline.ErrOffline) || calls != 1 {
+		t.Errorf("Run() while offline = %v after %d calls, want ErrOffline without calling", err, calls)
+	}
+}
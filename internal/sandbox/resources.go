@@ -0,0 +1,141 @@
+package sandbox
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/kaeawc/auto-worktree/internal/remote"
+)
+
+// Limits caps the CPU and memory one AI session may use
+type Limits struct {
+	// CPUs is a number of CPUs, e.g. "1.5"
+	CPUs string `json:"cpus,omitempty"`
+	// Memory is a size with a k, m, g or t suffix, e.g. "4g"
+	Memory string `json:"memory,omitempty"`
+}
+
+// IsZero reports whether no limit is set
+func (l Limits) IsZero() bool {
+	return l.CPUs == "" && l.Memory == ""
+}
+
+// containerArgs are the run flags that apply the limits to a container
+func (l Limits) containerArgs() []string {
+	var args []string
+	if l.CPUs != "" {
+		args = append(args, "--cpus", l.CPUs)
+	}
+
+	if l.Memory != "" {
+		args = append(args, "--memory", l.Memory)
+	}
+
+	return args
+}
+
+// HasSystemdRun reports whether sessions can be limited with systemd-run
+func HasSystemdRun() bool {
+	return remote.LookPath("systemd-run") == nil
+}
+
+// SystemdRunCommand wraps command in a transient systemd user scope named
+// unit that enforces the limits. The scope runs in the foreground, so the
+// command still owns the session's terminal.
+func SystemdRunCommand(unit string, l Limits, command []string) ([]string, error) {
+	args := []string{"systemd-run", "--user", "--scope", "--quiet", "--unit=" + unit}
+
+	if l.CPUs != "" {
+		cpus, err := strconv.ParseFloat(l.CPUs, 64)
+		if err != nil || cpus <= 0 {
+			return nil, fmt.Errorf("invalid CPU limit %q", l.CPUs)
+		}
+
+		// CPUQuota is a percentage of one CPU
+		args = append(args, "--property", fmt.Sprintf("CPUQuota=%d%%", int(cpus*100)))
+	}
+
+	if l.Memory != "" {
+		// systemd wants upper-case size suffixes
+		args = append(args, "--property", "MemoryMax="+strings.ToUpper(l.Memory))
+	}
+
+	return append(append(args, "--"), command...), nil
+}
+
+// ContainerUsage reads the container's current CPU and memory use, e.g. "12.5% CPU, 300MiB / 4GiB"
+func ContainerUsage(ctx context.Context, runtime, containerID string) (string, error) {
+	out, err := run(ctx, runtime, "stats", "--no-stream", "--format", "{{.CPUPerc}}|{{.MemUsage}}", containerID)
+	if err != nil {
+		return "", err
+	}
+
+	cpu, mem, _ := strings.Cut(out, "|")
+
+	return fmt.Sprintf("%s CPU, %s", strings.TrimSpace(cpu), strings.TrimSpace(mem)), nil
+}
+
+// UnitUsage reads a systemd scope's memory and total CPU time, e.g. "300MiB, 1m20s CPU"
+func UnitUsage(unit string) (string, error) {
+	output, err := remote.Command(context.Background(), "", nil, "systemctl", "--user", "show",
+		"--property", "MemoryCurrent,CPUUsageNSec", unit+".scope").Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to read usage of %s: %w", unit, err)
+	}
+
+	return parseUnitUsage(string(output))
+}
+
+// parseUnitUsage formats systemctl show output (KEY=VALUE lines)
+func parseUnitUsage(output string) (string, error) {
+	values := map[string]string{}
+
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		if k, v, ok := strings.Cut(line, "="); ok {
+			values[k] = v
+		}
+	}
+
+	mem, memErr := strconv.ParseUint(values["MemoryCurrent"], 10, 64)
+	nsec, cpuErr := strconv.ParseUint(values["CPUUsageNSec"], 10, 64)
+
+	// An inactive scope reports "[not set]" or the max uint64
+	if memErr != nil || cpuErr != nil || mem == ^uint64(0) {
+		return "", fmt.Errorf("no usage reported (scope not running)")
+	}
+
+	return fmt.Sprintf("%s, %s CPU", formatBytes(mem), formatCPUTime(nsec)), nil
+}
+
+// formatBytes renders a byte count with a binary unit, as docker stats does
+func formatBytes(n uint64) string {
+	const unit = 1024
+
+	if n < unit {
+		return fmt.Sprintf("%dB", n)
+	}
+
+	div, exp := uint64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.1f%ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+// formatCPUTime renders nanoseconds of CPU time as e.g. "1m20s"
+func formatCPUTime(nsec uint64) string {
+	secs := nsec / 1e9
+	if secs < 60 {
+		return fmt.Sprintf("%ds", secs)
+	}
+
+	if secs < 3600 {
+		return fmt.Sprintf("%dm%02ds", secs/60, secs%60)
+	}
+
+	return fmt.Sprintf("%dh%02dm", secs/3600, secs/60%60)
+}
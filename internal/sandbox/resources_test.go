@@ -0,0 +1,62 @@
+package sandbox
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRunArgs_Limits(t *testing.T) {
+	spec := Spec{Runtime: "docker", Image: "node:22", Name: "s", WorkDir: "/wt", Limits: Limits{CPUs: "1.5", Memory: "2g"}}
+
+	if got := strings.Join(runArgs(spec, 1000, 100), " "); !strings.Contains(got, "--cpus 1.5 --memory 2g") {
+		t.Errorf("runArgs() = %q, want the CPU and memory limits", got)
+	}
+}
+
+func TestSystemdRunCommand(t *testing.T) {
+	got, err := SystemdRunCommand("aw-x", Limits{CPUs: "1.5", Memory: "4g"}, []string{"claude"})
+	if err != nil {
+		t.Fatalf("SystemdRunCommand() error = %v", err)
+	}
+
+	want := []string{"systemd-run", "--user", "--scope", "--quiet", "--unit=aw-x",
+		"--property", "CPUQuota=150%", "--property", "MemoryMax=4G", "--", "claude"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SystemdRunCommand() = %v, want %v", got, want)
+	}
+
+	if _, err := SystemdRunCommand("aw-x", Limits{CPUs: "lots"}, []string{"claude"}); err == nil {
+		t.Error("SystemdRunCommand() with an invalid CPU limit should fail")
+	}
+}
+
+func TestParseUnitUsage(t *testing.T) {
+	got, err := parseUnitUsage("MemoryCurrent=314572800\nCPUUsageNSec=80000000000\n")
+	if err != nil {
+		t.Fatalf("parseUnitUsage() error = %v", err)
+	}
+
+	if want := "300.0MiB, 1m20s CPU"; got != want {
+		t.Errorf("parseUnitUsage() = %q, want %q", got, want)
+	}
+
+	if _, err := parseUnitUsage("MemoryCurrent=[not set]\nCPUUsageNSec=[not set]\n"); err == nil {
+		t.Error("parseUnitUsage() for an inactive scope should fail")
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	tests := map[uint64]string{
+		512:             "512B",
+		2048:            "2.0KiB",
+		3 * 1024 * 1024: "3.0MiB",
+		5 << 30:         "5.0GiB",
+	}
+
+	for n, want := range tests {
+		if got := formatBytes(n); got != want {
+			t.Errorf("formatBytes(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
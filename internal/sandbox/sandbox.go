@@ -0,0 +1,207 @@
+// Package sandbox runs AI sessions inside Docker or Podman containers that
+// can only see the worktree (and, read-only, the repository's .git directory,
+// so git works).
+package sandbox
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/kaeawc/auto-worktree/internal/limits"
+	"github.com/kaeawc/auto-worktree/internal/logging"
+	"github.com/kaeawc/auto-worktree/internal/remote"
+)
+
+// configLabel records configHash on the container, so Start can tell when it
+// was created for a different image or mounts
+const configLabel = "auto-worktree.config"
+
+// forwardedEnv are credentials passed into the container when set, so AI tools can sign in
+var forwardedEnv = []string{"ANTHROPIC_API_KEY", "OPENAI_API_KEY", "GEMINI_API_KEY", "GOOGLE_API_KEY"}
+
+// Spec describes the container for one session
+type Spec struct {
+	// Runtime is the container CLI: docker or podman
+	Runtime string
+	Image   string
+	// Name is the container name; sessions use their session name
+	Name string
+	// ReadOnlyMounts are host directories bind-mounted read-only at the same
+	// path; Mounts may make directories inside them writable again
+	ReadOnlyMounts []string
+	// Mounts are host directories bind-mounted read-write at the same path
+	Mounts []string
+	// WorkDir is where commands start inside the container
+	WorkDir string
+	// Limits caps the container's CPU and memory
+	Limits Limits
+}
+
+// Start returns the ID of a running container for spec, reusing one left from
+// an earlier session of the same name and starting it again if it stopped.
+// A container created for another image or other mounts is replaced, so an
+// old writable mount can't outlive a change to read-only.
+// The container CLI is stopped when ctx is done.
+func Start(ctx context.Context, spec Spec) (string, error) {
+	if spec.Image == "" {
+		return "", fmt.Errorf("no sandbox image configured (set auto-worktree.sandbox-image)")
+	}
+
+	if id, running, config, err := inspect(ctx, spec.Runtime, spec.Name); err == nil && config != configHash(spec) {
+		logging.Info("recreating sandbox container for a changed configuration", "container", spec.Name)
+
+		if err := Remove(ctx, spec.Runtime, id); err != nil {
+			return "", err
+		}
+	} else if err == nil {
+		if !running {
+			if _, err := run(ctx, spec.Runtime, "start", id); err != nil {
+				return "", fmt.Errorf("failed to restart container %s: %w", spec.Name, err)
+			}
+		}
+
+		// Limits may have changed since the container was created
+		if args := spec.Limits.containerArgs(); len(args) > 0 {
+			if _, err := run(ctx, spec.Runtime, append(append([]string{"update"}, args...), id)...); err != nil {
+				logging.Warn("failed to update sandbox limits", "container", spec.Name, "err", err)
+			}
+		}
+
+		return id, nil
+	}
+
+	id, err := run(ctx, spec.Runtime, runArgs(spec, os.Getuid(), os.Getgid())...)
+	if err != nil {
+		return "", fmt.Errorf("failed to start container from %s: %w", spec.Image, err)
+	}
+
+	return id, nil
+}
+
+// configHash identifies what a container can't change after it is created:
+// its image, mounts and working directory
+func configHash(spec Spec) string {
+	h := sha256.New()
+
+	fmt.Fprintf(h, "image=%s\nworkdir=%s\n", spec.Image, spec.WorkDir)
+
+	for _, m := range spec.ReadOnlyMounts {
+		fmt.Fprintf(h, "ro=%s\n", m)
+	}
+
+	for _, m := range spec.Mounts {
+		fmt.Fprintf(h, "rw=%s\n", m)
+	}
+
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+// runArgs builds the "run" invocation: a long-lived idle container that sessions exec into
+func runArgs(spec Spec, uid, gid int) []string {
+	args := []string{"run", "--detach", "--init", "--name", spec.Name,
+		"--label", "auto-worktree.session=" + spec.Name,
+		"--label", configLabel + "=" + configHash(spec),
+		"--workdir", spec.WorkDir,
+		"--env", "HOME=/tmp",
+	}
+
+	// Files the agent writes should belong to the user, not root
+	switch {
+	case spec.Runtime == "podman":
+		args = append(args, "--userns=keep-id")
+	case !remote.Enabled() && uid >= 0:
+		args = append(args, "--user", fmt.Sprintf("%d:%d", uid, gid))
+	}
+
+	args = append(args, spec.Limits.containerArgs()...)
+
+	// Read-only parents go first so the writable directories inside them are mounted over them
+	for _, m := range spec.ReadOnlyMounts {
+		args = append(args, "--volume", m+":"+m+":ro")
+	}
+
+	for _, m := range spec.Mounts {
+		args = append(args, "--volume", m+":"+m)
+	}
+
+	for _, name := range forwardedEnv {
+		if os.Getenv(name) != "" {
+			// A bare name copies the value from our environment
+			args = append(args, "--env", name)
+		}
+	}
+
+	return append(args, spec.Image, "sleep", "infinity")
+}
+
+// ExecCommand wraps command so it runs inside the container, with a terminal
+func ExecCommand(runtime, containerID, workDir string, command []string) []string {
+	args := []string{runtime, "exec", "--interactive", "--tty", "--workdir", workDir, containerID}
+	if len(command) == 0 {
+		command = []string{"sh"}
+	}
+
+	return append(args, command...)
+}
+
+// IsRunning reports whether the container exists and is running
+func IsRunning(ctx context.Context, runtime, containerID string) bool {
+	_, running, _, err := inspect(ctx, runtime, containerID)
+	return err == nil && running
+}
+
+// Remove stops and deletes the container
+func Remove(ctx context.Context, runtime, containerID string) error {
+	if _, err := run(ctx, runtime, "rm", "--force", containerID); err != nil {
+		return fmt.Errorf("failed to remove container %s: %w", containerID, err)
+	}
+
+	return nil
+}
+
+// inspect returns the container's full ID, whether it is running and its
+// configLabel, empty for containers created before the label existed
+func inspect(ctx context.Context, runtime, nameOrID string) (string, bool, string, error) {
+	format := "{{.Id}} {{.State.Running}} {{index .Config.Labels \"" + configLabel + "\"}}"
+
+	out, err := run(ctx, runtime, "inspect", "--format", format, nameOrID)
+	if err != nil {
+		return "", false, "", err
+	}
+
+	fields := strings.Fields(out)
+	for len(fields) < 3 {
+		fields = append(fields, "")
+	}
+
+	config := fields[2]
+	if config == "<no value>" {
+		config = ""
+	}
+
+	return fields[0], fields[1] == "true", config, nil
+}
+
+// run executes the container CLI, where the worktree lives when --host is set
+func run(ctx context.Context, runtime string, args ...string) (string, error) {
+	ctx, cancel := limits.Context(ctx, limits.ToolContainer)
+	defer cancel()
+
+	output, err := remote.Command(ctx, "", nil, runtime, args...).CombinedOutput()
+	err = limits.Wrap(ctx, limits.ToolContainer, err)
+	out := strings.TrimSpace(string(output))
+
+	if err != nil {
+		if out != "" {
+			return "", fmt.Errorf("%s %s: %w: %s", runtime, args[0], err, out)
+		}
+
+		return "", fmt.Errorf("%s %s: %w", runtime, args[0], err)
+	}
+
+	return out, nil
+}
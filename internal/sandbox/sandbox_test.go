@@ -0,0 +1,147 @@
+package sandbox
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRunArgs(t *testing.T) {
+	t.Setenv("ANTHROPIC_API_KEY", "secret")
+	t.Setenv("OPENAI_API_KEY", "")
+
+	spec := Spec{Runtime: "docker", Image: "node:22", Name: "auto-worktree-x", WorkDir: "/wt/pkg",
+		ReadOnlyMounts: []string{"/repo/.git"}, Mounts: []string{"/wt", "/repo/.git/objects"}}
+	got := strings.Join(runArgs(spec, 1000, 100), " ")
+
+	for _, want := range []string{
+		"run --detach --init --name auto-worktree-x",
+		"--workdir /wt/pkg",
+		"--label auto-worktree.config=" + configHash(spec),
+		"--user 1000:100",
+		"--volume /repo/.git:/repo/.git:ro --volume /wt:/wt --volume /repo/.git/objects:/repo/.git/objects",
+		"--env ANTHROPIC_API_KEY node:22 sleep infinity",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("runArgs() = %q, want it to contain %q", got, want)
+		}
+	}
+
+	if strings.Contains(got, "OPENAI_API_KEY") || strings.Contains(got, "secret") {
+		t.Errorf("runArgs() = %q, want only set keys forwarded, by name", got)
+	}
+
+	spec.Runtime = "podman"
+	if got := strings.Join(runArgs(spec, 1000, 100), " "); !strings.Contains(got, "--userns=keep-id") || strings.Contains(got, "--user ") {
+		t.Errorf("podman runArgs() = %q, want --userns=keep-id instead of --user", got)
+	}
+}
+
+func TestExecCommand(t *testing.T) {
+	got := ExecCommand("docker", "abc", "/wt", []string{"claude", "--continue"})
+	want := []string{"docker", "exec", "--interactive", "--tty", "--workdir", "/wt", "abc", "claude", "--continue"}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ExecCommand() = %v, want %v", got, want)
+	}
+
+	if got := ExecCommand("podman", "abc", "/wt", nil); got[len(got)-1] != "sh" {
+		t.Errorf("ExecCommand() without a command = %v, want a shell", got)
+	}
+}
+
+// fakeRuntime puts a "docker" on PATH that records its arguments and answers
+// inspect with the given output (or fails when it is empty)
+func fakeRuntime(t *testing.T, inspectOutput string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	log := filepath.Join(dir, "log")
+
+	script := "#!/bin/sh\necho \"$*\" >> " + log + "\n" +
+		"case \"$1\" in\n" +
+		"inspect) [ -n '" + inspectOutput + "' ] && echo '" + inspectOutput + "' && exit 0; echo 'no such container' >&2; exit 1;;\n" +
+		"run) echo newid;;\n" +
+		"esac\n"
+
+	if err := os.WriteFile(filepath.Join(dir, "docker"), []byte(script), 0o755); err != nil { //nolint:gosec // test script must be executable
+		t.Fatal(err)
+	}
+
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	return log
+}
+
+func TestConfigHash(t *testing.T) {
+	spec := Spec{Image: "img", ReadOnlyMounts: []string{"/repo/.git"}, Mounts: []string{"/wt"}, WorkDir: "/wt"}
+
+	// A mount that moves from writable to read-only must change the hash
+	writable := spec
+	writable.ReadOnlyMounts, writable.Mounts = nil, []string{"/wt", "/repo/.git"}
+
+	newImage := spec
+	newImage.Image = "img:2"
+
+	for _, other := range []Spec{writable, newImage} {
+		if configHash(other) == configHash(spec) {
+			t.Errorf("configHash(%+v) = configHash(%+v), want them to differ", other, spec)
+		}
+	}
+
+	limited := spec
+	limited.Name, limited.Limits = "other", Limits{CPUs: "2"}
+
+	if configHash(limited) != configHash(spec) {
+		t.Error("configHash() changed with the name or limits, which need no new container")
+	}
+}
+
+func TestStart(t *testing.T) {
+	spec := Spec{Runtime: "docker", Image: "img", Name: "s", Mounts: []string{"/wt"}, WorkDir: "/wt"}
+	config := configHash(spec)
+
+	tests := []struct {
+		name     string
+		inspect  string
+		wantID   string
+		wantLast string
+	}{
+		{"new container", "", "newid", "run"},
+		{"running container is reused", "oldid true " + config, "oldid", "inspect"},
+		{"stopped container is restarted", "oldid false " + config, "oldid", "start oldid"},
+		{"container without the label is recreated", "oldid true <no value>", "newid", "run"},
+		{"container for another config is recreated", "oldid false 0123456789abcdef", "newid", "run"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log := fakeRuntime(t, tt.inspect)
+
+			id, err := Start(context.Background(), spec)
+			if err != nil || id != tt.wantID {
+				t.Fatalf("Start() = %q, %v; want %q", id, err, tt.wantID)
+			}
+
+			data, _ := os.ReadFile(log) //nolint:errcheck // the assertion below reports a missing log
+			lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+
+			if last := lines[len(lines)-1]; !strings.HasPrefix(last, tt.wantLast) {
+				t.Errorf("last runtime call = %q, want %q", last, tt.wantLast)
+			}
+
+			// Replacing a container means removing the old one first
+			removed := strings.Contains(string(data), "rm --force oldid")
+			if replaced := tt.inspect != "" && tt.wantID == "newid"; removed != replaced {
+				t.Errorf("removed oldid = %v, want %v", removed, replaced)
+			}
+		})
+	}
+
+	if _, err := Start(context.Background(), Spec{Runtime: "docker", Name: "s"}); err == nil {
+		t.Error("Start() without an image succeeded")
+	}
+}
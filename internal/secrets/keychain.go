@@ -0,0 +1,135 @@
+package secrets
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// Keychain is an OS credential store holding auto-worktree's secrets by account name
+type Keychain interface {
+	// Name describes the keychain in messages, e.g. "macOS keychain"
+	Name() string
+	// Get returns the secret for account, or ErrNotFound
+	Get(account string) (string, error)
+	// Set stores secret for account, replacing any previous one
+	Set(account, secret string) error
+	// Delete removes the secret for account
+	Delete(account string) error
+}
+
+// SystemKeychain returns the OS keychain: the macOS keychain, the Secret
+// Service (GNOME Keyring, KWallet) through secret-tool, or the Windows
+// Credential Manager. It returns nil when none is available.
+func SystemKeychain() Keychain {
+	switch runtime.GOOS {
+	case "darwin":
+		if _, err := exec.LookPath("security"); err == nil {
+			return macKeychain{}
+		}
+	case "windows":
+		return windowsCredentialManager()
+	default:
+		// secret-tool needs a D-Bus session, which SSH logins and containers usually lack
+		if _, err := exec.LookPath("secret-tool"); err == nil && os.Getenv("DBUS_SESSION_BUS_ADDRESS") != "" {
+			return secretService{}
+		}
+	}
+
+	return nil
+}
+
+// macKeychain stores generic passwords with the security tool
+type macKeychain struct{}
+
+func (macKeychain) Name() string { return "macOS keychain" }
+
+func (macKeychain) Get(account string) (string, error) {
+	var stderr bytes.Buffer
+
+	cmd := exec.Command("security", "find-generic-password", "-s", service, "-a", account, "-w")
+	cmd.Stderr = &stderr
+
+	output, err := cmd.Output()
+	if err != nil {
+		if strings.Contains(stderr.String(), "could not be found") {
+			return "", fmt.Errorf("%s: %w", account, ErrNotFound)
+		}
+
+		return "", fmt.Errorf("security find-generic-password failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	return strings.TrimSuffix(string(output), "\n"), nil
+}
+
+func (macKeychain) Set(account, secret string) error {
+	// Run security interactively so the secret is read from stdin rather than
+	// appearing in the process list; -X takes it hex-encoded so it needs no quoting
+	cmd := exec.Command("security", "-i")
+	cmd.Stdin = strings.NewReader(fmt.Sprintf("add-generic-password -U -s %s -a %s -X %s\n",
+		service, account, hex.EncodeToString([]byte(secret))))
+
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("security add-generic-password failed: %w: %s", err, strings.TrimSpace(string(output)))
+	}
+
+	return nil
+}
+
+func (macKeychain) Delete(account string) error {
+	output, err := exec.Command("security", "delete-generic-password", "-s", service, "-a", account).CombinedOutput()
+	if err != nil {
+		if strings.Contains(string(output), "could not be found") {
+			return fmt.Errorf("%s: %w", account, ErrNotFound)
+		}
+
+		return fmt.Errorf("security delete-generic-password failed: %w: %s", err, strings.TrimSpace(string(output)))
+	}
+
+	return nil
+}
+
+// secretService stores secrets through the freedesktop Secret Service with secret-tool
+type secretService struct{}
+
+func (secretService) Name() string { return "Secret Service keyring" }
+
+func (secretService) Get(account string) (string, error) {
+	output, err := exec.Command("secret-tool", "lookup", "service", service, "account", account).Output()
+
+	var exitErr *exec.ExitError
+
+	switch {
+	case len(output) == 0 && (err == nil || errors.As(err, &exitErr)):
+		// secret-tool exits 1 with no output when nothing matches
+		return "", fmt.Errorf("%s: %w", account, ErrNotFound)
+	case err != nil:
+		return "", fmt.Errorf("secret-tool lookup failed: %w", err)
+	}
+
+	return string(output), nil
+}
+
+func (secretService) Set(account, secret string) error {
+	cmd := exec.Command("secret-tool", "store", "--label", service+" "+account, "service", service, "account", account)
+	cmd.Stdin = strings.NewReader(secret)
+
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("secret-tool store failed: %w: %s", err, strings.TrimSpace(string(output)))
+	}
+
+	return nil
+}
+
+func (secretService) Delete(account string) error {
+	if output, err := exec.Command("secret-tool", "clear", "service", service, "account", account).CombinedOutput(); err != nil {
+		return fmt.Errorf("secret-tool clear failed: %w: %s", err, strings.TrimSpace(string(output)))
+	}
+
+	return nil
+}
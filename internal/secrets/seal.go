@@ -0,0 +1,123 @@
+package secrets
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// The file backend seals values with a random key kept in credentials.key
+// beside the state database. The key is not derived from anything the user
+// knows, so sealing only keeps tokens out of plain sight (backups of the
+// database alone, grep, editors): anyone who can read ~/.auto-worktree can read
+// the key too, which makes the file backend as strong as plaintext there.
+// Callers warn with FileBackendWarning when a token lands in it.
+
+// keySize is the AES-256 key length in bytes
+const keySize = 32
+
+// FileBackendWarning explains what the file backend does and doesn't protect against
+const FileBackendWarning = "No OS keychain: the token is encrypted with a key stored beside it in ~/.auto-worktree, " +
+	"so anyone who can read that directory can read the token, as if it were in plaintext"
+
+// seal encrypts value with AES-GCM under the store's key, binding it to name
+// so a sealed value can't be swapped onto another credential
+func (s *Store) seal(name, value string) ([]byte, error) {
+	gcm, err := s.cipher(true)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	return gcm.Seal(nonce, nonce, []byte(value), []byte(name)), nil
+}
+
+// open decrypts a value sealed for name
+func (s *Store) open(name string, sealed []byte) (string, error) {
+	gcm, err := s.cipher(false)
+	if err != nil {
+		return "", err
+	}
+
+	if len(sealed) < gcm.NonceSize() {
+		return "", fmt.Errorf("stored %s credential is corrupt", name)
+	}
+
+	nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+
+	value, err := gcm.Open(nil, nonce, ciphertext, []byte(name))
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt %s credential (was %s replaced?): %w", name, s.keyPath, err)
+	}
+
+	return string(value), nil
+}
+
+// cipher loads the key file, creating it first when create is set
+func (s *Store) cipher(create bool) (cipher.AEAD, error) {
+	key, err := os.ReadFile(s.keyPath)
+	if errors.Is(err, os.ErrNotExist) && create {
+		key, err = s.createKey()
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read credential key: %w", err)
+	}
+
+	if len(key) != keySize {
+		return nil, fmt.Errorf("credential key %s is %d bytes, want %d", s.keyPath, len(key), keySize)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+
+	return gcm, nil
+}
+
+// createKey writes a new random key readable only by the user
+func (s *Store) createKey() ([]byte, error) {
+	key := make([]byte, keySize)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, fmt.Errorf("failed to generate key: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.keyPath), 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", filepath.Dir(s.keyPath), err)
+	}
+
+	// O_EXCL: if another process just created the key, use theirs
+	f, err := os.OpenFile(s.keyPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if errors.Is(err, os.ErrExist) {
+		return os.ReadFile(s.keyPath)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := f.Write(key); err != nil {
+		return nil, errors.Join(err, f.Close())
+	}
+
+	if err := f.Close(); err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
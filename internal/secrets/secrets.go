@@ -0,0 +1,258 @@
+// Package secrets keeps provider and AI tokens out of git config and shell
+// profiles: in the OS keychain when there is one, otherwise encrypted in the
+// state database.
+package secrets
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kaeawc/auto-worktree/internal/logging"
+	"github.com/kaeawc/auto-worktree/internal/state"
+)
+
+// service names auto-worktree's entries in the OS keychain
+const service = "auto-worktree"
+
+// Backends a credential can be stored in
+const (
+	BackendKeychain = "keychain"
+	BackendFile     = "file"
+)
+
+// ErrNotFound is returned when no credential is stored under a name
+var ErrNotFound = errors.New("credential not found")
+
+// Credential is a token auto-worktree can store, and the environment variable
+// auto-worktree and the tools it runs read it from
+type Credential struct {
+	Name        string
+	Env         string
+	Description string
+}
+
+// Credentials are the tokens that can be stored with 'auto-worktree auth'
+var Credentials = []Credential{
+	{Name: "github", Env: "GITHUB_TOKEN", Description: "GitHub token for gh and the GitHub API"},
+	{Name: "gitlab", Env: "GITLAB_TOKEN", Description: "GitLab token for glab and the GitLab API"},
+	{Name: "jira", Env: "JIRA_API_TOKEN", Description: "JIRA API token or personal access token"},
+	{Name: "linear", Env: "LINEAR_API_KEY", Description: "Linear personal API key"},
+	{Name: "anthropic", Env: "ANTHROPIC_API_KEY", Description: "Anthropic API key for Claude Code"},
+	{Name: "openai", Env: "OPENAI_API_KEY", Description: "OpenAI API key for Codex"},
+	{Name: "gemini", Env: "GEMINI_API_KEY", Description: "Gemini API key for the Gemini CLI"},
+}
+
+// Lookup finds the credential called name
+func Lookup(name string) (Credential, error) {
+	for _, c := range Credentials {
+		if c.Name == name {
+			return c, nil
+		}
+	}
+
+	names := make([]string, 0, len(Credentials))
+	for _, c := range Credentials {
+		names = append(names, c.Name)
+	}
+
+	return Credential{}, fmt.Errorf("unknown credential %q (expected one of: %s)", name, strings.Join(names, ", "))
+}
+
+// entry records where a credential is stored; file-backed values are sealed
+type entry struct {
+	Backend string    `json:"backend"`
+	Sealed  []byte    `json:"sealed,omitempty"`
+	Updated time.Time `json:"updated"`
+}
+
+// Store saves credentials in a keychain, falling back to values sealed with a
+// key file next to the state database
+type Store struct {
+	db       *state.Store
+	keyPath  string
+	keychain Keychain
+}
+
+// NewStore creates a store that records credentials in the state database at
+// dbPath and keeps their values in keychain, or sealed in the database when
+// keychain is nil or fails
+func NewStore(dbPath string, keychain Keychain) *Store {
+	return &Store{
+		db:       state.NewStore(dbPath),
+		keyPath:  filepath.Join(filepath.Dir(dbPath), "credentials.key"),
+		keychain: keychain,
+	}
+}
+
+// DefaultStore opens the store in the default state database with the OS keychain
+func DefaultStore() (*Store, error) {
+	path, err := state.DefaultPath()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewStore(path, SystemKeychain()), nil
+}
+
+// Set stores value under name and returns the backend it went to
+func (s *Store) Set(name, value string) (string, error) {
+	previous, err := s.entry(name)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return "", err
+	}
+
+	if s.keychain != nil {
+		keychainErr := s.keychain.Set(name, value)
+		if keychainErr == nil {
+			return BackendKeychain, s.put(name, entry{Backend: BackendKeychain, Updated: time.Now()})
+		}
+
+		logging.Warn("keychain unavailable, storing the credential encrypted on disk",
+			"keychain", s.keychain.Name(), "err", keychainErr)
+	}
+
+	sealed, err := s.seal(name, value)
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.put(name, entry{Backend: BackendFile, Sealed: sealed, Updated: time.Now()}); err != nil {
+		return "", err
+	}
+
+	// Don't leave an older value behind in the keychain
+	if previous.Backend == BackendKeychain && s.keychain != nil {
+		_ = s.keychain.Delete(name) //nolint:errcheck // the keychain just failed; the new value is stored
+	}
+
+	return BackendFile, nil
+}
+
+// Get returns the value stored under name, or ErrNotFound
+func (s *Store) Get(name string) (string, error) {
+	e, err := s.entry(name)
+	if err != nil {
+		return "", err
+	}
+
+	switch e.Backend {
+	case BackendKeychain:
+		if s.keychain == nil {
+			return "", fmt.Errorf("%s is stored in a keychain that is not available here", name)
+		}
+
+		return s.keychain.Get(name)
+	case BackendFile:
+		return s.open(name, e.Sealed)
+	default:
+		return "", fmt.Errorf("%s is stored in unknown backend %q", name, e.Backend)
+	}
+}
+
+// Delete removes the value stored under name; deleting a missing credential is not an error
+func (s *Store) Delete(name string) error {
+	e, err := s.entry(name)
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if e.Backend == BackendKeychain && s.keychain != nil {
+		if err := s.keychain.Delete(name); err != nil && !errors.Is(err, ErrNotFound) {
+			return fmt.Errorf("failed to remove %s from the %s: %w", name, s.keychain.Name(), err)
+		}
+	}
+
+	if err := s.db.Delete(state.BucketCredentials, name); err != nil {
+		return fmt.Errorf("failed to remove credential %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// List returns the backend of each stored credential by name
+func (s *Store) List() (map[string]string, error) {
+	stored := make(map[string]string)
+
+	err := s.db.ForEach(state.BucketCredentials, func(key string, value []byte) error {
+		var e entry
+		if err := json.Unmarshal(value, &e); err != nil {
+			return fmt.Errorf("failed to parse credential %s: %w", key, err)
+		}
+
+		stored[key] = e.Backend
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return stored, nil
+}
+
+// ApplyToEnv exports each stored credential whose variable is not already set,
+// so auto-worktree and the tools it runs pick it up. It returns the names exported.
+func (s *Store) ApplyToEnv() ([]string, error) {
+	stored, err := s.List()
+	if err != nil {
+		return nil, err
+	}
+
+	var applied []string
+
+	for _, c := range Credentials {
+		if _, ok := stored[c.Name]; !ok || os.Getenv(c.Env) != "" {
+			continue
+		}
+
+		value, err := s.Get(c.Name)
+		if err != nil {
+			logging.Warn("failed to read stored credential", "credential", c.Name, "err", err)
+			continue
+		}
+
+		if err := os.Setenv(c.Env, value); err != nil {
+			return applied, fmt.Errorf("failed to set %s: %w", c.Env, err)
+		}
+
+		applied = append(applied, c.Name)
+	}
+
+	sort.Strings(applied)
+
+	return applied, nil
+}
+
+// entry reads the record for name
+func (s *Store) entry(name string) (entry, error) {
+	var e entry
+
+	if err := s.db.Get(state.BucketCredentials, name, &e); err != nil {
+		if errors.Is(err, state.ErrNotFound) {
+			return e, fmt.Errorf("%s: %w", name, ErrNotFound)
+		}
+
+		return e, fmt.Errorf("failed to read credential %s: %w", name, err)
+	}
+
+	return e, nil
+}
+
+// put writes the record for name
+func (s *Store) put(name string, e entry) error {
+	if err := s.db.Put(state.BucketCredentials, name, e); err != nil {
+		return fmt.Errorf("failed to save credential %s: %w", name, err)
+	}
+
+	return nil
+}
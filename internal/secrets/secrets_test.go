@@ -0,0 +1,146 @@
+package secrets
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// fakeKeychain keeps secrets in memory, failing every call when broken is set
+type fakeKeychain struct {
+	secrets map[string]string
+	broken  bool
+}
+
+func (k *fakeKeychain) Name() string { return "fake keychain" }
+
+func (k *fakeKeychain) Get(account string) (string, error) {
+	if k.broken {
+		return "", errors.New("keychain locked")
+	}
+
+	secret, ok := k.secrets[account]
+	if !ok {
+		return "", fmt.Errorf("%s: %w", account, ErrNotFound)
+	}
+
+	return secret, nil
+}
+
+func (k *fakeKeychain) Set(account, secret string) error {
+	if k.broken {
+		return errors.New("keychain locked")
+	}
+
+	k.secrets[account] = secret
+
+	return nil
+}
+
+func (k *fakeKeychain) Delete(account string) error {
+	delete(k.secrets, account)
+	return nil
+}
+
+func TestStoreKeychain(t *testing.T) {
+	keychain := &fakeKeychain{secrets: map[string]string{}}
+	store := NewStore(filepath.Join(t.TempDir(), "state.db"), keychain)
+
+	backend, err := store.Set("github", "ghp_secret")
+	if err != nil || backend != BackendKeychain {
+		t.Fatalf("Set() = %q, %v; want keychain", backend, err)
+	}
+
+	if got, err := store.Get("github"); err != nil || got != "ghp_secret" {
+		t.Errorf("Get() = %q, %v", got, err)
+	}
+
+	if err := store.Delete("github"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	if _, err := store.Get("github"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get() after Delete = %v, want ErrNotFound", err)
+	}
+
+	if len(keychain.secrets) != 0 {
+		t.Errorf("keychain still holds %v", keychain.secrets)
+	}
+}
+
+func TestStoreFileFallback(t *testing.T) {
+	dir := t.TempDir()
+	store := NewStore(filepath.Join(dir, "state.db"), &fakeKeychain{broken: true})
+
+	backend, err := store.Set("linear", "lin_api_secret")
+	if err != nil || backend != BackendFile {
+		t.Fatalf("Set() = %q, %v; want file", backend, err)
+	}
+
+	if got, err := store.Get("linear"); err != nil || got != "lin_api_secret" {
+		t.Errorf("Get() = %q, %v", got, err)
+	}
+
+	db, err := os.ReadFile(filepath.Join(dir, "state.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if bytes.Contains(db, []byte("lin_api_secret")) {
+		t.Error("state database contains the token in plain text")
+	}
+
+	if info, err := os.Stat(filepath.Join(dir, "credentials.key")); err != nil || info.Mode().Perm() != 0o600 {
+		t.Errorf("credentials.key = %v, %v; want mode 0600", info, err)
+	}
+
+	// A value sealed for one credential can't be read back as another
+	e, _ := store.entry("linear")
+	if _, err := store.open("github", e.Sealed); err == nil {
+		t.Error("open() with the wrong name = nil error")
+	}
+}
+
+func TestStoreApplyToEnv(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "")
+	t.Setenv("JIRA_API_TOKEN", "from-env")
+
+	store := NewStore(filepath.Join(t.TempDir(), "state.db"), nil)
+
+	for name, value := range map[string]string{"github": "stored-github", "jira": "stored-jira"} {
+		if _, err := store.Set(name, value); err != nil {
+			t.Fatalf("Set(%s) error = %v", name, err)
+		}
+	}
+
+	applied, err := store.ApplyToEnv()
+	if err != nil {
+		t.Fatalf("ApplyToEnv() error = %v", err)
+	}
+
+	if len(applied) != 1 || applied[0] != "github" {
+		t.Errorf("ApplyToEnv() = %v, want [github]", applied)
+	}
+
+	if os.Getenv("GITHUB_TOKEN") != "stored-github" {
+		t.Errorf("GITHUB_TOKEN = %q", os.Getenv("GITHUB_TOKEN"))
+	}
+
+	// A variable that is already set wins over the stored credential
+	if os.Getenv("JIRA_API_TOKEN") != "from-env" {
+		t.Errorf("JIRA_API_TOKEN = %q, want from-env", os.Getenv("JIRA_API_TOKEN"))
+	}
+}
+
+func TestLookup(t *testing.T) {
+	if c, err := Lookup("gitlab"); err != nil || c.Env != "GITLAB_TOKEN" {
+		t.Errorf("Lookup(gitlab) = %+v, %v", c, err)
+	}
+
+	if _, err := Lookup("bitbucket"); err == nil {
+		t.Error("Lookup(bitbucket) = nil error")
+	}
+}
@@ -0,0 +1,8 @@
+//go:build !windows
+
+package secrets
+
+// windowsCredentialManager is only available on Windows
+func windowsCredentialManager() Keychain {
+	return nil
+}
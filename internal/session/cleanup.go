@@ -181,6 +181,11 @@ func (m *SessionManager) processSingleMetadata(metadata *Metadata, opts *Cleanup
 		return
 	}
 
+	// Sessions stopped for being idle keep their metadata so they can be resumed
+	if !classification.exists && metadata.Status == StatusPaused {
+		return
+	}
+
 	if !classification.exists {
 		result.OrphanedSessions++
 		if err := m.processOrphanedSession(metadata, opts); err != nil {
@@ -0,0 +1,77 @@
+package session
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kaeawc/auto-worktree/internal/redact"
+)
+
+// LastActivity returns when the session last saw input or output
+func (m *SessionManager) LastActivity(name string) (time.Time, error) {
+	if m.sessionType != TypeTmux {
+		return time.Time{}, fmt.Errorf("activity is only tracked for tmux sessions")
+	}
+
+	cmd, cancel := m.tmux(nil, "display-message", "-p", "-t", name, "#{session_activity}")
+	defer cancel()
+
+	output, err := cmd.Output()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to read activity of %s: %w", name, err)
+	}
+
+	return parseActivity(string(output))
+}
+
+// parseActivity parses tmux's session_activity, a Unix timestamp in seconds
+func parseActivity(output string) (time.Time, error) {
+	secs, err := strconv.ParseInt(strings.TrimSpace(output), 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("unexpected session activity %q", strings.TrimSpace(output))
+	}
+
+	return time.Unix(secs, 0), nil
+}
+
+// SaveTranscript writes the session's scrollback, with secrets redacted, to
+// ~/.auto-worktree/transcripts and returns the file's path
+func (m *SessionManager) SaveTranscript(name string) (string, error) {
+	if m.sessionType != TypeTmux {
+		return "", fmt.Errorf("transcripts are only saved for tmux sessions")
+	}
+
+	cmd, cancel := m.tmux(nil, "capture-pane", "-p", "-J", "-S", "-", "-t", name)
+	defer cancel()
+
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to capture %s: %w", name, err)
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+
+	dir := filepath.Join(home, ".auto-worktree", "transcripts")
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", fmt.Errorf("failed to create transcript directory: %w", err)
+	}
+
+	path := filepath.Join(dir, transcriptName(name, time.Now()))
+	if err := os.WriteFile(path, redact.Bytes(output), 0o600); err != nil {
+		return "", fmt.Errorf("failed to write transcript: %w", err)
+	}
+
+	return path, nil
+}
+
+// transcriptName names a transcript by session and time, so repeated shutdowns don't overwrite each other
+func transcriptName(sessionName string, at time.Time) string {
+	return fmt.Sprintf("%s-%s.log", sessionName, at.Format("20060102-150405"))
+}
@@ -0,0 +1,29 @@
+package session
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseActivity(t *testing.T) {
+	got, err := parseActivity("1700000000\n")
+	if err != nil {
+		t.Fatalf("parseActivity() error = %v", err)
+	}
+
+	if !got.Equal(time.Unix(1700000000, 0)) {
+		t.Errorf("parseActivity() = %v, want %v", got, time.Unix(1700000000, 0))
+	}
+
+	if _, err := parseActivity(""); err == nil {
+		t.Error("parseActivity() of empty output should fail")
+	}
+}
+
+func TestTranscriptName(t *testing.T) {
+	at := time.Date(2024, 3, 9, 14, 5, 6, 0, time.Local)
+
+	if got, want := transcriptName("auto-worktree-feature", at), "auto-worktree-feature-20240309-140506.log"; got != want {
+		t.Errorf("transcriptName() = %q, want %q", got, want)
+	}
+}
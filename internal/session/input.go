@@ -0,0 +1,50 @@
+package session
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kaeawc/auto-worktree/internal/redact"
+)
+
+// submitDelay gives the AI tool time to take in a paste before Enter submits it
+const submitDelay = 300 * time.Millisecond
+
+// SendText types text into the session as a bracketed paste and presses Enter,
+// so a multi-line prompt reaches the AI tool as a single message. Secrets in
+// text are redacted first.
+func (m *SessionManager) SendText(name, text string) error {
+	if m.sessionType != TypeTmux {
+		return fmt.Errorf("sending input is only supported for tmux sessions")
+	}
+
+	buffer := name + "-input"
+
+	load, cancelLoad := m.tmux(nil, "load-buffer", "-b", buffer, "-")
+	defer cancelLoad()
+
+	load.Stdin = strings.NewReader(redact.String(text))
+
+	if output, err := load.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to load text for %s: %w: %s", name, err, strings.TrimSpace(string(output)))
+	}
+
+	paste, cancelPaste := m.tmux(nil, "paste-buffer", "-d", "-p", "-b", buffer, "-t", name)
+	defer cancelPaste()
+
+	if output, err := paste.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to paste into %s: %w: %s", name, err, strings.TrimSpace(string(output)))
+	}
+
+	time.Sleep(submitDelay)
+
+	submit, cancelSubmit := m.tmux(nil, "send-keys", "-t", name, "Enter")
+	defer cancelSubmit()
+
+	if err := submit.Run(); err != nil {
+		return fmt.Errorf("failed to submit input to %s: %w", name, err)
+	}
+
+	return nil
+}
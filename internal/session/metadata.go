@@ -2,12 +2,13 @@
 package session
 
 import (
-	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
-	"sync"
 	"time"
+
+	"github.com/kaeawc/auto-worktree/internal/ai"
 )
 
 // Status represents the state of a session
@@ -23,21 +24,56 @@ const (
 	StatusUnknown        Status = "unknown"
 )
 
+// ErrMetadataNotFound is returned when a session has no saved metadata
+var ErrMetadataNotFound = errors.New("metadata not found")
+
+// ErrMetadataCorrupt is returned when a session's metadata can't be parsed,
+// such as a legacy file cut short by a crash while an older version wrote it
+var ErrMetadataCorrupt = errors.New("metadata is corrupt")
+
 // Metadata represents persistent session metadata
 type Metadata struct {
-	SessionName    string                 `json:"sessionName"`
-	SessionID      string                 `json:"sessionId"`
-	SessionType    string                 `json:"sessionType"`
-	WorktreePath   string                 `json:"worktreePath"`
-	BranchName     string                 `json:"branchName"`
-	CreatedAt      time.Time              `json:"createdAt"`
-	LastAccessedAt time.Time              `json:"lastAccessedAt"`
-	Status         Status                 `json:"status"`
-	WindowCount    int                    `json:"windowCount"`
-	PaneCount      int                    `json:"paneCount"`
-	RootProcessPid int                    `json:"rootProcessPid"`
-	Dependencies   DependenciesInfo       `json:"dependencies"`
-	CustomMetadata map[string]interface{} `json:"customMetadata,omitempty"`
+	Version          int                    `json:"version"` // format version, upgraded on load; see MetadataVersion
+	SessionName      string                 `json:"sessionName"`
+	SessionID        string                 `json:"sessionId"`
+	SessionType      string                 `json:"sessionType"`
+	WorktreePath     string                 `json:"worktreePath"`
+	BranchName       string                 `json:"branchName"`
+	Scope            string                 `json:"scope,omitempty"`            // monorepo package the session starts in, relative to WorktreePath
+	ContainerID      string                 `json:"containerId,omitempty"`      // sandbox container the session runs in
+	ContainerRuntime string                 `json:"containerRuntime,omitempty"` // docker or podman
+	AITool           string                 `json:"aiTool,omitempty"`           // ai-tool config key of the tool the session runs
+	ConversationID   string                 `json:"conversationId,omitempty"`   // the tool's conversation, so resume continues it
+	CreatedAt        time.Time              `json:"createdAt"`
+	LastAccessedAt   time.Time              `json:"lastAccessedAt"`
+	Status           Status                 `json:"status"`
+	WindowCount      int                    `json:"windowCount"`
+	PaneCount        int                    `json:"paneCount"`
+	RootProcessPid   int                    `json:"rootProcessPid"`
+	Dependencies     DependenciesInfo       `json:"dependencies"`
+	Resources        *ResourceInfo          `json:"resources,omitempty"`      // CPU and memory limits, when configured
+	Issue            *IssueSnapshot         `json:"issue,omitempty"`          // the issue as last given to the AI tool
+	TranscriptPath   string                 `json:"transcriptPath,omitempty"` // scrollback saved when the session was stopped for being idle
+	Usage            map[string]*ai.Usage   `json:"usage,omitempty"`          // AI tool usage of each conversation the session has run, by conversation ID
+	CustomMetadata   map[string]interface{} `json:"customMetadata,omitempty"`
+}
+
+// ResourceInfo records the CPU and memory limits a session runs under
+type ResourceInfo struct {
+	CPUs   string `json:"cpus,omitempty"`
+	Memory string `json:"memory,omitempty"`
+	// Unit is the systemd scope enforcing the limits when the session is not in a container
+	Unit string `json:"unit,omitempty"`
+}
+
+// IssueSnapshot is what the AI tool was last told about the session's issue,
+// so a resumed session can be told what changed since
+type IssueSnapshot struct {
+	Provider string    `json:"provider"`
+	ID       string    `json:"id"`
+	Title    string    `json:"title,omitempty"`
+	Body     string    `json:"body"`
+	SyncedAt time.Time `json:"syncedAt"`
 }
 
 // DependenciesInfo tracks dependency installation state
@@ -48,25 +84,8 @@ type DependenciesInfo struct {
 	InstalledAt    *time.Time `json:"installedAt,omitempty"`
 }
 
-// FileMetadataStore handles reading/writing session metadata to the file system
-type FileMetadataStore struct {
-	baseDir string
-	mu      sync.RWMutex
-}
-
-// NewMetadataStore creates a new file-based metadata store
-func NewMetadataStore(baseDir string) (*FileMetadataStore, error) {
-	// Create base directory if it doesn't exist
-	if err := os.MkdirAll(baseDir, 0o700); err != nil {
-		return nil, fmt.Errorf("failed to create metadata directory: %w", err)
-	}
-
-	return &FileMetadataStore{
-		baseDir: baseDir,
-	}, nil
-}
-
-// GetSessionDir returns the directory where session metadata is stored
+// GetSessionDir returns the directory of the session metadata files used
+// before the state database
 func GetSessionDir() (string, error) {
 	home, err := os.UserHomeDir()
 	if err != nil {
@@ -77,153 +96,3 @@ func GetSessionDir() (string, error) {
 
 	return sessionsDir, nil
 }
-
-// metadataPath returns the path for a session's metadata file
-func (s *FileMetadataStore) metadataPath(sessionName string) string {
-	return filepath.Join(s.baseDir, sessionName+".json")
-}
-
-// SaveMetadata persists session metadata to disk
-func (s *FileMetadataStore) SaveMetadata(metadata *Metadata) error {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	if metadata.SessionName == "" {
-		return fmt.Errorf("session name is required")
-	}
-
-	// Update LastAccessedAt to current time
-	metadata.LastAccessedAt = time.Now()
-
-	// Marshal to JSON
-	data, err := json.MarshalIndent(metadata, "", "  ")
-	if err != nil {
-		return fmt.Errorf("failed to marshal metadata: %w", err)
-	}
-
-	path := s.metadataPath(metadata.SessionName)
-
-	// Write to temporary file first for atomicity
-	tmpPath := path + ".tmp"
-	if err := os.WriteFile(tmpPath, data, 0o600); err != nil {
-		return fmt.Errorf("failed to write metadata: %w", err)
-	}
-
-	// Rename to final path
-	if err := os.Rename(tmpPath, path); err != nil {
-		_ = os.Remove(tmpPath) //nolint:errcheck // Cleanup attempt on failure
-		return fmt.Errorf("failed to save metadata: %w", err)
-	}
-
-	return nil
-}
-
-// LoadMetadata loads session metadata from disk
-func (s *FileMetadataStore) LoadMetadata(sessionName string) (*Metadata, error) {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-
-	path := s.metadataPath(sessionName)
-	data, err := os.ReadFile(path) //nolint:gosec // G304: Path is derived from sessionName parameter
-
-	if err != nil {
-		if os.IsNotExist(err) {
-			return nil, fmt.Errorf("metadata not found for session: %s", sessionName)
-		}
-
-		return nil, fmt.Errorf("failed to read metadata: %w", err)
-	}
-
-	var metadata Metadata
-	if err := json.Unmarshal(data, &metadata); err != nil {
-		return nil, fmt.Errorf("failed to parse metadata: %w", err)
-	}
-
-	return &metadata, nil
-}
-
-// DeleteMetadata removes session metadata from disk
-func (s *FileMetadataStore) DeleteMetadata(sessionName string) error {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	path := s.metadataPath(sessionName)
-	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
-		return fmt.Errorf("failed to delete metadata: %w", err)
-	}
-
-	return nil
-}
-
-// ListMetadata returns all session metadata files
-func (s *FileMetadataStore) ListMetadata() ([]string, error) {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-
-	entries, err := os.ReadDir(s.baseDir)
-
-	if err != nil {
-		if os.IsNotExist(err) {
-			return []string{}, nil
-		}
-
-		return nil, fmt.Errorf("failed to list metadata: %w", err)
-	}
-
-	var sessions []string
-
-	for _, entry := range entries {
-		if !entry.IsDir() && filepath.Ext(entry.Name()) == ".json" {
-			sessionName := entry.Name()[:len(entry.Name())-5] // Remove .json
-			sessions = append(sessions, sessionName)
-		}
-	}
-
-	return sessions, nil
-}
-
-// LoadAllMetadata loads all session metadata files
-func (s *FileMetadataStore) LoadAllMetadata() ([]*Metadata, error) {
-	sessionNames, err := s.ListMetadata()
-	if err != nil {
-		return nil, err
-	}
-
-	metadataList := make([]*Metadata, 0, len(sessionNames))
-
-	for _, sessionName := range sessionNames {
-		metadata, err := s.LoadMetadata(sessionName)
-
-		if err != nil {
-			// Skip corrupted metadata files
-			continue
-		}
-
-		metadataList = append(metadataList, metadata)
-	}
-
-	return metadataList, nil
-}
-
-// ExistsMetadata checks if metadata exists for a session
-func (s *FileMetadataStore) ExistsMetadata(sessionName string) bool {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-
-	path := s.metadataPath(sessionName)
-	_, err := os.Stat(path)
-
-	return err == nil
-}
-
-// UpdateStatus updates only the status of a session
-func (s *FileMetadataStore) UpdateStatus(sessionName string, status Status) error {
-	metadata, err := s.LoadMetadata(sessionName)
-	if err != nil {
-		return err
-	}
-
-	metadata.Status = status
-
-	return s.SaveMetadata(metadata)
-}
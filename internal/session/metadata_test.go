@@ -1,21 +1,17 @@
 package session
 
 import (
-	"os"
+	"errors"
 	"path/filepath"
+	"sync"
 	"testing"
 	"time"
+
+	"github.com/kaeawc/auto-worktree/internal/state"
 )
 
 func TestMetadataStore_SaveAndLoadMetadata(t *testing.T) {
-	// Create temporary directory for test
-	tmpDir := t.TempDir()
-
-	// Create metadata store
-	store, err := NewMetadataStore(tmpDir)
-	if err != nil {
-		t.Fatalf("failed to create metadata store: %v", err)
-	}
+	store := newTestMetadataStore(t)
 
 	// Create test metadata
 	now := time.Now()
@@ -68,8 +64,7 @@ func TestMetadataStore_SaveAndLoadMetadata(t *testing.T) {
 }
 
 func TestMetadataStore_DeleteMetadata(t *testing.T) {
-	tmpDir := t.TempDir()
-	store, _ := NewMetadataStore(tmpDir)
+	store := newTestMetadataStore(t)
 
 	// Save metadata
 	metadata := &Metadata{
@@ -91,8 +86,7 @@ func TestMetadataStore_DeleteMetadata(t *testing.T) {
 }
 
 func TestMetadataStore_ListMetadata(t *testing.T) {
-	tmpDir := t.TempDir()
-	store, _ := NewMetadataStore(tmpDir)
+	store := newTestMetadataStore(t)
 
 	// Save multiple metadata
 	sessionNames := []string{"session1", "session2", "session3"}
@@ -117,8 +111,7 @@ func TestMetadataStore_ListMetadata(t *testing.T) {
 }
 
 func TestMetadataStore_UpdateStatus(t *testing.T) {
-	tmpDir := t.TempDir()
-	store, _ := NewMetadataStore(tmpDir)
+	store := newTestMetadataStore(t)
 
 	// Save initial metadata
 	metadata := &Metadata{
@@ -141,8 +134,7 @@ func TestMetadataStore_UpdateStatus(t *testing.T) {
 }
 
 func TestMetadataStore_AtomicWrite(t *testing.T) {
-	tmpDir := t.TempDir()
-	store, _ := NewMetadataStore(tmpDir)
+	store := newTestMetadataStore(t)
 
 	// Save metadata multiple times to test atomicity
 	for i := 0; i < 10; i++ {
@@ -163,18 +155,10 @@ func TestMetadataStore_AtomicWrite(t *testing.T) {
 		t.Errorf("expected final window count 9, got %d", loaded.WindowCount)
 	}
 
-	// Verify no temporary files were left
-	files, _ := os.ReadDir(tmpDir)
-	for _, f := range files {
-		if filepath.Ext(f.Name()) == ".tmp" {
-			t.Errorf("found leftover temporary file: %s", f.Name())
-		}
-	}
 }
 
 func TestMetadataStore_ExistsMetadata(t *testing.T) {
-	tmpDir := t.TempDir()
-	store, _ := NewMetadataStore(tmpDir)
+	store := newTestMetadataStore(t)
 
 	// Verify non-existent metadata
 	if store.ExistsMetadata("nonexistent") {
@@ -196,8 +180,7 @@ func TestMetadataStore_ExistsMetadata(t *testing.T) {
 }
 
 func TestMetadataStore_LastAccessedAtUpdate(t *testing.T) {
-	tmpDir := t.TempDir()
-	store, _ := NewMetadataStore(tmpDir)
+	store := newTestMetadataStore(t)
 
 	beforeSave := time.Now()
 
@@ -218,12 +201,10 @@ func TestMetadataStore_LastAccessedAtUpdate(t *testing.T) {
 }
 
 func TestMetadataStore_CorruptedFile(t *testing.T) {
-	tmpDir := t.TempDir()
-	store, _ := NewMetadataStore(tmpDir)
+	store := newTestMetadataStore(t)
 
-	// Write corrupted JSON to a metadata file
-	metadataPath := filepath.Join(tmpDir, "corrupt-session.json")
-	os.WriteFile(metadataPath, []byte("{invalid json"), 0o600)
+	// Store corrupted JSON as a session's metadata
+	putRawMetadata(t, store, "corrupt-session", "{invalid json")
 
 	// Try to load corrupted metadata
 	_, err := store.LoadMetadata("corrupt-session")
@@ -233,8 +214,7 @@ func TestMetadataStore_CorruptedFile(t *testing.T) {
 }
 
 func TestMetadataStore_LoadAllMetadata(t *testing.T) {
-	tmpDir := t.TempDir()
-	store, _ := NewMetadataStore(tmpDir)
+	store := newTestMetadataStore(t)
 
 	// Save multiple metadata
 	sessionNames := []string{"session-1", "session-2", "session-3", "session-4", "session-5"}
@@ -299,3 +279,119 @@ func TestDependenciesInfo(t *testing.T) {
 		t.Errorf("expected InstalledAt to be set")
 	}
 }
+
+func TestMetadataStore_TruncatedEntrySetAside(t *testing.T) {
+	store := newTestMetadataStore(t)
+
+	store.SaveMetadata(&Metadata{SessionName: "good", SessionType: "tmux", Status: StatusRunning})
+
+	// Metadata cut short mid-write, as a crash left legacy files
+	putRawMetadata(t, store, "truncated", `{"sessionName": "truncated", "sessionTy`)
+
+	_, err := store.LoadMetadata("truncated")
+	if !errors.Is(err, ErrMetadataCorrupt) {
+		t.Fatalf("expected ErrMetadataCorrupt, got %v", err)
+	}
+
+	var setAside []byte
+
+	store.store.View(func(tx *state.Tx) error {
+		setAside = append([]byte(nil), tx.GetRaw(state.BucketCorruptSessions, "truncated")...)
+		return nil
+	})
+
+	if len(setAside) == 0 {
+		t.Error("corrupt metadata should be set aside")
+	}
+
+	all, err := store.LoadAllMetadata()
+	if err != nil {
+		t.Fatalf("failed to load all metadata: %v", err)
+	}
+
+	if len(all) != 1 || all[0].SessionName != "good" {
+		t.Errorf("expected only the good session, got %d", len(all))
+	}
+
+	// The session saves afresh over it
+	if err := store.SaveMetadata(&Metadata{SessionName: "truncated", Status: StatusRunning}); err != nil {
+		t.Fatalf("failed to save over corrupt metadata: %v", err)
+	}
+
+	if _, err := store.LoadMetadata("truncated"); err != nil {
+		t.Errorf("expected the new metadata to load, got %v", err)
+	}
+}
+
+func TestMetadataStore_CorruptEntriesSetAsideByList(t *testing.T) {
+	store := newTestMetadataStore(t)
+
+	store.SaveMetadata(&Metadata{SessionName: "good", Status: StatusRunning})
+	putRawMetadata(t, store, "broken", `{"sessionName": `)
+
+	if all, err := store.LoadAllMetadata(); err != nil || len(all) != 1 {
+		t.Fatalf("LoadAllMetadata() = %d entries (err %v), want 1", len(all), err)
+	}
+
+	if names, _ := store.ListMetadata(); len(names) != 1 || names[0] != "good" {
+		t.Errorf("ListMetadata() = %v, want the corrupt entry set aside", names)
+	}
+
+	putRawMetadata(t, store, "broken", `{"sessionName": `)
+
+	if err := store.UpdateStatus("broken", StatusPaused); !errors.Is(err, ErrMetadataCorrupt) {
+		t.Errorf("UpdateStatus() on corrupt metadata = %v, want ErrMetadataCorrupt", err)
+	}
+
+	if store.ExistsMetadata("broken") {
+		t.Error("UpdateStatus() should set corrupt metadata aside")
+	}
+}
+
+func TestMetadataStore_ConcurrentSaves(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.db")
+
+	var wg sync.WaitGroup
+
+	// Separate stores stand in for separate processes saving the same session
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+
+		go func(windows int) {
+			defer wg.Done()
+
+			store := NewStateMetadataStore(state.NewStore(path))
+			for j := 0; j < 20; j++ {
+				if err := store.SaveMetadata(&Metadata{SessionName: "shared", WindowCount: windows}); err != nil {
+					t.Errorf("save failed: %v", err)
+					return
+				}
+			}
+		}(i)
+	}
+
+	wg.Wait()
+
+	store := NewStateMetadataStore(state.NewStore(path))
+	if _, err := store.LoadMetadata("shared"); err != nil {
+		t.Errorf("metadata should be whole after concurrent saves: %v", err)
+	}
+}
+
+// newTestMetadataStore creates a metadata store in a fresh state database
+func newTestMetadataStore(t *testing.T) *StateMetadataStore {
+	t.Helper()
+
+	return NewStateMetadataStore(state.NewStore(filepath.Join(t.TempDir(), "state.db")))
+}
+
+// putRawMetadata stores data as a session's metadata as is
+func putRawMetadata(t *testing.T, store *StateMetadataStore, sessionName, data string) {
+	t.Helper()
+
+	if err := store.store.Update(func(tx *state.Tx) error {
+		return tx.PutRaw(state.BucketSessions, sessionName, []byte(data))
+	}); err != nil {
+		t.Fatalf("failed to store raw metadata: %v", err)
+	}
+}
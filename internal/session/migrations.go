@@ -0,0 +1,83 @@
+package session
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// metadataVersionKey is the JSON key holding a session's metadata version
+const metadataVersionKey = "version"
+
+// ErrMetadataTooNew is returned for metadata saved by a newer auto-worktree,
+// which this build could lose fields of by saving it again
+var ErrMetadataTooNew = errors.New("session metadata is newer than this auto-worktree supports; please upgrade")
+
+// metadataMigration upgrades a session's metadata by one version. It works on
+// the raw JSON fields, so it can rename, split or fill in keys that Metadata
+// no longer has.
+type metadataMigration struct {
+	description string
+	apply       func(fields map[string]json.RawMessage) error
+}
+
+// metadataMigrations are applied in order; migration i brings metadata to
+// version i+1. Never reorder or edit a released migration, only append new ones.
+var metadataMigrations = []metadataMigration{
+	// Metadata saved before versioning has no "version" key and needs no changes
+	{description: "start versioning session metadata", apply: func(map[string]json.RawMessage) error { return nil }},
+}
+
+// MetadataVersion is the session metadata version this build reads and writes
+var MetadataVersion = len(metadataMigrations)
+
+// decodeMetadata parses metadata saved by this or an older version, upgrading
+// an older format; migrated reports whether it should be saved back
+func decodeMetadata(data []byte) (metadata *Metadata, migrated bool, err error) {
+	return decodeMetadataWith(data, metadataMigrations)
+}
+
+// decodeMetadataWith is decodeMetadata with the migrations to apply
+func decodeMetadataWith(data []byte, migrations []metadataMigration) (*Metadata, bool, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, false, fmt.Errorf("failed to parse metadata: %w", err)
+	}
+
+	version := 0
+
+	if raw, ok := fields[metadataVersionKey]; ok {
+		if err := json.Unmarshal(raw, &version); err != nil {
+			return nil, false, fmt.Errorf("failed to parse metadata version: %w", err)
+		}
+	}
+
+	if version > len(migrations) {
+		return nil, false, fmt.Errorf("%w (v%d, this build supports v%d)", ErrMetadataTooNew, version, len(migrations))
+	}
+
+	migrated := version < len(migrations)
+
+	if migrated {
+		for v := version; v < len(migrations); v++ {
+			if err := migrations[v].apply(fields); err != nil {
+				return nil, false, fmt.Errorf("session metadata migration %d (%s) failed: %w", v+1, migrations[v].description, err)
+			}
+		}
+
+		fields[metadataVersionKey] = json.RawMessage(strconv.Itoa(len(migrations)))
+
+		var err error
+		if data, err = json.Marshal(fields); err != nil {
+			return nil, false, fmt.Errorf("failed to marshal migrated metadata: %w", err)
+		}
+	}
+
+	var metadata Metadata
+	if err := json.Unmarshal(data, &metadata); err != nil {
+		return nil, false, fmt.Errorf("failed to parse metadata: %w", err)
+	}
+
+	return &metadata, migrated, nil
+}
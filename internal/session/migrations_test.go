@@ -0,0 +1,116 @@
+package session
+
+import (
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kaeawc/auto-worktree/internal/state"
+)
+
+// legacyMetadata is session metadata as saved before it was versioned
+const legacyMetadata = `{"sessionName":"auto-worktree-feature","worktreePath":"/wt/feature",` +
+	`"branchName":"feature","status":"running","lastAccessedAt":"2025-01-02T03:04:05Z"}`
+
+func TestDecodeMetadata_Unversioned(t *testing.T) {
+	metadata, migrated, err := decodeMetadata([]byte(legacyMetadata))
+	if err != nil {
+		t.Fatalf("decodeMetadata() error = %v", err)
+	}
+
+	if !migrated || metadata.Version != MetadataVersion {
+		t.Errorf("migrated = %v, Version = %d; want true, %d", migrated, metadata.Version, MetadataVersion)
+	}
+
+	if metadata.BranchName != "feature" || metadata.Status != StatusRunning {
+		t.Errorf("decodeMetadata() = %+v, want the legacy fields kept", metadata)
+	}
+
+	current, _ := json.Marshal(metadata) //nolint:errcheck // Metadata always marshals
+	if _, migrated, err := decodeMetadata(current); err != nil || migrated {
+		t.Errorf("decodeMetadata(current) migrated = %v, err = %v; want false, nil", migrated, err)
+	}
+}
+
+func TestDecodeMetadataWith_Migrations(t *testing.T) {
+	// Version 1 renamed "worktree" to "worktreePath"; version 2 filled in a missing status
+	migrations := []metadataMigration{
+		{description: "rename worktree", apply: func(fields map[string]json.RawMessage) error {
+			if raw, ok := fields["worktree"]; ok {
+				fields["worktreePath"] = raw
+				delete(fields, "worktree")
+			}
+
+			return nil
+		}},
+		{description: "fill in status", apply: func(fields map[string]json.RawMessage) error {
+			if _, ok := fields["status"]; !ok {
+				fields["status"] = json.RawMessage(`"unknown"`)
+			}
+
+			return nil
+		}},
+	}
+
+	metadata, migrated, err := decodeMetadataWith([]byte(`{"sessionName":"s","worktree":"/wt/s"}`), migrations)
+	if err != nil {
+		t.Fatalf("decodeMetadataWith() error = %v", err)
+	}
+
+	if !migrated || metadata.Version != 2 || metadata.WorktreePath != "/wt/s" || metadata.Status != StatusUnknown {
+		t.Errorf("decodeMetadataWith(v0) = %+v, migrated = %v", metadata, migrated)
+	}
+
+	// Only the migrations after the saved version run
+	metadata, _, err = decodeMetadataWith([]byte(`{"version":1,"sessionName":"s","worktree":"/old","status":"paused"}`), migrations)
+	if err != nil {
+		t.Fatalf("decodeMetadataWith() error = %v", err)
+	}
+
+	if metadata.WorktreePath != "" || metadata.Status != StatusPaused {
+		t.Errorf("decodeMetadataWith(v1) = %+v, want only the v2 migration applied", metadata)
+	}
+
+	failing := append(migrations, metadataMigration{description: "broken", apply: func(map[string]json.RawMessage) error {
+		return errors.New("boom")
+	}})
+	if _, _, err := decodeMetadataWith([]byte(`{"version":2}`), failing); err == nil || !strings.Contains(err.Error(), "migration 3 (broken)") {
+		t.Errorf("decodeMetadataWith() error = %v, want it to name the failed migration", err)
+	}
+
+	if _, _, err := decodeMetadataWith([]byte(`{"version":3}`), migrations); !errors.Is(err, ErrMetadataTooNew) {
+		t.Errorf("decodeMetadataWith(v3) error = %v, want ErrMetadataTooNew", err)
+	}
+}
+
+func TestStateMetadataStore_UpgradesInPlace(t *testing.T) {
+	db := state.NewStore(filepath.Join(t.TempDir(), "state.db"))
+
+	if err := db.Update(func(tx *state.Tx) error {
+		return tx.PutRaw(state.BucketSessions, "auto-worktree-feature", []byte(legacyMetadata))
+	}); err != nil {
+		t.Fatalf("failed to seed legacy metadata: %v", err)
+	}
+
+	all, err := NewStateMetadataStore(db).LoadAllMetadata()
+	if err != nil || len(all) != 1 {
+		t.Fatalf("LoadAllMetadata() = %v, %v; want the legacy session", all, err)
+	}
+
+	var saved Metadata
+	if err := db.Get(state.BucketSessions, "auto-worktree-feature", &saved); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	if saved.Version != MetadataVersion {
+		t.Errorf("saved Version = %d, want %d", saved.Version, MetadataVersion)
+	}
+
+	// Upgrading isn't an access, so it keeps when the session was last used
+	if want := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC); !saved.LastAccessedAt.Equal(want) {
+		t.Errorf("saved LastAccessedAt = %v, want %v", saved.LastAccessedAt, want)
+	}
+}
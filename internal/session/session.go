@@ -7,6 +7,12 @@ import (
 	"os"
 	"os/exec"
 	"strings"
+
+	"github.com/kaeawc/auto-worktree/internal/events"
+	"github.com/kaeawc/auto-worktree/internal/interrupt"
+	"github.com/kaeawc/auto-worktree/internal/limits"
+	"github.com/kaeawc/auto-worktree/internal/remote"
+	"github.com/kaeawc/auto-worktree/internal/state"
 )
 
 // Type represents the type of terminal multiplexer
@@ -30,41 +36,57 @@ type Session struct {
 type SessionManager struct { //nolint:revive // Concrete type name, not an interface
 	sessionType   Type
 	metadataStore MetadataStore
+	// ctx stops the multiplexer commands when it is done; nil never does
+	ctx context.Context
 }
 
-// NewManager creates a new session manager
+// NewManager creates a new session manager whose commands stop on Ctrl-C
 // It requires tmux - screen is no longer supported
 func NewManager() *SessionManager {
+	return NewManagerContext(interrupt.Context())
+}
+
+// NewManagerContext creates a session manager whose commands stop when ctx is done
+func NewManagerContext(ctx context.Context) *SessionManager {
 	sessionType := TypeNone
 	if commandExists("tmux") {
 		sessionType = TypeTmux
 	}
 
-	// Initialize metadata store
-	sessionDir, err := GetSessionDir()
-	if err != nil {
-		// If metadata store initialization fails, continue without it
-		return &SessionManager{
-			sessionType:   sessionType,
-			metadataStore: nil,
-		}
-	}
-
-	metadataStore, err := NewMetadataStore(sessionDir)
+	// Session metadata lives in the shared state database
+	statePath, err := state.DefaultPath()
 	if err != nil {
 		// If metadata store initialization fails, continue without it
 		return &SessionManager{
 			sessionType:   sessionType,
 			metadataStore: nil,
+			ctx:           ctx,
 		}
 	}
 
 	return &SessionManager{
 		sessionType:   sessionType,
-		metadataStore: metadataStore,
+		metadataStore: NewStateMetadataStore(state.NewStore(statePath)),
+		ctx:           ctx,
 	}
 }
 
+// commandContext is the context the manager's commands run under
+func (m *SessionManager) commandContext() context.Context {
+	if m.ctx == nil {
+		return context.Background()
+	}
+
+	return m.ctx
+}
+
+// tmux builds a tmux command that stops when the manager's context is done or
+// the tmux timeout passes; call cancel once it has run
+func (m *SessionManager) tmux(env []string, args ...string) (*exec.Cmd, context.CancelFunc) {
+	ctx, cancel := limits.Context(m.commandContext(), limits.ToolTmux)
+	return remote.Command(ctx, "", env, "tmux", args...), cancel
+}
+
 // SessionType returns the session type this manager uses
 func (m *SessionManager) SessionType() Type {
 	return m.sessionType
@@ -81,14 +103,30 @@ func (m *SessionManager) CreateSession(name, workingDir string, command []string
 		return fmt.Errorf("no terminal multiplexer available (install tmux or screen)")
 	}
 
+	var err error
+
 	switch m.sessionType {
 	case TypeTmux:
-		return m.createTmuxSession(name, workingDir, command)
+		err = m.createTmuxSession(name, workingDir, command)
 	case TypeScreen:
-		return m.createScreenSession(name, workingDir, command)
+		err = m.createScreenSession(name, workingDir, command)
 	default:
 		return fmt.Errorf("unsupported session type: %s", m.sessionType)
 	}
+
+	recordSessionEvent(events.ActionSessionStart, name, workingDir, strings.Join(command, " "), err)
+
+	return err
+}
+
+// recordSessionEvent adds a session operation to the event log
+func recordSessionEvent(action, name, workingDir, detail string, err error) {
+	e := events.Event{Action: action, Session: name, Path: workingDir, Detail: detail}
+	if err != nil {
+		e.Error = err.Error()
+	}
+
+	events.Record(e)
 }
 
 // createTmuxSession creates a detached tmux session
@@ -103,9 +141,10 @@ func (m *SessionManager) createTmuxSession(name, workingDir string, command []st
 	}
 	args = append(args, command...)
 
-	cmd := exec.CommandContext(context.Background(), "tmux", args...)
 	// Set TERM to enable proper color support inside the session
-	cmd.Env = append(os.Environ(), "TERM=tmux-256color")
+	cmd, cancel := m.tmux([]string{"TERM=tmux-256color"}, args...)
+	defer cancel()
+
 	if err := cmd.Run(); err != nil {
 		return fmt.Errorf("failed to create tmux session: %w", err)
 	}
@@ -115,7 +154,9 @@ func (m *SessionManager) createTmuxSession(name, workingDir string, command []st
 		"set-option", "-t", name,
 		"default-terminal", "tmux-256color",
 	}
-	configCmd := exec.CommandContext(context.Background(), "tmux", configArgs...)
+	configCmd, cancelConfig := m.tmux(nil, configArgs...)
+	defer cancelConfig()
+
 	_ = configCmd.Run() //nolint:errcheck // Non-fatal: configuration failure doesn't prevent session creation
 	// Non-fatal: configuration failed but session is created
 
@@ -130,7 +171,7 @@ func (m *SessionManager) createScreenSession(name, workingDir string, command []
 		escapeShellArg(workingDir),
 		strings.Join(escapeShellArgs(command), " "))
 
-	cmd := exec.CommandContext(context.Background(), "screen", "-dmS", name, "bash", "-c", shellCmd)
+	cmd := exec.CommandContext(m.commandContext(), "screen", "-dmS", name, "bash", "-c", shellCmd)
 	if err := cmd.Run(); err != nil {
 		return fmt.Errorf("failed to create screen session: %w", err)
 	}
@@ -146,11 +187,13 @@ func (m *SessionManager) HasSession(name string) (bool, error) {
 
 	switch m.sessionType {
 	case TypeTmux:
-		cmd := exec.CommandContext(context.Background(), "tmux", "has-session", "-t", name)
+		cmd, cancel := m.tmux(nil, "has-session", "-t", name)
+		defer cancel()
+
 		return cmd.Run() == nil, nil
 	case TypeScreen:
 		// List sessions and check if name exists
-		cmd := exec.CommandContext(context.Background(), "screen", "-ls")
+		cmd := exec.CommandContext(m.commandContext(), "screen", "-ls")
 		output, err := cmd.Output()
 
 		if err != nil {
@@ -186,7 +229,9 @@ func (m *SessionManager) ListSessions() ([]string, error) {
 
 // listTmuxSessions lists all tmux sessions
 func (m *SessionManager) listTmuxSessions() ([]string, error) {
-	cmd := exec.CommandContext(context.Background(), "tmux", "list-sessions", "-F", "#{session_name}")
+	cmd, cancel := m.tmux(nil, "list-sessions", "-F", "#{session_name}")
+	defer cancel()
+
 	output, err := cmd.Output()
 
 	if err != nil {
@@ -201,7 +246,7 @@ func (m *SessionManager) listTmuxSessions() ([]string, error) {
 
 // listScreenSessions lists all screen sessions
 func (m *SessionManager) listScreenSessions() ([]string, error) {
-	cmd := exec.CommandContext(context.Background(), "screen", "-ls")
+	cmd := exec.CommandContext(m.commandContext(), "screen", "-ls")
 	output, err := cmd.Output()
 
 	if err != nil && len(output) == 0 {
@@ -234,18 +279,28 @@ func (m *SessionManager) listScreenSessions() ([]string, error) {
 
 // KillSession terminates a session
 func (m *SessionManager) KillSession(name string) error {
+	err := m.killSession(name)
+	recordSessionEvent(events.ActionSessionKill, name, "", "", err)
+
+	return err
+}
+
+// killSession terminates a session with the multiplexer in use
+func (m *SessionManager) killSession(name string) error {
 	if !m.IsAvailable() {
 		return fmt.Errorf("no terminal multiplexer available")
 	}
 
 	switch m.sessionType {
 	case TypeTmux:
-		cmd := exec.CommandContext(context.Background(), "tmux", "kill-session", "-t", name)
+		cmd, cancel := m.tmux(nil, "kill-session", "-t", name)
+		defer cancel()
+
 		return cmd.Run()
 	case TypeScreen:
 		// screen requires the full session name with PID prefix
 		// We need to find it first
-		cmd := exec.CommandContext(context.Background(), "screen", "-ls")
+		cmd := exec.CommandContext(m.commandContext(), "screen", "-ls")
 		output, err := cmd.Output()
 
 		if err != nil {
@@ -259,7 +314,7 @@ func (m *SessionManager) KillSession(name string) error {
 				parts := strings.Fields(line)
 				if len(parts) > 0 {
 					sessionFull := parts[0]
-					killCmd := exec.CommandContext(context.Background(), "screen", "-S", sessionFull, "-X", "quit")
+					killCmd := exec.CommandContext(m.commandContext(), "screen", "-S", sessionFull, "-X", "quit")
 
 					return killCmd.Run()
 				}
@@ -301,7 +356,7 @@ func (m *SessionManager) AttachToSession(name string) error {
 	}
 
 	// Detect terminal and open new window
-	return openTerminalWindow(attachCmd)
+	return openTerminalWindow(remote.AttachCommand(attachCmd))
 }
 
 // openTerminalWindow opens a new terminal window running the specified command
@@ -371,10 +426,9 @@ func GenerateSessionName(branchName string) string {
 	return "auto-worktree-" + name
 }
 
-// commandExists checks if a command is available in PATH
+// commandExists checks if a command is available in PATH (on the --host machine when set)
 func commandExists(cmd string) bool {
-	_, err := exec.LookPath(cmd)
-	return err == nil
+	return remote.LookPath(cmd) == nil
 }
 
 // escapeShellArg escapes a single shell argument
@@ -431,6 +485,11 @@ func GetShellCommand(configuredShell string) []string {
 		return []string{configuredShell}
 	}
 
+	if remote.Enabled() {
+		// Our $SHELL may not exist on the --host machine; tmux starts the remote default shell
+		return nil
+	}
+
 	return []string{GetUserShell()}
 }
 
@@ -537,6 +596,11 @@ func (m *SessionManager) SyncSessionStatus(sessionName string) error {
 	}
 
 	if !exists {
+		// A paused session was stopped on purpose and is restarted by resume
+		if status, err := m.GetSessionStatus(sessionName); err == nil && status == StatusPaused {
+			return nil
+		}
+
 		// Session no longer exists, mark as failed
 		return m.MarkSessionFailed(sessionName)
 	}
@@ -0,0 +1,248 @@
+package session
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kaeawc/auto-worktree/internal/logging"
+	"github.com/kaeawc/auto-worktree/internal/state"
+)
+
+// StateMetadataStore keeps session metadata in the shared state database
+type StateMetadataStore struct {
+	store *state.Store
+}
+
+// NewStateMetadataStore creates a metadata store backed by the state database
+func NewStateMetadataStore(store *state.Store) *StateMetadataStore {
+	return &StateMetadataStore{store: store}
+}
+
+// SaveMetadata persists session metadata. It is written in one transaction,
+// so a crash leaves either the old metadata or the new, never part of it.
+func (s *StateMetadataStore) SaveMetadata(metadata *Metadata) error {
+	if metadata.SessionName == "" {
+		return fmt.Errorf("session name is required")
+	}
+
+	// Update LastAccessedAt to current time
+	metadata.LastAccessedAt = time.Now()
+	metadata.Version = MetadataVersion
+
+	if err := s.store.Put(state.BucketSessions, metadata.SessionName, metadata); err != nil {
+		return fmt.Errorf("failed to save metadata: %w", err)
+	}
+
+	return nil
+}
+
+// LoadMetadata loads session metadata, upgrading it in place if an older version saved it
+func (s *StateMetadataStore) LoadMetadata(sessionName string) (*Metadata, error) {
+	var data []byte
+
+	if err := s.store.View(func(tx *state.Tx) error {
+		// The raw value is only valid inside the transaction
+		if raw := tx.GetRaw(state.BucketSessions, sessionName); raw != nil {
+			data = append([]byte(nil), raw...)
+		}
+
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to load metadata: %w", err)
+	}
+
+	if data == nil {
+		return nil, fmt.Errorf("%w for session: %s", ErrMetadataNotFound, sessionName)
+	}
+
+	metadata, migrated, err := decodeMetadata(data)
+	if errors.Is(err, ErrMetadataTooNew) {
+		return nil, err
+	}
+
+	if err != nil {
+		return nil, s.setAsideCorrupt(sessionName, err)
+	}
+
+	if migrated {
+		s.saveMigrated([]*Metadata{metadata})
+	}
+
+	return metadata, nil
+}
+
+// setAsideCorrupt moves a session's metadata that can't be parsed, such as
+// metadata cut short by a crash of an older version, to the corrupt sessions
+// bucket, so it stops breaking the session and the next save starts afresh.
+// It returns ErrMetadataCorrupt for the session.
+func (s *StateMetadataStore) setAsideCorrupt(sessionName string, parseErr error) error {
+	moved := false
+
+	err := s.store.Update(func(tx *state.Tx) error {
+		data := tx.GetRaw(state.BucketSessions, sessionName)
+		if data == nil {
+			return nil
+		}
+
+		// Another process may have saved it again since it was read
+		if _, _, err := decodeMetadata(data); err == nil || errors.Is(err, ErrMetadataTooNew) {
+			return nil
+		}
+
+		if err := tx.PutRaw(state.BucketCorruptSessions, sessionName, append([]byte(nil), data...)); err != nil {
+			return err
+		}
+
+		moved = true
+
+		return tx.Delete(state.BucketSessions, sessionName)
+	})
+
+	switch {
+	case err != nil:
+		logging.Debug("failed to set aside corrupt session metadata", "session", sessionName, "err", err)
+	case moved:
+		logging.Warn("set aside corrupt session metadata", "session", sessionName, "err", parseErr)
+	}
+
+	return fmt.Errorf("%w for session %s: %w", ErrMetadataCorrupt, sessionName, parseErr)
+}
+
+// saveMigrated writes back upgraded metadata as is, without touching
+// LastAccessedAt; on failure it is upgraded again the next time it's read
+func (s *StateMetadataStore) saveMigrated(migrated []*Metadata) {
+	err := s.store.Update(func(tx *state.Tx) error {
+		for _, metadata := range migrated {
+			data, err := json.Marshal(metadata)
+			if err != nil {
+				return err
+			}
+
+			if err := tx.PutRaw(state.BucketSessions, metadata.SessionName, data); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		logging.Debug("failed to save migrated session metadata", "err", err)
+	}
+}
+
+// DeleteMetadata removes session metadata
+func (s *StateMetadataStore) DeleteMetadata(sessionName string) error {
+	if err := s.store.Delete(state.BucketSessions, sessionName); err != nil {
+		return fmt.Errorf("failed to delete metadata: %w", err)
+	}
+
+	return nil
+}
+
+// ListMetadata returns the names of all sessions with metadata
+func (s *StateMetadataStore) ListMetadata() ([]string, error) {
+	names, err := s.store.Keys(state.BucketSessions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list metadata: %w", err)
+	}
+
+	if names == nil {
+		names = []string{}
+	}
+
+	return names, nil
+}
+
+// LoadAllMetadata loads all session metadata in a single read
+func (s *StateMetadataStore) LoadAllMetadata() ([]*Metadata, error) {
+	var metadataList []*Metadata
+
+	var migrated []*Metadata
+
+	corrupt := map[string]error{}
+
+	err := s.store.ForEach(state.BucketSessions, func(key string, value []byte) error {
+		metadata, upgraded, err := decodeMetadata(value)
+		if errors.Is(err, ErrMetadataTooNew) {
+			// Skipped, but kept for the newer version that saved it
+			logging.Debug("skipping session metadata from a newer version", "session", key)
+			return nil
+		}
+
+		if err != nil {
+			corrupt[key] = err
+			return nil
+		}
+
+		if upgraded {
+			migrated = append(migrated, metadata)
+		}
+
+		metadataList = append(metadataList, metadata)
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load metadata: %w", err)
+	}
+
+	if len(migrated) > 0 {
+		s.saveMigrated(migrated)
+	}
+
+	// Corrupt entries are skipped and set aside; the store is not in a
+	// transaction any more, so moving them can't deadlock
+	for key, parseErr := range corrupt {
+		_ = s.setAsideCorrupt(key, parseErr) //nolint:errcheck // the error only says it was corrupt
+	}
+
+	return metadataList, nil
+}
+
+// ExistsMetadata checks if metadata exists for a session
+func (s *StateMetadataStore) ExistsMetadata(sessionName string) bool {
+	exists := false
+
+	_ = s.store.View(func(tx *state.Tx) error { //nolint:errcheck // unreadable store means no metadata
+		exists = tx.GetRaw(state.BucketSessions, sessionName) != nil
+		return nil
+	})
+
+	return exists
+}
+
+// UpdateStatus updates only the status of a session in one transaction
+func (s *StateMetadataStore) UpdateStatus(sessionName string, status Status) error {
+	var decodeErr error
+
+	err := s.store.Update(func(tx *state.Tx) error {
+		data := tx.GetRaw(state.BucketSessions, sessionName)
+		if data == nil {
+			return fmt.Errorf("%w for session: %s", ErrMetadataNotFound, sessionName)
+		}
+
+		metadata, _, err := decodeMetadata(data)
+		if err != nil {
+			decodeErr = err
+			return err
+		}
+
+		metadata.Status = status
+		metadata.LastAccessedAt = time.Now()
+
+		updated, err := json.Marshal(metadata)
+		if err != nil {
+			return fmt.Errorf("failed to marshal metadata: %w", err)
+		}
+
+		return tx.PutRaw(state.BucketSessions, sessionName, updated)
+	})
+
+	if decodeErr != nil && !errors.Is(decodeErr, ErrMetadataTooNew) {
+		return s.setAsideCorrupt(sessionName, decodeErr)
+	}
+
+	return err
+}
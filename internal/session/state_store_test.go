@@ -0,0 +1,70 @@
+package session
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/kaeawc/auto-worktree/internal/state"
+)
+
+func TestStateMetadataStore_RoundTrip(t *testing.T) {
+	store := NewStateMetadataStore(state.NewStore(filepath.Join(t.TempDir(), "state.db")))
+
+	if store.ExistsMetadata("auto-worktree-feature") {
+		t.Fatal("expected no metadata before save")
+	}
+
+	metadata := &Metadata{
+		SessionName:  "auto-worktree-feature",
+		WorktreePath: "/path/to/worktree",
+		BranchName:   "feature",
+		Status:       StatusRunning,
+	}
+
+	if err := store.SaveMetadata(metadata); err != nil {
+		t.Fatalf("SaveMetadata() error = %v", err)
+	}
+
+	if err := store.SaveMetadata(&Metadata{SessionName: "auto-worktree-other", Status: StatusIdle}); err != nil {
+		t.Fatalf("SaveMetadata() error = %v", err)
+	}
+
+	loaded, err := store.LoadMetadata("auto-worktree-feature")
+	if err != nil {
+		t.Fatalf("LoadMetadata() error = %v", err)
+	}
+
+	if loaded.BranchName != "feature" || loaded.LastAccessedAt.IsZero() {
+		t.Errorf("LoadMetadata() = %+v, want branch feature with LastAccessedAt set", loaded)
+	}
+
+	if err := store.UpdateStatus("auto-worktree-feature", StatusPaused); err != nil {
+		t.Fatalf("UpdateStatus() error = %v", err)
+	}
+
+	if loaded, _ := store.LoadMetadata("auto-worktree-feature"); loaded.Status != StatusPaused {
+		t.Errorf("status = %v, want paused", loaded.Status)
+	}
+
+	if err := store.UpdateStatus("missing", StatusPaused); err == nil {
+		t.Error("UpdateStatus() on missing session should fail")
+	}
+
+	all, err := store.LoadAllMetadata()
+	if err != nil || len(all) != 2 {
+		t.Fatalf("LoadAllMetadata() = %d entries (err %v), want 2", len(all), err)
+	}
+
+	if err := store.DeleteMetadata("auto-worktree-other"); err != nil {
+		t.Fatalf("DeleteMetadata() error = %v", err)
+	}
+
+	names, err := store.ListMetadata()
+	if err != nil || len(names) != 1 || names[0] != "auto-worktree-feature" {
+		t.Errorf("ListMetadata() = %v (err %v), want [auto-worktree-feature]", names, err)
+	}
+
+	if _, err := store.LoadMetadata("auto-worktree-other"); err == nil {
+		t.Error("LoadMetadata() after delete should fail")
+	}
+}
@@ -0,0 +1,185 @@
+package state
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// migration upgrades the database by one schema version.
+// dir is the directory holding the database, where legacy state files live.
+type migration struct {
+	description string
+	apply       func(tx *Tx, dir string) error
+}
+
+// migrations are applied in order; migration i brings the schema to version i+1.
+// Never reorder or edit a released migration, only append new ones.
+var migrations = []migration{
+	{description: "create state buckets", apply: createBuckets},
+	{description: "import legacy JSON state files", apply: importLegacyFiles},
+	{description: "create cache bucket", apply: createCacheBucket},
+	{description: "create repository registry bucket", apply: createReposBucket},
+	{description: "create event log bucket", apply: createEventsBucket},
+	{description: "create credentials bucket", apply: createCredentialsBucket},
+	{description: "create corrupt sessions bucket", apply: createCorruptSessionsBucket},
+}
+
+// SchemaVersion is the schema version this build reads and writes
+var SchemaVersion = len(migrations)
+
+// migrate applies any pending migrations in a single transaction
+func migrate(db *bolt.DB, dir string) error {
+	current := 0
+
+	if err := db.View(func(tx *bolt.Tx) error {
+		current = (&Tx{tx: tx}).schemaVersion()
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to read state schema version: %w", err)
+	}
+
+	if current > SchemaVersion {
+		return fmt.Errorf("state database schema v%d is newer than this auto-worktree supports (v%d); please upgrade",
+			current, SchemaVersion)
+	}
+
+	if current == SchemaVersion {
+		return nil
+	}
+
+	err := db.Update(func(btx *bolt.Tx) error {
+		tx := &Tx{tx: btx}
+
+		for version := current; version < SchemaVersion; version++ {
+			if err := migrations[version].apply(tx, dir); err != nil {
+				return fmt.Errorf("state migration %d (%s) failed: %w", version+1, migrations[version].description, err)
+			}
+		}
+
+		data, err := json.Marshal(SchemaVersion)
+		if err != nil {
+			return err
+		}
+
+		return tx.PutRaw(bucketMeta, schemaVersionKey, data)
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// createBuckets creates the buckets every build expects to exist
+func createBuckets(tx *Tx, _ string) error {
+	for _, name := range []string{bucketMeta, BucketSessions, BucketHistory, BucketAnalytics, BucketNotes} {
+		if _, err := tx.tx.CreateBucketIfNotExists([]byte(name)); err != nil {
+			return fmt.Errorf("failed to create bucket %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// importLegacyFiles copies the JSON files used before the state database existed
+// (sessions/*.json, history.json, analytics.json). Unreadable or corrupt files are
+// skipped, and the originals are left in place so older versions keep working.
+func importLegacyFiles(tx *Tx, dir string) error {
+	if err := importLegacySessions(tx, filepath.Join(dir, "sessions")); err != nil {
+		return err
+	}
+
+	if data, err := os.ReadFile(filepath.Join(dir, "history.json")); err == nil && json.Valid(data) { //nolint:gosec // fixed file name
+		if err := tx.PutRaw(BucketHistory, "entries", data); err != nil {
+			return err
+		}
+	}
+
+	if data, err := os.ReadFile(filepath.Join(dir, "analytics.json")); err == nil { //nolint:gosec // fixed file name
+		var usage map[string]json.RawMessage
+		if json.Unmarshal(data, &usage) == nil {
+			for feature, value := range usage {
+				if err := tx.PutRaw(BucketAnalytics, feature, value); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// importLegacySessions copies each sessions/<name>.json file into the sessions bucket
+func importLegacySessions(tx *Tx, sessionsDir string) error {
+	entries, err := os.ReadDir(sessionsDir)
+	if err != nil {
+		return nil //nolint:nilerr // no legacy sessions to import
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(sessionsDir, entry.Name())) //nolint:gosec // listed from sessionsDir
+		if err != nil || !json.Valid(data) {
+			continue
+		}
+
+		if err := tx.PutRaw(BucketSessions, strings.TrimSuffix(entry.Name(), ".json"), data); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// createCacheBucket adds the bucket for recomputable results
+func createCacheBucket(tx *Tx, _ string) error {
+	if _, err := tx.tx.CreateBucketIfNotExists([]byte(BucketCache)); err != nil {
+		return fmt.Errorf("failed to create bucket %s: %w", BucketCache, err)
+	}
+
+	return nil
+}
+
+// createReposBucket adds the bucket for the registry of known repositories
+func createReposBucket(tx *Tx, _ string) error {
+	if _, err := tx.tx.CreateBucketIfNotExists([]byte(BucketRepos)); err != nil {
+		return fmt.Errorf("failed to create bucket %s: %w", BucketRepos, err)
+	}
+
+	return nil
+}
+
+// createEventsBucket adds the bucket for the event log
+func createEventsBucket(tx *Tx, _ string) error {
+	if _, err := tx.tx.CreateBucketIfNotExists([]byte(BucketEvents)); err != nil {
+		return fmt.Errorf("failed to create bucket %s: %w", BucketEvents, err)
+	}
+
+	return nil
+}
+
+// createCredentialsBucket adds the bucket for stored credentials
+func createCredentialsBucket(tx *Tx, _ string) error {
+	if _, err := tx.tx.CreateBucketIfNotExists([]byte(BucketCredentials)); err != nil {
+		return fmt.Errorf("failed to create bucket %s: %w", BucketCredentials, err)
+	}
+
+	return nil
+}
+
+// createCorruptSessionsBucket adds the bucket unreadable session metadata is set aside in
+func createCorruptSessionsBucket(tx *Tx, _ string) error {
+	if _, err := tx.tx.CreateBucketIfNotExists([]byte(BucketCorruptSessions)); err != nil {
+		return fmt.Errorf("failed to create bucket %s: %w", BucketCorruptSessions, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,328 @@
+// Package state is the embedded key/value store that holds auto-worktree's
+// per-user state (session metadata, command history, analytics, notes) in a
+// single versioned database instead of scattered JSON files.
+package state
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// Buckets holding each kind of state
+const (
+	BucketSessions  = "sessions"
+	BucketHistory   = "history"
+	BucketAnalytics = "analytics"
+	BucketNotes     = "notes"
+	// BucketCache holds results that can be recomputed, such as the last update check
+	BucketCache = "cache"
+	// BucketRepos is the registry of repositories auto-worktree has been used in
+	BucketRepos = "repos"
+	// BucketEvents is the append-only log of worktree and session operations
+	BucketEvents = "events"
+	// BucketCredentials records which tokens 'auto-worktree auth' stored, and
+	// holds the encrypted ones when there is no OS keychain
+	BucketCredentials = "credentials"
+	// BucketCorruptSessions keeps session metadata that could not be read,
+	// under its session name, out of the way of the sessions list
+	BucketCorruptSessions = "sessions-corrupt"
+
+	// bucketMeta holds the schema version and is not exposed to callers
+	bucketMeta = "meta"
+)
+
+// schemaVersionKey is the key in bucketMeta holding the applied schema version
+const schemaVersionKey = "schema_version"
+
+// openTimeout bounds how long we wait for another auto-worktree process to release the database
+const openTimeout = 5 * time.Second
+
+// ErrNotFound is returned by Get when a key does not exist
+var ErrNotFound = errors.New("not found")
+
+// Store is the state database. The file is opened only for the duration of
+// each operation so that concurrent auto-worktree processes don't block each other.
+type Store struct {
+	path string
+}
+
+// BucketInfo summarizes one bucket for inspection
+type BucketInfo struct {
+	Name string
+	Keys int
+}
+
+// NewStore creates a store backed by the database file at path
+func NewStore(path string) *Store {
+	return &Store{path: path}
+}
+
+// DefaultPath returns the default database location (~/.auto-worktree/state.db)
+func DefaultPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+
+	return filepath.Join(home, ".auto-worktree", "state.db"), nil
+}
+
+// Path returns the database file location
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Put stores value as JSON under key in bucket
+func (s *Store) Put(bucket, key string, value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s/%s: %w", bucket, key, err)
+	}
+
+	return s.Update(func(tx *Tx) error {
+		return tx.PutRaw(bucket, key, data)
+	})
+}
+
+// Get decodes the JSON value stored under key in bucket into out.
+// It returns ErrNotFound if the key does not exist.
+func (s *Store) Get(bucket, key string, out interface{}) error {
+	return s.View(func(tx *Tx) error {
+		data := tx.GetRaw(bucket, key)
+		if data == nil {
+			return fmt.Errorf("%s/%s: %w", bucket, key, ErrNotFound)
+		}
+
+		if err := json.Unmarshal(data, out); err != nil {
+			return fmt.Errorf("failed to parse %s/%s: %w", bucket, key, err)
+		}
+
+		return nil
+	})
+}
+
+// Delete removes key from bucket; deleting a missing key is not an error
+func (s *Store) Delete(bucket, key string) error {
+	return s.Update(func(tx *Tx) error {
+		return tx.Delete(bucket, key)
+	})
+}
+
+// Keys returns the keys in bucket in sorted order
+func (s *Store) Keys(bucket string) ([]string, error) {
+	var keys []string
+
+	err := s.View(func(tx *Tx) error {
+		return tx.ForEach(bucket, func(key string, _ []byte) error {
+			keys = append(keys, key)
+			return nil
+		})
+	})
+
+	return keys, err
+}
+
+// ForEach calls fn with every key and raw JSON value in bucket, in key order
+func (s *Store) ForEach(bucket string, fn func(key string, value []byte) error) error {
+	return s.View(func(tx *Tx) error {
+		return tx.ForEach(bucket, fn)
+	})
+}
+
+// Clear deletes every key in bucket
+func (s *Store) Clear(bucket string) error {
+	return s.Update(func(tx *Tx) error {
+		b := tx.tx.Bucket([]byte(bucket))
+		if b == nil {
+			return nil
+		}
+
+		if err := tx.tx.DeleteBucket([]byte(bucket)); err != nil {
+			return fmt.Errorf("failed to clear %s: %w", bucket, err)
+		}
+
+		_, err := tx.tx.CreateBucket([]byte(bucket))
+
+		return err
+	})
+}
+
+// Info returns the schema version and the key count of every bucket
+func (s *Store) Info() (int, []BucketInfo, error) {
+	version := 0
+
+	var buckets []BucketInfo
+
+	err := s.View(func(tx *Tx) error {
+		version = tx.schemaVersion()
+
+		return tx.tx.ForEach(func(name []byte, b *bolt.Bucket) error {
+			if string(name) == bucketMeta {
+				return nil
+			}
+
+			buckets = append(buckets, BucketInfo{Name: string(name), Keys: b.Stats().KeyN})
+
+			return nil
+		})
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+
+	sort.Slice(buckets, func(i, j int) bool { return buckets[i].Name < buckets[j].Name })
+
+	return version, buckets, nil
+}
+
+// View runs fn in a read-only transaction
+func (s *Store) View(fn func(tx *Tx) error) error {
+	db, err := s.open()
+	if err != nil {
+		return err
+	}
+	defer db.Close() //nolint:errcheck // read-only
+
+	return db.View(func(tx *bolt.Tx) error {
+		return fn(&Tx{tx: tx})
+	})
+}
+
+// Update runs fn in a read-write transaction; all changes are committed together or not at all
+func (s *Store) Update(fn func(tx *Tx) error) error {
+	db, err := s.open()
+	if err != nil {
+		return err
+	}
+
+	if err := db.Update(func(tx *bolt.Tx) error {
+		return fn(&Tx{tx: tx})
+	}); err != nil {
+		_ = db.Close() //nolint:errcheck // the update error is more useful
+
+		return err
+	}
+
+	if err := db.Close(); err != nil {
+		return fmt.Errorf("failed to close state database: %w", err)
+	}
+
+	return nil
+}
+
+// open opens the database, creating it and applying pending migrations as needed
+func (s *Store) open() (*bolt.DB, error) {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create state directory: %w", err)
+	}
+
+	db, err := bolt.Open(s.path, 0o600, &bolt.Options{Timeout: openTimeout})
+	if err != nil {
+		if errors.Is(err, bolt.ErrTimeout) {
+			return nil, fmt.Errorf("state database %s is locked by another auto-worktree process", s.path)
+		}
+
+		return nil, fmt.Errorf("failed to open state database: %w", err)
+	}
+
+	if err := migrate(db, filepath.Dir(s.path)); err != nil {
+		_ = db.Close() //nolint:errcheck // the migration error is more useful
+
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// Tx is a transaction over the state buckets
+type Tx struct {
+	tx *bolt.Tx
+}
+
+// GetRaw returns the raw value stored under key in bucket, or nil if missing.
+// The slice is only valid for the life of the transaction.
+func (t *Tx) GetRaw(bucket, key string) []byte {
+	b := t.tx.Bucket([]byte(bucket))
+	if b == nil {
+		return nil
+	}
+
+	return b.Get([]byte(key))
+}
+
+// PutRaw stores a raw value under key in bucket, creating the bucket if needed
+func (t *Tx) PutRaw(bucket, key string, value []byte) error {
+	b, err := t.tx.CreateBucketIfNotExists([]byte(bucket))
+	if err != nil {
+		return fmt.Errorf("failed to create bucket %s: %w", bucket, err)
+	}
+
+	if err := b.Put([]byte(key), value); err != nil {
+		return fmt.Errorf("failed to write %s/%s: %w", bucket, key, err)
+	}
+
+	return nil
+}
+
+// NextSequence returns the next value of bucket's autoincrementing counter, creating the bucket if needed
+func (t *Tx) NextSequence(bucket string) (uint64, error) {
+	b, err := t.tx.CreateBucketIfNotExists([]byte(bucket))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create bucket %s: %w", bucket, err)
+	}
+
+	seq, err := b.NextSequence()
+	if err != nil {
+		return 0, fmt.Errorf("failed to advance %s sequence: %w", bucket, err)
+	}
+
+	return seq, nil
+}
+
+// Delete removes key from bucket
+func (t *Tx) Delete(bucket, key string) error {
+	b := t.tx.Bucket([]byte(bucket))
+	if b == nil {
+		return nil
+	}
+
+	if err := b.Delete([]byte(key)); err != nil {
+		return fmt.Errorf("failed to delete %s/%s: %w", bucket, key, err)
+	}
+
+	return nil
+}
+
+// ForEach calls fn with every key and raw value in bucket, in key order
+func (t *Tx) ForEach(bucket string, fn func(key string, value []byte) error) error {
+	b := t.tx.Bucket([]byte(bucket))
+	if b == nil {
+		return nil
+	}
+
+	return b.ForEach(func(k, v []byte) error {
+		return fn(string(k), v)
+	})
+}
+
+// schemaVersion returns the applied schema version, or 0 for a new database
+func (t *Tx) schemaVersion() int {
+	data := t.GetRaw(bucketMeta, schemaVersionKey)
+	if data == nil {
+		return 0
+	}
+
+	var version int
+	if err := json.Unmarshal(data, &version); err != nil {
+		return 0
+	}
+
+	return version
+}
@@ -0,0 +1,148 @@
+package state
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestStorePutGetDelete(t *testing.T) {
+	store := NewStore(filepath.Join(t.TempDir(), "state.db"))
+
+	type note struct {
+		Text string `json:"text"`
+	}
+
+	if err := store.Put(BucketNotes, "b", note{Text: "second"}); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	if err := store.Put(BucketNotes, "a", note{Text: "first"}); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	var got note
+	if err := store.Get(BucketNotes, "a", &got); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	if got.Text != "first" {
+		t.Errorf("Get() = %q, want %q", got.Text, "first")
+	}
+
+	keys, err := store.Keys(BucketNotes)
+	if err != nil {
+		t.Fatalf("Keys() error = %v", err)
+	}
+
+	if len(keys) != 2 || keys[0] != "a" || keys[1] != "b" {
+		t.Errorf("Keys() = %v, want [a b]", keys)
+	}
+
+	if err := store.Delete(BucketNotes, "a"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	if err := store.Get(BucketNotes, "a", &got); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get() after delete error = %v, want ErrNotFound", err)
+	}
+
+	if err := store.Clear(BucketNotes); err != nil {
+		t.Fatalf("Clear() error = %v", err)
+	}
+
+	if keys, _ := store.Keys(BucketNotes); len(keys) != 0 {
+		t.Errorf("Keys() after clear = %v, want none", keys)
+	}
+}
+
+func TestStoreInfo(t *testing.T) {
+	store := NewStore(filepath.Join(t.TempDir(), "nested", "state.db"))
+
+	if err := store.Put(BucketSessions, "auto-worktree-x", map[string]string{"branchName": "x"}); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	version, buckets, err := store.Info()
+	if err != nil {
+		t.Fatalf("Info() error = %v", err)
+	}
+
+	if version != SchemaVersion {
+		t.Errorf("version = %d, want %d", version, SchemaVersion)
+	}
+
+	counts := make(map[string]int)
+	for _, b := range buckets {
+		counts[b.Name] = b.Keys
+	}
+
+	if _, ok := counts[bucketMeta]; ok {
+		t.Error("Info() should not list the meta bucket")
+	}
+
+	for _, name := range []string{BucketSessions, BucketHistory, BucketAnalytics, BucketNotes, BucketCache, BucketRepos, BucketEvents, BucketCredentials, BucketCorruptSessions} {
+		if _, ok := counts[name]; !ok {
+			t.Errorf("bucket %s missing from Info()", name)
+		}
+	}
+
+	if counts[BucketSessions] != 1 {
+		t.Errorf("sessions keys = %d, want 1", counts[BucketSessions])
+	}
+}
+
+func TestStoreImportsLegacyFiles(t *testing.T) {
+	dir := t.TempDir()
+	sessionsDir := filepath.Join(dir, "sessions")
+
+	if err := os.MkdirAll(sessionsDir, 0o700); err != nil {
+		t.Fatal(err)
+	}
+
+	files := map[string]string{
+		filepath.Join(sessionsDir, "auto-worktree-feature.json"): `{"sessionName":"auto-worktree-feature"}`,
+		filepath.Join(sessionsDir, "corrupt.json"):               `{not json`,
+		filepath.Join(dir, "history.json"):                       `[{"args":["issue","42"]}]`,
+		filepath.Join(dir, "analytics.json"):                     `{"issue":{"feature":"issue","count":3}}`,
+	}
+
+	for path, content := range files {
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	store := NewStore(filepath.Join(dir, "state.db"))
+
+	sessions, err := store.Keys(BucketSessions)
+	if err != nil {
+		t.Fatalf("Keys() error = %v", err)
+	}
+
+	if len(sessions) != 1 || sessions[0] != "auto-worktree-feature" {
+		t.Errorf("imported sessions = %v, want [auto-worktree-feature]", sessions)
+	}
+
+	var history []map[string]interface{}
+	if err := store.Get(BucketHistory, "entries", &history); err != nil || len(history) != 1 {
+		t.Errorf("imported history = %v (err %v), want 1 entry", history, err)
+	}
+
+	var usage struct {
+		Count int `json:"count"`
+	}
+	if err := store.Get(BucketAnalytics, "issue", &usage); err != nil || usage.Count != 3 {
+		t.Errorf("imported analytics count = %d (err %v), want 3", usage.Count, err)
+	}
+
+	// Legacy files are only imported once
+	if err := os.WriteFile(filepath.Join(sessionsDir, "auto-worktree-late.json"), []byte(`{}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if sessions, _ := store.Keys(BucketSessions); len(sessions) != 1 {
+		t.Errorf("sessions after reopen = %v, want legacy import not to run again", sessions)
+	}
+}
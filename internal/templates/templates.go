@@ -0,0 +1,224 @@
+// Package templates discovers and parses issue templates from a repository.
+package templates
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Template is a markdown issue template with optional YAML-style front matter
+type Template struct {
+	// Name is the display name (front matter "name", or the file name)
+	Name string
+	// About is a short description of when to use the template
+	About string
+	// Title is the default issue title (e.g. "[BUG] ")
+	Title string
+	// Labels are applied to issues created from the template
+	Labels []string
+	// Assignees are assigned to issues created from the template
+	Assignees []string
+	// Body is the template text the user fills in
+	Body string
+	// Path is the file the template was loaded from
+	Path string
+}
+
+// DefaultDirs returns the conventional template directories for a provider, relative to the repo root
+func DefaultDirs(providerType string) []string {
+	switch providerType {
+	case "gitlab":
+		return []string{filepath.Join(".gitlab", "issue_templates")}
+	case "github":
+		return []string{filepath.Join(".github", "ISSUE_TEMPLATE")}
+	default:
+		return []string{
+			filepath.Join(".github", "ISSUE_TEMPLATE"),
+			filepath.Join(".gitlab", "issue_templates"),
+		}
+	}
+}
+
+// Discover loads the markdown templates for a repository.
+// customDir (absolute or relative to rootPath) replaces the provider's default directories when set.
+func Discover(rootPath, customDir, providerType string) ([]Template, error) {
+	dirs := DefaultDirs(providerType)
+	if customDir != "" {
+		dirs = []string{customDir}
+	}
+
+	var result []Template
+
+	for _, dir := range dirs {
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(rootPath, dir)
+		}
+
+		found, err := loadDir(dir)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, found...)
+	}
+
+	return result, nil
+}
+
+// loadDir parses every .md file in dir, sorted by file name
+func loadDir(dir string) ([]Template, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to read template directory %s: %w", dir, err)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name() < entries[j].Name()
+	})
+
+	var result []Template
+
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.EqualFold(filepath.Ext(entry.Name()), ".md") {
+			continue
+		}
+
+		path := filepath.Join(dir, entry.Name())
+
+		data, err := os.ReadFile(path) //nolint:gosec // G304: template files inside the repository
+		if err != nil {
+			return nil, fmt.Errorf("failed to read template %s: %w", path, err)
+		}
+
+		tmpl := Parse(strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name())), string(data))
+		tmpl.Path = path
+		result = append(result, tmpl)
+	}
+
+	return result, nil
+}
+
+// Parse parses a template's front matter and body.
+// fallbackName is used when the front matter has no name.
+func Parse(fallbackName, content string) Template {
+	tmpl := Template{Name: fallbackName}
+
+	frontMatter, body, ok := splitFrontMatter(content)
+	if !ok {
+		tmpl.Body = strings.TrimSpace(content)
+		return tmpl
+	}
+
+	tmpl.Body = strings.TrimSpace(body)
+
+	fields := parseFrontMatter(frontMatter)
+	if name := firstValue(fields["name"]); name != "" {
+		tmpl.Name = name
+	}
+
+	tmpl.About = firstValue(fields["about"])
+	tmpl.Title = firstValue(fields["title"])
+	tmpl.Labels = fields["labels"]
+	tmpl.Assignees = fields["assignees"]
+
+	return tmpl
+}
+
+// splitFrontMatter separates a leading "---" delimited block from the rest of the content
+func splitFrontMatter(content string) (string, string, bool) {
+	content = strings.TrimPrefix(content, "\ufeff")
+
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) != "---" {
+		return "", content, false
+	}
+
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) == "---" {
+			return strings.Join(lines[1:i], "\n"), strings.Join(lines[i+1:], "\n"), true
+		}
+	}
+
+	return "", content, false
+}
+
+// parseFrontMatter parses the simple YAML subset used by issue templates:
+// scalars, inline lists ([a, b] or "a, b") and block lists ("- a").
+func parseFrontMatter(frontMatter string) map[string][]string {
+	fields := make(map[string][]string)
+
+	var currentKey string
+
+	for _, line := range strings.Split(frontMatter, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		if strings.HasPrefix(trimmed, "- ") && currentKey != "" {
+			if item := unquote(strings.TrimSpace(trimmed[2:])); item != "" {
+				fields[currentKey] = append(fields[currentKey], item)
+			}
+
+			continue
+		}
+
+		key, value, found := strings.Cut(trimmed, ":")
+		if !found {
+			continue
+		}
+
+		currentKey = strings.ToLower(strings.TrimSpace(key))
+		fields[currentKey] = splitList(strings.TrimSpace(value), currentKey)
+	}
+
+	return fields
+}
+
+// splitList splits an inline list value; only list-valued keys are split on commas
+func splitList(value, key string) []string {
+	if value == "" {
+		return nil
+	}
+
+	if key != "labels" && key != "assignees" {
+		return []string{unquote(value)}
+	}
+
+	value = strings.TrimSuffix(strings.TrimPrefix(value, "["), "]")
+
+	var items []string
+
+	for _, item := range strings.Split(value, ",") {
+		if item = unquote(strings.TrimSpace(item)); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
+func unquote(value string) string {
+	if len(value) >= 2 {
+		if (value[0] == '"' && value[len(value)-1] == '"') || (value[0] == '\'' && value[len(value)-1] == '\'') {
+			return value[1 : len(value)-1]
+		}
+	}
+
+	return value
+}
+
+func firstValue(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+
+	return values[0]
+}
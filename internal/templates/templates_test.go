@@ -0,0 +1,93 @@
+package templates
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const bugTemplate = `---
+name: Bug report
+about: Create a report to help us improve
+title: "[BUG] "
+labels: bug, 'needs triage'
+assignees:
+  - octocat
+  - hubot
+---
+
+## Describe the bug
+`
+
+func TestParse(t *testing.T) {
+	tmpl := Parse("bug_report", bugTemplate)
+
+	if tmpl.Name != "Bug report" {
+		t.Errorf("Name = %q, want %q", tmpl.Name, "Bug report")
+	}
+
+	if tmpl.Title != "[BUG] " {
+		t.Errorf("Title = %q, want %q", tmpl.Title, "[BUG] ")
+	}
+
+	if want := []string{"bug", "needs triage"}; !reflect.DeepEqual(tmpl.Labels, want) {
+		t.Errorf("Labels = %v, want %v", tmpl.Labels, want)
+	}
+
+	if want := []string{"octocat", "hubot"}; !reflect.DeepEqual(tmpl.Assignees, want) {
+		t.Errorf("Assignees = %v, want %v", tmpl.Assignees, want)
+	}
+
+	if tmpl.Body != "## Describe the bug" {
+		t.Errorf("Body = %q", tmpl.Body)
+	}
+}
+
+func TestParseWithoutFrontMatter(t *testing.T) {
+	tmpl := Parse("feature", "## What?\n\n## Why?\n")
+
+	if tmpl.Name != "feature" || tmpl.Body != "## What?\n\n## Why?" {
+		t.Errorf("Parse() = %+v", tmpl)
+	}
+
+	if tmpl.Labels != nil {
+		t.Errorf("Labels = %v, want nil", tmpl.Labels)
+	}
+}
+
+func TestDiscover(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, ".github", "ISSUE_TEMPLATE")
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	files := map[string]string{
+		"bug_report.md": bugTemplate,
+		"feature.md":    "## Proposal\n",
+		"config.yml":    "blank_issues_enabled: false\n",
+	}
+
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	found, err := Discover(root, "", "github")
+	if err != nil {
+		t.Fatalf("Discover() error = %v", err)
+	}
+
+	if len(found) != 2 || found[0].Name != "Bug report" || found[1].Name != "feature" {
+		t.Errorf("Discover() = %+v, want bug report and feature templates", found)
+	}
+
+	// A custom directory replaces the defaults
+	found, err = Discover(root, "missing-dir", "github")
+	if err != nil || len(found) != 0 {
+		t.Errorf("Discover(custom missing dir) = %v, %v; want no templates", found, err)
+	}
+}
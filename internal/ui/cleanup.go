@@ -14,11 +14,12 @@ const (
 	keyEsc            = "esc"
 )
 
+// Cleanup prompt styles, built from the active theme in applyStyles
 var (
-	cleanupTitleStyle    = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("170"))
-	cleanupWarningStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
-	cleanupQuestionStyle = lipgloss.NewStyle().Bold(true)
-	cleanupHintStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	cleanupTitleStyle    lipgloss.Style
+	cleanupWarningStyle  lipgloss.Style
+	cleanupQuestionStyle lipgloss.Style
+	cleanupHintStyle     lipgloss.Style
 )
 
 // CleanupPromptModel represents a prompt for cleaning up a worktree
@@ -32,6 +33,35 @@ type CleanupPromptModel struct {
 	PromptState     string // "confirm" or "branch" or "done"
 	Canceled        bool
 	SkipAutoCleanup bool // If true, skip automatic cleanup and use interactive prompt
+	Preview         CleanupPreview
+}
+
+// CleanupPreview summarizes a cleanup candidate's work; empty fields are not shown
+type CleanupPreview struct {
+	LastCommit string
+	// DiffStat is the change against the default branch, e.g. "3 files changed, +40 -12 vs main"
+	DiffStat string
+	// PullRequest describes the branch's pull request, or is "none" when it has none
+	PullRequest string
+}
+
+// Lines renders the preview as labeled lines
+func (p CleanupPreview) Lines() []string {
+	var lines []string
+
+	if p.LastCommit != "" {
+		lines = append(lines, "Last commit: "+p.LastCommit)
+	}
+
+	if p.DiffStat != "" {
+		lines = append(lines, "Changes: "+p.DiffStat)
+	}
+
+	if p.PullRequest != "" {
+		lines = append(lines, "Pull request: "+p.PullRequest)
+	}
+
+	return lines
 }
 
 // NewCleanupPrompt creates a new cleanup prompt
@@ -128,6 +158,10 @@ func (m CleanupPromptModel) View() string {
 		s += fmt.Sprintf("Reason: %s\n", m.CleanupReason)
 	}
 
+	for _, line := range m.Preview.Lines() {
+		s += line + "\n"
+	}
+
 	// Show warning if there are unpushed commits
 	if m.UnpushedCount > 0 {
 		s += "\n" + cleanupWarningStyle.Render(fmt.Sprintf("⚠ Warning: %d unpushed commit(s)", m.UnpushedCount)) + "\n"
@@ -168,8 +202,10 @@ func (m CleanupPromptModel) WasCanceled() bool {
 type CleanupConfirmationModel struct {
 	MergedCount int
 	StaleCount  int
-	Confirmed   bool
-	Canceled    bool
+	// Summary is shown under the counts, e.g. what the cleanup frees
+	Summary   string
+	Confirmed bool
+	Canceled  bool
 }
 
 // NewCleanupConfirmation creates a new cleanup confirmation prompt
@@ -218,6 +254,10 @@ func (m CleanupConfirmationModel) View() string {
 		s += fmt.Sprintf("Found %d stale worktree(s) that will require interactive confirmation\n", m.StaleCount)
 	}
 
+	if m.Summary != "" {
+		s += m.Summary + "\n"
+	}
+
 	if m.MergedCount == 0 && m.StaleCount == 0 {
 		s += "No worktrees found that need cleanup.\n"
 		return s
@@ -80,12 +80,12 @@ func (m ConfirmModel) View() string {
 	yesStyle := lipgloss.NewStyle().
 		Padding(0, 2).
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(ColorGreen)
+		BorderForeground(successColor)
 
 	noStyle := lipgloss.NewStyle().
 		Padding(0, 2).
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(ColorRed)
+		BorderForeground(errorColor)
 
 	unselectedStyle := lipgloss.NewStyle().
 		Padding(0, 2)
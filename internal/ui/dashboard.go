@@ -0,0 +1,423 @@
+package ui
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/kaeawc/auto-worktree/internal/git"
+)
+
+// Dashboard actions that exit the dashboard so the caller can run them
+const (
+	DashboardActionAttach  = "attach"
+	DashboardActionCleanup = "cleanup"
+	DashboardActionOpenPR  = "open-pr"
+)
+
+// DashboardRow is one worktree in the dashboard
+type DashboardRow struct {
+	Path   string
+	Branch string
+	// IssueState is the rendered issue/PR status (e.g. "[merged #42]"), or "-"
+	IssueState string
+	// PRNumber is the open PR for the branch, or 0 if none
+	PRNumber int
+	// CIState is the PR's check state: none, pending, failing, or passing
+	CIState string
+	// SessionState is the rendered session status (e.g. "🟢 running"), or "-"
+	SessionState string
+	Dirty        bool
+	Unpushed     int
+	// Health is the latest on-demand health check result, if one was run
+	Health *git.HealthCheckResult
+	// Column is the kanban column for the board view (one of KanbanColumns)
+	Column string
+}
+
+// DashboardLoader loads the current dashboard rows
+type DashboardLoader func() ([]DashboardRow, error)
+
+// dashboardLoadedMsg carries freshly loaded rows
+type dashboardLoadedMsg struct {
+	rows []DashboardRow
+	err  error
+}
+
+// dashboardHealthMsg carries a health check result for one row
+type dashboardHealthMsg struct {
+	path   string
+	result *git.HealthCheckResult
+	err    error
+}
+
+// dashboardTickMsg triggers a periodic refresh
+type dashboardTickMsg time.Time
+
+// DashboardModel is a full-screen view of every worktree with actions on the selected row
+type DashboardModel struct {
+	repo     *git.Repository
+	load     DashboardLoader
+	interval time.Duration
+	rows     []DashboardRow
+	cursor   int
+	loading  bool
+	lastRun  time.Time
+	message  string
+	err      error
+	action   string
+	selected *DashboardRow
+	board    bool
+	width    int
+	height   int
+}
+
+// NewDashboard creates a dashboard that refreshes rows from load every interval
+func NewDashboard(repo *git.Repository, load DashboardLoader, interval time.Duration) *DashboardModel {
+	return &DashboardModel{
+		repo:     repo,
+		load:     load,
+		interval: interval,
+		loading:  true,
+	}
+}
+
+// Action returns the action chosen when the dashboard exited, or "" if the user quit
+func (m *DashboardModel) Action() string {
+	return m.action
+}
+
+// Selected returns the row the action applies to
+func (m *DashboardModel) Selected() *DashboardRow {
+	return m.selected
+}
+
+// Init starts the first load and the refresh timer
+func (m *DashboardModel) Init() tea.Cmd {
+	return tea.Batch(m.refresh(), m.tick())
+}
+
+// Update handles messages
+func (m *DashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+
+		return m, nil
+
+	case dashboardLoadedMsg:
+		m.loading = false
+		m.lastRun = time.Now()
+		m.err = msg.err
+
+		if msg.err == nil {
+			m.setRows(msg.rows)
+		}
+
+		return m, nil
+
+	case dashboardHealthMsg:
+		for i := range m.rows {
+			if m.rows[i].Path == msg.path {
+				m.rows[i].Health = msg.result
+			}
+		}
+
+		if msg.err != nil {
+			m.message = fmt.Sprintf("Health check failed: %v", msg.err)
+		} else {
+			m.message = ""
+		}
+
+		return m, nil
+
+	case dashboardTickMsg:
+		return m, tea.Batch(m.refresh(), m.tick())
+	}
+
+	return m, nil
+}
+
+func (m *DashboardModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q", "esc", "ctrl+c":
+		return m, tea.Quit
+	case "up", "k":
+		if m.board {
+			m.cursor = moveOnBoard(m.rows, m.cursor, -1, 0)
+		} else if m.cursor > 0 {
+			m.cursor--
+		}
+	case "down", "j":
+		if m.board {
+			m.cursor = moveOnBoard(m.rows, m.cursor, 1, 0)
+		} else if m.cursor < len(m.rows)-1 {
+			m.cursor++
+		}
+	case "left":
+		if m.board {
+			m.cursor = moveOnBoard(m.rows, m.cursor, 0, -1)
+		}
+	case "right":
+		if m.board {
+			m.cursor = moveOnBoard(m.rows, m.cursor, 0, 1)
+		}
+	case "g":
+		m.board = !m.board
+	case "r":
+		return m, m.refresh()
+	case "h":
+		if row := m.current(); row != nil {
+			m.message = "Checking " + filepath.Base(row.Path) + "..."
+			return m, m.checkHealth(row.Path)
+		}
+	case "enter", "a":
+		return m.exitWith(DashboardActionAttach)
+	case "c":
+		return m.exitWith(DashboardActionCleanup)
+	case "o":
+		if row := m.current(); row != nil && row.PRNumber == 0 {
+			m.message = "No open PR for " + row.Branch
+			return m, nil
+		}
+
+		return m.exitWith(DashboardActionOpenPR)
+	}
+
+	return m, nil
+}
+
+// exitWith quits the dashboard with an action on the selected row
+func (m *DashboardModel) exitWith(action string) (tea.Model, tea.Cmd) {
+	row := m.current()
+	if row == nil {
+		return m, nil
+	}
+
+	m.action = action
+	m.selected = row
+
+	return m, tea.Quit
+}
+
+// current returns the row under the cursor
+func (m *DashboardModel) current() *DashboardRow {
+	if m.cursor < 0 || m.cursor >= len(m.rows) {
+		return nil
+	}
+
+	return &m.rows[m.cursor]
+}
+
+// setRows replaces the rows, keeping the cursor and health results on the same worktrees
+func (m *DashboardModel) setRows(rows []DashboardRow) {
+	selectedPath := ""
+	if row := m.current(); row != nil {
+		selectedPath = row.Path
+	}
+
+	health := make(map[string]*git.HealthCheckResult)
+	for _, row := range m.rows {
+		if row.Health != nil {
+			health[row.Path] = row.Health
+		}
+	}
+
+	m.rows = rows
+	m.cursor = 0
+
+	for i := range m.rows {
+		if m.rows[i].Health == nil {
+			m.rows[i].Health = health[m.rows[i].Path]
+		}
+
+		if m.rows[i].Path == selectedPath {
+			m.cursor = i
+		}
+	}
+}
+
+// View renders the dashboard
+func (m *DashboardModel) View() string {
+	var b strings.Builder
+
+	b.WriteString(TitleStyle.Render("📋 Worktree Dashboard"))
+	b.WriteString("\n\n")
+
+	switch {
+	case m.loading && m.lastRun.IsZero():
+		b.WriteString(SubtleStyle.Render("🔄 Loading worktrees..."))
+	default:
+		b.WriteString(SubtleStyle.Render(fmt.Sprintf("⏰ Updated %s | Refresh every %s",
+			m.lastRun.Format("15:04:05"), formatMonitorDuration(m.interval))))
+	}
+
+	b.WriteString("\n\n")
+
+	if m.err != nil {
+		b.WriteString(ErrorStyle.Render(fmt.Sprintf("❌ Error: %v", m.err)))
+		b.WriteString("\n\n")
+	}
+
+	switch {
+	case len(m.rows) == 0 && !m.loading:
+		b.WriteString("No worktrees found\n")
+	case m.board:
+		b.WriteString(m.renderBoard())
+	default:
+		b.WriteString(m.renderTable())
+	}
+
+	if m.message != "" {
+		b.WriteString("\n")
+		b.WriteString(WarningStyle.Render(m.message))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	help := "↑/↓ select • enter/a attach • c cleanup • o open PR • h health check • g board • r refresh • q quit"
+	if m.board {
+		help = "←/→/↑/↓ select • enter/a attach • c cleanup • o open PR • h health check • g table • r refresh • q quit"
+	}
+
+	b.WriteString(lipgloss.NewStyle().Foreground(subtleColor).Italic(true).Render(help))
+
+	return b.String()
+}
+
+// renderTable renders the worktree rows with the selected row highlighted
+func (m *DashboardModel) renderTable() string {
+	var b strings.Builder
+
+	header := fmt.Sprintf("  %s %s %s %s %s %s %s",
+		pad("WORKTREE", 28), pad("BRANCH", 28), pad("ISSUE/PR", 18), pad("CI", 10),
+		pad("SESSION", 14), pad("CHANGES", 16), "HEALTH")
+	b.WriteString(BoldStyle.Render(header))
+	b.WriteString("\n")
+
+	for i, row := range m.rows {
+		cursor := "  "
+		if i == m.cursor {
+			cursor = selectedItemStyle.UnsetPaddingLeft().Render("► ")
+		}
+
+		line := fmt.Sprintf("%s %s %s %s %s %s",
+			pad(truncate(filepath.Base(row.Path), 28), 28),
+			pad(truncate(row.Branch, 28), 28),
+			pad(row.IssueState, 18),
+			pad(renderCIState(row.CIState), 10),
+			pad(row.SessionState, 14),
+			pad(renderChanges(row), 16))
+
+		b.WriteString(cursor)
+		b.WriteString(line)
+		b.WriteString(" ")
+		b.WriteString(renderHealth(row.Health))
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+// renderCIState renders a PR check state
+func renderCIState(state string) string {
+	switch state {
+	case "passing":
+		return SuccessStyle.Render("✓ passing")
+	case "failing":
+		return ErrorStyle.Render("✗ failing")
+	case "pending":
+		return WarningStyle.Render("● pending")
+	default:
+		return SubtleStyle.Render("-")
+	}
+}
+
+// renderChanges renders the dirty and unpushed indicators for a row
+func renderChanges(row DashboardRow) string {
+	var parts []string
+
+	if row.Dirty {
+		parts = append(parts, WarningStyle.Render("dirty"))
+	}
+
+	if row.Unpushed > 0 {
+		parts = append(parts, WarningStyle.Render(fmt.Sprintf("↑%d", row.Unpushed)))
+	}
+
+	if len(parts) == 0 {
+		return SubtleStyle.Render("clean")
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// renderHealth renders the latest health check result, if any
+func renderHealth(result *git.HealthCheckResult) string {
+	if result == nil {
+		return SubtleStyle.Render("-")
+	}
+
+	switch result.GetMaxSeverity() {
+	case git.SeverityOK:
+		return iconCheckmark
+	case git.SeverityWarning:
+		return fmt.Sprintf("%s %d", iconWarning, len(result.Issues))
+	case git.SeverityError:
+		return fmt.Sprintf("%s %d", iconError, len(result.Issues))
+	default:
+		return fmt.Sprintf("%s %d", iconCritical, len(result.Issues))
+	}
+}
+
+// pad right-pads s to width visible columns, ignoring ANSI styling
+func pad(s string, width int) string {
+	if gap := width - lipgloss.Width(s); gap > 0 {
+		return s + strings.Repeat(" ", gap)
+	}
+
+	return s
+}
+
+// truncate shortens s to at most width runes, marking the cut with an ellipsis
+func truncate(s string, width int) string {
+	runes := []rune(s)
+	if len(runes) <= width {
+		return s
+	}
+
+	return string(runes[:width-1]) + "…"
+}
+
+// refresh reloads the rows in the background
+func (m *DashboardModel) refresh() tea.Cmd {
+	m.loading = true
+
+	return func() tea.Msg {
+		rows, err := m.load()
+		return dashboardLoadedMsg{rows: rows, err: err}
+	}
+}
+
+// checkHealth runs a health check on one worktree in the background
+func (m *DashboardModel) checkHealth(path string) tea.Cmd {
+	return func() tea.Msg {
+		result, err := m.repo.PerformHealthCheck(path)
+		return dashboardHealthMsg{path: path, result: result, err: err}
+	}
+}
+
+// tick schedules the next refresh
+func (m *DashboardModel) tick() tea.Cmd {
+	return tea.Tick(m.interval, func(t time.Time) tea.Msg {
+		return dashboardTickMsg(t)
+	})
+}
@@ -0,0 +1,80 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func dashboardKey(s string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+func newTestDashboard(rows []DashboardRow) *DashboardModel {
+	m := NewDashboard(nil, func() ([]DashboardRow, error) { return rows, nil }, time.Minute)
+	m.Update(dashboardLoadedMsg{rows: rows})
+
+	return m
+}
+
+func TestDashboardActions(t *testing.T) {
+	rows := []DashboardRow{
+		{Path: "/wt/a", Branch: "work/1-a", IssueState: "-", SessionState: "-"},
+		{Path: "/wt/b", Branch: "work/2-b", IssueState: "-", SessionState: "-", PRNumber: 7, CIState: "failing"},
+	}
+
+	m := newTestDashboard(rows)
+
+	m.Update(dashboardKey("j"))
+
+	if _, cmd := m.Update(dashboardKey("o")); cmd == nil {
+		t.Fatal("expected quit command for open PR")
+	}
+
+	if m.Action() != DashboardActionOpenPR || m.Selected() == nil || m.Selected().Path != "/wt/b" {
+		t.Errorf("Action() = %q, Selected() = %+v", m.Action(), m.Selected())
+	}
+
+	m = newTestDashboard(rows)
+
+	// The first row has no PR, so "o" only shows a message
+	if _, cmd := m.Update(dashboardKey("o")); cmd != nil || m.Action() != "" {
+		t.Errorf("open PR without a PR should not exit, action = %q", m.Action())
+	}
+
+	if !strings.Contains(m.View(), "No open PR") {
+		t.Error("expected a message about the missing PR")
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	if m.Action() != DashboardActionAttach || m.Selected().Path != "/wt/a" {
+		t.Errorf("enter: Action() = %q, Selected() = %+v", m.Action(), m.Selected())
+	}
+}
+
+func TestDashboardRefreshKeepsSelection(t *testing.T) {
+	m := newTestDashboard([]DashboardRow{{Path: "/wt/a"}, {Path: "/wt/b"}})
+	m.Update(dashboardKey("j"))
+
+	m.Update(dashboardLoadedMsg{rows: []DashboardRow{{Path: "/wt/new"}, {Path: "/wt/a"}, {Path: "/wt/b"}}})
+
+	if row := m.current(); row == nil || row.Path != "/wt/b" {
+		t.Errorf("selection after refresh = %+v, want /wt/b", row)
+	}
+}
+
+func TestDashboardView(t *testing.T) {
+	m := newTestDashboard([]DashboardRow{
+		{Path: "/wt/a", Branch: "work/1-a", IssueState: "-", SessionState: "🟢 running", Dirty: true, Unpushed: 2, CIState: "passing"},
+	})
+
+	view := m.View()
+	for _, want := range []string{"work/1-a", "dirty", "↑2", "passing", "🟢 running"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("View() missing %q:\n%s", want, view)
+		}
+	}
+}
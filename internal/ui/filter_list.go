@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
@@ -15,6 +16,9 @@ const (
 	filterKeyEnter = "enter"
 )
 
+// loadMoreBinding requests the next page when the list supports paging
+var loadMoreBinding = key.NewBinding(key.WithKeys("m"), key.WithHelp("m", "load more"))
+
 // FilterableListItem represents an item in the filterable list
 type FilterableListItem struct {
 	id          string // String ID for Linear (e.g., "ENG-123") or stringified number for GitHub
@@ -86,12 +90,15 @@ func (i FilterableListItem) FilterValue() string {
 // FilterListModel represents a filterable list UI component
 type FilterListModel struct {
 	list        list.Model
+	keys        KeyMap
 	filterInput textinput.Model
 	title       string
 	items       []FilterableListItem
 	choice      *FilterableListItem
 	err         error
 	filtering   bool
+	canLoadMore bool
+	loadMore    bool
 }
 
 // NewFilterList creates a new filterable list
@@ -108,6 +115,7 @@ func NewFilterList(title string, items []FilterableListItem) FilterListModel {
 	l.SetShowStatusBar(false)
 	l.SetFilteringEnabled(true)
 	l.Styles.Title = HeaderStyle
+	activeKeys.applyToList(&l)
 
 	// Create filter input
 	ti := textinput.New()
@@ -117,6 +125,7 @@ func NewFilterList(title string, items []FilterableListItem) FilterListModel {
 
 	return FilterListModel{
 		list:        l,
+		keys:        activeKeys,
 		filterInput: ti,
 		title:       title,
 		items:       items,
@@ -124,6 +133,12 @@ func NewFilterList(title string, items []FilterableListItem) FilterListModel {
 	}
 }
 
+// WithLoadMore lets the user press "m" to request the next page of items
+func (m FilterListModel) WithLoadMore() FilterListModel {
+	m.canLoadMore = true
+	return m
+}
+
 // Init initializes the model
 func (m FilterListModel) Init() tea.Cmd {
 	return nil
@@ -138,40 +153,32 @@ func (m FilterListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case tea.KeyMsg:
-		switch msg.String() {
-		case filterKeyCtrlC, "q":
-			if m.filtering {
+		if m.filtering {
+			// While filtering, printable keys belong to the filter text
+			switch msg.String() {
+			case filterKeyCtrlC, filterKeyEsc:
 				// Exit filter mode
 				m.filtering = false
 				m.filterInput.SetValue("")
 				m.filterInput.Blur()
 				return m, nil
-			}
-			// Quit
-			m.err = fmt.Errorf("canceled")
-			return m, tea.Quit
 
-		case filterKeyEsc:
-			if m.filtering {
-				// Exit filter mode
+			case filterKeyEnter:
+				// Exit filter mode and keep the filter
 				m.filtering = false
-				m.filterInput.SetValue("")
 				m.filterInput.Blur()
 				return m, nil
 			}
-			// Quit
+
+			break
+		}
+
+		switch {
+		case m.keys.isCancel(msg):
 			m.err = fmt.Errorf("canceled")
 			return m, tea.Quit
 
-		case filterKeyEnter:
-			if m.filtering {
-				// Exit filter mode and keep the filter
-				m.filtering = false
-				m.filterInput.Blur()
-				return m, nil
-			}
-
-			// Select current item
+		case key.Matches(msg, m.keys.Select):
 			selectedItem := m.list.SelectedItem()
 			if selectedItem != nil {
 				if item, ok := selectedItem.(FilterableListItem); ok {
@@ -181,13 +188,15 @@ func (m FilterListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return m, nil
 
-		case "/":
-			if !m.filtering {
-				// Enter filter mode
-				m.filtering = true
-				m.filterInput.Focus()
-				return m, textinput.Blink
-			}
+		case m.canLoadMore && key.Matches(msg, loadMoreBinding):
+			m.loadMore = true
+			return m, tea.Quit
+
+		case key.Matches(msg, m.keys.Filter):
+			// Enter filter mode
+			m.filtering = true
+			m.filterInput.Focus()
+			return m, textinput.Blink
 		}
 	}
 
@@ -238,7 +247,12 @@ func (m FilterListModel) View() string {
 		s.WriteString("\n")
 		s.WriteString(SubtleStyle.Render("(press Enter to apply, Esc to cancel)"))
 	} else {
-		s.WriteString(SubtleStyle.Render("Press / to filter, Enter to select, q/Esc to quit"))
+		bindings := []key.Binding{m.keys.Filter, m.keys.Select, m.keys.Cancel}
+		if m.canLoadMore {
+			bindings = []key.Binding{m.keys.Filter, loadMoreBinding, m.keys.Select, m.keys.Cancel}
+		}
+
+		s.WriteString(SubtleStyle.Render(helpFooter(bindings...)))
 	}
 
 	return BoxStyle.Render(s.String())
@@ -249,6 +263,11 @@ func (m FilterListModel) Choice() *FilterableListItem {
 	return m.choice
 }
 
+// LoadMore returns true if the user asked for the next page of items
+func (m FilterListModel) LoadMore() bool {
+	return m.loadMore
+}
+
 // Err returns any error
 func (m FilterListModel) Err() error {
 	return m.err
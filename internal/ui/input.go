@@ -28,6 +28,20 @@ func NewInput(prompt, placeholder string) InputModel {
 	}
 }
 
+// WithValue pre-fills the input with an initial value.
+func (m InputModel) WithValue(value string) InputModel {
+	m.textInput.SetValue(value)
+	return m
+}
+
+// Masked hides what is typed, for tokens and passwords.
+func (m InputModel) Masked() InputModel {
+	m.textInput.EchoMode = textinput.EchoPassword
+	m.textInput.EchoCharacter = '•'
+
+	return m
+}
+
 // Init initializes the input model.
 func (m InputModel) Init() tea.Cmd {
 	return textinput.Blink
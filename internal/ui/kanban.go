@@ -0,0 +1,153 @@
+package ui
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Kanban columns for the dashboard board view, in display order
+const (
+	ColumnInProgress  = "In Progress"
+	ColumnNeedsReview = "Needs Review"
+	ColumnCleanup     = "Merged/Cleanup"
+	ColumnStale       = "Stale"
+)
+
+// KanbanColumns lists the board columns left to right
+var KanbanColumns = []string{ColumnInProgress, ColumnNeedsReview, ColumnCleanup, ColumnStale}
+
+// defaultKanbanColumnWidth is used before the terminal size is known
+const defaultKanbanColumnWidth = 30
+
+// kanbanColumnStyle frames each column of the board; built from the active theme in applyStyles
+var kanbanColumnStyle lipgloss.Style
+
+// columnIndex returns the board column of a row, treating unknown columns as In Progress
+func columnIndex(row DashboardRow) int {
+	for i, column := range KanbanColumns {
+		if row.Column == column {
+			return i
+		}
+	}
+
+	return 0
+}
+
+// groupByColumn returns the row indices in each board column, preserving row order
+func groupByColumn(rows []DashboardRow) [][]int {
+	groups := make([][]int, len(KanbanColumns))
+
+	for i, row := range rows {
+		col := columnIndex(row)
+		groups[col] = append(groups[col], i)
+	}
+
+	return groups
+}
+
+// moveOnBoard moves the cursor within the board: dRow steps through the current
+// column and dCol jumps to the nearest card in the next non-empty column.
+// It returns the new row index, or the current one if there is nowhere to go.
+func moveOnBoard(rows []DashboardRow, cursor, dRow, dCol int) int {
+	if cursor < 0 || cursor >= len(rows) {
+		return cursor
+	}
+
+	groups := groupByColumn(rows)
+	col := columnIndex(rows[cursor])
+
+	pos := 0
+
+	for i, idx := range groups[col] {
+		if idx == cursor {
+			pos = i
+		}
+	}
+
+	if dRow != 0 {
+		next := pos + dRow
+		if next < 0 || next >= len(groups[col]) {
+			return cursor
+		}
+
+		return groups[col][next]
+	}
+
+	for c := col + dCol; c >= 0 && c < len(groups); c += dCol {
+		if len(groups[c]) == 0 {
+			continue
+		}
+
+		if pos >= len(groups[c]) {
+			pos = len(groups[c]) - 1
+		}
+
+		return groups[c][pos]
+	}
+
+	return cursor
+}
+
+// renderBoard renders the rows as kanban columns with the selected card highlighted
+func (m *DashboardModel) renderBoard() string {
+	width := defaultKanbanColumnWidth
+	if m.width > 0 {
+		// Each column adds two border and two padding cells
+		width = max(m.width/len(KanbanColumns)-4, 16)
+	}
+
+	groups := groupByColumn(m.rows)
+	columns := make([]string, len(KanbanColumns))
+
+	for c, name := range KanbanColumns {
+		var b strings.Builder
+
+		b.WriteString(BoldStyle.Render(fmt.Sprintf("%s (%d)", name, len(groups[c]))))
+		b.WriteString("\n")
+
+		if len(groups[c]) == 0 {
+			b.WriteString(SubtleStyle.Render("—"))
+		}
+
+		for _, idx := range groups[c] {
+			b.WriteString("\n")
+			b.WriteString(renderCard(m.rows[idx], width, idx == m.cursor))
+		}
+
+		columns[c] = kanbanColumnStyle.Width(width).Render(b.String())
+	}
+
+	return lipgloss.JoinHorizontal(lipgloss.Top, columns...) + "\n"
+}
+
+// renderCard renders one worktree as a two-line card
+func renderCard(row DashboardRow, width int, selected bool) string {
+	title := truncate(filepath.Base(row.Path), width-2)
+	if selected {
+		title = selectedItemStyle.UnsetPaddingLeft().Render("► " + title)
+	} else {
+		title = "  " + title
+	}
+
+	details := []string{}
+	if row.PRNumber > 0 {
+		details = append(details, fmt.Sprintf("#%d %s", row.PRNumber, renderCIState(row.CIState)))
+	}
+
+	if row.SessionState != "" && row.SessionState != "-" {
+		details = append(details, row.SessionState)
+	}
+
+	if row.Dirty || row.Unpushed > 0 {
+		details = append(details, renderChanges(row))
+	}
+
+	if len(details) == 0 {
+		details = append(details, SubtleStyle.Render(truncate(row.Branch, width-2)))
+	}
+
+	return title + "\n  " + strings.Join(details, " ")
+}
@@ -0,0 +1,83 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestGroupByColumn(t *testing.T) {
+	rows := []DashboardRow{
+		{Path: "/wt/a", Column: ColumnNeedsReview},
+		{Path: "/wt/b"},
+		{Path: "/wt/c", Column: ColumnStale},
+		{Path: "/wt/d", Column: ColumnNeedsReview},
+	}
+
+	groups := groupByColumn(rows)
+
+	want := [][]int{{1}, {0, 3}, nil, {2}}
+	for c := range want {
+		if len(groups[c]) != len(want[c]) {
+			t.Fatalf("column %s = %v, want %v", KanbanColumns[c], groups[c], want[c])
+		}
+
+		for i := range want[c] {
+			if groups[c][i] != want[c][i] {
+				t.Errorf("column %s = %v, want %v", KanbanColumns[c], groups[c], want[c])
+			}
+		}
+	}
+}
+
+func TestMoveOnBoard(t *testing.T) {
+	rows := []DashboardRow{
+		{Path: "/wt/a", Column: ColumnInProgress},
+		{Path: "/wt/b", Column: ColumnInProgress},
+		{Path: "/wt/c", Column: ColumnStale},
+	}
+
+	tests := []struct {
+		name             string
+		cursor, dRow, dC int
+		want             int
+	}{
+		{"down within column", 0, 1, 0, 1},
+		{"down at bottom stays", 1, 1, 0, 1},
+		{"right skips empty columns and clamps position", 1, 0, 1, 2},
+		{"left from stale returns to in progress", 2, 0, -1, 0},
+		{"left at first column stays", 0, 0, -1, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := moveOnBoard(rows, tt.cursor, tt.dRow, tt.dC); got != tt.want {
+				t.Errorf("moveOnBoard() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDashboardBoardView(t *testing.T) {
+	m := newTestDashboard([]DashboardRow{
+		{Path: "/wt/a", Branch: "work/a", Column: ColumnInProgress},
+		{Path: "/wt/b", Branch: "work/b", Column: ColumnCleanup},
+	})
+
+	m.Update(dashboardKey("g"))
+
+	view := m.View()
+	for _, column := range KanbanColumns {
+		if !strings.Contains(view, column) {
+			t.Errorf("board view missing column %q", column)
+		}
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRight})
+	m.Update(dashboardKey("c"))
+
+	if m.Action() != DashboardActionCleanup || m.Selected().Path != "/wt/b" {
+		t.Errorf("cleanup from board: Action() = %q, Selected() = %+v", m.Action(), m.Selected())
+	}
+}
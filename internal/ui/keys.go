@@ -0,0 +1,153 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// KeyMap holds the remappable keys shared by the list, menu, and filter components.
+// ctrl+c always cancels regardless of the mapping.
+type KeyMap struct {
+	Up     key.Binding
+	Down   key.Binding
+	Select key.Binding
+	Cancel key.Binding
+	Filter key.Binding
+}
+
+// KeyActions lists the action names accepted in keybinding overrides
+var KeyActions = []string{"up", "down", "select", "cancel", "filter"}
+
+// activeKeys is the key map used by every list-based component
+var activeKeys = DefaultKeyMap()
+
+// DefaultKeyMap returns the built-in bindings, which already accept j/k for navigation
+func DefaultKeyMap() KeyMap {
+	return KeyMap{
+		Up:     newBinding([]string{"up", "k"}, "up"),
+		Down:   newBinding([]string{"down", "j"}, "down"),
+		Select: newBinding([]string{"enter"}, "select"),
+		Cancel: newBinding([]string{"q", "esc"}, "quit"),
+		Filter: newBinding([]string{"/"}, "filter"),
+	}
+}
+
+// newBinding builds a binding whose help label lists every key, e.g. "↑/k"
+func newBinding(keys []string, desc string) key.Binding {
+	labels := make([]string, len(keys))
+	for i, k := range keys {
+		labels[i] = keyLabel(k)
+	}
+
+	return key.NewBinding(key.WithKeys(keys...), key.WithHelp(strings.Join(labels, "/"), desc))
+}
+
+// keyLabel returns the short display form of a key name
+func keyLabel(k string) string {
+	switch k {
+	case "up":
+		return "↑"
+	case "down":
+		return "↓"
+	case "left":
+		return "←"
+	case "right":
+		return "→"
+	default:
+		return k
+	}
+}
+
+// WithOverrides returns the key map with some actions rebound.
+// spec is a comma-separated list of action=keys pairs where keys are separated by "|",
+// e.g. "up=k|up,down=j|down,cancel=esc". An action listed in spec loses its default keys.
+func (k KeyMap) WithOverrides(spec string) (KeyMap, error) {
+	for _, pair := range strings.Split(spec, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		action, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return k, fmt.Errorf("invalid keybinding %q (want action=key|key)", pair)
+		}
+
+		action = strings.ToLower(strings.TrimSpace(action))
+
+		var keys []string
+
+		for _, name := range strings.Split(value, "|") {
+			if name = strings.TrimSpace(name); name != "" {
+				keys = append(keys, name)
+			}
+		}
+
+		if len(keys) == 0 {
+			return k, fmt.Errorf("no keys given for %s", action)
+		}
+
+		switch action {
+		case "up":
+			k.Up = newBinding(keys, "up")
+		case "down":
+			k.Down = newBinding(keys, "down")
+		case "select":
+			k.Select = newBinding(keys, "select")
+		case "cancel":
+			k.Cancel = newBinding(keys, "quit")
+		case "filter":
+			k.Filter = newBinding(keys, "filter")
+		default:
+			return k, fmt.Errorf("unknown key action %q (want one of %s)", action, strings.Join(KeyActions, ", "))
+		}
+	}
+
+	return k, nil
+}
+
+// ApplyKeyMap makes the key map active for components created afterwards
+func ApplyKeyMap(k KeyMap) {
+	activeKeys = k
+}
+
+// CurrentKeyMap returns the active key map
+func CurrentKeyMap() KeyMap {
+	return activeKeys
+}
+
+// isCancel reports whether a key press cancels the current component
+func (k KeyMap) isCancel(msg tea.KeyMsg) bool {
+	return msg.String() == keyCtrlC || key.Matches(msg, k.Cancel)
+}
+
+// applyToList installs the bindings in a bubbles list so its navigation and
+// built-in help footer follow the active mapping
+func (k KeyMap) applyToList(l *list.Model) {
+	l.KeyMap.CursorUp = k.Up
+	l.KeyMap.CursorDown = k.Down
+	l.KeyMap.Filter = k.Filter
+	l.KeyMap.Quit = k.Cancel
+	l.AdditionalShortHelpKeys = func() []key.Binding {
+		return []key.Binding{k.Select}
+	}
+}
+
+// helpFooter renders bindings as a one-line hint, e.g. "/ filter • enter select • q/esc quit"
+func helpFooter(bindings ...key.Binding) string {
+	parts := make([]string, 0, len(bindings))
+
+	for _, b := range bindings {
+		if !b.Enabled() {
+			continue
+		}
+
+		parts = append(parts, b.Help().Key+" "+b.Help().Desc)
+	}
+
+	return strings.Join(parts, " • ")
+}
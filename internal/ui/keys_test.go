@@ -0,0 +1,60 @@
+package ui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestKeyMapWithOverrides(t *testing.T) {
+	keys, err := DefaultKeyMap().WithOverrides("up=ctrl+p|up, cancel = esc")
+	if err != nil {
+		t.Fatalf("WithOverrides() error = %v", err)
+	}
+
+	if got := keys.Up.Help().Key; got != "ctrl+p/↑" {
+		t.Errorf("Up help = %q, want %q", got, "ctrl+p/↑")
+	}
+
+	q := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")}
+	if keys.isCancel(q) {
+		t.Error("q should no longer cancel once cancel is remapped to esc")
+	}
+
+	if !keys.isCancel(tea.KeyMsg{Type: tea.KeyCtrlC}) {
+		t.Error("ctrl+c should always cancel")
+	}
+
+	if got := keys.Down.Help().Key; got != "↓/j" {
+		t.Errorf("Down help = %q, want the default ↓/j", got)
+	}
+
+	for _, spec := range []string{"up", "up=", "jump=g"} {
+		if _, err := DefaultKeyMap().WithOverrides(spec); err == nil {
+			t.Errorf("WithOverrides(%q) should fail", spec)
+		}
+	}
+}
+
+func TestHelpFooter(t *testing.T) {
+	keys := DefaultKeyMap()
+
+	got := helpFooter(keys.Filter, keys.Select, keys.Cancel)
+	if want := "/ filter • enter select • q/esc quit"; got != want {
+		t.Errorf("helpFooter() = %q, want %q", got, want)
+	}
+}
+
+func TestMenuUsesActiveKeyMap(t *testing.T) {
+	defer ApplyKeyMap(DefaultKeyMap())
+
+	keys, _ := DefaultKeyMap().WithOverrides("select=l")
+	ApplyKeyMap(keys)
+
+	menu := NewMenu("Test", []MenuItem{NewMenuItem("One", "", "one")})
+
+	model, _ := menu.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("l")})
+	if got := model.(MenuModel).Choice(); got != "one" {
+		t.Errorf("Choice() = %q, want %q after the remapped select key", got, "one")
+	}
+}
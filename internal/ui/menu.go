@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -13,7 +14,7 @@ import (
 var (
 	titleStyle        = lipgloss.NewStyle().MarginLeft(2)
 	itemStyle         = lipgloss.NewStyle().PaddingLeft(4)
-	selectedItemStyle = lipgloss.NewStyle().PaddingLeft(2).Foreground(lipgloss.Color("170"))
+	selectedItemStyle lipgloss.Style // built from the active theme in applyStyles
 	paginationStyle   = list.DefaultStyles().PaginationStyle.PaddingLeft(4)
 	helpStyle         = list.DefaultStyles().HelpStyle.PaddingLeft(4).PaddingBottom(1)
 	quitTextStyle     = lipgloss.NewStyle().Margin(1, 0, 2, 4)
@@ -50,6 +51,7 @@ func (i MenuItem) Action() string { return i.action }
 // MenuModel represents the main menu model.
 type MenuModel struct {
 	list     list.Model
+	keys     KeyMap
 	choice   string
 	quitting bool
 }
@@ -71,8 +73,9 @@ func NewMenu(title string, items []MenuItem) MenuModel {
 	l.Styles.Title = titleStyle
 	l.Styles.PaginationStyle = paginationStyle
 	l.Styles.HelpStyle = helpStyle
+	activeKeys.applyToList(&l)
 
-	return MenuModel{list: l}
+	return MenuModel{list: l, keys: activeKeys}
 }
 
 // Init initializes the menu model.
@@ -88,13 +91,13 @@ func (m MenuModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case tea.KeyMsg:
-		switch keypress := msg.String(); keypress {
-		case "q", keyCtrlC, keyEsc:
+		switch {
+		case m.keys.isCancel(msg):
 			m.quitting = true
 
 			return m, tea.Quit
 
-		case "enter":
+		case key.Matches(msg, m.keys.Select):
 			i, ok := m.list.SelectedItem().(MenuItem)
 			if ok {
 				m.choice = i.Action()
@@ -29,6 +29,9 @@ type MonitorModel struct {
 	err      error
 	width    int
 	height   int
+	// testStatus, when set, returns each worktree's last test result by path
+	testStatus func() map[string]string
+	tests      map[string]string
 }
 
 // NewMonitor creates a new monitor model
@@ -41,9 +44,15 @@ func NewMonitor(repo *git.Repository, interval time.Duration) *MonitorModel {
 	}
 }
 
+// SetTestStatus shows each worktree's last test result, read with fn on every check
+func (m *MonitorModel) SetTestStatus(fn func() map[string]string) {
+	m.testStatus = fn
+}
+
 // HealthCheckCompleteMsg signals that a health check has completed
 type HealthCheckCompleteMsg struct {
 	Results []*git.HealthCheckResult
+	Tests   map[string]string
 	Error   error
 }
 
@@ -78,6 +87,7 @@ func (m *MonitorModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case HealthCheckCompleteMsg:
 		m.results = msg.Results
+		m.tests = msg.Tests
 		m.err = msg.Error
 		m.lastRun = time.Now()
 		m.running = false
@@ -101,7 +111,7 @@ func (m *MonitorModel) View() string {
 	// Header
 	titleStyle := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("205")).
+		Foreground(accentColor).
 		Padding(0, 1)
 
 	b.WriteString(titleStyle.Render("🔍 Worktree Health Monitor"))
@@ -109,7 +119,7 @@ func (m *MonitorModel) View() string {
 
 	// Status bar
 	statusStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("241"))
+		Foreground(subtleColor)
 
 	switch {
 	case m.running:
@@ -132,7 +142,7 @@ func (m *MonitorModel) View() string {
 	// Error display
 	if m.err != nil {
 		errorStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("196")).
+			Foreground(errorColor).
 			Bold(true)
 		b.WriteString(errorStyle.Render(fmt.Sprintf("❌ Error: %v", m.err)))
 		b.WriteString("\n\n")
@@ -149,7 +159,7 @@ func (m *MonitorModel) View() string {
 	b.WriteString("\n")
 
 	helpStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("241")).
+		Foreground(subtleColor).
 		Italic(true)
 
 	b.WriteString(helpStyle.Render("Press 'r' to refresh now • 'q' or ESC to quit"))
@@ -178,14 +188,14 @@ func (m *MonitorModel) renderResults() string {
 	// Summary
 	summaryStyle := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("86"))
+		Foreground(successColor)
 
 	if unhealthyCount == 0 {
 		b.WriteString(summaryStyle.Render("✅ All worktrees healthy!"))
 	} else {
 		warningStyle := lipgloss.NewStyle().
 			Bold(true).
-			Foreground(lipgloss.Color("214"))
+			Foreground(warningColor)
 		b.WriteString(warningStyle.Render(fmt.Sprintf("⚠️  %d unhealthy worktree(s) found", unhealthyCount)))
 	}
 
@@ -210,32 +220,32 @@ func (m *MonitorModel) renderWorktreeResult(result *git.HealthCheckResult) strin
 
 	// Worktree header
 	pathStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("39"))
+		Foreground(infoColor)
 	b.WriteString(pathStyle.Render(fmt.Sprintf("📁 %s", result.WorktreePath)))
 	b.WriteString(" ")
 
 	// Status
 	severity := result.GetMaxSeverity()
 	var statusIcon string
-	var statusColor string
+	var statusColor lipgloss.TerminalColor = lipgloss.NoColor{}
 
 	switch severity {
 	case git.SeverityOK:
 		statusIcon = iconCheckmark
-		statusColor = "86"
+		statusColor = successColor
 	case git.SeverityWarning:
 		statusIcon = iconWarning
-		statusColor = "214"
+		statusColor = warningColor
 	case git.SeverityError:
 		statusIcon = iconError
-		statusColor = "196"
+		statusColor = errorColor
 	case git.SeverityCritical:
 		statusIcon = iconCritical
-		statusColor = "196"
+		statusColor = errorColor
 	}
 
 	statusStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color(statusColor))
+		Foreground(statusColor)
 
 	if result.Healthy {
 		b.WriteString(statusStyle.Render(fmt.Sprintf("%s Healthy", statusIcon)))
@@ -243,6 +253,10 @@ func (m *MonitorModel) renderWorktreeResult(result *git.HealthCheckResult) strin
 		b.WriteString(statusStyle.Render(fmt.Sprintf("%s Unhealthy (%d issues)", statusIcon, len(result.Issues))))
 	}
 
+	if tests, ok := m.tests[result.WorktreePath]; ok {
+		b.WriteString("  Tests: " + tests)
+	}
+
 	b.WriteString("\n")
 
 	// Issues (only show if unhealthy and not too many)
@@ -271,7 +285,7 @@ func (m *MonitorModel) renderWorktreeResult(result *git.HealthCheckResult) strin
 			}
 
 			issueStyle := lipgloss.NewStyle().
-				Foreground(lipgloss.Color("245"))
+				Foreground(subtleColor)
 			b.WriteString(issueStyle.Render(fmt.Sprintf("   %s %s: %s\n", icon, issue.Category, issue.Description)))
 		}
 	}
@@ -285,8 +299,14 @@ func (m *MonitorModel) checkHealth() tea.Cmd {
 		m.running = true
 		results, err := m.repo.PerformHealthCheckAll()
 
+		var tests map[string]string
+		if m.testStatus != nil {
+			tests = m.testStatus()
+		}
+
 		return HealthCheckCompleteMsg{
 			Results: results,
+			Tests:   tests,
 			Error:   err,
 		}
 	}
@@ -0,0 +1,66 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ShortenPath fits path into width runes for a table column: the home
+// directory becomes ~, then middle folders give way to an ellipsis so the
+// start and the worktree's own folder stay readable
+func ShortenPath(path string, width int) string {
+	home, _ := os.UserHomeDir() //nolint:errcheck // without a home directory the path is kept as is
+
+	return shortenPath(path, home, width)
+}
+
+// shortenPath is ShortenPath with the home directory given
+func shortenPath(path, home string, width int) string {
+	sep := string(filepath.Separator)
+
+	if home != "" && (path == home || strings.HasPrefix(path, home+sep)) {
+		path = "~" + path[len(home):]
+	}
+
+	if len([]rune(path)) <= width {
+		return path
+	}
+
+	parts := strings.Split(path, sep)
+	head := parts[0] + sep
+	tail := ""
+
+	for i := len(parts) - 1; i > 0; i-- {
+		candidate := parts[i]
+		if tail != "" {
+			candidate += sep + tail
+		}
+
+		if len([]rune(head+"…"+sep+candidate)) > width {
+			break
+		}
+
+		tail = candidate
+	}
+
+	if tail != "" {
+		return head + "…" + sep + tail
+	}
+
+	return truncateMiddle(path, width)
+}
+
+// truncateMiddle shortens s to at most width runes by replacing its middle
+// with an ellipsis
+func truncateMiddle(s string, width int) string {
+	runes := []rune(s)
+	if len(runes) <= width || width < 2 {
+		return s
+	}
+
+	left := (width - 1) / 2
+	right := width - 1 - left
+
+	return string(runes[:left]) + "…" + string(runes[len(runes)-right:])
+}
@@ -0,0 +1,33 @@
+package ui
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestShortenPath(t *testing.T) {
+	tests := []struct {
+		name  string
+		path  string
+		width int
+		want  string
+	}{
+		{"fits", "/src/repo-worktrees/fix", 30, "/src/repo-worktrees/fix"},
+		{"home becomes ~", "/home/me/worktrees/repo/fix", 30, "~/worktrees/repo/fix"},
+		{"middle folders dropped", "/home/me/src/acme/platform/repo-worktrees/work-123-fix-login", 40, "~/…/repo-worktrees/work-123-fix-login"},
+		{"last folder too long", "/data/work-123-fix-the-login-form-validation", 20, "/data/wor…validation"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := shortenPath(filepath.FromSlash(tt.path), filepath.FromSlash("/home/me"), tt.width)
+			if got != filepath.FromSlash(tt.want) {
+				t.Errorf("shortenPath(%q, %d) = %q, want %q", tt.path, tt.width, got, tt.want)
+			}
+
+			if n := len([]rune(got)); n > tt.width {
+				t.Errorf("shortenPath() is %d runes, over the width %d", n, tt.width)
+			}
+		})
+	}
+}
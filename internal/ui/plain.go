@@ -0,0 +1,328 @@
+package ui
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/term"
+	"github.com/mattn/go-isatty"
+	"github.com/muesli/termenv"
+)
+
+// ErrNeedsTerminal is returned when a full-screen component is started in plain mode
+var ErrNeedsTerminal = errors.New("this screen needs an interactive terminal (drop --plain or use flags)")
+
+// plain is true when components must not take over the terminal
+var plain bool
+
+// plainIn and plainOut are the streams used by line-based prompts; replaced in tests.
+// Prompts go to stderr so they stay visible when stdout is piped.
+var (
+	plainIn            = bufio.NewReader(os.Stdin)
+	plainOut io.Writer = os.Stderr
+)
+
+// plainPrompter is implemented by components with a line-based fallback
+type plainPrompter interface {
+	runPlain(r *bufio.Reader, w io.Writer) (tea.Model, error)
+}
+
+// IsTerminal reports whether f is connected to a terminal
+func IsTerminal(f *os.File) bool {
+	return isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd())
+}
+
+// TerminalWidth returns the width of the terminal f is connected to, or of
+// $COLUMNS when it is not one, or 0 when the width is unknown
+func TerminalWidth(f *os.File) int {
+	if IsTerminal(f) {
+		if width, _, err := term.GetSize(f.Fd()); err == nil && width > 0 {
+			return width
+		}
+	}
+
+	if width, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && width > 0 {
+		return width
+	}
+
+	return 0
+}
+
+// SetPlain switches every component to plain output: no colors, no alternate
+// screen, and line-based prompts on stdin instead of full-screen ones
+func SetPlain(enabled bool) {
+	plain = enabled
+
+	if enabled {
+		lipgloss.SetColorProfile(termenv.Ascii)
+	}
+}
+
+// IsPlain reports whether plain output mode is active
+func IsPlain() bool {
+	return plain
+}
+
+// Run runs a component as a Bubble Tea program, or as a line-based prompt in plain mode.
+// Components without a line-based fallback return ErrNeedsTerminal in plain mode.
+func Run(model tea.Model, opts ...tea.ProgramOption) (tea.Model, error) {
+	if !plain {
+		return tea.NewProgram(model, opts...).Run()
+	}
+
+	if p, ok := model.(plainPrompter); ok {
+		return p.runPlain(plainIn, plainOut)
+	}
+
+	return model, ErrNeedsTerminal
+}
+
+// plainf writes prompt text; a failed write to the terminal is not actionable
+func plainf(w io.Writer, format string, args ...interface{}) {
+	_, _ = fmt.Fprintf(w, format, args...)
+}
+
+// readLine prints the prompt and reads one trimmed line; ok is false at end of input
+func readLine(r *bufio.Reader, w io.Writer, prompt string) (string, bool) {
+	plainf(w, "%s", prompt)
+
+	line, err := r.ReadString('\n')
+	if err != nil && line == "" {
+		plainf(w, "\n")
+		return "", false
+	}
+
+	return strings.TrimSpace(line), true
+}
+
+// askYesNo asks a question that defaults to no; ok is false at end of input
+func askYesNo(r *bufio.Reader, w io.Writer, question string) (yes, ok bool) {
+	answer, ok := readLine(r, w, question+" [y/N] ")
+	if !ok {
+		return false, false
+	}
+
+	answer = strings.ToLower(answer)
+
+	return answer == "y" || answer == "yes", true
+}
+
+// chooseIndex prints numbered options and reads a choice.
+// It returns the zero-based index, or -1 if the input was empty or ended.
+func chooseIndex(r *bufio.Reader, w io.Writer, title string, options []string) int {
+	if title != "" {
+		plainf(w, "%s\n", title)
+	}
+
+	for i, option := range options {
+		plainf(w, "  %d) %s\n", i+1, option)
+	}
+
+	for {
+		answer, ok := readLine(r, w, fmt.Sprintf("Choose 1-%d (empty to cancel): ", len(options)))
+		if !ok || answer == "" {
+			return -1
+		}
+
+		if n, err := strconv.Atoi(answer); err == nil && n >= 1 && n <= len(options) {
+			return n - 1
+		}
+
+		plainf(w, "Invalid choice %q\n", answer)
+	}
+}
+
+func (m MenuModel) runPlain(r *bufio.Reader, w io.Writer) (tea.Model, error) {
+	var (
+		actions []string
+		titles  []string
+	)
+
+	for _, item := range m.list.Items() {
+		if i, ok := item.(MenuItem); ok {
+			actions = append(actions, i.Action())
+			titles = append(titles, i.Title())
+		}
+	}
+
+	m.quitting = true
+
+	if idx := chooseIndex(r, w, m.list.Title, titles); idx >= 0 {
+		m.choice = actions[idx]
+	}
+
+	return m, nil
+}
+
+func (m ConfirmModel) runPlain(r *bufio.Reader, w io.Writer) (tea.Model, error) {
+	m.choice, _ = askYesNo(r, w, m.prompt)
+	m.quitting = true
+
+	return m, nil
+}
+
+func (m InputModel) runPlain(r *bufio.Reader, w io.Writer) (tea.Model, error) {
+	prompt := m.prompt
+	if def := m.textInput.Value(); def != "" {
+		prompt += fmt.Sprintf(" [%s]", def)
+	}
+
+	answer, ok := readLine(r, w, prompt+" ")
+	if !ok {
+		m.err = fmt.Errorf("canceled")
+		return m, nil
+	}
+
+	if answer == "" {
+		answer = m.textInput.Value()
+	}
+
+	m.value = answer
+
+	return m, nil
+}
+
+func (m TextAreaModel) runPlain(r *bufio.Reader, w io.Writer) (tea.Model, error) {
+	plainf(w, "%s\n(end with a line containing only \".\"; an empty answer keeps the current text)\n", m.prompt)
+
+	var lines []string
+
+	for {
+		line, err := r.ReadString('\n')
+		line = strings.TrimRight(line, "\r\n")
+
+		if line == "." || (err != nil && line == "") {
+			break
+		}
+
+		lines = append(lines, line)
+
+		if err != nil {
+			break
+		}
+	}
+
+	m.value = strings.Join(lines, "\n")
+	if len(lines) == 0 {
+		m.value = m.textarea.Value()
+	}
+
+	return m, nil
+}
+
+func (m FilterListModel) runPlain(r *bufio.Reader, w io.Writer) (tea.Model, error) {
+	items := m.items
+	plainf(w, "%s\n", m.title)
+
+	for {
+		for i, item := range items {
+			plainf(w, "  %d) %s\n", i+1, item.Title())
+		}
+
+		prompt := "Number, text to filter, or empty to cancel: "
+		if m.canLoadMore {
+			prompt = "Number, text to filter, m to load more, or empty to cancel: "
+		}
+
+		answer, ok := readLine(r, w, prompt)
+		if !ok || answer == "" {
+			m.err = fmt.Errorf("canceled")
+			return m, nil
+		}
+
+		if m.canLoadMore && answer == "m" {
+			m.loadMore = true
+			return m, nil
+		}
+
+		if n, err := strconv.Atoi(answer); err == nil && n >= 1 && n <= len(items) {
+			m.choice = &items[n-1]
+			return m, nil
+		}
+
+		items = filterItems(m.items, answer)
+		if len(items) == 0 {
+			plainf(w, "Nothing matches %q\n", answer)
+
+			items = m.items
+		}
+	}
+}
+
+// filterItems returns the items whose filter value contains query, ignoring case
+func filterItems(items []FilterableListItem, query string) []FilterableListItem {
+	query = strings.ToLower(query)
+
+	var matched []FilterableListItem
+
+	for _, item := range items {
+		if strings.Contains(strings.ToLower(item.FilterValue()), query) {
+			matched = append(matched, item)
+		}
+	}
+
+	return matched
+}
+
+func (m CleanupConfirmationModel) runPlain(r *bufio.Reader, w io.Writer) (tea.Model, error) {
+	if m.MergedCount == 0 && m.StaleCount == 0 {
+		m.Canceled = true
+		return m, nil
+	}
+
+	if m.MergedCount > 0 {
+		plainf(w, "Found %d merged worktree(s) ready for automatic cleanup\n", m.MergedCount)
+	}
+
+	if m.StaleCount > 0 {
+		plainf(w, "Found %d stale worktree(s) that will require interactive confirmation\n", m.StaleCount)
+	}
+
+	yes, ok := askYesNo(r, w, "Proceed with cleanup?")
+	m.Confirmed = yes
+	m.Canceled = !ok || !yes
+
+	return m, nil
+}
+
+func (m CleanupPromptModel) runPlain(r *bufio.Reader, w io.Writer) (tea.Model, error) {
+	plainf(w, "Cleanup worktree: %s\n", m.WorktreePath)
+
+	if m.Branch != "" {
+		plainf(w, "Branch: %s\n", m.Branch)
+	}
+
+	if m.CleanupReason != "" {
+		plainf(w, "Reason: %s\n", m.CleanupReason)
+	}
+
+	for _, line := range m.Preview.Lines() {
+		plainf(w, "%s\n", line)
+	}
+
+	if m.UnpushedCount > 0 {
+		plainf(w, "Warning: %d unpushed commit(s)\n", m.UnpushedCount)
+	}
+
+	m.PromptState = promptStateDone
+
+	if yes, _ := askYesNo(r, w, "Remove this worktree?"); !yes {
+		m.Canceled = true
+		return m, nil
+	}
+
+	m.Confirmed = true
+
+	if m.Branch != "" {
+		m.DeleteBranch, _ = askYesNo(r, w, fmt.Sprintf("Delete branch '%s'?", m.Branch))
+	}
+
+	return m, nil
+}
@@ -0,0 +1,137 @@
+package ui
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+// withPlainInput enables plain mode with input as stdin for the rest of the test
+func withPlainInput(t *testing.T, input string) {
+	t.Helper()
+
+	oldIn, oldOut, oldPlain := plainIn, plainOut, plain
+	plainIn, plainOut, plain = bufio.NewReader(strings.NewReader(input)), io.Discard, true
+
+	t.Cleanup(func() {
+		plainIn, plainOut, plain = oldIn, oldOut, oldPlain
+	})
+}
+
+func TestRunPlainMenu(t *testing.T) {
+	withPlainInput(t, "7\n2\n")
+
+	menu := NewMenu("Pick", []MenuItem{NewMenuItem("One", "", "one"), NewMenuItem("Two", "", "two")})
+
+	m, err := Run(menu)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	if got := m.(MenuModel).Choice(); got != "two" {
+		t.Errorf("Choice() = %q, want %q after an invalid answer and a retry", got, "two")
+	}
+}
+
+func TestRunPlainEndOfInputCancels(t *testing.T) {
+	withPlainInput(t, "")
+
+	m, _ := Run(NewConfirmModel("Delete?"))
+	if m.(ConfirmModel).GetChoice() {
+		t.Error("confirm should default to no when stdin is closed")
+	}
+
+	m, _ = Run(NewInput("Branch:", ""))
+	if m.(InputModel).Err() == nil {
+		t.Error("input should be canceled when stdin is closed")
+	}
+}
+
+func TestRunPlainInputDefault(t *testing.T) {
+	withPlainInput(t, "\n")
+
+	m, _ := Run(NewInput("Title:", "").WithValue("Fix login"))
+	if got := m.(InputModel).Value(); got != "Fix login" {
+		t.Errorf("Value() = %q, want the prefilled value", got)
+	}
+}
+
+func TestRunPlainFilterList(t *testing.T) {
+	withPlainInput(t, "login\n1\n")
+
+	items := []FilterableListItem{
+		NewFilterableListItem(1, "Add dark mode", nil, false),
+		NewFilterableListItem(2, "Fix login redirect", nil, false),
+	}
+
+	m, err := Run(NewFilterList("Issues", items))
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	if choice := m.(FilterListModel).Choice(); choice == nil || choice.Number() != 2 {
+		t.Errorf("Choice() = %v, want issue 2 (first match of the filter)", choice)
+	}
+}
+
+func TestRunPlainCleanupPrompt(t *testing.T) {
+	withPlainInput(t, "y\nn\n")
+
+	m, _ := Run(NewCleanupPrompt("/wt/feature", "feature", "merged", 0, true))
+
+	prompt := m.(CleanupPromptModel)
+	if !prompt.WasConfirmed() || prompt.ShouldDeleteBranch() {
+		t.Errorf("confirmed = %v, delete branch = %v; want true, false", prompt.WasConfirmed(), prompt.ShouldDeleteBranch())
+	}
+}
+
+func TestRunPlainCleanupPromptShowsPreview(t *testing.T) {
+	withPlainInput(t, "n\n")
+
+	var out strings.Builder
+	plainOut = &out
+
+	prompt := NewCleanupPrompt("/wt/feature", "feature", "stale (40 days old)", 0, true)
+	prompt.Preview = CleanupPreview{LastCommit: "Add retry", DiffStat: "2 files changed, +5 -1 vs main", PullRequest: "none"}
+
+	if _, err := Run(prompt); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	for _, want := range []string{"Branch: feature", "Last commit: Add retry", "Changes: 2 files changed, +5 -1 vs main", "Pull request: none"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("prompt output is missing %q:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestRunPlainFullScreenNeedsTerminal(t *testing.T) {
+	withPlainInput(t, "")
+
+	if _, err := Run(NewSpinnerModel("working")); !errors.Is(err, ErrNeedsTerminal) {
+		t.Errorf("Run() error = %v, want ErrNeedsTerminal", err)
+	}
+}
+
+func TestTerminalWidthFallsBackToColumns(t *testing.T) {
+	f, err := os.CreateTemp(t.TempDir(), "out")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	t.Setenv("COLUMNS", "100")
+
+	if got := TerminalWidth(f); got != 100 {
+		t.Errorf("TerminalWidth() = %d, want 100 from $COLUMNS", got)
+	}
+
+	t.Setenv("COLUMNS", "")
+
+	if got := TerminalWidth(f); got != 0 {
+		t.Errorf("TerminalWidth() = %d, want 0 when unknown", got)
+	}
+}
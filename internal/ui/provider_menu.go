@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
 )
@@ -36,6 +37,7 @@ func (i ProviderItem) FilterValue() string { return i.title }
 // ProviderMenuModel represents the provider selection menu
 type ProviderMenuModel struct {
 	list     list.Model
+	keys     KeyMap
 	choice   Provider
 	quitting bool
 }
@@ -76,9 +78,11 @@ func NewProviderMenuModel() *ProviderMenuModel {
 	l.Title = "Select Issue Provider"
 	l.Styles.Title = TitleStyle
 	l.SetShowStatusBar(false)
+	activeKeys.applyToList(&l)
 
 	return &ProviderMenuModel{
 		list:   l,
+		keys:   activeKeys,
 		choice: ProviderNone,
 	}
 }
@@ -100,13 +104,13 @@ func (m ProviderMenuModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case tea.KeyMsg:
-		switch msg.String() {
-		case keyCtrlC, "q", keyEsc:
+		switch {
+		case m.keys.isCancel(msg):
 			m.quitting = true
 
 			return m, tea.Quit
 
-		case keyEnter:
+		case key.Matches(msg, m.keys.Select):
 			if selectedItem, ok := m.list.SelectedItem().(ProviderItem); ok {
 				m.choice = selectedItem.provider
 				m.quitting = true
@@ -167,6 +171,7 @@ func (i AIToolItem) FilterValue() string { return i.title }
 // AIToolMenuModel represents the AI tool selection menu
 type AIToolMenuModel struct {
 	list     list.Model
+	keys     KeyMap
 	choice   AITool
 	quitting bool
 }
@@ -207,9 +212,11 @@ func NewAIToolMenuModel() *AIToolMenuModel {
 	l.Title = "Select AI Tool"
 	l.Styles.Title = TitleStyle
 	l.SetShowStatusBar(false)
+	activeKeys.applyToList(&l)
 
 	return &AIToolMenuModel{
 		list:   l,
+		keys:   activeKeys,
 		choice: AIToolNone,
 	}
 }
@@ -231,13 +238,13 @@ func (m AIToolMenuModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case tea.KeyMsg:
-		switch msg.String() {
-		case keyCtrlC, "q", keyEsc:
+		switch {
+		case m.keys.isCancel(msg):
 			m.quitting = true
 
 			return m, tea.Quit
 
-		case keyEnter:
+		case key.Matches(msg, m.keys.Select):
 			if selectedItem, ok := m.list.SelectedItem().(AIToolItem); ok {
 				m.choice = selectedItem.tool
 				m.quitting = true
@@ -3,6 +3,7 @@ package ui
 import (
 	"fmt"
 
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
 )
@@ -51,6 +52,7 @@ func (i SettingItem) FilterValue() string {
 // SettingsMenuModel represents the main settings menu
 type SettingsMenuModel struct {
 	list     list.Model
+	keys     KeyMap
 	choice   string
 	quitting bool
 }
@@ -85,9 +87,11 @@ func NewSettingsMenuModel(settings []SettingItem) *SettingsMenuModel {
 	l.Title = "Settings"
 	l.Styles.Title = TitleStyle
 	l.SetShowStatusBar(false)
+	activeKeys.applyToList(&l)
 
 	return &SettingsMenuModel{
 		list: l,
+		keys: activeKeys,
 	}
 }
 
@@ -106,12 +110,12 @@ func (m SettingsMenuModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case tea.KeyMsg:
-		switch msg.String() {
-		case keyCtrlC, "q", keyEsc:
+		switch {
+		case m.keys.isCancel(msg):
 			m.quitting = true
 			return m, tea.Quit
 
-		case keyEnter:
+		case key.Matches(msg, m.keys.Select):
 			item := m.list.SelectedItem()
 			switch i := item.(type) {
 			case SettingItem:
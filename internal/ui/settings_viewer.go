@@ -33,28 +33,89 @@ var settingCategories = map[string][]string{
 	},
 	"AI Tool": {
 		"auto-worktree.ai-tool",
+		"auto-worktree.sandbox",
+		"auto-worktree.sandbox-image",
+		"auto-worktree.session-cpus",
+		"auto-worktree.session-memory",
+		"auto-worktree.session-idle-timeout",
+		"auto-worktree.checkpoint-interval",
+		"auto-worktree.redact-patterns",
+		"auto-worktree.ai-confirm-context",
+		"auto-worktree.ai-diff-limit",
 	},
 	"Auto-select": {
 		"auto-worktree.issue-autoselect",
 		"auto-worktree.pr-autoselect",
+		"auto-worktree.ai-rank-cache-ttl",
+		"auto-worktree.ai-rank-max-calls",
+		"auto-worktree.issue-ranking-template",
+		"auto-worktree.pr-ranking-template",
+		"auto-worktree.issue-filter-milestone",
+		"auto-worktree.issue-sort",
+	},
+	"Pull Requests": {
+		"auto-worktree.pr-reviewers",
+		"auto-worktree.pr-labels",
+		"auto-worktree.pr-draft",
+		"auto-worktree.pr-codeowners",
+	},
+	"Worktrees": {
+		"auto-worktree.worktree-base",
+		"auto-worktree.cleanup-policy",
+		"auto-worktree.protected-branches",
+		"auto-worktree.submodule-init",
+		"auto-worktree.submodule-shallow",
+		"auto-worktree.lfs-pull",
+		"auto-worktree.scope-sparse-checkout",
+		"auto-worktree.test-command",
+	},
+	"Branch Names": {
+		"auto-worktree.base-branch",
+		"auto-worktree.branch-name-prefix",
+		"auto-worktree.branch-name-words",
+		"auto-worktree.branch-name-separator",
+		"auto-worktree.branch-name-dictionary",
+		"auto-worktree.issue-branch-prefixes",
 	},
 	"Hooks": {
 		"auto-worktree.run-hooks",
 		"auto-worktree.fail-on-hook-error",
 		"auto-worktree.custom-hooks",
 	},
+	"External Commands": {
+		"auto-worktree.command-timeouts",
+		"auto-worktree.max-parallel",
+	},
 	"Issue Templates": {
 		"auto-worktree.issue-templates-dir",
 		"auto-worktree.issue-templates-disabled",
 		"auto-worktree.issue-templates-no-prompt",
 		"auto-worktree.issue-templates-detected",
 	},
+	"Appearance": {
+		"auto-worktree.theme",
+		"auto-worktree.theme-colors",
+		"auto-worktree.keybindings",
+		"auto-worktree.list-path-width",
+		"auto-worktree.list-sort",
+		"auto-worktree.list-columns",
+		"auto-worktree.list-compact",
+	},
 	"Provider Configuration": {
+		"auto-worktree.github-transport",
+		"auto-worktree.github-project",
+		"auto-worktree.github-project-field",
+		"auto-worktree.github-project-ready",
+		"auto-worktree.github-project-in-progress",
+		"auto-worktree.gitlab-transport",
 		"auto-worktree.jira-server",
 		"auto-worktree.jira-project",
+		"auto-worktree.jira-email",
 		"auto-worktree.gitlab-server",
 		"auto-worktree.gitlab-project",
 		"auto-worktree.linear-team",
+		"auto-worktree.linear-project",
+		"auto-worktree.linear-cycle",
 	},
 }
 
@@ -62,8 +123,13 @@ var categoryOrder = []string{
 	"Issue Provider",
 	"AI Tool",
 	"Auto-select",
+	"Pull Requests",
+	"Worktrees",
+	"Branch Names",
 	"Hooks",
+	"External Commands",
 	"Issue Templates",
+	"Appearance",
 	"Provider Configuration",
 }
 
@@ -169,7 +235,7 @@ func (m SettingsViewerModel) View() string {
 	// Wrap in a box
 	return "\n" + lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(ColorBlue).
+		BorderForeground(infoColor).
 		Padding(1, 2).
 		Render(m.content) + "\n"
 }
@@ -14,13 +14,15 @@ type SpinnerModel struct {
 	message string
 	done    bool
 	err     error
+	// canceled is set when the user pressed Ctrl+C
+	canceled bool
 }
 
 // NewSpinnerModel creates a new spinner model
 func NewSpinnerModel(message string) *SpinnerModel {
 	s := spinner.New()
 	s.Spinner = spinner.Dot
-	s.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("205"))
+	s.Style = lipgloss.NewStyle().Foreground(accentColor)
 
 	return &SpinnerModel{
 		spinner: s,
@@ -40,6 +42,7 @@ func (m *SpinnerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.KeyMsg:
 		// Allow Ctrl+C to quit
 		if msg.Type == tea.KeyCtrlC {
+			m.canceled = true
 			return m, tea.Quit
 		}
 
@@ -67,6 +70,12 @@ func (m *SpinnerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	}
 }
 
+// Canceled reports whether the spinner was stopped with Ctrl+C rather than
+// by the work finishing
+func (m *SpinnerModel) Canceled() bool {
+	return m.canceled
+}
+
 // View renders the spinner
 func (m *SpinnerModel) View() string {
 	if m.done {
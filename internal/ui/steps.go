@@ -0,0 +1,433 @@
+package ui
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/kaeawc/auto-worktree/internal/logging"
+)
+
+// stepOutputLines is how many lines of a step's output are kept to show
+// under it when it fails
+const stepOutputLines = 5
+
+// stepMarker is the line StepProgress writes to its output pipe for each step
+// message it queues, so the view gets messages and output in order
+const stepMarker = "\x00"
+
+// StepState is where a step of a StepsModel is
+type StepState int
+
+// Step states
+const (
+	StepPending StepState = iota
+	StepRunning
+	StepDone
+	StepFailed
+	StepSkipped
+)
+
+// step is one line of a StepsModel
+type step struct {
+	name     string
+	state    StepState
+	started  time.Time
+	duration time.Duration
+	// note is the error of a failed step or why a step was skipped
+	note string
+	// output are the last lines the step printed
+	output []string
+	// warnings are the lines the step printed with ⚠, kept on screen after it is done
+	warnings []string
+}
+
+// StepsModel shows the steps of an operation with the state and duration of
+// each, the last output line of the running step, the output of a failed one
+// and the warnings of the others under them
+type StepsModel struct {
+	title string
+	steps []step
+	// between are lines printed while no step was running
+	between []string
+	spinner spinner.Model
+	width   int
+	now     func() time.Time
+}
+
+// NewStepsModel creates a StepsModel with every step pending
+func NewStepsModel(title string, names ...string) StepsModel {
+	s := spinner.New()
+	s.Spinner = spinner.Dot
+	s.Style = lipgloss.NewStyle().Foreground(accentColor)
+
+	steps := make([]step, len(names))
+	for i, name := range names {
+		steps[i] = step{name: name}
+	}
+
+	return StepsModel{title: title, steps: steps, spinner: s, now: time.Now}
+}
+
+// StepStartMsg marks a step running
+type StepStartMsg struct{ Name string }
+
+// StepDoneMsg marks a step done, or failed when Err is set
+type StepDoneMsg struct {
+	Name string
+	Err  error
+}
+
+// StepSkipMsg marks a step skipped, saying why
+type StepSkipMsg struct{ Name, Reason string }
+
+// StepOutputMsg is a line printed while a step runs
+type StepOutputMsg struct{ Line string }
+
+// StepsDoneMsg ends the view, leaving it on screen
+type StepsDoneMsg struct{}
+
+// Init starts the spinner
+func (m StepsModel) Init() tea.Cmd {
+	return m.spinner.Tick
+}
+
+// Update handles step messages
+func (m StepsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case spinner.TickMsg:
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+
+		return m, cmd
+
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+
+	case StepStartMsg:
+		if s := m.find(msg.Name); s != nil {
+			s.state, s.started = StepRunning, m.now()
+		}
+
+	case StepDoneMsg:
+		if s := m.find(msg.Name); s != nil {
+			s.state, s.duration = StepDone, m.now().Sub(s.started)
+
+			if msg.Err != nil {
+				s.state, s.note = StepFailed, msg.Err.Error()
+			}
+		}
+
+	case StepSkipMsg:
+		if s := m.find(msg.Name); s != nil {
+			s.state, s.note = StepSkipped, msg.Reason
+		}
+
+	case StepOutputMsg:
+		if strings.TrimSpace(msg.Line) == "" {
+			return m, nil
+		}
+
+		s := m.running()
+		if s == nil {
+			m.between = append(m.between, msg.Line)
+			return m, nil
+		}
+
+		if strings.Contains(msg.Line, "⚠") {
+			s.warnings = append(s.warnings, msg.Line)
+		}
+
+		s.output = append(s.output, msg.Line)
+		if len(s.output) > stepOutputLines {
+			s.output = s.output[len(s.output)-stepOutputLines:]
+		}
+
+	case StepsDoneMsg:
+		return m, tea.Quit
+	}
+
+	return m, nil
+}
+
+// find returns the step with the given name
+func (m *StepsModel) find(name string) *step {
+	for i := range m.steps {
+		if m.steps[i].name == name {
+			return &m.steps[i]
+		}
+	}
+
+	return nil
+}
+
+// running returns the step that is running, if any
+func (m *StepsModel) running() *step {
+	for i := range m.steps {
+		if m.steps[i].state == StepRunning {
+			return &m.steps[i]
+		}
+	}
+
+	return nil
+}
+
+// State returns the state of the named step
+func (m StepsModel) State(name string) StepState {
+	if s := m.find(name); s != nil {
+		return s.state
+	}
+
+	return StepPending
+}
+
+// View renders the steps
+func (m StepsModel) View() string {
+	nameWidth := 0
+	for _, s := range m.steps {
+		nameWidth = max(nameWidth, lipgloss.Width(s.name))
+	}
+
+	line := lipgloss.NewStyle()
+	if m.width > 0 {
+		line = line.MaxWidth(m.width)
+	}
+
+	var b strings.Builder
+
+	b.WriteString(TitleStyle.Render(m.title) + "\n")
+
+	for _, s := range m.steps {
+		name := s.name + strings.Repeat(" ", nameWidth-lipgloss.Width(s.name))
+
+		switch s.state {
+		case StepPending:
+			b.WriteString(line.Render(SubtleStyle.Render("  · "+name)) + "\n")
+		case StepRunning:
+			b.WriteString(line.Render(fmt.Sprintf("  %s %s  %s", m.spinner.View(), name,
+				SubtleStyle.Render(formatStepDuration(m.now().Sub(s.started))))) + "\n")
+
+			if len(s.output) > 0 {
+				b.WriteString(line.Render(SubtleStyle.Render("      "+s.output[len(s.output)-1])) + "\n")
+			}
+		case StepDone:
+			b.WriteString(line.Render(fmt.Sprintf("  %s %s  %s", SuccessStyle.Render("✓"), name,
+				SubtleStyle.Render(formatStepDuration(s.duration)))) + "\n")
+
+			for _, warning := range s.warnings {
+				b.WriteString(line.Render("      "+warning) + "\n")
+			}
+		case StepFailed:
+			b.WriteString(line.Render(fmt.Sprintf("  %s %s  %s  %s", ErrorStyle.Render("✗"), name,
+				SubtleStyle.Render(formatStepDuration(s.duration)), ErrorStyle.Render(s.note))) + "\n")
+
+			for _, out := range s.output {
+				b.WriteString(line.Render(SubtleStyle.Render("      "+out)) + "\n")
+			}
+		case StepSkipped:
+			b.WriteString(line.Render(SubtleStyle.Render("  - "+name+"  skipped: "+s.note)) + "\n")
+		}
+	}
+
+	for _, out := range m.between {
+		b.WriteString(line.Render(out) + "\n")
+	}
+
+	return b.String()
+}
+
+// formatStepDuration formats a step's duration, e.g. 0.4s or 1m05s
+func formatStepDuration(d time.Duration) string {
+	if d < time.Minute {
+		return fmt.Sprintf("%.1fs", d.Seconds())
+	}
+
+	return fmt.Sprintf("%dm%02ds", int(d.Minutes()), int(d.Seconds())%60)
+}
+
+// StepProgress shows a StepsModel while the steps run. Until Stop, what the
+// steps print to stdout and what is logged to stderr is shown as the running
+// step's output instead of scrolling past the view. In plain mode each step
+// is reported on its own line as it finishes instead.
+type StepProgress struct {
+	title string
+	names []string
+
+	program *tea.Program
+	// stdout is the real stdout while it is redirected to the view
+	stdout   *os.File
+	pipe     *os.File
+	restore  func()
+	copied   chan struct{}
+	finished chan struct{}
+
+	mu      sync.Mutex
+	queued  []tea.Msg
+	stopped bool
+
+	// plain mode
+	out     io.Writer
+	started map[string]time.Time
+
+	stopOnce sync.Once
+}
+
+// NewStepProgress creates a StepProgress for the named steps, in order
+func NewStepProgress(title string, names ...string) *StepProgress {
+	return &StepProgress{title: title, names: names, out: os.Stdout, started: map[string]time.Time{}}
+}
+
+// Start shows the steps, all pending
+func (p *StepProgress) Start() {
+	if plain {
+		fmt.Fprintln(p.out, p.title)
+		return
+	}
+
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		logging.Debug("could not capture output for the progress view", "err", err)
+		fmt.Fprintln(p.out, p.title)
+
+		return
+	}
+
+	p.stdout, p.pipe = os.Stdout, writer
+	os.Stdout = writer
+	p.restore = logging.RedirectConsole(writer)
+
+	// No input, so Ctrl-C stays a signal for the interrupt handler to turn
+	// into cancellation of the running step
+	p.program = tea.NewProgram(NewStepsModel(p.title, p.names...),
+		tea.WithOutput(p.stdout), tea.WithInput(nil), tea.WithoutSignalHandler())
+	p.copied = make(chan struct{})
+	p.finished = make(chan struct{})
+
+	go func() {
+		defer close(p.copied)
+
+		scanner := bufio.NewScanner(reader)
+		scanner.Split(scanOutputLines)
+
+		for scanner.Scan() {
+			if scanner.Text() != stepMarker {
+				p.program.Send(StepOutputMsg{Line: scanner.Text()})
+				continue
+			}
+
+			p.mu.Lock()
+			msg := p.queued[0]
+			p.queued = p.queued[1:]
+			p.mu.Unlock()
+
+			p.program.Send(msg)
+		}
+
+		_ = reader.Close() //nolint:errcheck // the view is done with it
+	}()
+
+	go func() {
+		defer close(p.finished)
+
+		if _, err := p.program.Run(); err != nil {
+			logging.Debug("progress view stopped", "err", err)
+		}
+	}()
+}
+
+// Begin marks the named step running
+func (p *StepProgress) Begin(name string) {
+	if p.program == nil {
+		p.started[name] = time.Now()
+		return
+	}
+
+	p.send(StepStartMsg{Name: name})
+}
+
+// Finish marks the named step done, or failed when err is set
+func (p *StepProgress) Finish(name string, err error) {
+	if p.program == nil {
+		took := formatStepDuration(time.Since(p.started[name]))
+		if err != nil {
+			fmt.Fprintf(p.out, "✗ %s (%s): %v\n", name, took, err)
+			return
+		}
+
+		fmt.Fprintf(p.out, "✓ %s (%s)\n", name, took)
+
+		return
+	}
+
+	p.send(StepDoneMsg{Name: name, Err: err})
+}
+
+// Skip marks the named step skipped, saying why
+func (p *StepProgress) Skip(name, reason string) {
+	if p.program == nil {
+		fmt.Fprintf(p.out, "- %s: skipped, %s\n", name, reason)
+		return
+	}
+
+	p.send(StepSkipMsg{Name: name, Reason: reason})
+}
+
+// send queues msg behind the output written so far
+func (p *StepProgress) send(msg tea.Msg) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.stopped {
+		return
+	}
+
+	p.queued = append(p.queued, msg)
+	_, _ = p.pipe.WriteString("\n" + stepMarker + "\n") //nolint:errcheck // the pipe is open until Stop
+}
+
+// Stop leaves the steps on screen and gives stdout and stderr back. It is
+// safe to call more than once.
+func (p *StepProgress) Stop() {
+	p.stopOnce.Do(func() {
+		if p.program == nil {
+			return
+		}
+
+		// Give back stdout first so the last of the output reaches the view
+		os.Stdout = p.stdout
+		p.restore()
+
+		p.mu.Lock()
+		p.stopped = true
+		_ = p.pipe.Close() //nolint:errcheck // the reader sees EOF either way
+		p.mu.Unlock()
+		<-p.copied
+
+		p.program.Send(StepsDoneMsg{})
+		<-p.finished
+	})
+}
+
+// scanOutputLines splits output into lines at \n or \r, so progress bars
+// that redraw a line show their latest state
+func scanOutputLines(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	for i, c := range data {
+		if c == '\n' || c == '\r' {
+			return i + 1, data[:i], nil
+		}
+	}
+
+	if atEOF && len(data) > 0 {
+		return len(data), data, nil
+	}
+
+	return 0, nil, nil
+}
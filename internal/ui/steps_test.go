@@ -0,0 +1,104 @@
+package ui
+
+import (
+	"bufio"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func stepsUpdate(model StepsModel, msgs ...any) StepsModel {
+	for _, msg := range msgs {
+		updated, _ := model.Update(msg)
+		model = updated.(StepsModel) //nolint:errcheck // always a StepsModel
+	}
+
+	return model
+}
+
+func TestStepsModel(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	model := NewStepsModel("Creating feat", "Add worktree", "Install dependencies", "Run hooks", "Create tmux session")
+	model.now = func() time.Time { return now }
+
+	model = stepsUpdate(model, StepStartMsg{Name: "Add worktree"}, StepOutputMsg{Line: "Preparing worktree"})
+	now = now.Add(400 * time.Millisecond)
+	model = stepsUpdate(model, StepDoneMsg{Name: "Add worktree"}, StepSkipMsg{Name: "Install dependencies", Reason: "auto-install is off"})
+
+	model = stepsUpdate(model, StepStartMsg{Name: "Run hooks"},
+		StepOutputMsg{Line: "⚠ Continuing despite hook failure"}, StepOutputMsg{Line: "npm ERR! missing script"})
+	now = now.Add(2 * time.Second)
+	model = stepsUpdate(model, StepDoneMsg{Name: "Run hooks", Err: errors.New("hook post-worktree failed")},
+		StepOutputMsg{Line: "✓ Assigned issue 42 to you"})
+
+	states := map[string]StepState{
+		"Add worktree":         StepDone,
+		"Install dependencies": StepSkipped,
+		"Run hooks":            StepFailed,
+		"Create tmux session":  StepPending,
+	}
+	for name, want := range states {
+		if got := model.State(name); got != want {
+			t.Errorf("State(%q) = %v, want %v", name, got, want)
+		}
+	}
+
+	view := model.View()
+	for _, want := range []string{
+		"✓ Add worktree", "0.4s",
+		"skipped: auto-install is off",
+		"✗ Run hooks", "2.0s", "hook post-worktree failed", "npm ERR! missing script",
+		"· Create tmux session",
+		"✓ Assigned issue 42 to you",
+	} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view should contain %q:\n%s", want, view)
+		}
+	}
+
+	// Output of a step that succeeded is hidden, except its warnings
+	if strings.Contains(view, "Preparing worktree") {
+		t.Errorf("view should hide the output of a step that succeeded:\n%s", view)
+	}
+}
+
+func TestStepsModelWarnings(t *testing.T) {
+	model := NewStepsModel("Creating feat", "Scope to package")
+	model = stepsUpdate(model, StepStartMsg{Name: "Scope to package"},
+		StepOutputMsg{Line: "⚠ pkg/api is not a directory in this branch"}, StepOutputMsg{Line: "done"},
+		StepDoneMsg{Name: "Scope to package"})
+
+	if view := model.View(); !strings.Contains(view, "⚠ pkg/api is not a directory") || strings.Contains(view, "done") {
+		t.Errorf("view should keep only the warnings of a step that succeeded:\n%s", view)
+	}
+}
+
+func TestScanOutputLines(t *testing.T) {
+	scanner := bufio.NewScanner(strings.NewReader("one\ntwo\r50%\r100%\nlast"))
+	scanner.Split(scanOutputLines)
+
+	var lines []string
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+
+	if got, want := strings.Join(lines, "|"), "one|two|50%|100%|last"; got != want {
+		t.Errorf("lines = %q, want %q", got, want)
+	}
+}
+
+func TestFormatStepDuration(t *testing.T) {
+	tests := map[time.Duration]string{
+		400 * time.Millisecond: "0.4s",
+		12 * time.Second:       "12.0s",
+		65 * time.Second:       "1m05s",
+	}
+
+	for d, want := range tests {
+		if got := formatStepDuration(d); got != want {
+			t.Errorf("formatStepDuration(%v) = %q, want %q", d, got, want)
+		}
+	}
+}
@@ -2,32 +2,83 @@ package ui
 
 import "github.com/charmbracelet/lipgloss"
 
-// Common styles used across UI components
-// Updated to use gum color scheme (ANSI 1-6) from theme.go
+// Common styles used across UI components.
+// They are rebuilt from the active theme by ApplyTheme; see theme.go.
 var (
-	// Color palette - using gum color scheme
-	primaryColor = ColorCyan             // Cyan for highlights/primary actions
-	successColor = ColorGreen            // Green for success
-	errorColor   = ColorRed              // Red for errors
-	warningColor = ColorYellow           // Yellow for warnings
-	subtleColor  = lipgloss.Color("241") // Gray for subtle text
+	// Color palette, taken from the active theme
+	primaryColor lipgloss.TerminalColor // Highlights/primary actions
+	accentColor  lipgloss.TerminalColor // Selected items and titles
+	successColor lipgloss.TerminalColor
+	errorColor   lipgloss.TerminalColor
+	warningColor lipgloss.TerminalColor
+	infoColor    lipgloss.TerminalColor // Borders/info
+	subtleColor  lipgloss.TerminalColor // Subtle text
 
 	// Text styles
-	TitleStyle   = lipgloss.NewStyle().Bold(true).Foreground(primaryColor)
+	TitleStyle   lipgloss.Style
+	SuccessStyle lipgloss.Style
+	ErrorStyle   lipgloss.Style
+	WarningStyle lipgloss.Style
+	SubtleStyle  lipgloss.Style
+	BoldStyle    lipgloss.Style
+
+	// Layout styles
+	BoxStyle    lipgloss.Style
+	HeaderStyle lipgloss.Style
+)
+
+func init() {
+	ApplyTheme(DefaultTheme())
+}
+
+// applyStyles rebuilds every package style from the theme's colors
+func applyStyles(t Theme) {
+	primaryColor = t.Primary
+	accentColor = t.Accent
+	successColor = t.Success
+	errorColor = t.Error
+	warningColor = t.Warning
+	infoColor = t.Info
+	subtleColor = t.Subtle
+
+	TitleStyle = lipgloss.NewStyle().Bold(true).Foreground(primaryColor)
 	SuccessStyle = lipgloss.NewStyle().Foreground(successColor)
-	ErrorStyle   = lipgloss.NewStyle().Foreground(errorColor)
+	ErrorStyle = lipgloss.NewStyle().Foreground(errorColor)
 	WarningStyle = lipgloss.NewStyle().Foreground(warningColor)
-	SubtleStyle  = lipgloss.NewStyle().Foreground(subtleColor)
-	BoldStyle    = lipgloss.NewStyle().Bold(true)
+	SubtleStyle = lipgloss.NewStyle().Foreground(subtleColor)
+	BoldStyle = lipgloss.NewStyle().Bold(true)
 
-	// Layout styles
 	BoxStyle = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(ColorBlue). // Blue for borders/info
-			Padding(1, 2)
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(infoColor).
+		Padding(1, 2)
 
 	HeaderStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(primaryColor).
-			MarginBottom(1)
-)
+		Bold(true).
+		Foreground(primaryColor).
+		MarginBottom(1)
+
+	InfoStyle = lipgloss.NewStyle().Foreground(infoColor)
+	MergedStyle = lipgloss.NewStyle().Foreground(t.Merged)
+	HighlightStyle = lipgloss.NewStyle().Foreground(primaryColor)
+
+	ClosedWithWarningStyle = lipgloss.NewStyle().Foreground(warningColor).Bold(true)
+	ActiveWorktreeStyle = lipgloss.NewStyle().Foreground(primaryColor).Bold(true)
+	NoChangesStyle = lipgloss.NewStyle().Foreground(subtleColor)
+
+	SelectedItemStyle = lipgloss.NewStyle().Foreground(primaryColor).Bold(true)
+	UnselectedItemStyle = lipgloss.NewStyle()
+	HelpStyle = lipgloss.NewStyle().Foreground(subtleColor).Italic(true)
+
+	selectedItemStyle = lipgloss.NewStyle().PaddingLeft(2).Foreground(accentColor)
+
+	cleanupTitleStyle = lipgloss.NewStyle().Bold(true).Foreground(accentColor)
+	cleanupWarningStyle = lipgloss.NewStyle().Foreground(warningColor)
+	cleanupQuestionStyle = lipgloss.NewStyle().Bold(true)
+	cleanupHintStyle = lipgloss.NewStyle().Foreground(subtleColor)
+
+	kanbanColumnStyle = lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(subtleColor).
+		Padding(0, 1)
+}
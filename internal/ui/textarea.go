@@ -28,6 +28,12 @@ func NewTextArea(prompt, placeholder string) TextAreaModel {
 	}
 }
 
+// WithValue pre-fills the textarea with an initial value (e.g. an issue template).
+func (m TextAreaModel) WithValue(value string) TextAreaModel {
+	m.textarea.SetValue(value)
+	return m
+}
+
 // Init initializes the textarea model.
 func (m TextAreaModel) Init() tea.Cmd {
 	return textarea.Blink
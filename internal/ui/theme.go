@@ -1,6 +1,9 @@
 package ui
 
 import (
+	"fmt"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
@@ -17,31 +20,190 @@ const (
 	ColorCyan    = lipgloss.Color("6") // Highlights/prompts
 )
 
-// Additional semantic styles not in styles.go
+// Additional semantic styles not in styles.go, built from the active theme in applyStyles
 var (
-	InfoStyle      = lipgloss.NewStyle().Foreground(ColorBlue)
-	MergedStyle    = lipgloss.NewStyle().Foreground(ColorMagenta)
-	HighlightStyle = lipgloss.NewStyle().Foreground(ColorCyan)
+	InfoStyle      lipgloss.Style
+	MergedStyle    lipgloss.Style
+	HighlightStyle lipgloss.Style
 
 	// Status indicator styles
-	ClosedWithWarningStyle = lipgloss.NewStyle().Foreground(ColorYellow).Bold(true)
-	ActiveWorktreeStyle    = lipgloss.NewStyle().Foreground(ColorCyan).Bold(true)
-	NoChangesStyle         = lipgloss.NewStyle().Foreground(lipgloss.Color("8")) // Gray
+	ClosedWithWarningStyle lipgloss.Style
+	ActiveWorktreeStyle    lipgloss.Style
+	NoChangesStyle         lipgloss.Style
 
 	// List item styles
-	SelectedItemStyle = lipgloss.NewStyle().
-				Foreground(ColorCyan).
-				Bold(true)
-
-	UnselectedItemStyle = lipgloss.NewStyle()
+	SelectedItemStyle   lipgloss.Style
+	UnselectedItemStyle lipgloss.Style
 
 	// Help text style
-	HelpStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("8")).
-			Italic(true)
+	HelpStyle lipgloss.Style
 )
 
-// GetWorktreeAgeColor returns the appropriate color based on worktree age
+// Theme is a named color palette for every UI element
+type Theme struct {
+	Name    string
+	Primary lipgloss.TerminalColor // Titles, highlights, prompts
+	Accent  lipgloss.TerminalColor // Selected items
+	Success lipgloss.TerminalColor
+	Error   lipgloss.TerminalColor
+	Warning lipgloss.TerminalColor
+	Info    lipgloss.TerminalColor // Borders and info boxes
+	Merged  lipgloss.TerminalColor
+	Subtle  lipgloss.TerminalColor // Hints, help text, secondary details
+
+	// Worktree age gradient: recent (<1 day), aging (1-4 days), stale (>4 days)
+	AgeRecent lipgloss.Color
+	AgeAging  lipgloss.Color
+	AgeStale  lipgloss.Color
+}
+
+// Theme names accepted by the auto-worktree.theme setting
+const (
+	ThemeDefault      = "default"
+	ThemeDark         = "dark"
+	ThemeLight        = "light"
+	ThemeHighContrast = "high-contrast"
+	ThemeMono         = "mono"
+)
+
+// ThemeNames lists the built-in themes
+var ThemeNames = []string{ThemeDefault, ThemeDark, ThemeLight, ThemeHighContrast, ThemeMono}
+
+// activeTheme is the theme the package styles were last built from
+var activeTheme Theme
+
+// DefaultTheme uses the terminal's own ANSI palette, with grays that adapt to light backgrounds
+func DefaultTheme() Theme {
+	return Theme{
+		Name:      ThemeDefault,
+		Primary:   ColorCyan,
+		Accent:    lipgloss.AdaptiveColor{Light: "127", Dark: "170"},
+		Success:   ColorGreen,
+		Error:     ColorRed,
+		Warning:   ColorYellow,
+		Info:      ColorBlue,
+		Merged:    ColorMagenta,
+		Subtle:    lipgloss.AdaptiveColor{Light: "243", Dark: "241"},
+		AgeRecent: ColorGreen,
+		AgeAging:  ColorYellow,
+		AgeStale:  ColorRed,
+	}
+}
+
+// LookupTheme returns a built-in theme by name
+func LookupTheme(name string) (Theme, error) {
+	t := DefaultTheme()
+
+	switch name {
+	case "", ThemeDefault:
+		return t, nil
+	case ThemeDark:
+		t.Accent = lipgloss.Color("170")
+		t.Subtle = lipgloss.Color("241")
+	case ThemeLight:
+		// Darker 256-color shades that stay readable on white backgrounds
+		t.Primary = lipgloss.Color("25")
+		t.Accent = lipgloss.Color("127")
+		t.Success = lipgloss.Color("28")
+		t.Error = lipgloss.Color("124")
+		t.Warning = lipgloss.Color("130")
+		t.Info = lipgloss.Color("25")
+		t.Merged = lipgloss.Color("90")
+		t.Subtle = lipgloss.Color("243")
+		t.AgeRecent, t.AgeAging, t.AgeStale = "28", "130", "124"
+	case ThemeHighContrast:
+		t.Primary = lipgloss.Color("14")
+		t.Accent = lipgloss.Color("13")
+		t.Success = lipgloss.Color("10")
+		t.Error = lipgloss.Color("9")
+		t.Warning = lipgloss.Color("11")
+		t.Info = lipgloss.Color("12")
+		t.Merged = lipgloss.Color("13")
+		t.Subtle = lipgloss.AdaptiveColor{Light: "0", Dark: "15"}
+		t.AgeRecent, t.AgeAging, t.AgeStale = "10", "11", "9"
+	case ThemeMono:
+		none := lipgloss.NoColor{}
+		t.Primary, t.Accent, t.Success, t.Error, t.Warning, t.Info, t.Merged, t.Subtle = none, none, none, none, none, none, none, none
+		t.AgeRecent, t.AgeAging, t.AgeStale = "", "", ""
+	default:
+		return t, fmt.Errorf("unknown theme %q (must be one of: %s)", name, strings.Join(ThemeNames, ", "))
+	}
+
+	t.Name = name
+
+	return t, nil
+}
+
+// WithOverrides returns the theme with individual colors replaced.
+// spec is a comma-separated list of element=color pairs, e.g. "success=#00aa00,subtle=245",
+// where color is an ANSI number (0-255) or a #rrggbb hex value.
+func (t Theme) WithOverrides(spec string) (Theme, error) {
+	for _, pair := range strings.Split(spec, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		element, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return t, fmt.Errorf("invalid color override %q (want element=color)", pair)
+		}
+
+		element = strings.ToLower(strings.TrimSpace(element))
+		value = strings.TrimSpace(value)
+
+		if !colorPattern.MatchString(value) {
+			return t, fmt.Errorf("invalid color %q for %s (want 0-255 or #rrggbb)", value, element)
+		}
+
+		color := lipgloss.Color(value)
+
+		switch element {
+		case "primary":
+			t.Primary = color
+		case "accent", "selected":
+			t.Accent = color
+		case "success":
+			t.Success = color
+		case "error":
+			t.Error = color
+		case "warning":
+			t.Warning = color
+		case "info":
+			t.Info = color
+		case "merged":
+			t.Merged = color
+		case "subtle":
+			t.Subtle = color
+		case "age-recent":
+			t.AgeRecent = color
+		case "age-aging":
+			t.AgeAging = color
+		case "age-stale":
+			t.AgeStale = color
+		default:
+			return t, fmt.Errorf("unknown theme element %q", element)
+		}
+	}
+
+	return t, nil
+}
+
+// colorPattern matches the color values accepted in overrides
+var colorPattern = regexp.MustCompile(`^(#[0-9a-fA-F]{6}|[0-9]{1,3})$`)
+
+// ApplyTheme rebuilds every UI style from the theme
+func ApplyTheme(t Theme) {
+	activeTheme = t
+	applyStyles(t)
+}
+
+// CurrentTheme returns the active theme
+func CurrentTheme() Theme {
+	return activeTheme
+}
+
+// GetWorktreeAgeColor returns the active theme's color for a worktree age
 // Matches the shell script logic:
 // - Red: >4 days (stale)
 // - Yellow: 1-4 days
@@ -51,11 +213,11 @@ func GetWorktreeAgeColor(age time.Duration) lipgloss.Color {
 
 	switch {
 	case days > 4:
-		return ColorRed
+		return activeTheme.AgeStale
 	case days >= 1:
-		return ColorYellow
+		return activeTheme.AgeAging
 	default:
-		return ColorGreen
+		return activeTheme.AgeRecent
 	}
 }
 
@@ -5,6 +5,8 @@ import (
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
+
+	"github.com/kaeawc/auto-worktree/internal/git"
 )
 
 func TestGetWorktreeAgeColor(t *testing.T) {
@@ -65,3 +67,56 @@ func TestGetWorktreeAgeStyle(t *testing.T) {
 		t.Errorf("GetWorktreeAgeStyle(%v) foreground = %v, want %v", age, style.GetForeground(), expectedColor)
 	}
 }
+
+func TestLookupTheme(t *testing.T) {
+	for _, name := range ThemeNames {
+		theme, err := LookupTheme(name)
+		if err != nil {
+			t.Errorf("LookupTheme(%q) error = %v", name, err)
+		}
+
+		if theme.Name != name {
+			t.Errorf("LookupTheme(%q).Name = %q", name, theme.Name)
+		}
+	}
+
+	if _, err := LookupTheme("solarized"); err == nil {
+		t.Error("LookupTheme() should reject unknown themes")
+	}
+
+	if len(ThemeNames) != len(git.ValidThemes) {
+		t.Errorf("ThemeNames %v and git.ValidThemes %v are out of sync", ThemeNames, git.ValidThemes)
+	}
+}
+
+func TestThemeWithOverrides(t *testing.T) {
+	theme, err := DefaultTheme().WithOverrides("success=#00aa00, Subtle=245,age-stale=160")
+	if err != nil {
+		t.Fatalf("WithOverrides() error = %v", err)
+	}
+
+	if theme.Success != lipgloss.Color("#00aa00") || theme.Subtle != lipgloss.Color("245") || theme.AgeStale != "160" {
+		t.Errorf("WithOverrides() = %+v", theme)
+	}
+
+	for _, spec := range []string{"success", "nope=1", "error=red", "info=#12345"} {
+		if _, err := DefaultTheme().WithOverrides(spec); err == nil {
+			t.Errorf("WithOverrides(%q) should fail", spec)
+		}
+	}
+}
+
+func TestApplyThemeUpdatesAgeColors(t *testing.T) {
+	defer ApplyTheme(DefaultTheme())
+
+	light, _ := LookupTheme(ThemeLight)
+	ApplyTheme(light)
+
+	if got := GetWorktreeAgeColor(10 * 24 * time.Hour); got != light.AgeStale {
+		t.Errorf("GetWorktreeAgeColor() = %v, want the light theme's stale color %v", got, light.AgeStale)
+	}
+
+	if CurrentTheme().Name != ThemeLight {
+		t.Errorf("CurrentTheme() = %q, want light", CurrentTheme().Name)
+	}
+}
@@ -0,0 +1,245 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kaeawc/auto-worktree/internal/git"
+)
+
+// defaultTidyRows is how many commits are shown before the terminal size is known
+const defaultTidyRows = 20
+
+// Keys that set a commit's action in the tidy screen
+var (
+	tidyPickKey   = key.NewBinding(key.WithKeys("p"), key.WithHelp("p", "pick"))
+	tidySquashKey = key.NewBinding(key.WithKeys("s"), key.WithHelp("s", "squash"))
+	tidyFixupKey  = key.NewBinding(key.WithKeys("f"), key.WithHelp("f", "fixup"))
+	tidyRewordKey = key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "reword"))
+	tidyDropKey   = key.NewBinding(key.WithKeys("d"), key.WithHelp("d", "drop"))
+	tidyAllKey    = key.NewBinding(key.WithKeys("a"), key.WithHelp("a", "fixup all into the first"))
+)
+
+// TidyModel lists a branch's unpushed commits, oldest first, for choosing what
+// to squash, fix up, reword or drop before pushing
+type TidyModel struct {
+	branch   string
+	steps    []git.TidyStep
+	cursor   int
+	height   int
+	keys     KeyMap
+	editing  bool
+	input    textinput.Model
+	message  string
+	applied  bool
+	canceled bool
+}
+
+// NewTidy creates the tidy screen with every commit picked
+func NewTidy(branch string, commits []git.TidyCommit) TidyModel {
+	steps := make([]git.TidyStep, len(commits))
+	for i, commit := range commits {
+		steps[i] = git.TidyStep{Commit: commit, Action: git.TidyPick}
+	}
+
+	input := textinput.New()
+	input.Width = 60
+
+	return TidyModel{branch: branch, steps: steps, keys: activeKeys, input: input}
+}
+
+// Init initializes the tidy screen
+func (m TidyModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles key presses in the tidy screen
+func (m TidyModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.height = msg.Height
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.editing {
+			return m.updateReword(msg)
+		}
+
+		m.message = ""
+
+		switch {
+		case m.keys.isCancel(msg):
+			m.canceled = true
+			return m, tea.Quit
+		case key.Matches(msg, m.keys.Select):
+			if err := git.CheckTidyPlan(m.steps); err != nil {
+				m.message = err.Error()
+				return m, nil
+			}
+
+			m.applied = true
+
+			return m, tea.Quit
+		case key.Matches(msg, m.keys.Up):
+			m.cursor = max(m.cursor-1, 0)
+		case key.Matches(msg, m.keys.Down):
+			m.cursor = min(m.cursor+1, len(m.steps)-1)
+		case key.Matches(msg, tidyPickKey):
+			m.setAction(git.TidyPick)
+		case key.Matches(msg, tidySquashKey):
+			m.setAction(git.TidySquash)
+		case key.Matches(msg, tidyFixupKey):
+			m.setAction(git.TidyFixup)
+		case key.Matches(msg, tidyDropKey):
+			m.setAction(git.TidyDrop)
+		case key.Matches(msg, tidyAllKey):
+			for i := 1; i < len(m.steps); i++ {
+				m.steps[i].Action = git.TidyFixup
+			}
+		case key.Matches(msg, tidyRewordKey):
+			m.editing = true
+			m.input.SetValue(m.steps[m.cursor].Commit.Subject)
+			m.input.Focus()
+
+			return m, textinput.Blink
+		}
+	}
+
+	return m, nil
+}
+
+// updateReword edits the new message of the commit under the cursor
+func (m TidyModel) updateReword(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEnter:
+		m.editing = false
+		m.input.Blur()
+
+		if message := strings.TrimSpace(m.input.Value()); message != "" && message != m.steps[m.cursor].Commit.Subject {
+			m.steps[m.cursor].Action = git.TidyReword
+			m.steps[m.cursor].Message = message
+		}
+
+		return m, nil
+	case tea.KeyEsc, tea.KeyCtrlC:
+		m.editing = false
+		m.input.Blur()
+
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+
+	return m, cmd
+}
+
+// setAction sets the action of the commit under the cursor. The oldest commit
+// has nothing before it to be squashed or fixed up into.
+func (m *TidyModel) setAction(action git.TidyAction) {
+	if m.cursor == 0 && (action == git.TidySquash || action == git.TidyFixup) {
+		m.message = fmt.Sprintf("The oldest commit has nothing above it to %s into", action)
+		return
+	}
+
+	m.steps[m.cursor].Action = action
+	m.steps[m.cursor].Message = ""
+}
+
+// View renders the commits with their actions
+func (m TidyModel) View() string {
+	if m.applied || m.canceled {
+		return ""
+	}
+
+	var s strings.Builder
+
+	s.WriteString(HeaderStyle.Render(fmt.Sprintf("Tidy %s: %d commits → %d", m.branch, len(m.steps), TidyCommitCount(m.steps))))
+	s.WriteString("\n")
+	s.WriteString(SubtleStyle.Render("Oldest first; squash and fixup meld a commit into the one above"))
+	s.WriteString("\n\n")
+
+	rows := defaultTidyRows
+	if m.height > 0 {
+		rows = max(m.height-10, 3)
+	}
+
+	start := min(max(m.cursor-rows/2, 0), max(len(m.steps)-rows, 0))
+	end := min(start+rows, len(m.steps))
+
+	for i := start; i < end; i++ {
+		step := m.steps[i]
+		hash := step.Commit.Hash
+		if len(hash) > 7 {
+			hash = hash[:7]
+		}
+
+		line := fmt.Sprintf("%-6s %s %s", step.Action, hash, step.Commit.Subject)
+
+		switch step.Action {
+		case git.TidyDrop:
+			line = ErrorStyle.Render(line)
+		case git.TidySquash, git.TidyFixup:
+			line = SubtleStyle.Render(line)
+		case git.TidyReword:
+			line = WarningStyle.Render(fmt.Sprintf("%-6s %s %s", step.Action, hash, step.Message))
+		}
+
+		if i == m.cursor {
+			s.WriteString(selectedItemStyle.UnsetPaddingLeft().Render("► ") + line)
+		} else {
+			s.WriteString("  " + line)
+		}
+
+		s.WriteString("\n")
+	}
+
+	if end-start < len(m.steps) {
+		s.WriteString(SubtleStyle.Render(fmt.Sprintf("  (%d-%d of %d)", start+1, end, len(m.steps))))
+		s.WriteString("\n")
+	}
+
+	s.WriteString("\n")
+
+	switch {
+	case m.editing:
+		s.WriteString("New message: " + m.input.View() + "\n")
+		s.WriteString(SubtleStyle.Render("(press Enter to keep it, Esc to cancel)"))
+	case m.message != "":
+		s.WriteString(WarningStyle.Render(m.message) + "\n")
+		fallthrough
+	default:
+		s.WriteString(SubtleStyle.Render(helpFooter(tidyPickKey, tidySquashKey, tidyFixupKey, tidyRewordKey, tidyDropKey, tidyAllKey)))
+		s.WriteString("\n")
+		s.WriteString(SubtleStyle.Render(helpFooter(m.keys.Up, m.keys.Down, key.NewBinding(key.WithHelp(m.keys.Select.Help().Key, "apply")), m.keys.Cancel)))
+	}
+
+	return BoxStyle.Render(s.String())
+}
+
+// Steps returns the plan, in commit order
+func (m TidyModel) Steps() []git.TidyStep {
+	return m.steps
+}
+
+// Applied reports whether the user chose to apply the plan
+func (m TidyModel) Applied() bool {
+	return m.applied
+}
+
+// TidyCommitCount returns how many commits a plan leaves
+func TidyCommitCount(steps []git.TidyStep) int {
+	count := 0
+
+	for _, step := range steps {
+		if step.Action == git.TidyPick || step.Action == git.TidyReword {
+			count++
+		}
+	}
+
+	return count
+}
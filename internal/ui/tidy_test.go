@@ -0,0 +1,64 @@
+package ui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/kaeawc/auto-worktree/internal/git"
+)
+
+func tidyKey(model TidyModel, keys ...string) TidyModel {
+	for _, k := range keys {
+		var msg tea.KeyMsg
+
+		switch k {
+		case "down":
+			msg = tea.KeyMsg{Type: tea.KeyDown}
+		case "enter":
+			msg = tea.KeyMsg{Type: tea.KeyEnter}
+		default:
+			msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+		}
+
+		updated, _ := model.Update(msg)
+		model = updated.(TidyModel) //nolint:errcheck // always a TidyModel
+	}
+
+	return model
+}
+
+func TestTidyModel(t *testing.T) {
+	commits := []git.TidyCommit{{Hash: "aaa", Subject: "start"}, {Hash: "bbb", Subject: "wip"}, {Hash: "ccc", Subject: "debug"}}
+
+	model := tidyKey(NewTidy("fix", commits), "f")
+	if model.Steps()[0].Action != git.TidyPick {
+		t.Errorf("the oldest commit was set to %s, want it kept a pick", model.Steps()[0].Action)
+	}
+
+	model = tidyKey(model, "down", "s", "down", "d")
+	if got := TidyCommitCount(model.Steps()); got != 1 {
+		t.Errorf("TidyCommitCount() = %d, want 1", got)
+	}
+
+	model = tidyKey(model, "a")
+	for _, step := range model.Steps()[1:] {
+		if step.Action != git.TidyFixup {
+			t.Errorf("after fixup all, %s is %s", step.Commit.Hash, step.Action)
+		}
+	}
+
+	model = tidyKey(model, "enter")
+	if !model.Applied() {
+		t.Error("Applied() = false after enter")
+	}
+}
+
+func TestTidyModelRefusesBadPlan(t *testing.T) {
+	commits := []git.TidyCommit{{Hash: "aaa", Subject: "start"}, {Hash: "bbb", Subject: "wip"}}
+
+	model := tidyKey(NewTidy("fix", commits), "d", "down", "f", "enter")
+	if model.Applied() {
+		t.Error("a fixup with nothing kept before it was applied")
+	}
+}
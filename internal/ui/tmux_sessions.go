@@ -5,6 +5,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
 
@@ -14,6 +15,8 @@ import (
 // SessionListItem represents a session in the sessions list
 type SessionListItem struct {
 	metadata *session.Metadata
+	// usage is the session's current CPU and memory use, when it can be measured
+	usage string
 }
 
 // NewSessionListItem creates a new session list item
@@ -23,6 +26,12 @@ func NewSessionListItem(metadata *session.Metadata) SessionListItem {
 	}
 }
 
+// WithUsage returns the item showing the session's current resource usage
+func (i SessionListItem) WithUsage(usage string) SessionListItem {
+	i.usage = usage
+	return i
+}
+
 // Title returns the display title for the session
 func (i SessionListItem) Title() string {
 	statusIcon := statusIcon(i.metadata.Status)
@@ -43,6 +52,27 @@ func (i SessionListItem) Description() string {
 		details = append(details, fmt.Sprintf("Windows: %d", i.metadata.WindowCount))
 	}
 
+	if i.metadata.ContainerID != "" {
+		details = append(details, fmt.Sprintf("Sandbox: %s %.12s", i.metadata.ContainerRuntime, i.metadata.ContainerID))
+	}
+
+	if r := i.metadata.Resources; r != nil {
+		var limits []string
+		if r.CPUs != "" {
+			limits = append(limits, r.CPUs+" CPUs")
+		}
+
+		if r.Memory != "" {
+			limits = append(limits, r.Memory)
+		}
+
+		details = append(details, "Limit: "+strings.Join(limits, ", "))
+	}
+
+	if i.usage != "" {
+		details = append(details, "Usage: "+i.usage)
+	}
+
 	if i.metadata.Dependencies.Installed {
 		details = append(details, fmt.Sprintf("Deps: %s", i.metadata.Dependencies.PackageManager))
 	}
@@ -63,6 +93,7 @@ func (i SessionListItem) Metadata() *session.Metadata {
 // SessionListModel represents the sessions list UI component
 type SessionListModel struct {
 	list      list.Model
+	keys      KeyMap
 	items     []SessionListItem
 	choice    *SessionListItem
 	err       error
@@ -83,9 +114,11 @@ func NewSessionList(title string, items []SessionListItem) SessionListModel {
 	l.SetShowStatusBar(true)
 	l.SetFilteringEnabled(true)
 	l.Styles.Title = HeaderStyle
+	activeKeys.applyToList(&l)
 
 	return SessionListModel{
 		list:      l,
+		keys:      activeKeys,
 		items:     items,
 		filtering: false,
 	}
@@ -106,8 +139,8 @@ func (m SessionListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "ctrl+c", "q", "esc":
+		switch {
+		case m.keys.isCancel(msg):
 			if m.filtering {
 				// Exit filter mode
 				m.filtering = false
@@ -121,7 +154,7 @@ func (m SessionListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 			return m, tea.Quit
 
-		case "enter":
+		case key.Matches(msg, m.keys.Select):
 			// Select current item
 			selectedItem := m.list.SelectedItem()
 			if selectedItem != nil {
@@ -134,7 +167,7 @@ func (m SessionListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 			return m, nil
 
-		case "/":
+		case key.Matches(msg, m.keys.Filter):
 			// Toggle filter mode
 			m.filtering = !m.filtering
 			m.list.SetShowFilter(m.filtering)
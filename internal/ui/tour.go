@@ -0,0 +1,86 @@
+package ui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// TourStepModel presents a single step of the onboarding tour
+type TourStepModel struct {
+	index       int
+	total       int
+	title       string
+	explanation string
+	command     string
+	proceed     bool
+	quitting    bool
+}
+
+// NewTourStepModel creates a tour step view.
+// index is zero-based; command is the equivalent CLI invocation shown to the user.
+func NewTourStepModel(index, total int, title, explanation, command string) TourStepModel {
+	return TourStepModel{
+		index:       index,
+		total:       total,
+		title:       title,
+		explanation: explanation,
+		command:     command,
+	}
+}
+
+// Init initializes the tour step.
+func (m TourStepModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles user input for the tour step.
+func (m TourStepModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		switch keyMsg.String() {
+		case keyEnter, " ":
+			m.proceed = true
+			m.quitting = true
+
+			return m, tea.Quit
+
+		case keyCtrlC, "q", keyEsc:
+			m.quitting = true
+
+			return m, tea.Quit
+		}
+	}
+
+	return m, nil
+}
+
+// View renders the tour step.
+func (m TourStepModel) View() string {
+	if m.quitting {
+		return ""
+	}
+
+	progress := SubtleStyle.Render(fmt.Sprintf("Step %d of %d", m.index+1, m.total))
+
+	parts := []string{
+		progress,
+		"",
+		TitleStyle.Render(m.title),
+		"",
+		m.explanation,
+	}
+
+	if m.command != "" {
+		parts = append(parts, "", SubtleStyle.Render("Equivalent command:"), HighlightStyle.Render("  $ "+m.command))
+	}
+
+	parts = append(parts, "", HelpStyle.Render("enter: run this step • q: quit the tour"))
+
+	return BoxStyle.Render(lipgloss.JoinVertical(lipgloss.Left, parts...))
+}
+
+// Proceed returns true if the user chose to run the step
+func (m TourStepModel) Proceed() bool {
+	return m.proceed
+}
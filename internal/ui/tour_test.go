@@ -0,0 +1,51 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestTourStepModelProceed(t *testing.T) {
+	tests := []struct {
+		name        string
+		key         tea.KeyMsg
+		wantProceed bool
+	}{
+		{name: "enter runs step", key: tea.KeyMsg{Type: tea.KeyEnter}, wantProceed: true},
+		{name: "q quits", key: tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")}, wantProceed: false},
+		{name: "esc quits", key: tea.KeyMsg{Type: tea.KeyEsc}, wantProceed: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model := NewTourStepModel(0, 4, "Create a worktree", "explanation", "auto-worktree new demo")
+
+			updated, cmd := model.Update(tt.key)
+			if cmd == nil {
+				t.Fatal("expected quit command")
+			}
+
+			step, ok := updated.(TourStepModel)
+			if !ok {
+				t.Fatalf("unexpected model type %T", updated)
+			}
+
+			if step.Proceed() != tt.wantProceed {
+				t.Errorf("Proceed() = %v, want %v", step.Proceed(), tt.wantProceed)
+			}
+		})
+	}
+}
+
+func TestTourStepModelView(t *testing.T) {
+	model := NewTourStepModel(1, 4, "Run the agent", "The agent edits files.", "auto-worktree resume")
+	view := model.View()
+
+	for _, want := range []string{"Step 2 of 4", "Run the agent", "auto-worktree resume"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("View() missing %q", want)
+		}
+	}
+}
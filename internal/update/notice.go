@@ -0,0 +1,55 @@
+package update
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kaeawc/auto-worktree/internal/state"
+)
+
+// CheckInterval is how long a cached update check stays fresh
+const CheckInterval = 24 * time.Hour
+
+// cacheKey is the key in state.BucketCache holding the last update check
+const cacheKey = "latest-release"
+
+// cachedCheck is the result of the last update check
+type cachedCheck struct {
+	Version   string    `json:"version"`
+	CheckedAt time.Time `json:"checkedAt"`
+}
+
+// CachedNotice returns a "new version available" message from the last
+// update check, or "" if the current version is up to date. It never touches
+// the network; stale reports whether the cache should be refreshed.
+func CachedNotice(store *state.Store, current string, now time.Time) (notice string, stale bool) {
+	var check cachedCheck
+	if err := store.Get(state.BucketCache, cacheKey, &check); err != nil {
+		return "", errors.Is(err, state.ErrNotFound)
+	}
+
+	stale = now.Sub(check.CheckedAt) > CheckInterval
+
+	if IsNewer(check.Version, current) {
+		notice = fmt.Sprintf("v%s available: run 'auto-worktree update'", check.Version)
+	}
+
+	return notice, stale
+}
+
+// Refresh checks for the latest release and caches the result for CachedNotice
+func Refresh(ctx context.Context, store *state.Store, client *Client) error {
+	release, err := client.Latest(ctx)
+	if err != nil {
+		return err
+	}
+
+	return Record(store, release, time.Now())
+}
+
+// Record caches release as the latest known release
+func Record(store *state.Store, release *Release, now time.Time) error {
+	return store.Put(state.BucketCache, cacheKey, cachedCheck{Version: release.Version(), CheckedAt: now})
+}
@@ -0,0 +1,309 @@
+// Package update checks GitHub releases for newer builds of auto-worktree and
+// replaces the running binary after verifying the release checksum.
+package update
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Repository is the GitHub repository releases are published to
+const Repository = "kaeawc/auto-worktree"
+
+// ChecksumsAsset is the release asset listing the SHA-256 of every binary, in sha256sum format
+const ChecksumsAsset = "checksums.txt"
+
+// maxBinarySize guards against downloading something that cannot be our binary
+const maxBinarySize = 200 << 20
+
+// ErrNoAsset is returned when a release has no binary for this platform
+var ErrNoAsset = errors.New("release has no binary for this platform")
+
+// ErrNoChecksums is returned when a release has no checksums to verify its binary against
+var ErrNoChecksums = errors.New("refusing to install an unverified binary")
+
+// Asset is a downloadable file attached to a release
+type Asset struct {
+	Name string `json:"name"`
+	URL  string `json:"browser_download_url"`
+}
+
+// Release is a published GitHub release
+type Release struct {
+	Tag    string  `json:"tag_name"`
+	URL    string  `json:"html_url"`
+	Assets []Asset `json:"assets"`
+}
+
+// Version returns the release tag without its leading "v"
+func (r *Release) Version() string {
+	return strings.TrimPrefix(r.Tag, "v")
+}
+
+// asset returns the named asset, if the release has it
+func (r *Release) asset(name string) (Asset, bool) {
+	for _, a := range r.Assets {
+		if a.Name == name {
+			return a, true
+		}
+	}
+
+	return Asset{}, false
+}
+
+// Client talks to the GitHub releases API
+type Client struct {
+	// BaseURL is the API root, replaced in tests
+	BaseURL string
+	HTTP    *http.Client
+}
+
+// NewClient creates a client for the public GitHub API
+func NewClient() *Client {
+	return &Client{
+		BaseURL: "https://api.github.com",
+		HTTP:    &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Latest returns the most recent published release
+func (c *Client) Latest(ctx context.Context) (*Release, error) {
+	url := fmt.Sprintf("%s/repos/%s/releases/latest", c.BaseURL, Repository)
+
+	body, err := c.get(ctx, url, 1<<20)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check for updates: %w", err)
+	}
+
+	var release Release
+	if err := json.Unmarshal(body, &release); err != nil {
+		return nil, fmt.Errorf("failed to parse release: %w", err)
+	}
+
+	if release.Tag == "" {
+		return nil, fmt.Errorf("release has no tag")
+	}
+
+	return &release, nil
+}
+
+// get fetches url, refusing bodies larger than limit
+func (c *Client) get(ctx context.Context, url string, limit int64) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close() //nolint:errcheck // read-only response body
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: %s", url, resp.Status)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, limit+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if int64(len(body)) > limit {
+		return nil, fmt.Errorf("GET %s: response larger than %d bytes", url, limit)
+	}
+
+	return body, nil
+}
+
+// AssetName returns the release binary name for a platform, matching `make release`
+func AssetName(goos, goarch string) string {
+	name := fmt.Sprintf("auto-worktree-%s-%s", goos, goarch)
+	if goos == "windows" {
+		name += ".exe"
+	}
+
+	return name
+}
+
+// Download fetches this platform's binary from the release and verifies it
+// against the release checksums. The binary is only returned if it matches.
+func (c *Client) Download(ctx context.Context, release *Release) ([]byte, error) {
+	name := AssetName(runtime.GOOS, runtime.GOARCH)
+
+	binary, ok := release.asset(name)
+	if !ok {
+		return nil, fmt.Errorf("%w (%s)", ErrNoAsset, name)
+	}
+
+	sums, ok := release.asset(ChecksumsAsset)
+	if !ok {
+		return nil, fmt.Errorf("%w: release %s has no %s asset, so %s cannot be verified; "+
+			"download it from %s and check it yourself, or ask the maintainers to attach the %s built by 'make release'",
+			ErrNoChecksums, release.Tag, ChecksumsAsset, name, release.URL, ChecksumsAsset)
+	}
+
+	sumData, err := c.get(ctx, sums.URL, 1<<20)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download checksums: %w", err)
+	}
+
+	want, err := checksumFor(sumData, name)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := c.get(ctx, binary.URL, maxBinarySize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", name, err)
+	}
+
+	sum := sha256.Sum256(data)
+	if got := hex.EncodeToString(sum[:]); !strings.EqualFold(got, want) {
+		return nil, fmt.Errorf("checksum mismatch for %s: got %s, want %s", name, got, want)
+	}
+
+	return data, nil
+}
+
+// checksumFor finds name in sha256sum-formatted data ("<hex>  <name>" per line)
+func checksumFor(data []byte, name string) (string, error) {
+	scanner := bufio.NewScanner(strings.NewReader(string(data)))
+
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && strings.TrimPrefix(fields[1], "*") == name {
+			if len(fields[0]) != sha256.Size*2 {
+				return "", fmt.Errorf("malformed checksum for %s", name)
+			}
+
+			return fields[0], nil
+		}
+	}
+
+	return "", fmt.Errorf("no checksum listed for %s", name)
+}
+
+// Replace atomically swaps the binary at path for data, keeping its permissions
+func Replace(path string, data []byte) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("failed to stat %s: %w", path, err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".auto-worktree-update-*")
+	if err != nil {
+		return fmt.Errorf("cannot write next to %s (try running with sudo): %w", path, err)
+	}
+
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath) //nolint:errcheck // already renamed on success
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close() //nolint:errcheck,gosec // write error takes precedence
+		return fmt.Errorf("failed to write new binary: %w", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write new binary: %w", err)
+	}
+
+	if err := os.Chmod(tmpPath, info.Mode().Perm()); err != nil {
+		return fmt.Errorf("failed to set permissions: %w", err)
+	}
+
+	// Windows cannot replace a running executable, but it can rename it
+	if runtime.GOOS == "windows" {
+		old := path + ".old"
+		_ = os.Remove(old) //nolint:errcheck // leftover from a previous update
+
+		if err := os.Rename(path, old); err != nil {
+			return fmt.Errorf("failed to move current binary aside: %w", err)
+		}
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("failed to replace %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// IsNewer reports whether version a is newer than b. Versions are dotted
+// numbers with an optional "v" prefix; a pre-release ("-dev", "-rc1") sorts
+// before the release itself.
+func IsNewer(a, b string) bool {
+	return compareVersions(a, b) > 0
+}
+
+func compareVersions(a, b string) int {
+	aCore, aPre, _ := strings.Cut(strings.TrimPrefix(a, "v"), "-")
+	bCore, bPre, _ := strings.Cut(strings.TrimPrefix(b, "v"), "-")
+
+	aParts := strings.Split(aCore, ".")
+	bParts := strings.Split(bCore, ".")
+
+	for i := 0; i < max(len(aParts), len(bParts)); i++ {
+		if d := versionPart(aParts, i) - versionPart(bParts, i); d != 0 {
+			return d
+		}
+	}
+
+	switch {
+	case aPre == bPre:
+		return 0
+	case aPre == "":
+		return 1
+	case bPre == "":
+		return -1
+	default:
+		return strings.Compare(aPre, bPre)
+	}
+}
+
+// versionPart returns the numeric component at i, treating missing or invalid parts as 0
+func versionPart(parts []string, i int) int {
+	if i >= len(parts) {
+		return 0
+	}
+
+	n, err := strconv.Atoi(parts[i])
+	if err != nil {
+		return 0
+	}
+
+	return n
+}
+
+// ManagedInstall returns the package manager that owns the binary at path,
+// or "" for a plain binary install that can update itself
+func ManagedInstall(path string) string {
+	resolved, err := filepath.EvalSymlinks(path)
+	if err == nil {
+		path = resolved
+	}
+
+	switch {
+	case strings.Contains(path, "/Cellar/") || strings.Contains(path, "/homebrew/"):
+		return "brew upgrade auto-worktree"
+	case strings.Contains(path, "/nix/store/"):
+		return "your Nix configuration"
+	default:
+		return ""
+	}
+}
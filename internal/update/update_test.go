@@ -0,0 +1,209 @@
+package update
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kaeawc/auto-worktree/internal/state"
+)
+
+func TestIsNewer(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"0.2.0", "0.1.0", true},
+		{"v0.2.0", "0.1.9", true},
+		{"0.10.0", "0.9.0", true},
+		{"1.0.0", "1.0.0", false},
+		{"0.1.0", "0.2.0", false},
+		{"0.1.0", "0.1.0-dev", true},
+		{"0.1.0-dev", "0.1.0", false},
+		{"1.0", "1.0.0", false},
+		{"1.0.1", "1.0", true},
+	}
+
+	for _, tt := range tests {
+		if got := IsNewer(tt.a, tt.b); got != tt.want {
+			t.Errorf("IsNewer(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestAssetName(t *testing.T) {
+	if got := AssetName("linux", "amd64"); got != "auto-worktree-linux-amd64" {
+		t.Errorf("AssetName(linux, amd64) = %q", got)
+	}
+
+	if got := AssetName("windows", "amd64"); got != "auto-worktree-windows-amd64.exe" {
+		t.Errorf("AssetName(windows, amd64) = %q", got)
+	}
+}
+
+func TestChecksumFor(t *testing.T) {
+	sum := strings.Repeat("ab", sha256.Size)
+	data := []byte(sum + "  auto-worktree-linux-amd64\n" + sum + " *auto-worktree-windows-amd64.exe\n")
+
+	got, err := checksumFor(data, "auto-worktree-windows-amd64.exe")
+	if err != nil || got != sum {
+		t.Errorf("checksumFor() = %q, %v; want %q", got, err, sum)
+	}
+
+	if _, err := checksumFor(data, "auto-worktree-darwin-arm64"); err == nil {
+		t.Error("checksumFor() for a missing asset: expected an error")
+	}
+
+	if _, err := checksumFor([]byte("abc  auto-worktree-linux-amd64\n"), "auto-worktree-linux-amd64"); err == nil {
+		t.Error("checksumFor() for a malformed checksum: expected an error")
+	}
+}
+
+// releaseServer serves a release whose checksums.txt lists listedSum for this platform's binary
+func releaseServer(t *testing.T, binary []byte, listedSum string, withChecksums bool) (*Client, *Release) {
+	t.Helper()
+
+	name := AssetName(runtime.GOOS, runtime.GOARCH)
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	mux.HandleFunc("/bin", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(binary)
+	})
+	mux.HandleFunc("/sums", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = fmt.Fprintf(w, "%s  %s\n", listedSum, name)
+	})
+
+	assets := fmt.Sprintf(`{"name": %q, "browser_download_url": %q}`, name, server.URL+"/bin")
+	if withChecksums {
+		assets += fmt.Sprintf(`, {"name": %q, "browser_download_url": %q}`, ChecksumsAsset, server.URL+"/sums")
+	}
+
+	mux.HandleFunc("/repos/"+Repository+"/releases/latest", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = fmt.Fprintf(w, `{"tag_name": "v9.9.9", "html_url": "https://example.com", "assets": [%s]}`, assets)
+	})
+
+	client := &Client{BaseURL: server.URL, HTTP: server.Client()}
+
+	release, err := client.Latest(context.Background())
+	if err != nil {
+		t.Fatalf("Latest() error = %v", err)
+	}
+
+	return client, release
+}
+
+func TestDownload(t *testing.T) {
+	binary := []byte("new binary")
+	sum := sha256.Sum256(binary)
+
+	client, release := releaseServer(t, binary, hex.EncodeToString(sum[:]), true)
+
+	if release.Version() != "9.9.9" {
+		t.Errorf("Version() = %q, want 9.9.9", release.Version())
+	}
+
+	data, err := client.Download(context.Background(), release)
+	if err != nil {
+		t.Fatalf("Download() error = %v", err)
+	}
+
+	if string(data) != string(binary) {
+		t.Errorf("Download() = %q, want %q", data, binary)
+	}
+}
+
+func TestDownloadChecksumMismatch(t *testing.T) {
+	client, release := releaseServer(t, []byte("tampered"), strings.Repeat("00", sha256.Size), true)
+
+	_, err := client.Download(context.Background(), release)
+	if err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Errorf("Download() error = %v, want checksum mismatch", err)
+	}
+}
+
+func TestDownloadRequiresChecksums(t *testing.T) {
+	client, release := releaseServer(t, []byte("binary"), "", false)
+
+	_, err := client.Download(context.Background(), release)
+	if !errors.Is(err, ErrNoChecksums) || !strings.Contains(err.Error(), ChecksumsAsset) {
+		t.Errorf("Download() without checksums.txt error = %v, want ErrNoChecksums naming the asset", err)
+	}
+}
+
+func TestReplace(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "auto-worktree")
+	if err := os.WriteFile(path, []byte("old"), 0o755); err != nil { //nolint:gosec // test binary
+		t.Fatal(err)
+	}
+
+	if err := Replace(path, []byte("new")); err != nil {
+		t.Fatalf("Replace() error = %v", err)
+	}
+
+	got, err := os.ReadFile(path) //nolint:gosec // test path
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(got) != "new" {
+		t.Errorf("binary = %q, want %q", got, "new")
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if runtime.GOOS != "windows" && info.Mode().Perm() != 0o755 {
+		t.Errorf("mode = %v, want 0755", info.Mode().Perm())
+	}
+}
+
+func TestManagedInstall(t *testing.T) {
+	if got := ManagedInstall("/opt/homebrew/Cellar/auto-worktree/0.1.0/bin/auto-worktree"); got == "" {
+		t.Error("ManagedInstall() for a Homebrew path: expected a hint")
+	}
+
+	if got := ManagedInstall("/usr/local/bin/auto-worktree"); got != "" {
+		t.Errorf("ManagedInstall() for a plain install = %q, want empty", got)
+	}
+}
+
+func TestCachedNotice(t *testing.T) {
+	store := state.NewStore(filepath.Join(t.TempDir(), "state.db"))
+	now := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	if notice, stale := CachedNotice(store, "0.1.0", now); notice != "" || !stale {
+		t.Errorf("CachedNotice() with no cache = %q, %v; want empty, stale", notice, stale)
+	}
+
+	if err := Record(store, &Release{Tag: "v0.2.0"}, now); err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+
+	notice, stale := CachedNotice(store, "0.1.0", now.Add(time.Hour))
+	if !strings.Contains(notice, "0.2.0") || stale {
+		t.Errorf("CachedNotice() = %q, %v; want a 0.2.0 notice, fresh", notice, stale)
+	}
+
+	if notice, _ := CachedNotice(store, "0.2.0", now); notice != "" {
+		t.Errorf("CachedNotice() when up to date = %q, want empty", notice)
+	}
+
+	if _, stale := CachedNotice(store, "0.1.0", now.Add(CheckInterval+time.Minute)); !stale {
+		t.Error("CachedNotice() after CheckInterval: expected stale")
+	}
+}
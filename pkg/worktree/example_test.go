@@ -0,0 +1,32 @@
+package worktree_test
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/kaeawc/auto-worktree/pkg/worktree"
+)
+
+// Example lists a repository's worktrees with their sessions
+func Example() {
+	repo, err := worktree.Open(".")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	worktrees, err := repo.List()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	sessions := worktree.NewSessionManager()
+
+	for _, wt := range worktrees {
+		status := "no session"
+		if s, err := sessions.ForBranch(wt.Branch); err == nil && s != nil {
+			status = s.Status
+		}
+
+		fmt.Printf("%s\t%s\t%s\n", wt.Branch, wt.Path, status)
+	}
+}
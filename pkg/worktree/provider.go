@@ -0,0 +1,32 @@
+package worktree
+
+import (
+	"github.com/kaeawc/auto-worktree/internal/cmd"
+	"github.com/kaeawc/auto-worktree/internal/providers"
+)
+
+// Provider is an issue tracker or code host: GitHub, GitLab, JIRA or Linear
+type Provider = providers.Provider
+
+// Issue is an issue at a Provider
+type Issue = providers.Issue
+
+// PullRequest is a pull or merge request at a Provider
+type PullRequest = providers.PullRequest
+
+// ListIssuesOptions filter Provider.ListIssues
+type ListIssuesOptions = providers.ListIssuesOptions
+
+// CreatePullRequestOptions describe a pull request for Provider.CreatePullRequest
+type CreatePullRequestOptions = providers.CreatePullRequestOptions
+
+// Comment is a comment on an issue or pull request
+type Comment = providers.Comment
+
+// ProviderFor returns the provider configured for the repository (or
+// detected from its remote), as the CLI uses. When JIRA or Linear track issues
+// and GitHub or GitLab host the code, issue calls go to the tracker and pull
+// request calls to the code host.
+func ProviderFor(r *Repository) (Provider, error) {
+	return cmd.GetProviderForRepository(r.repo)
+}
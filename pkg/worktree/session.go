@@ -0,0 +1,98 @@
+package worktree
+
+import (
+	"errors"
+	"time"
+
+	"github.com/kaeawc/auto-worktree/internal/session"
+)
+
+// Session is a tmux session auto-worktree started for a worktree
+type Session struct {
+	Name         string
+	WorktreePath string
+	Branch       string
+	// Status is "running", "paused", "idle", "needs_attention", "failed" or "unknown"
+	Status string
+	// AITool is the AI tool the session runs, e.g. "claude"
+	AITool         string
+	CreatedAt      time.Time
+	LastAccessedAt time.Time
+}
+
+// SessionManager reads and stops the sessions auto-worktree keeps for worktrees
+type SessionManager struct {
+	mgr *session.SessionManager
+}
+
+// NewSessionManager creates a session manager for the current user
+func NewSessionManager() *SessionManager {
+	return &SessionManager{mgr: session.NewManager()}
+}
+
+// SessionName returns the name of the session auto-worktree uses for branch
+func SessionName(branch string) string {
+	return session.GenerateSessionName(branch)
+}
+
+// Available reports whether tmux is installed
+func (m *SessionManager) Available() bool {
+	return m.mgr.IsAvailable()
+}
+
+// List returns every session auto-worktree has recorded, in all repositories
+func (m *SessionManager) List() ([]Session, error) {
+	all, err := m.mgr.LoadAllSessionMetadata()
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]Session, len(all))
+	for i, metadata := range all {
+		sessions[i] = copySession(metadata)
+	}
+
+	return sessions, nil
+}
+
+// ForBranch returns the recorded session for branch, or nil if there is none
+func (m *SessionManager) ForBranch(branch string) (*Session, error) {
+	metadata, err := m.mgr.LoadSessionMetadata(SessionName(branch))
+	if errors.Is(err, session.ErrMetadataNotFound) || (err == nil && metadata == nil) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	s := copySession(metadata)
+
+	return &s, nil
+}
+
+// Running reports whether tmux has the session called name
+func (m *SessionManager) Running(name string) (bool, error) {
+	return m.mgr.HasSession(name)
+}
+
+// Kill stops the session called name and forgets it
+func (m *SessionManager) Kill(name string) error {
+	if err := m.mgr.KillSession(name); err != nil {
+		return err
+	}
+
+	return m.mgr.DeleteSessionMetadata(name)
+}
+
+func copySession(metadata *session.Metadata) Session {
+	return Session{
+		Name:           metadata.SessionName,
+		WorktreePath:   metadata.WorktreePath,
+		Branch:         metadata.BranchName,
+		Status:         string(metadata.Status),
+		AITool:         metadata.AITool,
+		CreatedAt:      metadata.CreatedAt,
+		LastAccessedAt: metadata.LastAccessedAt,
+	}
+}
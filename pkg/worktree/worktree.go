@@ -0,0 +1,211 @@
+// Package worktree is the importable Go API of auto-worktree. It lets other
+// tools, such as bots and dashboards, list, create and remove worktrees, look
+// at their sessions, and talk to the configured issue provider without
+// shelling out to the CLI.
+//
+// The types here are copies of auto-worktree's internal state rather than the
+// internal types themselves, so the internal packages can change without
+// breaking callers.
+package worktree
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/kaeawc/auto-worktree/internal/git"
+)
+
+// Repository is a git repository managed by auto-worktree
+type Repository struct {
+	repo *git.Repository
+}
+
+// Worktree is one worktree of a repository and its status
+type Worktree struct {
+	// Path is the absolute path to the worktree
+	Path string
+	// Branch is the checked-out branch, empty when HEAD is detached
+	Branch string
+	// HEAD is the checked-out commit
+	HEAD     string
+	Detached bool
+	// LastCommit is when the last commit on the branch was made
+	LastCommit time.Time
+	// Unpushed is the number of commits not on the upstream branch
+	Unpushed int
+	// Merged is set when the branch has been merged into the default branch
+	Merged bool
+	// Protected is set when the branch matches auto-worktree.protected-branches
+	Protected bool
+	// Issue is the issue or pull request the branch is for, when known
+	Issue *IssueStatus
+}
+
+// IssueStatus is the state of a worktree's issue or pull request at its provider
+type IssueStatus struct {
+	// Provider is the kind of item, e.g. "github-issue", "github-pr" or "jira"
+	Provider string
+	ID       string
+	Closed   bool
+	// Completed is set when the issue was resolved or the pull request merged
+	Completed bool
+}
+
+// CreateOptions control how Create makes a worktree
+type CreateOptions struct {
+	// Existing checks out a branch that already exists, locally or only on origin,
+	// instead of creating one
+	Existing bool
+	// Base is the branch a new branch starts from (default: auto-worktree.base-branch,
+	// else the default branch)
+	Base string
+}
+
+// Open opens the repository containing dir. From inside a linked worktree it
+// opens the main working tree, as the CLI does.
+func Open(dir string) (*Repository, error) {
+	repo, err := git.NewRepositoryFromPath(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Repository{repo: repo}, nil
+}
+
+// Root returns the repository's main working tree
+func (r *Repository) Root() string {
+	return r.repo.RootPath
+}
+
+// WorktreeBase returns the directory new worktrees are created in
+func (r *Repository) WorktreeBase() string {
+	return r.repo.WorktreeBase
+}
+
+// DefaultBranch returns the branch new branches start from
+func (r *Repository) DefaultBranch() (string, error) {
+	return r.repo.GetDefaultBranch()
+}
+
+// List returns the repository's worktrees, other than the main working tree,
+// with their merge status
+func (r *Repository) List() ([]Worktree, error) {
+	worktrees, err := r.repo.ListWorktreesWithMergeStatusExcludingMain()
+	if err != nil {
+		return nil, err
+	}
+
+	return copyWorktrees(worktrees), nil
+}
+
+// ListWithStatus is List with the status of each branch's issue or pull
+// request looked up at p (see ProviderFor)
+func (r *Repository) ListWithStatus(p Provider) ([]Worktree, error) {
+	worktrees, err := r.repo.ListWorktreesWithAllStatusExcludingMain(p)
+	if err != nil {
+		return nil, err
+	}
+
+	return copyWorktrees(worktrees), nil
+}
+
+// Create makes a worktree for branch in WorktreeBase, initializing submodules
+// and Git LFS and running the configured hooks as the CLI does
+func (r *Repository) Create(branch string, opts CreateOptions) (*Worktree, error) {
+	path := filepath.Join(r.repo.WorktreeBase, git.SanitizeBranchName(branch))
+
+	if opts.Existing {
+		tracked := false
+
+		if !r.repo.BranchExists(branch) {
+			if !r.repo.RemoteBranchExists(branch) {
+				return nil, fmt.Errorf("branch %s does not exist locally or on origin", branch)
+			}
+
+			if err := r.repo.CreateTrackingBranch(branch); err != nil {
+				return nil, err
+			}
+
+			tracked = true
+		}
+
+		if err := r.repo.CreateWorktree(path, branch); err != nil {
+			if tracked {
+				_ = r.repo.DeleteBranch(branch) //nolint:errcheck // the worktree error is the one to report
+			}
+
+			return nil, err
+		}
+	} else {
+		if r.repo.BranchExists(branch) {
+			return nil, fmt.Errorf("branch %s already exists; set CreateOptions.Existing to use it", branch)
+		}
+
+		base := opts.Base
+		if base == "" {
+			baseBranch, err := r.repo.BaseBranchFor(branch)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get base branch: %w", err)
+			}
+
+			base = baseBranch
+		}
+
+		if err := r.repo.CreateWorktreeWithNewBranch(path, branch, base); err != nil {
+			return nil, err
+		}
+	}
+
+	wt, err := r.repo.GetWorktreeForBranch(branch)
+	if err != nil {
+		return nil, err
+	}
+
+	if wt == nil {
+		return nil, fmt.Errorf("worktree for %s not found after creating it", branch)
+	}
+
+	result := copyWorktree(wt)
+
+	return &result, nil
+}
+
+// Remove deletes the worktree at path, including uncommitted changes. The
+// branch is kept.
+func (r *Repository) Remove(path string) error {
+	return r.repo.RemoveWorktree(path)
+}
+
+// Prune removes git's records of worktrees whose directories are gone
+func (r *Repository) Prune() error {
+	return r.repo.PruneWorktrees()
+}
+
+func copyWorktrees(worktrees []*git.Worktree) []Worktree {
+	result := make([]Worktree, len(worktrees))
+	for i, wt := range worktrees {
+		result[i] = copyWorktree(wt)
+	}
+
+	return result
+}
+
+func copyWorktree(wt *git.Worktree) Worktree {
+	result := Worktree{
+		Path:       wt.Path,
+		Branch:     wt.Branch,
+		HEAD:       wt.HEAD,
+		Detached:   wt.IsDetached,
+		LastCommit: wt.LastCommitTime,
+		Unpushed:   wt.UnpushedCount,
+		Merged:     wt.IsBranchMerged,
+		Protected:  wt.IsProtected,
+	}
+
+	if s := wt.IssueStatus; s != nil {
+		result.Issue = &IssueStatus{Provider: s.Provider, ID: s.ID, Closed: s.IsClosed, Completed: s.IsCompleted}
+	}
+
+	return result
+}
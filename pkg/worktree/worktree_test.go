@@ -0,0 +1,83 @@
+package worktree
+
+import (
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// newTestRepository creates a git repository with one commit on main, with
+// HOME pointed at a temporary directory so worktrees and state stay there
+func newTestRepository(t *testing.T) string {
+	t.Helper()
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+	t.Setenv("GIT_AUTHOR_NAME", "Test")
+	t.Setenv("GIT_AUTHOR_EMAIL", "test@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "Test")
+	t.Setenv("GIT_COMMITTER_EMAIL", "test@example.com")
+
+	dir := filepath.Join(t.TempDir(), "app")
+
+	for _, args := range [][]string{
+		{"init", "-b", "main", dir},
+		{"-C", dir, "commit", "--allow-empty", "-m", "Initial commit"},
+	} {
+		if out, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+
+	return dir
+}
+
+func TestRepositoryCreateListRemove(t *testing.T) {
+	repo, err := Open(newTestRepository(t))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+
+	created, err := repo.Create("feature/login", CreateOptions{Base: "main"})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	if created.Branch != "feature/login" || filepath.Dir(created.Path) != repo.WorktreeBase() {
+		t.Errorf("Create() = %+v, want feature/login under %s", created, repo.WorktreeBase())
+	}
+
+	if _, err := repo.Create("feature/login", CreateOptions{}); err == nil {
+		t.Error("Create() of an existing branch without Existing = nil error")
+	}
+
+	worktrees, err := repo.List()
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+
+	if len(worktrees) != 1 || worktrees[0].Path != created.Path {
+		t.Fatalf("List() = %+v, want only %s", worktrees, created.Path)
+	}
+
+	if err := repo.Remove(created.Path); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+
+	if worktrees, err := repo.List(); err != nil || len(worktrees) != 0 {
+		t.Errorf("List() after Remove() = %+v, %v; want none", worktrees, err)
+	}
+}
+
+func TestSessionManagerForBranchWithoutSession(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	s, err := NewSessionManager().ForBranch("feature/none")
+	if err != nil || s != nil {
+		t.Errorf("ForBranch() = %+v, %v; want nil, nil", s, err)
+	}
+}